@@ -6,12 +6,17 @@
 package assistant_deployment_api
 
 import (
+	"context"
+
 	"github.com/rapidaai/api/assistant-api/config"
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
 	"github.com/rapidaai/pkg/storages"
+	"github.com/rapidaai/pkg/types"
 
 	storage_files "github.com/rapidaai/pkg/storages/file-storage"
 	"github.com/rapidaai/protos"
@@ -23,6 +28,19 @@ type assistantDeploymentApi struct {
 	postgres          connectors.PostgresConnector
 	deploymentService internal_services.AssistantDeploymentService
 	storage           storages.Storage
+	auditService      internal_services.ControlAuditService
+}
+
+// audit records a deployment of the assistant to a channel, a new deployment
+// replaces the one before it.
+func (deploymentApi *assistantDeploymentApi) audit(ctx context.Context, auth types.SimplePrinciple, deploymentId uint64, before, after interface{}) {
+	deploymentApi.auditService.Record(ctx, auth, &internal_audit.Entry{
+		Action:       internal_audit.ActionCreate,
+		ResourceType: internal_audit.ResourceAssistantDeployment,
+		ResourceId:   deploymentId,
+		Before:       before,
+		After:        after,
+	})
 }
 
 type assistantDeploymentGrpcApi struct {
@@ -39,6 +57,20 @@ func NewAssistantDeploymentGRPCApi(config *config.AssistantConfig, logger common
 			postgres:          postgres,
 			deploymentService: internal_assistant_service.NewAssistantDeploymentService(config, logger, postgres),
 			storage:           storage_files.NewStorage(config.AssetStoreConfig, logger),
+			auditService:      internal_audit_service.NewControlAuditService(logger, postgres),
+		},
+	}
+}
+
+// NewAssistantDeploymentDryRunGRPCApi checks deployments without saving them.
+func NewAssistantDeploymentDryRunGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.AssistantDeploymentDryRunServiceServer {
+	return &assistantDeploymentGrpcApi{
+		assistantDeploymentApi{
+			cfg:      config,
+			logger:   logger,
+			postgres: postgres,
 		},
 	}
 }
@@ -30,6 +30,10 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantApiDeployment(ctx co
 			"Please check and provide valid deployment request for api.",
 		)
 	}
+	if err := deploymentApi.checkAudioOptions(deployment.GetApi().GetInputAudio(), deployment.GetApi().GetOutputAudio()); err != nil {
+		return utils.Error[assistant_api.GetAssistantApiDeploymentResponse](err, invalidAudioOptions(err))
+	}
+	before, _ := deploymentApi.deploymentService.GetAssistantApiDeployment(ctx, iAuth, deployment.GetApi().GetAssistantId())
 	wpDeployment, err := deploymentApi.deploymentService.CreateApiDeployment(ctx,
 		iAuth, deployment.GetApi().GetAssistantId(),
 		deployment.GetApi().Greeting,
@@ -47,5 +51,6 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantApiDeployment(ctx co
 			"Please provider valid service credentials to perfom invoke, read docs @ docs.rapida.ai",
 		)
 	}
+	deploymentApi.audit(ctx, iAuth, wpDeployment.Id, before, wpDeployment)
 	return utils.Success[assistant_api.GetAssistantApiDeploymentResponse](wpDeployment)
 }
@@ -30,7 +30,11 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantDebuggerDeployment(c
 			"Please check and provide valid deployment request for debugger.",
 		)
 	}
+	if err := deploymentApi.checkAudioOptions(deployment.GetDebugger().GetInputAudio(), deployment.GetDebugger().GetOutputAudio()); err != nil {
+		return utils.Error[assistant_api.GetAssistantDebuggerDeploymentResponse](err, invalidAudioOptions(err))
+	}
 
+	before, _ := deploymentApi.deploymentService.GetAssistantDebuggerDeployment(ctx, iAuth, deployment.GetDebugger().GetAssistantId())
 	wpDeployment, err := deploymentApi.deploymentService.CreateDebuggerDeployment(ctx,
 		iAuth, deployment.GetDebugger().GetAssistantId(),
 		deployment.GetDebugger().Greeting,
@@ -49,6 +53,7 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantDebuggerDeployment(c
 			"Please provider valid service credentials to perfom invoke, read docs @ docs.rapida.ai",
 		)
 	}
+	deploymentApi.audit(ctx, iAuth, wpDeployment.Id, before, wpDeployment)
 	return utils.Success[assistant_api.GetAssistantDebuggerDeploymentResponse](wpDeployment)
 
 }
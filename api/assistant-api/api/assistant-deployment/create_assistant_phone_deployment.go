@@ -32,6 +32,10 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantPhoneDeployment(ctx
 			"Please check and provide valid deployment request for phone.",
 		)
 	}
+	if err := deploymentApi.checkAudioOptions(deployment.GetPhone().GetInputAudio(), deployment.GetPhone().GetOutputAudio()); err != nil {
+		return utils.Error[assistant_api.GetAssistantPhoneDeploymentResponse](err, invalidAudioOptions(err))
+	}
+	before, _ := deploymentApi.deploymentService.GetAssistantPhoneDeployment(ctx, iAuth, deployment.GetPhone().GetAssistantId())
 	wpDeployment, err := deploymentApi.deploymentService.CreatePhoneDeployment(ctx,
 		iAuth, deployment.GetPhone().GetAssistantId(),
 		deployment.GetPhone().Greeting,
@@ -52,5 +56,6 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantPhoneDeployment(ctx
 			"Please provider valid a valid request to create assistant phone deployment.",
 		)
 	}
+	deploymentApi.audit(ctx, iAuth, wpDeployment.Id, before, wpDeployment)
 	return utils.Success[assistant_api.GetAssistantPhoneDeploymentResponse](wpDeployment)
 }
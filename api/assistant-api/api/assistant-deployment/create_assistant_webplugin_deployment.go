@@ -31,7 +31,11 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantWebpluginDeployment(
 			"Please check and provide valid deployment request for webplugin.",
 		)
 	}
+	if err := deploymentApi.checkAudioOptions(deployment.GetPlugin().GetInputAudio(), deployment.GetPlugin().GetOutputAudio()); err != nil {
+		return utils.Error[assistant_api.GetAssistantWebpluginDeploymentResponse](err, invalidAudioOptions(err))
+	}
 
+	before, _ := deploymentApi.deploymentService.GetAssistantWebpluginDeployment(ctx, iAuth, deployment.GetPlugin().GetAssistantId())
 	wpDeployment, err := deploymentApi.deploymentService.CreateWebPluginDeployment(ctx,
 		iAuth, deployment.GetPlugin().GetAssistantId(),
 		deployment.GetPlugin().GetName(),
@@ -55,5 +59,6 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantWebpluginDeployment(
 			"Please provider valid service credentials to perfom invoke, read docs @ docs.rapida.ai",
 		)
 	}
+	deploymentApi.audit(ctx, iAuth, wpDeployment.Id, before, wpDeployment)
 	return utils.Success[assistant_api.GetAssistantWebpluginDeploymentResponse](wpDeployment)
 }
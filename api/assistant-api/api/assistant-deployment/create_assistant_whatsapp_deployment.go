@@ -31,6 +31,7 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantWhatsappDeployment(c
 			"Please check and provide valid deployment request for whatsapp.",
 		)
 	}
+	before, _ := deploymentApi.deploymentService.GetAssistantWhatsappDeployment(ctx, iAuth, deployment.GetWhatsapp().GetAssistantId())
 	wpDeployment, err := deploymentApi.deploymentService.CreateWhatsappDeployment(ctx,
 		iAuth, deployment.GetWhatsapp().GetAssistantId(),
 		deployment.GetWhatsapp().Greeting,
@@ -49,5 +50,6 @@ func (deploymentApi *assistantDeploymentApi) CreateAssistantWhatsappDeployment(c
 			"Please provider valid service credentials to perfom invoke, read docs @ docs.rapida.ai",
 		)
 	}
+	deploymentApi.audit(ctx, iAuth, wpDeployment.Id, before, wpDeployment)
 	return utils.Success[assistant_api.GetAssistantWhatsappDeploymentResponse](wpDeployment)
 }
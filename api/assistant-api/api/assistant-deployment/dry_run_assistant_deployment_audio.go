@@ -0,0 +1,96 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_deployment_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	internal_transformer "github.com/rapidaai/api/assistant-api/internal/transformer"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// DryRunAssistantDeploymentAudio implements assistant_api.AssistantDeploymentDryRunServiceServer.
+// It reports the problems with the listen. and speak. options of the audio
+// of a deployment without saving it.
+func (deploymentApi *assistantDeploymentApi) DryRunAssistantDeploymentAudio(ctx context.Context, request *assistant_api.DryRunAssistantDeploymentAudioRequest) (*assistant_api.DryRunAssistantDeploymentAudioResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || iAuth.GetCurrentProjectId() == nil {
+		return utils.AuthenticateError[assistant_api.DryRunAssistantDeploymentAudioResponse]()
+	}
+	issues := audioOptionIssues(request.GetInputAudio(), request.GetOutputAudio())
+	valid := true
+	for _, issue := range issues {
+		if issue.GetSeverity() == internal_transformer.SeverityError {
+			valid = false
+		}
+	}
+	return &assistant_api.DryRunAssistantDeploymentAudioResponse{
+		Code:    200,
+		Success: true,
+		Data:    issues,
+		Valid:   valid,
+	}, nil
+}
+
+// audioOptionIssues checks the options of the input audio against the
+// schema of its speech to text provider and those of the output audio
+// against its text to speech provider. Audio without a provider is off.
+func audioOptionIssues(input, output *assistant_api.DeploymentAudioProvider) []*assistant_api.DeploymentOptionIssue {
+	var issues []*assistant_api.DeploymentOptionIssue
+	add := func(audioType string, found []internal_transformer.OptionIssue) {
+		for _, issue := range found {
+			issues = append(issues, &assistant_api.DeploymentOptionIssue{
+				AudioType: audioType,
+				Key:       issue.Key,
+				Severity:  issue.Severity,
+				Message:   issue.Message,
+			})
+		}
+	}
+	if input.GetAudioProvider() != "" {
+		add("input", internal_transformer.ValidateSpeechToTextOptions(input.GetAudioProvider(), audioOptions(input)))
+	}
+	if output.GetAudioProvider() != "" {
+		add("output", internal_transformer.ValidateTextToSpeechOptions(output.GetAudioProvider(), audioOptions(output)))
+	}
+	return issues
+}
+
+func audioOptions(audio *assistant_api.DeploymentAudioProvider) map[string]string {
+	opts := make(map[string]string, len(audio.GetAudioOptions()))
+	for _, option := range audio.GetAudioOptions() {
+		opts[option.GetKey()] = option.GetValue()
+	}
+	return opts
+}
+
+// checkAudioOptions is run before a deployment is saved. Options the
+// transformers would ignore fail the save, unknown keys are only logged.
+func (deploymentApi *assistantDeploymentApi) checkAudioOptions(input, output *assistant_api.DeploymentAudioProvider) error {
+	var problems []string
+	for _, issue := range audioOptionIssues(input, output) {
+		if issue.GetSeverity() != internal_transformer.SeverityError {
+			deploymentApi.logger.Warnf("%s audio option: %s", issue.GetAudioType(), issue.GetMessage())
+			continue
+		}
+		problems = append(problems, issue.GetMessage())
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// invalidAudioOptions is the message of a deployment rejected for its audio
+// options.
+func invalidAudioOptions(err error) string {
+	return fmt.Sprintf("Please fix the audio options of the deployment: %v.", err)
+}
@@ -6,13 +6,18 @@
 package assistant_api
 
 import (
+	"context"
+
 	"github.com/rapidaai/api/assistant-api/config"
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
 	internal_knowledge_service "github.com/rapidaai/api/assistant-api/internal/services/knowledge"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
 	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/protos"
 )
 
@@ -30,6 +35,20 @@ type assistantApi struct {
 	assistantAnalysisService  internal_services.AssistantAnalysisService
 	assistantToolService      internal_services.AssistantToolService
 	assistantKnowledgeService internal_services.AssistantKnowledgeService
+	auditService              internal_services.ControlAuditService
+}
+
+// audit records a change to the assistant's configuration in the control
+// audit log. A failure is logged by the audit service, the change itself has
+// already been made.
+func (assistantApi *assistantApi) audit(ctx context.Context, auth types.SimplePrinciple, action, resourceType string, resourceId uint64, before, after interface{}) {
+	assistantApi.auditService.Record(ctx, auth, &internal_audit.Entry{
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		Before:       before,
+		After:        after,
+	})
 }
 
 type assistantGrpcApi struct {
@@ -57,11 +76,12 @@ func NewAssistantGRPCApi(config *config.AssistantConfig, logger commons.Logger,
 			vectordb:                  vectordb,
 			assistantService:          internal_assistant_service.NewAssistantService(config, logger, postgres, opensearch),
 			knowledgeDocumentService:  knowledgeDocSvc,
-			conversactionService:      internal_assistant_service.NewAssistantConversationService(logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+			conversactionService:      internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
 			assistantWebhookService:   internal_assistant_service.NewAssistantWebhookService(logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
 			assistantAnalysisService:  internal_assistant_service.NewAssistantAnalysisService(logger, postgres),
 			assistantToolService:      internal_assistant_service.NewAssistantToolService(logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
 			assistantKnowledgeService: internal_assistant_service.NewAssistantKnowledgeService(logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+			auditService:              internal_audit_service.NewControlAuditService(logger, postgres),
 		},
 	}
 }
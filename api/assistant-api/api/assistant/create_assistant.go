@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/types"
 	type_enums "github.com/rapidaai/pkg/types/enums"
 	"github.com/rapidaai/pkg/utils"
@@ -173,6 +174,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistant(ctx context.Context, cer *
 			"Unable to create assistant tags, please try again.",
 		)
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistant, assistant.Id, nil, assistant)
 
 	out := &assistant_api.Assistant{}
 	err = utils.Cast(assistant, out)
@@ -8,6 +8,7 @@ package assistant_api
 import (
 	"context"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/exceptions"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -25,6 +26,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantAnalysis(ctx context.Contex
 	if err != nil {
 		return exceptions.BadRequestError[assistant_api.GetAssistantAnalysisResponse]("Unable to create assistant analysis.")
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantAnalysis, wl.Id, nil, wl)
 	aAnalysis := &assistant_api.AssistantAnalysis{}
 	err = utils.Cast(wl, aAnalysis)
 	if err != nil {
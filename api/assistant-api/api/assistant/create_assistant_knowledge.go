@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	"github.com/rapidaai/pkg/exceptions"
 	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
@@ -44,6 +45,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantKnowledge(ctx context.Conte
 		return exceptions.BadRequestError[assistant_api.GetAssistantKnowledgeResponse](err.Error())
 	}
 
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantKnowledge, aK.Id, nil, aK)
 	out := &assistant_api.AssistantKnowledge{}
 	err = utils.Cast(aK, out)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -56,6 +57,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantProvider(ctx context.Contex
 				"Unable to create assistant provider model, please check the argument and try again.",
 			)
 		}
+		assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantProvider, providerModel.Id, nil, providerModel)
 		aProviderModel := &assistant_api.AssistantProviderModel{}
 		err = utils.Cast(providerModel, aProviderModel)
 		if err != nil {
@@ -84,6 +86,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantProvider(ctx context.Contex
 				"Unable to create assistant provider model, please check the argument and try again.",
 			)
 		}
+		assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantProvider, agentKitProvider.Id, nil, agentKitProvider)
 		aProviderModel := &assistant_api.AssistantProviderAgentkit{}
 		err = utils.Cast(agentKitProvider, aProviderModel)
 		if err != nil {
@@ -112,6 +115,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantProvider(ctx context.Contex
 				"Unable to create assistant provider model, please check the argument and try again.",
 			)
 		}
+		assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantProvider, websocketProvider.Id, nil, websocketProvider)
 		aProviderModel := &assistant_api.AssistantProviderWebsocket{}
 		err = utils.Cast(websocketProvider, aProviderModel)
 		if err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -25,6 +26,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantTag(ctx context.Context, eR
 			"Please provider valid service credentials to create assistant tag, read docs @ docs.rapida.ai",
 		)
 	}
+	before, _ := assistantApi.assistantService.Get(ctx, iAuth, eRequest.GetAssistantId(), nil, internal_services.NewDefaultGetAssistantOption())
 	_, err := assistantApi.assistantService.CreateOrUpdateAssistantTag(ctx, iAuth, eRequest.GetAssistantId(), eRequest.GetTags())
 	if err != nil {
 		return utils.Error[assistant_api.GetAssistantResponse](
@@ -44,6 +46,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantTag(ctx context.Context, eR
 			"Unable to create tags for assistant, please try again in sometime.",
 		)
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistantTag, assistant.Id, before, assistant)
 	out := &assistant_api.Assistant{}
 	err = utils.Cast(assistant, out)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	"github.com/rapidaai/pkg/exceptions"
 	"github.com/rapidaai/pkg/types"
@@ -43,6 +44,7 @@ func (assistantApi *assistantGrpcApi) CreateAssistantTool(ctx context.Context, a
 		return exceptions.BadRequestError[assistant_api.GetAssistantToolResponse](err.Error())
 	}
 
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantTool, aT.Id, nil, aT)
 	out := &assistant_api.AssistantTool{}
 	err = utils.Cast(aT, out)
 	if err != nil {
@@ -8,6 +8,7 @@ package assistant_api
 import (
 	"context"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/exceptions"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -34,10 +35,12 @@ func (assistantApi *assistantGrpcApi) CreateAssistantWebhook(ctx context.Context
 		cawr.GetRetryStatusCodes(),
 		cawr.GetMaxRetryCount(),
 		cawr.GetExecutionPriority(),
-		&cawr.Description)
+		&cawr.Description,
+		cawr.GetSigningSecret())
 	if err != nil {
 		return exceptions.BadRequestError[assistant_api.GetAssistantWebhookResponse]("Unable to create assistant webhook.")
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionCreate, internal_audit.ResourceAssistantWebhook, wl.Id, nil, wl)
 	aWebhook := &assistant_api.AssistantWebhook{}
 	err = utils.Cast(wl, aWebhook)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
 	assistant_api "github.com/rapidaai/protos"
@@ -32,6 +33,7 @@ func (assistantApi *assistantGrpcApi) DeleteAssistant(ctx context.Context, cer *
 			"Unable to update assistant, please try again in sometime",
 		)
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionDelete, internal_audit.ResourceAssistant, cer.GetId(), assistant, nil)
 	out := &assistant_api.Assistant{}
 	err = utils.Cast(assistant, out)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
 	assistant_api "github.com/rapidaai/protos"
@@ -32,6 +33,7 @@ func (assistantApi *assistantGrpcApi) DeleteAssistantAnalysis(ctx context.Contex
 			"Unable to update assistant analysis, please try again in sometime",
 		)
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionDelete, internal_audit.ResourceAssistantAnalysis, cer.GetId(), analysis, nil)
 	out := &assistant_api.AssistantAnalysis{}
 	err = utils.Cast(analysis, out)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
 	assistant_api "github.com/rapidaai/protos"
@@ -33,6 +34,7 @@ func (assistantApi *assistantGrpcApi) DeleteAssistantTool(ctx context.Context, c
 			"Unable to update assistant analysis, please try again in sometime",
 		)
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionDelete, internal_audit.ResourceAssistantTool, cer.GetId(), analysis, nil)
 	out := &assistant_api.AssistantTool{}
 	err = utils.Cast(analysis, out)
 	if err != nil {
@@ -8,6 +8,7 @@ package assistant_api
 import (
 	"context"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/exceptions"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -20,6 +21,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantAnalysis(ctx context.Contex
 		assistantApi.logger.Errorf("unauthenticated request for UpdateAssistantAnalysis")
 		return exceptions.AuthenticationError[protos.GetAssistantAnalysisResponse]()
 	}
+	before, _ := assistantApi.assistantAnalysisService.Get(ctx, iAuth, cawr.GetId(), cawr.GetAssistantId())
 	wl, err := assistantApi.assistantAnalysisService.Update(
 		ctx,
 		iAuth,
@@ -34,6 +36,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantAnalysis(ctx context.Contex
 	if err != nil {
 		return exceptions.BadRequestError[protos.GetAssistantAnalysisResponse]("Unable to create assistant webhook.")
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistantAnalysis, cawr.GetId(), before, wl)
 	aAnalysis := &protos.AssistantAnalysis{}
 	err = utils.Cast(wl, aAnalysis)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -25,6 +26,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantDetail(ctx context.Context,
 		)
 	}
 
+	before, _ := assistantApi.assistantService.Get(ctx, iAuth, cer.GetAssistantId(), nil, internal_services.NewDefaultGetAssistantOption())
 	_, err := assistantApi.assistantService.UpdateAssistantDetail(ctx,
 		iAuth,
 		cer.GetAssistantId(), cer.GetName(), cer.GetDescription())
@@ -42,6 +44,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantDetail(ctx context.Context,
 		)
 	}
 
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistant, assistant.Id, before, assistant)
 	out := &protos.Assistant{}
 	err = utils.Cast(assistant, out)
 	if err != nil {
@@ -8,6 +8,7 @@ package assistant_api
 import (
 	"context"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/exceptions"
 	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
 	"github.com/rapidaai/pkg/types"
@@ -21,6 +22,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantKnowledge(ctx context.Conte
 		assistantApi.logger.Errorf("unauthenticated request for UpdateAssistantKnowledge")
 		return exceptions.AuthenticationError[assistant_api.GetAssistantKnowledgeResponse]()
 	}
+	before, _ := assistantApi.assistantKnowledgeService.Get(ctx, iAuth, cawr.GetId(), cawr.GetAssistantId())
 	wl, err := assistantApi.assistantKnowledgeService.Update(
 		ctx,
 		iAuth,
@@ -37,6 +39,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantKnowledge(ctx context.Conte
 	if err != nil {
 		return exceptions.BadRequestError[assistant_api.GetAssistantKnowledgeResponse](err.Error())
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistantKnowledge, cawr.GetId(), before, wl)
 	aAnalysis := &assistant_api.AssistantKnowledge{}
 	err = utils.Cast(wl, aAnalysis)
 	if err != nil {
@@ -8,6 +8,7 @@ package assistant_api
 import (
 	"context"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/exceptions"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -21,6 +22,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantTool(ctx context.Context, c
 		return exceptions.AuthenticationError[protos.GetAssistantToolResponse]()
 	}
 
+	before, _ := assistantApi.assistantToolService.Get(ctx, iAuth, cawr.GetId(), cawr.GetAssistantId())
 	wl, err := assistantApi.assistantToolService.Update(
 		ctx,
 		iAuth,
@@ -34,6 +36,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantTool(ctx context.Context, c
 	if err != nil {
 		return exceptions.BadRequestError[protos.GetAssistantToolResponse](err.Error())
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistantTool, cawr.GetId(), before, wl)
 	aAnalysis := &protos.AssistantTool{}
 	err = utils.Cast(wl, aAnalysis)
 	if err != nil {
@@ -9,6 +9,8 @@ import (
 	"context"
 	"errors"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	"github.com/rapidaai/pkg/types"
 	enums "github.com/rapidaai/pkg/types/enums"
 	"github.com/rapidaai/pkg/utils"
@@ -25,6 +27,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantVersion(ctx context.Context
 		)
 	}
 
+	before, _ := assistantApi.assistantService.Get(ctx, iAuth, cer.GetAssistantId(), nil, internal_services.NewDefaultGetAssistantOption())
 	ep, err := assistantApi.assistantService.UpdateAssistantVersion(
 		ctx,
 		iAuth,
@@ -37,6 +40,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantVersion(ctx context.Context
 			"Unable to update assistant for given assistant id.",
 		)
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistantVersion, cer.GetAssistantId(), before, ep)
 	out := &protos.Assistant{}
 	err = utils.Cast(ep, out)
 	if err != nil {
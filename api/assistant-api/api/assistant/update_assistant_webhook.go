@@ -8,6 +8,7 @@ package assistant_api
 import (
 	"context"
 
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
 	"github.com/rapidaai/pkg/exceptions"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
@@ -20,6 +21,7 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantWebhook(ctx context.Context
 		assistantApi.logger.Errorf("unauthenticated request for invoke")
 		return exceptions.AuthenticationError[protos.GetAssistantWebhookResponse]()
 	}
+	before, _ := assistantApi.assistantWebhookService.Get(ctx, iAuth, cawr.GetId(), cawr.GetAssistantId())
 	wl, err := assistantApi.assistantWebhookService.Update(
 		ctx,
 		iAuth,
@@ -34,10 +36,12 @@ func (assistantApi *assistantGrpcApi) UpdateAssistantWebhook(ctx context.Context
 		cawr.GetRetryStatusCodes(),
 		cawr.GetMaxRetryCount(),
 		cawr.GetExecutionPriority(),
-		&cawr.Description)
+		&cawr.Description,
+		cawr.GetSigningSecret())
 	if err != nil {
 		return exceptions.BadRequestError[protos.GetAssistantWebhookResponse]("Unable to create assistant webhook.")
 	}
+	assistantApi.audit(ctx, iAuth, internal_audit.ActionUpdate, internal_audit.ResourceAssistantWebhook, cawr.GetId(), before, wl)
 	aWebhook := &protos.AssistantWebhook{}
 	err = utils.Cast(wl, aWebhook)
 	if err != nil {
@@ -0,0 +1,72 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_audit_api
+
+import (
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_audit_entity "github.com/rapidaai/api/assistant-api/internal/entity/audits"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type controlAuditApi struct {
+	cfg          *config.AssistantConfig
+	logger       commons.Logger
+	postgres     connectors.PostgresConnector
+	auditService internal_services.ControlAuditService
+}
+
+type controlAuditGrpcApi struct {
+	controlAuditApi
+}
+
+func NewControlAuditGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.ControlAuditServiceServer {
+	return &controlAuditGrpcApi{
+		controlAuditApi{
+			cfg:          config,
+			logger:       logger,
+			postgres:     postgres,
+			auditService: internal_audit_service.NewControlAuditService(logger, postgres),
+		},
+	}
+}
+
+// toControlAuditLog converts by hand, the snapshots are free-form JSON that
+// utils.Cast can not put into a Struct. Responses carrying them are built
+// directly for the same reason.
+func toControlAuditLog(log *internal_audit_entity.ControlAuditLog) *protos.ControlAuditLog {
+	out := &protos.ControlAuditLog{
+		Id:             log.Id,
+		ProjectId:      log.ProjectId,
+		OrganizationId: log.OrganizationId,
+		ActorId:        log.ActorId,
+		ActorType:      log.ActorType,
+		Action:         log.Action,
+		ResourceType:   log.ResourceType,
+		ResourceId:     log.ResourceId,
+		Reason:         log.Reason,
+		CreatedDate:    timestamppb.New(time.Time(log.CreatedDate)),
+	}
+	if log.Before != nil {
+		out.Before = utils.MapToStruct(log.Before)
+	}
+	if log.After != nil {
+		out.After = utils.MapToStruct(log.After)
+	}
+	if log.Changes != nil {
+		out.Changes = utils.MapToStruct(log.Changes)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_audit_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// CreateControlAuditLog implements assistant_api.ControlAuditServiceServer.
+// Other services record their own administrative actions with it, e.g. web-api
+// for credentials.
+func (auditApi *controlAuditGrpcApi) CreateControlAuditLog(ctx context.Context, req *assistant_api.CreateControlAuditLogRequest) (*assistant_api.CreateControlAuditLogResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		auditApi.logger.Errorf("unauthenticated request for CreateControlAuditLog")
+		return utils.Error[assistant_api.CreateControlAuditLogResponse](
+			errors.New("unauthenticated request for control audit log"),
+			"Please provider valid service credentials to record an action, read docs @ docs.rapida.ai",
+		)
+	}
+	if req.GetAction() == "" || req.GetResourceType() == "" {
+		return utils.Error[assistant_api.CreateControlAuditLogResponse](
+			errors.New("action and resource type are required"),
+			"Please provide the action and the resource type it was taken on.",
+		)
+	}
+
+	entry := &internal_audit.Entry{
+		Action:       req.GetAction(),
+		ResourceType: req.GetResourceType(),
+		ResourceId:   req.GetResourceId(),
+		Reason:       req.GetReason(),
+	}
+	if req.GetBefore() != nil {
+		entry.Before = req.GetBefore().AsMap()
+	}
+	if req.GetAfter() != nil {
+		entry.After = req.GetAfter().AsMap()
+	}
+	log, err := auditApi.auditService.Record(ctx, iAuth, entry)
+	if err != nil {
+		return utils.Error[assistant_api.CreateControlAuditLogResponse](
+			err,
+			"Unable to record the action, please try again.",
+		)
+	}
+	return &assistant_api.CreateControlAuditLogResponse{
+		Code:    200,
+		Success: true,
+		Data:    toControlAuditLog(log),
+	}, nil
+}
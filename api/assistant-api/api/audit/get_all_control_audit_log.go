@@ -0,0 +1,51 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_audit_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// GetAllControlAuditLog implements assistant_api.ControlAuditServiceServer.
+func (auditApi *controlAuditGrpcApi) GetAllControlAuditLog(ctx context.Context, req *assistant_api.GetAllControlAuditLogRequest) (*assistant_api.GetAllControlAuditLogResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		auditApi.logger.Errorf("unauthenticated request for GetAllControlAuditLog")
+		return utils.Error[assistant_api.GetAllControlAuditLogResponse](
+			errors.New("unauthenticated request for control audit log"),
+			"Please provider valid service credentials to get the audit log, read docs @ docs.rapida.ai",
+		)
+	}
+
+	cnt, logs, err := auditApi.auditService.GetAll(ctx, iAuth, req.GetCriterias(), req.GetPaginate())
+	if err != nil {
+		return utils.Error[assistant_api.GetAllControlAuditLogResponse](
+			err,
+			"Unable to get the audit log, please check the filters and try again.",
+		)
+	}
+
+	out := make([]*assistant_api.ControlAuditLog, 0, len(logs))
+	for _, log := range logs {
+		out = append(out, toControlAuditLog(log))
+	}
+	// built directly, a JSON round trip through utils.PaginatedSuccess drops
+	// the snapshots
+	return &assistant_api.GetAllControlAuditLogResponse{
+		Code:    200,
+		Success: true,
+		Data:    out,
+		Paginated: &assistant_api.Paginated{
+			TotalItem:   uint32(cnt),
+			CurrentPage: req.GetPaginate().GetPage(),
+		},
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_campaign_api
+
+import (
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_campaign "github.com/rapidaai/api/assistant-api/internal/campaign"
+	internal_campaign_entity "github.com/rapidaai/api/assistant-api/internal/entity/campaigns"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_campaign_service "github.com/rapidaai/api/assistant-api/internal/services/campaign"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type campaignApi struct {
+	cfg             *config.AssistantConfig
+	logger          commons.Logger
+	postgres        connectors.PostgresConnector
+	campaignService internal_services.CampaignService
+}
+
+type campaignGrpcApi struct {
+	campaignApi
+}
+
+func NewCampaignGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.CampaignServiceServer {
+	return &campaignGrpcApi{
+		campaignApi{
+			cfg:             config,
+			logger:          logger,
+			postgres:        postgres,
+			campaignService: internal_campaign_service.NewCampaignService(logger, postgres),
+		},
+	}
+}
+
+// toCampaign converts by hand, the call arguments are free-form values that
+// only survive as Any.
+func toCampaign(campaign *internal_campaign_entity.AssistantCampaign) *protos.Campaign {
+	out := &protos.Campaign{
+		Id:                 campaign.Id,
+		ProjectId:          campaign.ProjectId,
+		OrganizationId:     campaign.OrganizationId,
+		AssistantId:        campaign.AssistantId,
+		AssistantVersion:   campaign.AssistantVersion,
+		Name:               campaign.Name,
+		Status:             campaign.Status,
+		FromNumber:         campaign.FromNumber,
+		Timezone:           campaign.Timezone,
+		CallingHoursStart:  campaign.CallingHoursStart,
+		CallingHoursEnd:    campaign.CallingHoursEnd,
+		MaxConcurrentCalls: campaign.MaxConcurrentCalls,
+		MaxAttempts:        campaign.MaxAttempts,
+		RetryDelaySeconds:  campaign.RetryDelaySeconds,
+		PendingContacts:    campaign.Contacts[internal_campaign.ContactPending],
+		DialingContacts:    campaign.Contacts[internal_campaign.ContactDialing],
+		CompletedContacts:  campaign.Contacts[internal_campaign.ContactCompleted],
+		FailedContacts:     campaign.Contacts[internal_campaign.ContactFailed],
+		CreatedDate:        timestamppb.New(time.Time(campaign.CreatedDate)),
+		UpdatedDate:        timestamppb.New(time.Time(campaign.UpdatedDate)),
+	}
+	for _, n := range campaign.Contacts {
+		out.TotalContacts += n
+	}
+	out.Args, _ = utils.InterfaceMapToAnyMap(campaign.Args)
+	out.Options, _ = utils.InterfaceMapToAnyMap(campaign.Options)
+	out.Metadata, _ = utils.InterfaceMapToAnyMap(campaign.Metadata)
+	return out
+}
+
+func toCampaignContact(contact *internal_campaign_entity.AssistantCampaignContact) *protos.CampaignContact {
+	out := &protos.CampaignContact{
+		Id:             contact.Id,
+		CampaignId:     contact.AssistantCampaignId,
+		ToNumber:       contact.ToNumber,
+		Status:         contact.Status,
+		Attempts:       contact.Attempts,
+		ConversationId: contact.AssistantConversationId,
+		Outcome:        contact.Outcome,
+		LastError:      contact.LastError,
+		CreatedDate:    timestamppb.New(time.Time(contact.CreatedDate)),
+		UpdatedDate:    timestamppb.New(time.Time(contact.UpdatedDate)),
+	}
+	if contact.NextAttemptDate != nil {
+		out.NextAttemptDate = timestamppb.New(*contact.NextAttemptDate)
+	}
+	out.Args, _ = utils.InterfaceMapToAnyMap(contact.Args)
+	return out
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_campaign_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	internal_campaign "github.com/rapidaai/api/assistant-api/internal/campaign"
+	internal_campaign_entity "github.com/rapidaai/api/assistant-api/internal/entity/campaigns"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// CreateCampaign implements assistant_api.CampaignServiceServer.
+func (campaignApi *campaignGrpcApi) CreateCampaign(ctx context.Context, req *assistant_api.CreateCampaignRequest) (*assistant_api.GetCampaignResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		campaignApi.logger.Errorf("unauthenticated request for CreateCampaign")
+		return utils.Error[assistant_api.GetCampaignResponse](
+			errors.New("unauthenticated request for create campaign"),
+			"Please provider valid service credentials to create a campaign, read docs @ docs.rapida.ai",
+		)
+	}
+
+	campaign, contacts, err := campaignFromRequest(req)
+	if err != nil {
+		return utils.Error[assistant_api.GetCampaignResponse](err, fmt.Sprintf("Unable to create the campaign, %s.", err))
+	}
+	campaign, err = campaignApi.campaignService.Create(ctx, iAuth, campaign, contacts)
+	if err != nil {
+		return utils.Error[assistant_api.GetCampaignResponse](
+			err,
+			"Unable to create the campaign, please try again.",
+		)
+	}
+	return &assistant_api.GetCampaignResponse{Code: 200, Success: true, Data: toCampaign(campaign)}, nil
+}
+
+// campaignFromRequest validates the request, filling in the defaults of the
+// settings left out.
+func campaignFromRequest(req *assistant_api.CreateCampaignRequest) (*internal_campaign_entity.AssistantCampaign, []*internal_campaign_entity.AssistantCampaignContact, error) {
+	if req.GetAssistant().GetAssistantId() == 0 {
+		return nil, nil, errors.New("assistant is required")
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, nil, errors.New("name is required")
+	}
+	if _, err := internal_campaign.ParseCallingWindow(req.GetTimezone(), req.GetCallingHoursStart(), req.GetCallingHoursEnd()); err != nil {
+		return nil, nil, err
+	}
+
+	campaign := &internal_campaign_entity.AssistantCampaign{
+		AssistantId:        req.GetAssistant().GetAssistantId(),
+		AssistantVersion:   req.GetAssistant().GetVersion(),
+		Name:               name,
+		FromNumber:         strings.TrimSpace(req.GetFromNumber()),
+		Timezone:           strings.TrimSpace(req.GetTimezone()),
+		CallingHoursStart:  strings.TrimSpace(req.GetCallingHoursStart()),
+		CallingHoursEnd:    strings.TrimSpace(req.GetCallingHoursEnd()),
+		MaxConcurrentCalls: req.GetMaxConcurrentCalls(),
+		MaxAttempts:        req.GetMaxAttempts(),
+		RetryDelaySeconds:  req.GetRetryDelaySeconds(),
+	}
+	if campaign.Timezone == "" {
+		campaign.Timezone = "UTC"
+	}
+	switch {
+	case campaign.MaxConcurrentCalls == 0:
+		campaign.MaxConcurrentCalls = internal_campaign.DefaultMaxConcurrentCalls
+	case campaign.MaxConcurrentCalls > internal_campaign.MaxConcurrentCalls:
+		return nil, nil, fmt.Errorf("max concurrent calls is at most %d", internal_campaign.MaxConcurrentCalls)
+	}
+	switch {
+	case campaign.MaxAttempts == 0:
+		campaign.MaxAttempts = internal_campaign.DefaultMaxAttempts
+	case campaign.MaxAttempts > internal_campaign.MaxAttempts:
+		return nil, nil, fmt.Errorf("max attempts is at most %d", internal_campaign.MaxAttempts)
+	}
+	switch delay := time.Duration(campaign.RetryDelaySeconds) * time.Second; {
+	case delay == 0:
+		campaign.RetryDelaySeconds = uint32(internal_campaign.DefaultRetryDelay / time.Second)
+	case delay < internal_campaign.MinRetryDelay:
+		return nil, nil, fmt.Errorf("retry delay is at least %s", internal_campaign.MinRetryDelay)
+	}
+
+	var err error
+	if campaign.Args, err = utils.AnyMapToInterfaceMap(req.GetArgs()); err != nil {
+		return nil, nil, fmt.Errorf("args are invalid")
+	}
+	if campaign.Options, err = utils.AnyMapToInterfaceMap(req.GetOptions()); err != nil {
+		return nil, nil, fmt.Errorf("options are invalid")
+	}
+	if campaign.Metadata, err = utils.AnyMapToInterfaceMap(req.GetMetadata()); err != nil {
+		return nil, nil, fmt.Errorf("metadata is invalid")
+	}
+
+	if len(req.GetContacts()) == 0 {
+		return nil, nil, errors.New("at least one contact is required")
+	}
+	if len(req.GetContacts()) > internal_campaign.MaxContacts {
+		return nil, nil, fmt.Errorf("a campaign has at most %d contacts", internal_campaign.MaxContacts)
+	}
+	contacts := make([]*internal_campaign_entity.AssistantCampaignContact, 0, len(req.GetContacts()))
+	for i, c := range req.GetContacts() {
+		toNumber := strings.TrimSpace(c.GetToNumber())
+		if toNumber == "" {
+			return nil, nil, fmt.Errorf("contact %d has no phone number", i+1)
+		}
+		args, err := utils.AnyMapToInterfaceMap(c.GetArgs())
+		if err != nil {
+			return nil, nil, fmt.Errorf("args of contact %d are invalid", i+1)
+		}
+		contacts = append(contacts, &internal_campaign_entity.AssistantCampaignContact{ToNumber: toNumber, Args: args})
+	}
+	return campaign, contacts, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_campaign_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// GetCampaign implements assistant_api.CampaignServiceServer.
+func (campaignApi *campaignGrpcApi) GetCampaign(ctx context.Context, req *assistant_api.GetCampaignRequest) (*assistant_api.GetCampaignResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		campaignApi.logger.Errorf("unauthenticated request for GetCampaign")
+		return utils.Error[assistant_api.GetCampaignResponse](
+			errors.New("unauthenticated request for get campaign"),
+			"Please provider valid service credentials to get the campaign, read docs @ docs.rapida.ai",
+		)
+	}
+
+	campaign, err := campaignApi.campaignService.Get(ctx, iAuth, req.GetId())
+	if err != nil {
+		return utils.Error[assistant_api.GetCampaignResponse](
+			err,
+			"Unable to get the campaign, please check the campaign id and try again.",
+		)
+	}
+	return &assistant_api.GetCampaignResponse{Code: 200, Success: true, Data: toCampaign(campaign)}, nil
+}
+
+// GetAllCampaign implements assistant_api.CampaignServiceServer.
+func (campaignApi *campaignGrpcApi) GetAllCampaign(ctx context.Context, req *assistant_api.GetAllCampaignRequest) (*assistant_api.GetAllCampaignResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		campaignApi.logger.Errorf("unauthenticated request for GetAllCampaign")
+		return utils.Error[assistant_api.GetAllCampaignResponse](
+			errors.New("unauthenticated request for get all campaign"),
+			"Please provider valid service credentials to get the campaigns, read docs @ docs.rapida.ai",
+		)
+	}
+
+	cnt, campaigns, err := campaignApi.campaignService.GetAll(ctx, iAuth, req.GetCriterias(), req.GetPaginate())
+	if err != nil {
+		return utils.Error[assistant_api.GetAllCampaignResponse](
+			err,
+			"Unable to get the campaigns, please check the filters and try again.",
+		)
+	}
+	out := make([]*assistant_api.Campaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		out = append(out, toCampaign(campaign))
+	}
+	return &assistant_api.GetAllCampaignResponse{
+		Code:    200,
+		Success: true,
+		Data:    out,
+		Paginated: &assistant_api.Paginated{
+			TotalItem:   uint32(cnt),
+			CurrentPage: req.GetPaginate().GetPage(),
+		},
+	}, nil
+}
+
+// GetAllCampaignContact implements assistant_api.CampaignServiceServer.
+func (campaignApi *campaignGrpcApi) GetAllCampaignContact(ctx context.Context, req *assistant_api.GetAllCampaignContactRequest) (*assistant_api.GetAllCampaignContactResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		campaignApi.logger.Errorf("unauthenticated request for GetAllCampaignContact")
+		return utils.Error[assistant_api.GetAllCampaignContactResponse](
+			errors.New("unauthenticated request for get all campaign contact"),
+			"Please provider valid service credentials to get the campaign contacts, read docs @ docs.rapida.ai",
+		)
+	}
+
+	cnt, contacts, err := campaignApi.campaignService.GetAllContact(ctx, iAuth, req.GetCampaignId(), req.GetCriterias(), req.GetPaginate())
+	if err != nil {
+		return utils.Error[assistant_api.GetAllCampaignContactResponse](
+			err,
+			"Unable to get the campaign contacts, please check the filters and try again.",
+		)
+	}
+	out := make([]*assistant_api.CampaignContact, 0, len(contacts))
+	for _, contact := range contacts {
+		out = append(out, toCampaignContact(contact))
+	}
+	return &assistant_api.GetAllCampaignContactResponse{
+		Code:    200,
+		Success: true,
+		Data:    out,
+		Paginated: &assistant_api.Paginated{
+			TotalItem:   uint32(cnt),
+			CurrentPage: req.GetPaginate().GetPage(),
+		},
+	}, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_campaign_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	internal_campaign "github.com/rapidaai/api/assistant-api/internal/campaign"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// StartCampaign implements assistant_api.CampaignServiceServer. Draft and
+// paused campaigns start calling on the next scheduler run.
+func (campaignApi *campaignGrpcApi) StartCampaign(ctx context.Context, req *assistant_api.StartCampaignRequest) (*assistant_api.GetCampaignResponse, error) {
+	return campaignApi.updateStatus(ctx, "StartCampaign", req.GetId(),
+		internal_campaign.StatusRunning, internal_campaign.StatusDraft, internal_campaign.StatusPaused)
+}
+
+// PauseCampaign implements assistant_api.CampaignServiceServer. Calls in
+// progress go on, no new ones are placed.
+func (campaignApi *campaignGrpcApi) PauseCampaign(ctx context.Context, req *assistant_api.PauseCampaignRequest) (*assistant_api.GetCampaignResponse, error) {
+	return campaignApi.updateStatus(ctx, "PauseCampaign", req.GetId(),
+		internal_campaign.StatusPaused, internal_campaign.StatusRunning)
+}
+
+func (campaignApi *campaignGrpcApi) updateStatus(ctx context.Context, method string, campaignId uint64, status string, from ...string) (*assistant_api.GetCampaignResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		campaignApi.logger.Errorf("unauthenticated request for %s", method)
+		return utils.Error[assistant_api.GetCampaignResponse](
+			errors.New("unauthenticated request to update campaign"),
+			"Please provider valid service credentials to update the campaign, read docs @ docs.rapida.ai",
+		)
+	}
+
+	campaign, err := campaignApi.campaignService.UpdateStatus(ctx, iAuth, campaignId, status, from...)
+	if err != nil {
+		return utils.Error[assistant_api.GetCampaignResponse](
+			err,
+			fmt.Sprintf("Unable to update the campaign, %s.", err),
+		)
+	}
+	return &assistant_api.GetCampaignResponse{Code: 200, Success: true, Data: toCampaign(campaign)}, nil
+}
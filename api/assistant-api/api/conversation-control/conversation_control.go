@@ -0,0 +1,120 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_control_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_cluster "github.com/rapidaai/api/assistant-api/internal/cluster"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+type conversationControlApi struct {
+	cfg    *config.AssistantConfig
+	logger commons.Logger
+}
+
+type conversationControlGrpcApi struct {
+	conversationControlApi
+}
+
+func NewConversationControlGRPCApi(config *config.AssistantConfig, logger commons.Logger) protos.ConversationControlServiceServer {
+	return &conversationControlGrpcApi{
+		conversationControlApi{
+			cfg:    config,
+			logger: logger,
+		},
+	}
+}
+
+// forward calls the instance hosting a conversation not hosted by this one.
+type forward func(ctx context.Context, client protos.ConversationControlServiceClient) (*protos.ConversationControlResponse, error)
+
+// conversation resolves the conversation a request is about, by its id or
+// the id of its call at the provider.
+func (api *conversationControlApi) conversation(ctx context.Context, conversationID uint64, callID string) (uint64, error) {
+	if conversationID > 0 || callID == "" {
+		return conversationID, nil
+	}
+	registry := internal_cluster.Active()
+	if registry == nil {
+		return 0, internal_cluster.ErrNotLive
+	}
+	conversationID, err := registry.Conversation(ctx, callID)
+	if err == nil && conversationID == 0 {
+		err = internal_cluster.ErrNotLive
+	}
+	return conversationID, err
+}
+
+// direct applies a directive to a live conversation of the project of auth,
+// on this instance or through forward on the one hosting it.
+func (api *conversationControlApi) direct(ctx context.Context, auth types.SimplePrinciple, conversationID uint64, directive internal_type.DirectivePacket, forward forward) (*protos.ConversationControlResponse, error) {
+	if session, ok := internal_cluster.Lookup(conversationID); ok {
+		// conversations of other projects are as good as not live
+		if !sameProject(session.Auth(), auth) {
+			return notLive(internal_cluster.ErrNotLive)
+		}
+		if err := session.Direct(ctx, directive); err != nil {
+			return utils.Error[protos.ConversationControlResponse](
+				err,
+				"Unable to apply the directive to the conversation, please try again.",
+			)
+		}
+		return &protos.ConversationControlResponse{Code: 200, Success: true, Instance: api.instance()}, nil
+	}
+	return api.forward(ctx, auth, conversationID, forward)
+}
+
+// forward hands a request to the instance hosting the conversation.
+func (api *conversationControlApi) forward(ctx context.Context, auth types.SimplePrinciple, conversationID uint64, forward forward) (*protos.ConversationControlResponse, error) {
+	registry := internal_cluster.Active()
+	if registry == nil {
+		return notLive(internal_cluster.ErrNotLive)
+	}
+	ctx, client, err := registry.Forward(ctx, auth, conversationID)
+	if errors.Is(err, internal_cluster.ErrNotLive) {
+		return notLive(err)
+	}
+	if err != nil {
+		api.logger.Errorf("unable to reach the instance hosting conversation %d: %v", conversationID, err)
+		return utils.Error[protos.ConversationControlResponse](
+			err,
+			"Unable to reach the instance hosting the conversation, please try again.",
+		)
+	}
+	return forward(ctx, client)
+}
+
+// instance is the address of this instance, empty when it is not part of a
+// cluster.
+func (api *conversationControlApi) instance() string {
+	if registry := internal_cluster.Active(); registry != nil {
+		return registry.Instance()
+	}
+	return ""
+}
+
+func notLive(err error) (*protos.ConversationControlResponse, error) {
+	return utils.ErrorWithCode[protos.ConversationControlResponse](
+		404,
+		err,
+		"The conversation is not live, only calls still running can be controlled.",
+	)
+}
+
+func sameProject(session, auth types.SimplePrinciple) bool {
+	if session == nil || session.GetCurrentProjectId() == nil || auth.GetCurrentProjectId() == nil {
+		return false
+	}
+	return *session.GetCurrentProjectId() == *auth.GetCurrentProjectId()
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_control_api
+
+import (
+	"context"
+	"errors"
+
+	internal_cluster "github.com/rapidaai/api/assistant-api/internal/cluster"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// ForwardStatusCallback implements protos.ConversationControlServiceServer.
+// Only other instances call it, the callback was recorded by the one the
+// provider reached.
+func (api *conversationControlGrpcApi) ForwardStatusCallback(ctx context.Context, req *protos.ForwardStatusCallbackRequest) (*protos.ConversationControlResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !internal_cluster.Forwarded(ctx) {
+		api.logger.Errorf("unauthenticated request for ForwardStatusCallback")
+		return utils.Error[protos.ConversationControlResponse](
+			errors.New("unauthenticated request for status callback"),
+			"Status callbacks are only forwarded between instances.",
+		)
+	}
+	session, ok := internal_cluster.Lookup(req.GetAssistantConversationId())
+	if !ok || !sameProject(session.Auth(), iAuth) {
+		return notLive(internal_cluster.ErrNotLive)
+	}
+	session.StatusCallback(ctx, req.GetProvider(), req.GetEvent())
+	return &protos.ConversationControlResponse{Code: 200, Success: true, Instance: api.instance()}, nil
+}
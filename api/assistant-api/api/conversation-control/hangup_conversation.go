@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_control_api
+
+import (
+	"context"
+	"errors"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// HangupConversation implements protos.ConversationControlServiceServer.
+func (api *conversationControlGrpcApi) HangupConversation(ctx context.Context, req *protos.HangupConversationRequest) (*protos.ConversationControlResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		api.logger.Errorf("unauthenticated request for HangupConversation")
+		return utils.Error[protos.ConversationControlResponse](
+			errors.New("unauthenticated request for hangup"),
+			"Please provider valid service credentials to hang up the conversation, read docs @ docs.rapida.ai",
+		)
+	}
+	conversationID, err := api.conversation(ctx, req.GetAssistantConversationId(), req.GetCallId())
+	if err != nil {
+		return notLive(err)
+	}
+	reason := req.GetReason()
+	if reason == "" {
+		reason = "hung up by request"
+	}
+	return api.direct(ctx, iAuth, conversationID, internal_type.DirectivePacket{
+		Directive: protos.ConversationDirective_END_CONVERSATION,
+		Arguments: map[string]interface{}{"reason": reason},
+	}, func(ctx context.Context, client protos.ConversationControlServiceClient) (*protos.ConversationControlResponse, error) {
+		return client.HangupConversation(ctx, &protos.HangupConversationRequest{AssistantConversationId: conversationID, Reason: req.GetReason()})
+	})
+}
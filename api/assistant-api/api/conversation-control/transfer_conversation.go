@@ -0,0 +1,48 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_control_api
+
+import (
+	"context"
+	"errors"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// TransferConversation implements protos.ConversationControlServiceServer.
+func (api *conversationControlGrpcApi) TransferConversation(ctx context.Context, req *protos.TransferConversationRequest) (*protos.ConversationControlResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		api.logger.Errorf("unauthenticated request for TransferConversation")
+		return utils.Error[protos.ConversationControlResponse](
+			errors.New("unauthenticated request for transfer"),
+			"Please provider valid service credentials to transfer the conversation, read docs @ docs.rapida.ai",
+		)
+	}
+	if req.GetTo() == "" {
+		return utils.Error[protos.ConversationControlResponse](
+			errors.New("missing transfer target"),
+			"Please provide the number or SIP URI to transfer the conversation to.",
+		)
+	}
+	conversationID, err := api.conversation(ctx, req.GetAssistantConversationId(), req.GetCallId())
+	if err != nil {
+		return notLive(err)
+	}
+	arguments := map[string]interface{}{"to": req.GetTo()}
+	if req.GetWhisper() != "" {
+		arguments["whisper"] = req.GetWhisper()
+	}
+	return api.direct(ctx, iAuth, conversationID, internal_type.DirectivePacket{
+		Directive: protos.ConversationDirective_TRANSFER_CONVERSATION,
+		Arguments: arguments,
+	}, func(ctx context.Context, client protos.ConversationControlServiceClient) (*protos.ConversationControlResponse, error) {
+		return client.TransferConversation(ctx, &protos.TransferConversationRequest{AssistantConversationId: conversationID, To: req.GetTo(), Whisper: req.GetWhisper()})
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_debug_api
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+	"github.com/rapidaai/protos"
+)
+
+type conversationDebugApi struct {
+	cfg                 *config.AssistantConfig
+	logger              commons.Logger
+	postgres            connectors.PostgresConnector
+	conversationService internal_services.AssistantConversationService
+	transcriptService   internal_services.AssistantTranscriptService
+	auditService        internal_services.ControlAuditService
+}
+
+type conversationDebugGrpcApi struct {
+	conversationDebugApi
+}
+
+func NewConversationDebugGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.ConversationDebugServiceServer {
+	return &conversationDebugGrpcApi{
+		conversationDebugApi{
+			cfg:                 config,
+			logger:              logger,
+			postgres:            postgres,
+			conversationService: internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+			transcriptService:   internal_assistant_service.NewAssistantTranscriptService(config, logger, postgres),
+			auditService:        internal_audit_service.NewControlAuditService(logger, postgres),
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_debug_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_conversationdiff "github.com/rapidaai/api/assistant-api/internal/conversationdiff"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// DiffConversations implements assistant_api.ConversationDebugServiceServer.
+func (debugApi *conversationDebugGrpcApi) DiffConversations(ctx context.Context, req *assistant_api.DiffConversationsRequest) (*assistant_api.DiffConversationsResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		debugApi.logger.Errorf("unauthenticated request for DiffConversations")
+		return utils.Error[assistant_api.DiffConversationsResponse](
+			errors.New("unauthenticated request for conversation diff"),
+			"Please provider valid service credentials to compare conversations, read docs @ docs.rapida.ai",
+		)
+	}
+	if req.GetBaseConversationId() == 0 || req.GetCandidateConversationId() == 0 {
+		return utils.Error[assistant_api.DiffConversationsResponse](
+			errors.New("base and candidate conversation are required"),
+			"Please provide the base and the candidate conversation to compare.",
+		)
+	}
+
+	report, err := debugApi.transcriptService.Compare(ctx, iAuth, req.GetAssistantId(), req.GetBaseConversationId(), req.GetCandidateConversationId())
+	if err != nil {
+		return utils.Error[assistant_api.DiffConversationsResponse](
+			err,
+			"Unable to compare the conversations, please try again.",
+		)
+	}
+	artifact, err := internal_conversationdiff.Encode(report)
+	if err != nil {
+		return utils.Error[assistant_api.DiffConversationsResponse](
+			err,
+			"Unable to serialize the conversation diff, please try again.",
+		)
+	}
+	// the report carries both transcripts
+	for _, conversationId := range []uint64{req.GetBaseConversationId(), req.GetCandidateConversationId()} {
+		debugApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+			Action:       internal_audit.ActionExport,
+			ResourceType: internal_audit.ResourceConversationTranscript,
+			ResourceId:   conversationId,
+		})
+	}
+	return &assistant_api.DiffConversationsResponse{
+		Code:    200,
+		Success: true,
+		Data: &assistant_api.ConversationDiff{
+			AssistantId:             req.GetAssistantId(),
+			BaseConversationId:      req.GetBaseConversationId(),
+			CandidateConversationId: req.GetCandidateConversationId(),
+			ContentType:             internal_conversationdiff.ContentType,
+			Report:                  artifact,
+		},
+	}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_debug_api
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strconv"
+
+	"github.com/google/uuid"
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_sessionstate "github.com/rapidaai/api/assistant-api/internal/sessionstate"
+	"github.com/rapidaai/pkg/types"
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// RestoreConversation implements assistant_api.ConversationDebugServiceServer.
+// The snapshot becomes a new debugger conversation of the same assistant
+// version with the captured arguments, options and metadata. Resuming it
+// seeds the session with the rest of the captured state.
+func (debugApi *conversationDebugGrpcApi) RestoreConversation(ctx context.Context, req *assistant_api.RestoreConversationRequest) (*assistant_api.RestoreConversationResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		debugApi.logger.Errorf("unauthenticated request for RestoreConversation")
+		return utils.Error[assistant_api.RestoreConversationResponse](
+			errors.New("unauthenticated request for conversation restore"),
+			"Please provider valid service credentials to restore the conversation, read docs @ docs.rapida.ai",
+		)
+	}
+
+	state, err := internal_sessionstate.Decode(req.GetArtifact())
+	if err != nil {
+		return utils.Error[assistant_api.RestoreConversationResponse](
+			err,
+			"Unable to read the snapshot, please pass the artifact as it was returned.",
+		)
+	}
+	if state.ProjectID != *iAuth.GetCurrentProjectId() {
+		return utils.Error[assistant_api.RestoreConversationResponse](
+			errors.New("snapshot belongs to another project"),
+			"The snapshot was taken in another project and can not be restored here.",
+		)
+	}
+
+	conversation, err := debugApi.conversationService.CreateConversation(ctx, iAuth, uuid.NewString(), state.AssistantID, state.AssistantProviderModelID, type_enums.DIRECTION_INBOUND, utils.Debugger)
+	if err != nil {
+		return utils.Error[assistant_api.RestoreConversationResponse](
+			err,
+			"Unable to create the sandbox conversation, please try again.",
+		)
+	}
+
+	options := maps.Clone(state.Options)
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	options[internal_sessionstate.OptionKey] = string(req.GetArtifact())
+	metadata := maps.Clone(state.Metadata)
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata[internal_sessionstate.MetadataKeyRestoredFrom] = strconv.FormatUint(state.ConversationID, 10)
+
+	if _, err := debugApi.conversationService.ApplyConversationOption(ctx, iAuth, state.AssistantID, conversation.Id, options); err != nil {
+		return utils.Error[assistant_api.RestoreConversationResponse](
+			err,
+			"Unable to restore the conversation options, please try again.",
+		)
+	}
+	if len(state.Arguments) > 0 {
+		if _, err := debugApi.conversationService.ApplyConversationArgument(ctx, iAuth, state.AssistantID, conversation.Id, state.Arguments); err != nil {
+			debugApi.logger.Errorf("unable to restore arguments of conversation %d: %v", conversation.Id, err)
+		}
+	}
+	if _, err := debugApi.conversationService.ApplyConversationMetadata(ctx, iAuth, state.AssistantID, conversation.Id, types.NewMetadataList(metadata)); err != nil {
+		debugApi.logger.Errorf("unable to restore metadata of conversation %d: %v", conversation.Id, err)
+	}
+
+	debugApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+		Action:       internal_audit.ActionCreate,
+		ResourceType: internal_audit.ResourceConversationSnapshot,
+		ResourceId:   conversation.Id,
+		After:        map[string]interface{}{"restoredFrom": state.ConversationID, "capturedAt": state.CapturedAt},
+	})
+	return utils.Success[assistant_api.RestoreConversationResponse, *assistant_api.RestoredConversation](&assistant_api.RestoredConversation{
+		AssistantId:                state.AssistantID,
+		AssistantConversationId:    conversation.Id,
+		RestoredFromConversationId: state.ConversationID,
+	})
+}
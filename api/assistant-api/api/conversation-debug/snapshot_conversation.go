@@ -0,0 +1,67 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_debug_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_sessionstate "github.com/rapidaai/api/assistant-api/internal/sessionstate"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SnapshotConversation implements assistant_api.ConversationDebugServiceServer.
+func (debugApi *conversationDebugGrpcApi) SnapshotConversation(ctx context.Context, req *assistant_api.SnapshotConversationRequest) (*assistant_api.SnapshotConversationResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		debugApi.logger.Errorf("unauthenticated request for SnapshotConversation")
+		return utils.Error[assistant_api.SnapshotConversationResponse](
+			errors.New("unauthenticated request for conversation snapshot"),
+			"Please provider valid service credentials to snapshot the conversation, read docs @ docs.rapida.ai",
+		)
+	}
+
+	source, ok := internal_sessionstate.Lookup(req.GetAssistantConversationId())
+	var state *internal_sessionstate.State
+	if ok {
+		state = source.SessionState()
+	}
+	// conversations of other projects are as good as not live
+	if state == nil || state.ProjectID != *iAuth.GetCurrentProjectId() {
+		return utils.Error[assistant_api.SnapshotConversationResponse](
+			errors.New("conversation is not live on this instance"),
+			"The conversation is not live on this instance, snapshots can only be taken while the call runs.",
+		)
+	}
+	artifact, err := internal_sessionstate.Encode(state)
+	if err != nil {
+		return utils.Error[assistant_api.SnapshotConversationResponse](
+			err,
+			"Unable to serialize the conversation state, please try again.",
+		)
+	}
+	debugApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+		Action:       internal_audit.ActionExport,
+		ResourceType: internal_audit.ResourceConversationSnapshot,
+		ResourceId:   state.ConversationID,
+	})
+	// built directly, a JSON round trip through utils.Success does not keep
+	// the artifact bytes
+	return &assistant_api.SnapshotConversationResponse{
+		Code:    200,
+		Success: true,
+		Data: &assistant_api.ConversationSnapshot{
+			AssistantId:             state.AssistantID,
+			AssistantConversationId: state.ConversationID,
+			Artifact:                artifact,
+			CapturedAt:              timestamppb.New(state.CapturedAt),
+		},
+	}, nil
+}
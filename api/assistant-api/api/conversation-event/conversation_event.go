@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_event_api
+
+import (
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type conversationEventApi struct {
+	cfg                 *config.AssistantConfig
+	logger              commons.Logger
+	postgres            connectors.PostgresConnector
+	conversationService internal_services.AssistantConversationService
+	auditService        internal_services.ControlAuditService
+}
+
+type conversationEventGrpcApi struct {
+	conversationEventApi
+}
+
+func NewConversationEventGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.ConversationEventServiceServer {
+	return &conversationEventGrpcApi{
+		conversationEventApi{
+			cfg:                 config,
+			logger:              logger,
+			postgres:            postgres,
+			conversationService: internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+			auditService:        internal_audit_service.NewControlAuditService(logger, postgres),
+		},
+	}
+}
+
+// toConversationEvent converts by hand, the payload is free-form JSON that
+// utils.Cast can not put into a Struct.
+func toConversationEvent(event *internal_conversation_entity.AssistantConversationEvent) *protos.ConversationEvent {
+	out := &protos.ConversationEvent{
+		Sequence:    event.Sequence,
+		EventType:   event.EventType,
+		ContextId:   event.ContextId,
+		CreatedDate: timestamppb.New(time.Time(event.CreatedDate)),
+	}
+	if event.Payload != nil {
+		out.Payload = utils.MapToStruct(event.Payload)
+	}
+	return out
+}
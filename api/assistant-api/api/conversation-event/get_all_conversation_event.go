@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_event_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// maxEvents bounds the entries returned at once, the rest is read page by
+// page after the last sequence seen.
+const maxEvents = 1000
+
+// GetAllConversationEvent implements assistant_api.ConversationEventServiceServer.
+func (eventApi *conversationEventGrpcApi) GetAllConversationEvent(ctx context.Context, req *assistant_api.GetAllConversationEventRequest) (*assistant_api.GetAllConversationEventResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		eventApi.logger.Errorf("unauthenticated request for GetAllConversationEvent")
+		return utils.Error[assistant_api.GetAllConversationEventResponse](
+			errors.New("unauthenticated request for conversation events"),
+			"Please provider valid service credentials to replay the conversation, read docs @ docs.rapida.ai",
+		)
+	}
+
+	// one more than asked tells whether the log goes on
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxEvents {
+		limit = maxEvents
+	}
+	events, err := eventApi.conversationService.GetAllConversationEvent(ctx, iAuth,
+		req.GetAssistantId(),
+		req.GetAssistantConversationId(),
+		req.GetAfterSequence(),
+		req.GetEventTypes(),
+		limit+1,
+	)
+	if err != nil {
+		return utils.Error[assistant_api.GetAllConversationEventResponse](
+			err,
+			"Unable to get the events of the conversation, please try again.",
+		)
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	out := make([]*assistant_api.ConversationEvent, 0, len(events))
+	for _, event := range events {
+		out = append(out, toConversationEvent(event))
+	}
+	if req.GetAfterSequence() == 0 {
+		eventApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+			Action:       internal_audit.ActionAccess,
+			ResourceType: internal_audit.ResourceConversationEventLog,
+			ResourceId:   req.GetAssistantConversationId(),
+		})
+	}
+	// built directly, a JSON round trip through utils.Success drops the
+	// payloads
+	return &assistant_api.GetAllConversationEventResponse{
+		Code:    200,
+		Success: true,
+		Data:    out,
+		HasMore: hasMore,
+	}, nil
+}
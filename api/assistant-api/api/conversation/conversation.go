@@ -0,0 +1,55 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_api
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+	"github.com/rapidaai/protos"
+)
+
+type conversationApi struct {
+	cfg                 *config.AssistantConfig
+	logger              commons.Logger
+	postgres            connectors.PostgresConnector
+	conversationService internal_services.AssistantConversationService
+}
+
+type conversationGrpcApi struct {
+	conversationApi
+}
+
+func NewConversationGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.ConversationServiceServer {
+	return &conversationGrpcApi{
+		conversationApi{
+			cfg:                 config,
+			logger:              logger,
+			postgres:            postgres,
+			conversationService: internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+		},
+	}
+}
+
+// NewRecognitionQualityGRPCApi serves the recognition quality of the
+// conversations of assistants.
+func NewRecognitionQualityGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.RecognitionQualityServiceServer {
+	return &conversationGrpcApi{
+		conversationApi{
+			cfg:                 config,
+			logger:              logger,
+			postgres:            postgres,
+			conversationService: internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+		},
+	}
+}
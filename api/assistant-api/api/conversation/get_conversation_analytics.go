@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// GetConversationAnalytics implements assistant_api.ConversationServiceServer.
+func (conversationApi *conversationGrpcApi) GetConversationAnalytics(ctx context.Context, qry *assistant_api.GetConversationAnalyticsRequest) (*assistant_api.GetConversationAnalyticsResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		conversationApi.logger.Errorf("unauthenticated request for GetConversationAnalytics")
+		return utils.Error[assistant_api.GetConversationAnalyticsResponse](
+			errors.New("unauthenticated request for conversation analytics"),
+			"Please provider valid service credentials to get conversation analytics, read docs @ docs.rapida.ai",
+		)
+	}
+
+	analytics, err := conversationApi.conversationService.Analytics(ctx, iAuth, qry.GetFilter(), qry.GetGroupBy())
+	if err != nil {
+		return utils.Error[assistant_api.GetConversationAnalyticsResponse](
+			err,
+			"Unable to get the conversation analytics, please check the group and try again.",
+		)
+	}
+
+	out := []*assistant_api.ConversationAnalytics{}
+	err = utils.Cast(analytics, &out)
+	if err != nil {
+		conversationApi.logger.Errorf("unable to cast conversation analytics %v", err)
+	}
+	return utils.Success[assistant_api.GetConversationAnalyticsResponse, []*assistant_api.ConversationAnalytics](out)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// defaultLowConfidence is the confidence below which a segment counts as
+// poorly heard when the request gives none.
+const defaultLowConfidence = 0.8
+
+// GetRecognitionQuality implements assistant_api.RecognitionQualityServiceServer.
+func (conversationApi *conversationGrpcApi) GetRecognitionQuality(ctx context.Context, qry *assistant_api.GetRecognitionQualityRequest) (*assistant_api.GetRecognitionQualityResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		conversationApi.logger.Errorf("unauthenticated request for GetRecognitionQuality")
+		return utils.Error[assistant_api.GetRecognitionQualityResponse](
+			errors.New("unauthenticated request for recognition quality"),
+			"Please provider valid service credentials to get recognition quality, read docs @ docs.rapida.ai",
+		)
+	}
+	lowConfidence := qry.GetLowConfidence()
+	if lowConfidence <= 0 {
+		lowConfidence = defaultLowConfidence
+	}
+
+	quality, err := conversationApi.conversationService.GetRecognitionQuality(ctx, iAuth, qry.GetFilter(), lowConfidence)
+	if err != nil {
+		return utils.Error[assistant_api.GetRecognitionQualityResponse](
+			err,
+			"Unable to get the recognition quality, please try again.",
+		)
+	}
+
+	out := []*assistant_api.RecognitionQuality{}
+	err = utils.Cast(quality, &out)
+	if err != nil {
+		conversationApi.logger.Errorf("unable to cast recognition quality %v", err)
+	}
+	return utils.Success[assistant_api.GetRecognitionQualityResponse, []*assistant_api.RecognitionQuality](out)
+}
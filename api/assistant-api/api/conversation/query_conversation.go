@@ -0,0 +1,51 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_conversation_api
+
+import (
+	"context"
+	"errors"
+
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// QueryConversation implements assistant_api.ConversationServiceServer.
+// Conversations come without their related records unless the selectors ask
+// for them, a page of a bulk query stays small.
+func (conversationApi *conversationGrpcApi) QueryConversation(ctx context.Context, qry *assistant_api.QueryConversationRequest) (*assistant_api.QueryConversationResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		conversationApi.logger.Errorf("unauthenticated request for QueryConversation")
+		return utils.Error[assistant_api.QueryConversationResponse](
+			errors.New("unauthenticated request for query conversation"),
+			"Please provider valid service credentials to query conversations, read docs @ docs.rapida.ai",
+		)
+	}
+
+	cnt, conversations, err := conversationApi.conversationService.Query(ctx, iAuth,
+		qry.GetFilter(),
+		qry.GetPaginate(),
+		(&internal_services.GetConversationOption{}).WithFieldSelector(qry.GetSelectors()))
+	if err != nil {
+		return utils.Error[assistant_api.QueryConversationResponse](
+			err,
+			"Unable to query the conversations, please try again later.",
+		)
+	}
+
+	out := []*assistant_api.AssistantConversation{}
+	err = utils.Cast(conversations, &out)
+	if err != nil {
+		conversationApi.logger.Errorf("unable to cast assistant conversation %v", err)
+	}
+	return utils.PaginatedSuccess[assistant_api.QueryConversationResponse, []*assistant_api.AssistantConversation](
+		uint32(cnt),
+		qry.GetPaginate().GetPage(),
+		out)
+}
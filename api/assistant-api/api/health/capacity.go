@@ -0,0 +1,36 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package endpoint_health_api
+
+import (
+	"github.com/gin-gonic/gin"
+	internal_capacity "github.com/rapidaai/api/assistant-api/internal/capacity"
+	commons "github.com/rapidaai/pkg/commons"
+)
+
+// @Router /capacity [get]
+// @Summary Utilization of the replica for autoscalers, the busiest of its sessions, RTP ports, CPU and provider streams
+// @Produce json
+// @Success 200 {object} app.Response
+// @Failure 404 {object} app.Response
+func (hcApi *healthCheckApi) Capacity(c *gin.Context) {
+	monitor := internal_capacity.Active()
+	if monitor == nil {
+		c.JSON(404, commons.Response{
+			Code:    404,
+			Success: false,
+			Data: map[string]string{
+				"error": "capacity reporting is not configured",
+			},
+		})
+		return
+	}
+	c.JSON(200, commons.Response{
+		Code:    200,
+		Success: true,
+		Data:    monitor.Report(),
+	})
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package endpoint_health_api
+
+import (
+	"github.com/gin-gonic/gin"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+)
+
+// @Router /metrics [get]
+// @Summary Runtime metrics of the replica in the Prometheus text format
+// @Produce plain
+// @Success 200 {string} string
+func (hcApi *healthCheckApi) Metrics(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(200)
+	if _, err := internal_runtimemetrics.Default.WriteTo(c.Writer); err != nil {
+		hcApi.logger.Warnf("unable to write metrics: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ package endpoint_health_api
 
 import (
 	"github.com/gin-gonic/gin"
+	internal_drain "github.com/rapidaai/api/assistant-api/internal/drain"
 	commons "github.com/rapidaai/pkg/commons"
 )
 
@@ -14,8 +15,19 @@ import (
 // @Summary Readiness of service state of connections and other dependencies
 // @Produce json
 // @Success 200 {object} app.Response
-// @Failure 500 {object} app.Response
+// @Failure 503 {object} app.Response
 func (hcApi *healthCheckApi) Readiness(c *gin.Context) {
+	// a draining replica is taken out of rotation
+	if internal_drain.Default.Draining() {
+		c.JSON(503, commons.Response{
+			Code:    503,
+			Success: false,
+			Data: map[string]bool{
+				"draining": true,
+			},
+		})
+		return
+	}
 
 	c.JSON(200, commons.Response{
 		Code:    200,
@@ -26,3 +38,15 @@ func (hcApi *healthCheckApi) Readiness(c *gin.Context) {
 	})
 
 }
+
+// @Router /drain [get]
+// @Summary Progress of the drain of the replica, active sessions left and the deadline
+// @Produce json
+// @Success 200 {object} app.Response
+func (hcApi *healthCheckApi) Drain(c *gin.Context) {
+	c.JSON(200, commons.Response{
+		Code:    200,
+		Success: true,
+		Data:    internal_drain.Default.Progress(),
+	})
+}
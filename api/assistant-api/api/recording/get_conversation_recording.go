@@ -0,0 +1,67 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_recording_api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetConversationRecording implements assistant_api.RecordingServiceServer.
+func (recordingApi *recordingGrpcApi) GetConversationRecording(ctx context.Context, req *assistant_api.GetConversationRecordingRequest) (*assistant_api.GetConversationRecordingResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		recordingApi.logger.Errorf("unauthenticated request for GetConversationRecording")
+		return utils.Error[assistant_api.GetConversationRecordingResponse](
+			errors.New("unauthenticated request for conversation recording"),
+			"Please provider valid service credentials to get the conversation recording, read docs @ docs.rapida.ai",
+		)
+	}
+
+	recordings, err := recordingApi.recordingService.GetConversationRecordings(ctx, iAuth, req.GetAssistantConversationId())
+	if err != nil {
+		return utils.Error[assistant_api.GetConversationRecordingResponse](
+			err,
+			"Unable to get the conversation recording, please try again.",
+		)
+	}
+
+	out := make([]*assistant_api.ConversationRecording, 0, len(recordings))
+	for _, recording := range recordings {
+		rc := &assistant_api.ConversationRecording{
+			Id:                    recording.Id,
+			AssistantRecordingUrl: recording.AssistantRecordingUrl,
+			UserRecordingUrl:      recording.UserRecordingUrl,
+			StorageTier:           recording.StorageTier,
+			Restoring:             recording.Restoring,
+			Channels:              recording.Channels,
+			CreatedDate:           timestamppb.New(time.Time(recording.CreatedDate)),
+		}
+		if recording.ArchivedDate != nil {
+			rc.ArchivedDate = timestamppb.New(*recording.ArchivedDate)
+		}
+		out = append(out, rc)
+	}
+	if len(recordings) > 0 {
+		recordingApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+			Action:       internal_audit.ActionAccess,
+			ResourceType: internal_audit.ResourceConversationRecording,
+			ResourceId:   req.GetAssistantConversationId(),
+		})
+	}
+	return &assistant_api.GetConversationRecordingResponse{
+		Code:    200,
+		Success: true,
+		Data:    out,
+	}, nil
+}
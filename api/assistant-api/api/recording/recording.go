@@ -0,0 +1,43 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_recording_api
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+	"github.com/rapidaai/protos"
+)
+
+type recordingApi struct {
+	cfg              *config.AssistantConfig
+	logger           commons.Logger
+	postgres         connectors.PostgresConnector
+	recordingService internal_services.AssistantRecordingService
+	auditService     internal_services.ControlAuditService
+}
+
+type recordingGrpcApi struct {
+	recordingApi
+}
+
+func NewRecordingGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.RecordingServiceServer {
+	return &recordingGrpcApi{
+		recordingApi{
+			cfg:              config,
+			logger:           logger,
+			postgres:         postgres,
+			recordingService: internal_assistant_service.NewAssistantRecordingService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+			auditService:     internal_audit_service.NewControlAuditService(logger, postgres),
+		},
+	}
+}
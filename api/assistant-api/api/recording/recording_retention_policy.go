@@ -0,0 +1,82 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_recording_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// GetRecordingRetentionPolicy implements assistant_api.RecordingServiceServer.
+func (recordingApi *recordingGrpcApi) GetRecordingRetentionPolicy(ctx context.Context, req *assistant_api.GetRecordingRetentionPolicyRequest) (*assistant_api.GetRecordingRetentionPolicyResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		recordingApi.logger.Errorf("unauthenticated request for GetRecordingRetentionPolicy")
+		return utils.Error[assistant_api.GetRecordingRetentionPolicyResponse](
+			errors.New("unauthenticated request for recording retention policy"),
+			"Please provider valid service credentials to get the recording retention policy, read docs @ docs.rapida.ai",
+		)
+	}
+
+	policy, err := recordingApi.recordingService.GetRetentionPolicy(ctx, iAuth)
+	if err != nil {
+		return utils.Error[assistant_api.GetRecordingRetentionPolicyResponse](
+			err,
+			"Unable to get the recording retention policy, please try again.",
+		)
+	}
+
+	out := &assistant_api.RecordingRetentionPolicy{}
+	if err := utils.Cast(policy, out); err != nil {
+		recordingApi.logger.Errorf("unable to cast recording retention policy %v", err)
+	}
+	return utils.Success[assistant_api.GetRecordingRetentionPolicyResponse, *assistant_api.RecordingRetentionPolicy](out)
+}
+
+// UpdateRecordingRetentionPolicy implements assistant_api.RecordingServiceServer.
+func (recordingApi *recordingGrpcApi) UpdateRecordingRetentionPolicy(ctx context.Context, req *assistant_api.UpdateRecordingRetentionPolicyRequest) (*assistant_api.GetRecordingRetentionPolicyResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		recordingApi.logger.Errorf("unauthenticated request for UpdateRecordingRetentionPolicy")
+		return utils.Error[assistant_api.GetRecordingRetentionPolicyResponse](
+			errors.New("unauthenticated request for recording retention policy"),
+			"Please provider valid service credentials to update the recording retention policy, read docs @ docs.rapida.ai",
+		)
+	}
+
+	before, err := recordingApi.recordingService.GetRetentionPolicy(ctx, iAuth)
+	if err != nil {
+		return utils.Error[assistant_api.GetRecordingRetentionPolicyResponse](
+			err,
+			"Unable to get the recording retention policy, please try again.",
+		)
+	}
+	policy, err := recordingApi.recordingService.UpdateRetentionPolicy(ctx, iAuth, req.GetArchiveAfterDays(), req.GetDeleteAfterDays())
+	if err != nil {
+		return utils.Error[assistant_api.GetRecordingRetentionPolicyResponse](
+			err,
+			"Unable to update the recording retention policy, recordings must be archived before they are deleted.",
+		)
+	}
+	recordingApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+		Action:       internal_audit.ActionUpdate,
+		ResourceType: internal_audit.ResourceRetentionPolicy,
+		ResourceId:   policy.Id,
+		Before:       before,
+		After:        policy,
+	})
+
+	out := &assistant_api.RecordingRetentionPolicy{}
+	if err := utils.Cast(policy, out); err != nil {
+		recordingApi.logger.Errorf("unable to cast recording retention policy %v", err)
+	}
+	return utils.Success[assistant_api.GetRecordingRetentionPolicyResponse, *assistant_api.RecordingRetentionPolicy](out)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_recording_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// GetAssistantRecordingSetting implements assistant_api.RecordingServiceServer.
+func (recordingApi *recordingGrpcApi) GetAssistantRecordingSetting(ctx context.Context, req *assistant_api.GetAssistantRecordingSettingRequest) (*assistant_api.GetAssistantRecordingSettingResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		recordingApi.logger.Errorf("unauthenticated request for GetAssistantRecordingSetting")
+		return utils.Error[assistant_api.GetAssistantRecordingSettingResponse](
+			errors.New("unauthenticated request for recording setting"),
+			"Please provider valid service credentials to get the recording setting, read docs @ docs.rapida.ai",
+		)
+	}
+
+	setting, err := recordingApi.recordingService.GetAssistantSetting(ctx, iAuth, req.GetAssistantId())
+	if err != nil {
+		return utils.Error[assistant_api.GetAssistantRecordingSettingResponse](
+			err,
+			"Unable to get the recording setting of the assistant, please try again.",
+		)
+	}
+
+	out := &assistant_api.AssistantRecordingSetting{}
+	if err := utils.Cast(setting, out); err != nil {
+		recordingApi.logger.Errorf("unable to cast recording setting %v", err)
+	}
+	return utils.Success[assistant_api.GetAssistantRecordingSettingResponse, *assistant_api.AssistantRecordingSetting](out)
+}
+
+// UpdateAssistantRecordingSetting implements assistant_api.RecordingServiceServer.
+func (recordingApi *recordingGrpcApi) UpdateAssistantRecordingSetting(ctx context.Context, req *assistant_api.UpdateAssistantRecordingSettingRequest) (*assistant_api.GetAssistantRecordingSettingResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		recordingApi.logger.Errorf("unauthenticated request for UpdateAssistantRecordingSetting")
+		return utils.Error[assistant_api.GetAssistantRecordingSettingResponse](
+			errors.New("unauthenticated request for recording setting"),
+			"Please provider valid service credentials to update the recording setting, read docs @ docs.rapida.ai",
+		)
+	}
+	if req.GetAssistantId() == 0 {
+		return utils.Error[assistant_api.GetAssistantRecordingSettingResponse](
+			errors.New("assistant id is required"),
+			"Please provide the assistant to update the recording setting of.",
+		)
+	}
+
+	before, err := recordingApi.recordingService.GetAssistantSetting(ctx, iAuth, req.GetAssistantId())
+	if err != nil {
+		return utils.Error[assistant_api.GetAssistantRecordingSettingResponse](
+			err,
+			"Unable to get the recording setting of the assistant, please try again.",
+		)
+	}
+	setting, err := recordingApi.recordingService.UpdateAssistantSetting(ctx, iAuth, &internal_conversation_entity.AssistantRecordingSetting{
+		AssistantId:    req.GetAssistantId(),
+		Enabled:        req.GetEnabled(),
+		DualChannel:    req.GetDualChannel(),
+		RequireConsent: req.GetRequireConsent(),
+		RedactSpelling: req.GetRedactSpelling(),
+	})
+	if err != nil {
+		return utils.Error[assistant_api.GetAssistantRecordingSettingResponse](
+			err,
+			"Unable to update the recording setting of the assistant, please try again.",
+		)
+	}
+	recordingApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+		Action:       internal_audit.ActionUpdate,
+		ResourceType: internal_audit.ResourceRecordingSetting,
+		ResourceId:   setting.Id,
+		Before:       before,
+		After:        setting,
+	})
+
+	out := &assistant_api.AssistantRecordingSetting{}
+	if err := utils.Cast(setting, out); err != nil {
+		recordingApi.logger.Errorf("unable to cast recording setting %v", err)
+	}
+	return utils.Success[assistant_api.GetAssistantRecordingSettingResponse, *assistant_api.AssistantRecordingSetting](out)
+}
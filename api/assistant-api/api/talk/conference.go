@@ -0,0 +1,59 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_talk_api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	channel_conference "github.com/rapidaai/api/assistant-api/internal/channel/conference"
+	"github.com/rapidaai/pkg/types"
+)
+
+// JoinConference joins a human agent to a live audio conversation of the
+// project over a WebSocket, making a three-way call of the caller, the
+// assistant and the agent, see channel_conference.ServeAgent for the
+// protocol. The agent query names the agent in the conference.
+// Route: GET /v1/conference/:conversationId?agent=name
+func (cApi *ConversationApi) JoinConference(c *gin.Context) {
+	iAuth, isAuthenticated := types.GetAuthPrinciple(c)
+	if !isAuthenticated || !iAuth.HasProject() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthenticated request"})
+		return
+	}
+	conversationId, err := strconv.ParseUint(c.Param("conversationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+	agent := c.DefaultQuery("agent", string(channel_conference.RoleAgent))
+
+	session, ok := liveSession(c, iAuth, conversationId)
+	if !ok {
+		return
+	}
+	conferencing, ok := session.(channel_conference.Session)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation cannot be joined"})
+		return
+	}
+
+	upgrader := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024, CheckOrigin: func(r *http.Request) bool { return true }}
+	websocketConnection, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to upgrade connection"})
+		return
+	}
+	defer websocketConnection.Close()
+
+	if err := channel_conference.ServeAgent(c, cApi.logger, websocketConnection, conferencing, agent); err != nil {
+		cApi.logger.Warnf("agent %s could not join conversation %d: %v", agent, conversationId, err)
+		websocketConnection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()))
+	}
+}
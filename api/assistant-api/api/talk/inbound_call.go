@@ -6,6 +6,8 @@
 package assistant_talk_api
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -14,6 +16,7 @@ import (
 
 	internal_adapter "github.com/rapidaai/api/assistant-api/internal/adapters"
 	telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
 )
@@ -74,11 +77,51 @@ func (cApi *ConversationApi) CallReciever(c *gin.Context) {
 		return
 	}
 
-	if _, err := cApi.inboundDispatcher.HandleReceiveCall(c, c.Param("telephony"), iAuth, assistantId); err != nil {
+	provider := c.Param("telephony")
+	contextID, err := cApi.inboundDispatcher.HandleReceiveCall(c, provider, iAuth, assistantId)
+	if errors.Is(err, internal_type.ErrNotACall) {
+		// the provider answered the webhook already
+		return
+	}
+	if err != nil {
 		cApi.logger.Errorf("failed to handle inbound call: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to initiate talker"})
 		return
 	}
+
+	// no media connection follows for providers the call audio is read from
+	if telephony.Telephony(provider).PullsMedia() {
+		go cApi.talkPulledMedia(contextID)
+	}
+}
+
+// talkPulledMedia runs the conversation of a call whose audio the streamer
+// reads from the provider. It outlives the webhook request.
+func (cApi *ConversationApi) talkPulledMedia(contextID string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cc, vaultCred, err := cApi.inboundDispatcher.ResolveCallSessionByContext(ctx, contextID)
+	if err != nil {
+		cApi.logger.Errorf("error resolving session for context %s: %v", contextID, err)
+		return
+	}
+	// Mark call context as completed once the call has ended
+	defer cApi.inboundDispatcher.CompleteCallSession(ctx, contextID)
+
+	streamer, err := telephony.Telephony(cc.Provider).NewStreamer(cApi.logger, cc, vaultCred, telephony.StreamerOption{Ctx: ctx})
+	if err != nil {
+		cApi.logger.Errorf("error creating streamer for context %s: %v", contextID, err)
+		return
+	}
+	talker, err := internal_adapter.GetTalker(utils.PhoneCall, ctx, cApi.cfg, cApi.logger, cApi.postgres, cApi.opensearch, cApi.redis, cApi.storage, streamer)
+	if err != nil {
+		cApi.logger.Errorf("error creating talker for context %s: %v", contextID, err)
+		return
+	}
+	if err := talker.Talk(ctx, cc.ToAuth()); err != nil {
+		cApi.logger.Errorf("talker exited for context %s: %v", contextID, err)
+	}
 }
 
 // CallTalkerByContext handles WebSocket connections using a contextId stored in Postgres.
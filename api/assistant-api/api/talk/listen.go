@@ -0,0 +1,65 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_talk_api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	internal_adapter "github.com/rapidaai/api/assistant-api/internal/adapters"
+	channel_listen "github.com/rapidaai/api/assistant-api/internal/channel/listen"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// Listen exposes the assistant's listen layer as a standalone streaming
+// speech to text endpoint. Audio is transcribed with the same provider, model
+// and options as the deployment selected by the client source header
+// (defaults to the api deployment), see channel_listen for the protocol.
+// Route: GET /v1/listen/:assistantId?version=latest
+func (cApi *ConversationApi) Listen(c *gin.Context) {
+	iAuth, isAuthenticated := types.GetAuthPrinciple(c)
+	if !isAuthenticated {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthenticated request"})
+		return
+	}
+
+	assistantId, err := strconv.ParseUint(c.Param("assistantId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assistant ID"})
+		return
+	}
+
+	source := utils.SDK
+	if header := c.GetHeader(utils.HEADER_SOURCE_KEY); header != "" {
+		source = utils.FromSourceStr(header)
+	}
+
+	upgrader := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024, CheckOrigin: func(r *http.Request) bool { return true }}
+	websocketConnection, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to upgrade connection"})
+		return
+	}
+	defer websocketConnection.Close()
+
+	streamer := channel_listen.NewListenStreamer(c, cApi.logger, websocketConnection, &protos.AssistantDefinition{
+		AssistantId: assistantId,
+		Version:     c.DefaultQuery("version", "latest"),
+	})
+	listener, err := internal_adapter.GetListener(source, cApi.cfg, cApi.logger, cApi.postgres, cApi.opensearch, cApi.redis, streamer)
+	if err != nil {
+		cApi.logger.Errorf("failed to setup listener: %v", err)
+		return
+	}
+	if err := listener.Listen(c, iAuth); err != nil {
+		cApi.logger.Errorf("listener exited for assistant %d: %v", assistantId, err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_talk_api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internal_adapter "github.com/rapidaai/api/assistant-api/internal/adapters"
+	channel_livekit "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/livekit"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+type joinLiveKitRoomRequest struct {
+	CredentialId uint64                 `json:"credential_id,string" binding:"required"`
+	Room         string                 `json:"room" binding:"required"`
+	Identity     string                 `json:"identity"`
+	Name         string                 `json:"name"`
+	Version      string                 `json:"version"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	Args         map[string]interface{} `json:"args"`
+}
+
+// JoinLiveKitRoom sends the assistant into a LiveKit room as a participant.
+// The room's server url and api key pair come from a livekit vault
+// credential. The call returns once the assistant has joined; the
+// conversation runs until the last other participant leaves or the
+// assistant ends it.
+// Route: POST /v1/livekit/:assistantId
+func (cApi *ConversationApi) JoinLiveKitRoom(c *gin.Context) {
+	iAuth, isAuthenticated := types.GetAuthPrinciple(c)
+	if !isAuthenticated {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthenticated request"})
+		return
+	}
+
+	assistantId, err := strconv.ParseUint(c.Param("assistantId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assistant ID"})
+		return
+	}
+
+	var req joinLiveKitRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Identity == "" {
+		req.Identity = fmt.Sprintf("assistant-%d", assistantId)
+	}
+	if req.Version == "" {
+		req.Version = "latest"
+	}
+
+	credential, err := cApi.vaultClient.GetCredential(c, iAuth, req.CredentialId)
+	if err != nil {
+		cApi.logger.Errorf("failed to get livekit credential %d: %v", req.CredentialId, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to read livekit credential"})
+		return
+	}
+	config, err := channel_livekit.ConfigFromCredential(credential, req.Room, req.Identity, req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Metadata == nil {
+		req.Metadata = make(map[string]interface{})
+	}
+	req.Metadata["livekit.room"] = req.Room
+	req.Metadata["livekit.identity"] = req.Identity
+	metadata, err := utils.InterfaceMapToAnyMap(req.Metadata)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metadata"})
+		return
+	}
+	args, err := utils.InterfaceMapToAnyMap(req.Args)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid args"})
+		return
+	}
+
+	source := utils.SDK
+	if header := c.GetHeader(utils.HEADER_SOURCE_KEY); header != "" {
+		source = utils.FromSourceStr(header)
+	}
+
+	// the conversation outlives this request
+	ctx, cancel := context.WithCancel(context.Background())
+	streamer, err := channel_livekit.NewLiveKitStreamer(ctx, cApi.logger, config, &protos.ConversationInitialization{
+		Assistant: &protos.AssistantDefinition{
+			AssistantId: assistantId,
+			Version:     req.Version,
+		},
+		StreamMode: protos.StreamMode_STREAM_MODE_AUDIO,
+		Time:       timestamppb.Now(),
+		Metadata:   metadata,
+		Args:       args,
+	})
+	if err != nil {
+		cancel()
+		cApi.logger.Errorf("failed to join livekit room %s: %v", req.Room, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Unable to join livekit room"})
+		return
+	}
+	talker, err := internal_adapter.GetTalker(source, ctx, cApi.cfg, cApi.logger, cApi.postgres, cApi.opensearch, cApi.redis, cApi.storage, streamer)
+	if err != nil {
+		cancel()
+		cApi.logger.Errorf("failed to setup talker: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to start conversation"})
+		return
+	}
+
+	go func() {
+		defer cancel()
+		if err := talker.Talk(ctx, iAuth); err != nil {
+			cApi.logger.Errorf("livekit conversation in room %s exited: %v", req.Room, err)
+		}
+	}()
+	c.JSON(http.StatusOK, gin.H{"room": req.Room, "identity": req.Identity})
+}
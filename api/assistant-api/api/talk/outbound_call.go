@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	"github.com/rapidaai/pkg/types"
 	type_enums "github.com/rapidaai/pkg/types/enums"
@@ -109,6 +110,7 @@ func (cApi *ConversationGrpcApi) CreatePhoneCall(ctx context.Context, ir *protos
 		CalleeNumber:        toNumber,
 		FromNumber:          fromPhone,
 		Provider:            assistant.AssistantPhoneDeployment.TelephonyProvider,
+		StreamMode:          telephony.Telephony(assistant.AssistantPhoneDeployment.TelephonyProvider).StreamMode(assistant.AssistantPhoneDeployment.GetOptions()),
 		Status:              "queued",
 	}
 	if auth.GetCurrentProjectId() != nil {
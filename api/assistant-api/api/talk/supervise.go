@@ -0,0 +1,101 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_talk_api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	internal_cluster "github.com/rapidaai/api/assistant-api/internal/cluster"
+	internal_supervision "github.com/rapidaai/api/assistant-api/internal/supervision"
+	"github.com/rapidaai/pkg/types"
+)
+
+// Supervise attaches a supervisor to a live conversation of the project over
+// a WebSocket, see internal_supervision.Serve for the protocol. The mode
+// query is listen (default), whisper or barge, the audio query split
+// (default) or mixed. The conversation is supervised on the instance hosting
+// it, others answer with that instance.
+// Route: GET /v1/supervise/:conversationId?mode=listen&audio=split
+func (cApi *ConversationApi) Supervise(c *gin.Context) {
+	iAuth, isAuthenticated := types.GetAuthPrinciple(c)
+	if !isAuthenticated || !iAuth.HasProject() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthenticated request"})
+		return
+	}
+	conversationId, err := strconv.ParseUint(c.Param("conversationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+	mode, err := internal_supervision.ParseMode(c.Query("mode"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	layout, err := internal_supervision.ParseLayout(c.Query("audio"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, ok := liveSession(c, iAuth, conversationId)
+	if !ok {
+		return
+	}
+	supervised, ok := session.(internal_supervision.Session)
+	if !ok || supervised.Supervision() == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation cannot be supervised"})
+		return
+	}
+	observer, err := supervised.Supervision().Attach(mode, layout)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	upgrader := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024, CheckOrigin: func(r *http.Request) bool { return true }}
+	websocketConnection, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		observer.Detach()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to upgrade connection"})
+		return
+	}
+	defer websocketConnection.Close()
+
+	cApi.logger.Infof("supervisor attached to conversation %d in %s mode", conversationId, mode)
+	internal_supervision.Serve(c, cApi.logger, websocketConnection, supervised, observer)
+	if dropped := observer.Dropped(); dropped > 0 {
+		cApi.logger.Warnf("supervisor of conversation %d fell behind, dropped %d events", conversationId, dropped)
+	}
+}
+
+// liveSession returns the session of a live conversation of the project of
+// auth hosted by this instance, or answers the request when there is none.
+// A conversation hosted by another instance is answered with that instance.
+func liveSession(c *gin.Context, auth types.SimplePrinciple, conversationId uint64) (internal_cluster.Session, bool) {
+	session, ok := internal_cluster.Lookup(conversationId)
+	if !ok {
+		if registry := internal_cluster.Active(); registry != nil {
+			if owner, err := registry.Owner(c, conversationId); err == nil && owner != "" {
+				c.JSON(http.StatusConflict, gin.H{"error": "Conversation is hosted by another instance", "instance": owner})
+				return nil, false
+			}
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation is not live"})
+		return nil, false
+	}
+	// conversations of other projects are as good as not live
+	owner := session.Auth()
+	if owner == nil || owner.GetCurrentProjectId() == nil || *owner.GetCurrentProjectId() != *auth.GetCurrentProjectId() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation is not live"})
+		return nil, false
+	}
+	return session, true
+}
@@ -60,7 +60,7 @@ func newConversationApiCore(cfg *config.AssistantConfig, logger commons.Logger,
 	vaultClient := web_client.NewVaultClientGRPC(&cfg.AppConfig, logger, redis)
 	assistantService := internal_assistant_service.NewAssistantService(cfg, logger, postgres, opensearch)
 	fileStorage := storage_files.NewStorage(cfg.AssetStoreConfig, logger)
-	conversationService := internal_assistant_service.NewAssistantConversationService(logger, postgres, fileStorage)
+	conversationService := internal_assistant_service.NewAssistantConversationService(cfg, logger, postgres, fileStorage)
 
 	telephonyDeps := channel_telephony.TelephonyDispatcherDeps{
 		Cfg:                 cfg,
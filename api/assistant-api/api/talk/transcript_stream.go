@@ -0,0 +1,107 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_talk_api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	internal_livetranscript "github.com/rapidaai/api/assistant-api/internal/livetranscript"
+	"github.com/rapidaai/pkg/types"
+)
+
+// transcriptKeepAlive is how long the stream waits for an event before it
+// sends a comment, proxies close connections that stay quiet.
+const transcriptKeepAlive = 15 * time.Second
+
+// StreamTranscript streams the transcript of a live conversation as
+// server-sent events, the interim and final segments of the user and the
+// assistant, and an end event once the conversation ended. The events kept
+// so far are sent first. A subscriber reconnecting with Last-Event-ID, or
+// the lastEventId query of clients that cannot set it, continues after that
+// event. Session tokens only follow conversations of their assistant and
+// end user.
+// Route: GET /v1/transcript/:assistantId/:conversationId
+func (cApi *ConversationApi) StreamTranscript(c *gin.Context) {
+	iAuth, isAuthenticated := types.GetAuthPrinciple(c)
+	if !isAuthenticated || !iAuth.HasProject() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthenticated request"})
+		return
+	}
+	feed := internal_livetranscript.Active()
+	if feed == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Live transcripts are not enabled"})
+		return
+	}
+
+	assistantId, err := strconv.ParseUint(c.Param("assistantId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assistant ID"})
+		return
+	}
+	conversationId, err := strconv.ParseUint(c.Param("conversationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Query("lastEventId")
+	}
+	if lastEventId != "" && !internal_livetranscript.ValidID(lastEventId) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid last event ID"})
+		return
+	}
+
+	conversation, err := cApi.assistantConversationService.Get(c, iAuth, assistantId, conversationId, nil)
+	if err != nil || conversation == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation not found"})
+		return
+	}
+	if scope, ok := iAuth.(*types.SessionScope); ok && !scope.Permits(assistantId, conversation.Identifier) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "The session token does not permit this conversation"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		events, err := feed.Read(ctx, conversationId, lastEventId, transcriptKeepAlive)
+		if err != nil {
+			if ctx.Err() == nil {
+				cApi.logger.Errorf("unable to read the live transcript of conversation %d: %v", conversationId, err)
+			}
+			return
+		}
+		if len(events) == 0 {
+			if _, err := c.Writer.WriteString(": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+			continue
+		}
+		for _, event := range events {
+			if err := internal_livetranscript.WriteSSE(c.Writer, event); err != nil {
+				return
+			}
+			lastEventId = event.ID
+			if event.Type == internal_livetranscript.EventEnd {
+				c.Writer.Flush()
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_talk_api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	internal_adapter "github.com/rapidaai/api/assistant-api/internal/adapters"
+	channel_websocket "github.com/rapidaai/api/assistant-api/internal/channel/websocket"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// WebTalkSocket serves WebTalk over a plain WebSocket for browsers without
+// gRPC-web, see channel_websocket for the protocol. Browsers cannot set
+// headers on a WebSocket, the API key or session token and the client source
+// are taken from the query as well. The source defaults to the web plugin.
+// Route: GET /v1/webtalk?x-session-token=…
+func (cApi *ConversationApi) WebTalkSocket(c *gin.Context) {
+	iAuth, isAuthenticated := types.GetAuthPrinciple(c)
+	if !isAuthenticated {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthenticated request"})
+		return
+	}
+
+	source := utils.WebPlugin
+	if header := c.GetHeader(utils.HEADER_SOURCE_KEY); header != "" {
+		source = utils.FromSourceStr(header)
+	} else if query := c.Query(utils.HEADER_SOURCE_KEY); query != "" {
+		source = utils.FromSourceStr(query)
+	}
+
+	upgrader := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024, CheckOrigin: func(r *http.Request) bool { return true }}
+	websocketConnection, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to upgrade connection"})
+		return
+	}
+	defer websocketConnection.Close()
+
+	ctx := c.Request.Context()
+	streamer := channel_websocket.NewWebSocketStreamer(ctx, cApi.logger, websocketConnection)
+	talker, err := internal_adapter.GetTalker(
+		source,
+		ctx,
+		cApi.cfg,
+		cApi.logger,
+		cApi.postgres,
+		cApi.opensearch,
+		cApi.redis,
+		cApi.storage,
+		streamer,
+	)
+	if err != nil {
+		cApi.logger.Errorf("failed to setup talker: %v", err)
+		return
+	}
+	if err := talker.Talk(ctx, iAuth); err != nil {
+		cApi.logger.Errorf("webtalk over websocket exited: %v", err)
+	}
+}
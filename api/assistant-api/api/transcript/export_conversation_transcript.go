@@ -0,0 +1,47 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_transcript_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_transcript "github.com/rapidaai/api/assistant-api/internal/transcript"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// ExportConversationTranscript implements assistant_api.TranscriptServiceServer.
+func (transcriptApi *transcriptGrpcApi) ExportConversationTranscript(ctx context.Context, req *assistant_api.ExportConversationTranscriptRequest) (*assistant_api.ExportConversationTranscriptResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		transcriptApi.logger.Errorf("unauthenticated request for ExportConversationTranscript")
+		return utils.Error[assistant_api.ExportConversationTranscriptResponse](
+			errors.New("unauthenticated request for conversation transcript"),
+			"Please provider valid service credentials to export the transcript, read docs @ docs.rapida.ai",
+		)
+	}
+
+	content, err := transcriptApi.transcriptService.Export(ctx, iAuth, req.GetAssistantId(), req.GetAssistantConversationId())
+	if err != nil {
+		return utils.Error[assistant_api.ExportConversationTranscriptResponse](
+			err,
+			"Unable to export the conversation transcript, please try again.",
+		)
+	}
+	transcriptApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+		Action:       internal_audit.ActionExport,
+		ResourceType: internal_audit.ResourceConversationTranscript,
+		ResourceId:   req.GetAssistantConversationId(),
+	})
+	return utils.Success[assistant_api.ExportConversationTranscriptResponse, *assistant_api.ConversationTranscript](&assistant_api.ConversationTranscript{
+		AssistantConversationId: req.GetAssistantConversationId(),
+		ContentType:             internal_transcript.ContentType,
+		Content:                 content,
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_transcript_api
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_audit_service "github.com/rapidaai/api/assistant-api/internal/services/audit"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/protos"
+)
+
+type transcriptApi struct {
+	cfg               *config.AssistantConfig
+	logger            commons.Logger
+	postgres          connectors.PostgresConnector
+	transcriptService internal_services.AssistantTranscriptService
+	auditService      internal_services.ControlAuditService
+}
+
+type transcriptGrpcApi struct {
+	transcriptApi
+}
+
+func NewTranscriptGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) protos.TranscriptServiceServer {
+	return &transcriptGrpcApi{
+		transcriptApi{
+			cfg:               config,
+			logger:            logger,
+			postgres:          postgres,
+			transcriptService: internal_assistant_service.NewAssistantTranscriptService(config, logger, postgres),
+			auditService:      internal_audit_service.NewControlAuditService(logger, postgres),
+		},
+	}
+}
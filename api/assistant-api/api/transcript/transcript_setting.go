@@ -0,0 +1,82 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_transcript_api
+
+import (
+	"context"
+	"errors"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// GetTranscriptSetting implements assistant_api.TranscriptServiceServer.
+func (transcriptApi *transcriptGrpcApi) GetTranscriptSetting(ctx context.Context, req *assistant_api.GetTranscriptSettingRequest) (*assistant_api.GetTranscriptSettingResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		transcriptApi.logger.Errorf("unauthenticated request for GetTranscriptSetting")
+		return utils.Error[assistant_api.GetTranscriptSettingResponse](
+			errors.New("unauthenticated request for transcript setting"),
+			"Please provider valid service credentials to get the transcript setting, read docs @ docs.rapida.ai",
+		)
+	}
+
+	setting, err := transcriptApi.transcriptService.GetSetting(ctx, iAuth, req.GetAssistantId())
+	if err != nil {
+		return utils.Error[assistant_api.GetTranscriptSettingResponse](
+			err,
+			"Unable to get the transcript setting, please try again.",
+		)
+	}
+
+	out := &assistant_api.TranscriptSetting{}
+	if err := utils.Cast(setting, out); err != nil {
+		transcriptApi.logger.Errorf("unable to cast transcript setting %v", err)
+	}
+	return utils.Success[assistant_api.GetTranscriptSettingResponse, *assistant_api.TranscriptSetting](out)
+}
+
+// UpdateTranscriptSetting implements assistant_api.TranscriptServiceServer.
+func (transcriptApi *transcriptGrpcApi) UpdateTranscriptSetting(ctx context.Context, req *assistant_api.UpdateTranscriptSettingRequest) (*assistant_api.GetTranscriptSettingResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		transcriptApi.logger.Errorf("unauthenticated request for UpdateTranscriptSetting")
+		return utils.Error[assistant_api.GetTranscriptSettingResponse](
+			errors.New("unauthenticated request for transcript setting"),
+			"Please provider valid service credentials to update the transcript setting, read docs @ docs.rapida.ai",
+		)
+	}
+
+	before, err := transcriptApi.transcriptService.GetSetting(ctx, iAuth, req.GetAssistantId())
+	if err != nil {
+		return utils.Error[assistant_api.GetTranscriptSettingResponse](
+			err,
+			"Unable to get the transcript setting, please try again.",
+		)
+	}
+	setting, err := transcriptApi.transcriptService.UpdateSetting(ctx, iAuth, req.GetAssistantId(), req.GetAiNotice(), req.GetLegalFooter())
+	if err != nil {
+		return utils.Error[assistant_api.GetTranscriptSettingResponse](
+			err,
+			"Unable to update the transcript setting, please try again.",
+		)
+	}
+	transcriptApi.auditService.Record(ctx, iAuth, &internal_audit.Entry{
+		Action:       internal_audit.ActionUpdate,
+		ResourceType: internal_audit.ResourceTranscriptSetting,
+		ResourceId:   req.GetAssistantId(),
+		Before:       before,
+		After:        setting,
+	})
+
+	out := &assistant_api.TranscriptSetting{}
+	if err := utils.Cast(setting, out); err != nil {
+		transcriptApi.logger.Errorf("unable to cast transcript setting %v", err)
+	}
+	return utils.Success[assistant_api.GetTranscriptSettingResponse, *assistant_api.TranscriptSetting](out)
+}
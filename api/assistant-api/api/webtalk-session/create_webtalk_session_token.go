@@ -0,0 +1,62 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_webtalk_session_api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_sessiontoken "github.com/rapidaai/api/assistant-api/internal/sessiontoken"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateWebTalkSessionToken implements protos.WebTalkSessionServiceServer.
+func (api *webTalkSessionGrpcApi) CreateWebTalkSessionToken(ctx context.Context, req *protos.CreateWebTalkSessionTokenRequest) (*protos.CreateWebTalkSessionTokenResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		api.logger.Errorf("unauthenticated request for CreateWebTalkSessionToken")
+		return utils.Error[protos.CreateWebTalkSessionTokenResponse](
+			errors.New("unauthenticated request for session token"),
+			"Please provider valid service credentials to create a session token, read docs @ docs.rapida.ai",
+		)
+	}
+	if !api.tokens.Enabled() {
+		return utils.Error[protos.CreateWebTalkSessionTokenResponse](
+			internal_sessiontoken.ErrDisabled,
+			"Session tokens are not enabled on this deployment.",
+		)
+	}
+	// the assistant must belong to the project of the key
+	if _, err := api.assistantService.Get(ctx, iAuth, req.GetAssistantId(), nil, &internal_services.GetAssistantOption{}); err != nil {
+		return utils.ErrorWithCode[protos.CreateWebTalkSessionTokenResponse](
+			404,
+			err,
+			"Unable to find the assistant, please check the assistant id.",
+		)
+	}
+
+	token, expiresAt, err := api.tokens.Mint(iAuth, req.GetAssistantId(), req.GetUserId(), req.GetOrigin(), time.Duration(req.GetTtlSeconds())*time.Second)
+	if err != nil {
+		api.logger.Errorf("unable to mint a session token: %v", err)
+		return utils.Error[protos.CreateWebTalkSessionTokenResponse](
+			err,
+			"Unable to create the session token, please try again.",
+		)
+	}
+	return &protos.CreateWebTalkSessionTokenResponse{
+		Code:    200,
+		Success: true,
+		Data: &protos.WebTalkSessionToken{
+			Token:     token,
+			ExpiresAt: timestamppb.New(expiresAt),
+		},
+	}, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_webtalk_session_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// RevokeWebTalkSessionToken implements protos.WebTalkSessionServiceServer.
+func (api *webTalkSessionGrpcApi) RevokeWebTalkSessionToken(ctx context.Context, req *protos.RevokeWebTalkSessionTokenRequest) (*protos.RevokeWebTalkSessionTokenResponse, error) {
+	iAuth, isAuthenticated := types.GetSimplePrincipleGRPC(ctx)
+	if !isAuthenticated || !iAuth.HasProject() {
+		api.logger.Errorf("unauthenticated request for RevokeWebTalkSessionToken")
+		return utils.Error[protos.RevokeWebTalkSessionTokenResponse](
+			errors.New("unauthenticated request for session token"),
+			"Please provider valid service credentials to revoke a session token, read docs @ docs.rapida.ai",
+		)
+	}
+	if err := api.tokens.Revoke(ctx, req.GetToken(), *iAuth.GetCurrentProjectId()); err != nil {
+		api.logger.Errorf("unable to revoke the session token: %v", err)
+		return utils.Error[protos.RevokeWebTalkSessionTokenResponse](
+			err,
+			"Unable to revoke the session token, please check the token.",
+		)
+	}
+	return &protos.RevokeWebTalkSessionTokenResponse{Code: 200, Success: true}, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package assistant_webtalk_session_api
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_sessiontoken "github.com/rapidaai/api/assistant-api/internal/sessiontoken"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/protos"
+)
+
+type webTalkSessionApi struct {
+	cfg              *config.AssistantConfig
+	logger           commons.Logger
+	tokens           *internal_sessiontoken.Tokens
+	assistantService internal_services.AssistantService
+}
+
+type webTalkSessionGrpcApi struct {
+	webTalkSessionApi
+}
+
+func NewWebTalkSessionGRPCApi(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+	redis connectors.RedisConnector,
+	opensearch connectors.OpenSearchConnector,
+) protos.WebTalkSessionServiceServer {
+	return &webTalkSessionGrpcApi{
+		webTalkSessionApi{
+			cfg:              config,
+			logger:           logger,
+			tokens:           internal_sessiontoken.NewTokens(config.WebTalkSession, redis),
+			assistantService: internal_assistant_service.NewAssistantService(config, logger, postgres, opensearch),
+		},
+	}
+}
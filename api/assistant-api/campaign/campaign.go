@@ -0,0 +1,284 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_campaign
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	assistant_talk_api "github.com/rapidaai/api/assistant-api/api/talk"
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_campaign "github.com/rapidaai/api/assistant-api/internal/campaign"
+	internal_campaign_entity "github.com/rapidaai/api/assistant-api/internal/entity/campaigns"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_campaign_service "github.com/rapidaai/api/assistant-api/internal/services/campaign"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const (
+	defaultCampaignInterval = 15 * time.Second
+	defaultRingTimeout      = 2 * time.Minute
+)
+
+// phoneCaller places outbound calls, the talk API's CreatePhoneCall.
+type phoneCaller interface {
+	CreatePhoneCall(ctx context.Context, req *protos.CreatePhoneCallRequest) (*protos.CreatePhoneCallResponse, error)
+}
+
+// campaignEngine periodically places the calls of running campaigns and
+// records what became of them.
+type campaignEngine struct {
+	logger      commons.Logger
+	interval    time.Duration
+	ringTimeout time.Duration
+
+	campaignService internal_services.CampaignService
+	caller          phoneCaller
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewCampaignEngine(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+	redis connectors.RedisConnector,
+	opensearch connectors.OpenSearchConnector,
+	sipServer *sip_infra.Server,
+) *campaignEngine {
+	interval, ringTimeout := defaultCampaignInterval, defaultRingTimeout
+	if config.Campaign != nil {
+		if config.Campaign.IntervalSeconds > 0 {
+			interval = time.Duration(config.Campaign.IntervalSeconds) * time.Second
+		}
+		if config.Campaign.RingTimeoutSeconds > 0 {
+			ringTimeout = time.Duration(config.Campaign.RingTimeoutSeconds) * time.Second
+		}
+	}
+	return &campaignEngine{
+		logger:          logger,
+		interval:        interval,
+		ringTimeout:     ringTimeout,
+		campaignService: internal_campaign_service.NewCampaignService(logger, postgres),
+		caller:          assistant_talk_api.NewConversationGRPCApi(config, logger, postgres, redis, opensearch, opensearch, sipServer),
+	}
+}
+
+// Connect starts scheduling the campaigns, right away and then once per
+// interval.
+func (e *campaignEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stop != nil {
+		return nil
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+
+	go e.run(ctx, e.stop, e.done)
+	e.logger.Infow("Campaign scheduler started", "interval", e.interval.String(), "ring_timeout", e.ringTimeout.String())
+	return nil
+}
+
+// Disconnect stops the scheduler and waits for a run in progress to finish.
+// Calls already placed go on, the next start picks up their results.
+func (e *campaignEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.mu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (e *campaignEngine) run(ctx context.Context, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.schedule(ctx, stop)
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// schedule finishes the calls that ended, places the calls of the running
+// campaigns inside their calling hours and completes the campaigns that
+// have nothing left to call.
+func (e *campaignEngine) schedule(ctx context.Context, stop chan struct{}) {
+	campaigns, err := e.campaignService.GetActive(ctx)
+	if err != nil {
+		return
+	}
+	for _, campaign := range campaigns {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		e.finish(ctx, campaign, time.Now())
+		if campaign.Status != internal_campaign.StatusRunning {
+			continue
+		}
+		window, err := internal_campaign.ParseCallingWindow(campaign.Timezone, campaign.CallingHoursStart, campaign.CallingHoursEnd)
+		if err != nil {
+			e.logger.Warnf("campaign %d has invalid calling hours %v", campaign.Id, err)
+			continue
+		}
+		if now := time.Now(); window.Contains(now) {
+			e.dial(ctx, campaign, now)
+		}
+		completed, err := e.campaignService.Complete(ctx, campaign.Id)
+		if err == nil && completed {
+			e.logger.Infow("Campaign completed", "campaign_id", campaign.Id)
+		}
+	}
+}
+
+// finish records the result of the calls of the campaign that ended.
+func (e *campaignEngine) finish(ctx context.Context, campaign *internal_campaign_entity.AssistantCampaign, now time.Time) {
+	contacts, err := e.campaignService.GetDialing(ctx, campaign.Id)
+	if err != nil || len(contacts) == 0 {
+		return
+	}
+	conversationIds := make([]uint64, 0, len(contacts))
+	for _, contact := range contacts {
+		if contact.AssistantConversationId != 0 {
+			conversationIds = append(conversationIds, contact.AssistantConversationId)
+		}
+	}
+	contexts, err := e.campaignService.GetCallContexts(ctx, conversationIds)
+	if err != nil {
+		return
+	}
+	for _, contact := range contacts {
+		dialedAt := now
+		if contact.DialedDate != nil {
+			dialedAt = *contact.DialedDate
+		}
+		result := internal_campaign.CallResult(contexts[contact.AssistantConversationId], dialedAt, now, e.ringTimeout)
+		if !result.Finished {
+			continue
+		}
+		status, next := internal_campaign.ContactCompleted, (*time.Time)(nil)
+		if result.Retry {
+			status, next = internal_campaign.NextAttempt(contact.Attempts, campaign.MaxAttempts, campaign.RetryDelay(), now)
+		}
+		e.campaignService.FinishContact(ctx, contact.Id, status, result.Outcome, "", next)
+	}
+}
+
+// dial claims the contacts the campaign has call slots for and places
+// their calls.
+func (e *campaignEngine) dial(ctx context.Context, campaign *internal_campaign_entity.AssistantCampaign, now time.Time) {
+	contacts, err := e.campaignService.ClaimContacts(ctx, campaign, now)
+	if err != nil || len(contacts) == 0 {
+		return
+	}
+	// calls are placed on behalf of the campaign's project
+	scope := &types.ServiceScope{
+		ProjectId:      utils.Ptr(campaign.ProjectId),
+		OrganizationId: utils.Ptr(campaign.OrganizationId),
+	}
+	if campaign.CreatedBy != 0 {
+		scope.UserId = utils.Ptr(campaign.CreatedBy)
+	}
+	callCtx := context.WithValue(ctx, types.CTX_, &types.PlainClaimPrinciple[*types.ServiceScope]{Info: scope})
+	promotional := isPromotional(campaign)
+	for _, contact := range contacts {
+		// the calling hours of the campaign do not overrule TRAI's
+		if next, ok := internal_campaign.TRAIDeferral(contact.ToNumber, promotional, now); ok {
+			e.campaignService.ReleaseContact(ctx, contact.Id, next)
+			continue
+		}
+		conversationId, err := e.call(callCtx, campaign, contact)
+		if err != nil {
+			e.logger.Warnf("campaign %d could not call contact %d: %v", campaign.Id, contact.Id, err)
+			if internal_campaign.IsDNDRefusal(err.Error()) {
+				e.campaignService.FinishContact(ctx, contact.Id, internal_campaign.ContactFailed, internal_campaign.OutcomeDND, err.Error(), nil)
+				continue
+			}
+			status, next := internal_campaign.NextAttempt(contact.Attempts, campaign.MaxAttempts, campaign.RetryDelay(), now)
+			e.campaignService.FinishContact(ctx, contact.Id, status, internal_campaign.OutcomeFailed, err.Error(), next)
+			continue
+		}
+		e.campaignService.SetContactConversation(ctx, contact.Id, conversationId)
+	}
+}
+
+// isPromotional reports whether the calls of the campaign are promotional.
+// They are unless its options declare them transactional, the stricter
+// rules apply when nothing was said.
+func isPromotional(campaign *internal_campaign_entity.AssistantCampaign) bool {
+	callType, _ := campaign.Options[internal_type.CallTypeOption].(string)
+	return !strings.EqualFold(strings.TrimSpace(callType), internal_type.CallTypeTransactional)
+}
+
+// call places the call to the contact, returning its conversation.
+func (e *campaignEngine) call(ctx context.Context, campaign *internal_campaign_entity.AssistantCampaign, contact *internal_campaign_entity.AssistantCampaignContact) (uint64, error) {
+	args, err := utils.InterfaceMapToAnyMap(internal_campaign.MergeArgs(campaign.Args, contact.Args))
+	if err != nil {
+		return 0, fmt.Errorf("invalid args: %w", err)
+	}
+	options, err := utils.InterfaceMapToAnyMap(campaign.Options)
+	if err != nil {
+		return 0, fmt.Errorf("invalid options: %w", err)
+	}
+	metadata := make(map[string]interface{}, len(campaign.Metadata)+2)
+	for k, v := range campaign.Metadata {
+		metadata[k] = v
+	}
+	// lets the conversation be traced back to the campaign
+	metadata["campaign.id"] = strconv.FormatUint(campaign.Id, 10)
+	metadata["campaign.contact_id"] = strconv.FormatUint(contact.Id, 10)
+	mtd, err := utils.InterfaceMapToAnyMap(metadata)
+	if err != nil {
+		return 0, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	resp, err := e.caller.CreatePhoneCall(ctx, &protos.CreatePhoneCallRequest{
+		Assistant: &protos.AssistantDefinition{
+			AssistantId: campaign.AssistantId,
+			Version:     campaign.AssistantVersion,
+		},
+		ToNumber:   contact.ToNumber,
+		FromNumber: campaign.FromNumber,
+		Args:       args,
+		Options:    options,
+		Metadata:   mtd,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !resp.GetSuccess() {
+		return 0, fmt.Errorf("%s", resp.GetError().GetHumanMessage())
+	}
+	return resp.GetData().GetId(), nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_capacity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_capacity "github.com/rapidaai/api/assistant-api/internal/capacity"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// capacityEngine reports the utilization of this replica and samples its
+// CPU while it runs.
+type capacityEngine struct {
+	logger commons.Logger
+	cfg    *config.AssistantConfig
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewCapacityEngine(config *config.AssistantConfig, logger commons.Logger) *capacityEngine {
+	return &capacityEngine{
+		logger: logger,
+		cfg:    config,
+	}
+}
+
+// Connect installs the monitor of the replica and samples its CPU every
+// interval.
+func (e *capacityEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		return nil
+	}
+	monitor := internal_capacity.NewMonitor(internal_capacity.Limits{
+		Sessions:        e.cfg.Capacity.MaxSessions,
+		ProviderStreams: e.cfg.Capacity.MaxProviderStreams,
+	})
+	internal_capacity.Install(monitor)
+
+	sampleCtx, cancel := context.WithCancel(context.Background())
+	e.cancel, e.done = cancel, make(chan struct{})
+	go e.sample(sampleCtx, monitor, e.cfg.Capacity.SampleInterval(), e.done)
+	e.logger.Infow("Capacity reporting started",
+		"max_sessions", e.cfg.Capacity.MaxSessions,
+		"max_provider_streams", e.cfg.Capacity.MaxProviderStreams)
+	return nil
+}
+
+func (e *capacityEngine) sample(ctx context.Context, monitor *internal_capacity.Monitor, every time.Duration, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			monitor.Sample()
+		}
+	}
+}
+
+// Disconnect stops reporting the capacity.
+func (e *capacityEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel, e.done = nil, nil
+	e.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	internal_capacity.Install(nil)
+	return nil
+}
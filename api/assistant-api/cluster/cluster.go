@@ -0,0 +1,94 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_cluster "github.com/rapidaai/api/assistant-api/internal/cluster"
+	"github.com/rapidaai/pkg/clients"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+)
+
+// sessionRegistryEngine records the sessions of this instance in the shared
+// registry and keeps their records alive while they run.
+type sessionRegistryEngine struct {
+	logger commons.Logger
+	cfg    *config.AssistantConfig
+	redis  connectors.RedisConnector
+
+	mu       sync.Mutex
+	registry *internal_cluster.Registry
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewSessionRegistryEngine(config *config.AssistantConfig, logger commons.Logger, redis connectors.RedisConnector) *sessionRegistryEngine {
+	return &sessionRegistryEngine{
+		logger: logger,
+		cfg:    config,
+		redis:  redis,
+	}
+}
+
+// Connect installs the registry sessions claim their conversations in and
+// refreshes their records three times per ttl.
+func (e *sessionRegistryEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.registry != nil {
+		return nil
+	}
+	instance := e.cfg.SessionRegistry.Instance(e.cfg.Port)
+	ttl := e.cfg.SessionRegistry.TTL()
+	e.registry = internal_cluster.NewRegistry(e.redis.GetConnection(), e.logger,
+		clients.NewInternalClient(&e.cfg.AppConfig, e.logger, e.redis), instance, ttl)
+	internal_cluster.Install(e.registry)
+
+	refreshCtx, cancel := context.WithCancel(context.Background())
+	e.cancel, e.done = cancel, make(chan struct{})
+	go e.refresh(refreshCtx, e.registry, ttl/3, e.done)
+	e.logger.Infow("Session registry started", "instance", instance, "ttl", ttl)
+	return nil
+}
+
+func (e *sessionRegistryEngine) refresh(ctx context.Context, registry *internal_cluster.Registry, every time.Duration, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := registry.Refresh(ctx); err != nil && ctx.Err() == nil {
+				e.logger.Warnw("Failed to refresh session registry", "error", err)
+			}
+		}
+	}
+}
+
+// Disconnect stops routing to this instance and releases the sessions it
+// still records.
+func (e *sessionRegistryEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	registry, cancel, done := e.registry, e.cancel, e.done
+	e.registry, e.cancel, e.done = nil, nil, nil
+	e.mu.Unlock()
+	if registry == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	internal_cluster.Install(nil)
+	registry.Close(ctx)
+	return nil
+}
@@ -6,11 +6,17 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rapidaai/config"
+	"github.com/rapidaai/pkg/ciphers"
 	"github.com/rapidaai/pkg/configs"
 	"github.com/spf13/viper"
 )
@@ -23,6 +29,8 @@ type SIPConfig struct {
 	Transport         string `mapstructure:"transport"`
 	RTPPortRangeStart int    `mapstructure:"rtp_port_range_start"`
 	RTPPortRangeEnd   int    `mapstructure:"rtp_port_range_end"`
+	RegistrarRealm    string `mapstructure:"registrar_realm"` // Digest realm for PBXes registering as a trunk (registrar disabled if empty)
+	PickupPrefix      string `mapstructure:"pickup_prefix"`   // Feature code agents dial before a pickup code (defaults to *8)
 }
 
 type AudioSocketConfig struct {
@@ -30,16 +38,373 @@ type AudioSocketConfig struct {
 	Port int    `mapstructure:"port"`
 }
 
+// RecordingRetentionConfig enables the job archiving and deleting call
+// recordings by the retention policy of their project.
+type RecordingRetentionConfig struct {
+	IntervalMinutes int `mapstructure:"interval_minutes"` // how often the policies are applied (defaults to 60)
+}
+
+// ConversationEncryptionConfig enables envelope encryption of conversations:
+// each new conversation gets a data key, wrapped with the active master key,
+// that its recordings, messages and scratchpad are encrypted with.
+//
+// To rotate, add a master key and make it active. Keys wrapped with the
+// previous one are rewrapped in the background, it can be removed once that
+// is done.
+type ConversationEncryptionConfig struct {
+	MasterKeys  []string `mapstructure:"master_keys"`   // id:base64 of a 32 byte key, comma separated
+	ActiveKeyID string   `mapstructure:"active_key_id"` // master key new data keys are wrapped with
+	ProjectIDs  []uint64 `mapstructure:"project_ids"`   // projects whose conversations are encrypted (all if empty)
+}
+
+// Keyring parses the configured master keys.
+func (c *ConversationEncryptionConfig) Keyring() (*ciphers.Keyring, error) {
+	return ciphers.ParseKeyring(c.ActiveKeyID, c.MasterKeys)
+}
+
+// Encrypts reports whether new conversations of the project are encrypted.
+func (c *ConversationEncryptionConfig) Encrypts(projectID uint64) bool {
+	if len(c.ProjectIDs) == 0 {
+		return true
+	}
+	return slices.Contains(c.ProjectIDs, projectID)
+}
+
+// WarmPoolConfig keeps pipelines of the listed assistants built ahead of
+// their inbound phone calls, so they answer without connecting providers
+// first. Each standby holds open provider streams, size it to the calls that
+// arrive within a few seconds of each other.
+type WarmPoolConfig struct {
+	Assistants     []string `mapstructure:"assistants"`       // assistant_id:size, comma separated
+	MaxIdleSeconds int      `mapstructure:"max_idle_seconds"` // standbys not claimed by then are rebuilt (defaults to 15)
+}
+
+// Sizes parses the number of standbys kept per assistant.
+func (c *WarmPoolConfig) Sizes() (map[uint64]int, error) {
+	sizes := make(map[uint64]int, len(c.Assistants))
+	for _, entry := range c.Assistants {
+		id, size, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("warm pool assistant %q must be given as assistant_id:size", entry)
+		}
+		assistantId, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("warm pool assistant id %q is not a number", id)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("warm pool size of assistant %d must be a positive number", assistantId)
+		}
+		sizes[assistantId] = n
+	}
+	return sizes, nil
+}
+
+// MaxIdle is how long a standby waits for a call before it is rebuilt, zero
+// for the pool's default.
+func (c *WarmPoolConfig) MaxIdle() time.Duration {
+	return time.Duration(c.MaxIdleSeconds) * time.Second
+}
+
+// TelemetryBatchConfig queues the metrics and telephony events of calls and
+// writes them in batches instead of one insert per event. A batch is written
+// once it holds BatchSize records, FlushIntervalMs after the previous one, or
+// when a call ends.
+type TelemetryBatchConfig struct {
+	BatchSize       int `mapstructure:"batch_size"`        // defaults to 200
+	FlushIntervalMs int `mapstructure:"flush_interval_ms"` // defaults to 500
+	MaxPending      int `mapstructure:"max_pending"`       // oldest records are dropped beyond it while writes fail, defaults to 20 batches
+}
+
+// FlushInterval is the longest a record waits in the queue, zero for the
+// writer's default.
+func (c *TelemetryBatchConfig) FlushInterval() time.Duration {
+	return time.Duration(c.FlushIntervalMs) * time.Millisecond
+}
+
+// CampaignConfig enables the scheduler placing the calls of outbound
+// campaigns. Every replica may run it, contacts are claimed in the database.
+type CampaignConfig struct {
+	IntervalSeconds    int `mapstructure:"interval_seconds"`     // how often campaigns are scheduled (defaults to 15)
+	RingTimeoutSeconds int `mapstructure:"ring_timeout_seconds"` // calls not answered by then count as no answer (defaults to 120)
+}
+
+// WebRTCConfig relays the media of web callers through the TURN cluster
+// nearest to them. Each region is name@latitude:longitude=url|url, the
+// location may be left out for a region only used as the fallback. Regions
+// are probed every HealthCheckSeconds and callers are not sent to one that
+// stopped answering while another does.
+type WebRTCConfig struct {
+	TURNRegions        []string `mapstructure:"turn_regions"`         // comma separated, the first is used when the caller's location is unknown
+	TURNSecret         string   `mapstructure:"turn_secret"`          // TURN REST API secret, callers get credentials valid for their session
+	TURNUsername       string   `mapstructure:"turn_username"`        // static credentials when there is no secret
+	TURNCredential     string   `mapstructure:"turn_credential"`      //
+	HealthCheckSeconds int      `mapstructure:"health_check_seconds"` // defaults to 30
+}
+
+// TURNRegion is a TURN cluster of the WebRTC config.
+type TURNRegion struct {
+	Name                string
+	URLs                []string
+	Latitude, Longitude float64
+	Located             bool
+}
+
+// Regions parses the configured TURN regions.
+func (c *WebRTCConfig) Regions() ([]TURNRegion, error) {
+	if len(c.TURNRegions) == 0 {
+		return nil, fmt.Errorf("webrtc needs at least one turn region")
+	}
+	regions := make([]TURNRegion, 0, len(c.TURNRegions))
+	for _, entry := range c.TURNRegions {
+		head, urls, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || strings.TrimSpace(urls) == "" {
+			return nil, fmt.Errorf("turn region %q must be given as name@latitude:longitude=url|url", entry)
+		}
+		name, location, located := strings.Cut(strings.TrimSpace(head), "@")
+		region := TURNRegion{Name: strings.TrimSpace(name), Located: located}
+		if region.Name == "" {
+			return nil, fmt.Errorf("turn region %q has no name", entry)
+		}
+		if located {
+			lat, lon, ok := strings.Cut(location, ":")
+			var latErr, lonErr error
+			region.Latitude, latErr = strconv.ParseFloat(strings.TrimSpace(lat), 64)
+			region.Longitude, lonErr = strconv.ParseFloat(strings.TrimSpace(lon), 64)
+			if !ok || latErr != nil || lonErr != nil ||
+				region.Latitude < -90 || region.Latitude > 90 || region.Longitude < -180 || region.Longitude > 180 {
+				return nil, fmt.Errorf("turn region %s has an invalid location %q", region.Name, location)
+			}
+		}
+		for _, url := range strings.Split(urls, "|") {
+			url = strings.TrimSpace(url)
+			if !strings.HasPrefix(url, "turn:") && !strings.HasPrefix(url, "turns:") && !strings.HasPrefix(url, "stun:") {
+				return nil, fmt.Errorf("turn region %s has an invalid url %q", region.Name, url)
+			}
+			region.URLs = append(region.URLs, url)
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
+// HealthCheckInterval is how often the regions are probed, zero for the
+// default.
+func (c *WebRTCConfig) HealthCheckInterval() time.Duration {
+	return time.Duration(c.HealthCheckSeconds) * time.Second
+}
+
+// SessionResumeConfig keeps the state of WebTalk text sessions whose stream
+// dropped, a client reconnecting with its resume token within TTLSeconds
+// continues the same conversation.
+type SessionResumeConfig struct {
+	TTLSeconds int `mapstructure:"ttl_seconds"` // defaults to 300
+}
+
+// TTL is how long a dropped session can be resumed.
+func (c *SessionResumeConfig) TTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// DrainConfig is how long a replica that received SIGTERM lets its active
+// sessions finish before it shuts down.
+type DrainConfig struct {
+	DeadlineSeconds int `mapstructure:"deadline_seconds"` // defaults to 300
+}
+
+// Deadline is how long active sessions have to finish, also when drain is
+// not configured.
+func (c *DrainConfig) Deadline() time.Duration {
+	if c == nil || c.DeadlineSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.DeadlineSeconds) * time.Second
+}
+
+// CallMigrationConfig lets instances take live SIP calls over from each
+// other, an instance draining hands its calls to DrainTo, any instance
+// taking calls when empty.
+type CallMigrationConfig struct {
+	Instance       string `mapstructure:"instance"`        // defaults to the hostname
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"` // defaults to 10
+	DrainTo        string `mapstructure:"drain_to"`
+}
+
+// Name is the instance calls are handed to by their operators.
+func (c *CallMigrationConfig) Name() string {
+	if c.Instance != "" {
+		return c.Instance
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// Timeout is how long an instance has to take a call over before it stays
+// where it is.
+func (c *CallMigrationConfig) Timeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// SessionRegistryConfig records which instance hosts every live session in
+// redis, so callbacks and control requests reaching any instance are routed
+// to it.
+type SessionRegistryConfig struct {
+	Address    string `mapstructure:"address"`     // defaults to hostname:port
+	TTLSeconds int    `mapstructure:"ttl_seconds"` // defaults to 30
+}
+
+// Instance is the gRPC address other instances reach this one at.
+func (c *SessionRegistryConfig) Instance(port int) string {
+	if c.Address != "" {
+		return c.Address
+	}
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", hostname, port)
+}
+
+// TTL is how long a session stays recorded after its instance stopped
+// refreshing it, e.g. because it crashed.
+func (c *SessionRegistryConfig) TTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// WebTalkSessionConfig signs the short-lived session tokens browsers open
+// WebTalk sessions with instead of a project key. Revoked tokens are kept in
+// redis until they expire.
+type WebTalkSessionConfig struct {
+	Secret        string `mapstructure:"secret"`
+	TTLSeconds    int    `mapstructure:"ttl_seconds"`     // defaults to 300
+	MaxTTLSeconds int    `mapstructure:"max_ttl_seconds"` // defaults to 3600
+}
+
+// TTL is how long a token is valid when it is minted without a lifetime,
+// capped by MaxTTL.
+func (c *WebTalkSessionConfig) TTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return min(5*time.Minute, c.MaxTTL())
+	}
+	return min(time.Duration(c.TTLSeconds)*time.Second, c.MaxTTL())
+}
+
+// MaxTTL is the longest lifetime a token can be minted with.
+func (c *WebTalkSessionConfig) MaxTTL() time.Duration {
+	if c.MaxTTLSeconds <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.MaxTTLSeconds) * time.Second
+}
+
+// CapacityConfig is what one replica is sized for. Its utilization against
+// these limits, RTP ports and CPU is exported for autoscalers.
+type CapacityConfig struct {
+	MaxSessions        int64 `mapstructure:"max_sessions"`         // sessions component left out when 0
+	MaxProviderStreams int64 `mapstructure:"max_provider_streams"` // provider streams component left out when 0
+	SampleSeconds      int   `mapstructure:"sample_seconds"`       // defaults to 5
+}
+
+// SampleInterval is the window the CPU is averaged over.
+func (c *CapacityConfig) SampleInterval() time.Duration {
+	if c.SampleSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.SampleSeconds) * time.Second
+}
+
+// OutputPacingConfig is how web calls (WebRTC, LiveKit, WhatsApp) pace the
+// assistant's audio, in ms of audio. Without it a call plays one frame per
+// tick and queues without bound.
+type OutputPacingConfig struct {
+	MaxQueueMs           int `mapstructure:"max_queue_ms"`           // oldest audio dropped beyond it, unbounded when 0
+	CatchUpFactor        int `mapstructure:"catch_up_factor"`        // frames per tick at most after missed ticks, defaults to 1
+	InterruptWatermarkMs int `mapstructure:"interrupt_watermark_ms"` // queued audio still played on an interruption
+}
+
+// MaxQueue is the audio a call queues ahead of playback at most, 0 without
+// bound.
+func (c *OutputPacingConfig) MaxQueue() time.Duration {
+	return time.Duration(max(c.MaxQueueMs, 0)) * time.Millisecond
+}
+
+// InterruptWatermark is the queued audio still played on an interruption.
+func (c *OutputPacingConfig) InterruptWatermark() time.Duration {
+	return time.Duration(max(c.InterruptWatermarkMs, 0)) * time.Millisecond
+}
+
+// LiveTranscriptConfig keeps the transcript of live conversations in redis
+// for the server-sent events endpoint, which subscribers resume from the
+// last event they received.
+type LiveTranscriptConfig struct {
+	MaxEvents  int64 `mapstructure:"max_events"`  // kept per conversation, defaults to 1000
+	TTLSeconds int   `mapstructure:"ttl_seconds"` // kept after the last event, defaults to 3600
+}
+
+// Retained is how many events of a conversation are kept.
+func (c *LiveTranscriptConfig) Retained() int64 {
+	if c.MaxEvents <= 0 {
+		return 1000
+	}
+	return c.MaxEvents
+}
+
+// TTL is how long the events of a conversation are kept after its last one.
+func (c *LiveTranscriptConfig) TTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// TextToSpeechCacheConfig keeps the audio of the phrases assistants speak
+// again, for those enabling it with speaker.cache.enabled, in redis or in the
+// asset store.
+type TextToSpeechCacheConfig struct {
+	Store      string `mapstructure:"store"`       // redis or asset_store, defaults to redis
+	TTLSeconds int    `mapstructure:"ttl_seconds"` // a phrase is kept for, defaults to a week
+}
+
+// TTL is how long a phrase is kept.
+func (c *TextToSpeechCacheConfig) TTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
 type AssistantConfig struct {
 	config.AppConfig    `mapstructure:",squash"`
-	PostgresConfig      configs.PostgresConfig   `mapstructure:"postgres" validate:"required"`
-	RedisConfig         configs.RedisConfig      `mapstructure:"redis" validate:"required"`
+	PostgresConfig      configs.PostgresConfig    `mapstructure:"postgres" validate:"required"`
+	RedisConfig         configs.RedisConfig       `mapstructure:"redis" validate:"required"`
 	OpenSearchConfig    *configs.OpenSearchConfig `mapstructure:"opensearch"`
-	WeaviateConfig      configs.WeaviateConfig   `mapstructure:"weaviate"`
-	AssetStoreConfig    configs.AssetStoreConfig `mapstructure:"asset_store" validate:"required"`
-	PublicAssistantHost string                   `mapstructure:"public_assistant_host" validate:"required"`
-	SIPConfig           *SIPConfig               `mapstructure:"sip"`
-	AudioSocketConfig   *AudioSocketConfig       `mapstructure:"audiosocket"`
+	WeaviateConfig      configs.WeaviateConfig    `mapstructure:"weaviate"`
+	AssetStoreConfig    configs.AssetStoreConfig  `mapstructure:"asset_store" validate:"required"`
+	PublicAssistantHost string                    `mapstructure:"public_assistant_host" validate:"required"`
+	SIPConfig           *SIPConfig                `mapstructure:"sip"`
+	AudioSocketConfig   *AudioSocketConfig        `mapstructure:"audiosocket"`
+	RecordingRetention  *RecordingRetentionConfig `mapstructure:"recording_retention"`
+
+	ConversationEncryption *ConversationEncryptionConfig `mapstructure:"conversation_encryption"`
+	WarmPool               *WarmPoolConfig               `mapstructure:"warm_pool"`
+	TelemetryBatch         *TelemetryBatchConfig         `mapstructure:"telemetry_batch"`
+	Campaign               *CampaignConfig               `mapstructure:"campaign"`
+	WebRTC                 *WebRTCConfig                 `mapstructure:"webrtc"`
+	SessionResume          *SessionResumeConfig          `mapstructure:"session_resume"`
+	Drain                  *DrainConfig                  `mapstructure:"drain"`
+	CallMigration          *CallMigrationConfig          `mapstructure:"call_migration"`
+	SessionRegistry        *SessionRegistryConfig        `mapstructure:"session_registry"`
+	WebTalkSession         *WebTalkSessionConfig         `mapstructure:"webtalk_session"`
+	Capacity               *CapacityConfig               `mapstructure:"capacity"`
+	OutputPacing           *OutputPacingConfig           `mapstructure:"output_pacing"`
+	LiveTranscript         *LiveTranscriptConfig         `mapstructure:"live_transcript"`
+	TextToSpeechCache      *TextToSpeechCacheConfig      `mapstructure:"tts_cache"`
 }
 
 // reading config and intializing configs for application
@@ -79,6 +444,24 @@ func GetApplicationConfig(v *viper.Viper) (*AssistantConfig, error) {
 		(config.OpenSearchConfig.Host == "" || config.OpenSearchConfig.Schema == "") {
 		config.OpenSearchConfig = nil
 	}
+	if config.ConversationEncryption != nil {
+		if _, err := config.ConversationEncryption.Keyring(); err != nil {
+			log.Printf("invalid conversation encryption keys: %v", err)
+			return nil, err
+		}
+	}
+	if config.WarmPool != nil {
+		if _, err := config.WarmPool.Sizes(); err != nil {
+			log.Printf("invalid warm pool: %v", err)
+			return nil, err
+		}
+	}
+	if config.WebRTC != nil {
+		if _, err := config.WebRTC.Regions(); err != nil {
+			log.Printf("invalid webrtc turn regions: %v", err)
+			return nil, err
+		}
+	}
 	// valdating the app config
 	validate := validator.New()
 	err = validate.Struct(&config)
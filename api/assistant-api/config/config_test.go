@@ -7,6 +7,7 @@
 package config
 
 import (
+	"encoding/base64"
 	"os"
 	"path/filepath"
 	"testing"
@@ -153,3 +154,95 @@ func TestGetApplicationConfig(t *testing.T) {
 		t.Errorf("Expected AssetStoreConfig.StorageType to be 'local', but got %v", appConfig.AssetStoreConfig.StorageType)
 	}
 }
+
+func TestConversationEncryptionConfig(t *testing.T) {
+	encryption := &ConversationEncryptionConfig{
+		MasterKeys:  []string{"2025-01:" + base64.StdEncoding.EncodeToString(make([]byte, 32))},
+		ActiveKeyID: "2025-01",
+	}
+	if _, err := encryption.Keyring(); err != nil {
+		t.Fatalf("Keyring returned an error: %v", err)
+	}
+	if !encryption.Encrypts(7) {
+		t.Errorf("Expected every project to be encrypted without project ids")
+	}
+
+	encryption.ProjectIDs = []uint64{3}
+	if encryption.Encrypts(7) || !encryption.Encrypts(3) {
+		t.Errorf("Expected only project 3 to be encrypted")
+	}
+
+	encryption.ActiveKeyID = "2025-02"
+	if _, err := encryption.Keyring(); err == nil {
+		t.Errorf("Expected an error for an active key that is not configured")
+	}
+}
+
+func TestWarmPoolConfig(t *testing.T) {
+	pool := &WarmPoolConfig{Assistants: []string{"2231:3", " 4410 : 1 "}, MaxIdleSeconds: 20}
+	sizes, err := pool.Sizes()
+	if err != nil {
+		t.Fatalf("Sizes returned an error: %v", err)
+	}
+	if sizes[2231] != 3 || sizes[4410] != 1 || len(sizes) != 2 {
+		t.Errorf("Expected sizes 2231:3 and 4410:1, but got %v", sizes)
+	}
+	if pool.MaxIdle().Seconds() != 20 {
+		t.Errorf("Expected a max idle of 20s, but got %v", pool.MaxIdle())
+	}
+
+	for _, invalid := range []string{"2231", "abc:2", "2231:0", "2231:x"} {
+		pool.Assistants = []string{invalid}
+		if _, err := pool.Sizes(); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestWebRTCConfig(t *testing.T) {
+	webrtc := &WebRTCConfig{TURNRegions: []string{
+		"eu@50.11:8.68=turn:eu.turn.example.com:3478|turns:eu.turn.example.com:5349",
+		" us @ 39.04 : -77.49 = turn:us.turn.example.com:3478?transport=udp ",
+		"fallback=stun:stun.example.com:3478",
+	}, HealthCheckSeconds: 10}
+	regions, err := webrtc.Regions()
+	if err != nil {
+		t.Fatalf("Regions returned an error: %v", err)
+	}
+	if len(regions) != 3 {
+		t.Fatalf("Expected 3 regions, but got %v", regions)
+	}
+	if regions[0].Name != "eu" || len(regions[0].URLs) != 2 || regions[0].Latitude != 50.11 || !regions[0].Located {
+		t.Errorf("Unexpected eu region %+v", regions[0])
+	}
+	if regions[1].Name != "us" || regions[1].Longitude != -77.49 || regions[1].URLs[0] != "turn:us.turn.example.com:3478?transport=udp" {
+		t.Errorf("Unexpected us region %+v", regions[1])
+	}
+	if regions[2].Located {
+		t.Errorf("Expected the fallback region without a location")
+	}
+	if webrtc.HealthCheckInterval().Seconds() != 10 {
+		t.Errorf("Expected a health check every 10s, but got %v", webrtc.HealthCheckInterval())
+	}
+
+	for _, invalid := range []string{"eu", "eu=", "@1:2=turn:a", "eu@91:0=turn:a", "eu@1=turn:a", "eu=http://a"} {
+		webrtc.TURNRegions = []string{invalid}
+		if _, err := webrtc.Regions(); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+	webrtc.TURNRegions = nil
+	if _, err := webrtc.Regions(); err == nil {
+		t.Errorf("Expected an error without regions")
+	}
+}
+
+func TestTelemetryBatchConfig(t *testing.T) {
+	batch := &TelemetryBatchConfig{FlushIntervalMs: 250}
+	if batch.FlushInterval().Milliseconds() != 250 {
+		t.Errorf("Expected a flush interval of 250ms, but got %v", batch.FlushInterval())
+	}
+	if (&TelemetryBatchConfig{}).FlushInterval() != 0 {
+		t.Errorf("Expected no flush interval when it is not configured")
+	}
+}
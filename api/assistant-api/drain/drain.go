@@ -0,0 +1,33 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_drain
+
+import (
+	"context"
+	"time"
+
+	internal_drain "github.com/rapidaai/api/assistant-api/internal/drain"
+	"google.golang.org/grpc"
+)
+
+// StreamServerInterceptor refuses the sessions opened once the instance
+// started draining.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return internal_drain.Default.StreamServerInterceptor()
+}
+
+// Start marks the instance as draining, sessions must finish within
+// deadline. It is false when the instance already drains.
+func Start(deadline time.Duration) bool {
+	return internal_drain.Default.Start(deadline)
+}
+
+// Wait blocks until the active sessions finished or the deadline passed,
+// reporting progress on the way, and returns the sessions still active.
+func Wait(ctx context.Context, progress func(active int64)) int64 {
+	return internal_drain.Default.Wait(ctx, progress)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_encryption
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+)
+
+const (
+	rewrapInterval = time.Hour
+
+	// rewrapBatchSize bounds the keys rewrapped per query.
+	rewrapBatchSize = 500
+
+	// rewrapLockKey makes one replica rewrap the keys per interval.
+	rewrapLockKey = "assistant:conversation-key-rewrap:lock"
+)
+
+// conversationKeyRewrapEngine moves the data keys of encrypted conversations
+// to the active master key after a rotation, so the previous master key can
+// be retired. Once every key is under the active one a run is a single query.
+type conversationKeyRewrapEngine struct {
+	logger commons.Logger
+	redis  connectors.RedisConnector
+
+	conversationService internal_services.AssistantConversationService
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewConversationKeyRewrapEngine(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+	redis connectors.RedisConnector,
+) *conversationKeyRewrapEngine {
+	return &conversationKeyRewrapEngine{
+		logger:              logger,
+		redis:               redis,
+		conversationService: internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+	}
+}
+
+// Connect starts rewrapping, right away and then once per interval.
+func (e *conversationKeyRewrapEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stop != nil {
+		return nil
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+
+	go e.run(ctx, e.stop, e.done)
+	e.logger.Infow("Conversation key rewrap started", "interval", rewrapInterval.String())
+	return nil
+}
+
+// Disconnect stops the job and waits for a run in progress to finish.
+func (e *conversationKeyRewrapEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.mu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (e *conversationKeyRewrapEngine) run(ctx context.Context, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(rewrapInterval)
+	defer ticker.Stop()
+	for {
+		e.rewrap(ctx, stop)
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rewrap works through the stale keys batch by batch unless another replica
+// already did in this interval.
+func (e *conversationKeyRewrapEngine) rewrap(ctx context.Context, stop chan struct{}) {
+	acquired, err := e.redis.GetConnection().SetNX(ctx, rewrapLockKey, time.Now().Unix(), rewrapInterval/2).Result()
+	if err != nil {
+		e.logger.Warnf("unable to take the conversation key rewrap lock %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+	total := 0
+	for {
+		rewrapped, err := e.conversationService.RewrapConversationKeys(ctx, rewrapBatchSize)
+		total += rewrapped
+		if err != nil {
+			e.logger.Errorf("conversation key rewrap failed after rewrapping %d keys: %v", total, err)
+			return
+		}
+		if rewrapped < rewrapBatchSize {
+			break
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+	if total > 0 {
+		e.logger.Infow("Conversation keys rewrapped", "rewrapped", total)
+	}
+}
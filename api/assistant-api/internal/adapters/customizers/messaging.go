@@ -17,8 +17,13 @@ import (
 type Messaging interface {
 	GetID() string
 	Transition(state InteractionState) error
+	State() InteractionState
 	GetMode() type_enums.MessageMode
 	SwitchMode(mm type_enums.MessageMode)
+
+	// UseIDGenerator replaces random message ids with the ones from next,
+	// starting with the current message. Used by seeded conversations.
+	UseIDGenerator(next func() string)
 }
 
 type InteractionState int
@@ -55,6 +60,7 @@ func (s InteractionState) String() string {
 type messaging struct {
 	logger commons.Logger
 	in     string
+	nextID func() string
 	// actor type_enums.MessageActor
 	state InteractionState
 
@@ -67,6 +73,7 @@ func NewMessaging(logger commons.Logger) Messaging {
 	return &messaging{
 		logger: logger,
 		in:     uuid.NewString(),
+		nextID: uuid.NewString,
 		mode:   type_enums.TextMode,
 		state:  Unknown,
 	}
@@ -90,6 +97,19 @@ func (ms *messaging) GetID() string {
 	return ms.in
 }
 
+func (ms *messaging) UseIDGenerator(next func() string) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	ms.nextID = next
+	ms.in = next()
+}
+
+func (ms *messaging) State() InteractionState {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+	return ms.state
+}
+
 func (ms *messaging) Transition(newState InteractionState) error {
 	ms.mutex.Lock()
 	defer ms.mutex.Unlock()
@@ -105,7 +125,7 @@ func (ms *messaging) Transition(newState InteractionState) error {
 		if ms.state == Interrupted {
 			return fmt.Errorf("Transition: invalid transition: agent can't interrupted multiple times")
 		}
-		ms.in = uuid.NewString()
+		ms.in = ms.nextID()
 	}
 	ms.state = newState
 	return nil
@@ -13,6 +13,7 @@ import (
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_telemetry_batch "github.com/rapidaai/api/assistant-api/internal/telemetry/batch"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
@@ -30,24 +31,33 @@ func (gr *genericRequestor) GetSpeechToTextTransformer() (
 	*internal_assistant_entity.AssistantDeploymentAudio,
 	error,
 ) {
-	switch gr.source {
+	return inputAudioDeployment(gr.assistant, gr.source)
+}
+
+// inputAudioDeployment resolves the speech to text configuration of the
+// deployment that serves the given source.
+func inputAudioDeployment(a *internal_assistant_entity.Assistant, source utils.RapidaSource) (
+	*internal_assistant_entity.AssistantDeploymentAudio,
+	error,
+) {
+	switch source {
 	case utils.PhoneCall:
-		if a := gr.assistant; a != nil && a.AssistantPhoneDeployment != nil && a.AssistantPhoneDeployment.InputAudio != nil {
+		if a != nil && a.AssistantPhoneDeployment != nil && a.AssistantPhoneDeployment.InputAudio != nil {
 			return a.AssistantPhoneDeployment.InputAudio, nil
 		}
 
 	case utils.SDK:
-		if a := gr.assistant; a != nil && a.AssistantApiDeployment != nil && a.AssistantApiDeployment.InputAudio != nil {
+		if a != nil && a.AssistantApiDeployment != nil && a.AssistantApiDeployment.InputAudio != nil {
 			return a.AssistantApiDeployment.InputAudio, nil
 		}
 
 	case utils.WebPlugin:
-		if a := gr.assistant; a != nil && a.AssistantWebPluginDeployment != nil && a.AssistantWebPluginDeployment.InputAudio != nil {
+		if a != nil && a.AssistantWebPluginDeployment != nil && a.AssistantWebPluginDeployment.InputAudio != nil {
 			return a.AssistantWebPluginDeployment.InputAudio, nil
 		}
 
 	case utils.Debugger:
-		if a := gr.assistant; a != nil && a.AssistantDebuggerDeployment != nil && a.AssistantDebuggerDeployment.InputAudio != nil {
+		if a != nil && a.AssistantDebuggerDeployment != nil && a.AssistantDebuggerDeployment.InputAudio != nil {
 			return a.AssistantDebuggerDeployment.InputAudio, nil
 		}
 	}
@@ -55,24 +65,33 @@ func (gr *genericRequestor) GetSpeechToTextTransformer() (
 }
 
 func (gr *genericRequestor) GetTextToSpeechTransformer() (*internal_assistant_entity.AssistantDeploymentAudio, error) {
-	switch gr.source {
+	return outputAudioDeployment(gr.assistant, gr.source)
+}
+
+// outputAudioDeployment resolves the text to speech configuration of the
+// deployment that serves the given source.
+func outputAudioDeployment(a *internal_assistant_entity.Assistant, source utils.RapidaSource) (
+	*internal_assistant_entity.AssistantDeploymentAudio,
+	error,
+) {
+	switch source {
 	case utils.PhoneCall:
-		if a := gr.assistant; a != nil && a.AssistantPhoneDeployment != nil && a.AssistantPhoneDeployment.OuputAudio != nil {
+		if a != nil && a.AssistantPhoneDeployment != nil && a.AssistantPhoneDeployment.OuputAudio != nil {
 			return a.AssistantPhoneDeployment.OuputAudio, nil
 		}
 
 	case utils.SDK:
-		if a := gr.assistant; a != nil && a.AssistantApiDeployment != nil && a.AssistantApiDeployment.OuputAudio != nil {
+		if a != nil && a.AssistantApiDeployment != nil && a.AssistantApiDeployment.OuputAudio != nil {
 			return a.AssistantApiDeployment.OuputAudio, nil
 		}
 
 	case utils.WebPlugin:
-		if a := gr.assistant; a != nil && a.AssistantWebPluginDeployment != nil && a.AssistantWebPluginDeployment.OuputAudio != nil {
+		if a != nil && a.AssistantWebPluginDeployment != nil && a.AssistantWebPluginDeployment.OuputAudio != nil {
 			return a.AssistantWebPluginDeployment.OuputAudio, nil
 		}
 
 	case utils.Debugger:
-		if a := gr.assistant; a != nil && a.AssistantDebuggerDeployment != nil && a.AssistantDebuggerDeployment.OuputAudio != nil {
+		if a != nil && a.AssistantDebuggerDeployment != nil && a.AssistantDebuggerDeployment.OuputAudio != nil {
 			return a.AssistantDebuggerDeployment.OuputAudio, nil
 		}
 	}
@@ -85,6 +104,12 @@ func (gr *genericRequestor) GetAssistant(
 	assistantId uint64,
 	version string) (*internal_assistant_entity.Assistant, error) {
 	versionId := utils.GetVersionDefinition(version)
+	return gr.assistantService.Get(ctx, auth, assistantId, versionId, assistantOptionForSource(gr.source))
+}
+
+// assistantOptionForSource returns the assistant loading options used for a
+// live session, injecting only the deployment that serves the given source.
+func assistantOptionForSource(source utils.RapidaSource) *internal_services.GetAssistantOption {
 	assistantOpts := &internal_services.GetAssistantOption{
 		InjectTag: false,
 		//
@@ -98,7 +123,7 @@ func (gr *genericRequestor) GetAssistant(
 		InjectWebhook:       true,
 		InjectConversations: false,
 	}
-	switch gr.source {
+	switch source {
 	case utils.PhoneCall:
 		assistantOpts.InjectPhoneDeployment = true
 	case utils.Whatsapp:
@@ -110,7 +135,7 @@ func (gr *genericRequestor) GetAssistant(
 	case utils.Debugger:
 		assistantOpts.InjectDebuggerDeployment = true
 	}
-	return gr.assistantService.Get(ctx, auth, assistantId, versionId, assistantOpts)
+	return assistantOpts
 }
 
 /*
@@ -202,12 +227,13 @@ func (tc *genericRequestor) onAddMetadata(ctx context.Context, metadata ...*prot
 func (tc *genericRequestor) onAddMetrics(ctx context.Context, metrics ...*protos.Metric) error {
 	dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
 	defer cancel()
-	_, err := tc.conversationService.ApplyConversationMetrics(
+	err := internal_telemetry_batch.ConversationMetrics(
 		dbCtx,
+		tc.conversationService,
 		tc.auth,
 		tc.assistant.Id,
 		tc.assistantConversation.Id,
-		types.ToMetrics(metrics),
+		types.ToMetrics(metrics)...,
 	)
 	if err != nil {
 		tc.logger.Errorf("unable to flush metrics for conversation %+v", err)
@@ -218,7 +244,7 @@ func (tc *genericRequestor) onAddMetrics(ctx context.Context, metrics ...*protos
 func (deb *genericRequestor) onMessageMetric(ctx context.Context, messageId string, metrics []*protos.Metric) error {
 	dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
 	defer cancel()
-	if _, err := deb.conversationService.ApplyMessageMetrics(dbCtx, deb.Auth(), deb.Conversation().Id, messageId, types.ToMetrics(metrics)); err != nil {
+	if err := internal_telemetry_batch.MessageMetrics(dbCtx, deb.conversationService, deb.Auth(), deb.Conversation().Id, messageId, types.ToMetrics(metrics)...); err != nil {
 		deb.logger.Errorf("error updating metrics for message: %v", err)
 		return err
 	}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// isBackchannel reports whether a transcript is the caller acknowledging the
+// assistant while it is still heard ("yeah", "ok", laughter) rather than
+// taking the turn. Backchannels neither interrupt nor reach the executor.
+func (talking *genericRequestor) isBackchannel(vl internal_type.SpeechToTextPacket) bool {
+	if talking.backchannel == nil || !talking.assistantAudible() {
+		return false
+	}
+	var spoken time.Duration
+	if onset := talking.voiceOnset.Load(); onset > 0 {
+		spoken = time.Since(time.Unix(0, onset))
+	}
+	return talking.backchannel.Is(vl.Script, vl.Language, spoken)
+}
+
+// extendPlayback pushes out when the caller stops hearing the assistant by
+// the duration of an audio chunk sent to the channel.
+func (talking *genericRequestor) extendPlayback(d time.Duration) {
+	now := time.Now().UnixNano()
+	until := talking.playbackUntil.Load()
+	if until < now {
+		until = now
+	}
+	talking.playbackUntil.Store(until + d.Nanoseconds())
+	talking.meterAssistant(time.Unix(0, until), d)
+}
+
+// endPlayback marks the assistant as no longer heard, its audio was cut.
+func (talking *genericRequestor) endPlayback() {
+	talking.playbackUntil.Store(0)
+	talking.meterCut()
+}
+
+// assistantAudible reports whether the channel is likely still playing the
+// assistant's speech.
+func (talking *genericRequestor) assistantAudible() bool {
+	return time.Now().UnixNano() < talking.playbackUntil.Load()
+}
+
+// markVoiceOnset records when the caller started speaking, the first onset
+// of an utterance wins.
+func (talking *genericRequestor) markVoiceOnset() {
+	talking.voiceOnset.CompareAndSwap(0, time.Now().UnixNano())
+}
+
+// clearVoiceOnset forgets the onset once the caller's utterance is over.
+func (talking *genericRequestor) clearVoiceOnset() {
+	talking.voiceOnset.Store(0)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"strconv"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+)
+
+// recordChannelDrops stores how many messages the channel dropped under
+// backpressure with the conversation metrics. A call without drops records
+// nothing, the metrics only point at the calls whose audio was cut.
+func (r *genericRequestor) recordChannelDrops(ctx context.Context) {
+	channel, ok := r.streamer.(internal_type.DroppingChannel)
+	if !ok {
+		return
+	}
+	drops := channel.Dropped()
+	if drops.Input == 0 && drops.Output == 0 {
+		return
+	}
+	r.logger.Warnf("channel dropped %d input and %d output messages under backpressure", drops.Input, drops.Output)
+	if err := r.onAddMetrics(ctx,
+		&protos.Metric{
+			Name:        "channel_input_dropped",
+			Value:       strconv.FormatUint(drops.Input, 10),
+			Description: "Messages from the caller dropped because the talk loop fell behind",
+		},
+		&protos.Metric{
+			Name:        "channel_output_dropped",
+			Value:       strconv.FormatUint(drops.Output, 10),
+			Description: "Messages to the caller dropped because the channel fell behind",
+		},
+	); err != nil {
+		r.logger.Warnf("unable to record the channel drops: %v", err)
+	}
+}
@@ -59,6 +59,11 @@ func (r *genericRequestor) initializeBehavior(ctx context.Context) error {
 		r.logger.Errorf("error while fetching deployment behavior: %v", err)
 		return nil
 	}
+	if r.initializeVoicemailDrop(ctx) {
+		// nobody is there to greet or to wait for
+		r.initializeMaxSessionDuration(ctx, behavior)
+		return nil
+	}
 	r.initializeGreeting(ctx, behavior)
 	r.initializeIdleTimeout(ctx, behavior)
 	r.initializeMaxSessionDuration(ctx, behavior)
@@ -189,6 +194,9 @@ func (r *genericRequestor) startIdleTimeoutTimer(ctx context.Context, inputDurat
 	if r.idleTimeoutTimer != nil {
 		r.idleTimeoutTimer.Stop()
 	}
+	if r.onHold.Load() || r.leavingVoicemail() {
+		return
+	}
 
 	behavior, err := r.GetBehavior()
 	if err != nil {
@@ -0,0 +1,122 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"strings"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// speechEntityCues maps what the assistant asks for to the entity the user is
+// expected to answer with. Checked in order, the first match wins, so the more
+// specific cues come first.
+var speechEntityCues = []struct {
+	entity string
+	cues   []string
+}{
+	{internal_type.SpeechEntitySpelling, []string{"spell", "letter by letter"}},
+	{internal_type.SpeechEntityEmail, []string{"email", "e-mail"}},
+	{internal_type.SpeechEntityDigits, []string{
+		"phone number", "number", "digits", "pin", "otp", "code", "zip", "postcode", "account id", "order id",
+	}},
+	{internal_type.SpeechEntityAddress, []string{"address", "street", "where do you live"}},
+}
+
+// englishSpeechCues reports whether speechEntityCues apply to the
+// conversation. They are english, so the listen.language of the speech to
+// text, or else the language of the assistant, has to be english.
+func (talking *genericRequestor) englishSpeechCues() bool {
+	var language string
+	if transformerConfig, _ := talking.GetSpeechToTextTransformer(); transformerConfig != nil {
+		language, _ = transformerConfig.GetOptions().GetString("listen.language")
+	}
+	if language == "" && talking.assistant != nil {
+		language = talking.assistant.Language
+	}
+	language = strings.ToLower(strings.TrimSpace(language))
+	return language == "" || strings.HasPrefix(language, "en")
+}
+
+// expectedSpeechEntity guesses from the assistant's last response which kind
+// of entity the user will say next. Only questions are considered, statements
+// mentioning an email or a number don't expect an answer.
+func expectedSpeechEntity(text string) string {
+	text = strings.ToLower(text)
+	idx := strings.LastIndex(text, "?")
+	if idx < 0 {
+		return ""
+	}
+	// the last question of the response
+	question := text[:idx]
+	if start := strings.LastIndexAny(question, ".!?\n"); start >= 0 {
+		question = question[start+1:]
+	}
+	for _, c := range speechEntityCues {
+		for _, cue := range c.cues {
+			if containsWord(question, cue) {
+				return c.entity
+			}
+		}
+	}
+	return ""
+}
+
+// containsWord reports whether cue occurs in text on word boundaries.
+func containsWord(text, cue string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], cue)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(cue)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9'
+}
+
+// expectSpeechEntity records the entity of hint as the one expected next and
+// reports whether the speech to text has to be biased again, which is when
+// the expectation changed or the hint brings phrases. Hints come from the
+// talk loop and the LLM at the same time.
+func (talking *genericRequestor) expectSpeechEntity(hint internal_type.SpeechHintPacket) bool {
+	talking.speechEntityMu.Lock()
+	defer talking.speechEntityMu.Unlock()
+	if hint.Entity == talking.speechEntity && len(hint.Phrases) == 0 {
+		return false
+	}
+	talking.speechEntity = hint.Entity
+	return true
+}
+
+// expectedEntity returns the entity the speech to text is biased towards.
+func (talking *genericRequestor) expectedEntity() string {
+	talking.speechEntityMu.Lock()
+	defer talking.speechEntityMu.Unlock()
+	return talking.speechEntity
+}
+
+// callSpeechToTextBias forwards a hint to the speech to text transformer when
+// the provider supports changing its biasing mid stream.
+func (talking *genericRequestor) callSpeechToTextBias(ctx context.Context, hint internal_type.SpeechHintPacket) {
+	biaser, ok := talking.speechToTextTransformer.(internal_type.SpeechToTextBiaser)
+	if !ok {
+		return
+	}
+	utils.Go(ctx, func() {
+		if err := biaser.Bias(ctx, hint); err != nil {
+			talking.logger.Warnf("unable to bias speech to text for %q: %v", hint.Entity, err)
+		}
+	})
+}
@@ -13,6 +13,9 @@ import (
 
 	internal_adapter_request_customizers "github.com/rapidaai/api/assistant-api/internal/adapters/customizers"
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_interruption "github.com/rapidaai/api/assistant-api/internal/interruption"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+	internal_supervision "github.com/rapidaai/api/assistant-api/internal/supervision"
 	internal_adapter_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
 	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
@@ -35,7 +38,8 @@ func (talking *genericRequestor) callEndOfSpeech(ctx context.Context, vl interna
 }
 
 func (talking *genericRequestor) callTextAggregator(ctx context.Context, vl internal_type.Packet) error {
-	if talking.textAggregator != nil {
+	// a text chat streams the tokens as they come, there is nothing to speak
+	if talking.textAggregator != nil && !talking.chatting() {
 		if err := talking.textAggregator.Aggregate(ctx, vl); err != nil {
 			talking.logger.Debugf("unable to send packet to aggregator %v", err)
 		}
@@ -46,6 +50,9 @@ func (talking *genericRequestor) callTextAggregator(ctx context.Context, vl inte
 
 func (talking *genericRequestor) callRecording(ctx context.Context, vl internal_type.Packet) error {
 	if talking.recorder != nil {
+		if talking.redactedRecording(vl) {
+			return nil
+		}
 		if err := talking.recorder.Record(ctx, vl); err != nil {
 			talking.logger.Errorf("recorder error: %v", err)
 		}
@@ -75,10 +82,15 @@ func (talking *genericRequestor) callVadProcess(ctx context.Context, vl internal
 }
 
 func (talking *genericRequestor) callSpeechToText(ctx context.Context, vl internal_type.UserAudioPacket) error {
+	if talking.opusSpeechToText() != nil {
+		// the transformer hears the caller's opus from the streamer
+		return nil
+	}
 	if talking.speechToTextTransformer != nil {
 		utils.Go(ctx, func() {
 			if err := talking.speechToTextTransformer.Transform(ctx, vl); err != nil {
 				talking.logger.Tracef(ctx, "error while transforming input %s and error %s", talking.speechToTextTransformer.Name(), err.Error())
+				talking.providerFailed(internal_runtimemetrics.SpeechToText)
 			}
 		})
 	}
@@ -106,12 +118,20 @@ func (spk *genericRequestor) interruptAllProvider(ctx context.Context, result in
 }
 
 func (spk *genericRequestor) callSpeaking(ctx context.Context, result internal_type.LLMPacket) error {
+	if spk.onHold.Load() {
+		// nobody is listening, don't synthesize
+		return nil
+	}
 	switch res := result.(type) {
 	case internal_type.LLMResponseDonePacket:
 		if spk.textToSpeechTransformer != nil && spk.messaging.GetMode().Audio() {
 			if result.ContextId() != spk.messaging.GetID() {
 				return nil
 			}
+			// a cut answer is completed by its check-in
+			if spk.monologueCapped(res.ContextID) {
+				return nil
+			}
 			ctx, span, _ := spk.Tracer().StartSpan(ctx, utils.AssistantSpeakingStage)
 			defer span.EndSpan(ctx, utils.AssistantSpeakingStage)
 			span.AddAttributes(ctx,
@@ -120,6 +140,7 @@ func (spk *genericRequestor) callSpeaking(ctx context.Context, result internal_t
 			)
 			if err := spk.textToSpeechTransformer.Transform(ctx, res); err != nil {
 				spk.logger.Errorf("speak: failed to send flush to text to speech transformer error: %v", err)
+				spk.speechFailed(ctx, err)
 			}
 			return nil
 		}
@@ -139,8 +160,13 @@ func (spk *genericRequestor) callSpeaking(ctx context.Context, result internal_t
 				internal_adapter_telemetry.KV{K: "activity", V: internal_adapter_telemetry.StringValue("speak")},
 				internal_adapter_telemetry.KV{K: "script", V: internal_adapter_telemetry.StringValue(res.Text)},
 			)
-			if err := spk.textToSpeechTransformer.Transform(ctx, res); err != nil {
-				spk.logger.Errorf("speak: failed to send flush to text to speech transformer error: %v", err)
+			// past the length limit the caller no longer hears the answer, the
+			// text still goes out
+			if !spk.monologueCapped(res.ContextID) {
+				if err := spk.textToSpeechTransformer.Transform(ctx, res); err != nil {
+					spk.logger.Errorf("speak: failed to send flush to text to speech transformer error: %v", err)
+					spk.speechFailed(ctx, err)
+				}
 			}
 			if err := spk.Notify(ctx, &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: res.ContextId(), Completed: true, Message: &protos.ConversationAssistantMessage_Text{Text: res.Text}}); err != nil {
 				spk.logger.Tracef(ctx, "error while outputting chunk to the user: %w", err)
@@ -155,14 +181,61 @@ func (spk *genericRequestor) callSpeaking(ctx context.Context, result internal_t
 	return nil
 }
 
+// SendsDTMF reports whether the channel of the conversation plays key
+// presses to the remote party.
+func (talking *genericRequestor) SendsDTMF() bool {
+	channel, ok := talking.streamer.(internal_type.DTMFChannel)
+	return ok && channel.SendsDTMF()
+}
+
+// HoldsCall reports whether the channel of the conversation can put the
+// remote party on hold.
+func (talking *genericRequestor) HoldsCall() bool {
+	channel, ok := talking.streamer.(internal_type.HoldChannel)
+	return ok && channel.HoldsCall()
+}
+
 func (talking *genericRequestor) callDirective(ctx context.Context, vl internal_type.DirectivePacket) error {
 	anyArgs, _ := utils.InterfaceMapToAnyMap(vl.Arguments)
 	switch vl.Directive {
 	case protos.ConversationDirective_END_CONVERSATION:
+		talking.revokeResume()
 		if err := talking.Notify(ctx, &protos.ConversationDirective{Id: vl.ContextID, Type: vl.Directive, Args: anyArgs, Time: timestamppb.Now()}); err != nil {
 			talking.logger.Errorf("error notifying end conversation action: %v", err)
 		}
 		return nil
+	case protos.ConversationDirective_SEND_DTMF:
+		if err := talking.Notify(ctx, &protos.ConversationDirective{Id: vl.ContextID, Type: vl.Directive, Args: anyArgs, Time: timestamppb.Now()}); err != nil {
+			talking.logger.Errorf("error notifying send dtmf action: %v", err)
+		}
+		return nil
+	case protos.ConversationDirective_HOLD_CONVERSATION:
+		if err := talking.Notify(ctx, &protos.ConversationDirective{Id: vl.ContextID, Type: vl.Directive, Args: anyArgs, Time: timestamppb.Now()}); err != nil {
+			talking.logger.Errorf("error notifying hold conversation action: %v", err)
+		}
+		return nil
+	case protos.ConversationDirective_TRANSFER_CONVERSATION:
+		// screen-pop the caller on the agent's desktop before the agent answers
+		to, _ := vl.Arguments["to"].(string)
+		talking.OnTransferringConversation(ctx, to, vl.Arguments)
+		if err := talking.Notify(ctx, &protos.ConversationDirective{Id: vl.ContextID, Type: vl.Directive, Args: anyArgs, Time: timestamppb.Now()}); err != nil {
+			talking.logger.Errorf("error notifying transfer conversation action: %v", err)
+			return nil
+		}
+		// the channel is transferring now and plays what is spoken next to the
+		// human agent instead of the caller
+		if whisper, _ := vl.Arguments["whisper"].(string); whisper != "" {
+			if err := talking.callSpeaking(ctx, internal_type.LLMResponseDeltaPacket{ContextID: talking.messaging.GetID(), Text: whisper}); err != nil {
+				talking.logger.Errorf("error speaking transfer whisper: %v", err)
+			}
+			if err := talking.callSpeaking(ctx, internal_type.LLMResponseDonePacket{ContextID: talking.messaging.GetID()}); err != nil {
+				talking.logger.Errorf("error speaking transfer whisper: %v", err)
+			}
+		}
+		return nil
+	case protos.ConversationDirective_HANDOFF_CONVERSATION:
+		talking.handoff(ctx, vl)
+		return nil
 	default:
 	}
 	return nil
@@ -170,21 +243,50 @@ func (talking *genericRequestor) callDirective(ctx context.Context, vl internal_
 
 /**/
 func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_type.Packet) error {
-	for _, p := range pkts {
+	for i, p := range pkts {
+		talking.logEvent(p)
 		switch vl := p.(type) {
 		case internal_type.UserTextPacket:
 			// interrupting
-			talking.OnPacket(ctx, internal_type.InterruptionPacket{ContextID: vl.ContextID, Source: internal_type.InterruptionSourceWord})
+			talking.OnPacket(ctx, internal_type.InterruptionPacket{ContextID: vl.ContextID, Source: internal_type.InterruptionSourceWord, Explicit: true})
 
 			// add new ID for user text message
 			vl.ContextID = talking.messaging.GetID()
 
-			// calling end of speech analyzer
+			// a typed message is a complete turn in a text chat, calling end of
+			// speech analyzer otherwise
+			if talking.chatting() {
+				talking.OnPacket(ctx, internal_type.EndOfSpeechPacket{ContextID: talking.messaging.GetID(), Speech: vl.Text})
+				continue
+			}
 			if err := talking.callEndOfSpeech(ctx, vl); err != nil {
 				talking.OnPacket(ctx, internal_type.EndOfSpeechPacket{ContextID: talking.messaging.GetID(), Speech: vl.Text})
 			}
 			continue
 
+		case internal_type.UserTypingPacket:
+			talking.setUserTyping(ctx, vl)
+			continue
+
+		case internal_type.SupervisorBargePacket:
+			talking.setSupervisorBarge(ctx, vl)
+			continue
+
+		case internal_type.SupervisorSpeechPacket:
+			talking.supervisorSpeaks(ctx, vl)
+			continue
+
+		case internal_type.SupervisorAudioPacket:
+			talking.supervisorPlays(ctx, vl)
+			continue
+
+		case internal_type.UserDTMFPacket:
+			// a key press is a complete user turn, it interrupts the assistant
+			// and goes to the executor without end of speech analysis
+			talking.OnPacket(ctx, internal_type.InterruptionPacket{ContextID: vl.ContextID, Source: internal_type.InterruptionSourceWord, Explicit: true})
+			talking.OnPacket(ctx, internal_type.EndOfSpeechPacket{ContextID: talking.messaging.GetID(), Speech: "[DTMF] " + vl.Digit})
+			continue
+
 		case internal_type.UserAudioPacket:
 			if talking.denoiser != nil && !vl.NoiseReduced {
 				vl.NoiseReduced = true
@@ -202,14 +304,21 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 			if err := talking.callRecording(ctx, vl); err != nil {
 				talking.logger.Errorf("recorder error: %v", err)
 			}
+			talking.supervisorHears(internal_supervision.SourceCaller, vl.Audio)
+			talking.conferenceHears(vl.Audio)
 
-			if err := talking.callVadProcess(ctx, vl); err != nil {
-				talking.logger.Errorf("VAD process error: %v", err)
+			// comfort audio during hold must not be taken for the user speaking,
+			// neither must an answering machine while the voicemail is left
+			if !talking.onHold.Load() && !talking.leavingVoicemail() {
+				if err := talking.callVadProcess(ctx, vl); err != nil {
+					talking.logger.Errorf("VAD process error: %v", err)
+				}
 			}
 
 			if err := talking.callSpeechToText(ctx, vl); err != nil {
 				talking.logger.Errorf("speech to text transform error: %v", err)
 			}
+			talking.diarizationHears(vl.Audio)
 			continue
 		case internal_type.StaticPacket:
 			// when static packet is received it means that rapida system has something to speak
@@ -249,12 +358,34 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 
 			continue
 		case internal_type.InterruptionPacket:
+			talking.meterCaller(vl)
+			talking.latency.Voiced(vl.CapturedAt)
+
+			// speech to text sends the transcript behind a word interruption
+			// right after it, an acknowledgement must not cut the assistant
+			if vl.Source == internal_type.InterruptionSourceWord && i+1 < len(pkts) {
+				if stt, ok := pkts[i+1].(internal_type.SpeechToTextPacket); ok && talking.isBackchannel(stt) {
+					continue
+				}
+			}
+
 			ctx, span, _ := talking.Tracer().StartSpan(ctx, utils.AssistantUtteranceStage)
 			defer span.EndSpan(ctx, utils.AssistantUtteranceStage)
 
-			switch vl.Source {
-			case internal_type.InterruptionSourceWord:
-				span.AddAttributes(ctx, internal_telemetry.KV{K: "activity_type", V: internal_telemetry.StringValue("word_interrupt")})
+			action := talking.interruption.Decide(vl.Source)
+			// might be noise at first
+			if vl.Source != internal_type.InterruptionSourceWord && vl.StartAt < 5 {
+				continue
+			}
+			// too short or too soon after the last cut to be the caller taking
+			// the turn
+			if action == internal_interruption.ActionCut && !talking.cutAllowed(vl) {
+				action = talking.interruption.Withheld()
+			}
+
+			switch action {
+			case internal_interruption.ActionCut:
+				span.AddAttributes(ctx, internal_telemetry.KV{K: "activity_type", V: internal_telemetry.StringValue(string(vl.Source) + "_interrupt")})
 				talking.resetIdleTimeoutTimer(ctx)
 
 				// calling end of speech analyzer
@@ -265,6 +396,14 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 				if err := talking.messaging.Transition(internal_adapter_request_customizers.Interrupted); err != nil {
 					continue
 				}
+				talking.markCut()
+				talking.restoreSpeech()
+				talking.setAssistantTyping(ctx, false)
+				talking.endPlayback()
+				if tap := talking.supervision.Load(); tap != nil {
+					tap.Interrupt()
+				}
+				talking.conferenceInterrupted()
 
 				// Truncate system audio in the recorder to mirror the streamer's
 				// ClearOutputBuffer — audio buffered beyond this moment was never
@@ -277,17 +416,15 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 					talking.logger.Errorf("interrupt all provider error: %v", err)
 				}
 				//
-				// notify interruption without waiting
+				// notify interruption without waiting, streamers discard their
+				// queued audio on a word interruption whatever cut the speech
 				utils.Go(ctx, func() {
 					talking.Notify(ctx, &protos.ConversationInterruption{Type: protos.ConversationInterruption_INTERRUPTION_TYPE_WORD, Time: timestamppb.Now()})
 				})
 
 				continue
 			default:
-				// might be noise at first
-				if vl.StartAt < 5 {
-					continue
-				}
+				talking.markVoiceOnset()
 
 				// calling end of speech analyzer
 				if err := talking.callEndOfSpeech(ctx, vl); err != nil {
@@ -295,6 +432,10 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 				}
 				//
 
+				if action == internal_interruption.ActionDuck {
+					talking.duckSpeech()
+				}
+
 				span.AddAttributes(ctx, internal_telemetry.KV{K: "activity_type", V: internal_telemetry.StringValue("vad_interrupt")})
 				if err := talking.messaging.Transition(internal_adapter_request_customizers.Interrupt); err != nil {
 					continue
@@ -319,8 +460,24 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 					V: internal_telemetry.BoolValue(!vl.Interim),
 				})
 			defer span.EndSpan(ctx, utils.AssistantListeningStage)
+			if talking.onHold.Load() || talking.leavingVoicemail() {
+				continue
+			}
+			if talking.isBackchannel(vl) {
+				// the caller only acknowledged the assistant, let it talk on
+				talking.restoreSpeech()
+				if !vl.Interim {
+					talking.clearVoiceOnset()
+				}
+				span.AddAttributes(ctx, internal_telemetry.KV{K: "activity_type", V: internal_telemetry.StringValue("backchannel")})
+				continue
+			}
 			// later move the contextID with audio
 			vl.ContextID = talking.messaging.GetID()
+			talking.diarize(&vl)
+			talking.snapshotHeard(vl)
+			talking.recognitionHeard(vl)
+			talking.languageHeard(ctx, vl)
 			//
 			if err := talking.callEndOfSpeech(ctx, vl); err != nil {
 				if !vl.Interim {
@@ -330,6 +487,7 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 			continue
 		case internal_type.InterimEndOfSpeechPacket:
 			talking.Notify(ctx, &protos.ConversationUserMessage{Id: vl.ContextID, Message: &protos.ConversationUserMessage_Text{Text: vl.Speech}, Completed: false, Time: timestamppb.New(time.Now())})
+			talking.publishTranscript(vl.ContextID, "user", "", vl.Speech, false)
 			continue
 		case internal_type.EndOfSpeechPacket:
 			ctx, span, _ := talking.Tracer().StartSpan(ctx, utils.AssistantUtteranceStage)
@@ -341,6 +499,14 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 
 			// stop idle timeout as bot has started responding
 			talking.stopIdleTimeoutTimer()
+			talking.clearVoiceOnset()
+
+			// while dictating the assistant stays quiet until the dictation ends
+			userText, dictated := talking.dictate(ctx, vl)
+			if !dictated {
+				continue
+			}
+			talking.latency.Transcribed(vl.ContextID, time.Now())
 
 			if err := talking.messaging.Transition(internal_adapter_request_customizers.LLMGenerating); err != nil {
 				talking.logger.Errorf("messaging transition error: %v", err)
@@ -351,14 +517,24 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 				talking.logger.Tracef(ctx, "might be returing processing the duplicate message so cut it out.")
 				continue
 			}
+			talking.snapshotTurnEnded(ctx, vl.ContextID)
+			speaker := talking.recognitionTurnEnded(ctx, vl.ContextID)
+			userText = talking.spelledSpeech(userText)
 			utils.Go(ctx, func() {
-				if err := talking.onCreateMessage(ctx, internal_type.UserTextPacket{ContextID: vl.ContextID, Text: vl.Speech}); err != nil {
+				if err := talking.onCreateMessage(ctx, internal_type.UserTextPacket{ContextID: vl.ContextID, Text: userText, Speaker: speaker}); err != nil {
 					talking.logger.Errorf("Error in onCreateMessage: %v", err)
 				}
 			})
 
+			// a supervisor who barged in answers the caller, not the assistant
+			if talking.barged() {
+				talking.supervisorAnswers(ctx, userText)
+				continue
+			}
+			talking.setAssistantTyping(ctx, true)
+
 			//
-			if err := talking.assistantExecutor.Execute(ctx, talking, internal_type.UserTextPacket{ContextID: vl.ContextID, Text: vl.Speech}); err != nil {
+			if err := talking.assistantExecutor.Execute(ctx, talking, internal_type.UserTextPacket{ContextID: vl.ContextID, Text: userText}); err != nil {
 				talking.logger.Errorf("assistant executor error: %v", err)
 				talking.OnError(ctx)
 				continue
@@ -370,9 +546,11 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 				continue
 			}
 
+			talking.latency.FirstToken(vl.ContextID, time.Now())
 			if err := talking.messaging.Transition(internal_adapter_request_customizers.LLMGenerating); err != nil {
 				talking.logger.Errorf("messaging transition error: %v", err)
 			}
+			talking.publishTranscript(vl.ContextID, "assistant", "", vl.Text, false)
 			// sending to aggregator for assembling sentences
 			if err := talking.callTextAggregator(ctx, vl); err != nil {
 				if err := talking.callSpeaking(ctx, vl); err != nil {
@@ -398,12 +576,21 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 			if err := talking.callCreateMessage(ctx, vl); err != nil {
 				talking.logger.Errorf("error creating message: %v", err)
 			}
+			var entity string
+			if talking.englishSpeechCues() {
+				entity = expectedSpeechEntity(vl.Text)
+			}
+			if talking.spelling.Load() != nil {
+				entity = internal_type.SpeechEntitySpelling
+			}
+			talking.OnPacket(ctx, internal_type.SpeechHintPacket{ContextID: vl.ContextID, Entity: entity})
 
 			if err := talking.callTextAggregator(ctx, vl); err != nil {
 				if err := talking.callSpeaking(ctx, vl); err != nil {
 					talking.logger.Errorf("speaking error: %v", err)
 				}
 			}
+			talking.setAssistantTyping(ctx, false)
 
 			continue
 
@@ -411,6 +598,33 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 			talking.callDirective(ctx, vl)
 			continue
 
+		case internal_type.SpellingModePacket:
+			talking.setSpellingMode(vl)
+			continue
+
+		case internal_type.DictationModePacket:
+			talking.setDictationMode(ctx, vl)
+			continue
+
+		case internal_type.SpeakingProfilePacket:
+			talking.setSpeakingProfile(ctx, vl)
+			continue
+
+		case internal_type.CallHoldPacket:
+			talking.setCallHold(ctx, vl)
+			continue
+
+		case internal_type.CallPickupPacket:
+			talking.callPickedUp(ctx, vl)
+			continue
+
+		case internal_type.SpeechHintPacket:
+			// only re-bias when the expectation changes
+			if talking.expectSpeechEntity(vl) {
+				talking.callSpeechToTextBias(ctx, vl)
+			}
+			continue
+
 		case internal_type.ConversationMetricPacket:
 			// store the conversation metrics
 			utils.Go(ctx, func() {
@@ -451,7 +665,7 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 			if err := talking.Notify(ctx, &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: vl.ContextID, Completed: true}); err != nil {
 				talking.logger.Tracef(ctx, "error while outputing chunk to the user: %w", err)
 			}
-
+			talking.voicemailSpoken(ctx)
 			continue
 		case internal_type.TextToSpeechAudioPacket:
 
@@ -467,9 +681,26 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 				continue
 			}
 
-			// notify the user about audio chunk
-			if err := talking.Notify(ctx, &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: vl.ContextID, Message: &protos.ConversationAssistantMessage_Audio{Audio: vl.AudioChunk}, Completed: false}); err != nil {
-				talking.logger.Tracef(ctx, "error while outputing chunk to the user: %w", err)
+			chunkDuration := time.Duration(internal_audio.GetAudioInfo(vl.AudioChunk, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG).DurationMs) * time.Millisecond
+			if !talking.monologueAllows(ctx, vl, chunkDuration) {
+				continue
+			}
+
+			// the user may be barging in, keep the speech down until it is clear
+			if talking.speechDucked() {
+				vl.AudioChunk = talking.ducking.Apply(vl.AudioChunk)
+			}
+			talking.extendPlayback(chunkDuration)
+			talking.supervisorHears(internal_supervision.SourceAssistant, vl.AudioChunk)
+			talking.speechMonitor.Success()
+			talking.turnLatency(ctx, vl.ContextID)
+
+			// notify the user about audio chunk, in a conference the caller
+			// hears it mixed with the agents
+			if !talking.conferenceSpeaks(vl.AudioChunk) {
+				if err := talking.Notify(ctx, &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: vl.ContextID, Message: &protos.ConversationAssistantMessage_Audio{Audio: vl.AudioChunk}, Completed: false}); err != nil {
+					talking.logger.Tracef(ctx, "error while outputing chunk to the user: %w", err)
+				}
 			}
 
 			// for recording puposes
@@ -501,6 +732,12 @@ func (talking *genericRequestor) OnPacket(ctx context.Context, pkts ...internal_
 			})
 			continue
 
+		case internal_type.LLMErrorPacket:
+			talking.providerFailed(internal_runtimemetrics.LLM)
+			talking.setAssistantTyping(ctx, false)
+			talking.logger.Errorf("llm provider error for %s: %v", vl.ContextID, vl.Error)
+			continue
+
 		default:
 			talking.logger.Warnf("unknown packet type received in OnGeneration %T", vl)
 		}
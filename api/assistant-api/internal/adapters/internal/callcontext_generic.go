@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+)
+
+// saveChannelUUID records the provider's id of the call on its call context
+// when the channel only learns it after the call was set up.
+func (talking *genericRequestor) saveChannelUUID(channelUUID string) {
+	streamer, ok := talking.streamer.(callContextStreamer)
+	if !ok || streamer.CallContext() == nil || talking.callContextStore == nil || channelUUID == "" {
+		return
+	}
+	cc := streamer.CallContext()
+	if cc.ChannelUUID == channelUUID {
+		return
+	}
+	dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
+	defer cancel()
+	if err := talking.callContextStore.UpdateField(dbCtx, cc.ContextID, "channel_uuid", channelUUID); err != nil {
+		talking.logger.Warnf("unable to save the channel of call context %s: %v", cc.ContextID, err)
+	}
+}
+
+// saveCallOutcome records how the call ended on its call context.
+func (talking *genericRequestor) saveCallOutcome(outcome string) {
+	streamer, ok := talking.streamer.(callContextStreamer)
+	if !ok || streamer.CallContext() == nil || talking.callContextStore == nil {
+		return
+	}
+	cc := streamer.CallContext()
+	dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
+	defer cancel()
+	if err := talking.callContextStore.UpdateField(dbCtx, cc.ContextID, "outcome", outcome); err != nil {
+		talking.logger.Warnf("unable to save the outcome of call context %s: %v", cc.ContextID, err)
+	}
+}
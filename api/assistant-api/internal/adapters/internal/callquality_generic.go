@@ -0,0 +1,97 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"time"
+
+	internal_callquality "github.com/rapidaai/api/assistant-api/internal/callquality"
+	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// initializeCallQuality samples the media statistics of the streamer while the
+// call runs and stores the estimated MOS of every leg with the conversation
+// metrics. Text channels have no media statistics and are not scored.
+func (r *genericRequestor) initializeCallQuality(ctx context.Context) {
+	source, ok := r.streamer.(internal_callquality.Source)
+	if !ok || r.callQuality != nil {
+		return
+	}
+	r.callQuality = internal_callquality.NewLegs()
+	r.callQualityStop = make(chan struct{})
+
+	legs, stop := r.callQuality, r.callQualityStop
+	utils.Go(ctx, func() {
+		ticker := time.NewTicker(internal_callquality.SampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.sampleCallQuality(ctx, source, legs)
+			}
+		}
+	})
+}
+
+// sampleCallQuality scores the interval since the previous sample of the
+// caller's leg and of any leg the caller is bridged to.
+func (r *genericRequestor) sampleCallQuality(ctx context.Context, source internal_callquality.Source, legs *internal_callquality.Legs) {
+	samples := make(map[string]internal_callquality.Sample)
+	if sample, ok := source.CallQuality(); ok {
+		samples[internal_callquality.LegCaller] = sample
+	}
+	if bridged, ok := source.(internal_callquality.LegSource); ok {
+		for leg, sample := range bridged.CallQualityLegs() {
+			samples[leg] = sample
+		}
+	}
+	for leg, sample := range samples {
+		report, ok := legs.Add(leg, sample)
+		if !ok {
+			continue
+		}
+		r.logger.Debugf("call quality of the %s leg: mos %.2f, loss %.4f, jitter %s, rtt %s", leg, report.MOS, report.PacketLoss, report.Jitter, report.RoundTripTime)
+		r.onAddMetrics(ctx, report.LegMetrics(leg)...)
+	}
+}
+
+// finishCallQuality stops the sampling and scores the last interval of the
+// call. The score of each leg is kept on the conversation record and returned
+// as attributes of the disconnect span, the caller's without a leg name.
+func (r *genericRequestor) finishCallQuality(ctx context.Context) []internal_telemetry.KV {
+	if r.callQuality == nil {
+		return nil
+	}
+	close(r.callQualityStop)
+	legs := r.callQuality
+	r.callQuality = nil
+
+	if source, ok := r.streamer.(internal_callquality.Source); ok {
+		r.sampleCallQuality(ctx, source, legs)
+	}
+	var attributes []internal_telemetry.KV
+	for leg, report := range legs.Reports() {
+		if err := r.onAddMetadata(ctx, report.Metadata(leg)...); err != nil {
+			r.logger.Warnf("unable to record the call quality of the %s leg: %v", leg, err)
+		}
+		prefix := "call_quality."
+		if leg != internal_callquality.LegCaller {
+			prefix += leg + "."
+		}
+		attributes = append(attributes,
+			internal_telemetry.KV{K: prefix + "mos_average", V: internal_telemetry.FloatValue(report.AverageMOS)},
+			internal_telemetry.KV{K: prefix + "mos_min", V: internal_telemetry.FloatValue(report.MinMOS)},
+			internal_telemetry.KV{K: prefix + "samples", V: internal_telemetry.IntValue(report.Samples)},
+		)
+	}
+	return attributes
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_cdr "github.com/rapidaai/api/assistant-api/internal/cdr"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+)
+
+// CustomMetadata returns the metadata tools and clients attach to the
+// conversation record.
+func (talking *genericRequestor) CustomMetadata() internal_type.CustomMetadata {
+	return talking.customMetadata
+}
+
+// initializeCustomMetadata picks up the custom entries a resumed
+// conversation or the client's initialization already carries and stores
+// every new one with the conversation metadata.
+func (talking *genericRequestor) initializeCustomMetadata() {
+	talking.customMetadata = internal_cdr.NewMetadata(talking.metadata, func(ctx context.Context, metadata *protos.Metadata) error {
+		return talking.onAddMetadata(ctx, metadata)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"strings"
+
+	internal_cluster "github.com/rapidaai/api/assistant-api/internal/cluster"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+)
+
+// initializeCluster makes the session reachable by control requests and
+// provider callbacks landing on any instance while it is connected.
+func (r *genericRequestor) initializeCluster() {
+	if r.unregisterCluster != nil {
+		r.unregisterCluster()
+	}
+	callID := ""
+	if streamer, ok := r.streamer.(callContextStreamer); ok && streamer.CallContext() != nil {
+		callID = streamer.CallContext().ChannelUUID
+	}
+	r.unregisterCluster = internal_cluster.Register(r.assistantConversation.Id, callID, r)
+}
+
+// closeCluster takes the session out of the cluster.
+func (r *genericRequestor) closeCluster() {
+	if r.unregisterCluster != nil {
+		r.unregisterCluster()
+		r.unregisterCluster = nil
+	}
+}
+
+// Direct implements internal_cluster.Session. The directive runs on the talk
+// loop like one the assistant gave, it outlives the request it came with.
+func (r *genericRequestor) Direct(ctx context.Context, directive internal_type.DirectivePacket) error {
+	if directive.ContextID == "" {
+		directive.ContextID = r.messaging.GetID()
+	}
+	return r.OnPacket(r.streamer.Context(), directive)
+}
+
+// StatusCallback implements internal_cluster.Session. A call its provider
+// reports ended ends the session, its media stream may not have closed.
+func (r *genericRequestor) StatusCallback(ctx context.Context, provider, event string) {
+	if !callEnded(event) {
+		return
+	}
+	r.logger.Infof("%s reported call of conversation %d %s, ending the session", provider, r.assistantConversation.Id, event)
+	if err := r.OnPacket(r.streamer.Context(), internal_type.DirectivePacket{
+		ContextID: r.messaging.GetID(),
+		Directive: protos.ConversationDirective_END_CONVERSATION,
+		Arguments: map[string]interface{}{"reason": provider + " reported the call " + event},
+	}); err != nil {
+		r.logger.Errorf("error ending the session of an ended call: %v", err)
+	}
+}
+
+// callEnded reports whether a provider status is the end of the call.
+func callEnded(event string) bool {
+	switch strings.ToLower(event) {
+	case "completed", "failed", "busy", "no-answer", "canceled", "cancelled", "rejected", "unanswered", "timeout",
+		"hangup", "channel_destroyed", "channel_hangup_complete", "stasisend":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"errors"
+
+	channel_conference "github.com/rapidaai/api/assistant-api/internal/channel/conference"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// callerQueueFrames bounds the caller's audio waiting for the conference
+// clock, channels hand it over in chunks of several frames.
+const callerQueueFrames = 25
+
+// conferenceCall is the conference of a call human agents joined.
+type conferenceCall struct {
+	room      *channel_conference.Conference
+	caller    *channel_conference.Participant
+	assistant *channel_conference.Participant
+	cancel    context.CancelFunc
+}
+
+// JoinConference implements channel_conference.Session. The first agent
+// turns the call into a conference: from then on the caller hears the
+// assistant and the agents mixed on the conference clock instead of the
+// assistant alone.
+func (r *genericRequestor) JoinConference(ctx context.Context, id string, sink channel_conference.Sink, maxQueue int) (*channel_conference.Conference, *channel_conference.Participant, func(), error) {
+	r.conferenceMu.Lock()
+	defer r.conferenceMu.Unlock()
+	if r.streamer.Context().Err() != nil {
+		return nil, nil, nil, errors.New("conversation has ended")
+	}
+	if !r.messaging.GetMode().Audio() {
+		return nil, nil, nil, errors.New("only audio conversations can be joined")
+	}
+	call := r.conference.Load()
+	if call == nil {
+		call = r.startConference()
+	}
+	participant := call.room.Join(id, channel_conference.RoleAgent, sink, maxQueue)
+	r.logger.Infof("agent %s joined the conference of conversation %d", id, r.assistantConversation.Id)
+	r.OnPacket(r.streamer.Context(), internal_type.ConversationMetadataPacket{
+		ContextID: r.assistantConversation.Id,
+		Metadata:  []*protos.Metadata{{Key: "conference.agent", Value: id}},
+	})
+	return call.room, participant, func() { r.leaveConference(call, participant) }, nil
+}
+
+// startConference starts the conference clock of the call,
+// r.conferenceMu is held.
+func (r *genericRequestor) startConference() *conferenceCall {
+	room := channel_conference.NewConference()
+	call := &conferenceCall{room: room}
+	call.caller = room.Join(string(channel_conference.RoleCaller), channel_conference.RoleCaller, conferenceCaller{r}, callerQueueFrames)
+	// the assistant hears the caller alone, through speech to text
+	call.assistant = room.Join(string(channel_conference.RoleAssistant), channel_conference.RoleAssistant, nil, 0)
+	ctx, cancel := context.WithCancel(r.streamer.Context())
+	call.cancel = cancel
+	utils.Go(ctx, func() {
+		room.Run(ctx)
+	})
+	r.conference.Store(call)
+	return call
+}
+
+// leaveConference takes an agent out of the conference, the last one
+// leaving turns it back into the call of the caller and the assistant.
+func (r *genericRequestor) leaveConference(call *conferenceCall, participant *channel_conference.Participant) {
+	r.conferenceMu.Lock()
+	defer r.conferenceMu.Unlock()
+	call.room.Leave(participant)
+	r.logger.Infof("agent %s left the conference of conversation %d", participant.ID, r.assistantConversation.Id)
+	if call.room.Count(channel_conference.RoleAgent) > 0 || r.conference.Load() != call {
+		return
+	}
+	r.conference.Store(nil)
+	rest := call.room.Drain(call.assistant)
+	call.room.Close()
+	call.cancel()
+	// what the assistant said that was not mixed yet goes to the caller as is
+	if len(rest) > 0 {
+		if err := r.Notify(r.streamer.Context(), &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: r.messaging.GetID(), Message: &protos.ConversationAssistantMessage_Audio{Audio: rest}, Completed: false}); err != nil {
+			r.logger.Tracef(r.streamer.Context(), "error while outputing chunk to the user: %v", err)
+		}
+	}
+}
+
+// closeConference ends the conference with the call, the agents are told.
+func (r *genericRequestor) closeConference() {
+	r.conferenceMu.Lock()
+	defer r.conferenceMu.Unlock()
+	if call := r.conference.Swap(nil); call != nil {
+		call.room.Close()
+		call.cancel()
+	}
+}
+
+// conferenceHears hands the caller's audio to the agents. Speech to text
+// keeps getting the caller alone.
+func (talking *genericRequestor) conferenceHears(audio []byte) {
+	if call := talking.conference.Load(); call != nil {
+		call.room.Push(call.caller, audio)
+	}
+}
+
+// conferenceSpeaks hands the assistant's audio to the conference, false
+// when the call is no conference and the caller hears it directly.
+func (talking *genericRequestor) conferenceSpeaks(audio []byte) bool {
+	call := talking.conference.Load()
+	if call == nil {
+		return false
+	}
+	call.room.Push(call.assistant, audio)
+	return true
+}
+
+// conferenceInterrupted drops the assistant's audio not mixed yet.
+func (talking *genericRequestor) conferenceInterrupted() {
+	if call := talking.conference.Load(); call != nil {
+		call.room.Clear(call.assistant)
+	}
+}
+
+// conferenceCaller is how the caller hears the conference, through the
+// channel of the call.
+type conferenceCaller struct {
+	r *genericRequestor
+}
+
+func (c conferenceCaller) Hear(frame []byte) {
+	if err := c.r.Notify(c.r.streamer.Context(), &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: c.r.messaging.GetID(), Message: &protos.ConversationAssistantMessage_Audio{Audio: frame}, Completed: false}); err != nil {
+		c.r.logger.Tracef(c.r.streamer.Context(), "error while outputing chunk to the user: %v", err)
+	}
+}
+
+// Speaker tells the client who speaks, the role or empty when nobody does.
+func (c conferenceCaller) Speaker(p *channel_conference.Participant) {
+	var role string
+	if p != nil {
+		role = string(p.Role)
+	}
+	if err := c.r.Notify(c.r.streamer.Context(), &protos.ConversationMetadata{
+		AssistantConversationId: c.r.assistantConversation.Id,
+		Metadata:                []*protos.Metadata{{Key: internal_type.MetadataKeyConferenceSpeaker, Value: role}},
+	}); err != nil {
+		c.r.logger.Tracef(c.r.streamer.Context(), "error while notifying the active speaker: %v", err)
+	}
+}
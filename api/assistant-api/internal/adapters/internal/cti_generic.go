@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	internal_cti "github.com/rapidaai/api/assistant-api/internal/cti"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// OnTransferringConversation sends the screen-pop of the caller to the
+// webhooks subscribed to conversation.transferring, before the human agent
+// the call is transferred to answers.
+func (talking *genericRequestor) OnTransferringConversation(ctx context.Context, to string, args map[string]interface{}) {
+	if talking.assistant == nil || talking.assistantConversation == nil {
+		return
+	}
+	var body map[string]interface{}
+	for _, webhook := range talking.assistant.AssistantWebhooks {
+		if !slices.Contains(webhook.AssistantEvents, utils.ConversationTransferring.Get()) {
+			continue
+		}
+		if body == nil {
+			body = talking.screenPop(to, args).Body()
+		}
+		talking.Webhook(ctx, utils.ConversationTransferring.Get(), body, webhook)
+	}
+}
+
+// screenPop collects who is calling, why and what was learnt so far.
+func (talking *genericRequestor) screenPop(to string, args map[string]interface{}) internal_cti.ScreenPop {
+	intent, _ := args["reason"].(string)
+	caller := internal_cti.Caller{Identifier: talking.assistantConversation.Identifier}
+	if streamer, ok := talking.streamer.(callContextStreamer); ok && streamer.CallContext() != nil {
+		cc := streamer.CallContext()
+		caller.Number = cc.CallerNumber
+		caller.Called = cc.CalleeNumber
+		caller.Direction = cc.Direction
+		caller.Provider = cc.Provider
+	}
+	var uiHost string
+	if talking.config != nil {
+		uiHost = talking.config.UiHost
+	}
+	return internal_cti.ScreenPop{
+		Event:          utils.ConversationTransferring.Get(),
+		AssistantID:    fmt.Sprintf("%d", talking.assistant.Id),
+		ConversationID: fmt.Sprintf("%d", talking.assistantConversation.Id),
+		TransferTo:     to,
+		Caller:         caller,
+		Intent:         intent,
+		Slots:          internal_cti.Slots(talking.CustomMetadata().All(), talking.Scratchpad().All()),
+		TranscriptURL:  internal_cti.TranscriptURL(uiHost, talking.assistant.Id, talking.assistantConversation.Id),
+		Time:           time.Now(),
+	}
+}
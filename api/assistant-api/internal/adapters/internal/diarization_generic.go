@@ -0,0 +1,39 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	internal_diarization "github.com/rapidaai/api/assistant-api/internal/diarization"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// initializeDiarization reads from the speech to text options of the
+// deployment whether the speakers of the caller's side are told apart.
+func (r *genericRequestor) initializeDiarization() {
+	if transformerConfig, _ := r.GetSpeechToTextTransformer(); transformerConfig != nil {
+		r.diarizer = internal_diarization.FromOptions(speechToTextOptions(transformerConfig))
+	}
+}
+
+// diarizationHears keeps the caller's audio until its transcript arrives.
+func (r *genericRequestor) diarizationHears(audio []byte) {
+	if r.diarizer != nil {
+		r.diarizer.Hear(audio)
+	}
+}
+
+// diarize labels a final transcript of the caller with its speaker, by the
+// voice it was heard in when the provider did not label it.
+func (r *genericRequestor) diarize(vl *internal_type.SpeechToTextPacket) {
+	if r.diarizer == nil || vl.Interim {
+		return
+	}
+	if vl.Speaker != "" {
+		r.diarizer.Reset()
+		return
+	}
+	vl.Speaker = r.diarizer.Assign()
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	internal_adapter_request_customizers "github.com/rapidaai/api/assistant-api/internal/adapters/customizers"
+	internal_dictation "github.com/rapidaai/api/assistant-api/internal/dictation"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// setDictationMode starts a dictation, or ends the running one when its
+// maximum duration passed and hands it to the model.
+func (talking *genericRequestor) setDictationMode(ctx context.Context, vl internal_type.DictationModePacket) {
+	if !vl.Enabled {
+		if text, ok := talking.endDictation(ctx, internal_dictation.EndedByMaxDuration); ok {
+			talking.executeDictation(ctx, text)
+		}
+		return
+	}
+
+	dictation := internal_dictation.New(vl.MaxDuration)
+	talking.dictationMu.Lock()
+	defer talking.dictationMu.Unlock()
+	if talking.dictationTimer != nil {
+		talking.dictationTimer.Stop()
+	}
+	talking.dictation = dictation
+	talking.dictationTimer = time.AfterFunc(dictation.MaxDuration(), func() {
+		talking.OnPacket(ctx, internal_type.DictationModePacket{ContextID: vl.ContextID, Enabled: false})
+	})
+	talking.logger.Infof("dictation started for up to %s", dictation.MaxDuration())
+}
+
+// dictate adds a user turn to the running dictation. It returns the text to
+// hand to the model and true once the turn ended the dictation, false while
+// the caller is still dictating. Without a dictation the speech is returned
+// as is.
+func (talking *genericRequestor) dictate(ctx context.Context, vl internal_type.EndOfSpeechPacket) (string, bool) {
+	talking.dictationMu.Lock()
+	dictation := talking.dictation
+	talking.dictationMu.Unlock()
+	if dictation == nil {
+		return vl.Speech, true
+	}
+
+	if dictation.Add(vl.Speech) {
+		return talking.endDictation(ctx, internal_dictation.EndedBySpeech)
+	}
+	if dictation.Expired(time.Now()) {
+		return talking.endDictation(ctx, internal_dictation.EndedByMaxDuration)
+	}
+	if err := talking.Notify(ctx,
+		&protos.ConversationUserMessage{Id: vl.ContextID, Message: &protos.ConversationUserMessage_Text{Text: vl.Speech}, Completed: true, Time: timestamppb.Now()}); err != nil {
+		talking.logger.Tracef(ctx, "error while notifying dictated speech: %v", err)
+	}
+	return "", false
+}
+
+// endDictation stops the running dictation, attaches it to the conversation
+// as metadata and returns the turn that hands it to the model.
+func (talking *genericRequestor) endDictation(ctx context.Context, endedBy string) (string, bool) {
+	talking.dictationMu.Lock()
+	dictation := talking.dictation
+	talking.dictation = nil
+	if talking.dictationTimer != nil {
+		talking.dictationTimer.Stop()
+		talking.dictationTimer = nil
+	}
+	if dictation != nil {
+		talking.dictations++
+	}
+	key := fmt.Sprintf("dictation.%d", talking.dictations)
+	talking.dictationMu.Unlock()
+	if dictation == nil {
+		return "", false
+	}
+
+	document := dictation.Close(endedBy, time.Now())
+	talking.logger.Infof("dictation ended by %s after %d words", endedBy, document.Words)
+	if value, err := json.Marshal(document); err == nil {
+		utils.Go(ctx, func() {
+			if err := talking.onAddMetadata(ctx, &protos.Metadata{Key: key, Value: string(value)}); err != nil {
+				talking.logger.Errorf("unable to attach dictation: %v", err)
+			}
+		})
+	}
+	if document.Text == "" {
+		return "[dictation] The user did not dictate anything, ask whether they still want to.", true
+	}
+	return fmt.Sprintf("[dictation] The user dictated %d words, saved to the conversation as %q:\n%s\n[dictation] Confirm it was captured, do not read it back unless asked.", document.Words, key, document.Text), true
+}
+
+// executeDictation hands a dictation that ended without a user turn to the
+// model.
+func (talking *genericRequestor) executeDictation(ctx context.Context, text string) {
+	contextID := talking.messaging.GetID()
+	if err := talking.messaging.Transition(internal_adapter_request_customizers.LLMGenerating); err != nil {
+		talking.logger.Errorf("messaging transition error: %v", err)
+	}
+	packet := internal_type.UserTextPacket{ContextID: contextID, Text: text}
+	utils.Go(ctx, func() {
+		if err := talking.onCreateMessage(ctx, packet); err != nil {
+			talking.logger.Errorf("Error in onCreateMessage: %v", err)
+		}
+	})
+	if err := talking.assistantExecutor.Execute(ctx, talking, packet); err != nil {
+		talking.logger.Errorf("assistant executor error: %v", err)
+		talking.OnError(ctx)
+	}
+}
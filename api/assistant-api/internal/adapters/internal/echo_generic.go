@@ -0,0 +1,28 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_echo_cancellation "github.com/rapidaai/api/assistant-api/internal/echo_cancellation"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// initializeEchoCancellation turns on echo cancellation in the streamer when
+// the deployment enables it. Only channels that both play and receive the
+// audio can cancel it, the others are left alone.
+func (listening *genericRequestor) initializeEchoCancellation(options utils.Option) {
+	channel, ok := listening.streamer.(internal_echo_cancellation.Channel)
+	if !ok {
+		return
+	}
+	cfg, ok := internal_echo_cancellation.FromOptions(options, int(internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG.GetSampleRate()))
+	if !ok {
+		return
+	}
+	listening.logger.Debugf("echo cancellation enabled, tail %s, max delay %s", cfg.Tail, cfg.MaxDelay)
+	channel.EnableEchoCancellation(cfg)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_eventlog "github.com/rapidaai/api/assistant-api/internal/eventlog"
+	internal_scratchpad "github.com/rapidaai/api/assistant-api/internal/scratchpad"
+	internal_telemetry_batch "github.com/rapidaai/api/assistant-api/internal/telemetry/batch"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// initializeEventLog continues the event log of the conversation, a resumed
+// conversation after its latest entry. Entries of an encrypted conversation
+// are sealed with its data key; when the key cannot be read nothing is
+// logged rather than logging in plaintext.
+func (r *genericRequestor) initializeEventLog(ctx context.Context) {
+	if r.eventLog != nil || r.assistant == nil || r.assistantConversation == nil {
+		return
+	}
+	assistantId, conversationId := r.assistant.Id, r.assistantConversation.Id
+	last, err := r.conversationService.GetLastConversationEventSequence(ctx, conversationId)
+	if err != nil {
+		r.logger.Errorf("unable to continue the event log of conversation %d: %v", conversationId, err)
+		return
+	}
+	data, err := r.conversationService.GetConversationCipher(ctx, conversationId)
+	if err != nil {
+		r.logger.Errorf("unable to get the key of conversation %d, events are not logged: %v", conversationId, err)
+		return
+	}
+	r.eventLog = internal_eventlog.NewRecorder(last, func(events ...internal_eventlog.Event) {
+		entries := make([]*internal_conversation_entity.AssistantConversationEvent, 0, len(events))
+		for _, event := range events {
+			payload := event.Payload
+			if data != nil && len(payload) > 0 {
+				sealed, err := internal_scratchpad.Seal(payload, data, internal_eventlog.AAD(conversationId, event.Sequence))
+				if err != nil {
+					r.logger.Errorf("unable to seal event %d of conversation %d: %v", event.Sequence, conversationId, err)
+					sealed = nil
+				}
+				payload = sealed
+			}
+			entry := &internal_conversation_entity.AssistantConversationEvent{
+				AssistantId:             assistantId,
+				AssistantConversationId: conversationId,
+				Sequence:                event.Sequence,
+				EventType:               event.Type,
+				ContextId:               event.ContextID,
+				Payload:                 payload,
+			}
+			entry.CreatedDate = gorm_models.TimeWrapper(event.Time)
+			entries = append(entries, entry)
+		}
+		// queued right away when batching, the end of the call flushes them;
+		// otherwise written off the talk loop
+		if internal_telemetry_batch.Active() != nil {
+			internal_telemetry_batch.ConversationEvents(ctx, r.conversationService, entries...)
+			return
+		}
+		utils.Go(ctx, func() {
+			dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
+			defer cancel()
+			if err := r.conversationService.ApplyConversationEvents(dbCtx, entries); err != nil {
+				r.logger.Warnf("unable to log %d events of conversation %d: %v", len(entries), conversationId, err)
+			}
+		})
+	})
+}
+
+// logEvent adds what a packet did to the event log.
+func (r *genericRequestor) logEvent(p internal_type.Packet) {
+	if r.eventLog == nil {
+		return
+	}
+	switch vl := p.(type) {
+	case internal_type.UserAudioPacket:
+		r.eventLog.Audio(internal_eventlog.DirectionInput, vl.ContextID, len(vl.Audio))
+	case internal_type.TextToSpeechAudioPacket:
+		r.eventLog.Audio(internal_eventlog.DirectionOutput, vl.ContextID, len(vl.AudioChunk))
+	case internal_type.SpeechToTextPacket:
+		r.eventLog.Record(internal_eventlog.TypeTranscript, vl.ContextID, map[string]interface{}{
+			"text":       vl.Script,
+			"confidence": vl.Confidence,
+			"language":   vl.Language,
+			"interim":    vl.Interim,
+		})
+	case internal_type.UserTextPacket:
+		r.eventLog.Record(internal_eventlog.TypeUserMessage, vl.ContextID, map[string]interface{}{"text": vl.Text, "typed": true})
+	case internal_type.EndOfSpeechPacket:
+		r.eventLog.Record(internal_eventlog.TypeUserMessage, vl.ContextID, map[string]interface{}{"text": vl.Speech})
+	case internal_type.UserDTMFPacket:
+		r.eventLog.Record(internal_eventlog.TypeDTMF, vl.ContextID, map[string]interface{}{"digit": vl.Digit})
+	case internal_type.StaticPacket:
+		r.eventLog.Record(internal_eventlog.TypeAssistantMessage, vl.ContextID, map[string]interface{}{"text": vl.Text, "static": true})
+	case internal_type.LLMResponseDonePacket:
+		r.eventLog.Record(internal_eventlog.TypeAssistantMessage, vl.ContextID, map[string]interface{}{"text": vl.Text})
+	case internal_type.InterruptionPacket:
+		r.eventLog.Record(internal_eventlog.TypeInterruption, vl.ContextID, map[string]interface{}{
+			"source":   string(vl.Source),
+			"explicit": vl.Explicit,
+		})
+	case internal_type.LLMToolCallPacket:
+		r.eventLog.Record(internal_eventlog.TypeToolCall, vl.ContextID, map[string]interface{}{
+			"toolId":    vl.ToolID,
+			"name":      vl.Name,
+			"arguments": vl.Arguments,
+		})
+	case internal_type.LLMToolResultPacket:
+		r.eventLog.Record(internal_eventlog.TypeToolResult, vl.ContextID, map[string]interface{}{
+			"toolId": vl.ToolID,
+			"name":   vl.Name,
+			"result": vl.Result,
+			"tookMs": vl.TimeTaken / 1e6,
+		})
+	case internal_type.DirectivePacket:
+		r.eventLog.Record(internal_eventlog.TypeDirective, vl.ContextID, map[string]interface{}{
+			"directive": vl.Directive.String(),
+			"arguments": vl.Arguments,
+		})
+	}
+}
+
+// closeEventLog logs the audio still pending, before the telemetry of the
+// call is flushed.
+func (r *genericRequestor) closeEventLog() {
+	if r.eventLog != nil {
+		r.eventLog.Close()
+	}
+}
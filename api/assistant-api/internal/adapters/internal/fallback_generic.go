@@ -0,0 +1,162 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_audio_resampler "github.com/rapidaai/api/assistant-api/internal/audio/resampler"
+	internal_fallback "github.com/rapidaai/api/assistant-api/internal/fallback"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const (
+	// speechDegradedMetadata holds why text to speech was given up on,
+	// callbackOfferedMetadata is set when the caller was promised a call back.
+	speechDegradedMetadata  = "speech.degraded"
+	callbackOfferedMetadata = "speech.callback_offered"
+
+	// promptLoadTimeout bounds fetching a prompt from storage.
+	promptLoadTimeout = 10 * time.Second
+
+	// promptChunk is how much of a prompt goes out per audio packet.
+	promptChunk = 100 * time.Millisecond
+)
+
+// speechFailed records a failed synthesis. A provider that keeps failing is
+// reconnected once, when it still fails after that the call falls back to
+// the recorded prompts.
+func (spk *genericRequestor) speechFailed(ctx context.Context, err error) {
+	spk.providerFailed(internal_runtimemetrics.TextToSpeech)
+	switch spk.speechMonitor.Failure() {
+	case internal_fallback.Reconnect:
+		spk.logger.Warnf("text to speech keeps failing, reconnecting: %v", err)
+		if err := spk.disconnectTextToSpeech(ctx); err != nil {
+			spk.logger.Errorf("failed to close text to speech: %v", err)
+		}
+		// a provider that does not come back is given up on right away
+		spk.initializeTextToSpeech(ctx)
+	case internal_fallback.Degrade:
+		if err := spk.disconnectTextToSpeech(ctx); err != nil {
+			spk.logger.Errorf("failed to close text to speech: %v", err)
+		}
+		spk.speechUnavailable(ctx, err)
+	}
+}
+
+// speechUnavailable gives up on text to speech for the rest of the call. The
+// incident is recorded on the conversation and sent to the webhooks, the
+// caller hears the recorded prompts of the deployment and the call is
+// transferred or ended. Without prompts the call carries on in silence as
+// before.
+func (spk *genericRequestor) speechUnavailable(ctx context.Context, cause error) {
+	if !spk.speechDegraded.CompareAndSwap(false, true) {
+		return
+	}
+	spk.logger.Errorf("text to speech is unavailable: %v", cause)
+
+	var prompts internal_fallback.Prompts
+	if output, err := spk.GetTextToSpeechTransformer(); err == nil {
+		prompts = internal_fallback.FromOptions(output.GetOptions())
+	}
+	utils.Go(ctx, func() {
+		metadata := []*protos.Metadata{{Key: speechDegradedMetadata, Value: cause.Error()}}
+		if prompts.OffersCallback() {
+			metadata = append(metadata, &protos.Metadata{Key: callbackOfferedMetadata, Value: "true"})
+		}
+		if err := spk.onAddMetadata(ctx, metadata...); err != nil {
+			spk.logger.Errorf("unable to record text to speech outage: %v", err)
+		}
+		spk.OnDegradedConversation(ctx)
+	})
+
+	if !prompts.Configured() {
+		return
+	}
+	utils.Go(ctx, func() {
+		spk.playFallback(ctx, prompts)
+	})
+}
+
+// speechAvailable reports whether text is still synthesized.
+func (spk *genericRequestor) speechAvailable() bool {
+	return !spk.speechDegraded.Load()
+}
+
+// playFallback plays the prompts one after the other and transfers or ends
+// the call once the caller heard them. A prompt that cannot be loaded is
+// skipped, the call is wound up regardless. The caller talking over the
+// prompts does not cut them off.
+func (spk *genericRequestor) playFallback(ctx context.Context, prompts internal_fallback.Prompts) {
+	for _, key := range prompts.Sequence() {
+		audio, err := spk.loadPrompt(ctx, key)
+		if err != nil {
+			spk.logger.Errorf("unable to load fallback prompt %s: %v", key, err)
+			continue
+		}
+		step := internal_audio.BytesPerMs(internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG) * int(promptChunk/time.Millisecond)
+		for start := 0; start < len(audio); start += step {
+			end := min(start+step, len(audio))
+			if err := spk.OnPacket(ctx, internal_type.TextToSpeechAudioPacket{ContextID: spk.messaging.GetID(), AudioChunk: audio[start:end]}); err != nil {
+				spk.logger.Errorf("unable to play fallback prompt %s: %v", key, err)
+				break
+			}
+		}
+	}
+	for spk.assistantAudible() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(playbackPoll):
+		}
+	}
+
+	if prompts.TransferTo != "" {
+		spk.OnPacket(ctx, internal_type.DirectivePacket{
+			ContextID: spk.messaging.GetID(),
+			Directive: protos.ConversationDirective_TRANSFER_CONVERSATION,
+			Arguments: map[string]interface{}{"to": prompts.TransferTo},
+		})
+		return
+	}
+	spk.OnPacket(ctx, internal_type.DirectivePacket{
+		ContextID: spk.messaging.GetID(),
+		Directive: protos.ConversationDirective_END_CONVERSATION,
+		Arguments: map[string]interface{}{"reason": "text to speech unavailable"},
+	})
+}
+
+// loadPrompt fetches a prompt from storage as audio the channels play.
+func (spk *genericRequestor) loadPrompt(ctx context.Context, key string) ([]byte, error) {
+	if spk.storage == nil {
+		return nil, fmt.Errorf("no storage configured")
+	}
+	loadCtx, cancel := context.WithTimeout(ctx, promptLoadTimeout)
+	defer cancel()
+	out := spk.storage.Get(loadCtx, key)
+	if out.Error != nil {
+		return nil, out.Error
+	}
+	audio, config, err := internal_fallback.Decode(out.Data)
+	if err != nil {
+		return nil, err
+	}
+	target := internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG
+	if config.GetSampleRate() == target.GetSampleRate() && config.GetAudioFormat() == target.GetAudioFormat() {
+		return audio, nil
+	}
+	resampler, err := internal_audio_resampler.GetResampler(spk.logger)
+	if err != nil {
+		return nil, err
+	}
+	return resampler.Resample(audio, config, target)
+}
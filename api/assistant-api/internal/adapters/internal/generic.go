@@ -8,28 +8,53 @@ package adapter_internal
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rapidaai/api/assistant-api/config"
 	internal_adapter_request_customizers "github.com/rapidaai/api/assistant-api/internal/adapters/customizers"
+	internal_callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_callquality "github.com/rapidaai/api/assistant-api/internal/callquality"
+	internal_cdr "github.com/rapidaai/api/assistant-api/internal/cdr"
+	internal_latency "github.com/rapidaai/api/assistant-api/internal/latency"
 	"github.com/rapidaai/protos"
 
+	internal_supervision "github.com/rapidaai/api/assistant-api/internal/supervision"
 	internal_assistant_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry/assistant"
 	internal_assistant_telemetry_exporters "github.com/rapidaai/api/assistant-api/internal/telemetry/assistant/exporters"
+	internal_ttscache "github.com/rapidaai/api/assistant-api/internal/ttscache"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 
 	internal_agent_embeddings "github.com/rapidaai/api/assistant-api/internal/agent/embedding"
 	internal_agent_executor "github.com/rapidaai/api/assistant-api/internal/agent/executor"
 	internal_agent_executor_llm "github.com/rapidaai/api/assistant-api/internal/agent/executor/llm"
 	internal_agent_rerankers "github.com/rapidaai/api/assistant-api/internal/agent/reranker"
+	internal_diarization "github.com/rapidaai/api/assistant-api/internal/diarization"
+	internal_dictation "github.com/rapidaai/api/assistant-api/internal/dictation"
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
 	internal_knowledge_gorm "github.com/rapidaai/api/assistant-api/internal/entity/knowledges"
+	internal_eventlog "github.com/rapidaai/api/assistant-api/internal/eventlog"
+	internal_fallback "github.com/rapidaai/api/assistant-api/internal/fallback"
+	internal_interruption "github.com/rapidaai/api/assistant-api/internal/interruption"
+	internal_language "github.com/rapidaai/api/assistant-api/internal/language"
+	internal_livetranscript "github.com/rapidaai/api/assistant-api/internal/livetranscript"
+	internal_metering "github.com/rapidaai/api/assistant-api/internal/metering"
+	internal_pacing "github.com/rapidaai/api/assistant-api/internal/pacing"
+	internal_recognition "github.com/rapidaai/api/assistant-api/internal/recognition"
+	internal_scratchpad "github.com/rapidaai/api/assistant-api/internal/scratchpad"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
 	internal_knowledge_service "github.com/rapidaai/api/assistant-api/internal/services/knowledge"
+	internal_sessionstate "github.com/rapidaai/api/assistant-api/internal/sessionstate"
+	internal_snapshot "github.com/rapidaai/api/assistant-api/internal/snapshot"
+	internal_spelling "github.com/rapidaai/api/assistant-api/internal/spelling"
+	internal_startup "github.com/rapidaai/api/assistant-api/internal/startup"
 	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
+	internal_transcript "github.com/rapidaai/api/assistant-api/internal/transcript"
+	internal_warmpool "github.com/rapidaai/api/assistant-api/internal/warmpool"
 	endpoint_client "github.com/rapidaai/pkg/clients/endpoint"
 	integration_client "github.com/rapidaai/pkg/clients/integration"
 	web_client "github.com/rapidaai/pkg/clients/web"
@@ -50,6 +75,7 @@ type genericRequestor struct {
 	source   utils.RapidaSource
 	auth     types.SimplePrinciple
 	streamer internal_type.Streamer
+	storage  storages.Storage
 
 	// service
 	assistantService     internal_services.AssistantService
@@ -57,6 +83,7 @@ type genericRequestor struct {
 	webhookService       internal_services.AssistantWebhookService
 	knowledgeService     internal_services.KnowledgeService
 	assistantToolService internal_services.AssistantToolService
+	recordingService     internal_services.AssistantRecordingService
 
 	//
 	opensearch    connectors.OpenSearchConnector
@@ -77,22 +104,79 @@ type genericRequestor struct {
 
 	// listening
 	speechToTextTransformer internal_type.SpeechToTextTransformer
+	speechEntityMu          sync.Mutex
+	speechEntity            string                                    // expected entity the stt is biased towards
+	spelling                atomic.Pointer[internal_spelling.Capture] // set while spelling mode is on
+	onHold                  atomic.Bool                               // remote party has the call on hold
+
+	// dictation mode, see dictation_generic.go
+	dictationMu    sync.Mutex
+	dictation      *internal_dictation.Dictation // set while dictation mode is on
+	dictationTimer *time.Timer                   // ends the dictation at its max duration
+	dictations     int                           // dictations attached to the conversation
 
 	// audio intelligence
 	endOfSpeech internal_type.EndOfSpeech
 	vad         internal_type.Vad
 	denoiser    internal_type.Denoiser
 
+	// barge in, see interruption_generic.go
+	interruption internal_interruption.Strategy
+	ducking      internal_interruption.Ducking
+	duckedUntil  atomic.Int64 // unix nanos, speech is ducked until then
+	sensitivity  internal_interruption.Sensitivity
+	lastCut      atomic.Int64 // unix nanos the assistant was last cut, 0 if never
+
+	// acknowledgements while the assistant is heard, see backchannel_generic.go
+	backchannel   *internal_interruption.Backchannel
+	playbackUntil atomic.Int64 // unix nanos, the caller hears the assistant until about then
+	voiceOnset    atomic.Int64 // unix nanos the caller's current utterance started, 0 if none
+
 	// speak
 	textToSpeechTransformer internal_type.TextToSpeechTransformer
 	textAggregator          internal_type.LLMTextAggregator
 
-	recorder       internal_type.Recorder
-	templateParser parsers.StringTemplateParser
+	// recorded prompts once text to speech is down, see fallback_generic.go
+	speechMonitor  internal_fallback.Monitor
+	speechDegraded atomic.Bool
+
+	// rate and verbosity of the assistant, see pacing_generic.go
+	speakingProfiles internal_pacing.Profiles
+	initialProfile   *internal_pacing.Profile // selected at the start of the call
+	speakingProfile  atomic.Pointer[internal_pacing.Profile]
+	monologue        *internal_pacing.Monologue // longest the assistant talks per answer, see monologue_generic.go
+
+	recorder         internal_type.Recorder
+	recordingSetting *internal_conversation_entity.AssistantRecordingSetting // set while recording
+	templateParser   parsers.StringTemplateParser
+
+	// misheard turns pointing into the recording, see snapshot_generic.go
+	snapshotPolicy *internal_snapshot.Policy
+	snapshotTurn   internal_snapshot.Turn
+
+	// language and confidence of the transcript segments, see recognition_generic.go
+	recognitionTurn internal_recognition.Turn
+
+	// labelling the speakers of the caller's side, see diarization_generic.go
+	diarizer *internal_diarization.Clusterer
+
+	// following the caller into another language, see language_generic.go
+	languageMu       sync.Mutex
+	languageDetector *internal_language.Detector
+	languageSwitchMu sync.Mutex
+	language         atomic.Pointer[string]
 
 	// executor
 	assistantExecutor internal_agent_executor.AssistantExecutor
 
+	// handing the conversation to other assistants, see handoff_generic.go
+	handoffMu sync.Mutex
+	handoffs  int // handoffs attached to the conversation
+
+	// pipeline built ahead of the call, see warmpool_generic.go
+	standby *internal_warmpool.Standby
+	pooled  bool // the assistant is kept in the warm pool
+
 	// states
 	assistant             *internal_assistant_entity.Assistant
 	assistantConversation *internal_conversation_entity.AssistantConversation
@@ -102,6 +186,74 @@ type genericRequestor struct {
 	metadata map[string]interface{}
 	options  map[string]interface{}
 
+	// shared state of tools and llm, persisted in the call context
+	scratchpad       internal_type.Scratchpad
+	callContextStore internal_callcontext.Store
+
+	// metadata tools and clients attach to the conversation record
+	customMetadata internal_type.CustomMetadata
+
+	// transcript streamed to webhooks while the call runs, see transcript_generic.go
+	transcripts []*internal_transcript.Publisher
+	// the transcript followed live over server-sent events
+	liveTranscript *internal_livetranscript.Writer
+
+	// numbered log of what happens during the call, see eventlog_generic.go
+	eventLog *internal_eventlog.Recorder
+
+	// leaving a message on an answering machine, see voicemail_generic.go
+	answeredBy     string // who picked up, when the channel detected it
+	voicemail      atomic.Pointer[voicemailDrop]
+	voicemailTimer *time.Timer
+
+	// estimated MOS of the call, see callquality_generic.go
+	callQuality     *internal_callquality.Legs
+	callQualityStop chan struct{}
+
+	// talk and connect time of the call, see metering_generic.go
+	meter *internal_metering.Meter
+
+	// latency of the turns, see latency_generic.go
+	latency *internal_latency.Tracker
+
+	// the assistant writing an answer in a text chat, see textchat_generic.go
+	assistantTyping atomic.Bool
+
+	// supervisors following the conversation, see supervision_generic.go
+	supervision atomic.Pointer[internal_supervision.Tap]
+	bargedIn    atomic.Bool
+	bargeLog    []string // turns while a supervisor has the conversation
+	whispersMu  sync.Mutex
+	whispers    []string
+
+	// human agents joined to the call, see conference_generic.go
+	conferenceMu sync.Mutex
+	conference   atomic.Pointer[conferenceCall]
+
+	// cold start of the conversation, see startup_generic.go
+	startup     *internal_startup.Profile
+	credentials map[uint64]*providerCredential
+
+	// counted in the active sessions of the replica, see runtimemetrics_generic.go
+	sessionCounted atomic.Bool
+	// providers of the session counted in the open provider streams
+	providerStreams []string
+
+	// removes the session from the debug snapshot API, see state_generic.go
+	unregisterState func()
+
+	// removes the session from the cluster, see cluster_generic.go
+	unregisterCluster func()
+
+	// resumption of dropped WebTalk text sessions, see resume_generic.go
+	resumes       *internal_sessionstate.Resumes
+	resumeToken   string
+	resumed       *internal_sessionstate.State
+	resumeRevoked atomic.Bool
+
+	// audio of the phrases assistants speak again, see ttscache_generic.go
+	textToSpeechCache internal_ttscache.Cache
+
 	// experience
 	idleTimeoutTimer    *time.Timer
 	idleTimeoutDeadline time.Time // when the current idle timer is set to fire
@@ -121,12 +273,14 @@ func NewGenericRequestor(
 		config:   config,
 		source:   source,
 		streamer: streamer,
+		storage:  storage,
 		// services
 		assistantService:     internal_assistant_service.NewAssistantService(config, logger, postgres, opensearch),
 		knowledgeService:     internal_knowledge_service.NewKnowledgeService(config, logger, postgres, storage),
-		conversationService:  internal_assistant_service.NewAssistantConversationService(logger, postgres, storage),
+		conversationService:  internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage),
 		webhookService:       internal_assistant_service.NewAssistantWebhookService(logger, postgres, storage),
 		assistantToolService: internal_assistant_service.NewAssistantToolService(logger, postgres, storage),
+		recordingService:     internal_assistant_service.NewAssistantRecordingService(config, logger, postgres, storage),
 		templateParser:       parsers.NewPongo2StringTemplateParser(logger),
 		//
 
@@ -155,6 +309,15 @@ func NewGenericRequestor(
 		metadata:  make(map[string]interface{}),
 		args:      make(map[string]interface{}),
 		options:   make(map[string]interface{}),
+
+		scratchpad:       internal_scratchpad.NewScratchpad(nil, nil),
+		callContextStore: internal_callcontext.NewStore(postgres, logger),
+		customMetadata:   internal_cdr.NewMetadata(nil, nil),
+		latency:          internal_latency.NewTracker(),
+		startup:          internal_startup.NewProfile(time.Now()),
+		resumes:          newResumes(config, redis),
+
+		textToSpeechCache: newTextToSpeechCache(config, redis, storage),
 	}
 }
 
@@ -165,6 +328,11 @@ func (dm *genericRequestor) Source() utils.RapidaSource {
 
 func (deb *genericRequestor) onCreateMessage(ctx context.Context, msg internal_type.MessagePacket) error {
 	deb.histories = append(deb.histories, msg)
+	var speaker string
+	if user, ok := msg.(internal_type.UserTextPacket); ok {
+		speaker = user.Speaker
+	}
+	deb.publishTranscript(msg.ContextId(), msg.Role(), speaker, msg.Content(), true)
 	dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
 	defer cancel()
 	_, err := deb.conversationService.CreateConversationMessage(dbCtx, deb.Auth(), deb.Source(), deb.Assistant().Id, deb.Assistant().AssistantProviderId, deb.Conversation().Id, msg.ContextId(), msg.Role(), msg.Content())
@@ -226,6 +394,9 @@ func (talking *genericRequestor) BeginConversation(ctx context.Context, assistan
 		})
 	}
 	talking.assistantConversation = conversation
+	talking.initializeScratchpad(ctx)
+	talking.initializeCustomMetadata()
+	talking.initializeSeed()
 	return conversation, err
 }
 
@@ -236,7 +407,7 @@ func (talking *genericRequestor) ResumeConversation(ctx context.Context, assista
 		talking.logger.Errorf("failed to get assistant conversation: %+v", err)
 		return nil, err
 	}
-	if conversation == nil {
+	if conversation == nil || !permitsConversation(talking.Auth(), conversation) {
 		talking.logger.Errorf("conversation not found: %d", config.GetAssistantConversationId())
 		return nil, fmt.Errorf("conversation not found: %d", config.GetAssistantConversationId())
 	}
@@ -244,6 +415,9 @@ func (talking *genericRequestor) ResumeConversation(ctx context.Context, assista
 	talking.args = conversation.GetArguments()
 	talking.options = conversation.GetOptions()
 	talking.metadata = conversation.GetMetadatas()
+	talking.initializeScratchpad(ctx)
+	talking.initializeCustomMetadata()
+	talking.initializeSeed()
 	return conversation, nil
 }
 
@@ -285,3 +459,13 @@ func (gr *genericRequestor) CreateConversationRecording(ctx context.Context, use
 	}
 	return nil
 }
+
+func (gr *genericRequestor) CreateConversationStereoRecording(ctx context.Context, stereo []byte) error {
+	dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
+	defer cancel()
+	if _, err := gr.conversationService.CreateConversationStereoRecording(dbCtx, gr.auth, gr.assistant.Id, gr.assistantConversation.Id, stereo); err != nil {
+		gr.logger.Errorf("unable to create stereo recording for the conversation id %d with error : %v", gr.assistantConversation.Id, err)
+		return err
+	}
+	return nil
+}
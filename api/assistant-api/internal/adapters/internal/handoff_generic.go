@@ -0,0 +1,182 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	internal_adapter_request_customizers "github.com/rapidaai/api/assistant-api/internal/adapters/customizers"
+	internal_agent_executor_llm "github.com/rapidaai/api/assistant-api/internal/agent/executor/llm"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_handoff "github.com/rapidaai/api/assistant-api/internal/handoff"
+	internal_pacing "github.com/rapidaai/api/assistant-api/internal/pacing"
+	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// handoff hands the conversation to the assistant the directive names. The
+// channel, the conversation and its transcript stay, the executor is
+// replaced so the prompt and tools become the target's, and so does the voice
+// when the target speaks with another one. When the handoff fails the
+// conversation stays with the current assistant.
+func (talking *genericRequestor) handoff(ctx context.Context, vl internal_type.DirectivePacket) {
+	request, err := internal_handoff.ParseRequest(vl.Arguments)
+	if err != nil {
+		talking.logger.Errorf("unable to hand off the conversation: %v", err)
+		return
+	}
+
+	ctx, span, _ := talking.Tracer().StartSpan(ctx, utils.AssistantHandoffStage, internal_telemetry.MessageKV(vl.ContextID))
+	defer span.EndSpan(ctx, utils.AssistantHandoffStage)
+
+	talking.handoffMu.Lock()
+	defer talking.handoffMu.Unlock()
+
+	from := talking.assistant
+	record := internal_handoff.NewRecord(from.Id, from.AssistantProviderId, request, time.Now())
+	span.AddAttributes(ctx,
+		internal_telemetry.KV{K: "from_assistant_id", V: internal_telemetry.IntValue(from.Id)},
+		internal_telemetry.KV{K: "to_assistant_id", V: internal_telemetry.IntValue(request.AssistantID)},
+		internal_telemetry.KV{K: "reason", V: internal_telemetry.StringValue(request.Reason)},
+	)
+
+	to, err := talking.switchAssistant(ctx, request)
+	if err != nil {
+		talking.logger.Errorf("unable to hand off the conversation to assistant %d: %v", request.AssistantID, err)
+		record.Fail(err)
+		span.AddAttributes(ctx, internal_telemetry.KV{K: "status", V: internal_telemetry.StringValue(record.Status)})
+		talking.recordHandoff(ctx, record)
+		return
+	}
+	record.Complete(to.AssistantProviderId)
+	span.AddAttributes(ctx, internal_telemetry.KV{K: "status", V: internal_telemetry.StringValue(record.Status)})
+	talking.recordHandoff(ctx, record)
+	talking.logger.Infof("conversation %d handed off from assistant %d to %d", talking.assistantConversation.Id, from.Id, to.Id)
+
+	profile := talking.SpeakingProfile()
+	talking.initializeSpeakingProfile(ctx)
+	if talking.messaging.GetMode().Audio() {
+		talking.switchVoice(ctx, from, profile)
+	}
+	args, _ := utils.InterfaceMapToAnyMap(map[string]interface{}{
+		"from_assistant_id": record.FromAssistantID,
+		"to_assistant_id":   record.ToAssistantID,
+		"to_version":        record.ToVersion,
+		"reason":            record.Reason,
+	})
+	if err := talking.Notify(ctx, &protos.ConversationDirective{Id: vl.ContextID, Type: vl.Directive, Args: args, Time: timestamppb.Now()}); err != nil {
+		talking.logger.Errorf("error notifying handoff: %v", err)
+	}
+	talking.executeHandoff(ctx, internal_handoff.Briefing(from.Name, request.Reason))
+}
+
+// switchAssistant loads the target assistant and replaces the executor with
+// one initialized for it. The previous executor is closed only once the new
+// one is ready.
+func (talking *genericRequestor) switchAssistant(ctx context.Context, request internal_handoff.Request) (*internal_assistant_entity.Assistant, error) {
+	to, err := talking.GetAssistant(ctx, talking.Auth(), request.AssistantID, request.Version)
+	if err != nil {
+		return nil, err
+	}
+	from := talking.assistant
+	if to.Id == from.Id && to.AssistantProviderId == from.AssistantProviderId {
+		return nil, errors.New("the conversation is already with this assistant")
+	}
+
+	executor := internal_agent_executor_llm.NewAssistantExecutor(talking.logger)
+	talking.assistant = to
+	if err := executor.Initialize(ctx, talking, talking.handoffInitialization(to)); err != nil {
+		talking.assistant = from
+		executor.Close(ctx)
+		return nil, fmt.Errorf("failed to initialize executor: %w", err)
+	}
+	previous := talking.assistantExecutor
+	talking.assistantExecutor = executor
+	if err := previous.Close(ctx); err != nil {
+		talking.logger.Errorf("failed to close executor of assistant %d: %v", from.Id, err)
+	}
+	return to, nil
+}
+
+// handoffInitialization is the initialization the new executor starts with,
+// the conversation it joins keeps its arguments, options and metadata.
+func (talking *genericRequestor) handoffInitialization(to *internal_assistant_entity.Assistant) *protos.ConversationInitialization {
+	cfg := &protos.ConversationInitialization{
+		AssistantConversationId: talking.assistantConversation.Id,
+		Assistant: &protos.AssistantDefinition{
+			AssistantId: to.Id,
+			Version:     utils.GetVersionString(to.AssistantProviderId),
+		},
+		StreamMode: protos.StreamMode_STREAM_MODE_TEXT,
+		Time:       timestamppb.Now(),
+	}
+	if talking.messaging.GetMode().Audio() {
+		cfg.StreamMode = protos.StreamMode_STREAM_MODE_AUDIO
+	}
+	cfg.Args, _ = utils.InterfaceMapToAnyMap(talking.args)
+	cfg.Options, _ = utils.InterfaceMapToAnyMap(talking.options)
+	cfg.Metadata, _ = utils.InterfaceMapToAnyMap(talking.metadata)
+	return cfg
+}
+
+// switchVoice reconnects text to speech when the assistant that took over
+// speaks with another voice or at another rate. An assistant without a voice
+// for this source keeps the current one.
+func (talking *genericRequestor) switchVoice(ctx context.Context, from *internal_assistant_entity.Assistant, fromProfile *internal_pacing.Profile) {
+	next, err := talking.GetTextToSpeechTransformer()
+	if err != nil {
+		return
+	}
+	if current, err := outputAudioDeployment(from, talking.source); err == nil &&
+		current.GetName() == next.GetName() &&
+		reflect.DeepEqual(fromProfile.SpeakOptions(current.GetOptions()), talking.SpeakingProfile().SpeakOptions(next.GetOptions())) {
+		return
+	}
+	if err := talking.disconnectTextToSpeech(ctx); err != nil {
+		talking.logger.Errorf("failed to close text to speech of the previous assistant: %v", err)
+	}
+	if err := talking.initializeTextToSpeech(ctx); err != nil {
+		talking.logger.Errorf("failed to initialize text to speech after handoff: %v", err)
+	}
+}
+
+// recordHandoff attaches the handoff to the conversation as handoff.<n>,
+// failed ones included.
+func (talking *genericRequestor) recordHandoff(ctx context.Context, record *internal_handoff.Record) {
+	talking.handoffs++
+	key := fmt.Sprintf("handoff.%d", talking.handoffs)
+	value, err := json.Marshal(record)
+	if err != nil {
+		talking.logger.Errorf("unable to encode handoff: %v", err)
+		return
+	}
+	utils.Go(ctx, func() {
+		if err := talking.onAddMetadata(ctx, &protos.Metadata{Key: key, Value: string(value)}); err != nil {
+			talking.logger.Errorf("unable to record handoff: %v", err)
+		}
+	})
+}
+
+// executeHandoff gives the assistant that took over its first turn. The
+// briefing only goes to the executor, it is not part of the transcript.
+func (talking *genericRequestor) executeHandoff(ctx context.Context, briefing string) {
+	contextID := talking.messaging.GetID()
+	if err := talking.messaging.Transition(internal_adapter_request_customizers.LLMGenerating); err != nil {
+		talking.logger.Errorf("messaging transition error: %v", err)
+	}
+	if err := talking.assistantExecutor.Execute(ctx, talking, internal_type.UserTextPacket{ContextID: contextID, Text: briefing}); err != nil {
+		talking.logger.Errorf("assistant executor error: %v", err)
+		talking.OnError(ctx)
+	}
+}
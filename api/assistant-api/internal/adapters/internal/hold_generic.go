@@ -0,0 +1,30 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// setCallHold pauses the conversation while the remote party has the call on
+// hold. Whatever the assistant is saying is cut off, transcripts and speech
+// synthesis are dropped and the idle timeout is off until the call resumes.
+func (talking *genericRequestor) setCallHold(ctx context.Context, vl internal_type.CallHoldPacket) {
+	if talking.onHold.Swap(vl.Held) == vl.Held {
+		return
+	}
+	if !vl.Held {
+		talking.logger.Infof("call resumed from hold")
+		talking.startIdleTimeoutTimer(ctx)
+		return
+	}
+
+	talking.logger.Infof("call put on hold")
+	talking.OnPacket(ctx, internal_type.InterruptionPacket{ContextID: vl.ContextID, Source: internal_type.InterruptionSourceWord, Explicit: true})
+	talking.stopIdleTimeoutTimer()
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	internal_cdr "github.com/rapidaai/api/assistant-api/internal/cdr"
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	endpoint_client_builders "github.com/rapidaai/pkg/clients/endpoint/builders"
@@ -54,6 +55,16 @@ func (md *genericRequestor) OnErrorConversation(ctx context.Context) error {
 	return nil
 }
 
+func (md *genericRequestor) OnDegradedConversation(ctx context.Context) error {
+	for _, webhook := range md.assistant.AssistantWebhooks {
+		if slices.Contains(webhook.AssistantEvents, utils.ConversationDegraded.Get()) {
+			arguments := md.Parse(utils.ConversationDegraded, webhook.GetBody())
+			md.Webhook(ctx, utils.ConversationDegraded.Get(), arguments, webhook)
+		}
+	}
+	return nil
+}
+
 func (md *genericRequestor) OnEndConversation(ctx context.Context) error {
 	utils.Go(ctx, func() {
 		if len(md.assistant.AssistantAnalyses) > 0 {
@@ -205,6 +216,7 @@ func (md *genericRequestor) Parse(event utils.AssistantWebhookEvent, mapping map
 						"messages": md.SimplifyHistory(md.GetHistories()),
 					},
 					"analysis": analysisData,
+					"metadata": md.CustomMetadata().All(),
 				}
 			}
 		}
@@ -230,7 +242,11 @@ func (md *genericRequestor) Parse(event utils.AssistantWebhookEvent, mapping map
 			}
 		}
 		if k, ok := strings.CutPrefix(key, "metadata."); ok {
-			if mtd, ok := md.GetMetadata()[k]; ok {
+			if strings.HasPrefix(k, internal_cdr.Namespace) {
+				if mtd, ok := md.CustomMetadata().Get(k); ok {
+					arguments[value] = mtd
+				}
+			} else if mtd, ok := md.GetMetadata()[k]; ok {
 				arguments[value] = mtd
 			}
 		}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// duckSpeech lowers the assistant's speech for the configured duck window.
+// Further voice onsets extend it, a cut ends it.
+func (talking *genericRequestor) duckSpeech() {
+	talking.duckedUntil.Store(time.Now().Add(talking.ducking.Window).UnixNano())
+}
+
+// restoreSpeech returns the assistant's speech to full volume.
+func (talking *genericRequestor) restoreSpeech() {
+	talking.duckedUntil.Store(0)
+}
+
+// speechDucked reports whether speech going out now is ducked. Audio the
+// channel has already buffered keeps its volume.
+func (talking *genericRequestor) speechDucked() bool {
+	return time.Now().UnixNano() < talking.duckedUntil.Load()
+}
+
+// cutAllowed reports whether an interruption may cut the assistant under the
+// barge in sensitivity of the deployment. How long the caller has spoken is
+// counted from the first voice onset, interruptions not caused by the
+// caller's voice are never held back.
+func (talking *genericRequestor) cutAllowed(vl internal_type.InterruptionPacket) bool {
+	if vl.Explicit {
+		return true
+	}
+	if vl.Source == internal_type.InterruptionSourceVad {
+		talking.markVoiceOnset()
+	}
+	now := time.Now()
+	var spoken time.Duration
+	if onset := talking.voiceOnset.Load(); onset > 0 {
+		spoken = max(now.Sub(time.Unix(0, onset)), time.Nanosecond)
+	}
+	var lastCut time.Time
+	if at := talking.lastCut.Load(); at > 0 {
+		lastCut = time.Unix(0, at)
+	}
+	return talking.sensitivity.Allows(spoken, lastCut, now)
+}
+
+// markCut records that the assistant was cut, for the cooldown.
+func (talking *genericRequestor) markCut() {
+	talking.lastCut.Store(time.Now().UnixNano())
+}
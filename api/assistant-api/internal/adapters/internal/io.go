@@ -14,6 +14,8 @@ import (
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
 	internal_denoiser "github.com/rapidaai/api/assistant-api/internal/denoiser"
 	internal_end_of_speech "github.com/rapidaai/api/assistant-api/internal/end_of_speech"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_interruption "github.com/rapidaai/api/assistant-api/internal/interruption"
 	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
 	internal_transformer "github.com/rapidaai/api/assistant-api/internal/transformer"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
@@ -27,12 +29,26 @@ import (
 // This function is typically called at the beginning of a communication session.
 func (listening *genericRequestor) initializeSpeechToText(ctx context.Context) error {
 	eGroup, ectx := errgroup.WithContext(ctx)
-	options := utils.Option{"microphone.eos.timeout": 500}
 	// only initialize speech to text if the mode is audio or both
+	var passthrough bool
 	transformerConfig, _ := listening.GetSpeechToTextTransformer()
 	if transformerConfig != nil {
-		options = utils.MergeMaps(options, transformerConfig.GetOptions())
+		options := listening.languageListenOptions(speechToTextOptions(transformerConfig))
+		listening.interruption = internal_interruption.FromOptions(options)
+		listening.ducking = internal_interruption.DuckingFromOptions(options)
+		listening.sensitivity = internal_interruption.SensitivityFromOptions(options)
+		listening.backchannel = internal_interruption.BackchannelFromOptions(options)
+		listening.initializeEchoCancellation(options)
+		listening.initializeAudioPipeline(options)
+		passthrough = listening.opusPassthrough(options)
+		if passthrough {
+			options[internal_type.OptionsKeyInputCodec] = "opus"
+		}
 		eGroup.Go(func() error {
+			if transformer := listening.standbySpeechToText(); transformer != nil {
+				listening.speechToTextTransformer = transformer
+				return nil
+			}
 			//
 			spanCtx, span, _ := listening.Tracer().StartSpan(ectx, utils.AssistantListenConnectStage)
 			defer span.EndSpan(spanCtx, utils.AssistantListenConnectStage)
@@ -42,34 +58,11 @@ func (listening *genericRequestor) initializeSpeechToText(ctx context.Context) e
 				internal_telemetry.KV{K: "provider", V: internal_telemetry.StringValue(transformerConfig.AudioProvider)},
 			)
 
-			credentialId, err := options.GetUint64("rapida.credential_id")
-			if err != nil {
-				listening.logger.Errorf("unable to find credential from options %+v", err)
-				return err
-			}
-			credential, err := listening.VaultCaller().GetCredential(spanCtx, listening.Auth(), credentialId)
-			if err != nil {
-				listening.logger.Errorf("Api call to find credential failed %+v", err)
-				return err
-			}
-
 			// Use the original session ctx (not errgroup's ectx) so the
 			// transformer's stream lifecycle is tied to the session, not
 			// the short-lived errgroup that finishes after init.
-			atransformer, err := internal_transformer.GetSpeechToTextTransformer(
-				ctx,
-				listening.logger,
-				transformerConfig.AudioProvider,
-				credential,
-				func(pkt ...internal_type.Packet) error { return listening.OnPacket(ctx, pkt...) },
-				options)
-			if err != nil {
-				listening.logger.Errorf("unable to create input audio transformer with error %v", err)
-				return err
-			}
-			err = atransformer.Initialize()
+			atransformer, err := listening.connectSpeechToText(spanCtx, ctx, transformerConfig.AudioProvider, options)
 			if err != nil {
-				listening.logger.Errorf("unable to initilize transformer %v", err)
 				return err
 			}
 			listening.speechToTextTransformer = atransformer
@@ -98,9 +91,50 @@ func (listening *genericRequestor) initializeSpeechToText(ctx context.Context) e
 		listening.logger.Errorf("illegal init %+v", err)
 		return err
 	}
+	if passthrough {
+		listening.enableOpusPassthrough(ctx)
+	}
 	return nil
 }
 
+// connectSpeechToText connects the speech to text provider with options,
+// credentials are looked up within ctx and the transformer lives as long as
+// sessionCtx.
+func (listening *genericRequestor) connectSpeechToText(ctx, sessionCtx context.Context, provider string, options utils.Option) (internal_type.SpeechToTextTransformer, error) {
+	credentialId, err := options.GetUint64("rapida.credential_id")
+	if err != nil {
+		listening.logger.Errorf("unable to find credential from options %+v", err)
+		return nil, err
+	}
+	credential, err := listening.credential(ctx, credentialId)
+	if err != nil {
+		listening.logger.Errorf("Api call to find credential failed %+v", err)
+		return nil, err
+	}
+	atransformer, err := internal_transformer.GetSpeechToTextTransformer(
+		sessionCtx,
+		listening.logger,
+		provider,
+		credential,
+		func(pkt ...internal_type.Packet) error { return listening.OnPacket(sessionCtx, pkt...) },
+		options)
+	if err != nil {
+		listening.logger.Errorf("unable to create input audio transformer with error %v", err)
+		return nil, err
+	}
+	if err := atransformer.Initialize(); err != nil {
+		listening.logger.Errorf("unable to initilize transformer %v", err)
+		return nil, err
+	}
+	return atransformer, nil
+}
+
+// speechToTextOptions merges the deployment's listen options over the
+// defaults every speech to text session starts with.
+func speechToTextOptions(transformerConfig *internal_assistant_entity.AssistantDeploymentAudio) utils.Option {
+	return utils.MergeMaps(utils.Option{"microphone.eos.timeout": 500}, transformerConfig.GetOptions())
+}
+
 func (listening *genericRequestor) disconnectSpeechToText(ctx context.Context) error {
 	if listening.speechToTextTransformer != nil {
 		if err := listening.speechToTextTransformer.Close(ctx); err != nil {
@@ -155,6 +189,11 @@ func (listening *genericRequestor) disconnectEndOfSpeech(ctx context.Context) er
 }
 
 func (listening *genericRequestor) initializeDenoiser(ctx context.Context, options utils.Option) error {
+	if !internal_denoiser.Enabled(options) {
+		listening.logger.Debugf("noise suppression is turned off for the assistant")
+		listening.denoiser = nil
+		return nil
+	}
 	denoise, err := internal_denoiser.GetDenoiser(ctx, listening.logger, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG, options)
 	if err != nil {
 		listening.logger.Errorf("error wile intializing denoiser %+v", err)
@@ -175,12 +214,26 @@ func (listening *genericRequestor) initializeVAD(ctx context.Context, options ut
 }
 
 func (spk *genericRequestor) initializeTextToSpeech(context context.Context) error {
+	if !spk.speechAvailable() {
+		// the call is on the recorded prompts for good
+		return nil
+	}
 	speakerOpts := spk.GetOptions()
 	var wg sync.WaitGroup
+	var connectErr error
 	outputTransformer, _ := spk.GetTextToSpeechTransformer()
 	// connect text to speech transformer if configured and mode is audio
 	if outputTransformer != nil {
-		speakerOpts = utils.MergeMaps(outputTransformer.GetOptions())
+		// the standby speaks at the voice's own rate, in the language the
+		// call started in
+		profile := spk.SpeakingProfile()
+		if (profile == nil || profile.Rate == 0) && spk.switchedLanguage() == "" {
+			if transformer := spk.standbyTextToSpeech(); transformer != nil {
+				spk.textToSpeechTransformer = transformer
+				return nil
+			}
+		}
+		speakerOpts = spk.languageSpeakOptions(profile.SpeakOptions(utils.MergeMaps(outputTransformer.GetOptions())))
 
 		// context with span
 		context, span, _ := spk.Tracer().StartSpan(context, utils.AssistantSpeakConnectStage)
@@ -202,28 +255,36 @@ func (spk *genericRequestor) initializeTextToSpeech(context context.Context) err
 			if err != nil {
 				spk.logger.Errorf("unable to find credential from options %+v", err)
 			}
-			credential, err := spk.VaultCaller().GetCredential(context, spk.Auth(), credentialId)
+			credential, err := spk.credential(context, credentialId)
 			if err != nil {
 				spk.logger.Errorf("Api call to find credential failed %+v", err)
 			}
 
-			atransformer, err := internal_transformer.GetTextToSpeechTransformer(
-				context, spk.logger,
+			atransformer, err := newTextToSpeech(
+				context, spk.logger, spk.textToSpeechCache, spk.assistant.Id,
 				outputTransformer.GetName(),
 				credential,
 				func(pkt ...internal_type.Packet) error { return spk.OnPacket(context, pkt...) },
 				speakerOpts)
 			if err != nil {
 				spk.logger.Errorf("unable to create input audio transformer with error %v", err)
+				connectErr = err
+				return
 			}
 			if err := atransformer.Initialize(); err != nil {
 				spk.logger.Errorf("unable to initilize transformer %v", err)
+				connectErr = err
+				return
 			}
 			spk.textToSpeechTransformer = atransformer
 		})
 	}
 
 	wg.Wait()
+	if connectErr != nil {
+		spk.speechUnavailable(context, connectErr)
+		return connectErr
+	}
 	return nil
 
 }
@@ -238,9 +299,14 @@ func (spk *genericRequestor) disconnectTextToSpeech(ctx context.Context) error {
 	return nil
 }
 
-// Initialize the text aggregator for assembling sentences from tokens.
+// Initialize the text aggregator for assembling sentences from tokens, chunked
+// as the text to speech asks for.
 func (spk *genericRequestor) initializeTextAggregator(ctx context.Context) error {
-	if textAggregator, err := internal_sentence_aggregator.GetLLMTextAggregator(ctx, spk.logger); err == nil {
+	var speakerOpts utils.Option
+	if outputTransformer, err := spk.GetTextToSpeechTransformer(); err == nil {
+		speakerOpts = outputTransformer.GetOptions()
+	}
+	if textAggregator, err := internal_sentence_aggregator.GetLLMTextAggregator(ctx, spk.logger, speakerOpts); err == nil {
 		spk.textAggregator = textAggregator
 		go spk.onAssembleSentence(ctx)
 	}
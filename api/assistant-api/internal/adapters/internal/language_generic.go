@@ -0,0 +1,125 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_language "github.com/rapidaai/api/assistant-api/internal/language"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// initializeLanguageDetection reads from the speech to text options of the
+// deployment the languages the call may switch between.
+func (r *genericRequestor) initializeLanguageDetection() {
+	if transformerConfig, _ := r.GetSpeechToTextTransformer(); transformerConfig != nil {
+		r.languageDetector = internal_language.FromOptions(speechToTextOptions(transformerConfig))
+	}
+}
+
+// Language implements internal_type.Communication, the language the call
+// switched to, "" while it is in the one it started in.
+func (r *genericRequestor) Language() string {
+	return r.switchedLanguage()
+}
+
+func (r *genericRequestor) switchedLanguage() string {
+	if language := r.language.Load(); language != nil {
+		return *language
+	}
+	return ""
+}
+
+// languageListenOptions returns the speech to text options for the language
+// the call switched to.
+func (r *genericRequestor) languageListenOptions(options utils.Option) utils.Option {
+	if language := r.switchedLanguage(); language != "" {
+		return internal_language.ListenOptions(options, language)
+	}
+	return options
+}
+
+// languageSpeakOptions returns the text to speech options for the language
+// the call switched to.
+func (r *genericRequestor) languageSpeakOptions(options utils.Option) utils.Option {
+	if language := r.switchedLanguage(); language != "" {
+		return internal_language.SpeakOptions(options, language)
+	}
+	return options
+}
+
+// languageHeard follows the language of the caller through a final
+// transcript, switching the call once the caller settled in another allowed
+// language.
+func (r *genericRequestor) languageHeard(ctx context.Context, vl internal_type.SpeechToTextPacket) {
+	if r.languageDetector == nil || vl.Interim {
+		return
+	}
+	r.languageMu.Lock()
+	language, ok := r.languageDetector.Heard(vl.Language, vl.Script)
+	r.languageMu.Unlock()
+	if !ok {
+		return
+	}
+	utils.Go(ctx, func() {
+		r.switchLanguage(ctx, language)
+	})
+}
+
+// switchLanguage reconnects speech to text and text to speech, and with it
+// its normalizers, for language. The assistant is told to answer in it and
+// the client and the conversation record the switch.
+func (r *genericRequestor) switchLanguage(ctx context.Context, language string) {
+	r.languageSwitchMu.Lock()
+	defer r.languageSwitchMu.Unlock()
+	if r.switchedLanguage() == language || ctx.Err() != nil {
+		return
+	}
+	r.logger.Infof("caller of conversation %d speaks %s, switching language", r.assistantConversation.Id, language)
+	r.language.Store(&language)
+
+	if transformerConfig, _ := r.GetSpeechToTextTransformer(); transformerConfig != nil {
+		options := r.languageListenOptions(speechToTextOptions(transformerConfig))
+		if r.opusSpeechToText() != nil {
+			options[internal_type.OptionsKeyInputCodec] = "opus"
+		}
+		// the caller is heard by the previous transformer until the next
+		// one is connected
+		if next, err := r.connectSpeechToText(ctx, ctx, transformerConfig.AudioProvider, options); err != nil {
+			r.logger.Errorf("unable to switch speech to text to %s: %v", language, err)
+		} else {
+			previous := r.speechToTextTransformer
+			r.speechToTextTransformer = next
+			if previous != nil {
+				if err := previous.Close(ctx); err != nil {
+					r.logger.Warnf("unable to close speech to text of the previous language: %v", err)
+				}
+			}
+		}
+	}
+
+	if _, err := r.GetTextToSpeechTransformer(); err == nil && r.speechAvailable() {
+		if err := r.disconnectTextToSpeech(ctx); err != nil {
+			r.logger.Errorf("failed to close text to speech of the previous language: %v", err)
+		}
+		if err := r.initializeTextToSpeech(ctx); err != nil {
+			r.logger.Errorf("failed to initialize text to speech in %s: %v", language, err)
+		}
+	}
+
+	r.OnPacket(ctx, internal_type.ConversationMetadataPacket{
+		ContextID: r.assistantConversation.Id,
+		Metadata:  []*protos.Metadata{{Key: internal_type.MetadataKeyLanguage, Value: language}},
+	})
+	if err := r.Notify(ctx, &protos.ConversationMetadata{
+		AssistantConversationId: r.assistantConversation.Id,
+		Metadata:                []*protos.Metadata{{Key: internal_type.MetadataKeyLanguage, Value: language}},
+	}); err != nil {
+		r.logger.Tracef(ctx, "error while notifying the language switch: %v", err)
+	}
+}
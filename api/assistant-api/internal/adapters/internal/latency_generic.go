@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"time"
+
+	internal_latency "github.com/rapidaai/api/assistant-api/internal/latency"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// turnLatency completes the turn contextID when the first audio of its reply
+// goes to the channel. The stages are kept on the turn's message and on a
+// turn span, the percentiles of the conversation so far replace the previous
+// ones in its metrics.
+func (talking *genericRequestor) turnLatency(ctx context.Context, contextID string) {
+	turn, ok := talking.latency.FirstAudio(contextID, time.Now())
+	if !ok {
+		return
+	}
+	attributes := []internal_telemetry.KV{internal_telemetry.MessageKV(contextID)}
+	for _, stage := range internal_latency.Stages {
+		if latency, ok := turn.Latency[stage]; ok {
+			internal_runtimemetrics.ObserveLatency(string(stage), latency)
+			attributes = append(attributes, internal_telemetry.KV{K: "latency." + string(stage) + "_ms", V: internal_telemetry.IntValue(int(latency.Milliseconds()))})
+		}
+	}
+	spanCtx, span, _ := talking.Tracer().StartSpan(ctx, utils.AssistantTurnStage)
+	span.EndSpan(spanCtx, utils.AssistantTurnStage, attributes...)
+
+	talking.OnPacket(ctx, internal_type.MessageMetricPacket{ContextID: contextID, Metrics: turn.Metrics()})
+	utils.Go(ctx, func() {
+		talking.onAddMetrics(ctx, talking.latency.Metrics()...)
+	})
+}
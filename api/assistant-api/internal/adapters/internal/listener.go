@@ -0,0 +1,209 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_transformer "github.com/rapidaai/api/assistant-api/internal/transformer"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	web_client "github.com/rapidaai/pkg/clients/web"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// genericListener runs the listen layer of an assistant on its own: audio
+// received on the streamer is transcribed with the exact speech to text
+// provider, model and options configured on the assistant deployment, and
+// interim/final transcripts are sent back on the same streamer.
+//
+// No conversation is created and no LLM, TTS or end of speech analysis runs,
+// so customers can transcribe side audio (e.g. agent desktop capture) with the
+// same recognition behaviour as the live assistant.
+type genericListener struct {
+	logger   commons.Logger
+	source   utils.RapidaSource
+	streamer internal_type.Streamer
+
+	assistantService internal_services.AssistantService
+	vaultClient      web_client.VaultClient
+
+	speechToTextTransformer internal_type.SpeechToTextTransformer
+
+	// id groups interim transcripts of the same utterance, rotated on final.
+	// Transcripts arrive on the provider's goroutine.
+	idMu sync.Mutex
+	id   string
+}
+
+func NewGenericListener(
+	config *config.AssistantConfig,
+	logger commons.Logger, source utils.RapidaSource,
+	postgres connectors.PostgresConnector, opensearch connectors.OpenSearchConnector,
+	redis connectors.RedisConnector, streamer internal_type.Streamer,
+) *genericListener {
+	return &genericListener{
+		logger:           logger,
+		source:           source,
+		streamer:         streamer,
+		assistantService: internal_assistant_service.NewAssistantService(config, logger, postgres, opensearch),
+		vaultClient:      web_client.NewVaultClientGRPC(&config.AppConfig, logger, redis),
+		id:               uuid.NewString(),
+	}
+}
+
+// Listen consumes the streamer until it is closed. The first message must be a
+// ConversationInitialization identifying the assistant whose listen
+// configuration is used; every following audio message is transcribed.
+func (l *genericListener) Listen(_ context.Context, auth types.SimplePrinciple) error {
+	var initialized bool
+	defer l.disconnect(context.Background())
+	for {
+		req, err := l.streamer.Recv()
+		if err != nil {
+			return nil
+		}
+		switch payload := req.(type) {
+		case *protos.ConversationInitialization:
+			if initialized {
+				continue
+			}
+			if err := l.connect(l.streamer.Context(), auth, payload); err != nil {
+				l.logger.Errorf("listen: unable to initialize speech to text %+v", err)
+				return fmt.Errorf("listen.connect error: %w", err)
+			}
+			initialized = true
+
+		case *protos.ConversationUserMessage:
+			if !initialized {
+				continue
+			}
+			audio, ok := payload.GetMessage().(*protos.ConversationUserMessage_Audio)
+			if !ok {
+				l.logger.Warnf("listen: ignoring non audio input %T", payload.GetMessage())
+				continue
+			}
+			if err := l.speechToTextTransformer.Transform(l.streamer.Context(), internal_type.UserAudioPacket{Audio: audio.Audio}); err != nil {
+				l.logger.Tracef(l.streamer.Context(), "error while transforming input %s and error %s", l.speechToTextTransformer.Name(), err.Error())
+			}
+
+		case *protos.ConversationDisconnection:
+			return nil
+		}
+	}
+}
+
+// connect loads the assistant deployment serving the listener's source and
+// initializes its speech to text transformer.
+func (l *genericListener) connect(ctx context.Context, auth types.SimplePrinciple, init *protos.ConversationInitialization) error {
+	if init.GetAssistant() == nil {
+		return errors.New("assistant definition is required to listen")
+	}
+	assistant, err := l.assistantService.Get(ctx, auth,
+		init.GetAssistant().GetAssistantId(),
+		utils.GetVersionDefinition(init.GetAssistant().GetVersion()),
+		assistantOptionForSource(l.source))
+	if err != nil {
+		return err
+	}
+	transformerConfig, err := inputAudioDeployment(assistant, l.source)
+	if err != nil {
+		return err
+	}
+	return l.initializeSpeechToText(ctx, auth, transformerConfig)
+}
+
+func (l *genericListener) initializeSpeechToText(ctx context.Context, auth types.SimplePrinciple, transformerConfig *internal_assistant_entity.AssistantDeploymentAudio) error {
+	start := time.Now()
+	options := speechToTextOptions(transformerConfig)
+	credentialId, err := options.GetUint64("rapida.credential_id")
+	if err != nil {
+		l.logger.Errorf("unable to find credential from options %+v", err)
+		return err
+	}
+	credential, err := l.vaultClient.GetCredential(ctx, auth, credentialId)
+	if err != nil {
+		l.logger.Errorf("Api call to find credential failed %+v", err)
+		return err
+	}
+	atransformer, err := internal_transformer.GetSpeechToTextTransformer(
+		ctx,
+		l.logger,
+		transformerConfig.AudioProvider,
+		credential,
+		func(pkt ...internal_type.Packet) error { return l.OnPacket(ctx, pkt...) },
+		options)
+	if err != nil {
+		l.logger.Errorf("unable to create input audio transformer with error %v", err)
+		return err
+	}
+	if err := atransformer.Initialize(); err != nil {
+		l.logger.Errorf("unable to initilize transformer %v", err)
+		return err
+	}
+	l.speechToTextTransformer = atransformer
+	l.logger.Benchmark("listen.speechToText", time.Since(start))
+	return nil
+}
+
+// OnPacket forwards transcripts produced by the transformer to the streamer.
+// Interim transcripts share the id of the final transcript that completes them.
+func (l *genericListener) OnPacket(ctx context.Context, pkts ...internal_type.Packet) error {
+	for _, p := range pkts {
+		switch vl := p.(type) {
+		case internal_type.SpeechToTextPacket:
+			if vl.Script == "" {
+				continue
+			}
+			if err := l.streamer.Send(&protos.ConversationUserMessage{
+				Id:        l.utteranceID(!vl.Interim),
+				Message:   &protos.ConversationUserMessage_Text{Text: vl.Script},
+				Completed: !vl.Interim,
+				Time:      timestamppb.Now(),
+			}); err != nil {
+				l.logger.Tracef(ctx, "error while sending transcript to the listener: %v", err)
+			}
+		case internal_type.ConversationMetricPacket, internal_type.MessageMetricPacket:
+			// transformers report latency metrics, there is no conversation to attach them to
+		default:
+		}
+	}
+	return nil
+}
+
+// utteranceID returns the id of the current utterance, a final transcript
+// ends it and the next transcript starts a new one.
+func (l *genericListener) utteranceID(final bool) string {
+	l.idMu.Lock()
+	defer l.idMu.Unlock()
+	id := l.id
+	if final {
+		l.id = uuid.NewString()
+	}
+	return id
+}
+
+func (l *genericListener) disconnect(ctx context.Context) {
+	if l.speechToTextTransformer != nil {
+		if err := l.speechToTextTransformer.Close(ctx); err != nil {
+			l.logger.Warnf("cancel speech to text transformer with error %v", err)
+		}
+		l.speechToTextTransformer = nil
+	}
+}
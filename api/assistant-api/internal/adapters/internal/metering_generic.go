@@ -0,0 +1,59 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"time"
+
+	internal_metering "github.com/rapidaai/api/assistant-api/internal/metering"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// initializeMetering starts metering the call from now, a resumed session
+// from when it was resumed.
+func (r *genericRequestor) initializeMetering() {
+	r.meter = internal_metering.NewMeter(time.Now())
+}
+
+// meterAssistant counts an audio chunk of d heard from from as the assistant
+// talking.
+func (talking *genericRequestor) meterAssistant(from time.Time, d time.Duration) {
+	if talking.meter != nil {
+		talking.meter.Assistant(from, d)
+	}
+}
+
+// meterCut stops counting the assistant's audio that was cut before it was
+// heard.
+func (talking *genericRequestor) meterCut() {
+	if talking.meter != nil {
+		talking.meter.Cut(time.Now())
+	}
+}
+
+// meterCaller counts voice activity as the caller talking. Interruptions
+// from other sources say nothing about how long the caller spoke.
+func (talking *genericRequestor) meterCaller(vl internal_type.InterruptionPacket) {
+	if talking.meter == nil || vl.Source != internal_type.InterruptionSourceVad {
+		return
+	}
+	var spoken time.Duration
+	if vl.EndAt > vl.StartAt {
+		spoken = time.Duration((vl.EndAt - vl.StartAt) * float64(time.Second))
+	}
+	talking.meter.Caller(time.Now(), spoken)
+}
+
+// finishMetering stores the usage of the call with the conversation metrics.
+func (r *genericRequestor) finishMetering(ctx context.Context) {
+	if r.meter == nil {
+		return
+	}
+	usage := r.meter.Usage(time.Now())
+	r.logger.Debugf("usage: connect %s, assistant %s, caller %s, silence %s", usage.Connect, usage.AssistantTalk, usage.CallerTalk, usage.MutualSilence)
+	r.onAddMetrics(ctx, usage.Metrics()...)
+}
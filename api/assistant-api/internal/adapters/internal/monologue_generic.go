@@ -0,0 +1,89 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"time"
+
+	internal_pacing "github.com/rapidaai/api/assistant-api/internal/pacing"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+const (
+	// monologueSettle is how long no audio of a cut answer must arrive before
+	// the check-in is asked, providers ignoring interruptions keep sending
+	// what they synthesized already.
+	monologueSettle = 300 * time.Millisecond
+
+	// monologueSettleMax bounds the wait for a provider that never settles.
+	monologueSettleMax = 3 * time.Second
+)
+
+// initializeMonologue reads the longest the assistant may talk in one answer
+// from the text to speech options of the deployment.
+func (talking *genericRequestor) initializeMonologue() {
+	talking.monologue = nil
+	if output, err := talking.GetTextToSpeechTransformer(); err == nil {
+		talking.monologue = internal_pacing.MonologueFromOptions(output.GetOptions())
+	}
+}
+
+// monologueAllows tells whether an audio chunk of the assistant is played.
+// The first chunk past the limit cuts the answer, the rest of it is not
+// synthesized any more and the assistant checks in with the caller.
+func (talking *genericRequestor) monologueAllows(ctx context.Context, vl internal_type.TextToSpeechAudioPacket, d time.Duration) bool {
+	if talking.monologue == nil {
+		return true
+	}
+	switch talking.monologue.Spoken(vl.ContextID, d) {
+	case internal_pacing.Speak:
+		return true
+	case internal_pacing.Cut:
+		talking.logger.Debugf("assistant talked for over %v in %s, cutting the answer", talking.monologue.Max, vl.ContextID)
+		utils.Go(ctx, func() {
+			talking.checkIn(ctx, vl.ContextID)
+		})
+	}
+	return false
+}
+
+// monologueCapped reports whether the answer contextID was cut for running
+// too long.
+func (talking *genericRequestor) monologueCapped(contextID string) bool {
+	return talking.monologue != nil && talking.monologue.Capped(contextID)
+}
+
+// checkIn stops synthesizing the cut answer and, unless the deployment only
+// pauses, asks the caller whether to go on once the provider settled. The
+// answer is completed either way so the channel knows the assistant is done.
+func (talking *genericRequestor) checkIn(ctx context.Context, contextID string) {
+	tts := talking.textToSpeechTransformer
+	if tts == nil {
+		return
+	}
+	if err := tts.Transform(ctx, internal_type.InterruptionPacket{ContextID: contextID}); err != nil {
+		talking.logger.Errorf("unable to stop the answer at its length limit: %v", err)
+	}
+	deadline := time.Now().Add(monologueSettleMax)
+	for !talking.monologue.Quiet(monologueSettle) && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(playbackPoll):
+		}
+	}
+
+	if talking.monologue.Action == internal_pacing.MonologueCheckIn && talking.monologue.BeginCheckIn(contextID) {
+		if err := tts.Transform(ctx, internal_type.LLMResponseDeltaPacket{ContextID: contextID, Text: talking.monologue.CheckIn}); err != nil {
+			talking.logger.Errorf("unable to check in with the caller: %v", err)
+		}
+	}
+	if err := tts.Transform(ctx, internal_type.LLMResponseDonePacket{ContextID: contextID}); err != nil {
+		talking.logger.Errorf("unable to complete the cut answer: %v", err)
+	}
+}
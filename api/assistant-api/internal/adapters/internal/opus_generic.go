@@ -0,0 +1,83 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_audio_pipeline "github.com/rapidaai/api/assistant-api/internal/audio/pipeline"
+	internal_denoiser "github.com/rapidaai/api/assistant-api/internal/denoiser"
+	internal_echo_cancellation "github.com/rapidaai/api/assistant-api/internal/echo_cancellation"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// optionsKeyOpusPassthrough lets a speech to text transformer taking Opus
+// hear a WebRTC caller undecoded.
+const optionsKeyOpusPassthrough = "microphone.opus_passthrough"
+
+// opusPassthrough reports whether the caller's Opus may go to the speech to
+// text transformer as received. It must be asked for, and nothing may be set
+// up to clean the caller audio first: denoising, echo cancellation and audio
+// filters only work on the decoded audio the transformer would then miss.
+func (listening *genericRequestor) opusPassthrough(options utils.Option) bool {
+	if _, ok := listening.streamer.(internal_type.OpusChannel); !ok {
+		return false
+	}
+	enabled, err := options.GetString(optionsKeyOpusPassthrough)
+	if err != nil {
+		return false
+	}
+	if on, err := strconv.ParseBool(strings.TrimSpace(enabled)); err != nil || !on {
+		return false
+	}
+	if internal_denoiser.Enabled(options) {
+		listening.logger.Debugf("opus passthrough skipped, the caller audio is denoised")
+		return false
+	}
+	if _, ok := internal_echo_cancellation.FromOptions(options, int(internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG.GetSampleRate())); ok {
+		listening.logger.Debugf("opus passthrough skipped, echo is cancelled from the caller audio")
+		return false
+	}
+	if pipeline, err := internal_audio_pipeline.FromOptions(internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG, options, listening.Source()); err == nil && pipeline != nil {
+		listening.logger.Debugf("opus passthrough skipped, the caller audio is filtered")
+		return false
+	}
+	return true
+}
+
+// enableOpusPassthrough has the streamer hand the caller's Opus packets to
+// the speech to text transformer when the transformer opened its stream for
+// Opus. A standby transformer was connected for linear16 and keeps it.
+func (listening *genericRequestor) enableOpusPassthrough(ctx context.Context) {
+	channel, ok := listening.streamer.(internal_type.OpusChannel)
+	if !ok || listening.opusSpeechToText() == nil {
+		return
+	}
+	listening.logger.Debugf("speech to text takes the caller's opus")
+	channel.EnableOpusPassthrough(func(pkt internal_type.UserOpusPacket) {
+		receiver := listening.opusSpeechToText()
+		if receiver == nil {
+			return
+		}
+		if err := receiver.TransformOpus(ctx, pkt); err != nil {
+			listening.logger.Tracef(ctx, "error while transforming opus input %s", err.Error())
+		}
+	})
+}
+
+// opusSpeechToText returns the speech to text transformer when it takes the
+// caller's Opus, the linear16 audio of the talk loop is then not sent to it.
+func (listening *genericRequestor) opusSpeechToText() internal_type.SpeechToTextOpusReceiver {
+	receiver, ok := listening.speechToTextTransformer.(internal_type.SpeechToTextOpusReceiver)
+	if !ok || !receiver.AcceptsOpus() {
+		return nil
+	}
+	return receiver
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"time"
+
+	internal_pacing "github.com/rapidaai/api/assistant-api/internal/pacing"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// speakingProfileMetadata is the conversation metadata holding the speaking
+// profile the assistant last switched to.
+const speakingProfileMetadata = "speaking.profile"
+
+// initializeSpeakingProfile reads the speaking profiles of the deployment and
+// picks the one the call starts in by the time of day and the conversation's
+// arguments and options. Profiles that do not parse are ignored as a whole.
+func (talking *genericRequestor) initializeSpeakingProfile(ctx context.Context) {
+	talking.speakingProfiles, talking.initialProfile = nil, nil
+	talking.speakingProfile.Store(nil)
+
+	output, err := talking.GetTextToSpeechTransformer()
+	if err != nil {
+		return
+	}
+	profiles, err := internal_pacing.FromOptions(output.GetOptions())
+	if err != nil {
+		talking.logger.Warnf("ignoring speaking profiles: %v", err)
+		return
+	}
+	talking.speakingProfiles = profiles
+	talking.initialProfile = profiles.Select(time.Now(), utils.MergeMaps(talking.args, talking.options))
+	if talking.initialProfile != nil {
+		talking.speakingProfile.Store(talking.initialProfile)
+		talking.recordSpeakingProfile(ctx, talking.initialProfile)
+	}
+}
+
+// SpeakingProfiles returns the speaking profiles of the deployment.
+func (talking *genericRequestor) SpeakingProfiles() internal_pacing.Profiles {
+	return talking.speakingProfiles
+}
+
+// SpeakingProfile returns the profile the assistant speaks in, nil when it
+// speaks as configured.
+func (talking *genericRequestor) SpeakingProfile() *internal_pacing.Profile {
+	return talking.speakingProfile.Load()
+}
+
+// setSpeakingProfile switches to another profile mid-call. Text to speech is
+// reconnected when the speaking rate changes, the next answers already follow
+// the new verbosity.
+func (talking *genericRequestor) setSpeakingProfile(ctx context.Context, vl internal_type.SpeakingProfilePacket) {
+	next := talking.initialProfile
+	if vl.Profile != "" {
+		if next = talking.speakingProfiles.Get(vl.Profile); next == nil {
+			talking.logger.Warnf("unknown speaking profile %q", vl.Profile)
+			return
+		}
+	}
+	previous := talking.speakingProfile.Swap(next)
+	if previous == next {
+		return
+	}
+	talking.logger.Infof("speaking profile switched to %q", profileName(next))
+	talking.recordSpeakingProfile(ctx, next)
+
+	if talking.messaging.GetMode().Audio() && profileRate(previous) != profileRate(next) {
+		if err := talking.disconnectTextToSpeech(ctx); err != nil {
+			talking.logger.Errorf("failed to close text to speech for the speaking profile: %v", err)
+		}
+		if err := talking.initializeTextToSpeech(ctx); err != nil {
+			talking.logger.Errorf("failed to initialize text to speech for the speaking profile: %v", err)
+		}
+	}
+}
+
+func (talking *genericRequestor) recordSpeakingProfile(ctx context.Context, profile *internal_pacing.Profile) {
+	utils.Go(ctx, func() {
+		if err := talking.onAddMetadata(ctx, &protos.Metadata{Key: speakingProfileMetadata, Value: profileName(profile)}); err != nil {
+			talking.logger.Errorf("unable to record speaking profile: %v", err)
+		}
+	})
+}
+
+// profileName is the name of a profile, empty when speaking as configured.
+func profileName(profile *internal_pacing.Profile) string {
+	if profile == nil {
+		return ""
+	}
+	return profile.Name
+}
+
+func profileRate(profile *internal_pacing.Profile) float64 {
+	if profile == nil {
+		return 0
+	}
+	return profile.Rate
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+)
+
+// callPickedUp takes the assistant out of a call a human agent picked up.
+// The caller no longer hears it, so it stops listening and speaking the way
+// it does on hold, which the call never leaves. The agent is recorded on the
+// conversation.
+func (talking *genericRequestor) callPickedUp(ctx context.Context, vl internal_type.CallPickupPacket) {
+	talking.logger.Infof("call picked up by agent %s with code %s", vl.Agent, vl.Code)
+	talking.setCallHold(ctx, internal_type.CallHoldPacket{ContextID: vl.ContextID, Held: true})
+	talking.OnPacket(ctx, internal_type.ConversationMetadataPacket{
+		ContextID: talking.Conversation().Id,
+		Metadata:  []*protos.Metadata{{Key: "telephony.picked_up_by", Value: vl.Agent}},
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_audio_pipeline "github.com/rapidaai/api/assistant-api/internal/audio/pipeline"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// initializeAudioPipeline hands the streamer the audio filters the assistant
+// configured for the channel. A pipeline that cannot be built is left out,
+// the call goes on with the caller audio as it comes.
+func (listening *genericRequestor) initializeAudioPipeline(options utils.Option) {
+	channel, ok := listening.streamer.(internal_audio_pipeline.Channel)
+	if !ok {
+		return
+	}
+	pipeline, err := internal_audio_pipeline.FromOptions(internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG, options, listening.Source())
+	if err != nil {
+		listening.logger.Warnf("unable to build the audio pipeline %+v", err)
+		return
+	}
+	if pipeline == nil {
+		return
+	}
+	listening.logger.Debugf("audio pipeline %v for %s", pipeline.Names(), listening.Source())
+	channel.SetInputFilter(pipeline)
+}
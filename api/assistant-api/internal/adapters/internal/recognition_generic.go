@@ -0,0 +1,68 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_recognition "github.com/rapidaai/api/assistant-api/internal/recognition"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// recognitionHeard tags a final transcript of the caller with the language
+// and confidence speech to text reported, the listen.language of the
+// deployment when the provider reported none, and its speaker.
+func (r *genericRequestor) recognitionHeard(vl internal_type.SpeechToTextPacket) {
+	if vl.Interim {
+		return
+	}
+	fallback := ""
+	if transformerConfig, _ := r.GetSpeechToTextTransformer(); transformerConfig != nil {
+		fallback, _ = transformerConfig.GetOptions().GetString("listen.language")
+	}
+	segment := internal_recognition.NewSegment(vl.Script, vl.Language, fallback, vl.Confidence)
+	segment.Speaker = vl.Speaker
+	r.recognitionTurn.Heard(segment)
+}
+
+// recognitionTurnEnded attaches the segments of the turn to its message and
+// stores them for the recognition quality of the assistant. It returns the
+// speaker of the turn, "" when speakers are not told apart.
+func (r *genericRequestor) recognitionTurnEnded(ctx context.Context, contextID string) string {
+	segments := r.recognitionTurn.End()
+	if len(segments) == 0 {
+		return ""
+	}
+	r.OnPacket(ctx, internal_type.MessageMetricPacket{ContextID: contextID, Metrics: internal_recognition.Metrics(segments)})
+
+	conversation := r.assistantConversation
+	transformerConfig, _ := r.GetSpeechToTextTransformer()
+	speaker := internal_recognition.Speaker(segments)
+	if conversation == nil || transformerConfig == nil {
+		return speaker
+	}
+	entities := make([]*internal_conversation_entity.AssistantTranscriptSegment, 0, len(segments))
+	for _, s := range segments {
+		entities = append(entities, &internal_conversation_entity.AssistantTranscriptSegment{
+			AssistantId:             conversation.AssistantId,
+			AssistantConversationId: conversation.Id,
+			ContextId:               contextID,
+			Provider:                transformerConfig.AudioProvider,
+			Language:                s.Language,
+			Confidence:              s.Confidence,
+			Words:                   uint32(s.Words),
+			Speaker:                 s.Speaker,
+		})
+	}
+	utils.Go(ctx, func() {
+		if err := r.conversationService.CreateTranscriptSegments(ctx, entities); err != nil {
+			r.logger.Errorf("unable to store transcript segments of %s: %v", contextID, err)
+		}
+	})
+	return speaker
+}
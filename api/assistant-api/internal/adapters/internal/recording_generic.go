@@ -0,0 +1,59 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_audio_recorder "github.com/rapidaai/api/assistant-api/internal/audio/recorder"
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// initializeRecorder starts recording the call unless the recording setting
+// of the assistant or the consent of the caller rules it out.
+func (r *genericRequestor) initializeRecorder(ctx context.Context) {
+	setting, err := r.recordingService.GetAssistantSetting(ctx, r.Auth(), r.assistant.Id)
+	if err != nil {
+		r.logger.Tracef(ctx, "failed to get recording setting, using the default: %+v", err)
+		setting = internal_conversation_entity.DefaultRecordingSetting()
+	}
+	if !r.recordingConsented(setting) {
+		r.logger.Debugf("not recording conversation %d, disabled or without consent", r.assistantConversation.Id)
+		return
+	}
+	rc, err := internal_audio_recorder.GetRecorder(r.logger)
+	if err != nil {
+		r.logger.Tracef(ctx, "failed to initialize audio recorder: %+v", err)
+		return
+	}
+	r.recordingSetting = setting
+	r.recorder = rc
+	r.recorder.Start()
+}
+
+// recordingConsented tells whether the conversation may be recorded. A
+// caller declining is honoured even where consent is not required.
+func (r *genericRequestor) recordingConsented(setting *internal_conversation_entity.AssistantRecordingSetting) bool {
+	if !setting.Enabled {
+		return false
+	}
+	consent, err := r.GetOptions().GetBool(internal_type.RecordingConsentOption)
+	if err != nil {
+		return !setting.RequireConsent
+	}
+	return consent
+}
+
+// redactedRecording tells whether a packet is left out of the recording,
+// the caller's audio while spelling mode is on when the setting asks for it.
+func (r *genericRequestor) redactedRecording(vl internal_type.Packet) bool {
+	if r.recordingSetting == nil || !r.recordingSetting.RedactSpelling {
+		return false
+	}
+	_, isUser := vl.(internal_type.UserAudioPacket)
+	return isUser && r.spelling.Load() != nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"maps"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_sessionstate "github.com/rapidaai/api/assistant-api/internal/sessionstate"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/connectors"
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// newResumes keeps dropped sessions in redis when session resumption is
+// configured.
+func newResumes(cfg *config.AssistantConfig, redis connectors.RedisConnector) *internal_sessionstate.Resumes {
+	if cfg == nil || cfg.SessionResume == nil || redis == nil {
+		return nil
+	}
+	return internal_sessionstate.NewResumes(redis.GetConnection(), cfg.SessionResume.TTL())
+}
+
+// claimResume hands the session a resume token when its channel lets clients
+// reconnect. A client reconnecting with the token of a dropped session of the
+// same assistant continues that conversation: config is pointed at it and
+// the state kept at the drop is restored once it is resumed. An unknown or
+// expired token starts a new conversation.
+func (r *genericRequestor) claimResume(ctx context.Context, config *protos.ConversationInitialization, assistant *internal_assistant_entity.Assistant) {
+	if channel, ok := r.streamer.(internal_type.ResumableChannel); r.resumes == nil || !ok || !channel.Resumable() {
+		return
+	}
+	r.resumeToken = internal_sessionstate.NewResumeToken()
+	value, ok := config.GetOptions()[internal_sessionstate.OptionResumeToken]
+	if !ok {
+		return
+	}
+	// the token is not an option of the conversation
+	delete(config.Options, internal_sessionstate.OptionResumeToken)
+	if config.GetAssistantConversationId() > 0 {
+		return
+	}
+	token, err := utils.AnyToString(value)
+	if err != nil || token == "" {
+		return
+	}
+	state, err := r.resumes.Take(ctx, token)
+	if err != nil {
+		r.logger.Warnf("unable to read the session to resume, starting a new conversation: %v", err)
+		return
+	}
+	if state == nil {
+		r.logger.Infof("resume token expired, starting a new conversation")
+		return
+	}
+	if state.AssistantID != assistant.Id || !r.sameProject(state.ProjectID) {
+		r.logger.Warnf("resume token of conversation %d does not belong to assistant %d, starting a new conversation", state.ConversationID, assistant.Id)
+		return
+	}
+
+	config.AssistantConversationId = state.ConversationID
+	if config.GetStreamMode() == protos.StreamMode_STREAM_MODE_UNSPECIFIED {
+		switch type_enums.MessageMode(state.Mode) {
+		case type_enums.AudioMode:
+			config.StreamMode = protos.StreamMode_STREAM_MODE_AUDIO
+		default:
+			config.StreamMode = protos.StreamMode_STREAM_MODE_TEXT
+		}
+	}
+	r.resumeToken, r.resumed = token, state
+	r.logger.Infof("resuming conversation %d dropped at %s", state.ConversationID, state.CapturedAt)
+}
+
+func (r *genericRequestor) sameProject(projectID uint64) bool {
+	auth := r.Auth()
+	if auth == nil {
+		return false
+	}
+	id := auth.GetCurrentProjectId()
+	return id != nil && *id == projectID
+}
+
+// withResumeToken adds the resume token of the session to the options sent
+// back to the client.
+func (r *genericRequestor) withResumeToken(options map[string]*anypb.Any) map[string]*anypb.Any {
+	if r.resumeToken == "" {
+		return options
+	}
+	token, err := utils.StringToAny(r.resumeToken)
+	if err != nil {
+		return options
+	}
+	options = maps.Clone(options)
+	if options == nil {
+		options = make(map[string]*anypb.Any, 1)
+	}
+	options[internal_sessionstate.OptionResumeToken] = token
+	return options
+}
+
+// revokeResume keeps a conversation the assistant ended from being resumed.
+func (r *genericRequestor) revokeResume() {
+	r.resumeRevoked.Store(true)
+}
+
+// handedOver reports whether the call was taken over by another instance,
+// the conversation goes on there.
+func (r *genericRequestor) handedOver() bool {
+	channel, ok := r.streamer.(internal_type.HandoverChannel)
+	return ok && channel.HandedOver()
+}
+
+// saveResume keeps the state of a text session as it disconnects, the
+// client can resume it with its token until the ttl runs out.
+func (r *genericRequestor) saveResume(ctx context.Context) {
+	if r.resumes == nil || r.resumeToken == "" || r.resumeRevoked.Load() || r.assistantConversation == nil {
+		return
+	}
+	if r.messaging.GetMode() != type_enums.TextMode {
+		return
+	}
+	// the dialogue of an encrypted conversation is not kept outside it
+	if data, err := r.conversationService.GetConversationCipher(ctx, r.assistantConversation.Id); err != nil || data != nil {
+		return
+	}
+	state := r.SessionState()
+	if err := r.resumes.Save(ctx, r.resumeToken, state); err != nil {
+		r.logger.Warnf("unable to keep conversation %d for resumption: %v", state.ConversationID, err)
+	}
+}
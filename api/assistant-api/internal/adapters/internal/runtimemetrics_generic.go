@@ -0,0 +1,55 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	internal_drain "github.com/rapidaai/api/assistant-api/internal/drain"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+)
+
+// sessionStarted counts the connected session in the active sessions of its
+// source, a draining replica waits for it to end.
+func (r *genericRequestor) sessionStarted() {
+	if r.sessionCounted.CompareAndSwap(false, true) {
+		internal_runtimemetrics.ActiveSessions.With(string(r.source)).Inc()
+		internal_drain.Default.SessionStarted()
+		r.providerStreams = r.streamingProviders()
+		for _, provider := range r.providerStreams {
+			internal_runtimemetrics.ProviderStreams.With(provider).Inc()
+		}
+	}
+}
+
+// sessionEnded takes the session out of the active sessions, once.
+func (r *genericRequestor) sessionEnded() {
+	if r.sessionCounted.CompareAndSwap(true, false) {
+		internal_runtimemetrics.ActiveSessions.With(string(r.source)).Dec()
+		internal_drain.Default.SessionEnded()
+		for _, provider := range r.providerStreams {
+			internal_runtimemetrics.ProviderStreams.With(provider).Dec()
+		}
+		r.providerStreams = nil
+	}
+}
+
+// streamingProviders are the providers the session keeps a stream open to,
+// the LLM always and speech to text and text to speech when configured.
+func (r *genericRequestor) streamingProviders() []string {
+	providers := []string{internal_runtimemetrics.LLM}
+	if transformer, _ := r.GetSpeechToTextTransformer(); transformer != nil {
+		providers = append(providers, internal_runtimemetrics.SpeechToText)
+	}
+	if transformer, _ := r.GetTextToSpeechTransformer(); transformer != nil {
+		providers = append(providers, internal_runtimemetrics.TextToSpeech)
+	}
+	return providers
+}
+
+// providerFailed counts a failed request to the speech to text, LLM or text
+// to speech provider of the session.
+func (r *genericRequestor) providerFailed(provider string) {
+	internal_runtimemetrics.ProviderErrors.With(provider).Inc()
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	internal_callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_scratchpad "github.com/rapidaai/api/assistant-api/internal/scratchpad"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// callContextStreamer is implemented by telephony streamers, which are the
+// only ones backed by a call context.
+type callContextStreamer interface {
+	CallContext() *internal_callcontext.CallContext
+}
+
+// Scratchpad returns the conversation scoped key/value state.
+func (talking *genericRequestor) Scratchpad() internal_type.Scratchpad {
+	return talking.scratchpad
+}
+
+// initializeScratchpad restores the scratchpad from the call context and
+// persists every change back to it. Conversations without a call context
+// keep the in memory scratchpad. The scratchpad of an encrypted conversation
+// is stored sealed with its data key; when the key cannot be read it stays in
+// memory rather than being written in plaintext.
+func (talking *genericRequestor) initializeScratchpad(ctx context.Context) {
+	streamer, ok := talking.streamer.(callContextStreamer)
+	if !ok || streamer.CallContext() == nil || talking.callContextStore == nil {
+		return
+	}
+	cc := streamer.CallContext()
+	data, err := talking.conversationService.GetConversationCipher(ctx, talking.assistantConversation.Id)
+	if err != nil {
+		talking.logger.Errorf("unable to get the key of conversation %d, scratchpad is kept in memory: %v", talking.assistantConversation.Id, err)
+		return
+	}
+	aad := []byte(cc.ContextID)
+	values, err := internal_scratchpad.Open(cc.Scratchpad, data, aad)
+	if err != nil {
+		talking.logger.Errorf("unable to open the scratchpad of call context %s: %v", cc.ContextID, err)
+		return
+	}
+	talking.scratchpad = internal_scratchpad.NewScratchpad(values, func(ctx context.Context, values map[string]interface{}) error {
+		if data != nil {
+			sealed, err := internal_scratchpad.Seal(values, data, aad)
+			if err != nil {
+				return err
+			}
+			values = sealed
+		}
+		dbCtx, cancel := context.WithTimeout(context.Background(), dbWriteTimeout)
+		defer cancel()
+		return talking.callContextStore.SaveScratchpad(dbCtx, cc.ContextID, values)
+	})
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	internal_seed "github.com/rapidaai/api/assistant-api/internal/seed"
+)
+
+// initializeSeed puts the conversation in seeded mode when its options carry
+// a seed, message ids then repeat from run to run. The executor seeds model
+// sampling from the same option.
+func (talking *genericRequestor) initializeSeed() {
+	seed, ok := internal_seed.FromOptions(talking.options)
+	if !ok {
+		return
+	}
+	talking.messaging.UseIDGenerator(internal_seed.NewIDGenerator(seed))
+	talking.logger.Infof("conversation is running in seeded mode with seed %d", seed)
+}
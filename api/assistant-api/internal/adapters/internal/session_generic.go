@@ -18,7 +18,9 @@ import (
 	internal_audio_recorder "github.com/rapidaai/api/assistant-api/internal/audio/recorder"
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_startup "github.com/rapidaai/api/assistant-api/internal/startup"
 	internal_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
+	internal_telemetry_batch "github.com/rapidaai/api/assistant-api/internal/telemetry/batch"
 	"github.com/rapidaai/pkg/types"
 	type_enums "github.com/rapidaai/pkg/types/enums"
 	"github.com/rapidaai/pkg/utils"
@@ -51,6 +53,7 @@ const (
 //   - Closing all active listeners (speech-to-text transformers)
 //   - Closing all active speakers (text-to-speech transformers)
 //   - Flushing final conversation metrics (duration, status)
+//   - Scoring the call quality of the last media interval
 //   - Persisting audio recordings to storage
 //   - Exporting telemetry data for analytics
 //   - Cleaning up the assistant executor
@@ -94,15 +97,29 @@ func (r *genericRequestor) Disconnect(ctx context.Context) {
 		}
 	})
 	waitGroup.Wait()
+	r.releaseStandby(ctx)
+	r.sessionEnded()
 
-	// Phase 2: Trigger end-of-conversation hooks
-	r.OnEndConversation(ctx)
+	// Phase 2: Trigger end-of-conversation hooks, a call handed over to
+	// another instance goes on there
+	if !r.handedOver() {
+		r.OnEndConversation(ctx)
+	}
+	r.closeTranscriptStream(ctx)
+	r.closeEventLog()
+	r.finishMetering(ctx)
+	r.recordChannelDrops(ctx)
+	r.saveResume(ctx)
+	r.closeSessionState()
+	r.closeCluster()
+	r.closeSupervision()
+	r.closeConference()
 
 	// Phase 3: Persist audio recording asynchronously
 	r.persistRecording(ctx)
 
-	// Phase 4: Complete the tracing span
-	span.EndSpan(ctx, utils.AssistantDisconnectStage)
+	// Phase 4: Complete the tracing span with the call quality
+	span.EndSpan(ctx, utils.AssistantDisconnectStage, r.finishCallQuality(ctx)...)
 
 	// Phase 5: Export telemetry and cleanup
 	r.exportTelemetry(ctx)
@@ -145,25 +162,53 @@ func (r *genericRequestor) Connect(
 ) error {
 	ctx, span, _ := r.Tracer().StartSpan(ctx, utils.AssistantConnectStage)
 	defer span.EndSpan(ctx, utils.AssistantConnectStage)
+	initialized := time.Now()
 
-	// Set authentication context
-	r.SetAuth(auth)
-
-	// Retrieve assistant configuration
-	assistant, err := r.GetAssistant(ctx, auth, config.Assistant.AssistantId, config.Assistant.Version)
-	if err != nil {
-		r.logger.Errorf("failed to retrieve assistant configuration: %+v", err)
+	// Set authentication context, a session token only talks to the
+	// assistant and as the user it was minted for
+	if err := bindSessionToken(auth, config); err != nil {
+		r.logger.Errorf("refused session token: %+v", err)
 		return err
 	}
+	r.SetAuth(auth)
+	r.answeredBy = answeredBy(config)
+
+	// Retrieve assistant configuration, a standby built ahead of the call
+	// already has it
+	loaded := r.startup.Track(internal_startup.Assistant)
+	assistant := r.claimStandby(ctx, auth, config)
+	if assistant == nil {
+		var err error
+		if assistant, err = r.GetAssistant(ctx, auth, config.Assistant.AssistantId, config.Assistant.Version); err != nil {
+			loaded()
+			r.logger.Errorf("failed to retrieve assistant configuration: %+v", err)
+			return err
+		}
+	}
+	loaded()
+	r.claimResume(ctx, config, assistant)
+
+	// the providers' credentials are fetched while the conversation is created
+	r.prefetchCredentials(ctx, config, assistant)
 
 	// Route to appropriate session handler based on conversation ID presence
 	if conversationID := config.GetAssistantConversationId(); conversationID > 0 {
 		span.AddAttributes(ctx, internal_telemetry.KV{K: "conversation_initiation", V: internal_telemetry.StringValue("resume")}, internal_telemetry.KV{K: "conversation_id", V: internal_telemetry.IntValue(conversationID)})
-		return r.resumeSession(ctx, config, assistant)
+		if err := r.resumeSession(ctx, config, assistant); err != nil {
+			return err
+		}
+		r.sessionStarted()
+		r.reportStartup(ctx, initialized)
+		return nil
 	}
 
 	span.AddAttributes(ctx, internal_telemetry.KV{K: "conversation_initiation", V: internal_telemetry.StringValue("new")})
-	return r.createSession(ctx, config, assistant)
+	if err := r.createSession(ctx, config, assistant); err != nil {
+		return err
+	}
+	r.sessionStarted()
+	r.reportStartup(ctx, initialized)
+	return nil
 }
 
 // persistRecording saves the audio recording asynchronously.
@@ -179,6 +224,17 @@ func (r *genericRequestor) persistRecording(ctx context.Context) {
 				r.logger.Tracef(ctx, "failed to persist audio recording: %+v", err)
 				return
 			}
+			if r.recordingSetting != nil && r.recordingSetting.DualChannel {
+				stereo, err := internal_audio_recorder.Stereo(userAudio, systemAudio)
+				if err != nil {
+					r.logger.Tracef(ctx, "failed to merge audio recording channels: %+v", err)
+					return
+				}
+				if err = r.CreateConversationStereoRecording(ctx, stereo); err != nil {
+					r.logger.Tracef(ctx, "failed to create conversation recording record: %+v", err)
+				}
+				return
+			}
 			if err = r.CreateConversationRecording(ctx, userAudio, systemAudio); err != nil {
 				r.logger.Tracef(ctx, "failed to create conversation recording record: %+v", err)
 			}
@@ -198,6 +254,11 @@ func (r *genericRequestor) exportTelemetry(ctx context.Context) {
 	if err := r.tracer.Export(ctx, r.auth, exportOptions); err != nil {
 		r.logger.Errorf("failed to export telemetry data: %v", err)
 	}
+
+	// metrics queued for batching, the call quality ones were just added
+	if err := internal_telemetry_batch.Flush(context.Background()); err != nil {
+		r.logger.Errorf("failed to flush queued telemetry: %v", err)
+	}
 }
 
 // closeExecutor shuts down the assistant executor and releases its resources.
@@ -207,7 +268,7 @@ func (r *genericRequestor) closeExecutor(ctx context.Context) {
 	}
 }
 
-// stopTimers stops all active timers (idle timeout and max session duration).
+// stopTimers stops all active timers (idle timeout, max session duration and voicemail).
 func (r *genericRequestor) stopTimers() {
 	if r.idleTimeoutTimer != nil {
 		r.idleTimeoutTimer.Stop()
@@ -215,6 +276,9 @@ func (r *genericRequestor) stopTimers() {
 	if r.maxSessionTimer != nil {
 		r.maxSessionTimer.Stop()
 	}
+	if r.voicemailTimer != nil {
+		r.voicemailTimer.Stop()
+	}
 }
 
 // =============================================================================
@@ -231,15 +295,31 @@ func (r *genericRequestor) resumeSession(
 	defer span.EndSpan(ctx, utils.AssistantResumeConverstaionStage)
 
 	// Resume existing conversation
+	created := r.startup.Track(internal_startup.Conversation)
 	conversation, err := r.ResumeConversation(ctx, assistant, config)
+	created()
 	if err != nil {
 		r.logger.Errorf("failed to resume conversation: %+v", err)
 		return err
 	}
+	r.initializeSpeakingProfile(ctx)
+	r.initializeMonologue()
+	r.initializeTranscriptStream(ctx)
+	r.initializeEventLog(ctx)
+	r.initializeSnapshots()
+	r.initializeDiarization()
+	r.initializeLanguageDetection()
+	r.initializeMetering()
+	r.restoreSessionState(ctx)
+	r.initializeSessionState()
+	r.initializeSupervision()
+	r.initializeCluster()
+
 	// Initialize critical components concurrently
 	errGroup, _ := errgroup.WithContext(ctx)
 
 	errGroup.Go(func() error {
+		defer r.startup.Track(internal_startup.LLM)()
 		if err := r.assistantExecutor.Initialize(ctx, r, config); err != nil {
 			r.logger.Tracef(ctx, "failed to initialize executor: %+v", err)
 			return err
@@ -260,35 +340,34 @@ func (r *genericRequestor) resumeSession(
 		case protos.StreamMode_STREAM_MODE_TEXT:
 			r.messaging.SwitchMode(type_enums.TextMode)
 		case protos.StreamMode_STREAM_MODE_AUDIO:
+			spoken := r.startup.Track(internal_startup.TextToSpeech)
 			r.initializeTextToSpeech(ctx)
+			spoken()
 			r.messaging.SwitchMode(type_enums.AudioMode)
 		}
 		return nil
 	})
 
-	utils.Go(ctx, func() {
-		switch config.StreamMode {
-		case protos.StreamMode_STREAM_MODE_AUDIO:
+	// only an audio session listens, the caller's first words wait for it
+	if config.StreamMode == protos.StreamMode_STREAM_MODE_AUDIO {
+		listened := r.startup.Track(internal_startup.SpeechToText)
+		utils.Go(ctx, func() {
+			defer listened()
 			r.initializeSpeechToText(ctx)
-		}
-	})
+		})
+	}
 
 	// Start non-critical background tasks (not a new session)
 
 	// Initialize audio recorder when both input and output are configured
 	utils.Go(ctx, func() {
-		rc, err := internal_audio_recorder.GetRecorder(r.logger)
-		if err != nil {
-			r.logger.Tracef(ctx, "failed to initialize audio recorder: %+v", err)
-			return
-		}
-
-		r.recorder = rc
-		r.recorder.Start()
+		r.initializeRecorder(ctx)
 	})
 
 	// Establish speech-to-text listener connection
+	detected := r.startup.Track(internal_startup.EndOfSpeech)
 	utils.Go(ctx, func() {
+		defer detected()
 		if err := r.initializeEndOfSpeech(ctx); err != nil {
 			r.logger.Tracef(ctx, "failed to initialize input: %+v", err)
 		}
@@ -318,6 +397,8 @@ func (r *genericRequestor) resumeSession(
 	err = errGroup.Wait()
 	r.notifyConfiguration(ctx, config, conversation, assistant)
 	r.initializeBehavior(ctx)
+	r.initializeCallQuality(ctx)
+	r.recordWarmStart(ctx)
 	return err
 }
 
@@ -330,23 +411,36 @@ func (r *genericRequestor) createSession(
 	ctx, span, _ := r.Tracer().StartSpan(ctx, utils.AssistantCreateConversationStage)
 	defer span.EndSpan(ctx, utils.AssistantCreateConversationStage)
 
-	//
+	created := r.startup.Track(internal_startup.Conversation)
 	conversation, err := r.BeginConversation(
 		ctx,
 		assistant,
 		type_enums.DIRECTION_INBOUND,
 		config,
 	)
+	created()
 	if err != nil {
 		r.logger.Errorf("failed to begin conversation: %+v", err)
 		return err
 	}
+	r.initializeSpeakingProfile(ctx)
+	r.initializeMonologue()
+	r.initializeTranscriptStream(ctx)
+	r.initializeEventLog(ctx)
+	r.initializeSnapshots()
+	r.initializeDiarization()
+	r.initializeLanguageDetection()
+	r.initializeMetering()
+	r.initializeSessionState()
+	r.initializeSupervision()
+	r.initializeCluster()
 
 	// Initialize critical components concurrently
 	errGroup, _ := errgroup.WithContext(ctx)
 
 	// blocking initialization of assistant executor to ensure it's ready before processing any input or output
 	errGroup.Go(func() error {
+		defer r.startup.Track(internal_startup.LLM)()
 		if err := r.assistantExecutor.Initialize(ctx, r, config); err != nil {
 			r.logger.Tracef(ctx, "failed to initialize executor: %+v", err)
 			return err
@@ -359,7 +453,9 @@ func (r *genericRequestor) createSession(
 		case protos.StreamMode_STREAM_MODE_TEXT:
 			r.messaging.SwitchMode(type_enums.TextMode)
 		case protos.StreamMode_STREAM_MODE_AUDIO:
+			spoken := r.startup.Track(internal_startup.TextToSpeech)
 			r.initializeTextToSpeech(ctx)
+			spoken()
 			r.messaging.SwitchMode(type_enums.AudioMode)
 		}
 		return nil
@@ -373,31 +469,27 @@ func (r *genericRequestor) createSession(
 		return nil
 	})
 
-	utils.Go(ctx, func() {
-		switch config.StreamMode {
-		case protos.StreamMode_STREAM_MODE_AUDIO:
+	// only an audio session listens, the caller's first words wait for it
+	if config.StreamMode == protos.StreamMode_STREAM_MODE_AUDIO {
+		listened := r.startup.Track(internal_startup.SpeechToText)
+		utils.Go(ctx, func() {
+			defer listened()
 			r.initializeSpeechToText(ctx)
-		}
-	})
+		})
+	}
 
 	// Start non-critical background tasks
 	// Initialize audio recorder when both input and output are configured
 	utils.Go(ctx, func() {
-		rc, err := internal_audio_recorder.GetRecorder(r.logger)
-		if err != nil {
-			r.logger.Tracef(ctx, "failed to initialize audio recorder: %+v", err)
-			return
-		}
-		r.recorder = rc
-		r.recorder.Start()
-
+		r.initializeRecorder(ctx)
 	})
 
+	detected := r.startup.Track(internal_startup.EndOfSpeech)
 	utils.Go(ctx, func() {
+		defer detected()
 		if err := r.initializeEndOfSpeech(ctx); err != nil {
 			r.logger.Tracef(ctx, "failed to initialize input: %+v", err)
 		}
-
 	})
 
 	// Update conversation status metric
@@ -423,6 +515,8 @@ func (r *genericRequestor) createSession(
 	err = errGroup.Wait()
 	r.notifyConfiguration(ctx, config, conversation, assistant)
 	r.initializeBehavior(ctx)
+	r.initializeCallQuality(ctx)
+	r.recordWarmStart(ctx)
 	return err
 }
 
@@ -444,7 +538,7 @@ func (r *genericRequestor) notifyConfiguration(
 		},
 		Args:         config.GetArgs(),
 		Metadata:     config.GetOptions(),
-		Options:      config.GetMetadata(),
+		Options:      r.withResumeToken(config.GetMetadata()),
 		StreamMode:   config.GetStreamMode(),
 		UserIdentity: config.GetUserIdentity(),
 		Time:         timestamppb.Now(),
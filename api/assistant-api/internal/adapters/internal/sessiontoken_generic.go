@@ -0,0 +1,52 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"errors"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+)
+
+// errSessionTokenScope is returned to a session opened with a WebTalk
+// session token minted for another assistant or end user.
+var errSessionTokenScope = errors.New("the session token does not permit this assistant or user")
+
+// bindSessionToken holds a session opened with a WebTalk session token to
+// the assistant and end user the token was minted for. A client naming no
+// user talks as the user of the token.
+func bindSessionToken(auth types.SimplePrinciple, config *protos.ConversationInitialization) error {
+	scope, ok := auth.(*types.SessionScope)
+	if !ok {
+		return nil
+	}
+	userID := ""
+	switch identity := config.GetUserIdentity().(type) {
+	case nil:
+		if scope.UserId != "" {
+			config.UserIdentity = &protos.ConversationInitialization_Web{Web: &protos.WebIdentity{UserId: scope.UserId}}
+		}
+		userID = scope.UserId
+	case *protos.ConversationInitialization_Web:
+		userID = identity.Web.GetUserId()
+	default:
+		return errSessionTokenScope
+	}
+	if !scope.Permits(config.GetAssistant().GetAssistantId(), userID) {
+		return errSessionTokenScope
+	}
+	return nil
+}
+
+// permitsConversation reports whether auth may resume conversation, a
+// session token bound to an end user only resumes the conversations of that
+// user.
+func permitsConversation(auth types.SimplePrinciple, conversation *internal_conversation_entity.AssistantConversation) bool {
+	scope, ok := auth.(*types.SessionScope)
+	return !ok || scope.UserId == "" || conversation.Identifier == scope.UserId
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"time"
+
+	internal_snapshot "github.com/rapidaai/api/assistant-api/internal/snapshot"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// initializeSnapshots reads from the speech to text options of the deployment
+// whether misheard turns point into the recording.
+func (r *genericRequestor) initializeSnapshots() {
+	if transformerConfig, _ := r.GetSpeechToTextTransformer(); transformerConfig != nil {
+		r.snapshotPolicy = internal_snapshot.FromOptions(speechToTextOptions(transformerConfig))
+	}
+}
+
+// snapshotting reports whether turns are followed at all, there is nothing to
+// point into without a recording.
+func (r *genericRequestor) snapshotting() bool {
+	return r.snapshotPolicy != nil && r.recorder != nil
+}
+
+// snapshotHeard follows a final transcript of the caller. The turn is taken
+// to start where voice activity was first detected, or at the transcript
+// when there was none.
+func (r *genericRequestor) snapshotHeard(vl internal_type.SpeechToTextPacket) {
+	if !r.snapshotting() || vl.Interim {
+		return
+	}
+	onset := time.Now()
+	if nanos := r.voiceOnset.Load(); nanos > 0 {
+		onset = time.Unix(0, nanos)
+	}
+	r.snapshotTurn.Begin(r.recorder.Offset(onset))
+	r.snapshotTurn.Heard(vl.Confidence)
+}
+
+// snapshotTurnEnded attaches the stretch of the recording to the message of
+// the turn when it was transcribed below the threshold.
+func (r *genericRequestor) snapshotTurnEnded(ctx context.Context, contextID string) {
+	if !r.snapshotting() {
+		return
+	}
+	snapshot, ok := r.snapshotTurn.End(r.snapshotPolicy, r.recorder.Offset(time.Now()))
+	if !ok {
+		return
+	}
+	r.logger.Debugf("turn %s transcribed with confidence %.2f, snapshot %v-%v", contextID, snapshot.Confidence, snapshot.From, snapshot.To)
+	r.OnPacket(ctx, internal_type.MessageMetricPacket{ContextID: contextID, Metrics: snapshot.Metrics()})
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"fmt"
+
+	internal_spelling "github.com/rapidaai/api/assistant-api/internal/spelling"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// setSpellingMode turns spelling mode on or off.
+func (talking *genericRequestor) setSpellingMode(vl internal_type.SpellingModePacket) {
+	if !vl.Enabled {
+		talking.spelling.Store(nil)
+		return
+	}
+	capture, err := internal_spelling.NewCapture(vl.Kind, vl.Pattern)
+	if err != nil {
+		talking.logger.Warnf("unable to start spelling mode: %v", err)
+		return
+	}
+	talking.spelling.Store(capture)
+}
+
+// spelledSpeech decodes a user turn while spelling mode is on and annotates
+// it with the captured value, so the model confirms it by reading it back or
+// asks again when it doesn't validate. The mode ends with a valid capture.
+func (talking *genericRequestor) spelledSpeech(speech string) string {
+	capture := talking.spelling.Load()
+	if capture == nil {
+		return speech
+	}
+	result := capture.Parse(speech)
+	if !result.Valid {
+		return fmt.Sprintf("%s\n[spelling] decoded %q which is not a valid %s, ask the user to spell it again.", speech, result.Value, capture.Kind())
+	}
+	// spelling mode may have been set again by the model in the meantime
+	talking.spelling.CompareAndSwap(capture, nil)
+	return fmt.Sprintf("%s\n[spelling] captured %s %q, read it back to the user as %q and ask them to confirm.", speech, capture.Kind(), result.Value, result.ReadBack)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+	internal_startup "github.com/rapidaai/api/assistant-api/internal/startup"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// providerCredential is the vault credential of a provider, fetched while the
+// conversation is created.
+type providerCredential struct {
+	done       chan struct{}
+	credential *protos.VaultCredential
+	err        error
+}
+
+// prefetchCredentials fetches the credentials of the speech to text and text
+// to speech providers of an audio session while the conversation is created,
+// their connections find them ready. A text session connects neither, a
+// standby built for the assistant brings its connections.
+func (r *genericRequestor) prefetchCredentials(ctx context.Context, config *protos.ConversationInitialization, assistant *internal_assistant_entity.Assistant) {
+	r.credentials = nil
+	if config.GetStreamMode() != protos.StreamMode_STREAM_MODE_AUDIO {
+		return
+	}
+	if r.standby != nil && r.standby.Assistant == assistant {
+		return
+	}
+	for _, deployment := range []func(*internal_assistant_entity.Assistant, utils.RapidaSource) (*internal_assistant_entity.AssistantDeploymentAudio, error){inputAudioDeployment, outputAudioDeployment} {
+		audio, err := deployment(assistant, r.source)
+		if err != nil || audio == nil {
+			continue
+		}
+		credentialID, err := utils.Option(audio.GetOptions()).GetUint64("rapida.credential_id")
+		if err != nil {
+			continue
+		}
+		if r.credentials == nil {
+			r.credentials = make(map[uint64]*providerCredential, 2)
+		}
+		if _, ok := r.credentials[credentialID]; !ok {
+			r.credentials[credentialID] = &providerCredential{done: make(chan struct{})}
+		}
+	}
+	if len(r.credentials) == 0 {
+		return
+	}
+
+	fetched := r.startup.Track(internal_startup.Credentials)
+	var wg sync.WaitGroup
+	for credentialID, fetch := range r.credentials {
+		wg.Add(1)
+		utils.Go(ctx, func() {
+			defer wg.Done()
+			defer close(fetch.done)
+			fetch.credential, fetch.err = r.VaultCaller().GetCredential(ctx, r.Auth(), credentialID)
+		})
+	}
+	utils.Go(ctx, func() {
+		wg.Wait()
+		fetched()
+	})
+}
+
+// credential returns the vault credential credentialID, the prefetched one
+// when there is one.
+func (r *genericRequestor) credential(ctx context.Context, credentialID uint64) (*protos.VaultCredential, error) {
+	if fetch, ok := r.credentials[credentialID]; ok {
+		select {
+		case <-fetch.done:
+			if fetch.err == nil {
+				return fetch.credential, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return r.VaultCaller().GetCredential(ctx, r.Auth(), credentialID)
+}
+
+// reportStartup completes the startup profile once the session is ready, the
+// conversation was initialized at initialized. The breakdown is stored with
+// the conversation after speech to text, which connects in the background,
+// is done as well.
+func (r *genericRequestor) reportStartup(ctx context.Context, initialized time.Time) {
+	r.startup.Observe(internal_startup.Ready, time.Since(initialized))
+	r.startup.Observe(internal_startup.Total, r.startup.Elapsed())
+	utils.Go(ctx, func() {
+		r.startup.Wait()
+		for phase, took := range r.startup.Breakdown() {
+			internal_runtimemetrics.ObserveStartup(string(phase), took)
+		}
+		r.onAddMetrics(ctx, r.startup.Metrics()...)
+	})
+}
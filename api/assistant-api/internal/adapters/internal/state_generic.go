@@ -0,0 +1,151 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	internal_agent_executor "github.com/rapidaai/api/assistant-api/internal/agent/executor"
+	internal_sessionstate "github.com/rapidaai/api/assistant-api/internal/sessionstate"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// initializeSessionState makes the session capturable by the debug snapshot
+// API while it is connected.
+func (r *genericRequestor) initializeSessionState() {
+	if r.unregisterState != nil {
+		r.unregisterState()
+	}
+	r.unregisterState = internal_sessionstate.Register(r.assistantConversation.Id, r)
+}
+
+// closeSessionState removes the session from the snapshot API.
+func (r *genericRequestor) closeSessionState() {
+	if r.unregisterState != nil {
+		r.unregisterState()
+		r.unregisterState = nil
+	}
+}
+
+// SessionState implements internal_sessionstate.Source. It is a best effort
+// copy taken while the call runs, turns in flight may be half in it.
+func (r *genericRequestor) SessionState() *internal_sessionstate.State {
+	now := time.Now()
+	state := &internal_sessionstate.State{
+		CapturedAt:     now,
+		ConversationID: r.assistantConversation.Id,
+		Source:         r.source.Get(),
+		Mode:           r.messaging.GetMode().String(),
+		Arguments:      maps.Clone(r.args),
+		Options:        maps.Clone(r.options),
+		Metadata:       maps.Clone(r.metadata),
+		Scratchpad:     r.Scratchpad().All(),
+	}
+	// a restored conversation carries the artifact it was made from
+	delete(state.Options, internal_sessionstate.OptionKey)
+
+	if auth := r.Auth(); auth != nil {
+		if id := auth.GetCurrentProjectId(); id != nil {
+			state.ProjectID = *id
+		}
+		if id := auth.GetCurrentOrganizationId(); id != nil {
+			state.OrganizationID = *id
+		}
+	}
+	if assistant := r.assistant; assistant != nil {
+		state.AssistantID, state.AssistantProviderModelID = assistant.Id, assistant.AssistantProviderId
+	}
+
+	for _, msg := range r.histories {
+		state.Histories = append(state.Histories, internal_sessionstate.Message{Role: msg.Role(), Content: msg.Content()})
+	}
+	if inspector, ok := r.assistantExecutor.(internal_agent_executor.ContextInspector); ok {
+		state.ModelContext, state.PendingTools = internal_sessionstate.ModelContext(inspector.ModelContext())
+	}
+
+	state.Flow = internal_sessionstate.Flow{
+		MessageID:        r.messaging.GetID(),
+		Interaction:      r.messaging.State().String(),
+		OnHold:           r.onHold.Load(),
+		SpeechEntity:     r.expectedEntity(),
+		SpeakingProfile:  profileName(r.SpeakingProfile()),
+		SpeechDegraded:   r.speechDegraded.Load(),
+		AnsweredBy:       r.answeredBy,
+		IdleTimeoutCount: r.idleTimeoutCount,
+	}
+	if capture := r.spelling.Load(); capture != nil {
+		state.Flow.Spelling = capture.Kind()
+	}
+	r.dictationMu.Lock()
+	state.Flow.Dictating, state.Flow.Dictations = r.dictation != nil, r.dictations
+	r.dictationMu.Unlock()
+	r.handoffMu.Lock()
+	state.Flow.Handoffs = r.handoffs
+	r.handoffMu.Unlock()
+
+	untilMs := func(nanos int64) int64 {
+		if nanos <= now.UnixNano() {
+			return 0
+		}
+		return time.Duration(nanos - now.UnixNano()).Milliseconds()
+	}
+	state.Buffers = internal_sessionstate.Buffers{
+		AssistantAudibleMs: untilMs(r.playbackUntil.Load()),
+		DuckedMs:           untilMs(r.duckedUntil.Load()),
+		Recording:          r.recorder != nil,
+		TranscriptStreams:  len(r.transcripts),
+	}
+	if onset := r.voiceOnset.Load(); onset > 0 {
+		state.Buffers.CallerSpeakingMs = now.Sub(time.Unix(0, onset)).Milliseconds()
+	}
+	return state
+}
+
+// restoreSessionState seeds a conversation restored from a snapshot,
+// resumed after its stream dropped or migrated from another instance with
+// the captured dialogue, scratchpad, speaking profile and spelling mode. The
+// model picks the dialogue up as its history. Pending tool calls are not run again, they are only logged.
+func (r *genericRequestor) restoreSessionState(ctx context.Context) {
+	state, ok := r.resumed, r.resumed != nil
+	if !ok {
+		state = internal_sessionstate.TakeAdopted(r.assistantConversation.Id)
+		ok = state != nil
+	}
+	if !ok {
+		state, ok = internal_sessionstate.FromOptions(r.options)
+	}
+	if !ok {
+		return
+	}
+	r.logger.Infof("restoring conversation %d captured at %s from conversation %d", r.assistantConversation.Id, state.CapturedAt.Format(time.RFC3339), state.ConversationID)
+
+	r.histories = r.histories[:0]
+	for _, msg := range state.Histories {
+		switch msg.Role {
+		case "user":
+			r.histories = append(r.histories, internal_type.UserTextPacket{Text: msg.Content})
+		case "assistant", "rapida":
+			r.histories = append(r.histories, internal_type.LLMResponseDonePacket{Text: msg.Content})
+		}
+	}
+	for key, value := range state.Scratchpad {
+		if err := r.Scratchpad().Set(ctx, key, value); err != nil {
+			r.logger.Warnf("unable to restore scratchpad key %q: %v", key, err)
+		}
+	}
+	if state.Flow.SpeakingProfile != "" {
+		r.setSpeakingProfile(ctx, internal_type.SpeakingProfilePacket{Profile: state.Flow.SpeakingProfile})
+	}
+	if state.Flow.Spelling != "" {
+		r.setSpellingMode(internal_type.SpellingModePacket{Enabled: true, Kind: state.Flow.Spelling})
+	}
+	r.expectSpeechEntity(internal_type.SpeechHintPacket{Entity: state.Flow.SpeechEntity})
+	for _, call := range state.PendingTools {
+		r.logger.Infof("restored conversation had tool call %s (%s) pending with %s", call.ID, call.Name, call.Arguments)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	internal_adapter_request_customizers "github.com/rapidaai/api/assistant-api/internal/adapters/customizers"
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_supervision "github.com/rapidaai/api/assistant-api/internal/supervision"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxWhispers is how much guidance the assistant keeps, the oldest is
+// forgotten first.
+const maxWhispers = 20
+
+// initializeSupervision opens the conversation to supervisors.
+func (r *genericRequestor) initializeSupervision() {
+	r.supervision.Store(internal_supervision.NewTap(func(barging bool) {
+		if err := r.OnPacket(r.streamer.Context(), internal_type.SupervisorBargePacket{ContextID: r.messaging.GetID(), Barging: barging}); err != nil {
+			r.logger.Errorf("error while changing the supervisor barge: %v", err)
+		}
+	}))
+}
+
+// closeSupervision detaches the supervisors of the ended conversation.
+func (r *genericRequestor) closeSupervision() {
+	if tap := r.supervision.Swap(nil); tap != nil {
+		tap.Close()
+	}
+}
+
+// Supervision implements internal_supervision.Session.
+func (r *genericRequestor) Supervision() *internal_supervision.Tap {
+	return r.supervision.Load()
+}
+
+// Whisper implements internal_supervision.Session. The guidance is given to
+// the assistant with every answer it writes from now on.
+func (r *genericRequestor) Whisper(ctx context.Context, guidance string) error {
+	guidance = strings.TrimSpace(guidance)
+	if guidance == "" {
+		return errors.New("guidance is empty")
+	}
+	r.addWhisper(guidance)
+	r.logger.Infof("supervisor whispered to the assistant of conversation %d", r.assistantConversation.Id)
+	return nil
+}
+
+// Say implements internal_supervision.Session.
+func (r *genericRequestor) Say(ctx context.Context, text string) error {
+	return r.OnPacket(r.streamer.Context(), internal_type.SupervisorSpeechPacket{ContextID: r.messaging.GetID(), Text: strings.TrimSpace(text)})
+}
+
+// Play implements internal_supervision.Session.
+func (r *genericRequestor) Play(ctx context.Context, audio []byte) error {
+	return r.OnPacket(r.streamer.Context(), internal_type.SupervisorAudioPacket{ContextID: r.messaging.GetID(), Audio: audio})
+}
+
+// Whispers implements internal_type.Communication.
+func (r *genericRequestor) Whispers() []string {
+	r.whispersMu.Lock()
+	defer r.whispersMu.Unlock()
+	return append([]string(nil), r.whispers...)
+}
+
+func (r *genericRequestor) addWhisper(guidance string) {
+	r.whispersMu.Lock()
+	defer r.whispersMu.Unlock()
+	r.whispers = append(r.whispers, guidance)
+	if over := len(r.whispers) - maxWhispers; over > 0 {
+		r.whispers = r.whispers[over:]
+	}
+}
+
+// supervisorHears hands audio of the caller or the assistant to the
+// supervisors.
+func (talking *genericRequestor) supervisorHears(source internal_supervision.Source, audio []byte) {
+	tap := talking.supervision.Load()
+	if tap == nil {
+		return
+	}
+	if source == internal_supervision.SourceCaller {
+		tap.Caller(audio)
+		return
+	}
+	tap.Assistant(audio)
+}
+
+// barged reports whether a supervisor has the conversation, the assistant
+// does not answer the caller then.
+func (talking *genericRequestor) barged() bool {
+	return talking.bargedIn.Load()
+}
+
+// setSupervisorBarge mutes the assistant while a supervisor barges in, what
+// it was saying is cut off. Handing the conversation back gives the
+// assistant what was said meanwhile, its own context missed those turns.
+func (talking *genericRequestor) setSupervisorBarge(ctx context.Context, vl internal_type.SupervisorBargePacket) {
+	if talking.bargedIn.Swap(vl.Barging) == vl.Barging {
+		return
+	}
+	if vl.Barging {
+		talking.logger.Infof("supervisor barged in")
+		talking.OnPacket(ctx, internal_type.InterruptionPacket{ContextID: vl.ContextID, Source: internal_type.InterruptionSourceWord, Explicit: true})
+		talking.stopIdleTimeoutTimer()
+	} else {
+		talking.logger.Infof("supervisor handed the conversation back to the assistant")
+		if len(talking.bargeLog) > 0 {
+			talking.addWhisper("A supervisor had taken the conversation over, meanwhile:\n" + strings.Join(talking.bargeLog, "\n"))
+			talking.bargeLog = nil
+		}
+		talking.startIdleTimeoutTimer(ctx)
+	}
+	if err := talking.Notify(ctx, &protos.ConversationMetadata{
+		AssistantConversationId: talking.Conversation().Id,
+		Metadata:                []*protos.Metadata{{Key: internal_type.MetadataKeySupervisorBarge, Value: strconv.FormatBool(vl.Barging)}},
+	}); err != nil {
+		talking.logger.Tracef(ctx, "error while notifying the supervisor barge: %v", err)
+	}
+}
+
+// supervisorAnswers keeps a turn of the caller the barging supervisor
+// answers for the assistant.
+func (talking *genericRequestor) supervisorAnswers(ctx context.Context, text string) {
+	talking.bargeLog = append(talking.bargeLog, "user: "+text)
+	talking.startIdleTimeoutTimer(ctx)
+}
+
+// supervisorSpeaks speaks what the barging supervisor typed in the
+// assistant's voice, it is kept as a message of the supervisor.
+func (talking *genericRequestor) supervisorSpeaks(ctx context.Context, vl internal_type.SupervisorSpeechPacket) {
+	if !talking.barged() || vl.Text == "" {
+		return
+	}
+	vl.ContextID = talking.messaging.GetID()
+	talking.bargeLog = append(talking.bargeLog, "supervisor: "+vl.Text)
+	if err := talking.callCreateMessage(ctx, vl); err != nil {
+		talking.logger.Errorf("unable to create message of the supervisor %v", err)
+	}
+	if err := talking.messaging.Transition(internal_adapter_request_customizers.LLMGenerating); err != nil {
+		talking.logger.Errorf("messaging transition error: %v", err)
+	}
+	if err := talking.callTextAggregator(ctx, internal_type.LLMResponseDeltaPacket{ContextID: vl.ContextID, Text: vl.Text}); err != nil {
+		if err := talking.callSpeaking(ctx, internal_type.LLMResponseDeltaPacket{ContextID: vl.ContextID, Text: vl.Text}); err != nil {
+			talking.logger.Errorf("speaking error: %v", err)
+		}
+	}
+	if err := talking.messaging.Transition(internal_adapter_request_customizers.LLMGenerated); err != nil {
+		talking.logger.Errorf("messaging transition error: %v", err)
+	}
+	if err := talking.callTextAggregator(ctx, internal_type.LLMResponseDonePacket{ContextID: vl.ContextID}); err != nil {
+		if err := talking.callSpeaking(ctx, internal_type.LLMResponseDonePacket{ContextID: vl.ContextID, Text: vl.Text}); err != nil {
+			talking.logger.Errorf("speaking error: %v", err)
+		}
+	}
+}
+
+// supervisorPlays plays the voice of the barging supervisor to the caller,
+// it is recorded like the assistant's.
+func (talking *genericRequestor) supervisorPlays(ctx context.Context, vl internal_type.SupervisorAudioPacket) {
+	if !talking.barged() || !talking.messaging.GetMode().Audio() {
+		return
+	}
+	talking.extendIdleTimeoutTimer(time.Duration(internal_audio.GetAudioInfo(vl.Audio, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG).DurationMs) * time.Millisecond)
+	if err := talking.Notify(ctx, &protos.ConversationAssistantMessage{Time: timestamppb.Now(), Id: talking.messaging.GetID(), Message: &protos.ConversationAssistantMessage_Audio{Audio: vl.Audio}, Completed: false}); err != nil {
+		talking.logger.Tracef(ctx, "error while playing the supervisor to the user: %v", err)
+	}
+	if err := talking.callRecording(ctx, internal_type.TextToSpeechAudioPacket{ContextID: talking.messaging.GetID(), AudioChunk: vl.Audio}); err != nil {
+		talking.logger.Errorf("recorder error: %v", err)
+	}
+}
@@ -8,8 +8,11 @@ package adapter_internal
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	internal_cdr "github.com/rapidaai/api/assistant-api/internal/cdr"
+	internal_startup "github.com/rapidaai/api/assistant-api/internal/startup"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/types"
 	type_enums "github.com/rapidaai/pkg/types/enums"
@@ -61,6 +64,7 @@ func (t *genericRequestor) Talk(_ context.Context, auth types.SimplePrinciple) e
 		switch payload := req.(type) {
 		case *protos.ConversationInitialization:
 			t.logger.Infof("talk: received initialization, initialized=%v", initialized)
+			t.startup.Observe(internal_startup.Transport, t.startup.Elapsed())
 			if err := t.Connect(t.streamer.Context(), auth, payload); err != nil {
 				t.logger.Errorf("unexpected error while connect assistant, might be problem in configuration %+v", err)
 				return fmt.Errorf("talking.Connect error: %w", err)
@@ -105,7 +109,11 @@ func (t *genericRequestor) Talk(_ context.Context, auth types.SimplePrinciple) e
 			if initialized {
 				switch msg := payload.GetMessage().(type) {
 				case *protos.ConversationUserMessage_Audio:
-					if err := t.OnPacket(t.streamer.Context(), internal_type.UserAudioPacket{Audio: msg.Audio}); err != nil {
+					capturedAt := time.Now()
+					if payload.GetTime() != nil {
+						capturedAt = payload.GetTime().AsTime()
+					}
+					if err := t.OnPacket(t.streamer.Context(), internal_type.UserAudioPacket{Audio: msg.Audio, CapturedAt: capturedAt}); err != nil {
 						t.logger.Errorf("error processing user audio: %v", err)
 					}
 				case *protos.ConversationUserMessage_Text:
@@ -119,15 +127,74 @@ func (t *genericRequestor) Talk(_ context.Context, auth types.SimplePrinciple) e
 
 		case *protos.ConversationMetadata:
 			if initialized {
+				metadata := make([]*protos.Metadata, 0, len(payload.GetMetadata()))
+				for _, mtd := range payload.GetMetadata() {
+					// custom entries are validated and stored by the
+					// conversation's custom metadata, see internal/cdr
+					if key, ok := strings.CutPrefix(mtd.GetKey(), internal_cdr.Namespace); ok {
+						value := mtd.GetValue()
+						utils.Go(t.streamer.Context(), func() {
+							if err := t.CustomMetadata().Set(t.streamer.Context(), key, value); err != nil {
+								t.logger.Warnf("rejected custom metadata %s: %v", key, err)
+							}
+						})
+						continue
+					}
+					// key presses are input, not something to keep on the
+					// conversation
+					if mtd.GetKey() == internal_type.MetadataKeyDTMF {
+						if err := t.OnPacket(t.streamer.Context(), internal_type.UserDTMFPacket{Digit: mtd.GetValue()}); err != nil {
+							t.logger.Errorf("error processing user dtmf: %v", err)
+						}
+						continue
+					}
+					// neither is the user typing in a text chat
+					if mtd.GetKey() == internal_type.MetadataKeyUserTyping {
+						if err := t.OnPacket(t.streamer.Context(), internal_type.UserTypingPacket{ContextID: t.messaging.GetID(), Typing: mtd.GetValue() == "true"}); err != nil {
+							t.logger.Errorf("error processing user typing: %v", err)
+						}
+						continue
+					}
+					metadata = append(metadata, mtd)
+					switch mtd.GetKey() {
+					case internal_type.MetadataKeySpeechEntity:
+						if err := t.OnPacket(t.streamer.Context(), internal_type.SpeechHintPacket{Entity: mtd.GetValue()}); err != nil {
+							t.logger.Errorf("error processing speech hint: %v", err)
+						}
+					case internal_type.MetadataKeyTransferFailed:
+						if err := t.OnPacket(t.streamer.Context(), internal_type.EndOfSpeechPacket{ContextID: t.messaging.GetID(), Speech: "[TRANSFER FAILED] " + mtd.GetValue()}); err != nil {
+							t.logger.Errorf("error processing failed transfer: %v", err)
+						}
+					case internal_type.MetadataKeyCallHold:
+						if err := t.OnPacket(t.streamer.Context(), internal_type.CallHoldPacket{ContextID: t.messaging.GetID(), Held: mtd.GetValue() == "true"}); err != nil {
+							t.logger.Errorf("error processing call hold: %v", err)
+						}
+					case internal_type.MetadataKeyChannelUUID:
+						channelUUID := mtd.GetValue()
+						utils.Go(t.streamer.Context(), func() { t.saveChannelUUID(channelUUID) })
+					}
+				}
 				if err := t.OnPacket(t.streamer.Context(),
 					internal_type.ConversationMetadataPacket{
 						ContextID: payload.GetAssistantConversationId(),
-						Metadata:  payload.GetMetadata(),
+						Metadata:  metadata,
 					}); err != nil {
 					t.logger.Errorf("error while accepting metadata: %v", err)
 				}
 			}
 
+		case *protos.ConversationDirective:
+			// channels tell the talk loop about transfers they carried out
+			// themselves, e.g. an agent picking the call up
+			if initialized && payload.GetType() == protos.ConversationDirective_TRANSFER_CONVERSATION {
+				args, _ := utils.AnyMapToInterfaceMap(payload.GetArgs())
+				agent, _ := args["to"].(string)
+				code, _ := args["pickup"].(string)
+				if err := t.OnPacket(t.streamer.Context(), internal_type.CallPickupPacket{ContextID: t.messaging.GetID(), Agent: agent, Code: code}); err != nil {
+					t.logger.Errorf("error processing call pickup: %v", err)
+				}
+			}
+
 		case *protos.ConversationMetric:
 			if initialized {
 				if err := t.OnPacket(t.streamer.Context(),
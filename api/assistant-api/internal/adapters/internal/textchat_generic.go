@@ -0,0 +1,53 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"strconv"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+)
+
+// chatting reports whether the conversation is a text chat. A chat has no
+// speech on either side: typed input is a complete turn without end of
+// speech analysis and the tokens of the answer go out as they come instead
+// of being assembled into sentences for speech synthesis.
+func (talking *genericRequestor) chatting() bool {
+	return talking.messaging.GetMode().Text()
+}
+
+// setAssistantTyping tells the client of a text chat that the assistant
+// started or stopped writing its answer, only when that changes.
+func (talking *genericRequestor) setAssistantTyping(ctx context.Context, typing bool) {
+	if !talking.chatting() || talking.assistantTyping.Swap(typing) == typing {
+		return
+	}
+	var conversationID uint64
+	if conversation := talking.Conversation(); conversation != nil {
+		conversationID = conversation.Id
+	}
+	if err := talking.Notify(ctx, &protos.ConversationMetadata{
+		AssistantConversationId: conversationID,
+		Metadata:                []*protos.Metadata{{Key: internal_type.MetadataKeyAssistantTyping, Value: strconv.FormatBool(typing)}},
+	}); err != nil {
+		talking.logger.Tracef(ctx, "error while notifying assistant typing: %v", err)
+	}
+}
+
+// setUserTyping holds the idle timeout while the user of a text chat types,
+// a long message is not silence. Stopping without sending starts it again.
+func (talking *genericRequestor) setUserTyping(ctx context.Context, vl internal_type.UserTypingPacket) {
+	if !talking.chatting() {
+		return
+	}
+	if vl.Typing {
+		talking.stopIdleTimeoutTimer()
+		return
+	}
+	talking.startIdleTimeoutTimer(ctx)
+}
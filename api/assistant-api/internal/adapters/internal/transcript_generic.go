@@ -0,0 +1,116 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	internal_livetranscript "github.com/rapidaai/api/assistant-api/internal/livetranscript"
+	internal_transcript "github.com/rapidaai/api/assistant-api/internal/transcript"
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// transcriptFlushTimeout bounds sending what is left of the transcript once
+// the call ended.
+const transcriptFlushTimeout = 10 * time.Second
+
+// initializeTranscriptStream starts streaming the transcript to the webhooks
+// of the assistant that subscribed to transcript.interim or transcript.final.
+// Batches a webhook still rejects after its retries show up in its logs.
+func (r *genericRequestor) initializeTranscriptStream(ctx context.Context) {
+	if r.assistant == nil || r.assistantConversation == nil {
+		return
+	}
+	if feed := internal_livetranscript.Active(); feed != nil && r.liveTranscript == nil {
+		r.liveTranscript = internal_livetranscript.NewWriter(feed, r.logger, r.assistantConversation.Id)
+	}
+	if r.transcripts != nil {
+		return
+	}
+	for _, webhook := range r.assistant.AssistantWebhooks {
+		interim := slices.Contains(webhook.AssistantEvents, utils.TranscriptInterim.Get())
+		final := slices.Contains(webhook.AssistantEvents, utils.TranscriptFinal.Get())
+		if !interim && !final {
+			continue
+		}
+		r.transcripts = append(r.transcripts, internal_transcript.NewPublisher(
+			internal_transcript.Target{
+				URL:              webhook.GetUrl(),
+				Method:           webhook.GetMethod(),
+				Headers:          webhook.GetHeaders(),
+				Secret:           webhook.SigningSecret,
+				Timeout:          time.Duration(webhook.GetTimeoutSecond()) * time.Second,
+				MaxRetries:       webhook.GetMaxRetryCount(),
+				RetryStatusCodes: webhook.GetRetryStatusCode(),
+				Interim:          interim,
+				Final:            final,
+			},
+			fmt.Sprintf("%d", r.assistant.Id),
+			fmt.Sprintf("%d", r.assistantConversation.Id),
+			func(result internal_transcript.Result) {
+				var retries uint32
+				if result.Attempts > 0 {
+					retries = result.Attempts - 1
+				}
+				if err := r.CreateWebhookLog(ctx, webhook.Id, webhook.HttpUrl, webhook.HttpMethod, "transcript",
+					int64(result.Status), int64(result.Took), retries, type_enums.RECORD_FAILED,
+					result.Request, result.Response); err != nil {
+					r.logger.Errorf("unable to log failed transcript batch: %v", err)
+				}
+			},
+		))
+	}
+}
+
+// publishTranscript hands a transcript segment to the webhooks, the live
+// transcript and the supervisors, speaker is who said a message of the user
+// when speakers are told apart. What the platform speaks itself, the greeting
+// for one, is the assistant's to the caller.
+func (r *genericRequestor) publishTranscript(id, role, speaker, text string, final bool) {
+	if role == "rapida" {
+		role = "assistant"
+	}
+	if tap := r.supervision.Load(); tap != nil {
+		tap.Transcript(role, text, final)
+	}
+	if (len(r.transcripts) == 0 && r.liveTranscript == nil) || text == "" {
+		return
+	}
+	segment := internal_transcript.Segment{ID: id, Role: role, Speaker: speaker, Text: text, Final: final, Time: time.Now()}
+	if r.liveTranscript != nil && !r.liveTranscript.Publish(internal_livetranscript.Event{
+		Type: internal_livetranscript.EventTranscript, MessageID: id, Role: role, Speaker: speaker, Text: text, Final: final, Time: segment.Time,
+	}) {
+		r.logger.Warnf("live transcript is falling behind, dropped a segment of %s", id)
+	}
+	for _, publisher := range r.transcripts {
+		if !publisher.Publish(segment) {
+			r.logger.Warnf("transcript webhook is falling behind, dropped a segment of %s", id)
+		}
+	}
+}
+
+// closeTranscriptStream sends the rest of the transcript in the background.
+func (r *genericRequestor) closeTranscriptStream(ctx context.Context) {
+	publishers, live := r.transcripts, r.liveTranscript
+	r.transcripts, r.liveTranscript = nil, nil
+	if len(publishers) == 0 && live == nil {
+		return
+	}
+	utils.Go(ctx, func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), transcriptFlushTimeout)
+		defer cancel()
+		if live != nil {
+			live.Close(flushCtx)
+		}
+		for _, publisher := range publishers {
+			publisher.Close(flushCtx)
+		}
+	})
+}
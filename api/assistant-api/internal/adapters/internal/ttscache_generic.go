@@ -0,0 +1,53 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_transformer "github.com/rapidaai/api/assistant-api/internal/transformer"
+	internal_ttscache "github.com/rapidaai/api/assistant-api/internal/ttscache"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/storages"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// newTextToSpeechCache keeps the phrases assistants speak again when the
+// cache is configured, in the asset store or else in redis.
+func newTextToSpeechCache(cfg *config.AssistantConfig, redis connectors.RedisConnector, storage storages.Storage) internal_ttscache.Cache {
+	if cfg == nil || cfg.TextToSpeechCache == nil {
+		return nil
+	}
+	if cfg.TextToSpeechCache.Store == "asset_store" {
+		if storage == nil {
+			return nil
+		}
+		return internal_ttscache.NewStorageCache(storage, cfg.TextToSpeechCache.TTL())
+	}
+	if redis == nil {
+		return nil
+	}
+	return internal_ttscache.NewRedisCache(redis.GetConnection(), cfg.TextToSpeechCache.TTL())
+}
+
+// newTextToSpeech connects the text to speech provider of the assistant,
+// behind the cache of its phrases when the assistant enables it.
+func newTextToSpeech(ctx context.Context, logger commons.Logger, cache internal_ttscache.Cache, assistantId uint64,
+	provider string, credential *protos.VaultCredential, onPacket func(pkt ...internal_type.Packet) error, opts utils.Option,
+) (internal_type.TextToSpeechTransformer, error) {
+	build := func(onPacket func(pkt ...internal_type.Packet) error) (internal_type.TextToSpeechTransformer, error) {
+		return internal_transformer.GetTextToSpeechTransformer(ctx, logger, provider, credential, onPacket, opts)
+	}
+	if cache == nil || !internal_ttscache.Enabled(opts) {
+		return build(onPacket)
+	}
+	key := func(text string) string { return internal_ttscache.Key(assistantId, provider, opts, text) }
+	return internal_ttscache.NewTextToSpeech(logger, cache, key, onPacket, build)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	internal_callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const (
+	// maxVoicemailDuration hangs up a voicemail drop whose message never
+	// finished, e.g. because speech synthesis failed.
+	maxVoicemailDuration = 2 * time.Minute
+
+	// playbackPoll is how often the drop checks whether the machine still
+	// records the message.
+	playbackPoll = 100 * time.Millisecond
+)
+
+// voicemailDrop is the message being left on an answering machine.
+type voicemailDrop struct {
+	hangUp sync.Once
+}
+
+// answeredBy returns who the channel reports picked up the call, empty when
+// it did not detect it.
+func answeredBy(config *protos.ConversationInitialization) string {
+	value, ok := config.GetMetadata()[internal_type.MetadataKeyAnsweredBy]
+	if !ok {
+		return ""
+	}
+	by, err := utils.AnyToString(value)
+	if err != nil {
+		return ""
+	}
+	return by
+}
+
+// initializeVoicemailDrop speaks the phone deployment's voicemail message
+// instead of the greeting when an answering machine picked up the call. The
+// call hangs up once the message was played. It reports whether it took over
+// the start of the call.
+func (r *genericRequestor) initializeVoicemailDrop(ctx context.Context) bool {
+	if r.answeredBy != internal_type.AnsweredByMachine || r.source != utils.PhoneCall ||
+		r.assistant == nil || r.assistant.AssistantPhoneDeployment == nil {
+		return false
+	}
+	message, err := r.assistant.AssistantPhoneDeployment.GetOptions().GetString(internal_type.VoicemailMessageOption)
+	if err != nil {
+		return false
+	}
+	message = strings.TrimSpace(r.templateParser.Parse(message, r.GetArgs()))
+	if message == "" {
+		return false
+	}
+
+	drop := &voicemailDrop{}
+	r.voicemail.Store(drop)
+	r.logger.Infof("answering machine picked up, leaving voicemail")
+	r.voicemailTimer = time.AfterFunc(maxVoicemailDuration, func() {
+		r.logger.Warnf("voicemail was not played within %s, hanging up", maxVoicemailDuration)
+		r.endVoicemail(ctx, drop, false)
+	})
+	if err := r.OnPacket(ctx, internal_type.StaticPacket{ContextID: r.messaging.GetID(), Text: message}); err != nil {
+		r.logger.Errorf("error while sending voicemail message: %v", err)
+	}
+	return true
+}
+
+// leavingVoicemail reports whether the call is an answering machine the
+// voicemail is being left on. Nothing it plays is taken for the user.
+func (r *genericRequestor) leavingVoicemail() bool {
+	return r.voicemail.Load() != nil
+}
+
+// voicemailSpoken hangs up once the machine recorded the synthesized
+// message, the channel still plays it for a while after synthesis ended.
+func (r *genericRequestor) voicemailSpoken(ctx context.Context) {
+	drop := r.voicemail.Load()
+	if drop == nil {
+		return
+	}
+	utils.Go(ctx, func() {
+		for r.assistantAudible() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(playbackPoll):
+			}
+		}
+		r.endVoicemail(ctx, drop, true)
+	})
+}
+
+// endVoicemail records the outcome on the call context and ends the call.
+func (r *genericRequestor) endVoicemail(ctx context.Context, drop *voicemailDrop, played bool) {
+	drop.hangUp.Do(func() {
+		if r.voicemailTimer != nil {
+			r.voicemailTimer.Stop()
+		}
+		reason := "voicemail not played"
+		if played {
+			reason = "voicemail left"
+			r.saveCallOutcome(internal_callcontext.OutcomeVoicemailDropped)
+		}
+		r.OnPacket(ctx, internal_type.DirectivePacket{
+			ContextID: r.messaging.GetID(),
+			Directive: protos.ConversationDirective_END_CONVERSATION,
+			Arguments: map[string]interface{}{
+				"reason": reason,
+			},
+		})
+	})
+}
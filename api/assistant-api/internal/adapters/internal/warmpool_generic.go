@@ -0,0 +1,144 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package adapter_internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_transformer "github.com/rapidaai/api/assistant-api/internal/transformer"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	internal_warmpool "github.com/rapidaai/api/assistant-api/internal/warmpool"
+	web_client "github.com/rapidaai/pkg/clients/web"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// NewStandbyBuilder builds the standbys of the warm pool: the latest version
+// of the assistant is loaded with its phone deployment, and the speech to text
+// and text to speech providers of that deployment are connected.
+func NewStandbyBuilder(cfg *config.AssistantConfig, logger commons.Logger, postgres connectors.PostgresConnector, opensearch connectors.OpenSearchConnector, redis connectors.RedisConnector) internal_warmpool.Build {
+	assistantService := internal_assistant_service.NewAssistantService(cfg, logger, postgres, opensearch)
+	vaultClient := web_client.NewVaultClientGRPC(&cfg.AppConfig, logger, redis)
+	textToSpeechCache := newTextToSpeechCache(cfg, redis, storage_files.NewStorage(cfg.AssetStoreConfig, logger))
+
+	credential := func(ctx context.Context, auth types.SimplePrinciple, options utils.Option) (*protos.VaultCredential, error) {
+		credentialId, err := options.GetUint64("rapida.credential_id")
+		if err != nil {
+			return nil, fmt.Errorf("unable to find credential from options: %w", err)
+		}
+		return vaultClient.GetCredential(ctx, auth, credentialId)
+	}
+
+	return func(ctx context.Context, auth types.SimplePrinciple, assistantId uint64, standby *internal_warmpool.Standby) error {
+		assistant, err := assistantService.Get(ctx, auth, assistantId, nil, assistantOptionForSource(utils.PhoneCall))
+		if err != nil {
+			return err
+		}
+		standby.Assistant = assistant
+
+		if input, _ := inputAudioDeployment(assistant, utils.PhoneCall); input != nil {
+			options := speechToTextOptions(input)
+			cred, err := credential(ctx, auth, options)
+			if err != nil {
+				return err
+			}
+			transformer, err := internal_transformer.GetSpeechToTextTransformer(ctx, logger, input.AudioProvider, cred, standby.Deliver, options)
+			if err != nil {
+				return err
+			}
+			standby.SpeechToText = transformer
+			if err := transformer.Initialize(); err != nil {
+				return err
+			}
+		}
+
+		if output, _ := outputAudioDeployment(assistant, utils.PhoneCall); output != nil {
+			options := utils.MergeMaps(output.GetOptions())
+			cred, err := credential(ctx, auth, options)
+			if err != nil {
+				return err
+			}
+			transformer, err := newTextToSpeech(ctx, logger, textToSpeechCache, assistant.Id, output.GetName(), cred, standby.Deliver, options)
+			if err != nil {
+				return err
+			}
+			standby.TextToSpeech = transformer
+			if err := transformer.Initialize(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// claimStandby takes a pipeline of the assistant that was built ahead of the
+// call, and returns its assistant. Standbys are built for audio phone calls of
+// the latest version only, other sessions load the assistant themselves.
+func (r *genericRequestor) claimStandby(ctx context.Context, auth types.SimplePrinciple, cfg *protos.ConversationInitialization) *internal_assistant_entity.Assistant {
+	pool := internal_warmpool.Active()
+	assistantId := cfg.GetAssistant().GetAssistantId()
+	if r.source != utils.PhoneCall ||
+		cfg.GetStreamMode() != protos.StreamMode_STREAM_MODE_AUDIO ||
+		utils.GetVersionDefinition(cfg.GetAssistant().GetVersion()) != nil ||
+		!pool.Pools(assistantId) {
+		return nil
+	}
+	r.pooled = true
+	standby := pool.Claim(ctx, auth, assistantId)
+	if standby == nil {
+		return nil
+	}
+	standby.Bind(func(pkt ...internal_type.Packet) error { return r.OnPacket(ctx, pkt...) })
+	r.standby = standby
+	return standby.Assistant
+}
+
+// standbySpeechToText returns the open speech to text connection of the
+// claimed standby, as long as the session still runs the assistant it was
+// built for.
+func (r *genericRequestor) standbySpeechToText() internal_type.SpeechToTextTransformer {
+	if r.standby == nil || r.standby.Assistant != r.assistant {
+		return nil
+	}
+	return r.standby.TakeSpeechToText()
+}
+
+// standbyTextToSpeech is standbySpeechToText for the speaking side.
+func (r *genericRequestor) standbyTextToSpeech() internal_type.TextToSpeechTransformer {
+	if r.standby == nil || r.standby.Assistant != r.assistant {
+		return nil
+	}
+	return r.standby.TakeTextToSpeech()
+}
+
+// recordWarmStart stores with the conversation whether a pooled assistant
+// answered from a standby, to compare the answer times of both.
+func (r *genericRequestor) recordWarmStart(ctx context.Context) {
+	if !r.pooled {
+		return
+	}
+	r.onAddMetrics(ctx, &protos.Metric{
+		Name:        "warm_start",
+		Value:       strconv.FormatBool(r.standby != nil),
+		Description: "Whether the call was answered by a pipeline built ahead of it",
+	})
+}
+
+// releaseStandby closes what the session did not take of its standby.
+func (r *genericRequestor) releaseStandby(ctx context.Context) {
+	if r.standby != nil {
+		r.standby.Close(ctx)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	adapter_internal "github.com/rapidaai/api/assistant-api/internal/adapters/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	internal_warmpool "github.com/rapidaai/api/assistant-api/internal/warmpool"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
 	"github.com/rapidaai/pkg/storages"
@@ -22,3 +23,13 @@ func GetTalker(source utils.RapidaSource, ctx context.Context, cfg *config.Assis
 ) (internal_type.Talking, error) {
 	return adapter_internal.NewGenericRequestor(ctx, cfg, logger, source, postgres, opensearch, redis, storage, streamer), nil
 }
+
+func GetListener(source utils.RapidaSource, cfg *config.AssistantConfig, logger commons.Logger, postgres connectors.PostgresConnector, opensearch connectors.OpenSearchConnector, redis connectors.RedisConnector, streamer internal_type.Streamer,
+) (internal_type.Listening, error) {
+	return adapter_internal.NewGenericListener(cfg, logger, source, postgres, opensearch, redis, streamer), nil
+}
+
+func GetStandbyBuilder(cfg *config.AssistantConfig, logger commons.Logger, postgres connectors.PostgresConnector, opensearch connectors.OpenSearchConnector, redis connectors.RedisConnector,
+) internal_warmpool.Build {
+	return adapter_internal.NewStandbyBuilder(cfg, logger, postgres, opensearch, redis)
+}
@@ -52,6 +52,13 @@ type AssistantExecutor interface {
 	Close(ctx context.Context) error
 }
 
+// ContextInspector is implemented by executors that keep the model context of
+// the conversation in process, remote agents keep their own.
+type ContextInspector interface {
+	// ModelContext returns a copy of the messages the model is prompted with.
+	ModelContext() []*protos.Message
+}
+
 /**
  * ToolExecutor is an interface that defines methods for executing tools and retrieving function definitions.
  *
@@ -184,9 +184,22 @@ func (e *agentkitExecutor) listen(ctx context.Context, onPacket func(ctx context
 			return nil
 		default:
 		}
-		resp, err := e.talker.Recv()
+		e.mu.RLock()
+		talker := e.talker
+		e.mu.RUnlock()
+		if talker == nil {
+			return nil
+		}
+		resp, err := talker.Recv()
 		if err != nil {
 			e.logger.Debugf("Listener received error: %v", err)
+			// closed by Close, the conversation goes on without this agent
+			e.mu.RLock()
+			closed := e.talker != talker
+			e.mu.RUnlock()
+			if closed {
+				return nil
+			}
 			code := status.Code(err)
 			switch {
 			case errors.Is(err, io.EOF):
@@ -216,7 +229,7 @@ func (e *agentkitExecutor) handleResponse(ctx context.Context, resp *protos.Talk
 		e.logger.Debugf("AgentKit initialization acknowledged, conversationId=%d", data.Initialization.GetAssistantConversationId())
 
 	case *protos.TalkOutput_Interruption:
-		onPacket(ctx, internal_type.InterruptionPacket{ContextID: data.Interruption.Id, Source: internal_type.InterruptionSourceWord})
+		onPacket(ctx, internal_type.InterruptionPacket{ContextID: data.Interruption.Id, Source: internal_type.InterruptionSourceWord, Explicit: true})
 
 	case *protos.TalkOutput_Assistant:
 		switch msg := data.Assistant.GetMessage().(type) {
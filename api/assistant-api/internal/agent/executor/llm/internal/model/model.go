@@ -10,12 +10,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	internal_agent_executor "github.com/rapidaai/api/assistant-api/internal/agent/executor"
 	internal_agent_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool"
+	internal_language "github.com/rapidaai/api/assistant-api/internal/language"
+	internal_seed "github.com/rapidaai/api/assistant-api/internal/seed"
 	internal_adapter_telemetry "github.com/rapidaai/api/assistant-api/internal/telemetry"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	integration_client_builders "github.com/rapidaai/pkg/clients/integration/builders"
@@ -59,7 +62,7 @@ func (executor *modelAssistantExecutor) Initialize(ctx context.Context, communic
 
 	g, gCtx := errgroup.WithContext(ctx)
 	var providerCredential *protos.VaultCredential
-	var conversationLogs []*protos.Message
+	conversationLogs := executor.conversationLogs(communication.GetHistories())
 
 	// Goroutine to fetch provider credentials
 	g.Go(func() error {
@@ -173,6 +176,14 @@ func (executor *modelAssistantExecutor) listen(ctx context.Context, communicatio
 		resp, err := stream.Recv()
 		if err != nil {
 			executor.logger.Debugf("Listener received error: %v", err)
+			// closed by Close, e.g. when the conversation was handed to
+			// another assistant
+			executor.mu.RLock()
+			closed := executor.stream != stream
+			executor.mu.RUnlock()
+			if closed {
+				return nil
+			}
 			code := status.Code(err)
 			switch {
 			case errors.Is(err, io.EOF):
@@ -206,6 +217,25 @@ func (executor *modelAssistantExecutor) listen(ctx context.Context, communicatio
 	}
 }
 
+// conversationLogs turns the messages already exchanged in the conversation
+// into chat history, an executor taking over a conversation continues it.
+func (executor *modelAssistantExecutor) conversationLogs(histories []internal_type.MessagePacket) []*protos.Message {
+	logs := make([]*protos.Message, 0, len(histories))
+	for _, msg := range histories {
+		content := msg.Content()
+		if content == "" {
+			continue
+		}
+		switch msg.Role() {
+		case "user":
+			logs = append(logs, &protos.Message{Role: "user", Message: &protos.Message_User{User: &protos.UserMessage{Content: content}}})
+		case "assistant", "rapida":
+			logs = append(logs, &protos.Message{Role: "assistant", Message: &protos.Message_Assistant{Assistant: &protos.AssistantMessage{Contents: []string{content}}}})
+		}
+	}
+	return logs
+}
+
 // handleResponse processes a single response from the server.
 func (executor *modelAssistantExecutor) handleResponse(ctx context.Context, communication internal_type.Communication, resp *protos.ChatResponse) {
 	output := resp.GetData()
@@ -248,17 +278,50 @@ func (executor *modelAssistantExecutor) handleResponse(ctx context.Context, comm
 func (executor *modelAssistantExecutor) buildChatRequest(communication internal_type.Communication, contextID string, in *protos.Message, histories ...*protos.Message) *protos.ChatRequest {
 	assistant := communication.Assistant()
 	template := assistant.AssistantProviderModel.Template.GetTextChatCompleteTemplate()
+	scratchpad := communication.Scratchpad()
 	messages := executor.inputBuilder.Message(
 		template.Prompt,
-		utils.MergeMaps(executor.inputBuilder.PromptArguments(template.Variables), communication.GetArgs()),
+		utils.MergeMaps(executor.inputBuilder.PromptArguments(template.Variables), map[string]interface{}{"scratchpad": scratchpad.All()}, communication.GetArgs()),
 	)
+	if state := scratchpad.String(); state != "" {
+		messages = append(messages, &protos.Message{
+			Role: "system",
+			Message: &protos.Message_System{
+				System: &protos.SystemMessage{Content: "Scratchpad, the state shared between tool calls of this conversation:\n" + state},
+			},
+		})
+	}
+	if profile := communication.SpeakingProfile(); profile.Instructions() != "" {
+		messages = append(messages, &protos.Message{
+			Role: "system",
+			Message: &protos.Message_System{
+				System: &protos.SystemMessage{Content: fmt.Sprintf("The caller is served in the %q speaking profile:\n%s", profile.Name, profile.Instructions())},
+			},
+		})
+	}
+	if language := communication.Language(); language != "" {
+		messages = append(messages, &protos.Message{
+			Role: "system",
+			Message: &protos.Message_System{
+				System: &protos.SystemMessage{Content: fmt.Sprintf("The caller speaks %s (%s), answer in %s from now on.", internal_language.Name(language), language, internal_language.Name(language))},
+			},
+		})
+	}
+	if whispers := communication.Whispers(); len(whispers) > 0 {
+		messages = append(messages, &protos.Message{
+			Role: "system",
+			Message: &protos.Message_System{
+				System: &protos.SystemMessage{Content: "A supervisor following the conversation gave this guidance, follow it without mentioning it to the caller:\n- " + strings.Join(whispers, "\n- ")},
+			},
+		})
+	}
 	return executor.inputBuilder.Chat(
 		contextID,
 		&protos.Credential{
 			Id:    executor.providerCredential.GetId(),
 			Value: executor.providerCredential.GetValue(),
 		},
-		executor.inputBuilder.Options(utils.MergeMaps(assistant.AssistantProviderModel.GetOptions(), communication.GetOptions()), nil),
+		executor.inputBuilder.Options(executor.modelOptions(communication), nil),
 		executor.toolExecutor.GetFunctionDefinitions(),
 		map[string]string{
 			"assistant_id":                fmt.Sprintf("%d", assistant.Id),
@@ -269,6 +332,16 @@ func (executor *modelAssistantExecutor) buildChatRequest(communication internal_
 	)
 }
 
+// modelOptions merges the model options with the conversation options, seeded
+// conversations pass their seed on to providers that support seeded sampling.
+func (executor *modelAssistantExecutor) modelOptions(communication internal_type.Communication) map[string]interface{} {
+	options := utils.MergeMaps(communication.Assistant().AssistantProviderModel.GetOptions(), communication.GetOptions())
+	if seed, ok := internal_seed.FromOptions(communication.GetOptions()); ok {
+		return internal_seed.ModelOptions(options, seed)
+	}
+	return options
+}
+
 // executeToolCalls handles tool execution and recursive chat
 func (executor *modelAssistantExecutor) executeToolCalls(ctx context.Context, communication internal_type.Communication, contextID string, output *protos.Message, histories []*protos.Message,
 ) error {
@@ -325,6 +398,13 @@ func (executor *modelAssistantExecutor) handleStaticPacket(packet internal_type.
 	return nil
 }
 
+// ModelContext returns a copy of the chat history.
+func (executor *modelAssistantExecutor) ModelContext() []*protos.Message {
+	executor.mu.RLock()
+	defer executor.mu.RUnlock()
+	return slices.Clone(executor.history)
+}
+
 func (executor *modelAssistantExecutor) Close(ctx context.Context) error {
 	executor.mu.Lock()
 	defer executor.mu.Unlock()
@@ -138,14 +138,28 @@ func (e *websocketExecutor) listen(ctx context.Context, onPacket func(ctx contex
 		default:
 		}
 
+		e.writeMu.Lock()
+		conn := e.conn
+		e.writeMu.Unlock()
+		if conn == nil {
+			// closed by Close, the conversation goes on without this agent
+			return nil
+		}
+
 		// Allow periodic context checks
-		e.conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
 
-		_, data, err := e.conn.ReadMessage()
+		_, data, err := conn.ReadMessage()
 		if err != nil {
 			if netErr, ok := err.(interface{ Timeout() bool }); ok && netErr.Timeout() {
 				continue
 			}
+			e.writeMu.Lock()
+			closed := e.conn != conn
+			e.writeMu.Unlock()
+			if closed {
+				return nil
+			}
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
 				onPacket(ctx, internal_type.DirectivePacket{Directive: protos.ConversationDirective_END_CONVERSATION, Arguments: map[string]interface{}{"reason": "websocket closed the connection"}})
 				return nil
@@ -199,7 +213,7 @@ func (e *websocketExecutor) handleResponse(ctx context.Context, resp *Response,
 		if d.Source == "vad" {
 			source = internal_type.InterruptionSourceVad
 		}
-		onPacket(ctx, internal_type.InterruptionPacket{ContextID: d.ID, Source: source})
+		onPacket(ctx, internal_type.InterruptionPacket{ContextID: d.ID, Source: source, Explicit: true})
 
 	case TypeClose:
 		var d CloseData
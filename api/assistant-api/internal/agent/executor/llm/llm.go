@@ -64,3 +64,12 @@ func (a *assistantExecutor) Close(ctx context.Context) error {
 	}
 	return a.executor.Close(ctx)
 }
+
+// ModelContext implements internal_agent_executor.ContextInspector, nil when
+// the executor keeps no context in process.
+func (a *assistantExecutor) ModelContext() []*protos.Message {
+	if inspector, ok := a.executor.(internal_agent_executor.ContextInspector); ok {
+		return inspector.ModelContext()
+	}
+	return nil
+}
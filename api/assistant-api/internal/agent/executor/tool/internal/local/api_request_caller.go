@@ -125,6 +125,11 @@ func (md *apiRequestToolCaller) parse(mapping map[string]string, args map[string
 				arguments[value] = ot
 			}
 		}
+		if k, ok := strings.CutPrefix(key, "scratchpad."); ok {
+			if sv, ok := communication.Scratchpad().Get(k); ok {
+				arguments[value] = sv
+			}
+		}
 
 		if k, ok := strings.CutPrefix(key, "custom."); ok {
 			arguments[k] = value
@@ -0,0 +1,41 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// conversationMetadataCaller attaches key/value pairs such as an order ID or
+// a resolution code to the conversation record.
+type conversationMetadataCaller struct {
+	toolCaller
+}
+
+func (metadataTool *conversationMetadataCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	key, _ := args["key"].(string)
+	if err := communication.CustomMetadata().Set(ctx, key, args["value"]); err != nil {
+		metadataTool.logger.Errorf("unable to attach conversation metadata %s: %v", key, err)
+		return internal_tool.Result(err.Error(), false)
+	}
+	return internal_tool.Result(fmt.Sprintf("Attached %s to the conversation.", key), true)
+}
+
+func NewConversationMetadataCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &conversationMetadataCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
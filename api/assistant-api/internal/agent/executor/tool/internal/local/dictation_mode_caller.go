@@ -0,0 +1,41 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_dictation "github.com/rapidaai/api/assistant-api/internal/dictation"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+type dictationModeCaller struct {
+	toolCaller
+}
+
+func (dictationTool *dictationModeCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	var maxDuration time.Duration
+	if seconds, ok := args["max_duration_seconds"].(float64); ok && seconds > 0 {
+		maxDuration = time.Duration(seconds) * time.Second
+	}
+	communication.OnPacket(ctx, internal_type.DictationModePacket{ContextID: contextID, Enabled: true, MaxDuration: maxDuration})
+	return internal_tool.Result(fmt.Sprintf("Dictation mode is on for up to %s. Tell the user to go ahead and to say \"end dictation\" when they are done, then stay quiet until the dictation is handed to you.", internal_dictation.Limit(maxDuration)), true)
+}
+
+func NewDictationModeCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &dictationModeCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
@@ -123,6 +123,11 @@ func (md *endpointToolCaller) Parse(
 				arguments[value] = ot
 			}
 		}
+		if k, ok := strings.CutPrefix(key, "scratchpad."); ok {
+			if sv, ok := communication.Scratchpad().Get(k); ok {
+				arguments[value] = sv
+			}
+		}
 	}
 	return arguments
 }
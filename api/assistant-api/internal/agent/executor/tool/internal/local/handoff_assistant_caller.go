@@ -0,0 +1,57 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_handoff "github.com/rapidaai/api/assistant-api/internal/handoff"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// handoffAssistantCaller hands the conversation to the assistant configured
+// on the tool, e.g. a billing specialist. Each target is a tool of its own,
+// the model picks one by its description.
+type handoffAssistantCaller struct {
+	toolCaller
+	assistantID uint64
+	version     string
+}
+
+func (handoffTool *handoffAssistantCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	reason, _ := args["reason"].(string)
+	request := internal_handoff.Request{AssistantID: handoffTool.assistantID, Version: handoffTool.version, Reason: strings.TrimSpace(reason)}
+	communication.OnPacket(ctx, internal_type.DirectivePacket{
+		Directive: protos.ConversationDirective_HANDOFF_CONVERSATION,
+		Arguments: request.Arguments(),
+		ContextID: contextID,
+	})
+	return internal_tool.Result("The conversation was handed over, do not reply.", true)
+}
+
+func NewHandoffAssistantCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	opts := toolOptions.GetOptions()
+	assistantID, err := opts.GetUint64("tool.assistant_id")
+	if err != nil || assistantID == 0 {
+		return nil, fmt.Errorf("tool.assistant_id is not a valid assistant id: %v", err)
+	}
+	version, _ := opts.GetString("tool.assistant_version")
+	return &handoffAssistantCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+		assistantID: assistantID,
+		version:     strings.TrimSpace(version),
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+type putOnHoldCaller struct {
+	toolCaller
+}
+
+func (holdTool *putOnHoldCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	action, err := holdArgument(args)
+	if err != nil {
+		return internal_tool.Result(err.Error(), false)
+	}
+	// the channel would drop the directive and the caller never be held
+	if channel, ok := communication.(internal_type.HoldChannel); !ok || !channel.HoldsCall() {
+		return internal_tool.Result("Putting the caller on hold is not supported on this call.", false)
+	}
+	communication.OnPacket(ctx, internal_type.DirectivePacket{Directive: protos.ConversationDirective_HOLD_CONVERSATION, Arguments: map[string]interface{}{"action": action}, ContextID: contextID})
+	if action == "resume" {
+		return internal_tool.Result("The call is resumed, the caller hears you and is listened to again.", true)
+	}
+	return internal_tool.Result("The caller is on hold and hears hold music, they are not listened to. Resume the call before asking them anything.", true)
+}
+
+// holdArgument returns the hold action, hold unless resume is asked for.
+func holdArgument(args map[string]interface{}) (string, error) {
+	raw, ok := args["action"].(string)
+	if !ok {
+		return "hold", nil
+	}
+	switch action := strings.ToLower(strings.TrimSpace(raw)); action {
+	case "", "hold":
+		return "hold", nil
+	case "resume":
+		return action, nil
+	}
+	return "", fmt.Errorf("invalid hold action %q, allowed are hold and resume", raw)
+}
+
+func NewPutOnHoldCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &putOnHoldCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
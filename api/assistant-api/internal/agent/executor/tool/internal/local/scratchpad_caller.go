@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// scratchpadCaller reads and writes the conversation scratchpad.
+type scratchpadCaller struct {
+	toolCaller
+}
+
+func (scratchpadTool *scratchpadCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	action, _ := args["action"].(string)
+	key, _ := args["key"].(string)
+	scratchpad := communication.Scratchpad()
+	switch action {
+	case "get":
+		value, ok := scratchpad.Get(key)
+		if !ok {
+			return internal_tool.Result(fmt.Sprintf("Nothing is stored under %s.", key), false)
+		}
+		b, _ := json.Marshal(value)
+		return internal_tool.Result(string(b), true)
+	case "set":
+		if err := scratchpad.Set(ctx, key, args["value"]); err != nil {
+			scratchpadTool.logger.Errorf("unable to save scratchpad key %s: %v", key, err)
+			return internal_tool.Result(err.Error(), false)
+		}
+		return internal_tool.Result(fmt.Sprintf("Saved %s.", key), true)
+	case "delete":
+		if err := scratchpad.Delete(ctx, key); err != nil {
+			scratchpadTool.logger.Errorf("unable to delete scratchpad key %s: %v", key, err)
+			return internal_tool.Result(err.Error(), false)
+		}
+		return internal_tool.Result(fmt.Sprintf("Deleted %s.", key), true)
+	default:
+		return internal_tool.Result(fmt.Sprintf("Unknown scratchpad action %q, use get, set or delete.", action), false)
+	}
+}
+
+func NewScratchpadCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &scratchpadCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
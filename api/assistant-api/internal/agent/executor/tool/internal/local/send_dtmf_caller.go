@@ -0,0 +1,67 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// dtmfDigits are the keys that can be sent as RFC 4733 telephone events.
+const dtmfDigits = "0123456789*#ABCD"
+
+type sendDTMFCaller struct {
+	toolCaller
+}
+
+func (dtmfTool *sendDTMFCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	digits, err := dtmfArgument(args)
+	if err != nil {
+		return internal_tool.Result(err.Error(), false)
+	}
+	// the channel would drop the directive and the caller hear nothing
+	if channel, ok := communication.(internal_type.DTMFChannel); !ok || !channel.SendsDTMF() {
+		return internal_tool.Result("Sending DTMF digits is not supported on this call.", false)
+	}
+	communication.OnPacket(ctx, internal_type.DirectivePacket{Directive: protos.ConversationDirective_SEND_DTMF, Arguments: map[string]interface{}{"digits": digits}, ContextID: contextID})
+	return internal_tool.Result(fmt.Sprintf("Sent DTMF digits %s.", digits), true)
+}
+
+// dtmfArgument returns the normalized digits argument, rejecting anything
+// that can't be dialed.
+func dtmfArgument(args map[string]interface{}) (string, error) {
+	raw, ok := args["digits"].(string)
+	if !ok {
+		return "", fmt.Errorf("missing digits to send")
+	}
+	digits := strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+	if digits == "" {
+		return "", fmt.Errorf("missing digits to send")
+	}
+	for _, d := range digits {
+		if !strings.ContainsRune(dtmfDigits, d) {
+			return "", fmt.Errorf("invalid DTMF digit %q, allowed are %s", d, dtmfDigits)
+		}
+	}
+	return digits, nil
+}
+
+func NewSendDTMFCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &sendDTMFCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+type speakingProfileCaller struct {
+	toolCaller
+}
+
+// Call switches the speaking profile, e.g. to a slower one with more
+// confirmations when the caller struggles to follow. An empty profile goes
+// back to the one the call started in.
+func (profileTool *speakingProfileCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	name, _ := args["profile"].(string)
+	name = strings.TrimSpace(name)
+	if name != "" && communication.SpeakingProfiles().Get(name) == nil {
+		names := make([]string, 0, len(communication.SpeakingProfiles()))
+		for _, profile := range communication.SpeakingProfiles() {
+			names = append(names, profile.Name)
+		}
+		return internal_tool.Result(fmt.Sprintf("There is no speaking profile %q, the profiles are: %s.", name, strings.Join(names, ", ")), false)
+	}
+	communication.OnPacket(ctx, internal_type.SpeakingProfilePacket{ContextID: contextID, Profile: name})
+	if name == "" {
+		return internal_tool.Result("Speaking as at the start of the call again.", true)
+	}
+	return internal_tool.Result(fmt.Sprintf("Speaking profile %q is on, answer accordingly from now on.", name), true)
+}
+
+func NewSpeakingProfileCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &speakingProfileCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
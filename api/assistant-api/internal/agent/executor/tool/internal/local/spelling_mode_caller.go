@@ -0,0 +1,45 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_spelling "github.com/rapidaai/api/assistant-api/internal/spelling"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+type spellingModeCaller struct {
+	toolCaller
+}
+
+func (spellingTool *spellingModeCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	kind, _ := args["kind"].(string)
+	pattern, _ := args["pattern"].(string)
+	capture, err := internal_spelling.NewCapture(kind, pattern)
+	if err != nil {
+		return internal_tool.Result(err.Error(), false)
+	}
+	communication.OnPacket(ctx,
+		internal_type.SpellingModePacket{ContextID: contextID, Enabled: true, Kind: capture.Kind(), Pattern: pattern},
+		internal_type.SpeechHintPacket{ContextID: contextID, Entity: internal_type.SpeechEntitySpelling},
+	)
+	return internal_tool.Result(fmt.Sprintf("Spelling mode is on. Ask the user to spell their %s character by character.", capture.Kind()), true)
+}
+
+func NewSpellingModeCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &spellingModeCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
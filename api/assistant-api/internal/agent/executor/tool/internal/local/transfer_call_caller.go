@@ -0,0 +1,81 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_tool_local
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internal_tool "github.com/rapidaai/api/assistant-api/internal/agent/executor/tool/internal"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// whisperTurns is how many of the latest messages are read to the human
+// agent before the caller is bridged.
+const whisperTurns = 6
+
+// transferCallCaller hands the caller over to a human agent. The channel
+// dials the agent, plays a whisper summary to them and then bridges both legs.
+type transferCallCaller struct {
+	toolCaller
+}
+
+func (transferTool *transferCallCaller) Call(ctx context.Context, contextID, toolId string, args map[string]interface{}, communication internal_type.Communication) internal_tool.ToolCallResult {
+	to, _ := args["to"].(string)
+	to = strings.TrimSpace(to)
+	if to == "" {
+		return internal_tool.Result("missing number or sip uri to transfer to", false)
+	}
+	reason, _ := args["reason"].(string)
+	communication.OnPacket(ctx, internal_type.DirectivePacket{
+		Directive: protos.ConversationDirective_TRANSFER_CONVERSATION,
+		Arguments: map[string]interface{}{"to": to, "reason": strings.TrimSpace(reason), "whisper": transferWhisper(reason, communication.GetHistories())},
+		ContextID: contextID,
+	})
+	return internal_tool.Result(fmt.Sprintf("Transferring the call to %s.", to), true)
+}
+
+// transferWhisper summarizes the conversation so far for the human agent.
+func transferWhisper(reason string, histories []internal_type.MessagePacket) string {
+	var sb strings.Builder
+	sb.WriteString("Transferred call.")
+	if reason = strings.TrimSpace(reason); reason != "" {
+		sb.WriteString(" Reason: ")
+		sb.WriteString(strings.TrimSuffix(reason, "."))
+		sb.WriteString(".")
+	}
+	if len(histories) > whisperTurns {
+		histories = histories[len(histories)-whisperTurns:]
+	}
+	for _, msg := range histories {
+		content := strings.TrimSpace(msg.Content())
+		if content == "" {
+			continue
+		}
+		switch msg.Role() {
+		case "user":
+			sb.WriteString(" Caller said: ")
+		default:
+			sb.WriteString(" Assistant said: ")
+		}
+		sb.WriteString(content)
+	}
+	return sb.String()
+}
+
+func NewTransferCallCaller(ctx context.Context, logger commons.Logger, toolOptions *internal_assistant_entity.AssistantTool, communcation internal_type.Communication,
+) (internal_tool.ToolCaller, error) {
+	return &transferCallCaller{
+		toolCaller: toolCaller{
+			logger:      logger,
+			toolOptions: toolOptions,
+		},
+	}, nil
+}
@@ -65,6 +65,24 @@ func (executor *toolExecutor) initializeLocalTool(ctx context.Context, logger co
 		return internal_tool_local.NewEndpointToolCaller(ctx, logger, toolOpts, communication)
 	case "end_of_conversation":
 		return internal_tool_local.NewEndOfConversationCaller(ctx, logger, toolOpts, communication)
+	case "send_dtmf":
+		return internal_tool_local.NewSendDTMFCaller(ctx, logger, toolOpts, communication)
+	case "put_on_hold":
+		return internal_tool_local.NewPutOnHoldCaller(ctx, logger, toolOpts, communication)
+	case "spelling_mode":
+		return internal_tool_local.NewSpellingModeCaller(ctx, logger, toolOpts, communication)
+	case "dictation_mode":
+		return internal_tool_local.NewDictationModeCaller(ctx, logger, toolOpts, communication)
+	case "speaking_profile":
+		return internal_tool_local.NewSpeakingProfileCaller(ctx, logger, toolOpts, communication)
+	case "scratchpad":
+		return internal_tool_local.NewScratchpadCaller(ctx, logger, toolOpts, communication)
+	case "conversation_metadata":
+		return internal_tool_local.NewConversationMetadataCaller(ctx, logger, toolOpts, communication)
+	case "transfer_call":
+		return internal_tool_local.NewTransferCallCaller(ctx, logger, toolOpts, communication)
+	case "handoff_assistant":
+		return internal_tool_local.NewHandoffAssistantCaller(ctx, logger, toolOpts, communication)
 	default:
 		return nil, errors.New("illegal tool action provided")
 	}
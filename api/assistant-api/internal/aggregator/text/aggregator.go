@@ -7,15 +7,58 @@ package internal_sentence_aggregator
 
 import (
 	"context"
+	"strings"
 
 	internal_default_aggregator "github.com/rapidaai/api/assistant-api/internal/aggregator/text/internal/default"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Options of the text to speech that choose how the LLM's text is chunked
+// for it. With speaker.chunking set to "clause" the text to speech is fed
+// clause by clause, at the first comma, instead of sentence by sentence.
+const (
+	OptionsKeyChunking              = "speaker.chunking"
+	OptionsKeyChunkingMinChars      = "speaker.chunking.min_chars"
+	OptionsKeyChunkingMaxChars      = "speaker.chunking.max_chars"
+	OptionsKeyChunkingAbbreviations = "speaker.chunking.abbreviations"
+)
+
+const (
+	// ChunkingClause feeds the text to speech clause by clause.
+	ChunkingClause = "clause"
+
+	defaultChunkMinChars = 20
+	defaultChunkMaxChars = 200
 )
 
 func GetLLMTextAggregator(
 	ctx context.Context,
 	logger commons.Logger,
+	opts utils.Option,
 ) (internal_type.LLMTextAggregator, error) {
+	if chunking, err := opts.GetString(OptionsKeyChunking); err == nil && chunking == ChunkingClause {
+		return internal_default_aggregator.NewClauseLLMTextAggregator(ctx, logger, chunkOptions(opts))
+	}
 	return internal_default_aggregator.NewDefaultLLMTextAggregator(ctx, logger)
 }
+
+// chunkOptions reads the clause chunk sizes and extra comma separated
+// abbreviations from opts.
+func chunkOptions(opts utils.Option) internal_default_aggregator.ChunkOptions {
+	options := internal_default_aggregator.ChunkOptions{
+		MinChars: defaultChunkMinChars,
+		MaxChars: defaultChunkMaxChars,
+	}
+	if minChars, err := opts.GetUint64(OptionsKeyChunkingMinChars); err == nil {
+		options.MinChars = int(minChars)
+	}
+	if maxChars, err := opts.GetUint64(OptionsKeyChunkingMaxChars); err == nil {
+		options.MaxChars = int(maxChars)
+	}
+	if abbreviations, err := opts.GetString(OptionsKeyChunkingAbbreviations); err == nil && abbreviations != "" {
+		options.Abbreviations = strings.Split(abbreviations, ",")
+	}
+	return options
+}
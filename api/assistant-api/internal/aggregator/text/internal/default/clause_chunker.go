@@ -0,0 +1,229 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_default_aggregator
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// ============================================================================
+// Clause chunking
+// ============================================================================
+
+// ChunkOptions configures the clause chunker of NewClauseLLMTextAggregator.
+type ChunkOptions struct {
+	// MinChars is the shortest chunk a clause boundary (comma, semicolon,
+	// colon, dash) ends, shorter clauses wait for the next boundary. Sentence
+	// boundaries always end a chunk.
+	MinChars int
+
+	// MaxChars is the longest chunk, text running longer without a boundary
+	// is cut at a space. 0 leaves chunks unbounded.
+	MaxChars int
+
+	// Abbreviations are words, without their period, whose period does not
+	// end a sentence, in addition to the titles of defaultAbbreviations.
+	Abbreviations []string
+}
+
+// defaultAbbreviations are the abbreviations a sentence rarely ends with.
+var defaultAbbreviations = []string{
+	"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "mt", "vs",
+	"approx", "dept", "fig", "ft", "lt", "col", "gen", "sgt", "capt", "rev", "hon",
+	"ave", "blvd", "rd",
+}
+
+// clauseBoundaries end a clause, sentenceBoundaries a sentence.
+const clauseBoundaries = ",;:—–，、；："
+
+// closingMarks may follow the punctuation that ends a sentence or clause.
+const closingMarks = `"')]”’»`
+
+type boundaryKind int
+
+const (
+	noBoundary boundaryKind = iota
+	clauseBoundary
+	sentenceBoundary
+)
+
+// clauseChunker splits streamed text into chunks at clause and sentence
+// boundaries, so the text to speech starts on the first clause rather than
+// the first sentence.
+type clauseChunker struct {
+	minChars      int
+	maxChars      int
+	abbreviations map[string]bool
+}
+
+// NewClauseLLMTextAggregator creates a text aggregator that emits clauses as
+// soon as their boundary arrives, each as its own packet, within the sizes
+// of options. A period ends a sentence only when followed by a space, so
+// that "3." waits for "14", and not after an abbreviation, an initial or a
+// dotted abbreviation such as "U.S.".
+func NewClauseLLMTextAggregator(ctx context.Context, logger commons.Logger, options ChunkOptions) (internal_type.LLMTextAggregator, error) {
+	aggregator, err := NewDefaultLLMTextAggregator(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	abbreviations := make(map[string]bool, len(defaultAbbreviations)+len(options.Abbreviations))
+	for _, a := range append(defaultAbbreviations, options.Abbreviations...) {
+		if a = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(a), ".")); a != "" {
+			abbreviations[a] = true
+		}
+	}
+	aggregator.(*textAggregator).chunker = &clauseChunker{
+		minChars:      options.MinChars,
+		maxChars:      options.MaxChars,
+		abbreviations: abbreviations,
+	}
+	return aggregator, nil
+}
+
+// split returns the complete chunks of text and the text still waiting for
+// its boundary.
+func (c *clauseChunker) split(text string) ([]string, string) {
+	var chunks []string
+	start := 0
+	for i, r := range text {
+		if i < start {
+			continue
+		}
+		kind, end := c.boundary(text, i, r)
+		if kind == noBoundary {
+			continue
+		}
+		chunk := strings.TrimSpace(text[start:end])
+		if kind == clauseBoundary && utf8.RuneCountInString(chunk) < c.minChars {
+			continue
+		}
+		if chunk != "" {
+			chunks = append(chunks, c.limit(chunk)...)
+		}
+		start = end
+	}
+
+	rest := text[start:]
+	if c.maxChars > 0 && utf8.RuneCountInString(strings.TrimSpace(rest)) > c.maxChars {
+		parts := c.limit(strings.TrimLeftFunc(rest, unicode.IsSpace))
+		return append(chunks, parts[:len(parts)-1]...), parts[len(parts)-1]
+	}
+	return chunks, rest
+}
+
+// boundary reports whether r at i ends a clause or a sentence and where the
+// chunk it ends stops, closing quotes and brackets included.
+func (c *clauseChunker) boundary(text string, i int, r rune) (boundaryKind, int) {
+	kind := noBoundary
+	switch {
+	case strings.ContainsRune(clauseBoundaries, r):
+		kind = clauseBoundary
+	case isSentenceBoundary(r):
+		kind = sentenceBoundary
+	default:
+		return noBoundary, 0
+	}
+	end := i + utf8.RuneLen(r)
+	for end < len(text) {
+		next, size := utf8.DecodeRuneInString(text[end:])
+		if !strings.ContainsRune(closingMarks, next) {
+			break
+		}
+		end += size
+	}
+	// Latin punctuation ends nothing until a space follows it: 3.14, 1,000,
+	// example.com and text still streaming
+	if r < utf8.RuneSelf {
+		if end == len(text) {
+			return noBoundary, 0
+		}
+		if next, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(next) {
+			return noBoundary, 0
+		}
+	}
+	if r == '.' && c.abbreviated(text[:i]) {
+		return noBoundary, 0
+	}
+	return kind, end
+}
+
+// abbreviated reports whether the period after before is the period of an
+// abbreviation rather than a full stop.
+func (c *clauseChunker) abbreviated(before string) bool {
+	if strings.HasSuffix(before, ".") {
+		// an ellipsis written as periods
+		return false
+	}
+	word := before[strings.LastIndexFunc(before, func(r rune) bool { return !unicode.IsLetter(r) })+1:]
+	if word == "" {
+		return false
+	}
+	if strings.HasSuffix(before[:len(before)-len(word)], ".") {
+		// e.g., U.S.
+		return true
+	}
+	if first, size := utf8.DecodeRuneInString(word); size == len(word) && unicode.IsUpper(first) {
+		// an initial, J. Smith
+		return true
+	}
+	return c.abbreviations[strings.ToLower(word)]
+}
+
+// limit cuts chunk into chunks of at most maxChars, at a space and outside
+// of markup where it can.
+func (c *clauseChunker) limit(chunk string) []string {
+	var chunks []string
+	for c.maxChars > 0 && utf8.RuneCountInString(chunk) > c.maxChars {
+		cut := cutAt(chunk, c.maxChars)
+		chunks = append(chunks, strings.TrimSpace(chunk[:cut]))
+		chunk = strings.TrimLeftFunc(chunk[cut:], unicode.IsSpace)
+	}
+	return append(chunks, chunk)
+}
+
+// cutAt is the byte offset to cut s at, the last space within maxChars runes
+// before any unclosed tag, else maxChars runes or the end of the tag s
+// starts with.
+func cutAt(s string, maxChars int) int {
+	end, n := len(s), 0
+	for i := range s {
+		if n == maxChars {
+			end = i
+			break
+		}
+		n++
+	}
+	head := s[:end]
+	if tag := strings.LastIndexByte(head, '<'); tag > strings.LastIndexByte(head, '>') {
+		head = head[:tag]
+	}
+	if space := strings.LastIndexFunc(head, unicode.IsSpace); space > 0 {
+		return space
+	}
+	if head != "" {
+		return len(head)
+	}
+	// a tag longer than maxChars is kept whole
+	if tagEnd := strings.IndexByte(s, '>'); tagEnd >= 0 {
+		return tagEnd + 1
+	}
+	return end
+}
+
+func isSentenceBoundary(r rune) bool {
+	for _, b := range sentenceBoundaries {
+		if b == string(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_default_aggregator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+func newTestChunker(t *testing.T, options ChunkOptions) *clauseChunker {
+	logger, _ := commons.NewApplicationLogger()
+	aggregator, err := NewClauseLLMTextAggregator(t.Context(), logger, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { aggregator.Close() })
+	return aggregator.(*textAggregator).chunker
+}
+
+func TestClauseChunkerSplit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options ChunkOptions
+		input   string
+		chunks  []string
+		rest    string
+	}{
+		{
+			name:   "clauses and sentences",
+			input:  "Sure, I can help with that. Let me check: one moment",
+			chunks: []string{"Sure,", "I can help with that.", "Let me check:"},
+			rest:   " one moment",
+		},
+		{
+			name:    "short clauses wait for the minimum",
+			options: ChunkOptions{MinChars: 10},
+			input:   "Well, you see, it is late. Ok. Bye",
+			chunks:  []string{"Well, you see,", "it is late.", "Ok."},
+			rest:    " Bye",
+		},
+		{
+			name:   "a boundary waits for the next space",
+			input:  "It costs 3.",
+			chunks: nil,
+			rest:   "It costs 3.",
+		},
+		{
+			name:   "decimals, thousands and addresses",
+			input:  "Pay 1,250.50 at example.com today. Then",
+			chunks: []string{"Pay 1,250.50 at example.com today."},
+			rest:   " Then",
+		},
+		{
+			name:   "abbreviations and initials",
+			input:  "Dr. Smith met J. R. Doe in the U.S. capital. Next",
+			chunks: []string{"Dr. Smith met J. R. Doe in the U.S. capital."},
+			rest:   " Next",
+		},
+		{
+			name:    "extra abbreviations",
+			options: ChunkOptions{Abbreviations: []string{"Acct."}},
+			input:   "See acct. 42 now. Next",
+			chunks:  []string{"See acct. 42 now."},
+			rest:    " Next",
+		},
+		{
+			name:   "ellipsis and closing quotes",
+			input:  `Wait... He said "go." Then`,
+			chunks: []string{"Wait...", `He said "go."`},
+			rest:   " Then",
+		},
+		{
+			name:   "CJK punctuation needs no space",
+			input:  "你好，世界。再见",
+			chunks: []string{"你好，", "世界。"},
+			rest:   "再见",
+		},
+		{
+			name:    "long text is cut at a space",
+			options: ChunkOptions{MaxChars: 12},
+			input:   "one two three four five six",
+			chunks:  []string{"one two", "three four"},
+			rest:    "five six",
+		},
+		{
+			name:    "long sentences are cut",
+			options: ChunkOptions{MaxChars: 12},
+			input:   "one two three four. x",
+			chunks:  []string{"one two", "three four."},
+			rest:    " x",
+		},
+		{
+			name:    "markup is not cut",
+			options: ChunkOptions{MaxChars: 16},
+			input:   `say <break time="1s"/> now`,
+			chunks:  []string{"say", `<break time="1s"/>`},
+			rest:    "now",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks, rest := newTestChunker(t, tc.options).split(tc.input)
+			if !reflect.DeepEqual(chunks, tc.chunks) {
+				t.Errorf("expected chunks %q, got %q", tc.chunks, chunks)
+			}
+			if rest != tc.rest {
+				t.Errorf("expected rest %q, got %q", tc.rest, rest)
+			}
+		})
+	}
+}
+
+func TestClauseAggregatorStreaming(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	aggregator, _ := NewClauseLLMTextAggregator(t.Context(), logger, ChunkOptions{MinChars: 5, MaxChars: 200})
+	defer aggregator.Close()
+
+	ctx := context.Background()
+	chunks := []string{"Hello", " there", ",", " the", " total", " is", " 3", ".", "50", " dollars", ".", " Thanks"}
+	go func() {
+		for _, chunk := range chunks {
+			_ = aggregator.Aggregate(ctx, internal_type.LLMResponseDeltaPacket{ContextID: "llm", Text: chunk})
+		}
+		_ = aggregator.Aggregate(ctx, internal_type.LLMResponseDonePacket{ContextID: "llm"})
+	}()
+
+	var texts []string
+	for _, result := range collectResults(ctx, aggregator.Result()) {
+		if delta, ok := result.(internal_type.LLMResponseDeltaPacket); ok {
+			texts = append(texts, delta.Text)
+		}
+	}
+
+	expected := []string{"Hello there,", "the total is 3.50 dollars.", "Thanks"}
+	if strings.Join(texts, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, texts)
+	}
+}
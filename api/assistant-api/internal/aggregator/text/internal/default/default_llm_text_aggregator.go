@@ -93,6 +93,10 @@ type textAggregator struct {
 	// toEmitBuffer is a reusable slice that collects packets to emit during
 	// a single Aggregate call, reducing per-call heap allocations.
 	toEmitBuffer []internal_type.Packet
+
+	// chunker, when set, splits at clauses instead of sentences, see
+	// NewClauseLLMTextAggregator.
+	chunker *clauseChunker
 }
 
 // NewDefaultLLMTextAggregator creates a sentence-boundary text aggregator.
@@ -276,6 +280,10 @@ func (st *textAggregator) handleDoneLocked(done internal_type.LLMResponseDonePac
 // MUST be called with mu held.
 func (st *textAggregator) extractSentencesAtBoundaryLocked(contextID string) {
 	text := st.buffer.String()
+	if st.chunker != nil {
+		st.extractClausesLocked(contextID, text)
+		return
+	}
 
 	matches := st.boundaryRegex.FindAllStringIndex(text, -1)
 	if len(matches) == 0 {
@@ -303,15 +311,36 @@ func (st *textAggregator) extractSentencesAtBoundaryLocked(contextID string) {
 	}
 }
 
+// extractClausesLocked emits each complete chunk of text as its own delta
+// packet and retains the rest in the buffer.
+// MUST be called with mu held.
+func (st *textAggregator) extractClausesLocked(contextID, text string) {
+	chunks, rest := st.chunker.split(text)
+	for _, chunk := range chunks {
+		st.toEmitBuffer = append(st.toEmitBuffer, internal_type.LLMResponseDeltaPacket{
+			ContextID: contextID,
+			Text:      chunk,
+		})
+	}
+	st.buffer.Reset()
+	st.buffer.WriteString(rest)
+}
+
 // flushBufferLocked emits any non-empty buffered text as a final delta packet
 // and resets the buffer.
 // MUST be called with mu held.
 func (st *textAggregator) flushBufferLocked(contextID string) {
 	if remaining := strings.TrimSpace(st.buffer.String()); remaining != "" {
-		st.toEmitBuffer = append(st.toEmitBuffer, internal_type.LLMResponseDeltaPacket{
-			ContextID: contextID,
-			Text:      remaining,
-		})
+		chunks := []string{remaining}
+		if st.chunker != nil {
+			chunks = st.chunker.limit(remaining)
+		}
+		for _, chunk := range chunks {
+			st.toEmitBuffer = append(st.toEmitBuffer, internal_type.LLMResponseDeltaPacket{
+				ContextID: contextID,
+				Text:      chunk,
+			})
+		}
 	}
 	st.buffer.Reset()
 }
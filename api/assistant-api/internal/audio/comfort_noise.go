@@ -0,0 +1,91 @@
+// Copyright (c) 2023-2026 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audio
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// DefaultComfortNoiseLevel is the comfort noise level in dBov used when a
+	// channel turns it on without one, about the noise of a quiet PSTN line.
+	DefaultComfortNoiseLevel = -60
+
+	// MinComfortNoiseLevel and MaxComfortNoiseLevel bound the configurable
+	// level, quieter is inaudible and louder sounds like a bad line.
+	MinComfortNoiseLevel = -80
+	MaxComfortNoiseLevel = -30
+
+	// comfortNoiseSmoothing is the pole of the low pass shaping the noise,
+	// white noise hisses while phone lines rather hum.
+	comfortNoiseSmoothing = 0.6
+)
+
+// ComfortNoise generates the low-level noise played in the gaps of the
+// assistant's output. Between utterances a channel otherwise sends digital
+// silence, which callers on the PSTN take for a dropped call. It is not safe
+// for concurrent use.
+type ComfortNoise struct {
+	rms   float64 // level of the noise as linear16 RMS
+	gain  float64 // makes up for the power the low pass takes
+	state float64
+	rng   *rand.Rand
+}
+
+// NewComfortNoise creates a generator for the level in dBov, levels outside
+// MinComfortNoiseLevel..MaxComfortNoiseLevel are clamped to it.
+func NewComfortNoise(levelDBov int) *ComfortNoise {
+	levelDBov = min(max(levelDBov, MinComfortNoiseLevel), MaxComfortNoiseLevel)
+	return &ComfortNoise{
+		rms:  math.MaxInt16 * math.Pow(10, float64(levelDBov)/20),
+		gain: math.Sqrt((1 + comfortNoiseSmoothing) / (1 - comfortNoiseSmoothing)),
+		rng:  rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// Frame returns n samples of noise.
+func (c *ComfortNoise) Frame(n int) []int16 {
+	samples := make([]int16, n)
+	for i := range samples {
+		samples[i] = clampInt16(c.next())
+	}
+	return samples
+}
+
+// Fill adds noise under a frame whose energy is below the noise level, the
+// digital silence and near-silent lead-in and tail of synthesized speech.
+// Louder frames are left alone. It reports whether noise was added.
+func (c *ComfortNoise) Fill(samples []int16) bool {
+	if len(samples) == 0 || RMS(samples) >= c.rms {
+		return false
+	}
+	for i, s := range samples {
+		samples[i] = clampInt16(float64(s) + c.next())
+	}
+	return true
+}
+
+func (c *ComfortNoise) next() float64 {
+	c.state = comfortNoiseSmoothing*c.state + (1-comfortNoiseSmoothing)*c.rng.NormFloat64()
+	return c.state * c.gain * c.rms
+}
+
+// RMS returns the root mean square of linear16 samples.
+func RMS(samples []int16) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += float64(s) * float64(s)
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+func clampInt16(v float64) int16 {
+	return int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(v))))
+}
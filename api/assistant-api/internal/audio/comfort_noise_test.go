@@ -0,0 +1,53 @@
+// Copyright (c) 2023-2026 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func levelDBov(samples []int16) float64 {
+	return 20 * math.Log10(RMS(samples)/math.MaxInt16)
+}
+
+func TestComfortNoise_FrameLevel(t *testing.T) {
+	for _, level := range []int{-70, -60, -45} {
+		noise := NewComfortNoise(level)
+		assert.InDelta(t, float64(level), levelDBov(noise.Frame(8000)), 1.5, "level %d", level)
+	}
+}
+
+func TestComfortNoise_ClampsLevel(t *testing.T) {
+	assert.InDelta(t, float64(MaxComfortNoiseLevel), levelDBov(NewComfortNoise(-5).Frame(8000)), 1.5)
+	assert.InDelta(t, float64(MinComfortNoiseLevel), levelDBov(NewComfortNoise(-200).Frame(8000)), 3)
+}
+
+func TestComfortNoise_FillSilence(t *testing.T) {
+	noise := NewComfortNoise(-60)
+	frame := make([]int16, 320)
+	assert.True(t, noise.Fill(frame))
+	assert.NotZero(t, RMS(frame))
+}
+
+func TestComfortNoise_FillLeavesSpeech(t *testing.T) {
+	noise := NewComfortNoise(-60)
+	frame := make([]int16, 320)
+	for i := range frame {
+		frame[i] = int16(3000 * math.Sin(float64(i)/5))
+	}
+	original := append([]int16(nil), frame...)
+	assert.False(t, noise.Fill(frame))
+	assert.Equal(t, original, frame)
+	assert.False(t, noise.Fill(nil))
+}
+
+func TestRMS(t *testing.T) {
+	assert.Zero(t, RMS(nil))
+	assert.InDelta(t, 100, RMS([]int16{100, -100, 100, -100}), 1e-9)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2023-2026 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// MixLinear16 adds the linear16 samples of other to dst, clipping at full
+// scale. Samples of other beyond dst are left out.
+func MixLinear16(dst, other []byte) {
+	for i := 0; i+1 < len(other) && i+1 < len(dst); i += 2 {
+		sum := int32(int16(binary.LittleEndian.Uint16(dst[i:]))) + int32(int16(binary.LittleEndian.Uint16(other[i:])))
+		sum = max(min(sum, math.MaxInt16), math.MinInt16)
+		binary.LittleEndian.PutUint16(dst[i:], uint16(int16(sum)))
+	}
+}
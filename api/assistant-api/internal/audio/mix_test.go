@@ -0,0 +1,47 @@
+// Copyright (c) 2023-2026 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func linear16(samples ...int16) []byte {
+	out := make([]byte, 2*len(samples))
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(s))
+	}
+	return out
+}
+
+// ---------------------------------------------------------------------------
+// MixLinear16
+// ---------------------------------------------------------------------------
+
+func TestMixLinear16_AddsSamples(t *testing.T) {
+	dst := linear16(100, -200, 300)
+	MixLinear16(dst, linear16(10, 20, -30))
+	assert.Equal(t, linear16(110, -180, 270), dst)
+}
+
+func TestMixLinear16_ClipsAtFullScale(t *testing.T) {
+	dst := linear16(30000, -30000)
+	MixLinear16(dst, linear16(10000, -10000))
+	assert.Equal(t, linear16(math.MaxInt16, math.MinInt16), dst)
+}
+
+func TestMixLinear16_ShorterOther(t *testing.T) {
+	dst := linear16(1, 2, 3)
+	MixLinear16(dst, linear16(1))
+	assert.Equal(t, linear16(2, 2, 3), dst)
+
+	MixLinear16(dst[:2], linear16(1, 1, 1))
+	assert.Equal(t, linear16(3, 2, 3), dst)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_audio_pipeline
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// Settings of the built-in filters:
+//
+//	microphone.filter.dc_block.cutoff_hz      = 80
+//	microphone.filter.gain.db                 = 6
+//	microphone.filter.normalize.target_dbfs   = -20
+//	microphone.filter.normalize.max_gain_db   = 20
+const (
+	OptionsKeyDCBlockCutoff      = "microphone.filter.dc_block.cutoff_hz"
+	OptionsKeyGain               = "microphone.filter.gain.db"
+	OptionsKeyNormalizeTarget    = "microphone.filter.normalize.target_dbfs"
+	OptionsKeyNormalizeMaxGainDB = "microphone.filter.normalize.max_gain_db"
+)
+
+const (
+	defaultDCBlockCutoff   = 80.0
+	defaultNormalizeTarget = -20.0
+	defaultNormalizeMaxDB  = 20.0
+
+	// normalizeFloor is the level under which a frame is taken for silence
+	// or line noise, normalize does not raise its gain for it.
+	normalizeFloor = -50.0
+	// the gain drops quickly on loud speech and rises slowly after it
+	normalizeAttack  = 20 * time.Millisecond
+	normalizeRelease = 800 * time.Millisecond
+)
+
+func init() {
+	Register("dc_block", newDCBlock)
+	Register("gain", newGain)
+	Register("normalize", newNormalize)
+}
+
+// linear16 checks the filters are given audio they can process, all of them
+// work on linear16 mono samples.
+func linear16(cfg *protos.AudioConfig) error {
+	if cfg.GetAudioFormat() != protos.AudioConfig_LINEAR16 || cfg.GetChannels() > 1 || cfg.GetSampleRate() == 0 {
+		return fmt.Errorf("needs linear16 mono audio")
+	}
+	return nil
+}
+
+func sample(pcm []byte, i int) float64 {
+	return float64(int16(binary.LittleEndian.Uint16(pcm[i:])))
+}
+
+func putSample(pcm []byte, i int, v float64) {
+	binary.LittleEndian.PutUint16(pcm[i:], uint16(int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(v))))))
+}
+
+// dcBlock removes the DC offset and the rumble below the cutoff some
+// handsets and gateways add, which VAD otherwise takes for energy.
+type dcBlock struct {
+	r      float64
+	x1, y1 float64
+}
+
+func newDCBlock(cfg *protos.AudioConfig, opts utils.Option) (AudioFilter, error) {
+	if err := linear16(cfg); err != nil {
+		return nil, err
+	}
+	cutoff := defaultDCBlockCutoff
+	if v, err := opts.GetFloat64(OptionsKeyDCBlockCutoff); err == nil {
+		cutoff = v
+	}
+	if cutoff <= 0 || cutoff >= float64(cfg.GetSampleRate())/2 {
+		return nil, fmt.Errorf("cutoff %.0f Hz out of range", cutoff)
+	}
+	return &dcBlock{r: math.Exp(-2 * math.Pi * cutoff / float64(cfg.GetSampleRate()))}, nil
+}
+
+func (f *dcBlock) Name() string { return "dc_block" }
+
+func (f *dcBlock) Process(pcm []byte) ([]byte, error) {
+	out := make([]byte, len(pcm)&^1)
+	for i := 0; i+1 < len(pcm); i += 2 {
+		x := sample(pcm, i)
+		y := x - f.x1 + f.r*f.y1
+		f.x1, f.y1 = x, y
+		putSample(out, i, y)
+	}
+	return out, nil
+}
+
+func (f *dcBlock) Reset() { f.x1, f.y1 = 0, 0 }
+
+// gain scales the audio by a fixed amount, for lines known to be too quiet
+// or too hot.
+type gain struct {
+	factor float64
+}
+
+func newGain(cfg *protos.AudioConfig, opts utils.Option) (AudioFilter, error) {
+	if err := linear16(cfg); err != nil {
+		return nil, err
+	}
+	db, err := opts.GetFloat64(OptionsKeyGain)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not set", OptionsKeyGain)
+	}
+	return &gain{factor: math.Pow(10, db/20)}, nil
+}
+
+func (f *gain) Name() string { return "gain" }
+
+func (f *gain) Process(pcm []byte) ([]byte, error) {
+	out := make([]byte, len(pcm)&^1)
+	for i := 0; i+1 < len(pcm); i += 2 {
+		putSample(out, i, sample(pcm, i)*f.factor)
+	}
+	return out, nil
+}
+
+// normalize brings speech to a steady level whatever the distance to the
+// microphone or the loss of the line, without raising silence.
+type normalize struct {
+	sampleRate float64
+	target     float64 // RMS of linear16 samples
+	maxGain    float64
+	floor      float64
+	gain       float64
+}
+
+func newNormalize(cfg *protos.AudioConfig, opts utils.Option) (AudioFilter, error) {
+	if err := linear16(cfg); err != nil {
+		return nil, err
+	}
+	target, maxDB := defaultNormalizeTarget, defaultNormalizeMaxDB
+	if v, err := opts.GetFloat64(OptionsKeyNormalizeTarget); err == nil {
+		target = v
+	}
+	if v, err := opts.GetFloat64(OptionsKeyNormalizeMaxGainDB); err == nil {
+		maxDB = v
+	}
+	if target >= 0 || target <= normalizeFloor {
+		return nil, fmt.Errorf("target %.0f dBFS out of range", target)
+	}
+	if maxDB < 0 {
+		return nil, fmt.Errorf("max gain %.0f dB is negative", maxDB)
+	}
+	return &normalize{
+		sampleRate: float64(cfg.GetSampleRate()),
+		target:     dbfs(target),
+		maxGain:    math.Pow(10, maxDB/20),
+		floor:      dbfs(normalizeFloor),
+		gain:       1,
+	}, nil
+}
+
+func dbfs(level float64) float64 {
+	return math.MaxInt16 * math.Pow(10, level/20)
+}
+
+func (f *normalize) Name() string { return "normalize" }
+
+func (f *normalize) Process(pcm []byte) ([]byte, error) {
+	n := len(pcm) / 2
+	if n == 0 {
+		return pcm[:0], nil
+	}
+	var power, peak float64
+	for i := 0; i < n; i++ {
+		v := sample(pcm, 2*i)
+		power += v * v
+		peak = math.Max(peak, math.Abs(v))
+	}
+	rms := math.Sqrt(power / float64(n))
+
+	want := f.gain
+	if rms > f.floor {
+		want = math.Min(f.target/rms, f.maxGain)
+	}
+	// never clip the loudest sample of the frame
+	if peak > 0 {
+		want = math.Min(want, math.MaxInt16/peak)
+	}
+	tau := normalizeRelease
+	if want < f.gain {
+		tau = normalizeAttack
+	}
+	duration := float64(n) / f.sampleRate
+	next := f.gain + (want-f.gain)*(1-math.Exp(-duration/tau.Seconds()))
+
+	// ramp across the frame so the change of gain does not click
+	out := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		g := f.gain + (next-f.gain)*float64(i+1)/float64(n)
+		putSample(out, 2*i, sample(pcm, 2*i)*g)
+	}
+	f.gain = next
+	return out, nil
+}
+
+func (f *normalize) Reset() { f.gain = 1 }
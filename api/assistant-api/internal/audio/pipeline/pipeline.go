@@ -0,0 +1,159 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_audio_pipeline chains audio filters run on the caller
+// audio of every channel. A stage is written once as an AudioFilter and
+// registered by name; assistants pick the stages and their order in the
+// speech to text options, and each channel runs them on the linear16 16kHz
+// audio it hands to the talk loop.
+package internal_audio_pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rapidaai/pkg/streamers"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// The stages are a comma separated list of filter names, in the order they
+// run. A list for one source replaces the general one for its channels:
+//
+//	microphone.filters           = dc_block,normalize
+//	microphone.filters.phone-call = dc_block,gain
+const OptionsKeyFilters = "microphone.filters"
+
+// AudioFilter is a stage of the pipeline. Process takes a frame of the
+// pipeline's audio and returns it filtered; frames come in order but not in
+// any fixed size.
+type AudioFilter interface {
+	Name() string
+	Process(pcm []byte) ([]byte, error)
+}
+
+// Resetter is implemented by filters that keep state from one frame to the
+// next, reset when the media session restarts.
+type Resetter interface {
+	Reset()
+}
+
+// Factory creates a filter for audio in the configuration, reading its
+// settings from the options.
+type Factory func(cfg *protos.AudioConfig, opts utils.Option) (AudioFilter, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a filter available to the options under name. It panics
+// when the name is taken, filters register from init.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("audio filter %q registered twice", name))
+	}
+	registry[name] = factory
+}
+
+// Registered returns the names of the filters available, sorted.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Pipeline runs its filters in order. A pipeline is used by one channel and
+// is not safe for concurrent use.
+type Pipeline struct {
+	filters []AudioFilter
+}
+
+// New returns a pipeline of the filters.
+func New(filters ...AudioFilter) *Pipeline {
+	return &Pipeline{filters: filters}
+}
+
+// FromOptions builds the pipeline the options configure for channels of the
+// source, nil when they configure none. An unknown filter or a filter that
+// cannot be created fails the whole pipeline.
+func FromOptions(cfg *protos.AudioConfig, opts utils.Option, source utils.RapidaSource) (*Pipeline, error) {
+	list, err := opts.GetString(OptionsKeyFilters + "." + source.Get())
+	if err != nil {
+		list, _ = opts.GetString(OptionsKeyFilters)
+	}
+	var filters []AudioFilter
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		registryMu.RLock()
+		factory, ok := registry[name]
+		registryMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown audio filter %q, available %s", name, strings.Join(Registered(), ", "))
+		}
+		filter, err := factory(cfg, opts)
+		if err != nil {
+			return nil, fmt.Errorf("audio filter %s: %w", name, err)
+		}
+		filters = append(filters, filter)
+	}
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	return New(filters...), nil
+}
+
+// Process runs the frame through every filter. The first filter to fail
+// stops the frame, the error names it.
+func (p *Pipeline) Process(pcm []byte) ([]byte, error) {
+	var err error
+	for _, filter := range p.filters {
+		if pcm, err = filter.Process(pcm); err != nil {
+			return nil, fmt.Errorf("audio filter %s: %w", filter.Name(), err)
+		}
+	}
+	return pcm, nil
+}
+
+// Reset resets the filters that keep state.
+func (p *Pipeline) Reset() {
+	for _, filter := range p.filters {
+		if r, ok := filter.(Resetter); ok {
+			r.Reset()
+		}
+	}
+}
+
+// Names returns the names of the filters in the order they run.
+func (p *Pipeline) Names() []string {
+	names := make([]string, len(p.filters))
+	for i, filter := range p.filters {
+		names[i] = filter.Name()
+	}
+	return names
+}
+
+// Channel is implemented by streamers that run a pipeline on the caller
+// audio they receive, every streamer embedding streamers.BaseStreamer does.
+type Channel interface {
+	// SetInputFilter runs the filter on the caller audio from then on, nil
+	// stops filtering.
+	SetInputFilter(f streamers.InputFilter)
+}
+
+var _ streamers.InputFilter = (*Pipeline)(nil)
@@ -0,0 +1,149 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_audio_pipeline
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var linear16k = &protos.AudioConfig{SampleRate: 16000, AudioFormat: protos.AudioConfig_LINEAR16, Channels: 1}
+
+type stage struct {
+	name string
+	err  error
+}
+
+func (s stage) Name() string { return s.name }
+
+func (s stage) Process(pcm []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return append(pcm, s.name...), nil
+}
+
+func tone(n int, amplitude, offset float64) []byte {
+	pcm := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		v := offset + amplitude*math.Sin(2*math.Pi*440*float64(i)/16000)
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(int16(v)))
+	}
+	return pcm
+}
+
+func rms(pcm []byte) float64 {
+	var power float64
+	for i := 0; i+1 < len(pcm); i += 2 {
+		v := float64(int16(binary.LittleEndian.Uint16(pcm[i:])))
+		power += v * v
+	}
+	return math.Sqrt(power / float64(len(pcm)/2))
+}
+
+func mean(pcm []byte) float64 {
+	var sum float64
+	for i := 0; i+1 < len(pcm); i += 2 {
+		sum += float64(int16(binary.LittleEndian.Uint16(pcm[i:])))
+	}
+	return sum / float64(len(pcm)/2)
+}
+
+func TestPipeline_Process(t *testing.T) {
+	p := New(stage{name: "a"}, stage{name: "b"})
+	out, err := p.Process([]byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, "xab", string(out))
+	assert.Equal(t, []string{"a", "b"}, p.Names())
+
+	_, err = New(stage{name: "a"}, stage{name: "broken", err: errors.New("boom")}).Process([]byte("x"))
+	assert.EqualError(t, err, "audio filter broken: boom")
+}
+
+func TestFromOptions(t *testing.T) {
+	p, err := FromOptions(linear16k, utils.Option{}, utils.WebPlugin)
+	require.NoError(t, err)
+	assert.Nil(t, p, "nothing configured")
+
+	opts := utils.Option{
+		OptionsKeyFilters:                 "dc_block, normalize",
+		OptionsKeyFilters + ".phone-call": "gain",
+		OptionsKeyGain:                    6,
+	}
+	p, err = FromOptions(linear16k, opts, utils.WebPlugin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dc_block", "normalize"}, p.Names())
+
+	p, err = FromOptions(linear16k, opts, utils.PhoneCall)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gain"}, p.Names(), "the list of the source replaces the general one")
+
+	_, err = FromOptions(linear16k, utils.Option{OptionsKeyFilters: "dc_block,reverb"}, utils.SDK)
+	assert.ErrorContains(t, err, `unknown audio filter "reverb"`)
+	_, err = FromOptions(linear16k, utils.Option{OptionsKeyFilters: "gain"}, utils.SDK)
+	assert.ErrorContains(t, err, OptionsKeyGain)
+}
+
+func TestDCBlock(t *testing.T) {
+	f, err := newDCBlock(linear16k, utils.Option{})
+	require.NoError(t, err)
+	in := tone(16000, 3000, 2000)
+	out, err := f.Process(in)
+	require.NoError(t, err)
+	require.Len(t, out, len(in))
+	// after the filter settles the offset is gone and the tone is kept
+	settled := out[len(out)/2:]
+	assert.InDelta(t, 0, mean(settled), 20)
+	assert.InDelta(t, 3000/math.Sqrt2, rms(settled), 150)
+}
+
+func TestGain(t *testing.T) {
+	f, err := newGain(linear16k, utils.Option{OptionsKeyGain: 6.0206})
+	require.NoError(t, err)
+	out, err := f.Process(tone(1600, 1000, 0))
+	require.NoError(t, err)
+	assert.InDelta(t, 2000/math.Sqrt2, rms(out), 5)
+
+	_, err = newGain(&protos.AudioConfig{SampleRate: 8000, AudioFormat: protos.AudioConfig_MuLaw8, Channels: 1}, utils.Option{OptionsKeyGain: 6})
+	assert.Error(t, err, "only linear16")
+}
+
+func TestNormalize(t *testing.T) {
+	f, err := newNormalize(linear16k, utils.Option{})
+	require.NoError(t, err)
+	target := dbfs(defaultNormalizeTarget)
+
+	// quiet speech in 20ms frames is raised to the target over a few seconds
+	var out []byte
+	for i := 0; i < 250; i++ {
+		out, err = f.Process(tone(320, 600, 0))
+		require.NoError(t, err)
+	}
+	assert.InDelta(t, target, rms(out), target*0.05)
+
+	// loud speech is brought down quickly and never clipped
+	for i := 0; i < 10; i++ {
+		out, err = f.Process(tone(320, 30000, 0))
+		require.NoError(t, err)
+	}
+	assert.Less(t, rms(out), 30000/math.Sqrt2)
+
+	// silence is not raised
+	f.(Resetter).Reset()
+	for i := 0; i < 100; i++ {
+		out, err = f.Process(tone(320, 20, 0))
+		require.NoError(t, err)
+	}
+	assert.InDelta(t, 20/math.Sqrt2, rms(out), 2)
+}
@@ -86,6 +86,17 @@ func (r *audioRecorder) Start() {
 	r.started = true
 }
 
+// Offset returns how far into the recording t is. Audio heard at t is found
+// at this position in the persisted WAVs.
+func (r *audioRecorder) Offset(t time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.started || t.Before(r.startTime) {
+		return 0
+	}
+	return t.Sub(r.startTime)
+}
+
 // bytesPerSecond returns the PCM byte rate for the internal audio format.
 func bytesPerSecond() int {
 	return internal_audio.BytesPerSecond(audioConfig)
@@ -305,11 +316,40 @@ func (r *audioRecorder) computeBufferLength() int {
 	return totalLen
 }
 
+// Stereo interleaves the two mono WAVs returned by Persist into a single
+// two-channel WAV, the user on the left channel and the system on the right.
+// Persist renders both tracks to the same length, a shorter track is padded
+// with silence regardless.
+func Stereo(userWAV, systemWAV []byte) ([]byte, error) {
+	if len(userWAV) < wavHeaderSize || len(systemWAV) < wavHeaderSize {
+		return nil, fmt.Errorf("stereo: both tracks must be WAV encoded")
+	}
+	user, system := userWAV[wavHeaderSize:], systemWAV[wavHeaderSize:]
+	samples := max(len(user), len(system)) / AudioBytesPerSample
+	pcmData := make([]byte, samples*2*AudioBytesPerSample)
+	for i := 0; i < samples; i++ {
+		at := i * AudioBytesPerSample
+		out := at * 2
+		if at+AudioBytesPerSample <= len(user) {
+			copy(pcmData[out:], user[at:at+AudioBytesPerSample])
+		}
+		if at+AudioBytesPerSample <= len(system) {
+			copy(pcmData[out+AudioBytesPerSample:], system[at:at+AudioBytesPerSample])
+		}
+	}
+	return encodeWAVChannels(pcmData, 2)
+}
+
 // encodeWAV wraps raw PCM data in a canonical WAV (RIFF) container.
 // Format: 16-bit LINEAR PCM at the configured sample rate and channel count.
 func encodeWAV(pcmData []byte) ([]byte, error) {
+	return encodeWAVChannels(pcmData, audioConfig.Channels)
+}
+
+// encodeWAVChannels is encodeWAV for interleaved PCM with the given number
+// of channels.
+func encodeWAVChannels(pcmData []byte, channels uint32) ([]byte, error) {
 	sampleRate := audioConfig.SampleRate
-	channels := audioConfig.Channels
 	blockAlign := uint16(channels) * uint16(AudioBytesPerSample)
 	byteRate := uint32(sampleRate) * uint32(blockAlign)
 
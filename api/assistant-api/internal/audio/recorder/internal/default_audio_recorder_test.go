@@ -551,6 +551,27 @@ func TestPushCopiesData(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Offset
+// ---------------------------------------------------------------------------
+
+func TestOffset(t *testing.T) {
+	rec, clk := newTestRecorderWithClock(t)
+	if got := rec.Offset(clk.Now()); got != 0 {
+		t.Errorf("offset before start = %v, want 0", got)
+	}
+	before := clk.Now()
+	clk.Advance(time.Second)
+	rec.Start()
+	clk.Advance(2500 * time.Millisecond)
+	if got := rec.Offset(clk.Now()); got != 2500*time.Millisecond {
+		t.Errorf("offset = %v, want 2.5s", got)
+	}
+	if got := rec.Offset(before); got != 0 {
+		t.Errorf("offset of a time before start = %v, want 0", got)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Persist
 // ---------------------------------------------------------------------------
@@ -742,3 +763,41 @@ func TestPersistOnlySystemAudio(t *testing.T) {
 		t.Error("system track layout wrong")
 	}
 }
+
+func TestStereoInterleavesTracks(t *testing.T) {
+	rec, fc := newTestRecorderWithClock(t)
+	rec.Start()
+	ctx := context.Background()
+	rec.Record(ctx, internal_type.UserAudioPacket{Audio: pcm(0x01, 3200)})
+	rec.Record(ctx, internal_type.TextToSpeechAudioPacket{ContextID: "c1", AudioChunk: pcm(0x02, 3200)})
+	fc.Advance(100 * time.Millisecond)
+
+	userWAV, systemWAV, err := rec.Persist()
+	if err != nil {
+		t.Fatalf("Persist error: %v", err)
+	}
+	stereo, err := Stereo(userWAV, systemWAV)
+	if err != nil {
+		t.Fatalf("Stereo error: %v", err)
+	}
+	if ch := binary.LittleEndian.Uint16(stereo[22:24]); ch != 2 {
+		t.Fatalf("channels: got %d", ch)
+	}
+	if align := binary.LittleEndian.Uint16(stereo[32:34]); align != 2*AudioBytesPerSample {
+		t.Errorf("block align: got %d", align)
+	}
+	data := wavPCMData(stereo)
+	if len(data) != 2*len(wavPCMData(userWAV)) {
+		t.Fatalf("expected %d PCM bytes, got %d", 2*len(wavPCMData(userWAV)), len(data))
+	}
+	// First frame: two user bytes then two system bytes.
+	if data[0] != 0x01 || data[1] != 0x01 || data[2] != 0x02 || data[3] != 0x02 {
+		t.Errorf("first frame not interleaved: % x", data[:4])
+	}
+}
+
+func TestStereoRejectsRawPCM(t *testing.T) {
+	if _, err := Stereo(pcm(0x01, 10), pcm(0x02, 10)); err == nil {
+		t.Fatal("expected error for input without WAV header")
+	}
+}
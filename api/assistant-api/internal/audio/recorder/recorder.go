@@ -15,3 +15,9 @@ import (
 func GetRecorder(logger commons.Logger) (internal_type.Recorder, error) {
 	return internal_recorder.NewDefaultAudioRecorder(logger)
 }
+
+// Stereo merges the user and system WAVs of a recorder into one dual-channel
+// WAV, caller left and assistant right.
+func Stereo(userWAV, systemWAV []byte) ([]byte, error) {
+	return internal_recorder.Stereo(userWAV, systemWAV)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_audit describes the control-plane actions that are written
+// to the control audit log: who changed or accessed what, why, and the state
+// of the resource before and after.
+package internal_audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/rapidaai/pkg/types"
+	"google.golang.org/grpc/metadata"
+)
+
+// Actions
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionAccess = "access"
+	ActionExport = "export"
+)
+
+// Resources
+const (
+	ResourceAssistant              = "assistant"
+	ResourceAssistantProvider      = "assistant_provider"
+	ResourceAssistantVersion       = "assistant_version"
+	ResourceAssistantTag           = "assistant_tag"
+	ResourceAssistantTool          = "assistant_tool"
+	ResourceAssistantWebhook       = "assistant_webhook"
+	ResourceAssistantAnalysis      = "assistant_analysis"
+	ResourceAssistantKnowledge     = "assistant_knowledge"
+	ResourceAssistantDeployment    = "assistant_deployment"
+	ResourceRetentionPolicy        = "retention_policy"
+	ResourceRecordingSetting       = "recording_setting"
+	ResourceTranscriptSetting      = "transcript_setting"
+	ResourceConversationRecording  = "conversation_recording"
+	ResourceConversationTranscript = "conversation_transcript"
+	ResourceConversationSnapshot   = "conversation_snapshot"
+	ResourceConversationEventLog   = "conversation_event_log"
+	ResourceCredential             = "credential"
+)
+
+// Redacted replaces the values of secret fields in snapshots.
+const Redacted = "[REDACTED]"
+
+// secretSuffixes are matched against the end of field names in lower case,
+// without separators, so apiKey and client_secret are redacted but maxTokens
+// and credentialId are not.
+var secretSuffixes = []string{"password", "secret", "token", "apikey", "privatekey", "certificate"}
+
+// Entry is one action to record. Before is nil for creates and accesses,
+// After is nil for deletes.
+type Entry struct {
+	Action       string
+	ResourceType string
+	ResourceId   uint64
+	Reason       string
+	Before       interface{}
+	After        interface{}
+}
+
+// Change is the value of one field before and after an action.
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Reason returns the reason the caller gave in the x-audit-reason header,
+// e.g. the ticket a configuration change was made for.
+func Reason(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, types.AUDIT_REASON_KEY); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// Snapshot returns the JSON form of v as a map with the values of secret
+// fields redacted. It returns nil for nil.
+func Snapshot(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	redact(out)
+	return out, nil
+}
+
+// redact also covers options stored as {"key": "api_key", "value": "..."}.
+func redact(m map[string]interface{}) {
+	if key, ok := m["key"].(string); ok && isSecret(key) {
+		if _, ok := m["value"]; ok {
+			m["value"] = Redacted
+		}
+	}
+	for k, v := range m {
+		if isSecret(k) {
+			m[k] = Redacted
+			continue
+		}
+		switch nested := v.(type) {
+		case map[string]interface{}:
+			redact(nested)
+		case []interface{}:
+			for _, item := range nested {
+				if im, ok := item.(map[string]interface{}); ok {
+					redact(im)
+				}
+			}
+		}
+	}
+}
+
+func isSecret(field string) bool {
+	name := strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "").Replace(field))
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the fields that differ between two snapshots, keyed by their
+// dotted path. Lists are compared as a whole.
+func Diff(before, after map[string]interface{}) map[string]Change {
+	changes := make(map[string]Change)
+	diff("", before, after, changes)
+	return changes
+}
+
+func diff(prefix string, before, after map[string]interface{}, changes map[string]Change) {
+	for k, b := range before {
+		compare(prefix+k, b, after[k], changes)
+	}
+	for k, a := range after {
+		if _, ok := before[k]; !ok {
+			compare(prefix+k, nil, a, changes)
+		}
+	}
+}
+
+func compare(path string, before, after interface{}, changes map[string]Change) {
+	bm, bok := before.(map[string]interface{})
+	am, aok := after.(map[string]interface{})
+	if bok && aok {
+		diff(path+".", bm, am, changes)
+		return
+	}
+	if !reflect.DeepEqual(before, after) {
+		changes[path] = Change{Before: before, After: after}
+	}
+}
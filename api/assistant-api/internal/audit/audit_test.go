@@ -0,0 +1,82 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rapidaai/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+type webhook struct {
+	Id        uint64              `json:"id"`
+	Url       string              `json:"url"`
+	Headers   map[string]string   `json:"headers"`
+	ApiKey    string              `json:"apiKey"`
+	MaxTokens int                 `json:"maxTokens"`
+	Options   []map[string]string `json:"options"`
+}
+
+func TestSnapshot_RedactsSecrets(t *testing.T) {
+	snapshot, err := Snapshot(&webhook{
+		Id:        7,
+		Url:       "https://example.com/hook",
+		Headers:   map[string]string{"X-Auth-Token": "t0k3n", "Accept": "json"},
+		ApiKey:    "sk-live",
+		MaxTokens: 512,
+		Options:   []map[string]string{{"key": "client_secret", "value": "s3cr3t"}, {"key": "region", "value": "eu"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Redacted, snapshot["apiKey"])
+	assert.Equal(t, float64(512), snapshot["maxTokens"])
+	headers := snapshot["headers"].(map[string]interface{})
+	assert.Equal(t, Redacted, headers["X-Auth-Token"])
+	assert.Equal(t, "json", headers["Accept"])
+	options := snapshot["options"].([]interface{})
+	assert.Equal(t, Redacted, options[0].(map[string]interface{})["value"])
+	assert.Equal(t, "eu", options[1].(map[string]interface{})["value"])
+
+	var missing *webhook
+	snapshot, err = Snapshot(missing)
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string]interface{}{
+		"name":    "support",
+		"options": map[string]interface{}{"temperature": 0.2, "model": "gpt-4o"},
+		"tags":    []interface{}{"a"},
+		"removed": true,
+	}
+	after := map[string]interface{}{
+		"name":    "support",
+		"options": map[string]interface{}{"temperature": 0.7, "model": "gpt-4o"},
+		"tags":    []interface{}{"a", "b"},
+		"added":   "x",
+	}
+
+	assert.Equal(t, map[string]Change{
+		"options.temperature": {Before: 0.2, After: 0.7},
+		"tags":                {Before: []interface{}{"a"}, After: []interface{}{"a", "b"}},
+		"removed":             {Before: true, After: nil},
+		"added":               {Before: nil, After: "x"},
+	}, Diff(before, after))
+
+	assert.Equal(t, map[string]Change{"name": {Before: nil, After: "support"}},
+		Diff(nil, map[string]interface{}{"name": "support"}))
+}
+
+func TestReason(t *testing.T) {
+	assert.Empty(t, Reason(context.Background()))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(types.AUDIT_REASON_KEY, " INC-1042 rollback "))
+	assert.Equal(t, "INC-1042 rollback", Reason(ctx))
+}
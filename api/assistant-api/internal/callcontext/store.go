@@ -8,13 +8,17 @@ package internal_callcontext
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
+	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
 )
 
 // Store provides operations to save and retrieve call contexts from Postgres.
@@ -58,6 +62,9 @@ type Store interface {
 	// UpdateField sets a single column on an existing call context.
 	// Used to patch the channel UUID after the telephony provider returns it.
 	UpdateField(ctx context.Context, contextID, field, value string) error
+
+	// SaveScratchpad replaces the scratchpad of a call context.
+	SaveScratchpad(ctx context.Context, contextID string, scratchpad map[string]interface{}) error
 }
 
 type postgresStore struct {
@@ -98,10 +105,17 @@ func (s *postgresStore) Save(ctx context.Context, cc *CallContext) (string, erro
 // This deliberately reads any status (pending, queued, claimed, completed, failed)
 // because upstream telephony providers fire event webhooks asynchronously — a
 // "completed" callback from Twilio can arrive well after the media stream ends.
+//
+// Callbacks are the bulk of the traffic, so the read goes to a replica. A
+// context saved moments ago may not have replicated yet; a miss is retried
+// on the primary before it is reported.
 func (s *postgresStore) Get(ctx context.Context, contextID string) (*CallContext, error) {
-	db := s.postgres.DB(ctx)
 	var cc CallContext
-	if err := db.Where("context_id = ?", contextID).First(&cc).Error; err != nil {
+	err := s.postgres.ReadDB(ctx).Where("context_id = ?", contextID).First(&cc).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = s.postgres.DB(ctx).Where("context_id = ?", contextID).First(&cc).Error
+	}
+	if err != nil {
 		return nil, fmt.Errorf("call context not found: %s: %w", contextID, err)
 	}
 
@@ -112,14 +126,18 @@ func (s *postgresStore) Get(ctx context.Context, contextID string) (*CallContext
 }
 
 // Claim atomically transitions a call context from "pending" or "queued" to "claimed"
-// using an atomic UPDATE ... WHERE status IN ('pending','queued'). Only one concurrent
-// caller can win. The context remains in the database so event callbacks can still read it.
+// using an atomic UPDATE ... WHERE status IN ('pending','queued') RETURNING *. Only one
+// concurrent caller can win, and the claimed row comes back in the same round trip.
+// The context remains in the database so event callbacks can still read it.
 // Both "pending" (inbound) and "queued" (outbound) are valid pre-claim states.
+// Claims always run on the primary.
 func (s *postgresStore) Claim(ctx context.Context, contextID string) (*CallContext, error) {
 	db := s.postgres.DB(ctx)
 
 	// Atomic update: only succeeds if status is still "pending" or "queued"
-	result := db.Model(&CallContext{}).
+	var cc CallContext
+	result := db.Model(&cc).
+		Clauses(clause.Returning{}).
 		Where("context_id = ? AND status IN ?", contextID, []string{StatusPending, StatusQueued}).
 		Updates(map[string]interface{}{
 			"status":       StatusClaimed,
@@ -133,12 +151,6 @@ func (s *postgresStore) Claim(ctx context.Context, contextID string) (*CallConte
 		return nil, fmt.Errorf("call context %s not found or already claimed", contextID)
 	}
 
-	// Fetch the full row after claiming
-	var cc CallContext
-	if err := db.Where("context_id = ?", contextID).First(&cc).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch claimed call context %s: %w", contextID, err)
-	}
-
 	s.logger.Debugf("claimed call context: contextId=%s, assistant=%d, conversation=%d",
 		cc.ContextID, cc.AssistantID, cc.ConversationID)
 
@@ -183,6 +195,7 @@ func (s *postgresStore) UpdateField(ctx context.Context, contextID, field, value
 		"channel_uuid": true,
 		"status":       true,
 		"provider":     true,
+		"outcome":      true,
 	}
 	if !allowed[field] {
 		return fmt.Errorf("field %q is not updatable on call context", field)
@@ -199,3 +212,21 @@ func (s *postgresStore) UpdateField(ctx context.Context, contextID, field, value
 	s.logger.Debugf("updated call context field: contextId=%s, %s=%s", contextID, field, value)
 	return nil
 }
+
+// SaveScratchpad replaces the scratchpad column of a call context row.
+func (s *postgresStore) SaveScratchpad(ctx context.Context, contextID string, scratchpad map[string]interface{}) error {
+	db := s.postgres.DB(ctx)
+	result := db.Model(&CallContext{}).
+		Where("context_id = ?", contextID).
+		Updates(map[string]interface{}{
+			"scratchpad":   gorm_types.InterfaceMap(scratchpad),
+			"updated_date": time.Now(),
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to save scratchpad of call context %s: %w", contextID, result.Error)
+	}
+
+	s.logger.Debugf("saved call context scratchpad: contextId=%s, keys=%d", contextID, len(scratchpad))
+	return nil
+}
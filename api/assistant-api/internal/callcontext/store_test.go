@@ -0,0 +1,189 @@
+//go:build cgo
+
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_callcontext
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/rapidaai/pkg/commons"
+)
+
+const callContextTable = `CREATE TABLE IF NOT EXISTS call_contexts (
+	id INTEGER PRIMARY KEY,
+	context_id TEXT NOT NULL UNIQUE,
+	status TEXT NOT NULL DEFAULT 'pending',
+	assistant_id INTEGER NOT NULL,
+	conversation_id INTEGER NOT NULL,
+	project_id INTEGER NOT NULL DEFAULT 0,
+	organization_id INTEGER NOT NULL DEFAULT 0,
+	auth_token TEXT NOT NULL DEFAULT '',
+	auth_type TEXT NOT NULL DEFAULT '',
+	provider TEXT NOT NULL DEFAULT '',
+	direction TEXT NOT NULL DEFAULT '',
+	caller_number TEXT NOT NULL DEFAULT '',
+	callee_number TEXT NOT NULL DEFAULT '',
+	from_number TEXT NOT NULL DEFAULT '',
+	created_date TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_date TIMESTAMP,
+	assistant_provider_id INTEGER NOT NULL DEFAULT 0,
+	channel_uuid TEXT NOT NULL DEFAULT '',
+	scratchpad TEXT NOT NULL DEFAULT '{}',
+	media TEXT NOT NULL DEFAULT '{}',
+	stream_mode TEXT NOT NULL DEFAULT '',
+	outcome TEXT NOT NULL DEFAULT ''
+)`
+
+// sqliteConnector serves the store from sqlite databases, with reads on
+// replica when it is set.
+type sqliteConnector struct {
+	primary *gorm.DB
+	replica *gorm.DB
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) error    { return nil }
+func (c *sqliteConnector) Name() string                         { return "sqlite" }
+func (c *sqliteConnector) IsConnected(ctx context.Context) bool { return true }
+func (c *sqliteConnector) Disconnect(ctx context.Context) error { return nil }
+func (c *sqliteConnector) DB(ctx context.Context) *gorm.DB      { return c.primary.WithContext(ctx) }
+func (c *sqliteConnector) Query(ctx context.Context, qry string, dest interface{}) error {
+	return c.primary.Raw(qry).Scan(dest).Error
+}
+
+func (c *sqliteConnector) ReadDB(ctx context.Context) *gorm.DB {
+	if c.replica == nil {
+		return c.DB(ctx)
+	}
+	return c.replica.WithContext(ctx)
+}
+
+// openSQLite opens a WAL mode database file with a pool of conns
+// connections, creating the call context table.
+func openSQLite(tb testing.TB, path string, conns int, prepare bool) *gorm.DB {
+	tb.Helper()
+	db, err := gorm.Open(sqlite.Open(path+"?_journal_mode=WAL&_busy_timeout=5000"), &gorm.Config{
+		Logger:      logger.Discard,
+		PrepareStmt: prepare,
+	})
+	require.NoError(tb, err)
+	require.NoError(tb, db.Exec(callContextTable).Error)
+	sqlDB, err := db.DB()
+	require.NoError(tb, err)
+	sqlDB.SetMaxOpenConns(conns)
+	tb.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func newTestStore(conn *sqliteConnector) Store {
+	logger, _ := commons.NewApplicationLogger()
+	return NewStore(conn, logger)
+}
+
+func TestStore_GetFallsBackToPrimary(t *testing.T) {
+	dir := t.TempDir()
+	// the replica is a separate, empty database: nothing has replicated yet
+	conn := &sqliteConnector{
+		primary: openSQLite(t, filepath.Join(dir, "primary.db"), 1, false),
+		replica: openSQLite(t, filepath.Join(dir, "replica.db"), 1, false),
+	}
+	store := newTestStore(conn)
+	ctx := context.Background()
+
+	contextID, err := store.Save(ctx, &CallContext{AssistantID: 1, ConversationID: 2, Direction: "inbound"})
+	require.NoError(t, err)
+
+	cc, err := store.Get(ctx, contextID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), cc.ConversationID)
+
+	_, err = store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestStore_ClaimReturnsRowOnce(t *testing.T) {
+	conn := &sqliteConnector{primary: openSQLite(t, filepath.Join(t.TempDir(), "primary.db"), 1, false)}
+	store := newTestStore(conn)
+	ctx := context.Background()
+
+	contextID, err := store.Save(ctx, &CallContext{AssistantID: 1, ConversationID: 2, Provider: "twilio"})
+	require.NoError(t, err)
+
+	cc, err := store.Claim(ctx, contextID)
+	require.NoError(t, err)
+	assert.Equal(t, contextID, cc.ContextID)
+	assert.Equal(t, StatusClaimed, cc.Status)
+	assert.Equal(t, "twilio", cc.Provider)
+
+	_, err = store.Claim(ctx, contextID)
+	assert.ErrorContains(t, err, "already claimed")
+}
+
+// BenchmarkStore_Callbacks replays webhook traffic: mostly status callbacks
+// reading a context, with a new call saved and claimed every tenth request.
+// The primary has a single connection, as a saturated pool does; routing the
+// reads to a replica frees it for writes.
+func BenchmarkStore_Callbacks(b *testing.B) {
+	for _, bc := range []struct {
+		name    string
+		replica bool
+		prepare bool
+	}{
+		{"primary", false, false},
+		{"primary_prepared", false, true},
+		{"replica", true, false},
+		{"replica_prepared", true, true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			path := filepath.Join(b.TempDir(), "calls.db")
+			conn := &sqliteConnector{primary: openSQLite(b, path, 1, bc.prepare)}
+			if bc.replica {
+				conn.replica = openSQLite(b, path, 8, bc.prepare)
+			}
+			store := newTestStore(conn)
+			ctx := context.Background()
+
+			ids := make([]string, 256)
+			for i := range ids {
+				id, err := store.Save(ctx, &CallContext{AssistantID: 1, ConversationID: uint64(i)})
+				require.NoError(b, err)
+				ids[i] = id
+			}
+
+			var seq atomic.Uint64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					n := seq.Add(1)
+					if n%10 == 0 {
+						id, err := store.Save(ctx, &CallContext{AssistantID: 1, ConversationID: n})
+						if err == nil {
+							_, err = store.Claim(ctx, id)
+						}
+						if err != nil {
+							b.Error(err)
+						}
+						continue
+					}
+					if _, err := store.Get(ctx, ids[n%uint64(len(ids))]); err != nil {
+						b.Error(fmt.Errorf("callback %d: %w", n, err))
+					}
+				}
+			})
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	gorm_generator "github.com/rapidaai/pkg/models/gorm/generators"
+	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
 	"github.com/rapidaai/pkg/types"
 	"github.com/rapidaai/pkg/utils"
 	"gorm.io/gorm"
@@ -24,6 +25,18 @@ const (
 	StatusFailed    = "failed"    // Call setup or execution failed
 )
 
+// Stream modes of a call. Text is used when the provider transcribes and
+// speaks the call itself, the assistant then only exchanges text.
+const (
+	StreamModeAudio = "audio"
+	StreamModeText  = "text"
+)
+
+// Call outcomes, see CallContext.Outcome.
+const (
+	OutcomeVoicemailDropped = "voicemail_dropped" // message was left on an answering machine
+)
+
 // CallContext holds all the information needed to resolve a call session.
 // It bridges the gap between the HTTP call-setup request (inbound webhook or outbound gRPC)
 // and the AudioSocket/WebSocket connection that follows.
@@ -56,6 +69,23 @@ type CallContext struct {
 	// Asterisk channel ID, SIP Call-ID, etc.). Stored so that any telephony operation
 	// (transfer, disconnect, hold) can reference the live call on the provider.
 	ChannelUUID string `json:"channelUuid" gorm:"column:channel_uuid;type:varchar(200);not null;default:''"`
+
+	// Scratchpad is the structured key/value state tools and the LLM share
+	// during the call. Persisted on every write so it survives reconnects.
+	Scratchpad gorm_types.InterfaceMap `json:"scratchpad" gorm:"column:scratchpad;type:jsonb;not null;default:'{}'"`
+
+	// Media locates call audio the platform reads from the provider instead
+	// of having it streamed in, e.g. the Kinesis Video stream of an Amazon
+	// Connect contact.
+	Media gorm_types.InterfaceMap `json:"media" gorm:"column:media;type:jsonb;not null;default:'{}'"`
+
+	// StreamMode is StreamModeText for calls the provider handles speech
+	// for, e.g. Twilio ConversationRelay. Empty means audio.
+	StreamMode string `json:"streamMode" gorm:"column:stream_mode;type:varchar(20);not null;default:''"`
+
+	// Outcome records how the call ended when it did not reach a
+	// conversation, e.g. OutcomeVoicemailDropped. Empty otherwise.
+	Outcome string `json:"outcome" gorm:"column:outcome;type:varchar(30);not null;default:''"`
 }
 
 func (CallContext) TableName() string {
@@ -91,6 +121,12 @@ func (cc *CallContext) IsPending() bool {
 	return cc.Status == StatusPending
 }
 
+// IsTextStream returns true if the provider exchanges text instead of audio
+// with the assistant.
+func (cc *CallContext) IsTextStream() bool {
+	return cc.StreamMode == StreamModeText
+}
+
 // IsClaimed returns true if the context has been claimed by a media connection.
 func (cc *CallContext) IsClaimed() bool {
 	return cc.Status == StatusClaimed
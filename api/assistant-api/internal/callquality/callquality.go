@@ -0,0 +1,258 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_callquality estimates the MOS of a call from the media
+// statistics of its channel (packet loss, jitter and round trip time from RTP
+// and RTCP, or the WebRTC stats) with a simplified ITU-T G.107 E-model.
+package internal_callquality
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+const (
+	// SampleInterval is how often a call is sampled while it runs.
+	SampleInterval = 30 * time.Second
+
+	// E-model parameters. The codec impairment is that of G.711 with packet
+	// loss concealment (G.113 appendix I); wideband codecs do at least as
+	// well, so the estimate is conservative for them.
+	defaultR         = 93.2
+	codecImpairment  = 0
+	lossRobustness   = 25.1
+	packetizationLag = 20 * time.Millisecond
+
+	// PoorMOS is the score under which a leg is recorded as poor, R 70 in
+	// G.107 terms where some users are dissatisfied.
+	PoorMOS = 3.6
+)
+
+// Legs of a call, scored apart.
+const (
+	// LegCaller is the leg the channel was opened for.
+	LegCaller = "caller"
+	// LegAgent is the leg of a human agent the caller was bridged to by a
+	// warm transfer or a pickup.
+	LegAgent = "agent"
+)
+
+// Source is implemented by streamers that can report media statistics.
+type Source interface {
+	// CallQuality returns the current statistics, false while no media
+	// flows.
+	CallQuality() (Sample, bool)
+}
+
+// LegSource is implemented by streamers that bridge the caller to other legs.
+type LegSource interface {
+	// CallQualityLegs returns the statistics of the legs besides the
+	// caller's that carried media, by leg name.
+	CallQualityLegs() map[string]Sample
+}
+
+// Sample is a snapshot of the media statistics of a call. Packet counters are
+// cumulative since the start of the call.
+type Sample struct {
+	PacketsReceived uint64
+	PacketsLost     int64
+	Jitter          time.Duration
+	RoundTripTime   time.Duration
+
+	// loss and jitter the remote party reports about the audio we send,
+	// zero when the channel has no such reports
+	RemoteFractionLost float64
+	RemoteJitter       time.Duration
+}
+
+// Report is the call quality so far.
+type Report struct {
+	MOS        float64 // of the latest interval
+	AverageMOS float64
+	MinMOS     float64
+
+	PacketLoss    float64 // fraction lost in the latest interval
+	Jitter        time.Duration
+	RoundTripTime time.Duration
+
+	Samples int
+}
+
+// MOS estimates the mean opinion score (1 to 4.5) for a packet loss
+// fraction, jitter and round trip time.
+func MOS(loss float64, jitter, roundTripTime time.Duration) float64 {
+	// mouth to ear delay: half the round trip, a jitter buffer of twice the
+	// jitter and the packetization delay
+	delay := float64(roundTripTime/2+2*jitter+packetizationLag) / float64(time.Millisecond)
+	delayImpairment := 0.024 * delay
+	if delay > 177.3 {
+		delayImpairment += 0.11 * (delay - 177.3)
+	}
+
+	lossPercent := math.Min(math.Max(loss, 0), 1) * 100
+	lossImpairment := codecImpairment + (95-codecImpairment)*lossPercent/(lossPercent+lossRobustness)
+
+	r := defaultR - delayImpairment - lossImpairment
+	switch {
+	case r <= 0:
+		return 1
+	case r >= 100:
+		return 4.5
+	}
+	return 1 + 0.035*r + r*(r-60)*(100-r)*7e-6
+}
+
+// Tracker scores the samples of one call.
+type Tracker struct {
+	mu       sync.Mutex
+	previous Sample
+	report   Report
+	mosSum   float64
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Add scores the interval since the previous sample. It returns false when
+// no packets arrived in the interval, such intervals are not scored.
+func (t *Tracker) Add(sample Sample) (Report, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	received := float64(sample.PacketsReceived) - float64(t.previous.PacketsReceived)
+	lost := math.Max(float64(sample.PacketsLost-t.previous.PacketsLost), 0)
+	t.previous = sample
+	if received <= 0 {
+		return t.report, false
+	}
+
+	loss := math.Max(lost/(lost+received), sample.RemoteFractionLost)
+	jitter := max(sample.Jitter, sample.RemoteJitter)
+	mos := MOS(loss, jitter, sample.RoundTripTime)
+
+	t.mosSum += mos
+	t.report.Samples++
+	if t.report.Samples == 1 || mos < t.report.MinMOS {
+		t.report.MinMOS = mos
+	}
+	t.report.MOS = mos
+	t.report.AverageMOS = t.mosSum / float64(t.report.Samples)
+	t.report.PacketLoss = loss
+	t.report.Jitter = jitter
+	t.report.RoundTripTime = sample.RoundTripTime
+	return t.report, true
+}
+
+// Report returns the call quality so far.
+func (t *Tracker) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.report
+}
+
+// Legs scores every leg of one call with a Tracker of its own.
+type Legs struct {
+	mu       sync.Mutex
+	trackers map[string]*Tracker
+}
+
+func NewLegs() *Legs {
+	return &Legs{trackers: make(map[string]*Tracker)}
+}
+
+// Add scores the interval of leg since its previous sample, see Tracker.Add.
+func (l *Legs) Add(leg string, sample Sample) (Report, bool) {
+	l.mu.Lock()
+	tracker, ok := l.trackers[leg]
+	if !ok {
+		tracker = NewTracker()
+		l.trackers[leg] = tracker
+	}
+	l.mu.Unlock()
+	return tracker.Add(sample)
+}
+
+// Reports returns the call quality of the legs scored at least once.
+func (l *Legs) Reports() map[string]Report {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	reports := make(map[string]Report, len(l.trackers))
+	for leg, tracker := range l.trackers {
+		if report := tracker.Report(); report.Samples > 0 {
+			reports[leg] = report
+		}
+	}
+	return reports
+}
+
+// LegMetrics returns the report as metrics of leg. The caller's leg keeps
+// the names of Metrics, other legs have theirs in the name
+// (call_quality_agent_mos).
+func (r Report) LegMetrics(leg string) []*protos.Metric {
+	metrics := r.Metrics()
+	if leg == LegCaller {
+		return metrics
+	}
+	for _, metric := range metrics {
+		metric.Name = "call_quality_" + leg + "_" + strings.TrimPrefix(metric.Name, "call_quality_")
+		metric.Description += ", " + leg + " leg"
+	}
+	return metrics
+}
+
+// Metadata returns the final call quality of leg as conversation metadata,
+// the record of the call operators filter by (call_quality.agent.poor).
+func (r Report) Metadata(leg string) []*protos.Metadata {
+	prefix := "call_quality." + leg + "."
+	return []*protos.Metadata{
+		{Key: prefix + "mos_average", Value: strconv.FormatFloat(r.AverageMOS, 'f', 2, 64)},
+		{Key: prefix + "mos_min", Value: strconv.FormatFloat(r.MinMOS, 'f', 2, 64)},
+		{Key: prefix + "poor", Value: strconv.FormatBool(r.AverageMOS < PoorMOS)},
+	}
+}
+
+// Metrics returns the report as conversation metrics. They are stored by
+// name, the last report of a call is the one that remains.
+func (r Report) Metrics() []*protos.Metric {
+	return []*protos.Metric{
+		{
+			Name:        "call_quality_mos",
+			Value:       strconv.FormatFloat(r.MOS, 'f', 2, 64),
+			Description: "Estimated MOS of the latest sampled interval of the call",
+		},
+		{
+			Name:        "call_quality_mos_average",
+			Value:       strconv.FormatFloat(r.AverageMOS, 'f', 2, 64),
+			Description: "Estimated MOS averaged over the call",
+		},
+		{
+			Name:        "call_quality_mos_min",
+			Value:       strconv.FormatFloat(r.MinMOS, 'f', 2, 64),
+			Description: "Lowest estimated MOS of any sampled interval of the call",
+		},
+		{
+			Name:        "call_quality_packet_loss",
+			Value:       strconv.FormatFloat(r.PacketLoss, 'f', 4, 64),
+			Description: "Fraction of audio packets lost in the latest sampled interval",
+		},
+		{
+			Name:        "call_quality_jitter_ms",
+			Value:       strconv.FormatFloat(float64(r.Jitter)/float64(time.Millisecond), 'f', 2, 64),
+			Description: "Interarrival jitter of the audio in the latest sampled interval",
+		},
+		{
+			Name:        "call_quality_rtt_ms",
+			Value:       strconv.FormatInt(r.RoundTripTime.Milliseconds(), 10),
+			Description: "Round trip time of the media path, zero when the channel does not measure it",
+		},
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_callquality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMOS(t *testing.T) {
+	perfect := MOS(0, 0, 0)
+	assert.InDelta(t, 4.40, perfect, 0.01, "G.711 on a clean network")
+	assert.InDelta(t, 3.90, MOS(0.05, 0, 0), 0.01)
+	assert.InDelta(t, 3.59, MOS(0, 0, 600*time.Millisecond), 0.01)
+
+	assert.Less(t, MOS(0, 40*time.Millisecond, 0), perfect, "jitter adds buffering delay")
+	assert.Less(t, MOS(0.2, 0, 0), MOS(0.05, 0, 0))
+	assert.GreaterOrEqual(t, MOS(1, 0, 5*time.Second), 1.0)
+}
+
+func TestTracker_Intervals(t *testing.T) {
+	tracker := NewTracker()
+
+	report, ok := tracker.Add(Sample{PacketsReceived: 100})
+	require.True(t, ok)
+	assert.Equal(t, 0.0, report.PacketLoss)
+	assert.InDelta(t, 4.40, report.MOS, 0.01)
+
+	// 90 packets arrived and 10 were lost since the previous sample
+	report, ok = tracker.Add(Sample{PacketsReceived: 190, PacketsLost: 10, RoundTripTime: 80 * time.Millisecond})
+	require.True(t, ok)
+	assert.InDelta(t, 0.1, report.PacketLoss, 1e-9)
+	assert.Equal(t, 2, report.Samples)
+	assert.Equal(t, report.MOS, report.MinMOS)
+	assert.InDelta(t, (4.40+report.MOS)/2, report.AverageMOS, 0.01)
+
+	_, ok = tracker.Add(Sample{PacketsReceived: 190, PacketsLost: 10})
+	assert.False(t, ok, "an interval without media is not scored")
+	assert.Equal(t, report, tracker.Report())
+}
+
+func TestTracker_RemoteReports(t *testing.T) {
+	tracker := NewTracker()
+	report, ok := tracker.Add(Sample{PacketsReceived: 50, Jitter: 5 * time.Millisecond, RemoteFractionLost: 0.25, RemoteJitter: 30 * time.Millisecond})
+	require.True(t, ok)
+	assert.Equal(t, 0.25, report.PacketLoss, "the worse direction counts")
+	assert.Equal(t, 30*time.Millisecond, report.Jitter)
+}
+
+func TestReport_Metrics(t *testing.T) {
+	metrics := Report{MOS: 4.1234, AverageMOS: 4, MinMOS: 3.5, PacketLoss: 0.01, Jitter: 12500 * time.Microsecond, RoundTripTime: 95 * time.Millisecond}.Metrics()
+	values := make(map[string]string)
+	for _, m := range metrics {
+		values[m.GetName()] = m.GetValue()
+	}
+	assert.Equal(t, map[string]string{
+		"call_quality_mos":         "4.12",
+		"call_quality_mos_average": "4.00",
+		"call_quality_mos_min":     "3.50",
+		"call_quality_packet_loss": "0.0100",
+		"call_quality_jitter_ms":   "12.50",
+		"call_quality_rtt_ms":      "95",
+	}, values)
+}
+
+func TestLegs(t *testing.T) {
+	legs := NewLegs()
+	_, ok := legs.Add(LegCaller, Sample{PacketsReceived: 100})
+	require.True(t, ok)
+	_, ok = legs.Add(LegAgent, Sample{})
+	require.False(t, ok)
+	assert.NotContains(t, legs.Reports(), LegAgent, "a leg without media has no report")
+
+	report, ok := legs.Add(LegAgent, Sample{PacketsReceived: 70, PacketsLost: 30})
+	require.True(t, ok)
+	reports := legs.Reports()
+	assert.Equal(t, report, reports[LegAgent])
+	assert.Greater(t, reports[LegCaller].AverageMOS, reports[LegAgent].AverageMOS, "legs are scored apart")
+}
+
+func TestReport_LegMetricsAndMetadata(t *testing.T) {
+	report := Report{MOS: 2.9, AverageMOS: 3.1, MinMOS: 2.9}
+	assert.Equal(t, "call_quality_mos", report.LegMetrics(LegCaller)[0].GetName())
+	assert.Equal(t, "call_quality_agent_mos", report.LegMetrics(LegAgent)[0].GetName())
+	assert.Equal(t, "call_quality_mos", report.Metrics()[0].GetName(), "leg metrics are built afresh")
+
+	values := make(map[string]string)
+	for _, m := range report.Metadata(LegAgent) {
+		values[m.GetKey()] = m.GetValue()
+	}
+	assert.Equal(t, map[string]string{
+		"call_quality.agent.mos_average": "3.10",
+		"call_quality.agent.mos_min":     "2.90",
+		"call_quality.agent.poor":        "true",
+	}, values)
+}
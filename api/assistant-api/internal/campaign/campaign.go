@@ -0,0 +1,200 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_campaign holds the rules outbound campaigns are scheduled
+// by: when a campaign may call, what became of a call placed to a contact and
+// when the contact is tried again.
+package internal_campaign
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+)
+
+// Campaign statuses. A campaign is created as a draft, calls while running
+// and is completed by the scheduler once every contact is done.
+const (
+	StatusDraft     = "draft"
+	StatusRunning   = "running"
+	StatusPaused    = "paused"
+	StatusCompleted = "completed"
+)
+
+// Contact statuses. A dialing contact has a call placed and not yet
+// finished; it is back to pending when it is retried.
+const (
+	ContactPending   = "pending"
+	ContactDialing   = "dialing"
+	ContactCompleted = "completed"
+	ContactFailed    = "failed"
+)
+
+// Outcomes of the last call to a contact, besides the call context outcomes
+// such as callcontext.OutcomeVoicemailDropped.
+const (
+	OutcomeAnswered = "answered"
+	OutcomeNoAnswer = "no_answer"
+	OutcomeFailed   = "failed"
+)
+
+// Limits and defaults of the campaign settings.
+const (
+	DefaultMaxConcurrentCalls = 1
+	MaxConcurrentCalls        = 100
+	DefaultMaxAttempts        = 1
+	MaxAttempts               = 10
+	DefaultRetryDelay         = 15 * time.Minute
+	MinRetryDelay             = time.Minute
+	MaxContacts               = 10000
+)
+
+// StaleCallAfter is how long a connected call may run before the scheduler
+// stops waiting for it to complete. A call context stays claimed when its
+// session died without completing it, which would hold a call slot forever.
+const StaleCallAfter = 4 * time.Hour
+
+// CallingWindow is the local time of day a campaign places calls in. End
+// before Start spans midnight, a window without bounds is always open.
+type CallingWindow struct {
+	Location *time.Location
+	Start    int // minutes after midnight
+	End      int
+	Always   bool
+}
+
+// ParseCallingWindow builds the window from an IANA timezone, UTC when
+// empty, and HH:MM bounds, which are both set or both empty.
+func ParseCallingWindow(timezone, start, end string) (*CallingWindow, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+	if start == "" && end == "" {
+		return &CallingWindow{Location: location, Always: true}, nil
+	}
+	from, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, fmt.Errorf("calling hours %s to %s are empty", start, end)
+	}
+	return &CallingWindow{Location: location, Start: from, End: to}, nil
+}
+
+func parseClock(value string) (int, error) {
+	hour, minute, ok := strings.Cut(value, ":")
+	h, herr := strconv.Atoi(hour)
+	m, merr := strconv.Atoi(minute)
+	if !ok || herr != nil || merr != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("calling hour %q is not HH:MM", value)
+	}
+	return h*60 + m, nil
+}
+
+// Contains reports whether calls may be placed at t.
+func (w *CallingWindow) Contains(t time.Time) bool {
+	if w.Always {
+		return true
+	}
+	local := t.In(w.Location)
+	minute := local.Hour()*60 + local.Minute()
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	return minute >= w.Start || minute < w.End
+}
+
+// NextOpen returns t when the window contains it, otherwise when it opens
+// next.
+func (w *CallingWindow) NextOpen(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	local := t.In(w.Location)
+	open := time.Date(local.Year(), local.Month(), local.Day(), w.Start/60, w.Start%60, 0, 0, w.Location)
+	if !open.After(local) {
+		open = open.AddDate(0, 0, 1)
+	}
+	return open
+}
+
+// Result is what became of the call placed to a contact.
+type Result struct {
+	// Finished is false while the call is ringing or connected.
+	Finished bool
+	// Retry is set for calls that did not reach anyone.
+	Retry   bool
+	Outcome string
+}
+
+// CallResult reads the result of a call from its call context, nil when
+// none was saved. Calls are placed with the call context queued and claimed
+// once media connects; queued past ringTimeout means nobody answered.
+func CallResult(cc *callcontext.CallContext, dialedAt, now time.Time, ringTimeout time.Duration) Result {
+	elapsed := now.Sub(dialedAt)
+	switch {
+	case cc == nil:
+		if elapsed < ringTimeout {
+			return Result{}
+		}
+		return Result{Finished: true, Retry: true, Outcome: OutcomeFailed}
+	case cc.Status == callcontext.StatusCompleted:
+		outcome := cc.Outcome
+		if outcome == "" {
+			outcome = OutcomeAnswered
+		}
+		return Result{Finished: true, Outcome: outcome}
+	case cc.Status == callcontext.StatusFailed:
+		return Result{Finished: true, Retry: true, Outcome: OutcomeFailed}
+	case cc.Status == callcontext.StatusClaimed:
+		if elapsed < StaleCallAfter {
+			return Result{}
+		}
+		return Result{Finished: true, Outcome: OutcomeAnswered}
+	default:
+		if elapsed < ringTimeout {
+			return Result{}
+		}
+		return Result{Finished: true, Retry: true, Outcome: OutcomeNoAnswer}
+	}
+}
+
+// NextAttempt returns the status of a contact whose call did not reach
+// anyone after attempts calls, and when it is called again if it is.
+func NextAttempt(attempts, maxAttempts uint32, retryDelay time.Duration, now time.Time) (string, *time.Time) {
+	if attempts >= maxAttempts {
+		return ContactFailed, nil
+	}
+	next := now.Add(retryDelay)
+	return ContactPending, &next
+}
+
+// MergeArgs returns the arguments of the call to a contact, the contact's
+// over the campaign's.
+func MergeArgs(campaign, contact map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(campaign)+len(contact))
+	for k, v := range campaign {
+		out[k] = v
+	}
+	for k, v := range contact {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_campaign
+
+import (
+	"testing"
+	"time"
+
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCallingWindow(t *testing.T) {
+	w, err := ParseCallingWindow("", "", "")
+	require.NoError(t, err)
+	assert.True(t, w.Always)
+	assert.Equal(t, "UTC", w.Location.String())
+
+	w, err = ParseCallingWindow("America/New_York", "09:00", "20:30")
+	require.NoError(t, err)
+	assert.Equal(t, 9*60, w.Start)
+	assert.Equal(t, 20*60+30, w.End)
+
+	for _, tc := range [][3]string{
+		{"Mars/Olympus", "09:00", "17:00"},
+		{"UTC", "9", "17:00"},
+		{"UTC", "09:00", "24:00"},
+		{"UTC", "09:00", ""},
+		{"UTC", "09:00", "09:00"},
+	} {
+		_, err := ParseCallingWindow(tc[0], tc[1], tc[2])
+		assert.Error(t, err, "%v", tc)
+	}
+}
+
+func TestCallingWindow_Contains(t *testing.T) {
+	w, err := ParseCallingWindow("America/New_York", "09:00", "20:00")
+	require.NoError(t, err)
+	// 13:00 UTC is 09:00 in New York in summer
+	assert.True(t, w.Contains(time.Date(2026, 7, 1, 13, 0, 0, 0, time.UTC)))
+	assert.False(t, w.Contains(time.Date(2026, 7, 1, 12, 59, 0, 0, time.UTC)))
+	assert.False(t, w.Contains(time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC)))
+
+	overnight, err := ParseCallingWindow("UTC", "22:00", "02:00")
+	require.NoError(t, err)
+	assert.True(t, overnight.Contains(time.Date(2026, 7, 1, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, overnight.Contains(time.Date(2026, 7, 1, 1, 59, 0, 0, time.UTC)))
+	assert.False(t, overnight.Contains(time.Date(2026, 7, 1, 2, 0, 0, 0, time.UTC)))
+}
+
+func TestCallingWindow_NextOpen(t *testing.T) {
+	w, err := ParseCallingWindow("UTC", "09:00", "17:00")
+	require.NoError(t, err)
+	open := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, open, w.NextOpen(open))
+	assert.Equal(t, time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC), w.NextOpen(time.Date(2026, 7, 1, 6, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 7, 2, 9, 0, 0, 0, time.UTC), w.NextOpen(time.Date(2026, 7, 1, 17, 0, 0, 0, time.UTC)))
+
+	overnight, err := ParseCallingWindow("UTC", "22:00", "02:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 7, 1, 22, 0, 0, 0, time.UTC), overnight.NextOpen(time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestCallResult(t *testing.T) {
+	dialed := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	ring := 2 * time.Minute
+	soon, late := dialed.Add(time.Minute), dialed.Add(3*time.Minute)
+
+	assert.False(t, CallResult(nil, dialed, soon, ring).Finished)
+	assert.Equal(t, Result{Finished: true, Retry: true, Outcome: OutcomeFailed}, CallResult(nil, dialed, late, ring))
+
+	queued := &callcontext.CallContext{Status: callcontext.StatusQueued}
+	assert.False(t, CallResult(queued, dialed, soon, ring).Finished)
+	assert.Equal(t, Result{Finished: true, Retry: true, Outcome: OutcomeNoAnswer}, CallResult(queued, dialed, late, ring))
+
+	claimed := &callcontext.CallContext{Status: callcontext.StatusClaimed}
+	assert.False(t, CallResult(claimed, dialed, late, ring).Finished)
+	assert.Equal(t, Result{Finished: true, Outcome: OutcomeAnswered}, CallResult(claimed, dialed, dialed.Add(StaleCallAfter), ring))
+
+	completed := &callcontext.CallContext{Status: callcontext.StatusCompleted}
+	assert.Equal(t, Result{Finished: true, Outcome: OutcomeAnswered}, CallResult(completed, dialed, soon, ring))
+	completed.Outcome = callcontext.OutcomeVoicemailDropped
+	assert.Equal(t, Result{Finished: true, Outcome: callcontext.OutcomeVoicemailDropped}, CallResult(completed, dialed, soon, ring))
+
+	failed := &callcontext.CallContext{Status: callcontext.StatusFailed}
+	assert.Equal(t, Result{Finished: true, Retry: true, Outcome: OutcomeFailed}, CallResult(failed, dialed, soon, ring))
+}
+
+func TestNextAttempt(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	status, next := NextAttempt(1, 3, 10*time.Minute, now)
+	assert.Equal(t, ContactPending, status)
+	require.NotNil(t, next)
+	assert.Equal(t, now.Add(10*time.Minute), *next)
+
+	status, next = NextAttempt(3, 3, 10*time.Minute, now)
+	assert.Equal(t, ContactFailed, status)
+	assert.Nil(t, next)
+}
+
+func TestMergeArgs(t *testing.T) {
+	campaign := map[string]interface{}{"clinic": "Downtown", "name": "there"}
+	merged := MergeArgs(campaign, map[string]interface{}{"name": "Ana"})
+	assert.Equal(t, map[string]interface{}{"clinic": "Downtown", "name": "Ana"}, merged)
+	assert.Equal(t, "there", campaign["name"])
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_campaign
+
+import (
+	"strings"
+	"time"
+)
+
+// OutcomeDND is the outcome of a contact whose number is on India's DND
+// register (NCPR) and whose promotional call the provider refused. Calling
+// again is refused as well, the contact is not retried.
+const OutcomeDND = "dnd"
+
+// istZone is India Standard Time, which has no daylight saving.
+var istZone = time.FixedZone("IST", 5*3600+30*60)
+
+// TRAIWindow is when TRAI allows promotional calls to Indian numbers,
+// 09:00 to 21:00 in India whatever the calling hours of the campaign.
+var TRAIWindow = &CallingWindow{Location: istZone, Start: 9 * 60, End: 21 * 60}
+
+// IsIndianNumber reports whether number is an Indian (+91) number.
+func IsIndianNumber(number string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, number)
+	trimmed := strings.TrimSpace(number)
+	switch {
+	case strings.HasPrefix(trimmed, "+"):
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	case len(digits) != 12:
+		// without a country code the number is national, which is not
+		// known to be India
+		return false
+	}
+	return strings.HasPrefix(digits, "91") && len(digits) == 12
+}
+
+// TRAIDeferral returns when a call to number may be placed if it may not be
+// at now: promotional calls to Indian numbers wait for the TRAI window to
+// open, transactional calls are not restricted.
+func TRAIDeferral(number string, promotional bool, now time.Time) (time.Time, bool) {
+	if !promotional || !IsIndianNumber(number) || TRAIWindow.Contains(now) {
+		return time.Time{}, false
+	}
+	return TRAIWindow.NextOpen(now), true
+}
+
+// IsDNDRefusal reports whether a failed call was refused because the number
+// is on the DND register, as Indian providers say in their error.
+func IsDNDRefusal(message string) bool {
+	message = strings.ToLower(message)
+	for _, word := range []string{"dnd", "ncpr", "do not disturb", "do-not-disturb"} {
+		if strings.Contains(message, word) {
+			return true
+		}
+	}
+	return false
+}
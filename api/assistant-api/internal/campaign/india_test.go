@@ -0,0 +1,43 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_campaign
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIsIndianNumber(t *testing.T) {
+	for _, number := range []string{"+919876543210", "+91 98765 43210", "+91-80-4711-2233", "00919876543210", "919876543210"} {
+		assert.True(t, IsIndianNumber(number), number)
+	}
+	for _, number := range []string{"", "+14155550100", "09876543210", "9876543210", "+9198765"} {
+		assert.False(t, IsIndianNumber(number), number)
+	}
+}
+
+func TestTRAIDeferral(t *testing.T) {
+	// 15:30 UTC is 21:00 in India, the window just closed
+	evening := time.Date(2026, 7, 1, 15, 30, 0, 0, time.UTC)
+	next, ok := TRAIDeferral("+919876543210", true, evening)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 7, 2, 3, 30, 0, 0, time.UTC), next.UTC())
+
+	_, ok = TRAIDeferral("+919876543210", false, evening)
+	assert.False(t, ok, "transactional calls are not restricted")
+	_, ok = TRAIDeferral("+14155550100", true, evening)
+	assert.False(t, ok, "only Indian numbers")
+	_, ok = TRAIDeferral("+919876543210", true, evening.Add(-time.Minute))
+	assert.False(t, ok, "20:59 in India")
+}
+
+func TestIsDNDRefusal(t *testing.T) {
+	assert.True(t, IsDNDRefusal("status code 403: Number is registered under NCPR"))
+	assert.True(t, IsDNDRefusal("Call to a DND number is not allowed"))
+	assert.False(t, IsDNDRefusal("status code 401: Authentication failed"))
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_capacity reports how busy the replica is as a single
+// utilization for autoscalers: the busiest of its active sessions, RTP
+// ports, CPU and provider streams, each against what a replica is sized for.
+package internal_capacity
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	internal_drain "github.com/rapidaai/api/assistant-api/internal/drain"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+)
+
+// Components of the utilization.
+const (
+	Sessions        = "sessions"
+	RTPPorts        = "rtp_ports"
+	CPU             = "cpu"
+	ProviderStreams = "provider_streams"
+)
+
+// Limits are what one replica is sized for, a zero limit leaves its
+// component out.
+type Limits struct {
+	Sessions        int64
+	ProviderStreams int64
+}
+
+// Report is the capacity of the replica, served on /capacity.
+type Report struct {
+	// Utilization is the busiest component, 1 is a replica at its limits.
+	Utilization float64            `json:"utilization"`
+	Components  map[string]float64 `json:"components"`
+	// ActiveSessions also tells a replica apart that can be removed at no
+	// cost, e.g. as its pod-deletion-cost.
+	ActiveSessions int64 `json:"activeSessions"`
+	Draining       bool  `json:"draining"`
+	// ScaleInProtected is set while sessions run, removing the replica
+	// without letting it drain ends them.
+	ScaleInProtected bool `json:"scaleInProtected"`
+}
+
+// sources are what a Monitor reads, replaced in tests.
+type sources struct {
+	activeSessions  func() int64
+	draining        func() bool
+	rtpPorts        func() (float64, bool)
+	providerStreams func() int64
+	cpuTime         func() (time.Duration, bool)
+	cpus            func() int
+}
+
+var replica = sources{
+	activeSessions:  internal_drain.Default.Active,
+	draining:        internal_drain.Default.Draining,
+	rtpPorts:        internal_runtimemetrics.RTPPortUtilization,
+	providerStreams: internal_runtimemetrics.OpenProviderStreams,
+	cpuTime:         processCPUTime,
+	cpus:            func() int { return runtime.GOMAXPROCS(0) },
+}
+
+// Monitor reports the capacity of the replica. The CPU is averaged between
+// two calls to Sample, the other components are read as reported.
+type Monitor struct {
+	limits  Limits
+	sources sources
+
+	mu        sync.Mutex
+	sampledAt time.Time
+	cpuTime   time.Duration
+	cpu       float64
+	hasCPU    bool
+}
+
+// NewMonitor reports the capacity of this replica against limits.
+func NewMonitor(limits Limits) *Monitor {
+	m := &Monitor{limits: limits, sources: replica}
+	m.Sample()
+	return m
+}
+
+// Sample averages the CPU used by the process, mostly its audio pipelines,
+// over the cores it can run on since the last sample.
+func (m *Monitor) Sample() {
+	cpuTime, ok := m.sources.cpuTime()
+	if !ok {
+		return
+	}
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.sampledAt.IsZero() {
+		if wall := now.Sub(m.sampledAt); wall > 0 {
+			m.cpu = min(float64(cpuTime-m.cpuTime)/(float64(wall)*float64(m.sources.cpus())), 1)
+			m.hasCPU = true
+		}
+	}
+	m.sampledAt, m.cpuTime = now, cpuTime
+}
+
+// Report returns the capacity of the replica now.
+func (m *Monitor) Report() Report {
+	active := m.sources.activeSessions()
+	report := Report{
+		Components:       map[string]float64{},
+		ActiveSessions:   active,
+		Draining:         m.sources.draining(),
+		ScaleInProtected: active > 0,
+	}
+	if m.limits.Sessions > 0 {
+		report.Components[Sessions] = float64(active) / float64(m.limits.Sessions)
+	}
+	if m.limits.ProviderStreams > 0 {
+		report.Components[ProviderStreams] = float64(m.sources.providerStreams()) / float64(m.limits.ProviderStreams)
+	}
+	if ports, ok := m.sources.rtpPorts(); ok {
+		report.Components[RTPPorts] = ports
+	}
+	m.mu.Lock()
+	if m.hasCPU {
+		report.Components[CPU] = m.cpu
+	}
+	m.mu.Unlock()
+	for _, utilization := range report.Components {
+		report.Utilization = max(report.Utilization, utilization)
+	}
+	return report
+}
+
+var active atomic.Pointer[Monitor]
+
+// Install makes m the monitor of the replica, nil stops reporting.
+func Install(m *Monitor) {
+	active.Store(m)
+}
+
+// Active returns the monitor of the replica, nil when capacity is not
+// reported.
+func Active() *Monitor {
+	return active.Load()
+}
+
+// component reads one component of the installed monitor on a scrape.
+func component(name string) func() (float64, bool) {
+	return func() (float64, bool) {
+		m := Active()
+		if m == nil {
+			return 0, false
+		}
+		utilization, ok := m.Report().Components[name]
+		return utilization, ok
+	}
+}
+
+var (
+	Utilization = internal_runtimemetrics.Default.NewGaugeFunc("rapida_assistant_capacity_utilization",
+		"Utilization of the busiest capacity component of the replica, 1 is a replica at its limits.", func() (float64, bool) {
+			m := Active()
+			if m == nil {
+				return 0, false
+			}
+			return m.Report().Utilization, true
+		})
+	SessionsUtilization = internal_runtimemetrics.Default.NewGaugeFunc("rapida_assistant_capacity_sessions_utilization",
+		"Active sessions against the sessions a replica is sized for.", component(Sessions))
+	RTPPortsUtilization = internal_runtimemetrics.Default.NewGaugeFunc("rapida_assistant_capacity_rtp_ports_utilization",
+		"RTP ports in use against the configured range.", component(RTPPorts))
+	CPUUtilization = internal_runtimemetrics.Default.NewGaugeFunc("rapida_assistant_capacity_cpu_utilization",
+		"CPU used by the process over the cores it can run on.", component(CPU))
+	ProviderStreamsUtilization = internal_runtimemetrics.Default.NewGaugeFunc("rapida_assistant_capacity_provider_streams_utilization",
+		"Open provider streams against the streams a replica is sized for.", component(ProviderStreams))
+)
@@ -0,0 +1,92 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_capacity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReplica struct {
+	sessions int64
+	streams  int64
+	draining bool
+	ports    float64
+	hasPorts bool
+	cpuTime  time.Duration
+}
+
+func (f *fakeReplica) sources() sources {
+	return sources{
+		activeSessions:  func() int64 { return f.sessions },
+		draining:        func() bool { return f.draining },
+		rtpPorts:        func() (float64, bool) { return f.ports, f.hasPorts },
+		providerStreams: func() int64 { return f.streams },
+		cpuTime:         func() (time.Duration, bool) { return f.cpuTime, true },
+		cpus:            func() int { return 2 },
+	}
+}
+
+func TestMonitor_Report(t *testing.T) {
+	f := &fakeReplica{sessions: 30, streams: 45, ports: 0.2, hasPorts: true}
+	m := &Monitor{limits: Limits{Sessions: 100, ProviderStreams: 300}, sources: f.sources()}
+
+	report := m.Report()
+	assert.Equal(t, map[string]float64{Sessions: 0.3, ProviderStreams: 0.15, RTPPorts: 0.2}, report.Components)
+	assert.Equal(t, 0.3, report.Utilization, "the busiest component")
+	assert.Equal(t, int64(30), report.ActiveSessions)
+	assert.True(t, report.ScaleInProtected)
+	assert.False(t, report.Draining)
+}
+
+func TestMonitor_ReportWithoutLimits(t *testing.T) {
+	f := &fakeReplica{sessions: 3, streams: 9}
+	m := &Monitor{sources: f.sources()}
+
+	report := m.Report()
+	assert.Empty(t, report.Components, "components without a limit are left out")
+	assert.Equal(t, 0.0, report.Utilization)
+	assert.True(t, report.ScaleInProtected)
+
+	f.sessions, f.draining = 0, true
+	report = m.Report()
+	assert.False(t, report.ScaleInProtected)
+	assert.True(t, report.Draining)
+}
+
+func TestMonitor_SampleCPU(t *testing.T) {
+	f := &fakeReplica{cpuTime: time.Second}
+	m := &Monitor{sources: f.sources()}
+	m.Sample()
+	_, ok := m.Report().Components[CPU]
+	assert.False(t, ok, "the CPU needs two samples")
+
+	time.Sleep(20 * time.Millisecond)
+	f.cpuTime += 20 * time.Millisecond
+	m.Sample()
+	cpu := m.Report().Components[CPU]
+	assert.Greater(t, cpu, 0.0)
+	assert.LessOrEqual(t, cpu, 0.5, "20ms of CPU over at least 20ms of 2 cores")
+}
+
+func TestInstall_Gauges(t *testing.T) {
+	f := &fakeReplica{sessions: 5}
+	Install(&Monitor{limits: Limits{Sessions: 10}, sources: f.sources()})
+	defer Install(nil)
+
+	var out strings.Builder
+	_, err := internal_runtimemetrics.Default.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "rapida_assistant_capacity_utilization 0.5\n")
+	assert.Contains(t, out.String(), "rapida_assistant_capacity_sessions_utilization 0.5\n")
+	assert.NotContains(t, out.String(), "rapida_assistant_capacity_rtp_ports_utilization ")
+}
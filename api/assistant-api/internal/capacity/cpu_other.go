@@ -0,0 +1,16 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+//go:build !unix
+
+package internal_capacity
+
+import "time"
+
+// processCPUTime is not available, the CPU is left out of the utilization.
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
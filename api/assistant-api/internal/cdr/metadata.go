@@ -0,0 +1,145 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_cdr keeps the custom metadata tools and clients attach to
+// a conversation record. Entries live under their own key namespace so they
+// never overwrite what the platform records itself (dtmf, analysis.*,
+// dictation.*), values are scalars so they map onto CDR columns, and both the
+// number and the size of entries are bounded.
+package internal_cdr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/protos"
+)
+
+const (
+	// Namespace prefixes every custom key in the conversation metadata.
+	Namespace = "custom."
+
+	// MaxKeys is the number of custom entries a conversation can carry.
+	MaxKeys = 32
+
+	// MaxKeyLength bounds a key, without the namespace.
+	MaxKeyLength = 64
+
+	// MaxValueSize bounds the stored form of a value in bytes.
+	MaxValueSize = 1024
+)
+
+var (
+	ErrInvalidKey    = errors.New("custom metadata keys are made of letters, digits, '_', '-' and '.'")
+	ErrInvalidValue  = errors.New("custom metadata values are strings, numbers or booleans")
+	ErrValueTooLarge = fmt.Errorf("custom metadata values are limited to %d bytes", MaxValueSize)
+	ErrTooManyKeys   = fmt.Errorf("a conversation carries at most %d custom metadata entries", MaxKeys)
+)
+
+// Persister stores one entry on the conversation.
+type Persister func(ctx context.Context, metadata *protos.Metadata) error
+
+type metadata struct {
+	mu      sync.RWMutex
+	values  map[string]interface{}
+	persist Persister
+}
+
+// NewMetadata returns the custom metadata of a conversation, seeded with the
+// namespaced entries of its existing metadata. persist may be nil, entries
+// then only live in memory.
+func NewMetadata(conversation map[string]interface{}, persist Persister) internal_type.CustomMetadata {
+	values := make(map[string]interface{})
+	for k, v := range conversation {
+		if key, ok := strings.CutPrefix(k, Namespace); ok {
+			values[key] = v
+		}
+	}
+	return &metadata{values: values, persist: persist}
+}
+
+// Key validates a key and strips the namespace when the caller included it.
+func Key(key string) (string, error) {
+	key = strings.TrimPrefix(strings.TrimSpace(key), Namespace)
+	if key == "" || len(key) > MaxKeyLength {
+		return "", fmt.Errorf("%w, up to %d characters: %q", ErrInvalidKey, MaxKeyLength, key)
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return key, nil
+}
+
+// Encode returns the stored form of a value.
+func Encode(value interface{}) (string, error) {
+	var encoded string
+	switch v := value.(type) {
+	case string:
+		encoded = v
+	case bool:
+		encoded = strconv.FormatBool(v)
+	case float64:
+		encoded = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		encoded = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int, int32, int64, uint, uint32, uint64:
+		encoded = fmt.Sprint(v)
+	default:
+		return "", fmt.Errorf("%w, got %T", ErrInvalidValue, value)
+	}
+	if len(encoded) > MaxValueSize {
+		return "", ErrValueTooLarge
+	}
+	return encoded, nil
+}
+
+func (m *metadata) Get(key string) (interface{}, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.values[strings.TrimPrefix(key, Namespace)]
+	return v, ok
+}
+
+func (m *metadata) Set(ctx context.Context, key string, value interface{}) error {
+	key, err := Key(key)
+	if err != nil {
+		return err
+	}
+	encoded, err := Encode(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	m.mu.Lock()
+	if _, ok := m.values[key]; !ok && len(m.values) >= MaxKeys {
+		m.mu.Unlock()
+		return ErrTooManyKeys
+	}
+	m.values[key] = value
+	m.mu.Unlock()
+
+	if m.persist == nil {
+		return nil
+	}
+	return m.persist(ctx, &protos.Metadata{Key: Namespace + key, Value: encoded})
+}
+
+func (m *metadata) All() map[string]interface{} {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	all := make(map[string]interface{}, len(m.values))
+	for k, v := range m.values {
+		all[k] = v
+	}
+	return all
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_cdr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata_SetNamespacesAndPersists(t *testing.T) {
+	var saved []*protos.Metadata
+	m := NewMetadata(map[string]interface{}{"dtmf": "1", "custom.order_id": "A-1"}, func(ctx context.Context, md *protos.Metadata) error {
+		saved = append(saved, md)
+		return nil
+	})
+
+	require.NoError(t, m.Set(context.Background(), "resolution_code", "R42"))
+	require.NoError(t, m.Set(context.Background(), "custom.refund", 12.5))
+	require.NoError(t, m.Set(context.Background(), "escalated", false))
+
+	assert.Equal(t, map[string]interface{}{"order_id": "A-1", "resolution_code": "R42", "refund": 12.5, "escalated": false}, m.All())
+	assert.Equal(t, []*protos.Metadata{
+		{Key: "custom.resolution_code", Value: "R42"},
+		{Key: "custom.refund", Value: "12.5"},
+		{Key: "custom.escalated", Value: "false"},
+	}, saved)
+
+	v, ok := m.Get("custom.order_id")
+	assert.True(t, ok)
+	assert.Equal(t, "A-1", v)
+}
+
+func TestMetadata_Validation(t *testing.T) {
+	m := NewMetadata(nil, nil)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, m.Set(ctx, "", "x"), ErrInvalidKey)
+	assert.ErrorIs(t, m.Set(ctx, "order id", "x"), ErrInvalidKey)
+	assert.ErrorIs(t, m.Set(ctx, strings.Repeat("k", MaxKeyLength+1), "x"), ErrInvalidKey)
+	assert.ErrorIs(t, m.Set(ctx, "items", []string{"a"}), ErrInvalidValue)
+	assert.ErrorIs(t, m.Set(ctx, "notes", strings.Repeat("x", MaxValueSize+1)), ErrValueTooLarge)
+	assert.Empty(t, m.All())
+}
+
+func TestMetadata_MaxKeys(t *testing.T) {
+	m := NewMetadata(nil, nil)
+	ctx := context.Background()
+	for i := 0; i < MaxKeys; i++ {
+		require.NoError(t, m.Set(ctx, fmt.Sprintf("k%d", i), i))
+	}
+
+	assert.ErrorIs(t, m.Set(ctx, "one_more", 1), ErrTooManyKeys)
+	assert.NoError(t, m.Set(ctx, "k0", "updated"), "existing keys can still be updated")
+}
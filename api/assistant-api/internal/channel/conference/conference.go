@@ -0,0 +1,320 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package channel_conference turns the 1:1 call of a channel into a
+// conference: the caller, the assistant and human agents joining the call.
+// Every participant hears the others mixed, the loudest participant is
+// tracked as the active speaker. Only the caller's own audio goes on to
+// speech to text, agents are never transcribed as the user.
+//
+// All audio is linear16 16 kHz mono, the format the talk loop works in.
+package channel_conference
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+)
+
+// Role is the part a participant has in the conference.
+type Role string
+
+const (
+	RoleCaller    Role = "caller"
+	RoleAssistant Role = "assistant"
+	RoleAgent     Role = "agent"
+)
+
+const (
+	// FrameDuration is the mixing unit.
+	FrameDuration = 20 * time.Millisecond
+	// FrameBytes is 20 ms of linear16 16 kHz mono audio.
+	FrameBytes = 640
+
+	// speakerLevel is the RMS level a frame needs to count as speech, about
+	// -36 dBFS.
+	speakerLevel = 500
+	// speakerHoldFrames is how long another participant has to be the
+	// loudest, or everyone quiet, before the active speaker changes.
+	speakerHoldFrames = 15
+)
+
+// Sink is how a participant hears the conference.
+type Sink interface {
+	// Hear receives a 20 ms frame of the other participants mixed. It is
+	// called from the conference clock and must not block.
+	Hear(frame []byte)
+	// Speaker is told the active speaker changed, nil when nobody speaks.
+	Speaker(p *Participant)
+}
+
+// Participant is someone in the conference.
+type Participant struct {
+	ID   string
+	Role Role
+
+	sink     Sink
+	maxQueue int
+
+	// guarded by the conference
+	queue   [][]byte
+	partial []byte
+	dropped int
+}
+
+// Conference mixes its participants on a 20 ms clock.
+type Conference struct {
+	mu           sync.Mutex
+	participants []*Participant
+	closed       bool
+	done         chan struct{}
+
+	speaker   *Participant
+	candidate *Participant
+	held      int // frames the candidate has been the loudest
+}
+
+func NewConference() *Conference {
+	return &Conference{done: make(chan struct{})}
+}
+
+// Join adds a participant, sink is nil for one that hears the conference
+// another way, e.g. the assistant through speech to text. maxQueue bounds
+// the frames of the participant waiting for the clock, the oldest are
+// dropped beyond it, zero queues without bound.
+func (c *Conference) Join(id string, role Role, sink Sink, maxQueue int) *Participant {
+	p := &Participant{ID: id, Role: role, sink: sink, maxQueue: maxQueue}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.participants = append(c.participants, p)
+	}
+	return p
+}
+
+// Leave removes a participant.
+func (c *Conference) Leave(p *Participant) {
+	c.mu.Lock()
+	var speaker func()
+	for i, q := range c.participants {
+		if q == p {
+			c.participants = append(c.participants[:i], c.participants[i+1:]...)
+			break
+		}
+	}
+	if c.candidate == p {
+		c.candidate, c.held = nil, 0
+	}
+	if c.speaker == p {
+		c.speaker = nil
+		speaker = c.announce(nil)
+	}
+	c.mu.Unlock()
+	if speaker != nil {
+		speaker()
+	}
+}
+
+// Count returns how many participants have role.
+func (c *Conference) Count(role Role) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, p := range c.participants {
+		if p.Role == role {
+			n++
+		}
+	}
+	return n
+}
+
+// ActiveSpeaker returns the participant speaking, nil when nobody does.
+func (c *Conference) ActiveSpeaker() *Participant {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.speaker
+}
+
+// Push queues audio of p in 20 ms frames, the rest waits for more.
+func (c *Conference) Push(p *Participant, audio []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p.partial = append(p.partial, audio...)
+	for len(p.partial) >= FrameBytes {
+		p.queue = append(p.queue, append([]byte(nil), p.partial[:FrameBytes]...))
+		p.partial = p.partial[FrameBytes:]
+	}
+	if over := len(p.queue) - p.maxQueue; p.maxQueue > 0 && over > 0 {
+		p.queue = p.queue[over:]
+		p.dropped += over
+	}
+}
+
+// Clear drops the audio of p that was not mixed yet, e.g. the assistant's
+// when it is interrupted.
+func (c *Conference) Clear(p *Participant) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p.queue, p.partial = nil, nil
+}
+
+// Drain returns and removes the audio of p that was not mixed yet.
+func (c *Conference) Drain(p *Participant) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var audio []byte
+	for _, frame := range p.queue {
+		audio = append(audio, frame...)
+	}
+	audio = append(audio, p.partial...)
+	p.queue, p.partial = nil, nil
+	return audio
+}
+
+// Dropped returns how many frames of p were dropped to bound its queue.
+func (c *Conference) Dropped(p *Participant) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return p.dropped
+}
+
+// Tick takes one frame of every participant with audio queued and hands
+// each participant the others mixed. Participants nobody else has audio
+// for hear nothing.
+func (c *Conference) Tick() {
+	c.mu.Lock()
+	frames := make([][]byte, len(c.participants))
+	var loudest *Participant
+	var level float64
+	for i, p := range c.participants {
+		if len(p.queue) == 0 {
+			continue
+		}
+		frames[i] = p.queue[0]
+		p.queue = p.queue[1:]
+		if l := rms(frames[i]); l >= speakerLevel && l > level {
+			loudest, level = p, l
+		}
+	}
+	type delivery struct {
+		sink  Sink
+		frame []byte
+	}
+	var deliveries []delivery
+	for i, p := range c.participants {
+		if p.sink == nil {
+			continue
+		}
+		var mix []byte
+		for j, frame := range frames {
+			if j == i || frame == nil {
+				continue
+			}
+			if mix == nil {
+				mix = append([]byte(nil), frame...)
+				continue
+			}
+			internal_audio.MixLinear16(mix, frame)
+		}
+		if mix != nil {
+			deliveries = append(deliveries, delivery{p.sink, mix})
+		}
+	}
+	speaker := c.track(loudest)
+	c.mu.Unlock()
+
+	for _, d := range deliveries {
+		d.sink.Hear(d.frame)
+	}
+	if speaker != nil {
+		speaker()
+	}
+}
+
+// Run ticks the conference until ctx is done or it is closed.
+func (c *Conference) Run(ctx context.Context) {
+	ticker := time.NewTicker(FrameDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.Tick()
+		}
+	}
+}
+
+// Close ends the conference, its participants leave.
+func (c *Conference) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.participants = nil
+	close(c.done)
+}
+
+// Done is closed once the conference ended.
+func (c *Conference) Done() <-chan struct{} {
+	return c.done
+}
+
+// track updates the active speaker with the loudest participant of a
+// frame, c.mu is held. It returns the announcement to make once released.
+func (c *Conference) track(loudest *Participant) func() {
+	if loudest == c.speaker {
+		c.candidate, c.held = nil, 0
+		return nil
+	}
+	if loudest != c.candidate {
+		c.candidate, c.held = loudest, 0
+	}
+	c.held++
+	if c.held < speakerHoldFrames {
+		return nil
+	}
+	c.speaker, c.candidate, c.held = loudest, nil, 0
+	return c.announce(loudest)
+}
+
+// announce returns the call telling every participant the active speaker is
+// p, c.mu is held.
+func (c *Conference) announce(p *Participant) func() {
+	sinks := make([]Sink, 0, len(c.participants))
+	for _, q := range c.participants {
+		if q.sink != nil {
+			sinks = append(sinks, q.sink)
+		}
+	}
+	return func() {
+		for _, sink := range sinks {
+			sink.Speaker(p)
+		}
+	}
+}
+
+// rms returns the RMS level of a linear16 frame.
+func rms(frame []byte) float64 {
+	n := len(frame) / 2
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i+1 < len(frame); i += 2 {
+		s := float64(int16(binary.LittleEndian.Uint16(frame[i:])))
+		sum += s * s
+	}
+	return math.Sqrt(sum / float64(n))
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package channel_conference
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	frames   [][]byte
+	speakers []*Participant
+}
+
+func (s *recordingSink) Hear(frame []byte)      { s.frames = append(s.frames, frame) }
+func (s *recordingSink) Speaker(p *Participant) { s.speakers = append(s.speakers, p) }
+
+// frame returns a 20 ms frame of samples of value v.
+func frame(v int16) []byte {
+	b := make([]byte, FrameBytes)
+	for i := 0; i < FrameBytes; i += 2 {
+		binary.LittleEndian.PutUint16(b[i:], uint16(v))
+	}
+	return b
+}
+
+func sample(b []byte) int16 {
+	return int16(binary.LittleEndian.Uint16(b))
+}
+
+func TestConference_EveryoneHearsTheOthers(t *testing.T) {
+	c := NewConference()
+	callerSink, agentSink := &recordingSink{}, &recordingSink{}
+	caller := c.Join("caller", RoleCaller, callerSink, 0)
+	assistant := c.Join("assistant", RoleAssistant, nil, 0)
+	agent := c.Join("agent", RoleAgent, agentSink, 0)
+
+	c.Push(caller, frame(100))
+	c.Push(assistant, frame(20))
+	c.Push(agent, frame(3))
+	c.Tick()
+
+	require.Len(t, callerSink.frames, 1)
+	assert.Equal(t, int16(23), sample(callerSink.frames[0]), "the caller hears the assistant and the agent")
+	require.Len(t, agentSink.frames, 1)
+	assert.Equal(t, int16(120), sample(agentSink.frames[0]), "the agent hears the caller and the assistant")
+
+	// nobody else speaking, the caller hears nothing
+	c.Push(caller, frame(100))
+	c.Tick()
+	assert.Len(t, callerSink.frames, 1)
+	assert.Len(t, agentSink.frames, 2)
+}
+
+func TestConference_QueuesFramesAndBoundsThem(t *testing.T) {
+	c := NewConference()
+	sink := &recordingSink{}
+	c.Join("caller", RoleCaller, sink, 0)
+	assistant := c.Join("assistant", RoleAssistant, nil, 0)
+	agent := c.Join("agent", RoleAgent, nil, 2)
+
+	// speech synthesis runs ahead, the clock plays it a frame per tick
+	c.Push(assistant, append(frame(1), frame(2)[:FrameBytes/2]...))
+	c.Push(assistant, frame(2)[FrameBytes/2:])
+	c.Tick()
+	c.Tick()
+	c.Tick()
+	require.Len(t, sink.frames, 2)
+	assert.Equal(t, int16(2), sample(sink.frames[1]))
+
+	c.Push(agent, append(append(frame(1), frame(2)...), frame(3)...))
+	assert.Equal(t, 1, c.Dropped(agent))
+	c.Tick()
+	assert.Equal(t, int16(2), sample(sink.frames[2]), "the oldest frame was dropped")
+
+	c.Push(assistant, append(frame(5), 1, 2))
+	assert.Len(t, c.Drain(assistant), FrameBytes+2)
+	c.Push(assistant, frame(5))
+	c.Clear(assistant)
+	c.Push(agent, nil)
+	c.Tick()
+	c.Tick()
+	assert.Len(t, sink.frames, 4)
+	assert.Equal(t, int16(3), sample(sink.frames[3]))
+}
+
+func TestConference_ActiveSpeaker(t *testing.T) {
+	c := NewConference()
+	sink := &recordingSink{}
+	caller := c.Join("caller", RoleCaller, sink, 0)
+	agent := c.Join("agent", RoleAgent, nil, 0)
+
+	for i := 0; i < speakerHoldFrames-1; i++ {
+		c.Push(caller, frame(2000))
+		c.Tick()
+	}
+	assert.Nil(t, c.ActiveSpeaker(), "a short burst does not take the floor")
+	c.Push(caller, frame(2000))
+	c.Tick()
+	assert.Equal(t, caller, c.ActiveSpeaker())
+
+	// the agent is louder long enough
+	for i := 0; i < speakerHoldFrames; i++ {
+		c.Push(caller, frame(800))
+		c.Push(agent, frame(3000))
+		c.Tick()
+	}
+	assert.Equal(t, agent, c.ActiveSpeaker())
+
+	c.Leave(agent)
+	assert.Nil(t, c.ActiveSpeaker())
+	assert.Equal(t, []*Participant{caller, agent, nil}, sink.speakers)
+	assert.Equal(t, 0, c.Count(RoleAgent))
+}
+
+func TestConference_Close(t *testing.T) {
+	c := NewConference()
+	c.Close()
+	c.Close()
+	select {
+	case <-c.Done():
+	default:
+		t.Fatal("a closed conference is done")
+	}
+	c.Join("agent", RoleAgent, nil, 0)
+	assert.Equal(t, 0, c.Count(RoleAgent), "nobody joins an ended conference")
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package channel_conference
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// Session is a live conversation human agents can join.
+type Session interface {
+	// JoinConference adds a human agent to the conference of the
+	// conversation, started with the first one, see Conference.Join for
+	// maxQueue. leave takes them out again.
+	JoinConference(ctx context.Context, id string, sink Sink, maxQueue int) (conference *Conference, participant *Participant, leave func(), err error)
+}
+
+const (
+	// agentQueueFrames bounds what an agent is behind the conference, about
+	// 200 ms.
+	agentQueueFrames = 10
+	// legBuffer is how many frames an agent's connection may fall behind
+	// before frames are dropped.
+	legBuffer = 50
+)
+
+// ServeAgent runs the WebSocket of a human agent in the conference of
+// session until either side ends.
+//
+// Protocol:
+//   - binary frames carry audio as linear16 16 kHz mono PCM, the agent's
+//     microphone from the client and the caller and the assistant mixed
+//     from the server
+//   - text frames from the server are JSON events:
+//     {"type":"joined","id":"agent-1"},
+//     {"type":"speaker","id":"caller","role":"caller"} when the active
+//     speaker changes (both empty when nobody speaks) and {"type":"end"}
+//     once the conversation ended
+func ServeAgent(ctx context.Context, logger commons.Logger, conn *websocket.Conn, session Session, id string) error {
+	leg := &agentLeg{logger: logger, conn: conn, frames: make(chan []byte, legBuffer), events: make(chan map[string]string, legBuffer)}
+	conference, participant, leave, err := session.JoinConference(ctx, id, leg, agentQueueFrames)
+	if err != nil {
+		return err
+	}
+	defer leave()
+	leg.write(websocket.TextMessage, map[string]string{"type": "joined", "id": id})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+					logger.Errorf("conference: unexpected websocket close error %v", err)
+				}
+				return
+			}
+			if messageType == websocket.BinaryMessage && len(message) > 0 {
+				conference.Push(participant, message)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-conference.Done():
+			leg.write(websocket.TextMessage, map[string]string{"type": "end"})
+			leg.close()
+			return nil
+		case frame := <-leg.frames:
+			leg.write(websocket.BinaryMessage, frame)
+		case event := <-leg.events:
+			leg.write(websocket.TextMessage, event)
+		}
+	}
+}
+
+// agentLeg is the Sink of an agent connected over a WebSocket.
+type agentLeg struct {
+	logger    commons.Logger
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+	frames    chan []byte
+	events    chan map[string]string
+	dropped   atomic.Uint64
+}
+
+func (l *agentLeg) Hear(frame []byte) {
+	select {
+	case l.frames <- frame:
+	default:
+		if l.dropped.Add(1)%legBuffer == 1 {
+			l.logger.Warnf("conference: agent is falling behind, dropped %d frames", l.dropped.Load())
+		}
+	}
+}
+
+func (l *agentLeg) Speaker(p *Participant) {
+	event := map[string]string{"type": "speaker", "id": "", "role": ""}
+	if p != nil {
+		event["id"], event["role"] = p.ID, string(p.Role)
+	}
+	select {
+	case l.events <- event:
+	default:
+	}
+}
+
+// write sends a binary frame or a JSON text frame.
+func (l *agentLeg) write(messageType int, v interface{}) {
+	payload, ok := v.([]byte)
+	if !ok {
+		var err error
+		if payload, err = json.Marshal(v); err != nil {
+			l.logger.Errorf("conference: unable to encode event: %v", err)
+			return
+		}
+	}
+	l.writeLock.Lock()
+	defer l.writeLock.Unlock()
+	if err := l.conn.WriteMessage(messageType, payload); err != nil {
+		l.logger.Debugf("conference: unable to write to the agent: %v", err)
+	}
+}
+
+// close ends the connection with a close frame.
+func (l *agentLeg) close() {
+	l.writeLock.Lock()
+	defer l.writeLock.Unlock()
+	l.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "conversation ended"), time.Now().Add(time.Second))
+}
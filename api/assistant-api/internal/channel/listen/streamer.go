@@ -0,0 +1,140 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package channel_listen implements the WebSocket transport of the standalone
+// streaming speech to text API.
+//
+// Protocol:
+//   - binary frames carry caller audio as linear16, 16 kHz, mono PCM
+//   - text frames carry JSON control events, {"type":"stop"} ends the session
+//   - transcripts are sent back as JSON text frames:
+//     {"type":"transcript","id":"…","text":"…","completed":false}
+//
+// Interim transcripts of the same utterance share an id; the transcript with
+// completed=true is final and the next utterance starts with a new id.
+package channel_listen
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	EventTranscript = "transcript"
+	EventStop       = "stop"
+)
+
+// Event is the JSON envelope exchanged on text frames.
+type Event struct {
+	Type      string `json:"type"`
+	Id        string `json:"id,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Completed bool   `json:"completed,omitempty"`
+}
+
+type listenStreamer struct {
+	logger     commons.Logger
+	ctx        context.Context
+	cancel     context.CancelFunc
+	connection *websocket.Conn
+	writeLock  sync.Mutex
+
+	// pending initialization, emitted on the first Recv
+	initialization *protos.ConversationInitialization
+}
+
+// NewListenStreamer wraps an upgraded WebSocket connection. The assistant
+// definition is emitted as a ConversationInitialization on the first Recv so
+// the listener resolves the assistant's listen configuration before audio.
+func NewListenStreamer(ctx context.Context, logger commons.Logger, connection *websocket.Conn, assistant *protos.AssistantDefinition) internal_type.Streamer {
+	sCtx, cancel := context.WithCancel(ctx)
+	return &listenStreamer{
+		logger:     logger,
+		ctx:        sCtx,
+		cancel:     cancel,
+		connection: connection,
+		initialization: &protos.ConversationInitialization{
+			Assistant:  assistant,
+			StreamMode: protos.StreamMode_STREAM_MODE_AUDIO,
+			Time:       timestamppb.Now(),
+		},
+	}
+}
+
+func (ls *listenStreamer) Context() context.Context {
+	return ls.ctx
+}
+
+func (ls *listenStreamer) Recv() (internal_type.Stream, error) {
+	if ls.initialization != nil {
+		init := ls.initialization
+		ls.initialization = nil
+		return init, nil
+	}
+	for {
+		messageType, message, err := ls.connection.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				ls.logger.Errorf("listen: unexpected websocket close error %v", err)
+			}
+			ls.cancel()
+			return nil, io.EOF
+		}
+		switch messageType {
+		case websocket.BinaryMessage:
+			if len(message) == 0 {
+				continue
+			}
+			return &protos.ConversationUserMessage{
+				Message: &protos.ConversationUserMessage_Audio{Audio: message},
+				Time:    timestamppb.Now(),
+			}, nil
+		case websocket.TextMessage:
+			var event Event
+			if err := json.Unmarshal(message, &event); err != nil {
+				ls.logger.Warnf("listen: unable to parse control event %v", err)
+				continue
+			}
+			if event.Type == EventStop {
+				ls.cancel()
+				return &protos.ConversationDisconnection{
+					Type: protos.ConversationDisconnection_DISCONNECTION_TYPE_USER,
+					Time: timestamppb.Now(),
+				}, nil
+			}
+		}
+	}
+}
+
+func (ls *listenStreamer) Send(out internal_type.Stream) error {
+	switch data := out.(type) {
+	case *protos.ConversationUserMessage:
+		text, ok := data.GetMessage().(*protos.ConversationUserMessage_Text)
+		if !ok {
+			return nil
+		}
+		return ls.write(Event{Type: EventTranscript, Id: data.GetId(), Text: text.Text, Completed: data.GetCompleted()})
+	}
+	return nil
+}
+
+func (ls *listenStreamer) write(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ls.writeLock.Lock()
+	defer ls.writeLock.Unlock()
+	return ls.connection.WriteMessage(websocket.TextMessage, payload)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package channel_listen
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPair starts a websocket server wrapping the server side connection in
+// a listen streamer and returns it with the connected client connection.
+func newTestPair(t *testing.T) (internal_type.Streamer, *websocket.Conn) {
+	t.Helper()
+	logger, _ := commons.NewApplicationLogger()
+	streamerCh := make(chan internal_type.Streamer, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		streamerCh <- NewListenStreamer(context.Background(), logger, conn, &protos.AssistantDefinition{AssistantId: 42, Version: "latest"})
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return <-streamerCh, client
+}
+
+func TestListenStreamer_FirstRecvIsInitialization(t *testing.T) {
+	streamer, _ := newTestPair(t)
+
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	init, ok := msg.(*protos.ConversationInitialization)
+	require.True(t, ok, "expected ConversationInitialization, got %T", msg)
+	assert.Equal(t, uint64(42), init.GetAssistant().GetAssistantId())
+	assert.Equal(t, protos.StreamMode_STREAM_MODE_AUDIO, init.GetStreamMode())
+}
+
+func TestListenStreamer_BinaryFrameIsAudio(t *testing.T) {
+	streamer, client := newTestPair(t)
+	_, _ = streamer.Recv()
+
+	require.NoError(t, client.WriteMessage(websocket.BinaryMessage, []byte{1, 2, 3, 4}))
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	user, ok := msg.(*protos.ConversationUserMessage)
+	require.True(t, ok)
+	assert.Equal(t, []byte{1, 2, 3, 4}, user.GetAudio())
+}
+
+func TestListenStreamer_StopEventDisconnects(t *testing.T) {
+	streamer, client := newTestPair(t)
+	_, _ = streamer.Recv()
+
+	require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(`{"type":"stop"}`)))
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	_, ok := msg.(*protos.ConversationDisconnection)
+	assert.True(t, ok)
+	assert.Error(t, streamer.Context().Err(), "context should be cancelled after stop")
+}
+
+func TestListenStreamer_ClosedConnectionReturnsEOF(t *testing.T) {
+	streamer, client := newTestPair(t)
+	_, _ = streamer.Recv()
+
+	client.Close()
+	_, err := streamer.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestListenStreamer_SendTranscript(t *testing.T) {
+	streamer, client := newTestPair(t)
+
+	require.NoError(t, streamer.Send(&protos.ConversationUserMessage{
+		Id:        "utterance-1",
+		Message:   &protos.ConversationUserMessage_Text{Text: "hello world"},
+		Completed: true,
+	}))
+
+	_, payload, err := client.ReadMessage()
+	require.NoError(t, err)
+	var event Event
+	require.NoError(t, json.Unmarshal(payload, &event))
+	assert.Equal(t, Event{Type: EventTranscript, Id: "utterance-1", Text: "hello world", Completed: true}, event)
+}
+
+func TestListenStreamer_SendIgnoresOtherMessages(t *testing.T) {
+	streamer, _ := newTestPair(t)
+
+	assert.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Message: &protos.ConversationAssistantMessage_Text{Text: "ignored"},
+	}))
+}
@@ -9,13 +9,16 @@ package channel_telephony
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rapidaai/api/assistant-api/config"
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_cluster "github.com/rapidaai/api/assistant-api/internal/cluster"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_telemetry_batch "github.com/rapidaai/api/assistant-api/internal/telemetry/batch"
 	web_client "github.com/rapidaai/pkg/clients/web"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/types"
@@ -24,6 +27,10 @@ import (
 	"github.com/rapidaai/protos"
 )
 
+// statusForwardTimeout bounds handing a status callback to the instance
+// hosting the session of the call.
+const statusForwardTimeout = 5 * time.Second
+
 // InboundDispatcher handles inbound call processing across all telephony
 // channels (SIP, Asterisk, Twilio, Exotel, Vonage). It encapsulates the
 // common business logic: provider resolution, call reception, conversation
@@ -69,16 +76,26 @@ func (d *InboundDispatcher) HandleStatusCallback(c *gin.Context, provider string
 
 	// Build telemetry from StatusInfo — the dispatcher owns telemetry construction.
 	metric := types.NewMetric("STATUS", statusInfo.Event, utils.Ptr("Status of conversation"))
-	if _, err := d.conversationService.ApplyConversationMetrics(c, auth, assistantId, conversationId, []*types.Metric{metric}); err != nil {
+	if err := internal_telemetry_batch.ConversationMetrics(c, d.conversationService, auth, assistantId, conversationId, metric); err != nil {
 		d.logger.Errorf("failed to apply conversation metrics in callback: %v", err)
 		return fmt.Errorf("failed to process metrics: %w", err)
 	}
 
 	event := types.NewEvent(statusInfo.Event, statusInfo.Payload)
-	if _, err := d.conversationService.ApplyConversationTelephonyEvent(c, auth, provider, assistantId, conversationId, []*types.Event{event}); err != nil {
+	if err := internal_telemetry_batch.TelephonyEvents(c, d.conversationService, auth, provider, assistantId, conversationId, event); err != nil {
 		d.logger.Errorf("failed to apply telephony events in callback: %v", err)
 		return fmt.Errorf("failed to process events: %w", err)
 	}
+
+	// the session of the call may be hosted by another instance, the
+	// provider is answered without waiting for it
+	utils.Go(context.Background(), func() {
+		ctx, cancel := context.WithTimeout(context.Background(), statusForwardTimeout)
+		defer cancel()
+		if err := internal_cluster.StatusCallback(ctx, auth, conversationId, provider, statusInfo.Event); err != nil {
+			d.logger.Warnf("failed to hand status %s to the session of conversation %d: %v", statusInfo.Event, conversationId, err)
+		}
+	})
 	return nil
 }
 
@@ -107,6 +124,14 @@ func (d *InboundDispatcher) HandleReceiveCall(c *gin.Context, provider string, a
 		return "", fmt.Errorf("telephony provider %s not connected: %w", provider, err)
 	}
 
+	if Telephony(provider).SignsWebhooks() {
+		vaultCred, err := d.deploymentCredential(c, auth, assistantId)
+		if err != nil {
+			return "", fmt.Errorf("unable to verify %s webhook: %w", provider, err)
+		}
+		c.Set("vaultCredential", vaultCred)
+	}
+
 	callInfo, err := tel.ReceiveCall(c)
 	if err != nil {
 		return "", fmt.Errorf("receive call failed: %w", err)
@@ -146,24 +171,20 @@ func (d *InboundDispatcher) HandleReceiveCall(c *gin.Context, provider string, a
 	// Apply metric from CallInfo.Status
 	wg.Go(func() error {
 		metric := types.NewMetric("STATUS", callInfo.Status, utils.Ptr("Status of telephony api"))
-		metrics, err := d.conversationService.ApplyConversationMetrics(c, auth, assistant.Id, conversation.Id, []*types.Metric{metric})
-		if err != nil {
+		if err := internal_telemetry_batch.ConversationMetrics(c, d.conversationService, auth, assistant.Id, conversation.Id, metric); err != nil {
 			d.logger.Errorf("failed to apply conversation metrics: %v", err)
 			return err
 		}
-		conversation.Metrics = append(conversation.Metrics, metrics...)
 		return nil
 	})
 
 	// Apply telephony event from CallInfo.StatusInfo
 	wg.Go(func() error {
 		event := types.NewEvent(callInfo.StatusInfo.Event, callInfo.StatusInfo.Payload)
-		evts, err := d.conversationService.ApplyConversationTelephonyEvent(c, auth, assistant.AssistantPhoneDeployment.TelephonyProvider, assistant.Id, conversation.Id, []*types.Event{event})
-		if err != nil {
+		if err := internal_telemetry_batch.TelephonyEvents(c, d.conversationService, auth, assistant.AssistantPhoneDeployment.TelephonyProvider, assistant.Id, conversation.Id, event); err != nil {
 			d.logger.Errorf("failed to apply telephony events: %v", err)
 			return err
 		}
-		conversation.TelephonyEvents = append(conversation.TelephonyEvents, evts...)
 		return nil
 	})
 
@@ -184,6 +205,13 @@ func (d *InboundDispatcher) HandleReceiveCall(c *gin.Context, provider string, a
 		CallerNumber:        callInfo.CallerNumber,
 		Provider:            provider,
 		ChannelUUID:         callInfo.ChannelUUID,
+		StreamMode:          Telephony(provider).StreamMode(assistant.AssistantPhoneDeployment.GetOptions()),
+	}
+	if len(callInfo.Media) > 0 {
+		cc.Media = make(map[string]interface{}, len(callInfo.Media))
+		for k, v := range callInfo.Media {
+			cc.Media[k] = v
+		}
 	}
 	if auth.GetCurrentProjectId() != nil {
 		cc.ProjectID = *auth.GetCurrentProjectId()
@@ -200,7 +228,9 @@ func (d *InboundDispatcher) HandleReceiveCall(c *gin.Context, provider string, a
 	// Pass contextId to the telephony provider for inbound call setup
 	// For Asterisk: the contextId is returned as plain text — dialplan uses it as the AudioSocket UUID
 	// For WebSocket providers: the contextId is embedded in the WebSocket URL path
+	// The deployment options let providers pick how the call is connected (Twilio ConversationRelay)
 	c.Set("contextId", contextID)
+	c.Set("options", assistant.AssistantPhoneDeployment.GetOptions())
 
 	if err := tel.InboundCall(c, auth, assistant.Id, callInfo.CallerNumber, conversation.Id); err != nil {
 		d.logger.Errorf("failed to initiate inbound call: %v", err)
@@ -214,6 +244,17 @@ func (d *InboundDispatcher) HandleReceiveCall(c *gin.Context, provider string, a
 // This is the only DB round-trip needed — call IDs (assistant, conversation,
 // provider) are already in the CallContext from Redis.
 func (d *InboundDispatcher) ResolveVaultCredential(ctx context.Context, auth types.SimplePrinciple, assistantId, conversationId uint64) (*protos.VaultCredential, error) {
+	vltC, err := d.deploymentCredential(ctx, auth, assistantId)
+	if err != nil {
+		internal_telemetry_batch.ConversationMetrics(ctx, d.conversationService, auth, assistantId, conversationId, &types.Metric{Name: type_enums.STATUS.String(), Value: type_enums.RECORD_FAILED.String(), Description: "Failed to resolve vault credential"})
+		return nil, err
+	}
+	return vltC, nil
+}
+
+// deploymentCredential fetches the vault credential of the assistant's phone
+// deployment, without a conversation to record a failure on.
+func (d *InboundDispatcher) deploymentCredential(ctx context.Context, auth types.SimplePrinciple, assistantId uint64) (*protos.VaultCredential, error) {
 	assistant, err := d.assistantService.Get(ctx, auth, assistantId, nil, &internal_services.GetAssistantOption{InjectPhoneDeployment: true})
 	if err != nil {
 		return nil, err
@@ -227,7 +268,6 @@ func (d *InboundDispatcher) ResolveVaultCredential(ctx context.Context, auth typ
 	}
 	vltC, err := d.vaultClient.GetCredential(ctx, auth, credentialID)
 	if err != nil {
-		d.conversationService.ApplyConversationMetrics(ctx, auth, assistantId, conversationId, []*types.Metric{{Name: type_enums.STATUS.String(), Value: type_enums.RECORD_FAILED.String(), Description: "Failed to resolve vault credential"}})
 		return nil, fmt.Errorf("failed to resolve vault credential: %w", err)
 	}
 	return vltC, nil
@@ -0,0 +1,74 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_amazonconnect
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/connect"
+	"github.com/aws/aws-sdk-go/service/kinesisvideo"
+	"github.com/aws/aws-sdk-go/service/kinesisvideomedia"
+	"github.com/rapidaai/protos"
+)
+
+// NewSession creates an AWS session from an amazon-connect vault credential
+// with access_key_id, secret_access_key and region.
+func NewSession(vaultCredential *protos.VaultCredential) (*awsSession.Session, error) {
+	credMap := vaultCredential.GetValue().AsMap()
+	accessKeyId, _ := credMap["access_key_id"].(string)
+	secretAccessKey, _ := credMap["secret_access_key"].(string)
+	region, _ := credMap["region"].(string)
+	if accessKeyId == "" || secretAccessKey == "" || region == "" {
+		return nil, fmt.Errorf("amazon connect credential requires access_key_id, secret_access_key and region")
+	}
+	return awsSession.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials(accessKeyId, secretAccessKey, ""),
+	})
+}
+
+// GetMedia opens the stream of a contact from the fragment media streaming
+// started with. The stream stays open while the producer keeps writing.
+func GetMedia(ctx context.Context, sess *awsSession.Session, streamARN, startFragment string) (io.ReadCloser, error) {
+	endpoint, err := kinesisvideo.New(sess).GetDataEndpointWithContext(ctx, &kinesisvideo.GetDataEndpointInput{
+		StreamARN: aws.String(streamARN),
+		APIName:   aws.String(kinesisvideo.APINameGetMedia),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media endpoint of %s: %w", streamARN, err)
+	}
+
+	selector := &kinesisvideomedia.StartSelector{StartSelectorType: aws.String(kinesisvideomedia.StartSelectorTypeNow)}
+	if startFragment != "" {
+		selector = &kinesisvideomedia.StartSelector{
+			StartSelectorType:   aws.String(kinesisvideomedia.StartSelectorTypeFragmentNumber),
+			AfterFragmentNumber: aws.String(startFragment),
+		}
+	}
+	media := kinesisvideomedia.New(sess, aws.NewConfig().WithEndpoint(aws.StringValue(endpoint.DataEndpoint)))
+	out, err := media.GetMediaWithContext(ctx, &kinesisvideomedia.GetMediaInput{
+		StreamARN:     aws.String(streamARN),
+		StartSelector: selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media of %s: %w", streamARN, err)
+	}
+	return out.Payload, nil
+}
+
+// StopContact disconnects the customer of a contact.
+func StopContact(ctx context.Context, sess *awsSession.Session, instanceID, contactID string) error {
+	_, err := connect.New(sess).StopContactWithContext(ctx, &connect.StopContactInput{
+		InstanceId: aws.String(instanceID),
+		ContactId:  aws.String(contactID),
+	})
+	return err
+}
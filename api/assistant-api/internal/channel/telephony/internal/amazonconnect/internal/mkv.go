@@ -0,0 +1,313 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_amazonconnect
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Matroska element ids the reader looks at. Master elements are entered,
+// every other element is read whole and dropped unless it is one of the
+// leaves below.
+const (
+	idEBML        = 0x1A45DFA3
+	idSegment     = 0x18538067
+	idCluster     = 0x1F43B675
+	idTracks      = 0x1654AE6B
+	idTrackEntry  = 0xAE
+	idAudio       = 0xE1
+	idBlockGroup  = 0xA0
+	idTags        = 0x1254C367
+	idTag         = 0x7373
+	idSimpleTag   = 0x67C8
+	idTrackNumber = 0xD7
+	idTrackName   = 0x536E
+	idCodecID     = 0x86
+	idSampleRate  = 0xB5
+	idChannels    = 0x9F
+	idSimpleBlock = 0xA3
+	idBlock       = 0xA1
+	idTagName     = 0x45A3
+	idTagString   = 0x4487
+)
+
+// maxElementSize bounds the leaves read into memory, a KVS fragment carries
+// a few seconds of audio.
+const maxElementSize = 16 << 20
+
+var masterElements = map[uint32]bool{
+	idEBML:       true,
+	idSegment:    true,
+	idCluster:    true,
+	idTracks:     true,
+	idTrackEntry: true,
+	idAudio:      true,
+	idBlockGroup: true,
+	idTags:       true,
+	idTag:        true,
+	idSimpleTag:  true,
+}
+
+// Track describes a track of the stream.
+type Track struct {
+	Number     uint64
+	Name       string
+	CodecID    string
+	SampleRate float64
+	Channels   uint64
+}
+
+// Frame is the payload of one block.
+type Frame struct {
+	TrackNumber uint64
+	Data        []byte
+}
+
+// MKVReader reads the Matroska stream GetMedia returns: a sequence of KVS
+// fragments, each one an EBML document whose segment has the track list,
+// clusters of blocks and the fragment tags. Sizes of segments and clusters
+// may be unknown, the reader does not track nesting and simply enters every
+// master element it knows.
+type MKVReader struct {
+	r      *bufio.Reader
+	tracks map[uint64]*Track
+	tags   map[string]string
+
+	track   *Track // track entry being read
+	tagName string // name of the simple tag being read
+}
+
+// NewMKVReader creates a reader of r.
+func NewMKVReader(r io.Reader) *MKVReader {
+	return &MKVReader{
+		r:      bufio.NewReader(r),
+		tracks: make(map[uint64]*Track),
+		tags:   make(map[string]string),
+	}
+}
+
+// Next returns the next block. Track entries and tags read on the way are
+// available from Track and Tag. Laced blocks are skipped, KVS producers do
+// not lace audio.
+func (m *MKVReader) Next() (*Frame, error) {
+	for {
+		id, err := readID(m.r)
+		if err != nil {
+			return nil, err
+		}
+		size, known, err := readSize(m.r)
+		if err != nil {
+			return nil, unexpected(err)
+		}
+
+		if masterElements[id] {
+			m.enter(id)
+			continue
+		}
+		if !known {
+			return nil, fmt.Errorf("mkv element %x has unknown size", id)
+		}
+		if size > maxElementSize {
+			return nil, fmt.Errorf("mkv element %x of %d bytes is too large", id, size)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(m.r, data); err != nil {
+			return nil, unexpected(err)
+		}
+
+		frame, err := m.leaf(id, data)
+		if err != nil {
+			return nil, err
+		}
+		if frame != nil {
+			return frame, nil
+		}
+	}
+}
+
+// Track returns the track with the given number, nil before its entry was
+// read.
+func (m *MKVReader) Track(number uint64) *Track {
+	return m.tracks[number]
+}
+
+// TrackNamed returns the track with the given name.
+func (m *MKVReader) TrackNamed(name string) *Track {
+	for _, track := range m.tracks {
+		if track.Name == name {
+			return track
+		}
+	}
+	return nil
+}
+
+// Tag returns the latest value of a simple tag.
+func (m *MKVReader) Tag(name string) string {
+	return m.tags[name]
+}
+
+func (m *MKVReader) enter(id uint32) {
+	switch id {
+	case idTrackEntry:
+		m.track = &Track{}
+	case idSimpleTag:
+		m.tagName = ""
+	}
+}
+
+func (m *MKVReader) leaf(id uint32, data []byte) (*Frame, error) {
+	switch id {
+	case idTrackNumber:
+		if m.track != nil {
+			m.track.Number = readUint(data)
+			m.tracks[m.track.Number] = m.track
+		}
+	case idTrackName:
+		if m.track != nil {
+			m.track.Name = readString(data)
+		}
+	case idCodecID:
+		if m.track != nil {
+			m.track.CodecID = readString(data)
+		}
+	case idSampleRate:
+		if m.track != nil {
+			m.track.SampleRate = readFloat(data)
+		}
+	case idChannels:
+		if m.track != nil {
+			m.track.Channels = readUint(data)
+		}
+	case idTagName:
+		m.tagName = readString(data)
+	case idTagString:
+		if m.tagName != "" {
+			m.tags[m.tagName] = readString(data)
+		}
+	case idSimpleBlock, idBlock:
+		return readBlock(data)
+	}
+	return nil, nil
+}
+
+// readBlock parses the header of a (simple) block: track number, 16 bit
+// relative timecode and flags.
+func readBlock(data []byte) (*Frame, error) {
+	track, n, err := vint(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mkv block: %w", err)
+	}
+	if len(data) < n+3 {
+		return nil, errors.New("invalid mkv block: header too short")
+	}
+	if flags := data[n+2]; flags&0x06 != 0 {
+		return nil, nil
+	}
+	return &Frame{TrackNumber: track, Data: data[n+3:]}, nil
+}
+
+func readID(r *bufio.Reader) (uint32, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	length := vintLength(first)
+	if length == 0 || length > 4 {
+		return 0, fmt.Errorf("invalid mkv element id %#x", first)
+	}
+	id := uint32(first)
+	for i := 1; i < length; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, unexpected(err)
+		}
+		id = id<<8 | uint32(b)
+	}
+	return id, nil
+}
+
+// readSize reads an element size, known is false for the reserved all ones
+// value of streamed masters.
+func readSize(r *bufio.Reader) (size uint64, known bool, err error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, false, err
+	}
+	length := vintLength(first)
+	if length == 0 {
+		return 0, false, fmt.Errorf("invalid mkv element size %#x", first)
+	}
+	buf := make([]byte, length)
+	buf[0] = first
+	if _, err := io.ReadFull(r, buf[1:]); err != nil {
+		return 0, false, err
+	}
+	size, _, err = vint(buf)
+	return size, size != 1<<(7*length)-1, err
+}
+
+// vint decodes a variable length integer without its length marker.
+func vint(b []byte) (uint64, int, error) {
+	if len(b) == 0 {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	length := vintLength(b[0])
+	if length == 0 || len(b) < length {
+		return 0, 0, fmt.Errorf("invalid variable length integer %#x", b[0])
+	}
+	value := uint64(b[0]) & uint64(0xFF>>length)
+	for _, c := range b[1:length] {
+		value = value<<8 | uint64(c)
+	}
+	return value, length, nil
+}
+
+func vintLength(first byte) int {
+	for i := 0; i < 8; i++ {
+		if first&(0x80>>i) != 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func readUint(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+func readFloat(data []byte) float64 {
+	switch len(data) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	}
+	return 0
+}
+
+// readString drops the zero padding strings may have.
+func readString(data []byte) string {
+	for len(data) > 0 && data[len(data)-1] == 0 {
+		data = data[:len(data)-1]
+	}
+	return string(data)
+}
+
+func unexpected(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_amazonconnect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// element encodes an element with a known size.
+func element(id uint32, children ...[]byte) []byte {
+	body := bytes.Join(children, nil)
+	return append(append(encodeID(id), encodeSize(len(body))...), body...)
+}
+
+// streamed encodes a master of unknown size, as KVS writes segments and
+// clusters.
+func streamed(id uint32, children ...[]byte) []byte {
+	return append(append(encodeID(id), 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF), bytes.Join(children, nil)...)
+}
+
+func encodeID(id uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	for len(b) > 1 && b[0] == 0 {
+		b = b[1:]
+	}
+	return b
+}
+
+func encodeSize(n int) []byte {
+	if n < 0x7F {
+		return []byte{0x80 | byte(n)}
+	}
+	return []byte{0x40 | byte(n>>8), byte(n)}
+}
+
+func uintElement(id uint32, v uint64) []byte {
+	return element(id, []byte{byte(v)})
+}
+
+func floatElement(id uint32, v float64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, math.Float64bits(v))
+	return element(id, b)
+}
+
+func simpleBlock(track byte, flags byte, data []byte) []byte {
+	return element(idSimpleBlock, append([]byte{0x80 | track, 0x00, 0x00, flags}, data...))
+}
+
+func tags(kv ...string) []byte {
+	var simpleTags [][]byte
+	for i := 0; i < len(kv); i += 2 {
+		simpleTags = append(simpleTags, element(idSimpleTag, element(idTagName, []byte(kv[i])), element(idTagString, []byte(kv[i+1]))))
+	}
+	return element(idTags, element(idTag, simpleTags...))
+}
+
+func trackEntry(number uint64, name string) []byte {
+	return element(idTrackEntry,
+		uintElement(idTrackNumber, number),
+		element(idTrackName, []byte(name)),
+		element(idCodecID, []byte("A_PCM/INT/LIT")),
+		element(idAudio, floatElement(idSampleRate, 8000), uintElement(idChannels, 1)),
+	)
+}
+
+func fragment(contactID string, blocks ...[]byte) []byte {
+	return append(
+		element(idEBML, element(0x4282, []byte("matroska"))),
+		streamed(idSegment,
+			element(0x1549A966, element(0x2AD7B1, []byte{0x0F, 0x42, 0x40})), // Info, skipped
+			element(idTracks, trackEntry(1, TrackFromCustomer), trackEntry(2, TrackToCustomer)),
+			tags(TagContactID, contactID, "AWS_KINESISVIDEO_FRAGMENT_NUMBER", "9134"),
+			streamed(idCluster, append([][]byte{uintElement(0xE7, 0)}, blocks...)...),
+		)...,
+	)
+}
+
+func TestMKVReader_ReadsBlocksTracksAndTags(t *testing.T) {
+	stream := append(
+		fragment("contact-1",
+			simpleBlock(1, 0x80, []byte{1, 2, 3, 4}),
+			simpleBlock(2, 0x80, []byte{9, 9}),
+			element(idBlockGroup, element(idBlock, []byte{0x81, 0x00, 0x14, 0x00, 5, 6})),
+			simpleBlock(1, 0x82, []byte{7, 7}), // laced, skipped
+		),
+		fragment("contact-2", simpleBlock(1, 0x80, []byte{8}))...,
+	)
+	reader := NewMKVReader(bytes.NewReader(stream))
+
+	frame, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Frame{TrackNumber: 1, Data: []byte{1, 2, 3, 4}}, frame)
+	assert.Equal(t, "contact-1", reader.Tag(TagContactID))
+	assert.Equal(t, "9134", reader.Tag("AWS_KINESISVIDEO_FRAGMENT_NUMBER"))
+
+	customer := reader.TrackNamed(TrackFromCustomer)
+	require.NotNil(t, customer)
+	assert.Equal(t, &Track{Number: 1, Name: TrackFromCustomer, CodecID: "A_PCM/INT/LIT", SampleRate: 8000, Channels: 1}, customer)
+	assert.Equal(t, TrackToCustomer, reader.Track(2).Name)
+	assert.Nil(t, reader.TrackNamed("VIDEO"))
+
+	frame, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), frame.TrackNumber)
+
+	frame, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Frame{TrackNumber: 1, Data: []byte{5, 6}}, frame, "block of a block group")
+
+	frame, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{8}, frame.Data, "laced block skipped")
+	assert.Equal(t, "contact-2", reader.Tag(TagContactID))
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestMKVReader_Errors(t *testing.T) {
+	block := simpleBlock(1, 0x80, []byte{1, 2, 3, 4})
+	_, err := NewMKVReader(bytes.NewReader(block[:len(block)-2])).Next()
+	assert.Equal(t, io.ErrUnexpectedEOF, err, "truncated element")
+
+	_, err = NewMKVReader(bytes.NewReader([]byte{0x00, 0x81})).Next()
+	assert.Error(t, err, "invalid id")
+
+	_, err = NewMKVReader(bytes.NewReader(streamed(idSimpleBlock))).Next()
+	assert.ErrorContains(t, err, "unknown size")
+
+	_, err = NewMKVReader(bytes.NewReader(element(idSimpleBlock, []byte{0x81}))).Next()
+	assert.ErrorContains(t, err, "header too short")
+}
+
+func TestContactData_InstanceID(t *testing.T) {
+	contact := ContactData{InstanceARN: "arn:aws:connect:us-east-1:123456789012:instance/b6070940-51ab-4aa2-97df-6b0b4bb6f2c9"}
+	assert.Equal(t, "b6070940-51ab-4aa2-97df-6b0b4bb6f2c9", contact.InstanceID())
+	contact.InstanceARN = "b6070940"
+	assert.Equal(t, "b6070940", contact.InstanceID())
+}
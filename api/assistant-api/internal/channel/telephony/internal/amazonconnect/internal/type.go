@@ -0,0 +1,83 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_amazonconnect
+
+import "strings"
+
+// Keys of the call context media of a contact.
+const (
+	MediaStreamARN     = "stream_arn"
+	MediaStartFragment = "start_fragment_number"
+	MediaContactID     = "contact_id"
+	MediaInstanceID    = "instance_id"
+)
+
+// Track names of the Kinesis Video stream of a contact.
+const (
+	TrackFromCustomer = "AUDIO_FROM_CUSTOMER"
+	TrackToCustomer   = "AUDIO_TO_CUSTOMER"
+)
+
+// TagContactID is the fragment tag with the contact the audio belongs to.
+// Connect reuses streams across contacts.
+const TagContactID = "ContactId"
+
+// ContactFlowEvent is the event Amazon Connect passes to the Lambda function
+// of an "Invoke AWS Lambda function" block.
+type ContactFlowEvent struct {
+	Name    string `json:"Name"`
+	Details struct {
+		ContactData ContactData       `json:"ContactData"`
+		Parameters  map[string]string `json:"Parameters"`
+	} `json:"Details"`
+}
+
+type ContactData struct {
+	ContactId         string            `json:"ContactId"`
+	InitialContactId  string            `json:"InitialContactId"`
+	Channel           string            `json:"Channel"`
+	InstanceARN       string            `json:"InstanceARN"`
+	InitiationMethod  string            `json:"InitiationMethod"`
+	Attributes        map[string]string `json:"Attributes"`
+	CustomerEndpoint  *Endpoint         `json:"CustomerEndpoint"`
+	SystemEndpoint    *Endpoint         `json:"SystemEndpoint"`
+	MediaStreams      MediaStreams      `json:"MediaStreams"`
+	Queue             *Queue            `json:"Queue"`
+	LanguageCode      string            `json:"LanguageCode"`
+	PreviousContactId string            `json:"PreviousContactId"`
+}
+
+type Endpoint struct {
+	Address string `json:"Address"`
+	Type    string `json:"Type"`
+}
+
+type Queue struct {
+	Name string `json:"Name"`
+	ARN  string `json:"ARN"`
+}
+
+type MediaStreams struct {
+	Customer struct {
+		Audio *AudioStream `json:"Audio"`
+	} `json:"Customer"`
+}
+
+// AudioStream is set once the flow ran a "Start media streaming" block.
+type AudioStream struct {
+	StreamARN           string `json:"StreamARN"`
+	StartFragmentNumber string `json:"StartFragmentNumber"`
+	StartTimestamp      string `json:"StartTimestamp"`
+}
+
+// InstanceID is the id part of the instance ARN
+// (arn:aws:connect:<region>:<account>:instance/<id>).
+func (c *ContactData) InstanceID() string {
+	if i := strings.LastIndex(c.InstanceARN, "instance/"); i >= 0 {
+		return c.InstanceARN[i+len("instance/"):]
+	}
+	return c.InstanceARN
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_amazonconnect_telephony
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_amazonconnect "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/amazonconnect/internal"
+	internal_telephony_base "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/base"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// connectSampleRate is the rate of the PCM tracks Connect streams.
+const connectSampleRate = 8000
+
+// Streamer reads the caller's audio of an Amazon Connect contact from its
+// Kinesis Video stream. Media streaming only carries the call out of
+// Connect, the assistant listens but its audio does not reach the caller;
+// ending the conversation stops the contact.
+type Streamer struct {
+	internal_telephony_base.BaseTelephonyStreamer
+
+	session       *awsSession.Session
+	streamARN     string
+	startFragment string
+	contactID     string
+	instanceID    string
+
+	mu      sync.Mutex
+	payload io.ReadCloser
+
+	configSent   atomic.Bool
+	closed       atomic.Bool
+	audioDropped atomic.Bool
+}
+
+// NewStreamer starts reading the stream stored on the call context. The
+// streamer lives until ctx is done or the conversation ends.
+func NewStreamer(ctx context.Context, logger commons.Logger, cc *callcontext.CallContext, vaultCred *protos.VaultCredential) (internal_type.Streamer, error) {
+	session, err := internal_amazonconnect.NewSession(vaultCred)
+	if err != nil {
+		return nil, err
+	}
+	media := func(key string) string {
+		if v, ok := cc.Media[key]; ok {
+			return fmt.Sprintf("%v", v)
+		}
+		return ""
+	}
+	s := &Streamer{
+		BaseTelephonyStreamer: internal_telephony_base.NewBaseTelephonyStreamer(
+			logger, cc, vaultCred,
+			internal_telephony_base.WithSourceAudioConfig(internal_audio.NewLinear8khzMonoAudioConfig()),
+		),
+		session:       session,
+		streamARN:     media(internal_amazonconnect.MediaStreamARN),
+		startFragment: media(internal_amazonconnect.MediaStartFragment),
+		contactID:     media(internal_amazonconnect.MediaContactID),
+		instanceID:    media(internal_amazonconnect.MediaInstanceID),
+	}
+	if s.streamARN == "" {
+		return nil, errors.New("call context has no kinesis video stream")
+	}
+	if s.contactID == "" {
+		s.contactID = cc.ChannelUUID
+	}
+
+	go s.readMedia()
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Close()
+		case <-s.Ctx.Done():
+		}
+	}()
+	return s, nil
+}
+
+// readMedia feeds the customer track to the input buffer until the stream
+// ends or moves on to another contact.
+func (s *Streamer) readMedia() {
+	payload, err := internal_amazonconnect.GetMedia(s.Ctx, s.session, s.streamARN, s.startFragment)
+	if err != nil {
+		s.Logger.Errorf("Amazon Connect: %v", err)
+		s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		return
+	}
+	s.mu.Lock()
+	s.payload = payload
+	s.mu.Unlock()
+	if s.closed.Load() {
+		payload.Close()
+		return
+	}
+
+	reader := internal_amazonconnect.NewMKVReader(payload)
+	var customerTrack uint64
+	for {
+		frame, err := reader.Next()
+		if err != nil {
+			if s.Ctx.Err() == nil && !errors.Is(err, io.EOF) {
+				s.Logger.Warnf("Amazon Connect: reading stream of contact %s failed: %v", s.contactID, err)
+			}
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+			return
+		}
+		if contact := reader.Tag(internal_amazonconnect.TagContactID); contact != "" && contact != s.contactID {
+			s.Logger.Infof("Amazon Connect: stream moved on to contact %s, contact %s ended", contact, s.contactID)
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+			return
+		}
+
+		if customerTrack == 0 {
+			customerTrack = s.customerTrack(reader)
+		}
+		if frame.TrackNumber != customerTrack {
+			continue
+		}
+
+		var audio []byte
+		s.WithInputBuffer(func(buf *bytes.Buffer) {
+			buf.Write(frame.Data)
+			if buf.Len() >= s.InputBufferThreshold() {
+				audio = bytes.Clone(buf.Bytes())
+				buf.Reset()
+			}
+		})
+		if audio != nil {
+			s.PushInput(s.CreateVoiceRequest(audio))
+		}
+	}
+}
+
+// customerTrack finds the AUDIO_FROM_CUSTOMER track, falling back to the
+// first track for streams that do not name theirs.
+func (s *Streamer) customerTrack(reader *internal_amazonconnect.MKVReader) uint64 {
+	track := reader.TrackNamed(internal_amazonconnect.TrackFromCustomer)
+	if track == nil {
+		track = reader.Track(1)
+	}
+	if track == nil {
+		return 0
+	}
+	if track.SampleRate != 0 && track.SampleRate != connectSampleRate {
+		s.Logger.Warnf("Amazon Connect: track %s is %.0f Hz, audio is read as %d Hz", track.Name, track.SampleRate, connectSampleRate)
+	}
+	return track.Number
+}
+
+// Recv opens the conversation, then returns the audio and events readMedia
+// pushed.
+func (s *Streamer) Recv() (internal_type.Stream, error) {
+	if s.configSent.CompareAndSwap(false, true) {
+		return s.CreateConnectionRequest(), nil
+	}
+	return s.BaseTelephonyStreamer.Recv()
+}
+
+func (s *Streamer) Send(response internal_type.Stream) error {
+	switch data := response.(type) {
+	case *protos.ConversationAssistantMessage:
+		if _, ok := data.Message.(*protos.ConversationAssistantMessage_Audio); ok && s.audioDropped.CompareAndSwap(false, true) {
+			s.Logger.Infof("Amazon Connect: media streaming is receive only, assistant audio of contact %s is not played", s.contactID)
+		}
+	case *protos.ConversationDirective:
+		if data.GetType() == protos.ConversationDirective_END_CONVERSATION {
+			if s.instanceID != "" {
+				if err := internal_amazonconnect.StopContact(s.Ctx, s.session, s.instanceID, s.contactID); err != nil {
+					s.Logger.Errorf("Error stopping Amazon Connect contact %s: %v", s.contactID, err)
+				}
+			}
+			return s.Close()
+		}
+	}
+	return nil
+}
+
+// Close stops reading the stream.
+func (s *Streamer) Close() error {
+	if !s.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	s.Cancel()
+	s.mu.Lock()
+	payload := s.payload
+	s.mu.Unlock()
+	if payload != nil {
+		payload.Close()
+	}
+	s.ResetInputBuffer()
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_amazonconnect_telephony
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_amazonconnect "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/amazonconnect/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const amazonConnectProvider = "amazon-connect"
+
+// attributePrefix namespaces contact attributes in the conversation metadata.
+const attributePrefix = "connect.attributes."
+
+// amazonConnectTelephony implements the Telephony interface for Amazon
+// Connect. Connect has no way to stream a call to a third party, the contact
+// flow starts media streaming to Kinesis Video Streams and a Lambda function
+// hands the contact over; the assistant then reads the caller's audio from
+// the stream.
+type amazonConnectTelephony struct {
+	appCfg *config.AssistantConfig
+	logger commons.Logger
+}
+
+// NewAmazonConnectTelephony creates a new Amazon Connect telephony provider
+func NewAmazonConnectTelephony(config *config.AssistantConfig, logger commons.Logger) (internal_type.Telephony, error) {
+	return &amazonConnectTelephony{
+		appCfg: config,
+		logger: logger,
+	}, nil
+}
+
+// StatusCallback handles Amazon Connect contact events forwarded from
+// EventBridge. The event name is detail.eventType, e.g. DISCONNECTED.
+func (act *amazonConnectTelephony) StatusCallback(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+) (*internal_type.StatusInfo, error) {
+	var eventDetails map[string]interface{}
+	if err := c.ShouldBindJSON(&eventDetails); err != nil {
+		act.logger.Errorf("failed to parse Amazon Connect event body: %+v", err)
+		return nil, fmt.Errorf("failed to parse Amazon Connect event body: %w", err)
+	}
+
+	eventType := "unknown"
+	if detail, ok := eventDetails["detail"].(map[string]interface{}); ok {
+		if v, ok := detail["eventType"]; ok {
+			eventType = fmt.Sprintf("%v", v)
+		}
+	} else if v, ok := eventDetails["detail-type"]; ok {
+		eventType = fmt.Sprintf("%v", v)
+	}
+	return &internal_type.StatusInfo{Event: eventType, Payload: eventDetails}, nil
+}
+
+// CatchAllStatusCallback handles catch-all status callbacks
+func (act *amazonConnectTelephony) CatchAllStatusCallback(ctx *gin.Context) (*internal_type.StatusInfo, error) {
+	return nil, nil
+}
+
+// ReceiveCall handles the contact flow event a Lambda function forwards as
+// is, after the flow ran "Start media streaming":
+//
+//	POST https://host/v1/talk/amazon-connect/call/<assistantId>
+//
+// Contact attributes become conversation metadata under connect.attributes.
+func (act *amazonConnectTelephony) ReceiveCall(c *gin.Context) (*internal_type.CallInfo, error) {
+	var event internal_amazonconnect.ContactFlowEvent
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact flow event"})
+		return nil, fmt.Errorf("failed to parse contact flow event: %w", err)
+	}
+	contact := event.Details.ContactData
+	if contact.ContactId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing ContactId in contact flow event"})
+		return nil, errors.New("missing contact id in contact flow event")
+	}
+	audio := contact.MediaStreams.Customer.Audio
+	if audio == nil || audio.StreamARN == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Contact has no media stream — run Start media streaming before invoking the function"})
+		return nil, fmt.Errorf("contact %s has no customer audio stream", contact.ContactId)
+	}
+
+	callerNumber := contact.ContactId
+	if contact.CustomerEndpoint != nil && contact.CustomerEndpoint.Address != "" {
+		callerNumber = contact.CustomerEndpoint.Address
+	}
+
+	extra := map[string]string{
+		"connect.contact_id":   contact.ContactId,
+		"connect.instance_arn": contact.InstanceARN,
+		"connect.stream_arn":   audio.StreamARN,
+	}
+	if contact.SystemEndpoint != nil && contact.SystemEndpoint.Address != "" {
+		extra["connect.system_endpoint"] = contact.SystemEndpoint.Address
+	}
+	if contact.Queue != nil && contact.Queue.Name != "" {
+		extra["connect.queue"] = contact.Queue.Name
+	}
+	for k, v := range contact.Attributes {
+		extra[attributePrefix+k] = v
+	}
+
+	return &internal_type.CallInfo{
+		ChannelUUID:  contact.ContactId,
+		CallerNumber: callerNumber,
+		Provider:     amazonConnectProvider,
+		Status:       "SUCCESS",
+		StatusInfo: internal_type.StatusInfo{Event: "webhook", Payload: map[string]string{
+			"contact_id":        contact.ContactId,
+			"initiation_method": contact.InitiationMethod,
+			"channel":           contact.Channel,
+		}},
+		Extra: extra,
+		Media: map[string]string{
+			internal_amazonconnect.MediaStreamARN:     audio.StreamARN,
+			internal_amazonconnect.MediaStartFragment: audio.StartFragmentNumber,
+			internal_amazonconnect.MediaContactID:     contact.ContactId,
+			internal_amazonconnect.MediaInstanceID:    contact.InstanceID(),
+		},
+	}, nil
+}
+
+// InboundCall answers the Lambda function with flat string values it can
+// return to the contact flow as is, they are available there as external
+// attributes.
+func (act *amazonConnectTelephony) InboundCall(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	clientNumber string,
+	assistantConversationId uint64,
+) error {
+	contextID, exists := c.Get("contextId")
+	if !exists || contextID == "" {
+		return fmt.Errorf("missing contextId — CallReciever must save call context before InboundCall")
+	}
+	c.JSON(http.StatusOK, map[string]string{
+		"rapida_context_id":      fmt.Sprintf("%v", contextID),
+		"rapida_conversation_id": fmt.Sprintf("%d", assistantConversationId),
+	})
+	return nil
+}
+
+// OutboundCall is not supported, Amazon Connect calls reach the assistant
+// from a contact flow. Outbound contacts started with
+// StartOutboundVoiceContact are handed over by their flow like inbound ones.
+func (act *amazonConnectTelephony) OutboundCall(
+	auth types.SimplePrinciple,
+	toPhone string,
+	fromPhone string,
+	assistantId, assistantConversationId uint64,
+	vaultCredential *protos.VaultCredential,
+	opts utils.Option,
+) (*internal_type.CallInfo, error) {
+	err := errors.New("amazon connect calls are handed over by a contact flow, outbound calls are not supported")
+	return &internal_type.CallInfo{Provider: amazonConnectProvider, Status: "FAILED", ErrorMessage: err.Error()}, err
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_amazonconnect_telephony
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_amazonconnect "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/amazonconnect/internal"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const contactFlowEvent = `{
+  "Name": "ContactFlowEvent",
+  "Details": {
+    "ContactData": {
+      "Attributes": {"customer_tier": "gold", "order_id": "A-17"},
+      "Channel": "VOICE",
+      "ContactId": "5ca32fbd-8f92-46af-92a5-6b0f970f0efe",
+      "CustomerEndpoint": {"Address": "+15551234567", "Type": "TELEPHONE_NUMBER"},
+      "InitiationMethod": "INBOUND",
+      "InstanceARN": "arn:aws:connect:us-east-1:123456789012:instance/b6070940-51ab-4aa2-97df-6b0b4bb6f2c9",
+      "MediaStreams": {
+        "Customer": {
+          "Audio": {
+            "StartFragmentNumber": "91343852333181432392682062622220590765191907586",
+            "StartTimestamp": "1565781909613",
+            "StreamARN": "arn:aws:kinesisvideo:us-east-1:123456789012:stream/connect-contact-stream/1565272947806"
+          }
+        }
+      },
+      "Queue": {"Name": "Support", "ARN": "arn:aws:connect:us-east-1:123456789012:instance/b6070940/queue/q1"},
+      "SystemEndpoint": {"Address": "+15557654321", "Type": "TELEPHONE_NUMBER"}
+    },
+    "Parameters": {}
+  }
+}`
+
+func newTestTelephony(t *testing.T) *amazonConnectTelephony {
+	logger, _ := commons.NewApplicationLogger()
+	tel, err := NewAmazonConnectTelephony(&config.AssistantConfig{PublicAssistantHost: "api.rapida.ai"}, logger)
+	require.NoError(t, err)
+	return tel.(*amazonConnectTelephony)
+}
+
+func newTestContext(body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/talk/amazon-connect/call/1", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, recorder
+}
+
+func TestReceiveCall(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, _ := newTestContext(contactFlowEvent)
+
+	info, err := tel.ReceiveCall(c)
+	require.NoError(t, err)
+	assert.Equal(t, "+15551234567", info.CallerNumber)
+	assert.Equal(t, "5ca32fbd-8f92-46af-92a5-6b0f970f0efe", info.ChannelUUID)
+	assert.Equal(t, amazonConnectProvider, info.Provider)
+	assert.Equal(t, "gold", info.Extra["connect.attributes.customer_tier"])
+	assert.Equal(t, "A-17", info.Extra["connect.attributes.order_id"])
+	assert.Equal(t, "Support", info.Extra["connect.queue"])
+	assert.Equal(t, "+15557654321", info.Extra["connect.system_endpoint"])
+	assert.Equal(t, map[string]string{
+		internal_amazonconnect.MediaStreamARN:     "arn:aws:kinesisvideo:us-east-1:123456789012:stream/connect-contact-stream/1565272947806",
+		internal_amazonconnect.MediaStartFragment: "91343852333181432392682062622220590765191907586",
+		internal_amazonconnect.MediaContactID:     "5ca32fbd-8f92-46af-92a5-6b0f970f0efe",
+		internal_amazonconnect.MediaInstanceID:    "b6070940-51ab-4aa2-97df-6b0b4bb6f2c9",
+	}, info.Media)
+}
+
+func TestReceiveCall_RequiresMediaStream(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, recorder := newTestContext(`{"Details":{"ContactData":{"ContactId":"c1","CustomerEndpoint":{"Address":"+1555"}}}}`)
+	_, err := tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Start media streaming")
+
+	c, recorder = newTestContext(`{"Details":{"ContactData":{}}}`)
+	_, err = tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestInboundCall(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, recorder := newTestContext("")
+	assert.Error(t, tel.InboundCall(c, nil, 1, "+1555", 42))
+
+	c.Set("contextId", "ctx-1")
+	require.NoError(t, tel.InboundCall(c, nil, 1, "+1555", 42))
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, map[string]string{"rapida_context_id": "ctx-1", "rapida_conversation_id": "42"}, response)
+}
+
+func TestStatusCallback(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, _ := newTestContext(`{"detail-type":"Amazon Connect Contact Event","detail":{"eventType":"DISCONNECTED","contactId":"c1"}}`)
+	info, err := tel.StatusCallback(c, nil, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "DISCONNECTED", info.Event)
+}
+
+func TestOutboundCall_NotSupported(t *testing.T) {
+	info, err := newTestTelephony(t).OutboundCall(nil, "+1555", "+1666", 1, 2, nil, utils.Option{})
+	assert.Error(t, err)
+	assert.Equal(t, "FAILED", info.Status)
+}
+
+func TestNewStreamer_RequiresCredentialAndStream(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	value, err := structpb.NewStruct(map[string]interface{}{"access_key_id": "AKIA", "secret_access_key": "secret", "region": "us-east-1"})
+	require.NoError(t, err)
+	credential := &protos.VaultCredential{Value: value}
+
+	_, err = NewStreamer(t.Context(), logger, &callcontext.CallContext{}, &protos.VaultCredential{})
+	assert.ErrorContains(t, err, "access_key_id")
+
+	_, err = NewStreamer(t.Context(), logger, &callcontext.CallContext{}, credential)
+	assert.ErrorContains(t, err, "kinesis video stream")
+}
@@ -9,6 +9,7 @@ package internal_asterisk_audiosocket
 import (
 	"bufio"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"io"
 )
@@ -22,7 +23,10 @@ const (
 	FrameTypeError   byte = 0xFF
 )
 
-const maxFrameSize = 65535
+const (
+	maxFrameSize = 65535
+	uuidSize     = 16
+)
 
 // Frame represents a single AudioSocket frame.
 type Frame struct {
@@ -78,3 +82,36 @@ func WriteFrame(w io.Writer, frameType byte, payload []byte) error {
 	}
 	return nil
 }
+
+// ReadUUID reads the handshake Asterisk sends first on every connection, the
+// UUID given to AudioSocket() in the dialplan, and returns it in its
+// canonical form. The dialplan passes the call contextId as that UUID.
+func ReadUUID(r *bufio.Reader) (string, error) {
+	frame, err := ReadFrame(r)
+	if err != nil {
+		return "", err
+	}
+	if frame.Type != FrameTypeUUID {
+		return "", fmt.Errorf("expected UUID frame (0x01), got frame type 0x%02x", frame.Type)
+	}
+	return FormatUUID(frame.Payload)
+}
+
+// FormatUUID formats the 16 byte payload of a UUID frame as
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func FormatUUID(payload []byte) (string, error) {
+	if len(payload) != uuidSize {
+		return "", fmt.Errorf("invalid UUID payload length: %d (expected %d)", len(payload), uuidSize)
+	}
+	h := hex.EncodeToString(payload)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32], nil
+}
+
+// ErrorCode returns the code Asterisk sends in an error frame, 0 when the
+// frame carries none.
+func (f *Frame) ErrorCode() byte {
+	if f.Type != FrameTypeError || len(f.Payload) == 0 {
+		return 0
+	}
+	return f.Payload[0]
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_asterisk_audiosocket
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUUID = []byte{
+	0x4f, 0x2b, 0x8a, 0x10, 0x3c, 0x51, 0x4e, 0x0d,
+	0x9a, 0x77, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab,
+}
+
+func TestFrame_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	audio := bytes.Repeat([]byte{0x01, 0x02}, defaultOptimalFrameSize/2)
+	require.NoError(t, WriteFrame(&buf, FrameTypeAudio, audio))
+	require.NoError(t, WriteFrame(&buf, FrameTypeHangup, nil))
+	assert.Equal(t, []byte{FrameTypeAudio, 0x01, 0x40}, buf.Bytes()[:3], "320 byte length is big-endian")
+
+	r := bufio.NewReader(&buf)
+	frame, err := ReadFrame(r)
+	require.NoError(t, err)
+	assert.Equal(t, FrameTypeAudio, frame.Type)
+	assert.Equal(t, audio, frame.Payload)
+
+	frame, err = ReadFrame(r)
+	require.NoError(t, err)
+	assert.Equal(t, FrameTypeHangup, frame.Type)
+	assert.Empty(t, frame.Payload)
+
+	_, err = ReadFrame(r)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadFrame_Truncated(t *testing.T) {
+	_, err := ReadFrame(bufio.NewReader(bytes.NewReader([]byte{FrameTypeAudio, 0x01, 0x40, 0x00})))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestReadUUID(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteFrame(&buf, FrameTypeUUID, testUUID))
+	uuid, err := ReadUUID(bufio.NewReader(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, "4f2b8a10-3c51-4e0d-9a77-0123456789ab", uuid)
+}
+
+func TestReadUUID_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteFrame(&buf, FrameTypeAudio, testUUID))
+	_, err := ReadUUID(bufio.NewReader(&buf))
+	assert.ErrorContains(t, err, "expected UUID frame")
+
+	buf.Reset()
+	require.NoError(t, WriteFrame(&buf, FrameTypeUUID, testUUID[:8]))
+	_, err = ReadUUID(bufio.NewReader(&buf))
+	assert.ErrorContains(t, err, "invalid UUID payload length")
+
+	_, err = ReadUUID(bufio.NewReader(bytes.NewReader(nil)))
+	assert.ErrorIs(t, err, io.EOF, "a probe that closes right away is reported as EOF")
+}
+
+func TestFrame_ErrorCode(t *testing.T) {
+	assert.Equal(t, byte(0x02), (&Frame{Type: FrameTypeError, Payload: []byte{0x02}}).ErrorCode())
+	assert.Equal(t, byte(0), (&Frame{Type: FrameTypeError}).ErrorCode())
+	assert.Equal(t, byte(0), (&Frame{Type: FrameTypeAudio, Payload: []byte{0x02}}).ErrorCode())
+}
+
+func TestWriteFrame_TooLarge(t *testing.T) {
+	assert.Error(t, WriteFrame(io.Discard, FrameTypeAudio, make([]byte, maxFrameSize+1)))
+}
@@ -13,14 +13,15 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"strings"
 	"sync"
 
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_asterisk "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/asterisk/internal"
 	internal_telephony_base "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/base"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Streamer implements AudioSocket media streaming over TCP.
@@ -41,6 +42,8 @@ type Streamer struct {
 
 	initialUUID string
 	configSent  bool
+
+	ariEvents *internal_asterisk.ARIEventRelay
 }
 
 // NewStreamer creates a new AudioSocket streamer.
@@ -86,6 +89,10 @@ func NewStreamer(
 	audioProcessor.SetInputAudioCallback(as.sendProcessedInputAudio)
 	audioProcessor.SetOutputChunkCallback(as.sendAudioChunk)
 	go audioProcessor.RunOutputSender(as.outputCtx)
+
+	// AudioSocket carries audio only, key presses and hangup causes come
+	// from the ARI event socket
+	as.ariEvents = internal_asterisk.StartARIEventRelay(logger, vaultCred, cc, as.PushInput)
 	return as, nil
 }
 
@@ -127,8 +134,16 @@ func (as *Streamer) Recv() (internal_type.Stream, error) {
 		return as.CreateConnectionRequest(), nil
 	}
 	for {
+		// frames arrive every 20ms, ARI events wait at most that long
+		select {
+		case msg := <-as.InputCh:
+			return msg, nil
+		default:
+		}
+
 		frame, err := ReadFrame(as.reader)
 		if err != nil {
+			as.ariEvents.Stop()
 			if err == io.EOF {
 				return nil, io.EOF
 			}
@@ -137,7 +152,11 @@ func (as *Streamer) Recv() (internal_type.Stream, error) {
 
 		switch frame.Type {
 		case FrameTypeUUID:
-			as.initialUUID = strings.TrimSpace(string(frame.Payload))
+			uuid, err := FormatUUID(frame.Payload)
+			if err != nil {
+				return nil, err
+			}
+			as.initialUUID = uuid
 			if !as.configSent {
 				as.configSent = true
 				return &protos.ConversationInitialization{
@@ -156,7 +175,8 @@ func (as *Streamer) Recv() (internal_type.Stream, error) {
 			as.WithInputBuffer(func(buf *bytes.Buffer) {
 				if buf.Len() > 0 {
 					audioRequest = &protos.ConversationUserMessage{
-						Message: &protos.ConversationUserMessage_Audio{Audio: buf.Bytes()},
+						Message: &protos.ConversationUserMessage_Audio{Audio: as.FilterInput(buf.Bytes())},
+						Time:    timestamppb.Now(),
 					}
 					buf.Reset()
 				}
@@ -167,9 +187,11 @@ func (as *Streamer) Recv() (internal_type.Stream, error) {
 		case FrameTypeSilence:
 			// Silence frame, no action needed
 		case FrameTypeHangup:
+			as.ariEvents.Stop()
 			return nil, io.EOF
 		case FrameTypeError:
-			return nil, fmt.Errorf("audiosocket error frame received")
+			as.ariEvents.Stop()
+			return nil, fmt.Errorf("audiosocket error frame received, code 0x%02x", frame.ErrorCode())
 		default:
 			// Ignore unknown frame types
 		}
@@ -195,12 +217,24 @@ func (as *Streamer) Send(response internal_type.Stream) error {
 			_ = as.writeFrame(FrameTypeHangup, nil)
 			return as.close()
 		}
+		if data.GetType() == protos.ConversationDirective_SEND_DTMF {
+			// AudioSocket frames carry audio only, key presses go through ARI
+			digits, err := internal_telephony_base.DTMFDigits(data)
+			if err != nil {
+				return err
+			}
+			if err := internal_asterisk.SendDTMFViaARI(as.VaultCredential(), as.ChannelUUID, digits); err != nil {
+				as.Logger.Error("Failed to send DTMF via ARI API", "error", err)
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
 func (as *Streamer) close() error {
+	as.ariEvents.Stop()
 	if as.outputCancel != nil {
 		as.outputCancel()
 	}
@@ -213,3 +247,8 @@ func (as *Streamer) close() error {
 	}
 	return nil
 }
+
+// SendsDTMF reports that SEND_DTMF directives reach the remote party.
+func (as *Streamer) SendsDTMF() bool {
+	return true
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_asterisk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/rapidaai/protos"
+)
+
+// SendDTMFViaARI plays digits to the channel using the Asterisk ARI API.
+// Neither chan_websocket nor AudioSocket can carry DTMF towards Asterisk.
+func SendDTMFViaARI(vaultCredential *protos.VaultCredential, channel, digits string) error {
+	if vaultCredential == nil {
+		return fmt.Errorf("vault credential is nil")
+	}
+	if channel == "" {
+		return fmt.Errorf("asterisk channel is unknown")
+	}
+
+	credMap := vaultCredential.GetValue().AsMap()
+	ariURL, _ := credMap["ari_url"].(string)
+	if ariURL == "" {
+		return fmt.Errorf("ari_url is not configured")
+	}
+	user, _ := credMap["ari_user"].(string)
+	password, _ := credMap["ari_password"].(string)
+
+	endpoint := fmt.Sprintf("%s/ari/channels/%s/dtmf?%s", ariURL, url.PathEscape(channel), url.Values{"dtmf": {digits}}.Encode())
+	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(user, password)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("ARI API returned status: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_asterisk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+const (
+	defaultARIApp = "rapida"
+
+	ariReconnectMin = time.Second
+	ariReconnectMax = 30 * time.Second
+
+	// ariContextArg prefixes the StasisStart argument carrying the contextId
+	// of an outbound call, see OutboundCall.
+	ariContextArg = "context_id="
+
+	// ariContextVar is the channel variable the dialplan keeps the contextId
+	// in. ARI only reports it when it is listed in channelvars of ari.conf.
+	ariContextVar = "RAPIDA_CONTEXT_ID"
+)
+
+// Conversation metadata keys of the call state ARI reports.
+const (
+	metadataKeyHangupCause     = "telephony.hangup_cause"
+	metadataKeyHangupCauseText = "telephony.hangup_cause_text"
+	metadataKeyBridge          = "telephony.bridge"
+)
+
+// ARIEventHandler receives the ARI events of one call. It is called on the
+// goroutine reading the event socket and must not block.
+type ARIEventHandler func(event *AsteriskARIEvent)
+
+type ariSubscription struct {
+	contextID string
+	handler   ARIEventHandler
+}
+
+// ariEventConsumer reads the ARI event WebSocket of one Asterisk credential
+// and hands every event to the call its channel belongs to. Calls whose
+// channel is not known yet are matched by contextId until the first event
+// of their channel names it.
+type ariEventConsumer struct {
+	logger commons.Logger
+	url    string
+	header http.Header
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	channels map[string]*ariSubscription
+	contexts map[string]*ariSubscription
+	refs     int
+}
+
+var (
+	ariConsumersMu sync.Mutex
+	ariConsumers   = map[string]*ariEventConsumer{}
+)
+
+// SubscribeARIEvents delivers the ARI events of a call to handler. The call
+// is found by its channel id, or by contextId when the channel id is not
+// known yet. Calls on the same credential share one event connection, which
+// is opened with the first subscription and closed with the last one.
+// The returned function ends the subscription.
+func SubscribeARIEvents(
+	logger commons.Logger,
+	vaultCredential *protos.VaultCredential,
+	channelID, contextID string,
+	handler ARIEventHandler,
+) (func(), error) {
+	if vaultCredential == nil {
+		return nil, fmt.Errorf("vault credential is nil")
+	}
+	if channelID == "" && contextID == "" {
+		return nil, fmt.Errorf("neither channel nor context of the call is known")
+	}
+	key, eventsURL, header, err := ariEventsEndpoint(vaultCredential.GetValue().AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &ariSubscription{contextID: contextID, handler: handler}
+	ariConsumersMu.Lock()
+	consumer, ok := ariConsumers[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		consumer = &ariEventConsumer{
+			logger:   logger,
+			url:      eventsURL,
+			header:   header,
+			cancel:   cancel,
+			channels: make(map[string]*ariSubscription),
+			contexts: make(map[string]*ariSubscription),
+		}
+		ariConsumers[key] = consumer
+		go consumer.run(ctx)
+	}
+	consumer.add(channelID, sub)
+	ariConsumersMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ariConsumersMu.Lock()
+			defer ariConsumersMu.Unlock()
+			if consumer.remove(sub) == 0 {
+				consumer.cancel()
+				delete(ariConsumers, key)
+			}
+		})
+	}, nil
+}
+
+// ariEventsEndpoint returns the event socket url of the credential and the
+// key consumers are shared by.
+func ariEventsEndpoint(credMap map[string]interface{}) (string, string, http.Header, error) {
+	ariURL, _ := credMap["ari_url"].(string)
+	if ariURL == "" {
+		return "", "", nil, fmt.Errorf("ari_url is not configured")
+	}
+	u, err := url.Parse(ariURL)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid ari_url: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	app := defaultARIApp
+	if v, ok := credMap["ari_app"].(string); ok && v != "" {
+		app = v
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/ari/events"
+	// subscribeAll also reports channels the dialplan sent to AudioSocket or
+	// chan_websocket, which never enter the Stasis application
+	u.RawQuery = url.Values{"app": {app}, "subscribeAll": {"true"}}.Encode()
+
+	user, _ := credMap["ari_user"].(string)
+	password, _ := credMap["ari_password"].(string)
+	req := &http.Request{Header: http.Header{}}
+	req.SetBasicAuth(user, password)
+	return ariURL + "|" + user + "|" + app, u.String(), req.Header, nil
+}
+
+func (c *ariEventConsumer) add(channelID string, sub *ariSubscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refs++
+	if channelID != "" {
+		c.channels[channelID] = sub
+		return
+	}
+	c.contexts[sub.contextID] = sub
+}
+
+// remove drops the subscription and returns how many are left.
+func (c *ariEventConsumer) remove(sub *ariSubscription) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, s := range c.channels {
+		if s == sub {
+			delete(c.channels, id)
+		}
+	}
+	if c.contexts[sub.contextID] == sub {
+		delete(c.contexts, sub.contextID)
+	}
+	c.refs--
+	return c.refs
+}
+
+func (c *ariEventConsumer) run(ctx context.Context) {
+	backoff := ariReconnectMin
+	for {
+		connected, err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = ariReconnectMin
+		}
+		c.logger.Warnw("ARI event connection lost, reconnecting", "error", err, "retry", backoff.String())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, ariReconnectMax)
+	}
+}
+
+// consume reads events until the connection fails or ctx is done.
+func (c *ariEventConsumer) consume(ctx context.Context) (bool, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.url, c.header)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+		event := &AsteriskARIEvent{}
+		if err := json.Unmarshal(message, event); err != nil {
+			c.logger.Debugw("Failed to parse ARI event", "error", err)
+			continue
+		}
+		c.dispatch(event)
+	}
+}
+
+func (c *ariEventConsumer) dispatch(event *AsteriskARIEvent) {
+	if event.Channel == nil || event.Channel.ID == "" {
+		return
+	}
+	c.mu.Lock()
+	sub, ok := c.channels[event.Channel.ID]
+	if !ok {
+		if contextID := ariEventContextID(event); contextID != "" {
+			if sub, ok = c.contexts[contextID]; ok {
+				delete(c.contexts, contextID)
+				c.channels[event.Channel.ID] = sub
+			}
+		}
+	}
+	c.mu.Unlock()
+	if ok {
+		sub.handler(event)
+	}
+}
+
+// ariEventContextID returns the contextId an event carries, from the
+// StasisStart arguments or the channel variables.
+func ariEventContextID(event *AsteriskARIEvent) string {
+	for _, arg := range event.Args {
+		if contextID, ok := strings.CutPrefix(arg, ariContextArg); ok {
+			return contextID
+		}
+	}
+	if event.Channel != nil {
+		return event.Channel.ChannelVars[ariContextVar]
+	}
+	return ""
+}
+
+// ARIEventMetadata maps the call state an ARI event reports to conversation
+// metadata, nil for events the conversation does not need.
+func ARIEventMetadata(event *AsteriskARIEvent) []*protos.Metadata {
+	switch event.Type {
+	case "StasisStart":
+		return []*protos.Metadata{{Key: internal_type.MetadataKeyChannelUUID, Value: event.Channel.ID}}
+	case "ChannelDtmfReceived":
+		if event.Digit == "" {
+			return nil
+		}
+		return []*protos.Metadata{{Key: internal_type.MetadataKeyDTMF, Value: event.Digit}}
+	case "ChannelHangupRequest":
+		metadata := []*protos.Metadata{{Key: metadataKeyHangupCause, Value: strconv.Itoa(event.Cause)}}
+		if text := hangupCauseText(event); text != "" {
+			metadata = append(metadata, &protos.Metadata{Key: metadataKeyHangupCauseText, Value: text})
+		}
+		return metadata
+	case "ChannelEnteredBridge":
+		if event.Bridge == nil {
+			return nil
+		}
+		return []*protos.Metadata{{Key: metadataKeyBridge, Value: event.Bridge.ID}}
+	case "ChannelLeftBridge":
+		return []*protos.Metadata{{Key: metadataKeyBridge, Value: ""}}
+	}
+	return nil
+}
+
+// q850Causes names the hangup causes calls commonly end with.
+var q850Causes = map[int]string{
+	1:   "Unallocated number",
+	16:  "Normal clearing",
+	17:  "User busy",
+	18:  "No user responding",
+	19:  "No answer",
+	21:  "Call rejected",
+	27:  "Destination out of order",
+	28:  "Invalid number format",
+	31:  "Normal, unspecified",
+	34:  "No circuit available",
+	38:  "Network out of order",
+	41:  "Temporary failure",
+	127: "Interworking, unspecified",
+}
+
+func hangupCauseText(event *AsteriskARIEvent) string {
+	if event.CauseTxt != "" {
+		return event.CauseTxt
+	}
+	return q850Causes[event.Cause]
+}
+
+// ARIEventRelay passes the ARI events of a call to its streamer as
+// conversation metadata.
+type ARIEventRelay struct {
+	unsubscribe func()
+}
+
+// StartARIEventRelay subscribes to the ARI events of the call and pushes
+// their metadata with push. The channel id is reported with the first event
+// when the call context did not know it. Credentials without ARI access
+// relay nothing.
+func StartARIEventRelay(
+	logger commons.Logger,
+	vaultCredential *protos.VaultCredential,
+	cc *callcontext.CallContext,
+	push func(internal_type.Stream),
+) *ARIEventRelay {
+	var reported atomic.Bool
+	reported.Store(cc.ChannelUUID != "")
+	unsubscribe, err := SubscribeARIEvents(logger, vaultCredential, cc.ChannelUUID, cc.ContextID, func(event *AsteriskARIEvent) {
+		metadata := ARIEventMetadata(event)
+		if !reported.Swap(true) && event.Type != "StasisStart" {
+			metadata = append([]*protos.Metadata{{Key: internal_type.MetadataKeyChannelUUID, Value: event.Channel.ID}}, metadata...)
+		}
+		if len(metadata) > 0 {
+			push(&protos.ConversationMetadata{Metadata: metadata})
+		}
+	})
+	if err != nil {
+		logger.Debugw("ARI events are not relayed for the call", "contextId", cc.ContextID, "reason", err.Error())
+		return &ARIEventRelay{}
+	}
+	return &ARIEventRelay{unsubscribe: unsubscribe}
+}
+
+// Stop ends the relay, it is safe to call more than once.
+func (r *ARIEventRelay) Stop() {
+	if r != nil && r.unsubscribe != nil {
+		r.unsubscribe()
+	}
+}
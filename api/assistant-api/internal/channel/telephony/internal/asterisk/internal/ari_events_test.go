@@ -0,0 +1,136 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_asterisk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fakeARI serves the ARI event socket and writes whatever is sent on events
+// to every connection.
+func fakeARI(t *testing.T, events chan string) (*httptest.Server, chan *http.Request) {
+	requests := make(chan *http.Request, 4)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for event := range events {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(event)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func ariCredential(t *testing.T, ariURL string) *protos.VaultCredential {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"ari_url":      ariURL,
+		"ari_user":     "rapida",
+		"ari_password": "secret",
+	})
+	require.NoError(t, err)
+	return &protos.VaultCredential{Value: value}
+}
+
+func TestSubscribeARIEvents_DeliversEventsOfTheChannel(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	events := make(chan string, 4)
+	defer close(events)
+	server, requests := fakeARI(t, events)
+
+	received := make(chan *AsteriskARIEvent, 4)
+	unsubscribe, err := SubscribeARIEvents(logger, ariCredential(t, server.URL), "1700000000.42", "", func(event *AsteriskARIEvent) {
+		received <- event
+	})
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	select {
+	case r := <-requests:
+		assert.Equal(t, "/ari/events", r.URL.Path)
+		assert.Equal(t, "rapida", r.URL.Query().Get("app"))
+		assert.Equal(t, "true", r.URL.Query().Get("subscribeAll"))
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "rapida", user)
+		assert.Equal(t, "secret", password)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the event socket was not opened")
+	}
+
+	events <- `{"type":"ChannelDtmfReceived","digit":"7","channel":{"id":"1700000000.99"}}`
+	events <- `{"type":"ChannelDtmfReceived","digit":"5","duration_ms":120,"channel":{"id":"1700000000.42"}}`
+
+	select {
+	case event := <-received:
+		assert.Equal(t, "5", event.Digit, "events of other channels are not delivered")
+		assert.Equal(t, 120, event.DurationMs)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event delivered")
+	}
+}
+
+func TestARIEventConsumer_MatchesContextOnFirstEvent(t *testing.T) {
+	c := &ariEventConsumer{channels: map[string]*ariSubscription{}, contexts: map[string]*ariSubscription{}}
+	var got []string
+	sub := &ariSubscription{contextID: "ctx-1", handler: func(event *AsteriskARIEvent) { got = append(got, event.Type) }}
+	c.add("", sub)
+
+	c.dispatch(&AsteriskARIEvent{Type: "ChannelDtmfReceived", Channel: &AsteriskChannel{ID: "chan-1"}})
+	c.dispatch(&AsteriskARIEvent{Type: "StasisStart", Args: []string{"incoming", "context_id=ctx-1"}, Channel: &AsteriskChannel{ID: "chan-1"}})
+	c.dispatch(&AsteriskARIEvent{Type: "ChannelHangupRequest", Channel: &AsteriskChannel{ID: "chan-1"}})
+	assert.Equal(t, []string{"StasisStart", "ChannelHangupRequest"}, got, "the channel is known once StasisStart names the context")
+
+	other := &ariSubscription{contextID: "ctx-2", handler: func(event *AsteriskARIEvent) { got = append(got, "ctx-2:"+event.Type) }}
+	c.add("", other)
+	c.dispatch(&AsteriskARIEvent{Type: "ChannelDtmfReceived", Channel: &AsteriskChannel{ID: "chan-2", ChannelVars: map[string]string{ariContextVar: "ctx-2"}}})
+	assert.Equal(t, "ctx-2:ChannelDtmfReceived", got[len(got)-1], "dialplan calls are matched by channel variable")
+
+	assert.Equal(t, 1, c.remove(sub))
+	assert.Equal(t, 0, c.remove(other))
+	assert.Empty(t, c.channels)
+}
+
+func TestARIEventMetadata(t *testing.T) {
+	channel := &AsteriskChannel{ID: "chan-1"}
+	assert.Equal(t, []*protos.Metadata{{Key: internal_type.MetadataKeyChannelUUID, Value: "chan-1"}},
+		ARIEventMetadata(&AsteriskARIEvent{Type: "StasisStart", Channel: channel}))
+	assert.Equal(t, []*protos.Metadata{{Key: internal_type.MetadataKeyDTMF, Value: "#"}},
+		ARIEventMetadata(&AsteriskARIEvent{Type: "ChannelDtmfReceived", Digit: "#", Channel: channel}))
+	assert.Equal(t, []*protos.Metadata{
+		{Key: metadataKeyHangupCause, Value: "17"},
+		{Key: metadataKeyHangupCauseText, Value: "User busy"},
+	}, ARIEventMetadata(&AsteriskARIEvent{Type: "ChannelHangupRequest", Cause: 17, Channel: channel}))
+	assert.Equal(t, []*protos.Metadata{{Key: metadataKeyBridge, Value: "bridge-1"}},
+		ARIEventMetadata(&AsteriskARIEvent{Type: "ChannelEnteredBridge", Bridge: &AsteriskBridge{ID: "bridge-1"}, Channel: channel}))
+	assert.Nil(t, ARIEventMetadata(&AsteriskARIEvent{Type: "ChannelVarset", Channel: channel}))
+}
+
+func TestStartARIEventRelay_WithoutARI(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	relay := StartARIEventRelay(logger, nil, &callcontext.CallContext{ContextID: "ctx-1"}, func(internal_type.Stream) {})
+	relay.Stop()
+	relay.Stop()
+}
@@ -125,6 +125,17 @@ type AsteriskARIEvent struct {
 	Bridge    *AsteriskBridge        `json:"bridge,omitempty"`
 	Peer      *AsteriskChannel       `json:"peer,omitempty"`
 	Extra     map[string]interface{} `json:"-"`
+
+	// StasisStart
+	Args []string `json:"args,omitempty"`
+
+	// ChannelDtmfReceived
+	Digit      string `json:"digit,omitempty"`
+	DurationMs int    `json:"duration_ms,omitempty"`
+
+	// ChannelHangupRequest, ChannelDestroyed
+	Cause    int    `json:"cause,omitempty"`
+	CauseTxt string `json:"cause_txt,omitempty"`
 }
 
 // AsteriskChannel represents a channel in ARI
@@ -190,10 +190,16 @@ func (apt *asteriskTelephony) OutboundCall(
 		}
 	}
 
+	contextID, _ := opts.GetString("rapida.context_id")
 	if !hasDialplan {
-		// No dialplan context — use Stasis app mode
+		// No dialplan context — use Stasis app mode. The contextId argument
+		// lets the ARI event consumer match StasisStart to the call.
 		params.Set("app", appName)
-		params.Set("appArgs", fmt.Sprintf("incoming,assistant_id=%d,conversation_id=%d", assistantId, assistantConversationId))
+		appArgs := fmt.Sprintf("incoming,assistant_id=%d,conversation_id=%d", assistantId, assistantConversationId)
+		if contextID != "" {
+			appArgs += ",context_id=" + contextID
+		}
+		params.Set("appArgs", appArgs)
 	}
 
 	// Build channel variables as a JSON body.
@@ -201,7 +207,7 @@ func (apt *asteriskTelephony) OutboundCall(
 	// The RAPIDA_CONTEXT_ID variable is essential — the Asterisk dialplan uses it
 	// as the AudioSocket UUID so the AudioSocket server can resolve the call context.
 	channelVars := map[string]string{}
-	if contextID, err := opts.GetString("rapida.context_id"); err == nil && contextID != "" {
+	if contextID != "" {
 		channelVars["RAPIDA_CONTEXT_ID"] = contextID
 	}
 
@@ -40,6 +40,8 @@ type asteriskWebsocketStreamer struct {
 	// Media buffering state
 	mediaBuffering bool
 	mediaBufferMu  sync.Mutex
+
+	ariEvents *internal_asterisk.ARIEventRelay
 }
 
 // NewAsteriskWebsocketStreamer creates a new Asterisk WebSocket streamer.
@@ -66,6 +68,7 @@ func NewAsteriskWebsocketStreamer(
 	// Set up callbacks
 	audioProcessor.SetInputAudioCallback(aws.sendProcessedInputAudio)
 	audioProcessor.SetOutputChunkCallback(aws.sendAudioChunk)
+	aws.ariEvents = internal_asterisk.StartARIEventRelay(logger, vaultCred, cc, aws.PushInput)
 	return aws
 }
 
@@ -96,6 +99,11 @@ func (aws *asteriskWebsocketStreamer) Recv() (internal_type.Stream, error) {
 	if aws.connection == nil {
 		return nil, aws.handleError("WebSocket connection is nil", io.EOF)
 	}
+	select {
+	case msg := <-aws.InputCh:
+		return msg, nil
+	default:
+	}
 	messageType, message, err := aws.connection.ReadMessage()
 	if err != nil {
 		return nil, aws.handleWebSocketError(err)
@@ -215,6 +223,16 @@ func (aws *asteriskWebsocketStreamer) Send(response internal_type.Stream) error
 				aws.Logger.Errorf("Error disconnecting:", err)
 			}
 		}
+		if data.GetType() == protos.ConversationDirective_SEND_DTMF {
+			digits, err := internal_telephony_base.DTMFDigits(data)
+			if err != nil {
+				return err
+			}
+			if err := internal_asterisk.SendDTMFViaARI(aws.VaultCredential(), aws.channelName, digits); err != nil {
+				aws.Logger.Error("Failed to send DTMF via ARI API", "error", err)
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -321,9 +339,15 @@ func (aws *asteriskWebsocketStreamer) hangupViaARI() error {
 }
 
 func (tws *asteriskWebsocketStreamer) Cancel() error {
+	tws.ariEvents.Stop()
 	if tws.connection != nil {
 		tws.connection.Close()
 		tws.connection = nil
 	}
 	return nil
 }
+
+// SendsDTMF reports that SEND_DTMF directives reach the remote party.
+func (aws *asteriskWebsocketStreamer) SendsDTMF() bool {
+	return true
+}
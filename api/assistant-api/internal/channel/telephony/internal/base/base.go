@@ -8,15 +8,17 @@ package internal_telephony_base
 
 import (
 	"encoding/base64"
+	"fmt"
 
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
 	internal_audio_resampler "github.com/rapidaai/api/assistant-api/internal/audio/resampler"
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
-	channel_base "github.com/rapidaai/api/assistant-api/internal/channel/base"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/streamers"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TelephonyOption configures a BaseTelephonyStreamer.
@@ -27,8 +29,8 @@ type telephonyConfig struct {
 	// provider. Defaults to RAPIDA_AUDIO_CONFIG (linear16 16kHz) if nil.
 	sourceAudioConfig *protos.AudioConfig
 
-	// baseOpts are forwarded to channel_base.NewBaseStreamer.
-	baseOpts []channel_base.Option
+	// baseOpts are forwarded to streamers.NewBaseStreamer.
+	baseOpts []streamers.Option
 }
 
 // WithSourceAudioConfig sets the native audio format of the telephony provider.
@@ -38,10 +40,10 @@ func WithSourceAudioConfig(cfg *protos.AudioConfig) TelephonyOption {
 	return func(c *telephonyConfig) { c.sourceAudioConfig = cfg }
 }
 
-// WithBaseOption appends one or more channel_base.Option to the underlying
+// WithBaseOption appends one or more streamers.Option to the underlying
 // BaseStreamer configuration. Use this for advanced overrides (channel sizes,
 // explicit thresholds, etc.).
-func WithBaseOption(opts ...channel_base.Option) TelephonyOption {
+func WithBaseOption(opts ...streamers.Option) TelephonyOption {
 	return func(c *telephonyConfig) { c.baseOpts = append(c.baseOpts, opts...) }
 }
 
@@ -52,14 +54,14 @@ var RAPIDA_AUDIO_CONFIG = internal_audio.NewLinear16khzMonoAudioConfig()
 // BaseTelephonyStreamer — telephony-specific base that embeds BaseStreamer
 // ============================================================================
 
-// BaseTelephonyStreamer embeds channel_base.BaseStreamer for common buffer,
+// BaseTelephonyStreamer embeds streamers.BaseStreamer for common buffer,
 // channel, and lifecycle management. It adds telephony-specific concerns:
 // call context, audio resampler, base64 encoder, and vault credentials.
 //
 // Concrete telephony streamers (Twilio, Exotel, Vonage, SIP, Asterisk) embed
 // this struct and only implement transport-specific I/O logic.
 type BaseTelephonyStreamer struct {
-	channel_base.BaseStreamer
+	streamers.BaseStreamer
 
 	// callCtx holds IDs and metadata from the call setup phase (Redis).
 	// Replaces separate assistant/conversation entity references — the
@@ -113,16 +115,20 @@ func NewBaseTelephonyStreamer(
 	}
 
 	// Build base options: derive thresholds from the source audio config,
-	// then allow caller to override via WithBaseOption.
-	baseOpts := []channel_base.Option{
-		channel_base.WithInputAudioConfig(sourceAudioCfg),
-		channel_base.WithOutputAudioConfig(sourceAudioCfg),
+	// then allow caller to override via WithBaseOption. The caller's audio
+	// keeps its latest frames when the talk loop falls behind, text to
+	// speech waits for the paced writer instead of cutting the reply.
+	baseOpts := []streamers.Option{
+		streamers.WithInputAudioConfig(sourceAudioCfg),
+		streamers.WithOutputAudioConfig(sourceAudioCfg),
+		streamers.WithInputBackpressure(streamers.Backpressure{Policy: streamers.DropOldest}),
+		streamers.WithOutputBackpressure(streamers.Backpressure{Policy: streamers.BlockWithTimeout}),
 	}
 	baseOpts = append(baseOpts, tc.baseOpts...)
 
 	resampler, _ := internal_audio_resampler.GetResampler(logger)
 	return BaseTelephonyStreamer{
-		BaseStreamer:      channel_base.NewBaseStreamer(logger, baseOpts...),
+		BaseStreamer:      streamers.NewBaseStreamer(logger, baseOpts...),
 		callCtx:           cc,
 		resampler:         resampler,
 		encoder:           base64.StdEncoding,
@@ -137,8 +143,9 @@ func NewBaseTelephonyStreamer(
 // ============================================================================
 
 // CreateVoiceRequest resamples raw audio from the provider's native format
-// to the internal Rapida format (linear16 16kHz) and wraps it in a
-// ConversationUserMessage for downstream processing.
+// to the internal Rapida format (linear16 16kHz), runs it through the input
+// pipeline and wraps it in a ConversationUserMessage for downstream
+// processing.
 func (base *BaseTelephonyStreamer) CreateVoiceRequest(audioData []byte) *protos.ConversationUserMessage {
 	// base.Logger.Debugw("CreateVoiceRequest: Resampling audio",
 	// 	"input_size", len(audioData),
@@ -163,8 +170,9 @@ func (base *BaseTelephonyStreamer) CreateVoiceRequest(audioData []byte) *protos.
 
 	return &protos.ConversationUserMessage{
 		Message: &protos.ConversationUserMessage_Audio{
-			Audio: resampled,
+			Audio: base.FilterInput(resampled),
 		},
+		Time: timestamppb.Now(),
 	}
 }
 
@@ -219,3 +227,12 @@ func (base *BaseTelephonyStreamer) CreateConnectionRequest() *protos.Conversatio
 		StreamMode:              protos.StreamMode_STREAM_MODE_AUDIO,
 	}
 }
+
+// DTMFDigits returns the digits carried by a SEND_DTMF directive.
+func DTMFDigits(directive *protos.ConversationDirective) (string, error) {
+	arg, ok := directive.GetArgs()["digits"]
+	if !ok {
+		return "", fmt.Errorf("send dtmf directive without digits")
+	}
+	return utils.AnyToString(arg)
+}
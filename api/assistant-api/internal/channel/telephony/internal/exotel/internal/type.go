@@ -5,14 +5,65 @@
 // See LICENSE.md or contact sales@rapida.ai for commercial usage.
 package internal_exotel
 
+import "encoding/json"
+
+// Voicebot applet chunk rules for the media sent to Exotel: a multiple of
+// 320 bytes (20ms of linear16 8kHz), at least 3.2k or the caller hears gaps
+// between chunks, and at most 100k.
+const (
+	VoicebotChunkMultiple = 320
+	VoicebotMinChunk      = 3200
+	VoicebotMaxChunk      = 100000
+)
+
+// ExotelMediaEvent is a message of the Voicebot applet stream, one of
+// connected, start, media, dtmf, mark and stop.
 type ExotelMediaEvent struct {
-	Event     string       `json:"event"`
-	StreamSid string       `json:"stream_sid"`
-	Media     *ExotelMedia `json:"media,omitempty"`
+	Event          string       `json:"event"`
+	SequenceNumber json.Number  `json:"sequence_number,omitempty"`
+	StreamSid      string       `json:"stream_sid"`
+	Start          *ExotelStart `json:"start,omitempty"`
+	Media          *ExotelMedia `json:"media,omitempty"`
+	Dtmf           *ExotelDtmf  `json:"dtmf,omitempty"`
+	Mark           *ExotelMark  `json:"mark,omitempty"`
+	Stop           *ExotelStop  `json:"stop,omitempty"`
+}
+
+type ExotelStart struct {
+	StreamSid        string            `json:"stream_sid"`
+	CallSid          string            `json:"call_sid"`
+	AccountSid       string            `json:"account_sid"`
+	From             string            `json:"from"`
+	To               string            `json:"to"`
+	CustomParameters map[string]string `json:"custom_parameters,omitempty"`
+	MediaFormat      struct {
+		Encoding   string      `json:"encoding"`
+		SampleRate json.Number `json:"sample_rate"`
+		BitRate    string      `json:"bit_rate"`
+	} `json:"media_format"`
 }
 
 type ExotelMedia struct {
-	Payload string `json:"payload"`
+	Chunk     json.Number `json:"chunk,omitempty"`
+	Timestamp json.Number `json:"timestamp,omitempty"`
+	Payload   string      `json:"payload"`
+}
+
+type ExotelDtmf struct {
+	Digit    string      `json:"digit"`
+	Duration json.Number `json:"duration,omitempty"`
+}
+
+// ExotelMark names a point in the media sent, Exotel echoes it back once
+// the audio before it was played or cleared.
+type ExotelMark struct {
+	Name string `json:"name"`
+}
+
+type ExotelStop struct {
+	CallSid    string `json:"call_sid"`
+	AccountSid string `json:"account_sid"`
+	Reason     string `json:"reason"`
 }
 
 type MakeCallResponse struct {
@@ -130,6 +130,14 @@ func (tpc *exotelTelephony) OutboundCall(
 	formData.Set("StatusCallback", fmt.Sprintf("https://%s/%s", tpc.appCfg.PublicAssistantHost, internal_type.GetContextEventPath(exotelProvider, contextID)))
 	// for exotel there is no way to set dynamic path so pass it as custom filed
 	formData.Set("CustomField", internal_type.GetContextAnswerPath(exotelProvider, contextID))
+	// promotional calls are scrubbed against the DND register by the carrier,
+	// transactional ones reach DND numbers too
+	switch callType, _ := opts.GetString(internal_type.CallTypeOption); callType {
+	case internal_type.CallTypeTransactional:
+		formData.Set("CallType", "trans")
+	case internal_type.CallTypePromotional:
+		formData.Set("CallType", "promo")
+	}
 
 	client := &http.Client{Timeout: 60 * time.Second}
 	req, err := http.NewRequest("POST", *clientUrl, strings.NewReader(formData.Encode()))
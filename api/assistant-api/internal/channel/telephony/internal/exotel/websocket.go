@@ -9,7 +9,11 @@ package internal_exotel_telephony
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
@@ -18,6 +22,7 @@ import (
 	internal_exotel "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/exotel/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/streamers"
 	"github.com/rapidaai/protos"
 )
 
@@ -29,6 +34,11 @@ type exotelWebsocketStreamer struct {
 
 	connection *websocket.Conn
 	streamID   string
+
+	// marks sent after each response and not yet echoed, by name
+	marksMu sync.Mutex
+	marks   map[string]time.Time
+	markSeq uint64
 }
 
 func NewExotelWebsocketStreamer(logger commons.Logger, connection *websocket.Conn, cc *callcontext.CallContext, vaultCred *protos.VaultCredential,
@@ -37,13 +47,22 @@ func NewExotelWebsocketStreamer(logger commons.Logger, connection *websocket.Con
 		BaseTelephonyStreamer: internal_telephony_base.NewBaseTelephonyStreamer(
 			logger, cc, vaultCred,
 			internal_telephony_base.WithSourceAudioConfig(EXOTEL_LINEAR_8K_AUDIO_CONFIG),
+			// the voicebot applet leaves gaps between chunks under 3.2k
+			internal_telephony_base.WithBaseOption(streamers.WithOutputFrameSize(internal_exotel.VoicebotMinChunk)),
 		),
 		streamID:   "",
 		connection: connection,
+		marks:      make(map[string]time.Time),
 	}
 }
 
 func (exotel *exotelWebsocketStreamer) Recv() (internal_type.Stream, error) {
+	// metadata queued by the start event follows the connection request
+	select {
+	case msg := <-exotel.InputCh:
+		return msg, nil
+	default:
+	}
 	if exotel.connection == nil {
 		return nil, io.EOF
 	}
@@ -74,8 +93,19 @@ func (exotel *exotelWebsocketStreamer) Recv() (internal_type.Stream, error) {
 		}
 		return msg, err
 	case "dtmf":
+		if mediaEvent.Dtmf == nil || mediaEvent.Dtmf.Digit == "" {
+			return nil, nil
+		}
+		return &protos.ConversationMetadata{
+			Metadata: []*protos.Metadata{{Key: internal_type.MetadataKeyDTMF, Value: mediaEvent.Dtmf.Digit}},
+		}, nil
+	case "mark":
+		exotel.handleMarkEvent(mediaEvent)
 		return nil, nil
 	case "stop":
+		if mediaEvent.Stop != nil {
+			exotel.Logger.Infof("Exotel stream stopped for call %s: %s", mediaEvent.Stop.CallSid, mediaEvent.Stop.Reason)
+		}
 		exotel.Cancel()
 		return nil, io.EOF
 	default:
@@ -111,9 +141,13 @@ func (exotel *exotelWebsocketStreamer) Send(response internal_type.Stream) error
 						return
 					}
 				}
-				// Flush remaining audio when response is marked complete
-				if data.GetCompleted() && buf.Len() > 0 {
-					remainingChunk := buf.Bytes()
+				if !data.GetCompleted() || exotel.streamID == "" {
+					return
+				}
+				// Flush remaining audio when response is marked complete,
+				// padded with silence to the 320 byte multiple Exotel plays
+				if buf.Len() > 0 {
+					remainingChunk := padChunk(buf.Bytes())
 					if err := exotel.sendingExotelMessage("media", map[string]interface{}{
 						"payload": exotel.Encoder().EncodeToString(remainingChunk),
 					}); err != nil {
@@ -123,6 +157,9 @@ func (exotel *exotelWebsocketStreamer) Send(response internal_type.Stream) error
 					}
 					buf.Reset()
 				}
+				if err := exotel.sendMark(data.GetId()); err != nil {
+					exotel.Logger.Errorf("Failed to send mark", "error", err.Error())
+				}
 			})
 			return sendErr
 		}
@@ -133,6 +170,10 @@ func (exotel *exotelWebsocketStreamer) Send(response internal_type.Stream) error
 			if err := exotel.sendingExotelMessage("clear", nil); err != nil {
 				exotel.Logger.Errorf("Error sending clear command:", err)
 			}
+			// cleared marks are echoed as well, they did not play
+			exotel.marksMu.Lock()
+			clear(exotel.marks)
+			exotel.marksMu.Unlock()
 		}
 	case *protos.ConversationDirective:
 		if data.GetType() == protos.ConversationDirective_END_CONVERSATION {
@@ -140,6 +181,10 @@ func (exotel *exotelWebsocketStreamer) Send(response internal_type.Stream) error
 				exotel.Logger.Errorf("Error disconnecting command:", err)
 			}
 		}
+		if data.GetType() == protos.ConversationDirective_SEND_DTMF {
+			// the voicebot applet stream only receives DTMF
+			exotel.Logger.Warnf("sending DTMF is not supported on exotel voicebot streams, call %s", exotel.ChannelUUID)
+		}
 	}
 	return nil
 }
@@ -147,6 +192,71 @@ func (exotel *exotelWebsocketStreamer) Send(response internal_type.Stream) error
 // start event contains streamSid to be used for subsequent media messages
 func (exotel *exotelWebsocketStreamer) handleStartEvent(mediaEvent internal_exotel.ExotelMediaEvent) {
 	exotel.streamID = mediaEvent.StreamSid
+	start := mediaEvent.Start
+	if start == nil {
+		return
+	}
+	if exotel.streamID == "" {
+		exotel.streamID = start.StreamSid
+	}
+	format := start.MediaFormat
+	if rate := format.SampleRate.String(); rate != "" && rate != "8000" {
+		// audio is exchanged as linear16 8kHz, the stream url must not ask
+		// for another sample rate
+		exotel.Logger.Warnf("exotel stream %s is at %s Hz, expected 8000", exotel.streamID, rate)
+	}
+	fields := map[string]string{
+		"call_sid":    start.CallSid,
+		"account_sid": start.AccountSid,
+		"stream_sid":  exotel.streamID,
+		"from":        start.From,
+		"to":          start.To,
+	}
+	if format.Encoding != "" {
+		fields["media_format"] = fmt.Sprintf("%s;rate=%s", format.Encoding, format.SampleRate)
+	}
+	exotel.PushTransportMetadata(exotelProvider, fields)
+}
+
+// handleMarkEvent notes that the audio of a response before its mark was
+// played to the caller.
+func (exotel *exotelWebsocketStreamer) handleMarkEvent(mediaEvent internal_exotel.ExotelMediaEvent) {
+	if mediaEvent.Mark == nil {
+		return
+	}
+	exotel.marksMu.Lock()
+	sent, ok := exotel.marks[mediaEvent.Mark.Name]
+	delete(exotel.marks, mediaEvent.Mark.Name)
+	exotel.marksMu.Unlock()
+	if ok {
+		exotel.Logger.Debugf("exotel played response %s, %s after it was sent", mediaEvent.Mark.Name, time.Since(sent))
+	}
+}
+
+// sendMark asks Exotel to echo a mark once the audio sent before it has
+// played. Responses without an id are numbered.
+func (exotel *exotelWebsocketStreamer) sendMark(name string) error {
+	exotel.marksMu.Lock()
+	if name == "" {
+		exotel.markSeq++
+		name = "response-" + strconv.FormatUint(exotel.markSeq, 10)
+	}
+	exotel.marks[name] = time.Now()
+	exotel.marksMu.Unlock()
+	return exotel.writeExotelMessage(map[string]interface{}{
+		"event":      "mark",
+		"stream_sid": exotel.streamID,
+		"mark":       map[string]string{"name": name},
+	})
+}
+
+// padChunk pads the last audio of a response with silence to a multiple of
+// VoicebotChunkMultiple bytes.
+func padChunk(chunk []byte) []byte {
+	if rem := len(chunk) % internal_exotel.VoicebotChunkMultiple; rem != 0 {
+		chunk = append(chunk, make([]byte, internal_exotel.VoicebotChunkMultiple-rem)...)
+	}
+	return chunk
 }
 
 func (exotel *exotelWebsocketStreamer) handleMediaEvent(mediaEvent internal_exotel.ExotelMediaEvent) (*protos.ConversationUserMessage, error) {
@@ -168,16 +278,20 @@ func (exotel *exotelWebsocketStreamer) handleMediaEvent(mediaEvent internal_exot
 }
 
 func (exotel *exotelWebsocketStreamer) sendingExotelMessage(eventType string, mediaData map[string]interface{}) error {
-	if exotel.connection == nil || exotel.streamID == "" {
-		return nil
-	}
 	message := map[string]interface{}{
-		"event":     eventType,
-		"streamSid": exotel.streamID,
+		"event":      eventType,
+		"stream_sid": exotel.streamID,
 	}
 	if mediaData != nil {
 		message["media"] = mediaData
 	}
+	return exotel.writeExotelMessage(message)
+}
+
+func (exotel *exotelWebsocketStreamer) writeExotelMessage(message map[string]interface{}) error {
+	if exotel.connection == nil || exotel.streamID == "" {
+		return nil
+	}
 	exotelMessageJSON, err := json.Marshal(message)
 	if err != nil {
 		return exotel.handleError("Failed to marshal Exotel message", err)
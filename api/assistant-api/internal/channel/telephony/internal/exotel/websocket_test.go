@@ -0,0 +1,170 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_exotel_telephony
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_exotel "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/exotel/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// voicebotPair connects an Exotel streamer to a websocket whose server side
+// plays the voicebot applet.
+func voicebotPair(t *testing.T) (*exotelWebsocketStreamer, *websocket.Conn) {
+	exotel := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		exotel <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	logger, _ := commons.NewApplicationLogger()
+	streamer := NewExotelWebsocketStreamer(logger, conn, &callcontext.CallContext{
+		AssistantID:    1,
+		ConversationID: 2,
+	}, nil).(*exotelWebsocketStreamer)
+	return streamer, <-exotel
+}
+
+func startEvent() map[string]interface{} {
+	return map[string]interface{}{
+		"event":           "start",
+		"sequence_number": 1,
+		"stream_sid":      "st-1",
+		"start": map[string]interface{}{
+			"stream_sid":  "st-1",
+			"call_sid":    "call-1",
+			"account_sid": "acc-1",
+			"from":        "+919876543210",
+			"to":          "+918047112233",
+			"media_format": map[string]interface{}{
+				"encoding":    "base64",
+				"sample_rate": "8000",
+				"bit_rate":    "128kbps",
+			},
+		},
+	}
+}
+
+func TestExotelWebsocketStreamer_Recv(t *testing.T) {
+	streamer, exotel := voicebotPair(t)
+
+	require.NoError(t, exotel.WriteJSON(map[string]interface{}{"event": "connected"}))
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	_, ok := msg.(*protos.ConversationInitialization)
+	require.True(t, ok)
+
+	require.NoError(t, exotel.WriteJSON(startEvent()))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+	assert.Equal(t, "st-1", streamer.streamID)
+
+	// the call details of the start event are queued for the next read
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	transport, ok := msg.(*protos.ConversationMetadata)
+	require.True(t, ok)
+	values := map[string]string{}
+	for _, m := range transport.GetMetadata() {
+		values[m.GetKey()] = m.GetValue()
+	}
+	assert.Equal(t, "call-1", values["transport.exotel.call_sid"])
+	assert.Equal(t, "+919876543210", values["transport.exotel.from"])
+	assert.Equal(t, "base64;rate=8000", values["transport.exotel.media_format"])
+
+	require.NoError(t, exotel.WriteJSON(map[string]interface{}{
+		"event": "dtmf", "stream_sid": "st-1", "dtmf": map[string]interface{}{"digit": "7", "duration": "250"},
+	}))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	metadata, ok := msg.(*protos.ConversationMetadata)
+	require.True(t, ok)
+	assert.Equal(t, internal_type.MetadataKeyDTMF, metadata.GetMetadata()[0].GetKey())
+	assert.Equal(t, "7", metadata.GetMetadata()[0].GetValue())
+
+	// an echoed mark is consumed without reaching the talk loop
+	require.NoError(t, exotel.WriteJSON(map[string]interface{}{
+		"event": "mark", "stream_sid": "st-1", "mark": map[string]interface{}{"name": "unknown"},
+	}))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+
+	require.NoError(t, exotel.WriteJSON(map[string]interface{}{
+		"event": "stop", "stream_sid": "st-1", "stop": map[string]interface{}{"call_sid": "call-1", "reason": "callended"},
+	}))
+	_, err = streamer.Recv()
+	assert.Error(t, err)
+}
+
+func TestExotelWebsocketStreamer_Send(t *testing.T) {
+	streamer, exotel := voicebotPair(t)
+	require.NoError(t, exotel.WriteJSON(startEvent()))
+	_, err := streamer.Recv()
+	require.NoError(t, err)
+
+	// 450ms of linear16 16kHz is 7200 bytes once resampled to 8kHz
+	require.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Id:        "msg-1",
+		Message:   &protos.ConversationAssistantMessage_Audio{Audio: make([]byte, 14400)},
+		Completed: true,
+	}))
+
+	var sizes []int
+	for {
+		var event map[string]interface{}
+		require.NoError(t, exotel.ReadJSON(&event))
+		assert.Equal(t, "st-1", event["stream_sid"])
+		if event["event"] == "mark" {
+			assert.Equal(t, "msg-1", event["mark"].(map[string]interface{})["name"])
+			break
+		}
+		require.Equal(t, "media", event["event"])
+		payload, err := base64.StdEncoding.DecodeString(event["media"].(map[string]interface{})["payload"].(string))
+		require.NoError(t, err)
+		sizes = append(sizes, len(payload))
+	}
+	require.NotEmpty(t, sizes)
+	for i, size := range sizes {
+		assert.Zero(t, size%internal_exotel.VoicebotChunkMultiple, "chunk %d of %d bytes", i, size)
+		if i < len(sizes)-1 {
+			assert.Equal(t, internal_exotel.VoicebotMinChunk, size)
+		}
+	}
+	assert.Contains(t, streamer.marks, "msg-1")
+
+	require.NoError(t, streamer.Send(&protos.ConversationInterruption{Type: protos.ConversationInterruption_INTERRUPTION_TYPE_WORD}))
+	var cleared map[string]interface{}
+	require.NoError(t, exotel.ReadJSON(&cleared))
+	assert.Equal(t, "clear", cleared["event"])
+	assert.Empty(t, streamer.marks)
+}
+
+func TestPadChunk(t *testing.T) {
+	assert.Len(t, padChunk(make([]byte, 320)), 320)
+	assert.Len(t, padChunk(make([]byte, 321)), 640)
+	padded := padChunk([]byte{1, 2, 3})
+	assert.Len(t, padded, 320)
+	assert.Equal(t, []byte{1, 2, 3, 0}, padded[:4])
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_freeswitch_telephony
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+const (
+	defaultESLPort     = "8021"
+	defaultESLPassword = "ClueCon"
+	eslTimeout         = 10 * time.Second
+)
+
+// eslClient is a minimal inbound Event Socket client. It only sends commands
+// and reads their replies, events are never subscribed to.
+type eslClient struct {
+	conn   net.Conn
+	reader *textproto.Reader
+}
+
+// dialESL connects to the event socket the credential names and
+// authenticates. The credential carries esl_address (host or host:port,
+// port 8021 when omitted) and optionally esl_password.
+func dialESL(vaultCredential *protos.VaultCredential) (*eslClient, error) {
+	if vaultCredential == nil {
+		return nil, fmt.Errorf("missing vault credential for FreeSWITCH event socket")
+	}
+	credMap := vaultCredential.GetValue().AsMap()
+	address, _ := credMap["esl_address"].(string)
+	if address == "" {
+		return nil, fmt.Errorf("missing esl_address in vault credential")
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, defaultESLPort)
+	}
+	password, _ := credMap["esl_password"].(string)
+	if password == "" {
+		password = defaultESLPassword
+	}
+
+	conn, err := net.DialTimeout("tcp", address, eslTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach event socket %s: %w", address, err)
+	}
+	client := &eslClient{conn: conn, reader: textproto.NewReader(bufio.NewReader(conn))}
+	if err := client.auth(password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+func (c *eslClient) auth(password string) error {
+	c.conn.SetDeadline(time.Now().Add(eslTimeout))
+	header, _, err := c.read()
+	if err != nil {
+		return fmt.Errorf("event socket greeting: %w", err)
+	}
+	if header.Get("Content-Type") != "auth/request" {
+		return fmt.Errorf("unexpected event socket greeting %q", header.Get("Content-Type"))
+	}
+	header, _, err = c.send("auth " + password)
+	if err != nil {
+		return err
+	}
+	if reply := header.Get("Reply-Text"); !strings.HasPrefix(reply, "+OK") {
+		return fmt.Errorf("event socket authentication failed: %s", reply)
+	}
+	return nil
+}
+
+// API runs an api command and returns its output. Outputs starting with
+// -ERR are returned as errors.
+func (c *eslClient) API(command string) (string, error) {
+	c.conn.SetDeadline(time.Now().Add(eslTimeout))
+	_, body, err := c.send("api " + command)
+	if err != nil {
+		return "", err
+	}
+	result := strings.TrimSpace(string(body))
+	if strings.HasPrefix(result, "-ERR") {
+		return "", fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(result, "-ERR")))
+	}
+	return result, nil
+}
+
+// BackgroundAPI queues an api command and returns the job uuid without
+// waiting for the command to finish.
+func (c *eslClient) BackgroundAPI(command string) (string, error) {
+	c.conn.SetDeadline(time.Now().Add(eslTimeout))
+	header, _, err := c.send("bgapi " + command)
+	if err != nil {
+		return "", err
+	}
+	reply := header.Get("Reply-Text")
+	if !strings.HasPrefix(reply, "+OK") {
+		return "", fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(reply, "-ERR")))
+	}
+	return header.Get("Job-UUID"), nil
+}
+
+func (c *eslClient) Close() error {
+	return c.conn.Close()
+}
+
+// send writes a command and reads its reply, skipping anything that is not
+// a reply.
+func (c *eslClient) send(command string) (textproto.MIMEHeader, []byte, error) {
+	if _, err := io.WriteString(c.conn, command+"\n\n"); err != nil {
+		return nil, nil, fmt.Errorf("unable to write to event socket: %w", err)
+	}
+	for {
+		header, body, err := c.read()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read event socket reply: %w", err)
+		}
+		switch header.Get("Content-Type") {
+		case "command/reply", "api/response":
+			return header, body, nil
+		case "text/disconnect-notice":
+			return nil, nil, io.EOF
+		}
+	}
+}
+
+// read reads one message: a header block and the body its Content-Length
+// announces.
+func (c *eslClient) read() (textproto.MIMEHeader, []byte, error) {
+	header, err := c.reader.ReadMIMEHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	length := header.Get("Content-Length")
+	if length == "" {
+		return header, nil, nil
+	}
+	n, err := strconv.Atoi(length)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Content-Length %q", length)
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(c.reader.R, body); err != nil {
+		return nil, nil, err
+	}
+	return header, body, nil
+}
+
+// eslAPI runs a single api command on a connection of its own.
+func eslAPI(vaultCredential *protos.VaultCredential, command string) (string, error) {
+	client, err := dialESL(vaultCredential)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	return client.API(command)
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_freeswitch_telephony
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const freeswitchProvider = "freeswitch"
+
+// freeswitchTelephony implements the Telephony interface for FreeSWITCH.
+// Audio flows over mod_audio_fork, calls are placed through the event socket.
+type freeswitchTelephony struct {
+	appCfg *config.AssistantConfig
+	logger commons.Logger
+}
+
+// NewFreeSWITCHTelephony creates a new FreeSWITCH telephony provider
+func NewFreeSWITCHTelephony(config *config.AssistantConfig, logger commons.Logger) (internal_type.Telephony, error) {
+	return &freeswitchTelephony{
+		appCfg: config,
+		logger: logger,
+	}, nil
+}
+
+// StatusCallback handles channel events posted by FreeSWITCH, e.g. by
+// mod_json_cdr or an event handler script. The event name is read from
+// Event-Name.
+func (fst *freeswitchTelephony) StatusCallback(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+) (*internal_type.StatusInfo, error) {
+	var eventDetails map[string]interface{}
+	if err := c.ShouldBindJSON(&eventDetails); err != nil {
+		fst.logger.Errorf("failed to parse FreeSWITCH event body: %+v", err)
+		return nil, fmt.Errorf("failed to parse FreeSWITCH event body: %w", err)
+	}
+
+	eventType := "unknown"
+	if v, ok := eventDetails["Event-Name"]; ok {
+		eventType = fmt.Sprintf("%v", v)
+	}
+	return &internal_type.StatusInfo{Event: eventType, Payload: eventDetails}, nil
+}
+
+// CatchAllStatusCallback handles catch-all status callbacks
+func (fst *freeswitchTelephony) CatchAllStatusCallback(ctx *gin.Context) (*internal_type.StatusInfo, error) {
+	return nil, nil
+}
+
+// ReceiveCall handles the inbound call request of the FreeSWITCH dialplan.
+// The dialplan passes the caller as `from` (or `caller_id_number`) and the
+// channel uuid as `uuid`:
+//
+//	curl https://host/v1/talk/freeswitch/call/<assistantId>?from=${caller_id_number}&uuid=${uuid}
+func (fst *freeswitchTelephony) ReceiveCall(c *gin.Context) (*internal_type.CallInfo, error) {
+	clientNumber := c.Query("from")
+	if clientNumber == "" {
+		clientNumber = c.Query("caller_id_number")
+	}
+	if clientNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing caller information — provide 'from' query parameter"})
+		return nil, fmt.Errorf("missing caller information in query params")
+	}
+
+	info := &internal_type.CallInfo{
+		CallerNumber: clientNumber,
+		Provider:     freeswitchProvider,
+		Status:       "SUCCESS",
+		StatusInfo:   internal_type.StatusInfo{Event: "webhook", Payload: map[string]string{"from": clientNumber}},
+	}
+	if channelUUID := c.Query("uuid"); channelUUID != "" {
+		info.ChannelUUID = channelUUID
+	}
+	return info, nil
+}
+
+// InboundCall answers the dialplan with the WebSocket url mod_audio_fork has
+// to stream the call to, as plain text:
+//
+//	uuid_audio_fork ${uuid} start <url> mono 16k {"uuid":"${uuid}"}
+func (fst *freeswitchTelephony) InboundCall(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	clientNumber string,
+	assistantConversationId uint64,
+) error {
+	contextID, exists := c.Get("contextId")
+	if !exists || contextID == "" {
+		return fmt.Errorf("missing contextId — CallReciever must save call context before InboundCall")
+	}
+	c.String(http.StatusOK, fst.audioForkURL(fmt.Sprintf("%v", contextID)))
+	return nil
+}
+
+// OutboundCall originates a call through the FreeSWITCH event socket. The
+// originate is queued with bgapi and parks the channel; once it is answered
+// api_on_answer starts mod_audio_fork towards the contextId WebSocket.
+//
+// Vault credential must contain:
+//   - esl_address: event socket host[:port] (port defaults to 8021)
+//   - Optional: esl_password (default "ClueCon"), gateway, sample_rate (8000 or 16000)
+//
+// Deployment options may contain:
+//   - gateway: sofia gateway to dial through, without one the number is
+//     dialled as a user of the local directory
+//   - caller_id: override the caller id number
+func (fst *freeswitchTelephony) OutboundCall(
+	auth types.SimplePrinciple,
+	toPhone string,
+	fromPhone string,
+	assistantId, assistantConversationId uint64,
+	vaultCredential *protos.VaultCredential,
+	opts utils.Option,
+) (*internal_type.CallInfo, error) {
+	info := &internal_type.CallInfo{Provider: freeswitchProvider}
+
+	client, err := dialESL(vaultCredential)
+	if err != nil {
+		info.Status = "FAILED"
+		info.ErrorMessage = err.Error()
+		return info, err
+	}
+	defer client.Close()
+
+	credMap := vaultCredential.GetValue().AsMap()
+	endpoint := fmt.Sprintf("user/%s", toPhone)
+	if gateway, ok := credMap["gateway"].(string); ok && gateway != "" {
+		endpoint = fmt.Sprintf("sofia/gateway/%s/%s", gateway, toPhone)
+	}
+	if gateway, err := opts.GetString("gateway"); err == nil && gateway != "" {
+		endpoint = fmt.Sprintf("sofia/gateway/%s/%s", gateway, toPhone)
+	}
+
+	callerId := fromPhone
+	if callerIdVal, err := opts.GetString("caller_id"); err == nil && callerIdVal != "" {
+		callerId = callerIdVal
+	}
+
+	contextID, _ := opts.GetString("rapida.context_id")
+	channelUUID := uuid.NewString()
+	command := originateCommand(channelUUID, contextID, callerId, endpoint,
+		fst.audioForkURL(contextID), SampleRate(vaultCredential))
+
+	fst.logger.Infof("FreeSWITCH outbound call: endpoint=%s, callerId=%s, uuid=%s", endpoint, callerId, channelUUID)
+	jobUUID, err := client.BackgroundAPI(command)
+	if err != nil {
+		info.Status = "FAILED"
+		info.ErrorMessage = fmt.Sprintf("originate error: %s", err.Error())
+		return info, err
+	}
+
+	info.ChannelUUID = channelUUID
+	info.Status = "SUCCESS"
+	info.StatusInfo = internal_type.StatusInfo{Event: "originate", Payload: map[string]string{
+		"uuid":     channelUUID,
+		"job_uuid": jobUUID,
+		"endpoint": endpoint,
+	}}
+	return info, nil
+}
+
+// audioForkURL is the WebSocket url of the call's contextId route.
+func (fst *freeswitchTelephony) audioForkURL(contextID string) string {
+	return fmt.Sprintf("wss://%s/%s", fst.appCfg.PublicAssistantHost, internal_type.GetContextAnswerPath(freeswitchProvider, contextID))
+}
+
+// originateCommand builds the originate of an outbound call. Values in the
+// variable block are single quoted as the audio fork command has spaces, the
+// command itself passes no metadata since a JSON object's commas would end
+// the variable.
+func originateCommand(channelUUID, contextID, callerId, endpoint, forkURL string, sampleRate int) string {
+	fork := fmt.Sprintf("uuid_audio_fork %s start %s mono %s", channelUUID, forkURL, audioForkRate(sampleRate))
+	return fmt.Sprintf("originate {origination_uuid=%s,origination_caller_id_number=%s,rapida_context_id=%s,api_on_answer='%s'}%s &park()",
+		channelUUID, callerId, contextID, fork, endpoint)
+}
+
+// SampleRate is the L16 rate mod_audio_fork streams with for the credential,
+// 16kHz unless sample_rate asks for 8kHz.
+func SampleRate(vaultCredential *protos.VaultCredential) int {
+	if vaultCredential == nil {
+		return 16000
+	}
+	if v, ok := vaultCredential.GetValue().AsMap()["sample_rate"]; ok {
+		if rate, err := strconv.Atoi(fmt.Sprintf("%v", v)); err == nil && rate == 8000 {
+			return 8000
+		}
+	}
+	return 16000
+}
+
+func audioForkRate(sampleRate int) string {
+	if sampleRate == 8000 {
+		return "8k"
+	}
+	return "16k"
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_freeswitch_telephony
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTestTelephony(t *testing.T) *freeswitchTelephony {
+	logger, _ := commons.NewApplicationLogger()
+	tel, err := NewFreeSWITCHTelephony(&config.AssistantConfig{PublicAssistantHost: "api.rapida.ai"}, logger)
+	require.NoError(t, err)
+	return tel.(*freeswitchTelephony)
+}
+
+func credential(t *testing.T, values map[string]interface{}) *protos.VaultCredential {
+	value, err := structpb.NewStruct(values)
+	require.NoError(t, err)
+	return &protos.VaultCredential{Value: value}
+}
+
+// fakeESL accepts one event socket connection, checks the password and
+// answers every command with reply. Received commands are sent on the
+// returned channel.
+func fakeESL(t *testing.T, password, reply string) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	commands := make(chan string, 4)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		fmt.Fprint(conn, "Content-Type: auth/request\n\n")
+		for {
+			command, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			reader.ReadString('\n')
+			command = strings.TrimSpace(command)
+			commands <- command
+			if strings.HasPrefix(command, "auth ") {
+				if command == "auth "+password {
+					fmt.Fprint(conn, "Content-Type: command/reply\nReply-Text: +OK accepted\n\n")
+				} else {
+					fmt.Fprint(conn, "Content-Type: command/reply\nReply-Text: -ERR invalid\n\n")
+				}
+				continue
+			}
+			fmt.Fprint(conn, reply)
+		}
+	}()
+	return listener.Addr().String(), commands
+}
+
+func TestReceiveCall(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tel := newTestTelephony(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/talk/freeswitch/call/1?caller_id_number=1001&uuid=5b1c2e4a-7f0d-4b1e-9a55-3d1f0c9e8a21", nil)
+	info, err := tel.ReceiveCall(c)
+	require.NoError(t, err)
+	assert.Equal(t, "freeswitch", info.Provider)
+	assert.Equal(t, "1001", info.CallerNumber)
+	assert.Equal(t, "5b1c2e4a-7f0d-4b1e-9a55-3d1f0c9e8a21", info.ChannelUUID)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/talk/freeswitch/call/1", nil)
+	_, err = tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestInboundCall_ReturnsAudioForkURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tel := newTestTelephony(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("contextId", "ctx-1")
+	require.NoError(t, tel.InboundCall(c, nil, 1, "1001", 2))
+	assert.Equal(t, "wss://api.rapida.ai/v1/talk/freeswitch/ctx/ctx-1", w.Body.String())
+
+	c, _ = gin.CreateTestContext(httptest.NewRecorder())
+	assert.Error(t, tel.InboundCall(c, nil, 1, "1001", 2))
+}
+
+func TestOutboundCall_OriginatesThroughEventSocket(t *testing.T) {
+	address, commands := fakeESL(t, "secret", "Content-Type: command/reply\nReply-Text: +OK Job-UUID: job-1\nJob-UUID: job-1\n\n")
+	tel := newTestTelephony(t)
+
+	info, err := tel.OutboundCall(nil, "+15551234567", "+15559876543", 1, 2,
+		credential(t, map[string]interface{}{"esl_address": address, "esl_password": "secret", "gateway": "carrier"}),
+		utils.Option{"rapida.context_id": "ctx-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCESS", info.Status)
+	assert.NotEmpty(t, info.ChannelUUID)
+
+	assert.Equal(t, "auth secret", <-commands)
+	originate := <-commands
+	assert.Equal(t, fmt.Sprintf("bgapi originate {origination_uuid=%[1]s,origination_caller_id_number=+15559876543,rapida_context_id=ctx-1,"+
+		"api_on_answer='uuid_audio_fork %[1]s start wss://api.rapida.ai/v1/talk/freeswitch/ctx/ctx-1 mono 16k'}sofia/gateway/carrier/+15551234567 &park()",
+		info.ChannelUUID), originate)
+	assert.Equal(t, "job-1", info.StatusInfo.Payload.(map[string]string)["job_uuid"])
+}
+
+func TestOutboundCall_Failures(t *testing.T) {
+	tel := newTestTelephony(t)
+
+	info, err := tel.OutboundCall(nil, "1002", "1001", 1, 2, credential(t, map[string]interface{}{}), utils.Option{})
+	assert.ErrorContains(t, err, "esl_address")
+	assert.Equal(t, "FAILED", info.Status)
+
+	address, _ := fakeESL(t, "secret", "")
+	info, err = tel.OutboundCall(nil, "1002", "1001", 1, 2, credential(t, map[string]interface{}{"esl_address": address}), utils.Option{})
+	assert.ErrorContains(t, err, "authentication failed")
+	assert.Equal(t, "FAILED", info.Status)
+
+	address, _ = fakeESL(t, "ClueCon", "Content-Type: command/reply\nReply-Text: -ERR no reply\n\n")
+	info, err = tel.OutboundCall(nil, "1002", "1001", 1, 2, credential(t, map[string]interface{}{"esl_address": address}), utils.Option{})
+	assert.ErrorContains(t, err, "no reply")
+	assert.Equal(t, "FAILED", info.Status)
+}
+
+func TestESLAPI_ReadsResponseBody(t *testing.T) {
+	address, commands := fakeESL(t, "ClueCon", "Content-Type: api/response\nContent-Length: 24\n\n-ERR No such channel!\n\n\n")
+	_, err := eslAPI(credential(t, map[string]interface{}{"esl_address": address}), "uuid_kill 1234")
+	assert.EqualError(t, err, "No such channel!")
+	<-commands
+	assert.Equal(t, "api uuid_kill 1234", <-commands)
+}
+
+func TestSampleRate(t *testing.T) {
+	assert.Equal(t, 16000, SampleRate(nil))
+	assert.Equal(t, 16000, SampleRate(credential(t, map[string]interface{}{})))
+	assert.Equal(t, 8000, SampleRate(credential(t, map[string]interface{}{"sample_rate": "8000"})))
+	assert.Equal(t, 16000, SampleRate(credential(t, map[string]interface{}{"sample_rate": "44100"})))
+	assert.Equal(t, "8k", audioForkRate(8000))
+}
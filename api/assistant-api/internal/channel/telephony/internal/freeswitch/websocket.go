@@ -0,0 +1,239 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_freeswitch_telephony
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gorilla/websocket"
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_telephony_base "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/base"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// playAudioFrames is how many output frames one playAudio message carries.
+// mod_audio_fork plays every message as a file of its own, so audio is sent
+// in larger pieces than the 20ms frames of the other providers.
+const playAudioFrames = 25
+
+// AudioForkMetadata is the text frame mod_audio_fork sends before any audio,
+// the metadata argument of uuid_audio_fork. All fields are optional.
+type AudioForkMetadata struct {
+	UUID       string `json:"uuid"`
+	SampleRate int    `json:"sampleRate"`
+}
+
+// audioForkCommand is a message the WebSocket server sends to mod_audio_fork.
+type audioForkCommand struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+type audioForkPlayAudio struct {
+	AudioContentType string `json:"audioContentType"`
+	SampleRate       int    `json:"sampleRate"`
+	AudioContent     string `json:"audioContent"`
+}
+
+type freeswitchWebsocketStreamer struct {
+	internal_telephony_base.BaseTelephonyStreamer
+
+	connection  *websocket.Conn
+	audioConfig *protos.AudioConfig
+	connected   bool
+}
+
+// NewFreeSWITCHWebsocketStreamer creates a streamer for a mod_audio_fork
+// connection. mod_audio_fork sends mono L16 at the rate given to
+// uuid_audio_fork, which has to match the credential's sample_rate.
+func NewFreeSWITCHWebsocketStreamer(logger commons.Logger, connection *websocket.Conn, cc *callcontext.CallContext, vaultCred *protos.VaultCredential) internal_type.Streamer {
+	audioConfig := internal_audio.NewLinear16khzMonoAudioConfig()
+	if SampleRate(vaultCred) == 8000 {
+		audioConfig = internal_audio.NewLinear8khzMonoAudioConfig()
+	}
+	return &freeswitchWebsocketStreamer{
+		BaseTelephonyStreamer: internal_telephony_base.NewBaseTelephonyStreamer(
+			logger, cc, vaultCred,
+			internal_telephony_base.WithSourceAudioConfig(audioConfig),
+		),
+		connection:  connection,
+		audioConfig: audioConfig,
+	}
+}
+
+func (fs *freeswitchWebsocketStreamer) Recv() (internal_type.Stream, error) {
+	if fs.connection == nil {
+		return nil, io.EOF
+	}
+	select {
+	case msg := <-fs.InputCh:
+		return msg, nil
+	default:
+	}
+	messageType, message, err := fs.connection.ReadMessage()
+	if err != nil {
+		fs.Cancel()
+		return nil, io.EOF
+	}
+
+	switch messageType {
+	case websocket.TextMessage:
+		fs.handleMetadata(message)
+		if !fs.connected {
+			fs.connected = true
+			return fs.CreateConnectionRequest(), nil
+		}
+	case websocket.BinaryMessage:
+		// metadata is optional, without it the first audio frame opens the
+		// conversation and is kept for the next one
+		if !fs.connected {
+			fs.connected = true
+			fs.WithInputBuffer(func(buf *bytes.Buffer) { buf.Write(message) })
+			return fs.CreateConnectionRequest(), nil
+		}
+		msg := fs.handleMediaEvent(message)
+		if msg == nil {
+			return nil, nil
+		}
+		return msg, nil
+	case websocket.CloseMessage:
+		return nil, io.EOF
+	default:
+		fs.Logger.Warn("Unhandled message type", "type", messageType)
+	}
+	return nil, nil
+}
+
+// handleMetadata reads the metadata text frame. A channel uuid the call
+// context does not know yet is passed on to the conversation.
+func (fs *freeswitchWebsocketStreamer) handleMetadata(message []byte) {
+	var metadata AudioForkMetadata
+	if err := json.Unmarshal(message, &metadata); err != nil {
+		fs.Logger.Debugf("Ignoring non-JSON mod_audio_fork metadata: %s", string(message))
+		return
+	}
+	if metadata.SampleRate != 0 && uint32(metadata.SampleRate) != fs.audioConfig.GetSampleRate() {
+		fs.Logger.Warnf("mod_audio_fork streams at %d Hz but the credential expects %d Hz, set sample_rate accordingly",
+			metadata.SampleRate, fs.audioConfig.GetSampleRate())
+	}
+	if metadata.UUID != "" && metadata.UUID != fs.ChannelUUID {
+		fs.ChannelUUID = metadata.UUID
+		fs.PushInput(&protos.ConversationMetadata{Metadata: []*protos.Metadata{
+			{Key: internal_type.MetadataKeyChannelUUID, Value: metadata.UUID},
+		}})
+	}
+}
+
+func (fs *freeswitchWebsocketStreamer) handleMediaEvent(message []byte) *protos.ConversationUserMessage {
+	var audioRequest *protos.ConversationUserMessage
+	fs.WithInputBuffer(func(buf *bytes.Buffer) {
+		buf.Write(message)
+		if buf.Len() >= fs.InputBufferThreshold() {
+			audioRequest = fs.CreateVoiceRequest(buf.Bytes())
+			buf.Reset()
+		}
+	})
+	return audioRequest
+}
+
+func (fs *freeswitchWebsocketStreamer) Send(response internal_type.Stream) error {
+	if fs.connection == nil {
+		return nil
+	}
+	switch data := response.(type) {
+	case *protos.ConversationAssistantMessage:
+		switch content := data.Message.(type) {
+		case *protos.ConversationAssistantMessage_Audio:
+			audioData := content.Audio
+			if fs.audioConfig.GetSampleRate() != internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG.GetSampleRate() {
+				resampled, err := fs.Resampler().Resample(content.Audio, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG, fs.audioConfig)
+				if err != nil {
+					fs.Logger.Warnw("Failed to resample output audio, forwarding raw bytes", "error", err.Error())
+				} else {
+					audioData = resampled
+				}
+			}
+
+			var sendErr error
+			fs.WithOutputBuffer(func(buf *bytes.Buffer) {
+				buf.Write(audioData)
+				size := fs.OutputFrameSize() * playAudioFrames
+				for buf.Len() >= size {
+					if err := fs.playAudio(buf.Next(size)); err != nil {
+						sendErr = err
+						return
+					}
+				}
+				if data.GetCompleted() && buf.Len() > 0 {
+					if err := fs.playAudio(buf.Bytes()); err != nil {
+						sendErr = err
+						return
+					}
+					buf.Reset()
+				}
+			})
+			return sendErr
+		}
+	case *protos.ConversationInterruption:
+		if data.Type == protos.ConversationInterruption_INTERRUPTION_TYPE_WORD {
+			fs.ResetOutputBuffer()
+			if err := fs.sendCommand(audioForkCommand{Type: "killAudio"}); err != nil {
+				fs.Logger.Errorf("Error sending killAudio command: %v", err)
+			}
+		}
+	case *protos.ConversationDirective:
+		if data.GetType() == protos.ConversationDirective_END_CONVERSATION && fs.ChannelUUID != "" {
+			if _, err := eslAPI(fs.VaultCredential(), "uuid_kill "+fs.ChannelUUID); err != nil {
+				fs.Logger.Errorf("Error ending FreeSWITCH call: %v", err)
+			}
+		}
+		if err := fs.Cancel(); err != nil {
+			fs.Logger.Errorf("Error disconnecting command: %v", err)
+		}
+	}
+	return nil
+}
+
+func (fs *freeswitchWebsocketStreamer) playAudio(audio []byte) error {
+	return fs.sendCommand(audioForkCommand{Type: "playAudio", Data: audioForkPlayAudio{
+		AudioContentType: "raw",
+		SampleRate:       int(fs.audioConfig.GetSampleRate()),
+		AudioContent:     fs.Encoder().EncodeToString(audio),
+	}})
+}
+
+func (fs *freeswitchWebsocketStreamer) sendCommand(command audioForkCommand) error {
+	if fs.connection == nil {
+		return nil
+	}
+	message, err := json.Marshal(command)
+	if err != nil {
+		return err
+	}
+	if err := fs.connection.WriteMessage(websocket.TextMessage, message); err != nil {
+		fs.Logger.Error("Failed to send mod_audio_fork command", "type", command.Type, "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (fs *freeswitchWebsocketStreamer) GetConversationUuid() string {
+	return fs.ChannelUUID
+}
+
+func (fs *freeswitchWebsocketStreamer) Cancel() error {
+	if fs.connection != nil {
+		fs.connection.Close()
+		fs.connection = nil
+	}
+	return nil
+}
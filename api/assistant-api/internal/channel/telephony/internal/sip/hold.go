@@ -0,0 +1,259 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sip_telephony
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/zaf/g711"
+)
+
+const (
+	// holdToneHz is the comfort tone frequency, a whole number of periods
+	// fits in one 20ms frame so frames repeat without a click.
+	holdToneHz = 400
+
+	// holdToneAmplitude keeps the comfort tone around -30 dBFS.
+	holdToneAmplitude = 1000
+
+	// holdMusicAmplitude keeps the peaks of the hold music around -20 dBFS.
+	holdMusicAmplitude = 3000
+
+	// holdMusicNote and holdMusicPause are how long each note of the hold
+	// music rings and the pause before it repeats.
+	holdMusicNote  = 400 * time.Millisecond
+	holdMusicPause = 1200 * time.Millisecond
+)
+
+// hold actions of the HOLD_CONVERSATION directive
+const (
+	holdActionHold   = "hold"
+	holdActionResume = "resume"
+)
+
+// holdMusicNotes is the arpeggio of the hold music, C major in Hz.
+var holdMusicNotes = []float64{523.25, 659.25, 783.99, 659.25}
+
+// watchHold follows the session's hold and resume events for the lifetime of
+// the streamer.
+func (s *Streamer) watchHold(session *sip_infra.Session) {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case event, ok := <-session.Events():
+			if !ok {
+				return
+			}
+			switch event.Type {
+			case sip_infra.EventTypeHold:
+				s.setHold(true)
+			case sip_infra.EventTypeResume:
+				s.setHold(false)
+			}
+		}
+	}
+}
+
+// setHold pauses the assistant while the remote party has the call on hold.
+// Their hold music is dropped, pending assistant audio is discarded and the
+// configured comfort audio is fed to the pipeline instead. The talk loop is
+// told through MetadataKeyCallHold so it stops listening and speaking.
+func (s *Streamer) setHold(held bool) {
+	if s.transferring.Load() {
+		// the caller is with the agent, the assistant is not part of the call
+		return
+	}
+	if s.held.Swap(held) == held {
+		return
+	}
+
+	s.mu.Lock()
+	if s.holdStop != nil {
+		close(s.holdStop)
+		s.holdStop = nil
+	}
+	if held {
+		s.holdStop = make(chan struct{})
+		go s.runHoldAudio(s.holdStop, s.holdFrame())
+	}
+	s.mu.Unlock()
+
+	if held {
+		s.ResetInputBuffer()
+		s.ClearOutputBuffer()
+	}
+	s.Logger.Infow("SIP call hold changed", "held", held, "hold_audio", s.config.HoldAudio)
+	s.PushInput(&protos.ConversationMetadata{
+		Metadata: []*protos.Metadata{{Key: internal_type.MetadataKeyCallHold, Value: strconv.FormatBool(held)}},
+	})
+}
+
+// runHoldAudio writes one comfort frame per packet interval into the input
+// buffer until stop is closed. A nil frame feeds nothing.
+func (s *Streamer) runHoldAudio(stop <-chan struct{}, frame []byte) {
+	if frame == nil {
+		return
+	}
+	ticker := time.NewTicker(packetIntervalMs * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.WithInputBuffer(func(buf *bytes.Buffer) {
+				buf.Write(frame)
+			})
+		}
+	}
+}
+
+// holdFrame returns one 20ms µ-law frame of the configured comfort audio, or
+// nil when none is configured.
+func (s *Streamer) holdFrame() []byte {
+	switch s.config.HoldAudio {
+	case sip_infra.HoldAudioSilence:
+		return bytes.Repeat([]byte{0xFF}, mulawFrameSize)
+	case sip_infra.HoldAudioTone:
+		return comfortTone()
+	}
+	return nil
+}
+
+// comfortTone renders one 20ms frame of the comfort tone as µ-law 8kHz.
+func comfortTone() []byte {
+	pcm := make([]byte, mulawFrameSize*2)
+	for i := 0; i < mulawFrameSize; i++ {
+		sample := holdToneAmplitude * math.Sin(2*math.Pi*holdToneHz*float64(i)/8000)
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(int16(sample)))
+	}
+	return g711.EncodeUlaw(pcm)
+}
+
+// holdCall puts the caller on hold for a HOLD_CONVERSATION directive, or
+// resumes the call. The action argument is hold or resume, hold when absent.
+func (s *Streamer) holdCall(directive *protos.ConversationDirective) error {
+	action := holdActionHold
+	if arg, ok := directive.GetArgs()["action"]; ok {
+		value, err := utils.AnyToString(arg)
+		if err != nil {
+			return err
+		}
+		action = value
+	}
+	switch action {
+	case holdActionHold:
+		return s.setLocalHold(true)
+	case holdActionResume:
+		return s.setLocalHold(false)
+	}
+	return fmt.Errorf("unknown hold action %q", action)
+}
+
+// setLocalHold holds the call from the assistant's side: the caller hears
+// the hold music between the assistant's words and is not listened to. The
+// remote party is told with a sendonly re-INVITE in the background; when it
+// refuses, the music is all the caller gets.
+func (s *Streamer) setLocalHold(held bool) error {
+	if s.transferring.Load() || s.pickedUp.Load() {
+		return fmt.Errorf("call is with an agent")
+	}
+	s.mu.RLock()
+	server := s.dialer
+	if server == nil {
+		server = s.server
+	}
+	session := s.session
+	s.mu.RUnlock()
+	if server == nil || session == nil {
+		return sip_infra.ErrRTPNotInitialized
+	}
+	if s.holding.Swap(held) == held {
+		return nil
+	}
+	if held {
+		s.ResetInputBuffer()
+	}
+	s.Logger.Infow("SIP call hold changed by the assistant", "call_id", session.GetCallID(), "held", held)
+
+	go func() {
+		s.holdMu.Lock()
+		defer s.holdMu.Unlock()
+		// the latest request wins when hold and resume follow each other
+		if err := server.HoldCall(session, s.holding.Load()); err != nil && s.ctx.Err() == nil {
+			s.Logger.Warnw("Remote party did not take the hold", "call_id", session.GetCallID(), "error", err)
+		}
+	}()
+	return nil
+}
+
+// holdMusic returns the 20ms µ-law frames of the configured hold music,
+// played in a loop, or nil when none is configured.
+func (s *Streamer) holdMusic() [][]byte {
+	switch s.config.HoldMusic {
+	case sip_infra.HoldAudioNone:
+		return nil
+	case sip_infra.HoldAudioSilence:
+		return [][]byte{bytes.Repeat([]byte{0xFF}, mulawFrameSize)}
+	}
+	return holdMelody()
+}
+
+// writeHoldMusic sends one frame of hold music, dropped when the RTP leg is
+// busy.
+func (s *Streamer) writeHoldMusic(frame []byte) {
+	s.mu.RLock()
+	rtpHandler := s.rtpHandler
+	s.mu.RUnlock()
+	if rtpHandler == nil || !rtpHandler.IsRunning() {
+		return
+	}
+	if codec := rtpHandler.GetCodec(); codec != nil && codec.Name == "PCMA" {
+		frame = mulawToAlaw(frame)
+	}
+	select {
+	case rtpHandler.AudioOut() <- frame:
+	default:
+	}
+}
+
+// holdMelody renders the hold music as 20ms µ-law 8kHz frames: the notes
+// of holdMusicNotes fading out one after the other, then a pause.
+func holdMelody() [][]byte {
+	noteSamples := int(holdMusicNote.Seconds() * 8000)
+	pauseSamples := int(holdMusicPause.Seconds() * 8000)
+	pcm := make([]byte, 0, (len(holdMusicNotes)*noteSamples+pauseSamples)*2)
+	for _, hz := range holdMusicNotes {
+		for i := 0; i < noteSamples; i++ {
+			t := float64(i) / 8000
+			// a short attack avoids clicks, the decay makes it a chime
+			envelope := math.Min(1, t/0.01) * math.Exp(-4*t)
+			sample := holdMusicAmplitude * envelope * math.Sin(2*math.Pi*hz*t)
+			pcm = binary.LittleEndian.AppendUint16(pcm, uint16(int16(sample)))
+		}
+	}
+	pcm = append(pcm, make([]byte, pauseSamples*2)...)
+
+	mulaw := g711.EncodeUlaw(pcm)
+	frames := make([][]byte, 0, len(mulaw)/mulawFrameSize)
+	for i := 0; i+mulawFrameSize <= len(mulaw); i += mulawFrameSize {
+		frames = append(frames, mulaw[i:i+mulawFrameSize])
+	}
+	return frames
+}
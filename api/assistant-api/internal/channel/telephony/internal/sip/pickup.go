@@ -0,0 +1,116 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sip_telephony
+
+import (
+	"fmt"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// park makes the call available to human agents when the trunk enables
+// pickup. The code they dial is reported to the talk loop, which keeps it on
+// the conversation for dashboards to show.
+func (s *Streamer) park(session *sip_infra.Session) {
+	if !s.config.Pickup || s.dialer == nil {
+		return
+	}
+	code, err := s.dialer.Park(session, s.pickUp)
+	if err != nil {
+		s.Logger.Warnw("Call not parked for pickup", "call_id", session.GetCallID(), "error", err)
+		return
+	}
+	s.mu.Lock()
+	s.pickupCode = code
+	s.mu.Unlock()
+
+	s.Logger.Infow("Call parked for pickup", "call_id", session.GetCallID(), "code", code)
+	s.PushInput(&protos.ConversationMetadata{
+		Metadata: []*protos.Metadata{{Key: internal_type.MetadataKeyPickupCode, Value: code}},
+	})
+}
+
+// unpark withdraws the call from pickup once it ends.
+func (s *Streamer) unpark() {
+	s.mu.Lock()
+	code := s.pickupCode
+	s.pickupCode = ""
+	s.mu.Unlock()
+	if code != "" && s.dialer != nil {
+		s.dialer.Unpark(code)
+	}
+}
+
+// pickUp hands the caller over to the agent who dialed the pickup code. The
+// caller's audio is taken from the assistant and bridged to the agent's
+// phone, and the talk loop is told with a TRANSFER_CONVERSATION directive.
+// A call already being transferred cannot be picked up.
+func (s *Streamer) pickUp(agent *sip_infra.Session) error {
+	s.mu.RLock()
+	caller := s.rtpHandler
+	s.mu.RUnlock()
+
+	agentRTP := agent.GetRTPHandler()
+	if caller == nil || agentRTP == nil {
+		return sip_infra.ErrRTPNotInitialized
+	}
+	if !s.transferring.CompareAndSwap(false, true) {
+		return fmt.Errorf("call is being transferred")
+	}
+	s.pickedUp.Store(true)
+
+	s.mu.Lock()
+	code := s.pickupCode
+	s.pickupCode = ""
+	s.mu.Unlock()
+
+	select {
+	case s.transferCh <- struct{}{}:
+	case <-s.ctx.Done():
+		return sip_infra.ErrSessionClosed
+	}
+	s.ClearOutputBuffer()
+
+	agentURI := agent.GetInfo().RemoteURI
+	args, _ := utils.InterfaceMapToAnyMap(map[string]interface{}{"to": agentURI, "pickup": code})
+	s.PushInput(&protos.ConversationDirective{
+		Type: protos.ConversationDirective_TRANSFER_CONVERSATION,
+		Args: args,
+	})
+
+	go s.runPickup(caller, agent, agentRTP)
+	return nil
+}
+
+// runPickup relays the caller and the agent until either hangs up, then ends
+// both calls.
+func (s *Streamer) runPickup(caller *sip_infra.RTPHandler, agent *sip_infra.Session, agentRTP *sip_infra.RTPHandler) {
+	defer func() {
+		if err := s.dialer.EndCall(agent); err != nil {
+			s.Logger.Warnw("Failed to end agent call", "call_id", agent.GetCallID(), "error", err)
+		}
+	}()
+
+	bridge := sip_infra.NewBridge(s.ctx, s.Logger, caller, agentRTP)
+	bridge.Start()
+	s.mu.Lock()
+	s.agentRTP = agentRTP
+	s.mu.Unlock()
+	s.Logger.Infow("Picked up call bridged", "agent_call_id", agent.GetCallID(), "agent", agent.GetInfo().RemoteURI)
+
+	select {
+	case <-bridge.Done():
+	case <-agent.ByeReceived():
+	case <-agent.Context().Done():
+	}
+	bridge.Stop()
+	s.Logger.Infow("Picked up call ended", "agent_call_id", agent.GetCallID())
+	s.Close()
+}
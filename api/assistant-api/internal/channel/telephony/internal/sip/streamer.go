@@ -10,12 +10,14 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_callquality "github.com/rapidaai/api/assistant-api/internal/callquality"
 	internal_telephony_base "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/base"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
@@ -58,14 +60,46 @@ type Streamer struct {
 	server     *sip_infra.Server
 	rtpHandler *sip_infra.RTPHandler
 
+	// dialer places the agent leg of a warm transfer. It is the shared SIP
+	// server for inbound sessions, outbound streamers fall back to server.
+	dialer *sip_infra.Server
+
+	// codec is the codec the call was set up with. It may change mid-call
+	// through re-INVITE, audio paths read the live one from the RTP handler.
 	codec *sip_infra.Codec
 
+	// warm transfer state, see transfer.go
+	transferring atomic.Bool
+	transferCh   chan struct{}
+	whisper      []byte
+	whisperAt    time.Time
+	// agentRTP is the media of the agent the caller was bridged to by a
+	// warm transfer or a pickup, kept once bridged for call quality
+	agentRTP *sip_infra.RTPHandler
+
+	// hold state, see hold.go. holdStop stops the comfort audio. holding
+	// is set while the assistant holds the call, holdMu orders the
+	// re-INVITEs signalling it.
+	held     atomic.Bool
+	holdStop chan struct{}
+	holding  atomic.Bool
+	holdMu   sync.Mutex
+
+	// pickup state, see pickup.go. pickupCode is empty unless the call is
+	// parked, pickedUp is set once an agent took it over.
+	pickupCode string
+	pickedUp   atomic.Bool
+
 	// SIP uses its own context derived from the session/parent context,
 	// overriding the BaseStreamer context.
 	ctx    context.Context
 	cancel context.CancelFunc
 
 	configSent atomic.Bool
+
+	// handedOver is set when the call was taken over by another instance,
+	// see HandedOver
+	handedOver atomic.Bool
 }
 
 // NewStreamer creates a SIP streamer.
@@ -75,10 +109,14 @@ type Streamer struct {
 //
 // When sipSession is nil (outbound / standalone path), a dedicated SIP server
 // is spun up with its own RTP port pool and event handlers.
+//
+// dialer is the server warm transfers place the human agent leg on, it may be
+// nil when the streamer owns its server.
 func NewStreamer(ctx context.Context,
 	config *sip_infra.Config,
 	logger commons.Logger,
 	sipSession *sip_infra.Session,
+	dialer *sip_infra.Server,
 	cc *callcontext.CallContext,
 	vaultCred *protos.VaultCredential,
 ) (internal_type.Streamer, error) {
@@ -94,10 +132,12 @@ func NewStreamer(ctx context.Context,
 			logger, cc, vaultCred,
 			internal_telephony_base.WithSourceAudioConfig(internal_audio.NewMulaw8khzMonoAudioConfig()),
 		),
-		config: config,
-		codec:  codec,
-		ctx:    streamerCtx,
-		cancel: cancel,
+		config:     config,
+		dialer:     dialer,
+		codec:      codec,
+		transferCh: make(chan struct{}),
+		ctx:        streamerCtx,
+		cancel:     cancel,
 	}
 
 	// --- Inbound: reuse existing session's RTP handler ---
@@ -122,10 +162,13 @@ func NewStreamer(ctx context.Context,
 
 		s.session = sipSession
 		s.rtpHandler = rtpHandler
+		s.pushTransportMetadata(sipSession)
 
 		logger.Info("NewStreamer: Starting forwardIncomingAudio goroutine")
 		go s.forwardIncomingAudio()
 		go s.runRTPWriter()
+		go s.watchHold(sipSession)
+		s.park(sipSession)
 
 		localIP, localPort := rtpHandler.LocalAddr()
 		logger.Infow("SIP streamer created (inbound)",
@@ -203,6 +246,13 @@ func (s *Streamer) handleInvite(session *sip_infra.Session, fromURI, toURI strin
 		return sip_infra.NewSIPError("handleInvite", session.GetCallID(), "failed to create RTP handler", err)
 	}
 
+	if s.config.Concealment {
+		rtpHandler.EnableConcealment()
+	}
+	if s.config.ComfortNoise != 0 {
+		rtpHandler.EnableComfortNoise(s.config.ComfortNoise)
+	}
+
 	s.mu.Lock()
 	s.rtpHandler = rtpHandler
 	s.mu.Unlock()
@@ -212,13 +262,15 @@ func (s *Streamer) handleInvite(session *sip_infra.Session, fromURI, toURI strin
 	session.SetLocalRTP(localIP, localPort)
 	session.SetNegotiatedCodec(codec.Name, int(codec.ClockRate))
 	session.SetRTPHandler(rtpHandler)
+	s.pushTransportMetadata(session)
 
 	// Start RTP processing
 	rtpHandler.Start()
 
-	// Start audio forwarding and RTP writer
+	// Start audio forwarding, RTP writer and hold tracking
 	go s.forwardIncomingAudio()
 	go s.runRTPWriter()
+	go s.watchHold(session)
 
 	s.Logger.Infow("SIP call established",
 		"call_id", session.GetCallID(),
@@ -229,6 +281,19 @@ func (s *Streamer) handleInvite(session *sip_infra.Session, fromURI, toURI strin
 	return nil
 }
 
+// pushTransportMetadata records the far end of the call on the conversation,
+// audio issues often come down to a particular phone or carrier.
+func (s *Streamer) pushTransportMetadata(session *sip_infra.Session) {
+	info := session.GetInfo()
+	s.PushTransportMetadata("sip", map[string]string{
+		"user_agent":        info.RemoteUserAgent,
+		"transport":         info.SignalingTransport,
+		"signaling_address": info.SignalingAddress,
+		"rtp_address":       info.RemoteRTPAddress,
+		"codec":             info.Codec,
+	})
+}
+
 func (s *Streamer) handleBye(session *sip_infra.Session) error {
 	s.Logger.Infow("BYE received, closing streamer", "call_id", session.GetCallID())
 	return s.Close()
@@ -246,6 +311,11 @@ func (s *Streamer) handleError(session *sip_infra.Session, err error) {
 // keeps Recv() simple and ensures the inputBuffer always contains µ-law data.
 //
 // Audio flow: RTP packets → [A-law→µ-law if PCMA] → inputBuffer → Recv()
+// While the call is on hold, RTP audio is dropped, see hold.go.
+// DTMF flow:  RFC 4733 events → ConversationMetadata → InputCh → Recv()
+// RTCP flow:  receiver reports → ConversationMetric → InputCh → Recv()
+//
+// It returns when a warm transfer takes the caller's audio over.
 func (s *Streamer) forwardIncomingAudio() {
 	s.mu.RLock()
 	rtpHandler := s.rtpHandler
@@ -268,11 +338,20 @@ func (s *Streamer) forwardIncomingAudio() {
 		select {
 		case <-s.ctx.Done():
 			return
+		case <-s.transferCh:
+			return
 		case audioData, ok := <-rtpHandler.AudioIn():
 			if !ok {
 				return
 			}
 
+			// On hold this is the remote side's hold music, the assistant
+			// gets the comfort audio instead. While the assistant holds the
+			// call the caller is not listened to.
+			if s.held.Load() || s.holding.Load() {
+				continue
+			}
+
 			// Transcode A-law → µ-law if PCMA codec is negotiated, so the
 			// inputBuffer always holds µ-law samples regardless of codec.
 			if codec := rtpHandler.GetCodec(); codec != nil && codec.Name == "PCMA" {
@@ -281,10 +360,92 @@ func (s *Streamer) forwardIncomingAudio() {
 			s.WithInputBuffer(func(buf *bytes.Buffer) {
 				buf.Write(audioData)
 			})
+
+		case event, ok := <-rtpHandler.DTMFIn():
+			if !ok {
+				return
+			}
+			s.PushInput(&protos.ConversationMetadata{
+				Metadata: []*protos.Metadata{{Key: internal_type.MetadataKeyDTMF, Value: event.Digit}},
+			})
+
+		case stats := <-rtpHandler.RTCPIn():
+			s.PushInput(&protos.ConversationMetric{Metrics: rtcpMetrics(stats)})
 		}
 	}
 }
 
+// rtcpMetrics turns the call quality the remote party reports into
+// conversation metrics. They are stored by name, so every report replaces
+// the previous values. The round trip time is left out until the remote
+// party answered one of our sender reports.
+func rtcpMetrics(stats sip_infra.RTCPStats) []*protos.Metric {
+	metrics := []*protos.Metric{
+		{
+			Name:        "rtcp_jitter_ms",
+			Value:       strconv.FormatFloat(float64(stats.Jitter)/float64(time.Millisecond), 'f', 2, 64),
+			Description: "Interarrival jitter of the assistant audio as seen by the remote party",
+		},
+		{
+			Name:        "rtcp_fraction_lost",
+			Value:       strconv.FormatFloat(stats.FractionLost, 'f', 4, 64),
+			Description: "Share of assistant audio packets lost since the previous RTCP report",
+		},
+		{
+			Name:        "rtcp_packets_lost",
+			Value:       strconv.FormatInt(stats.PacketsLost, 10),
+			Description: "Assistant audio packets lost since the start of the call",
+		},
+	}
+	if stats.RoundTripTime > 0 {
+		metrics = append(metrics, &protos.Metric{
+			Name:        "rtcp_round_trip_time_ms",
+			Value:       strconv.FormatInt(stats.RoundTripTime.Milliseconds(), 10),
+			Description: "Round trip time of the SIP media path from the latest RTCP report",
+		})
+	}
+	return metrics
+}
+
+// CallQuality reports the media statistics of the call for MOS scoring: loss
+// and jitter of the caller's audio as received here, and what the caller's
+// side reports about ours through RTCP.
+func (s *Streamer) CallQuality() (internal_callquality.Sample, bool) {
+	s.mu.RLock()
+	rtpHandler := s.rtpHandler
+	s.mu.RUnlock()
+	if rtpHandler == nil {
+		return internal_callquality.Sample{}, false
+	}
+
+	return rtpSample(rtpHandler), true
+}
+
+// CallQualityLegs reports the media statistics of the agent's leg once the
+// caller was bridged to an agent.
+func (s *Streamer) CallQualityLegs() map[string]internal_callquality.Sample {
+	s.mu.RLock()
+	agentRTP := s.agentRTP
+	s.mu.RUnlock()
+	if agentRTP == nil {
+		return nil
+	}
+	return map[string]internal_callquality.Sample{internal_callquality.LegAgent: rtpSample(agentRTP)}
+}
+
+func rtpSample(rtpHandler *sip_infra.RTPHandler) internal_callquality.Sample {
+	rtp := rtpHandler.GetDetailedStats()
+	rtcp := rtpHandler.GetRTCPStats()
+	return internal_callquality.Sample{
+		PacketsReceived:    rtp.PacketsReceived,
+		PacketsLost:        int64(rtp.PacketsLost),
+		Jitter:             rtp.Jitter,
+		RoundTripTime:      rtcp.RoundTripTime,
+		RemoteFractionLost: rtcp.FractionLost,
+		RemoteJitter:       rtcp.Jitter,
+	}
+}
+
 func (s *Streamer) Context() context.Context {
 	return s.ctx
 }
@@ -294,6 +455,9 @@ func (s *Streamer) Context() context.Context {
 // always contains µ-law samples by the time Recv reads them.
 //
 // Audio flow: inputBuffer (µ-law 8kHz) → Resample → LINEAR16 16kHz → STT
+//
+// Control messages pushed to InputCh (DTMF, RTCP metrics) are returned ahead
+// of audio.
 func (s *Streamer) Recv() (internal_type.Stream, error) {
 	if s.closed.Load() {
 		return nil, io.EOF
@@ -318,6 +482,8 @@ func (s *Streamer) Recv() (internal_type.Stream, error) {
 		select {
 		case <-s.ctx.Done():
 			return nil, io.EOF
+		case msg := <-s.InputCh:
+			return msg, nil
 		default:
 		}
 
@@ -356,6 +522,11 @@ func (s *Streamer) Recv() (internal_type.Stream, error) {
 		select {
 		case <-s.ctx.Done():
 			return nil, io.EOF
+		case msg := <-s.InputCh:
+			if !waitTimer.Stop() {
+				<-waitTimer.C
+			}
+			return msg, nil
 		case <-waitTimer.C:
 		}
 	}
@@ -372,18 +543,35 @@ func (s *Streamer) Send(response internal_type.Stream) error {
 			return s.sendAudio(content.Audio)
 		}
 	case *protos.ConversationInterruption:
-		if data.Type == protos.ConversationInterruption_INTERRUPTION_TYPE_WORD {
+		// the caller can't interrupt the whisper, it is not heard by them
+		if data.Type == protos.ConversationInterruption_INTERRUPTION_TYPE_WORD && !s.transferring.Load() {
 			return s.handleInterruption()
 		}
 	case *protos.ConversationDirective:
-		if data.GetType() == protos.ConversationDirective_END_CONVERSATION {
+		switch data.GetType() {
+		case protos.ConversationDirective_END_CONVERSATION:
+			if s.transferring.Load() {
+				// the transfer owns the call now and ends it with the bridge
+				return nil
+			}
 			return s.Close()
+		case protos.ConversationDirective_SEND_DTMF:
+			return s.sendDTMF(data)
+		case protos.ConversationDirective_TRANSFER_CONVERSATION:
+			return s.warmTransfer(data)
+		case protos.ConversationDirective_HOLD_CONVERSATION:
+			return s.holdCall(data)
 		}
 	}
 	return nil
 }
 
-func (s *Streamer) sendAudio(audioData []byte) error {
+// sendDTMF plays the directive's digits on the RTP leg as RFC 4733 events.
+func (s *Streamer) sendDTMF(directive *protos.ConversationDirective) error {
+	digits, err := internal_telephony_base.DTMFDigits(directive)
+	if err != nil {
+		return err
+	}
 	s.mu.RLock()
 	rtpHandler := s.rtpHandler
 	s.mu.RUnlock()
@@ -391,9 +579,17 @@ func (s *Streamer) sendAudio(audioData []byte) error {
 	if rtpHandler == nil || !rtpHandler.IsRunning() {
 		return sip_infra.ErrRTPNotInitialized
 	}
+	return rtpHandler.SendDTMF(digits)
+}
 
-	// Get the current codec from the RTP handler (may have been updated by re-INVITE)
-	codec := rtpHandler.GetCodec()
+func (s *Streamer) sendAudio(audioData []byte) error {
+	s.mu.RLock()
+	rtpHandler := s.rtpHandler
+	s.mu.RUnlock()
+
+	if rtpHandler == nil || !rtpHandler.IsRunning() {
+		return sip_infra.ErrRTPNotInitialized
+	}
 
 	// TTS produces LINEAR16 16kHz audio. Resample to µ-law 8kHz for RTP transmission.
 	outData, err := s.Resampler().Resample(audioData, RAPIDA_AUDIO_CONFIG, MULAW_8K_AUDIO_CONFIG)
@@ -402,13 +598,19 @@ func (s *Streamer) sendAudio(audioData []byte) error {
 		return err
 	}
 
-	if codec != nil && codec.Name == "PCMA" {
-		outData = mulawToAlaw(outData)
+	if s.pickedUp.Load() {
+		// the caller is with the agent who picked the call up
+		return nil
+	}
+	if s.transferring.Load() {
+		s.bufferWhisper(outData)
+		return nil
 	}
 
 	// Use BaseStreamer output buffer for consistent 20ms chunking.
 	// BufferAndSendOutput accumulates audio and pushes 20ms frames to OutputCh.
 	// runRTPWriter goroutine reads from OutputCh and forwards to RTP handler.
+	// Frames stay µ-law until they are written, see runRTPWriter.
 	s.BufferAndSendOutput(outData)
 	return nil
 }
@@ -421,6 +623,11 @@ func (s *Streamer) sendAudio(audioData []byte) error {
 // - Queue incoming frames in pendingAudio
 // - Send one frame per 20ms tick
 // - On FlushAudioCh, discard all queued audio
+// - While the assistant holds the call, send hold music when nothing is queued
+//
+// Queued frames are µ-law and only encoded for the codec negotiated at the
+// moment they are written, so a re-INVITE switching PCMU/PCMA mid-utterance
+// takes effect on the next frame instead of garbling the queued audio.
 func (s *Streamer) runRTPWriter() {
 	const pacingInterval = 20 * time.Millisecond
 	ticker := time.NewTicker(pacingInterval)
@@ -428,6 +635,10 @@ func (s *Streamer) runRTPWriter() {
 
 	// pendingAudio holds 20ms PCM frames waiting for the next tick.
 	var pendingAudio [][]byte
+	// music is the hold music played while the assistant holds the call,
+	// from its frame musicAt on.
+	music := s.holdMusic()
+	musicAt := 0
 
 	for {
 		select {
@@ -446,6 +657,12 @@ func (s *Streamer) runRTPWriter() {
 			}
 
 		case <-ticker.C:
+			// Speech goes out over the hold music, the music goes on after it.
+			if len(pendingAudio) == 0 && len(music) > 0 && s.holding.Load() {
+				s.writeHoldMusic(music[musicAt])
+				musicAt = (musicAt + 1) % len(music)
+				continue
+			}
 			// Send one paced audio frame per tick (20ms real-time).
 			if len(pendingAudio) > 0 {
 				s.mu.RLock()
@@ -453,8 +670,12 @@ func (s *Streamer) runRTPWriter() {
 				s.mu.RUnlock()
 
 				if rtpHandler != nil && rtpHandler.IsRunning() {
+					frame := pendingAudio[0]
+					if codec := rtpHandler.GetCodec(); codec != nil && codec.Name == "PCMA" {
+						frame = mulawToAlaw(frame)
+					}
 					select {
-					case rtpHandler.AudioOut() <- pendingAudio[0]:
+					case rtpHandler.AudioOut() <- frame:
 					case <-s.ctx.Done():
 						return
 					default:
@@ -498,6 +719,7 @@ func (s *Streamer) Close() error {
 	}
 	// Cancel context first
 	s.cancel()
+	s.unpark()
 
 	s.mu.Lock()
 	rtpHandler := s.rtpHandler
@@ -508,6 +730,10 @@ func (s *Streamer) Close() error {
 	s.session = nil
 	s.mu.Unlock()
 
+	if session != nil && session.HandedOver() {
+		s.handedOver.Store(true)
+	}
+
 	// Clear input buffer
 	s.ResetInputBuffer()
 
@@ -546,3 +772,26 @@ func (s *Streamer) Close() error {
 func mulawToAlaw(in []byte) []byte {
 	return g711.EncodeAlaw(g711.DecodeUlaw(in))
 }
+
+// SendsDTMF reports that SEND_DTMF directives reach the remote party.
+func (s *Streamer) SendsDTMF() bool {
+	return true
+}
+
+// HoldsCall reports that HOLD_CONVERSATION directives put the caller on
+// hold.
+func (s *Streamer) HoldsCall() bool {
+	return true
+}
+
+// HandedOver reports whether the call was taken over by another instance.
+// Closing the streamer of such a call sends no BYE, the dialog goes on there.
+func (s *Streamer) HandedOver() bool {
+	if s.handedOver.Load() {
+		return true
+	}
+	s.mu.RLock()
+	session := s.session
+	s.mu.RUnlock()
+	return session != nil && session.HandedOver()
+}
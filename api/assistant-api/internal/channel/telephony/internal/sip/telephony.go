@@ -89,6 +89,25 @@ func (t *sipTelephony) parseConfig(vaultCredential *protos.VaultCredential) (*si
 	if domain, ok := credMap["sip_domain"].(string); ok {
 		cfg.Domain = domain
 	}
+	if holdAudio, ok := credMap["sip_hold_audio"].(string); ok {
+		cfg.HoldAudio = sip_infra.ParseHoldAudio(holdAudio)
+	}
+	if holdMusic, ok := credMap["sip_hold_music"].(string); ok && holdMusic != "" {
+		cfg.HoldMusic = sip_infra.ParseHoldAudio(holdMusic)
+	}
+	cfg.Redundancy = sip_infra.ParseFlag(credMap["sip_red"])
+	cfg.Concealment = sip_infra.ParseFlag(credMap["sip_plc"])
+	cfg.ComfortNoise = sip_infra.ParseComfortNoise(credMap["sip_comfort_noise"])
+	cfg.Pickup = sip_infra.ParseFlag(credMap["sip_pickup"])
+	if security, ok := credMap["sip_security"].(string); ok {
+		cfg.SecurityPolicy = sip_infra.ParseSecurityPolicy(security)
+	}
+	if callerName, ok := credMap["sip_caller_name"].(string); ok {
+		cfg.CallerName = callerName
+	}
+	if identity, ok := credMap["sip_identity_headers"].(string); ok {
+		cfg.IdentityHeaders = sip_infra.ParseIdentityHeaders(identity)
+	}
 
 	// --- Platform operational settings (from app config) ---
 	if t.appCfg.SIPConfig != nil {
@@ -161,6 +180,19 @@ func (t *sipTelephony) OutboundCall(
 		info.ErrorMessage = fmt.Sprintf("config error: %s", err.Error())
 		return info, err
 	}
+	// the deployment or the call request name the caller over the trunk's default
+	if callerName, _ := opts.GetString(internal_type.CallerNameOption); callerName != "" {
+		cfg.CallerName = callerName
+	}
+
+	// The destination is a phone number or a SIP URI dialled as given
+	if sip_infra.IsSIPURI(toPhone) {
+		if _, err := sip_infra.ParseSIPURI(toPhone); err != nil {
+			info.Status = "FAILED"
+			info.ErrorMessage = fmt.Sprintf("destination error: %s", err.Error())
+			return info, err
+		}
+	}
 
 	// Validate shared server is available and running
 	if t.sharedServer == nil {
@@ -0,0 +1,187 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sip_telephony
+
+import (
+	"fmt"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const (
+	// transferRingTimeout is how long the human agent leg may ring before the
+	// transfer is given up.
+	transferRingTimeout = 30 * time.Second
+
+	// whisperSettle is how long the whisper may stay silent before it is
+	// considered finished and the caller is bridged.
+	whisperSettle = time.Second
+
+	// mulawFrameSize is one 20ms frame of µ-law 8kHz audio.
+	mulawFrameSize = 160
+)
+
+// warmTransfer starts a warm transfer to the destination of a
+// TRANSFER_CONVERSATION directive. The caller stops being heard by the
+// assistant, assistant audio is kept as the whisper for the human agent, and
+// once the agent has heard it both legs are bridged. The assistant is out of
+// the call from then on.
+func (s *Streamer) warmTransfer(directive *protos.ConversationDirective) error {
+	arg, ok := directive.GetArgs()["to"]
+	if !ok {
+		return fmt.Errorf("transfer directive without destination")
+	}
+	to, err := utils.AnyToString(arg)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	dialer := s.dialer
+	if dialer == nil {
+		dialer = s.server
+	}
+	session := s.session
+	rtpHandler := s.rtpHandler
+	s.whisper = nil
+	s.whisperAt = time.Time{}
+	s.mu.Unlock()
+
+	if dialer == nil || session == nil || rtpHandler == nil {
+		return sip_infra.ErrRTPNotInitialized
+	}
+	if !s.transferring.CompareAndSwap(false, true) {
+		return fmt.Errorf("call is already being transferred")
+	}
+
+	// the bridge takes over the caller's audio, hand it over from the
+	// assistant first
+	select {
+	case s.transferCh <- struct{}{}:
+	case <-s.ctx.Done():
+		return nil
+	}
+
+	go s.runWarmTransfer(dialer, session, rtpHandler, to)
+	return nil
+}
+
+func (s *Streamer) runWarmTransfer(dialer *sip_infra.Server, session *sip_infra.Session, caller *sip_infra.RTPHandler, to string) {
+	s.Logger.Infow("Warm transfer started", "call_id", session.GetCallID(), "to", to)
+
+	agent, err := dialer.Dial(s.ctx, s.config, to, session.GetInfo().RemoteURI, transferRingTimeout)
+	if err != nil {
+		s.Logger.Warnw("Warm transfer failed, resuming the assistant", "call_id", session.GetCallID(), "to", to, "error", err)
+		s.abortTransfer(err)
+		return
+	}
+	defer func() {
+		if err := dialer.EndCall(agent); err != nil {
+			s.Logger.Warnw("Failed to end agent leg", "call_id", agent.GetCallID(), "error", err)
+		}
+	}()
+
+	agentRTP := agent.GetRTPHandler()
+	if agentRTP == nil {
+		s.abortTransfer(sip_infra.ErrRTPNotInitialized)
+		return
+	}
+	if !s.playWhisper(agent, agentRTP) {
+		// the agent hung up during the whisper, the caller stays with the assistant
+		s.abortTransfer(fmt.Errorf("agent hung up before the caller was connected"))
+		return
+	}
+
+	bridge := sip_infra.NewBridge(s.ctx, s.Logger, caller, agentRTP)
+	bridge.Start()
+	s.mu.Lock()
+	s.agentRTP = agentRTP
+	s.mu.Unlock()
+	s.Logger.Infow("Warm transfer bridged", "call_id", session.GetCallID(), "agent_call_id", agent.GetCallID())
+
+	select {
+	case <-bridge.Done():
+	case <-agent.ByeReceived():
+	case <-agent.Context().Done():
+	}
+	bridge.Stop()
+	s.Logger.Infow("Warm transfer ended", "call_id", session.GetCallID(), "agent_call_id", agent.GetCallID())
+	s.Close()
+}
+
+// playWhisper paces the buffered whisper to the agent leg until it has been
+// silent for whisperSettle. It reports false when the agent hung up meanwhile.
+func (s *Streamer) playWhisper(agent *sip_infra.Session, agentRTP *sip_infra.RTPHandler) bool {
+	ticker := time.NewTicker(packetIntervalMs * time.Millisecond)
+	defer ticker.Stop()
+
+	answeredAt := time.Now()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return false
+		case <-agent.ByeReceived():
+			return false
+		case <-agent.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		var frame []byte
+		if len(s.whisper) > 0 {
+			n := min(mulawFrameSize, len(s.whisper))
+			frame, s.whisper = s.whisper[:n], s.whisper[n:]
+		}
+		lastAudio := s.whisperAt
+		s.mu.Unlock()
+
+		if frame == nil {
+			if lastAudio.Before(answeredAt) {
+				lastAudio = answeredAt
+			}
+			if time.Since(lastAudio) >= whisperSettle {
+				return true
+			}
+			continue
+		}
+		if codec := agentRTP.GetCodec(); codec != nil && codec.Name == "PCMA" {
+			frame = mulawToAlaw(frame)
+		}
+		select {
+		case agentRTP.AudioOut() <- frame:
+		default:
+		}
+	}
+}
+
+// bufferWhisper keeps assistant audio produced during a transfer for the
+// agent leg. audio is µ-law 8kHz.
+func (s *Streamer) bufferWhisper(audio []byte) {
+	s.mu.Lock()
+	s.whisper = append(s.whisper, audio...)
+	s.whisperAt = time.Now()
+	s.mu.Unlock()
+}
+
+// abortTransfer hands the caller back to the assistant and tells it why the
+// transfer did not go through.
+func (s *Streamer) abortTransfer(reason error) {
+	s.mu.Lock()
+	s.whisper = nil
+	s.mu.Unlock()
+	s.transferring.Store(false)
+
+	go s.forwardIncomingAudio()
+	s.PushInput(&protos.ConversationMetadata{
+		Metadata: []*protos.Metadata{{Key: internal_type.MetadataKeyTransferFailed, Value: reason.Error()}},
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_twilio_telephony
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_telephony_base "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/base"
+	internal_twilio "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/twilio/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// conversationRelayStreamer talks to Twilio ConversationRelay. Twilio runs
+// speech recognition and synthesis, so the conversation is started in text
+// mode and only prompts and text tokens cross the websocket.
+type conversationRelayStreamer struct {
+	internal_telephony_base.BaseTelephonyStreamer
+
+	connection *websocket.Conn
+}
+
+func NewConversationRelayStreamer(logger commons.Logger, connection *websocket.Conn, cc *callcontext.CallContext, vaultCred *protos.VaultCredential) internal_type.Streamer {
+	return &conversationRelayStreamer{
+		BaseTelephonyStreamer: internal_telephony_base.NewBaseTelephonyStreamer(logger, cc, vaultCred),
+		connection:            connection,
+	}
+}
+
+func (crs *conversationRelayStreamer) Recv() (internal_type.Stream, error) {
+	// metadata queued by the setup message follows the connection request
+	select {
+	case msg := <-crs.InputCh:
+		return msg, nil
+	default:
+	}
+	if crs.connection == nil {
+		return nil, io.EOF
+	}
+	_, message, err := crs.connection.ReadMessage()
+	if err != nil {
+		if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+			crs.Logger.Error("Unexpected conversation relay close error", "error", err.Error())
+		}
+		crs.Cancel()
+		return nil, io.EOF
+	}
+
+	var event internal_twilio.ConversationRelayEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		crs.Logger.Error("Failed to unmarshal conversation relay message", "error", err.Error())
+		return nil, nil
+	}
+	return crs.handleEvent(event)
+}
+
+func (crs *conversationRelayStreamer) handleEvent(event internal_twilio.ConversationRelayEvent) (internal_type.Stream, error) {
+	switch event.Type {
+	case "setup":
+		if crs.ChannelUUID == "" {
+			crs.ChannelUUID = event.CallSid
+		}
+		crs.PushTransportMetadata(twilioProvider, map[string]string{
+			"mode":       ModeConversationRelay,
+			"call_sid":   event.CallSid,
+			"session_id": event.SessionId,
+			"direction":  event.Direction,
+		})
+		request := crs.CreateConnectionRequest()
+		request.StreamMode = protos.StreamMode_STREAM_MODE_TEXT
+		return request, nil
+	case "prompt":
+		// partial prompts are only sent when enabled on the TwiML
+		if !event.Last || strings.TrimSpace(event.VoicePrompt) == "" {
+			return nil, nil
+		}
+		return &protos.ConversationUserMessage{
+			Message:   &protos.ConversationUserMessage_Text{Text: event.VoicePrompt},
+			Completed: true,
+		}, nil
+	case "dtmf":
+		return &protos.ConversationMetadata{
+			Metadata: []*protos.Metadata{{Key: internal_type.MetadataKeyDTMF, Value: event.Digit}},
+		}, nil
+	case "interrupt":
+		// Twilio already stopped speaking, the prompt that follows carries
+		// what the caller said
+		crs.Logger.Debugf("caller interrupted after %q", event.UtteranceUntilInterrupt)
+		return nil, nil
+	case "error":
+		crs.Logger.Errorf("conversation relay error on call %s: %s", crs.GetConversationUuid(), event.Description)
+		return nil, nil
+	default:
+		crs.Logger.Warn("Unhandled conversation relay message", "type", event.Type)
+		return nil, nil
+	}
+}
+
+func (crs *conversationRelayStreamer) Send(response internal_type.Stream) error {
+	if crs.connection == nil {
+		return nil
+	}
+	switch data := response.(type) {
+	case *protos.ConversationAssistantMessage:
+		content, ok := data.Message.(*protos.ConversationAssistantMessage_Text)
+		if !ok {
+			return nil
+		}
+		// deltas stream as tokens, the completed message repeats the whole
+		// text and only ends the turn
+		if data.GetCompleted() {
+			return crs.write(internal_twilio.ConversationRelayText{Type: "text", Last: true})
+		}
+		return crs.write(internal_twilio.ConversationRelayText{Type: "text", Token: content.Text})
+	case *protos.ConversationDirective:
+		switch data.GetType() {
+		case protos.ConversationDirective_END_CONVERSATION:
+			if err := crs.write(internal_twilio.ConversationRelayEnd{Type: "end"}); err != nil {
+				crs.Logger.Errorf("Error ending conversation relay session: %v", err)
+			}
+			return crs.Cancel()
+		case protos.ConversationDirective_SEND_DTMF:
+			digits, err := internal_telephony_base.DTMFDigits(data)
+			if err != nil {
+				return err
+			}
+			return crs.write(internal_twilio.ConversationRelayDigits{Type: "sendDigits", Digits: digits})
+		}
+	}
+	return nil
+}
+
+func (crs *conversationRelayStreamer) write(message interface{}) error {
+	if crs.connection == nil {
+		return nil
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if err := crs.connection.WriteMessage(websocket.TextMessage, payload); err != nil {
+		crs.Logger.Error("Failed to send message to conversation relay", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (crs *conversationRelayStreamer) GetConversationUuid() string {
+	return crs.ChannelUUID
+}
+
+func (crs *conversationRelayStreamer) Cancel() error {
+	if crs.connection != nil {
+		crs.connection.Close()
+		crs.connection = nil
+	}
+	return nil
+}
+
+// SendsDTMF reports that SEND_DTMF directives reach the remote party.
+func (crs *conversationRelayStreamer) SendsDTMF() bool {
+	return true
+}
@@ -13,5 +13,66 @@ type TwilioMediaEvent struct {
 		Timestamp string `json:"timestamp"`
 		Payload   string `json:"payload"`
 	} `json:"media"`
+	Start struct {
+		AccountSid  string   `json:"accountSid"`
+		CallSid     string   `json:"callSid"`
+		Tracks      []string `json:"tracks"`
+		MediaFormat struct {
+			Encoding   string `json:"encoding"`
+			SampleRate int    `json:"sampleRate"`
+			Channels   int    `json:"channels"`
+		} `json:"mediaFormat"`
+		CustomParameters map[string]string `json:"customParameters"`
+	} `json:"start"`
 	StreamSid string `json:"streamSid"`
 }
+
+// ConversationRelayEvent is a message Twilio sends over a ConversationRelay
+// websocket. Twilio transcribes the caller, prompts carry the text.
+type ConversationRelayEvent struct {
+	Type string `json:"type"`
+
+	// setup
+	SessionId        string            `json:"sessionId"`
+	CallSid          string            `json:"callSid"`
+	From             string            `json:"from"`
+	To               string            `json:"to"`
+	Direction        string            `json:"direction"`
+	CustomParameters map[string]string `json:"customParameters"`
+
+	// prompt
+	VoicePrompt string `json:"voicePrompt"`
+	Lang        string `json:"lang"`
+	Last        bool   `json:"last"`
+
+	// interrupt
+	UtteranceUntilInterrupt  string `json:"utteranceUntilInterrupt"`
+	DurationUntilInterruptMs int    `json:"durationUntilInterruptMs"`
+
+	// dtmf
+	Digit string `json:"digit"`
+
+	// error
+	Description string `json:"description"`
+}
+
+// ConversationRelayText is a text token for Twilio to speak, last marks the
+// end of the assistant's turn.
+type ConversationRelayText struct {
+	Type  string `json:"type"`
+	Token string `json:"token"`
+	Last  bool   `json:"last"`
+}
+
+// ConversationRelayDigits sends DTMF digits on the call.
+type ConversationRelayDigits struct {
+	Type   string `json:"type"`
+	Digits string `json:"digits"`
+}
+
+// ConversationRelayEnd ends the session, Twilio continues with the action
+// url of <Connect> or hangs up.
+type ConversationRelayEnd struct {
+	Type        string `json:"type"`
+	HandoffData string `json:"handoffData,omitempty"`
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_twilio_telephony
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Deployment options selecting how Twilio connects the call. With mode
+// conversation_relay Twilio transcribes and speaks the call, the relay
+// options are passed on as <ConversationRelay> attributes.
+const (
+	ModeOption            = "mode"
+	ModeMediaStream       = "media_stream"
+	ModeConversationRelay = "conversation_relay"
+)
+
+// relayAttributes maps deployment options to <ConversationRelay> attributes.
+var relayAttributes = []struct{ option, attribute string }{
+	{"relay.language", "language"},
+	{"relay.voice", "voice"},
+	{"relay.tts_provider", "ttsProvider"},
+	{"relay.transcription_provider", "transcriptionProvider"},
+}
+
+// IsConversationRelay reports whether the deployment options select
+// ConversationRelay instead of media streams.
+func IsConversationRelay(opts utils.Option) bool {
+	mode, err := opts.GetString(ModeOption)
+	return err == nil && strings.EqualFold(strings.TrimSpace(mode), ModeConversationRelay)
+}
+
+// CreateConversationRelayTwiML connects the call to the assistant through
+// ConversationRelay, the websocket at path receives the caller's speech as
+// text and sends back the text Twilio speaks.
+func (tpc *twilioTelephony) CreateConversationRelayTwiML(mediaServer string, path string, assistantId uint64, clientNumber string, opts utils.Option) string {
+	var attrs strings.Builder
+	writeRelayAttribute(&attrs, "url", fmt.Sprintf("wss://%s/%s", mediaServer, path))
+	for _, attr := range relayAttributes {
+		if v, err := opts.GetString(attr.option); err == nil && strings.TrimSpace(v) != "" {
+			writeRelayAttribute(&attrs, attr.attribute, strings.TrimSpace(v))
+		}
+	}
+	// digits are forwarded to the assistant, and the caller can talk over it
+	writeRelayAttribute(&attrs, "dtmfDetection", "true")
+	writeRelayAttribute(&attrs, "interruptible", "any")
+
+	return fmt.Sprintf(`
+	    <Response>
+		 	<Connect>
+	        	<ConversationRelay%s>
+					<Parameter name="assistant_id" value="%d"/>
+					<Parameter name="client_number" value="%s"/>
+				</ConversationRelay>
+			</Connect>
+	    </Response>
+	`,
+		attrs.String(),
+		assistantId,
+		escapeRelayValue(clientNumber),
+	)
+}
+
+func writeRelayAttribute(b *strings.Builder, name, value string) {
+	fmt.Fprintf(b, ` %s="%s"`, name, escapeRelayValue(value))
+}
+
+func escapeRelayValue(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_twilio_telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/rapidaai/api/assistant-api/config"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_twilio "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/twilio/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestIsConversationRelay(t *testing.T) {
+	assert.True(t, IsConversationRelay(utils.Option{"mode": "conversation_relay"}))
+	assert.True(t, IsConversationRelay(utils.Option{"mode": " Conversation_Relay "}))
+	assert.False(t, IsConversationRelay(utils.Option{"mode": "media_stream"}))
+	assert.False(t, IsConversationRelay(utils.Option{}))
+	assert.False(t, IsConversationRelay(nil))
+}
+
+func TestInboundCall_ConversationRelay(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, _ := commons.NewApplicationLogger()
+	tel, err := NewTwilioTelephony(&config.AssistantConfig{PublicAssistantHost: "api.rapida.ai"}, logger)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("contextId", "ctx-1")
+	c.Set("options", utils.Option{
+		"mode":           "conversation_relay",
+		"relay.language": "en-GB",
+		"relay.voice":    `Polly.Amy"`,
+	})
+	require.NoError(t, tel.InboundCall(c, nil, 1, "+15551234567", 2))
+
+	body := w.Body.String()
+	assert.Contains(t, body, `<ConversationRelay url="wss://api.rapida.ai/v1/talk/twilio/ctx/ctx-1" language="en-GB" voice="Polly.Amy&#34;" dtmfDetection="true" interruptible="any">`)
+	assert.Contains(t, body, `<Parameter name="client_number" value="+15551234567"/>`)
+	assert.NotContains(t, body, "<Stream")
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Set("contextId", "ctx-1")
+	require.NoError(t, tel.InboundCall(c, nil, 1, "+15551234567", 2))
+	assert.Contains(t, w.Body.String(), `<Stream url="wss://api.rapida.ai/v1/talk/twilio/ctx/ctx-1"`)
+}
+
+// relayPair connects a ConversationRelay streamer to a websocket whose
+// server side plays Twilio.
+func relayPair(t *testing.T) (*conversationRelayStreamer, *websocket.Conn) {
+	twilio := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		twilio <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	logger, _ := commons.NewApplicationLogger()
+	streamer := NewConversationRelayStreamer(logger, conn, &callcontext.CallContext{
+		AssistantID:    1,
+		ConversationID: 2,
+		StreamMode:     callcontext.StreamModeText,
+	}, nil).(*conversationRelayStreamer)
+	return streamer, <-twilio
+}
+
+func TestConversationRelayStreamer_Recv(t *testing.T) {
+	streamer, twilio := relayPair(t)
+
+	require.NoError(t, twilio.WriteJSON(map[string]interface{}{"type": "setup", "callSid": "CA123", "direction": "inbound"}))
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	initialization, ok := msg.(*protos.ConversationInitialization)
+	require.True(t, ok)
+	assert.Equal(t, protos.StreamMode_STREAM_MODE_TEXT, initialization.GetStreamMode())
+	assert.Equal(t, uint64(2), initialization.GetAssistantConversationId())
+	assert.Equal(t, "CA123", streamer.GetConversationUuid())
+
+	// the session's transport details follow the connection request
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	transport, ok := msg.(*protos.ConversationMetadata)
+	require.True(t, ok)
+	values := map[string]string{}
+	for _, m := range transport.GetMetadata() {
+		values[m.GetKey()] = m.GetValue()
+	}
+	assert.Equal(t, map[string]string{
+		"transport.channel":          "twilio",
+		"transport.twilio.call_sid":  "CA123",
+		"transport.twilio.direction": "inbound",
+		"transport.twilio.mode":      "conversation_relay",
+	}, values)
+
+	require.NoError(t, twilio.WriteJSON(map[string]interface{}{"type": "prompt", "voicePrompt": "I want to", "last": false}))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+
+	require.NoError(t, twilio.WriteJSON(map[string]interface{}{"type": "prompt", "voicePrompt": "I want to book a table", "last": true}))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	user, ok := msg.(*protos.ConversationUserMessage)
+	require.True(t, ok)
+	assert.Equal(t, "I want to book a table", user.GetText())
+
+	require.NoError(t, twilio.WriteJSON(map[string]interface{}{"type": "dtmf", "digit": "5"}))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	metadata, ok := msg.(*protos.ConversationMetadata)
+	require.True(t, ok)
+	assert.Equal(t, internal_type.MetadataKeyDTMF, metadata.GetMetadata()[0].GetKey())
+	assert.Equal(t, "5", metadata.GetMetadata()[0].GetValue())
+
+	twilio.Close()
+	_, err = streamer.Recv()
+	assert.Error(t, err)
+}
+
+func TestConversationRelayStreamer_Send(t *testing.T) {
+	streamer, twilio := relayPair(t)
+
+	require.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Message: &protos.ConversationAssistantMessage_Text{Text: "Sure, "},
+	}))
+	require.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Message:   &protos.ConversationAssistantMessage_Text{Text: "Sure, for how many?"},
+		Completed: true,
+	}))
+	// audio is never produced in text mode, and is not forwarded if it is
+	require.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Message: &protos.ConversationAssistantMessage_Audio{Audio: []byte{1, 2}},
+	}))
+	digits, err := anypb.New(wrapperspb.String("12#"))
+	require.NoError(t, err)
+	require.NoError(t, streamer.Send(&protos.ConversationDirective{
+		Type: protos.ConversationDirective_SEND_DTMF,
+		Args: map[string]*anypb.Any{"digits": digits},
+	}))
+	require.NoError(t, streamer.Send(&protos.ConversationDirective{Type: protos.ConversationDirective_END_CONVERSATION}))
+
+	var token internal_twilio.ConversationRelayText
+	require.NoError(t, twilio.ReadJSON(&token))
+	assert.Equal(t, internal_twilio.ConversationRelayText{Type: "text", Token: "Sure, "}, token)
+	require.NoError(t, twilio.ReadJSON(&token))
+	assert.Equal(t, internal_twilio.ConversationRelayText{Type: "text", Last: true}, token)
+
+	var sent internal_twilio.ConversationRelayDigits
+	require.NoError(t, twilio.ReadJSON(&sent))
+	assert.Equal(t, internal_twilio.ConversationRelayDigits{Type: "sendDigits", Digits: "12#"}, sent)
+
+	var end internal_twilio.ConversationRelayEnd
+	require.NoError(t, twilio.ReadJSON(&end))
+	assert.Equal(t, "end", end.Type)
+	assert.Nil(t, streamer.connection)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rapidaai/api/assistant-api/config"
@@ -111,15 +112,34 @@ func (tpc *twilioTelephony) OutboundCall(auth types.SimplePrinciple, toPhone str
 		"initiated", "ringing", "answered", "completed",
 	})
 	callParams.SetStatusCallbackMethod("POST")
-	callParams.SetTwiml(
-		tpc.CreateTwinML(
-			tpc.appCfg.PublicAssistantHost,
-			fmt.Sprintf("%d__%d", assistantId, assistantConversationId),
-			internal_type.GetContextAnswerPath(twilioProvider, contextID),
-			fmt.Sprintf("https://%s/%s", tpc.appCfg.PublicAssistantHost, internal_type.GetContextEventPath(twilioProvider, contextID)),
-			assistantId,
-			toPhone),
-	)
+	setCallReason(callParams, opts)
+	if IsConversationRelay(opts) {
+		callParams.SetTwiml(
+			tpc.CreateConversationRelayTwiML(
+				tpc.appCfg.PublicAssistantHost,
+				internal_type.GetContextAnswerPath(twilioProvider, contextID),
+				assistantId,
+				toPhone,
+				opts),
+		)
+	} else {
+		// detection is synchronous, the stream only starts once twilio knows
+		// who picked up and a machine's greeting has ended
+		detectMachine := LeavesVoicemail(opts)
+		if detectMachine {
+			callParams.SetMachineDetection(machineDetectionMode)
+		}
+		callParams.SetTwiml(
+			tpc.CreateTwinML(
+				tpc.appCfg.PublicAssistantHost,
+				fmt.Sprintf("%d__%d", assistantId, assistantConversationId),
+				internal_type.GetContextAnswerPath(twilioProvider, contextID),
+				fmt.Sprintf("https://%s/%s", tpc.appCfg.PublicAssistantHost, internal_type.GetContextEventPath(twilioProvider, contextID)),
+				assistantId,
+				toPhone,
+				detectMachine),
+		)
+	}
 	resp, err := client.Api.CreateCall(callParams)
 	if err != nil || resp.Status == nil || resp.Sid == nil {
 		info.Status = "FAILED"
@@ -133,13 +153,27 @@ func (tpc *twilioTelephony) OutboundCall(auth types.SimplePrinciple, toPhone str
 	return info, nil
 }
 
-func (tpc *twilioTelephony) CreateTwinML(mediaServer string, name, path string, callback string, assistantId uint64, clientNumber string) string {
+// setCallReason presents the caller reason of the options with the call on
+// carriers supporting twilio's branded calls. The caller name itself is the
+// CNAM registered for the from number, twilio takes no name per call.
+func setCallReason(callParams *openapi.CreateCallParams, opts utils.Option) {
+	if reason, _ := opts.GetString(internal_type.CallerReasonOption); strings.TrimSpace(reason) != "" {
+		callParams.SetCallReason(strings.TrimSpace(reason))
+	}
+}
+
+func (tpc *twilioTelephony) CreateTwinML(mediaServer string, name, path string, callback string, assistantId uint64, clientNumber string, detectMachine bool) string {
+	var detection string
+	if detectMachine {
+		detection = fmt.Sprintf(`
+					<Parameter name="%s" value="true"/>`, machineDetectionParameter)
+	}
 	return fmt.Sprintf(`
 	    <Response>
 		 	<Connect>
 	        	<Stream url="wss://%s/%s" name="%s" statusCallback="%s" statusCallbackEvent="initiated ringing answered completed">
 					<Parameter name="assistant_id" value="%d"/>
-					<Parameter name="client_number" value="%s"/>
+					<Parameter name="client_number" value="%s"/>%s
 				</Stream>
 			</Connect>
 	    </Response>
@@ -150,6 +184,7 @@ func (tpc *twilioTelephony) CreateTwinML(mediaServer string, name, path string,
 		callback,
 		assistantId,
 		clientNumber,
+		detection,
 	)
 }
 
@@ -157,13 +192,26 @@ func (tpc *twilioTelephony) InboundCall(c *gin.Context, auth types.SimplePrincip
 	contextID, _ := c.Get("contextId")
 	ctxID := fmt.Sprintf("%v", contextID)
 
+	// the dispatcher passes the deployment options along with the contextId
+	if options, ok := c.Get("options"); ok {
+		if opts, ok := options.(utils.Option); ok && IsConversationRelay(opts) {
+			c.Data(http.StatusOK, "text/xml", []byte(
+				tpc.CreateConversationRelayTwiML(
+					tpc.appCfg.PublicAssistantHost,
+					internal_type.GetContextAnswerPath(twilioProvider, ctxID),
+					assistantId, clientNumber, opts),
+			))
+			return nil
+		}
+	}
+
 	c.Data(http.StatusOK, "text/xml", []byte(
 		tpc.CreateTwinML(
 			tpc.appCfg.PublicAssistantHost,
 			fmt.Sprintf("%d__%d", assistantId, assistantConversationId),
 			internal_type.GetContextAnswerPath("twilio", ctxID),
 			fmt.Sprintf("https://%s/%s", tpc.appCfg.PublicAssistantHost, internal_type.GetContextEventPath("twilio", ctxID)),
-			assistantId, clientNumber),
+			assistantId, clientNumber, false),
 	))
 	return nil
 }
@@ -13,8 +13,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	openapi "github.com/twilio/twilio-go/rest/api/v2010"
 )
 
 // TestReceiveCall tests the ReceiveCall method with Twilio webhook parameters
@@ -335,3 +337,13 @@ func TestReceiveCall_CallInfoStructure(t *testing.T) {
 	assert.Equal(t, "webhook", callInfo.StatusInfo.Event)
 	assert.NotNil(t, callInfo.StatusInfo.Payload)
 }
+
+func TestSetCallReason(t *testing.T) {
+	params := &openapi.CreateCallParams{}
+	setCallReason(params, utils.Option{internal_type.CallerReasonOption: " Appointment reminder "})
+	assert.Equal(t, "Appointment reminder", *params.CallReason)
+
+	params = &openapi.CreateCallParams{}
+	setCallReason(params, utils.Option{internal_type.CallerNameOption: "Rapida Clinic"})
+	assert.Nil(t, params.CallReason)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_twilio_telephony
+
+import (
+	"strings"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+const (
+	// machineDetectionMode waits for the end of a machine's greeting, so a
+	// message played right after the stream starts lands after the beep.
+	machineDetectionMode = "DetectMessageEnd"
+
+	// machineDetectionParameter is the <Stream> parameter telling the streamer
+	// the call went through answering machine detection.
+	machineDetectionParameter = "machine_detection"
+)
+
+// LeavesVoicemail reports whether the deployment options configure a message
+// for answering machines, outbound media stream calls then detect them.
+func LeavesVoicemail(opts utils.Option) bool {
+	message, err := opts.GetString(internal_type.VoicemailMessageOption)
+	return err == nil && strings.TrimSpace(message) != ""
+}
+
+// answeredBy maps twilio's AnsweredBy of a call to internal_type's values.
+func answeredBy(twilioAnsweredBy string) string {
+	switch {
+	case twilioAnsweredBy == "human":
+		return internal_type.AnsweredByHuman
+	case strings.HasPrefix(twilioAnsweredBy, "machine_"):
+		return internal_type.AnsweredByMachine
+	case twilioAnsweredBy == "fax":
+		return internal_type.AnsweredByFax
+	default:
+		return internal_type.AnsweredByUnknown
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_twilio_telephony
+
+import (
+	"testing"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeavesVoicemail(t *testing.T) {
+	assert.True(t, LeavesVoicemail(utils.Option{"voicemail.message": "Hi, this is Rapida calling back."}))
+	assert.False(t, LeavesVoicemail(utils.Option{"voicemail.message": "  "}))
+	assert.False(t, LeavesVoicemail(utils.Option{}))
+}
+
+func TestAnsweredBy(t *testing.T) {
+	for twilio, want := range map[string]string{
+		"human":               internal_type.AnsweredByHuman,
+		"machine_end_beep":    internal_type.AnsweredByMachine,
+		"machine_end_silence": internal_type.AnsweredByMachine,
+		"machine_end_other":   internal_type.AnsweredByMachine,
+		"fax":                 internal_type.AnsweredByFax,
+		"unknown":             internal_type.AnsweredByUnknown,
+		"":                    internal_type.AnsweredByUnknown,
+	} {
+		assert.Equal(t, want, answeredBy(twilio), twilio)
+	}
+}
+
+func TestCreateTwinML_MachineDetection(t *testing.T) {
+	tpc := &twilioTelephony{}
+	twiml := tpc.CreateTwinML("host", "1__2", "v1/talk/twilio/ctx/abc", "https://host/event", 1, "+15550100", true)
+	assert.Contains(t, twiml, `<Parameter name="machine_detection" value="true"/>`)
+
+	twiml = tpc.CreateTwinML("host", "1__2", "v1/talk/twilio/ctx/abc", "https://host/event", 1, "+15550100", false)
+	assert.NotContains(t, twiml, "machine_detection")
+}
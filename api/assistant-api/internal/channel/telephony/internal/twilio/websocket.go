@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/gorilla/websocket"
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
@@ -19,6 +20,7 @@ import (
 	internal_twilio "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/twilio/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 	"github.com/twilio/twilio-go"
 	openapi "github.com/twilio/twilio-go/rest/api/v2010"
@@ -51,6 +53,12 @@ func NewTwilioWebsocketStreamer(logger commons.Logger, connection *websocket.Con
 }
 
 func (tws *twilioWebsocketStreamer) Recv() (internal_type.Stream, error) {
+	// metadata queued after the start event follows the connection request
+	select {
+	case msg := <-tws.InputCh:
+		return msg, nil
+	default:
+	}
 	if tws.connection == nil {
 		return nil, tws.handleError("WebSocket connection is nil", io.EOF)
 	}
@@ -68,8 +76,7 @@ func (tws *twilioWebsocketStreamer) Recv() (internal_type.Stream, error) {
 	case "connected":
 		return nil, nil
 	case "start":
-		tws.handleStartEvent(mediaEvent)
-		return tws.CreateConnectionRequest(), nil
+		return tws.handleStartEvent(mediaEvent), nil
 	case "media":
 		msg, err := tws.handleMediaEvent(mediaEvent)
 		if msg == nil {
@@ -164,13 +171,56 @@ func (tws *twilioWebsocketStreamer) Send(response internal_type.Stream) error {
 				tws.Logger.Errorf("Error disconnecting command:", err)
 			}
 		}
+		if data.GetType() == protos.ConversationDirective_SEND_DTMF {
+			// Media streams only receive DTMF, and redirecting the call to
+			// <Play digits> would tear the stream down. The streamer is no
+			// DTMFChannel, so the tool reports this to the model first.
+			tws.Logger.Warnf("sending DTMF is not supported on twilio media streams, call %s", tws.GetConversationUuid())
+		}
 	}
 	return nil
 }
 
 // start event contains streamSid to be used for subsequent media messages
-func (tws *twilioWebsocketStreamer) handleStartEvent(mediaEvent internal_twilio.TwilioMediaEvent) {
+func (tws *twilioWebsocketStreamer) handleStartEvent(mediaEvent internal_twilio.TwilioMediaEvent) *protos.ConversationInitialization {
 	tws.streamID = mediaEvent.StreamSid
+	request := tws.CreateConnectionRequest()
+
+	start := mediaEvent.Start
+	fields := map[string]string{
+		"mode":       ModeMediaStream,
+		"call_sid":   start.CallSid,
+		"stream_sid": mediaEvent.StreamSid,
+		"tracks":     strings.Join(start.Tracks, ","),
+	}
+	if start.MediaFormat.Encoding != "" {
+		fields["media_format"] = fmt.Sprintf("%s;rate=%d;channels=%d", start.MediaFormat.Encoding, start.MediaFormat.SampleRate, start.MediaFormat.Channels)
+	}
+	if start.CustomParameters[machineDetectionParameter] == "true" {
+		by := tws.fetchAnsweredBy(start.CallSid)
+		fields["answered_by"] = by
+		if metadata, err := utils.InterfaceMapToAnyMap(map[string]interface{}{internal_type.MetadataKeyAnsweredBy: by}); err == nil {
+			request.Metadata = metadata
+		}
+	}
+	tws.PushTransportMetadata(twilioProvider, fields)
+	return request
+}
+
+// fetchAnsweredBy asks twilio who picked up the call. Detection finished
+// before the stream started, so the result is on the call already.
+func (tws *twilioWebsocketStreamer) fetchAnsweredBy(callSid string) string {
+	client, err := tws.client(tws.VaultCredential())
+	if err != nil {
+		tws.Logger.Errorf("Error creating Twilio client: %v", err)
+		return internal_type.AnsweredByUnknown
+	}
+	call, err := client.Api.FetchCall(callSid, &openapi.FetchCallParams{})
+	if err != nil || call.AnsweredBy == nil {
+		tws.Logger.Warnf("unable to fetch who answered call %s: %v", callSid, err)
+		return internal_type.AnsweredByUnknown
+	}
+	return answeredBy(*call.AnsweredBy)
 }
 
 func (tws *twilioWebsocketStreamer) GetConversationUuid() string {
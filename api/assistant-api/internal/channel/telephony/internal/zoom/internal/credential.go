@@ -0,0 +1,77 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_zoom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+// maxWebhookAge rejects replayed webhooks, Zoom signs the time it sent them.
+const maxWebhookAge = 5 * time.Minute
+
+// Credential is a zoom vault credential: the OAuth client of the Zoom app
+// and the secret token of its event subscription.
+type Credential struct {
+	ClientID     string
+	ClientSecret string
+	SecretToken  string
+}
+
+// NewCredential reads client_id, client_secret and secret_token.
+func NewCredential(vaultCredential *protos.VaultCredential) (*Credential, error) {
+	credMap := vaultCredential.GetValue().AsMap()
+	cred := &Credential{}
+	cred.ClientID, _ = credMap["client_id"].(string)
+	cred.ClientSecret, _ = credMap["client_secret"].(string)
+	cred.SecretToken, _ = credMap["secret_token"].(string)
+	if cred.ClientID == "" || cred.ClientSecret == "" || cred.SecretToken == "" {
+		return nil, errors.New("zoom credential requires client_id, client_secret and secret_token")
+	}
+	return cred, nil
+}
+
+// EncryptToken answers the url validation challenge of plainToken.
+func (c *Credential) EncryptToken(plainToken string) string {
+	return hexHMAC(c.SecretToken, plainToken)
+}
+
+// VerifyWebhook checks the signature Zoom sent body with, the signed
+// message is v0:<timestamp>:<body>.
+func (c *Credential) VerifyWebhook(signature, timestamp string, body []byte, now time.Time) error {
+	if signature == "" || timestamp == "" {
+		return errors.New("webhook is not signed")
+	}
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid webhook timestamp")
+	}
+	if age := now.Sub(time.Unix(sent, 0)); age > maxWebhookAge || age < -maxWebhookAge {
+		return errors.New("webhook timestamp is out of range")
+	}
+	expected := "v0=" + hexHMAC(c.SecretToken, "v0:"+timestamp+":"+string(body))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("webhook signature mismatch")
+	}
+	return nil
+}
+
+// StreamSignature signs the handshakes of a stream with the client secret.
+func (c *Credential) StreamSignature(meetingUUID, streamID string) string {
+	return hexHMAC(c.ClientSecret, c.ClientID+","+meetingUUID+","+streamID)
+}
+
+func hexHMAC(key, message string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
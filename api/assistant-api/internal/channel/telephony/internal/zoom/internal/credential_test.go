@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_zoom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testCredential() *Credential {
+	return &Credential{ClientID: "client", ClientSecret: "client-secret", SecretToken: "token"}
+}
+
+func TestNewCredential(t *testing.T) {
+	_, err := NewCredential(&protos.VaultCredential{})
+	assert.ErrorContains(t, err, "client_id")
+
+	value, err := structpb.NewStruct(map[string]interface{}{"client_id": "id", "client_secret": "secret", "secret_token": "token"})
+	require.NoError(t, err)
+	cred, err := NewCredential(&protos.VaultCredential{Value: value})
+	require.NoError(t, err)
+	assert.Equal(t, &Credential{ClientID: "id", ClientSecret: "secret", SecretToken: "token"}, cred)
+}
+
+func TestCredential_EncryptToken(t *testing.T) {
+	mac := hmac.New(sha256.New, []byte("token"))
+	mac.Write([]byte("qgg8vlvZRS6UYooatFL8Aw"))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), testCredential().EncryptToken("qgg8vlvZRS6UYooatFL8Aw"))
+}
+
+func TestCredential_VerifyWebhook(t *testing.T) {
+	cred := testCredential()
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"event":"meeting.rtms_started"}`)
+	signature := "v0=" + hexHMAC("token", "v0:"+timestamp+":"+string(body))
+
+	assert.NoError(t, cred.VerifyWebhook(signature, timestamp, body, now))
+	assert.Error(t, cred.VerifyWebhook(signature, timestamp, []byte(`{"event":"other"}`), now))
+	assert.Error(t, cred.VerifyWebhook("", timestamp, body, now))
+	assert.ErrorContains(t, cred.VerifyWebhook(signature, timestamp, body, now.Add(10*time.Minute)), "out of range")
+}
+
+func TestCredential_StreamSignature(t *testing.T) {
+	assert.Equal(t, hexHMAC("client-secret", "client,meeting==,stream-1"), testCredential().StreamSignature("meeting==", "stream-1"))
+}
+
+func TestFirstURL(t *testing.T) {
+	assert.Equal(t, "wss://a.zoom.us", FirstURL(" wss://a.zoom.us, wss://b.zoom.us"))
+	assert.Equal(t, "", FirstURL(" , "))
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_zoom
+
+import "strings"
+
+// Keys of the call context media of an RTMS stream.
+const (
+	MediaMeetingUUID = "meeting_uuid"
+	MediaStreamID    = "rtms_stream_id"
+	MediaServerURLs  = "server_urls"
+)
+
+// Webhook events of the Zoom event subscription.
+const (
+	EventURLValidation = "endpoint.url_validation"
+
+	// eventRTMSStarted ends the name of the events that start a stream, e.g.
+	// meeting.rtms_started. Contact Center and Phone streams use their own
+	// prefix with the same payload.
+	eventRTMSStarted = "rtms_started"
+)
+
+// Headers Zoom signs its webhooks with.
+const (
+	HeaderSignature = "x-zm-signature"
+	HeaderTimestamp = "x-zm-request-timestamp"
+)
+
+// WebhookEvent is a notification of the Zoom event subscription.
+type WebhookEvent struct {
+	Event   string         `json:"event"`
+	EventTs int64          `json:"event_ts"`
+	Payload WebhookPayload `json:"payload"`
+}
+
+// WebhookPayload holds the fields of the events the channel reads, the
+// validation challenge and the start of a stream.
+type WebhookPayload struct {
+	PlainToken   string `json:"plainToken"`
+	MeetingUUID  string `json:"meeting_uuid"`
+	RTMSStreamID string `json:"rtms_stream_id"`
+	ServerURLs   string `json:"server_urls"`
+	OperatorID   string `json:"operator_id"`
+}
+
+// StartsStream reports whether the event hands a stream over.
+func (e WebhookEvent) StartsStream() bool {
+	return strings.HasSuffix(e.Event, eventRTMSStarted)
+}
+
+// URLValidationResponse answers the challenge Zoom sends when the endpoint
+// is saved in the app.
+type URLValidationResponse struct {
+	PlainToken     string `json:"plainToken"`
+	EncryptedToken string `json:"encryptedToken"`
+}
+
+// Message types of the RTMS signaling and media connections.
+const (
+	MsgSignalingHandshakeReq  = 1
+	MsgSignalingHandshakeResp = 2
+	MsgDataHandshakeReq       = 3
+	MsgDataHandshakeResp      = 4
+	MsgClientReadyAck         = 7
+	MsgStreamStateUpdate      = 8
+	MsgSessionStateUpdate     = 9
+	MsgKeepAliveReq           = 12
+	MsgKeepAliveResp          = 13
+	MsgMediaDataAudio         = 14
+)
+
+// Values of the audio the media handshake asks for: raw 16 kHz mono L16, one
+// stream with every participant mixed, sent every 20 ms.
+const (
+	MediaTypeAudio       = 1
+	AudioContentRaw      = 2
+	AudioSampleRate16k   = 1
+	AudioChannelMono     = 1
+	AudioCodecL16        = 1
+	AudioDataMixedStream = 1
+	AudioSendRateMs      = 20
+)
+
+// StatusOK is the status_code of a successful handshake.
+const StatusOK = 0
+
+// Stream and session states that end the stream.
+const (
+	StreamStateTerminating = 3
+	StreamStateTerminated  = 4
+	SessionStateStopped    = 5
+)
+
+// Message is the envelope of every RTMS message, the fields present depend
+// on MsgType.
+type Message struct {
+	MsgType      int           `json:"msg_type"`
+	StatusCode   int           `json:"status_code,omitempty"`
+	Reason       string        `json:"reason,omitempty"`
+	State        int           `json:"state,omitempty"`
+	Timestamp    int64         `json:"timestamp,omitempty"`
+	MediaServer  *MediaServer  `json:"media_server,omitempty"`
+	Content      *AudioContent `json:"content,omitempty"`
+	RTMSStreamID string        `json:"rtms_stream_id,omitempty"`
+}
+
+type MediaServer struct {
+	ServerURLs struct {
+		Audio string `json:"audio"`
+		All   string `json:"all"`
+	} `json:"server_urls"`
+}
+
+// AudioContent is a frame of the caller's audio, base64 encoded.
+type AudioContent struct {
+	UserID    int64  `json:"user_id"`
+	Data      string `json:"data"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// SignalingHandshake opens the signaling connection of a stream.
+type SignalingHandshake struct {
+	MsgType         int    `json:"msg_type"`
+	ProtocolVersion int    `json:"protocol_version"`
+	MeetingUUID     string `json:"meeting_uuid"`
+	RTMSStreamID    string `json:"rtms_stream_id"`
+	Signature       string `json:"signature"`
+}
+
+// DataHandshake opens the media connection and picks the audio format.
+type DataHandshake struct {
+	MsgType           int         `json:"msg_type"`
+	ProtocolVersion   int         `json:"protocol_version"`
+	MeetingUUID       string      `json:"meeting_uuid"`
+	RTMSStreamID      string      `json:"rtms_stream_id"`
+	Signature         string      `json:"signature"`
+	MediaType         int         `json:"media_type"`
+	PayloadEncryption bool        `json:"payload_encryption"`
+	MediaParams       MediaParams `json:"media_params"`
+}
+
+type MediaParams struct {
+	Audio AudioParams `json:"audio"`
+}
+
+type AudioParams struct {
+	ContentType int `json:"content_type"`
+	SampleRate  int `json:"sample_rate"`
+	Channel     int `json:"channel"`
+	Codec       int `json:"codec"`
+	DataOpt     int `json:"data_opt"`
+	SendRate    int `json:"send_rate"`
+}
+
+// ClientReady tells the signaling connection the media connection is up.
+type ClientReady struct {
+	MsgType      int    `json:"msg_type"`
+	RTMSStreamID string `json:"rtms_stream_id"`
+}
+
+// KeepAlive answers a keep alive request, on the connection it came on.
+type KeepAlive struct {
+	MsgType   int   `json:"msg_type"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// FirstURL returns the first of the comma separated server URLs of a
+// stream.
+func FirstURL(urls string) string {
+	for _, url := range strings.Split(urls, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			return url
+		}
+	}
+	return ""
+}
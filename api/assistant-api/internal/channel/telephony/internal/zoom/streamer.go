@@ -0,0 +1,357 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_zoom_telephony
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_telephony_base "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/base"
+	internal_zoom "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/zoom/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+)
+
+// handshakeTimeout bounds each handshake with the RTMS servers.
+const handshakeTimeout = 10 * time.Second
+
+// Streamer listens to a Zoom RTMS stream. The signaling connection carries
+// the handshake and the state of the stream, the media connection the mixed
+// audio of the call. RTMS only streams out of Zoom, the assistant's audio
+// does not reach the caller; ending the conversation leaves the stream.
+type Streamer struct {
+	internal_telephony_base.BaseTelephonyStreamer
+
+	credential  *internal_zoom.Credential
+	meetingUUID string
+	streamID    string
+	serverURL   string
+	dialer      *websocket.Dialer
+
+	mu        sync.Mutex
+	signaling *websocket.Conn
+	media     *websocket.Conn
+
+	configSent   atomic.Bool
+	closed       atomic.Bool
+	audioDropped atomic.Bool
+}
+
+// NewStreamer connects to the stream stored on the call context. The
+// streamer lives until ctx is done or the conversation ends.
+func NewStreamer(ctx context.Context, logger commons.Logger, cc *callcontext.CallContext, vaultCred *protos.VaultCredential) (internal_type.Streamer, error) {
+	credential, err := internal_zoom.NewCredential(vaultCred)
+	if err != nil {
+		return nil, err
+	}
+	media := func(key string) string {
+		if v, ok := cc.Media[key]; ok {
+			return fmt.Sprintf("%v", v)
+		}
+		return ""
+	}
+	s := &Streamer{
+		BaseTelephonyStreamer: internal_telephony_base.NewBaseTelephonyStreamer(
+			logger, cc, vaultCred,
+			internal_telephony_base.WithSourceAudioConfig(internal_audio.NewLinear16khzMonoAudioConfig()),
+		),
+		credential:  credential,
+		meetingUUID: media(internal_zoom.MediaMeetingUUID),
+		streamID:    media(internal_zoom.MediaStreamID),
+		serverURL:   internal_zoom.FirstURL(media(internal_zoom.MediaServerURLs)),
+		dialer:      websocket.DefaultDialer,
+	}
+	if s.meetingUUID == "" || s.streamID == "" || s.serverURL == "" {
+		return nil, errors.New("call context has no zoom stream")
+	}
+
+	go s.run()
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Close()
+		case <-s.Ctx.Done():
+		}
+	}()
+	return s, nil
+}
+
+// run connects to the stream and feeds its audio to the input buffer until
+// the stream ends.
+func (s *Streamer) run() {
+	defer s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+
+	mediaURL, err := s.connectSignaling()
+	if err != nil {
+		if s.Ctx.Err() == nil {
+			s.Logger.Errorf("Zoom: stream %s: %v", s.streamID, err)
+		}
+		return
+	}
+	if err := s.connectMedia(mediaURL); err != nil {
+		if s.Ctx.Err() == nil {
+			s.Logger.Errorf("Zoom: stream %s: %v", s.streamID, err)
+		}
+		return
+	}
+	s.PushTransportMetadata(zoomProvider, map[string]string{
+		"meeting_uuid":   s.meetingUUID,
+		"rtms_stream_id": s.streamID,
+		"media_server":   mediaURL,
+	})
+
+	go s.readSignaling()
+	s.readMedia()
+}
+
+// connectSignaling opens the signaling connection and returns the URL of
+// the media server the handshake named.
+func (s *Streamer) connectSignaling() (string, error) {
+	conn, err := s.dial(s.serverURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to signaling server: %w", err)
+	}
+	s.mu.Lock()
+	s.signaling = conn
+	s.mu.Unlock()
+
+	response, err := s.handshake(conn, internal_zoom.SignalingHandshake{
+		MsgType:         internal_zoom.MsgSignalingHandshakeReq,
+		ProtocolVersion: 1,
+		MeetingUUID:     s.meetingUUID,
+		RTMSStreamID:    s.streamID,
+		Signature:       s.credential.StreamSignature(s.meetingUUID, s.streamID),
+	}, internal_zoom.MsgSignalingHandshakeResp)
+	if err != nil {
+		return "", fmt.Errorf("signaling handshake failed: %w", err)
+	}
+	if response.MediaServer == nil {
+		return "", errors.New("signaling handshake named no media server")
+	}
+	mediaURL := response.MediaServer.ServerURLs.Audio
+	if mediaURL == "" {
+		mediaURL = response.MediaServer.ServerURLs.All
+	}
+	if mediaURL == "" {
+		return "", errors.New("signaling handshake named no media server")
+	}
+	return mediaURL, nil
+}
+
+// connectMedia opens the media connection for the mixed audio of the call
+// and tells signaling the client is ready.
+func (s *Streamer) connectMedia(mediaURL string) error {
+	conn, err := s.dial(mediaURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to media server: %w", err)
+	}
+	s.mu.Lock()
+	s.media = conn
+	s.mu.Unlock()
+
+	if _, err := s.handshake(conn, internal_zoom.DataHandshake{
+		MsgType:         internal_zoom.MsgDataHandshakeReq,
+		ProtocolVersion: 1,
+		MeetingUUID:     s.meetingUUID,
+		RTMSStreamID:    s.streamID,
+		Signature:       s.credential.StreamSignature(s.meetingUUID, s.streamID),
+		MediaType:       internal_zoom.MediaTypeAudio,
+		MediaParams: internal_zoom.MediaParams{Audio: internal_zoom.AudioParams{
+			ContentType: internal_zoom.AudioContentRaw,
+			SampleRate:  internal_zoom.AudioSampleRate16k,
+			Channel:     internal_zoom.AudioChannelMono,
+			Codec:       internal_zoom.AudioCodecL16,
+			DataOpt:     internal_zoom.AudioDataMixedStream,
+			SendRate:    internal_zoom.AudioSendRateMs,
+		}},
+	}, internal_zoom.MsgDataHandshakeResp); err != nil {
+		return fmt.Errorf("media handshake failed: %w", err)
+	}
+
+	s.mu.Lock()
+	signaling := s.signaling
+	s.mu.Unlock()
+	return signaling.WriteJSON(internal_zoom.ClientReady{
+		MsgType:      internal_zoom.MsgClientReadyAck,
+		RTMSStreamID: s.streamID,
+	})
+}
+
+func (s *Streamer) dial(url string) (*websocket.Conn, error) {
+	ctx, cancel := context.WithTimeout(s.Ctx, handshakeTimeout)
+	defer cancel()
+	conn, _, err := s.dialer.DialContext(ctx, url, nil)
+	return conn, err
+}
+
+// handshake sends request and waits for the response of type want.
+func (s *Streamer) handshake(conn *websocket.Conn, request interface{}, want int) (*internal_zoom.Message, error) {
+	if err := conn.WriteJSON(request); err != nil {
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		var message internal_zoom.Message
+		if err := conn.ReadJSON(&message); err != nil {
+			return nil, err
+		}
+		if message.MsgType != want {
+			continue
+		}
+		if message.StatusCode != internal_zoom.StatusOK {
+			return nil, fmt.Errorf("status %d: %s", message.StatusCode, message.Reason)
+		}
+		return &message, nil
+	}
+}
+
+// readSignaling answers keep alives and ends the conversation when the
+// stream stops.
+func (s *Streamer) readSignaling() {
+	s.mu.Lock()
+	conn := s.signaling
+	s.mu.Unlock()
+	for {
+		var message internal_zoom.Message
+		if err := conn.ReadJSON(&message); err != nil {
+			s.end()
+			return
+		}
+		switch message.MsgType {
+		case internal_zoom.MsgKeepAliveReq:
+			s.keepAlive(conn, message)
+		case internal_zoom.MsgStreamStateUpdate:
+			if message.State == internal_zoom.StreamStateTerminating || message.State == internal_zoom.StreamStateTerminated {
+				s.Logger.Infof("Zoom: stream %s ended: %s", s.streamID, message.Reason)
+				s.end()
+				return
+			}
+		case internal_zoom.MsgSessionStateUpdate:
+			if message.State == internal_zoom.SessionStateStopped {
+				s.Logger.Infof("Zoom: session of stream %s stopped", s.streamID)
+				s.end()
+				return
+			}
+		}
+	}
+}
+
+// readMedia feeds the audio frames to the input buffer until the media
+// connection closes.
+func (s *Streamer) readMedia() {
+	s.mu.Lock()
+	conn := s.media
+	s.mu.Unlock()
+	for {
+		var message internal_zoom.Message
+		if err := conn.ReadJSON(&message); err != nil {
+			if s.Ctx.Err() == nil && !s.closed.Load() && websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				s.Logger.Warnf("Zoom: media of stream %s failed: %v", s.streamID, err)
+			}
+			return
+		}
+		switch message.MsgType {
+		case internal_zoom.MsgKeepAliveReq:
+			s.keepAlive(conn, message)
+		case internal_zoom.MsgMediaDataAudio:
+			if message.Content == nil || message.Content.Data == "" {
+				continue
+			}
+			frame, err := base64.StdEncoding.DecodeString(message.Content.Data)
+			if err != nil {
+				s.Logger.Warnf("Zoom: invalid audio frame on stream %s: %v", s.streamID, err)
+				continue
+			}
+			var audio []byte
+			s.WithInputBuffer(func(buf *bytes.Buffer) {
+				buf.Write(frame)
+				if buf.Len() >= s.InputBufferThreshold() {
+					audio = bytes.Clone(buf.Bytes())
+					buf.Reset()
+				}
+			})
+			if audio != nil {
+				s.PushInput(s.CreateVoiceRequest(audio))
+			}
+		}
+	}
+}
+
+// keepAlive answers a keep alive request. Each connection is written by the
+// goroutine reading it only, once the handshakes are done.
+func (s *Streamer) keepAlive(conn *websocket.Conn, request internal_zoom.Message) {
+	if err := conn.WriteJSON(internal_zoom.KeepAlive{
+		MsgType:   internal_zoom.MsgKeepAliveResp,
+		Timestamp: request.Timestamp,
+	}); err != nil && !s.closed.Load() {
+		s.Logger.Warnf("Zoom: keep alive of stream %s failed: %v", s.streamID, err)
+	}
+}
+
+// Recv opens the conversation, then returns the audio and events run
+// pushed.
+func (s *Streamer) Recv() (internal_type.Stream, error) {
+	if s.configSent.CompareAndSwap(false, true) {
+		return s.CreateConnectionRequest(), nil
+	}
+	return s.BaseTelephonyStreamer.Recv()
+}
+
+func (s *Streamer) Send(response internal_type.Stream) error {
+	switch data := response.(type) {
+	case *protos.ConversationAssistantMessage:
+		if _, ok := data.Message.(*protos.ConversationAssistantMessage_Audio); ok && s.audioDropped.CompareAndSwap(false, true) {
+			s.Logger.Infof("Zoom: RTMS is receive only, assistant audio of stream %s is not played", s.streamID)
+		}
+	case *protos.ConversationDirective:
+		if data.GetType() == protos.ConversationDirective_END_CONVERSATION {
+			return s.Close()
+		}
+	}
+	return nil
+}
+
+// end ends the conversation once the stream stopped. The talker closes the
+// streamer when it handled the disconnection.
+func (s *Streamer) end() {
+	s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+	s.closeConnections()
+}
+
+// Close leaves the stream, Zoom keeps the call itself going.
+func (s *Streamer) Close() error {
+	if !s.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	s.Cancel()
+	s.closeConnections()
+	s.ResetInputBuffer()
+	return nil
+}
+
+func (s *Streamer) closeConnections() {
+	s.mu.Lock()
+	signaling, media := s.signaling, s.media
+	s.mu.Unlock()
+	if media != nil {
+		media.Close()
+	}
+	if signaling != nil {
+		signaling.Close()
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_zoom_telephony
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_zoom "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/zoom/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rtmsServer is a signaling and media server streaming one audio frame.
+type rtmsServer struct {
+	*httptest.Server
+	signature string
+	ready     chan internal_zoom.Message
+	keepAlive chan internal_zoom.Message
+	terminate chan struct{}
+}
+
+func newRTMSServer(t *testing.T) *rtmsServer {
+	s := &rtmsServer{
+		signature: (&internal_zoom.Credential{ClientID: "client", ClientSecret: "client-secret"}).StreamSignature("m1==", "s1"),
+		ready:     make(chan internal_zoom.Message, 1),
+		keepAlive: make(chan internal_zoom.Message, 1),
+		terminate: make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/signaling", s.signaling)
+	mux.HandleFunc("/media", s.media)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *rtmsServer) url(path string) string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + path
+}
+
+func (s *rtmsServer) accept(w http.ResponseWriter, r *http.Request, want int) (*websocket.Conn, bool) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return nil, false
+	}
+	var handshake map[string]interface{}
+	if err := conn.ReadJSON(&handshake); err != nil {
+		conn.Close()
+		return nil, false
+	}
+	status := 0
+	if handshake["signature"] != s.signature || int(handshake["msg_type"].(float64)) != want {
+		status = 1
+	}
+	response := map[string]interface{}{"msg_type": want + 1, "status_code": status}
+	if want == internal_zoom.MsgSignalingHandshakeReq {
+		response["media_server"] = map[string]interface{}{"server_urls": map[string]string{"audio": s.url("/media")}}
+	}
+	conn.WriteJSON(response)
+	return conn, status == 0
+}
+
+func (s *rtmsServer) signaling(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.accept(w, r, internal_zoom.MsgSignalingHandshakeReq)
+	if !ok {
+		return
+	}
+	defer conn.Close()
+	var ready internal_zoom.Message
+	if err := conn.ReadJSON(&ready); err != nil {
+		return
+	}
+	s.ready <- ready
+	<-s.terminate
+	conn.WriteJSON(map[string]interface{}{"msg_type": internal_zoom.MsgStreamStateUpdate, "state": internal_zoom.StreamStateTerminated, "reason": "stop"})
+	conn.ReadMessage()
+}
+
+func (s *rtmsServer) media(w http.ResponseWriter, r *http.Request) {
+	conn, ok := s.accept(w, r, internal_zoom.MsgDataHandshakeReq)
+	if !ok {
+		return
+	}
+	defer conn.Close()
+	conn.WriteJSON(map[string]interface{}{"msg_type": internal_zoom.MsgKeepAliveReq, "timestamp": 1738})
+	var keepAlive internal_zoom.Message
+	if err := conn.ReadJSON(&keepAlive); err != nil {
+		return
+	}
+	s.keepAlive <- keepAlive
+	frame := base64.StdEncoding.EncodeToString(make([]byte, 32000))
+	conn.WriteJSON(map[string]interface{}{"msg_type": internal_zoom.MsgMediaDataAudio, "content": map[string]interface{}{"user_id": 0, "data": frame}})
+	conn.ReadMessage()
+}
+
+func newTestStreamer(t *testing.T, serverURL string) internal_type.Streamer {
+	logger, _ := commons.NewApplicationLogger()
+	streamer, err := NewStreamer(t.Context(), logger, &callcontext.CallContext{
+		ChannelUUID: "s1",
+		Media: map[string]interface{}{
+			internal_zoom.MediaMeetingUUID: "m1==",
+			internal_zoom.MediaStreamID:    "s1",
+			internal_zoom.MediaServerURLs:  serverURL,
+		},
+	}, testVaultCredential(t))
+	require.NoError(t, err)
+	return streamer
+}
+
+// recvUntil reads the streamer until match accepts a message.
+func recvUntil(t *testing.T, streamer internal_type.Streamer, match func(internal_type.Stream) bool) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for {
+		received := make(chan internal_type.Stream, 1)
+		go func() {
+			msg, err := streamer.Recv()
+			if err == io.EOF {
+				msg = nil
+			}
+			received <- msg
+		}()
+		select {
+		case msg := <-received:
+			if msg != nil && match(msg) {
+				return
+			}
+			require.NotNil(t, msg, "stream ended early")
+		case <-deadline:
+			t.Fatal("timed out waiting for message")
+		}
+	}
+}
+
+func TestStreamer_ListensToStream(t *testing.T) {
+	server := newRTMSServer(t)
+	streamer := newTestStreamer(t, server.url("/signaling"))
+
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	assert.IsType(t, &protos.ConversationInitialization{}, msg)
+
+	select {
+	case ready := <-server.ready:
+		assert.Equal(t, internal_zoom.MsgClientReadyAck, ready.MsgType)
+		assert.Equal(t, "s1", ready.RTMSStreamID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("client ready not sent")
+	}
+	select {
+	case keepAlive := <-server.keepAlive:
+		assert.Equal(t, internal_zoom.MsgKeepAliveResp, keepAlive.MsgType)
+		assert.Equal(t, int64(1738), keepAlive.Timestamp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("keep alive not answered")
+	}
+
+	recvUntil(t, streamer, func(msg internal_type.Stream) bool {
+		user, ok := msg.(*protos.ConversationUserMessage)
+		return ok && len(user.GetAudio()) > 0
+	})
+
+	// assistant audio is dropped, the stream is receive only
+	assert.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Message: &protos.ConversationAssistantMessage_Audio{Audio: []byte{1, 2}},
+	}))
+
+	close(server.terminate)
+	recvUntil(t, streamer, func(msg internal_type.Stream) bool {
+		_, ok := msg.(*protos.ConversationDisconnection)
+		return ok
+	})
+}
+
+func TestStreamer_RejectedHandshakeEndsConversation(t *testing.T) {
+	server := newRTMSServer(t)
+	server.signature = "other"
+	streamer := newTestStreamer(t, server.url("/signaling"))
+
+	_, err := streamer.Recv()
+	require.NoError(t, err)
+	recvUntil(t, streamer, func(msg internal_type.Stream) bool {
+		_, ok := msg.(*protos.ConversationDisconnection)
+		return ok
+	})
+}
+
+func TestNewStreamer_RequiresCredentialAndStream(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	_, err := NewStreamer(t.Context(), logger, &callcontext.CallContext{}, &protos.VaultCredential{})
+	assert.ErrorContains(t, err, "client_id")
+
+	_, err = NewStreamer(t.Context(), logger, &callcontext.CallContext{}, testVaultCredential(t))
+	assert.ErrorContains(t, err, "zoom stream")
+}
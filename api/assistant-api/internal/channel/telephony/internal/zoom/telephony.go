@@ -0,0 +1,186 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_zoom_telephony
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_zoom "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/zoom/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const zoomProvider = "zoom"
+
+// zoomTelephony implements the Telephony interface for Zoom Realtime Media
+// Streams (RTMS). Zoom notifies the event subscription of the app when a
+// stream of a meeting, Zoom Phone call or Contact Center engagement starts;
+// the assistant then connects to the stream and listens to it.
+type zoomTelephony struct {
+	appCfg *config.AssistantConfig
+	logger commons.Logger
+	now    func() time.Time
+}
+
+// NewZoomTelephony creates a new Zoom telephony provider
+func NewZoomTelephony(config *config.AssistantConfig, logger commons.Logger) (internal_type.Telephony, error) {
+	return &zoomTelephony{
+		appCfg: config,
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+// StatusCallback records the Zoom events sent to the context event path.
+func (zt *zoomTelephony) StatusCallback(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+) (*internal_type.StatusInfo, error) {
+	var eventDetails map[string]interface{}
+	if err := c.ShouldBindJSON(&eventDetails); err != nil {
+		zt.logger.Errorf("failed to parse Zoom event body: %+v", err)
+		return nil, fmt.Errorf("failed to parse Zoom event body: %w", err)
+	}
+	eventType := "unknown"
+	if v, ok := eventDetails["event"]; ok {
+		eventType = fmt.Sprintf("%v", v)
+	}
+	return &internal_type.StatusInfo{Event: eventType, Payload: eventDetails}, nil
+}
+
+// CatchAllStatusCallback handles catch-all status callbacks
+func (zt *zoomTelephony) CatchAllStatusCallback(ctx *gin.Context) (*internal_type.StatusInfo, error) {
+	return nil, nil
+}
+
+// ReceiveCall handles the event subscription of the Zoom app:
+//
+//	POST https://host/v1/talk/zoom/call/<assistantId>
+//
+// Every event is checked against the secret token of the deployment's
+// credential, the dispatcher puts it on the request as vaultCredential.
+// The url validation challenge is answered and other events than the start
+// of a stream are acknowledged, neither is a call.
+func (zt *zoomTelephony) ReceiveCall(c *gin.Context) (*internal_type.CallInfo, error) {
+	cred, err := zt.credential(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Zoom credential is not configured"})
+		return nil, err
+	}
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Zoom event"})
+		return nil, fmt.Errorf("failed to read Zoom event: %w", err)
+	}
+	if err := cred.VerifyWebhook(c.GetHeader(internal_zoom.HeaderSignature), c.GetHeader(internal_zoom.HeaderTimestamp), body, zt.now()); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Zoom signature"})
+		return nil, fmt.Errorf("zoom webhook rejected: %w", err)
+	}
+
+	var event internal_zoom.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Zoom event"})
+		return nil, fmt.Errorf("failed to parse Zoom event: %w", err)
+	}
+
+	switch {
+	case event.Event == internal_zoom.EventURLValidation:
+		c.JSON(http.StatusOK, internal_zoom.URLValidationResponse{
+			PlainToken:     event.Payload.PlainToken,
+			EncryptedToken: cred.EncryptToken(event.Payload.PlainToken),
+		})
+		return nil, internal_type.ErrNotACall
+	case !event.StartsStream():
+		// streams end on their signaling connection, stop events need no work
+		c.Status(http.StatusOK)
+		return nil, internal_type.ErrNotACall
+	}
+
+	payload := event.Payload
+	if payload.MeetingUUID == "" || payload.RTMSStreamID == "" || internal_zoom.FirstURL(payload.ServerURLs) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing stream of " + event.Event})
+		return nil, fmt.Errorf("zoom event %s has no stream to connect to", event.Event)
+	}
+
+	return &internal_type.CallInfo{
+		ChannelUUID:  payload.RTMSStreamID,
+		CallerNumber: payload.MeetingUUID,
+		Provider:     zoomProvider,
+		Status:       "SUCCESS",
+		StatusInfo: internal_type.StatusInfo{Event: event.Event, Payload: map[string]string{
+			"meeting_uuid":   payload.MeetingUUID,
+			"rtms_stream_id": payload.RTMSStreamID,
+			"operator_id":    payload.OperatorID,
+		}},
+		Extra: map[string]string{
+			"zoom.event":          event.Event,
+			"zoom.meeting_uuid":   payload.MeetingUUID,
+			"zoom.rtms_stream_id": payload.RTMSStreamID,
+			"zoom.operator_id":    payload.OperatorID,
+		},
+		Media: map[string]string{
+			internal_zoom.MediaMeetingUUID: payload.MeetingUUID,
+			internal_zoom.MediaStreamID:    payload.RTMSStreamID,
+			internal_zoom.MediaServerURLs:  payload.ServerURLs,
+		},
+	}, nil
+}
+
+func (zt *zoomTelephony) credential(c *gin.Context) (*internal_zoom.Credential, error) {
+	value, ok := c.Get("vaultCredential")
+	vaultCredential, _ := value.(*protos.VaultCredential)
+	if !ok || vaultCredential == nil {
+		return nil, errors.New("missing vaultCredential — the dispatcher resolves it before ReceiveCall")
+	}
+	return internal_zoom.NewCredential(vaultCredential)
+}
+
+// InboundCall acknowledges the event, Zoom only needs a 200 within three
+// seconds. The assistant connects to the stream on its own.
+func (zt *zoomTelephony) InboundCall(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	clientNumber string,
+	assistantConversationId uint64,
+) error {
+	contextID, exists := c.Get("contextId")
+	if !exists || contextID == "" {
+		return fmt.Errorf("missing contextId — CallReciever must save call context before InboundCall")
+	}
+	c.JSON(http.StatusOK, map[string]string{
+		"rapida_context_id":      fmt.Sprintf("%v", contextID),
+		"rapida_conversation_id": fmt.Sprintf("%d", assistantConversationId),
+	})
+	return nil
+}
+
+// OutboundCall is not supported, streams are started by Zoom. Zoom Phone
+// calls the assistant should place or answer with its voice go over a SIP
+// trunk to the SIP channel.
+func (zt *zoomTelephony) OutboundCall(
+	auth types.SimplePrinciple,
+	toPhone string,
+	fromPhone string,
+	assistantId, assistantConversationId uint64,
+	vaultCredential *protos.VaultCredential,
+	opts utils.Option,
+) (*internal_type.CallInfo, error) {
+	err := errors.New("zoom streams are started by zoom, outbound calls are not supported")
+	return &internal_type.CallInfo{Provider: zoomProvider, Status: "FAILED", ErrorMessage: err.Error()}, err
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_zoom_telephony
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_zoom "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/zoom/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const rtmsStartedEvent = `{
+  "event": "meeting.rtms_started",
+  "event_ts": 1732313171881,
+  "payload": {
+    "meeting_uuid": "4444AAAiAAAAAiAiAiiAii==",
+    "operator_id": "30R7kT7bTIKSNUFEuH_Qlg",
+    "rtms_stream_id": "609340fb1bd4445f9e5f5bd3b0c10c4b",
+    "server_urls": "wss://127.0.0.1:443"
+  }
+}`
+
+var testNow = time.Unix(1732313172, 0)
+
+func testVaultCredential(t *testing.T) *protos.VaultCredential {
+	value, err := structpb.NewStruct(map[string]interface{}{"client_id": "client", "client_secret": "client-secret", "secret_token": "token"})
+	require.NoError(t, err)
+	return &protos.VaultCredential{Value: value}
+}
+
+func newTestTelephony(t *testing.T) *zoomTelephony {
+	logger, _ := commons.NewApplicationLogger()
+	tel, err := NewZoomTelephony(&config.AssistantConfig{PublicAssistantHost: "api.rapida.ai"}, logger)
+	require.NoError(t, err)
+	zt := tel.(*zoomTelephony)
+	zt.now = func() time.Time { return testNow }
+	return zt
+}
+
+// newSignedContext returns a request carrying body signed like Zoom signs
+// its webhooks, with the credential the dispatcher resolves.
+func newSignedContext(t *testing.T, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/talk/zoom/call/1", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	timestamp := strconv.FormatInt(testNow.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte("token"))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	c.Request.Header.Set(internal_zoom.HeaderTimestamp, timestamp)
+	c.Request.Header.Set(internal_zoom.HeaderSignature, "v0="+hex.EncodeToString(mac.Sum(nil)))
+	c.Set("vaultCredential", testVaultCredential(t))
+	return c, recorder
+}
+
+func TestReceiveCall(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, _ := newSignedContext(t, rtmsStartedEvent)
+
+	info, err := tel.ReceiveCall(c)
+	require.NoError(t, err)
+	assert.Equal(t, "609340fb1bd4445f9e5f5bd3b0c10c4b", info.ChannelUUID)
+	assert.Equal(t, "4444AAAiAAAAAiAiAiiAii==", info.CallerNumber)
+	assert.Equal(t, zoomProvider, info.Provider)
+	assert.Equal(t, "meeting.rtms_started", info.StatusInfo.Event)
+	assert.Equal(t, "30R7kT7bTIKSNUFEuH_Qlg", info.Extra["zoom.operator_id"])
+	assert.Equal(t, map[string]string{
+		internal_zoom.MediaMeetingUUID: "4444AAAiAAAAAiAiAiiAii==",
+		internal_zoom.MediaStreamID:    "609340fb1bd4445f9e5f5bd3b0c10c4b",
+		internal_zoom.MediaServerURLs:  "wss://127.0.0.1:443",
+	}, info.Media)
+}
+
+func TestReceiveCall_URLValidation(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, recorder := newSignedContext(t, `{"event":"endpoint.url_validation","payload":{"plainToken":"qgg8vlvZRS6UYooatFL8Aw"}}`)
+
+	_, err := tel.ReceiveCall(c)
+	assert.ErrorIs(t, err, internal_type.ErrNotACall)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response internal_zoom.URLValidationResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	mac := hmac.New(sha256.New, []byte("token"))
+	mac.Write([]byte("qgg8vlvZRS6UYooatFL8Aw"))
+	assert.Equal(t, internal_zoom.URLValidationResponse{
+		PlainToken:     "qgg8vlvZRS6UYooatFL8Aw",
+		EncryptedToken: hex.EncodeToString(mac.Sum(nil)),
+	}, response)
+}
+
+func TestReceiveCall_OtherEventsAreNotCalls(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, recorder := newSignedContext(t, `{"event":"meeting.rtms_stopped","payload":{"rtms_stream_id":"s1"}}`)
+	_, err := tel.ReceiveCall(c)
+	assert.ErrorIs(t, err, internal_type.ErrNotACall)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestReceiveCall_Rejected(t *testing.T) {
+	tel := newTestTelephony(t)
+
+	c, recorder := newSignedContext(t, rtmsStartedEvent)
+	c.Request.Header.Set(internal_zoom.HeaderSignature, "v0=forged")
+	_, err := tel.ReceiveCall(c)
+	assert.ErrorContains(t, err, "signature")
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	c, recorder = newSignedContext(t, rtmsStartedEvent)
+	c.Set("vaultCredential", nil)
+	_, err = tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	c, recorder = newSignedContext(t, `{"event":"meeting.rtms_started","payload":{"meeting_uuid":"m1"}}`)
+	_, err = tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, internal_type.ErrNotACall)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestInboundCall(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, recorder := newSignedContext(t, "")
+	assert.Error(t, tel.InboundCall(c, nil, 1, "m1", 42))
+
+	c.Set("contextId", "ctx-1")
+	require.NoError(t, tel.InboundCall(c, nil, 1, "m1", 42))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "ctx-1")
+}
+
+func TestOutboundCall_NotSupported(t *testing.T) {
+	info, err := newTestTelephony(t).OutboundCall(nil, "+1555", "+1666", 1, 2, nil, utils.Option{})
+	assert.Error(t, err)
+	assert.Equal(t, "FAILED", info.Status)
+}
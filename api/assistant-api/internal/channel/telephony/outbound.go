@@ -13,10 +13,13 @@ import (
 	"github.com/rapidaai/api/assistant-api/config"
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_telemetry_batch "github.com/rapidaai/api/assistant-api/internal/telemetry/batch"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	web_client "github.com/rapidaai/pkg/clients/web"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/types"
 	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/pkg/utils"
 )
 
 // OutboundDispatcher handles outbound call dispatching across all telephony
@@ -82,35 +85,36 @@ func (d *OutboundDispatcher) performOutbound(ctx context.Context, cc *callcontex
 	// Load assistant with phone deployment
 	assistant, err := d.assistantService.Get(ctx, auth, cc.AssistantID, nil, &internal_services.GetAssistantOption{InjectPhoneDeployment: true})
 	if err != nil {
-		d.conversationService.ApplyConversationMetrics(ctx, auth, cc.AssistantID, cc.ConversationID,
-			[]*types.Metric{types.NewStatusMetric(type_enums.RECORD_FAILED)})
+		internal_telemetry_batch.ConversationMetrics(ctx, d.conversationService, auth, cc.AssistantID, cc.ConversationID,
+			types.NewStatusMetric(type_enums.RECORD_FAILED))
 		return fmt.Errorf("failed to load assistant %d: %w", cc.AssistantID, err)
 	}
 
 	if !assistant.IsPhoneDeploymentEnable() {
-		d.conversationService.ApplyConversationMetrics(ctx, auth, cc.AssistantID, cc.ConversationID,
-			[]*types.Metric{types.NewStatusMetric(type_enums.RECORD_FAILED)})
+		internal_telemetry_batch.ConversationMetrics(ctx, d.conversationService, auth, cc.AssistantID, cc.ConversationID,
+			types.NewStatusMetric(type_enums.RECORD_FAILED))
 		return fmt.Errorf("phone deployment not enabled for assistant %d", cc.AssistantID)
 	}
 
 	// Get vault credential
 	credentialID, err := assistant.AssistantPhoneDeployment.GetOptions().GetUint64("rapida.credential_id")
 	if err != nil {
-		d.conversationService.ApplyConversationMetrics(ctx, auth, cc.AssistantID, cc.ConversationID,
-			[]*types.Metric{types.NewStatusMetric(type_enums.RECORD_FAILED)})
+		internal_telemetry_batch.ConversationMetrics(ctx, d.conversationService, auth, cc.AssistantID, cc.ConversationID,
+			types.NewStatusMetric(type_enums.RECORD_FAILED))
 		return fmt.Errorf("failed to get credential ID: %w", err)
 	}
 
 	vltC, err := d.vaultClient.GetCredential(ctx, auth, credentialID)
 	if err != nil {
-		d.conversationService.ApplyConversationMetrics(ctx, auth, cc.AssistantID, cc.ConversationID,
-			[]*types.Metric{types.NewStatusMetric(type_enums.RECORD_FAILED)})
+		internal_telemetry_batch.ConversationMetrics(ctx, d.conversationService, auth, cc.AssistantID, cc.ConversationID,
+			types.NewStatusMetric(type_enums.RECORD_FAILED))
 		return fmt.Errorf("failed to get vault credential: %w", err)
 	}
 
 	// Build options with contextId for channel variables (ARI, SIP headers, etc.)
 	opts := assistant.AssistantPhoneDeployment.GetOptions()
 	opts["rapida.context_id"] = cc.ContextID
+	d.applyCallerIdentity(ctx, auth, cc, opts)
 
 	// Place the outbound call via the telephony provider
 	callInfo, callErr := telephony.OutboundCall(auth, cc.CallerNumber, cc.FromNumber, cc.AssistantID, cc.ConversationID, vltC, opts)
@@ -151,13 +155,30 @@ func (d *OutboundDispatcher) performOutbound(ctx context.Context, cc *callcontex
 
 	// Apply metric from CallInfo.Status
 	metric := types.NewMetric("STATUS", callInfo.Status, nil)
-	d.conversationService.ApplyConversationMetrics(ctx, auth, cc.AssistantID, cc.ConversationID, []*types.Metric{metric})
+	internal_telemetry_batch.ConversationMetrics(ctx, d.conversationService, auth, cc.AssistantID, cc.ConversationID, metric)
 
 	// Apply telephony event from CallInfo.StatusInfo
 	if callInfo.StatusInfo.Event != "" {
 		event := types.NewEvent(callInfo.StatusInfo.Event, callInfo.StatusInfo.Payload)
-		d.conversationService.ApplyConversationTelephonyEvent(ctx, auth, cc.Provider, cc.AssistantID, cc.ConversationID, []*types.Event{event})
+		internal_telemetry_batch.TelephonyEvents(ctx, d.conversationService, auth, cc.Provider, cc.AssistantID, cc.ConversationID, event)
 	}
 
 	return callErr
 }
+
+// applyCallerIdentity puts the caller identity and call type set on the
+// outbound call request over the phone deployment's in opts. The request's options are
+// stored on the conversation; failing to read them keeps the deployment's.
+func (d *OutboundDispatcher) applyCallerIdentity(ctx context.Context, auth types.SimplePrinciple, cc *callcontext.CallContext, opts utils.Option) {
+	conversation, err := d.conversationService.GetConversation(ctx, auth, cc.AssistantID, cc.ConversationID, &internal_services.GetConversationOption{InjectOption: true})
+	if err != nil {
+		d.logger.Warnf("outbound dispatcher[%s]: failed to load call options for contextId=%s: %v", cc.Provider, cc.ContextID, err)
+		return
+	}
+	callOpts := conversation.GetOptions()
+	for _, key := range internal_type.CallRequestOptions {
+		if value, err := callOpts.GetString(key); err == nil && value != "" {
+			opts[key] = value
+		}
+	}
+}
@@ -16,18 +16,23 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/rapidaai/api/assistant-api/config"
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_amazonconnect_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/amazonconnect"
 	internal_asterisk_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/asterisk"
 	internal_asterisk_audiosocket "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/asterisk/audiosocket"
 	internal_asterisk_websocket "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/asterisk/websocket"
 	internal_exotel_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/exotel"
+	internal_freeswitch_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/freeswitch"
 	internal_sip_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/sip"
 	internal_twilio_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/twilio"
 	internal_vonage_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/vonage"
+	internal_zoom_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony/internal/zoom"
+	channel_whatsapp "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/whatsapp"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
 	web_client "github.com/rapidaai/pkg/clients/web"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 )
 
@@ -35,17 +40,45 @@ import (
 type Telephony string
 
 const (
-	Twilio   Telephony = "twilio"
-	Exotel   Telephony = "exotel"
-	Vonage   Telephony = "vonage"
-	Asterisk Telephony = "asterisk"
-	SIP      Telephony = "sip"
+	Twilio        Telephony = "twilio"
+	Exotel        Telephony = "exotel"
+	Vonage        Telephony = "vonage"
+	Asterisk      Telephony = "asterisk"
+	FreeSWITCH    Telephony = "freeswitch"
+	SIP           Telephony = "sip"
+	AmazonConnect Telephony = "amazon-connect"
+	Zoom          Telephony = "zoom"
+	WhatsApp      Telephony = "whatsapp"
 )
 
 func (at Telephony) String() string {
 	return string(at)
 }
 
+// PullsMedia reports whether the platform reads the call audio from the
+// provider itself. No media connection claims the call context of these
+// calls, the talker is started as soon as the call was received.
+func (at Telephony) PullsMedia() bool {
+	return at == AmazonConnect || at == Zoom || at == WhatsApp
+}
+
+// SignsWebhooks reports whether the provider signs its inbound webhooks with
+// a secret of the deployment's credential. The dispatcher resolves the
+// credential before ReceiveCall for these.
+func (at Telephony) SignsWebhooks() bool {
+	return at == Zoom || at == WhatsApp
+}
+
+// StreamMode returns the stream mode of calls placed with the given
+// deployment options. Calls are audio unless the provider recognises and
+// synthesises speech itself, as Twilio does with ConversationRelay.
+func (at Telephony) StreamMode(opts utils.Option) string {
+	if at == Twilio && internal_twilio_telephony.IsConversationRelay(opts) {
+		return callcontext.StreamModeText
+	}
+	return callcontext.StreamModeAudio
+}
+
 // --------------------------------------------------------------------------
 // Factory — GetTelephony returns the right provider implementation
 // --------------------------------------------------------------------------
@@ -70,6 +103,14 @@ func GetTelephony(at Telephony, cfg *config.AssistantConfig, logger commons.Logg
 		return internal_vonage_telephony.NewVonageTelephony(cfg, logger)
 	case Asterisk:
 		return internal_asterisk_telephony.NewAsteriskTelephony(cfg, logger)
+	case FreeSWITCH:
+		return internal_freeswitch_telephony.NewFreeSWITCHTelephony(cfg, logger)
+	case AmazonConnect:
+		return internal_amazonconnect_telephony.NewAmazonConnectTelephony(cfg, logger)
+	case Zoom:
+		return internal_zoom_telephony.NewZoomTelephony(cfg, logger)
+	case WhatsApp:
+		return channel_whatsapp.NewWhatsAppTelephony(cfg, logger)
 	case SIP:
 		if opt.SIPServer == nil {
 			return nil, errors.New("SIP server not available — SIP telephony requires a running SIP server")
@@ -108,9 +149,11 @@ type TelephonyDispatcherDeps struct {
 // StreamerOption carries the transport-specific parameters needed to construct a
 // streamer. Callers populate only the fields relevant to their transport:
 //
-//   - WebSocket providers (Twilio, Exotel, Vonage, Asterisk WS): set WebSocketConn
-//   - AudioSocket (Asterisk): set AudioSocketConn, AudioSocketReader, AudioSocketWriter, InitialUUID
-//   - SIP: set Ctx, SIPSession, SIPConfig
+//   - WebSocket providers (Twilio, Exotel, Vonage, Asterisk WS, FreeSWITCH): set WebSocketConn
+//   - AudioSocket (Asterisk): set AudioSocketConn, AudioSocketReader, AudioSocketWriter
+//   - SIP: set Ctx, SIPSession, SIPConfig and SIPServer for warm transfers
+//   - Amazon Connect, Zoom: set Ctx, the stream is read until it is done
+//   - WhatsApp: set Ctx, the call is answered over WebRTC until it ends
 type StreamerOption struct {
 	// WebSocket transport
 	WebSocketConn *websocket.Conn
@@ -124,6 +167,7 @@ type StreamerOption struct {
 	Ctx        context.Context
 	SIPSession *sip_infra.Session
 	SIPConfig  *sip_infra.Config
+	SIPServer  *sip_infra.Server
 }
 
 // NewStreamer is the unified streamer factory. It creates a transport-specific
@@ -137,6 +181,9 @@ func (at Telephony) NewStreamer(
 ) (internal_type.Streamer, error) {
 	switch at {
 	case Twilio:
+		if cc.IsTextStream() {
+			return internal_twilio_telephony.NewConversationRelayStreamer(logger, opt.WebSocketConn, cc, vaultCred), nil
+		}
 		return internal_twilio_telephony.NewTwilioWebsocketStreamer(logger, opt.WebSocketConn, cc, vaultCred), nil
 	case Exotel:
 		return internal_exotel_telephony.NewExotelWebsocketStreamer(logger, opt.WebSocketConn, cc, vaultCred), nil
@@ -147,9 +194,23 @@ func (at Telephony) NewStreamer(
 			return internal_asterisk_audiosocket.NewStreamer(logger, opt.AudioSocketConn, opt.AudioSocketReader, opt.AudioSocketWriter, cc, vaultCred)
 		}
 		return internal_asterisk_websocket.NewAsteriskWebsocketStreamer(logger, opt.WebSocketConn, cc, vaultCred), nil
+	case FreeSWITCH:
+		return internal_freeswitch_telephony.NewFreeSWITCHWebsocketStreamer(logger, opt.WebSocketConn, cc, vaultCred), nil
+	case AmazonConnect:
+		return internal_amazonconnect_telephony.NewStreamer(opt.Ctx, logger, cc, vaultCred)
+	case Zoom:
+		return internal_zoom_telephony.NewStreamer(opt.Ctx, logger, cc, vaultCred)
+	case WhatsApp:
+		return channel_whatsapp.NewStreamer(opt.Ctx, logger, cc, vaultCred)
 	case SIP:
-		return internal_sip_telephony.NewStreamer(opt.Ctx, opt.SIPConfig, logger, opt.SIPSession, cc, vaultCred)
+		return internal_sip_telephony.NewStreamer(opt.Ctx, opt.SIPConfig, logger, opt.SIPSession, opt.SIPServer, cc, vaultCred)
 	default:
 		return nil, fmt.Errorf("streamer not supported for provider %q", at)
 	}
 }
+
+// ReadAudioSocketContextID reads the UUID handshake of an Asterisk AudioSocket
+// connection, which carries the contextId the dialplan got from InboundCall.
+func ReadAudioSocketContextID(reader *bufio.Reader) (string, error) {
+	return internal_asterisk_audiosocket.ReadUUID(reader)
+}
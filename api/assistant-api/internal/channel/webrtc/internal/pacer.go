@@ -0,0 +1,115 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package webrtc_internal
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Pacing is how the output writer of a web call paces the assistant's audio.
+// The zero value paces one frame per tick without bound, the writer's
+// behaviour before pacing was configurable.
+type Pacing struct {
+	// MaxQueueFrames bounds the frames queued ahead of playback, the oldest
+	// are dropped to make room so the caller never hears the assistant later
+	// than that. Zero queues without bound.
+	MaxQueueFrames int
+	// CatchUpFactor is how many frames a tick sends at most while the writer
+	// is behind its playback clock, after ticks were missed. One or less
+	// paces strictly.
+	CatchUpFactor int
+	// InterruptWatermarkFrames is how many queued frames still play on an
+	// interruption, the rest is dropped. Zero silences at once.
+	InterruptWatermarkFrames int
+}
+
+var activePacing atomic.Pointer[Pacing]
+
+// InstallPacing sets the pacing of new web calls.
+func InstallPacing(p Pacing) {
+	activePacing.Store(&p)
+}
+
+// ActivePacing returns the installed pacing, the zero value when there is
+// none.
+func ActivePacing() Pacing {
+	if p := activePacing.Load(); p != nil {
+		return *p
+	}
+	return Pacing{}
+}
+
+// Pacer queues the assistant's 20 ms frames and hands them out at playback
+// rate. It keeps a playback clock from the first frame sent, a tick late
+// against it sends up to CatchUpFactor frames. The clock restarts whenever
+// the queue runs dry, a pause in the assistant's audio is not caught up.
+// A Pacer is owned by the output writer goroutine.
+type Pacer struct {
+	pacing Pacing
+	queue  [][]byte
+	due    time.Time // when the next frame is due, zero while idle
+}
+
+func NewPacer(p Pacing) *Pacer {
+	return &Pacer{pacing: p}
+}
+
+// Len returns the frames queued.
+func (p *Pacer) Len() int {
+	return len(p.queue)
+}
+
+// Push queues frame and returns how many of the oldest frames were dropped
+// to keep within MaxQueueFrames.
+func (p *Pacer) Push(frame []byte) int {
+	p.queue = append(p.queue, frame)
+	if p.pacing.MaxQueueFrames <= 0 || len(p.queue) <= p.pacing.MaxQueueFrames {
+		return 0
+	}
+	dropped := len(p.queue) - p.pacing.MaxQueueFrames
+	p.queue = p.queue[dropped:]
+	return dropped
+}
+
+// Interrupt drops the queued frames beyond InterruptWatermarkFrames and
+// returns how many it dropped.
+func (p *Pacer) Interrupt() int {
+	keep := min(max(p.pacing.InterruptWatermarkFrames, 0), len(p.queue))
+	dropped := len(p.queue) - keep
+	p.queue = p.queue[:keep]
+	if keep == 0 {
+		p.due = time.Time{}
+	}
+	return dropped
+}
+
+// Next returns the frames due at now, at least one while any is queued. The
+// slice is only valid until the next call to the Pacer.
+func (p *Pacer) Next(now time.Time) [][]byte {
+	if len(p.queue) == 0 {
+		p.due = time.Time{}
+		return nil
+	}
+	if p.due.IsZero() {
+		p.due = now
+	}
+	n := 1
+	if factor := p.pacing.CatchUpFactor; factor > 1 {
+		if behind := now.Sub(p.due); behind >= frameDuration {
+			n = min(1+int(behind/frameDuration), factor)
+		}
+	}
+	n = min(n, len(p.queue))
+	frames := p.queue[:n]
+	p.queue = p.queue[n:]
+	p.due = p.due.Add(time.Duration(n) * frameDuration)
+	return frames
+}
+
+// frameDuration is the playback time of one frame.
+const frameDuration = OpusFrameDuration * time.Millisecond
@@ -0,0 +1,97 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package webrtc_internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func frames(n int) [][]byte {
+	out := make([][]byte, n)
+	for i := range out {
+		out[i] = []byte{byte(i)}
+	}
+	return out
+}
+
+func TestPacer_StrictByDefault(t *testing.T) {
+	pacer := NewPacer(Pacing{})
+	for _, f := range frames(5) {
+		assert.Zero(t, pacer.Push(f))
+	}
+	start := time.Now()
+
+	assert.Equal(t, [][]byte{{0}}, pacer.Next(start))
+	// a tick a second late still sends one frame
+	assert.Equal(t, [][]byte{{1}}, pacer.Next(start.Add(time.Second)))
+	assert.Equal(t, 3, pacer.Len())
+}
+
+func TestPacer_CatchUp(t *testing.T) {
+	pacer := NewPacer(Pacing{CatchUpFactor: 3})
+	for _, f := range frames(10) {
+		pacer.Push(f)
+	}
+	start := time.Now()
+	assert.Len(t, pacer.Next(start), 1)
+
+	// the next frame was due at +20ms, at +100ms the writer is 4 frames
+	// behind and catches up by 3
+	assert.Equal(t, [][]byte{{1}, {2}, {3}}, pacer.Next(start.Add(100*time.Millisecond)))
+	assert.Equal(t, [][]byte{{4}, {5}, {6}}, pacer.Next(start.Add(120*time.Millisecond)))
+	assert.Equal(t, [][]byte{{7}}, pacer.Next(start.Add(140*time.Millisecond)), "back on the clock")
+}
+
+func TestPacer_ClockRestartsWhenIdle(t *testing.T) {
+	pacer := NewPacer(Pacing{CatchUpFactor: 3})
+	pacer.Push([]byte{0})
+	start := time.Now()
+	pacer.Next(start)
+	assert.Nil(t, pacer.Next(start.Add(20*time.Millisecond)))
+
+	for _, f := range frames(3) {
+		pacer.Push(f)
+	}
+	assert.Len(t, pacer.Next(start.Add(time.Second)), 1, "a pause is not caught up")
+}
+
+func TestPacer_MaxQueueDropsOldest(t *testing.T) {
+	pacer := NewPacer(Pacing{MaxQueueFrames: 3})
+	dropped := 0
+	for _, f := range frames(5) {
+		dropped += pacer.Push(f)
+	}
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, [][]byte{{2}}, pacer.Next(time.Now()))
+}
+
+func TestPacer_Interrupt(t *testing.T) {
+	pacer := NewPacer(Pacing{InterruptWatermarkFrames: 2})
+	for _, f := range frames(5) {
+		pacer.Push(f)
+	}
+	assert.Equal(t, 3, pacer.Interrupt())
+	assert.Equal(t, 2, pacer.Len())
+	assert.Equal(t, [][]byte{{0}}, pacer.Next(time.Now()), "the frames next to play are kept")
+
+	silent := NewPacer(Pacing{})
+	for _, f := range frames(5) {
+		silent.Push(f)
+	}
+	assert.Equal(t, 5, silent.Interrupt())
+	assert.Zero(t, silent.Len())
+}
+
+func TestActivePacing(t *testing.T) {
+	assert.Equal(t, Pacing{}, ActivePacing())
+	InstallPacing(Pacing{MaxQueueFrames: 10})
+	defer InstallPacing(Pacing{})
+	assert.Equal(t, Pacing{MaxQueueFrames: 10}, ActivePacing())
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package webrtc_internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+)
+
+const (
+	// DefaultHealthCheckInterval is how often regions are probed when the
+	// interval is not configured.
+	DefaultHealthCheckInterval = 30 * time.Second
+	// unhealthyAfter consecutive failed probes take a region out of selection.
+	unhealthyAfter = 2
+	probeTimeout   = 3 * time.Second
+	// credentialTTL bounds the TURN REST API credentials handed to a caller,
+	// longer than any call.
+	credentialTTL = 12 * time.Hour
+)
+
+// Region is a TURN cluster web callers can relay their media through.
+type Region struct {
+	Name string
+	URLs []string
+	// Latitude and Longitude locate the cluster, a region without a location
+	// is only used when no located one can be chosen.
+	Latitude, Longitude float64
+	Located             bool
+}
+
+// Credentials authenticate callers on the TURN servers. With a Secret every
+// session gets TURN REST API credentials of its own, otherwise the static
+// Username and Credential are shared.
+type Credentials struct {
+	Secret     string
+	Username   string
+	Credential string
+}
+
+// Location is where a web caller is. Without coordinates the longitude is
+// estimated from the caller's timezone.
+type Location struct {
+	Latitude, Longitude float64
+	Located             bool // both coordinates are known
+	LongitudeOnly       bool // only the longitude is, estimated
+}
+
+// ClientLocation returns the location the client reported, empty when it
+// reported neither coordinates nor a known timezone.
+func ClientLocation(client *types.ClientInfo, now time.Time) Location {
+	if client == nil {
+		return Location{}
+	}
+	if client.Latitude != 0 || client.Longitude != 0 {
+		return Location{Latitude: client.Latitude, Longitude: client.Longitude, Located: true}
+	}
+	if client.Timezone == "" {
+		return Location{}
+	}
+	tz, err := time.LoadLocation(client.Timezone)
+	if err != nil {
+		return Location{}
+	}
+	// one hour of UTC offset is 15 degrees of longitude
+	_, offset := now.In(tz).Zone()
+	return Location{Longitude: float64(offset) / 3600 * 15, LongitudeOnly: true}
+}
+
+type relayRegion struct {
+	Region
+	probeURL string
+	failures atomic.Int32
+	rtt      atomic.Int64
+}
+
+func (r *relayRegion) healthy() bool {
+	return r.failures.Load() < unhealthyAfter
+}
+
+// Relays selects the TURN region of each web caller and keeps the health of
+// the regions.
+type Relays struct {
+	logger      commons.Logger
+	regions     []*relayRegion
+	credentials Credentials
+	probe       func(ctx context.Context, url string) (time.Duration, error)
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewRelays(logger commons.Logger, regions []Region, credentials Credentials) *Relays {
+	r := &Relays{logger: logger, credentials: credentials, probe: stunProbe}
+	for _, region := range regions {
+		r.regions = append(r.regions, &relayRegion{Region: region, probeURL: probeURL(region.URLs)})
+	}
+	return r
+}
+
+// Select returns the region the caller at loc relays through: the nearest
+// healthy one, the first healthy one when the caller's location is unknown.
+// When no region is healthy the choice is made among all of them, a relay
+// that may recover beats no relay.
+func (r *Relays) Select(loc Location) Region {
+	candidates := make([]*relayRegion, 0, len(r.regions))
+	for _, region := range r.regions {
+		if region.healthy() {
+			candidates = append(candidates, region)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = r.regions
+	}
+	if len(candidates) == 0 {
+		return Region{}
+	}
+	selected, best := candidates[0], math.Inf(1)
+	if !loc.Located && !loc.LongitudeOnly {
+		return selected.Region
+	}
+	for _, region := range candidates {
+		if !region.Located {
+			continue
+		}
+		if d := distance(loc, region.Region); d < best {
+			selected, best = region, d
+		}
+	}
+	return selected.Region
+}
+
+// Config returns the WebRTC config of a session of the caller at loc, the
+// selected region's servers ahead of the default STUN servers.
+func (r *Relays) Config(loc Location, sessionID string, now time.Time) *Config {
+	config := DefaultConfig()
+	region := r.Select(loc)
+	if len(region.URLs) == 0 {
+		return config
+	}
+	username, credential := r.sessionCredentials(sessionID, now)
+	config.Region = region.Name
+	config.ICEServers = append([]ICEServer{{URLs: region.URLs, Username: username, Credential: credential}}, config.ICEServers...)
+	return config
+}
+
+// sessionCredentials derives TURN REST API credentials: the username is the
+// expiry and the session, the credential its HMAC-SHA1 under the secret.
+func (r *Relays) sessionCredentials(sessionID string, now time.Time) (string, string) {
+	if r.credentials.Secret == "" {
+		return r.credentials.Username, r.credentials.Credential
+	}
+	username := strconv.FormatInt(now.Add(credentialTTL).Unix(), 10) + ":" + sessionID
+	mac := hmac.New(sha1.New, []byte(r.credentials.Secret))
+	mac.Write([]byte(username))
+	return username, base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Healthy reports whether the region answers its probes, and its last
+// round trip time.
+func (r *Relays) Healthy(name string) (bool, time.Duration) {
+	for _, region := range r.regions {
+		if region.Name == name {
+			return region.healthy(), time.Duration(region.rtt.Load())
+		}
+	}
+	return false, 0
+}
+
+// Check probes every region once.
+func (r *Relays) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, region := range r.regions {
+		if region.probeURL == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(region *relayRegion) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+			rtt, err := r.probe(probeCtx, region.probeURL)
+			if err != nil {
+				if region.failures.Add(1) == unhealthyAfter {
+					r.logger.Warnw("TURN region stopped answering", "region", region.Name, "url", region.probeURL, "error", err)
+				}
+				return
+			}
+			if region.failures.Swap(0) >= unhealthyAfter {
+				r.logger.Infow("TURN region answers again", "region", region.Name, "rtt", rtt)
+			}
+			region.rtt.Store(int64(rtt))
+		}(region)
+	}
+	wg.Wait()
+}
+
+// Start probes the regions every interval until Stop.
+func (r *Relays) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			r.Check(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.done)
+}
+
+// Stop ends the health checks and waits for the running one.
+func (r *Relays) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+var activeRelays atomic.Pointer[Relays]
+
+// InstallRelays makes r select the TURN region of new web sessions, nil
+// removes it.
+func InstallRelays(r *Relays) {
+	activeRelays.Store(r)
+}
+
+// ActiveRelays returns the installed relays, nil when there are none.
+func ActiveRelays() *Relays {
+	return activeRelays.Load()
+}
+
+// distance orders regions by how far they are from the caller, the great
+// circle distance in km, or degrees of longitude when only the longitude is
+// known. Values of the two are not compared with each other.
+func distance(loc Location, region Region) float64 {
+	if loc.LongitudeOnly {
+		d := math.Abs(loc.Longitude - region.Longitude)
+		return math.Min(d, 360-d)
+	}
+	const earthRadius = 6371.0
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(region.Latitude - loc.Latitude)
+	dLon := rad(region.Longitude - loc.Longitude)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(loc.Latitude))*math.Cos(rad(region.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// probeURL picks the URL a region is probed on, a UDP one when there is.
+func probeURL(urls []string) string {
+	for _, url := range urls {
+		if !strings.HasPrefix(url, "turns:") && !strings.Contains(url, "transport=tcp") {
+			return url
+		}
+	}
+	if len(urls) > 0 {
+		return urls[0]
+	}
+	return ""
+}
+
+const (
+	stunBindingRequest  = 0x0001
+	stunBindingResponse = 0x0101
+	stunMagicCookie     = 0x2112A442
+)
+
+// stunProbe measures the round trip of a STUN binding request, which TURN
+// servers answer without credentials. Over TCP and TLS only the connection
+// is established.
+func stunProbe(ctx context.Context, url string) (time.Duration, error) {
+	scheme, rest, _ := strings.Cut(url, ":")
+	address, query, _ := strings.Cut(rest, "?")
+	network, port := "udp", "3478"
+	if scheme == "turns" {
+		network, port = "tcp", "5349"
+	} else if strings.Contains(query, "transport=tcp") {
+		network = "tcp"
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, port)
+	}
+
+	start := time.Now()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if network == "tcp" {
+		return time.Since(start), nil
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	request := make([]byte, 20)
+	binary.BigEndian.PutUint16(request[0:], stunBindingRequest)
+	binary.BigEndian.PutUint32(request[4:], stunMagicCookie)
+	if _, err := rand.Read(request[8:]); err != nil {
+		return 0, err
+	}
+	if _, err := conn.Write(request); err != nil {
+		return 0, err
+	}
+	response := make([]byte, RTPBufferSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		return 0, err
+	}
+	if n < 20 || binary.BigEndian.Uint16(response[0:]) != stunBindingResponse ||
+		binary.BigEndian.Uint32(response[4:]) != stunMagicCookie || !bytes.Equal(response[8:20], request[8:20]) {
+		return 0, errors.New("not a stun binding response")
+	}
+	return time.Since(start), nil
+}
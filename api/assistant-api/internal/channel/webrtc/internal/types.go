@@ -39,6 +39,7 @@ const (
 type Config struct {
 	ICEServers         []ICEServer
 	ICETransportPolicy string // "all" or "relay"
+	Region             string // TURN region selected for the caller, empty without relays
 }
 
 // ICEServer represents a STUN/TURN server
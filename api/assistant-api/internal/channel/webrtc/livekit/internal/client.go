@@ -0,0 +1,113 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// SignalClient is the signal connection of a participant.
+type SignalClient struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+}
+
+// SignalURL builds the WebSocket URL of the signal connection. Tracks are
+// subscribed to explicitly, the assistant only wants audio.
+func SignalURL(serverURL, token string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(serverURL))
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid livekit url %q", serverURL)
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	case "http", "ws":
+		u.Scheme = "ws"
+	default:
+		return "", fmt.Errorf("unsupported livekit url scheme %q", u.Scheme)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/rtc"
+	query := url.Values{}
+	query.Set("access_token", token)
+	query.Set("auto_subscribe", "0")
+	query.Set("protocol", strconv.Itoa(ProtocolVersion))
+	query.Set("sdk", "go")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Dial joins the room described by cfg and waits for the join response.
+func Dial(ctx context.Context, cfg *Config) (*SignalClient, *JoinResponse, error) {
+	token, err := AccessToken(cfg.APIKey, cfg.APISecret, cfg.Room, cfg.Identity, cfg.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	signalURL, err := SignalURL(cfg.URL, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, JoinTimeout)
+	defer cancel()
+	conn, resp, err := websocket.DefaultDialer.DialContext(dialCtx, signalURL, nil)
+	if err != nil {
+		if resp != nil {
+			return nil, nil, fmt.Errorf("failed to connect to livekit: %s", resp.Status)
+		}
+		return nil, nil, fmt.Errorf("failed to connect to livekit: %w", err)
+	}
+
+	client := &SignalClient{conn: conn}
+	conn.SetReadDeadline(time.Now().Add(JoinTimeout))
+	for {
+		msg, err := client.Read()
+		if err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("no join response from livekit: %w", err)
+		}
+		if msg.Join != nil {
+			conn.SetReadDeadline(time.Time{})
+			return client, msg.Join, nil
+		}
+	}
+}
+
+// Read blocks for the next server message.
+func (c *SignalClient) Read() (*SignalResponse, error) {
+	for {
+		messageType, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		if messageType != websocket.BinaryMessage {
+			continue
+		}
+		return DecodeSignalResponse(message)
+	}
+}
+
+// Send writes a request built by one of the *Request functions.
+func (c *SignalClient) Send(request []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return c.conn.WriteMessage(websocket.BinaryMessage, request)
+}
+
+// Close leaves the room and closes the connection.
+func (c *SignalClient) Close() error {
+	c.Send(LeaveRequest())
+	return c.conn.Close()
+}
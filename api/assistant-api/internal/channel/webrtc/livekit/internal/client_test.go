@@ -0,0 +1,152 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestAccessToken(t *testing.T) {
+	token, err := AccessToken("key", "secret", "support", "assistant", "Rapida")
+	require.NoError(t, err)
+
+	claims := &accessClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return []byte("secret"), nil })
+	require.NoError(t, err)
+	assert.Equal(t, "key", claims.Issuer)
+	assert.Equal(t, "assistant", claims.Subject)
+	assert.Equal(t, "Rapida", claims.Name)
+	assert.Equal(t, VideoGrant{Room: "support", RoomJoin: true, CanPublish: true, CanSubscribe: true, CanPublishData: true}, claims.Video)
+
+	_, err = AccessToken("", "secret", "support", "assistant", "")
+	assert.Error(t, err)
+	_, err = AccessToken("key", "secret", "", "assistant", "")
+	assert.Error(t, err)
+}
+
+func TestSignalURL(t *testing.T) {
+	signalURL, err := SignalURL("https://rapida.livekit.cloud/", "tok")
+	require.NoError(t, err)
+	u, err := url.Parse(signalURL)
+	require.NoError(t, err)
+	assert.Equal(t, "wss", u.Scheme)
+	assert.Equal(t, "/rtc", u.Path)
+	assert.Equal(t, "tok", u.Query().Get("access_token"))
+	assert.Equal(t, "0", u.Query().Get("auto_subscribe"))
+
+	signalURL, err = SignalURL("ws://localhost:7880", "tok")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signalURL, "ws://localhost:7880/rtc?"))
+
+	_, err = SignalURL("ftp://localhost", "tok")
+	assert.Error(t, err)
+	_, err = SignalURL("livekit", "tok")
+	assert.Error(t, err)
+}
+
+func TestConfigFromCredential(t *testing.T) {
+	value, err := structpb.NewStruct(map[string]interface{}{"url": "wss://lk", "api_key": "key", "api_secret": "secret"})
+	require.NoError(t, err)
+	config, err := ConfigFromCredential(&protos.VaultCredential{Value: value}, "support", "assistant", "Rapida")
+	require.NoError(t, err)
+	assert.Equal(t, &Config{URL: "wss://lk", APIKey: "key", APISecret: "secret", Room: "support", Identity: "assistant", Name: "Rapida"}, config)
+
+	value, err = structpb.NewStruct(map[string]interface{}{"url": "wss://lk"})
+	require.NoError(t, err)
+	_, err = ConfigFromCredential(&protos.VaultCredential{Value: value}, "support", "assistant", "")
+	assert.Error(t, err)
+	_, err = ConfigFromCredential(nil, "support", "assistant", "")
+	assert.Error(t, err)
+}
+
+func TestDial_WaitsForJoinAndExchangesRequests(t *testing.T) {
+	requests := make(chan []byte, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rtc" || r.URL.Query().Get("access_token") == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// messages before the join are ignored
+		conn.WriteMessage(websocket.BinaryMessage, message(18, 1))
+		conn.WriteMessage(websocket.BinaryMessage, message(1, message(1, message(2, "support"), 2, message(1, "PA_agent"))))
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			requests <- msg
+			conn.WriteMessage(websocket.BinaryMessage, message(6, message(1, "cid")))
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{URL: server.URL, APIKey: "key", APISecret: "secret", Room: "support", Identity: "assistant"}
+	client, join, err := Dial(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, "support", join.RoomName)
+	assert.Equal(t, "PA_agent", join.Participant.Sid)
+
+	require.NoError(t, client.Send(AddTrackRequest("cid", AudioTrackName)))
+	assert.Equal(t, AddTrackRequest("cid", AudioTrackName), <-requests)
+	resp, err := client.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "cid", resp.TrackPublished)
+
+	require.NoError(t, client.Close())
+	assert.Equal(t, LeaveRequest(), <-requests)
+
+	config.URL = server.URL + "/other"
+	_, _, err = Dial(context.Background(), config)
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestMixer(t *testing.T) {
+	frame := func(samples ...int16) []byte {
+		b := make([]byte, 2*len(samples))
+		for i, s := range samples {
+			binary.LittleEndian.PutUint16(b[2*i:], uint16(s))
+		}
+		return b
+	}
+	m := NewMixer(4, 8)
+	assert.Nil(t, m.Next(), "nothing queued")
+
+	m.Write("a", frame(100, -200))
+	assert.Equal(t, frame(100, -200), m.Next(), "a single track is passed through")
+
+	m.Write("a", frame(100, 30000))
+	m.Write("b", frame(50, 30000))
+	assert.Equal(t, frame(150, 32767), m.Next(), "summed and clipped")
+
+	m.Write("a", frame(1))
+	assert.Nil(t, m.Next(), "half a frame waits for more audio")
+	m.Write("a", frame(2))
+	assert.Equal(t, frame(1, 2), m.Next())
+
+	m.Write("b", frame(1, 2, 3, 4, 5, 6))
+	assert.Equal(t, frame(3, 4), m.Next(), "a track running ahead loses its oldest audio")
+	m.Remove("b")
+	assert.Nil(t, m.Next())
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// DataMetadataKey is the metadata key of data messages that are not a JSON
+// object and carry no topic.
+const DataMetadataKey = "livekit.data"
+
+// UserData is a data message a participant sent to the room.
+type UserData struct {
+	ParticipantIdentity string
+	Topic               string
+	Payload             []byte
+}
+
+// DecodeDataPacket decodes a DataPacket received on a data channel. Packets
+// other than user data (speaker updates, transcriptions, ...) return nil.
+func DecodeDataPacket(b []byte) (*UserData, error) {
+	var data *UserData
+	var identity string
+	err := fields(b, func(num protowire.Number, value []byte, _ uint64) error {
+		switch num {
+		case 2: // UserPacket
+			data = &UserData{}
+			return fields(value, func(num protowire.Number, value []byte, _ uint64) error {
+				switch num {
+				case 2:
+					data.Payload = value
+				case 4:
+					data.Topic = string(value)
+				case 5:
+					data.ParticipantIdentity = string(value)
+				}
+				return nil
+			})
+		case 4:
+			identity = string(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data packet: %w", err)
+	}
+	if data != nil && data.ParticipantIdentity == "" {
+		data.ParticipantIdentity = identity
+	}
+	return data, nil
+}
+
+// Metadata maps a data message to conversation metadata. Every member of a
+// JSON object becomes one entry, strings as is and other values as JSON.
+// Anything else is stored whole under its topic, or DataMetadataKey.
+func (d *UserData) Metadata() map[string]string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(d.Payload, &object); err == nil && len(object) > 0 {
+		entries := make(map[string]string, len(object))
+		for key, raw := range object {
+			var text string
+			if err := json.Unmarshal(raw, &text); err == nil {
+				entries[key] = text
+			} else {
+				entries[key] = string(raw)
+			}
+		}
+		return entries
+	}
+	key := d.Topic
+	if key == "" {
+		key = DataMetadataKey
+	}
+	return map[string]string{key: string(d.Payload)}
+}
+
+// SortedKeys returns the keys of m in order, so metadata is applied in the
+// same order every time.
+func SortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
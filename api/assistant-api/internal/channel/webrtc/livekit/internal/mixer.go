@@ -0,0 +1,78 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+)
+
+// Mixer sums the decoded audio of several remote tracks into one stream of
+// linear16 frames. Every track has its own queue, a track that runs ahead
+// by more than maxQueued bytes loses its oldest audio.
+type Mixer struct {
+	mu        sync.Mutex
+	frameSize int
+	maxQueued int
+	tracks    map[string][]byte
+}
+
+// NewMixer creates a mixer producing frames of frameSize bytes.
+func NewMixer(frameSize, maxQueued int) *Mixer {
+	return &Mixer{frameSize: frameSize, maxQueued: maxQueued, tracks: make(map[string][]byte)}
+}
+
+// Write queues audio of a track.
+func (m *Mixer) Write(track string, pcm []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	queued := append(m.tracks[track], pcm...)
+	if over := len(queued) - m.maxQueued; over > 0 {
+		queued = queued[over:]
+	}
+	m.tracks[track] = queued
+}
+
+// Remove drops a track and its queued audio.
+func (m *Mixer) Remove(track string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tracks, track)
+}
+
+// Next returns the next mixed frame, nil when no track has a full frame.
+// A track with less than a frame queued is left for the next call.
+func (m *Mixer) Next() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ready [][]byte
+	for track, queued := range m.tracks {
+		if len(queued) >= m.frameSize {
+			ready = append(ready, queued[:m.frameSize])
+			m.tracks[track] = queued[m.frameSize:]
+		}
+	}
+	switch len(ready) {
+	case 0:
+		return nil
+	case 1:
+		return append([]byte(nil), ready[0]...)
+	}
+
+	frame := make([]byte, m.frameSize)
+	for i := 0; i+1 < m.frameSize; i += 2 {
+		sum := 0
+		for _, audio := range ready {
+			sum += int(int16(binary.LittleEndian.Uint16(audio[i:])))
+		}
+		sum = max(math.MinInt16, min(math.MaxInt16, sum))
+		binary.LittleEndian.PutUint16(frame[i:], uint16(int16(sum)))
+	}
+	return frame
+}
@@ -0,0 +1,404 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// LiveKit signals with protobuf messages on a WebSocket (livekit_rtc.proto).
+// Only the messages and fields a participant publishing one audio track needs
+// are encoded here, everything else is skipped when decoding.
+
+// SignalTarget tells which of the two peer connections an ICE candidate
+// belongs to.
+type SignalTarget int
+
+const (
+	TargetPublisher  SignalTarget = 0
+	TargetSubscriber SignalTarget = 1
+)
+
+// TrackType and TrackSource values of livekit_models.proto.
+const (
+	TrackTypeAudio         = 0
+	TrackSourceMicrophone  = 2
+	participantDisconnect  = 3
+	disconnectClientReason = 1
+)
+
+// SignalRequest fields
+const (
+	requestOffer        = 1
+	requestAnswer       = 2
+	requestTrickle      = 3
+	requestAddTrack     = 4
+	requestSubscription = 6
+	requestLeave        = 8
+	requestPing         = 14
+)
+
+// SignalResponse fields
+const (
+	responseJoin           = 1
+	responseAnswer         = 2
+	responseOffer          = 3
+	responseTrickle        = 4
+	responseUpdate         = 5
+	responseTrackPublished = 6
+	responseLeave          = 8
+	responseRefreshToken   = 16
+	responsePong           = 18
+)
+
+// ICEServer is a STUN/TURN server handed out in the join response.
+type ICEServer struct {
+	URLs       []string
+	Username   string
+	Credential string
+}
+
+// TrackInfo is a track published by a participant.
+type TrackInfo struct {
+	Sid   string
+	Type  int
+	Name  string
+	Muted bool
+}
+
+// ParticipantInfo describes a participant of the room.
+type ParticipantInfo struct {
+	Sid          string
+	Identity     string
+	State        int
+	Tracks       []TrackInfo
+	Metadata     string
+	Name         string
+	Disconnected bool
+}
+
+// JoinResponse is the first message of the signal connection.
+type JoinResponse struct {
+	RoomName          string
+	Participant       ParticipantInfo
+	OtherParticipants []ParticipantInfo
+	ICEServers        []ICEServer
+	SubscriberPrimary bool
+	PingTimeout       int
+	PingInterval      int
+}
+
+// SessionDescription is an SDP offer or answer.
+type SessionDescription struct {
+	Type string
+	SDP  string
+}
+
+// Trickle carries one ICE candidate as RTCIceCandidateInit JSON.
+type Trickle struct {
+	CandidateInit string
+	Target        SignalTarget
+}
+
+// SignalResponse is a decoded server message. At most one field is set,
+// messages the client does not handle decode to an empty response.
+type SignalResponse struct {
+	Join           *JoinResponse
+	Answer         *SessionDescription
+	Offer          *SessionDescription
+	Trickle        *Trickle
+	Update         []ParticipantInfo
+	TrackPublished string // cid of the published track
+	Leave          bool
+	RefreshToken   string
+	Pong           bool
+}
+
+// ============================================================================
+// Requests
+// ============================================================================
+
+func request(field protowire.Number, message []byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(nil, field, protowire.BytesType), message)
+}
+
+func appendString(b []byte, field protowire.Number, value string) []byte {
+	if value == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, field, protowire.BytesType)
+	return protowire.AppendString(b, value)
+}
+
+func appendVarint(b []byte, field protowire.Number, value uint64) []byte {
+	if value == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, field, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func sessionDescription(sdpType, sdp string) []byte {
+	return appendString(appendString(nil, 1, sdpType), 2, sdp)
+}
+
+// OfferRequest sends the publisher's offer.
+func OfferRequest(sdp string) []byte {
+	return request(requestOffer, sessionDescription("offer", sdp))
+}
+
+// AnswerRequest answers the server's offer for the subscriber.
+func AnswerRequest(sdp string) []byte {
+	return request(requestAnswer, sessionDescription("answer", sdp))
+}
+
+// TrickleRequest sends a local ICE candidate of the given peer connection.
+func TrickleRequest(candidateInit string, target SignalTarget) []byte {
+	return request(requestTrickle, appendVarint(appendString(nil, 1, candidateInit), 2, uint64(target)))
+}
+
+// AddTrackRequest announces a microphone track before it is negotiated.
+func AddTrackRequest(cid, name string) []byte {
+	b := appendString(nil, 1, cid)
+	b = appendString(b, 2, name)
+	b = appendVarint(b, 3, TrackTypeAudio)
+	b = appendVarint(b, 8, TrackSourceMicrophone)
+	return request(requestAddTrack, b)
+}
+
+// SubscriptionRequest subscribes to the given tracks.
+func SubscriptionRequest(trackSids []string) []byte {
+	var b []byte
+	for _, sid := range trackSids {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, sid)
+	}
+	return request(requestSubscription, appendVarint(b, 2, 1))
+}
+
+// LeaveRequest tells the server the participant is leaving for good.
+func LeaveRequest() []byte {
+	return request(requestLeave, appendVarint(nil, 2, disconnectClientReason))
+}
+
+// PingRequest keeps the signal connection alive, timestamp is in ms.
+func PingRequest(timestamp int64) []byte {
+	b := protowire.AppendTag(nil, requestPing, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(timestamp))
+}
+
+// ============================================================================
+// Responses
+// ============================================================================
+
+var errMalformed = errors.New("malformed signal message")
+
+// fields walks the fields of a message, calling fn with the field number,
+// the value of length delimited fields and the value of varints.
+func fields(b []byte, fn func(num protowire.Number, value []byte, v uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return errMalformed
+		}
+		b = b[n:]
+		var value []byte
+		var v uint64
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return errMalformed
+		}
+		b = b[n:]
+		if err := fn(num, value, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeSignalResponse decodes a binary message of the signal connection.
+func DecodeSignalResponse(b []byte) (*SignalResponse, error) {
+	resp := &SignalResponse{}
+	err := fields(b, func(num protowire.Number, value []byte, v uint64) error {
+		var err error
+		switch num {
+		case responseJoin:
+			resp.Join, err = decodeJoin(value)
+		case responseAnswer:
+			resp.Answer, err = decodeSessionDescription(value)
+		case responseOffer:
+			resp.Offer, err = decodeSessionDescription(value)
+		case responseTrickle:
+			resp.Trickle, err = decodeTrickle(value)
+		case responseUpdate:
+			resp.Update, err = decodeParticipantUpdate(value)
+		case responseTrackPublished:
+			err = fields(value, func(num protowire.Number, value []byte, _ uint64) error {
+				if num == 1 {
+					resp.TrackPublished = string(value)
+				}
+				return nil
+			})
+		case responseLeave:
+			resp.Leave = true
+		case responseRefreshToken:
+			resp.RefreshToken = string(value)
+		case responsePong:
+			resp.Pong = true
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signal response: %w", err)
+	}
+	return resp, nil
+}
+
+func decodeSessionDescription(b []byte) (*SessionDescription, error) {
+	sd := &SessionDescription{}
+	err := fields(b, func(num protowire.Number, value []byte, _ uint64) error {
+		switch num {
+		case 1:
+			sd.Type = string(value)
+		case 2:
+			sd.SDP = string(value)
+		}
+		return nil
+	})
+	return sd, err
+}
+
+func decodeTrickle(b []byte) (*Trickle, error) {
+	t := &Trickle{}
+	err := fields(b, func(num protowire.Number, value []byte, v uint64) error {
+		switch num {
+		case 1:
+			t.CandidateInit = string(value)
+		case 2:
+			t.Target = SignalTarget(v)
+		}
+		return nil
+	})
+	return t, err
+}
+
+func decodeJoin(b []byte) (*JoinResponse, error) {
+	join := &JoinResponse{}
+	err := fields(b, func(num protowire.Number, value []byte, v uint64) error {
+		switch num {
+		case 1: // Room
+			return fields(value, func(num protowire.Number, value []byte, _ uint64) error {
+				if num == 2 {
+					join.RoomName = string(value)
+				}
+				return nil
+			})
+		case 2:
+			p, err := decodeParticipant(value)
+			join.Participant = p
+			return err
+		case 3:
+			p, err := decodeParticipant(value)
+			join.OtherParticipants = append(join.OtherParticipants, p)
+			return err
+		case 5:
+			server, err := decodeICEServer(value)
+			join.ICEServers = append(join.ICEServers, server)
+			return err
+		case 6:
+			join.SubscriberPrimary = v != 0
+		case 10:
+			join.PingTimeout = int(int32(v))
+		case 11:
+			join.PingInterval = int(int32(v))
+		}
+		return nil
+	})
+	return join, err
+}
+
+func decodeICEServer(b []byte) (ICEServer, error) {
+	var server ICEServer
+	err := fields(b, func(num protowire.Number, value []byte, _ uint64) error {
+		switch num {
+		case 1:
+			server.URLs = append(server.URLs, string(value))
+		case 2:
+			server.Username = string(value)
+		case 3:
+			server.Credential = string(value)
+		}
+		return nil
+	})
+	return server, err
+}
+
+func decodeParticipantUpdate(b []byte) ([]ParticipantInfo, error) {
+	var participants []ParticipantInfo
+	err := fields(b, func(num protowire.Number, value []byte, _ uint64) error {
+		if num != 1 {
+			return nil
+		}
+		p, err := decodeParticipant(value)
+		participants = append(participants, p)
+		return err
+	})
+	return participants, err
+}
+
+func decodeParticipant(b []byte) (ParticipantInfo, error) {
+	var p ParticipantInfo
+	err := fields(b, func(num protowire.Number, value []byte, v uint64) error {
+		switch num {
+		case 1:
+			p.Sid = string(value)
+		case 2:
+			p.Identity = string(value)
+		case 3:
+			p.State = int(v)
+		case 4:
+			track, err := decodeTrack(value)
+			p.Tracks = append(p.Tracks, track)
+			return err
+		case 5:
+			p.Metadata = string(value)
+		case 9:
+			p.Name = string(value)
+		}
+		return nil
+	})
+	p.Disconnected = p.State == participantDisconnect
+	return p, err
+}
+
+func decodeTrack(b []byte) (TrackInfo, error) {
+	var track TrackInfo
+	err := fields(b, func(num protowire.Number, value []byte, v uint64) error {
+		switch num {
+		case 1:
+			track.Sid = string(value)
+		case 2:
+			track.Type = int(v)
+		case 3:
+			track.Name = string(value)
+		case 4:
+			track.Muted = v != 0
+		}
+		return nil
+	})
+	return track, err
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// message builds a protobuf message from alternating field numbers and
+// values, strings and []byte are length delimited, ints are varints.
+func message(pairs ...interface{}) []byte {
+	var b []byte
+	for i := 0; i < len(pairs); i += 2 {
+		num := protowire.Number(pairs[i].(int))
+		switch v := pairs[i+1].(type) {
+		case string:
+			b = protowire.AppendString(protowire.AppendTag(b, num, protowire.BytesType), v)
+		case []byte:
+			b = protowire.AppendBytes(protowire.AppendTag(b, num, protowire.BytesType), v)
+		case int:
+			b = protowire.AppendVarint(protowire.AppendTag(b, num, protowire.VarintType), uint64(v))
+		}
+	}
+	return b
+}
+
+func TestDecodeSignalResponse_Join(t *testing.T) {
+	agent := message(1, "PA_agent", 2, "assistant")
+	caller := message(1, "PA_caller", 2, "caller", 9, "Caller",
+		4, message(1, "TR_mic", 2, TrackTypeAudio, 3, "microphone"),
+		4, message(1, "TR_cam", 2, 1))
+	join := message(
+		1, message(1, "RM_1", 2, "support"),
+		2, agent,
+		3, caller,
+		5, message(1, "turn:turn.example.com:3478", 2, "user", 3, "secret"),
+		6, 1,
+		11, 15,
+		// unknown fields are skipped
+		20, "ignored",
+	)
+
+	resp, err := DecodeSignalResponse(message(1, join))
+	require.NoError(t, err)
+	require.NotNil(t, resp.Join)
+	assert.Equal(t, "support", resp.Join.RoomName)
+	assert.Equal(t, "PA_agent", resp.Join.Participant.Sid)
+	assert.True(t, resp.Join.SubscriberPrimary)
+	assert.Equal(t, 15, resp.Join.PingInterval)
+	assert.Equal(t, []ICEServer{{URLs: []string{"turn:turn.example.com:3478"}, Username: "user", Credential: "secret"}}, resp.Join.ICEServers)
+	require.Len(t, resp.Join.OtherParticipants, 1)
+	other := resp.Join.OtherParticipants[0]
+	assert.Equal(t, "caller", other.Identity)
+	assert.Equal(t, "Caller", other.Name)
+	assert.Equal(t, []TrackInfo{{Sid: "TR_mic", Type: TrackTypeAudio, Name: "microphone"}, {Sid: "TR_cam", Type: 1}}, other.Tracks)
+}
+
+func TestDecodeSignalResponse_Messages(t *testing.T) {
+	resp, err := DecodeSignalResponse(message(3, message(1, "offer", 2, "v=0")))
+	require.NoError(t, err)
+	assert.Equal(t, &SessionDescription{Type: "offer", SDP: "v=0"}, resp.Offer)
+
+	resp, err = DecodeSignalResponse(message(2, message(1, "answer", 2, "v=0")))
+	require.NoError(t, err)
+	assert.Equal(t, "answer", resp.Answer.Type)
+
+	resp, err = DecodeSignalResponse(message(4, message(1, `{"candidate":"c"}`, 2, 1)))
+	require.NoError(t, err)
+	assert.Equal(t, &Trickle{CandidateInit: `{"candidate":"c"}`, Target: TargetSubscriber}, resp.Trickle)
+
+	resp, err = DecodeSignalResponse(message(5, message(1, message(1, "PA_caller", 3, participantDisconnect))))
+	require.NoError(t, err)
+	require.Len(t, resp.Update, 1)
+	assert.True(t, resp.Update[0].Disconnected)
+
+	resp, err = DecodeSignalResponse(message(6, message(1, "cid-1", 2, message(1, "TR_1"))))
+	require.NoError(t, err)
+	assert.Equal(t, "cid-1", resp.TrackPublished)
+
+	resp, err = DecodeSignalResponse(message(8, message(2, 4)))
+	require.NoError(t, err)
+	assert.True(t, resp.Leave)
+
+	resp, err = DecodeSignalResponse(message(18, 1234))
+	require.NoError(t, err)
+	assert.True(t, resp.Pong)
+
+	_, err = DecodeSignalResponse([]byte{0x0a, 0x05, 0x01})
+	assert.Error(t, err, "length past the end")
+}
+
+func TestSignalRequests(t *testing.T) {
+	assert.Equal(t, message(1, message(1, "offer", 2, "v=0")), OfferRequest("v=0"))
+	assert.Equal(t, message(2, message(1, "answer", 2, "v=0")), AnswerRequest("v=0"))
+	assert.Equal(t, message(3, message(1, "{}")), TrickleRequest("{}", TargetPublisher), "publisher is the zero value")
+	assert.Equal(t, message(3, message(1, "{}", 2, 1)), TrickleRequest("{}", TargetSubscriber))
+	assert.Equal(t, message(4, message(1, "cid", 2, "assistant", 8, TrackSourceMicrophone)), AddTrackRequest("cid", "assistant"))
+	assert.Equal(t, message(6, message(1, "TR_1", 1, "TR_2", 2, 1)), SubscriptionRequest([]string{"TR_1", "TR_2"}))
+	assert.Equal(t, message(8, message(2, disconnectClientReason)), LeaveRequest())
+	assert.Equal(t, message(14, 42), PingRequest(42))
+}
+
+func TestDecodeDataPacket(t *testing.T) {
+	packet := message(2, message(1, "PA_caller", 2, []byte(`{"order_id":"A-17","items":2}`), 4, "context"), 4, "caller")
+	data, err := DecodeDataPacket(packet)
+	require.NoError(t, err)
+	assert.Equal(t, "caller", data.ParticipantIdentity)
+	assert.Equal(t, "context", data.Topic)
+	assert.Equal(t, map[string]string{"order_id": "A-17", "items": "2"}, data.Metadata())
+
+	data, err = DecodeDataPacket(message(2, message(2, []byte("hello"), 4, "chat")))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"chat": "hello"}, data.Metadata())
+
+	data, err = DecodeDataPacket(message(2, message(2, []byte("[1,2]"))))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{DataMetadataKey: "[1,2]"}, data.Metadata())
+
+	data, err = DecodeDataPacket(message(3, message(1, "speakers")))
+	require.NoError(t, err)
+	assert.Nil(t, data, "not user data")
+
+	assert.Equal(t, []string{"a", "b", "c"}, SortedKeys(map[string]string{"c": "", "a": "", "b": ""}))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenTTL is how long an access token is valid for joining. The server
+// refreshes the token of a connected participant on its own.
+const TokenTTL = 10 * time.Minute
+
+// VideoGrant is the room permission part of a LiveKit access token.
+type VideoGrant struct {
+	Room           string `json:"room"`
+	RoomJoin       bool   `json:"roomJoin"`
+	CanPublish     bool   `json:"canPublish"`
+	CanSubscribe   bool   `json:"canSubscribe"`
+	CanPublishData bool   `json:"canPublishData"`
+}
+
+type accessClaims struct {
+	jwt.RegisteredClaims
+	Name  string     `json:"name,omitempty"`
+	Video VideoGrant `json:"video"`
+}
+
+// AccessToken signs a token that lets identity join room, publish audio,
+// subscribe to the other participants and exchange data messages.
+func AccessToken(apiKey, apiSecret, room, identity, name string) (string, error) {
+	if apiKey == "" || apiSecret == "" {
+		return "", errors.New("livekit api key and secret are required")
+	}
+	if room == "" || identity == "" {
+		return "", errors.New("livekit room and identity are required")
+	}
+	now := time.Now()
+	claims := accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    apiKey,
+			Subject:   identity,
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenTTL)),
+		},
+		Name: name,
+		Video: VideoGrant{
+			Room:           room,
+			RoomJoin:       true,
+			CanPublish:     true,
+			CanSubscribe:   true,
+			CanPublishData: true,
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(apiSecret))
+}
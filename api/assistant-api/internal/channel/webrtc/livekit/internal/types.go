@@ -0,0 +1,66 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package livekit_internal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+// Signal connection
+const (
+	// ProtocolVersion is the signal protocol spoken by the client, the one
+	// with a subscriber and a publisher peer connection.
+	ProtocolVersion = 9
+
+	// DefaultPingInterval is used when the join response has none.
+	DefaultPingInterval = 10 * time.Second
+
+	// JoinTimeout bounds the wait for the join response.
+	JoinTimeout = 10 * time.Second
+
+	// AudioTrackName is the name of the track the assistant publishes.
+	AudioTrackName = "assistant"
+)
+
+// Remote audio is mixed in 20ms frames of 16kHz linear16 and may queue up
+// to 200ms per track.
+const (
+	MixFrameBytes = 640
+	MixMaxQueued  = 10 * MixFrameBytes
+)
+
+// Config holds what is needed to join a room.
+type Config struct {
+	// URL of the LiveKit server, ws(s):// or http(s)://
+	URL       string
+	APIKey    string
+	APISecret string
+	Room      string
+	// Identity and Name the assistant joins the room with
+	Identity string
+	Name     string
+}
+
+// ConfigFromCredential reads the server url, api_key and api_secret of a
+// livekit vault credential.
+func ConfigFromCredential(credential *protos.VaultCredential, room, identity, name string) (*Config, error) {
+	if credential == nil || credential.GetValue() == nil {
+		return nil, errors.New("livekit credential is missing")
+	}
+	values := credential.GetValue().AsMap()
+	config := &Config{Room: room, Identity: identity, Name: name}
+	config.URL, _ = values["url"].(string)
+	config.APIKey, _ = values["api_key"].(string)
+	config.APISecret, _ = values["api_secret"].(string)
+	if config.URL == "" || config.APIKey == "" || config.APISecret == "" {
+		return nil, errors.New("livekit credential needs url, api_key and api_secret")
+	}
+	return config, nil
+}
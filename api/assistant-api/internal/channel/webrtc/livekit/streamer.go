@@ -0,0 +1,610 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package channel_livekit lets the assistant join a LiveKit room as a
+// participant. It speaks the LiveKit signal protocol itself and runs the two
+// peer connections of a participant with Pion: the subscriber receives the
+// audio of the other participants and their data messages, the publisher
+// sends the assistant's audio track.
+package channel_livekit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	pionwebrtc "github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media"
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_audio_resampler "github.com/rapidaai/api/assistant-api/internal/audio/resampler"
+	webrtc_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/internal"
+	livekit_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/livekit/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/streamers"
+	"github.com/rapidaai/protos"
+)
+
+// Config is what the streamer needs to join a room.
+type Config = livekit_internal.Config
+
+// ConfigFromCredential builds the Config for joining room with a livekit
+// vault credential.
+func ConfigFromCredential(credential *protos.VaultCredential, room, identity, name string) (*Config, error) {
+	return livekit_internal.ConfigFromCredential(credential, room, identity, name)
+}
+
+type livekitStreamer struct {
+	streamers.BaseStreamer
+
+	config *Config
+	signal *livekit_internal.SignalClient
+	join   *livekit_internal.JoinResponse
+
+	publisher  *pionwebrtc.PeerConnection
+	subscriber *pionwebrtc.PeerConnection
+	localTrack *pionwebrtc.TrackLocalStaticSample
+
+	resampler internal_type.AudioResampler
+	opusCodec *webrtc_internal.OpusCodec
+	mixer     *livekit_internal.Mixer
+
+	// remote candidates that arrived before the remote description
+	pendingCandidates map[livekit_internal.SignalTarget][]pionwebrtc.ICECandidateInit
+	// audio tracks subscribed to and remote participants in the room
+	subscribed   map[string]bool
+	participants map[string]bool
+
+	audioWg       sync.WaitGroup
+	peerConnected atomic.Bool
+	closeOnce     sync.Once
+}
+
+// NewLiveKitStreamer joins the room and starts publishing once the server
+// accepted the assistant's track. initialization is handed to the talk loop
+// as the first message, it names the assistant that joins.
+func NewLiveKitStreamer(
+	ctx context.Context,
+	logger commons.Logger,
+	config *Config,
+	initialization *protos.ConversationInitialization,
+) (internal_type.Streamer, error) {
+	resampler, err := internal_audio_resampler.GetResampler(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	opusCodec, err := webrtc_internal.NewOpusCodec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Opus codec: %w", err)
+	}
+
+	signal, join, err := livekit_internal.Dial(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &livekitStreamer{
+		BaseStreamer: streamers.NewBaseStreamer(logger,
+			streamers.WithInputChannelSize(webrtc_internal.InputChannelSize),
+			streamers.WithOutputChannelSize(webrtc_internal.OutputChannelSize),
+			streamers.WithInputBufferThreshold(webrtc_internal.InputBufferThreshold),
+			streamers.WithOutputBufferThreshold(webrtc_internal.OutputBufferThreshold),
+			streamers.WithOutputFrameSize(webrtc_internal.OpusFrameBytes),
+			streamers.WithInputBackpressure(streamers.Backpressure{Policy: streamers.DropOldest}),
+			streamers.WithOutputBackpressure(streamers.Backpressure{Policy: streamers.BlockWithTimeout}),
+		),
+		config:            config,
+		signal:            signal,
+		join:              join,
+		resampler:         resampler,
+		opusCodec:         opusCodec,
+		mixer:             livekit_internal.NewMixer(livekit_internal.MixFrameBytes, livekit_internal.MixMaxQueued),
+		pendingCandidates: make(map[livekit_internal.SignalTarget][]pionwebrtc.ICECandidateInit),
+		subscribed:        make(map[string]bool),
+		participants:      make(map[string]bool),
+	}
+
+	if err := s.createPeerConnections(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.PushInput(initialization)
+
+	s.updateParticipants(join.OtherParticipants)
+	if err := s.signal.Send(livekit_internal.AddTrackRequest(s.localTrack.ID(), livekit_internal.AudioTrackName)); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to publish audio track: %w", err)
+	}
+
+	s.Logger.Infow("Joined LiveKit room",
+		"room", join.RoomName,
+		"identity", join.Participant.Identity,
+		"participants", len(join.OtherParticipants))
+
+	go s.runSignalReader()
+	go s.runPing()
+	go s.runMixer()
+	go s.runOutputWriter()
+	go s.watchCallerContext(ctx)
+	return s, nil
+}
+
+// ============================================================================
+// Peer connections
+// ============================================================================
+
+func (s *livekitStreamer) createPeerConnections() error {
+	mediaEngine := &pionwebrtc.MediaEngine{}
+	if err := mediaEngine.RegisterCodec(pionwebrtc.RTPCodecParameters{
+		RTPCodecCapability: pionwebrtc.RTPCodecCapability{
+			MimeType:    pionwebrtc.MimeTypeOpus,
+			ClockRate:   webrtc_internal.OpusSampleRate,
+			Channels:    webrtc_internal.OpusChannels,
+			SDPFmtpLine: webrtc_internal.OpusSDPFmtpLine,
+		},
+		PayloadType: webrtc_internal.OpusPayloadType,
+	}, pionwebrtc.RTPCodecTypeAudio); err != nil {
+		return fmt.Errorf("failed to register Opus codec: %w", err)
+	}
+	registry := &interceptor.Registry{}
+	if err := pionwebrtc.RegisterDefaultInterceptors(mediaEngine, registry); err != nil {
+		return fmt.Errorf("failed to register interceptors: %w", err)
+	}
+	api := pionwebrtc.NewAPI(
+		pionwebrtc.WithMediaEngine(mediaEngine),
+		pionwebrtc.WithInterceptorRegistry(registry),
+	)
+
+	// the server hands out its own TURN servers, fall back to public STUN
+	var iceServers []pionwebrtc.ICEServer
+	for _, srv := range s.join.ICEServers {
+		iceServers = append(iceServers, pionwebrtc.ICEServer{URLs: srv.URLs, Username: srv.Username, Credential: srv.Credential})
+	}
+	if len(iceServers) == 0 {
+		for _, srv := range webrtc_internal.DefaultConfig().ICEServers {
+			iceServers = append(iceServers, pionwebrtc.ICEServer{URLs: srv.URLs})
+		}
+	}
+	pcConfig := pionwebrtc.Configuration{ICEServers: iceServers}
+
+	var err error
+	if s.subscriber, err = api.NewPeerConnection(pcConfig); err != nil {
+		return fmt.Errorf("failed to create subscriber peer connection: %w", err)
+	}
+	if s.publisher, err = api.NewPeerConnection(pcConfig); err != nil {
+		return fmt.Errorf("failed to create publisher peer connection: %w", err)
+	}
+	s.trickleCandidates(s.subscriber, livekit_internal.TargetSubscriber)
+	s.trickleCandidates(s.publisher, livekit_internal.TargetPublisher)
+
+	s.subscriber.OnTrack(func(track *pionwebrtc.TrackRemote, _ *pionwebrtc.RTPReceiver) {
+		if track.Kind() != pionwebrtc.RTPCodecTypeAudio {
+			return
+		}
+		s.Logger.Infow("LiveKit audio track subscribed", "track", track.ID(), "codec", track.Codec().MimeType)
+		s.audioWg.Add(1)
+		go s.readRemoteAudio(track)
+	})
+	s.subscriber.OnDataChannel(func(dc *pionwebrtc.DataChannel) {
+		dc.OnMessage(func(msg pionwebrtc.DataChannelMessage) {
+			s.handleData(msg.Data)
+		})
+	})
+	s.subscriber.OnConnectionStateChange(func(state pionwebrtc.PeerConnectionState) {
+		s.Logger.Infow("LiveKit subscriber connection state changed", "state", state)
+		if state == pionwebrtc.PeerConnectionStateFailed {
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		}
+	})
+	s.publisher.OnConnectionStateChange(func(state pionwebrtc.PeerConnectionState) {
+		s.Logger.Infow("LiveKit publisher connection state changed", "state", state)
+		switch state {
+		case pionwebrtc.PeerConnectionStateConnected:
+			s.peerConnected.Store(true)
+		case pionwebrtc.PeerConnectionStateFailed:
+			s.peerConnected.Store(false)
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		case pionwebrtc.PeerConnectionStateDisconnected:
+			s.peerConnected.Store(false)
+		}
+	})
+
+	track, err := pionwebrtc.NewTrackLocalStaticSample(
+		pionwebrtc.RTPCodecCapability{
+			MimeType:  pionwebrtc.MimeTypeOpus,
+			ClockRate: webrtc_internal.OpusSampleRate,
+			Channels:  webrtc_internal.OpusChannels,
+		},
+		"rapida-audio",
+		"rapida-assistant",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create local audio track: %w", err)
+	}
+	if _, err := s.publisher.AddTrack(track); err != nil {
+		return fmt.Errorf("failed to add track: %w", err)
+	}
+	s.localTrack = track
+	return nil
+}
+
+// trickleCandidates sends the local candidates of pc to the server.
+func (s *livekitStreamer) trickleCandidates(pc *pionwebrtc.PeerConnection, target livekit_internal.SignalTarget) {
+	pc.OnICECandidate(func(c *pionwebrtc.ICECandidate) {
+		if c == nil {
+			return
+		}
+		candidateInit, err := json.Marshal(c.ToJSON())
+		if err != nil {
+			return
+		}
+		if err := s.signal.Send(livekit_internal.TrickleRequest(string(candidateInit), target)); err != nil {
+			s.Logger.Debugw("Failed to send ICE candidate to LiveKit", "error", err)
+		}
+	})
+}
+
+// ============================================================================
+// Signaling
+// ============================================================================
+
+// runSignalReader handles server messages until the signal connection closes,
+// which ends the conversation.
+func (s *livekitStreamer) runSignalReader() {
+	for {
+		msg, err := s.signal.Read()
+		if err != nil {
+			s.Logger.Infow("LiveKit signal connection closed", "error", err)
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+			return
+		}
+		switch {
+		case msg.Offer != nil:
+			s.handleOffer(msg.Offer.SDP)
+		case msg.Answer != nil:
+			s.setRemoteDescription(s.publisher, livekit_internal.TargetPublisher, pionwebrtc.SDPTypeAnswer, msg.Answer.SDP)
+		case msg.Trickle != nil:
+			s.handleTrickle(msg.Trickle)
+		case msg.TrackPublished != "" && msg.TrackPublished == s.localTrack.ID():
+			s.negotiatePublisher()
+		case msg.Update != nil:
+			s.updateParticipants(msg.Update)
+		case msg.Leave:
+			s.Logger.Infow("Removed from LiveKit room")
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+			return
+		}
+	}
+}
+
+// runPing keeps the signal connection alive at the interval the server asked for.
+func (s *livekitStreamer) runPing() {
+	interval := time.Duration(s.join.PingInterval) * time.Second
+	if interval <= 0 {
+		interval = livekit_internal.DefaultPingInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.Ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.signal.Send(livekit_internal.PingRequest(now.UnixMilli())); err != nil {
+				s.Logger.Debugw("Failed to ping LiveKit", "error", err)
+			}
+		}
+	}
+}
+
+// handleOffer answers the server's offer for the subscriber connection.
+func (s *livekitStreamer) handleOffer(sdp string) {
+	if !s.setRemoteDescription(s.subscriber, livekit_internal.TargetSubscriber, pionwebrtc.SDPTypeOffer, sdp) {
+		return
+	}
+	answer, err := s.subscriber.CreateAnswer(nil)
+	if err != nil {
+		s.Logger.Errorw("Failed to create LiveKit subscriber answer", "error", err)
+		return
+	}
+	if err := s.subscriber.SetLocalDescription(answer); err != nil {
+		s.Logger.Errorw("Failed to set LiveKit subscriber answer", "error", err)
+		return
+	}
+	if err := s.signal.Send(livekit_internal.AnswerRequest(answer.SDP)); err != nil {
+		s.Logger.Errorw("Failed to send LiveKit subscriber answer", "error", err)
+	}
+}
+
+// negotiatePublisher offers the assistant's track once the server accepted it.
+func (s *livekitStreamer) negotiatePublisher() {
+	offer, err := s.publisher.CreateOffer(nil)
+	if err != nil {
+		s.Logger.Errorw("Failed to create LiveKit publisher offer", "error", err)
+		return
+	}
+	if err := s.publisher.SetLocalDescription(offer); err != nil {
+		s.Logger.Errorw("Failed to set LiveKit publisher offer", "error", err)
+		return
+	}
+	if err := s.signal.Send(livekit_internal.OfferRequest(offer.SDP)); err != nil {
+		s.Logger.Errorw("Failed to send LiveKit publisher offer", "error", err)
+	}
+}
+
+// setRemoteDescription applies a remote description and the candidates that
+// were waiting for it.
+func (s *livekitStreamer) setRemoteDescription(pc *pionwebrtc.PeerConnection, target livekit_internal.SignalTarget, sdpType pionwebrtc.SDPType, sdp string) bool {
+	if err := pc.SetRemoteDescription(pionwebrtc.SessionDescription{Type: sdpType, SDP: sdp}); err != nil {
+		s.Logger.Errorw("Failed to set LiveKit remote description", "type", sdpType, "error", err)
+		return false
+	}
+	s.Mu.Lock()
+	pending := s.pendingCandidates[target]
+	delete(s.pendingCandidates, target)
+	s.Mu.Unlock()
+	for _, candidate := range pending {
+		if err := pc.AddICECandidate(candidate); err != nil {
+			s.Logger.Debugw("Failed to add LiveKit ICE candidate", "error", err)
+		}
+	}
+	return true
+}
+
+func (s *livekitStreamer) handleTrickle(trickle *livekit_internal.Trickle) {
+	var candidate pionwebrtc.ICECandidateInit
+	if err := json.Unmarshal([]byte(trickle.CandidateInit), &candidate); err != nil {
+		s.Logger.Debugw("Ignoring malformed LiveKit ICE candidate", "error", err)
+		return
+	}
+	pc := s.publisher
+	if trickle.Target == livekit_internal.TargetSubscriber {
+		pc = s.subscriber
+	}
+	s.Mu.Lock()
+	if pc.RemoteDescription() == nil {
+		s.pendingCandidates[trickle.Target] = append(s.pendingCandidates[trickle.Target], candidate)
+		s.Mu.Unlock()
+		return
+	}
+	s.Mu.Unlock()
+	if err := pc.AddICECandidate(candidate); err != nil {
+		s.Logger.Debugw("Failed to add LiveKit ICE candidate", "error", err)
+	}
+}
+
+// updateParticipants subscribes to the audio of new participants and ends
+// the conversation when the last one has left.
+func (s *livekitStreamer) updateParticipants(participants []livekit_internal.ParticipantInfo) {
+	var tracks []string
+	s.Mu.Lock()
+	hadParticipants := len(s.participants) > 0
+	for _, p := range participants {
+		if p.Sid == s.join.Participant.Sid {
+			continue
+		}
+		if p.Disconnected {
+			delete(s.participants, p.Sid)
+			continue
+		}
+		s.participants[p.Sid] = true
+		for _, track := range p.Tracks {
+			if track.Type == livekit_internal.TrackTypeAudio && !s.subscribed[track.Sid] {
+				s.subscribed[track.Sid] = true
+				tracks = append(tracks, track.Sid)
+			}
+		}
+	}
+	empty := hadParticipants && len(s.participants) == 0
+	s.Mu.Unlock()
+
+	if len(tracks) > 0 {
+		if err := s.signal.Send(livekit_internal.SubscriptionRequest(tracks)); err != nil {
+			s.Logger.Errorw("Failed to subscribe to LiveKit audio", "tracks", tracks, "error", err)
+		}
+	}
+	if empty {
+		s.Logger.Infow("Last participant left the LiveKit room")
+		s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+	}
+}
+
+// handleData passes data messages of the room on as conversation metadata.
+func (s *livekitStreamer) handleData(packet []byte) {
+	data, err := livekit_internal.DecodeDataPacket(packet)
+	if err != nil {
+		s.Logger.Debugw("Ignoring malformed LiveKit data packet", "error", err)
+		return
+	}
+	if data == nil {
+		return
+	}
+	entries := data.Metadata()
+	metadata := make([]*protos.Metadata, 0, len(entries))
+	for _, key := range livekit_internal.SortedKeys(entries) {
+		metadata = append(metadata, &protos.Metadata{Key: key, Value: entries[key]})
+	}
+	s.PushInput(&protos.ConversationMetadata{Metadata: metadata})
+}
+
+// ============================================================================
+// Audio
+// ============================================================================
+
+// readRemoteAudio decodes a participant's track and queues it for the mixer.
+func (s *livekitStreamer) readRemoteAudio(track *pionwebrtc.TrackRemote) {
+	defer s.audioWg.Done()
+	defer s.mixer.Remove(track.ID())
+
+	if track.Codec().MimeType != pionwebrtc.MimeTypeOpus {
+		s.Logger.Errorw("Unsupported codec, only Opus is supported", "codec", track.Codec().MimeType)
+		return
+	}
+	// every track needs its own decoder state
+	opusDecoder, err := webrtc_internal.NewOpusCodec()
+	if err != nil {
+		s.Logger.Errorw("Failed to create Opus decoder", "error", err)
+		return
+	}
+
+	buf := make([]byte, webrtc_internal.RTPBufferSize)
+	consecutiveErrors := 0
+	for {
+		if s.Ctx.Err() != nil {
+			return
+		}
+		n, _, err := track.Read(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			consecutiveErrors++
+			if consecutiveErrors >= webrtc_internal.MaxConsecutiveErrors {
+				s.Logger.Errorw("Too many consecutive read errors, stopping audio reader", "lastError", err)
+				return
+			}
+			continue
+		}
+		consecutiveErrors = 0
+
+		pkt := &rtp.Packet{}
+		if err := pkt.Unmarshal(buf[:n]); err != nil || len(pkt.Payload) == 0 {
+			continue
+		}
+		pcm, err := opusDecoder.Decode(pkt.Payload)
+		if err != nil {
+			s.Logger.Debugw("Opus decode failed", "error", err, "payloadSize", len(pkt.Payload))
+			continue
+		}
+		resampled, err := s.resampler.Resample(pcm, internal_audio.WEBRTC_AUDIO_CONFIG, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG)
+		if err != nil {
+			s.Logger.Debugw("Audio resample failed", "error", err)
+			continue
+		}
+		s.mixer.Write(track.ID(), resampled)
+	}
+}
+
+// runMixer feeds the mixed audio of all participants to the conversation,
+// one frame every 20ms.
+func (s *livekitStreamer) runMixer() {
+	ticker := time.NewTicker(time.Duration(webrtc_internal.OpusFrameDuration) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.Ctx.Done():
+			return
+		case <-ticker.C:
+			if frame := s.mixer.Next(); frame != nil {
+				s.BufferAndSendInput(frame)
+			}
+		}
+	}
+}
+
+// runOutputWriter Opus-encodes the assistant's audio and writes it to the
+// published track at playback rate. Other output has no place in the room.
+func (s *livekitStreamer) runOutputWriter() {
+	ticker := time.NewTicker(time.Duration(webrtc_internal.OutputPaceInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	pacer := webrtc_internal.NewPacer(webrtc_internal.ActivePacing())
+	for {
+		select {
+		case <-s.Ctx.Done():
+			return
+		case <-s.FlushAudioCh:
+			pacer.Interrupt()
+		case <-ticker.C:
+			if pacer.Len() == 0 || !s.peerConnected.Load() {
+				continue
+			}
+			for _, frame := range pacer.Next(time.Now()) {
+				encoded, err := s.opusCodec.Encode(frame)
+				if err != nil {
+					s.Logger.Debugw("Opus encode failed", "error", err)
+					continue
+				}
+				if err := s.localTrack.WriteSample(media.Sample{
+					Data:     encoded,
+					Duration: webrtc_internal.OpusFrameDuration * time.Millisecond,
+				}); err != nil {
+					s.Logger.Debugw("Failed to write sample to track", "error", err)
+				}
+			}
+		case msg := <-s.OutputCh:
+			if m, ok := msg.(*protos.ConversationAssistantMessage); ok {
+				if audio, ok := m.Message.(*protos.ConversationAssistantMessage_Audio); ok {
+					if dropped := pacer.Push(audio.Audio); dropped > 0 {
+						s.Logger.Debugw("Output audio queue full, dropped the oldest frames", "frames", dropped)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Send takes the conversation's output. Audio is published to the room,
+// interruptions silence it and ending the conversation leaves the room.
+func (s *livekitStreamer) Send(response internal_type.Stream) error {
+	switch data := response.(type) {
+	case *protos.ConversationAssistantMessage:
+		if content, ok := data.Message.(*protos.ConversationAssistantMessage_Audio); ok {
+			audio48kHz, err := s.resampler.Resample(content.Audio, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG, internal_audio.WEBRTC_AUDIO_CONFIG)
+			if err != nil {
+				return err
+			}
+			s.BufferAndSendOutput(audio48kHz)
+		}
+	case *protos.ConversationInterruption:
+		if data.Type == protos.ConversationInterruption_INTERRUPTION_TYPE_WORD {
+			s.ClearOutputBuffer()
+		}
+	case *protos.ConversationDirective:
+		if data.GetType() == protos.ConversationDirective_END_CONVERSATION {
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_TOOL)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Lifecycle
+// ============================================================================
+
+// watchCallerContext leaves the room when the caller's context is cancelled,
+// and once the conversation has ended.
+func (s *livekitStreamer) watchCallerContext(callerCtx context.Context) {
+	select {
+	case <-callerCtx.Done():
+	case <-s.Ctx.Done():
+	}
+	s.Close()
+}
+
+// Close leaves the room and releases the peer connections. It is idempotent.
+func (s *livekitStreamer) Close() error {
+	s.closeOnce.Do(func() {
+		s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		s.signal.Close()
+		if s.publisher != nil {
+			s.publisher.Close()
+		}
+		if s.subscriber != nil {
+			s.subscriber.Close()
+		}
+		s.Cancel()
+		s.audioWg.Wait()
+	})
+	return nil
+}
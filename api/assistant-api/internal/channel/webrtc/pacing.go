@@ -0,0 +1,34 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package channel_webrtc
+
+import (
+	"time"
+
+	webrtc_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/internal"
+)
+
+// Pacing bounds the assistant's audio queued ahead of playback on web calls.
+type Pacing = webrtc_internal.Pacing
+
+// NewPacing returns the pacing that queues maxQueue of audio at most, 0 without
+// bound, keeps interruptWatermark of it playing on an interruption and sends
+// up to catchUpFactor frames per tick after missed ticks.
+func NewPacing(maxQueue, interruptWatermark time.Duration, catchUpFactor int) Pacing {
+	frame := webrtc_internal.OpusFrameDuration * time.Millisecond
+	return Pacing{
+		MaxQueueFrames:           int(maxQueue / frame),
+		CatchUpFactor:            catchUpFactor,
+		InterruptWatermarkFrames: int(interruptWatermark / frame),
+	}
+}
+
+// InstallPacing sets the output pacing of new WebRTC, LiveKit and WhatsApp
+// calls.
+func InstallPacing(p Pacing) {
+	webrtc_internal.InstallPacing(p)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package channel_webrtc
+
+import (
+	webrtc_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/internal"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// Relays select the TURN region web callers relay their media through.
+type Relays = webrtc_internal.Relays
+
+// TURNRegion is a TURN cluster of Relays.
+type TURNRegion = webrtc_internal.Region
+
+// TURNCredentials authenticate web callers on the TURN servers.
+type TURNCredentials = webrtc_internal.Credentials
+
+func NewRelays(logger commons.Logger, regions []TURNRegion, credentials TURNCredentials) *Relays {
+	return webrtc_internal.NewRelays(logger, regions, credentials)
+}
+
+// InstallRelays makes r pick the TURN region of new WebRTC sessions, nil
+// goes back to the default STUN servers.
+func InstallRelays(r *Relays) {
+	webrtc_internal.InstallRelays(r)
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,12 +23,17 @@ import (
 	"github.com/pion/webrtc/v4/pkg/media"
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
 	internal_audio_resampler "github.com/rapidaai/api/assistant-api/internal/audio/resampler"
-	channel_base "github.com/rapidaai/api/assistant-api/internal/channel/base"
+	internal_callquality "github.com/rapidaai/api/assistant-api/internal/callquality"
 	webrtc_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/internal"
+	internal_echo_cancellation "github.com/rapidaai/api/assistant-api/internal/echo_cancellation"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/streamers"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // ============================================================================
@@ -38,11 +44,11 @@ import (
 // with gRPC bidirectional stream for signaling instead of WebSocket.
 // Audio flows through WebRTC media tracks; gRPC is used for signaling.
 //
-// It embeds baseStreamer which manages input/output channels, audio buffers,
-// and common lifecycle helpers. webrtcStreamer focuses only on WebRTC-specific
+// It embeds streamers.BaseStreamer which manages input/output channels,
+// audio buffers, and common lifecycle helpers. webrtcStreamer focuses only on WebRTC-specific
 // logic: peer connections, Opus encoding, gRPC dispatch, and signaling.
 type webrtcStreamer struct {
-	channel_base.BaseStreamer // channels, buffers, PushInput/PushOutput, Recv, Context
+	streamers.BaseStreamer // channels, buffers, PushInput/PushOutput, Recv, Context
 
 	// WebRTC-specific components
 	config     *webrtc_internal.Config
@@ -70,6 +76,14 @@ type webrtcStreamer struct {
 	// SRTP session is established. Uses atomic for lock-free access from
 	// runOutputWriter's hot loop.
 	peerConnected atomic.Bool
+
+	// echo removes the played assistant audio from the caller audio, nil
+	// unless the deployment enabled echo cancellation.
+	echo atomic.Pointer[internal_echo_cancellation.Canceller]
+
+	// opus receives the caller's packets undecoded once the speech to text
+	// transformer takes Opus, nil otherwise.
+	opus atomic.Pointer[func(internal_type.UserOpusPacket)]
 }
 
 // NewWebRTCStreamer creates a new WebRTC streamer with gRPC signaling.
@@ -92,12 +106,14 @@ func NewWebRTCStreamer(
 	}
 
 	s := &webrtcStreamer{
-		BaseStreamer: channel_base.NewBaseStreamer(logger,
-			channel_base.WithInputChannelSize(webrtc_internal.InputChannelSize),
-			channel_base.WithOutputChannelSize(webrtc_internal.OutputChannelSize),
-			channel_base.WithInputBufferThreshold(webrtc_internal.InputBufferThreshold),
-			channel_base.WithOutputBufferThreshold(webrtc_internal.OutputBufferThreshold),
-			channel_base.WithOutputFrameSize(webrtc_internal.OpusFrameBytes),
+		BaseStreamer: streamers.NewBaseStreamer(logger,
+			streamers.WithInputChannelSize(webrtc_internal.InputChannelSize),
+			streamers.WithOutputChannelSize(webrtc_internal.OutputChannelSize),
+			streamers.WithInputBufferThreshold(webrtc_internal.InputBufferThreshold),
+			streamers.WithOutputBufferThreshold(webrtc_internal.OutputBufferThreshold),
+			streamers.WithOutputFrameSize(webrtc_internal.OpusFrameBytes),
+			streamers.WithInputBackpressure(streamers.Backpressure{Policy: streamers.DropOldest}),
+			streamers.WithOutputBackpressure(streamers.Backpressure{Policy: streamers.BlockWithTimeout}),
 		),
 		config:      webrtc_internal.DefaultConfig(),
 		grpcStream:  grpcStream,
@@ -108,9 +124,17 @@ func NewWebRTCStreamer(
 		// peerConnected zero-value is false — correct: not connected yet
 	}
 
+	// With TURN regions configured the caller relays through the one nearest
+	// to where the client says it is.
+	if relays := webrtc_internal.ActiveRelays(); relays != nil {
+		now := time.Now()
+		client := types.GetClientInfoFromGrpcContext(grpcStream.Context())
+		s.config = relays.Config(webrtc_internal.ClientLocation(client, now), s.sessionID, now)
+	}
+
 	// Start background loops
-	go s.runGrpcReader()   // inputCh feeder
-	go s.runOutputWriter() // outputCh consumer
+	go s.runGrpcReader()   // InputCh feeder
+	go s.runOutputWriter() // OutputCh consumer
 
 	// Watch the caller's context so a cancelled parent triggers graceful close
 	// rather than an abrupt context cancellation mid-cleanup.
@@ -218,7 +242,7 @@ func (s *webrtcStreamer) setupPeerEventHandlers() {
 		s.Logger.Infow("WebRTC connection state changed", "state", state, "session", s.sessionID)
 
 		// Update mode under lock, then release before any channel operations
-		// to avoid holding mu while pushing to outputCh.
+		// to avoid holding mu while pushing to OutputCh.
 		s.Mu.Lock()
 		switch state {
 		case pionwebrtc.PeerConnectionStateConnected:
@@ -236,6 +260,7 @@ func (s *webrtcStreamer) setupPeerEventHandlers() {
 			// Unblock runOutputWriter so buffered greeting audio can drain.
 			s.peerConnected.Store(true)
 			s.sendReady()
+			s.pushCandidateMetadata()
 
 		case pionwebrtc.PeerConnectionStateFailed:
 			// Connection failed irrecoverably — tear down and notify downstream.
@@ -349,6 +374,10 @@ func (s *webrtcStreamer) readRemoteAudio(track *pionwebrtc.TrackRemote) {
 		if len(pkt.Payload) == 0 {
 			continue
 		}
+		if sink := s.opus.Load(); sink != nil {
+			// buf is read into again, the payload must outlive it
+			(*sink)(internal_type.UserOpusPacket{Payload: slices.Clone(pkt.Payload), Timestamp: pkt.Timestamp})
+		}
 
 		// Decode Opus to PCM (48kHz)
 		pcm, err := opusDecoder.Decode(pkt.Payload)
@@ -363,6 +392,10 @@ func (s *webrtcStreamer) readRemoteAudio(track *pionwebrtc.TrackRemote) {
 			continue
 		}
 
+		if echo := s.echo.Load(); echo != nil {
+			resampled = echo.Cancel(resampled)
+		}
+
 		// Buffer and flush to channel when threshold is reached
 		s.BufferAndSendInput(resampled)
 	}
@@ -370,14 +403,14 @@ func (s *webrtcStreamer) readRemoteAudio(track *pionwebrtc.TrackRemote) {
 
 // runOutputWriter is the single output loop:
 //
-//	outputCh -> loop (process) -> upstream service
+//	OutputCh -> loop (process) -> upstream service
 //
-// All outbound messages flow through outputCh to preserve ordering.
+// All outbound messages flow through OutputCh to preserve ordering.
 // Raw proto types and pre-built *WebTalkResponse (signaling) are accepted.
 // The writer wraps raw types into WebTalkResponse before sending to gRPC.
 //
 //   - ConversationAssistantMessage_Audio → queue raw PCM → Opus-encode → WebRTC track
-//     (paced at 20ms real-time intervals to smooth TTS bursts)
+//     (paced at 20ms real-time intervals to smooth TTS bursts, see Pacing)
 //   - *protos.WebTalkResponse (signaling) → send directly to gRPC
 //   - All other raw types → wrap in WebTalkResponse → send to gRPC
 //
@@ -386,8 +419,8 @@ func (s *webrtcStreamer) runOutputWriter() {
 	ticker := time.NewTicker(time.Duration(webrtc_internal.OutputPaceInterval) * time.Millisecond)
 	defer ticker.Stop()
 
-	// pendingAudio holds raw 20ms PCM frames waiting for the next tick.
-	var pendingAudio [][]byte
+	// pacer holds raw 20ms PCM frames waiting for their tick.
+	pacer := webrtc_internal.NewPacer(webrtc_internal.ActivePacing())
 
 	for {
 		select {
@@ -395,29 +428,35 @@ func (s *webrtcStreamer) runOutputWriter() {
 			return
 
 		case <-s.FlushAudioCh:
-			// Interruption: discard all queued audio immediately.
-			pendingAudio = pendingAudio[:0]
+			// Interruption: discard the queued audio, all of it unless the
+			// pacing keeps a few frames playing.
+			pacer.Interrupt()
 
 		case <-ticker.C:
-			// Encode and send one paced audio frame per tick (20ms real-time).
-			// Only write when the peer connection is established — before that,
-			// Pion silently drops WriteSample (no SRTP session). Frames stay
-			// buffered in pendingAudio and drain once connected.
-			if len(pendingAudio) > 0 && s.peerConnected.Load() {
-				encoded, err := s.opusCodec.Encode(pendingAudio[0])
-				if err != nil {
-					s.Logger.Debugw("Opus encode failed", "error", err)
-				} else {
+			// Encode and send the paced audio frames of this tick, one per
+			// tick (20ms real-time) unless catching up. Only write when the
+			// peer connection is established — before that, Pion silently
+			// drops WriteSample (no SRTP session). Frames stay queued in the
+			// pacer and drain once connected.
+			if pacer.Len() > 0 && s.peerConnected.Load() {
+				for _, frame := range pacer.Next(time.Now()) {
+					encoded, err := s.opusCodec.Encode(frame)
+					if err != nil {
+						s.Logger.Debugw("Opus encode failed", "error", err)
+						continue
+					}
 					s.writeAudioFrame(encoded)
+					s.playedForEcho(frame)
 				}
-				pendingAudio = pendingAudio[1:]
 			}
 
 		case msg := <-s.OutputCh:
 			// Assistant audio → queue raw PCM for paced Opus encoding.
 			if m, ok := msg.(*protos.ConversationAssistantMessage); ok {
 				if audio, ok := m.Message.(*protos.ConversationAssistantMessage_Audio); ok {
-					pendingAudio = append(pendingAudio, audio.Audio)
+					if dropped := pacer.Push(audio.Audio); dropped > 0 {
+						s.Logger.Debugw("Output audio queue full, dropped the oldest frames", "frames", dropped)
+					}
 					continue
 				}
 			}
@@ -490,7 +529,7 @@ func (s *webrtcStreamer) writeAudioFrame(data []byte) {
 // Signaling helpers
 // ============================================================================
 
-// sendConfig sends WebRTC configuration (ICE servers, codec info) to client via outputCh.
+// sendConfig sends WebRTC configuration (ICE servers, codec info) to client via OutputCh.
 func (s *webrtcStreamer) sendConfig() {
 	iceServers := make([]*protos.ICEServer, len(s.config.ICEServers))
 	for i, srv := range s.config.ICEServers {
@@ -514,7 +553,7 @@ func (s *webrtcStreamer) sendConfig() {
 	)
 }
 
-// sendOffer sends SDP offer to client via outputCh.
+// sendOffer sends SDP offer to client via OutputCh.
 func (s *webrtcStreamer) sendOffer(sdp string) {
 	s.PushOutput(&protos.ServerSignaling{
 		SessionId: s.sessionID,
@@ -527,7 +566,7 @@ func (s *webrtcStreamer) sendOffer(sdp string) {
 	})
 }
 
-// sendICECandidate sends ICE candidate to client via outputCh.
+// sendICECandidate sends ICE candidate to client via OutputCh.
 func (s *webrtcStreamer) sendICECandidate(ice *webrtc_internal.ICECandidate) {
 	s.PushOutput(&protos.ServerSignaling{
 		SessionId: s.sessionID,
@@ -542,7 +581,7 @@ func (s *webrtcStreamer) sendICECandidate(ice *webrtc_internal.ICECandidate) {
 	})
 }
 
-// sendReady sends ready signal to client via outputCh.
+// sendReady sends ready signal to client via OutputCh.
 func (s *webrtcStreamer) sendReady() {
 	s.PushOutput(&protos.ServerSignaling{
 		SessionId: s.sessionID,
@@ -550,7 +589,7 @@ func (s *webrtcStreamer) sendReady() {
 	})
 }
 
-// sendClear sends clear/interrupt signal to client via outputCh.
+// sendClear sends clear/interrupt signal to client via OutputCh.
 func (s *webrtcStreamer) sendClear() {
 	s.PushOutput(&protos.ServerSignaling{
 		SessionId: s.sessionID,
@@ -559,7 +598,7 @@ func (s *webrtcStreamer) sendClear() {
 }
 
 // runGrpcReader reads from the gRPC stream in a loop and pushes
-// non-signaling messages into inputCh. Signaling is handled internally.
+// non-signaling messages into InputCh. Signaling is handled internally.
 // Runs until the gRPC stream closes or the context is cancelled.
 func (s *webrtcStreamer) runGrpcReader() {
 	for {
@@ -571,6 +610,7 @@ func (s *webrtcStreamer) runGrpcReader() {
 		switch msg.GetRequest().(type) {
 		case *protos.WebTalkRequest_Initialization:
 			s.PushInput(msg.GetInitialization())
+			s.pushClientMetadata()
 			s.handleConfigurationMessage(msg.GetInitialization().GetStreamMode())
 		case *protos.WebTalkRequest_Configuration:
 			s.PushInput(msg.GetConfiguration())
@@ -670,6 +710,9 @@ func (s *webrtcStreamer) resetAudioSession() {
 	s.localTrack = nil
 	s.currentMode = protos.StreamMode_STREAM_MODE_TEXT
 	s.peerConnected.Store(false)
+	if echo := s.echo.Load(); echo != nil {
+		echo.Reset()
+	}
 }
 
 // setupAudioAndHandshake tears down any stale peer connection, creates a fresh
@@ -691,7 +734,7 @@ func (s *webrtcStreamer) setupAudioAndHandshake() error {
 	return s.initiateWebRTCHandshake()
 }
 
-// initiateWebRTCHandshake sends config and creates/sends SDP offer via outputCh.
+// initiateWebRTCHandshake sends config and creates/sends SDP offer via OutputCh.
 func (s *webrtcStreamer) initiateWebRTCHandshake() error {
 	s.sendConfig()
 
@@ -723,8 +766,8 @@ func (s *webrtcStreamer) createAndSetLocalOffer() (*pionwebrtc.SessionDescriptio
 // ============================================================================
 
 // Send pushes output to the client via the unified output channel.
-// All messages (audio and non-audio) flow through outputCh to preserve ordering.
-// send (non-blocking) -> outputCh -> loop (runOutputWriter) -> upstream service
+// All messages (audio and non-audio) flow through OutputCh to preserve ordering.
+// send (non-blocking) -> OutputCh -> loop (runOutputWriter) -> upstream service
 func (s *webrtcStreamer) Send(response internal_type.Stream) error {
 	switch data := response.(type) {
 	case *protos.ConversationAssistantMessage:
@@ -768,6 +811,131 @@ func (s *webrtcStreamer) Send(response internal_type.Stream) error {
 	return nil
 }
 
+// CallQuality reports the inbound audio statistics of the peer connection and
+// the round trip time of the nominated candidate pair for MOS scoring.
+func (s *webrtcStreamer) CallQuality() (internal_callquality.Sample, bool) {
+	s.Mu.Lock()
+	pc := s.pc
+	s.Mu.Unlock()
+	if pc == nil || !s.peerConnected.Load() {
+		return internal_callquality.Sample{}, false
+	}
+
+	var sample internal_callquality.Sample
+	found := false
+	for _, stats := range pc.GetStats() {
+		switch st := stats.(type) {
+		case pionwebrtc.InboundRTPStreamStats:
+			if st.Kind != "audio" {
+				continue
+			}
+			found = true
+			sample.PacketsReceived += uint64(st.PacketsReceived)
+			sample.PacketsLost += int64(st.PacketsLost)
+			sample.Jitter = max(sample.Jitter, time.Duration(st.Jitter*float64(time.Second)))
+		case pionwebrtc.ICECandidatePairStats:
+			if st.Nominated {
+				sample.RoundTripTime = time.Duration(st.CurrentRoundTripTime * float64(time.Second))
+			}
+		}
+	}
+	return sample, found
+}
+
+// EnableEchoCancellation cancels the echo of the audio played to the caller
+// from the audio received, for browsers and devices that play the assistant
+// on a speaker without their own echo cancellation.
+func (s *webrtcStreamer) EnableEchoCancellation(cfg internal_echo_cancellation.Config) {
+	s.echo.Store(internal_echo_cancellation.NewCanceller(cfg))
+}
+
+// Resumable reports that a WebTalk client reconnecting after a page refresh
+// may resume its session with the token it was handed.
+func (s *webrtcStreamer) Resumable() bool {
+	return true
+}
+
+// EnableOpusPassthrough hands the caller's Opus packets to sink as they are
+// read from the track. The packets are still decoded for VAD and recording,
+// the speech to text transformer is spared the resampled linear16.
+func (s *webrtcStreamer) EnableOpusPassthrough(sink func(internal_type.UserOpusPacket)) {
+	s.opus.Store(&sink)
+}
+
+// playedForEcho hands a frame written to the track to the echo canceller at
+// the rate the caller audio is cancelled at.
+func (s *webrtcStreamer) playedForEcho(frame []byte) {
+	echo := s.echo.Load()
+	if echo == nil {
+		return
+	}
+	played, err := s.resampler.Resample(frame, internal_audio.WEBRTC_AUDIO_CONFIG, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG)
+	if err != nil {
+		s.Logger.Debugw("Echo reference resample failed", "error", err)
+		return
+	}
+	echo.Played(played)
+}
+
+// pushClientMetadata records the browser the session was signalled from. The
+// SDK sends its user agent as x-user-agent, other gRPC clients only carry
+// their own user-agent header.
+func (s *webrtcStreamer) pushClientMetadata() {
+	ctx := s.grpcStream.Context()
+	fields := map[string]string{}
+	if client := types.GetClientInfoFromGrpcContext(ctx); client != nil {
+		fields["user_agent"] = client.UserAgent
+		fields["platform"] = client.Platform
+		fields["connection_type"] = client.ConnectionEffectiveType
+	}
+	if s.config.Region != "" {
+		fields["turn_region"] = s.config.Region
+	}
+	if fields["user_agent"] == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, key := range []string{utils.HEADER_USER_AGENT, "user-agent"} {
+				if values := md.Get(key); len(values) > 0 && values[0] != "" {
+					fields["user_agent"] = values[0]
+					break
+				}
+			}
+		}
+	}
+	s.PushTransportMetadata("webrtc", fields)
+}
+
+// pushCandidateMetadata records the ICE candidate pair the peer connection
+// settled on. A relay candidate means media goes through TURN.
+func (s *webrtcStreamer) pushCandidateMetadata() {
+	s.Mu.Lock()
+	pc := s.pc
+	s.Mu.Unlock()
+	if pc == nil {
+		return
+	}
+	for _, sender := range pc.GetSenders() {
+		if dtls := sender.Transport(); dtls != nil {
+			s.pushCandidatePair(dtls.ICETransport())
+			return
+		}
+	}
+}
+
+func (s *webrtcStreamer) pushCandidatePair(ice *pionwebrtc.ICETransport) {
+	if ice == nil {
+		return
+	}
+	pair, err := ice.GetSelectedCandidatePair()
+	if err != nil || pair == nil || pair.Local == nil || pair.Remote == nil {
+		return
+	}
+	s.PushTransportMetadata("webrtc", map[string]string{
+		"local_candidate":  pair.Local.Typ.String(),
+		"remote_candidate": pair.Remote.Typ.String(),
+		"protocol":         pair.Local.Protocol.String(),
+	})
+}
+
 // ============================================================================
 // Lifecycle
 // ============================================================================
@@ -790,7 +958,7 @@ func (s *webrtcStreamer) watchCallerContext(callerCtx context.Context) {
 // been called (e.g. from runGrpcReader or a client disconnect signal), the
 // duplicate push is a no-op.
 func (s *webrtcStreamer) Close() error {
-	// Push disconnection signal into inputCh so the Talk loop exits cleanly.
+	// Push disconnection signal into InputCh so the Talk loop exits cleanly.
 	// PushDisconnection is idempotent (checks+sets s.Closed under lock).
 	s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
 
@@ -807,7 +975,7 @@ func (s *webrtcStreamer) Close() error {
 	s.Mu.Unlock()
 
 	// Cancel the streamer-wide context last so that Recv() can still
-	// drain inputCh before the context fires.
+	// drain InputCh before the context fires.
 	s.Cancel()
 	return nil
 }
@@ -0,0 +1,90 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package whatsapp_internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client takes actions on the calls of a business phone number through
+// the calls endpoint of the Cloud API.
+type Client struct {
+	GraphURL      string
+	PhoneNumberID string
+	AccessToken   string
+	HTTPClient    *http.Client
+}
+
+// NewClient returns a client for the calls to phoneNumberID.
+func NewClient(accessToken, phoneNumberID string) *Client {
+	return &Client{
+		GraphURL:      DefaultGraphURL,
+		PhoneNumberID: phoneNumberID,
+		AccessToken:   accessToken,
+		HTTPClient:    &http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// PreAccept sends the answer ahead of accepting the call, so media can
+// connect while the call still rings.
+func (c *Client) PreAccept(ctx context.Context, callID, sdp string) error {
+	return c.Do(ctx, CallAction{CallID: callID, Action: ActionPreAccept, Session: &Session{SDPType: SDPTypeAnswer, SDP: sdp}})
+}
+
+// Accept answers the call with sdp.
+func (c *Client) Accept(ctx context.Context, callID, sdp string) error {
+	return c.Do(ctx, CallAction{CallID: callID, Action: ActionAccept, Session: &Session{SDPType: SDPTypeAnswer, SDP: sdp}})
+}
+
+// Reject declines a call that was not accepted.
+func (c *Client) Reject(ctx context.Context, callID string) error {
+	return c.Do(ctx, CallAction{CallID: callID, Action: ActionReject})
+}
+
+// Terminate hangs up an accepted call.
+func (c *Client) Terminate(ctx context.Context, callID string) error {
+	return c.Do(ctx, CallAction{CallID: callID, Action: ActionTerminate})
+}
+
+// Do posts action to the calls endpoint.
+func (c *Client) Do(ctx context.Context, action CallAction) error {
+	action.MessagingProduct = "whatsapp"
+	body, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/%s/calls", strings.TrimSuffix(c.GraphURL, "/"), c.PhoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("whatsapp %s of call %s failed: %w", action.Action, action.CallID, err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= http.StatusBadRequest {
+		var graphErr struct {
+			Error *GraphError `json:"error"`
+		}
+		if json.Unmarshal(respBody, &graphErr) == nil && graphErr.Error != nil {
+			return fmt.Errorf("whatsapp %s of call %s failed: %w", action.Action, action.CallID, graphErr.Error)
+		}
+		return fmt.Errorf("whatsapp %s of call %s failed with status %d", action.Action, action.CallID, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package whatsapp_internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("EAAG", "1234567890")
+	client.GraphURL = server.URL + "/v23.0/"
+	return client
+}
+
+func TestClient_Accept(t *testing.T) {
+	var got CallAction
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v23.0/1234567890/calls", r.URL.Path)
+		assert.Equal(t, "Bearer EAAG", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Write([]byte(`{"messaging_product":"whatsapp","success":true}`))
+	})
+
+	require.NoError(t, client.Accept(context.Background(), "wacid.1", "v=0"))
+	assert.Equal(t, CallAction{
+		MessagingProduct: "whatsapp",
+		CallID:           "wacid.1",
+		Action:           ActionAccept,
+		Session:          &Session{SDPType: SDPTypeAnswer, SDP: "v=0"},
+	}, got)
+}
+
+func TestClient_Terminate(t *testing.T) {
+	var got map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	require.NoError(t, client.Terminate(context.Background(), "wacid.1"))
+	assert.Equal(t, "terminate", got["action"])
+	assert.NotContains(t, got, "session")
+}
+
+func TestClient_GraphError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Call not found","type":"OAuthException","code":138000,"fbtrace_id":"A1"}}`))
+	})
+
+	err := client.PreAccept(context.Background(), "wacid.1", "v=0")
+	var graphErr *GraphError
+	require.ErrorAs(t, err, &graphErr)
+	assert.Equal(t, 138000, graphErr.Code)
+	assert.ErrorContains(t, err, "pre_accept of call wacid.1")
+
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	assert.ErrorContains(t, client.Reject(context.Background(), "wacid.1"), "status 502")
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package whatsapp_internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/rapidaai/protos"
+)
+
+// Credential is the WhatsApp vault credential: a system user access token
+// of the business, and the secret and verify token of the Meta app the
+// webhook is subscribed with.
+type Credential struct {
+	AccessToken string
+	AppSecret   string
+	VerifyToken string
+}
+
+// NewCredential reads the access_token, app_secret and verify_token of a
+// whatsapp vault credential.
+func NewCredential(vaultCredential *protos.VaultCredential) (*Credential, error) {
+	if vaultCredential == nil || vaultCredential.GetValue() == nil {
+		return nil, errors.New("whatsapp credential needs access_token, app_secret and verify_token")
+	}
+	values := vaultCredential.GetValue().AsMap()
+	cred := &Credential{}
+	cred.AccessToken, _ = values["access_token"].(string)
+	cred.AppSecret, _ = values["app_secret"].(string)
+	cred.VerifyToken, _ = values["verify_token"].(string)
+	if cred.AccessToken == "" || cred.AppSecret == "" || cred.VerifyToken == "" {
+		return nil, errors.New("whatsapp credential needs access_token, app_secret and verify_token")
+	}
+	return cred, nil
+}
+
+// VerifyWebhook checks the sha256=<hex> signature Meta computes over the
+// body with the app secret.
+func (c *Credential) VerifyWebhook(signature string, body []byte) error {
+	sum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return errors.New("missing webhook signature")
+	}
+	expected, err := hex.DecodeString(sum)
+	if err != nil {
+		return errors.New("malformed webhook signature")
+	}
+	mac := hmac.New(sha256.New, []byte(c.AppSecret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.New("webhook signature mismatch")
+	}
+	return nil
+}
+
+// VerifySubscription reports whether a subscription request names the
+// verify token of the app.
+func (c *Credential) VerifySubscription(mode, token string) bool {
+	return mode == ModeSubscribe && subtle.ConstantTimeCompare([]byte(token), []byte(c.VerifyToken)) == 1
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package whatsapp_internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNewCredential(t *testing.T) {
+	_, err := NewCredential(&protos.VaultCredential{})
+	assert.ErrorContains(t, err, "access_token")
+
+	value, err := structpb.NewStruct(map[string]interface{}{"access_token": "EAAG", "app_secret": "secret"})
+	require.NoError(t, err)
+	_, err = NewCredential(&protos.VaultCredential{Value: value})
+	assert.ErrorContains(t, err, "verify_token")
+
+	value, err = structpb.NewStruct(map[string]interface{}{"access_token": "EAAG", "app_secret": "secret", "verify_token": "verify"})
+	require.NoError(t, err)
+	cred, err := NewCredential(&protos.VaultCredential{Value: value})
+	require.NoError(t, err)
+	assert.Equal(t, &Credential{AccessToken: "EAAG", AppSecret: "secret", VerifyToken: "verify"}, cred)
+}
+
+func TestCredential_VerifyWebhook(t *testing.T) {
+	cred := &Credential{AppSecret: "secret"}
+	body := []byte(`{"object":"whatsapp_business_account"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.NoError(t, cred.VerifyWebhook(signature, body))
+	assert.ErrorContains(t, cred.VerifyWebhook(signature, []byte(`{}`)), "mismatch")
+	assert.ErrorContains(t, cred.VerifyWebhook("", body), "missing")
+	assert.ErrorContains(t, cred.VerifyWebhook("sha256=zz", body), "malformed")
+}
+
+func TestCredential_VerifySubscription(t *testing.T) {
+	cred := &Credential{VerifyToken: "verify"}
+	assert.True(t, cred.VerifySubscription("subscribe", "verify"))
+	assert.False(t, cred.VerifySubscription("subscribe", "other"))
+	assert.False(t, cred.VerifySubscription("unsubscribe", "verify"))
+}
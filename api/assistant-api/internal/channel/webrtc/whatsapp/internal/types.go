@@ -0,0 +1,171 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package whatsapp_internal
+
+import "time"
+
+// Keys of the call context media a WhatsApp call is answered with.
+const (
+	MediaCallID        = "call_id"
+	MediaPhoneNumberID = "phone_number_id"
+	MediaSDPOffer      = "sdp_offer"
+)
+
+// Webhook headers and verification parameters.
+const (
+	HeaderSignature = "X-Hub-Signature-256"
+
+	QueryMode        = "hub.mode"
+	QueryVerifyToken = "hub.verify_token"
+	QueryChallenge   = "hub.challenge"
+	ModeSubscribe    = "subscribe"
+)
+
+// Call events of the calls webhook field.
+const (
+	FieldCalls = "calls"
+
+	EventConnect   = "connect"
+	EventTerminate = "terminate"
+
+	SDPTypeOffer  = "offer"
+	SDPTypeAnswer = "answer"
+
+	DirectionUserInitiated = "USER_INITIATED"
+)
+
+// Actions of the calls endpoint of the Cloud API.
+const (
+	ActionPreAccept = "pre_accept"
+	ActionAccept    = "accept"
+	ActionReject    = "reject"
+	ActionTerminate = "terminate"
+)
+
+const (
+	// DefaultGraphURL is the Graph API the calls endpoint is on.
+	DefaultGraphURL = "https://graph.facebook.com/v23.0"
+
+	// RequestTimeout bounds a call action.
+	RequestTimeout = 10 * time.Second
+
+	// GatherTimeout bounds the ICE gathering of the answer. The Cloud API
+	// takes no trickled candidates, they all go in the answer.
+	GatherTimeout = 5 * time.Second
+)
+
+// WebhookEvent is a notification of the WhatsApp Business Account webhook.
+type WebhookEvent struct {
+	Object string  `json:"object"`
+	Entry  []Entry `json:"entry"`
+}
+
+type Entry struct {
+	ID      string   `json:"id"`
+	Changes []Change `json:"changes"`
+}
+
+type Change struct {
+	Field string      `json:"field"`
+	Value ChangeValue `json:"value"`
+}
+
+type ChangeValue struct {
+	MessagingProduct string    `json:"messaging_product"`
+	Metadata         Metadata  `json:"metadata"`
+	Contacts         []Contact `json:"contacts,omitempty"`
+	Calls            []Call    `json:"calls,omitempty"`
+}
+
+type Metadata struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	PhoneNumberID      string `json:"phone_number_id"`
+}
+
+type Contact struct {
+	WaID    string `json:"wa_id"`
+	Profile struct {
+		Name string `json:"name"`
+	} `json:"profile"`
+}
+
+// Call is a call event. Connect events of calls users place carry the
+// SDP offer; terminate events the outcome of the call.
+type Call struct {
+	ID        string   `json:"id"`
+	To        string   `json:"to"`
+	From      string   `json:"from"`
+	Event     string   `json:"event"`
+	Timestamp string   `json:"timestamp"`
+	Direction string   `json:"direction,omitempty"`
+	Session   *Session `json:"session,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Duration  int      `json:"duration,omitempty"`
+}
+
+type Session struct {
+	SDPType string `json:"sdp_type"`
+	SDP     string `json:"sdp"`
+}
+
+// IncomingCall is a connect event of a user initiated call, with the
+// number it was placed to.
+type IncomingCall struct {
+	Call
+	PhoneNumberID string
+	CallerName    string
+}
+
+// Offers reports whether the call brings an offer to answer.
+func (c *Call) Offers() bool {
+	return c.Event == EventConnect && c.Session != nil && c.Session.SDPType == SDPTypeOffer && c.Session.SDP != ""
+}
+
+// Calls returns the call events of the notification, with the business
+// number each was placed to.
+func (e *WebhookEvent) Calls() []IncomingCall {
+	var calls []IncomingCall
+	for _, entry := range e.Entry {
+		for _, change := range entry.Changes {
+			if change.Field != FieldCalls {
+				continue
+			}
+			names := make(map[string]string, len(change.Value.Contacts))
+			for _, contact := range change.Value.Contacts {
+				names[contact.WaID] = contact.Profile.Name
+			}
+			for _, call := range change.Value.Calls {
+				calls = append(calls, IncomingCall{
+					Call:          call,
+					PhoneNumberID: change.Value.Metadata.PhoneNumberID,
+					CallerName:    names[call.From],
+				})
+			}
+		}
+	}
+	return calls
+}
+
+// CallAction is the body of a request to the calls endpoint.
+type CallAction struct {
+	MessagingProduct string   `json:"messaging_product"`
+	CallID           string   `json:"call_id"`
+	Action           string   `json:"action"`
+	Session          *Session `json:"session,omitempty"`
+}
+
+// GraphError is the error the Graph API responds with.
+type GraphError struct {
+	Message   string `json:"message"`
+	Type      string `json:"type"`
+	Code      int    `json:"code"`
+	FBTraceID string `json:"fbtrace_id"`
+}
+
+func (e *GraphError) Error() string {
+	return e.Message
+}
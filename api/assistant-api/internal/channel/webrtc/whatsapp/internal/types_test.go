@@ -0,0 +1,64 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package whatsapp_internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const connectAndTerminate = `{
+  "object": "whatsapp_business_account",
+  "entry": [{
+    "id": "WABA",
+    "changes": [{
+      "field": "calls",
+      "value": {
+        "messaging_product": "whatsapp",
+        "metadata": {"display_phone_number": "16315553601", "phone_number_id": "1234567890"},
+        "contacts": [{"profile": {"name": "Pablo"}, "wa_id": "16315553602"}],
+        "calls": [
+          {"id": "wacid.1", "to": "16315553601", "from": "16315553602", "event": "connect", "timestamp": "1671644824",
+           "direction": "USER_INITIATED", "session": {"sdp_type": "offer", "sdp": "v=0"}},
+          {"id": "wacid.0", "to": "16315553601", "from": "16315553603", "event": "terminate", "timestamp": "1671644820",
+           "direction": "USER_INITIATED", "status": "COMPLETED", "duration": 42}
+        ]
+      }
+    }, {
+      "field": "messages",
+      "value": {"messaging_product": "whatsapp", "metadata": {"phone_number_id": "1234567890"}}
+    }]
+  }]
+}`
+
+func TestWebhookEvent_Calls(t *testing.T) {
+	var event WebhookEvent
+	require.NoError(t, json.Unmarshal([]byte(connectAndTerminate), &event))
+
+	calls := event.Calls()
+	require.Len(t, calls, 2)
+
+	assert.True(t, calls[0].Offers())
+	assert.Equal(t, "wacid.1", calls[0].ID)
+	assert.Equal(t, "1234567890", calls[0].PhoneNumberID)
+	assert.Equal(t, "Pablo", calls[0].CallerName)
+	assert.Equal(t, "v=0", calls[0].Session.SDP)
+
+	assert.False(t, calls[1].Offers())
+	assert.Equal(t, EventTerminate, calls[1].Event)
+	assert.Equal(t, 42, calls[1].Duration)
+	assert.Empty(t, calls[1].CallerName)
+}
+
+func TestCall_Offers(t *testing.T) {
+	assert.False(t, (&Call{Event: EventConnect}).Offers())
+	assert.False(t, (&Call{Event: EventConnect, Session: &Session{SDPType: SDPTypeAnswer, SDP: "v=0"}}).Offers())
+	assert.False(t, (&Call{Event: EventTerminate, Session: &Session{SDPType: SDPTypeOffer, SDP: "v=0"}}).Offers())
+}
@@ -0,0 +1,440 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package channel_whatsapp answers WhatsApp voice calls placed to a business
+// number. Meta delivers the SDP offer of a call in the calls webhook; the
+// streamer answers it with a Pion peer connection through the Cloud API and
+// exchanges Opus audio with the caller over WebRTC.
+package channel_whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	pionwebrtc "github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media"
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	internal_audio_resampler "github.com/rapidaai/api/assistant-api/internal/audio/resampler"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	webrtc_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/internal"
+	whatsapp_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/whatsapp/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/streamers"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// activeCalls holds the streamers of the calls answered by this instance,
+// by call id, so a terminate webhook can end the conversation right away.
+// Webhooks reaching another instance leave it to the peer connection.
+var activeCalls sync.Map
+
+// hangup ends the conversation of callID if this instance answers it.
+func hangup(callID string) bool {
+	s, ok := activeCalls.Load(callID)
+	if ok {
+		s.(*whatsappStreamer).hungUp()
+	}
+	return ok
+}
+
+type whatsappStreamer struct {
+	streamers.BaseStreamer
+
+	cc       *callcontext.CallContext
+	client   *whatsapp_internal.Client
+	callID   string
+	sdpOffer string
+
+	pc         *pionwebrtc.PeerConnection
+	localTrack *pionwebrtc.TrackLocalStaticSample
+	resampler  internal_type.AudioResampler
+	opusCodec  *webrtc_internal.OpusCodec
+
+	audioWg       sync.WaitGroup
+	peerConnected atomic.Bool
+	// accepted is set once the call was accepted, remoteEnded once the
+	// caller hung up; the call is terminated on close in between.
+	accepted    atomic.Bool
+	remoteEnded atomic.Bool
+	closeOnce   sync.Once
+}
+
+// NewStreamer answers the call whose offer is stored on the call context.
+// The streamer lives until ctx is done or the call ends.
+func NewStreamer(ctx context.Context, logger commons.Logger, cc *callcontext.CallContext, vaultCred *protos.VaultCredential) (internal_type.Streamer, error) {
+	credential, err := whatsapp_internal.NewCredential(vaultCred)
+	if err != nil {
+		return nil, err
+	}
+	media := func(key string) string {
+		if v, ok := cc.Media[key]; ok {
+			return fmt.Sprintf("%v", v)
+		}
+		return ""
+	}
+	callID, phoneNumberID, offer := media(whatsapp_internal.MediaCallID), media(whatsapp_internal.MediaPhoneNumberID), media(whatsapp_internal.MediaSDPOffer)
+	if callID == "" || phoneNumberID == "" || offer == "" {
+		return nil, errors.New("call context has no whatsapp call offer")
+	}
+
+	resampler, err := internal_audio_resampler.GetResampler(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resampler: %w", err)
+	}
+	opusCodec, err := webrtc_internal.NewOpusCodec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Opus codec: %w", err)
+	}
+
+	s := &whatsappStreamer{
+		BaseStreamer: streamers.NewBaseStreamer(logger,
+			streamers.WithInputChannelSize(webrtc_internal.InputChannelSize),
+			streamers.WithOutputChannelSize(webrtc_internal.OutputChannelSize),
+			streamers.WithInputBufferThreshold(webrtc_internal.InputBufferThreshold),
+			streamers.WithOutputBufferThreshold(webrtc_internal.OutputBufferThreshold),
+			streamers.WithOutputFrameSize(webrtc_internal.OpusFrameBytes),
+			streamers.WithInputBackpressure(streamers.Backpressure{Policy: streamers.DropOldest}),
+			streamers.WithOutputBackpressure(streamers.Backpressure{Policy: streamers.BlockWithTimeout}),
+		),
+		cc:        cc,
+		client:    whatsapp_internal.NewClient(credential.AccessToken, phoneNumberID),
+		callID:    callID,
+		sdpOffer:  offer,
+		resampler: resampler,
+		opusCodec: opusCodec,
+	}
+	if err := s.createPeerConnection(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	activeCalls.Store(callID, s)
+
+	s.PushInput(&protos.ConversationInitialization{
+		AssistantConversationId: cc.ConversationID,
+		Assistant: &protos.AssistantDefinition{
+			AssistantId: cc.AssistantID,
+			Version:     utils.GetVersionString(cc.AssistantProviderId),
+		},
+		StreamMode: protos.StreamMode_STREAM_MODE_AUDIO,
+	})
+	s.PushTransportMetadata(whatsappProvider, map[string]string{
+		"call_id":         callID,
+		"phone_number_id": phoneNumberID,
+	})
+
+	go s.answer()
+	go s.runOutputWriter()
+	go s.watchCallerContext(ctx)
+	return s, nil
+}
+
+// ============================================================================
+// Peer connection
+// ============================================================================
+
+func (s *whatsappStreamer) createPeerConnection() error {
+	mediaEngine := &pionwebrtc.MediaEngine{}
+	if err := mediaEngine.RegisterCodec(pionwebrtc.RTPCodecParameters{
+		RTPCodecCapability: pionwebrtc.RTPCodecCapability{
+			MimeType:    pionwebrtc.MimeTypeOpus,
+			ClockRate:   webrtc_internal.OpusSampleRate,
+			Channels:    webrtc_internal.OpusChannels,
+			SDPFmtpLine: webrtc_internal.OpusSDPFmtpLine,
+		},
+		PayloadType: webrtc_internal.OpusPayloadType,
+	}, pionwebrtc.RTPCodecTypeAudio); err != nil {
+		return fmt.Errorf("failed to register Opus codec: %w", err)
+	}
+	registry := &interceptor.Registry{}
+	if err := pionwebrtc.RegisterDefaultInterceptors(mediaEngine, registry); err != nil {
+		return fmt.Errorf("failed to register interceptors: %w", err)
+	}
+	api := pionwebrtc.NewAPI(
+		pionwebrtc.WithMediaEngine(mediaEngine),
+		pionwebrtc.WithInterceptorRegistry(registry),
+	)
+
+	var iceServers []pionwebrtc.ICEServer
+	for _, srv := range webrtc_internal.DefaultConfig().ICEServers {
+		iceServers = append(iceServers, pionwebrtc.ICEServer{URLs: srv.URLs})
+	}
+	pc, err := api.NewPeerConnection(pionwebrtc.Configuration{ICEServers: iceServers})
+	if err != nil {
+		return fmt.Errorf("failed to create peer connection: %w", err)
+	}
+	s.pc = pc
+
+	pc.OnTrack(func(track *pionwebrtc.TrackRemote, _ *pionwebrtc.RTPReceiver) {
+		if track.Kind() != pionwebrtc.RTPCodecTypeAudio {
+			return
+		}
+		s.Logger.Infow("WhatsApp audio track received", "call", s.callID, "codec", track.Codec().MimeType)
+		s.audioWg.Add(1)
+		go s.readRemoteAudio(track)
+	})
+	pc.OnConnectionStateChange(func(state pionwebrtc.PeerConnectionState) {
+		s.Logger.Infow("WhatsApp connection state changed", "call", s.callID, "state", state)
+		switch state {
+		case pionwebrtc.PeerConnectionStateConnected:
+			s.peerConnected.Store(true)
+		case pionwebrtc.PeerConnectionStateDisconnected:
+			s.peerConnected.Store(false)
+		case pionwebrtc.PeerConnectionStateFailed, pionwebrtc.PeerConnectionStateClosed:
+			s.peerConnected.Store(false)
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		}
+	})
+
+	track, err := pionwebrtc.NewTrackLocalStaticSample(
+		pionwebrtc.RTPCodecCapability{
+			MimeType:  pionwebrtc.MimeTypeOpus,
+			ClockRate: webrtc_internal.OpusSampleRate,
+			Channels:  webrtc_internal.OpusChannels,
+		},
+		"audio",
+		"rapida-assistant",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create local audio track: %w", err)
+	}
+	if _, err := pc.AddTrack(track); err != nil {
+		return fmt.Errorf("failed to add track: %w", err)
+	}
+	s.localTrack = track
+	return nil
+}
+
+// answer creates the answer to the caller's offer and accepts the call
+// with it. The call is declined when no answer can be made.
+func (s *whatsappStreamer) answer() {
+	sdp, err := s.createAnswer()
+	if err != nil {
+		s.Logger.Errorw("Failed to answer WhatsApp call", "call", s.callID, "error", err)
+		ctx, cancel := context.WithTimeout(context.Background(), whatsapp_internal.RequestTimeout)
+		defer cancel()
+		if err := s.client.Reject(ctx, s.callID); err != nil {
+			s.Logger.Warnw("Failed to reject WhatsApp call", "call", s.callID, "error", err)
+		}
+		s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(s.Ctx, 2*whatsapp_internal.RequestTimeout)
+	defer cancel()
+	if err := s.client.PreAccept(ctx, s.callID, sdp); err != nil {
+		s.Logger.Warnw("WhatsApp pre-accept failed, accepting directly", "call", s.callID, "error", err)
+	}
+	if err := s.client.Accept(ctx, s.callID, sdp); err != nil {
+		if s.Ctx.Err() == nil {
+			s.Logger.Errorw("Failed to accept WhatsApp call", "call", s.callID, "error", err)
+		}
+		s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		return
+	}
+	s.accepted.Store(true)
+	s.Logger.Infow("WhatsApp call accepted", "call", s.callID)
+}
+
+// createAnswer applies the offer and returns the answer with all local
+// candidates gathered.
+func (s *whatsappStreamer) createAnswer() (string, error) {
+	if err := s.pc.SetRemoteDescription(pionwebrtc.SessionDescription{
+		Type: pionwebrtc.SDPTypeOffer,
+		SDP:  s.sdpOffer,
+	}); err != nil {
+		return "", fmt.Errorf("failed to set offer: %w", err)
+	}
+	answer, err := s.pc.CreateAnswer(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create answer: %w", err)
+	}
+	gathered := pionwebrtc.GatheringCompletePromise(s.pc)
+	if err := s.pc.SetLocalDescription(answer); err != nil {
+		return "", fmt.Errorf("failed to set answer: %w", err)
+	}
+	select {
+	case <-gathered:
+	case <-time.After(whatsapp_internal.GatherTimeout):
+		s.Logger.Warnw("ICE gathering timed out, answering with the candidates found", "call", s.callID)
+	case <-s.Ctx.Done():
+		return "", s.Ctx.Err()
+	}
+	return s.pc.LocalDescription().SDP, nil
+}
+
+// ============================================================================
+// Audio
+// ============================================================================
+
+// readRemoteAudio decodes the caller's audio and feeds it to the
+// conversation at 16kHz.
+func (s *whatsappStreamer) readRemoteAudio(track *pionwebrtc.TrackRemote) {
+	defer s.audioWg.Done()
+
+	if track.Codec().MimeType != pionwebrtc.MimeTypeOpus {
+		s.Logger.Errorw("Unsupported codec, only Opus is supported", "codec", track.Codec().MimeType)
+		return
+	}
+	opusDecoder, err := webrtc_internal.NewOpusCodec()
+	if err != nil {
+		s.Logger.Errorw("Failed to create Opus decoder", "error", err)
+		return
+	}
+
+	buf := make([]byte, webrtc_internal.RTPBufferSize)
+	consecutiveErrors := 0
+	for {
+		if s.Ctx.Err() != nil {
+			return
+		}
+		n, _, err := track.Read(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			consecutiveErrors++
+			if consecutiveErrors >= webrtc_internal.MaxConsecutiveErrors {
+				s.Logger.Errorw("Too many consecutive read errors, stopping audio reader", "lastError", err)
+				return
+			}
+			continue
+		}
+		consecutiveErrors = 0
+
+		pkt := &rtp.Packet{}
+		if err := pkt.Unmarshal(buf[:n]); err != nil || len(pkt.Payload) == 0 {
+			continue
+		}
+		pcm, err := opusDecoder.Decode(pkt.Payload)
+		if err != nil {
+			s.Logger.Debugw("Opus decode failed", "error", err, "payloadSize", len(pkt.Payload))
+			continue
+		}
+		resampled, err := s.resampler.Resample(pcm, internal_audio.WEBRTC_AUDIO_CONFIG, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG)
+		if err != nil {
+			s.Logger.Debugw("Audio resample failed", "error", err)
+			continue
+		}
+		s.BufferAndSendInput(resampled)
+	}
+}
+
+// runOutputWriter Opus-encodes the assistant's audio and writes it to the
+// call at playback rate. Nothing else of the output reaches a phone call.
+func (s *whatsappStreamer) runOutputWriter() {
+	ticker := time.NewTicker(time.Duration(webrtc_internal.OutputPaceInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	pacer := webrtc_internal.NewPacer(webrtc_internal.ActivePacing())
+	for {
+		select {
+		case <-s.Ctx.Done():
+			return
+		case <-s.FlushAudioCh:
+			pacer.Interrupt()
+		case <-ticker.C:
+			if pacer.Len() == 0 || !s.peerConnected.Load() {
+				continue
+			}
+			for _, frame := range pacer.Next(time.Now()) {
+				encoded, err := s.opusCodec.Encode(frame)
+				if err != nil {
+					s.Logger.Debugw("Opus encode failed", "error", err)
+					continue
+				}
+				if err := s.localTrack.WriteSample(media.Sample{
+					Data:     encoded,
+					Duration: webrtc_internal.OpusFrameDuration * time.Millisecond,
+				}); err != nil {
+					s.Logger.Debugw("Failed to write sample to track", "error", err)
+				}
+			}
+		case msg := <-s.OutputCh:
+			if m, ok := msg.(*protos.ConversationAssistantMessage); ok {
+				if audio, ok := m.Message.(*protos.ConversationAssistantMessage_Audio); ok {
+					if dropped := pacer.Push(audio.Audio); dropped > 0 {
+						s.Logger.Debugw("Output audio queue full, dropped the oldest frames", "frames", dropped)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Send plays the assistant's audio to the caller. Interruptions silence it
+// and ending the conversation hangs up.
+func (s *whatsappStreamer) Send(response internal_type.Stream) error {
+	switch data := response.(type) {
+	case *protos.ConversationAssistantMessage:
+		if content, ok := data.Message.(*protos.ConversationAssistantMessage_Audio); ok {
+			audio48kHz, err := s.resampler.Resample(content.Audio, internal_audio.RAPIDA_INTERNAL_AUDIO_CONFIG, internal_audio.WEBRTC_AUDIO_CONFIG)
+			if err != nil {
+				return err
+			}
+			s.BufferAndSendOutput(audio48kHz)
+		}
+	case *protos.ConversationInterruption:
+		if data.Type == protos.ConversationInterruption_INTERRUPTION_TYPE_WORD {
+			s.ClearOutputBuffer()
+		}
+	case *protos.ConversationDirective:
+		if data.GetType() == protos.ConversationDirective_END_CONVERSATION {
+			s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_TOOL)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Lifecycle
+// ============================================================================
+
+// hungUp ends the conversation after the caller hung up.
+func (s *whatsappStreamer) hungUp() {
+	s.remoteEnded.Store(true)
+	s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+}
+
+// watchCallerContext ends the call when the caller's context is cancelled,
+// and once the conversation has ended.
+func (s *whatsappStreamer) watchCallerContext(callerCtx context.Context) {
+	select {
+	case <-callerCtx.Done():
+	case <-s.Ctx.Done():
+	}
+	s.Close()
+}
+
+// Close hangs up a call the caller has not ended and releases the peer
+// connection. It is idempotent.
+func (s *whatsappStreamer) Close() error {
+	s.closeOnce.Do(func() {
+		activeCalls.CompareAndDelete(s.callID, s)
+		s.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+		if s.accepted.Load() && !s.remoteEnded.Load() {
+			ctx, cancel := context.WithTimeout(context.Background(), whatsapp_internal.RequestTimeout)
+			if err := s.client.Terminate(ctx, s.callID); err != nil {
+				s.Logger.Warnw("Failed to terminate WhatsApp call", "call", s.callID, "error", err)
+			}
+			cancel()
+		}
+		if s.pc != nil {
+			s.pc.Close()
+		}
+		s.Cancel()
+		s.audioWg.Wait()
+	})
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package channel_whatsapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	whatsapp_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/whatsapp/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+const whatsappProvider = "whatsapp"
+
+// whatsappTelephony implements the Telephony interface for the calls a
+// user places to a WhatsApp Business number. The Meta app of the business
+// subscribes its webhook to the calls field; every call event of the
+// number arrives there.
+type whatsappTelephony struct {
+	appCfg *config.AssistantConfig
+	logger commons.Logger
+}
+
+// NewWhatsAppTelephony creates a new WhatsApp telephony provider
+func NewWhatsAppTelephony(config *config.AssistantConfig, logger commons.Logger) (internal_type.Telephony, error) {
+	return &whatsappTelephony{
+		appCfg: config,
+		logger: logger,
+	}, nil
+}
+
+// StatusCallback records WhatsApp events sent to the context event path.
+func (wt *whatsappTelephony) StatusCallback(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+) (*internal_type.StatusInfo, error) {
+	var eventDetails map[string]interface{}
+	if err := c.ShouldBindJSON(&eventDetails); err != nil {
+		wt.logger.Errorf("failed to parse WhatsApp event body: %+v", err)
+		return nil, fmt.Errorf("failed to parse WhatsApp event body: %w", err)
+	}
+	return &internal_type.StatusInfo{Event: "webhook", Payload: eventDetails}, nil
+}
+
+// CatchAllStatusCallback handles catch-all status callbacks
+func (wt *whatsappTelephony) CatchAllStatusCallback(ctx *gin.Context) (*internal_type.StatusInfo, error) {
+	return nil, nil
+}
+
+// ReceiveCall handles the webhook of the Meta app:
+//
+//	GET  https://host/v1/talk/whatsapp/call/<assistantId>  subscription check
+//	POST https://host/v1/talk/whatsapp/call/<assistantId>  notifications
+//
+// The subscription check is answered with its challenge when it names the
+// verify token, notifications must be signed with the app secret. The
+// dispatcher puts the deployment's credential on the request as
+// vaultCredential. Only the connect event of a call brings a call, a
+// terminate event ends the conversation of a call this instance answers.
+func (wt *whatsappTelephony) ReceiveCall(c *gin.Context) (*internal_type.CallInfo, error) {
+	cred, err := wt.credential(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "WhatsApp credential is not configured"})
+		return nil, err
+	}
+
+	if c.Request.Method == http.MethodGet {
+		if !cred.VerifySubscription(c.Query(whatsapp_internal.QueryMode), c.Query(whatsapp_internal.QueryVerifyToken)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid verify token"})
+			return nil, errors.New("whatsapp subscription check with wrong verify token")
+		}
+		c.String(http.StatusOK, c.Query(whatsapp_internal.QueryChallenge))
+		return nil, internal_type.ErrNotACall
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid WhatsApp event"})
+		return nil, fmt.Errorf("failed to read WhatsApp event: %w", err)
+	}
+	if err := cred.VerifyWebhook(c.GetHeader(whatsapp_internal.HeaderSignature), body); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid WhatsApp signature"})
+		return nil, fmt.Errorf("whatsapp webhook rejected: %w", err)
+	}
+	var event whatsapp_internal.WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid WhatsApp event"})
+		return nil, fmt.Errorf("failed to parse WhatsApp event: %w", err)
+	}
+
+	var incoming *whatsapp_internal.IncomingCall
+	for _, call := range event.Calls() {
+		switch {
+		case call.Event == whatsapp_internal.EventTerminate:
+			if hangup(call.ID) {
+				wt.logger.Infof("WhatsApp: call %s ended by the caller: %s", call.ID, call.Status)
+			}
+		case call.Offers() && incoming == nil:
+			incoming = &call
+		}
+	}
+	if incoming == nil {
+		// messages, statuses and call events without an offer need no work
+		c.Status(http.StatusOK)
+		return nil, internal_type.ErrNotACall
+	}
+	if incoming.PhoneNumberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing phone number of call"})
+		return nil, fmt.Errorf("whatsapp call %s names no phone number", incoming.ID)
+	}
+
+	return &internal_type.CallInfo{
+		ChannelUUID:  incoming.ID,
+		CallerNumber: incoming.From,
+		Provider:     whatsappProvider,
+		Status:       "SUCCESS",
+		StatusInfo: internal_type.StatusInfo{Event: incoming.Event, Payload: map[string]string{
+			"call_id":         incoming.ID,
+			"from":            incoming.From,
+			"to":              incoming.To,
+			"direction":       incoming.Direction,
+			"phone_number_id": incoming.PhoneNumberID,
+		}},
+		Extra: map[string]string{
+			"whatsapp.call_id":         incoming.ID,
+			"whatsapp.phone_number_id": incoming.PhoneNumberID,
+			"whatsapp.caller_name":     incoming.CallerName,
+		},
+		Media: map[string]string{
+			whatsapp_internal.MediaCallID:        incoming.ID,
+			whatsapp_internal.MediaPhoneNumberID: incoming.PhoneNumberID,
+			whatsapp_internal.MediaSDPOffer:      incoming.Session.SDP,
+		},
+	}, nil
+}
+
+func (wt *whatsappTelephony) credential(c *gin.Context) (*whatsapp_internal.Credential, error) {
+	value, ok := c.Get("vaultCredential")
+	vaultCredential, _ := value.(*protos.VaultCredential)
+	if !ok || vaultCredential == nil {
+		return nil, errors.New("missing vaultCredential — the dispatcher resolves it before ReceiveCall")
+	}
+	return whatsapp_internal.NewCredential(vaultCredential)
+}
+
+// InboundCall acknowledges the webhook. The streamer answers the call
+// through the Cloud API once it has set up its peer connection.
+func (wt *whatsappTelephony) InboundCall(
+	c *gin.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	clientNumber string,
+	assistantConversationId uint64,
+) error {
+	contextID, exists := c.Get("contextId")
+	if !exists || contextID == "" {
+		return fmt.Errorf("missing contextId — CallReciever must save call context before InboundCall")
+	}
+	c.JSON(http.StatusOK, map[string]string{
+		"rapida_context_id":      fmt.Sprintf("%v", contextID),
+		"rapida_conversation_id": fmt.Sprintf("%d", assistantConversationId),
+	})
+	return nil
+}
+
+// OutboundCall is not supported. Business initiated calls need the user's
+// call permission and the answer comes back in a webhook, which may reach
+// another instance than the one holding the offer.
+func (wt *whatsappTelephony) OutboundCall(
+	auth types.SimplePrinciple,
+	toPhone string,
+	fromPhone string,
+	assistantId, assistantConversationId uint64,
+	vaultCredential *protos.VaultCredential,
+	opts utils.Option,
+) (*internal_type.CallInfo, error) {
+	err := errors.New("whatsapp calls are placed by users, outbound calls are not supported")
+	return &internal_type.CallInfo{Provider: whatsappProvider, Status: "FAILED", ErrorMessage: err.Error()}, err
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package channel_whatsapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rapidaai/api/assistant-api/config"
+	whatsapp_internal "github.com/rapidaai/api/assistant-api/internal/channel/webrtc/whatsapp/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/streamers"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const connectEvent = `{"object":"whatsapp_business_account","entry":[{"id":"WABA","changes":[{"field":"calls","value":{
+  "messaging_product":"whatsapp",
+  "metadata":{"display_phone_number":"16315553601","phone_number_id":"1234567890"},
+  "contacts":[{"profile":{"name":"Pablo"},"wa_id":"16315553602"}],
+  "calls":[{"id":"wacid.1","to":"16315553601","from":"16315553602","event":"connect","timestamp":"1671644824",
+    "direction":"USER_INITIATED","session":{"sdp_type":"offer","sdp":"v=0\r\n"}}]}}]}]}`
+
+const terminateEvent = `{"object":"whatsapp_business_account","entry":[{"id":"WABA","changes":[{"field":"calls","value":{
+  "messaging_product":"whatsapp",
+  "metadata":{"display_phone_number":"16315553601","phone_number_id":"1234567890"},
+  "calls":[{"id":"wacid.1","to":"16315553601","from":"16315553602","event":"terminate","timestamp":"1671644900",
+    "direction":"USER_INITIATED","status":"COMPLETED","duration":76}]}}]}]}`
+
+func newTestTelephony(t *testing.T) internal_type.Telephony {
+	logger, _ := commons.NewApplicationLogger()
+	tel, err := NewWhatsAppTelephony(&config.AssistantConfig{}, logger)
+	require.NoError(t, err)
+	return tel
+}
+
+// newWebhookContext returns a request to the webhook carrying the
+// credential the dispatcher resolves, with body signed by the app secret.
+func newWebhookContext(t *testing.T, method, target, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	mac := hmac.New(sha256.New, []byte("app-secret"))
+	mac.Write([]byte(body))
+	c.Request.Header.Set(whatsapp_internal.HeaderSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	value, err := structpb.NewStruct(map[string]interface{}{"access_token": "EAAG", "app_secret": "app-secret", "verify_token": "verify"})
+	require.NoError(t, err)
+	c.Set("vaultCredential", &protos.VaultCredential{Value: value})
+	return c, recorder
+}
+
+func TestReceiveCall_SubscriptionCheck(t *testing.T) {
+	tel := newTestTelephony(t)
+	c, recorder := newWebhookContext(t, http.MethodGet, "/v1/talk/whatsapp/call/1?hub.mode=subscribe&hub.verify_token=verify&hub.challenge=1158201444", "")
+	_, err := tel.ReceiveCall(c)
+	assert.ErrorIs(t, err, internal_type.ErrNotACall)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "1158201444", recorder.Body.String())
+
+	c, recorder = newWebhookContext(t, http.MethodGet, "/v1/talk/whatsapp/call/1?hub.mode=subscribe&hub.verify_token=guess&hub.challenge=1", "")
+	_, err = tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, internal_type.ErrNotACall)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestReceiveCall_Connect(t *testing.T) {
+	c, _ := newWebhookContext(t, http.MethodPost, "/v1/talk/whatsapp/call/1", connectEvent)
+	info, err := newTestTelephony(t).ReceiveCall(c)
+	require.NoError(t, err)
+	assert.Equal(t, "wacid.1", info.ChannelUUID)
+	assert.Equal(t, "16315553602", info.CallerNumber)
+	assert.Equal(t, whatsappProvider, info.Provider)
+	assert.Equal(t, "connect", info.StatusInfo.Event)
+	assert.Equal(t, "Pablo", info.Extra["whatsapp.caller_name"])
+	assert.Equal(t, map[string]string{
+		whatsapp_internal.MediaCallID:        "wacid.1",
+		whatsapp_internal.MediaPhoneNumberID: "1234567890",
+		whatsapp_internal.MediaSDPOffer:      "v=0\r\n",
+	}, info.Media)
+}
+
+func TestReceiveCall_TerminateEndsAnsweredCall(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	s := &whatsappStreamer{BaseStreamer: streamers.NewBaseStreamer(logger), callID: "wacid.1"}
+	activeCalls.Store("wacid.1", s)
+	t.Cleanup(func() { activeCalls.Delete("wacid.1") })
+
+	c, recorder := newWebhookContext(t, http.MethodPost, "/v1/talk/whatsapp/call/1", terminateEvent)
+	_, err := newTestTelephony(t).ReceiveCall(c)
+	assert.ErrorIs(t, err, internal_type.ErrNotACall)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, s.remoteEnded.Load())
+
+	msg, err := s.Recv()
+	require.NoError(t, err)
+	assert.IsType(t, &protos.ConversationDisconnection{}, msg)
+}
+
+func TestReceiveCall_Rejected(t *testing.T) {
+	tel := newTestTelephony(t)
+
+	c, recorder := newWebhookContext(t, http.MethodPost, "/v1/talk/whatsapp/call/1", connectEvent)
+	c.Request.Header.Set(whatsapp_internal.HeaderSignature, "sha256=00")
+	_, err := tel.ReceiveCall(c)
+	assert.ErrorContains(t, err, "signature")
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	c, recorder = newWebhookContext(t, http.MethodPost, "/v1/talk/whatsapp/call/1", connectEvent)
+	c.Set("vaultCredential", nil)
+	_, err = tel.ReceiveCall(c)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestOutboundCall_NotSupported(t *testing.T) {
+	info, err := newTestTelephony(t).OutboundCall(nil, "+1555", "+1666", 1, 2, nil, utils.Option{})
+	assert.Error(t, err)
+	assert.Equal(t, "FAILED", info.Status)
+}
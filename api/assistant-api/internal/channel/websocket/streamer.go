@@ -0,0 +1,187 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package channel_websocket implements WebTalk over a plain WebSocket for
+// browsers that cannot use gRPC-web.
+//
+// Protocol:
+//   - text frames carry a WebTalkRequest from the client and a WebTalkResponse
+//     from the server, encoded as protobuf JSON (e.g. {"message":{"text":"hi"}})
+//   - binary frames carry audio as linear16, 16 kHz, mono PCM: the caller's
+//     microphone from the client, the assistant's speech from the server
+//
+// Audio is only exchanged in STREAM_MODE_AUDIO, set by the initialization or
+// a later configuration; caller audio sent in text mode is dropped. There is
+// no peer connection, WebRTC signaling of the client is ignored except
+// disconnect. A word interruption tells the client to drop the assistant
+// audio it has not played yet.
+package channel_websocket
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type websocketStreamer struct {
+	logger     commons.Logger
+	ctx        context.Context
+	cancel     context.CancelFunc
+	connection *websocket.Conn
+	writeLock  sync.Mutex
+
+	audio atomic.Bool // the session is in STREAM_MODE_AUDIO
+}
+
+// NewWebSocketStreamer wraps an upgraded WebSocket connection of a WebTalk
+// session.
+func NewWebSocketStreamer(ctx context.Context, logger commons.Logger, connection *websocket.Conn) internal_type.Streamer {
+	sCtx, cancel := context.WithCancel(ctx)
+	return &websocketStreamer{
+		logger:     logger,
+		ctx:        sCtx,
+		cancel:     cancel,
+		connection: connection,
+	}
+}
+
+func (ws *websocketStreamer) Context() context.Context {
+	return ws.ctx
+}
+
+func (ws *websocketStreamer) Recv() (internal_type.Stream, error) {
+	for {
+		messageType, message, err := ws.connection.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				ws.logger.Errorf("webtalk: unexpected websocket close error %v", err)
+			}
+			ws.cancel()
+			return nil, io.EOF
+		}
+		switch messageType {
+		case websocket.BinaryMessage:
+			if len(message) == 0 || !ws.audio.Load() {
+				continue
+			}
+			return &protos.ConversationUserMessage{
+				Message: &protos.ConversationUserMessage_Audio{Audio: message},
+				Time:    timestamppb.Now(),
+			}, nil
+		case websocket.TextMessage:
+			req := &protos.WebTalkRequest{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(message, req); err != nil {
+				ws.logger.Warnf("webtalk: unable to parse request %v", err)
+				continue
+			}
+			if in := ws.request(req); in != nil {
+				return in, nil
+			}
+		}
+	}
+}
+
+// request returns what the talk loop receives for req, nil when nothing.
+func (ws *websocketStreamer) request(req *protos.WebTalkRequest) internal_type.Stream {
+	switch in := req.GetRequest().(type) {
+	case *protos.WebTalkRequest_Initialization:
+		ws.audio.Store(in.Initialization.GetStreamMode() == protos.StreamMode_STREAM_MODE_AUDIO)
+		return in.Initialization
+	case *protos.WebTalkRequest_Configuration:
+		ws.audio.Store(in.Configuration.GetStreamMode() == protos.StreamMode_STREAM_MODE_AUDIO)
+		return in.Configuration
+	case *protos.WebTalkRequest_Message:
+		return in.Message
+	case *protos.WebTalkRequest_Metadata:
+		return in.Metadata
+	case *protos.WebTalkRequest_Metric:
+		return in.Metric
+	case *protos.WebTalkRequest_Disconnection:
+		ws.cancel()
+		return in.Disconnection
+	case *protos.WebTalkRequest_Signaling:
+		if in.Signaling.GetDisconnect() {
+			ws.cancel()
+			return &protos.ConversationDisconnection{
+				Type: protos.ConversationDisconnection_DISCONNECTION_TYPE_USER,
+				Time: timestamppb.Now(),
+			}
+		}
+		ws.logger.Debugf("webtalk: websocket sessions carry their audio, ignoring signaling")
+	}
+	return nil
+}
+
+func (ws *websocketStreamer) Send(out internal_type.Stream) error {
+	resp := &protos.WebTalkResponse{Code: 200, Success: true}
+	switch data := out.(type) {
+	case *protos.ConversationAssistantMessage:
+		if audio, ok := data.GetMessage().(*protos.ConversationAssistantMessage_Audio); ok {
+			if !ws.audio.Load() {
+				return nil
+			}
+			return ws.write(websocket.BinaryMessage, audio.Audio)
+		}
+		resp.Data = &protos.WebTalkResponse_Assistant{Assistant: data}
+	case *protos.ConversationConfiguration:
+		resp.Data = &protos.WebTalkResponse_Configuration{Configuration: data}
+	case *protos.ConversationInitialization:
+		resp.Data = &protos.WebTalkResponse_Initialization{Initialization: data}
+	case *protos.ConversationUserMessage:
+		resp.Data = &protos.WebTalkResponse_User{User: data}
+	case *protos.ConversationInterruption:
+		resp.Data = &protos.WebTalkResponse_Interruption{Interruption: data}
+	case *protos.ConversationDirective:
+		resp.Data = &protos.WebTalkResponse_Directive{Directive: data}
+	case *protos.ConversationError:
+		resp.Code, resp.Success = 500, false
+		resp.Data = &protos.WebTalkResponse_Error{Error: data}
+	case *protos.ConversationMetadata:
+		resp.Data = &protos.WebTalkResponse_Metadata{Metadata: data}
+	case *protos.ConversationMetric:
+		resp.Data = &protos.WebTalkResponse_Metric{Metric: data}
+	default:
+		return nil
+	}
+	payload, err := protojson.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if err := ws.write(websocket.TextMessage, payload); err != nil {
+		return err
+	}
+	// the client was told, the session ends with the directive
+	if directive, ok := out.(*protos.ConversationDirective); ok && directive.GetType() == protos.ConversationDirective_END_CONVERSATION {
+		ws.close()
+	}
+	return nil
+}
+
+// close ends the session from the server side, the pending Recv returns
+// io.EOF.
+func (ws *websocketStreamer) close() {
+	ws.writeLock.Lock()
+	if err := ws.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "conversation ended")); err != nil {
+		ws.logger.Debugf("webtalk: unable to send close frame %v", err)
+	}
+	ws.writeLock.Unlock()
+	ws.connection.Close()
+	ws.cancel()
+}
+
+func (ws *websocketStreamer) write(messageType int, payload []byte) error {
+	ws.writeLock.Lock()
+	defer ws.writeLock.Unlock()
+	return ws.connection.WriteMessage(messageType, payload)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package channel_websocket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// newTestPair starts a websocket server wrapping the server side connection in
+// a websocket streamer and returns it with the connected client connection.
+func newTestPair(t *testing.T) (internal_type.Streamer, *websocket.Conn) {
+	t.Helper()
+	logger, _ := commons.NewApplicationLogger()
+	streamerCh := make(chan internal_type.Streamer, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		streamerCh <- NewWebSocketStreamer(context.Background(), logger, conn)
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return <-streamerCh, client
+}
+
+func sendRequest(t *testing.T, client *websocket.Conn, req *protos.WebTalkRequest) {
+	t.Helper()
+	payload, err := protojson.Marshal(req)
+	require.NoError(t, err)
+	require.NoError(t, client.WriteMessage(websocket.TextMessage, payload))
+}
+
+func initialize(t *testing.T, streamer internal_type.Streamer, client *websocket.Conn, mode protos.StreamMode) {
+	t.Helper()
+	sendRequest(t, client, &protos.WebTalkRequest{Request: &protos.WebTalkRequest_Initialization{
+		Initialization: &protos.ConversationInitialization{
+			Assistant:  &protos.AssistantDefinition{AssistantId: 42, Version: "latest"},
+			StreamMode: mode,
+		},
+	}})
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	init, ok := msg.(*protos.ConversationInitialization)
+	require.True(t, ok, "expected ConversationInitialization, got %T", msg)
+	assert.Equal(t, uint64(42), init.GetAssistant().GetAssistantId())
+}
+
+func TestWebSocketStreamer_JSONRequests(t *testing.T) {
+	streamer, client := newTestPair(t)
+	initialize(t, streamer, client, protos.StreamMode_STREAM_MODE_TEXT)
+
+	require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(`{"message":{"text":"hello"}}`)))
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	user, ok := msg.(*protos.ConversationUserMessage)
+	require.True(t, ok, "expected ConversationUserMessage, got %T", msg)
+	assert.Equal(t, "hello", user.GetText())
+}
+
+func TestWebSocketStreamer_AudioOnlyInAudioMode(t *testing.T) {
+	streamer, client := newTestPair(t)
+	initialize(t, streamer, client, protos.StreamMode_STREAM_MODE_TEXT)
+
+	// dropped in text mode, the configuration is the next message
+	require.NoError(t, client.WriteMessage(websocket.BinaryMessage, []byte{9, 9}))
+	sendRequest(t, client, &protos.WebTalkRequest{Request: &protos.WebTalkRequest_Configuration{
+		Configuration: &protos.ConversationConfiguration{StreamMode: protos.StreamMode_STREAM_MODE_AUDIO},
+	}})
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	_, ok := msg.(*protos.ConversationConfiguration)
+	require.True(t, ok, "expected ConversationConfiguration, got %T", msg)
+
+	require.NoError(t, client.WriteMessage(websocket.BinaryMessage, []byte{1, 2, 3, 4}))
+	msg, err = streamer.Recv()
+	require.NoError(t, err)
+	user, ok := msg.(*protos.ConversationUserMessage)
+	require.True(t, ok)
+	assert.Equal(t, []byte{1, 2, 3, 4}, user.GetAudio())
+	assert.NotNil(t, user.GetTime())
+}
+
+func TestWebSocketStreamer_SendAudioIsBinary(t *testing.T) {
+	streamer, client := newTestPair(t)
+	initialize(t, streamer, client, protos.StreamMode_STREAM_MODE_AUDIO)
+
+	require.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Message: &protos.ConversationAssistantMessage_Audio{Audio: []byte{5, 6}},
+	}))
+	messageType, payload, err := client.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	assert.Equal(t, []byte{5, 6}, payload)
+}
+
+func TestWebSocketStreamer_SendTextIsJSON(t *testing.T) {
+	streamer, client := newTestPair(t)
+
+	require.NoError(t, streamer.Send(&protos.ConversationAssistantMessage{
+		Id:      "answer-1",
+		Message: &protos.ConversationAssistantMessage_Text{Text: "hi there"},
+	}))
+	messageType, payload, err := client.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	resp := &protos.WebTalkResponse{}
+	require.NoError(t, protojson.Unmarshal(payload, resp))
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, "answer-1", resp.GetAssistant().GetId())
+	assert.Equal(t, "hi there", resp.GetAssistant().GetText())
+}
+
+func TestWebSocketStreamer_SignalingDisconnect(t *testing.T) {
+	streamer, client := newTestPair(t)
+
+	sendRequest(t, client, &protos.WebTalkRequest{Request: &protos.WebTalkRequest_Signaling{
+		Signaling: &protos.ClientSignaling{Message: &protos.ClientSignaling_Disconnect{Disconnect: true}},
+	}})
+	msg, err := streamer.Recv()
+	require.NoError(t, err)
+	_, ok := msg.(*protos.ConversationDisconnection)
+	assert.True(t, ok)
+	assert.Error(t, streamer.Context().Err(), "context should be cancelled after disconnect")
+}
+
+func TestWebSocketStreamer_EndConversationCloses(t *testing.T) {
+	streamer, client := newTestPair(t)
+
+	require.NoError(t, streamer.Send(&protos.ConversationDirective{Type: protos.ConversationDirective_END_CONVERSATION}))
+	_, payload, err := client.ReadMessage()
+	require.NoError(t, err)
+	resp := &protos.WebTalkResponse{}
+	require.NoError(t, protojson.Unmarshal(payload, resp))
+	assert.Equal(t, protos.ConversationDirective_END_CONVERSATION, resp.GetDirective().GetType())
+
+	_, err = streamer.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestWebSocketStreamer_ClosedConnectionReturnsEOF(t *testing.T) {
+	streamer, client := newTestPair(t)
+
+	client.Close()
+	_, err := streamer.Recv()
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_cluster tracks the instance each live conversation is
+// hosted by, so control requests and provider callbacks landing on another
+// instance reach the one running its streamer.
+package internal_cluster
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+)
+
+// Session is a live conversation hosted by this instance.
+type Session interface {
+	// Auth is who the conversation runs for, requests from other projects
+	// are refused.
+	Auth() types.SimplePrinciple
+	// Direct applies a directive to the conversation, e.g. ends or
+	// transfers the call.
+	Direct(ctx context.Context, directive internal_type.DirectivePacket) error
+	// StatusCallback tells the conversation its provider reported event for
+	// the call, e.g. completed.
+	StatusCallback(ctx context.Context, provider, event string)
+}
+
+type entry struct {
+	session Session
+	callID  string
+}
+
+// sessions are the live sessions of this process by conversation id.
+var sessions sync.Map
+
+// Register makes a live session reachable from every instance until the
+// returned function is called. callID is the id of its call at the
+// provider, empty when it has none. A session registered later for the same
+// conversation, e.g. after a migration, replaces it and is not removed by
+// the earlier one.
+func Register(conversationID uint64, callID string, session Session) func() {
+	e := &entry{session: session, callID: callID}
+	sessions.Store(conversationID, e)
+	if registry := Active(); registry != nil {
+		registry.claim(conversationID, callID)
+	}
+	return func() {
+		if !sessions.CompareAndDelete(conversationID, e) {
+			return
+		}
+		if registry := Active(); registry != nil {
+			registry.release(conversationID, callID)
+		}
+	}
+}
+
+// Lookup returns the session of a conversation hosted by this instance.
+func Lookup(conversationID uint64) (Session, bool) {
+	e, ok := sessions.Load(conversationID)
+	if !ok {
+		return nil, false
+	}
+	return e.(*entry).session, true
+}
+
+// each calls fn with every session of this instance.
+func each(fn func(conversationID uint64, callID string)) {
+	sessions.Range(func(key, value any) bool {
+		fn(key.(uint64), value.(*entry).callID)
+		return true
+	})
+}
+
+// StatusCallback hands a status callback of the provider to the session of
+// the conversation, on this instance or the one hosting it. Callbacks of
+// conversations no instance hosts, e.g. of ended calls, are dropped.
+func StatusCallback(ctx context.Context, auth types.SimplePrinciple, conversationID uint64, provider, event string) error {
+	if session, ok := Lookup(conversationID); ok {
+		session.StatusCallback(ctx, provider, event)
+		return nil
+	}
+	registry := Active()
+	if registry == nil {
+		return nil
+	}
+	ctx, client, err := registry.Forward(ctx, auth, conversationID)
+	if errors.Is(err, ErrNotLive) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	res, err := client.ForwardStatusCallback(ctx, &protos.ForwardStatusCallbackRequest{
+		AssistantConversationId: conversationID,
+		Provider:                provider,
+		Event:                   event,
+	})
+	if err != nil {
+		return err
+	}
+	if !res.GetSuccess() {
+		return errors.New(res.GetError().GetErrorMessage())
+	}
+	return nil
+}
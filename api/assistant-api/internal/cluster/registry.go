@@ -0,0 +1,229 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_cluster
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rapidaai/pkg/clients"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// registryKeyPrefix namespaces the owners of live conversations and calls.
+const registryKeyPrefix = "rapida:cluster:"
+
+// claimTimeout bounds the redis writes of a session starting or ending.
+const claimTimeout = 2 * time.Second
+
+// forwardedKey marks a request forwarded by another instance, it is not
+// forwarded again.
+const forwardedKey = "x-rapida-forwarded-by"
+
+// ErrNotLive is returned for conversations no instance hosts.
+var ErrNotLive = errors.New("conversation is not live on any instance")
+
+func conversationKey(conversationID uint64) string {
+	return registryKeyPrefix + "conversation:" + strconv.FormatUint(conversationID, 10)
+}
+
+func callKey(callID string) string { return registryKeyPrefix + "call:" + callID }
+
+// releaseScript deletes a key only while it still holds the value this
+// instance set, a session that moved on is not released by its old host.
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Registry keeps which instance hosts each live conversation in redis.
+// Claims expire after ttl unless refreshed, the conversations of an
+// instance that died are not routed to it for long.
+type Registry struct {
+	client   redis.UniversalClient
+	logger   commons.Logger
+	internal clients.InternalClient
+	instance string
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewRegistry claims conversations for instance, the gRPC address other
+// instances reach this one at. internal signs the requests forwarded to
+// other instances.
+func NewRegistry(client redis.UniversalClient, logger commons.Logger, internal clients.InternalClient, instance string, ttl time.Duration) *Registry {
+	return &Registry{
+		client:   client,
+		logger:   logger,
+		internal: internal,
+		instance: instance,
+		ttl:      ttl,
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Instance is the address this instance is reached at.
+func (r *Registry) Instance() string {
+	return r.instance
+}
+
+func (r *Registry) claim(conversationID uint64, callID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), claimTimeout)
+	defer cancel()
+	if err := r.set(ctx, conversationID, callID); err != nil {
+		r.logger.Warnf("unable to register conversation %d with the cluster: %v", conversationID, err)
+	}
+}
+
+func (r *Registry) set(ctx context.Context, conversationID uint64, callID string) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, conversationKey(conversationID), r.instance, r.ttl)
+		if callID != "" {
+			pipe.Set(ctx, callKey(callID), strconv.FormatUint(conversationID, 10), r.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *Registry) release(conversationID uint64, callID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), claimTimeout)
+	defer cancel()
+	keys := []string{conversationKey(conversationID)}
+	if err := releaseScript.Run(ctx, r.client, keys, r.instance).Err(); err != nil {
+		r.logger.Warnf("unable to unregister conversation %d from the cluster: %v", conversationID, err)
+	}
+	if callID == "" {
+		return
+	}
+	// a call taken over by another instance keeps the conversation
+	if err := releaseScript.Run(ctx, r.client, []string{callKey(callID)}, strconv.FormatUint(conversationID, 10)).Err(); err != nil {
+		r.logger.Warnf("unable to unregister call %s from the cluster: %v", callID, err)
+	}
+}
+
+// Refresh renews the claims of the sessions of this instance before they
+// expire, and takes back conversations an instance taking them over later
+// lost again.
+func (r *Registry) Refresh(ctx context.Context) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		each(func(conversationID uint64, callID string) {
+			pipe.Set(ctx, conversationKey(conversationID), r.instance, r.ttl)
+			if callID != "" {
+				pipe.Set(ctx, callKey(callID), strconv.FormatUint(conversationID, 10), r.ttl)
+			}
+		})
+		return nil
+	})
+	return err
+}
+
+// Owner returns the instance hosting a conversation, "" when none does.
+func (r *Registry) Owner(ctx context.Context, conversationID uint64) (string, error) {
+	instance, err := r.client.Get(ctx, conversationKey(conversationID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return instance, err
+}
+
+// Conversation returns the conversation of a call by its id at the
+// provider, 0 when no instance hosts the call.
+func (r *Registry) Conversation(ctx context.Context, callID string) (uint64, error) {
+	value, err := r.client.Get(ctx, callKey(callID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// Forward returns a client of the instance hosting a conversation and the
+// context to call it with, signed for auth. It returns ErrNotLive when no
+// other instance hosts the conversation; a request forwarded once is not
+// forwarded again.
+func (r *Registry) Forward(ctx context.Context, auth types.SimplePrinciple, conversationID uint64) (context.Context, protos.ConversationControlServiceClient, error) {
+	if Forwarded(ctx) {
+		return nil, nil, ErrNotLive
+	}
+	instance, err := r.Owner(ctx, conversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if instance == "" || instance == r.instance {
+		return nil, nil, ErrNotLive
+	}
+	conn, err := r.conn(instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx = r.internal.WithAuth(ctx, auth)
+	ctx = metadata.AppendToOutgoingContext(ctx, forwardedKey, r.instance)
+	return ctx, protos.NewConversationControlServiceClient(conn), nil
+}
+
+func (r *Registry) conn(instance string) (*grpc.ClientConn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if conn, ok := r.conns[instance]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(instance, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	r.conns[instance] = conn
+	return conn, nil
+}
+
+// Close releases the claims of the sessions still on this instance and the
+// connections to the other instances.
+func (r *Registry) Close(ctx context.Context) {
+	each(func(conversationID uint64, callID string) {
+		r.release(conversationID, callID)
+	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for instance, conn := range r.conns {
+		conn.Close()
+		delete(r.conns, instance)
+	}
+}
+
+// Forwarded reports whether a request was forwarded by another instance.
+func Forwarded(ctx context.Context) bool {
+	return len(metadata.ValueFromIncomingContext(ctx, forwardedKey)) > 0
+}
+
+var active atomic.Pointer[Registry]
+
+// Install makes r the registry sessions are claimed in, nil keeps them
+// local to this instance.
+func Install(r *Registry) {
+	active.Store(r)
+}
+
+// Active returns the installed registry, nil when there is none.
+func Active() *Registry {
+	return active.Load()
+}
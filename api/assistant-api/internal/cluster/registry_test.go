@@ -0,0 +1,102 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+type testSession struct {
+	directives []internal_type.DirectivePacket
+}
+
+func (s *testSession) Direct(ctx context.Context, directive internal_type.DirectivePacket) error {
+	s.directives = append(s.directives, directive)
+	return nil
+}
+
+func (s *testSession) StatusCallback(ctx context.Context, provider, event string) {}
+
+func (s *testSession) Auth() types.SimplePrinciple { return nil }
+
+func TestRegister_Local(t *testing.T) {
+	first, second := &testSession{}, &testSession{}
+	unregisterFirst := Register(1, "", first)
+	unregisterSecond := Register(1, "", second)
+
+	session, ok := Lookup(1)
+	require.True(t, ok)
+	assert.Same(t, second, session)
+
+	unregisterFirst()
+	_, ok = Lookup(1)
+	assert.True(t, ok, "the earlier session does not remove the later one")
+	unregisterSecond()
+	_, ok = Lookup(1)
+	assert.False(t, ok)
+}
+
+func TestRegistry_ClaimRelease(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	logger, _ := commons.NewApplicationLogger()
+	registry := NewRegistry(db, logger, nil, "10.0.0.1:9007", time.Minute)
+	Install(registry)
+	defer Install(nil)
+
+	mock.ExpectSet(conversationKey(7), "10.0.0.1:9007", time.Minute).SetVal("OK")
+	mock.ExpectSet(callKey("call-7"), "7", time.Minute).SetVal("OK")
+	unregister := Register(7, "call-7", &testSession{})
+
+	mock.ExpectSet(conversationKey(7), "10.0.0.1:9007", time.Minute).SetVal("OK")
+	mock.ExpectSet(callKey("call-7"), "7", time.Minute).SetVal("OK")
+	require.NoError(t, registry.Refresh(context.Background()))
+
+	mock.ExpectGet(conversationKey(7)).SetVal("10.0.0.1:9007")
+	owner, err := registry.Owner(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:9007", owner)
+
+	mock.ExpectGet(callKey("call-7")).SetVal("7")
+	conversationID, err := registry.Conversation(context.Background(), "call-7")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), conversationID)
+
+	mock.ExpectEvalSha(releaseScript.Hash(), []string{conversationKey(7)}, "10.0.0.1:9007").SetVal(int64(1))
+	mock.ExpectEvalSha(releaseScript.Hash(), []string{callKey("call-7")}, "7").SetVal(int64(1))
+	unregister()
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegistry_Forward(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	logger, _ := commons.NewApplicationLogger()
+	registry := NewRegistry(db, logger, nil, "10.0.0.1:9007", time.Minute)
+
+	mock.ExpectGet(conversationKey(8)).RedisNil()
+	_, _, err := registry.Forward(context.Background(), nil, 8)
+	assert.ErrorIs(t, err, ErrNotLive)
+
+	mock.ExpectGet(conversationKey(8)).SetVal("10.0.0.1:9007")
+	_, _, err = registry.Forward(context.Background(), nil, 8)
+	assert.ErrorIs(t, err, ErrNotLive, "a conversation claimed by this instance is not forwarded to itself")
+
+	forwarded := metadata.NewIncomingContext(context.Background(), metadata.Pairs(forwardedKey, "10.0.0.2:9007"))
+	assert.True(t, Forwarded(forwarded))
+	_, _, err = registry.Forward(forwarded, nil, 8)
+	assert.ErrorIs(t, err, ErrNotLive, "a forwarded request is not forwarded again")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_conversationdiff compares two conversations of an
+// assistant held on the same input, typically one recorded session replayed
+// against two assistant versions, so a prompt or provider upgrade can be
+// reviewed turn by turn before it ships.
+package internal_conversationdiff
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	type_enums "github.com/rapidaai/pkg/types/enums"
+)
+
+// ContentType is the content type of an encoded report.
+const ContentType = "application/json"
+
+// Message is one stored message of a conversation.
+type Message struct {
+	ID   string
+	Role string
+	Body string
+	At   time.Time
+}
+
+// ToolCall is one tool the assistant ran, MessageID is the message of the
+// turn it ran in.
+type ToolCall struct {
+	MessageID string
+	Name      string
+	Status    string
+	TimeTaken time.Duration
+}
+
+// Conversation is what is compared of a conversation, messages in the order
+// they were held.
+type Conversation struct {
+	ID                       uint64
+	AssistantProviderModelID uint64
+	Messages                 []Message
+	Tools                    []ToolCall
+}
+
+// Turn is one user message and everything the assistant replied to it. A
+// greeting before the user spoke is a turn without user text.
+type Turn struct {
+	User    string
+	Reply   string
+	Latency time.Duration // user message to the first reply, zero without either
+	Tools   []string
+}
+
+// Turns splits a conversation into turns.
+func Turns(c *Conversation) []Turn {
+	var (
+		turns    []Turn
+		userAt   time.Time
+		turnOf   = make(map[string]int)
+		replying bool
+	)
+	for _, msg := range c.Messages {
+		switch msg.Role {
+		case "user":
+			turns = append(turns, Turn{User: strings.TrimSpace(msg.Body)})
+			userAt, replying = msg.At, false
+		case "assistant", "rapida":
+			if len(turns) == 0 {
+				turns = append(turns, Turn{})
+			}
+			turn := &turns[len(turns)-1]
+			if body := strings.TrimSpace(msg.Body); body != "" {
+				if turn.Reply != "" {
+					turn.Reply += " "
+				}
+				turn.Reply += body
+			}
+			if !replying && turn.User != "" && msg.At.After(userAt) {
+				turn.Latency = msg.At.Sub(userAt)
+			}
+			replying = true
+		default:
+			continue
+		}
+		turnOf[msg.ID] = len(turns) - 1
+	}
+	for _, call := range c.Tools {
+		if i, ok := turnOf[call.MessageID]; ok {
+			turns[i].Tools = append(turns[i].Tools, call.Name)
+		}
+	}
+	return turns
+}
+
+// Report is the structured difference between a base and a candidate
+// conversation.
+type Report struct {
+	Base      Version    `json:"base"`
+	Candidate Version    `json:"candidate"`
+	Summary   Summary    `json:"summary"`
+	Turns     []TurnDiff `json:"turns"`
+}
+
+// Version describes one side of the comparison.
+type Version struct {
+	ConversationID           uint64 `json:"conversationId,string"`
+	AssistantProviderModelID uint64 `json:"assistantProviderModelId,string"`
+	Turns                    int    `json:"turns"`
+	ToolCalls                int    `json:"toolCalls"`
+	FailedToolCalls          int    `json:"failedToolCalls"`
+	MedianLatencyMs          int64  `json:"medianLatencyMs"`
+}
+
+// Summary counts the differences over all turns.
+type Summary struct {
+	Turns                int   `json:"turns"`
+	ChangedReplies       int   `json:"changedReplies"`
+	ToolChanges          int   `json:"toolChanges"`
+	InputMismatches      int   `json:"inputMismatches"`
+	MissingTurns         int   `json:"missingTurns"`
+	MedianLatencyDeltaMs int64 `json:"medianLatencyDeltaMs"`
+}
+
+// Side is a turn as one of the conversations held it.
+type Side struct {
+	Reply     string   `json:"reply"`
+	LatencyMs int64    `json:"latencyMs"`
+	Tools     []string `json:"tools,omitempty"`
+}
+
+// TurnDiff is the difference of one turn. Base or Candidate is nil when only
+// the other conversation got that far.
+type TurnDiff struct {
+	Index int    `json:"index"`
+	User  string `json:"user"`
+	// CandidateUser is set when the replayed input did not match, the rest of
+	// the turn is then hardly comparable.
+	CandidateUser  string   `json:"candidateUser,omitempty"`
+	Base           *Side    `json:"base,omitempty"`
+	Candidate      *Side    `json:"candidate,omitempty"`
+	ReplyChanged   bool     `json:"replyChanged"`
+	Similarity     float64  `json:"similarity"`
+	LatencyDeltaMs int64    `json:"latencyDeltaMs"`
+	ToolsAdded     []string `json:"toolsAdded,omitempty"`
+	ToolsRemoved   []string `json:"toolsRemoved,omitempty"`
+}
+
+// Compare builds the report of the candidate against the base, turns are
+// matched by position.
+func Compare(base, candidate *Conversation) *Report {
+	baseTurns, candidateTurns := Turns(base), Turns(candidate)
+	report := &Report{
+		Base:      version(base, baseTurns),
+		Candidate: version(candidate, candidateTurns),
+	}
+
+	var deltas []int64
+	for i := 0; i < len(baseTurns) || i < len(candidateTurns); i++ {
+		diff := TurnDiff{Index: i}
+		switch {
+		case i >= len(candidateTurns):
+			diff.User, diff.Base = baseTurns[i].User, side(baseTurns[i])
+			report.Summary.MissingTurns++
+		case i >= len(baseTurns):
+			diff.User, diff.Candidate = candidateTurns[i].User, side(candidateTurns[i])
+			report.Summary.MissingTurns++
+		default:
+			b, c := baseTurns[i], candidateTurns[i]
+			diff.User, diff.Base, diff.Candidate = b.User, side(b), side(c)
+			if normalize(b.User) != normalize(c.User) {
+				diff.CandidateUser = c.User
+				report.Summary.InputMismatches++
+			}
+			diff.Similarity = math.Round(similarity(b.Reply, c.Reply)*100) / 100
+			diff.ReplyChanged = normalize(b.Reply) != normalize(c.Reply)
+			if diff.ReplyChanged {
+				report.Summary.ChangedReplies++
+			}
+			if b.Latency > 0 && c.Latency > 0 {
+				diff.LatencyDeltaMs = (c.Latency - b.Latency).Milliseconds()
+				deltas = append(deltas, diff.LatencyDeltaMs)
+			}
+			diff.ToolsAdded, diff.ToolsRemoved = subtract(c.Tools, b.Tools), subtract(b.Tools, c.Tools)
+			if len(diff.ToolsAdded) > 0 || len(diff.ToolsRemoved) > 0 {
+				report.Summary.ToolChanges++
+			}
+		}
+		report.Turns = append(report.Turns, diff)
+	}
+	report.Summary.Turns = len(report.Turns)
+	report.Summary.MedianLatencyDeltaMs = median(deltas)
+	return report
+}
+
+// Encode serializes the report as the artifact handed out.
+func Encode(r *Report) ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+func version(c *Conversation, turns []Turn) Version {
+	v := Version{
+		ConversationID:           c.ID,
+		AssistantProviderModelID: c.AssistantProviderModelID,
+		Turns:                    len(turns),
+		ToolCalls:                len(c.Tools),
+	}
+	for _, call := range c.Tools {
+		if call.Status == type_enums.RECORD_FAILED.String() {
+			v.FailedToolCalls++
+		}
+	}
+	var latencies []int64
+	for _, turn := range turns {
+		if turn.Latency > 0 {
+			latencies = append(latencies, turn.Latency.Milliseconds())
+		}
+	}
+	v.MedianLatencyMs = median(latencies)
+	return v
+}
+
+func side(t Turn) *Side {
+	return &Side{Reply: t.Reply, LatencyMs: t.Latency.Milliseconds(), Tools: t.Tools}
+}
+
+// subtract returns the names in a that are not matched one to one in b.
+func subtract(a, b []string) []string {
+	left := make(map[string]int, len(b))
+	for _, name := range b {
+		left[name]++
+	}
+	var out []string
+	for _, name := range a {
+		if left[name] > 0 {
+			left[name]--
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+func median(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// words lowercases text and drops the punctuation, replies that only differ
+// in those read the same to the caller.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+	})
+}
+
+func normalize(text string) string {
+	return strings.Join(words(text), " ")
+}
+
+// similarity is the share of words two replies have in common in order, 1
+// for the same reply and 0 for nothing in common.
+func similarity(a, b string) float64 {
+	wa, wb := words(a), words(b)
+	if len(wa)+len(wb) == 0 {
+		return 1
+	}
+	// longest common subsequence over words, one row at a time
+	prev := make([]int, len(wb)+1)
+	cur := make([]int, len(wb)+1)
+	for i := 1; i <= len(wa); i++ {
+		for j := 1; j <= len(wb); j++ {
+			switch {
+			case wa[i-1] == wb[j-1]:
+				cur[j] = prev[j-1] + 1
+			case prev[j] >= cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return float64(2*prev[len(wb)]) / float64(len(wa)+len(wb))
+}
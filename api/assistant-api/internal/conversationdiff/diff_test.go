@@ -0,0 +1,110 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_conversationdiff
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func at(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+
+func TestTurns(t *testing.T) {
+	c := &Conversation{
+		Messages: []Message{
+			{ID: "g", Role: "assistant", Body: "Hi, how can I help?", At: at(0)},
+			{ID: "m1", Role: "user", Body: "Where is my order?", At: at(1000)},
+			{ID: "m1", Role: "assistant", Body: "Let me check.", At: at(1800)},
+			{ID: "m1", Role: "assistant", Body: "It ships today.", At: at(3000)},
+			{ID: "m2", Role: "user", Body: "Thanks", At: at(4000)},
+		},
+		Tools: []ToolCall{{MessageID: "m1", Name: "lookup_order"}, {MessageID: "unknown", Name: "ignored"}},
+	}
+
+	turns := Turns(c)
+	require.Len(t, turns, 3)
+	assert.Equal(t, Turn{Reply: "Hi, how can I help?"}, turns[0])
+	assert.Equal(t, "Let me check. It ships today.", turns[1].Reply)
+	assert.Equal(t, 800*time.Millisecond, turns[1].Latency, "up to the first reply")
+	assert.Equal(t, []string{"lookup_order"}, turns[1].Tools)
+	assert.Equal(t, Turn{User: "Thanks"}, turns[2])
+}
+
+func TestCompare(t *testing.T) {
+	base := &Conversation{
+		ID: 1, AssistantProviderModelID: 10,
+		Messages: []Message{
+			{ID: "a", Role: "user", Body: "Book a table for two", At: at(0)},
+			{ID: "a", Role: "assistant", Body: "Sure, for what time?", At: at(900)},
+			{ID: "b", Role: "user", Body: "Seven pm", At: at(3000)},
+			{ID: "b", Role: "assistant", Body: "Booked for 7 pm.", At: at(4000)},
+		},
+		Tools: []ToolCall{{MessageID: "b", Name: "book", Status: "COMPLETE"}},
+	}
+	candidate := &Conversation{
+		ID: 2, AssistantProviderModelID: 11,
+		Messages: []Message{
+			{ID: "x", Role: "user", Body: "book a table for two.", At: at(0)},
+			{ID: "x", Role: "assistant", Body: "sure for what time", At: at(600)},
+			{ID: "y", Role: "user", Body: "Seven pm", At: at(3000)},
+			{ID: "y", Role: "assistant", Body: "Done, you are booked for 7 pm.", At: at(4500)},
+			{ID: "z", Role: "user", Body: "Thanks", At: at(6000)},
+		},
+		Tools: []ToolCall{
+			{MessageID: "y", Name: "check_availability", Status: "FAILED"},
+			{MessageID: "y", Name: "book", Status: "COMPLETE"},
+		},
+	}
+
+	r := Compare(base, candidate)
+	require.Len(t, r.Turns, 3)
+
+	assert.False(t, r.Turns[0].ReplyChanged, "only case and punctuation differ")
+	assert.Equal(t, 1.0, r.Turns[0].Similarity)
+	assert.Empty(t, r.Turns[0].CandidateUser)
+	assert.Equal(t, int64(-300), r.Turns[0].LatencyDeltaMs)
+
+	assert.True(t, r.Turns[1].ReplyChanged)
+	assert.Equal(t, 0.73, r.Turns[1].Similarity)
+	assert.Equal(t, int64(500), r.Turns[1].LatencyDeltaMs)
+	assert.Equal(t, []string{"check_availability"}, r.Turns[1].ToolsAdded)
+	assert.Empty(t, r.Turns[1].ToolsRemoved)
+
+	assert.Nil(t, r.Turns[2].Base)
+	assert.Equal(t, "Thanks", r.Turns[2].User)
+
+	assert.Equal(t, Summary{Turns: 3, ChangedReplies: 1, ToolChanges: 1, MissingTurns: 1, MedianLatencyDeltaMs: 100}, r.Summary)
+	assert.Equal(t, Version{ConversationID: 1, AssistantProviderModelID: 10, Turns: 2, ToolCalls: 1, MedianLatencyMs: 950}, r.Base)
+	assert.Equal(t, 1, r.Candidate.FailedToolCalls)
+
+	artifact, err := Encode(r)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(artifact, &decoded))
+	assert.Equal(t, "2", decoded["candidate"].(map[string]interface{})["conversationId"])
+}
+
+func TestCompare_InputMismatch(t *testing.T) {
+	base := &Conversation{Messages: []Message{{Role: "user", Body: "cancel my order"}}}
+	candidate := &Conversation{Messages: []Message{{Role: "user", Body: "cancel my border"}}}
+
+	r := Compare(base, candidate)
+	assert.Equal(t, "cancel my border", r.Turns[0].CandidateUser)
+	assert.Equal(t, 1, r.Summary.InputMismatches)
+	assert.False(t, r.Turns[0].ReplyChanged)
+}
+
+func TestSubtract(t *testing.T) {
+	assert.Equal(t, []string{"a"}, subtract([]string{"a", "a", "b"}, []string{"a", "b"}))
+	assert.Empty(t, subtract([]string{"a"}, []string{"a", "a"}))
+}
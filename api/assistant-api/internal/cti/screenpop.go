@@ -0,0 +1,97 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_cti builds the screen-pop sent to the desktop of the human
+// agent a call is transferred to, so the agent sees who is calling and why
+// before they answer.
+package internal_cti
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Caller is who the agent is about to talk to.
+type Caller struct {
+	Identifier string
+	Number     string
+	Called     string
+	Direction  string
+	Provider   string
+}
+
+// ScreenPop is the body of the conversation.transferring event.
+type ScreenPop struct {
+	Event          string
+	AssistantID    string
+	ConversationID string
+	TransferTo     string
+	Caller         Caller
+	Intent         string
+	Slots          map[string]interface{}
+	TranscriptURL  string
+	Time           time.Time
+}
+
+// Slots merges what was collected during the call, the scratchpad wins over
+// the custom metadata when both hold the same key.
+func Slots(metadata, scratchpad map[string]interface{}) map[string]interface{} {
+	slots := make(map[string]interface{}, len(metadata)+len(scratchpad))
+	for k, v := range metadata {
+		slots[k] = v
+	}
+	for k, v := range scratchpad {
+		slots[k] = v
+	}
+	return slots
+}
+
+// TranscriptURL is the page of the conversation in the console, empty when
+// the console host isn't configured.
+func TranscriptURL(uiHost string, assistantID, conversationID uint64) string {
+	uiHost = strings.TrimRight(strings.TrimSpace(uiHost), "/")
+	if uiHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/deployment/assistant/%d/sessions/%d", uiHost, assistantID, conversationID)
+}
+
+// Body returns the screen-pop as the body of a webhook.
+func (s ScreenPop) Body() map[string]interface{} {
+	slots := s.Slots
+	if slots == nil {
+		slots = map[string]interface{}{}
+	}
+	caller := map[string]interface{}{}
+	for k, v := range map[string]string{
+		"identifier": s.Caller.Identifier,
+		"number":     s.Caller.Number,
+		"called":     s.Caller.Called,
+		"direction":  s.Caller.Direction,
+		"provider":   s.Caller.Provider,
+	} {
+		if v != "" {
+			caller[k] = v
+		}
+	}
+	body := map[string]interface{}{
+		"event":          s.Event,
+		"assistantId":    s.AssistantID,
+		"conversationId": s.ConversationID,
+		"transferTo":     s.TransferTo,
+		"caller":         caller,
+		"slots":          slots,
+		"time":           s.Time.UTC().Format(time.RFC3339),
+	}
+	if s.Intent != "" {
+		body["intent"] = s.Intent
+	}
+	if s.TranscriptURL != "" {
+		body["transcriptUrl"] = s.TranscriptURL
+	}
+	return body
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_cti
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlots_ScratchpadWins(t *testing.T) {
+	slots := Slots(
+		map[string]interface{}{"account": "old", "campaign": "spring"},
+		map[string]interface{}{"account": "A-42"},
+	)
+	assert.Equal(t, map[string]interface{}{"account": "A-42", "campaign": "spring"}, slots)
+}
+
+func TestTranscriptURL(t *testing.T) {
+	assert.Equal(t, "https://app.rapida.ai/deployment/assistant/7/sessions/99", TranscriptURL("https://app.rapida.ai/", 7, 99))
+	assert.Empty(t, TranscriptURL(" ", 7, 99))
+}
+
+func TestScreenPop_Body(t *testing.T) {
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	body := ScreenPop{
+		Event:          "conversation.transferring",
+		AssistantID:    "7",
+		ConversationID: "99",
+		TransferTo:     "+15550100",
+		Caller:         Caller{Number: "+15550199", Direction: "inbound"},
+		Intent:         "billing dispute",
+		Slots:          map[string]interface{}{"account": "A-42"},
+		TranscriptURL:  "https://app.rapida.ai/deployment/assistant/7/sessions/99",
+		Time:           at,
+	}.Body()
+
+	assert.Equal(t, "+15550100", body["transferTo"])
+	assert.Equal(t, map[string]interface{}{"number": "+15550199", "direction": "inbound"}, body["caller"])
+	assert.Equal(t, "billing dispute", body["intent"])
+	assert.Equal(t, "2025-03-01T10:00:00Z", body["time"])
+	assert.Equal(t, map[string]interface{}{"account": "A-42"}, body["slots"])
+}
+
+func TestScreenPop_BodyOmitsUnknowns(t *testing.T) {
+	body := ScreenPop{Event: "conversation.transferring", TransferTo: "sip:desk@pbx"}.Body()
+	_, hasIntent := body["intent"]
+	_, hasURL := body["transcriptUrl"]
+	assert.False(t, hasIntent)
+	assert.False(t, hasURL)
+	assert.Equal(t, map[string]interface{}{}, body["slots"])
+}
@@ -7,6 +7,7 @@ package internal_denoiser
 
 import (
 	"context"
+	"strings"
 
 	internal_denoiser_krisp "github.com/rapidaai/api/assistant-api/internal/denoiser/internal/krisp"
 	internal_denoiser_rnnoise "github.com/rapidaai/api/assistant-api/internal/denoiser/internal/rn_noise"
@@ -22,8 +23,19 @@ const (
 	RN_NOISE                   DenoiserIdentifier = "rn_noise"
 	KRISP                      DenoiserIdentifier = "krisp"
 	DenoiserOptionsKeyProvider                    = "microphone.denoising.provider"
+
+	// DenoiserOptionsKeyEnable turns noise suppression off when "false", the
+	// caller audio then goes to VAD and STT as the channel delivered it.
+	DenoiserOptionsKeyEnable = "microphone.denoising.enable"
 )
 
+// Enabled reports whether noise suppression is on for the options of an
+// assistant deployment, it is unless turned off.
+func Enabled(options utils.Option) bool {
+	enabled, err := options.GetString(DenoiserOptionsKeyEnable)
+	return err != nil || !strings.EqualFold(strings.TrimSpace(enabled), "false")
+}
+
 // logger, audioConfig, opts
 func GetDenoiser(ctx context.Context, logger commons.Logger, inCfg *protos.AudioConfig, options utils.Option) (internal_type.Denoiser, error) {
 	provider, _ := options.GetString(DenoiserOptionsKeyProvider)
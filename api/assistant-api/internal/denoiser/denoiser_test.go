@@ -6,7 +6,11 @@
 package internal_denoiser
 
 import (
+	"encoding/binary"
+	"math"
+	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
@@ -153,6 +157,28 @@ func TestAllDenoiserTypesCallFactory(t *testing.T) {
 	}
 }
 
+// TestEnabled tests the per assistant noise suppression toggle
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  utils.Option
+		expected bool
+	}{
+		{name: "not configured", options: utils.Option{}, expected: true},
+		{name: "true", options: utils.Option{DenoiserOptionsKeyEnable: "true"}, expected: true},
+		{name: "false", options: utils.Option{DenoiserOptionsKeyEnable: "false"}, expected: false},
+		{name: "false with spaces and case", options: utils.Option{DenoiserOptionsKeyEnable: " FALSE "}, expected: false},
+		{name: "bool false", options: utils.Option{DenoiserOptionsKeyEnable: false}, expected: false},
+		{name: "unrecognised stays on", options: utils.Option{DenoiserOptionsKeyEnable: "off"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Enabled(tt.options))
+		})
+	}
+}
+
 // TestDenoiserIdentifierStringConsistency validates consistency
 func TestDenoiserIdentifierStringConsistency(t *testing.T) {
 	tests := []struct {
@@ -302,3 +328,46 @@ func BenchmarkDenoiserIdentifierString(b *testing.B) {
 		_ = string(identifier)
 	}
 }
+
+// noisyLinear16 is a 440Hz tone under white noise, 16kHz mono linear16.
+func noisyLinear16(d time.Duration) []byte {
+	samples := int(d.Seconds() * 16000)
+	rng := rand.New(rand.NewSource(1))
+	out := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		v := 0.3*math.Sin(2*math.Pi*440*float64(i)/16000) + 0.1*(rng.Float64()*2-1)
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(int16(v*math.MaxInt16)))
+	}
+	return out
+}
+
+// benchmarkDenoise measures the latency the denoiser adds to caller audio
+// arriving in chunks of the given length, reported per 20ms frame as well.
+func benchmarkDenoise(b *testing.B, chunk time.Duration) {
+	logger, _ := commons.NewApplicationLogger()
+	config := &protos.AudioConfig{SampleRate: 16000, AudioFormat: protos.AudioConfig_LINEAR16, Channels: 1}
+	denoiser, err := GetDenoiser(b.Context(), logger, config, utils.Option{DenoiserOptionsKeyProvider: RN_NOISE})
+	if err != nil {
+		b.Skipf("rnnoise is not available: %v", err)
+	}
+	defer denoiser.Close()
+	audio := noisyLinear16(chunk)
+
+	b.SetBytes(int64(len(audio)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := denoiser.Denoise(b.Context(), audio); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	frames := float64(chunk / (20 * time.Millisecond))
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/frames, "ns/frame")
+}
+
+// BenchmarkDenoise_20ms benchmarks a single 20ms frame, as WebRTC delivers it
+func BenchmarkDenoise_20ms(b *testing.B) { benchmarkDenoise(b, 20*time.Millisecond) }
+
+// BenchmarkDenoise_60ms benchmarks a buffered 60ms chunk of three frames
+func BenchmarkDenoise_60ms(b *testing.B) { benchmarkDenoise(b, 60*time.Millisecond) }
@@ -0,0 +1,309 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_diarization tells apart the speakers on the far end of a
+// call, e.g. a bridged human agent or several people around one phone, so the
+// transcripts of the caller carry who said them. Providers diarizing on their
+// own, e.g. Deepgram, label their transcripts, the Clusterer labels the rest
+// from the audio they were heard in.
+//
+// All audio is linear16 16 kHz mono, the format the talk loop works in.
+package internal_diarization
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sync"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Diarization is configured on the speech to text options of a deployment,
+// the fallback clusterer tells apart at most max_speakers speakers:
+//
+//	listen.diarize              = true
+//	listen.diarize.max_speakers = 4
+const (
+	OptionsKeyDiarize     = "listen.diarize"
+	OptionsKeyMaxSpeakers = "listen.diarize.max_speakers"
+)
+
+// DefaultMaxSpeakers is how many speakers are told apart when the deployment
+// does not say.
+const DefaultMaxSpeakers = 4
+
+// Label names the speaker n, the way providers number them from 0.
+func Label(n int) string {
+	return fmt.Sprintf("speaker_%d", n)
+}
+
+// Enabled reports whether opts turn diarization on.
+func Enabled(opts utils.Option) bool {
+	diarize, err := opts.GetBool(OptionsKeyDiarize)
+	return err == nil && diarize
+}
+
+// FromOptions returns the clusterer configured in opts, nil when
+// diarization is off.
+func FromOptions(opts utils.Option) *Clusterer {
+	if !Enabled(opts) {
+		return nil
+	}
+	maxSpeakers := DefaultMaxSpeakers
+	if n, err := opts.GetFloat64(OptionsKeyMaxSpeakers); err == nil && n >= 1 {
+		maxSpeakers = int(n)
+	}
+	return NewClusterer(maxSpeakers)
+}
+
+const (
+	sampleRate = 16000
+	// frameSize is 32 ms of audio, analysed every hopSize.
+	frameSize = 512
+	hopSize   = 256
+	melBands  = 26
+	// coefficients are the cepstral coefficients kept, c0 follows loudness
+	// rather than the voice and is left out.
+	coefficients = 13
+	// voicedLevel is the RMS level a frame needs to count as speech, about
+	// -36 dBFS.
+	voicedLevel = 500
+	// minVoicedFrames is about half a second of speech, less tells too
+	// little about the voice to label it.
+	minVoicedFrames = 30
+	// maxAudio bounds the audio waiting for its transcript, 15 seconds.
+	maxAudio = 15 * sampleRate * 2
+	// sameSpeaker is the cosine similarity from which an utterance is taken
+	// to be of a speaker heard before.
+	sameSpeaker = 0.9
+)
+
+// speaker is a cluster of utterances.
+type speaker struct {
+	centroid   []float64
+	utterances int
+}
+
+// Clusterer labels the utterances of a call by their voice, clustering
+// their embeddings online as they come. It is coarse, a fallback for
+// providers not diarizing. It is safe for concurrent use.
+type Clusterer struct {
+	mu          sync.Mutex
+	maxSpeakers int
+	speakers    []*speaker
+	audio       []byte
+}
+
+func NewClusterer(maxSpeakers int) *Clusterer {
+	return &Clusterer{maxSpeakers: max(maxSpeakers, 1)}
+}
+
+// Hear keeps audio of the caller until its transcript arrives, only the
+// latest 15 seconds are kept.
+func (c *Clusterer) Hear(audio []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.audio = append(c.audio, audio...)
+	if over := len(c.audio) - maxAudio; over > 0 {
+		c.audio = c.audio[over+over%2:]
+	}
+}
+
+// Reset drops the audio heard, e.g. when the provider labelled its
+// transcript.
+func (c *Clusterer) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.audio = nil
+}
+
+// Assign labels the audio heard since the last transcript and starts over,
+// "" when there was too little speech in it to tell.
+func (c *Clusterer) Assign() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	embedding := Embed(c.audio)
+	c.audio = nil
+	if embedding == nil {
+		return ""
+	}
+	return Label(c.assign(embedding))
+}
+
+// assign returns the speaker of embedding, a new one while there are fewer
+// than maxSpeakers and none is similar enough, c.mu is held.
+func (c *Clusterer) assign(embedding []float64) int {
+	best, similarity := -1, -1.0
+	for i, s := range c.speakers {
+		if sim := cosine(s.centroid, embedding); sim > similarity {
+			best, similarity = i, sim
+		}
+	}
+	if best < 0 || (similarity < sameSpeaker && len(c.speakers) < c.maxSpeakers) {
+		c.speakers = append(c.speakers, &speaker{centroid: append([]float64(nil), embedding...), utterances: 1})
+		return len(c.speakers) - 1
+	}
+	s := c.speakers[best]
+	s.utterances++
+	for i := range s.centroid {
+		s.centroid[i] += (embedding[i] - s.centroid[i]) / float64(s.utterances)
+	}
+	return best
+}
+
+// Embed returns the embedding of the voice in audio: the mean and spread of
+// its mel cepstrum over the frames with speech, nil when there are too few.
+func Embed(audio []byte) []float64 {
+	samples := make([]float64, len(audio)/2)
+	for i := range samples {
+		samples[i] = float64(int16(binary.LittleEndian.Uint16(audio[2*i:])))
+	}
+	bank := filterBank()
+	var frames [][]float64
+	buffer := make([]complex128, frameSize)
+	for start := 0; start+frameSize <= len(samples); start += hopSize {
+		frame := samples[start : start+frameSize]
+		if rms(frame) < voicedLevel {
+			continue
+		}
+		for i, s := range frame {
+			buffer[i] = complex(s*hann[i], 0)
+		}
+		fft(buffer)
+		energies := make([]float64, melBands)
+		for b, filter := range bank {
+			for k, weight := range filter {
+				if weight > 0 {
+					energies[b] += weight * real(buffer[k]*cmplx.Conj(buffer[k]))
+				}
+			}
+			energies[b] = math.Log(energies[b] + 1)
+		}
+		frames = append(frames, cepstrum(energies))
+	}
+	if len(frames) < minVoicedFrames {
+		return nil
+	}
+
+	embedding := make([]float64, 2*coefficients)
+	for _, f := range frames {
+		for i, v := range f {
+			embedding[i] += v / float64(len(frames))
+		}
+	}
+	for _, f := range frames {
+		for i, v := range f {
+			d := v - embedding[i]
+			embedding[coefficients+i] += d * d / float64(len(frames))
+		}
+	}
+	for i := coefficients; i < len(embedding); i++ {
+		embedding[i] = math.Sqrt(embedding[i])
+	}
+	return embedding
+}
+
+var hann = func() []float64 {
+	w := make([]float64, frameSize)
+	for i := range w {
+		w[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(frameSize-1))
+	}
+	return w
+}()
+
+var (
+	bankOnce sync.Once
+	bank     [][]float64
+)
+
+// filterBank returns the triangular mel filters over the FFT bins, from 100
+// Hz up to 7.6 kHz.
+func filterBank() [][]float64 {
+	bankOnce.Do(func() {
+		mel := func(hz float64) float64 { return 2595 * math.Log10(1+hz/700) }
+		hz := func(mel float64) float64 { return 700 * (math.Pow(10, mel/2595) - 1) }
+		low, high := mel(100), mel(7600)
+		edges := make([]float64, melBands+2)
+		for i := range edges {
+			edges[i] = hz(low+(high-low)*float64(i)/float64(melBands+1)) * frameSize / sampleRate
+		}
+		bank = make([][]float64, melBands)
+		for b := range bank {
+			bank[b] = make([]float64, frameSize/2+1)
+			for k := range bank[b] {
+				bin := float64(k)
+				switch {
+				case bin > edges[b] && bin <= edges[b+1]:
+					bank[b][k] = (bin - edges[b]) / (edges[b+1] - edges[b])
+				case bin > edges[b+1] && bin < edges[b+2]:
+					bank[b][k] = (edges[b+2] - bin) / (edges[b+2] - edges[b+1])
+				}
+			}
+		}
+	})
+	return bank
+}
+
+// cepstrum returns the coefficients 1 to 13 of the DCT of the log mel
+// energies.
+func cepstrum(energies []float64) []float64 {
+	c := make([]float64, coefficients)
+	for i := range c {
+		for b, e := range energies {
+			c[i] += e * math.Cos(math.Pi*float64(i+1)*(float64(b)+0.5)/float64(len(energies)))
+		}
+	}
+	return c
+}
+
+// fft transforms x in place, its length a power of two.
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even, odd := x[start+k], w*x[start+k+size/2]
+				x[start+k], x[start+k+size/2] = even+odd, even-odd
+				w *= step
+			}
+		}
+	}
+}
+
+func rms(samples []float64) float64 {
+	var sum float64
+	for _, s := range samples {
+		sum += s * s
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
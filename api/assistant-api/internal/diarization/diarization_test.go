@@ -0,0 +1,95 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_diarization
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// voice synthesizes a second of a voice: harmonics of pitch whose level
+// falls by tilt dB per harmonic, with some noise.
+func voice(pitch, tilt float64, seed int64) []byte {
+	rnd := rand.New(rand.NewSource(seed))
+	audio := make([]byte, sampleRate*2)
+	for i := 0; i < sampleRate; i++ {
+		t := float64(i) / sampleRate
+		var s float64
+		for h := 1; float64(h)*pitch < 7000; h++ {
+			s += math.Pow(10, -tilt*float64(h-1)/20) * math.Sin(2*math.Pi*pitch*float64(h)*t)
+		}
+		s = 4000*s + 100*rnd.NormFloat64()
+		binary.LittleEndian.PutUint16(audio[2*i:], uint16(int16(max(min(s, math.MaxInt16), math.MinInt16))))
+	}
+	return audio
+}
+
+func TestFromOptions(t *testing.T) {
+	assert.Nil(t, FromOptions(utils.Option{}))
+	assert.Nil(t, FromOptions(utils.Option{OptionsKeyDiarize: "false"}))
+
+	c := FromOptions(utils.Option{OptionsKeyDiarize: "true"})
+	require.NotNil(t, c)
+	assert.Equal(t, DefaultMaxSpeakers, c.maxSpeakers)
+
+	c = FromOptions(utils.Option{OptionsKeyDiarize: "true", OptionsKeyMaxSpeakers: "2"})
+	require.NotNil(t, c)
+	assert.Equal(t, 2, c.maxSpeakers)
+}
+
+func TestEmbed_NeedsSpeech(t *testing.T) {
+	assert.Nil(t, Embed(nil))
+	assert.Nil(t, Embed(make([]byte, sampleRate*2)), "silence is nobody")
+	assert.Nil(t, Embed(voice(120, 6, 1)[:sampleRate/5]), "a fifth of a second is too little")
+	assert.Len(t, Embed(voice(120, 6, 1)), 2*coefficients)
+}
+
+func TestClusterer_TellsSpeakersApart(t *testing.T) {
+	c := NewClusterer(4)
+	low := func(seed int64) []byte { return voice(110+float64(seed), 9, seed) }
+	high := func(seed int64) []byte { return voice(230+float64(seed), 2, seed) }
+
+	c.Hear(low(1))
+	first := c.Assign()
+	assert.Equal(t, "speaker_0", first)
+
+	c.Hear(high(2))
+	second := c.Assign()
+	assert.Equal(t, "speaker_1", second)
+
+	c.Hear(low(3))
+	assert.Equal(t, first, c.Assign(), "the first speaker again")
+	c.Hear(high(4))
+	assert.Equal(t, second, c.Assign(), "the second speaker again")
+
+	assert.Empty(t, c.Assign(), "nothing heard since")
+	c.Hear(low(5))
+	c.Reset()
+	assert.Empty(t, c.Assign())
+}
+
+func TestClusterer_BoundsSpeakers(t *testing.T) {
+	c := NewClusterer(1)
+	c.Hear(voice(110, 9, 1))
+	assert.Equal(t, "speaker_0", c.Assign())
+	c.Hear(voice(230, 2, 2))
+	assert.Equal(t, "speaker_0", c.Assign(), "no more speakers than allowed")
+	assert.Len(t, c.speakers, 1)
+}
+
+func TestClusterer_KeepsLatestAudio(t *testing.T) {
+	c := NewClusterer(2)
+	for i := 0; i < 20; i++ {
+		c.Hear(make([]byte, sampleRate*2+1))
+	}
+	assert.Len(t, c.audio, maxAudio)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_dictation captures long caller utterances (complaint
+// details, notes) across turns, honoring spoken punctuation and formatting
+// commands ("comma", "new paragraph") until the caller says "end dictation"
+// or the maximum duration is reached.
+package internal_dictation
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultMaxDuration guards a dictation nobody ends.
+	DefaultMaxDuration = 5 * time.Minute
+
+	// MaxDuration is the longest dictation that can be asked for.
+	MaxDuration = 30 * time.Minute
+)
+
+// Reasons a dictation ended.
+const (
+	EndedBySpeech      = "speech"
+	EndedByMaxDuration = "max_duration"
+)
+
+// commands are the spoken formatting commands and what they write.
+var commands = map[string]string{
+	"comma":             ",",
+	"period":            ".",
+	"full stop":         ".",
+	"question mark":     "?",
+	"exclamation mark":  "!",
+	"exclamation point": "!",
+	"colon":             ":",
+	"semicolon":         ";",
+	"new line":          "\n",
+	"next line":         "\n",
+	"new paragraph":     "\n\n",
+	"open quote":        "“",
+	"close quote":       "”",
+	"open parenthesis":  "(",
+	"close parenthesis": ")",
+	"open bracket":      "(",
+	"close bracket":     ")",
+}
+
+// endPhrases end a dictation when they close an utterance.
+var endPhrases = []string{"end dictation", "end of dictation", "stop dictation", "finish dictation"}
+
+// Document is a finished dictation as attached to the conversation.
+type Document struct {
+	Text      string    `json:"text"`
+	Words     int       `json:"words"`
+	StartedAt time.Time `json:"started_at"`
+	Seconds   float64   `json:"duration_seconds"`
+	EndedBy   string    `json:"ended_by"`
+}
+
+// Dictation collects the utterances of one dictation.
+type Dictation struct {
+	mu          sync.Mutex
+	startedAt   time.Time
+	maxDuration time.Duration
+	text        strings.Builder
+}
+
+// Limit returns the maximum duration a dictation asking for maxDuration
+// gets. Zero means DefaultMaxDuration, longer than MaxDuration is capped.
+func Limit(maxDuration time.Duration) time.Duration {
+	if maxDuration <= 0 {
+		return DefaultMaxDuration
+	}
+	return min(maxDuration, MaxDuration)
+}
+
+// New starts a dictation that ends after Limit(maxDuration) at the latest.
+func New(maxDuration time.Duration) *Dictation {
+	return &Dictation{startedAt: time.Now(), maxDuration: Limit(maxDuration)}
+}
+
+// MaxDuration returns how long the dictation may run.
+func (d *Dictation) MaxDuration() time.Duration {
+	return d.maxDuration
+}
+
+// Add appends an utterance and reports whether the caller ended the
+// dictation with it.
+func (d *Dictation) Add(speech string) bool {
+	words := strings.Fields(speech)
+	ended := false
+	for _, phrase := range endPhrases {
+		n := len(strings.Fields(phrase))
+		if len(words) >= n && bare(strings.Join(words[len(words)-n:], " ")) == phrase {
+			words, ended = words[:len(words)-n], true
+			break
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	write(&d.text, words)
+	return ended
+}
+
+// Expired reports whether the dictation ran past its maximum duration.
+func (d *Dictation) Expired(now time.Time) bool {
+	return now.Sub(d.startedAt) >= d.maxDuration
+}
+
+// Close returns the dictation as a document.
+func (d *Dictation) Close(endedBy string, now time.Time) Document {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	text := strings.TrimSpace(d.text.String())
+	return Document{
+		Text:      text,
+		Words:     len(strings.Fields(text)),
+		StartedAt: d.startedAt,
+		Seconds:   now.Sub(d.startedAt).Seconds(),
+		EndedBy:   endedBy,
+	}
+}
+
+// Format renders a single utterance with its spoken commands applied.
+func Format(speech string) string {
+	var b strings.Builder
+	write(&b, strings.Fields(speech))
+	return strings.TrimSpace(b.String())
+}
+
+// write appends words to b, replacing spoken commands. Punctuation the
+// recognizer put around a command word is its guess at the command and is
+// dropped. Sentences and paragraphs start with a capital letter.
+func write(b *strings.Builder, words []string) {
+	for i := 0; i < len(words); i++ {
+		mark, n := command(words[i:])
+		if n == 0 {
+			writeWord(b, words[i])
+			continue
+		}
+		i += n - 1
+		text := strings.TrimRight(b.String(), " ")
+		b.Reset()
+		b.WriteString(text)
+		switch mark {
+		case "\n", "\n\n":
+			b.WriteString(mark)
+		case "“", "(":
+			if text != "" && !strings.HasSuffix(text, "\n") {
+				b.WriteString(" ")
+			}
+			b.WriteString(mark)
+		default:
+			b.WriteString(mark + " ")
+		}
+	}
+}
+
+// command matches a two or one word command at the start of words.
+func command(words []string) (string, int) {
+	if len(words) >= 2 {
+		if mark, ok := commands[bare(words[0]+" "+words[1])]; ok {
+			return mark, 2
+		}
+	}
+	if mark, ok := commands[bare(words[0])]; ok {
+		return mark, 1
+	}
+	return "", 0
+}
+
+func writeWord(b *strings.Builder, word string) {
+	text := b.String()
+	if text != "" && !strings.HasSuffix(text, " ") && !strings.HasSuffix(text, "\n") &&
+		!strings.HasSuffix(text, "“") && !strings.HasSuffix(text, "(") {
+		b.WriteString(" ")
+	}
+	if sentenceStart(strings.TrimRight(text, " “(")) {
+		r := []rune(word)
+		r[0] = unicode.ToUpper(r[0])
+		word = string(r)
+	}
+	b.WriteString(word)
+}
+
+func sentenceStart(text string) bool {
+	return text == "" || strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") ||
+		strings.HasSuffix(text, "!") || strings.HasSuffix(text, "\n")
+}
+
+// bare lower-cases a phrase and strips punctuation around its words.
+func bare(phrase string) string {
+	words := strings.Fields(strings.ToLower(phrase))
+	for i, word := range words {
+		words[i] = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_dictation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		speech string
+		want   string
+	}{
+		{"the parcel was late comma and damaged period", "The parcel was late, and damaged."},
+		{"is it covered Question mark.", "Is it covered?"},
+		{"first point new line second point", "First point\nSecond point"},
+		{"dear team new paragraph thanks", "Dear team\n\nThanks"},
+		{"he said open quote not my problem close quote period", "He said “not my problem”."},
+		{"call me exclamation mark", "Call me!"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Format(tt.speech), tt.speech)
+	}
+}
+
+func TestDictation_AddAndClose(t *testing.T) {
+	d := New(0)
+	assert.Equal(t, DefaultMaxDuration, d.MaxDuration())
+
+	assert.False(t, d.Add("my order never arrived period"))
+	assert.False(t, d.Add("I want a refund comma please"))
+	assert.True(t, d.Add("that is all period End dictation."))
+
+	doc := d.Close(EndedBySpeech, time.Now())
+	assert.Equal(t, "My order never arrived. I want a refund, please that is all.", doc.Text)
+	assert.Equal(t, 12, doc.Words)
+	assert.Equal(t, EndedBySpeech, doc.EndedBy)
+}
+
+func TestDictation_MaxDuration(t *testing.T) {
+	d := New(time.Hour)
+	assert.Equal(t, MaxDuration, d.MaxDuration())
+
+	assert.False(t, d.Expired(time.Now()))
+	assert.True(t, d.Expired(time.Now().Add(MaxDuration)))
+}
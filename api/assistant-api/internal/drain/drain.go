@@ -0,0 +1,134 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_drain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default is the drain of this replica.
+var Default = New()
+
+// pollInterval is how often Wait looks at the active sessions.
+const pollInterval = 500 * time.Millisecond
+
+// Drain takes a replica out of rotation for a rolling deploy: once started
+// it refuses new sessions while the active ones finish, up to a deadline.
+type Drain struct {
+	active   atomic.Int64
+	draining atomic.Bool
+
+	mu       sync.Mutex
+	since    time.Time
+	deadline time.Time
+}
+
+// Progress is where a drain is, reported on the health endpoint.
+type Progress struct {
+	Draining       bool      `json:"draining"`
+	ActiveSessions int64     `json:"activeSessions"`
+	Since          time.Time `json:"since,omitempty"`
+	Deadline       time.Time `json:"deadline,omitempty"`
+}
+
+// New returns a drain that was not started.
+func New() *Drain {
+	return &Drain{}
+}
+
+// Start starts draining, active sessions have until deadline to finish. It
+// returns false when the drain was already started.
+func (d *Drain) Start(deadline time.Duration) bool {
+	if !d.draining.CompareAndSwap(false, true) {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.since = time.Now()
+	d.deadline = d.since.Add(deadline)
+	return true
+}
+
+// Draining reports whether new sessions are refused.
+func (d *Drain) Draining() bool {
+	return d.draining.Load()
+}
+
+// SessionStarted counts a session that connected.
+func (d *Drain) SessionStarted() {
+	d.active.Add(1)
+}
+
+// SessionEnded counts a session that disconnected.
+func (d *Drain) SessionEnded() {
+	d.active.Add(-1)
+}
+
+// Active is the number of connected sessions.
+func (d *Drain) Active() int64 {
+	return d.active.Load()
+}
+
+// Progress returns the state of the drain.
+func (d *Drain) Progress() Progress {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return Progress{
+		Draining:       d.Draining(),
+		ActiveSessions: d.Active(),
+		Since:          d.since,
+		Deadline:       d.deadline,
+	}
+}
+
+// Wait blocks until the active sessions finished, the deadline passed or ctx
+// is done, and returns the sessions still active. progress, when not nil, is
+// called with the sessions still active every time their number changes.
+func (d *Drain) Wait(ctx context.Context, progress func(active int64)) int64 {
+	d.mu.Lock()
+	deadline := d.deadline
+	d.mu.Unlock()
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	last := int64(-1)
+	for {
+		active := d.Active()
+		if active <= 0 {
+			return 0
+		}
+		if active != last && progress != nil {
+			progress(active)
+		}
+		last = active
+		select {
+		case <-ctx.Done():
+			return d.Active()
+		case <-ticker.C:
+		}
+	}
+}
+
+// StreamServerInterceptor refuses new streams with UNAVAILABLE while
+// draining, clients reconnect to another replica.
+func (d *Drain) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if d.Draining() {
+			return status.Error(codes.Unavailable, "server is draining, reconnect")
+		}
+		return handler(srv, ss)
+	}
+}
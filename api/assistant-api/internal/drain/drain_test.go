@@ -0,0 +1,60 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_drain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrain_WaitForSessions(t *testing.T) {
+	d := New()
+	d.SessionStarted()
+	d.SessionStarted()
+	assert.False(t, d.Draining())
+
+	assert.True(t, d.Start(time.Minute))
+	assert.False(t, d.Start(time.Minute))
+	assert.True(t, d.Progress().Draining)
+	assert.Equal(t, int64(2), d.Progress().ActiveSessions)
+
+	go func() {
+		d.SessionEnded()
+		time.Sleep(10 * time.Millisecond)
+		d.SessionEnded()
+	}()
+	assert.Equal(t, int64(0), d.Wait(context.Background(), nil))
+}
+
+func TestDrain_WaitDeadline(t *testing.T) {
+	d := New()
+	d.SessionStarted()
+	d.Start(20 * time.Millisecond)
+
+	var reported []int64
+	assert.Equal(t, int64(1), d.Wait(context.Background(), func(active int64) {
+		reported = append(reported, active)
+	}))
+	assert.Equal(t, []int64{1}, reported)
+}
+
+func TestDrain_StreamServerInterceptor(t *testing.T) {
+	d := New()
+	interceptor := d.StreamServerInterceptor()
+	handler := func(interface{}, grpc.ServerStream) error { return nil }
+
+	assert.NoError(t, interceptor(nil, nil, &grpc.StreamServerInfo{}, handler))
+	d.Start(time.Minute)
+	err := interceptor(nil, nil, &grpc.StreamServerInfo{}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
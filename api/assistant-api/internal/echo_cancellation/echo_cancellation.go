@@ -0,0 +1,319 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_echo_cancellation removes the assistant's own voice from
+// the caller audio of clients that play it on a speaker without acoustic echo
+// cancellation, so the assistant does not hear itself and barge in on its own
+// speech. The audio the channel played is the reference, an adaptive (NLMS)
+// filter models the path back into the microphone.
+package internal_echo_cancellation
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Echo cancellation is configured on the speech to text options of a
+// deployment, it is off unless enabled:
+//
+//	microphone.echo_cancellation           = true
+//	microphone.echo_cancellation.tail_ms   = 64
+//	microphone.echo_cancellation.max_delay = 500
+const (
+	OptionsKeyEnable   = "microphone.echo_cancellation"
+	OptionsKeyTail     = "microphone.echo_cancellation.tail_ms"
+	OptionsKeyMaxDelay = "microphone.echo_cancellation.max_delay"
+)
+
+const (
+	// DefaultTail is how long an echo the filter models once aligned, the
+	// reverberation of a room rather than the round trip.
+	DefaultTail = 64 * time.Millisecond
+
+	// DefaultMaxDelay is the longest round trip from playing a frame to
+	// hearing it back that is searched for, network both ways included.
+	DefaultMaxDelay = 500 * time.Millisecond
+)
+
+const (
+	// blockSamples is the resolution of the delay search.
+	blockSamples = 64
+	// estimateEvery is how often the delay is searched for again.
+	estimateEvery = 500 * time.Millisecond
+	// minCorrelation is the least envelope correlation taken as an echo.
+	minCorrelation = 0.5
+	// maxBacklog bounds the played audio waiting to be heard, a channel that
+	// writes ahead of real time is cut back to it.
+	maxBacklog = time.Second
+
+	stepSize = 0.3
+	// doubleTalk is the Geigel threshold, a microphone sample this close to
+	// the loudest recent reference sample is the caller speaking.
+	doubleTalk = 0.6
+)
+
+// Config of a canceller.
+type Config struct {
+	SampleRate int // of the linear16 mono audio both ways
+	Tail       time.Duration
+	MaxDelay   time.Duration
+}
+
+// FromOptions returns the configuration in opts, false when echo
+// cancellation is not enabled.
+func FromOptions(opts utils.Option, sampleRate int) (Config, bool) {
+	enabled, err := opts.GetString(OptionsKeyEnable)
+	if err != nil {
+		return Config{}, false
+	}
+	if on, err := strconv.ParseBool(strings.TrimSpace(enabled)); err != nil || !on {
+		return Config{}, false
+	}
+	cfg := Config{SampleRate: sampleRate, Tail: DefaultTail, MaxDelay: DefaultMaxDelay}
+	if ms, err := opts.GetFloat64(OptionsKeyTail); err == nil && ms > 0 {
+		cfg.Tail = time.Duration(ms * float64(time.Millisecond))
+	}
+	if ms, err := opts.GetFloat64(OptionsKeyMaxDelay); err == nil && ms >= 0 {
+		cfg.MaxDelay = time.Duration(ms * float64(time.Millisecond))
+	}
+	return cfg, true
+}
+
+// Channel is implemented by streamers that can cancel the echo of the audio
+// they play from the audio they receive.
+type Channel interface {
+	// EnableEchoCancellation starts cancelling echo with the configuration.
+	EnableEchoCancellation(cfg Config)
+}
+
+// Canceller cancels the echo of played audio from microphone audio. Both are
+// linear16 mono at the configured rate and are expected in real time, the
+// played audio as it goes out and the microphone audio as it comes in.
+type Canceller struct {
+	mu sync.Mutex
+
+	sampleRate int
+	taps       int
+	maxDelay   int
+
+	// played audio not yet lined up with the microphone
+	backlog    []float64
+	maxBacklog int
+
+	// history is the reference lined up with the microphone, long enough
+	// for the delay and the filter. It is a ring written twice, at pos and
+	// pos+size, so the latest size samples are always history[pos:pos+size]
+	// in order.
+	history []float64
+	size    int
+	pos     int
+	weights []float64
+	delay   int
+
+	// envelopes of the reference and the microphone for the delay search
+	refBlocks, micBlocks []float64
+	refAcc, micAcc       float64
+	blockFill            int
+	sinceEstimate        int
+	estimateInterval     int
+}
+
+// NewCanceller returns a canceller for the configuration.
+func NewCanceller(cfg Config) *Canceller {
+	perMs := float64(cfg.SampleRate) / 1000
+	taps := max(1, int(perMs*float64(cfg.Tail.Milliseconds())))
+	maxDelay := int(perMs * float64(cfg.MaxDelay.Milliseconds()))
+	// the envelopes cover the delays searched and a second to compare
+	window := maxDelay/blockSamples + int(perMs*1000)/blockSamples
+	return &Canceller{
+		sampleRate:       cfg.SampleRate,
+		taps:             taps,
+		maxDelay:         maxDelay,
+		maxBacklog:       int(perMs * float64(maxBacklog.Milliseconds())),
+		history:          make([]float64, 2*(maxDelay+taps)),
+		size:             maxDelay + taps,
+		weights:          make([]float64, taps),
+		refBlocks:        make([]float64, 0, window),
+		micBlocks:        make([]float64, 0, window),
+		estimateInterval: int(perMs * float64(estimateEvery.Milliseconds())),
+	}
+}
+
+// Played adds audio the channel played to the reference.
+func (c *Canceller) Played(pcm []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i+1 < len(pcm); i += 2 {
+		c.backlog = append(c.backlog, float64(int16(binary.LittleEndian.Uint16(pcm[i:]))))
+	}
+	if over := len(c.backlog) - c.maxBacklog; over > 0 {
+		c.backlog = c.backlog[over:]
+	}
+}
+
+// Cancel returns the microphone audio with the echo of the played audio
+// taken out.
+func (c *Canceller) Cancel(pcm []byte) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]byte, len(pcm))
+	for i := 0; i+1 < len(pcm); i += 2 {
+		// the reference advances with the microphone, silence while nothing
+		// was played
+		far := 0.0
+		if len(c.backlog) > 0 {
+			far, c.backlog = c.backlog[0], c.backlog[1:]
+		}
+		c.history[c.pos], c.history[c.pos+c.size] = far, far
+		c.pos = (c.pos + 1) % c.size
+
+		near := float64(int16(binary.LittleEndian.Uint16(pcm[i:])))
+		c.track(far, near)
+
+		e := c.filter(near)
+		binary.LittleEndian.PutUint16(out[i:], uint16(int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, e)))))
+	}
+	return out
+}
+
+// Reset forgets the reference and what was learned of the echo path, for a
+// new media session.
+func (c *Canceller) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.backlog = c.backlog[:0]
+	clear(c.history)
+	clear(c.weights)
+	c.pos, c.delay = 0, 0
+	c.refBlocks, c.micBlocks = c.refBlocks[:0], c.micBlocks[:0]
+	c.refAcc, c.micAcc, c.blockFill, c.sinceEstimate = 0, 0, 0, 0
+}
+
+// Delay is where the filter starts, a little short of the round trip of the
+// echo found.
+func (c *Canceller) Delay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Duration(c.delay) * time.Second / time.Duration(c.sampleRate)
+}
+
+// filter runs one microphone sample through the adaptive filter and returns
+// what is left of it.
+func (c *Canceller) filter(near float64) float64 {
+	// the reference window the filter sees ends delay samples back
+	window := c.history[c.pos : c.pos+c.size]
+	// weights are kept in the order of x, the last tap is the latest sample
+	x := window[c.size-c.delay-c.taps : c.size-c.delay]
+	w := c.weights[:len(x)]
+
+	var y, power, peak float64
+	for k, v := range x {
+		y += w[k] * v
+		sq := v * v
+		power += sq
+		if sq > peak {
+			peak = sq
+		}
+	}
+	e := near - y
+	// adapt only to echo, not while the caller talks or nothing was played
+	if power > 0 && near*near < doubleTalk*doubleTalk*peak {
+		g := stepSize * e / (power + 1)
+		for k, v := range x {
+			w[k] += g * v
+		}
+	}
+	return e
+}
+
+// track keeps the envelopes and searches for the delay every estimateEvery.
+func (c *Canceller) track(far, near float64) {
+	c.refAcc += math.Abs(far)
+	c.micAcc += math.Abs(near)
+	c.blockFill++
+	if c.blockFill == blockSamples {
+		c.refBlocks = appendWindow(c.refBlocks, c.refAcc)
+		c.micBlocks = appendWindow(c.micBlocks, c.micAcc)
+		c.refAcc, c.micAcc, c.blockFill = 0, 0, 0
+	}
+	c.sinceEstimate++
+	if c.sinceEstimate < c.estimateInterval {
+		return
+	}
+	c.sinceEstimate = 0
+	if lag, ok := estimateDelay(c.refBlocks, c.micBlocks, c.maxDelay/blockSamples); ok {
+		// the envelopes are only block accurate, start the filter two blocks
+		// early so the first of the echo is not missed
+		delay := max(0, (lag-2)*blockSamples)
+		if abs(delay-c.delay) >= blockSamples {
+			// a new path, what was learned of the old one does not apply
+			c.delay = delay
+			clear(c.weights)
+		}
+	}
+}
+
+func appendWindow(window []float64, v float64) []float64 {
+	if len(window) == cap(window) {
+		copy(window, window[1:])
+		window = window[:len(window)-1]
+	}
+	return append(window, v)
+}
+
+// estimateDelay returns the lag in blocks at which the microphone envelope
+// follows the reference envelope best, false when the reference was silent
+// or nothing in the microphone resembles it.
+func estimateDelay(ref, mic []float64, maxLag int) (int, bool) {
+	n := len(mic)
+	if len(ref) != n || n <= maxLag+1 {
+		return 0, false
+	}
+	best, bestLag := 0.0, 0
+	for lag := 0; lag <= maxLag; lag++ {
+		r, m := ref[:n-lag], mic[lag:]
+		if corr := correlation(r, m); corr > best {
+			best, bestLag = corr, lag
+		}
+	}
+	return bestLag, best >= minCorrelation
+}
+
+// correlation is the Pearson correlation of two equally long series, zero
+// when either is flat.
+func correlation(a, b []float64) float64 {
+	var sa, sb float64
+	for i := range a {
+		sa += a[i]
+		sb += b[i]
+	}
+	ma, mb := sa/float64(len(a)), sb/float64(len(b))
+	var cov, va, vb float64
+	for i := range a {
+		da, db := a[i]-ma, b[i]-mb
+		cov += da * db
+		va += da * da
+		vb += db * db
+	}
+	if va == 0 || vb == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(va*vb)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_echo_cancellation
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rate  = 16000
+	frame = rate / 50 // 20ms
+)
+
+// speechLike is noise shaped by a syllable rate envelope, so its envelope
+// has something to line up with.
+func speechLike(rng *rand.Rand, n int, level float64) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		envelope := 0.5 + 0.5*math.Sin(2*math.Pi*4*float64(i)/rate)
+		out[i] = level * envelope * envelope * (rng.Float64()*2 - 1)
+	}
+	return out
+}
+
+func toPCM(samples []float64) []byte {
+	out := make([]byte, len(samples)*2)
+	for i, v := range samples {
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(int16(v)))
+	}
+	return out
+}
+
+func energy(pcm []byte) float64 {
+	var sum float64
+	for i := 0; i+1 < len(pcm); i += 2 {
+		v := float64(int16(binary.LittleEndian.Uint16(pcm[i:])))
+		sum += v * v
+	}
+	return sum
+}
+
+// echoOf is far played back through a room: delayed by the round trip,
+// attenuated, with a short reflection.
+func echoOf(far []float64, i int, delay int) float64 {
+	at := func(j int) float64 {
+		if j < 0 {
+			return 0
+		}
+		return far[j]
+	}
+	return 0.5*at(i-delay) + 0.2*at(i-delay-40)
+}
+
+// run plays far and feeds the microphone in 20ms frames and returns the
+// microphone audio and the cancelled audio of the last second.
+func run(c *Canceller, far, caller []float64, delay int) (mic, out []byte) {
+	for start := 0; start+frame <= len(far); start += frame {
+		c.Played(toPCM(far[start : start+frame]))
+		near := make([]float64, frame)
+		for i := range near {
+			near[i] = echoOf(far, start+i, delay) + caller[start+i]
+		}
+		pcm := toPCM(near)
+		cancelled := c.Cancel(pcm)
+		if start >= len(far)-rate {
+			mic, out = append(mic, pcm...), append(out, cancelled...)
+		}
+	}
+	return mic, out
+}
+
+func TestFromOptions(t *testing.T) {
+	_, ok := FromOptions(utils.Option{}, rate)
+	assert.False(t, ok, "off unless enabled")
+	_, ok = FromOptions(utils.Option{OptionsKeyEnable: "false"}, rate)
+	assert.False(t, ok)
+
+	cfg, ok := FromOptions(utils.Option{OptionsKeyEnable: true}, rate)
+	require.True(t, ok)
+	assert.Equal(t, Config{SampleRate: rate, Tail: DefaultTail, MaxDelay: DefaultMaxDelay}, cfg)
+
+	cfg, ok = FromOptions(utils.Option{OptionsKeyEnable: "true", OptionsKeyTail: "32", OptionsKeyMaxDelay: 300}, rate)
+	require.True(t, ok)
+	assert.Equal(t, 32*time.Millisecond, cfg.Tail)
+	assert.Equal(t, 300*time.Millisecond, cfg.MaxDelay)
+}
+
+func TestCanceller_RemovesEcho(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	far := speechLike(rng, 6*rate, 8000)
+	silent := make([]float64, len(far))
+	delay := 180 * rate / 1000
+
+	c := NewCanceller(Config{SampleRate: rate, Tail: DefaultTail, MaxDelay: DefaultMaxDelay})
+	mic, out := run(c, far, silent, delay)
+
+	assert.InDelta(t, 180*time.Millisecond, c.Delay(), float64(20*time.Millisecond), "round trip found")
+	erle := 10 * math.Log10(energy(mic)/energy(out))
+	assert.Greater(t, erle, 15.0, "echo return loss enhancement in dB")
+}
+
+func TestCanceller_KeepsCaller(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	far := speechLike(rng, 6*rate, 8000)
+	caller := make([]float64, len(far))
+	// the caller starts speaking over the assistant for the last second
+	copy(caller[5*rate:], speechLike(rng, rate, 6000))
+	delay := 120 * rate / 1000
+
+	c := NewCanceller(Config{SampleRate: rate, Tail: DefaultTail, MaxDelay: DefaultMaxDelay})
+	_, out := run(c, far, caller, delay)
+
+	callerEnergy := energy(toPCM(caller[5*rate:]))
+	assert.InDelta(t, 1.0, energy(out)/callerEnergy, 0.35, "the caller is left, less the echo")
+}
+
+func TestCanceller_NothingPlayed(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	caller := toPCM(speechLike(rng, frame, 5000))
+	c := NewCanceller(Config{SampleRate: rate, Tail: DefaultTail, MaxDelay: DefaultMaxDelay})
+	assert.Equal(t, caller, c.Cancel(caller))
+
+	c.Played(caller)
+	c.Reset()
+	assert.Equal(t, caller, c.Cancel(caller), "reset drops the reference")
+}
+
+// BenchmarkCancel measures the latency added to each 20ms frame of caller
+// audio with the default tail.
+func BenchmarkCancel(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	played := toPCM(speechLike(rng, frame, 8000))
+	mic := toPCM(speechLike(rng, frame, 4000))
+	c := NewCanceller(Config{SampleRate: rate, Tail: DefaultTail, MaxDelay: DefaultMaxDelay})
+
+	b.SetBytes(int64(len(mic)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Played(played)
+		c.Cancel(mic)
+	}
+}
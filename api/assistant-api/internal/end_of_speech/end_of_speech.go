@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 
+	internal_endpointing "github.com/rapidaai/api/assistant-api/internal/end_of_speech/internal/endpointing"
 	internal_silence_based "github.com/rapidaai/api/assistant-api/internal/end_of_speech/internal/silence_based"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
@@ -20,12 +21,25 @@ type EndOfSpeechIdentifier string
 const (
 	SilenceBasedEndOfSpeech       EndOfSpeechIdentifier = "silence_based_eos"
 	LiveKitEndOfSpeech            EndOfSpeechIdentifier = "livekit_eos"
+	EndpointingEndOfSpeech        EndOfSpeechIdentifier = "endpointing_eos"
 	EndOfSpeechOptionsKeyProvider                       = "microphone.eos.provider"
 )
 
+// GetEndOfSpeech returns the end of speech detector configured in opts.
+// Without a provider, setting any endpointing knob selects endpointing over
+// the fixed silence timeout.
 func GetEndOfSpeech(ctx context.Context, logger commons.Logger, onCallback func(context.Context, ...internal_type.Packet) error, opts utils.Option) (internal_type.EndOfSpeech, error) {
 	provider, _ := opts.GetString(EndOfSpeechOptionsKeyProvider)
+	if provider == "" {
+		_, delay := opts[internal_endpointing.OptionsKeySpeechFinalDelay]
+		_, silence := opts[internal_endpointing.OptionsKeyMaxTurnSilence]
+		if delay || silence {
+			provider = string(EndpointingEndOfSpeech)
+		}
+	}
 	switch EndOfSpeechIdentifier(provider) {
+	case EndpointingEndOfSpeech:
+		return internal_endpointing.NewEndpointingEndOfSpeech(logger, onCallback, opts)
 	case SilenceBasedEndOfSpeech:
 		return internal_silence_based.NewSilenceBasedEndOfSpeech(logger, onCallback, opts)
 	case LiveKitEndOfSpeech:
@@ -42,9 +42,34 @@ func TestGetEndOfSpeech_LiveKitIdentifier(t *testing.T) {
 	assert.Nil(t, endOfSpeech)
 }
 
+func TestGetEndOfSpeech_EndpointingIdentifier(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	endOfSpeech, err := GetEndOfSpeech(t.Context(), logger, mockCallback, utils.Option{EndOfSpeechOptionsKeyProvider: EndpointingEndOfSpeech})
+	require.NoError(t, err)
+	assert.Equal(t, "endpointingEndOfSpeech", endOfSpeech.Name())
+}
+
+func TestGetEndOfSpeech_EndpointingKnobs(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	endOfSpeech, err := GetEndOfSpeech(t.Context(), logger, mockCallback, utils.Option{"microphone.eos.max_turn_silence_ms": 1800})
+	require.NoError(t, err)
+	assert.Equal(t, "endpointingEndOfSpeech", endOfSpeech.Name())
+
+	// an explicit provider wins over the knobs
+	endOfSpeech, err = GetEndOfSpeech(t.Context(), logger, mockCallback, utils.Option{
+		EndOfSpeechOptionsKeyProvider:          SilenceBasedEndOfSpeech,
+		"microphone.eos.speech_final_delay_ms": 200,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "silenceBasedEndOfSpeech", endOfSpeech.Name())
+}
+
 func TestEndOfSpeechIdentifier_Constants(t *testing.T) {
 	assert.Equal(t, EndOfSpeechIdentifier("silence_based_eos"), SilenceBasedEndOfSpeech)
 	assert.Equal(t, EndOfSpeechIdentifier("livekit_eos"), LiveKitEndOfSpeech)
+	assert.Equal(t, EndOfSpeechIdentifier("endpointing_eos"), EndpointingEndOfSpeech)
 	assert.NotEqual(t, SilenceBasedEndOfSpeech, LiveKitEndOfSpeech)
 }
 
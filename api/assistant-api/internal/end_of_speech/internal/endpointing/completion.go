@@ -0,0 +1,58 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_endpointing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Completion is how finished an utterance reads.
+type Completion int
+
+const (
+	// Uncertain gives no hint either way.
+	Uncertain Completion = iota
+	// Complete ends like a finished sentence.
+	Complete
+	// Incomplete trails off, the user is likely to go on.
+	Incomplete
+)
+
+// trailing are words a finished turn rarely ends on: conjunctions,
+// prepositions, articles and fillers.
+var trailing = map[string]bool{
+	"and": true, "or": true, "but": true, "so": true, "because": true, "if": true, "then": true,
+	"to": true, "of": true, "for": true, "with": true, "in": true, "on": true, "at": true, "from": true,
+	"the": true, "a": true, "an": true, "my": true, "your": true, "is": true, "was": true,
+	"um": true, "uh": true, "erm": true, "hmm": true, "like": true,
+}
+
+// Classify reads the transcript of a turn for whether the user is done. The
+// punctuation is what speech to text providers put in, the word list is
+// English.
+func Classify(text string) Completion {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Uncertain
+	}
+	switch {
+	case strings.HasSuffix(text, "...") || strings.HasSuffix(text, "…"):
+		return Incomplete
+	case strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") || strings.HasSuffix(text, "!"):
+		return Complete
+	case strings.HasSuffix(text, ",") || strings.HasSuffix(text, ":") || strings.HasSuffix(text, ";") || strings.HasSuffix(text, "-"):
+		return Incomplete
+	}
+	words := strings.Fields(text)
+	last := strings.ToLower(strings.TrimFunc(words[len(words)-1], func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}))
+	if trailing[last] {
+		return Incomplete
+	}
+	return Uncertain
+}
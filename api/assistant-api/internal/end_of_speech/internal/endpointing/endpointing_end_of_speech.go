@@ -0,0 +1,226 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_endpointing
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Options of the speech to text deployment, in ms.
+const (
+	// OptionsKeySpeechFinalDelay is the silence after which a turn that
+	// reads finished ends.
+	OptionsKeySpeechFinalDelay = "microphone.eos.speech_final_delay_ms"
+	// OptionsKeyMaxTurnSilence is the longest silence a turn that trails off
+	// waits for the user to go on.
+	OptionsKeyMaxTurnSilence = "microphone.eos.max_turn_silence_ms"
+	// OptionsKeyTimeout is the silence for turns that give no hint, the same
+	// option the silence based detector uses.
+	OptionsKeyTimeout = "microphone.eos.timeout"
+)
+
+// Defaults of the options.
+const (
+	DefaultSpeechFinalDelay = 300 * time.Millisecond
+	DefaultTimeout          = 1000 * time.Millisecond
+	DefaultMaxTurnSilence   = 2000 * time.Millisecond
+)
+
+// Config are the silences the detector waits for.
+type Config struct {
+	SpeechFinalDelay time.Duration
+	Timeout          time.Duration
+	MaxTurnSilence   time.Duration
+}
+
+// ConfigFromOptions reads the config from opts. No silence exceeds the max
+// turn silence.
+func ConfigFromOptions(opts utils.Option) Config {
+	get := func(key string, fallback time.Duration) time.Duration {
+		if v, err := opts.GetFloat64(key); err == nil && v >= 0 {
+			return time.Duration(v) * time.Millisecond
+		}
+		return fallback
+	}
+	cfg := Config{
+		SpeechFinalDelay: get(OptionsKeySpeechFinalDelay, DefaultSpeechFinalDelay),
+		Timeout:          get(OptionsKeyTimeout, DefaultTimeout),
+		MaxTurnSilence:   get(OptionsKeyMaxTurnSilence, DefaultMaxTurnSilence),
+	}
+	cfg.Timeout = min(cfg.Timeout, cfg.MaxTurnSilence)
+	cfg.SpeechFinalDelay = min(cfg.SpeechFinalDelay, cfg.Timeout)
+	return cfg
+}
+
+// Silence returns how long to wait after the user said text.
+func (c Config) Silence(text string) time.Duration {
+	switch Classify(text) {
+	case Complete:
+		return c.SpeechFinalDelay
+	case Incomplete:
+		return c.MaxTurnSilence
+	}
+	return c.Timeout
+}
+
+// EndpointingEOS decides the user finished speaking from voice activity, the
+// stability of interim transcripts and how the transcript reads. Every sign
+// of the user speaking, voice activity or an interim transcript that
+// changed, restarts the wait; a finished sentence is waited on shortly and
+// one trailing off up to the max turn silence. An interim transcript that
+// stays the same is no sign of speech, the wait runs on.
+type EndpointingEOS struct {
+	logger   commons.Logger
+	callback func(context.Context, ...internal_type.Packet) error
+	config   Config
+
+	mu         sync.Mutex
+	contextID  string
+	text       string // final transcripts of the turn so far
+	interim    string // latest interim transcript after them
+	timer      *time.Timer
+	generation uint64
+	closed     bool
+}
+
+// NewEndpointingEndOfSpeech creates an endpointing end of speech detector.
+func NewEndpointingEndOfSpeech(logger commons.Logger, callback func(context.Context, ...internal_type.Packet) error, opts utils.Option) (internal_type.EndOfSpeech, error) {
+	return &EndpointingEOS{
+		logger:   logger,
+		callback: callback,
+		config:   ConfigFromOptions(opts),
+	}, nil
+}
+
+// Name returns the component name
+func (eos *EndpointingEOS) Name() string {
+	return "endpointingEndOfSpeech"
+}
+
+// Analyze processes incoming speech packets
+func (eos *EndpointingEOS) Analyze(ctx context.Context, pkt internal_type.Packet) error {
+	switch p := pkt.(type) {
+	case internal_type.UserTextPacket:
+		if p.Text == "" {
+			return nil
+		}
+		eos.mu.Lock()
+		eos.contextID, eos.text, eos.interim = p.ContextId(), p.Text, ""
+		eos.mu.Unlock()
+		eos.notify(ctx, p.ContextId(), p.Text)
+		eos.expire(ctx, 0, true)
+
+	case internal_type.InterruptionPacket:
+		eos.mu.Lock()
+		defer eos.mu.Unlock()
+		if eos.text == "" {
+			return nil
+		}
+		eos.schedule(ctx)
+
+	case internal_type.SpeechToTextPacket:
+		script := strings.TrimSpace(p.Script)
+		eos.mu.Lock()
+		if p.Interim {
+			defer eos.mu.Unlock()
+			if script == "" || script == eos.interim {
+				return nil
+			}
+			eos.interim = script
+			if eos.text == "" {
+				return nil
+			}
+			eos.schedule(ctx)
+			return nil
+		}
+		if script == "" {
+			eos.mu.Unlock()
+			return nil
+		}
+		if eos.text != "" {
+			eos.text += " " + script
+		} else {
+			eos.text = script
+		}
+		eos.contextID, eos.interim = p.ContextId(), ""
+		contextID, text := eos.contextID, eos.text
+		eos.schedule(ctx)
+		eos.mu.Unlock()
+		eos.notify(ctx, contextID, text)
+	}
+	return nil
+}
+
+// schedule restarts the wait for the end of the turn. Caller must hold eos.mu.
+func (eos *EndpointingEOS) schedule(ctx context.Context) {
+	if eos.closed {
+		return
+	}
+	said := eos.text
+	if eos.interim != "" {
+		said = strings.TrimSpace(said + " " + eos.interim)
+	}
+	eos.generation++
+	generation := eos.generation
+	if eos.timer != nil {
+		eos.timer.Stop()
+	}
+	eos.timer = time.AfterFunc(eos.config.Silence(said), func() {
+		eos.expire(ctx, generation, false)
+	})
+}
+
+// expire ends the turn unless it was extended since the wait started.
+func (eos *EndpointingEOS) expire(ctx context.Context, generation uint64, now bool) {
+	eos.mu.Lock()
+	if eos.closed || (!now && generation != eos.generation) {
+		eos.mu.Unlock()
+		return
+	}
+	contextID, text := eos.contextID, eos.text
+	eos.contextID, eos.text, eos.interim = "", "", ""
+	eos.generation++
+	if eos.timer != nil {
+		eos.timer.Stop()
+		eos.timer = nil
+	}
+	eos.mu.Unlock()
+
+	if text == "" || eos.callback == nil {
+		return
+	}
+	// the analyzing context is long gone once the silence passed
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	_ = eos.callback(ctx, internal_type.EndOfSpeechPacket{Speech: text, ContextID: contextID})
+}
+
+// notify lets the client know about the speech so far.
+func (eos *EndpointingEOS) notify(ctx context.Context, contextID, text string) {
+	if eos.callback != nil {
+		eos.callback(ctx, internal_type.InterimEndOfSpeechPacket{Speech: text, ContextID: contextID})
+	}
+}
+
+// Close shuts down the detector
+func (eos *EndpointingEOS) Close() error {
+	eos.mu.Lock()
+	defer eos.mu.Unlock()
+	eos.closed = true
+	if eos.timer != nil {
+		eos.timer.Stop()
+		eos.timer = nil
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_endpointing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	for text, want := range map[string]Completion{
+		"":                              Uncertain,
+		"I'd like to book a table.":     Complete,
+		"can you hear me?":              Complete,
+		"my number is":                  Incomplete,
+		"I want to go to the bank and":  Incomplete,
+		"well, um":                      Incomplete,
+		"let me think...":               Incomplete,
+		"the order number is 4 5,":      Incomplete,
+		"yes please":                    Uncertain,
+		"I need help with my account ✓": Uncertain,
+	} {
+		assert.Equal(t, want, Classify(text), text)
+	}
+}
+
+func TestConfigFromOptions(t *testing.T) {
+	cfg := ConfigFromOptions(utils.Option{})
+	assert.Equal(t, Config{SpeechFinalDelay: DefaultSpeechFinalDelay, Timeout: DefaultTimeout, MaxTurnSilence: DefaultMaxTurnSilence}, cfg)
+
+	cfg = ConfigFromOptions(utils.Option{OptionsKeySpeechFinalDelay: "150", OptionsKeyTimeout: 700, OptionsKeyMaxTurnSilence: 2500.0})
+	assert.Equal(t, Config{SpeechFinalDelay: 150 * time.Millisecond, Timeout: 700 * time.Millisecond, MaxTurnSilence: 2500 * time.Millisecond}, cfg)
+
+	// nothing waits longer than the max turn silence
+	cfg = ConfigFromOptions(utils.Option{OptionsKeySpeechFinalDelay: 900, OptionsKeyMaxTurnSilence: 600})
+	assert.Equal(t, 600*time.Millisecond, cfg.Timeout)
+	assert.Equal(t, 600*time.Millisecond, cfg.SpeechFinalDelay)
+
+	assert.Equal(t, cfg.SpeechFinalDelay, cfg.Silence("done."))
+	assert.Equal(t, cfg.MaxTurnSilence, cfg.Silence("and"))
+}
+
+type turns struct {
+	ends chan internal_type.EndOfSpeechPacket
+}
+
+func newDetector(t *testing.T, opts utils.Option) (internal_type.EndOfSpeech, *turns) {
+	t.Helper()
+	got := &turns{ends: make(chan internal_type.EndOfSpeechPacket, 4)}
+	eos, err := NewEndpointingEndOfSpeech(nil, func(_ context.Context, pkts ...internal_type.Packet) error {
+		for _, p := range pkts {
+			if end, ok := p.(internal_type.EndOfSpeechPacket); ok {
+				got.ends <- end
+			}
+		}
+		return nil
+	}, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { eos.Close() })
+	return eos, got
+}
+
+func (g *turns) wait(t *testing.T, within time.Duration) (internal_type.EndOfSpeechPacket, time.Duration) {
+	t.Helper()
+	start := time.Now()
+	select {
+	case end := <-g.ends:
+		return end, time.Since(start)
+	case <-time.After(within):
+		t.Fatalf("turn did not end within %v", within)
+	}
+	return internal_type.EndOfSpeechPacket{}, 0
+}
+
+var fast = utils.Option{OptionsKeySpeechFinalDelay: 20, OptionsKeyTimeout: 120, OptionsKeyMaxTurnSilence: 400}
+
+func TestEndpointing_FinishedSentenceEndsQuickly(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "I'd like to cancel my order."})
+
+	end, took := got.wait(t, time.Second)
+	assert.Equal(t, "I'd like to cancel my order.", end.Speech)
+	assert.Equal(t, "c1", end.ContextID)
+	assert.Less(t, took, 120*time.Millisecond)
+}
+
+func TestEndpointing_TrailingOffWaitsLonger(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "my account number is"})
+
+	_, took := got.wait(t, time.Second)
+	assert.GreaterOrEqual(t, took, 350*time.Millisecond)
+}
+
+func TestEndpointing_JoinsFinals(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "my number is"})
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "four five six."})
+
+	end, took := got.wait(t, time.Second)
+	assert.Equal(t, "my number is four five six.", end.Speech)
+	assert.Less(t, took, 120*time.Millisecond)
+}
+
+func TestEndpointing_ChangingInterimExtends(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "okay."})
+	for _, interim := range []string{"and", "and also", "and also the"} {
+		time.Sleep(10 * time.Millisecond)
+		eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{Script: interim, Interim: true})
+	}
+	select {
+	case end := <-got.ends:
+		t.Fatalf("turn ended while the user was still talking: %q", end.Speech)
+	case <-time.After(150 * time.Millisecond):
+	}
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "and also the invoice."})
+	end, _ := got.wait(t, time.Second)
+	assert.Equal(t, "okay. and also the invoice.", end.Speech)
+}
+
+func TestEndpointing_StableInterimDoesNotExtend(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "yes"})
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{Script: "please", Interim: true})
+	started := time.Now()
+	for i := 0; i < 20; i++ {
+		time.Sleep(10 * time.Millisecond)
+		eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{Script: "please", Interim: true})
+	}
+	end, _ := got.wait(t, time.Second)
+	assert.Equal(t, "yes", end.Speech)
+	assert.Less(t, time.Since(started), 350*time.Millisecond)
+}
+
+func TestEndpointing_UserTextEndsRightAway(t *testing.T) {
+	eos, got := newDetector(t, utils.Option{OptionsKeyTimeout: 5000})
+	eos.Analyze(t.Context(), internal_type.UserTextPacket{ContextID: "c1", Text: "hello"})
+
+	end, _ := got.wait(t, 100*time.Millisecond)
+	assert.Equal(t, "hello", end.Speech)
+}
+
+func TestEndpointing_VoiceActivityWithoutTranscript(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.InterruptionPacket{Source: internal_type.InterruptionSourceVad})
+	select {
+	case <-got.ends:
+		t.Fatal("nothing was said")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestEndpointing_Close(t *testing.T) {
+	eos, got := newDetector(t, fast)
+	eos.Analyze(t.Context(), internal_type.SpeechToTextPacket{ContextID: "c1", Script: "hello there"})
+	require.NoError(t, eos.Close())
+	select {
+	case <-got.ends:
+		t.Fatal("closed detector ended a turn")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
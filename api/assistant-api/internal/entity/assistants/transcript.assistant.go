@@ -0,0 +1,19 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_entity
+
+import gorm_model "github.com/rapidaai/pkg/models/gorm"
+
+// AssistantTranscriptSetting is the text appended to every transcript of the
+// assistant's conversations when it is exported.
+type AssistantTranscriptSetting struct {
+	gorm_model.Audited
+	gorm_model.Mutable
+	gorm_model.Organizational
+	AssistantId uint64 `json:"assistantId" gorm:"type:bigint;not null"`
+	AiNotice    string `json:"aiNotice" gorm:"type:text;not null;default:''"`
+	LegalFooter string `json:"legalFooter" gorm:"type:text;not null;default:''"`
+}
@@ -23,6 +23,10 @@ type AssistantWebhook struct {
 	HttpHeaders gorm_types.StringMap `json:"httpHeaders" gorm:"type:string;"`
 	HttpBody    gorm_types.StringMap `json:"httpBody" gorm:"type:string;"`
 
+	// key the batches of streamed transcripts are signed with, never returned
+	// by the api
+	SigningSecret string `json:"signingSecret" gorm:"type:text"`
+
 	//
 	RetryStatusCodes  gorm_types.StringArray `json:"retryStatusCodes" gorm:"type:string;not null;"`
 	MaxRetryCount     uint32                 `json:"maxRetryCount" gorm:"type:int"`
@@ -0,0 +1,27 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audit_entity
+
+import (
+	gorm_model "github.com/rapidaai/pkg/models/gorm"
+	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
+)
+
+// ControlAuditLog is one administrative action on a project. Rows are only
+// ever inserted, the table rejects updates and deletes.
+type ControlAuditLog struct {
+	gorm_model.Audited
+	gorm_model.Organizational
+	ActorId      uint64                  `json:"actorId" gorm:"type:bigint;not null;default:0"`
+	ActorType    string                  `json:"actorType" gorm:"type:string;size:50;not null"`
+	Action       string                  `json:"action" gorm:"type:string;size:50;not null"`
+	ResourceType string                  `json:"resourceType" gorm:"type:string;size:100;not null"`
+	ResourceId   uint64                  `json:"resourceId" gorm:"type:bigint;not null;default:0"`
+	Reason       string                  `json:"reason" gorm:"type:text;not null;default:''"`
+	Before       gorm_types.InterfaceMap `json:"before" gorm:"type:jsonb"`
+	After        gorm_types.InterfaceMap `json:"after" gorm:"type:jsonb"`
+	Changes      gorm_types.InterfaceMap `json:"changes" gorm:"type:jsonb"`
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_campaign_entity
+
+import (
+	"time"
+
+	gorm_model "github.com/rapidaai/pkg/models/gorm"
+	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
+)
+
+// AssistantCampaign places outbound calls of an assistant to its contacts.
+// Status is the campaign's own lifecycle, see internal_campaign.
+type AssistantCampaign struct {
+	gorm_model.Audited
+	gorm_model.Organizational
+	CreatedBy          uint64                  `json:"createdBy" gorm:"type:bigint;not null;default:0"`
+	AssistantId        uint64                  `json:"assistantId" gorm:"type:bigint;not null"`
+	AssistantVersion   string                  `json:"assistantVersion" gorm:"type:string;size:50;not null;default:''"`
+	Name               string                  `json:"name" gorm:"type:string;size:200;not null"`
+	Status             string                  `json:"status" gorm:"type:string;size:20;not null;default:draft"`
+	FromNumber         string                  `json:"fromNumber" gorm:"type:string;size:50;not null;default:''"`
+	Timezone           string                  `json:"timezone" gorm:"type:string;size:64;not null;default:UTC"`
+	CallingHoursStart  string                  `json:"callingHoursStart" gorm:"type:string;size:5;not null;default:''"`
+	CallingHoursEnd    string                  `json:"callingHoursEnd" gorm:"type:string;size:5;not null;default:''"`
+	MaxConcurrentCalls uint32                  `json:"maxConcurrentCalls" gorm:"type:integer;not null;default:1"`
+	MaxAttempts        uint32                  `json:"maxAttempts" gorm:"type:integer;not null;default:1"`
+	RetryDelaySeconds  uint32                  `json:"retryDelaySeconds" gorm:"type:integer;not null;default:900"`
+	Args               gorm_types.InterfaceMap `json:"args" gorm:"type:jsonb;not null;default:'{}'"`
+	Options            gorm_types.InterfaceMap `json:"options" gorm:"type:jsonb;not null;default:'{}'"`
+	Metadata           gorm_types.InterfaceMap `json:"metadata" gorm:"type:jsonb;not null;default:'{}'"`
+
+	// Contacts counts the contacts by status, filled in on reads.
+	Contacts map[string]uint32 `json:"contacts" gorm:"-"`
+}
+
+// RetryDelay is how long a contact waits between two calls.
+func (c *AssistantCampaign) RetryDelay() time.Duration {
+	return time.Duration(c.RetryDelaySeconds) * time.Second
+}
+
+// AssistantCampaignContact is one number a campaign calls, with the call
+// arguments only it gets.
+type AssistantCampaignContact struct {
+	gorm_model.Audited
+	AssistantCampaignId     uint64                  `json:"assistantCampaignId" gorm:"type:bigint;not null"`
+	ToNumber                string                  `json:"toNumber" gorm:"type:string;size:50;not null"`
+	Args                    gorm_types.InterfaceMap `json:"args" gorm:"type:jsonb;not null;default:'{}'"`
+	Status                  string                  `json:"status" gorm:"type:string;size:20;not null;default:pending"`
+	Attempts                uint32                  `json:"attempts" gorm:"type:integer;not null;default:0"`
+	AssistantConversationId uint64                  `json:"assistantConversationId" gorm:"type:bigint;not null;default:0"`
+	Outcome                 string                  `json:"outcome" gorm:"type:string;size:30;not null;default:''"`
+	LastError               string                  `json:"lastError" gorm:"type:text;not null;default:''"`
+	DialedDate              *time.Time              `json:"dialedDate" gorm:"type:timestamp;default:null"`
+	NextAttemptDate         *time.Time              `json:"nextAttemptDate" gorm:"type:timestamp;default:null"`
+}
@@ -6,6 +6,8 @@
 package internal_conversation_entity
 
 import (
+	"time"
+
 	gorm_model "github.com/rapidaai/pkg/models/gorm"
 	type_enums "github.com/rapidaai/pkg/types/enums"
 	"github.com/rapidaai/pkg/utils"
@@ -69,4 +71,28 @@ type AssistantConversationRecording struct {
 	AssistantConversationId uint64 `json:"assistantConversationId" gorm:"type:bigint;not null"`
 	AssistantRecordingUrl   string `json:"assistantRecordingUrl" gorm:"type:string;not null"`
 	UserRecordingUrl        string `json:"userRecordingUrl" gorm:"type:string;not null"`
+
+	// StorageTier is where the audio of the recording is kept, archived
+	// recordings have to be restored before they can be played.
+	StorageTier  string     `json:"storageTier" gorm:"type:string;size:20;not null;default:standard"`
+	ArchivedDate *time.Time `json:"archivedDate" gorm:"type:timestamp;default:null"`
+
+	// Encrypted recordings are stored encrypted with the data key of the
+	// conversation, they are decrypted on retrieval.
+	Encrypted bool `json:"encrypted" gorm:"type:boolean;not null;default:false"`
+
+	// Channels is 2 for a stereo recording, both urls then point at the
+	// same file with the caller on the left channel.
+	Channels uint32 `json:"channels" gorm:"type:integer;not null;default:1"`
+
+	// Restoring is set on retrieval when the recording is archived and its
+	// audio is not readable yet.
+	Restoring bool `json:"restoring" gorm:"-"`
 }
+
+// Storage tiers of a recording.
+const (
+	RecordingStorageStandard = "standard"
+	RecordingStorageArchive  = "archive"
+	RecordingStorageDeleted  = "deleted"
+)
@@ -0,0 +1,26 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_conversation_entity
+
+import (
+	"time"
+
+	gorm_model "github.com/rapidaai/pkg/models/gorm"
+)
+
+// AssistantConversationKey is the data key the recordings, messages and
+// scratchpad of an encrypted conversation are encrypted with. Only its
+// wrapped form is stored, MasterKeyId names the master key that unwraps it.
+type AssistantConversationKey struct {
+	gorm_model.Audited
+	gorm_model.Mutable
+	gorm_model.Organizational
+	AssistantConversationId uint64     `json:"assistantConversationId" gorm:"type:bigint;not null"`
+	Algorithm               string     `json:"algorithm" gorm:"type:string;size:50;not null"`
+	MasterKeyId             string     `json:"masterKeyId" gorm:"type:string;size:100;not null"`
+	WrappedKey              []byte     `json:"-" gorm:"type:bytea;not null"`
+	RewrappedDate           *time.Time `json:"rewrappedDate" gorm:"type:timestamp;default:null"`
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_conversation_entity
+
+import (
+	gorm_model "github.com/rapidaai/pkg/models/gorm"
+	gorm_types "github.com/rapidaai/pkg/models/gorm/types"
+)
+
+// AssistantConversationEvent is one entry of the event log of a
+// conversation. Entries are only ever inserted, the sequence orders them.
+// The payload of an encrypted conversation is stored sealed.
+type AssistantConversationEvent struct {
+	gorm_model.Audited
+	AssistantId             uint64                  `json:"assistantId" gorm:"type:bigint;not null"`
+	AssistantConversationId uint64                  `json:"assistantConversationId" gorm:"type:bigint;not null"`
+	Sequence                uint64                  `json:"sequence" gorm:"type:bigint;not null"`
+	EventType               string                  `json:"eventType" gorm:"type:string;size:50;not null"`
+	ContextId               string                  `json:"contextId" gorm:"type:string;size:200;not null;default:''"`
+	Payload                 gorm_types.InterfaceMap `json:"payload" gorm:"type:jsonb"`
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_conversation_entity
+
+import (
+	gorm_model "github.com/rapidaai/pkg/models/gorm"
+)
+
+// AssistantTranscriptSegment is a final transcript of the caller as speech to
+// text heard it: the provider, the language it reported and its confidence,
+// and the speaker who said it when speakers are told apart.
+// The text stays with the message, segments are aggregated into the
+// recognition quality of an assistant.
+type AssistantTranscriptSegment struct {
+	gorm_model.Audited
+	AssistantId             uint64  `json:"assistantId" gorm:"type:bigint;not null"`
+	AssistantConversationId uint64  `json:"assistantConversationId" gorm:"type:bigint;not null"`
+	ContextId               string  `json:"contextId" gorm:"type:string;size:200;not null;default:''"`
+	Provider                string  `json:"provider" gorm:"type:string;size:100;not null"`
+	Language                string  `json:"language" gorm:"type:string;size:50;not null;default:''"`
+	Confidence              float64 `json:"confidence" gorm:"type:double precision;not null"`
+	Words                   uint32  `json:"words" gorm:"type:integer;not null"`
+	Speaker                 string  `json:"speaker" gorm:"type:string;size:50;not null;default:''"`
+}
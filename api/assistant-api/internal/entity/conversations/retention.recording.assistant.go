@@ -0,0 +1,19 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_conversation_entity
+
+import gorm_model "github.com/rapidaai/pkg/models/gorm"
+
+// AssistantRecordingRetentionPolicy is how long the call recordings of a
+// project are kept in standard storage and in total. A zero number of days
+// disables the step.
+type AssistantRecordingRetentionPolicy struct {
+	gorm_model.Audited
+	gorm_model.Mutable
+	gorm_model.Organizational
+	ArchiveAfterDays uint32 `json:"archiveAfterDays" gorm:"type:integer;not null;default:0"`
+	DeleteAfterDays  uint32 `json:"deleteAfterDays" gorm:"type:integer;not null;default:0"`
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_conversation_entity
+
+import gorm_model "github.com/rapidaai/pkg/models/gorm"
+
+// AssistantRecordingSetting is how the calls of an assistant are recorded.
+// Assistants without a setting record every call as two mono tracks.
+//
+// The flags carry no gorm default on purpose, gorm would otherwise leave a
+// false value out of the insert and the column default would win.
+type AssistantRecordingSetting struct {
+	gorm_model.Audited
+	gorm_model.Mutable
+	gorm_model.Organizational
+	AssistantId uint64 `json:"assistantId" gorm:"type:bigint;not null"`
+	Enabled     bool   `json:"enabled" gorm:"type:boolean;not null"`
+
+	// DualChannel stores one stereo file, the caller left and the assistant
+	// right, instead of a file per side.
+	DualChannel bool `json:"dualChannel" gorm:"type:boolean;not null"`
+
+	// RequireConsent records only conversations that carry the consent
+	// option, see internal_type.RecordingConsentOption.
+	RequireConsent bool `json:"requireConsent" gorm:"type:boolean;not null"`
+
+	// RedactSpelling leaves out what the caller says while spelling mode is
+	// on, that is where card numbers, codes and addresses are dictated.
+	RedactSpelling bool `json:"redactSpelling" gorm:"type:boolean;not null"`
+}
+
+// DefaultRecordingSetting is the setting of an assistant that has none.
+func DefaultRecordingSetting() *AssistantRecordingSetting {
+	return &AssistantRecordingSetting{Enabled: true}
+}
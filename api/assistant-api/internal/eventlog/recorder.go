@@ -0,0 +1,159 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_eventlog numbers what happens during a conversation so it
+// can be replayed in order: audio going in and out, transcripts, messages,
+// interruptions, tool calls and directives. Audio is logged as metadata only,
+// the chunks of a second in one direction make a single entry.
+package internal_eventlog
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Types of the entries of the log.
+const (
+	TypeAudio            = "audio"
+	TypeTranscript       = "transcript"
+	TypeUserMessage      = "user.message"
+	TypeAssistantMessage = "assistant.message"
+	TypeInterruption     = "interruption"
+	TypeToolCall         = "tool.call"
+	TypeToolResult       = "tool.result"
+	TypeDirective        = "directive"
+	TypeDTMF             = "dtmf"
+)
+
+// Directions of audio entries.
+const (
+	DirectionInput  = "input"
+	DirectionOutput = "output"
+)
+
+// audioWindow is the longest stretch of audio one entry covers.
+const audioWindow = time.Second
+
+// Event is an entry of the log.
+type Event struct {
+	Sequence  uint64
+	Type      string
+	ContextID string
+	Payload   map[string]interface{}
+	Time      time.Time
+}
+
+type audioRun struct {
+	contextID  string
+	chunks     int
+	bytes      int
+	start, end time.Time
+}
+
+// AAD binds the sealed payload of an encrypted conversation to its entry, it
+// can't be moved to another one.
+func AAD(assistantConversationID, sequence uint64) []byte {
+	return []byte(fmt.Sprintf("%d/%d", assistantConversationID, sequence))
+}
+
+// Recorder numbers entries and hands them to emit in order. emit is called
+// with the recorder locked and must not block.
+type Recorder struct {
+	mu   sync.Mutex
+	last uint64
+	runs map[string]*audioRun
+	emit func(...Event)
+	now  func() time.Time
+}
+
+// NewRecorder continues the log after the sequence last, 0 for a new
+// conversation.
+func NewRecorder(last uint64, emit func(...Event)) *Recorder {
+	return &Recorder{last: last, runs: make(map[string]*audioRun), emit: emit, now: time.Now}
+}
+
+// Record logs an entry. Audio still pending is logged first so the log
+// keeps the order things happened in.
+func (r *Recorder) Record(eventType, contextID string, payload map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.closeRuns()
+	r.last++
+	events = append(events, Event{Sequence: r.last, Type: eventType, ContextID: contextID, Payload: payload, Time: r.now()})
+	r.emit(events...)
+}
+
+// Audio adds a chunk of bytes heard or played in direction to the pending
+// audio entry of that direction.
+func (r *Recorder) Audio(direction, contextID string, bytes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	run := r.runs[direction]
+	if run != nil && (run.contextID != contextID || now.Sub(run.start) >= audioWindow) {
+		r.emit(r.closeRun(direction, run))
+		run = nil
+	}
+	if run == nil {
+		run = &audioRun{contextID: contextID, start: now}
+		r.runs[direction] = run
+	}
+	run.chunks++
+	run.bytes += bytes
+	run.end = now
+}
+
+// Close logs the pending audio.
+func (r *Recorder) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if events := r.closeRuns(); len(events) > 0 {
+		r.emit(events...)
+	}
+}
+
+// Last is the sequence of the latest entry.
+func (r *Recorder) Last() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// closeRuns turns the pending audio into entries, the one started first
+// first.
+func (r *Recorder) closeRuns() []Event {
+	directions := make([]string, 0, len(r.runs))
+	for direction := range r.runs {
+		directions = append(directions, direction)
+	}
+	sort.Slice(directions, func(i, j int) bool {
+		return r.runs[directions[i]].start.Before(r.runs[directions[j]].start)
+	})
+	events := make([]Event, 0, len(directions)+1)
+	for _, direction := range directions {
+		events = append(events, r.closeRun(direction, r.runs[direction]))
+	}
+	return events
+}
+
+func (r *Recorder) closeRun(direction string, run *audioRun) Event {
+	delete(r.runs, direction)
+	r.last++
+	return Event{
+		Sequence:  r.last,
+		Type:      TypeAudio,
+		ContextID: run.contextID,
+		Payload: map[string]interface{}{
+			"direction":  direction,
+			"chunks":     run.chunks,
+			"bytes":      run.bytes,
+			"durationMs": run.end.Sub(run.start).Milliseconds(),
+		},
+		Time: run.start,
+	}
+}
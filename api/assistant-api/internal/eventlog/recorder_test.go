@@ -0,0 +1,88 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_eventlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestRecorder(last uint64) (*Recorder, *clock, *[]Event) {
+	var got []Event
+	c := &clock{t: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}
+	r := NewRecorder(last, func(events ...Event) { got = append(got, events...) })
+	r.now = c.now
+	return r, c, &got
+}
+
+func TestRecorder_ContinuesSequence(t *testing.T) {
+	r, _, got := newTestRecorder(41)
+	r.Record(TypeUserMessage, "m1", map[string]interface{}{"text": "hi"})
+	r.Record(TypeAssistantMessage, "m2", map[string]interface{}{"text": "hello"})
+
+	assert.Len(t, *got, 2)
+	assert.Equal(t, uint64(42), (*got)[0].Sequence)
+	assert.Equal(t, uint64(43), (*got)[1].Sequence)
+	assert.Equal(t, uint64(43), r.Last())
+}
+
+func TestRecorder_CoalescesAudio(t *testing.T) {
+	r, c, got := newTestRecorder(0)
+	for i := 0; i < 10; i++ {
+		r.Audio(DirectionInput, "m1", 320)
+		c.advance(20 * time.Millisecond)
+	}
+	assert.Empty(t, *got)
+
+	r.Record(TypeTranscript, "m1", map[string]interface{}{"text": "hi"})
+	assert.Len(t, *got, 2)
+	audio := (*got)[0]
+	assert.Equal(t, TypeAudio, audio.Type)
+	assert.Equal(t, uint64(1), audio.Sequence)
+	assert.Equal(t, 10, audio.Payload["chunks"])
+	assert.Equal(t, 3200, audio.Payload["bytes"])
+	assert.Equal(t, int64(180), audio.Payload["durationMs"])
+	assert.Equal(t, TypeTranscript, (*got)[1].Type)
+	assert.Equal(t, uint64(2), (*got)[1].Sequence)
+}
+
+func TestRecorder_SplitsAudioByWindowAndContext(t *testing.T) {
+	r, c, got := newTestRecorder(0)
+	r.Audio(DirectionOutput, "m1", 100)
+	c.advance(audioWindow)
+	r.Audio(DirectionOutput, "m1", 100)
+	assert.Len(t, *got, 1)
+
+	r.Audio(DirectionOutput, "m2", 100)
+	assert.Len(t, *got, 2)
+	assert.Equal(t, "m1", (*got)[1].ContextID)
+
+	r.Close()
+	assert.Len(t, *got, 3)
+	assert.Equal(t, "m2", (*got)[2].ContextID)
+	assert.Equal(t, uint64(3), (*got)[2].Sequence)
+}
+
+func TestRecorder_PendingAudioInStartOrder(t *testing.T) {
+	r, c, got := newTestRecorder(0)
+	r.Audio(DirectionOutput, "m1", 100)
+	c.advance(10 * time.Millisecond)
+	r.Audio(DirectionInput, "m2", 100)
+	r.Record(TypeInterruption, "m1", nil)
+
+	assert.Len(t, *got, 3)
+	assert.Equal(t, DirectionOutput, (*got)[0].Payload["direction"])
+	assert.Equal(t, DirectionInput, (*got)[1].Payload["direction"])
+	assert.Equal(t, TypeInterruption, (*got)[2].Type)
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_fallback
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// The recorded prompts are configured on the text to speech options of a
+// deployment as storage keys of WAV files, linear16 or μ-law and mono. A key
+// without a RIFF header is taken for raw linear16 at 16kHz:
+//
+//	speak.fallback.apology     = prompts/sorry.wav
+//	speak.fallback.callback    = prompts/we-will-call-you-back.wav
+//	speak.fallback.transfer    = prompts/connecting-you.wav
+//	speak.fallback.transfer_to = +14155550100
+const (
+	OptionsKeyApology    = "speak.fallback.apology"
+	OptionsKeyCallback   = "speak.fallback.callback"
+	OptionsKeyTransfer   = "speak.fallback.transfer"
+	OptionsKeyTransferTo = "speak.fallback.transfer_to"
+)
+
+// Consecutive synthesis failures after which text to speech is reconnected,
+// and after the reconnect given up on.
+const MaxFailures = 3
+
+// Prompts are what the caller hears once text to speech is down.
+type Prompts struct {
+	Apology    string
+	Callback   string
+	Transfer   string
+	TransferTo string // where the call is transferred to, it ends otherwise
+}
+
+// FromOptions reads the prompts configured in opts.
+func FromOptions(opts utils.Option) Prompts {
+	get := func(key string) string {
+		value, _ := opts.GetString(key)
+		return strings.TrimSpace(value)
+	}
+	return Prompts{
+		Apology:    get(OptionsKeyApology),
+		Callback:   get(OptionsKeyCallback),
+		Transfer:   get(OptionsKeyTransfer),
+		TransferTo: get(OptionsKeyTransferTo),
+	}
+}
+
+// Configured reports whether the deployment set up a fallback at all.
+func (p Prompts) Configured() bool {
+	return p.Apology != "" || p.Callback != "" || p.Transfer != "" || p.TransferTo != ""
+}
+
+// Sequence returns the storage keys to play in order: the apology, then the
+// transfer prompt when the call is transferred or the callback offer when it
+// ends.
+func (p Prompts) Sequence() []string {
+	keys := make([]string, 0, 2)
+	if p.Apology != "" {
+		keys = append(keys, p.Apology)
+	}
+	next := p.Callback
+	if p.TransferTo != "" {
+		next = p.Transfer
+	}
+	if next != "" {
+		keys = append(keys, next)
+	}
+	return keys
+}
+
+// OffersCallback reports whether the caller is promised a call back.
+func (p Prompts) OffersCallback() bool {
+	return p.TransferTo == "" && p.Callback != ""
+}
+
+// Decode returns the audio samples of a prompt and their format.
+func Decode(data []byte) ([]byte, *protos.AudioConfig, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		if len(data)%2 != 0 {
+			return nil, nil, errors.New("raw prompt is not linear16")
+		}
+		return data, internal_audio.NewLinear16khzMonoAudioConfig(), nil
+	}
+
+	var config *protos.AudioConfig
+	for rest := data[12:]; len(rest) >= 8; {
+		id, size := string(rest[0:4]), binary.LittleEndian.Uint32(rest[4:8])
+		rest = rest[8:]
+		if uint64(size) > uint64(len(rest)) {
+			size = uint32(len(rest)) // streamed WAVs leave the data size open
+		}
+		chunk := rest[:size]
+		switch id {
+		case "fmt ":
+			c, err := decodeFormat(chunk)
+			if err != nil {
+				return nil, nil, err
+			}
+			config = c
+		case "data":
+			if config == nil {
+				return nil, nil, errors.New("wav data before its format")
+			}
+			return chunk, config, nil
+		}
+		if size%2 == 1 && int(size) < len(rest) {
+			size++ // chunks are word aligned
+		}
+		rest = rest[size:]
+	}
+	return nil, nil, errors.New("wav has no data")
+}
+
+func decodeFormat(chunk []byte) (*protos.AudioConfig, error) {
+	if len(chunk) < 16 {
+		return nil, errors.New("wav format is truncated")
+	}
+	var f struct {
+		Tag, Channels uint16
+		SampleRate    uint32
+		_             uint32
+		_             uint16
+		Bits          uint16
+	}
+	if err := binary.Read(bytes.NewReader(chunk[:16]), binary.LittleEndian, &f); err != nil {
+		return nil, err
+	}
+	if f.Channels != 1 {
+		return nil, fmt.Errorf("wav has %d channels, prompts must be mono", f.Channels)
+	}
+	config := &protos.AudioConfig{SampleRate: f.SampleRate, Channels: 1}
+	switch {
+	case f.Tag == 1 && f.Bits == 16:
+		config.AudioFormat = protos.AudioConfig_LINEAR16
+	case f.Tag == 7 && f.Bits == 8:
+		config.AudioFormat = protos.AudioConfig_MuLaw8
+	default:
+		return nil, fmt.Errorf("wav format %d with %d bits is not linear16 or μ-law", f.Tag, f.Bits)
+	}
+	return config, nil
+}
+
+// Action is what to do about a failed synthesis.
+type Action int
+
+const (
+	Retry     Action = iota // keep the provider, it may recover
+	Reconnect               // connect to the provider again
+	Degrade                 // give up and play the prompts
+)
+
+// Monitor tells a flaky text to speech provider from one that is down. It
+// counts consecutive failures, a provider that keeps failing is reconnected
+// once and given up on when it still fails after that.
+type Monitor struct {
+	mu          sync.Mutex
+	failures    int
+	reconnected bool
+}
+
+// Failure records a failed synthesis.
+func (m *Monitor) Failure() Action {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures++
+	if m.failures < MaxFailures {
+		return Retry
+	}
+	m.failures = 0
+	if m.reconnected {
+		return Degrade
+	}
+	m.reconnected = true
+	return Reconnect
+}
+
+// Success records audio coming back from the provider.
+func (m *Monitor) Success() {
+	m.mu.Lock()
+	m.failures = 0
+	m.mu.Unlock()
+}
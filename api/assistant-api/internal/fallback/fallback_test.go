@@ -0,0 +1,106 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_fallback
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wav(t *testing.T, tag, channels uint16, rate uint32, bits uint16, extra []byte, pcm []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(0))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	for _, v := range []any{tag, channels, rate, rate * uint32(channels*bits/8), channels * bits / 8, bits} {
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+	buf.Write(extra)
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(pcm)))
+	buf.Write(pcm)
+	return buf.Bytes()
+}
+
+func TestFromOptions(t *testing.T) {
+	prompts := FromOptions(utils.Option{
+		OptionsKeyApology:    " sorry.wav ",
+		OptionsKeyCallback:   "callback.wav",
+		OptionsKeyTransfer:   "transfer.wav",
+		"speak.voice.id":     "v1",
+		OptionsKeyTransferTo: "",
+	})
+	assert.True(t, prompts.Configured())
+	assert.Equal(t, []string{"sorry.wav", "callback.wav"}, prompts.Sequence())
+	assert.True(t, prompts.OffersCallback())
+
+	prompts.TransferTo = "+14155550100"
+	assert.Equal(t, []string{"sorry.wav", "transfer.wav"}, prompts.Sequence())
+	assert.False(t, prompts.OffersCallback())
+
+	assert.False(t, FromOptions(utils.Option{}).Configured())
+	assert.Empty(t, Prompts{TransferTo: "+14155550100"}.Sequence())
+}
+
+func TestDecode_Linear16(t *testing.T) {
+	pcm := []byte{1, 2, 3, 4}
+	list := append([]byte("LIST"), 2, 0, 0, 0, 'x', 'y')
+	data, config, err := Decode(wav(t, 1, 1, 8000, 16, list, pcm))
+	require.NoError(t, err)
+	assert.Equal(t, pcm, data)
+	assert.Equal(t, uint32(8000), config.GetSampleRate())
+	assert.Equal(t, protos.AudioConfig_LINEAR16, config.GetAudioFormat())
+}
+
+func TestDecode_Mulaw(t *testing.T) {
+	data, config, err := Decode(wav(t, 7, 1, 8000, 8, nil, []byte{0xff, 0x7f}))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0x7f}, data)
+	assert.Equal(t, protos.AudioConfig_MuLaw8, config.GetAudioFormat())
+}
+
+func TestDecode_Raw(t *testing.T) {
+	data, config, err := Decode([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	assert.Len(t, data, 4)
+	assert.Equal(t, uint32(16000), config.GetSampleRate())
+
+	_, _, err = Decode([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestDecode_Unsupported(t *testing.T) {
+	_, _, err := Decode(wav(t, 1, 2, 16000, 16, nil, []byte{1, 2, 3, 4}))
+	assert.Error(t, err, "stereo")
+	_, _, err = Decode(wav(t, 3, 1, 16000, 32, nil, []byte{1, 2, 3, 4}))
+	assert.Error(t, err, "float")
+	_, _, err = Decode([]byte("RIFF\x00\x00\x00\x00WAVE"))
+	assert.Error(t, err, "no data")
+}
+
+func TestMonitor(t *testing.T) {
+	var m Monitor
+	assert.Equal(t, Retry, m.Failure())
+	assert.Equal(t, Retry, m.Failure())
+	m.Success()
+	assert.Equal(t, Retry, m.Failure())
+	assert.Equal(t, Retry, m.Failure())
+	assert.Equal(t, Reconnect, m.Failure())
+
+	// still failing after the reconnect
+	assert.Equal(t, Retry, m.Failure())
+	assert.Equal(t, Retry, m.Failure())
+	assert.Equal(t, Degrade, m.Failure())
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_handoff describes handing a live conversation from one
+// assistant to another, e.g. from a triage assistant to a billing specialist.
+// The call and its transcript stay, the prompt, tools and voice become the
+// ones of the assistant taking over.
+package internal_handoff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Arguments of the HANDOFF_CONVERSATION directive.
+const (
+	ArgAssistantID = "assistant_id"
+	ArgVersion     = "version"
+	ArgReason      = "reason"
+)
+
+// Outcomes of a handoff.
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Request is the assistant a directive hands the conversation to.
+type Request struct {
+	AssistantID uint64
+	Version     string // vrsn_<id>, empty for the latest version
+	Reason      string
+}
+
+// ParseRequest reads a request from the directive arguments. The assistant
+// id may be a number or a string, ids of external agents usually are
+// strings.
+func ParseRequest(args map[string]interface{}) (Request, error) {
+	id, err := utils.Option(args).GetUint64(ArgAssistantID)
+	if err != nil || id == 0 {
+		return Request{}, errors.New("missing the id of the assistant to hand the conversation to")
+	}
+	version, _ := args[ArgVersion].(string)
+	reason, _ := args[ArgReason].(string)
+	return Request{AssistantID: id, Version: strings.TrimSpace(version), Reason: strings.TrimSpace(reason)}, nil
+}
+
+// Arguments returns the directive arguments of the request. The id is sent
+// as a string, a JSON number can't hold every uint64.
+func (r Request) Arguments() map[string]interface{} {
+	args := map[string]interface{}{ArgAssistantID: fmt.Sprintf("%d", r.AssistantID)}
+	if r.Version != "" {
+		args[ArgVersion] = r.Version
+	}
+	if r.Reason != "" {
+		args[ArgReason] = r.Reason
+	}
+	return args
+}
+
+// Record is a handoff as attached to the conversation.
+type Record struct {
+	FromAssistantID string    `json:"from_assistant_id"`
+	FromVersion     string    `json:"from_version"`
+	ToAssistantID   string    `json:"to_assistant_id"`
+	ToVersion       string    `json:"to_version,omitempty"`
+	Reason          string    `json:"reason,omitempty"`
+	Status          string    `json:"status"`
+	Error           string    `json:"error,omitempty"`
+	Time            time.Time `json:"time"`
+}
+
+// NewRecord starts the record of a handoff away from the given assistant
+// version.
+func NewRecord(fromAssistantID, fromVersion uint64, r Request, now time.Time) *Record {
+	return &Record{
+		FromAssistantID: fmt.Sprintf("%d", fromAssistantID),
+		FromVersion:     utils.GetVersionString(fromVersion),
+		ToAssistantID:   fmt.Sprintf("%d", r.AssistantID),
+		ToVersion:       r.Version,
+		Reason:          r.Reason,
+		Time:            now,
+	}
+}
+
+// Complete marks the handoff done, with the version that took over.
+func (r *Record) Complete(toVersion uint64) {
+	r.ToVersion = utils.GetVersionString(toVersion)
+	r.Status = StatusCompleted
+}
+
+// Fail marks the handoff failed, the conversation stays with the assistant
+// it was handed away from.
+func (r *Record) Fail(err error) {
+	r.Status = StatusFailed
+	r.Error = err.Error()
+}
+
+// Briefing is the first turn of the assistant taking over. It has the
+// transcript so far, the briefing tells it why it is talking now.
+func Briefing(from string, reason string) string {
+	var sb strings.Builder
+	sb.WriteString("[handoff] You are taking over this conversation")
+	if from = strings.TrimSpace(from); from != "" {
+		fmt.Fprintf(&sb, " from %q", from)
+	}
+	sb.WriteString(".")
+	if reason != "" {
+		fmt.Fprintf(&sb, " Reason: %s.", strings.TrimSuffix(reason, "."))
+	}
+	sb.WriteString(" Introduce yourself briefly and continue where the conversation left off, do not ask for what the user already said.")
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_handoff
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequest(t *testing.T) {
+	r, err := ParseRequest(map[string]interface{}{"assistant_id": "2214", "version": " vrsn_9 ", "reason": " billing question "})
+	require.NoError(t, err)
+	assert.Equal(t, Request{AssistantID: 2214, Version: "vrsn_9", Reason: "billing question"}, r)
+
+	r, err = ParseRequest(map[string]interface{}{"assistant_id": float64(17)})
+	require.NoError(t, err)
+	assert.Equal(t, Request{AssistantID: 17}, r)
+
+	for _, args := range []map[string]interface{}{
+		{},
+		{"assistant_id": ""},
+		{"assistant_id": "billing"},
+		{"assistant_id": float64(0)},
+	} {
+		_, err := ParseRequest(args)
+		assert.Error(t, err)
+	}
+}
+
+func TestRequest_ArgumentsRoundTrip(t *testing.T) {
+	r := Request{AssistantID: 18446744073709551615, Reason: "billing"}
+	assert.Equal(t, map[string]interface{}{"assistant_id": "18446744073709551615", "reason": "billing"}, r.Arguments())
+
+	parsed, err := ParseRequest(r.Arguments())
+	require.NoError(t, err)
+	assert.Equal(t, r, parsed)
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(1, 11, Request{AssistantID: 2, Reason: "billing"}, now)
+	record.Complete(21)
+	value, err := json.Marshal(record)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"from_assistant_id":"1","from_version":"vrsn_11","to_assistant_id":"2","to_version":"vrsn_21","reason":"billing","status":"completed","time":"2025-03-01T10:00:00Z"}`, string(value))
+
+	record = NewRecord(1, 11, Request{AssistantID: 3, Version: "vrsn_4"}, now)
+	record.Fail(errors.New("assistant not found"))
+	assert.Equal(t, StatusFailed, record.Status)
+	assert.Equal(t, "assistant not found", record.Error)
+	assert.Equal(t, "vrsn_4", record.ToVersion)
+}
+
+func TestBriefing(t *testing.T) {
+	assert.Equal(t,
+		`[handoff] You are taking over this conversation from "Triage". Reason: billing question. Introduce yourself briefly and continue where the conversation left off, do not ask for what the user already said.`,
+		Briefing("Triage", "billing question."))
+	assert.Equal(t,
+		"[handoff] You are taking over this conversation. Introduce yourself briefly and continue where the conversation left off, do not ask for what the user already said.",
+		Briefing(" ", ""))
+}
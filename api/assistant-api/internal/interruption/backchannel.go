@@ -0,0 +1,170 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_interruption
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+const (
+	// OptionsKeyBackchannel turns backchannel detection off when "false".
+	OptionsKeyBackchannel = "microphone.interruption.backchannel"
+
+	// OptionsKeyBackchannelPhrases is the prefix of the per language phrase
+	// lists, e.g. "microphone.interruption.backchannel.phrases.en" set to
+	// "yeah, ok, got it". A configured list replaces the built-in one of that
+	// language, the bare key without a language applies to all of them.
+	OptionsKeyBackchannelPhrases = "microphone.interruption.backchannel.phrases"
+)
+
+const (
+	// maxBackchannelWords is the longest transcript taken for a backchannel.
+	maxBackchannelWords = 3
+
+	// MaxBackchannelDuration is the longest utterance taken for a backchannel.
+	MaxBackchannelDuration = 1500 * time.Millisecond
+)
+
+// defaultBackchannels are the acknowledgements callers commonly make while
+// the assistant speaks, by base language.
+var defaultBackchannels = map[string][]string{
+	"en": {"yeah", "yes", "yep", "yup", "ok", "okay", "alright", "right", "sure", "cool", "great", "mhm", "mm", "hmm", "uh huh", "mm hmm", "got it", "i see", "haha", "hehe"},
+	"es": {"sí", "si", "vale", "claro", "ajá", "aja", "ok", "bueno", "ya", "entiendo", "jaja"},
+	"fr": {"oui", "ouais", "d'accord", "ok", "voilà", "bien sûr", "je vois", "mhm", "haha"},
+	"de": {"ja", "genau", "ok", "okay", "mhm", "stimmt", "alles klar", "richtig", "haha"},
+	"pt": {"sim", "tá", "ta", "ok", "certo", "uhum", "claro", "entendi", "haha"},
+	"hi": {"haan", "han", "ha", "accha", "achha", "theek hai", "ji", "हाँ", "हां", "अच्छा", "ठीक है", "जी", "हम्म"},
+}
+
+// Backchannel tells short acknowledgements ("yeah", "ok", laughter) the
+// caller makes while the assistant speaks from attempts to take the turn.
+type Backchannel struct {
+	all       map[string]struct{}
+	languages map[string]map[string]struct{}
+}
+
+// BackchannelFromOptions returns the backchannel classifier configured in
+// opts, nil when detection is turned off.
+func BackchannelFromOptions(opts utils.Option) *Backchannel {
+	if enabled, err := opts.GetString(OptionsKeyBackchannel); err == nil && strings.EqualFold(strings.TrimSpace(enabled), "false") {
+		return nil
+	}
+	b := &Backchannel{languages: make(map[string]map[string]struct{})}
+	for language, phrases := range defaultBackchannels {
+		b.languages[language] = phraseSet(phrases)
+	}
+	for key := range opts {
+		if key == OptionsKeyBackchannelPhrases {
+			phrases, _ := opts.GetString(key)
+			b.all = phraseSet(strings.Split(phrases, ","))
+			continue
+		}
+		if language, ok := strings.CutPrefix(key, OptionsKeyBackchannelPhrases+"."); ok {
+			phrases, _ := opts.GetString(key)
+			b.languages[baseLanguage(language)] = phraseSet(strings.Split(phrases, ","))
+		}
+	}
+	return b
+}
+
+// Is reports whether a transcript in the given language is a backchannel.
+// spoken is how long the caller has been speaking, zero when unknown.
+// Transcripts made only of non-speech tags such as "[laughter]" count as
+// backchannels, as do repeats like "yeah yeah".
+func (b *Backchannel) Is(transcript, language string, spoken time.Duration) bool {
+	if spoken > MaxBackchannelDuration {
+		return false
+	}
+	transcript, tagged := stripNonSpeech(transcript)
+	text := normalizePhrase(transcript)
+	if text == "" {
+		return tagged
+	}
+	words := strings.Fields(text)
+	if len(words) > maxBackchannelWords {
+		return false
+	}
+	phrases, ok := b.languages[baseLanguage(language)]
+	if !ok {
+		phrases = b.languages["en"]
+	}
+	known := func(phrase string) bool {
+		_, inLanguage := phrases[phrase]
+		_, inAll := b.all[phrase]
+		return inLanguage || inAll
+	}
+	if known(text) {
+		return true
+	}
+	for _, word := range words {
+		if !known(word) {
+			return false
+		}
+	}
+	return true
+}
+
+func phraseSet(phrases []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(phrases))
+	for _, phrase := range phrases {
+		if phrase = normalizePhrase(phrase); phrase != "" {
+			set[phrase] = struct{}{}
+		}
+	}
+	return set
+}
+
+// normalizePhrase lower-cases text and drops punctuation, hyphens split
+// words ("uh-huh" is "uh huh").
+func normalizePhrase(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), unicode.IsMark(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r), r == '-':
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// stripNonSpeech removes bracketed annotations speech to text providers emit
+// for laughter and noise, reporting whether there were any.
+func stripNonSpeech(text string) (string, bool) {
+	var b strings.Builder
+	tagged, depth := false, 0
+	for _, r := range text {
+		switch r {
+		case '[', '(', '<':
+			depth++
+			tagged = true
+			continue
+		case ']', ')', '>':
+			if depth > 0 {
+				depth--
+			}
+			continue
+		}
+		if depth == 0 {
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), tagged
+}
+
+// baseLanguage returns the primary subtag of a language tag, "en" for "en-US".
+func baseLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_interruption
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackchannel_Is(t *testing.T) {
+	b := BackchannelFromOptions(utils.Option{})
+	require.NotNil(t, b)
+
+	tests := []struct {
+		transcript string
+		language   string
+		want       bool
+	}{
+		{"Yeah.", "en-US", true},
+		{"uh-huh", "en", true},
+		{"okay okay", "en-US", true},
+		{"Got it!", "", true},
+		{"[laughter]", "en-US", true},
+		{"(laughs) yeah", "en-US", true},
+		{"no", "en-US", false},
+		{"yeah but wait", "en-US", false},
+		{"yeah yeah yeah yeah", "en-US", false},
+		{"", "en-US", false},
+		{"vale", "es-ES", true},
+		{"d'accord", "fr-FR", true},
+		{"ठीक है", "hi-IN", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, b.Is(tt.transcript, tt.language, 0), "%q in %s", tt.transcript, tt.language)
+	}
+}
+
+func TestBackchannel_TooLong(t *testing.T) {
+	b := BackchannelFromOptions(utils.Option{})
+
+	assert.True(t, b.Is("yeah", "en", time.Second))
+	assert.False(t, b.Is("yeah", "en", 2*time.Second), "a drawn out utterance takes the turn")
+}
+
+func TestBackchannelFromOptions(t *testing.T) {
+	assert.Nil(t, BackchannelFromOptions(utils.Option{OptionsKeyBackchannel: "false"}))
+
+	b := BackchannelFromOptions(utils.Option{
+		OptionsKeyBackchannelPhrases + ".en": "totally, for sure",
+		OptionsKeyBackchannelPhrases:         "bingo",
+	})
+	assert.True(t, b.Is("for sure", "en-GB", 0))
+	assert.False(t, b.Is("yeah", "en-GB", 0), "a configured list replaces the built-in one")
+	assert.True(t, b.Is("bingo", "de", 0))
+	assert.True(t, b.Is("genau", "de", 0))
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_interruption
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Listen options of an assistant deployment tuning barge in.
+const (
+	// OptionsKeyStrategy selects how the user barging in interrupts the assistant.
+	OptionsKeyStrategy = "microphone.interruption.strategy"
+
+	// OptionsKeyDuckLevel is how many dB ducked speech is lowered by.
+	OptionsKeyDuckLevel = "microphone.interruption.duck_level"
+
+	// OptionsKeyDuckWindow is the DuckWindow override in milliseconds.
+	OptionsKeyDuckWindow = "microphone.interruption.duck_window"
+)
+
+const (
+	// DuckWindow is how long ducked speech stays quiet after a voice onset
+	// without a confirmed word before it returns to full volume.
+	DuckWindow = 1500 * time.Millisecond
+
+	// duckGain is the volume of ducked speech, about -10 dB.
+	duckGain = 0.3
+
+	// maxDuckLevel is the deepest duck allowed, anything below is as good as
+	// cutting the speech.
+	maxDuckLevel = 40
+)
+
+// Strategy decides what an interruption of the assistant's speech does.
+type Strategy string
+
+const (
+	// StrategyVad cuts the assistant on voice activity onset. It is the
+	// fastest to react but noise and back channels ("mhm") cut it as well.
+	StrategyVad Strategy = "vad"
+
+	// StrategyWord cuts the assistant on the first word speech to text
+	// confirms, voice onset is only passed on to the client. It is the default.
+	StrategyWord Strategy = "word"
+
+	// StrategyHybrid ducks the assistant on voice onset instead of clearing
+	// its speech and cuts it once a word is confirmed. Speech returns to full
+	// volume if no word follows within the duck window, so back channels
+	// ("mm-hm") only dip the assistant for a moment.
+	StrategyHybrid Strategy = "hybrid"
+)
+
+// Action is what the talk loop does with a single interruption.
+type Action int
+
+const (
+	// ActionNotify tells the client the user may be speaking, the
+	// assistant keeps talking.
+	ActionNotify Action = iota
+
+	// ActionDuck lowers the volume of the assistant's speech.
+	ActionDuck
+
+	// ActionCut stops the assistant and discards its pending speech.
+	ActionCut
+)
+
+// FromOptions returns the strategy configured in opts, StrategyWord when none
+// or an unknown one is configured.
+func FromOptions(opts utils.Option) Strategy {
+	value, err := opts.GetString(OptionsKeyStrategy)
+	if err != nil {
+		return StrategyWord
+	}
+	switch strategy := Strategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case StrategyVad, StrategyHybrid:
+		return strategy
+	}
+	return StrategyWord
+}
+
+// Decide returns the action for an interruption coming from source.
+func (s Strategy) Decide(source internal_type.InterruptionSource) Action {
+	if source == internal_type.InterruptionSourceWord {
+		return ActionCut
+	}
+	switch s {
+	case StrategyVad:
+		return ActionCut
+	case StrategyHybrid:
+		return ActionDuck
+	}
+	return ActionNotify
+}
+
+// Ducking is how the hybrid strategy lowers the assistant's speech while a
+// voice onset waits for a confirmed word.
+type Ducking struct {
+	Gain   float64       // linear gain of ducked speech, 0 < Gain <= 1
+	Window time.Duration // how long speech stays ducked without a word
+}
+
+// DuckingFromOptions returns the ducking configured in opts. The level is
+// given in dB below full volume and clamped to 0..40, the window in
+// milliseconds. Unset or invalid values keep the defaults.
+func DuckingFromOptions(opts utils.Option) Ducking {
+	ducking := Ducking{Gain: duckGain, Window: DuckWindow}
+	if level, err := opts.GetFloat64(OptionsKeyDuckLevel); err == nil {
+		level = math.Min(math.Abs(level), maxDuckLevel)
+		ducking.Gain = math.Pow(10, -level/20)
+	}
+	if window, err := opts.GetFloat64(OptionsKeyDuckWindow); err == nil && window > 0 {
+		ducking.Window = time.Duration(window) * time.Millisecond
+	}
+	return ducking
+}
+
+// Apply returns a copy of LINEAR16 little endian audio at the ducked volume.
+func (d Ducking) Apply(audio []byte) []byte {
+	return scale(audio, d.Gain)
+}
+
+// Duck returns a copy of LINEAR16 little endian audio at the default ducked
+// volume.
+func Duck(audio []byte) []byte {
+	return scale(audio, duckGain)
+}
+
+func scale(audio []byte, gain float64) []byte {
+	out := make([]byte, len(audio))
+	for i := 0; i+1 < len(audio); i += 2 {
+		sample := int16(binary.LittleEndian.Uint16(audio[i:]))
+		binary.LittleEndian.PutUint16(out[i:], uint16(int16(float64(sample)*gain)))
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_interruption
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromOptions(t *testing.T) {
+	assert.Equal(t, StrategyWord, FromOptions(utils.Option{}))
+	assert.Equal(t, StrategyVad, FromOptions(utils.Option{OptionsKeyStrategy: "vad"}))
+	assert.Equal(t, StrategyHybrid, FromOptions(utils.Option{OptionsKeyStrategy: " Hybrid "}))
+	assert.Equal(t, StrategyWord, FromOptions(utils.Option{OptionsKeyStrategy: "loudest"}))
+}
+
+func TestStrategy_Decide(t *testing.T) {
+	tests := []struct {
+		strategy Strategy
+		source   internal_type.InterruptionSource
+		want     Action
+	}{
+		{StrategyWord, internal_type.InterruptionSourceVad, ActionNotify},
+		{StrategyWord, internal_type.InterruptionSourceWord, ActionCut},
+		{StrategyVad, internal_type.InterruptionSourceVad, ActionCut},
+		{StrategyVad, internal_type.InterruptionSourceWord, ActionCut},
+		{StrategyHybrid, internal_type.InterruptionSourceVad, ActionDuck},
+		{StrategyHybrid, internal_type.InterruptionSourceWord, ActionCut},
+		{"", internal_type.InterruptionSourceVad, ActionNotify},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.strategy.Decide(tt.source), "%s on %s", tt.strategy, tt.source)
+	}
+}
+
+func TestDuck(t *testing.T) {
+	loud, quiet := int16(10000), int16(-10000)
+	audio := make([]byte, 6)
+	binary.LittleEndian.PutUint16(audio[0:], uint16(loud))
+	binary.LittleEndian.PutUint16(audio[2:], uint16(quiet))
+
+	out := Duck(audio)
+
+	assert.Equal(t, int16(3000), int16(binary.LittleEndian.Uint16(out[0:])))
+	assert.Equal(t, int16(-3000), int16(binary.LittleEndian.Uint16(out[2:])))
+	assert.Equal(t, int16(0), int16(binary.LittleEndian.Uint16(out[4:])))
+	assert.Equal(t, int16(10000), int16(binary.LittleEndian.Uint16(audio[0:])), "input is left as is")
+}
+
+func TestDuckingFromOptions(t *testing.T) {
+	ducking := DuckingFromOptions(utils.Option{})
+	assert.Equal(t, 0.3, ducking.Gain)
+	assert.Equal(t, DuckWindow, ducking.Window)
+
+	ducking = DuckingFromOptions(utils.Option{OptionsKeyDuckLevel: 20, OptionsKeyDuckWindow: "800"})
+	assert.InDelta(t, 0.1, ducking.Gain, 1e-9)
+	assert.Equal(t, 800*time.Millisecond, ducking.Window)
+
+	ducking = DuckingFromOptions(utils.Option{OptionsKeyDuckLevel: -6, OptionsKeyDuckWindow: 0})
+	assert.InDelta(t, 0.501, ducking.Gain, 1e-3, "the sign of the level is ignored")
+	assert.Equal(t, DuckWindow, ducking.Window)
+
+	assert.InDelta(t, 0.01, DuckingFromOptions(utils.Option{OptionsKeyDuckLevel: 90}).Gain, 1e-9)
+}
+
+func TestDucking_Apply(t *testing.T) {
+	audio := make([]byte, 2)
+	binary.LittleEndian.PutUint16(audio, 10000)
+
+	out := Ducking{Gain: 0.5}.Apply(audio)
+
+	assert.Equal(t, int16(5000), int16(binary.LittleEndian.Uint16(out)))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_interruption
+
+import (
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+const (
+	// OptionsKeyMinSpeech is how long in milliseconds the caller must have
+	// been speaking before the assistant is cut.
+	OptionsKeyMinSpeech = "microphone.interruption.min_speech_ms"
+
+	// OptionsKeyCooldown is how long in milliseconds after a cut the
+	// assistant cannot be cut again.
+	OptionsKeyCooldown = "microphone.interruption.cooldown_ms"
+)
+
+// Sensitivity holds back cuts that are likely noise rather than the caller
+// taking the turn: speech too short to be a word and cuts right after the
+// previous one. The zero value holds back nothing.
+type Sensitivity struct {
+	MinSpeech time.Duration
+	Cooldown  time.Duration
+}
+
+// SensitivityFromOptions returns the sensitivity configured in opts, unset
+// or negative values hold back nothing.
+func SensitivityFromOptions(opts utils.Option) Sensitivity {
+	var s Sensitivity
+	if v, err := opts.GetFloat64(OptionsKeyMinSpeech); err == nil && v > 0 {
+		s.MinSpeech = time.Duration(v) * time.Millisecond
+	}
+	if v, err := opts.GetFloat64(OptionsKeyCooldown); err == nil && v > 0 {
+		s.Cooldown = time.Duration(v) * time.Millisecond
+	}
+	return s
+}
+
+// Allows reports whether a cut goes through. spoken is how long the caller
+// has been speaking, zero when no voice onset was seen and the speech is
+// taken as long enough. lastCut is when the assistant was last cut, zero if
+// never.
+func (s Sensitivity) Allows(spoken time.Duration, lastCut, now time.Time) bool {
+	if spoken > 0 && spoken < s.MinSpeech {
+		return false
+	}
+	if !lastCut.IsZero() && now.Sub(lastCut) < s.Cooldown {
+		return false
+	}
+	return true
+}
+
+// Withheld returns what becomes of a cut the sensitivity held back: the
+// hybrid strategy still ducks the assistant, the others only notify.
+func (s Strategy) Withheld() Action {
+	if s == StrategyHybrid {
+		return ActionDuck
+	}
+	return ActionNotify
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_interruption
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensitivityFromOptions(t *testing.T) {
+	assert.Equal(t, Sensitivity{}, SensitivityFromOptions(utils.Option{}))
+	assert.Equal(t, Sensitivity{}, SensitivityFromOptions(utils.Option{OptionsKeyMinSpeech: "-5", OptionsKeyCooldown: "soon"}))
+	assert.Equal(t,
+		Sensitivity{MinSpeech: 250 * time.Millisecond, Cooldown: 2 * time.Second},
+		SensitivityFromOptions(utils.Option{OptionsKeyMinSpeech: "250", OptionsKeyCooldown: 2000}),
+	)
+}
+
+func TestSensitivity_Allows(t *testing.T) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	s := Sensitivity{MinSpeech: 300 * time.Millisecond, Cooldown: time.Second}
+
+	assert.False(t, s.Allows(100*time.Millisecond, time.Time{}, now), "too short")
+	assert.True(t, s.Allows(300*time.Millisecond, time.Time{}, now))
+	assert.True(t, s.Allows(0, time.Time{}, now), "no onset seen")
+
+	assert.False(t, s.Allows(time.Second, now.Add(-500*time.Millisecond), now), "cooling down")
+	assert.True(t, s.Allows(time.Second, now.Add(-time.Second), now))
+
+	assert.True(t, Sensitivity{}.Allows(time.Millisecond, now, now), "zero value holds back nothing")
+}
+
+func TestStrategy_Withheld(t *testing.T) {
+	assert.Equal(t, ActionDuck, StrategyHybrid.Withheld())
+	assert.Equal(t, ActionNotify, StrategyWord.Withheld())
+	assert.Equal(t, ActionNotify, StrategyVad.Withheld())
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_language identifies the language the caller speaks from
+// the first transcripts of the call and later on, so speech to text, text to
+// speech and the normalizers can follow the caller into another of the
+// languages the assistant allows.
+package internal_language
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Language switching is configured on the deployment. The input audio lists
+// the languages the assistant allows, a call starts in listen.language or
+// the first of them. The output audio overrides its options per language,
+// e.g. the voice, speaker. options as they are and others relative to speak.:
+//
+//	listen.languages                = en-US,es-ES,hi-IN
+//	listen.languages.confirm        = 2
+//	speak.languages.es-ES.voice.id  = <spanish voice>
+//	speak.languages.es-ES.speaker.pronunciation.dictionaries = currency,date
+const (
+	OptionsKeyLanguages = "listen.languages"
+	OptionsKeyConfirm   = "listen.languages.confirm"
+	SpeakOptionsPrefix  = "speak.languages."
+)
+
+// DefaultConfirm is how many final transcripts in a row have to be in
+// another language before the call switches to it.
+const DefaultConfirm = 2
+
+// Detector follows the language of the caller through the final transcripts
+// of the call. It is not safe for concurrent use, transcripts arrive in
+// order.
+type Detector struct {
+	allowed []string
+	current string
+	confirm int
+
+	candidate string
+	votes     int
+}
+
+// FromOptions returns the detector configured in opts, nil when fewer than
+// two languages are allowed and there is nothing to switch between.
+func FromOptions(opts utils.Option) *Detector {
+	list, err := opts.GetString(OptionsKeyLanguages)
+	if err != nil {
+		return nil
+	}
+	var allowed []string
+	for _, language := range strings.Split(list, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			allowed = append(allowed, language)
+		}
+	}
+	if len(allowed) < 2 {
+		return nil
+	}
+	start, _ := opts.GetString("listen.language")
+	confirm := DefaultConfirm
+	if n, err := opts.GetFloat64(OptionsKeyConfirm); err == nil && n >= 1 {
+		confirm = int(n)
+	}
+	return NewDetector(allowed, start, confirm)
+}
+
+// NewDetector follows the caller among the allowed languages starting in
+// start, the first allowed language when start is none of them.
+func NewDetector(allowed []string, start string, confirm int) *Detector {
+	current := Match(allowed, start)
+	if current == "" {
+		current = allowed[0]
+	}
+	return &Detector{allowed: allowed, current: current, confirm: max(confirm, 1)}
+}
+
+// Current is the language the call is in.
+func (d *Detector) Current() string {
+	return d.current
+}
+
+// Allowed is the languages the call may switch between.
+func (d *Detector) Allowed() []string {
+	return d.allowed
+}
+
+// Heard weighs a final transcript, reported is the language speech to text
+// reported for it. It returns the language to switch to once enough
+// transcripts in a row were in it. The text tells the language where it
+// clearly can, the reported language otherwise, speech to text held to one
+// language is apt to report that one whatever it hears.
+func (d *Detector) Heard(reported, text string) (string, bool) {
+	language := Identify(text, d.allowed)
+	if language == "" {
+		language = Match(d.allowed, reported)
+	}
+	if language == "" || language == d.current {
+		d.candidate, d.votes = "", 0
+		return "", false
+	}
+	if language != d.candidate {
+		d.candidate, d.votes = language, 0
+	}
+	d.votes++
+	if d.votes < d.confirm {
+		return "", false
+	}
+	d.current, d.candidate, d.votes = language, "", 0
+	return language, true
+}
+
+// Match returns the allowed language code is, comparing the language
+// without its region when there is no exact match: es matches es-ES. It
+// returns "" when code is none of them.
+func Match(allowed []string, code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return ""
+	}
+	for _, language := range allowed {
+		if strings.ToLower(language) == code {
+			return language
+		}
+	}
+	for _, language := range allowed {
+		if base(language) == base(code) {
+			return language
+		}
+	}
+	return ""
+}
+
+func base(code string) string {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		return code[:i]
+	}
+	return code
+}
+
+// ListenOptions returns the speech to text options of the input audio to
+// transcribe language.
+func ListenOptions(opts utils.Option, language string) utils.Option {
+	return utils.MergeMaps(opts, utils.Option{"listen.language": language})
+}
+
+// SpeakOptions returns the text to speech options of the output audio to
+// speak language: the language set for the provider and the normalizers,
+// and the options overridden for it.
+func SpeakOptions(opts utils.Option, language string) utils.Option {
+	switched := utils.MergeMaps(opts, utils.Option{"speak.language": language, "speaker.language": language})
+	for key, value := range opts {
+		if !strings.HasPrefix(key, SpeakOptionsPrefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, SpeakOptionsPrefix)
+		dot := strings.Index(rest, ".")
+		if dot <= 0 || !strings.EqualFold(rest[:dot], language) {
+			continue
+		}
+		option := rest[dot+1:]
+		if !strings.HasPrefix(option, "speaker.") {
+			option = "speak." + option
+		}
+		switched[option] = value
+	}
+	return switched
+}
+
+// names are the languages identified by their words or script.
+var names = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"it": "Italian",
+	"pt": "Portuguese",
+	"hi": "Hindi",
+	"ar": "Arabic",
+	"ru": "Russian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"zh": "Chinese",
+}
+
+// Name returns the English name of the language of code, code itself when
+// it is not known.
+func Name(code string) string {
+	if name, ok := names[base(code)]; ok {
+		return name
+	}
+	return code
+}
+
+// stopwords are the most frequent words of the languages written in the
+// latin script, the ones telling them apart.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "you", "i", "to", "of", "it", "that", "my", "have", "what", "this", "with", "for", "can", "not", "yes", "please", "thank", "hello", "want", "need", "would"},
+	"es": {"el", "la", "los", "las", "es", "y", "que", "de", "en", "un", "una", "por", "para", "con", "no", "sí", "quiero", "hola", "gracias", "mi", "yo", "usted", "está", "necesito", "cómo", "pero"},
+	"fr": {"le", "la", "les", "est", "et", "je", "vous", "de", "un", "une", "pour", "avec", "pas", "oui", "bonjour", "merci", "mon", "ma", "que", "qui", "c'est", "suis", "voudrais", "des", "du"},
+	"de": {"der", "die", "das", "ist", "und", "ich", "sie", "nicht", "ein", "eine", "zu", "mit", "für", "ja", "nein", "hallo", "danke", "mein", "bitte", "was", "wie", "haben", "möchte", "auf", "den"},
+	"it": {"il", "lo", "gli", "è", "e", "che", "di", "un", "una", "per", "con", "non", "sì", "ciao", "grazie", "mio", "io", "sono", "vorrei", "come", "della", "questo", "buongiorno", "anche"},
+	"pt": {"o", "os", "as", "é", "e", "que", "de", "um", "uma", "para", "com", "não", "sim", "olá", "obrigado", "obrigada", "meu", "eu", "você", "está", "quero", "preciso", "como", "mas"},
+}
+
+// scripts tell apart the languages written in their own script.
+var scripts = []struct {
+	language string
+	table    *unicode.RangeTable
+}{
+	{"hi", unicode.Devanagari},
+	{"ar", unicode.Arabic},
+	{"ru", unicode.Cyrillic},
+	{"ja", unicode.Hiragana},
+	{"ja", unicode.Katakana},
+	{"ko", unicode.Hangul},
+	{"zh", unicode.Han},
+}
+
+// Identify returns the allowed language text is in, "" when it cannot tell.
+// Text mostly written in a script of its own is in the language of the
+// script, text in the latin script in the language whose frequent words it
+// uses most, by a margin.
+func Identify(text string, allowed []string) string {
+	var letters int
+	counts := map[string]int{}
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, s := range scripts {
+			if unicode.Is(s.table, r) {
+				counts[s.language]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+	// kana decides for japanese written with kanji
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+	for language, n := range counts {
+		if 2*n > letters {
+			return Match(allowed, language)
+		}
+	}
+
+	hits := map[string]int{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		for _, a := range allowed {
+			language := base(a)
+			for _, w := range stopwords[language] {
+				if w == word {
+					hits[a]++
+					break
+				}
+			}
+		}
+	}
+	best, second := "", 0
+	for _, a := range allowed {
+		switch {
+		case best == "" || hits[a] > hits[best]:
+			if best != "" {
+				second = hits[best]
+			}
+			best = a
+		case hits[a] > second:
+			second = hits[a]
+		}
+	}
+	if best == "" || hits[best] < 2 || hits[best] < 2*second {
+		return ""
+	}
+	return best
+}
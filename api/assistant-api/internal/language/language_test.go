@@ -0,0 +1,100 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_language
+
+import (
+	"testing"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromOptions(t *testing.T) {
+	assert.Nil(t, FromOptions(utils.Option{}))
+	assert.Nil(t, FromOptions(utils.Option{OptionsKeyLanguages: "en-US"}), "nothing to switch between")
+
+	d := FromOptions(utils.Option{OptionsKeyLanguages: "en-US, es-ES ,hi-IN", "listen.language": "es"})
+	require.NotNil(t, d)
+	assert.Equal(t, []string{"en-US", "es-ES", "hi-IN"}, d.Allowed())
+	assert.Equal(t, "es-ES", d.Current())
+	assert.Equal(t, DefaultConfirm, d.confirm)
+
+	d = FromOptions(utils.Option{OptionsKeyLanguages: "en-US,es-ES", "listen.language": "fr-FR", OptionsKeyConfirm: "1"})
+	require.NotNil(t, d)
+	assert.Equal(t, "en-US", d.Current(), "a start not allowed starts in the first")
+	assert.Equal(t, 1, d.confirm)
+}
+
+func TestMatch(t *testing.T) {
+	allowed := []string{"en-US", "es-ES", "pt-BR"}
+	assert.Equal(t, "es-ES", Match(allowed, "es"))
+	assert.Equal(t, "es-ES", Match(allowed, "ES-es"))
+	assert.Equal(t, "pt-BR", Match(allowed, "pt-PT"))
+	assert.Equal(t, "", Match(allowed, "fr"))
+	assert.Equal(t, "", Match(allowed, ""))
+}
+
+func TestIdentify(t *testing.T) {
+	allowed := []string{"en-US", "es-ES", "fr-FR", "de-DE", "hi-IN"}
+	assert.Equal(t, "en-US", Identify("I need to change my booking please", allowed))
+	assert.Equal(t, "es-ES", Identify("Hola, quiero cambiar mi reserva por favor", allowed))
+	assert.Equal(t, "fr-FR", Identify("Bonjour, je voudrais changer ma réservation", allowed))
+	assert.Equal(t, "de-DE", Identify("Hallo, ich möchte meine Buchung ändern", allowed))
+	assert.Equal(t, "hi-IN", Identify("मुझे अपनी बुकिंग बदलनी है", allowed))
+
+	assert.Equal(t, "", Identify("okay", allowed), "too little to tell")
+	assert.Equal(t, "", Identify("12345", allowed))
+	assert.Equal(t, "", Identify("मुझे अपनी बुकिंग बदलनी है", []string{"en-US", "es-ES"}), "not an allowed language")
+}
+
+func TestDetector_SwitchesOnceConfirmed(t *testing.T) {
+	d := NewDetector([]string{"en-US", "es-ES"}, "en-US", 2)
+
+	_, ok := d.Heard("en", "hola quiero ayuda con mi cuenta")
+	assert.False(t, ok, "one transcript is not enough")
+	_, ok = d.Heard("en", "I want help with my account")
+	assert.False(t, ok, "back to the current language starts over")
+	_, ok = d.Heard("en", "hola quiero ayuda con mi cuenta")
+	assert.False(t, ok)
+
+	language, ok := d.Heard("es", "sí, es para la factura")
+	assert.True(t, ok)
+	assert.Equal(t, "es-ES", language)
+	assert.Equal(t, "es-ES", d.Current())
+
+	// the text is unclear, the reported language decides
+	_, ok = d.Heard("en-US", "okay")
+	assert.False(t, ok)
+	language, ok = d.Heard("en-US", "fine")
+	assert.True(t, ok)
+	assert.Equal(t, "en-US", language)
+
+	_, ok = d.Heard("fr", "d'accord")
+	assert.False(t, ok, "languages not allowed are ignored")
+	assert.Equal(t, "en-US", d.Current())
+}
+
+func TestSpeakOptions(t *testing.T) {
+	opts := utils.Option{
+		"speak.voice.id":                 "english",
+		"speak.model":                    "multilingual",
+		"speak.languages.es-ES.voice.id": "spanish",
+		"speak.languages.es-ES.speaker.pronunciation.dictionaries": "date",
+		"speak.languages.hi-IN.voice.id":                           "hindi",
+	}
+	switched := SpeakOptions(opts, "es-ES")
+	assert.Equal(t, "spanish", switched["speak.voice.id"])
+	assert.Equal(t, "multilingual", switched["speak.model"])
+	assert.Equal(t, "es-ES", switched["speak.language"])
+	assert.Equal(t, "es-ES", switched["speaker.language"])
+	assert.Equal(t, "date", switched["speaker.pronunciation.dictionaries"])
+	assert.Equal(t, "english", opts["speak.voice.id"], "the options of the deployment are left alone")
+
+	assert.Equal(t, "fr-FR", ListenOptions(utils.Option{"listen.language": "en-US"}, "fr-FR")["listen.language"])
+	assert.Equal(t, "Spanish", Name("es-ES"))
+	assert.Equal(t, "xx-YY", Name("xx-YY"))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_latency measures how long the caller waits at each stage
+// of a turn. A turn starts with the caller's last voiced audio, stamped when
+// the channel received it, goes through the transcript handed to the LLM and
+// its first token, and ends with the first audio of the reply handed to the
+// channel.
+package internal_latency
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+// Stage is a leg of the path from the caller's audio to the reply.
+type Stage string
+
+const (
+	// CaptureToTranscript runs from the caller's last voiced audio to the
+	// transcript of the turn, speech to text and end of speech detection.
+	CaptureToTranscript Stage = "capture_to_transcript"
+	// TranscriptToFirstToken is the LLM until its first token.
+	TranscriptToFirstToken Stage = "transcript_to_first_token"
+	// TokenToFirstAudio is text to speech until the first audio.
+	TokenToFirstAudio Stage = "token_to_first_audio"
+	// Total runs from the caller's last voiced audio to the first audio.
+	Total Stage = "turn"
+)
+
+// Stages in the order of the path.
+var Stages = []Stage{CaptureToTranscript, TranscriptToFirstToken, TokenToFirstAudio, Total}
+
+// maxObservations bounds what a conversation keeps per stage, the latest
+// turns count.
+const maxObservations = 1024
+
+// Turn is the latency of a completed turn. A stage is zero when it was not
+// measured, the capture of typed input or of audio without VAD is unknown.
+type Turn struct {
+	ContextID string
+	Latency   map[Stage]time.Duration
+}
+
+type turn struct {
+	contextID   string
+	captured    time.Time
+	transcribed time.Time
+	firstToken  time.Time
+}
+
+// Tracker follows the turns of one conversation. Only the latest turn is
+// followed, a reply superseded by the caller speaking again is not measured.
+type Tracker struct {
+	mu           sync.Mutex
+	voiced       time.Time
+	current      *turn
+	observations map[Stage][]time.Duration
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{observations: make(map[Stage][]time.Duration)}
+}
+
+// Voiced records the capture time of audio speech was detected in.
+func (t *Tracker) Voiced(at time.Time) {
+	if at.IsZero() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if at.After(t.voiced) {
+		t.voiced = at
+	}
+}
+
+// Transcribed starts turn contextID, whose transcript was handed to the LLM
+// at at. The voiced audio since the previous turn is the caller's.
+func (t *Tracker) Transcribed(contextID string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current = &turn{contextID: contextID, captured: t.voiced, transcribed: at}
+	t.voiced = time.Time{}
+}
+
+// FirstToken records the first token of the reply to turn contextID.
+func (t *Tracker) FirstToken(contextID string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil || t.current.contextID != contextID || !t.current.firstToken.IsZero() {
+		return
+	}
+	t.current.firstToken = at
+}
+
+// FirstAudio completes turn contextID with the first audio of its reply. It
+// returns false for any later audio and for turns not followed.
+func (t *Tracker) FirstAudio(contextID string, at time.Time) (Turn, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current := t.current
+	if current == nil || current.contextID != contextID {
+		return Turn{}, false
+	}
+	t.current = nil
+
+	completed := Turn{ContextID: contextID, Latency: make(map[Stage]time.Duration, len(Stages))}
+	observe := func(stage Stage, from, to time.Time) {
+		if from.IsZero() || to.IsZero() || to.Before(from) {
+			return
+		}
+		completed.Latency[stage] = to.Sub(from)
+		observations := append(t.observations[stage], to.Sub(from))
+		if len(observations) > maxObservations {
+			observations = observations[len(observations)-maxObservations:]
+		}
+		t.observations[stage] = observations
+	}
+	observe(CaptureToTranscript, current.captured, current.transcribed)
+	observe(TranscriptToFirstToken, current.transcribed, current.firstToken)
+	observe(TokenToFirstAudio, current.firstToken, at)
+	observe(Total, current.captured, at)
+	return completed, true
+}
+
+// Percentile returns the p-th percentile (0 to 100) of the stage over the
+// completed turns, false before any was measured.
+func (t *Tracker) Percentile(stage Stage, p float64) (time.Duration, bool) {
+	t.mu.Lock()
+	observations := slices.Clone(t.observations[stage])
+	t.mu.Unlock()
+	if len(observations) == 0 {
+		return 0, false
+	}
+	slices.Sort(observations)
+	// nearest rank
+	rank := int(p/100*float64(len(observations)) + 0.5)
+	return observations[min(max(rank, 1), len(observations))-1], true
+}
+
+// Metrics returns p50, p90 and p99 of every stage measured so far as
+// conversation metrics (latency_turn_p90_ms). They are stored by name, the
+// latest replace the previous ones.
+func (t *Tracker) Metrics() []*protos.Metric {
+	var metrics []*protos.Metric
+	for _, stage := range Stages {
+		for _, p := range []float64{50, 90, 99} {
+			latency, ok := t.Percentile(stage, p)
+			if !ok {
+				break
+			}
+			percentile := "p" + strconv.Itoa(int(p))
+			metrics = append(metrics, &protos.Metric{
+				Name:        "latency_" + string(stage) + "_" + percentile + "_ms",
+				Value:       strconv.FormatInt(latency.Milliseconds(), 10),
+				Description: "Latency " + string(stage) + " of the conversation's turns, " + percentile,
+			})
+		}
+	}
+	return metrics
+}
+
+// Metrics returns the stages measured for the turn as metrics of its
+// message (latency_turn_ms).
+func (t Turn) Metrics() []*protos.Metric {
+	var metrics []*protos.Metric
+	for _, stage := range Stages {
+		latency, ok := t.Latency[stage]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, &protos.Metric{
+			Name:        "latency_" + string(stage) + "_ms",
+			Value:       strconv.FormatInt(latency.Milliseconds(), 10),
+			Description: "Latency " + string(stage) + " of the turn",
+		})
+	}
+	return metrics
+}
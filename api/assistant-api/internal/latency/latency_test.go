@@ -0,0 +1,84 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_latency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_Turn(t *testing.T) {
+	tracker := NewTracker()
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	tracker.Voiced(at(0))
+	tracker.Voiced(at(400))
+	tracker.Voiced(at(200)) // a late VAD result of earlier audio
+	tracker.Transcribed("ctx-1", at(1100))
+	tracker.FirstToken("ctx-1", at(1500))
+	tracker.FirstToken("ctx-1", at(1600))
+
+	_, ok := tracker.FirstAudio("ctx-0", at(1700))
+	assert.False(t, ok, "audio of another turn")
+
+	turn, ok := tracker.FirstAudio("ctx-1", at(1800))
+	require.True(t, ok)
+	assert.Equal(t, map[Stage]time.Duration{
+		CaptureToTranscript:    700 * time.Millisecond,
+		TranscriptToFirstToken: 400 * time.Millisecond,
+		TokenToFirstAudio:      300 * time.Millisecond,
+		Total:                  1400 * time.Millisecond,
+	}, turn.Latency)
+
+	_, ok = tracker.FirstAudio("ctx-1", at(1900))
+	assert.False(t, ok, "only the first audio completes the turn")
+}
+
+func TestTracker_TypedTurn(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Now()
+	tracker.Transcribed("ctx-1", now)
+	tracker.FirstToken("ctx-1", now.Add(time.Second))
+	turn, ok := tracker.FirstAudio("ctx-1", now.Add(2*time.Second))
+	require.True(t, ok)
+	assert.NotContains(t, turn.Latency, CaptureToTranscript)
+	assert.NotContains(t, turn.Latency, Total)
+	assert.Len(t, turn.Metrics(), 2)
+}
+
+func TestTracker_Percentiles(t *testing.T) {
+	tracker := NewTracker()
+	_, ok := tracker.Percentile(Total, 50)
+	assert.False(t, ok)
+	assert.Empty(t, tracker.Metrics())
+
+	now := time.Now()
+	for i := 1; i <= 10; i++ {
+		tracker.Voiced(now)
+		tracker.Transcribed("ctx", now)
+		tracker.FirstToken("ctx", now)
+		tracker.FirstAudio("ctx", now.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	p50, _ := tracker.Percentile(Total, 50)
+	p90, _ := tracker.Percentile(Total, 90)
+	p99, _ := tracker.Percentile(Total, 99)
+	assert.Equal(t, 500*time.Millisecond, p50)
+	assert.Equal(t, 900*time.Millisecond, p90)
+	assert.Equal(t, time.Second, p99)
+
+	values := make(map[string]string)
+	for _, m := range tracker.Metrics() {
+		values[m.GetName()] = m.GetValue()
+	}
+	assert.Equal(t, "500", values["latency_turn_p50_ms"])
+	assert.Equal(t, "0", values["latency_capture_to_transcript_p99_ms"])
+	assert.Len(t, values, 12)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_livetranscript keeps the transcript of live conversations
+// in a redis stream per conversation, so subscribers on any instance follow
+// it as server-sent events and pick up where they left off after a
+// reconnect. The id of an event is its stream entry id.
+package internal_livetranscript
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// EventTranscript is a segment of the transcript, EventEnd is sent once
+	// after the conversation ended.
+	EventTranscript = "transcript"
+	EventEnd        = "end"
+)
+
+// streamKeyPrefix namespaces the streams by conversation id.
+const streamKeyPrefix = "rapida:transcript:live:"
+
+// readBatch is the most events one read returns.
+const readBatch = 100
+
+func streamKey(conversationID uint64) string {
+	return streamKeyPrefix + strconv.FormatUint(conversationID, 10)
+}
+
+// entryID is the form of a redis stream entry id.
+var entryID = regexp.MustCompile(`^\d+-\d+$`)
+
+// ValidID reports whether id can be resumed from, the Last-Event-ID of a
+// subscriber.
+func ValidID(id string) bool {
+	return entryID.MatchString(id)
+}
+
+// Event is one update of a live conversation. Interim segments of the user
+// are the speech recognized so far, those of the assistant the text it
+// generated since the previous one. Final segments are the complete message
+// as persisted.
+type Event struct {
+	ID        string    `json:"-"`
+	Type      string    `json:"type"`
+	MessageID string    `json:"messageId,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	Speaker   string    `json:"speaker,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Final     bool      `json:"final,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// WriteSSE writes e to w as a server-sent event.
+func WriteSSE(w io.Writer, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// Feed appends the events of live conversations to their streams and reads
+// them back. A stream keeps the last maxEvents events and is removed ttl
+// after its last event.
+type Feed struct {
+	client    redis.UniversalClient
+	maxEvents int64
+	ttl       time.Duration
+}
+
+func NewFeed(client redis.UniversalClient, maxEvents int64, ttl time.Duration) *Feed {
+	return &Feed{client: client, maxEvents: maxEvents, ttl: ttl}
+}
+
+var active atomic.Pointer[Feed]
+
+// Install makes feed the one live conversations publish to.
+func Install(feed *Feed) {
+	active.Store(feed)
+}
+
+// Active returns the installed feed, nil when live transcripts are off.
+func Active() *Feed {
+	return active.Load()
+}
+
+// Append adds events to the stream of a conversation in order.
+func (f *Feed) Append(ctx context.Context, conversationID uint64, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	key := streamKey(conversationID)
+	_, err := f.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, e := range events {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: key,
+				MaxLen: f.maxEvents,
+				Approx: true,
+				Values: []interface{}{"event", data},
+			})
+		}
+		pipe.Expire(ctx, key, f.ttl)
+		return nil
+	})
+	return err
+}
+
+// Read returns the events of a conversation after the event id, from the
+// oldest kept when id is empty. It waits up to block for one when there is
+// none yet and returns none when the wait ran out.
+func (f *Feed) Read(ctx context.Context, conversationID uint64, id string, block time.Duration) ([]Event, error) {
+	if id == "" {
+		id = "0"
+	}
+	streams, err := f.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{streamKey(conversationID), id},
+		Count:   readBatch,
+		Block:   block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			e, err := decode(message)
+			if err != nil {
+				continue
+			}
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func decode(message redis.XMessage) (Event, error) {
+	var e Event
+	data, ok := message.Values["event"].(string)
+	if !ok {
+		return e, errors.New("stream entry without an event")
+	}
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return e, err
+	}
+	e.ID = message.ID
+	return e, nil
+}
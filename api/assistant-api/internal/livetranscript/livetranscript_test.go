@@ -0,0 +1,97 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_livetranscript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidID(t *testing.T) {
+	assert.True(t, ValidID("1718000000000-0"))
+	assert.False(t, ValidID("0"))
+	assert.False(t, ValidID("$"))
+	assert.False(t, ValidID("1718000000000-0\nevent: end"))
+}
+
+func TestWriteSSE(t *testing.T) {
+	var buf bytes.Buffer
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, WriteSSE(&buf, Event{ID: "1-0", Type: EventTranscript, MessageID: "m1", Role: "user", Text: "hi", Final: true, Time: at}))
+	assert.Equal(t, "id: 1-0\nevent: transcript\n"+
+		`data: {"type":"transcript","messageId":"m1","role":"user","text":"hi","final":true,"time":"2025-01-02T03:04:05Z"}`+"\n\n", buf.String())
+}
+
+func TestFeed_AppendRead(t *testing.T) {
+	ctx := context.Background()
+	db, mock := redismock.NewClientMock()
+	feed := NewFeed(db, 1000, time.Hour)
+	e := Event{Type: EventTranscript, MessageID: "m1", Role: "assistant", Text: "hello", Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+
+	mock.ExpectXAdd(&redis.XAddArgs{Stream: streamKey(42), MaxLen: 1000, Approx: true, Values: []interface{}{"event", data}}).SetVal("1-0")
+	mock.ExpectExpire(streamKey(42), time.Hour).SetVal(true)
+	require.NoError(t, feed.Append(ctx, 42, e))
+
+	mock.ExpectXRead(&redis.XReadArgs{Streams: []string{streamKey(42), "0"}, Count: readBatch, Block: time.Second}).SetVal([]redis.XStream{{
+		Stream: streamKey(42),
+		Messages: []redis.XMessage{
+			{ID: "1-0", Values: map[string]interface{}{"event": string(data)}},
+			{ID: "2-0", Values: map[string]interface{}{"other": "ignored"}},
+		},
+	}})
+	events, err := feed.Read(ctx, 42, "", time.Second)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1-0", events[0].ID)
+	assert.Equal(t, "hello", events[0].Text)
+
+	// the wait ran out
+	mock.ExpectXRead(&redis.XReadArgs{Streams: []string{streamKey(42), "1-0"}, Count: readBatch, Block: time.Second}).RedisNil()
+	events, err = feed.Read(ctx, 42, "1-0", time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriter_PublishesInOrderAndEnds(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	var (
+		mu  sync.Mutex
+		got []Event
+	)
+	w := newWriter(func(_ context.Context, conversationID uint64, events ...Event) error {
+		assert.Equal(t, uint64(7), conversationID)
+		mu.Lock()
+		got = append(got, events...)
+		mu.Unlock()
+		return nil
+	}, logger, 7)
+
+	for _, text := range []string{"a", "b", "c"} {
+		require.True(t, w.Publish(Event{Type: EventTranscript, Text: text}))
+	}
+	w.Close(context.Background())
+	assert.False(t, w.Publish(Event{Type: EventTranscript, Text: "late"}))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, got, 4)
+	assert.Equal(t, []string{"a", "b", "c", ""}, []string{got[0].Text, got[1].Text, got[2].Text, got[3].Text})
+	assert.Equal(t, EventEnd, got[3].Type)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_livetranscript
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/pkg/commons"
+)
+
+const (
+	// queueSize bounds the events waiting for redis, newer ones are dropped
+	// beyond it rather than holding up the call.
+	queueSize = 512
+	// appendTimeout bounds one write of events.
+	appendTimeout = 2 * time.Second
+)
+
+// Writer publishes the events of one conversation off the talk loop, the
+// events waiting when a write is done go out together in the next.
+type Writer struct {
+	logger         commons.Logger
+	conversationID uint64
+	write          func(ctx context.Context, conversationID uint64, events ...Event) error
+
+	mu     sync.RWMutex
+	closed bool
+	in     chan Event
+	done   chan struct{}
+}
+
+// NewWriter starts publishing the events of a conversation to feed.
+func NewWriter(feed *Feed, logger commons.Logger, conversationID uint64) *Writer {
+	return newWriter(feed.Append, logger, conversationID)
+}
+
+func newWriter(write func(context.Context, uint64, ...Event) error, logger commons.Logger, conversationID uint64) *Writer {
+	w := &Writer{
+		logger:         logger,
+		conversationID: conversationID,
+		write:          write,
+		in:             make(chan Event, queueSize),
+		done:           make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Publish queues an event. It never blocks and returns false when the event
+// was dropped because the queue is full or the writer closed.
+func (w *Writer) Publish(e Event) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return false
+	}
+	select {
+	case w.in <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close publishes what is still queued followed by the end event, unless
+// ctx ends first.
+func (w *Writer) Close(ctx context.Context) {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.closed = true
+	w.in <- Event{Type: EventEnd, Time: time.Now()}
+	close(w.in)
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+	}
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+	batch := make([]Event, 0, queueSize)
+	for e := range w.in {
+		batch = append(batch[:0], e)
+	drain:
+		for len(batch) < cap(batch) {
+			select {
+			case next, ok := <-w.in:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), appendTimeout)
+		if err := w.write(ctx, w.conversationID, batch...); err != nil {
+			w.logger.Warnf("unable to publish %d live transcript events of conversation %d: %v", len(batch), w.conversationID, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_metering meters the billable usage of a call: how long it
+// was connected, how long each party talked and how long nobody did. Talk
+// time is measured on the wall clock, the assistant from the audio sent to
+// the channel and the caller from voice activity, so usage can be priced by
+// active speech rather than by connected minutes.
+package internal_metering
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+// CallerBridge is the longest gap between two voice activity reports that is
+// still counted as the caller talking, the pauses between words.
+const CallerBridge = 300 * time.Millisecond
+
+// span is a stretch of time someone talked.
+type span struct {
+	from, to time.Time
+}
+
+// spans are the stretches of talk of one party, mostly in order.
+type spans []span
+
+// add records talk from from to to, merging it into the latest stretch when
+// they are no more than bridge apart.
+func (s *spans) add(from, to time.Time, bridge time.Duration) {
+	if !to.After(from) {
+		to = from
+	}
+	if n := len(*s); n > 0 {
+		last := &(*s)[n-1]
+		if !from.After(last.to.Add(bridge)) && !to.Before(last.from) {
+			if from.Before(last.from) {
+				last.from = from
+			}
+			if to.After(last.to) {
+				last.to = to
+			}
+			return
+		}
+	}
+	*s = append(*s, span{from: from, to: to})
+}
+
+// within returns the stretches sorted, merged and clipped to from and to.
+func (s spans) within(from, to time.Time) spans {
+	sorted := slices.Clone(s)
+	slices.SortFunc(sorted, func(a, b span) int { return a.from.Compare(b.from) })
+	var out spans
+	for _, sp := range sorted {
+		if sp.from.Before(from) {
+			sp.from = from
+		}
+		if sp.to.After(to) {
+			sp.to = to
+		}
+		if !sp.to.After(sp.from) {
+			continue
+		}
+		out.add(sp.from, sp.to, 0)
+	}
+	return out
+}
+
+// total is the summed length of merged stretches.
+func (s spans) total() time.Duration {
+	var d time.Duration
+	for _, sp := range s {
+		d += sp.to.Sub(sp.from)
+	}
+	return d
+}
+
+// overlap is how long two merged, sorted sets of stretches overlap.
+func overlap(a, b spans) time.Duration {
+	var d time.Duration
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		from, to := a[i].from, a[i].to
+		if b[j].from.After(from) {
+			from = b[j].from
+		}
+		if b[j].to.Before(to) {
+			to = b[j].to
+		}
+		if to.After(from) {
+			d += to.Sub(from)
+		}
+		if a[i].to.Before(b[j].to) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return d
+}
+
+// Meter collects the talk of both parties of a call. It is safe for
+// concurrent use.
+type Meter struct {
+	mu        sync.Mutex
+	start     time.Time
+	assistant spans
+	caller    spans
+}
+
+// NewMeter creates a meter for a call connected at start.
+func NewMeter(start time.Time) *Meter {
+	return &Meter{start: start}
+}
+
+// Assistant records the assistant being heard for d from from.
+func (m *Meter) Assistant(from time.Time, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.assistant.add(from, from.Add(d), 0)
+}
+
+// Cut drops the assistant's talk after at, the audio still queued then was
+// never heard.
+func (m *Meter) Cut(at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for n := len(m.assistant); n > 0; n = len(m.assistant) {
+		last := &m.assistant[n-1]
+		if !last.to.After(at) {
+			return
+		}
+		if last.from.Before(at) {
+			last.to = at
+			return
+		}
+		m.assistant = m.assistant[:n-1]
+	}
+}
+
+// Caller records voice activity of the caller: d of speech reported at at.
+func (m *Meter) Caller(at time.Time, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.caller.add(at.Add(-max(d, 0)), at, CallerBridge)
+}
+
+// Usage is the metered usage of a call.
+type Usage struct {
+	Connect       time.Duration // from connect to end
+	AssistantTalk time.Duration
+	CallerTalk    time.Duration
+	Overlap       time.Duration // both talked at once
+	MutualSilence time.Duration // nobody talked
+}
+
+// Usage returns the usage of the call ended at end. Talk outside of the
+// connected time is not counted.
+func (m *Meter) Usage(end time.Time) Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if end.Before(m.start) {
+		end = m.start
+	}
+	assistant := m.assistant.within(m.start, end)
+	caller := m.caller.within(m.start, end)
+	u := Usage{
+		Connect:       end.Sub(m.start),
+		AssistantTalk: assistant.total(),
+		CallerTalk:    caller.total(),
+		Overlap:       overlap(assistant, caller),
+	}
+	u.MutualSilence = u.Connect - (u.AssistantTalk + u.CallerTalk - u.Overlap)
+	return u
+}
+
+// Metrics returns the usage as conversation metrics, in seconds.
+func (u Usage) Metrics() []*protos.Metric {
+	seconds := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Seconds(), 'f', 2, 64)
+	}
+	return []*protos.Metric{
+		{
+			Name:        "usage_connect_seconds",
+			Value:       seconds(u.Connect),
+			Description: "Time the call was connected",
+		},
+		{
+			Name:        "usage_assistant_talk_seconds",
+			Value:       seconds(u.AssistantTalk),
+			Description: "Time the caller heard the assistant",
+		},
+		{
+			Name:        "usage_caller_talk_seconds",
+			Value:       seconds(u.CallerTalk),
+			Description: "Time the caller spoke, from voice activity",
+		},
+		{
+			Name:        "usage_overlap_seconds",
+			Value:       seconds(u.Overlap),
+			Description: "Time both the assistant and the caller spoke",
+		},
+		{
+			Name:        "usage_mutual_silence_seconds",
+			Value:       seconds(u.MutualSilence),
+			Description: "Time neither the assistant nor the caller spoke",
+		},
+	}
+}
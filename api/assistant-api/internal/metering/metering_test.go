@@ -0,0 +1,84 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_metering
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) time.Time { return start.Add(d) }
+
+func TestMeter_Usage(t *testing.T) {
+	m := NewMeter(start)
+
+	// the assistant greets for 3s in chunks
+	for i := 0; i < 3; i++ {
+		m.Assistant(at(time.Duration(i)*time.Second), time.Second)
+	}
+	// the caller answers from 5s to 7s, reported every 100ms
+	for d := 5100 * time.Millisecond; d <= 7*time.Second; d += 100 * time.Millisecond {
+		m.Caller(at(d), 100*time.Millisecond)
+	}
+	// and barges in on the reply from 9.5s to 10.5s
+	m.Assistant(at(8*time.Second), 3*time.Second)
+	m.Caller(at(10*time.Second), 500*time.Millisecond)
+	m.Caller(at(10500*time.Millisecond), 500*time.Millisecond)
+	m.Cut(at(10500 * time.Millisecond))
+
+	u := m.Usage(at(20 * time.Second))
+	assert.Equal(t, 20*time.Second, u.Connect)
+	assert.Equal(t, 5500*time.Millisecond, u.AssistantTalk)
+	assert.Equal(t, 3*time.Second, u.CallerTalk)
+	assert.Equal(t, time.Second, u.Overlap)
+	assert.Equal(t, 12500*time.Millisecond, u.MutualSilence)
+}
+
+func TestMeter_CallerBridge(t *testing.T) {
+	m := NewMeter(start)
+	m.Caller(at(time.Second), 0)
+	m.Caller(at(time.Second+CallerBridge), 0)
+	m.Caller(at(3*time.Second), 0)
+
+	assert.Equal(t, CallerBridge, m.Usage(at(5*time.Second)).CallerTalk, "pause between words is talk, the long one is not")
+}
+
+func TestMeter_CutDropsUnheardAudio(t *testing.T) {
+	m := NewMeter(start)
+	m.Assistant(at(0), time.Second)
+	m.Assistant(at(2*time.Second), time.Second)
+	m.Assistant(at(3*time.Second), time.Second)
+	m.Cut(at(1500 * time.Millisecond))
+
+	assert.Equal(t, time.Second, m.Usage(at(10*time.Second)).AssistantTalk)
+}
+
+func TestMeter_ClipsToCall(t *testing.T) {
+	m := NewMeter(start)
+	m.Caller(at(200*time.Millisecond), time.Second)
+	m.Assistant(at(4*time.Second), 5*time.Second)
+
+	u := m.Usage(at(5 * time.Second))
+	assert.Equal(t, 200*time.Millisecond, u.CallerTalk)
+	assert.Equal(t, time.Second, u.AssistantTalk)
+	assert.Equal(t, 3800*time.Millisecond, u.MutualSilence)
+
+	assert.Equal(t, Usage{}, NewMeter(start).Usage(at(-time.Second)))
+}
+
+func TestUsage_Metrics(t *testing.T) {
+	metrics := Usage{Connect: 90 * time.Second, AssistantTalk: 1250 * time.Millisecond}.Metrics()
+	assert.Len(t, metrics, 5)
+	assert.Equal(t, "usage_connect_seconds", metrics[0].GetName())
+	assert.Equal(t, "90.00", metrics[0].GetValue())
+	assert.Equal(t, "1.25", metrics[1].GetValue())
+	assert.Equal(t, "0.00", metrics[4].GetValue())
+}
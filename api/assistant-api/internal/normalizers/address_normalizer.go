@@ -7,31 +7,33 @@ package internal_normalizers
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/rapidaai/pkg/commons"
 )
 
 type addressNormalizer struct {
 	logger       commons.Logger
+	re           *regexp.Regexp
 	replacements map[string]string
 }
 
 func NewAddressNormalizer(logger commons.Logger) Normalizer {
 	return &addressNormalizer{
 		logger: logger,
+		// one pass over the text for all abbreviations
+		re: regexp.MustCompile(`(?i)\b(st|ave|rd|blvd)\b`),
 		replacements: map[string]string{
-			`(?i)\bst\b`:   "street",
-			`(?i)\bave\b`:  "avenue",
-			`(?i)\brd\b`:   "road",
-			`(?i)\bblvd\b`: "boulevard",
+			"st":   "street",
+			"ave":  "avenue",
+			"rd":   "road",
+			"blvd": "boulevard",
 		},
 	}
 }
 
 func (an *addressNormalizer) Normalize(s string) string {
-	for abbr, full := range an.replacements {
-		re := regexp.MustCompile(abbr)
-		s = re.ReplaceAllString(s, full)
-	}
-	return s
+	return an.re.ReplaceAllStringFunc(s, func(match string) string {
+		return an.replacements[strings.ToLower(match)]
+	})
 }
@@ -0,0 +1,140 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// chainMemoSize is how many recently normalized sentences a chain remembers.
+const chainMemoSize = 256
+
+// Chain runs normalizers over text one sentence at a time. A sentence ends at
+// . ! ? or a newline followed by whitespace, the whitespace between sentences
+// is kept as it is. Sentences normalized recently, greetings and fillers the
+// assistant repeats, are answered from memory instead of running every
+// normalizer again. A nil Chain returns text unchanged.
+type Chain struct {
+	normalizers []Normalizer
+
+	mu    sync.Mutex
+	memo  map[string]string
+	order []string // insertion order of memo, oldest at next
+	next  int
+}
+
+// NewChain chains normalizers in the order given. It returns nil when there
+// are none.
+func NewChain(normalizers ...Normalizer) *Chain {
+	if len(normalizers) == 0 {
+		return nil
+	}
+	return &Chain{
+		normalizers: normalizers,
+		memo:        make(map[string]string),
+	}
+}
+
+// Len is the number of normalizers in the chain.
+func (c *Chain) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.normalizers)
+}
+
+// Normalize implements Normalizer.
+func (c *Chain) Normalize(s string) string {
+	if c == nil || s == "" {
+		return s
+	}
+	var out strings.Builder
+	out.Grow(len(s) + len(s)/4)
+	for s != "" {
+		sentence, space, rest := nextSentence(s)
+		out.WriteString(c.sentence(sentence))
+		out.WriteString(space)
+		s = rest
+	}
+	return out.String()
+}
+
+// sentence normalizes a single sentence, from memory when it was seen
+// recently.
+func (c *Chain) sentence(s string) string {
+	if s == "" {
+		return s
+	}
+	c.mu.Lock()
+	normalized, ok := c.memo[s]
+	c.mu.Unlock()
+	if ok {
+		return normalized
+	}
+
+	normalized = s
+	for _, normalizer := range c.normalizers {
+		normalized = normalizer.Normalize(normalized)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.memo[s]; ok {
+		return normalized
+	}
+	if len(c.order) < chainMemoSize {
+		c.order = append(c.order, s)
+	} else {
+		delete(c.memo, c.order[c.next])
+		c.order[c.next] = s
+		c.next = (c.next + 1) % chainMemoSize
+	}
+	c.memo[s] = normalized
+	return normalized
+}
+
+// nextSentence splits the first sentence off s, with the whitespace that
+// follows it. Leading whitespace is returned as a sentence of its own.
+func nextSentence(s string) (sentence, space, rest string) {
+	if start := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsSpace(r) }); start != 0 {
+		if start < 0 {
+			return "", s, ""
+		}
+		return "", s[:start], s[start:]
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		if r != '.' && r != '!' && r != '?' && r != '\n' {
+			continue
+		}
+		if r != '\n' && (i == len(s) || !isSpaceAt(s, i)) {
+			continue
+		}
+		end := i
+		if r == '\n' {
+			end = i - size
+		}
+		j := end
+		for j < len(s) {
+			r, size := utf8.DecodeRuneInString(s[j:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			j += size
+		}
+		return s[:end], s[end:j], s[j:]
+	}
+	return s, "", ""
+}
+
+func isSpaceAt(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsSpace(r)
+}
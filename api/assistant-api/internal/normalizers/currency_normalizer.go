@@ -17,16 +17,41 @@ import (
 type currencyNormalizer struct {
 	logger commons.Logger
 	re     *regexp.Regexp
+	locale *Locale
 }
 
+var (
+	// amounts with the symbol ahead, $12.50, and behind, 12,50 €, grouped by
+	// a dot, comma or space, the two decimals after either
+	symbolAmountRe = regexp.MustCompile(`([$€£₹])\s?(\d{1,3}(?:[.,\x{00a0}\x{202f}]\d{3})+|\d+)(?:[.,](\d{2}))?\b`)
+	amountSymbolRe = regexp.MustCompile(`\b(\d{1,3}(?:[.,\x{00a0}\x{202f}]\d{3})+|\d+)(?:[.,](\d{2}))?\s?([$€£₹])`)
+	amountGroupRe  = regexp.MustCompile(`[.,\x{00a0}\x{202f}]`)
+)
+
 func NewCurrencyNormalizer(logger commons.Logger) Normalizer {
+	return NewCurrencyNormalizerFor(logger, English)
+}
+
+// NewCurrencyNormalizerFor speaks amounts in the language of locale.
+func NewCurrencyNormalizerFor(logger commons.Logger, locale *Locale) Normalizer {
 	return &currencyNormalizer{
 		logger: logger,
 		re:     regexp.MustCompile(`\$([0-9,]+)\.(\d{2})`),
+		locale: locale,
 	}
 }
 
 func (cn *currencyNormalizer) Normalize(s string) string {
+	if !cn.locale.english() {
+		s = symbolAmountRe.ReplaceAllStringFunc(s, func(match string) string {
+			parts := symbolAmountRe.FindStringSubmatch(match)
+			return cn.amount(match, parts[1], parts[2], parts[3])
+		})
+		return amountSymbolRe.ReplaceAllStringFunc(s, func(match string) string {
+			parts := amountSymbolRe.FindStringSubmatch(match)
+			return cn.amount(match, parts[3], parts[1], parts[2])
+		})
+	}
 	return cn.re.ReplaceAllStringFunc(s, func(match string) string {
 		parts := cn.re.FindStringSubmatch(match)
 		dollarStr := strings.ReplaceAll(parts[1], ",", "")
@@ -47,3 +72,22 @@ func (cn *currencyNormalizer) Normalize(s string) string {
 		return dollars + " dollars and " + cents + " cents"
 	})
 }
+
+// amount speaks major and minor units of the currency of symbol, match is
+// left as it is for a currency the locale has no words for.
+func (cn *currencyNormalizer) amount(match, symbol, major, minor string) string {
+	currency, ok := cn.locale.Currencies[symbol]
+	if !ok {
+		return match
+	}
+	majorAmount, err := strconv.Atoi(amountGroupRe.ReplaceAllString(major, ""))
+	if err != nil {
+		cn.logger.Warn("Failed to parse amount", "error", err, "amount", major)
+		return match
+	}
+	var minorAmount int
+	if minor != "" {
+		minorAmount, _ = strconv.Atoi(minor)
+	}
+	return cn.locale.Amount(currency, majorAmount, minorAmount)
+}
@@ -15,11 +15,18 @@ import (
 type dateNormalizer struct {
 	logger commons.Logger
 	re     *regexp.Regexp
+	locale *Locale
 }
 
 func NewDateNormalizer(logger commons.Logger) Normalizer {
+	return NewDateNormalizerFor(logger, English)
+}
+
+// NewDateNormalizerFor speaks dates in the language of locale.
+func NewDateNormalizerFor(logger commons.Logger, locale *Locale) Normalizer {
 	return &dateNormalizer{
 		logger: logger,
+		locale: locale,
 		re: regexp.MustCompile(
 			`(\d{4}-\d{2}-\d{2})|` + // YYYY-MM-DD
 				`(\d{2}/\d{2}/\d{4})|` + // DD/MM/YYYY or MM/DD/YYYY
@@ -53,6 +60,6 @@ func (dn *dateNormalizer) Normalize(s string) string {
 			dn.logger.Warn("Failed to parse date", "error", err, "date", match)
 			return match
 		}
-		return date.Format("January 2, 2006")
+		return dn.locale.Date(date)
 	})
 }
@@ -0,0 +1,42 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rapidaai/pkg/commons"
+)
+
+type emailNormalizer struct {
+	logger   commons.Logger
+	re       *regexp.Regexp
+	replacer *strings.Replacer
+}
+
+// NewEmailNormalizer spells out email addresses, "john.doe@acme.io" as
+// "john dot doe at acme dot io".
+func NewEmailNormalizer(logger commons.Logger) Normalizer {
+	return &emailNormalizer{
+		logger: logger,
+		re:     regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`),
+		replacer: strings.NewReplacer(
+			"@", " at ",
+			".", " dot ",
+			"_", " underscore ",
+			"-", " dash ",
+			"+", " plus ",
+			"%", " percent ",
+		),
+	}
+}
+
+func (en *emailNormalizer) Normalize(s string) string {
+	return en.re.ReplaceAllStringFunc(s, func(match string) string {
+		return strings.Join(strings.Fields(en.replacer.Replace(match)), " ")
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	ntw "moul.io/number-to-words"
+)
+
+// Locale is a language pack of the normalizers: how numbers, dates, times
+// and amounts are spoken in a language. The language of the text to speech
+// picks the pack, see LocaleFor, more packs are added with RegisterLocale.
+type Locale struct {
+	// Language is the ISO 639-1 code of the pack, e.g. es.
+	Language string
+
+	// Cardinal spells out n, zero and above.
+	Cardinal func(n int) string
+
+	// Ordinal spells out the ordinal of n written with an English suffix,
+	// 3rd, nil when the language writes ordinals otherwise.
+	Ordinal func(n int) string
+
+	// Minus is said before a negative number, Point between the integer
+	// and the decimal digits, Plus before the country code of a phone
+	// number.
+	Minus string
+	Point string
+	Plus  string
+
+	// DecimalComma writes decimals after a comma and groups thousands with a
+	// dot, 1.234,5.
+	DecimalComma bool
+
+	// Date speaks a date, the text to speech reads the digits left in it.
+	Date func(t time.Time) string
+
+	// Time speaks a time of day.
+	Time func(hour, minute int) string
+
+	// Currencies are the currencies by their symbol.
+	Currencies map[string]Currency
+
+	// Amount speaks major units and minor units of c.
+	Amount func(c Currency, major, minor int) string
+}
+
+// Currency is how the units of a currency are called in a language. One is
+// the phrase for exactly one unit, article included where the language has
+// one, Many the noun following any other count.
+type Currency struct {
+	One       string
+	Many      string
+	MinorOne  string
+	MinorMany string
+}
+
+// English is the pack of the normalizers written for English, and the one
+// of languages without a pack.
+var English = &Locale{
+	Language: "en",
+	Cardinal: func(n int) string { return ntw.IntegerToEnUs(n) },
+	Ordinal:  englishOrdinal,
+	Minus:    "minus",
+	Point:    "point",
+	Plus:     "plus",
+	Date:     func(t time.Time) string { return t.Format("January 2, 2006") },
+	Time: func(hour, minute int) string {
+		return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC).Format("3:04 PM")
+	},
+	Currencies: map[string]Currency{
+		"$": {One: "one dollar", Many: "dollars", MinorOne: "one cent", MinorMany: "cents"},
+	},
+	Amount: func(c Currency, major, minor int) string {
+		return ntw.IntegerToEnUs(major) + " " + c.Many + " and " + ntw.IntegerToEnUs(minor) + " " + c.MinorMany
+	},
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{
+		"en": English,
+		"es": Spanish,
+		"fr": French,
+		"de": German,
+		"hi": Hindi,
+	}
+)
+
+// RegisterLocale adds a language pack, replacing the one of its language.
+func RegisterLocale(l *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[strings.ToLower(l.Language)] = l
+}
+
+// LocaleFor returns the pack of language, a language code with or without
+// its region such as es-ES. Languages without a pack get English.
+func LocaleFor(language string) *Locale {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	if l, ok := locales[language]; ok {
+		return l
+	}
+	return English
+}
+
+// englishOrdinals are the ordinals not made by adding th to the cardinal.
+var englishOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// englishOrdinal turns the last word of the cardinal of n into an ordinal:
+// twenty-first, one hundred twelfth, forty-fourth.
+func englishOrdinal(n int) string {
+	cardinal := ntw.IntegerToEnUs(n)
+	i := strings.LastIndexAny(cardinal, " -") + 1
+	head, last := cardinal[:i], cardinal[i:]
+	switch {
+	case englishOrdinals[last] != "":
+		last = englishOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return head + last
+}
+
+// english reports whether l speaks the way the normalizers always did.
+func (l *Locale) english() bool {
+	return l == nil || l == English
+}
+
+// digits speaks digits one by one.
+func (l *Locale) digits(digits string) string {
+	words := make([]string, 0, len(digits))
+	for _, d := range digits {
+		words = append(words, l.Cardinal(int(d-'0')))
+	}
+	return strings.Join(words, " ")
+}
+
+// digitGroups speaks groups of digits one by one, a comma pausing between
+// groups.
+func (l *Locale) digitGroups(groups []string) string {
+	spoken := make([]string, len(groups))
+	for i, g := range groups {
+		spoken[i] = l.digits(g)
+	}
+	return strings.Join(spoken, ", ")
+}
+
+// digitGroups splits a run of digits the way numbers are dictated, in groups
+// of three with a last group of four rather than one of a single digit.
+func digitGroups(digits string) []string {
+	var groups []string
+	for len(digits) > 4 {
+		groups = append(groups, digits[:3])
+		digits = digits[3:]
+	}
+	return append(groups, digits)
+}
+
+// scaled spells out n from its largest scale down, part spells out group
+// times scale, group being how many of scale are left in n. The last scale
+// is 1.
+func scaled(n int, scales []int, part func(group, scale int) string) string {
+	var words []string
+	for _, scale := range scales {
+		if group := n / scale; group > 0 {
+			words = append(words, part(group, scale))
+			n %= scale
+		}
+	}
+	return strings.Join(words, " ")
+}
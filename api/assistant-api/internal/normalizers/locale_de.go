@@ -0,0 +1,137 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// German speaks "15.01.2024" as "15. Januar 2024" and "21 €" as
+// "einundzwanzig Euro".
+var German = &Locale{
+	Language:     "de",
+	Cardinal:     germanCardinal,
+	Minus:        "minus",
+	Point:        "Komma",
+	Plus:         "plus",
+	DecimalComma: true,
+	Date: func(t time.Time) string {
+		return fmt.Sprintf("%d. %s %d", t.Day(), germanMonths[t.Month()-1], t.Year())
+	},
+	Time: func(hour, minute int) string {
+		spoken := germanCardinal(hour) + " Uhr"
+		if hour == 1 {
+			spoken = "ein Uhr"
+		}
+		if minute == 0 {
+			return spoken
+		}
+		return spoken + " " + germanCardinal(minute)
+	},
+	Currencies: map[string]Currency{
+		"$": {One: "ein Dollar", Many: "Dollar", MinorOne: "ein Cent", MinorMany: "Cent"},
+		"€": {One: "ein Euro", Many: "Euro", MinorOne: "ein Cent", MinorMany: "Cent"},
+		"£": {One: "ein Pfund", Many: "Pfund", MinorOne: "ein Penny", MinorMany: "Pence"},
+		"₹": {One: "eine Rupie", Many: "Rupien", MinorOne: "ein Paisa", MinorMany: "Paisa"},
+	},
+	Amount: func(c Currency, major, minor int) string {
+		spoken := germanCount(major, c.One, c.Many)
+		if minor == 0 {
+			return spoken
+		}
+		return spoken + " und " + germanCount(minor, c.MinorOne, c.MinorMany)
+	},
+}
+
+var germanMonths = [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}
+
+var (
+	germanUnits = []string{"", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+		"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn"}
+	germanTens = []string{"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+)
+
+// germanCardinal writes numbers below a million as one word,
+// zweitausenddreihunderteinundzwanzig, millions and milliards apart.
+func germanCardinal(n int) string {
+	if n == 0 {
+		return "null"
+	}
+	return scaled(n, []int{1_000_000_000, 1_000_000, 1}, func(group, scale int) string {
+		switch {
+		case scale == 1:
+			return germanBelowMillion(group, true)
+		case group == 1 && scale == 1_000_000:
+			return "eine Million"
+		case group == 1:
+			return "eine Milliarde"
+		case scale == 1_000_000:
+			return germanFeminine(germanBelowMillion(group, true)) + " Millionen"
+		default:
+			return germanFeminine(germanCardinal(group)) + " Milliarden"
+		}
+	})
+}
+
+// germanBelowMillion spells out 1 to 999999, final when it ends the number
+// and a trailing one is eins rather than ein.
+func germanBelowMillion(n int, final bool) string {
+	var spoken string
+	if thousands := n / 1_000; thousands > 0 {
+		spoken = germanBelowThousand(thousands, false) + "tausend"
+	}
+	if rest := n % 1_000; rest > 0 {
+		spoken += germanBelowThousand(rest, final)
+	}
+	return spoken
+}
+
+func germanBelowThousand(n int, final bool) string {
+	var spoken string
+	if hundreds := n / 100; hundreds > 0 {
+		spoken = germanPrefix(hundreds) + "hundert"
+	}
+	switch rest := n % 100; {
+	case rest == 0:
+	case rest == 1 && final:
+		spoken += "eins"
+	case rest == 1:
+		spoken += "ein"
+	case rest < 20:
+		spoken += germanUnits[rest]
+	case rest%10 == 0:
+		spoken += germanTens[rest/10]
+	default:
+		spoken += germanPrefix(rest%10) + "und" + germanTens[rest/10]
+	}
+	return spoken
+}
+
+// germanPrefix is a digit leading a compound: einhundert, einundzwanzig.
+func germanPrefix(digit int) string {
+	if digit == 1 {
+		return "ein"
+	}
+	return germanUnits[digit]
+}
+
+// germanFeminine agrees a trailing eins with Million and Milliarde:
+// einundzwanzig Millionen, hunderteine Millionen.
+func germanFeminine(s string) string {
+	if strings.HasSuffix(s, "eins") {
+		return strings.TrimSuffix(s, "eins") + "eine"
+	}
+	return s
+}
+
+func germanCount(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return germanCardinal(n) + " " + many
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Spanish speaks "15/01/2024" as "15 de enero de 2024" and "12,50 €" as
+// "doce euros con cincuenta céntimos".
+var Spanish = &Locale{
+	Language:     "es",
+	Cardinal:     spanishCardinal,
+	Minus:        "menos",
+	Point:        "coma",
+	Plus:         "más",
+	DecimalComma: true,
+	Date: func(t time.Time) string {
+		return fmt.Sprintf("%d de %s de %d", t.Day(), spanishMonths[t.Month()-1], t.Year())
+	},
+	Time: func(hour, minute int) string {
+		spoken := "las " + spanishCardinal(hour)
+		if hour == 1 {
+			spoken = "la una"
+		}
+		if minute == 0 {
+			return spoken + " en punto"
+		}
+		return spoken + " y " + spanishCardinal(minute)
+	},
+	Currencies: map[string]Currency{
+		"$": {One: "un dólar", Many: "dólares", MinorOne: "un centavo", MinorMany: "centavos"},
+		"€": {One: "un euro", Many: "euros", MinorOne: "un céntimo", MinorMany: "céntimos"},
+		"£": {One: "una libra", Many: "libras", MinorOne: "un penique", MinorMany: "peniques"},
+		"₹": {One: "una rupia", Many: "rupias", MinorOne: "un paisa", MinorMany: "paisas"},
+	},
+	Amount: func(c Currency, major, minor int) string {
+		spoken := spanishCount(major, c.One, c.Many)
+		if minor == 0 {
+			return spoken
+		}
+		return spoken + " con " + spanishCount(minor, c.MinorOne, c.MinorMany)
+	},
+}
+
+var spanishMonths = [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+
+var (
+	spanishUnits = []string{"", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve"}
+	spanishTens     = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+	spanishHundreds = []string{"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos", "setecientos", "ochocientos", "novecientos"}
+)
+
+func spanishCardinal(n int) string {
+	if n == 0 {
+		return "cero"
+	}
+	return scaled(n, []int{1_000_000, 1_000, 1}, func(group, scale int) string {
+		switch {
+		case scale == 1:
+			return spanishBelowThousand(group)
+		case scale == 1_000 && group == 1:
+			return "mil"
+		case scale == 1_000:
+			return spanishApocope(spanishBelowThousand(group)) + " mil"
+		case group == 1:
+			return "un millón"
+		default:
+			return spanishApocope(spanishCardinal(group)) + " millones"
+		}
+	})
+}
+
+func spanishBelowThousand(n int) string {
+	if n == 100 {
+		return "cien"
+	}
+	var words []string
+	if hundreds := n / 100; hundreds > 0 {
+		words = append(words, spanishHundreds[hundreds])
+	}
+	switch rest := n % 100; {
+	case rest == 0:
+	case rest < 30:
+		words = append(words, spanishUnits[rest])
+	case rest%10 == 0:
+		words = append(words, spanishTens[rest/10])
+	default:
+		words = append(words, spanishTens[rest/10]+" y "+spanishUnits[rest%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// spanishApocope shortens a trailing uno before a noun: veintiún mil, un
+// millón.
+func spanishApocope(s string) string {
+	switch {
+	case strings.HasSuffix(s, "veintiuno"):
+		return strings.TrimSuffix(s, "veintiuno") + "veintiún"
+	case strings.HasSuffix(s, "uno"):
+		return strings.TrimSuffix(s, "uno") + "un"
+	}
+	return s
+}
+
+// spanishCount speaks n of a noun, "de" joins round millions to it: un
+// millón de euros.
+func spanishCount(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	spoken := spanishApocope(spanishCardinal(n))
+	if n%1_000_000 == 0 {
+		return spoken + " de " + many
+	}
+	return spoken + " " + many
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// French speaks "01/03/2024" as "1er mars 2024" and "14:30" as "quatorze
+// heures trente".
+var French = &Locale{
+	Language:     "fr",
+	Cardinal:     frenchCardinal,
+	Minus:        "moins",
+	Point:        "virgule",
+	Plus:         "plus",
+	DecimalComma: true,
+	Date: func(t time.Time) string {
+		day := fmt.Sprintf("%d", t.Day())
+		if t.Day() == 1 {
+			day = "1er"
+		}
+		return fmt.Sprintf("%s %s %d", day, frenchMonths[t.Month()-1], t.Year())
+	},
+	Time: func(hour, minute int) string {
+		spoken := frenchFeminine(frenchCardinal(hour)) + " heure"
+		if hour > 1 {
+			spoken += "s"
+		}
+		if minute == 0 {
+			return spoken
+		}
+		return spoken + " " + frenchFeminine(frenchCardinal(minute))
+	},
+	Currencies: map[string]Currency{
+		"$": {One: "un dollar", Many: "dollars", MinorOne: "un cent", MinorMany: "cents"},
+		"€": {One: "un euro", Many: "euros", MinorOne: "un centime", MinorMany: "centimes"},
+		"£": {One: "une livre", Many: "livres", MinorOne: "un penny", MinorMany: "pence"},
+		"₹": {One: "une roupie", Many: "roupies", MinorOne: "un paisa", MinorMany: "paisas"},
+	},
+	Amount: func(c Currency, major, minor int) string {
+		spoken := frenchCount(major, c.One, c.Many)
+		if minor == 0 {
+			return spoken
+		}
+		return spoken + " et " + frenchCount(minor, c.MinorOne, c.MinorMany)
+	},
+}
+
+var frenchMonths = [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
+
+var (
+	frenchUnits = []string{"", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+		"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf"}
+	frenchTens = []string{"", "dix", "vingt", "trente", "quarante", "cinquante", "soixante", "soixante", "quatre-vingt", "quatre-vingt"}
+)
+
+func frenchCardinal(n int) string {
+	if n == 0 {
+		return "zéro"
+	}
+	return scaled(n, []int{1_000_000_000, 1_000_000, 1_000, 1}, func(group, scale int) string {
+		switch scale {
+		case 1:
+			return frenchBelowThousand(group, true)
+		case 1_000:
+			if group == 1 {
+				return "mille"
+			}
+			return frenchBelowThousand(group, false) + " mille"
+		case 1_000_000:
+			if group == 1 {
+				return "un million"
+			}
+			return frenchBelowThousand(group, true) + " millions"
+		default:
+			if group == 1 {
+				return "un milliard"
+			}
+			return frenchCardinal(group) + " milliards"
+		}
+	})
+}
+
+// frenchBelowThousand spells out 1 to 999, final unless mille follows:
+// quatre-vingts and deux cents lose their s before it.
+func frenchBelowThousand(n int, final bool) string {
+	var words []string
+	hundreds, rest := n/100, n%100
+	switch {
+	case hundreds == 1:
+		words = append(words, "cent")
+	case hundreds > 1 && rest == 0 && final:
+		words = append(words, frenchUnits[hundreds]+" cents")
+	case hundreds > 1:
+		words = append(words, frenchUnits[hundreds]+" cent")
+	}
+	if rest > 0 {
+		below := frenchBelowHundred(rest)
+		if !final && below == "quatre-vingts" {
+			below = "quatre-vingt"
+		}
+		words = append(words, below)
+	}
+	return strings.Join(words, " ")
+}
+
+func frenchBelowHundred(n int) string {
+	if n < 20 {
+		return frenchUnits[n]
+	}
+	tens, units := n/10, n%10
+	// soixante-dix and quatre-vingt-dix count on from dix
+	if tens == 7 || tens == 9 {
+		units += 10
+	}
+	switch {
+	case units == 0 && tens == 8:
+		return "quatre-vingts"
+	case units == 0:
+		return frenchTens[tens]
+	case (units == 1 || units == 11) && tens != 8 && tens != 9:
+		return frenchTens[tens] + " et " + frenchUnits[units]
+	default:
+		return frenchTens[tens] + "-" + frenchUnits[units]
+	}
+}
+
+// frenchFeminine agrees a trailing un with a feminine noun: une heure,
+// vingt et une minutes.
+func frenchFeminine(s string) string {
+	if s == "un" || strings.HasSuffix(s, " et un") || strings.HasSuffix(s, "-un") {
+		return s + "e"
+	}
+	return s
+}
+
+// frenchCount speaks n of a noun, "de" joins round millions to it: deux
+// millions de dollars.
+func frenchCount(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	spoken := frenchCardinal(n)
+	if n%1_000_000 == 0 {
+		return spoken + " de " + many
+	}
+	return spoken + " " + many
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"fmt"
+	"time"
+)
+
+// Hindi speaks "15/01/2024" as "15 जनवरी 2024" and counts "₹250000" in
+// lakhs, "दो लाख पचास हज़ार रुपये".
+var Hindi = &Locale{
+	Language: "hi",
+	Cardinal: hindiCardinal,
+	Minus:    "ऋण",
+	Point:    "दशमलव",
+	Plus:     "प्लस",
+	Date: func(t time.Time) string {
+		return fmt.Sprintf("%d %s %d", t.Day(), hindiMonths[t.Month()-1], t.Year())
+	},
+	Time: func(hour, minute int) string {
+		if minute == 0 {
+			return hindiCardinal(hour) + " बजे"
+		}
+		return hindiCardinal(hour) + " बजकर " + hindiCardinal(minute) + " मिनट"
+	},
+	Currencies: map[string]Currency{
+		"$": {One: "एक डॉलर", Many: "डॉलर", MinorOne: "एक सेंट", MinorMany: "सेंट"},
+		"€": {One: "एक यूरो", Many: "यूरो", MinorOne: "एक सेंट", MinorMany: "सेंट"},
+		"£": {One: "एक पाउंड", Many: "पाउंड", MinorOne: "एक पेनी", MinorMany: "पेंस"},
+		"₹": {One: "एक रुपया", Many: "रुपये", MinorOne: "एक पैसा", MinorMany: "पैसे"},
+	},
+	Amount: func(c Currency, major, minor int) string {
+		spoken := hindiCount(major, c.One, c.Many)
+		if minor == 0 {
+			return spoken
+		}
+		return spoken + " और " + hindiCount(minor, c.MinorOne, c.MinorMany)
+	},
+}
+
+var hindiMonths = [12]string{"जनवरी", "फ़रवरी", "मार्च", "अप्रैल", "मई", "जून", "जुलाई", "अगस्त", "सितंबर", "अक्टूबर", "नवंबर", "दिसंबर"}
+
+// hindiBelowHundred are 0 to 99, which Hindi does not compose from tens and
+// units.
+var hindiBelowHundred = [100]string{
+	"शून्य", "एक", "दो", "तीन", "चार", "पाँच", "छह", "सात", "आठ", "नौ",
+	"दस", "ग्यारह", "बारह", "तेरह", "चौदह", "पंद्रह", "सोलह", "सत्रह", "अठारह", "उन्नीस",
+	"बीस", "इक्कीस", "बाईस", "तेईस", "चौबीस", "पच्चीस", "छब्बीस", "सत्ताईस", "अट्ठाईस", "उनतीस",
+	"तीस", "इकतीस", "बत्तीस", "तैंतीस", "चौंतीस", "पैंतीस", "छत्तीस", "सैंतीस", "अड़तीस", "उनतालीस",
+	"चालीस", "इकतालीस", "बयालीस", "तैंतालीस", "चवालीस", "पैंतालीस", "छियालीस", "सैंतालीस", "अड़तालीस", "उनचास",
+	"पचास", "इक्यावन", "बावन", "तिरेपन", "चौवन", "पचपन", "छप्पन", "सत्तावन", "अट्ठावन", "उनसठ",
+	"साठ", "इकसठ", "बासठ", "तिरसठ", "चौंसठ", "पैंसठ", "छियासठ", "सड़सठ", "अड़सठ", "उनहत्तर",
+	"सत्तर", "इकहत्तर", "बहत्तर", "तिहत्तर", "चौहत्तर", "पचहत्तर", "छिहत्तर", "सतहत्तर", "अठहत्तर", "उनासी",
+	"अस्सी", "इक्यासी", "बयासी", "तिरासी", "चौरासी", "पचासी", "छियासी", "सत्तासी", "अट्ठासी", "नवासी",
+	"नब्बे", "इक्यानबे", "बानबे", "तिरानबे", "चौरानबे", "पंचानबे", "छियानबे", "सत्तानबे", "अट्ठानबे", "निन्यानबे",
+}
+
+// hindiCardinal counts in the Indian system, hundreds, thousands, lakhs,
+// crores and arabs.
+func hindiCardinal(n int) string {
+	if n < 100 {
+		return hindiBelowHundred[n]
+	}
+	return scaled(n, []int{1_000_000_000, 10_000_000, 100_000, 1_000, 100, 1}, func(group, scale int) string {
+		if scale == 1 {
+			return hindiBelowHundred[group]
+		}
+		return hindiCardinal(group) + " " + hindiScales[scale]
+	})
+}
+
+var hindiScales = map[int]string{
+	100:           "सौ",
+	1_000:         "हज़ार",
+	100_000:       "लाख",
+	10_000_000:    "करोड़",
+	1_000_000_000: "अरब",
+}
+
+func hindiCount(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return hindiCardinal(n) + " " + many
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_normalizers
+
+import (
+	"testing"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// Locale Tests
+// =============================================================================
+
+func TestLocaleFor(t *testing.T) {
+	assert.Equal(t, Spanish, LocaleFor("es"))
+	assert.Equal(t, Spanish, LocaleFor("es-MX"))
+	assert.Equal(t, French, LocaleFor("FR_ca"))
+	assert.Equal(t, German, LocaleFor("de-DE"))
+	assert.Equal(t, Hindi, LocaleFor("hi-IN"))
+	assert.Equal(t, English, LocaleFor("en-US"))
+	assert.Equal(t, English, LocaleFor("ja"))
+	assert.Equal(t, English, LocaleFor(""))
+}
+
+func TestRegisterLocale(t *testing.T) {
+	italian := &Locale{Language: "it", Cardinal: func(n int) string { return "uno" }}
+	RegisterLocale(italian)
+	defer func() {
+		localesMu.Lock()
+		delete(locales, "it")
+		localesMu.Unlock()
+	}()
+	assert.Equal(t, italian, LocaleFor("it-IT"))
+}
+
+func TestLocaleCardinal(t *testing.T) {
+	tests := []struct {
+		locale   *Locale
+		n        int
+		expected string
+	}{
+		{Spanish, 0, "cero"},
+		{Spanish, 21, "veintiuno"},
+		{Spanish, 100, "cien"},
+		{Spanish, 115, "ciento quince"},
+		{Spanish, 1000, "mil"},
+		{Spanish, 2024, "dos mil veinticuatro"},
+		{Spanish, 21000, "veintiún mil"},
+		{Spanish, 1000000, "un millón"},
+		{Spanish, 3500000, "tres millones quinientos mil"},
+		{French, 21, "vingt et un"},
+		{French, 71, "soixante et onze"},
+		{French, 80, "quatre-vingts"},
+		{French, 91, "quatre-vingt-onze"},
+		{French, 200, "deux cents"},
+		{French, 80000, "quatre-vingt mille"},
+		{French, 2024, "deux mille vingt-quatre"},
+		{French, 2000000, "deux millions"},
+		{German, 1, "eins"},
+		{German, 21, "einundzwanzig"},
+		{German, 101, "einhunderteins"},
+		{German, 2024, "zweitausendvierundzwanzig"},
+		{German, 1000000, "eine Million"},
+		{German, 21000000, "einundzwanzig Millionen"},
+		{Hindi, 0, "शून्य"},
+		{Hindi, 45, "पैंतालीस"},
+		{Hindi, 250000, "दो लाख पचास हज़ार"},
+		{Hindi, 10000000, "एक करोड़"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.locale.Cardinal(tt.n), "%s %d", tt.locale.Language, tt.n)
+	}
+}
+
+func TestLocaleDateNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	tests := []struct {
+		locale   *Locale
+		input    string
+		expected string
+	}{
+		{Spanish, "Su cita es el 15/01/2024", "Su cita es el 15 de enero de 2024"},
+		{French, "Rendez-vous le 2024-03-01", "Rendez-vous le 1er mars 2024"},
+		{German, "Termin am 15/01/2024", "Termin am 15. Januar 2024"},
+		{Hindi, "15/01/2024", "15 जनवरी 2024"},
+		{English, "15/01/2024", "January 15, 2024"},
+	}
+	for _, tt := range tests {
+		normalizer := NewDateNormalizerFor(logger, tt.locale)
+		assert.Equal(t, tt.expected, normalizer.Normalize(tt.input), tt.locale.Language)
+	}
+}
+
+func TestLocaleTimeNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	tests := []struct {
+		locale   *Locale
+		input    string
+		expected string
+	}{
+		{Spanish, "Llegamos 14:30", "Llegamos las catorce y treinta"},
+		{Spanish, "1:00", "la una en punto"},
+		{French, "à 21:00", "à vingt et une heures"},
+		{French, "1:01", "une heure une"},
+		{German, "um 14:30", "um vierzehn Uhr dreißig"},
+		{Hindi, "14:30", "चौदह बजकर तीस मिनट"},
+		{Hindi, "9:00", "नौ बजे"},
+	}
+	for _, tt := range tests {
+		normalizer := NewTimeNormalizerFor(logger, tt.locale)
+		assert.Equal(t, tt.expected, normalizer.Normalize(tt.input), tt.locale.Language)
+	}
+}
+
+func TestLocaleCurrencyNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	tests := []struct {
+		locale   *Locale
+		input    string
+		expected string
+	}{
+		{Spanish, "Son $12.50", "Son doce dólares con cincuenta centavos"},
+		{Spanish, "Son 12,50 €", "Son doce euros con cincuenta céntimos"},
+		{Spanish, "Cuesta 1.000.000 €", "Cuesta un millón de euros"},
+		{Spanish, "Cuesta 1 €", "Cuesta un euro"},
+		{French, "Total : 1\u202f234,56 €", "Total : mille deux cent trente-quatre euros et cinquante-six centimes"},
+		{French, "Total : 21 €", "Total : vingt et un euros"},
+		{German, "Nur 21,01 €", "Nur einundzwanzig Euro und ein Cent"},
+		{Hindi, "₹250000", "दो लाख पचास हज़ार रुपये"},
+		{Hindi, "₹1.50", "एक रुपया और पचास पैसे"},
+		{Spanish, "Son 5 ¥", "Son 5 ¥"},
+	}
+	for _, tt := range tests {
+		normalizer := NewCurrencyNormalizerFor(logger, tt.locale)
+		assert.Equal(t, tt.expected, normalizer.Normalize(tt.input), tt.locale.Language)
+	}
+}
+
+func TestLocaleNumberToWordNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	assert.Equal(t, "tengo veintiuno años", NewNumberToWordNormalizerFor(logger, Spanish).Normalize("tengo 21 años"))
+	assert.Equal(t, "j'ai quarante-deux ans", NewNumberToWordNormalizerFor(logger, French).Normalize("j'ai 42 ans"))
+	assert.Equal(t, "I am twenty-one", NewNumberToWordNormalizerFor(logger, English).Normalize("I am 21"))
+	assert.Equal(t, "mil doscientos treinta y cuatro coma cinco", NewNumberToWordNormalizerFor(logger, Spanish).Normalize("1.234,5"))
+	assert.Equal(t, "minus drei Komma fünf", NewNumberToWordNormalizerFor(logger, German).Normalize("-3,5"))
+	assert.Equal(t, "3e", NewNumberToWordNormalizerFor(logger, French).Normalize("3e"))
+}
+
+func TestLocalePhoneNumberNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	assert.Equal(t, "más tres cuatro, nueve uno dos, tres cuatro cinco, seis siete ocho",
+		NewPhoneNumberNormalizerFor(logger, Spanish).Normalize("+34912345678"))
+}
@@ -392,6 +392,62 @@ func BenchmarkNormalizerChain(b *testing.B) {
 	})
 }
 
+// BenchmarkTurn normalizes the sentences of an assistant turn the way the
+// text to speech transformers do, running each normalizer over the text
+// against the compiled chain. A chain is reused across turns, so its memory
+// is warm for sentences the assistant repeats.
+func BenchmarkTurn(b *testing.B) {
+	normalizers := []Normalizer{
+		NewCurrencyNormalizer(benchLogger()),
+		NewDateNormalizer(benchLogger()),
+		NewTimeNormalizer(benchLogger()),
+		NewNumberToWordNormalizer(benchLogger()),
+		NewAddressNormalizer(benchLogger()),
+		NewUrlNormalizer(benchLogger()),
+		NewTechAbbreviationNormalizer(benchLogger()),
+		NewRoleAbbreviationNormalizer(benchLogger()),
+		NewGeneralAbbreviationNormalizer(benchLogger()),
+		NewSymbolNormalizer(benchLogger()),
+	}
+	turn := []string{
+		"Hello, how can I help you today?",
+		"Your order for $99.99 will arrive on 2024-01-20 between 14:00 and 17:00.",
+		"Visit https://track.example.com for updates.",
+		"Is there anything else I can help you with?",
+	}
+
+	b.Run("each_normalizer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, sentence := range turn {
+				for _, n := range normalizers {
+					sentence = n.Normalize(sentence)
+				}
+			}
+		}
+	})
+
+	b.Run("chain", func(b *testing.B) {
+		chain := NewChain(normalizers...)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, sentence := range turn {
+				chain.Normalize(sentence)
+			}
+		}
+	})
+
+	b.Run("chain_cold", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			chain := NewChain(normalizers...)
+			for _, sentence := range turn {
+				chain.Normalize(sentence)
+			}
+		}
+	})
+}
+
 // =============================================================================
 // Memory Allocation Benchmarks
 // =============================================================================
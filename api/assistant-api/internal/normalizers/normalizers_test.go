@@ -7,6 +7,7 @@
 package internal_normalizers
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/rapidaai/pkg/commons"
@@ -253,7 +254,7 @@ func TestNumberToWordNormalizer(t *testing.T) {
 		{
 			name:     "zero",
 			input:    "Score is 0",
-			expected: "Score is ", // BUG: returns empty string for 0
+			expected: "Score is zero",
 		},
 		{
 			name:     "multiple numbers",
@@ -266,9 +267,44 @@ func TestNumberToWordNormalizer(t *testing.T) {
 			expected: "There are ninety-nine problems",
 		},
 		{
-			name:     "number over 99 unchanged",
+			name:     "hundreds",
 			input:    "Population is 100",
-			expected: "Population is 100", // 3+ digits not matched
+			expected: "Population is one hundred",
+		},
+		{
+			name:     "billions with thousands separators",
+			input:    "Revenue hit 3,250,000,017 units",
+			expected: "Revenue hit three billion two hundred fifty million seventeen units",
+		},
+		{
+			name:     "negative",
+			input:    "It is -5 outside",
+			expected: "It is minus five outside",
+		},
+		{
+			name:     "hyphen between numbers is not a sign",
+			input:    "Pages 5-6",
+			expected: "Pages five-six",
+		},
+		{
+			name:     "decimal",
+			input:    "Pi is 3.14",
+			expected: "Pi is three point one four",
+		},
+		{
+			name:     "ordinals",
+			input:    "The 1st, 2nd, 3rd and 21st",
+			expected: "The first, second, third and twenty-first",
+		},
+		{
+			name:     "ordinal of tens",
+			input:    "Her 40th birthday on the 12th",
+			expected: "Her fortieth birthday on the twelfth",
+		},
+		{
+			name:     "long digit run without grouping",
+			input:    "Call 4155550123",
+			expected: "Call four billion one hundred fifty-five million five hundred fifty thousand one hundred twenty-three",
 		},
 		{
 			name:     "no numbers",
@@ -300,6 +336,153 @@ func TestNumberToWordNormalizer(t *testing.T) {
 	}
 }
 
+func TestNumberToWordNormalizerDigitGroups(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	normalizer := NewNumberToWordNormalizer(logger, WithDigitGroups())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "dash separated groups",
+			input:    "Call 555-123-4567",
+			expected: "Call five five five, one two three, four five six seven",
+		},
+		{
+			name:     "ten digit run",
+			input:    "Call 4155550123 now",
+			expected: "Call four one five, five five five, zero one two three now",
+		},
+		{
+			name:     "seven digit run",
+			input:    "Dial 5550123",
+			expected: "Dial five five five, zero one two three",
+		},
+		{
+			name:     "short numbers stay numbers",
+			input:    "Room 4155 on floor 3",
+			expected: "Room four thousand one hundred fifty-five on floor three",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizer.Normalize(tt.input))
+		})
+	}
+}
+
+// =============================================================================
+// Phone Number Normalizer Tests
+// =============================================================================
+
+func TestPhoneNumberNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	normalizer := NewPhoneNumberNormalizer(logger)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "e164",
+			input:    "Call +14155550123 today",
+			expected: "Call plus one, four one five, five five five, zero one two three today",
+		},
+		{
+			name:     "e164 with two digit country code",
+			input:    "+442079460958",
+			expected: "plus four four, two zero seven, nine four six, zero nine five eight",
+		},
+		{
+			name:     "international with separators",
+			input:    "Reach us at +44 20 7946 0958.",
+			expected: "Reach us at plus four four, two zero, seven nine four six, zero nine five eight.",
+		},
+		{
+			name:     "national with area code in parentheses",
+			input:    "Dial (415) 555-0123",
+			expected: "Dial four one five, five five five, zero one two three",
+		},
+		{
+			name:     "national with dashes",
+			input:    "It is 415-555-0123",
+			expected: "It is four one five, five five five, zero one two three",
+		},
+		{
+			name:     "national with dots",
+			input:    "It is 415.555.0123",
+			expected: "It is four one five, five five five, zero one two three",
+		},
+		{
+			name:     "date is not a phone number",
+			input:    "On 2024-01-15",
+			expected: "On 2024-01-15",
+		},
+		{
+			name:     "thousands are not a phone number",
+			input:    "About 1 250 000 people",
+			expected: "About 1 250 000 people",
+		},
+		{
+			name:     "too short",
+			input:    "Room 12 34",
+			expected: "Room 12 34",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizer.Normalize(tt.input))
+		})
+	}
+}
+
+// =============================================================================
+// Email Normalizer Tests
+// =============================================================================
+
+func TestEmailNormalizer(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	normalizer := NewEmailNormalizer(logger)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "dotted local part",
+			input:    "Write to john.doe@acme.io",
+			expected: "Write to john dot doe at acme dot io",
+		},
+		{
+			name:     "symbols in local part",
+			input:    "mail jane_smith+billing@mail.example-corp.co.uk, thanks",
+			expected: "mail jane underscore smith plus billing at mail dot example dash corp dot co dot uk, thanks",
+		},
+		{
+			name:     "sentence ending with address",
+			input:    "It is support@rapida.ai.",
+			expected: "It is support at rapida dot ai.",
+		},
+		{
+			name:     "at sign without address",
+			input:    "Meet @ noon",
+			expected: "Meet @ noon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizer.Normalize(tt.input))
+		})
+	}
+}
+
 // =============================================================================
 // Address Normalizer Tests
 // =============================================================================
@@ -832,6 +1015,60 @@ func TestNormalizerChain(t *testing.T) {
 	}
 }
 
+func TestChain(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+
+	t.Run("nil chain", func(t *testing.T) {
+		var chain *Chain
+		assert.Nil(t, NewChain())
+		assert.Equal(t, 0, chain.Len())
+		assert.Equal(t, "Meet at 14:30.", chain.Normalize("Meet at 14:30."))
+	})
+
+	t.Run("sentence units keep their separators", func(t *testing.T) {
+		chain := NewChain(NewTimeNormalizer(logger), NewTechAbbreviationNormalizer(logger))
+		assert.Equal(t, 2, chain.Len())
+		assert.Equal(t,
+			"  Meet at 2:30 PM.  The ay pee eye is up!\nok",
+			chain.Normalize("  Meet at 14:30.  The API is up!\nok"),
+		)
+	})
+
+	t.Run("matches running the normalizers per sentence", func(t *testing.T) {
+		normalizers := []Normalizer{
+			NewCurrencyNormalizer(logger),
+			NewDateNormalizer(logger),
+			NewTimeNormalizer(logger),
+			NewAddressNormalizer(logger),
+			NewUrlNormalizer(logger),
+			NewGeneralAbbreviationNormalizer(logger),
+			NewSymbolNormalizer(logger),
+		}
+		chain := NewChain(normalizers...)
+		for _, sentence := range []string{
+			"It costs $500.50 on 2024-01-15 at 123 Main St",
+			"See https://rapida.ai for 25% off",
+			"Dr. Smith is in",
+		} {
+			want := sentence
+			for _, n := range normalizers {
+				want = n.Normalize(want)
+			}
+			assert.Equal(t, want, chain.Normalize(sentence))
+			assert.Equal(t, want, chain.Normalize(sentence), "from memory")
+		}
+	})
+
+	t.Run("memory is bounded", func(t *testing.T) {
+		chain := NewChain(NewSymbolNormalizer(logger))
+		for i := 0; i < 3*chainMemoSize; i++ {
+			chain.Normalize(strings.Repeat("%", i+1))
+		}
+		assert.Len(t, chain.memo, chainMemoSize)
+		assert.Equal(t, " percent percent", chain.Normalize("%%"))
+	})
+}
+
 // =============================================================================
 // Edge Cases and Error Handling Tests
 // =============================================================================
@@ -921,6 +1158,8 @@ func TestNilSafeNormalizers(t *testing.T) {
 		"time":     NewTimeNormalizer(logger),
 		"number":   NewNumberToWordNormalizer(logger),
 		"address":  NewAddressNormalizer(logger),
+		"phone":    NewPhoneNumberNormalizer(logger),
+		"email":    NewEmailNormalizer(logger),
 		"url":      NewUrlNormalizer(logger),
 		"tech":     NewTechAbbreviationNormalizer(logger),
 		"role":     NewRoleAbbreviationNormalizer(logger),
@@ -953,15 +1192,6 @@ func TestNilSafeNormalizers(t *testing.T) {
 func TestKnownIssues(t *testing.T) {
 	logger, _ := commons.NewApplicationLogger()
 
-	t.Run("number_to_word_zero_returns_empty", func(t *testing.T) {
-		normalizer := NewNumberToWordNormalizer(logger)
-		// This is a known bug - 0 returns empty string
-		// Expected: "Count is zero"
-		// Actual: "Count is "
-		result := normalizer.Normalize("Count is 0")
-		assert.Equal(t, "Count is ", result)
-	})
-
 	t.Run("currency_without_cents_not_matched", func(t *testing.T) {
 		normalizer := NewCurrencyNormalizer(logger)
 		// Known limitation - requires .XX cents format
@@ -8,52 +8,140 @@ package internal_normalizers
 import (
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
 )
 
+// OptionsKeyGroupDigits reads phone number like digit sequences digit by
+// digit in groups, "555-123-4567" as "five five five, one two three, four
+// five six seven", instead of as one number.
+const OptionsKeyGroupDigits = "speaker.number.group_digits"
+
 type numberToWordNormalizer struct {
 	logger commons.Logger
 	re     *regexp.Regexp
+	digits *regexp.Regexp
+	locale *Locale
+}
+
+// NumberToWordOption configures the number to word normalizer.
+type NumberToWordOption func(*numberToWordNormalizer)
+
+// WithDigitGroups reads phone number like sequences digit by digit, see
+// OptionsKeyGroupDigits.
+func WithDigitGroups() NumberToWordOption {
+	return func(nwn *numberToWordNormalizer) {
+		// three or more dash separated groups, or seven digits and more
+		nwn.digits = regexp.MustCompile(`\b(?:\d{2,4}(?:-\d{2,4}){2,}|\d{7,})\b`)
+	}
 }
 
-func NewNumberToWordNormalizer(logger commons.Logger) Normalizer {
-	return &numberToWordNormalizer{
+// NumberToWordOptions returns the options of the number to word normalizer
+// set in opts.
+func NumberToWordOptions(opts utils.Option) []NumberToWordOption {
+	var options []NumberToWordOption
+	if group, err := opts.GetBool(OptionsKeyGroupDigits); err == nil && group {
+		options = append(options, WithDigitGroups())
+	}
+	return options
+}
+
+func NewNumberToWordNormalizer(logger commons.Logger, options ...NumberToWordOption) Normalizer {
+	return NewNumberToWordNormalizerFor(logger, English, options...)
+}
+
+// NewNumberToWordNormalizerFor spells numbers out in the language of locale:
+// integers of any size, negatives, decimals and, where the locale has them,
+// ordinals such as 3rd.
+func NewNumberToWordNormalizerFor(logger commons.Logger, locale *Locale, options ...NumberToWordOption) Normalizer {
+	thousands, decimal := `,`, `\.`
+	if locale.DecimalComma {
+		thousands, decimal = `\.`, `,`
+	}
+	nwn := &numberToWordNormalizer{
 		logger: logger,
-		re:     regexp.MustCompile(`\b\d{1,2}\b`),
+		re:     regexp.MustCompile(`([-−])?\b(\d{1,3}(?:` + thousands + `\d{3})+|\d+)(?:` + decimal + `(\d+))?(st|nd|rd|th)?\b`),
+		locale: locale,
 	}
+	for _, option := range options {
+		option(nwn)
+	}
+	return nwn
 }
 
 func (nwn *numberToWordNormalizer) Normalize(s string) string {
-	return nwn.re.ReplaceAllStringFunc(s, func(match string) string {
-		num, err := strconv.Atoi(match)
-		if err != nil {
-			nwn.logger.Warn("Failed to parse number", "error", err, "number", match)
-			return match
+	if nwn.digits != nil {
+		s = nwn.digits.ReplaceAllStringFunc(s, nwn.digitGroups)
+	}
+	matches := nwn.re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var out strings.Builder
+	last := 0
+	for _, m := range matches {
+		start := m[0]
+		// a dash between words and numbers is a hyphen: 5-6, COVID-19
+		if m[2] >= 0 && start > 0 {
+			if r, _ := utf8.DecodeLastRuneInString(s[:start]); unicode.IsLetter(r) || unicode.IsDigit(r) {
+				start = m[3]
+			}
 		}
-		return nwn.numberToWord(num)
-	})
+		negative := m[2] >= 0 && start == m[2]
+		out.WriteString(s[last:start])
+		out.WriteString(nwn.spell(s[start:m[1]], negative, group(s, m, 2), group(s, m, 3), group(s, m, 4)))
+		last = m[1]
+	}
+	out.WriteString(s[last:])
+	return out.String()
 }
 
-func (nwn *numberToWordNormalizer) numberToWord(num int) string {
-	if num < 0 || num > 99 {
-		return strconv.Itoa(num)
+// spell speaks a number of integer digits, thousands separators included,
+// decimal digits and ordinal suffix, match is kept when it cannot.
+func (nwn *numberToWordNormalizer) spell(match string, negative bool, integer, decimals, suffix string) string {
+	num, err := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(integer))
+	if err != nil {
+		nwn.logger.Warn("Failed to parse number", "error", err, "number", match)
+		return match
+	}
+	var spoken string
+	switch {
+	case suffix == "":
+		spoken = nwn.locale.Cardinal(num)
+	case nwn.locale.Ordinal == nil || decimals != "":
+		return match
+	default:
+		spoken = nwn.locale.Ordinal(num)
+	}
+	if decimals != "" {
+		spoken += " " + nwn.locale.Point + " " + nwn.locale.digits(decimals)
 	}
+	if negative {
+		spoken = nwn.locale.Minus + " " + spoken
+	}
+	return spoken
+}
 
-	units := []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
-	teens := []string{"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
-	tens := []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+// digitGroups reads a phone number like sequence digit by digit, in its
+// dash separated groups or else in groups of three with a last group of
+// four rather than a single digit.
+func (nwn *numberToWordNormalizer) digitGroups(match string) string {
+	groups := strings.Split(match, "-")
+	if len(groups) == 1 {
+		groups = digitGroups(match)
+	}
+	return nwn.locale.digitGroups(groups)
+}
 
-	if num < 10 {
-		return units[num]
-	} else if num < 20 {
-		return teens[num-10]
-	} else {
-		ten := num / 10
-		unit := num % 10
-		if unit == 0 {
-			return tens[ten]
-		}
-		return tens[ten] + "-" + units[unit]
+// group returns submatch i of a match of FindAllStringSubmatchIndex, "" when
+// it did not take part.
+func group(s string, m []int, i int) string {
+	if m[2*i] < 0 {
+		return ""
 	}
+	return s[m[2*i]:m[2*i+1]]
 }
@@ -0,0 +1,105 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rapidaai/pkg/commons"
+)
+
+type phoneNumberNormalizer struct {
+	logger commons.Logger
+	re     *regexp.Regexp
+	digits *regexp.Regexp
+	locale *Locale
+}
+
+func NewPhoneNumberNormalizer(logger commons.Logger) Normalizer {
+	return NewPhoneNumberNormalizerFor(logger, English)
+}
+
+// NewPhoneNumberNormalizerFor reads phone numbers digit by digit in the
+// language of locale, pausing between the groups they are written in:
+// "+1 415-555-0123" as "plus one, four one five, five five five, zero one
+// two three". E.164 numbers without separators are grouped after their
+// country code.
+func NewPhoneNumberNormalizerFor(logger commons.Logger, locale *Locale) Normalizer {
+	return &phoneNumberNormalizer{
+		logger: logger,
+		re: regexp.MustCompile(
+			`(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?\b\d{2,4}(?:[ .-]\d{2,4}){1,4}\b|` + // +44 20 7946 0958, (415) 555-0123
+				`\+\d{7,15}\b`, // +14155550123
+		),
+		digits: regexp.MustCompile(`\d+`),
+		locale: locale,
+	}
+}
+
+func (pn *phoneNumberNormalizer) Normalize(s string) string {
+	return pn.re.ReplaceAllStringFunc(s, func(match string) string {
+		groups := pn.digits.FindAllString(match, -1)
+		if count := len(strings.Join(groups, "")); count < 7 || count > 15 {
+			return match
+		}
+		international := strings.HasPrefix(match, "+")
+		if !international && !strings.HasPrefix(match, "(") && !phoneLike(match, groups) {
+			return match
+		}
+		var countryCode string
+		if international {
+			if len(groups) == 1 {
+				countryCode = groups[0][:countryCodeLength(groups[0])]
+				groups = digitGroups(groups[0][len(countryCode):])
+			} else {
+				countryCode, groups = groups[0], groups[1:]
+			}
+			return pn.locale.Plus + " " + pn.locale.digits(countryCode) + ", " + pn.locale.digitGroups(groups)
+		}
+		return pn.locale.digitGroups(groups)
+	})
+}
+
+// phoneLike tells a national phone number from dates, 2024-01-15, and
+// numbers grouped by thousands, 1 250 000.
+func phoneLike(match string, groups []string) bool {
+	if len(groups) == 3 && !strings.Contains(match, " ") {
+		if a, b, c := len(groups[0]), len(groups[1]), len(groups[2]); (a == 4 && b == 2 && c == 2) || (a == 2 && b == 2 && c == 4) {
+			return false
+		}
+	}
+	if strings.Contains(match, "-") || len(groups[0]) > 3 {
+		return true
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return true
+		}
+	}
+	return false
+}
+
+// countryCodeLength is the length of the country code e164 starts with,
+// country codes being prefix free.
+func countryCodeLength(e164 string) int {
+	switch {
+	case e164[0] == '1' || e164[0] == '7':
+		return 1
+	case twoDigitCountryCodes[e164[:2]]:
+		return 2
+	}
+	return 3
+}
+
+var twoDigitCountryCodes = map[string]bool{
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true, "34": true, "36": true, "39": true,
+	"40": true, "41": true, "43": true, "44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "52": true, "53": true, "54": true, "55": true, "56": true, "57": true, "58": true,
+	"60": true, "61": true, "62": true, "63": true, "64": true, "65": true, "66": true,
+	"81": true, "82": true, "84": true, "86": true,
+	"90": true, "91": true, "92": true, "93": true, "94": true, "95": true, "98": true,
+}
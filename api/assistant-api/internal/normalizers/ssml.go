@@ -0,0 +1,285 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_normalizers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// OptionsKeySSMLPassthrough lets the SSML the assistant writes through to
+// the text to speech instead of escaping it, see NormalizeSSML.
+const OptionsKeySSMLPassthrough = "speaker.ssml.passthrough"
+
+// SSMLPassthrough reports whether opts let the assistant's SSML through.
+func SSMLPassthrough(opts utils.Option) bool {
+	passthrough, err := opts.GetBool(OptionsKeySSMLPassthrough)
+	return err == nil && passthrough
+}
+
+// SSML tags the assistant may write.
+const (
+	SSMLBreak    = "break"
+	SSMLEmphasis = "emphasis"
+	SSMLSayAs    = "say-as"
+	SSMLProsody  = "prosody"
+)
+
+// SSMLDialect is the SSML a text to speech provider speaks.
+type SSMLDialect struct {
+	// Tags are the SSML tags the provider speaks, the others keep their text
+	// and lose their markup.
+	Tags []string
+
+	// BreakSeconds writes break times in seconds, time="0.5s", and turns
+	// strengths into times.
+	BreakSeconds bool
+
+	// InterpretAs renames say-as interpret-as values, a value renamed to ""
+	// keeps the text only.
+	InterpretAs map[string]string
+
+	// Pause is written for a break by a provider without breaks.
+	Pause string
+}
+
+// W3CSSML speaks every tag as the W3C wrote it.
+var W3CSSML = SSMLDialect{Tags: []string{SSMLBreak, SSMLEmphasis, SSMLSayAs, SSMLProsody}}
+
+// PlainText is a provider without SSML, breaks are paused on with an
+// ellipsis.
+var PlainText = SSMLDialect{Pause: "..."}
+
+var (
+	ssmlTagRe  = regexp.MustCompile(`<(/?)([a-zA-Z][\w:-]*)((?:\s+[\w:-]+\s*=\s*(?:"[^"<>]*"|'[^'<>]*'))*)\s*(/?)>`)
+	ssmlAttrRe = regexp.MustCompile(`([\w:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+	ssmlBreakTime  = regexp.MustCompile(`^(\d+(?:\.\d+)?)(ms|s)$`)
+	ssmlRate       = regexp.MustCompile(`^(x-slow|slow|medium|fast|x-fast|default|[+-]?\d+(\.\d+)?%)$`)
+	ssmlPitch      = regexp.MustCompile(`^(x-low|low|medium|high|x-high|default|[+-]?\d+(\.\d+)?(%|Hz|st))$`)
+	ssmlVolume     = regexp.MustCompile(`^(silent|x-soft|soft|medium|loud|x-loud|default|[+-]?\d+(\.\d+)?dB)$`)
+	ssmlSayAsFmt   = regexp.MustCompile(`^[a-z0-9]{1,8}$`)
+	ssmlEmphasis   = map[string]bool{"strong": true, "moderate": true, "none": true, "reduced": true}
+	ssmlStrengths  = map[string]float64{"none": 0, "x-weak": 0.1, "weak": 0.25, "medium": 0.5, "strong": 0.75, "x-strong": 1}
+	ssmlInterprets = map[string]bool{
+		"characters": true, "spell-out": true, "cardinal": true, "number": true, "ordinal": true,
+		"digits": true, "fraction": true, "unit": true, "date": true, "time": true,
+		"telephone": true, "address": true, "expletive": true, "verbatim": true,
+	}
+)
+
+// maxBreakSeconds is the longest break the assistant may ask for.
+const maxBreakSeconds = 10
+
+type ssmlNode struct {
+	text  string
+	tag   string
+	attrs [][2]string
+	close bool
+}
+
+// NormalizeSSML keeps the break, emphasis, say-as and prosody tags the
+// assistant wrote in text and renders them in dialect. Tags with invalid
+// attributes and all other tags are dropped, their text kept, tags left open
+// are closed. normalize runs over the text between the tags, escaping it
+// included; the space it trims next to a tag is kept.
+func NormalizeSSML(text string, dialect SSMLDialect, normalize func(string) string) string {
+	var out strings.Builder
+	for _, node := range parseSSML(text) {
+		if node.tag == "" {
+			out.WriteString(keepSpace(node.text, normalize(node.text)))
+			continue
+		}
+		out.WriteString(dialect.render(node))
+	}
+	return out.String()
+}
+
+// keepSpace puts back the space around text that normalizing it trimmed, so
+// the words on both sides of a tag do not run together.
+func keepSpace(text, normalized string) string {
+	if normalized == "" {
+		if strings.TrimSpace(text) != text {
+			return " "
+		}
+		return ""
+	}
+	if startsWithSpace(text) && !startsWithSpace(normalized) {
+		normalized = " " + normalized
+	}
+	if endsWithSpace(text) && !endsWithSpace(normalized) {
+		normalized += " "
+	}
+	return normalized
+}
+
+func startsWithSpace(text string) bool {
+	return text != "" && strings.TrimLeftFunc(text, unicode.IsSpace) != text
+}
+
+func endsWithSpace(text string) bool {
+	return text != "" && strings.TrimRightFunc(text, unicode.IsSpace) != text
+}
+
+// parseSSML splits text into text and the valid whitelisted tags, open tags
+// matched by their closing tag.
+func parseSSML(text string) []ssmlNode {
+	var nodes []ssmlNode
+	var open []ssmlNode
+	last := 0
+	for _, m := range ssmlTagRe.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > last {
+			nodes = append(nodes, ssmlNode{text: text[last:m[0]]})
+		}
+		last = m[1]
+		closing, name, selfClosing := m[3] > m[2], strings.ToLower(text[m[4]:m[5]]), m[9] > m[8]
+		switch {
+		case closing:
+			if len(open) > 0 && open[len(open)-1].tag == name {
+				nodes = append(nodes, ssmlNode{tag: name, attrs: open[len(open)-1].attrs, close: true})
+				open = open[:len(open)-1]
+			}
+		case name == SSMLBreak:
+			if attrs, ok := validSSML(name, text[m[6]:m[7]]); ok && selfClosing {
+				nodes = append(nodes, ssmlNode{tag: name, attrs: attrs})
+			}
+		case selfClosing:
+		default:
+			if attrs, ok := validSSML(name, text[m[6]:m[7]]); ok {
+				open = append(open, ssmlNode{tag: name, attrs: attrs})
+				nodes = append(nodes, open[len(open)-1])
+			}
+		}
+	}
+	if last < len(text) {
+		nodes = append(nodes, ssmlNode{text: text[last:]})
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		nodes = append(nodes, ssmlNode{tag: open[i].tag, attrs: open[i].attrs, close: true})
+	}
+	return nodes
+}
+
+// validSSML returns the attributes of a whitelisted tag, false when the tag
+// is not whitelisted or an attribute is unknown or invalid.
+func validSSML(tag, attributes string) ([][2]string, bool) {
+	var attrs [][2]string
+	for _, a := range ssmlAttrRe.FindAllStringSubmatch(attributes, -1) {
+		name, value := strings.ToLower(a[1]), a[2]+a[3]
+		if !validSSMLAttr(tag, name, value) {
+			return nil, false
+		}
+		attrs = append(attrs, [2]string{name, value})
+	}
+	switch tag {
+	case SSMLBreak, SSMLEmphasis:
+		return attrs, true
+	case SSMLSayAs:
+		return attrs, ssmlAttr(attrs, "interpret-as") != ""
+	case SSMLProsody:
+		return attrs, len(attrs) > 0
+	}
+	return nil, false
+}
+
+func validSSMLAttr(tag, name, value string) bool {
+	switch tag + " " + name {
+	case "break time":
+		m := ssmlBreakTime.FindStringSubmatch(value)
+		if m == nil {
+			return false
+		}
+		seconds, _ := strconv.ParseFloat(m[1], 64)
+		if m[2] == "ms" {
+			seconds /= 1000
+		}
+		return seconds <= maxBreakSeconds
+	case "break strength":
+		_, ok := ssmlStrengths[value]
+		return ok
+	case "emphasis level":
+		return ssmlEmphasis[value]
+	case "say-as interpret-as":
+		return ssmlInterprets[value]
+	case "say-as format":
+		return ssmlSayAsFmt.MatchString(value)
+	case "prosody rate":
+		return ssmlRate.MatchString(value)
+	case "prosody pitch":
+		return ssmlPitch.MatchString(value)
+	case "prosody volume":
+		return ssmlVolume.MatchString(value)
+	}
+	return false
+}
+
+func ssmlAttr(attrs [][2]string, name string) string {
+	for _, a := range attrs {
+		if a[0] == name {
+			return a[1]
+		}
+	}
+	return ""
+}
+
+// render writes a tag of node in the dialect, "" when the dialect has no
+// such tag.
+func (d SSMLDialect) render(node ssmlNode) string {
+	switch {
+	case !d.speaks(node.tag) && node.tag == SSMLBreak && d.Pause != "":
+		return " " + d.Pause + " "
+	case !d.speaks(node.tag):
+		return ""
+	case node.tag == SSMLBreak && d.BreakSeconds:
+		return `<break time="` + breakSeconds(node.attrs) + `"/>`
+	}
+	interpretAs, renamed := d.InterpretAs[ssmlAttr(node.attrs, "interpret-as")]
+	if node.tag == SSMLSayAs && renamed && interpretAs == "" {
+		return ""
+	}
+	if node.close {
+		return "</" + node.tag + ">"
+	}
+	var attrs strings.Builder
+	for _, a := range node.attrs {
+		name, value := a[0], a[1]
+		if node.tag == SSMLSayAs && name == "interpret-as" && renamed {
+			value = interpretAs
+		}
+		fmt.Fprintf(&attrs, ` %s="%s"`, name, value)
+	}
+	if node.tag == SSMLBreak {
+		return "<break" + attrs.String() + "/>"
+	}
+	return "<" + node.tag + attrs.String() + ">"
+}
+
+func (d SSMLDialect) speaks(tag string) bool {
+	for _, t := range d.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// breakSeconds is the time of a break in seconds, 0.5s, its strength
+// when it has no time.
+func breakSeconds(attrs [][2]string) string {
+	seconds := ssmlStrengths[ssmlAttr(attrs, "strength")]
+	if m := ssmlBreakTime.FindStringSubmatch(ssmlAttr(attrs, "time")); m != nil {
+		seconds, _ = strconv.ParseFloat(m[1], 64)
+		if m[2] == "ms" {
+			seconds /= 1000
+		}
+	}
+	return strconv.FormatFloat(seconds, 'f', -1, 64) + "s"
+}
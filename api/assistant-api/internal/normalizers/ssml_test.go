@@ -0,0 +1,120 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_normalizers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// =============================================================================
+// SSML Passthrough Tests
+// =============================================================================
+
+var escapeXML = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+
+func TestSSMLPassthrough(t *testing.T) {
+	assert.True(t, SSMLPassthrough(utils.Option{OptionsKeySSMLPassthrough: true}))
+	assert.True(t, SSMLPassthrough(utils.Option{OptionsKeySSMLPassthrough: "true"}))
+	assert.False(t, SSMLPassthrough(utils.Option{OptionsKeySSMLPassthrough: "false"}))
+	assert.False(t, SSMLPassthrough(utils.Option{}))
+}
+
+func TestNormalizeSSMLW3C(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "whitelisted tags are kept",
+			input:    `Wait<break time="500ms"/> that is <emphasis level="strong">huge</emphasis>.`,
+			expected: `Wait<break time="500ms"/> that is <emphasis level="strong">huge</emphasis>.`,
+		},
+		{
+			name:     "say-as and prosody",
+			input:    `Code <say-as interpret-as="characters">AB1</say-as>, <prosody rate="slow" pitch="-2st">slowly</prosody>`,
+			expected: `Code <say-as interpret-as="characters">AB1</say-as>, <prosody rate="slow" pitch="-2st">slowly</prosody>`,
+		},
+		{
+			name:     "text between tags is normalized",
+			input:    `Tom & Jerry <emphasis>3 < 5</emphasis>`,
+			expected: `Tom &amp; Jerry <emphasis>3 &lt; 5</emphasis>`,
+		},
+		{
+			name:     "unknown tags are dropped with their text kept",
+			input:    `<speak><voice name="x">Hello <audio src="a.mp3"/>there</voice></speak>`,
+			expected: `Hello there`,
+		},
+		{
+			name:     "invalid attributes drop the tag",
+			input:    `<prosody rate="warp">fast</prosody> <break time="60s"/>done <say-as interpret-as="evil">x</say-as>`,
+			expected: `fast done x`,
+		},
+		{
+			name:     "unknown attribute drops the tag",
+			input:    `<emphasis onclick="x">hi</emphasis>`,
+			expected: `hi`,
+		},
+		{
+			name:     "unclosed tags are closed",
+			input:    `<prosody volume="loud">Listen <emphasis>now`,
+			expected: `<prosody volume="loud">Listen <emphasis>now</emphasis></prosody>`,
+		},
+		{
+			name:     "stray and crossed closing tags are dropped",
+			input:    `a</emphasis> <prosody rate="fast">b<emphasis>c</prosody>d</emphasis>`,
+			expected: `a <prosody rate="fast">b<emphasis>cd</emphasis></prosody>`,
+		},
+		{
+			name:     "break must be self closing",
+			input:    `a<break time="1s">b`,
+			expected: `ab`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeSSML(tt.input, W3CSSML, escapeXML))
+		})
+	}
+}
+
+func TestNormalizeSSMLDialects(t *testing.T) {
+	input := `Hi<break strength="strong"/> <say-as interpret-as="verbatim">AB</say-as> <emphasis>now</emphasis><break time="250ms"/>`
+
+	t.Run("plain text", func(t *testing.T) {
+		assert.Equal(t, "Hi ...  AB now ... ", NormalizeSSML(input, PlainText, escapeXML))
+	})
+
+	t.Run("breaks in seconds", func(t *testing.T) {
+		dialect := SSMLDialect{Tags: []string{SSMLBreak}, BreakSeconds: true}
+		assert.Equal(t, `Hi<break time="0.75s"/> AB now<break time="0.25s"/>`, NormalizeSSML(input, dialect, escapeXML))
+	})
+
+	t.Run("interpretations renamed", func(t *testing.T) {
+		dialect := SSMLDialect{Tags: W3CSSML.Tags, InterpretAs: map[string]string{"verbatim": "characters"}}
+		assert.Equal(t,
+			`Hi<break strength="strong"/> <say-as interpret-as="characters">AB</say-as> <emphasis>now</emphasis><break time="250ms"/>`,
+			NormalizeSSML(input, dialect, escapeXML))
+	})
+
+	t.Run("space trimmed by normalizing is kept", func(t *testing.T) {
+		assert.Equal(t, `say <emphasis> this</emphasis> <emphasis>now</emphasis>`,
+			NormalizeSSML(`say  <emphasis> this</emphasis> <emphasis>now</emphasis>`, W3CSSML, strings.TrimSpace))
+	})
+
+	t.Run("interpretations without say-as", func(t *testing.T) {
+		dialect := SSMLDialect{Tags: W3CSSML.Tags, InterpretAs: map[string]string{"verbatim": ""}}
+		assert.Equal(t,
+			`Hi<break strength="strong"/> AB <emphasis>now</emphasis><break time="250ms"/>`,
+			NormalizeSSML(input, dialect, escapeXML))
+	})
+}
@@ -12,22 +12,25 @@ import (
 )
 
 type symbolNormalizer struct {
-	logger    commons.Logger
-	symbolMap map[string]string
+	logger   commons.Logger
+	replacer *strings.Replacer
 }
 
 func NewSymbolNormalizer(logger commons.Logger) Normalizer {
+	symbolMap := createSymbolMap()
+	pairs := make([]string, 0, 2*len(symbolMap))
+	for symbol, word := range symbolMap {
+		pairs = append(pairs, symbol, word)
+	}
 	return &symbolNormalizer{
-		logger:    logger,
-		symbolMap: createSymbolMap(),
+		logger: logger,
+		// replaces every symbol in one pass over the text
+		replacer: strings.NewReplacer(pairs...),
 	}
 }
 
 func (sn *symbolNormalizer) Normalize(s string) string {
-	for symbol, word := range sn.symbolMap {
-		s = strings.ReplaceAll(s, symbol, word)
-	}
-	return s
+	return sn.replacer.Replace(s)
 }
 
 func createSymbolMap() map[string]string {
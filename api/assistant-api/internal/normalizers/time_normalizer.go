@@ -15,11 +15,18 @@ import (
 type timeNormalizer struct {
 	logger commons.Logger
 	re     *regexp.Regexp
+	locale *Locale
 }
 
 func NewTimeNormalizer(logger commons.Logger) Normalizer {
+	return NewTimeNormalizerFor(logger, English)
+}
+
+// NewTimeNormalizerFor speaks times of day in the language of locale.
+func NewTimeNormalizerFor(logger commons.Logger, locale *Locale) Normalizer {
 	return &timeNormalizer{
 		logger: logger,
+		locale: locale,
 		re:     regexp.MustCompile(`(\d{1,2}):(\d{2})`),
 	}
 }
@@ -31,6 +38,6 @@ func (tn *timeNormalizer) Normalize(s string) string {
 			tn.logger.Warn("Failed to parse time", "error", err, "time", match)
 			return match
 		}
-		return t.Format("3:04 PM")
+		return tn.locale.Time(t.Hour(), t.Minute())
 	})
 }
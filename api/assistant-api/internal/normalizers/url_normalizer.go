@@ -14,17 +14,18 @@ import (
 
 type urlNormalizer struct {
 	logger commons.Logger
+	re     *regexp.Regexp
 }
 
 func NewUrlNormalizer(logger commons.Logger) Normalizer {
 	return &urlNormalizer{
 		logger: logger,
+		re:     regexp.MustCompile(`(https?://)?([^\s.]+\.[^\s]{2,}|www\.[^\s]+\.[^\s]{2,})`),
 	}
 }
 
 func (un *urlNormalizer) Normalize(s string) string {
-	re := regexp.MustCompile(`(https?://)?([^\s.]+\.[^\s]{2,}|www\.[^\s]+\.[^\s]{2,})`)
-	return re.ReplaceAllStringFunc(s, func(match string) string {
+	return un.re.ReplaceAllStringFunc(s, func(match string) string {
 		return strings.ReplaceAll(match, ".", " dot ")
 	})
 }
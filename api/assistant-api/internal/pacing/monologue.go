@@ -0,0 +1,149 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_pacing
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+)
+
+// The longest the assistant talks without the caller getting a word in is
+// set on the text to speech options of a deployment, in seconds of audio.
+// Past it the assistant asks whether to go on, or with the pause action
+// just stops and waits for the caller:
+//
+//	speak.monologue.max      = 45
+//	speak.monologue.action   = check_in
+//	speak.monologue.check_in = Shall I continue?
+const (
+	OptionsKeyMonologueMax     = "speak.monologue.max"
+	OptionsKeyMonologueAction  = "speak.monologue.action"
+	OptionsKeyMonologueCheckIn = "speak.monologue.check_in"
+)
+
+// What the assistant does once it talked for too long.
+const (
+	MonologueCheckIn = "check_in"
+	MonologuePause   = "pause"
+)
+
+// DefaultCheckIn is asked when the deployment configures no question.
+const DefaultCheckIn = "Shall I continue?"
+
+// Verdict is what becomes of a chunk of the assistant's audio.
+type Verdict int
+
+const (
+	// Speak plays the chunk.
+	Speak Verdict = iota
+	// Cut drops the chunk, it is the first past the limit and the rest of
+	// the answer is to be stopped.
+	Cut
+	// Drop leaves out a chunk of an answer that was cut.
+	Drop
+)
+
+type monologueState int
+
+const (
+	stateTalking monologueState = iota
+	stateCapped
+	stateCheckingIn
+)
+
+// Monologue limits how long the assistant talks in one answer. It measures
+// the audio actually sent to the caller, whatever the model made of its
+// instructions. It is safe for concurrent use.
+type Monologue struct {
+	Max     time.Duration
+	Action  string
+	CheckIn string
+
+	mu        sync.Mutex
+	contextID string
+	spoken    time.Duration
+	state     monologueState
+	dropped   time.Time
+	clock     func() time.Time
+}
+
+// MonologueFromOptions reads the limit configured in opts, nil when there is
+// none.
+func MonologueFromOptions(opts utils.Option) *Monologue {
+	seconds, err := opts.GetFloat64(OptionsKeyMonologueMax)
+	if err != nil || seconds <= 0 {
+		return nil
+	}
+	m := &Monologue{
+		Max:     time.Duration(seconds * float64(time.Second)),
+		Action:  MonologueCheckIn,
+		CheckIn: DefaultCheckIn,
+		clock:   time.Now,
+	}
+	if action, err := opts.GetString(OptionsKeyMonologueAction); err == nil && strings.EqualFold(strings.TrimSpace(action), MonologuePause) {
+		m.Action = MonologuePause
+	}
+	if question, err := opts.GetString(OptionsKeyMonologueCheckIn); err == nil && strings.TrimSpace(question) != "" {
+		m.CheckIn = strings.TrimSpace(question)
+	}
+	return m
+}
+
+// Spoken accounts for d of audio of the answer contextID and tells whether
+// it is played. An answer other than the last starts afresh, the check-in
+// itself is never counted.
+func (m *Monologue) Spoken(contextID string, d time.Duration) Verdict {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if contextID != m.contextID {
+		m.contextID, m.spoken, m.state = contextID, 0, stateTalking
+	}
+	switch m.state {
+	case stateCheckingIn:
+		return Speak
+	case stateCapped:
+		m.dropped = m.clock()
+		return Drop
+	}
+	if m.spoken+d > m.Max {
+		m.state, m.dropped = stateCapped, m.clock()
+		return Cut
+	}
+	m.spoken += d
+	return Speak
+}
+
+// Capped reports whether the answer contextID was cut, no more of it is to
+// be synthesized.
+func (m *Monologue) Capped(contextID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.contextID == contextID && m.state != stateTalking
+}
+
+// Quiet reports whether no audio of the cut answer arrived for settle, what
+// the provider still had in flight is through by then.
+func (m *Monologue) Quiet(settle time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.clock().Sub(m.dropped) >= settle
+}
+
+// BeginCheckIn lets the audio of the answer contextID through again, for the
+// check-in question. It returns false when the answer was not cut or the
+// caller moved on meanwhile.
+func (m *Monologue) BeginCheckIn(contextID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.contextID != contextID || m.state != stateCapped {
+		return false
+	}
+	m.state = stateCheckingIn
+	return true
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_pacing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMonologue(limit time.Duration) (*Monologue, *time.Time) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	return &Monologue{Max: limit, Action: MonologueCheckIn, CheckIn: DefaultCheckIn, clock: func() time.Time { return now }}, &now
+}
+
+func TestMonologueFromOptions(t *testing.T) {
+	assert.Nil(t, MonologueFromOptions(utils.Option{}))
+	assert.Nil(t, MonologueFromOptions(utils.Option{OptionsKeyMonologueMax: "0"}))
+
+	m := MonologueFromOptions(utils.Option{OptionsKeyMonologueMax: "30"})
+	require.NotNil(t, m)
+	assert.Equal(t, 30*time.Second, m.Max)
+	assert.Equal(t, MonologueCheckIn, m.Action)
+	assert.Equal(t, DefaultCheckIn, m.CheckIn)
+
+	m = MonologueFromOptions(utils.Option{
+		OptionsKeyMonologueMax:     "12.5",
+		OptionsKeyMonologueAction:  " Pause ",
+		OptionsKeyMonologueCheckIn: "Want me to go on?",
+	})
+	require.NotNil(t, m)
+	assert.Equal(t, 12500*time.Millisecond, m.Max)
+	assert.Equal(t, MonologuePause, m.Action)
+	assert.Equal(t, "Want me to go on?", m.CheckIn)
+}
+
+func TestMonologue_CutsPastTheLimit(t *testing.T) {
+	m, _ := testMonologue(time.Second)
+	assert.Equal(t, Speak, m.Spoken("a", 400*time.Millisecond))
+	assert.Equal(t, Speak, m.Spoken("a", 600*time.Millisecond))
+	assert.False(t, m.Capped("a"))
+
+	assert.Equal(t, Cut, m.Spoken("a", 100*time.Millisecond))
+	assert.True(t, m.Capped("a"))
+	assert.Equal(t, Drop, m.Spoken("a", 100*time.Millisecond))
+
+	// the caller answered, the next answer has the whole limit again
+	assert.False(t, m.Capped("b"))
+	assert.Equal(t, Speak, m.Spoken("b", time.Second))
+}
+
+func TestMonologue_CheckIn(t *testing.T) {
+	m, now := testMonologue(time.Second)
+	assert.False(t, m.BeginCheckIn("a"), "not cut")
+
+	m.Spoken("a", 2*time.Second)
+	assert.False(t, m.Quiet(300*time.Millisecond))
+	*now = now.Add(300 * time.Millisecond)
+	assert.True(t, m.Quiet(300*time.Millisecond))
+
+	assert.False(t, m.BeginCheckIn("b"), "another answer")
+	require.True(t, m.BeginCheckIn("a"))
+	assert.Equal(t, Speak, m.Spoken("a", 5*time.Second))
+	assert.True(t, m.Capped("a"), "the answer itself stays cut")
+}
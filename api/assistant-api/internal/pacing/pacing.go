@@ -0,0 +1,236 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_pacing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	internal_campaign "github.com/rapidaai/api/assistant-api/internal/campaign"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// Speaking profiles are configured on the text to speech options of a
+// deployment. OptionsKeyProfiles lists them in the order they are tried at
+// the start of a call, each is set under speak.profile.<name>:
+//
+//	speak.profiles                 = elderly,night
+//	speak.profile.elderly.rate     = 0.85
+//	speak.profile.elderly.confirm  = true
+//	speak.profile.elderly.when     = caller.age_group=senior
+//	speak.profile.night.verbosity  = brief
+//	speak.profile.night.hours      = 21:00-07:00
+//	speak.profile.night.days       = sat,sun
+//	speak.profile.night.timezone   = Europe/Berlin
+const (
+	OptionsKeyProfiles = "speak.profiles"
+	optionsKeyProfile  = "speak.profile."
+)
+
+// RateOption is the speaking rate text to speech providers apply when they
+// support one, a multiple of the voice's normal rate.
+const RateOption = "speak.rate"
+
+// Speaking rates a profile may set.
+const (
+	MinRate = 0.5
+	MaxRate = 2.0
+)
+
+// How much the assistant says per answer.
+const (
+	VerbosityBrief    = "brief"
+	VerbosityNormal   = "normal"
+	VerbosityDetailed = "detailed"
+)
+
+var days = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Profile is how the assistant speaks: its speaking rate, how long its
+// answers are and whether it confirms details back to the caller.
+type Profile struct {
+	Name          string
+	Rate          float64 // 0 keeps the rate of the voice
+	Verbosity     string
+	Confirmations bool
+
+	hours *internal_campaign.CallingWindow
+	days  map[time.Weekday]bool
+	when  map[string]string
+}
+
+// Profiles are the speaking profiles of a deployment in the order they are
+// tried.
+type Profiles []*Profile
+
+// FromOptions reads the speaking profiles configured in opts, none when
+// OptionsKeyProfiles is not set.
+func FromOptions(opts utils.Option) (Profiles, error) {
+	names, err := opts.GetString(OptionsKeyProfiles)
+	if err != nil || strings.TrimSpace(names) == "" {
+		return nil, nil
+	}
+	profiles := make(Profiles, 0)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		profile, err := profileFromOptions(name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("speaking profile %s: %w", name, err)
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func profileFromOptions(name string, opts utils.Option) (*Profile, error) {
+	key := func(k string) string { return optionsKeyProfile + name + "." + k }
+	profile := &Profile{Name: name, Verbosity: VerbosityNormal}
+
+	if rate, err := opts.GetString(key("rate")); err == nil && strings.TrimSpace(rate) != "" {
+		value, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil || value < MinRate || value > MaxRate {
+			return nil, fmt.Errorf("rate %q is not between %.1f and %.1f", rate, MinRate, MaxRate)
+		}
+		profile.Rate = value
+	}
+	if verbosity, err := opts.GetString(key("verbosity")); err == nil && strings.TrimSpace(verbosity) != "" {
+		switch v := strings.ToLower(strings.TrimSpace(verbosity)); v {
+		case VerbosityBrief, VerbosityNormal, VerbosityDetailed:
+			profile.Verbosity = v
+		default:
+			return nil, fmt.Errorf("verbosity %q is not brief, normal or detailed", verbosity)
+		}
+	}
+	if confirm, err := opts.GetBool(key("confirm")); err == nil {
+		profile.Confirmations = confirm
+	}
+
+	if hours, err := opts.GetString(key("hours")); err == nil && strings.TrimSpace(hours) != "" {
+		start, end, ok := strings.Cut(hours, "-")
+		if !ok {
+			return nil, fmt.Errorf("hours %q are not HH:MM-HH:MM", hours)
+		}
+		timezone, _ := opts.GetString(key("timezone"))
+		window, err := internal_campaign.ParseCallingWindow(timezone, start, end)
+		if err != nil {
+			return nil, err
+		}
+		profile.hours = window
+	}
+	if list, err := opts.GetString(key("days")); err == nil && strings.TrimSpace(list) != "" {
+		profile.days = make(map[time.Weekday]bool)
+		for _, d := range strings.Split(list, ",") {
+			abbr := strings.ToLower(strings.TrimSpace(d))
+			if len(abbr) > 3 {
+				abbr = abbr[:3] // monday, tues, ...
+			}
+			day, ok := days[abbr]
+			if !ok {
+				return nil, fmt.Errorf("day %q is unknown", d)
+			}
+			profile.days[day] = true
+		}
+	}
+	if when, err := opts.GetString(key("when")); err == nil && strings.TrimSpace(when) != "" {
+		profile.when = make(map[string]string)
+		for _, condition := range strings.Split(when, ",") {
+			k, v, ok := strings.Cut(condition, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				return nil, fmt.Errorf("condition %q is not key=value", condition)
+			}
+			profile.when[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return profile, nil
+}
+
+// Get returns the profile called name, nil when there is none.
+func (p Profiles) Get(name string) *Profile {
+	for _, profile := range p {
+		if strings.EqualFold(profile.Name, name) {
+			return profile
+		}
+	}
+	return nil
+}
+
+// Select returns the first profile whose rules all hold for a call at now
+// with the given caller signals, the conversation's arguments and options.
+// Profiles without rules are only ever switched to mid-call.
+func (p Profiles) Select(now time.Time, signals utils.Option) *Profile {
+	for _, profile := range p {
+		if profile.matches(now, signals) {
+			return profile
+		}
+	}
+	return nil
+}
+
+func (profile *Profile) matches(now time.Time, signals utils.Option) bool {
+	if profile.hours == nil && profile.days == nil && profile.when == nil {
+		return false
+	}
+	if profile.hours != nil && !profile.hours.Contains(now) {
+		return false
+	}
+	if profile.days != nil {
+		location := time.UTC
+		if profile.hours != nil {
+			location = profile.hours.Location
+		}
+		if !profile.days[now.In(location).Weekday()] {
+			return false
+		}
+	}
+	for key, want := range profile.when {
+		got, ok := signals[key]
+		if !ok || got == nil || !strings.EqualFold(strings.TrimSpace(fmt.Sprint(got)), want) {
+			return false
+		}
+	}
+	return true
+}
+
+// SpeakOptions returns the text to speech options with the speaking rate of
+// the profile applied, opts itself is left as is.
+func (profile *Profile) SpeakOptions(opts utils.Option) utils.Option {
+	if profile == nil || profile.Rate == 0 {
+		return opts
+	}
+	out := make(utils.Option, len(opts)+1)
+	for k, v := range opts {
+		out[k] = v
+	}
+	out[RateOption] = strconv.FormatFloat(profile.Rate, 'f', -1, 64)
+	return out
+}
+
+// Instructions tells the model how to answer under the profile, empty when
+// the assistant's own prompt applies unchanged.
+func (profile *Profile) Instructions() string {
+	if profile == nil {
+		return ""
+	}
+	var lines []string
+	switch profile.Verbosity {
+	case VerbosityBrief:
+		lines = append(lines, "Keep every answer to one or two short sentences and only go into detail when the caller asks for it.")
+	case VerbosityDetailed:
+		lines = append(lines, "Answer fully, explain one step at a time and do not leave out details the caller may need.")
+	}
+	if profile.Confirmations {
+		lines = append(lines, "Repeat names, numbers, dates and amounts back to the caller and wait for them to confirm before acting on them. Check the caller is following before moving on.")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_pacing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProfiles(t *testing.T) Profiles {
+	t.Helper()
+	profiles, err := FromOptions(utils.Option{
+		OptionsKeyProfiles:                "elderly, night, careful",
+		"speak.profile.elderly.rate":      "0.85",
+		"speak.profile.elderly.confirm":   "true",
+		"speak.profile.elderly.when":      "caller.age_group=senior",
+		"speak.profile.night.verbosity":   "brief",
+		"speak.profile.night.hours":       "21:00-07:00",
+		"speak.profile.night.timezone":    "Europe/Berlin",
+		"speak.profile.careful.rate":      "0.9",
+		"speak.profile.careful.confirm":   "true",
+		"speak.profile.careful.verbosity": "detailed",
+	})
+	require.NoError(t, err)
+	require.Len(t, profiles, 3)
+	return profiles
+}
+
+func TestFromOptions_NoProfiles(t *testing.T) {
+	profiles, err := FromOptions(utils.Option{"speak.voice.id": "x"})
+	require.NoError(t, err)
+	assert.Empty(t, profiles)
+}
+
+func TestFromOptions_Invalid(t *testing.T) {
+	for name, opts := range map[string]utils.Option{
+		"rate":      {OptionsKeyProfiles: "a", "speak.profile.a.rate": "4"},
+		"verbosity": {OptionsKeyProfiles: "a", "speak.profile.a.verbosity": "chatty"},
+		"hours":     {OptionsKeyProfiles: "a", "speak.profile.a.hours": "21:00"},
+		"timezone":  {OptionsKeyProfiles: "a", "speak.profile.a.hours": "21:00-07:00", "speak.profile.a.timezone": "Mars/Olympus"},
+		"day":       {OptionsKeyProfiles: "a", "speak.profile.a.days": "someday"},
+		"when":      {OptionsKeyProfiles: "a", "speak.profile.a.when": "senior"},
+	} {
+		_, err := FromOptions(opts)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestSelect_CallerSignal(t *testing.T) {
+	profiles := testProfiles(t)
+	noon := time.Date(2025, 3, 3, 11, 0, 0, 0, time.UTC) // 12:00 in Berlin
+
+	selected := profiles.Select(noon, utils.Option{"caller.age_group": "Senior"})
+	require.NotNil(t, selected)
+	assert.Equal(t, "elderly", selected.Name)
+	assert.Equal(t, 0.85, selected.Rate)
+
+	assert.Nil(t, profiles.Select(noon, utils.Option{"caller.age_group": "adult"}))
+}
+
+func TestSelect_Schedule(t *testing.T) {
+	profiles := testProfiles(t)
+	late := time.Date(2025, 3, 3, 22, 30, 0, 0, time.UTC) // 23:30 in Berlin
+	early := time.Date(2025, 3, 3, 5, 0, 0, 0, time.UTC)  // 06:00 in Berlin
+	day := time.Date(2025, 3, 3, 6, 30, 0, 0, time.UTC)   // 07:30 in Berlin
+
+	assert.Equal(t, "night", profiles.Select(late, nil).Name)
+	assert.Equal(t, "night", profiles.Select(early, nil).Name)
+	assert.Nil(t, profiles.Select(day, nil))
+
+	// the caller signal is tried first
+	assert.Equal(t, "elderly", profiles.Select(late, utils.Option{"caller.age_group": "senior"}).Name)
+}
+
+func TestSelect_Days(t *testing.T) {
+	profiles, err := FromOptions(utils.Option{
+		OptionsKeyProfiles:           "weekend",
+		"speak.profile.weekend.days": "Saturday, sun",
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, profiles.Select(time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC), nil))
+	assert.Nil(t, profiles.Select(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC), nil))
+}
+
+func TestGet_DirectiveOnlyProfile(t *testing.T) {
+	profiles := testProfiles(t)
+	careful := profiles.Get("Careful")
+	require.NotNil(t, careful)
+	assert.Equal(t, VerbosityDetailed, careful.Verbosity)
+	assert.Nil(t, profiles.Get("unknown"))
+
+	// without rules it is never selected at the start of a call
+	assert.Nil(t, profiles.Select(time.Date(2025, 3, 3, 11, 0, 0, 0, time.UTC), nil))
+}
+
+func TestSpeakOptions(t *testing.T) {
+	profiles := testProfiles(t)
+	opts := utils.Option{"speak.voice.id": "v1"}
+
+	out := profiles.Get("elderly").SpeakOptions(opts)
+	assert.Equal(t, "0.85", out[RateOption])
+	assert.Equal(t, "v1", out["speak.voice.id"])
+	assert.NotContains(t, opts, RateOption)
+
+	assert.Equal(t, opts, profiles.Get("night").SpeakOptions(opts))
+	var none *Profile
+	assert.Equal(t, opts, none.SpeakOptions(opts))
+}
+
+func TestInstructions(t *testing.T) {
+	profiles := testProfiles(t)
+	assert.Contains(t, profiles.Get("night").Instructions(), "short sentences")
+	assert.Contains(t, profiles.Get("elderly").Instructions(), "confirm")
+	assert.NotContains(t, profiles.Get("elderly").Instructions(), "short sentences")
+
+	plain := &Profile{Name: "plain", Verbosity: VerbosityNormal}
+	assert.Empty(t, plain.Instructions())
+	var none *Profile
+	assert.Empty(t, none.Instructions())
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_recognition tags the transcript of the caller with the
+// language and confidence speech to text heard each segment in, so the
+// providers can be compared per locale, and with the speaker who said it when
+// speakers are told apart.
+package internal_recognition
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/protos"
+)
+
+// Segment is a final transcript of the caller, the unit providers report a
+// language and confidence for.
+type Segment struct {
+	Language   string  `json:"language"`
+	Confidence float64 `json:"confidence"`
+	Words      int     `json:"words"`
+	Speaker    string  `json:"speaker,omitempty"`
+}
+
+// NewSegment tags script with language and confidence, fallback is the
+// language when the provider reported none.
+func NewSegment(script, language, fallback string, confidence float64) Segment {
+	if language == "" {
+		language = fallback
+	}
+	return Segment{Language: language, Confidence: confidence, Words: len(strings.Fields(script))}
+}
+
+// Language is the language most words of the segments were heard in, "" when
+// none was reported.
+func Language(segments []Segment) string {
+	return mostWords(segments, func(s Segment) string { return s.Language })
+}
+
+// Speaker is the speaker who said most words of the segments, "" when
+// speakers are not told apart.
+func Speaker(segments []Segment) string {
+	return mostWords(segments, func(s Segment) string { return s.Speaker })
+}
+
+func mostWords(segments []Segment, key func(Segment) string) string {
+	words := map[string]int{}
+	best := ""
+	for _, s := range segments {
+		k := key(s)
+		if k == "" {
+			continue
+		}
+		words[k] += s.Words
+		if best == "" || words[k] > words[best] {
+			best = k
+		}
+	}
+	return best
+}
+
+// Metrics returns the segments of a turn as metrics of its message: the
+// language and speaker of the turn and every segment as JSON.
+func Metrics(segments []Segment) []*protos.Metric {
+	if len(segments) == 0 {
+		return nil
+	}
+	encoded, _ := json.Marshal(segments)
+	metrics := []*protos.Metric{{
+		Name:        type_enums.STT_SEGMENTS.String(),
+		Value:       string(encoded),
+		Description: "Language, confidence, word count and speaker speech to text reported for each transcript segment of the turn",
+	}}
+	if language := Language(segments); language != "" {
+		metrics = append(metrics, &protos.Metric{
+			Name:        type_enums.STT_LANGUAGE.String(),
+			Value:       language,
+			Description: fmt.Sprintf("Language most of the %d transcript segments of the turn were heard in", len(segments)),
+		})
+	}
+	if speaker := Speaker(segments); speaker != "" {
+		metrics = append(metrics, &protos.Metric{
+			Name:        type_enums.STT_SPEAKER.String(),
+			Value:       speaker,
+			Description: fmt.Sprintf("Speaker who said most of the %d transcript segments of the turn", len(segments)),
+		})
+	}
+	return metrics
+}
+
+// Turn collects the segments of one turn of the caller. It is safe for
+// concurrent use.
+type Turn struct {
+	mu       sync.Mutex
+	segments []Segment
+}
+
+// Heard adds a segment to the turn.
+func (t *Turn) Heard(segment Segment) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.segments = append(t.segments, segment)
+}
+
+// End returns the segments of the turn and starts the next one.
+func (t *Turn) End() []Segment {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	segments := t.segments
+	t.segments = nil
+	return segments
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_recognition
+
+import (
+	"testing"
+
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTurn(t *testing.T) {
+	var turn Turn
+	turn.Heard(NewSegment("hola buenos dias", "es", "en-US", 0.91))
+	turn.Heard(NewSegment("I need help with my order", "", "en-US", 0.72))
+
+	segments := turn.End()
+	require.Len(t, segments, 2)
+	assert.Equal(t, Segment{Language: "es", Confidence: 0.91, Words: 3}, segments[0])
+	assert.Equal(t, Segment{Language: "en-US", Confidence: 0.72, Words: 6}, segments[1])
+	assert.Empty(t, turn.End())
+
+	assert.Equal(t, "en-US", Language(segments))
+	assert.Equal(t, "", Language([]Segment{{Words: 2}}))
+}
+
+func TestMetrics(t *testing.T) {
+	assert.Nil(t, Metrics(nil))
+
+	metrics := Metrics([]Segment{{Language: "de-DE", Confidence: 0.8, Words: 2}})
+	require.Len(t, metrics, 2)
+	assert.Equal(t, type_enums.STT_SEGMENTS.String(), metrics[0].Name)
+	assert.JSONEq(t, `[{"language":"de-DE","confidence":0.8,"words":2}]`, metrics[0].Value)
+	assert.Equal(t, type_enums.STT_LANGUAGE.String(), metrics[1].Name)
+	assert.Equal(t, "de-DE", metrics[1].Value)
+}
+
+func TestSpeaker(t *testing.T) {
+	caller := NewSegment("my account number is one two three", "en", "", 0.9)
+	caller.Speaker = "speaker_0"
+	agent := NewSegment("thanks", "en", "", 0.9)
+	agent.Speaker = "speaker_1"
+
+	assert.Equal(t, "speaker_0", Speaker([]Segment{agent, caller}))
+	assert.Equal(t, "", Speaker([]Segment{{Words: 3}}))
+
+	metrics := Metrics([]Segment{agent, caller})
+	require.Len(t, metrics, 3)
+	assert.Contains(t, metrics[0].Value, `"speaker":"speaker_1"`)
+	assert.Equal(t, type_enums.STT_SPEAKER.String(), metrics[2].Name)
+	assert.Equal(t, "speaker_0", metrics[2].Value)
+}
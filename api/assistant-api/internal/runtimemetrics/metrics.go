@@ -0,0 +1,144 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_runtimemetrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/rapidaai/pkg/streamers"
+)
+
+// Providers a session talks to, the provider label of the provider metrics.
+const (
+	SpeechToText = "speech_to_text"
+	LLM          = "llm"
+	TextToSpeech = "text_to_speech"
+)
+
+// Default is the registry served on /metrics.
+var Default = NewRegistry()
+
+// latencyBuckets cover a fast provider to a reply the caller gave up on, in
+// seconds.
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5, 10}
+
+// channelBlockedBuckets cover a push waiting a frame to one waiting out the
+// default block timeout, in seconds.
+var channelBlockedBuckets = []float64{0.005, 0.02, 0.05, 0.1, 0.25, 0.5, 1}
+
+var (
+	ActiveSessions = Default.NewGaugeVec("rapida_assistant_active_sessions",
+		"Sessions connected on this replica.", "source")
+	AudioFrames = Default.NewCounterVec("rapida_assistant_audio_frames_total",
+		"Audio frames streamers buffered for the talk loop (input) and the caller (output).", "direction")
+	ChannelDrops = Default.NewCounterVec("rapida_assistant_channel_drops_total",
+		"Messages streamers dropped because their channel was full, by the backpressure policy of the channel.", "direction", "policy")
+	ChannelBlocked = Default.NewHistogramVec("rapida_assistant_channel_blocked_seconds",
+		"How long streamers waited for room in a full channel before the message was enqueued.",
+		channelBlockedBuckets, "direction")
+	TurnLatency = Default.NewHistogramVec("rapida_assistant_turn_latency_seconds",
+		"Latency of the stages of a turn, capture_to_transcript is speech to text, transcript_to_first_token the LLM and token_to_first_audio text to speech.",
+		latencyBuckets, "stage")
+	StartupLatency = Default.NewHistogramVec("rapida_assistant_startup_seconds",
+		"Latency of the startup phases of a conversation, from the channel accepting the caller (transport) to the session being ready (total).",
+		latencyBuckets, "phase")
+	ProviderErrors = Default.NewCounterVec("rapida_assistant_provider_errors_total",
+		"Failed requests to speech to text, LLM and text to speech providers.", "provider")
+	ProviderStreams = Default.NewGaugeVec("rapida_assistant_provider_streams",
+		"Speech to text, LLM and text to speech streams of the connected sessions.", "provider")
+)
+
+// OpenProviderStreams is the number of provider streams of the connected
+// sessions.
+func OpenProviderStreams() int64 {
+	return ProviderStreams.With(SpeechToText).Value() +
+		ProviderStreams.With(LLM).Value() +
+		ProviderStreams.With(TextToSpeech).Value()
+}
+
+// PortPool is the RTP port range of the SIP server, shared by all replicas.
+type PortPool interface {
+	InUse() (int, error)
+	Size() int
+}
+
+type portPoolBox struct{ PortPool }
+
+var rtpPortPool atomic.Pointer[portPoolBox]
+
+// SetRTPPortPool reports the utilization of p, nil stops.
+func SetRTPPortPool(p PortPool) {
+	if p == nil {
+		rtpPortPool.Store(nil)
+		return
+	}
+	rtpPortPool.Store(&portPoolBox{p})
+}
+
+var (
+	RTPPortsInUse = Default.NewGaugeFunc("rapida_assistant_rtp_ports_in_use",
+		"RTP ports allocated across all replicas.", func() (float64, bool) {
+			pool := rtpPortPool.Load()
+			if pool == nil {
+				return 0, false
+			}
+			inUse, err := pool.InUse()
+			return float64(inUse), err == nil
+		})
+	RTPPorts = Default.NewGaugeFunc("rapida_assistant_rtp_ports",
+		"RTP ports of the configured range.", func() (float64, bool) {
+			pool := rtpPortPool.Load()
+			if pool == nil {
+				return 0, false
+			}
+			return float64(pool.Size()), true
+		})
+)
+
+// RTPPortUtilization is the fraction of the RTP port range in use, false
+// without a SIP server.
+func RTPPortUtilization() (float64, bool) {
+	pool := rtpPortPool.Load()
+	if pool == nil || pool.Size() == 0 {
+		return 0, false
+	}
+	inUse, err := pool.InUse()
+	return float64(inUse) / float64(pool.Size()), err == nil
+}
+
+// ObserveLatency records a stage of a completed turn.
+func ObserveLatency(stage string, latency time.Duration) {
+	TurnLatency.With(stage).Observe(latency.Seconds())
+}
+
+// ObserveStartup records a startup phase of a conversation.
+func ObserveStartup(phase string, latency time.Duration) {
+	StartupLatency.With(phase).Observe(latency.Seconds())
+}
+
+// Streamers counts the frames, drops and blocked pushes of every
+// BaseStreamer once set as their observer.
+var Streamers streamers.BackpressureObserver = streamerObserver{}
+
+type streamerObserver struct{}
+
+func (streamerObserver) AudioFrames(direction streamers.Direction, n int) {
+	AudioFrames.With(string(direction)).Add(uint64(n))
+}
+
+func (streamerObserver) Dropped(direction streamers.Direction) {
+	ChannelDrops.With(string(direction), string(streamers.DropNewest)).Inc()
+}
+
+func (streamerObserver) DroppedBy(direction streamers.Direction, policy streamers.BackpressurePolicy) {
+	ChannelDrops.With(string(direction), string(policy)).Inc()
+}
+
+func (streamerObserver) Blocked(direction streamers.Direction, wait time.Duration) {
+	ChannelBlocked.With(string(direction)).Observe(wait.Seconds())
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_runtimemetrics keeps the runtime counters of the service
+// and writes them in the Prometheus text exposition format. Only what the
+// service needs is implemented: integer counters and gauges, gauges read at
+// scrape time and histograms, each with a fixed set of labels.
+package internal_runtimemetrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry holds the metrics written on a scrape, in registration order.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []collector
+}
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, c)
+}
+
+// WriteTo writes every metric in the text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	metrics := slices.Clone(r.metrics)
+	r.mu.Unlock()
+	counted := &countingWriter{w: w}
+	buf := bufio.NewWriter(counted)
+	for _, m := range metrics {
+		m.write(buf)
+	}
+	err := buf.Flush()
+	return counted.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// family is what all series of a metric share.
+type family struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func (f family) header(w *bufio.Writer) {
+	w.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+	w.WriteString("# TYPE " + f.name + " " + f.kind + "\n")
+}
+
+// series is a set of label values, keyed by their joined values.
+type series[T any] struct {
+	family
+	mu          sync.RWMutex
+	values      map[string]*T
+	labelValues map[string][]string
+}
+
+func newSeries[T any](f family) *series[T] {
+	return &series[T]{family: f, values: make(map[string]*T), labelValues: make(map[string][]string)}
+}
+
+func (s *series[T]) with(values []string) *T {
+	if len(values) != len(s.family.labels) {
+		panic("metric " + s.name + " takes " + strconv.Itoa(len(s.family.labels)) + " label values")
+	}
+	key := strings.Join(values, "\xff")
+	s.mu.RLock()
+	v, ok := s.values[key]
+	s.mu.RUnlock()
+	if ok {
+		return v
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok = s.values[key]; ok {
+		return v
+	}
+	v = new(T)
+	s.values[key] = v
+	s.labelValues[key] = slices.Clone(values)
+	return v
+}
+
+// each calls fn for the series sorted by their label values.
+func (s *series[T]) each(fn func(labels []string, v *T)) {
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	values := make([]*T, len(keys))
+	labels := make([][]string, len(keys))
+	for i, key := range keys {
+		values[i], labels[i] = s.values[key], s.labelValues[key]
+	}
+	s.mu.RUnlock()
+	for i := range keys {
+		fn(labels[i], values[i])
+	}
+}
+
+// Counter only goes up.
+type Counter struct{ v atomic.Uint64 }
+
+func (c *Counter) Inc()          { c.v.Add(1) }
+func (c *Counter) Add(n uint64)  { c.v.Add(n) }
+func (c *Counter) Value() uint64 { return c.v.Load() }
+
+type CounterVec struct{ *series[Counter] }
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) CounterVec {
+	v := CounterVec{newSeries[Counter](family{name: name, help: help, kind: "counter", labels: labels})}
+	r.register(v)
+	return v
+}
+
+// With returns the counter of the label values, in the order of the labels.
+func (v CounterVec) With(values ...string) *Counter {
+	return v.with(values)
+}
+
+func (v CounterVec) write(w *bufio.Writer) {
+	v.header(w)
+	v.each(func(labels []string, c *Counter) {
+		sample(w, v.name, v.family.labels, labels, "", "", strconv.FormatUint(c.Value(), 10))
+	})
+}
+
+// Gauge goes up and down.
+type Gauge struct{ v atomic.Int64 }
+
+func (g *Gauge) Inc()         { g.v.Add(1) }
+func (g *Gauge) Dec()         { g.v.Add(-1) }
+func (g *Gauge) Set(n int64)  { g.v.Store(n) }
+func (g *Gauge) Value() int64 { return g.v.Load() }
+
+type GaugeVec struct{ *series[Gauge] }
+
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) GaugeVec {
+	v := GaugeVec{newSeries[Gauge](family{name: name, help: help, kind: "gauge", labels: labels})}
+	r.register(v)
+	return v
+}
+
+// With returns the gauge of the label values, in the order of the labels.
+func (v GaugeVec) With(values ...string) *Gauge {
+	return v.with(values)
+}
+
+func (v GaugeVec) write(w *bufio.Writer) {
+	v.header(w)
+	v.each(func(labels []string, g *Gauge) {
+		sample(w, v.name, v.family.labels, labels, "", "", strconv.FormatInt(g.Value(), 10))
+	})
+}
+
+// GaugeFunc is read when scraped, it is left out while read returns false.
+type GaugeFunc struct {
+	family
+	read func() (float64, bool)
+}
+
+// NewGaugeFunc registers a gauge read by fn on every scrape.
+func (r *Registry) NewGaugeFunc(name, help string, fn func() (float64, bool)) GaugeFunc {
+	g := GaugeFunc{family: family{name: name, help: help, kind: "gauge"}, read: fn}
+	r.register(g)
+	return g
+}
+
+func (g GaugeFunc) write(w *bufio.Writer) {
+	value, ok := g.read()
+	if !ok {
+		return
+	}
+	g.header(w)
+	sample(w, g.name, nil, nil, "", "", formatFloat(value))
+}
+
+// Histogram counts observations into cumulative buckets.
+type Histogram struct {
+	mu      sync.Mutex
+	bounds  []float64
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+func (h *Histogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+type HistogramVec struct {
+	*series[Histogram]
+	bounds []float64
+}
+
+// NewHistogramVec registers a histogram with the upper bounds of its
+// buckets, ascending. The +Inf bucket is implied.
+func (r *Registry) NewHistogramVec(name, help string, bounds []float64, labels ...string) HistogramVec {
+	v := HistogramVec{newSeries[Histogram](family{name: name, help: help, kind: "histogram", labels: labels}), bounds}
+	r.register(v)
+	return v
+}
+
+// With returns the histogram of the label values, in the order of the labels.
+func (v HistogramVec) With(values ...string) *Histogram {
+	h := v.with(values)
+	h.mu.Lock()
+	if h.bounds == nil {
+		h.bounds, h.buckets = v.bounds, make([]uint64, len(v.bounds))
+	}
+	h.mu.Unlock()
+	return h
+}
+
+func (v HistogramVec) write(w *bufio.Writer) {
+	v.header(w)
+	v.each(func(labels []string, h *Histogram) {
+		h.mu.Lock()
+		buckets, count, sum := slices.Clone(h.buckets), h.count, h.sum
+		h.mu.Unlock()
+		for i, bound := range v.bounds {
+			if i < len(buckets) {
+				sample(w, v.name+"_bucket", v.family.labels, labels, "le", formatFloat(bound), strconv.FormatUint(buckets[i], 10))
+			}
+		}
+		sample(w, v.name+"_bucket", v.family.labels, labels, "le", "+Inf", strconv.FormatUint(count, 10))
+		sample(w, v.name+"_sum", v.family.labels, labels, "", "", formatFloat(sum))
+		sample(w, v.name+"_count", v.family.labels, labels, "", "", strconv.FormatUint(count, 10))
+	})
+}
+
+// sample writes one line, name{labels,extra="value"} value.
+func sample(w *bufio.Writer, name string, names, values []string, extraName, extraValue, value string) {
+	w.WriteString(name)
+	if len(names) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, label := range names {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label + `="` + escapeLabel(values[i]) + `"`)
+		}
+		if extraName != "" {
+			if len(names) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteString(" " + value + "\n")
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,62 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_runtimemetrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	r := NewRegistry()
+	sessions := r.NewGaugeVec("sessions", "Connected sessions.", "source")
+	drops := r.NewCounterVec("drops_total", "Dropped \"messages\".\nPer direction.", "direction")
+	latency := r.NewHistogramVec("latency_seconds", "Latency.", []float64{0.5, 1}, "stage")
+	r.NewGaugeFunc("ports", "Ports in use.", func() (float64, bool) { return 12, true })
+	r.NewGaugeFunc("unknown", "Not readable.", func() (float64, bool) { return 0, false })
+
+	sessions.With("sdk").Inc()
+	sessions.With("phone-call").Inc()
+	sessions.With("phone-call").Inc()
+	sessions.With("sdk").Dec()
+	drops.With(`in"put`).Add(3)
+	latency.With("turn").Observe(0.25)
+	latency.With("turn").Observe(0.75)
+	latency.With("turn").Observe(4)
+
+	var out strings.Builder
+	n, err := r.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Equal(t, int64(out.Len()), n)
+	assert.Equal(t, `# HELP sessions Connected sessions.
+# TYPE sessions gauge
+sessions{source="phone-call"} 2
+sessions{source="sdk"} 0
+# HELP drops_total Dropped "messages".\nPer direction.
+# TYPE drops_total counter
+drops_total{direction="in\"put"} 3
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{stage="turn",le="0.5"} 1
+latency_seconds_bucket{stage="turn",le="1"} 2
+latency_seconds_bucket{stage="turn",le="+Inf"} 3
+latency_seconds_sum{stage="turn"} 5
+latency_seconds_count{stage="turn"} 3
+# HELP ports Ports in use.
+# TYPE ports gauge
+ports 12
+`, out.String())
+}
+
+func TestRegistry_LabelCount(t *testing.T) {
+	counter := NewRegistry().NewCounterVec("c", "C.", "a", "b")
+	assert.Panics(t, func() { counter.With("only-a") })
+	assert.Same(t, counter.With("x", "y"), counter.With("x", "y"))
+}
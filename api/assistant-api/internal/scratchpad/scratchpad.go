@@ -0,0 +1,96 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_scratchpad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// Persister stores the full scratchpad after every change.
+type Persister func(ctx context.Context, values map[string]interface{}) error
+
+type scratchpad struct {
+	mu      sync.RWMutex
+	values  map[string]interface{}
+	persist Persister
+}
+
+// NewScratchpad returns a scratchpad seeded with values. persist may be nil
+// for conversations without a call context, the scratchpad then only lives
+// in memory.
+func NewScratchpad(values map[string]interface{}, persist Persister) internal_type.Scratchpad {
+	copied := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		copied[k] = v
+	}
+	return &scratchpad{values: copied, persist: persist}
+}
+
+func (s *scratchpad) Get(key string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+func (s *scratchpad) Set(ctx context.Context, key string, value interface{}) error {
+	if key == "" {
+		return fmt.Errorf("scratchpad key is empty")
+	}
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Errorf("scratchpad value of %s is not serializable: %w", key, err)
+	}
+	s.mu.Lock()
+	s.values[key] = value
+	s.mu.Unlock()
+	return s.save(ctx)
+}
+
+func (s *scratchpad) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	_, ok := s.values[key]
+	delete(s.values, key)
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return s.save(ctx)
+}
+
+func (s *scratchpad) All() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]interface{}, len(s.values))
+	for k, v := range s.values {
+		all[k] = v
+	}
+	return all
+}
+
+// String serializes the entries as JSON with sorted keys.
+func (s *scratchpad) String() string {
+	all := s.All()
+	if len(all) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(all)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func (s *scratchpad) save(ctx context.Context) error {
+	if s.persist == nil {
+		return nil
+	}
+	return s.persist(ctx, s.All())
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_scratchpad
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScratchpad_SetGetDelete(t *testing.T) {
+	s := NewScratchpad(map[string]interface{}{"order_id": "A-1"}, nil)
+	v, ok := s.Get("order_id")
+	assert.True(t, ok)
+	assert.Equal(t, "A-1", v)
+
+	require.NoError(t, s.Set(context.Background(), "verified", true))
+	require.NoError(t, s.Delete(context.Background(), "order_id"))
+	assert.Equal(t, map[string]interface{}{"verified": true}, s.All())
+}
+
+func TestScratchpad_Persists(t *testing.T) {
+	var saved []map[string]interface{}
+	s := NewScratchpad(nil, func(ctx context.Context, values map[string]interface{}) error {
+		saved = append(saved, values)
+		return nil
+	})
+
+	require.NoError(t, s.Set(context.Background(), "step", "billing"))
+	require.NoError(t, s.Delete(context.Background(), "missing"))
+	require.NoError(t, s.Delete(context.Background(), "step"))
+	assert.Equal(t, []map[string]interface{}{{"step": "billing"}, {}}, saved)
+}
+
+func TestScratchpad_PersistError(t *testing.T) {
+	s := NewScratchpad(nil, func(ctx context.Context, values map[string]interface{}) error {
+		return errors.New("db down")
+	})
+	assert.Error(t, s.Set(context.Background(), "step", "billing"))
+	// the value is still usable for the rest of the conversation
+	v, _ := s.Get("step")
+	assert.Equal(t, "billing", v)
+}
+
+func TestScratchpad_Validation(t *testing.T) {
+	s := NewScratchpad(nil, nil)
+	assert.Error(t, s.Set(context.Background(), "", 1))
+	assert.Error(t, s.Set(context.Background(), "fn", func() {}))
+}
+
+func TestScratchpad_String(t *testing.T) {
+	s := NewScratchpad(nil, nil)
+	assert.Empty(t, s.String())
+
+	s = NewScratchpad(map[string]interface{}{"b": 2, "a": "x"}, nil)
+	assert.Equal(t, `{"a":"x","b":2}`, s.String())
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_scratchpad
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/rapidaai/pkg/ciphers"
+)
+
+// sealedKey holds the scratchpad of an encrypted conversation, the entries
+// are sealed together so not even their keys are stored in plaintext.
+const sealedKey = "_sealed"
+
+// Seal returns the form an encrypted conversation stores its scratchpad in.
+func Seal(values map[string]interface{}, data *ciphers.DataCipher, aad []byte) (map[string]interface{}, error) {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := data.SealString(string(plaintext), aad)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{sealedKey: sealed}, nil
+}
+
+// Open returns the entries of a stored scratchpad. Scratchpads that were not
+// sealed are returned as they are.
+func Open(stored map[string]interface{}, data *ciphers.DataCipher, aad []byte) (map[string]interface{}, error) {
+	sealed, ok := stored[sealedKey].(string)
+	if !ok || len(stored) != 1 {
+		return stored, nil
+	}
+	if data == nil {
+		return nil, errors.New("scratchpad is sealed but no key was given")
+	}
+	plaintext, err := data.OpenString(sealed, aad)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(plaintext), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_scratchpad
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rapidaai/pkg/ciphers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	data, err := ciphers.NewDataCipher(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	values := map[string]interface{}{"account_number": "12345678", "verified": true}
+
+	stored, err := Seal(values, data, []byte("ctx-1"))
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.NotContains(t, stored[sealedKey], "12345678")
+
+	opened, err := Open(stored, data, []byte("ctx-1"))
+	require.NoError(t, err)
+	assert.Equal(t, values, opened)
+
+	_, err = Open(stored, data, []byte("ctx-2"))
+	assert.Error(t, err)
+	_, err = Open(stored, nil, []byte("ctx-1"))
+	assert.Error(t, err)
+
+	plain := map[string]interface{}{"order_id": "A-17"}
+	opened, err = Open(plain, data, []byte("ctx-1"))
+	require.NoError(t, err)
+	assert.Equal(t, plain, opened, "scratchpads written before encryption are read as they are")
+}
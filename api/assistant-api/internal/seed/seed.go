@@ -0,0 +1,59 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_seed
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// OptionKey is the conversation option that starts a conversation in seeded
+// mode. Golden-file and CI tests of the talk loop set it so message ids and
+// model sampling repeat from run to run.
+const OptionKey = "rapida.seed"
+
+// ModelSeedKey is the model option providers that support seeded sampling
+// read their seed from.
+const ModelSeedKey = "model.seed"
+
+// FromOptions returns the seed of a conversation started in seeded mode.
+func FromOptions(opts utils.Option) (int64, bool) {
+	seed, err := opts.GetUint64(OptionKey)
+	if err != nil {
+		return 0, false
+	}
+	return int64(seed), true
+}
+
+// NewIDGenerator returns a generator of version 4 UUIDs drawn from a source
+// seeded with seed, the same seed always yields the same sequence. It is safe
+// for concurrent use.
+func NewIDGenerator(seed int64) func() string {
+	var mu sync.Mutex
+	source := rand.New(rand.NewSource(seed))
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		id, err := uuid.NewRandomFromReader(source)
+		if err != nil {
+			// math/rand never fails to read
+			return uuid.NewString()
+		}
+		return id.String()
+	}
+}
+
+// ModelOptions adds the seed to options unless the model is already given
+// one explicitly. options is modified in place and returned.
+func ModelOptions(options map[string]interface{}, seed int64) map[string]interface{} {
+	if _, ok := options[ModelSeedKey]; !ok {
+		options[ModelSeedKey] = seed
+	}
+	return options
+}
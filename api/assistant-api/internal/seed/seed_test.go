@@ -0,0 +1,43 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_seed
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIDGenerator_RepeatsForSameSeed(t *testing.T) {
+	a, b := NewIDGenerator(42), NewIDGenerator(42)
+	first := a()
+	assert.Equal(t, first, b())
+	assert.Equal(t, a(), b())
+	assert.NotEqual(t, first, NewIDGenerator(7)())
+
+	id, err := uuid.Parse(first)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), id.Version())
+}
+
+func TestFromOptions(t *testing.T) {
+	seed, ok := FromOptions(utils.Option{OptionKey: "1234"})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234), seed)
+
+	_, ok = FromOptions(utils.Option{})
+	assert.False(t, ok)
+	_, ok = FromOptions(utils.Option{OptionKey: "abc"})
+	assert.False(t, ok)
+}
+
+func TestModelOptions(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{ModelSeedKey: int64(3)}, ModelOptions(map[string]interface{}{}, 3))
+	assert.Equal(t, map[string]interface{}{ModelSeedKey: "9"}, ModelOptions(map[string]interface{}{ModelSeedKey: "9"}, 3))
+}
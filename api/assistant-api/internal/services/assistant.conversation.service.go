@@ -10,6 +10,7 @@ import (
 
 	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
 	internal_message_gorm "github.com/rapidaai/api/assistant-api/internal/entity/messages"
+	"github.com/rapidaai/pkg/ciphers"
 	"github.com/rapidaai/pkg/types"
 	type_enums "github.com/rapidaai/pkg/types/enums"
 	"github.com/rapidaai/pkg/utils"
@@ -28,6 +29,22 @@ type GetConversationOption struct {
 	InjectTelephonyEvent bool
 }
 
+// TelemetryRecord is a single metric, telephony event or event log entry of a
+// conversation waiting in the telemetry writer. Records of many conversations are written
+// together by ApplyTelemetry.
+type TelemetryRecord struct {
+	AssistantId             uint64
+	AssistantConversationId uint64
+	UserId                  *uint64
+
+	// one of
+	Metric    *types.Metric // conversation metric, or message metric with MessageId
+	MessageId string
+	Event     *types.Event // telephony event of Provider
+	Provider  string
+	LogEvent  *internal_conversation_entity.AssistantConversationEvent
+}
+
 func NewDefaultGetConversationOption() *GetConversationOption {
 	return &GetConversationOption{
 		InjectContext:        true,
@@ -131,6 +148,28 @@ func (opt *GetMessageOption) WithInjectMetadata(ij bool) *GetMessageOption {
 	return opt
 }
 
+// ConversationAnalytics is one group of the conversations aggregated by
+// AssistantConversationService.Analytics, durations are in seconds.
+type ConversationAnalytics struct {
+	Group           string  `json:"group"`
+	Conversations   uint64  `json:"conversations"`
+	TotalDuration   float64 `json:"totalDuration"`
+	AverageDuration float64 `json:"averageDuration"`
+}
+
+// RecognitionQuality is how well speech to text heard the callers of an
+// assistant in one language, aggregated by
+// AssistantConversationService.GetRecognitionQuality.
+type RecognitionQuality struct {
+	AssistantId           uint64  `json:"assistantId"`
+	Provider              string  `json:"provider"`
+	Language              string  `json:"language"`
+	Segments              uint64  `json:"segments"`
+	Words                 uint64  `json:"words"`
+	AverageConfidence     float64 `json:"averageConfidence"`
+	LowConfidenceSegments uint64  `json:"lowConfidenceSegments"`
+}
+
 type AssistantConversationService interface {
 	//
 	GetAll(ctx context.Context,
@@ -141,6 +180,24 @@ type AssistantConversationService interface {
 		opts *GetConversationOption,
 	) (int64, []*internal_conversation_entity.AssistantConversation, error)
 
+	// Query returns the conversations of the project matching the filter,
+	// across assistants, newest first.
+	Query(ctx context.Context,
+		auth types.SimplePrinciple,
+		filter *workflow_api.ConversationFilter,
+		paginate *workflow_api.Paginate,
+		opts *GetConversationOption,
+	) (int64, []*internal_conversation_entity.AssistantConversation, error)
+
+	// Analytics counts the conversations matching the filter and sums their
+	// duration per day, assistant, disposition, source or direction. An empty
+	// groupBy aggregates all of them into one group.
+	Analytics(ctx context.Context,
+		auth types.SimplePrinciple,
+		filter *workflow_api.ConversationFilter,
+		groupBy string,
+	) ([]*ConversationAnalytics, error)
+
 	// later you will ask why two let me tell you one for end user
 	// comming from request adapter
 	// anotehr is CRM
@@ -301,6 +358,16 @@ type AssistantConversationService interface {
 		user, system []byte,
 	) (*internal_conversation_entity.AssistantConversationRecording, error)
 
+	// CreateConversationStereoRecording stores a recording made as one
+	// dual-channel file, the caller left and the assistant right.
+	CreateConversationStereoRecording(
+		ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+		assistantConversationId uint64,
+		stereo []byte,
+	) (*internal_conversation_entity.AssistantConversationRecording, error)
+
 	ApplyConversationTelephonyEvent(
 		ctx context.Context,
 		auth types.SimplePrinciple,
@@ -309,4 +376,56 @@ type AssistantConversationService interface {
 		assistantConversationId uint64,
 		events []*types.Event,
 	) ([]*internal_conversation_entity.AssistantConversationTelephonyEvent, error)
+
+	// GetConversationCipher returns the data key of an encrypted
+	// conversation, for state kept outside the conversation service such as
+	// the scratchpad. It returns nil when the conversation is not encrypted.
+	GetConversationCipher(ctx context.Context, assistantConversationId uint64) (*ciphers.DataCipher, error)
+
+	// RewrapConversationKeys wraps up to limit data keys that are not under
+	// the active master key with it and returns how many it rewrapped.
+	RewrapConversationKeys(ctx context.Context, limit int) (int, error)
+
+	// ApplyTelemetry writes the metrics, telephony events and event log
+	// entries of many conversations in one transaction. Later records of the
+	// same metric replace earlier ones, as ApplyConversationMetrics does.
+	ApplyTelemetry(ctx context.Context, records []*TelemetryRecord) error
+
+	// ApplyConversationEvents appends entries to the event log of a
+	// conversation, entries already logged are skipped.
+	ApplyConversationEvents(ctx context.Context,
+		events []*internal_conversation_entity.AssistantConversationEvent,
+	) error
+
+	// GetLastConversationEventSequence returns the sequence of the latest
+	// entry of the event log of a conversation, 0 when it has none.
+	GetLastConversationEventSequence(ctx context.Context, assistantConversationId uint64) (uint64, error)
+
+	// GetAllConversationEvent returns up to limit entries of the event log of
+	// a conversation of the caller's project that follow afterSequence, in
+	// order, optionally only those of eventTypes. Sealed payloads are opened.
+	// A limit of 0 reads 1000 entries.
+	GetAllConversationEvent(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+		assistantConversationId uint64,
+		afterSequence uint64,
+		eventTypes []string,
+		limit int,
+	) ([]*internal_conversation_entity.AssistantConversationEvent, error)
+
+	// CreateTranscriptSegments stores the language and confidence of the
+	// transcript segments of a turn.
+	CreateTranscriptSegments(ctx context.Context,
+		segments []*internal_conversation_entity.AssistantTranscriptSegment,
+	) error
+
+	// GetRecognitionQuality aggregates the transcript segments of the
+	// conversations matching the filter per assistant, speech to text
+	// provider and language. Segments below lowConfidence are counted apart.
+	GetRecognitionQuality(ctx context.Context,
+		auth types.SimplePrinciple,
+		filter *workflow_api.ConversationFilter,
+		lowConfidence float64,
+	) ([]*RecognitionQuality, error)
 }
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/rapidaai/api/assistant-api/config"
 	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	"github.com/rapidaai/pkg/commons"
@@ -22,6 +23,7 @@ import (
 	type_enums "github.com/rapidaai/pkg/types/enums"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -29,9 +31,11 @@ type assistantConversationService struct {
 	logger   commons.Logger
 	postgres connectors.PostgresConnector
 	storage  storages.Storage
+	keys     *conversationKeys
 }
 
 func NewAssistantConversationService(
+	cfg *config.AssistantConfig,
 	logger commons.Logger,
 	postgres connectors.PostgresConnector,
 	storage storages.Storage) internal_services.AssistantConversationService {
@@ -39,6 +43,7 @@ func NewAssistantConversationService(
 		logger:   logger,
 		postgres: postgres,
 		storage:  storage,
+		keys:     newConversationKeys(cfg, logger),
 	}
 }
 
@@ -161,21 +166,23 @@ func (conversationService *assistantConversationService) Get(
 					return
 				}
 
+				data, err := conversationService.keys.dataCipher(db, assistantConversationId)
+				if err != nil {
+					conversationService.logger.Warnf("unable to get the key of conversation %d %+v", assistantConversationId, err)
+					return
+				}
+
 				assistantConversation.Recordings = make([]*internal_conversation_entity.AssistantConversationRecording, 0)
-				// updating all to public url
+				// updating all to public url, archived recordings are restored
+				// and left out until they can be played
 				for _, recording := range assistantConversationRecording {
-					assistantUrl, err := conversationService.GetRecordingPublicUrl(ctx, recording.AssistantRecordingUrl)
-					if err != nil {
-						conversationService.logger.Warnf("unable to get assistant public url %+v", err)
+					if err := resolveRecording(ctx, conversationService.storage, data, recording); err != nil {
+						conversationService.logger.Warnf("unable to get recording public url %+v", err)
 						continue
 					}
-					userUrl, err := conversationService.GetRecordingPublicUrl(ctx, recording.UserRecordingUrl)
-					if err != nil {
-						conversationService.logger.Warnf("unable to get user public url %+v", err)
+					if recording.Restoring {
 						continue
 					}
-					recording.AssistantRecordingUrl = *assistantUrl
-					recording.UserRecordingUrl = *userUrl
 					assistantConversation.Recordings = append(assistantConversation.Recordings, recording)
 				}
 			})
@@ -254,11 +261,17 @@ func (conversationService *assistantConversationService) CreateConversation(
 	if auth.GetUserId() != nil {
 		conversation.Mutable.CreatedBy = *auth.GetUserId()
 	}
-	tx := db.Create(&conversation)
-	if tx.Error != nil {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&conversation).Error; err != nil {
+			return err
+		}
+		_, err := conversationService.keys.create(tx, conversation)
+		return err
+	})
+	if err != nil {
 		conversationService.logger.Benchmark("conversationService.CreateConversation", time.Since(start))
-		conversationService.logger.Errorf("error while creating conversation %v", tx.Error)
-		return nil, tx.Error
+		conversationService.logger.Errorf("error while creating conversation %v", err)
+		return nil, err
 	}
 	conversationService.logger.Benchmark("conversationService.CreateConversation", time.Since(start))
 	return conversation, nil
@@ -538,16 +551,67 @@ func (conversationService *assistantConversationService) CreateConversationRecor
 	user, assistant []byte,
 ) (*internal_conversation_entity.AssistantConversationRecording, error) {
 	start := time.Now()
+	recording, err := conversationService.createConversationRecording(ctx, auth, assistantId, assistantConversationId, 1,
+		recordingObject{name: "user-%d.wav", audio: user},
+		recordingObject{name: "assistant-%d.wav", audio: assistant})
+	conversationService.logger.Benchmark("conversationService.CreateConversationRecording", time.Since(start))
+	return recording, err
+}
+
+func (conversationService *assistantConversationService) CreateConversationStereoRecording(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId,
+	assistantConversationId uint64,
+	stereo []byte,
+) (*internal_conversation_entity.AssistantConversationRecording, error) {
+	start := time.Now()
+	recording, err := conversationService.createConversationRecording(ctx, auth, assistantId, assistantConversationId, 2,
+		recordingObject{name: "recording-%d.wav", audio: stereo})
+	conversationService.logger.Benchmark("conversationService.CreateConversationStereoRecording", time.Since(start))
+	return recording, err
+}
+
+// recordingObject is an audio file of a recording, name is formatted with
+// the id of the recording.
+type recordingObject struct {
+	name  string
+	audio []byte
+}
+
+// createConversationRecording stores the audio files of a recording, sealed
+// when the conversation has a data key, and creates its record. The first
+// file is the user's and the last the assistant's, a single file is both.
+func (conversationService *assistantConversationService) createConversationRecording(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId,
+	assistantConversationId uint64,
+	channels uint32,
+	objects ...recordingObject,
+) (*internal_conversation_entity.AssistantConversationRecording, error) {
 	db := conversationService.postgres.DB(ctx)
 
 	s3Prefix := conversationService.ObjectPrefix(*auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId())
 	recordingId := gorm_generator.ID()
 
-	userKey := conversationService.ObjectKey(s3Prefix, assistantConversationId, fmt.Sprintf("user-%d.wav", recordingId))
-	conversationService.storage.Store(ctx, userKey, user)
+	data, err := conversationService.keys.dataCipher(db, assistantConversationId)
+	if err != nil {
+		conversationService.logger.Errorf("error while getting the key of conversation %v", err)
+		return nil, err
+	}
 
-	assistantKey := conversationService.ObjectKey(s3Prefix, assistantConversationId, fmt.Sprintf("assistant-%d.wav", recordingId))
-	conversationService.storage.Store(ctx, assistantKey, assistant)
+	keys := make([]string, len(objects))
+	for i, object := range objects {
+		keys[i] = conversationService.ObjectKey(s3Prefix, assistantConversationId, fmt.Sprintf(object.name, recordingId))
+		audio := object.audio
+		if data != nil {
+			if audio, err = data.Seal(audio, []byte(keys[i])); err != nil {
+				return nil, err
+			}
+		}
+		conversationService.storage.Store(ctx, keys[i], audio)
+	}
 
 	conversationRecording := &internal_conversation_entity.AssistantConversationRecording{
 		Audited: gorm_models.Audited{
@@ -559,19 +623,19 @@ func (conversationService *assistantConversationService) CreateConversationRecor
 		},
 		AssistantId:             assistantId,
 		AssistantConversationId: assistantConversationId,
-		AssistantRecordingUrl:   assistantKey,
-		UserRecordingUrl:        userKey,
+		AssistantRecordingUrl:   keys[len(keys)-1],
+		UserRecordingUrl:        keys[0],
+		Encrypted:               data != nil,
+		Channels:                channels,
 	}
 	if auth.GetUserId() != nil {
 		conversationRecording.Mutable.CreatedBy = *auth.GetUserId()
 	}
 	tx := db.Create(&conversationRecording)
 	if tx.Error != nil {
-		conversationService.logger.Benchmark("conversationService.CreateConversationRecording", time.Since(start))
 		conversationService.logger.Errorf("error while creating conversation recording %v", tx.Error)
 		return nil, tx.Error
 	}
-	conversationService.logger.Benchmark("conversationService.CreateConversationRecording", time.Since(start))
 	return conversationRecording, nil
 }
 
@@ -0,0 +1,188 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_message_gorm "github.com/rapidaai/api/assistant-api/internal/entity/messages"
+	"github.com/rapidaai/pkg/ciphers"
+	"github.com/rapidaai/pkg/commons"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"gorm.io/gorm"
+)
+
+// conversationKeys creates and unwraps the data keys of encrypted
+// conversations. Without conversation encryption configured no keys are
+// created, and conversations that already have one cannot be read.
+type conversationKeys struct {
+	encryption *config.ConversationEncryptionConfig
+	keyring    *ciphers.Keyring
+}
+
+func newConversationKeys(cfg *config.AssistantConfig, logger commons.Logger) *conversationKeys {
+	if cfg == nil || cfg.ConversationEncryption == nil {
+		return &conversationKeys{}
+	}
+	keyring, err := cfg.ConversationEncryption.Keyring()
+	if err != nil {
+		logger.Errorf("conversation encryption is disabled, the master keys are invalid: %v", err)
+		return &conversationKeys{}
+	}
+	return &conversationKeys{encryption: cfg.ConversationEncryption, keyring: keyring}
+}
+
+// conversationKeyAAD binds a wrapped key to its conversation so it cannot be
+// moved to another one.
+func conversationKeyAAD(assistantConversationId uint64) []byte {
+	return []byte(strconv.FormatUint(assistantConversationId, 10))
+}
+
+// create stores a data key for the conversation when its project is
+// encrypted. It returns nil for conversations that are kept in plaintext.
+func (keys *conversationKeys) create(db *gorm.DB, conversation *internal_conversation_entity.AssistantConversation) (*ciphers.DataCipher, error) {
+	if keys.keyring == nil || !keys.encryption.Encrypts(conversation.ProjectId) {
+		return nil, nil
+	}
+	data, wrapped, err := keys.keyring.GenerateDataKey(conversationKeyAAD(conversation.Id))
+	if err != nil {
+		return nil, err
+	}
+	key := &internal_conversation_entity.AssistantConversationKey{
+		Organizational:          conversation.Organizational,
+		Mutable:                 gorm_models.Mutable{CreatedBy: conversation.CreatedBy},
+		AssistantConversationId: conversation.Id,
+		Algorithm:               ciphers.EnvelopeAlgorithm,
+		MasterKeyId:             wrapped.KeyID,
+		WrappedKey:              wrapped.Key,
+	}
+	if tx := db.Create(key); tx.Error != nil {
+		return nil, tx.Error
+	}
+	return data, nil
+}
+
+// dataCiphers returns the data keys of the conversations that are encrypted,
+// conversations kept in plaintext are left out.
+func (keys *conversationKeys) dataCiphers(db *gorm.DB, assistantConversationIds ...uint64) (map[uint64]*ciphers.DataCipher, error) {
+	out := make(map[uint64]*ciphers.DataCipher)
+	if len(assistantConversationIds) == 0 {
+		return out, nil
+	}
+	var stored []*internal_conversation_entity.AssistantConversationKey
+	if tx := db.Where("assistant_conversation_id IN ?", assistantConversationIds).Find(&stored); tx.Error != nil {
+		return nil, tx.Error
+	}
+	if len(stored) > 0 && keys.keyring == nil {
+		return nil, errors.New("conversation is encrypted but no master keys are configured")
+	}
+	for _, key := range stored {
+		data, err := keys.keyring.Unwrap(&ciphers.WrappedKey{KeyID: key.MasterKeyId, Key: key.WrappedKey}, conversationKeyAAD(key.AssistantConversationId))
+		if err != nil {
+			return nil, fmt.Errorf("unable to unwrap the key of conversation %d: %w", key.AssistantConversationId, err)
+		}
+		out[key.AssistantConversationId] = data
+	}
+	return out, nil
+}
+
+func (keys *conversationKeys) dataCipher(db *gorm.DB, assistantConversationId uint64) (*ciphers.DataCipher, error) {
+	found, err := keys.dataCiphers(db, assistantConversationId)
+	if err != nil {
+		return nil, err
+	}
+	return found[assistantConversationId], nil
+}
+
+// rewrap wraps up to limit data keys that are not under the active master
+// key with it.
+func (keys *conversationKeys) rewrap(db *gorm.DB, limit int, now time.Time) (int, error) {
+	if keys.keyring == nil {
+		return 0, nil
+	}
+	var stale []*internal_conversation_entity.AssistantConversationKey
+	if tx := db.Where("master_key_id <> ?", keys.keyring.ActiveKeyID()).Order("id").Limit(limit).Find(&stale); tx.Error != nil {
+		return 0, tx.Error
+	}
+	rewrapped := 0
+	for _, key := range stale {
+		wrapped, err := keys.keyring.Rewrap(&ciphers.WrappedKey{KeyID: key.MasterKeyId, Key: key.WrappedKey}, conversationKeyAAD(key.AssistantConversationId))
+		if err != nil {
+			return rewrapped, fmt.Errorf("unable to rewrap the key of conversation %d: %w", key.AssistantConversationId, err)
+		}
+		tx := db.Model(&internal_conversation_entity.AssistantConversationKey{}).
+			Where("id = ? AND master_key_id = ?", key.Id, key.MasterKeyId).
+			Updates(map[string]interface{}{
+				"master_key_id":  wrapped.KeyID,
+				"wrapped_key":    wrapped.Key,
+				"rewrapped_date": now,
+				"updated_date":   now,
+			})
+		if tx.Error != nil {
+			return rewrapped, tx.Error
+		}
+		rewrapped++
+	}
+	return rewrapped, nil
+}
+
+// sealMessage encrypts the body of a message, bound to its message id.
+func sealMessage(data *ciphers.DataCipher, messageId, body string) (string, error) {
+	if data == nil {
+		return body, nil
+	}
+	return data.SealString(body, []byte(messageId))
+}
+
+// openMessages decrypts the bodies of the messages in place.
+func (keys *conversationKeys) openMessages(db *gorm.DB, messages []*internal_message_gorm.AssistantConversationMessage) error {
+	var sealed []uint64
+	for _, message := range messages {
+		if ciphers.IsSealed(message.Body) {
+			sealed = append(sealed, message.AssistantConversationId)
+		}
+	}
+	if len(sealed) == 0 {
+		return nil
+	}
+	found, err := keys.dataCiphers(db, sealed...)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		data, ok := found[message.AssistantConversationId]
+		if !ok {
+			continue
+		}
+		body, err := data.OpenString(message.Body, []byte(message.MessageId))
+		if err != nil {
+			return fmt.Errorf("unable to decrypt message %s: %w", message.MessageId, err)
+		}
+		message.Body = body
+	}
+	return nil
+}
+
+// GetConversationCipher returns the data key of the conversation, nil when
+// the conversation is not encrypted.
+func (conversationService *assistantConversationService) GetConversationCipher(ctx context.Context, assistantConversationId uint64) (*ciphers.DataCipher, error) {
+	return conversationService.keys.dataCipher(conversationService.postgres.DB(ctx), assistantConversationId)
+}
+
+// RewrapConversationKeys moves up to limit data keys to the active master
+// key, across projects.
+func (conversationService *assistantConversationService) RewrapConversationKeys(ctx context.Context, limit int) (int, error) {
+	start := time.Now()
+	rewrapped, err := conversationService.keys.rewrap(conversationService.postgres.DB(ctx), limit, start)
+	conversationService.logger.Benchmark("conversationService.RewrapConversationKeys", time.Since(start))
+	return rewrapped, err
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"time"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_eventlog "github.com/rapidaai/api/assistant-api/internal/eventlog"
+	internal_scratchpad "github.com/rapidaai/api/assistant-api/internal/scratchpad"
+	"github.com/rapidaai/pkg/types"
+	"gorm.io/gorm/clause"
+)
+
+// defaultConversationEvents is how many entries of the event log are read
+// when no limit is given.
+const defaultConversationEvents = 1000
+
+func (conversationService *assistantConversationService) ApplyConversationEvents(ctx context.Context,
+	events []*internal_conversation_entity.AssistantConversationEvent,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+	start := time.Now()
+	tx := conversationService.postgres.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&events)
+	conversationService.logger.Benchmark("conversationService.ApplyConversationEvents", time.Since(start))
+	if tx.Error != nil {
+		conversationService.logger.Errorf("error while appending %d conversation events %v", len(events), tx.Error)
+		return tx.Error
+	}
+	return nil
+}
+
+func (conversationService *assistantConversationService) GetLastConversationEventSequence(ctx context.Context, assistantConversationId uint64) (uint64, error) {
+	start := time.Now()
+	var last uint64
+	tx := conversationService.postgres.DB(ctx).
+		Model(&internal_conversation_entity.AssistantConversationEvent{}).
+		Where("assistant_conversation_id = ?", assistantConversationId).
+		Select("COALESCE(MAX(sequence), 0)").
+		Scan(&last)
+	conversationService.logger.Benchmark("conversationService.GetLastConversationEventSequence", time.Since(start))
+	if tx.Error != nil {
+		conversationService.logger.Errorf("error while getting the last event of conversation %d %v", assistantConversationId, tx.Error)
+		return 0, tx.Error
+	}
+	return last, nil
+}
+
+func (conversationService *assistantConversationService) GetAllConversationEvent(ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+	afterSequence uint64,
+	eventTypes []string,
+	limit int,
+) ([]*internal_conversation_entity.AssistantConversationEvent, error) {
+	start := time.Now()
+	db := conversationService.postgres.DB(ctx)
+	conversation := &internal_conversation_entity.AssistantConversation{}
+	if tx := db.
+		Where("id = ? AND assistant_id = ? AND project_id = ? AND organization_id = ?",
+			assistantConversationId,
+			assistantId,
+			*auth.GetCurrentProjectId(),
+			*auth.GetCurrentOrganizationId()).
+		First(conversation); tx.Error != nil {
+		conversationService.logger.Errorf("not able to find the conversation to replay %v", tx.Error)
+		return nil, tx.Error
+	}
+	if limit <= 0 {
+		limit = defaultConversationEvents
+	}
+
+	var events []*internal_conversation_entity.AssistantConversationEvent
+	qry := db.Where("assistant_conversation_id = ? AND sequence > ?", conversation.Id, afterSequence)
+	if len(eventTypes) > 0 {
+		qry = qry.Where("event_type IN ?", eventTypes)
+	}
+	if tx := qry.Order("sequence ASC").Limit(limit).Find(&events); tx.Error != nil {
+		conversationService.logger.Errorf("not able to get the events of conversation %d %v", conversation.Id, tx.Error)
+		return nil, tx.Error
+	}
+
+	data, err := conversationService.keys.dataCipher(db, conversation.Id)
+	if err != nil {
+		conversationService.logger.Errorf("not able to get the key of conversation %d %v", conversation.Id, err)
+		return nil, err
+	}
+	for _, event := range events {
+		payload, err := internal_scratchpad.Open(event.Payload, data, internal_eventlog.AAD(conversation.Id, event.Sequence))
+		if err != nil {
+			conversationService.logger.Errorf("not able to open event %d of conversation %d %v", event.Sequence, conversation.Id, err)
+			return nil, err
+		}
+		event.Payload = payload
+	}
+	conversationService.logger.Benchmark("conversationService.GetAllConversationEvent", time.Since(start))
+	return events, nil
+}
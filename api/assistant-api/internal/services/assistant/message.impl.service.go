@@ -68,6 +68,11 @@ func (conversationService *assistantConversationService) GetAllConversationMessa
 		conversationService.logger.Errorf("Unable to get all conversation message with error %v", tx.Error)
 		return cnt, nil, tx.Error
 	}
+	if err := conversationService.keys.openMessages(db, conversationMessage); err != nil {
+		conversationService.logger.Benchmark("conversationService.GetAllConversationMessage", time.Since(start))
+		conversationService.logger.Errorf("unable to decrypt messages with error %v", err)
+		return cnt, nil, err
+	}
 	conversationService.logger.Benchmark("conversationService.GetAllConversationMessage", time.Since(start))
 	return cnt, conversationMessage, nil
 }
@@ -156,6 +161,11 @@ func (conversationService *assistantConversationService) GetAllAssistantMessage(
 		conversationService.logger.Errorf("not able to find any conversations for assistant %v", tx.Error)
 		return cnt, nil, tx.Error
 	}
+	if err := conversationService.keys.openMessages(db, conversationMessage); err != nil {
+		conversationService.logger.Benchmark("conversationService.GetAllAssistantMessage", time.Since(start))
+		conversationService.logger.Errorf("unable to decrypt messages with error %v", err)
+		return cnt, nil, err
+	}
 	conversationService.logger.Benchmark("conversationService.GetAllAssistantMessage", time.Since(start))
 	return cnt, conversationMessage, nil
 }
@@ -246,6 +256,11 @@ func (conversationService *assistantConversationService) GetAllMessage(
 		conversationService.logger.Errorf("not able to find any messages for project %v", tx.Error)
 		return cnt, nil, tx.Error
 	}
+	if err := conversationService.keys.openMessages(db, conversationMessage); err != nil {
+		conversationService.logger.Benchmark("conversationService.GetAllMessage", time.Since(start))
+		conversationService.logger.Errorf("unable to decrypt messages with error %v", err)
+		return cnt, nil, err
+	}
 	conversationService.logger.Benchmark("conversationService.GetAllMessage", time.Since(start))
 	return cnt, conversationMessage, nil
 }
@@ -299,6 +314,16 @@ func (conversationService *assistantConversationService) CreateConversationMessa
 ) (*internal_message_gorm.AssistantConversationMessage, error) {
 	start := time.Now()
 	db := conversationService.postgres.DB(ctx)
+	data, err := conversationService.keys.dataCipher(db, assistantConversationId)
+	if err != nil {
+		conversationService.logger.Benchmark("conversationService.CreateConversationMessage", time.Since(start))
+		conversationService.logger.Errorf("error while getting the key of conversation %v", err)
+		return nil, err
+	}
+	body, err := sealMessage(data, messageId, message)
+	if err != nil {
+		return nil, err
+	}
 	conversationMessage := &internal_message_gorm.AssistantConversationMessage{
 		AssistantConversationId:  assistantConversationId,
 		AssistantId:              assistantId,
@@ -306,7 +331,7 @@ func (conversationService *assistantConversationService) CreateConversationMessa
 		MessageId:                messageId,
 		Source:                   source.Get(),
 		Role:                     role,
-		Body:                     message,
+		Body:                     body,
 		Mutable: gorm_models.Mutable{
 			CreatedBy: 99,
 		},
@@ -322,6 +347,7 @@ func (conversationService *assistantConversationService) CreateConversationMessa
 		return nil, tx.Error
 	}
 
+	conversationMessage.Body = message
 	conversationService.logger.Benchmark("conversationService.CreateConversationMessage", time.Since(start))
 	return conversationMessage, nil
 }
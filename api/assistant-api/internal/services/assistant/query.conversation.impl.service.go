@@ -0,0 +1,193 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"github.com/rapidaai/pkg/types"
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/protos"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// disconnectReasonMetadataKey is the conversation metadata the disposition
+	// of a conversation is recorded under.
+	disconnectReasonMetadataKey = "disconnect_reason"
+
+	// defaultQueryPageSize applies when a query does not ask for a page size,
+	// an unbounded query would return every conversation of the project.
+	defaultQueryPageSize = 20
+
+	// conversationDuration is the recorded duration of a conversation in
+	// nanoseconds, of the TIME_TAKEN metric aliased as duration.
+	conversationDuration = "CASE WHEN duration.value ~ '^[0-9]+$' THEN duration.value::bigint END"
+)
+
+// analyticsGroups are the expressions conversations can be grouped by.
+var analyticsGroups = map[string]string{
+	"":            "''",
+	"day":         "to_char(assistant_conversations.created_date, 'YYYY-MM-DD')",
+	"assistant":   "assistant_conversations.assistant_id::text",
+	"disposition": "COALESCE(disposition.value, '')",
+	"source":      "assistant_conversations.source",
+	"direction":   "assistant_conversations.direction",
+}
+
+func (conversationService *assistantConversationService) Query(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	filter *protos.ConversationFilter,
+	paginate *protos.Paginate,
+	opts *internal_services.GetConversationOption,
+) (int64, []*internal_conversation_entity.AssistantConversation, error) {
+	start := time.Now()
+	db := conversationService.postgres.DB(ctx)
+	var (
+		conversations []*internal_conversation_entity.AssistantConversation
+		cnt           int64
+	)
+	qry := conversationService.filterConversations(db.Model(internal_conversation_entity.AssistantConversation{}), auth, filter)
+
+	if opts != nil && opts.InjectMetric {
+		qry = qry.
+			Preload("Metrics")
+	}
+
+	if opts != nil && opts.InjectMetadata {
+		qry = qry.
+			Preload("Metadatas")
+	}
+
+	if opts != nil && opts.InjectArgument {
+		qry = qry.
+			Preload("Arguments")
+	}
+
+	if opts != nil && opts.InjectOption {
+		qry = qry.
+			Preload("Options")
+	}
+
+	if opts != nil && opts.InjectTelephonyEvent {
+		qry = qry.
+			Preload("TelephonyEvents")
+	}
+
+	pageSize := int(paginate.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultQueryPageSize
+	}
+	tx := qry.
+		Scopes(gorm_models.
+			Paginate(gorm_models.
+				NewPaginated(
+					int(paginate.GetPage()),
+					pageSize,
+					&cnt,
+					qry))).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Table: "assistant_conversations", Name: "created_date"},
+			Desc:   true,
+		}).Find(&conversations)
+
+	conversationService.logger.Benchmark("conversationService.Query", time.Since(start))
+	if tx.Error != nil {
+		conversationService.logger.Errorf("not able to query conversations %v", tx.Error)
+		return cnt, nil, tx.Error
+	}
+	return cnt, conversations, nil
+}
+
+func (conversationService *assistantConversationService) Analytics(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	filter *protos.ConversationFilter,
+	groupBy string,
+) ([]*internal_services.ConversationAnalytics, error) {
+	group, ok := analyticsGroups[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported group %q, expected day, assistant, disposition, source or direction", groupBy)
+	}
+
+	start := time.Now()
+	db := conversationService.postgres.DB(ctx)
+	var analytics []*internal_services.ConversationAnalytics
+	qry := db.Model(internal_conversation_entity.AssistantConversation{}).
+		Select(fmt.Sprintf(`%s AS "group", COUNT(*) AS conversations, COALESCE(SUM(%s), 0) / 1e9 AS total_duration, COALESCE(AVG(%s), 0) / 1e9 AS average_duration`,
+			group, conversationDuration, conversationDuration)).
+		Joins("LEFT JOIN assistant_conversation_metrics duration ON duration.assistant_conversation_id = assistant_conversations.id AND duration.name = ?", type_enums.TIME_TAKEN.String())
+	if groupBy == "disposition" {
+		qry = qry.Joins("LEFT JOIN assistant_conversation_metadata disposition ON disposition.assistant_conversation_id = assistant_conversations.id AND disposition.key = ?", disconnectReasonMetadataKey)
+	}
+
+	qry = conversationService.filterConversations(qry, auth, filter)
+	if groupBy != "" {
+		qry = qry.Group(group).Order(group)
+	}
+	tx := qry.Scan(&analytics)
+
+	conversationService.logger.Benchmark("conversationService.Analytics", time.Since(start))
+	if tx.Error != nil {
+		conversationService.logger.Errorf("not able to aggregate conversations by %q %v", groupBy, tx.Error)
+		return nil, tx.Error
+	}
+	return analytics, nil
+}
+
+// filterConversations restricts the query to the conversations of the
+// current project that match the filter. Columns are qualified, the query
+// may join other tables.
+func (conversationService *assistantConversationService) filterConversations(qry *gorm.DB, auth types.SimplePrinciple, filter *protos.ConversationFilter) *gorm.DB {
+	qry = qry.
+		Where("assistant_conversations.organization_id = ? AND assistant_conversations.project_id = ?", *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId())
+
+	if ids := filter.GetAssistantIds(); len(ids) > 0 {
+		qry = qry.Where("assistant_conversations.assistant_id IN ?", ids)
+	}
+	if after := filter.GetCreatedAfter(); after != nil {
+		qry = qry.Where("assistant_conversations.created_date >= ?", after.AsTime())
+	}
+	if before := filter.GetCreatedBefore(); before != nil {
+		qry = qry.Where("assistant_conversations.created_date < ?", before.AsTime())
+	}
+	if sources := filter.GetSources(); len(sources) > 0 {
+		qry = qry.Where("assistant_conversations.source IN ?", sources)
+	}
+	if directions := filter.GetDirections(); len(directions) > 0 {
+		qry = qry.Where("assistant_conversations.direction IN ?", directions)
+	}
+	if dispositions := filter.GetDispositions(); len(dispositions) > 0 {
+		qry = qry.Where("EXISTS (SELECT 1 FROM assistant_conversation_metadata md WHERE md.assistant_conversation_id = assistant_conversations.id AND md.key = ? AND md.value IN ?)",
+			disconnectReasonMetadataKey, dispositions)
+	}
+	if filter.GetMinDuration() > 0 || filter.GetMaxDuration() > 0 {
+		maxDuration := int64(filter.GetMaxDuration()) * int64(time.Second)
+		if filter.GetMaxDuration() == 0 {
+			maxDuration = 1<<63 - 1
+		}
+		qry = qry.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM assistant_conversation_metrics duration WHERE duration.assistant_conversation_id = assistant_conversations.id AND duration.name = ? AND %s BETWEEN ? AND ?)", conversationDuration),
+			type_enums.TIME_TAKEN.String(), int64(filter.GetMinDuration())*int64(time.Second), maxDuration)
+	}
+	// The bodies of encrypted conversations are ciphertext, they never
+	// match a keyword.
+	if keyword := strings.TrimSpace(filter.GetKeyword()); keyword != "" {
+		qry = qry.Where("EXISTS (SELECT 1 FROM assistant_conversation_messages msg WHERE msg.assistant_conversation_id = assistant_conversations.id AND msg.body ILIKE ?)",
+			"%"+likeEscaper.Replace(keyword)+"%")
+	}
+	return qry
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,58 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"time"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+)
+
+func (conversationService *assistantConversationService) CreateTranscriptSegments(ctx context.Context,
+	segments []*internal_conversation_entity.AssistantTranscriptSegment,
+) error {
+	if len(segments) == 0 {
+		return nil
+	}
+	start := time.Now()
+	tx := conversationService.postgres.DB(ctx).Create(&segments)
+	conversationService.logger.Benchmark("conversationService.CreateTranscriptSegments", time.Since(start))
+	if tx.Error != nil {
+		conversationService.logger.Errorf("error while storing %d transcript segments %v", len(segments), tx.Error)
+		return tx.Error
+	}
+	return nil
+}
+
+func (conversationService *assistantConversationService) GetRecognitionQuality(ctx context.Context,
+	auth types.SimplePrinciple,
+	filter *protos.ConversationFilter,
+	lowConfidence float64,
+) ([]*internal_services.RecognitionQuality, error) {
+	start := time.Now()
+	var quality []*internal_services.RecognitionQuality
+	qry := conversationService.postgres.DB(ctx).
+		Model(internal_conversation_entity.AssistantConversation{}).
+		Select(`segments.assistant_id, segments.provider, segments.language, COUNT(*) AS segments, COALESCE(SUM(segments.words), 0) AS words, `+
+			`COALESCE(SUM(segments.confidence * segments.words) / NULLIF(SUM(segments.words), 0), AVG(segments.confidence)) AS average_confidence, `+
+			`COUNT(*) FILTER (WHERE segments.confidence < ?) AS low_confidence_segments`, lowConfidence).
+		Joins("JOIN assistant_transcript_segments segments ON segments.assistant_conversation_id = assistant_conversations.id")
+	qry = conversationService.filterConversations(qry, auth, filter).
+		Group("segments.assistant_id, segments.provider, segments.language").
+		Order("segments.assistant_id, segments.provider, segments.language")
+	tx := qry.Scan(&quality)
+
+	conversationService.logger.Benchmark("conversationService.GetRecognitionQuality", time.Since(start))
+	if tx.Error != nil {
+		conversationService.logger.Errorf("not able to aggregate the recognition quality %v", tx.Error)
+		return nil, tx.Error
+	}
+	return quality, nil
+}
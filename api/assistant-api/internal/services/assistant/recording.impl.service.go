@@ -0,0 +1,437 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/ciphers"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"github.com/rapidaai/pkg/storages"
+	"github.com/rapidaai/pkg/types"
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// recordingRestoreDays is how long a restored copy of an archived
+	// recording stays readable before it has to be restored again.
+	recordingRestoreDays = 7
+
+	// retentionBatchSize bounds the recordings archived or deleted per
+	// project and step in one run of the retention policies.
+	retentionBatchSize = 500
+)
+
+type assistantRecordingService struct {
+	logger   commons.Logger
+	postgres connectors.PostgresConnector
+	storage  storages.Storage
+	keys     *conversationKeys
+}
+
+func NewAssistantRecordingService(
+	cfg *config.AssistantConfig,
+	logger commons.Logger,
+	postgres connectors.PostgresConnector,
+	storage storages.Storage) internal_services.AssistantRecordingService {
+	return &assistantRecordingService{
+		logger:   logger,
+		postgres: postgres,
+		storage:  storage,
+		keys:     newConversationKeys(cfg, logger),
+	}
+}
+
+func (recordingService *assistantRecordingService) GetRetentionPolicy(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+) (*internal_conversation_entity.AssistantRecordingRetentionPolicy, error) {
+	start := time.Now()
+	db := recordingService.postgres.DB(ctx)
+	policy := &internal_conversation_entity.AssistantRecordingRetentionPolicy{}
+	tx := db.
+		Where("organization_id = ? AND project_id = ?", *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId()).
+		First(policy)
+	recordingService.logger.Benchmark("recordingService.GetRetentionPolicy", time.Since(start))
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return &internal_conversation_entity.AssistantRecordingRetentionPolicy{
+			Organizational: gorm_models.Organizational{
+				ProjectId:      *auth.GetCurrentProjectId(),
+				OrganizationId: *auth.GetCurrentOrganizationId(),
+			},
+		}, nil
+	}
+	if tx.Error != nil {
+		recordingService.logger.Errorf("not able to get recording retention policy %v", tx.Error)
+		return nil, tx.Error
+	}
+	return policy, nil
+}
+
+func (recordingService *assistantRecordingService) UpdateRetentionPolicy(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	archiveAfterDays, deleteAfterDays uint32,
+) (*internal_conversation_entity.AssistantRecordingRetentionPolicy, error) {
+	if deleteAfterDays > 0 && archiveAfterDays >= deleteAfterDays {
+		return nil, errors.New("recordings must be archived before they are deleted")
+	}
+	start := time.Now()
+	db := recordingService.postgres.DB(ctx)
+	policy := &internal_conversation_entity.AssistantRecordingRetentionPolicy{
+		Organizational: gorm_models.Organizational{
+			ProjectId:      *auth.GetCurrentProjectId(),
+			OrganizationId: *auth.GetCurrentOrganizationId(),
+		},
+		ArchiveAfterDays: archiveAfterDays,
+		DeleteAfterDays:  deleteAfterDays,
+	}
+	if auth.GetUserId() != nil {
+		policy.CreatedBy = *auth.GetUserId()
+		policy.UpdatedBy = *auth.GetUserId()
+	}
+	tx := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"archive_after_days", "delete_after_days",
+			"updated_by", "updated_date"}),
+	}).Create(policy)
+	recordingService.logger.Benchmark("recordingService.UpdateRetentionPolicy", time.Since(start))
+	if tx.Error != nil {
+		recordingService.logger.Errorf("not able to update recording retention policy %v", tx.Error)
+		return nil, tx.Error
+	}
+	return recordingService.GetRetentionPolicy(ctx, auth)
+}
+
+func (recordingService *assistantRecordingService) GetAssistantSetting(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+) (*internal_conversation_entity.AssistantRecordingSetting, error) {
+	start := time.Now()
+	db := recordingService.postgres.DB(ctx)
+	setting := &internal_conversation_entity.AssistantRecordingSetting{}
+	tx := db.
+		Where("assistant_id = ? AND project_id = ?", assistantId, *auth.GetCurrentProjectId()).
+		First(setting)
+	recordingService.logger.Benchmark("recordingService.GetAssistantSetting", time.Since(start))
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		setting = internal_conversation_entity.DefaultRecordingSetting()
+		setting.AssistantId = assistantId
+		setting.Organizational = gorm_models.Organizational{
+			ProjectId:      *auth.GetCurrentProjectId(),
+			OrganizationId: *auth.GetCurrentOrganizationId(),
+		}
+		return setting, nil
+	}
+	if tx.Error != nil {
+		recordingService.logger.Errorf("not able to get recording setting of assistant %d %v", assistantId, tx.Error)
+		return nil, tx.Error
+	}
+	return setting, nil
+}
+
+func (recordingService *assistantRecordingService) UpdateAssistantSetting(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	setting *internal_conversation_entity.AssistantRecordingSetting,
+) (*internal_conversation_entity.AssistantRecordingSetting, error) {
+	start := time.Now()
+	db := recordingService.postgres.DB(ctx)
+	setting.Organizational = gorm_models.Organizational{
+		ProjectId:      *auth.GetCurrentProjectId(),
+		OrganizationId: *auth.GetCurrentOrganizationId(),
+	}
+	if auth.GetUserId() != nil {
+		setting.CreatedBy = *auth.GetUserId()
+		setting.UpdatedBy = *auth.GetUserId()
+	}
+	tx := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "assistant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"enabled", "dual_channel", "require_consent", "redact_spelling",
+			"updated_by", "updated_date"}),
+	}).Create(setting)
+	recordingService.logger.Benchmark("recordingService.UpdateAssistantSetting", time.Since(start))
+	if tx.Error != nil {
+		recordingService.logger.Errorf("not able to update recording setting of assistant %d %v", setting.AssistantId, tx.Error)
+		return nil, tx.Error
+	}
+	return recordingService.GetAssistantSetting(ctx, auth, setting.AssistantId)
+}
+
+func (recordingService *assistantRecordingService) GetConversationRecordings(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantConversationId uint64,
+) ([]*internal_conversation_entity.AssistantConversationRecording, error) {
+	start := time.Now()
+	db := recordingService.postgres.DB(ctx)
+	var recordings []*internal_conversation_entity.AssistantConversationRecording
+	tx := db.
+		Where("assistant_conversation_id = ? AND organization_id = ? AND project_id = ? AND status = ?",
+			assistantConversationId, *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId(), type_enums.RECORD_ACTIVE.String()).
+		Order("created_date").
+		Find(&recordings)
+	if tx.Error != nil {
+		recordingService.logger.Benchmark("recordingService.GetConversationRecordings", time.Since(start))
+		recordingService.logger.Errorf("not able to find recordings of conversation %d %v", assistantConversationId, tx.Error)
+		return nil, tx.Error
+	}
+	data, err := recordingService.keys.dataCipher(db, assistantConversationId)
+	if err != nil {
+		recordingService.logger.Benchmark("recordingService.GetConversationRecordings", time.Since(start))
+		recordingService.logger.Errorf("not able to get the key of conversation %d %v", assistantConversationId, err)
+		return nil, err
+	}
+	for _, recording := range recordings {
+		if err := resolveRecording(ctx, recordingService.storage, data, recording); err != nil {
+			recordingService.logger.Benchmark("recordingService.GetConversationRecordings", time.Since(start))
+			recordingService.logger.Errorf("not able to retrieve recording %d %v", recording.Id, err)
+			return nil, err
+		}
+	}
+	recordingService.logger.Benchmark("recordingService.GetConversationRecordings", time.Since(start))
+	return recordings, nil
+}
+
+func (recordingService *assistantRecordingService) ApplyRetentionPolicies(ctx context.Context, now time.Time) (int, int, error) {
+	start := time.Now()
+	defer func() {
+		recordingService.logger.Benchmark("recordingService.ApplyRetentionPolicies", time.Since(start))
+	}()
+
+	lifecycle, ok := recordingService.storage.(storages.LifecycleStorage)
+	if !ok {
+		return 0, 0, errors.New("recording storage does not support archival and deletion")
+	}
+
+	db := recordingService.postgres.DB(ctx)
+	var policies []*internal_conversation_entity.AssistantRecordingRetentionPolicy
+	tx := db.
+		Where("status = ? AND (archive_after_days > 0 OR delete_after_days > 0)", type_enums.RECORD_ACTIVE.String()).
+		Find(&policies)
+	if tx.Error != nil {
+		recordingService.logger.Errorf("not able to list recording retention policies %v", tx.Error)
+		return 0, 0, tx.Error
+	}
+
+	archived, deleted := 0, 0
+	for _, policy := range policies {
+		if policy.DeleteAfterDays > 0 {
+			n, err := recordingService.deleteExpired(ctx, lifecycle, policy, now.AddDate(0, 0, -int(policy.DeleteAfterDays)))
+			deleted += n
+			if err != nil {
+				return archived, deleted, err
+			}
+		}
+		if policy.ArchiveAfterDays > 0 {
+			n, err := recordingService.archiveExpired(ctx, lifecycle, policy, now.AddDate(0, 0, -int(policy.ArchiveAfterDays)), now)
+			archived += n
+			if err != nil {
+				return archived, deleted, err
+			}
+		}
+	}
+	return archived, deleted, nil
+}
+
+// expiredRecordings returns a batch of the active recordings of the project of
+// the policy created before the cutoff.
+func (recordingService *assistantRecordingService) expiredRecordings(
+	ctx context.Context,
+	policy *internal_conversation_entity.AssistantRecordingRetentionPolicy,
+	cutoff time.Time,
+	tiers ...string,
+) ([]*internal_conversation_entity.AssistantConversationRecording, error) {
+	var recordings []*internal_conversation_entity.AssistantConversationRecording
+	tx := recordingService.postgres.DB(ctx).
+		Where("project_id = ? AND status = ? AND storage_tier IN ? AND created_date < ?",
+			policy.ProjectId, type_enums.RECORD_ACTIVE.String(), tiers, cutoff).
+		Order("created_date").
+		Limit(retentionBatchSize).
+		Find(&recordings)
+	if tx.Error != nil {
+		recordingService.logger.Errorf("not able to find expired recordings of project %d %v", policy.ProjectId, tx.Error)
+		return nil, tx.Error
+	}
+	return recordings, nil
+}
+
+// deleteExpired removes the audio of the recordings past the retention of the
+// project and marks them deleted. The rows remain as a record of the call.
+func (recordingService *assistantRecordingService) deleteExpired(
+	ctx context.Context,
+	lifecycle storages.LifecycleStorage,
+	policy *internal_conversation_entity.AssistantRecordingRetentionPolicy,
+	cutoff time.Time,
+) (int, error) {
+	recordings, err := recordingService.expiredRecordings(ctx, policy, cutoff,
+		internal_conversation_entity.RecordingStorageStandard, internal_conversation_entity.RecordingStorageArchive)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, recording := range recordings {
+		if err := deleteRecordingObjects(ctx, lifecycle, recording); err != nil {
+			recordingService.logger.Warnf("unable to delete audio of recording %d %v", recording.Id, err)
+			continue
+		}
+		tx := recordingService.postgres.DB(ctx).
+			Model(recording).
+			Updates(map[string]interface{}{
+				"status":       type_enums.RECORD_INACTIVE.String(),
+				"storage_tier": internal_conversation_entity.RecordingStorageDeleted,
+			})
+		if tx.Error != nil {
+			recordingService.logger.Errorf("not able to mark recording %d deleted %v", recording.Id, tx.Error)
+			return deleted, tx.Error
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// archiveExpired moves the audio of the recordings past the standard storage
+// period of the project to the archive tier.
+func (recordingService *assistantRecordingService) archiveExpired(
+	ctx context.Context,
+	lifecycle storages.LifecycleStorage,
+	policy *internal_conversation_entity.AssistantRecordingRetentionPolicy,
+	cutoff, now time.Time,
+) (int, error) {
+	recordings, err := recordingService.expiredRecordings(ctx, policy, cutoff, internal_conversation_entity.RecordingStorageStandard)
+	if err != nil {
+		return 0, err
+	}
+	archived := 0
+	for _, recording := range recordings {
+		if err := archiveRecordingObjects(ctx, lifecycle, recording); err != nil {
+			recordingService.logger.Warnf("unable to archive audio of recording %d %v", recording.Id, err)
+			continue
+		}
+		tx := recordingService.postgres.DB(ctx).
+			Model(recording).
+			Updates(map[string]interface{}{
+				"storage_tier":  internal_conversation_entity.RecordingStorageArchive,
+				"archived_date": now,
+			})
+		if tx.Error != nil {
+			recordingService.logger.Errorf("not able to mark recording %d archived %v", recording.Id, tx.Error)
+			return archived, tx.Error
+		}
+		archived++
+	}
+	return archived, nil
+}
+
+func archiveRecordingObjects(ctx context.Context, lifecycle storages.LifecycleStorage, recording *internal_conversation_entity.AssistantConversationRecording) error {
+	for _, key := range recordingKeys(recording) {
+		if err := lifecycle.Archive(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteRecordingObjects(ctx context.Context, lifecycle storages.LifecycleStorage, recording *internal_conversation_entity.AssistantConversationRecording) error {
+	for _, key := range recordingKeys(recording) {
+		if err := lifecycle.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordingKeys returns the storage keys of the audio of a recording. Older
+// recordings stored both sides under one key.
+func recordingKeys(recording *internal_conversation_entity.AssistantConversationRecording) []string {
+	if recording.UserRecordingUrl == recording.AssistantRecordingUrl {
+		return []string{recording.AssistantRecordingUrl}
+	}
+	return []string{recording.AssistantRecordingUrl, recording.UserRecordingUrl}
+}
+
+// resolveRecording replaces the storage keys of a recording with urls it can
+// be played from. An archived recording is restored first; while it is not
+// readable the urls are cleared and the recording is flagged as restoring.
+// The storage only holds ciphertext of encrypted recordings, they are
+// decrypted with the data key of the conversation into data urls.
+func resolveRecording(ctx context.Context, storage storages.Storage, data *ciphers.DataCipher, recording *internal_conversation_entity.AssistantConversationRecording) error {
+	if recording.StorageTier == internal_conversation_entity.RecordingStorageArchive {
+		if lifecycle, ok := storage.(storages.LifecycleStorage); ok {
+			readable := true
+			for _, key := range recordingKeys(recording) {
+				ok, err := lifecycle.Restore(ctx, key, recordingRestoreDays)
+				if err != nil {
+					return err
+				}
+				readable = readable && ok
+			}
+			if !readable {
+				recording.Restoring = true
+				recording.AssistantRecordingUrl = ""
+				recording.UserRecordingUrl = ""
+				return nil
+			}
+		}
+	}
+
+	if recording.Encrypted {
+		if data == nil {
+			return errors.New("recording is encrypted but its conversation has no key")
+		}
+		assistantUrl, err := decryptedRecordingUrl(ctx, storage, data, recording.AssistantRecordingUrl)
+		if err != nil {
+			return err
+		}
+		userUrl, err := decryptedRecordingUrl(ctx, storage, data, recording.UserRecordingUrl)
+		if err != nil {
+			return err
+		}
+		recording.AssistantRecordingUrl = assistantUrl
+		recording.UserRecordingUrl = userUrl
+		return nil
+	}
+
+	assistantUrl := storage.GetUrl(ctx, recording.AssistantRecordingUrl)
+	if assistantUrl.Error != nil {
+		return assistantUrl.Error
+	}
+	userUrl := storage.GetUrl(ctx, recording.UserRecordingUrl)
+	if userUrl.Error != nil {
+		return userUrl.Error
+	}
+	recording.AssistantRecordingUrl = assistantUrl.CompletePath
+	recording.UserRecordingUrl = userUrl.CompletePath
+	return nil
+}
+
+// decryptedRecordingUrl reads an encrypted recording and returns its audio
+// as a data url, the object itself cannot be handed out.
+func decryptedRecordingUrl(ctx context.Context, storage storages.Storage, data *ciphers.DataCipher, key string) (string, error) {
+	object := storage.Get(ctx, key)
+	if object.Error != nil {
+		return "", object.Error
+	}
+	audio, err := data.Open(object.Data, []byte(key))
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt recording %s: %w", key, err)
+	}
+	return "data:audio/wav;base64," + base64.StdEncoding.EncodeToString(audio), nil
+}
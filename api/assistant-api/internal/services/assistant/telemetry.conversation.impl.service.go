@@ -0,0 +1,140 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"time"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_message_gorm "github.com/rapidaai/api/assistant-api/internal/entity/messages"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type conversationMetricKey struct {
+	conversationId uint64
+	name           string
+}
+
+type messageMetricKey struct {
+	messageId string
+	name      string
+}
+
+// ApplyTelemetry writes a batch of the telemetry writer with one statement per
+// table. An upsert must not touch a row twice, so repeated metrics of a
+// conversation or message are collapsed to their last value first.
+func (conversationService *assistantConversationService) ApplyTelemetry(
+	ctx context.Context,
+	records []*internal_services.TelemetryRecord,
+) error {
+	start := time.Now()
+	var (
+		metrics        = make([]*internal_conversation_entity.AssistantConversationMetric, 0)
+		metricAt       = make(map[conversationMetricKey]int)
+		messageMetrics = make([]*internal_message_gorm.AssistantConversationMessageMetric, 0)
+		messageAt      = make(map[messageMetricKey]int)
+		events         = make([]*internal_conversation_entity.AssistantConversationTelephonyEvent, 0)
+		logEvents      = make([]*internal_conversation_entity.AssistantConversationEvent, 0)
+	)
+	for _, r := range records {
+		switch {
+		case r.LogEvent != nil:
+			logEvents = append(logEvents, r.LogEvent)
+		case r.Event != nil:
+			events = append(events, &internal_conversation_entity.AssistantConversationTelephonyEvent{
+				AssistantConversationId: r.AssistantConversationId,
+				Event:                   *gorm_models.NewEvent(r.Event.EventType, r.Event.Payload),
+				Provider:                r.Provider,
+				AssistantId:             r.AssistantId,
+			})
+		case r.Metric != nil && r.MessageId != "":
+			mtr := &internal_message_gorm.AssistantConversationMessageMetric{
+				Metric: gorm_models.Metric{
+					Name:        r.Metric.GetName(),
+					Value:       r.Metric.GetValue(),
+					Description: r.Metric.GetDescription(),
+				},
+				AssistantConversationId:        r.AssistantConversationId,
+				AssistantConversationMessageId: r.MessageId,
+			}
+			if r.UserId != nil {
+				mtr.UpdatedBy = *r.UserId
+				mtr.CreatedBy = *r.UserId
+			}
+			key := messageMetricKey{r.MessageId, mtr.Name}
+			if i, ok := messageAt[key]; ok {
+				messageMetrics[i] = mtr
+				continue
+			}
+			messageAt[key] = len(messageMetrics)
+			messageMetrics = append(messageMetrics, mtr)
+		case r.Metric != nil:
+			mtr := &internal_conversation_entity.AssistantConversationMetric{
+				Metric: gorm_models.Metric{
+					Name:        r.Metric.GetName(),
+					Value:       r.Metric.GetValue(),
+					Description: r.Metric.GetDescription(),
+				},
+				AssistantId:             r.AssistantId,
+				AssistantConversationId: r.AssistantConversationId,
+			}
+			if r.UserId != nil {
+				mtr.UpdatedBy = *r.UserId
+				mtr.CreatedBy = *r.UserId
+			}
+			key := conversationMetricKey{r.AssistantConversationId, mtr.Name}
+			if i, ok := metricAt[key]; ok {
+				metrics[i] = mtr
+				continue
+			}
+			metricAt[key] = len(metrics)
+			metrics = append(metrics, mtr)
+		}
+	}
+
+	err := conversationService.postgres.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(metrics) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "assistant_conversation_id"}, {Name: "name"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"value", "description",
+					"updated_by", "updated_date"}),
+			}).Create(&metrics).Error; err != nil {
+				return err
+			}
+		}
+		if len(messageMetrics) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "name"}, {Name: "assistant_conversation_message_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"value", "description",
+					"updated_by", "updated_date"}),
+			}).Create(&messageMetrics).Error; err != nil {
+				return err
+			}
+		}
+		if len(events) > 0 {
+			if err := tx.Create(&events).Error; err != nil {
+				return err
+			}
+		}
+		if len(logEvents) > 0 {
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&logEvents).Error
+		}
+		return nil
+	})
+	if err != nil {
+		conversationService.logger.Benchmark("conversationService.ApplyTelemetry", time.Since(start))
+		conversationService.logger.Errorf("error while applying telemetry batch of %d records %v", len(records), err)
+		return err
+	}
+	conversationService.logger.Benchmark("conversationService.ApplyTelemetry", time.Since(start))
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_assistant_service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_conversationdiff "github.com/rapidaai/api/assistant-api/internal/conversationdiff"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_message_gorm "github.com/rapidaai/api/assistant-api/internal/entity/messages"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_transcript "github.com/rapidaai/api/assistant-api/internal/transcript"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"github.com/rapidaai/pkg/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type assistantTranscriptService struct {
+	logger   commons.Logger
+	postgres connectors.PostgresConnector
+	keys     *conversationKeys
+}
+
+func NewAssistantTranscriptService(
+	cfg *config.AssistantConfig,
+	logger commons.Logger,
+	postgres connectors.PostgresConnector) internal_services.AssistantTranscriptService {
+	return &assistantTranscriptService{
+		logger:   logger,
+		postgres: postgres,
+		keys:     newConversationKeys(cfg, logger),
+	}
+}
+
+func (transcriptService *assistantTranscriptService) GetSetting(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+) (*internal_assistant_entity.AssistantTranscriptSetting, error) {
+	start := time.Now()
+	db := transcriptService.postgres.DB(ctx)
+	setting := &internal_assistant_entity.AssistantTranscriptSetting{}
+	tx := db.
+		Where("assistant_id = ? AND organization_id = ? AND project_id = ?", assistantId, *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId()).
+		First(setting)
+	transcriptService.logger.Benchmark("transcriptService.GetSetting", time.Since(start))
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return &internal_assistant_entity.AssistantTranscriptSetting{
+			Organizational: gorm_models.Organizational{
+				ProjectId:      *auth.GetCurrentProjectId(),
+				OrganizationId: *auth.GetCurrentOrganizationId(),
+			},
+			AssistantId: assistantId,
+		}, nil
+	}
+	if tx.Error != nil {
+		transcriptService.logger.Errorf("not able to get transcript setting %v", tx.Error)
+		return nil, tx.Error
+	}
+	return setting, nil
+}
+
+func (transcriptService *assistantTranscriptService) UpdateSetting(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	aiNotice, legalFooter string,
+) (*internal_assistant_entity.AssistantTranscriptSetting, error) {
+	start := time.Now()
+	db := transcriptService.postgres.DB(ctx)
+
+	// the setting is keyed by assistant alone, make sure the assistant is in
+	// the caller's project before writing it
+	var owned int64
+	if tx := db.Model(&internal_assistant_entity.Assistant{}).
+		Where("id = ? AND organization_id = ? AND project_id = ?", assistantId, *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId()).
+		Count(&owned); tx.Error != nil {
+		transcriptService.logger.Errorf("not able to find the assistant of the transcript setting %v", tx.Error)
+		return nil, tx.Error
+	}
+	if owned == 0 {
+		return nil, errors.New("assistant not found")
+	}
+
+	setting := &internal_assistant_entity.AssistantTranscriptSetting{
+		Organizational: gorm_models.Organizational{
+			ProjectId:      *auth.GetCurrentProjectId(),
+			OrganizationId: *auth.GetCurrentOrganizationId(),
+		},
+		AssistantId: assistantId,
+		AiNotice:    aiNotice,
+		LegalFooter: legalFooter,
+	}
+	if auth.GetUserId() != nil {
+		setting.CreatedBy = *auth.GetUserId()
+		setting.UpdatedBy = *auth.GetUserId()
+	}
+	tx := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "assistant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"ai_notice", "legal_footer",
+			"updated_by", "updated_date"}),
+	}).Create(setting)
+	transcriptService.logger.Benchmark("transcriptService.UpdateSetting", time.Since(start))
+	if tx.Error != nil {
+		transcriptService.logger.Errorf("not able to update transcript setting %v", tx.Error)
+		return nil, tx.Error
+	}
+	return transcriptService.GetSetting(ctx, auth, assistantId)
+}
+
+func (transcriptService *assistantTranscriptService) Export(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+) (string, error) {
+	start := time.Now()
+	db := transcriptService.postgres.DB(ctx)
+
+	conversation := &internal_conversation_entity.AssistantConversation{}
+	if tx := db.
+		Where("id = ? AND assistant_id = ? AND project_id = ? AND organization_id = ?",
+			assistantConversationId,
+			assistantId,
+			*auth.GetCurrentProjectId(),
+			*auth.GetCurrentOrganizationId()).
+		First(conversation); tx.Error != nil {
+		transcriptService.logger.Errorf("not able to find the conversation to export %v", tx.Error)
+		return "", tx.Error
+	}
+
+	var messages []*internal_message_gorm.AssistantConversationMessage
+	if tx := db.
+		Where("assistant_conversation_id = ?", conversation.Id).
+		Order("created_date ASC").
+		Find(&messages); tx.Error != nil {
+		transcriptService.logger.Errorf("not able to get the messages to export %v", tx.Error)
+		return "", tx.Error
+	}
+	if err := transcriptService.keys.openMessages(db, messages); err != nil {
+		transcriptService.logger.Errorf("not able to decrypt the messages to export %v", err)
+		return "", err
+	}
+
+	setting, err := transcriptService.GetSetting(ctx, auth, assistantId)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]internal_transcript.Line, 0, len(messages))
+	for _, message := range messages {
+		lines = append(lines, internal_transcript.Line{
+			Role: message.Role,
+			Body: message.Body,
+			At:   time.Time(message.CreatedDate),
+		})
+	}
+	transcriptService.logger.Benchmark("transcriptService.Export", time.Since(start))
+	return internal_transcript.Render(lines, internal_transcript.Footer{
+		AINotice:    setting.AiNotice,
+		LegalFooter: setting.LegalFooter,
+	}), nil
+}
+
+func (transcriptService *assistantTranscriptService) Compare(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	baseConversationId, candidateConversationId uint64,
+) (*internal_conversationdiff.Report, error) {
+	start := time.Now()
+	base, err := transcriptService.comparable(ctx, auth, assistantId, baseConversationId)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := transcriptService.comparable(ctx, auth, assistantId, candidateConversationId)
+	if err != nil {
+		return nil, err
+	}
+	transcriptService.logger.Benchmark("transcriptService.Compare", time.Since(start))
+	return internal_conversationdiff.Compare(base, candidate), nil
+}
+
+// comparable loads the messages and tool calls of a conversation of the
+// assistant in the caller's project.
+func (transcriptService *assistantTranscriptService) comparable(
+	ctx context.Context,
+	auth types.SimplePrinciple,
+	assistantId uint64,
+	assistantConversationId uint64,
+) (*internal_conversationdiff.Conversation, error) {
+	db := transcriptService.postgres.DB(ctx)
+
+	conversation := &internal_conversation_entity.AssistantConversation{}
+	if tx := db.
+		Where("id = ? AND assistant_id = ? AND project_id = ? AND organization_id = ?",
+			assistantConversationId,
+			assistantId,
+			*auth.GetCurrentProjectId(),
+			*auth.GetCurrentOrganizationId()).
+		First(conversation); tx.Error != nil {
+		transcriptService.logger.Errorf("not able to find the conversation to compare %v", tx.Error)
+		return nil, tx.Error
+	}
+
+	var messages []*internal_message_gorm.AssistantConversationMessage
+	if tx := db.
+		Where("assistant_conversation_id = ?", conversation.Id).
+		Order("created_date ASC").
+		Find(&messages); tx.Error != nil {
+		transcriptService.logger.Errorf("not able to get the messages to compare %v", tx.Error)
+		return nil, tx.Error
+	}
+	if err := transcriptService.keys.openMessages(db, messages); err != nil {
+		transcriptService.logger.Errorf("not able to decrypt the messages to compare %v", err)
+		return nil, err
+	}
+
+	var toolLogs []*internal_assistant_entity.AssistantToolLog
+	if tx := db.
+		Where("assistant_conversation_id = ? AND project_id = ?", conversation.Id, *auth.GetCurrentProjectId()).
+		Order("created_date ASC").
+		Find(&toolLogs); tx.Error != nil {
+		transcriptService.logger.Errorf("not able to get the tool calls to compare %v", tx.Error)
+		return nil, tx.Error
+	}
+
+	out := &internal_conversationdiff.Conversation{
+		ID:                       conversation.Id,
+		AssistantProviderModelID: conversation.AssistantProviderModelId,
+		Messages:                 make([]internal_conversationdiff.Message, 0, len(messages)),
+		Tools:                    make([]internal_conversationdiff.ToolCall, 0, len(toolLogs)),
+	}
+	for _, message := range messages {
+		out.Messages = append(out.Messages, internal_conversationdiff.Message{
+			ID:   message.MessageId,
+			Role: message.Role,
+			Body: message.Body,
+			At:   time.Time(message.CreatedDate),
+		})
+	}
+	for _, toolLog := range toolLogs {
+		out.Tools = append(out.Tools, internal_conversationdiff.ToolCall{
+			MessageID: toolLog.AssistantConversationMessageId,
+			Name:      toolLog.AssistantToolName,
+			Status:    toolLog.Status.String(),
+			TimeTaken: time.Duration(toolLog.TimeTaken),
+		})
+	}
+	return out, nil
+}
@@ -69,6 +69,7 @@ func (eService *assistantWebhookService) Create(ctx context.Context,
 	maxRetryCount uint32,
 	executionPriority uint32,
 	description *string,
+	signingSecret string,
 ) (*internal_assistant_entity.AssistantWebhook, error) {
 	start := time.Now()
 	db := eService.postgres.DB(ctx)
@@ -84,6 +85,7 @@ func (eService *assistantWebhookService) Create(ctx context.Context,
 		MaxRetryCount:     maxRetryCount,
 		TimeoutSeconds:    timeoutSecond,
 		ExecutionPriority: executionPriority,
+		SigningSecret:     signingSecret,
 		Mutable: gorm_models.Mutable{
 			CreatedBy: *auth.GetUserId(),
 			Status:    type_enums.RECORD_ACTIVE,
@@ -112,6 +114,7 @@ func (eService *assistantWebhookService) Update(ctx context.Context,
 	maxRetryCount uint32,
 	executionPriority uint32,
 	description *string,
+	signingSecret string,
 ) (*internal_assistant_entity.AssistantWebhook, error) {
 	start := time.Now()
 	db := eService.postgres.DB(ctx)
@@ -126,10 +129,12 @@ func (eService *assistantWebhookService) Update(ctx context.Context,
 		MaxRetryCount:     maxRetryCount,
 		TimeoutSeconds:    timeoutSecond,
 		ExecutionPriority: executionPriority,
+		SigningSecret:     signingSecret,
 		Mutable: gorm_models.Mutable{
 			UpdatedBy: *auth.GetUserId(),
 		},
 	}
+	// an empty secret keeps the one set before
 	tx := db.Where("id = ? AND assistant_id = ? ",
 		webhookId,
 		assistantId).Updates(&webhook)
@@ -0,0 +1,32 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_services
+
+import (
+	"context"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_audit_entity "github.com/rapidaai/api/assistant-api/internal/entity/audits"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+)
+
+type ControlAuditService interface {
+	// Record appends the action to the audit log of the caller's project with
+	// the caller as actor. The reason defaults to the one given in the
+	// request headers.
+	Record(ctx context.Context,
+		auth types.SimplePrinciple,
+		entry *internal_audit.Entry,
+	) (*internal_audit_entity.ControlAuditLog, error)
+
+	// GetAll returns the audit log of the caller's project, newest first.
+	GetAll(ctx context.Context,
+		auth types.SimplePrinciple,
+		criterias []*protos.Criteria,
+		paginate *protos.Paginate,
+	) (int64, []*internal_audit_entity.ControlAuditLog, error)
+}
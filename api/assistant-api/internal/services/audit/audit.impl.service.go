@@ -0,0 +1,159 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_audit_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	internal_audit "github.com/rapidaai/api/assistant-api/internal/audit"
+	internal_audit_entity "github.com/rapidaai/api/assistant-api/internal/entity/audits"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+	"gorm.io/gorm/clause"
+)
+
+// filterable are the columns the audit log can be queried on, criterias go
+// straight into the query so nothing else is accepted.
+var filterable = map[string]bool{
+	"action":        true,
+	"resource_type": true,
+	"resource_id":   true,
+	"actor_id":      true,
+	"actor_type":    true,
+	"created_date":  true,
+}
+
+var comparisons = map[string]bool{"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true}
+
+type controlAuditService struct {
+	logger   commons.Logger
+	postgres connectors.PostgresConnector
+}
+
+func NewControlAuditService(logger commons.Logger, postgres connectors.PostgresConnector) internal_services.ControlAuditService {
+	return &controlAuditService{
+		logger:   logger,
+		postgres: postgres,
+	}
+}
+
+func (auditService *controlAuditService) Record(ctx context.Context,
+	auth types.SimplePrinciple,
+	entry *internal_audit.Entry,
+) (*internal_audit_entity.ControlAuditLog, error) {
+	start := time.Now()
+	if !auth.HasProject() || !auth.HasOrganization() {
+		return nil, errors.New("audit log entries belong to a project")
+	}
+	before, err := internal_audit.Snapshot(entry.Before)
+	if err != nil {
+		auditService.logger.Errorf("not able to snapshot %s %d before %s %v", entry.ResourceType, entry.ResourceId, entry.Action, err)
+		return nil, err
+	}
+	after, err := internal_audit.Snapshot(entry.After)
+	if err != nil {
+		auditService.logger.Errorf("not able to snapshot %s %d after %s %v", entry.ResourceType, entry.ResourceId, entry.Action, err)
+		return nil, err
+	}
+	reason := strings.TrimSpace(entry.Reason)
+	if reason == "" {
+		reason = internal_audit.Reason(ctx)
+	}
+
+	log := &internal_audit_entity.ControlAuditLog{
+		Organizational: gorm_models.Organizational{
+			ProjectId:      *auth.GetCurrentProjectId(),
+			OrganizationId: *auth.GetCurrentOrganizationId(),
+		},
+		ActorType:    auth.Type(),
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceId:   entry.ResourceId,
+		Reason:       reason,
+		Before:       before,
+		After:        after,
+	}
+	if auth.GetUserId() != nil {
+		log.ActorId = *auth.GetUserId()
+	}
+	if before != nil || after != nil {
+		log.Changes, err = changes(before, after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx := auditService.postgres.DB(ctx).Create(log)
+	auditService.logger.Benchmark("auditService.Record", time.Since(start))
+	if tx.Error != nil {
+		auditService.logger.Errorf("not able to record %s of %s %d %v", entry.Action, entry.ResourceType, entry.ResourceId, tx.Error)
+		return nil, tx.Error
+	}
+	return log, nil
+}
+
+// changes stores the diff in the same JSON form as the snapshots.
+func changes(before, after map[string]interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(internal_audit.Diff(before, after))
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{})
+	return out, json.Unmarshal(raw, &out)
+}
+
+func (auditService *controlAuditService) GetAll(ctx context.Context,
+	auth types.SimplePrinciple,
+	criterias []*protos.Criteria,
+	paginate *protos.Paginate,
+) (int64, []*internal_audit_entity.ControlAuditLog, error) {
+	start := time.Now()
+	db := auditService.postgres.DB(ctx)
+	var (
+		logs []*internal_audit_entity.ControlAuditLog
+		cnt  int64
+	)
+	qry := db.Model(internal_audit_entity.ControlAuditLog{})
+	qry.Where("organization_id = ? AND project_id = ?", *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId())
+	for _, ct := range criterias {
+		logic := ct.GetLogic()
+		if logic == "" {
+			logic = "="
+		}
+		if !filterable[ct.GetKey()] || !comparisons[logic] {
+			return 0, nil, fmt.Errorf("audit log can not be filtered with %s %s", ct.GetKey(), ct.GetLogic())
+		}
+		qry.Where(fmt.Sprintf("%s %s ?", ct.GetKey(), logic), ct.GetValue())
+	}
+
+	tx := qry.
+		Scopes(gorm_models.
+			Paginate(gorm_models.
+				NewPaginated(
+					int(paginate.GetPage()),
+					int(paginate.GetPageSize()),
+					&cnt,
+					qry))).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "created_date"},
+			Desc:   true,
+		}).Find(&logs)
+	auditService.logger.Benchmark("auditService.GetAll", time.Since(start))
+	if tx.Error != nil {
+		auditService.logger.Errorf("not able to get the audit log %v", tx.Error)
+		return cnt, nil, tx.Error
+	}
+	return cnt, logs, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_services
+
+import (
+	"context"
+	"time"
+
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_campaign_entity "github.com/rapidaai/api/assistant-api/internal/entity/campaigns"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+)
+
+type CampaignService interface {
+	// Create saves a draft campaign of the caller's project with its contacts.
+	Create(ctx context.Context,
+		auth types.SimplePrinciple,
+		campaign *internal_campaign_entity.AssistantCampaign,
+		contacts []*internal_campaign_entity.AssistantCampaignContact,
+	) (*internal_campaign_entity.AssistantCampaign, error)
+
+	Get(ctx context.Context,
+		auth types.SimplePrinciple,
+		campaignId uint64,
+	) (*internal_campaign_entity.AssistantCampaign, error)
+
+	GetAll(ctx context.Context,
+		auth types.SimplePrinciple,
+		criterias []*protos.Criteria,
+		paginate *protos.Paginate,
+	) (int64, []*internal_campaign_entity.AssistantCampaign, error)
+
+	// UpdateStatus moves the campaign to status when it is in one of from,
+	// completed campaigns stay completed.
+	UpdateStatus(ctx context.Context,
+		auth types.SimplePrinciple,
+		campaignId uint64,
+		status string,
+		from ...string,
+	) (*internal_campaign_entity.AssistantCampaign, error)
+
+	GetAllContact(ctx context.Context,
+		auth types.SimplePrinciple,
+		campaignId uint64,
+		criterias []*protos.Criteria,
+		paginate *protos.Paginate,
+	) (int64, []*internal_campaign_entity.AssistantCampaignContact, error)
+
+	// The scheduler works across projects, the methods below are not scoped
+	// to a caller.
+
+	// GetActive returns the running campaigns and the paused ones, whose
+	// calls in progress are still to be finished.
+	GetActive(ctx context.Context) ([]*internal_campaign_entity.AssistantCampaign, error)
+
+	// GetDialing returns the contacts of the campaign with a call placed.
+	GetDialing(ctx context.Context, campaignId uint64) ([]*internal_campaign_entity.AssistantCampaignContact, error)
+
+	// ClaimContacts marks contacts due at now as dialing, as many as the
+	// campaign has free call slots. Concurrent schedulers never claim the
+	// same contact or more slots than the campaign has.
+	ClaimContacts(ctx context.Context,
+		campaign *internal_campaign_entity.AssistantCampaign,
+		now time.Time,
+	) ([]*internal_campaign_entity.AssistantCampaignContact, error)
+
+	// SetContactConversation links the dialing contact to the conversation
+	// of the call placed to it.
+	SetContactConversation(ctx context.Context, contactId, conversationId uint64) error
+
+	// FinishContact records the result of the call to a dialing contact.
+	// next is when a pending contact is called again.
+	FinishContact(ctx context.Context,
+		contactId uint64,
+		status, outcome, lastError string,
+		next *time.Time,
+	) error
+
+	// ReleaseContact hands a dialing contact back to pending without a call
+	// placed, the attempt it was claimed for is not counted. next is when it
+	// is due again.
+	ReleaseContact(ctx context.Context, contactId uint64, next time.Time) error
+
+	// GetCallContexts returns the call contexts of the conversations by
+	// conversation id.
+	GetCallContexts(ctx context.Context, conversationIds []uint64) (map[uint64]*callcontext.CallContext, error)
+
+	// Complete marks the running campaign completed when none of its contacts
+	// is pending or dialing, reporting whether it did.
+	Complete(ctx context.Context, campaignId uint64) (bool, error)
+}
@@ -0,0 +1,440 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_campaign_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_campaign "github.com/rapidaai/api/assistant-api/internal/campaign"
+	internal_campaign_entity "github.com/rapidaai/api/assistant-api/internal/entity/campaigns"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	gorm_models "github.com/rapidaai/pkg/models/gorm"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/protos"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// filterable are the columns campaigns and their contacts can be queried on,
+// criterias go straight into the query so nothing else is accepted.
+var (
+	filterable = map[string]bool{
+		"status":       true,
+		"assistant_id": true,
+		"name":         true,
+		"created_date": true,
+	}
+	contactFilterable = map[string]bool{
+		"status":    true,
+		"to_number": true,
+		"outcome":   true,
+		"attempts":  true,
+	}
+)
+
+var comparisons = map[string]bool{"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true}
+
+type campaignService struct {
+	logger   commons.Logger
+	postgres connectors.PostgresConnector
+}
+
+func NewCampaignService(logger commons.Logger, postgres connectors.PostgresConnector) internal_services.CampaignService {
+	return &campaignService{
+		logger:   logger,
+		postgres: postgres,
+	}
+}
+
+func (campaignService *campaignService) Create(ctx context.Context,
+	auth types.SimplePrinciple,
+	campaign *internal_campaign_entity.AssistantCampaign,
+	contacts []*internal_campaign_entity.AssistantCampaignContact,
+) (*internal_campaign_entity.AssistantCampaign, error) {
+	start := time.Now()
+	campaign.Organizational = gorm_models.Organizational{
+		ProjectId:      *auth.GetCurrentProjectId(),
+		OrganizationId: *auth.GetCurrentOrganizationId(),
+	}
+	if auth.GetUserId() != nil {
+		campaign.CreatedBy = *auth.GetUserId()
+	}
+	campaign.Status = internal_campaign.StatusDraft
+	err := campaignService.postgres.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return err
+		}
+		for _, contact := range contacts {
+			contact.AssistantCampaignId = campaign.Id
+			contact.Status = internal_campaign.ContactPending
+		}
+		return tx.CreateInBatches(contacts, 500).Error
+	})
+	campaignService.logger.Benchmark("campaignService.Create", time.Since(start))
+	if err != nil {
+		campaignService.logger.Errorf("not able to create campaign %s %v", campaign.Name, err)
+		return nil, err
+	}
+	campaign.Contacts = map[string]uint32{internal_campaign.ContactPending: uint32(len(contacts))}
+	return campaign, nil
+}
+
+func (campaignService *campaignService) Get(ctx context.Context,
+	auth types.SimplePrinciple,
+	campaignId uint64,
+) (*internal_campaign_entity.AssistantCampaign, error) {
+	start := time.Now()
+	var campaign *internal_campaign_entity.AssistantCampaign
+	tx := campaignService.postgres.DB(ctx).
+		Where("id = ? AND organization_id = ? AND project_id = ?", campaignId, *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId()).
+		First(&campaign)
+	campaignService.logger.Benchmark("campaignService.Get", time.Since(start))
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to get campaign %d %v", campaignId, tx.Error)
+		return nil, tx.Error
+	}
+	if err := campaignService.count(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+func (campaignService *campaignService) GetAll(ctx context.Context,
+	auth types.SimplePrinciple,
+	criterias []*protos.Criteria,
+	paginate *protos.Paginate,
+) (int64, []*internal_campaign_entity.AssistantCampaign, error) {
+	start := time.Now()
+	db := campaignService.postgres.DB(ctx)
+	var (
+		campaigns []*internal_campaign_entity.AssistantCampaign
+		cnt       int64
+	)
+	qry := db.Model(internal_campaign_entity.AssistantCampaign{})
+	qry.Where("organization_id = ? AND project_id = ?", *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId())
+	if err := where(qry, filterable, criterias); err != nil {
+		return 0, nil, err
+	}
+	tx := qry.
+		Scopes(gorm_models.
+			Paginate(gorm_models.
+				NewPaginated(
+					int(paginate.GetPage()),
+					int(paginate.GetPageSize()),
+					&cnt,
+					qry))).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "created_date"},
+			Desc:   true,
+		}).Find(&campaigns)
+	campaignService.logger.Benchmark("campaignService.GetAll", time.Since(start))
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to get campaigns %v", tx.Error)
+		return cnt, nil, tx.Error
+	}
+	if err := campaignService.count(ctx, campaigns...); err != nil {
+		return cnt, nil, err
+	}
+	return cnt, campaigns, nil
+}
+
+// count fills in the contact counts of the campaigns.
+func (campaignService *campaignService) count(ctx context.Context, campaigns ...*internal_campaign_entity.AssistantCampaign) error {
+	if len(campaigns) == 0 {
+		return nil
+	}
+	ids := make([]uint64, 0, len(campaigns))
+	byId := make(map[uint64]*internal_campaign_entity.AssistantCampaign, len(campaigns))
+	for _, c := range campaigns {
+		c.Contacts = map[string]uint32{}
+		ids = append(ids, c.Id)
+		byId[c.Id] = c
+	}
+	var rows []struct {
+		AssistantCampaignId uint64
+		Status              string
+		Count               uint32
+	}
+	tx := campaignService.postgres.DB(ctx).
+		Model(internal_campaign_entity.AssistantCampaignContact{}).
+		Select("assistant_campaign_id, status, COUNT(*) AS count").
+		Where("assistant_campaign_id IN ?", ids).
+		Group("assistant_campaign_id, status").
+		Scan(&rows)
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to count campaign contacts %v", tx.Error)
+		return tx.Error
+	}
+	for _, row := range rows {
+		byId[row.AssistantCampaignId].Contacts[row.Status] = row.Count
+	}
+	return nil
+}
+
+func (campaignService *campaignService) UpdateStatus(ctx context.Context,
+	auth types.SimplePrinciple,
+	campaignId uint64,
+	status string,
+	from ...string,
+) (*internal_campaign_entity.AssistantCampaign, error) {
+	start := time.Now()
+	tx := campaignService.postgres.DB(ctx).
+		Model(internal_campaign_entity.AssistantCampaign{}).
+		Where("id = ? AND organization_id = ? AND project_id = ? AND status IN ?", campaignId, *auth.GetCurrentOrganizationId(), *auth.GetCurrentProjectId(), from).
+		Updates(map[string]interface{}{"status": status, "updated_date": time.Now()})
+	campaignService.logger.Benchmark("campaignService.UpdateStatus", time.Since(start))
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to update status of campaign %d %v", campaignId, tx.Error)
+		return nil, tx.Error
+	}
+	campaign, err := campaignService.Get(ctx, auth, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if tx.RowsAffected == 0 && campaign.Status != status {
+		return nil, fmt.Errorf("campaign is %s", campaign.Status)
+	}
+	return campaign, nil
+}
+
+func (campaignService *campaignService) GetAllContact(ctx context.Context,
+	auth types.SimplePrinciple,
+	campaignId uint64,
+	criterias []*protos.Criteria,
+	paginate *protos.Paginate,
+) (int64, []*internal_campaign_entity.AssistantCampaignContact, error) {
+	start := time.Now()
+	if _, err := campaignService.Get(ctx, auth, campaignId); err != nil {
+		return 0, nil, err
+	}
+	db := campaignService.postgres.DB(ctx)
+	var (
+		contacts []*internal_campaign_entity.AssistantCampaignContact
+		cnt      int64
+	)
+	qry := db.Model(internal_campaign_entity.AssistantCampaignContact{})
+	qry.Where("assistant_campaign_id = ?", campaignId)
+	if err := where(qry, contactFilterable, criterias); err != nil {
+		return 0, nil, err
+	}
+	tx := qry.
+		Scopes(gorm_models.
+			Paginate(gorm_models.
+				NewPaginated(
+					int(paginate.GetPage()),
+					int(paginate.GetPageSize()),
+					&cnt,
+					qry))).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+		}).Find(&contacts)
+	campaignService.logger.Benchmark("campaignService.GetAllContact", time.Since(start))
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to get contacts of campaign %d %v", campaignId, tx.Error)
+		return cnt, nil, tx.Error
+	}
+	return cnt, contacts, nil
+}
+
+func where(qry *gorm.DB, allowed map[string]bool, criterias []*protos.Criteria) error {
+	for _, ct := range criterias {
+		logic := ct.GetLogic()
+		if logic == "" {
+			logic = "="
+		}
+		if !allowed[ct.GetKey()] || !comparisons[logic] {
+			return fmt.Errorf("campaigns can not be filtered with %s %s", ct.GetKey(), ct.GetLogic())
+		}
+		qry.Where(fmt.Sprintf("%s %s ?", ct.GetKey(), logic), ct.GetValue())
+	}
+	return nil
+}
+
+func (campaignService *campaignService) GetActive(ctx context.Context) ([]*internal_campaign_entity.AssistantCampaign, error) {
+	var campaigns []*internal_campaign_entity.AssistantCampaign
+	tx := campaignService.postgres.DB(ctx).
+		Where("status IN ?", []string{internal_campaign.StatusRunning, internal_campaign.StatusPaused}).
+		Find(&campaigns)
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to get active campaigns %v", tx.Error)
+		return nil, tx.Error
+	}
+	return campaigns, nil
+}
+
+func (campaignService *campaignService) GetDialing(ctx context.Context, campaignId uint64) ([]*internal_campaign_entity.AssistantCampaignContact, error) {
+	var contacts []*internal_campaign_entity.AssistantCampaignContact
+	tx := campaignService.postgres.DB(ctx).
+		Where("assistant_campaign_id = ? AND status = ?", campaignId, internal_campaign.ContactDialing).
+		Find(&contacts)
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to get dialing contacts of campaign %d %v", campaignId, tx.Error)
+		return nil, tx.Error
+	}
+	return contacts, nil
+}
+
+func (campaignService *campaignService) ClaimContacts(ctx context.Context,
+	campaign *internal_campaign_entity.AssistantCampaign,
+	now time.Time,
+) ([]*internal_campaign_entity.AssistantCampaignContact, error) {
+	start := time.Now()
+	var contacts []*internal_campaign_entity.AssistantCampaignContact
+	err := campaignService.postgres.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		// the campaign row lock serializes the schedulers of a campaign, so
+		// the dialing count below stays true until the claim commits
+		var locked internal_campaign_entity.AssistantCampaign
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", campaign.Id, internal_campaign.StatusRunning).
+			First(&locked).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		var dialing int64
+		if err := tx.Model(internal_campaign_entity.AssistantCampaignContact{}).
+			Where("assistant_campaign_id = ? AND status = ?", campaign.Id, internal_campaign.ContactDialing).
+			Count(&dialing).Error; err != nil {
+			return err
+		}
+		free := int64(locked.MaxConcurrentCalls) - dialing
+		if free <= 0 {
+			return nil
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("assistant_campaign_id = ? AND status = ? AND (next_attempt_date IS NULL OR next_attempt_date <= ?)",
+				campaign.Id, internal_campaign.ContactPending, now).
+			Order("id").
+			Limit(int(free)).
+			Find(&contacts).Error; err != nil {
+			return err
+		}
+		if len(contacts) == 0 {
+			return nil
+		}
+		ids := make([]uint64, 0, len(contacts))
+		for _, contact := range contacts {
+			ids = append(ids, contact.Id)
+			contact.Status = internal_campaign.ContactDialing
+			contact.Attempts++
+			contact.AssistantConversationId = 0
+			contact.DialedDate = &now
+			contact.NextAttemptDate = nil
+		}
+		return tx.Model(internal_campaign_entity.AssistantCampaignContact{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":                    internal_campaign.ContactDialing,
+				"attempts":                  gorm.Expr("attempts + 1"),
+				"assistant_conversation_id": 0,
+				"dialed_date":               now,
+				"next_attempt_date":         nil,
+				"updated_date":              now,
+			}).Error
+	})
+	campaignService.logger.Benchmark("campaignService.ClaimContacts", time.Since(start))
+	if err != nil {
+		campaignService.logger.Errorf("not able to claim contacts of campaign %d %v", campaign.Id, err)
+		return nil, err
+	}
+	return contacts, nil
+}
+
+func (campaignService *campaignService) SetContactConversation(ctx context.Context, contactId, conversationId uint64) error {
+	tx := campaignService.postgres.DB(ctx).
+		Model(internal_campaign_entity.AssistantCampaignContact{}).
+		Where("id = ? AND status = ?", contactId, internal_campaign.ContactDialing).
+		Updates(map[string]interface{}{"assistant_conversation_id": conversationId, "updated_date": time.Now()})
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to link contact %d to conversation %d %v", contactId, conversationId, tx.Error)
+		return tx.Error
+	}
+	return nil
+}
+
+func (campaignService *campaignService) FinishContact(ctx context.Context,
+	contactId uint64,
+	status, outcome, lastError string,
+	next *time.Time,
+) error {
+	// only a dialing contact is finished, another scheduler may have been first
+	tx := campaignService.postgres.DB(ctx).
+		Model(internal_campaign_entity.AssistantCampaignContact{}).
+		Where("id = ? AND status = ?", contactId, internal_campaign.ContactDialing).
+		Updates(map[string]interface{}{
+			"status":            status,
+			"outcome":           outcome,
+			"last_error":        lastError,
+			"next_attempt_date": next,
+			"updated_date":      time.Now(),
+		})
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to finish contact %d %v", contactId, tx.Error)
+		return tx.Error
+	}
+	return nil
+}
+
+func (campaignService *campaignService) ReleaseContact(ctx context.Context, contactId uint64, next time.Time) error {
+	tx := campaignService.postgres.DB(ctx).
+		Model(internal_campaign_entity.AssistantCampaignContact{}).
+		Where("id = ? AND status = ?", contactId, internal_campaign.ContactDialing).
+		Updates(map[string]interface{}{
+			"status":            internal_campaign.ContactPending,
+			"attempts":          gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"dialed_date":       nil,
+			"next_attempt_date": next,
+			"updated_date":      time.Now(),
+		})
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to release contact %d %v", contactId, tx.Error)
+		return tx.Error
+	}
+	return nil
+}
+
+func (campaignService *campaignService) GetCallContexts(ctx context.Context, conversationIds []uint64) (map[uint64]*callcontext.CallContext, error) {
+	out := make(map[uint64]*callcontext.CallContext, len(conversationIds))
+	if len(conversationIds) == 0 {
+		return out, nil
+	}
+	var contexts []*callcontext.CallContext
+	tx := campaignService.postgres.DB(ctx).
+		Where("conversation_id IN ?", conversationIds).
+		Find(&contexts)
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to get call contexts %v", tx.Error)
+		return nil, tx.Error
+	}
+	for _, cc := range contexts {
+		out[cc.ConversationID] = cc
+	}
+	return out, nil
+}
+
+func (campaignService *campaignService) Complete(ctx context.Context, campaignId uint64) (bool, error) {
+	db := campaignService.postgres.DB(ctx)
+	remaining := db.Model(internal_campaign_entity.AssistantCampaignContact{}).
+		Select("1").
+		Where("assistant_campaign_id = ? AND status IN ?", campaignId,
+			[]string{internal_campaign.ContactPending, internal_campaign.ContactDialing})
+	tx := db.Model(internal_campaign_entity.AssistantCampaign{}).
+		Where("id = ? AND status = ?", campaignId, internal_campaign.StatusRunning).
+		Where("NOT EXISTS (?)", remaining).
+		Updates(map[string]interface{}{"status": internal_campaign.StatusCompleted, "updated_date": time.Now()})
+	if tx.Error != nil {
+		campaignService.logger.Errorf("not able to complete campaign %d %v", campaignId, tx.Error)
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_services
+
+import (
+	"context"
+	"time"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	"github.com/rapidaai/pkg/types"
+)
+
+type AssistantRecordingService interface {
+	// GetRetentionPolicy returns the retention policy of the current project,
+	// a policy keeping recordings forever when none was set.
+	GetRetentionPolicy(ctx context.Context,
+		auth types.SimplePrinciple,
+	) (*internal_conversation_entity.AssistantRecordingRetentionPolicy, error)
+
+	UpdateRetentionPolicy(ctx context.Context,
+		auth types.SimplePrinciple,
+		archiveAfterDays, deleteAfterDays uint32,
+	) (*internal_conversation_entity.AssistantRecordingRetentionPolicy, error)
+
+	// GetAssistantSetting returns how the calls of an assistant are
+	// recorded, the default setting when none was set.
+	GetAssistantSetting(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+	) (*internal_conversation_entity.AssistantRecordingSetting, error)
+
+	UpdateAssistantSetting(ctx context.Context,
+		auth types.SimplePrinciple,
+		setting *internal_conversation_entity.AssistantRecordingSetting,
+	) (*internal_conversation_entity.AssistantRecordingSetting, error)
+
+	// GetConversationRecordings returns the recordings of a conversation with
+	// playable urls. Archived recordings are restored on the way, those that
+	// are not readable yet are returned as restoring and without urls.
+	GetConversationRecordings(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantConversationId uint64,
+	) ([]*internal_conversation_entity.AssistantConversationRecording, error)
+
+	// ApplyRetentionPolicies archives and deletes the recordings that have
+	// outlived the policy of their project as of now, across projects. It
+	// works in batches, recordings left over are handled by the next call.
+	ApplyRetentionPolicies(ctx context.Context, now time.Time) (archived, deleted int, err error)
+}
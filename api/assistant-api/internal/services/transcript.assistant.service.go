@@ -0,0 +1,45 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_services
+
+import (
+	"context"
+
+	internal_conversationdiff "github.com/rapidaai/api/assistant-api/internal/conversationdiff"
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	"github.com/rapidaai/pkg/types"
+)
+
+type AssistantTranscriptService interface {
+	// GetSetting returns the transcript setting of the assistant, an empty
+	// one when none was saved.
+	GetSetting(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+	) (*internal_assistant_entity.AssistantTranscriptSetting, error)
+
+	UpdateSetting(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+		aiNotice, legalFooter string,
+	) (*internal_assistant_entity.AssistantTranscriptSetting, error)
+
+	// Export renders the transcript of a conversation of the assistant with
+	// the AI notice and legal footer of its setting appended.
+	Export(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+		assistantConversationId uint64,
+	) (string, error)
+
+	// Compare diffs two conversations of the assistant turn by turn, the
+	// candidate against the base.
+	Compare(ctx context.Context,
+		auth types.SimplePrinciple,
+		assistantId uint64,
+		baseConversationId, candidateConversationId uint64,
+	) (*internal_conversationdiff.Report, error)
+}
@@ -28,6 +28,7 @@ type AssistantWebhookService interface {
 		retryStatusCodes []string,
 		retryCount, executionPriority uint32,
 		description *string,
+		signingSecret string,
 	) (*internal_assistant_entity.AssistantWebhook, error)
 	Update(ctx context.Context,
 		auth types.SimplePrinciple,
@@ -41,6 +42,7 @@ type AssistantWebhookService interface {
 		retryStatusCodes []string,
 		maxRetryCount, executionPriority uint32,
 		description *string,
+		signingSecret string,
 	) (*internal_assistant_entity.AssistantWebhook, error)
 
 	GetAll(ctx context.Context,
@@ -0,0 +1,154 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_sessionstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// migrationKeyPrefix namespaces the calls moving between instances. The
+// artifact of a call is kept under its call id, the instance taking it over
+// is signalled through an inbox and answers through the ack of the call.
+const migrationKeyPrefix = "rapida:session:migration:"
+
+// migrationAnyInbox is the inbox of calls any instance may take over.
+const migrationAnyInbox = migrationKeyPrefix + "inbox"
+
+func migrationKey(callID string) string     { return migrationKeyPrefix + "call:" + callID }
+func migrationAckKey(callID string) string  { return migrationKeyPrefix + "ack:" + callID }
+func migrationInbox(instance string) string { return migrationKeyPrefix + "inbox:" + instance }
+
+// Migration is a call moving to another instance: what its channel needs to
+// take the call over, e.g. the SIP dialog and media, and the state of the
+// conversation to resume it with.
+type Migration struct {
+	CallID    string          `json:"callId"`
+	From      string          `json:"from"`
+	Channel   json.RawMessage `json:"channel"`
+	State     *State          `json:"state,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// Migrations moves calls between instances through redis. Artifacts are
+// kept for ttl, a call nobody took over in that time stays where it is.
+type Migrations struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewMigrations keeps artifacts in client for ttl.
+func NewMigrations(client redis.UniversalClient, ttl time.Duration) *Migrations {
+	return &Migrations{client: client, ttl: ttl}
+}
+
+// Offer hands a call to the instance to, any instance taking calls when to
+// is empty.
+func (m *Migrations) Offer(ctx context.Context, to string, migration *Migration) error {
+	artifact, err := json.Marshal(migration)
+	if err != nil {
+		return err
+	}
+	inbox := migrationAnyInbox
+	if to != "" {
+		inbox = migrationInbox(to)
+	}
+	_, err = m.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, migrationAckKey(migration.CallID))
+		pipe.Set(ctx, migrationKey(migration.CallID), artifact, m.ttl)
+		pipe.LPush(ctx, inbox, migration.CallID)
+		return nil
+	})
+	return err
+}
+
+// Await waits for the instance taking the call over to answer. It returns
+// the error it answered with, or one when nobody answered within timeout;
+// the artifact is withdrawn then so the call can not be taken over late.
+func (m *Migrations) Await(ctx context.Context, callID string, timeout time.Duration) error {
+	result, err := m.client.BLPop(ctx, timeout, migrationAckKey(callID)).Result()
+	if errors.Is(err, redis.Nil) {
+		if m.client.Del(ctx, migrationKey(callID)).Val() == 0 {
+			// taken just now, its answer is on the way
+			result, err = m.client.BLPop(ctx, timeout, migrationAckKey(callID)).Result()
+		} else {
+			return errors.New("no instance took the call over in time")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if message := result[1]; message != "" {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// Next waits up to timeout for a call offered to instance or to any
+// instance. It returns nil when none was offered, or the one offered was
+// withdrawn before it could be taken.
+func (m *Migrations) Next(ctx context.Context, instance string, timeout time.Duration) (*Migration, error) {
+	result, err := m.client.BRPop(ctx, timeout, migrationInbox(instance), migrationAnyInbox).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := m.client.GetDel(ctx, migrationKey(result[1])).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var migration Migration
+	if err := json.Unmarshal(artifact, &migration); err != nil {
+		return nil, err
+	}
+	return &migration, nil
+}
+
+// Ack answers the instance the call was taken from, with the error when it
+// could not be taken over.
+func (m *Migrations) Ack(ctx context.Context, callID string, failure error) error {
+	message := ""
+	if failure != nil {
+		message = failure.Error()
+	}
+	_, err := m.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, migrationAckKey(callID), message)
+		pipe.Expire(ctx, migrationAckKey(callID), m.ttl)
+		return nil
+	})
+	return err
+}
+
+// adopted are the states of conversations migrated to this process, until
+// the session resuming them takes them.
+var adopted sync.Map
+
+// Adopt keeps the state a migrated conversation resumes with.
+func Adopt(conversationID uint64, state *State) {
+	if state != nil {
+		adopted.Store(conversationID, state)
+	}
+}
+
+// TakeAdopted returns the state kept for a migrated conversation and forgets
+// it, nil when there is none.
+func TakeAdopted(conversationID uint64) *State {
+	state, ok := adopted.LoadAndDelete(conversationID)
+	if !ok {
+		return nil
+	}
+	return state.(*State)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sessionstate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_Next(t *testing.T) {
+	ctx := context.Background()
+	db, mock := redismock.NewClientMock()
+	migrations := NewMigrations(db, time.Minute)
+
+	migration := &Migration{CallID: "call", From: "a", Channel: json.RawMessage(`{"callId":"call"}`), State: &State{ConversationID: 42}}
+	artifact, err := json.Marshal(migration)
+	require.NoError(t, err)
+
+	mock.ExpectBRPop(time.Second, migrationInbox("b"), migrationAnyInbox).SetVal([]string{migrationInbox("b"), "call"})
+	mock.ExpectGetDel(migrationKey("call")).SetVal(string(artifact))
+	got, err := migrations.Next(ctx, "b", time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "a", got.From)
+	assert.Equal(t, uint64(42), got.State.ConversationID)
+	assert.JSONEq(t, `{"callId":"call"}`, string(got.Channel))
+
+	mock.ExpectBRPop(time.Second, migrationInbox("b"), migrationAnyInbox).SetVal([]string{migrationAnyInbox, "gone"})
+	mock.ExpectGetDel(migrationKey("gone")).RedisNil()
+	got, err = migrations.Next(ctx, "b", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, got, "a withdrawn call is not taken over")
+
+	mock.ExpectBRPop(time.Second, migrationInbox("b"), migrationAnyInbox).RedisNil()
+	got, err = migrations.Next(ctx, "b", time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrations_Await(t *testing.T) {
+	ctx := context.Background()
+	db, mock := redismock.NewClientMock()
+	migrations := NewMigrations(db, time.Minute)
+
+	mock.ExpectBLPop(time.Second, migrationAckKey("call")).SetVal([]string{migrationAckKey("call"), ""})
+	assert.NoError(t, migrations.Await(ctx, "call", time.Second))
+
+	mock.ExpectBLPop(time.Second, migrationAckKey("call")).SetVal([]string{migrationAckKey("call"), "no sip config"})
+	assert.EqualError(t, migrations.Await(ctx, "call", time.Second), "no sip config")
+
+	mock.ExpectBLPop(time.Second, migrationAckKey("call")).RedisNil()
+	mock.ExpectDel(migrationKey("call")).SetVal(1)
+	assert.Error(t, migrations.Await(ctx, "call", time.Second), "nobody took the call over")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdopt(t *testing.T) {
+	assert.Nil(t, TakeAdopted(7))
+	Adopt(7, &State{ConversationID: 7})
+	state := TakeAdopted(7)
+	require.NotNil(t, state)
+	assert.Equal(t, uint64(7), state.ConversationID)
+	assert.Nil(t, TakeAdopted(7), "an adopted state is resumed once")
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_sessionstate
+
+import "sync"
+
+// sessions are the live sessions of this process by conversation id. A
+// conversation is only found on the instance that hosts its call.
+var sessions sync.Map
+
+// Register makes a live session capturable until the returned function is
+// called. A session registered later for the same conversation, e.g. after
+// a reconnect, replaces it and is not removed by the earlier one.
+func Register(conversationID uint64, source Source) func() {
+	entry := &source
+	sessions.Store(conversationID, entry)
+	return func() {
+		sessions.CompareAndDelete(conversationID, entry)
+	}
+}
+
+// Lookup returns the live session of a conversation.
+func Lookup(conversationID uint64) (Source, bool) {
+	entry, ok := sessions.Load(conversationID)
+	if !ok {
+		return nil, false
+	}
+	return *entry.(*Source), true
+}
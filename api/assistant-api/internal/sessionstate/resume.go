@@ -0,0 +1,74 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_sessionstate
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OptionResumeToken is the initialization option a client reconnecting to a
+// dropped session passes its resume token in. The session hands the token
+// out in the same option of the initialization it sends back.
+const OptionResumeToken = "rapida.resume_token"
+
+// resumeKeyPrefix namespaces the states kept for resumption. Keys are the
+// hash of the token, the token itself is never stored.
+const resumeKeyPrefix = "rapida:session:resume:"
+
+// NewResumeToken returns an unguessable token for a resumable session.
+func NewResumeToken() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func resumeKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return resumeKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// Resumes keeps the state of dropped sessions for ttl, so a client that
+// reconnects in time continues the same conversation on any instance.
+type Resumes struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewResumes keeps states in client for ttl.
+func NewResumes(client redis.UniversalClient, ttl time.Duration) *Resumes {
+	return &Resumes{client: client, ttl: ttl}
+}
+
+// Save keeps the state of a session under its token, replacing the one kept
+// at an earlier drop.
+func (r *Resumes) Save(ctx context.Context, token string, state *State) error {
+	artifact, err := Encode(state)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, resumeKey(token), artifact, r.ttl).Err()
+}
+
+// Take returns the state kept under token and forgets it, a token resumes a
+// single session. It returns nil when there is none or it expired.
+func (r *Resumes) Take(ctx context.Context, token string) (*State, error) {
+	artifact, err := r.client.GetDel(ctx, resumeKey(token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Decode(artifact)
+}
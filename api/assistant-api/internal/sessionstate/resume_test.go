@@ -0,0 +1,50 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sessionstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeToken(t *testing.T) {
+	a, b := NewResumeToken(), NewResumeToken()
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+	assert.NotContains(t, resumeKey(a), a)
+	assert.Equal(t, resumeKey(a), resumeKey(a))
+}
+
+func TestResumes_SaveTake(t *testing.T) {
+	ctx := context.Background()
+	db, mock := redismock.NewClientMock()
+	resumes := NewResumes(db, 5*time.Minute)
+
+	state := &State{ConversationID: 42, Mode: "text", Histories: []Message{{Role: "user", Content: "hi"}}}
+	artifact, err := Encode(state)
+	require.NoError(t, err)
+
+	mock.ExpectSet(resumeKey("token"), artifact, 5*time.Minute).SetVal("OK")
+	require.NoError(t, resumes.Save(ctx, "token", state))
+
+	mock.ExpectGetDel(resumeKey("token")).SetVal(string(artifact))
+	got, err := resumes.Take(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), got.ConversationID)
+	assert.Equal(t, state.Histories, got.Histories)
+
+	mock.ExpectGetDel(resumeKey("token")).RedisNil()
+	got, err = resumes.Take(ctx, "token")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
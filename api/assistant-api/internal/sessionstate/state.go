@@ -0,0 +1,170 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_sessionstate captures the in-memory state of a live
+// conversation as a portable artifact, so an incident can be reproduced by
+// restoring it into a sandbox conversation.
+package internal_sessionstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Version is the version of the artifact layout written by Encode.
+const Version = 1
+
+// OptionKey is the conversation option a restored conversation carries its
+// artifact in, the session seeds itself from it when resumed.
+const OptionKey = "rapida.restore"
+
+// MetadataKeyRestoredFrom is the conversation metadata naming the
+// conversation a restored one was captured from.
+const MetadataKeyRestoredFrom = "rapida.restored_from"
+
+// ErrVersion is returned for artifacts of an unknown layout.
+var ErrVersion = errors.New("unsupported session state version")
+
+// State is the in-memory state of a conversation at one moment.
+type State struct {
+	Version    int       `json:"version"`
+	CapturedAt time.Time `json:"capturedAt"`
+
+	ProjectID                uint64 `json:"projectId,string"`
+	OrganizationID           uint64 `json:"organizationId,string"`
+	AssistantID              uint64 `json:"assistantId,string"`
+	AssistantProviderModelID uint64 `json:"assistantProviderModelId,string"`
+	ConversationID           uint64 `json:"conversationId,string"`
+	Source                   string `json:"source"`
+	Mode                     string `json:"mode"`
+
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Options   map[string]interface{} `json:"options,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+
+	// Histories is the dialogue as the session keeps it.
+	Histories []Message `json:"histories,omitempty"`
+	// ModelContext is what the model is prompted with, tool calls and
+	// results included, as protojson. Empty for executors that keep it
+	// remotely.
+	ModelContext []json.RawMessage `json:"modelContext,omitempty"`
+	PendingTools []ToolCall        `json:"pendingTools,omitempty"`
+
+	Scratchpad map[string]interface{} `json:"scratchpad,omitempty"`
+	Flow       Flow                   `json:"flow"`
+	Buffers    Buffers                `json:"buffers"`
+}
+
+// Message is one turn of the dialogue.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ToolCall is a tool the model asked for that has no result yet.
+type ToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// Flow is where the conversation stands.
+type Flow struct {
+	MessageID        string `json:"messageId"`
+	Interaction      string `json:"interaction"`
+	OnHold           bool   `json:"onHold,omitempty"`
+	Spelling         string `json:"spelling,omitempty"` // kind of value spelling mode expects
+	SpeechEntity     string `json:"speechEntity,omitempty"`
+	Dictating        bool   `json:"dictating,omitempty"`
+	SpeakingProfile  string `json:"speakingProfile,omitempty"`
+	SpeechDegraded   bool   `json:"speechDegraded,omitempty"`
+	AnsweredBy       string `json:"answeredBy,omitempty"`
+	Handoffs         int    `json:"handoffs,omitempty"`
+	Dictations       int    `json:"dictations,omitempty"`
+	IdleTimeoutCount uint64 `json:"idleTimeoutCount,omitempty"`
+}
+
+// Buffers describes the audio in flight, how much is still to be heard or
+// held back rather than the audio itself. Durations are in ms from the
+// capture.
+type Buffers struct {
+	AssistantAudibleMs int64 `json:"assistantAudibleMs,omitempty"`
+	CallerSpeakingMs   int64 `json:"callerSpeakingMs,omitempty"`
+	DuckedMs           int64 `json:"duckedMs,omitempty"`
+	Recording          bool  `json:"recording,omitempty"`
+	TranscriptStreams  int   `json:"transcriptStreams,omitempty"`
+}
+
+// Source is implemented by live sessions that can be captured.
+type Source interface {
+	// SessionState captures the current state of the session.
+	SessionState() *State
+}
+
+// ModelContext converts model messages for the artifact and lists the tool
+// calls among them that have no result yet.
+func ModelContext(messages []*protos.Message) ([]json.RawMessage, []ToolCall) {
+	out := make([]json.RawMessage, 0, len(messages))
+	var pending []ToolCall
+	for _, msg := range messages {
+		if raw, err := protojson.Marshal(msg); err == nil {
+			out = append(out, raw)
+		}
+		for _, call := range msg.GetAssistant().GetToolCalls() {
+			pending = append(pending, ToolCall{
+				ID:        call.GetId(),
+				Name:      call.GetFunction().GetName(),
+				Arguments: call.GetFunction().GetArguments(),
+			})
+		}
+		for _, result := range msg.GetTool().GetTools() {
+			for i, call := range pending {
+				if call.ID == result.GetId() {
+					pending = append(pending[:i], pending[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return out, pending
+}
+
+// Encode serializes the state as an artifact.
+func Encode(s *State) ([]byte, error) {
+	s.Version = Version
+	return json.Marshal(s)
+}
+
+// Decode reads an artifact written by Encode.
+func Decode(artifact []byte) (*State, error) {
+	var s State
+	if err := json.Unmarshal(artifact, &s); err != nil {
+		return nil, fmt.Errorf("invalid session state: %w", err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("%w %d", ErrVersion, s.Version)
+	}
+	return &s, nil
+}
+
+// FromOptions returns the state a restored conversation was created from.
+func FromOptions(opts utils.Option) (*State, bool) {
+	artifact, err := opts.GetString(OptionKey)
+	if err != nil || artifact == "" {
+		return nil, false
+	}
+	s, err := Decode([]byte(artifact))
+	if err != nil {
+		return nil, false
+	}
+	return s, true
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sessionstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixed struct{ state *State }
+
+func (f fixed) SessionState() *State { return f.state }
+
+func TestEncodeDecode(t *testing.T) {
+	in := &State{
+		CapturedAt:     time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC),
+		ConversationID: 18446744073709551615,
+		Histories:      []Message{{Role: "user", Content: "hi"}},
+		Scratchpad:     map[string]interface{}{"order": "A-1"},
+		Flow:           Flow{MessageID: "m1", OnHold: true},
+	}
+	artifact, err := Encode(in)
+	require.NoError(t, err)
+	assert.Contains(t, string(artifact), `"conversationId":"18446744073709551615"`)
+
+	out, err := Decode(artifact)
+	require.NoError(t, err)
+	assert.Equal(t, Version, out.Version)
+	assert.Equal(t, in.ConversationID, out.ConversationID)
+	assert.Equal(t, in.Histories, out.Histories)
+	assert.Equal(t, in.Flow, out.Flow)
+	assert.True(t, in.CapturedAt.Equal(out.CapturedAt))
+
+	_, err = Decode([]byte(`{"version":99}`))
+	assert.ErrorIs(t, err, ErrVersion)
+	_, err = Decode([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestFromOptions(t *testing.T) {
+	artifact, err := Encode(&State{AssistantID: 7})
+	require.NoError(t, err)
+
+	s, ok := FromOptions(utils.Option{OptionKey: string(artifact)})
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), s.AssistantID)
+
+	_, ok = FromOptions(utils.Option{})
+	assert.False(t, ok)
+	_, ok = FromOptions(utils.Option{OptionKey: "{}"})
+	assert.False(t, ok, "no version")
+}
+
+func TestModelContext_PendingTools(t *testing.T) {
+	call := func(id, name string) *protos.ToolCall {
+		return &protos.ToolCall{Id: id, Function: &protos.FunctionCall{Name: name, Arguments: `{"q":1}`}}
+	}
+	messages := []*protos.Message{
+		{Role: "user", Message: &protos.Message_User{User: &protos.UserMessage{Content: "book it"}}},
+		{Role: "assistant", Message: &protos.Message_Assistant{Assistant: &protos.AssistantMessage{ToolCalls: []*protos.ToolCall{call("t1", "lookup"), call("t2", "book")}}}},
+		{Role: "tool", Message: &protos.Message_Tool{Tool: &protos.ToolMessage{Tools: []*protos.ToolMessage_Tool{{Id: "t1", Name: "lookup", Content: "ok"}}}}},
+	}
+
+	raw, pending := ModelContext(messages)
+	assert.Len(t, raw, 3)
+	assert.Contains(t, string(raw[0]), "book it")
+	assert.Equal(t, []ToolCall{{ID: "t2", Name: "book", Arguments: `{"q":1}`}}, pending)
+}
+
+func TestRegistry(t *testing.T) {
+	first := fixed{&State{Flow: Flow{MessageID: "a"}}}
+	unregister := Register(42, first)
+	got, ok := Lookup(42)
+	require.True(t, ok)
+	assert.Equal(t, "a", got.SessionState().Flow.MessageID)
+
+	// a reconnect replaces the session, the stale unregister leaves it
+	unregisterSecond := Register(42, fixed{&State{Flow: Flow{MessageID: "b"}}})
+	unregister()
+	got, ok = Lookup(42)
+	require.True(t, ok)
+	assert.Equal(t, "b", got.SessionState().Flow.MessageID)
+
+	unregisterSecond()
+	_, ok = Lookup(42)
+	assert.False(t, ok)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_sessiontoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/rapidaai/api/assistant-api/config"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/types"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrDisabled is returned when no WebTalk session secret is configured.
+	ErrDisabled = errors.New("webtalk session tokens are not configured")
+	// ErrRevoked is returned for a token revoked before it expired.
+	ErrRevoked = errors.New("session token was revoked")
+)
+
+// issuer tells session tokens apart from the other tokens signed by rapida.
+const issuer = "rapida-webtalk"
+
+// revokedKeyPrefix namespaces revoked tokens by id. Entries expire with the
+// token, an expired token is refused anyway.
+const revokedKeyPrefix = "rapida:session:token:revoked:"
+
+func revokedKey(tokenID string) string {
+	return revokedKeyPrefix + tokenID
+}
+
+// claims of a session token, the end user is the subject.
+type claims struct {
+	jwt.RegisteredClaims
+	ProjectId      uint64 `json:"projectId"`
+	OrganizationId uint64 `json:"organizationId"`
+	AssistantId    uint64 `json:"assistantId"`
+	Origin         string `json:"origin,omitempty"`
+}
+
+func (c *claims) scope(token string) *types.SessionScope {
+	return &types.SessionScope{
+		ProjectId:      &c.ProjectId,
+		OrganizationId: &c.OrganizationId,
+		AssistantId:    c.AssistantId,
+		UserId:         c.Subject,
+		Origin:         c.Origin,
+		TokenId:        c.ID,
+		CurrentToken:   token,
+	}
+}
+
+// Tokens mints the short-lived tokens browsers open WebTalk sessions with
+// and checks them, see types.SessionScope. It implements
+// types.ClaimAuthenticator for the session middleware.
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+	maxTTL time.Duration
+	client func() redis.UniversalClient
+}
+
+// NewTokens signs tokens with the configured secret and keeps revocations in
+// redis. Without a configuration every call fails with ErrDisabled.
+func NewTokens(cfg *config.WebTalkSessionConfig, redis connectors.RedisConnector) *Tokens {
+	if cfg == nil || cfg.Secret == "" {
+		return &Tokens{}
+	}
+	return &Tokens{
+		secret: []byte(cfg.Secret),
+		ttl:    cfg.TTL(),
+		maxTTL: cfg.MaxTTL(),
+		client: redis.GetConnection,
+	}
+}
+
+// Enabled reports whether tokens can be minted.
+func (t *Tokens) Enabled() bool {
+	return t.secret != nil
+}
+
+// Mint returns a token of the project of auth for talking to assistantID as
+// userID from pages of origin, and when it expires. An empty userID or
+// origin binds the token to none. ttl is capped by the configured maximum,
+// the configured default when zero.
+func (t *Tokens) Mint(auth types.SimplePrinciple, assistantID uint64, userID, origin string, ttl time.Duration) (string, time.Time, error) {
+	if !t.Enabled() {
+		return "", time.Time{}, ErrDisabled
+	}
+	if !auth.HasProject() || !auth.HasOrganization() || assistantID == 0 {
+		return "", time.Time{}, errors.New("a session token needs a project and an assistant")
+	}
+	if ttl <= 0 {
+		ttl = t.ttl
+	}
+	ttl = min(ttl, t.maxTTL)
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		ProjectId:      *auth.GetCurrentProjectId(),
+		OrganizationId: *auth.GetCurrentOrganizationId(),
+		AssistantId:    assistantID,
+		Origin:         origin,
+	}).SignedString(t.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error signing session token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// parse checks the signature, issuer and expiry of token.
+func (t *Tokens) parse(token string) (*claims, error) {
+	if !t.Enabled() {
+		return nil, ErrDisabled
+	}
+	c := &claims{}
+	if _, err := jwt.ParseWithClaims(token, c, func(*jwt.Token) (interface{}, error) {
+		return t.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	); err != nil {
+		return nil, fmt.Errorf("invalid session token: %w", err)
+	}
+	return c, nil
+}
+
+// Claim implements types.ClaimAuthenticator, it resolves a valid token that
+// was not revoked to its scope.
+func (t *Tokens) Claim(ctx context.Context, token string) (*types.PlainClaimPrinciple[*types.SessionScope], error) {
+	c, err := t.parse(token)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := t.client().Exists(ctx, revokedKey(c.ID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("unable to check the session token: %w", err)
+	}
+	if revoked > 0 {
+		return nil, ErrRevoked
+	}
+	return &types.PlainClaimPrinciple[*types.SessionScope]{Info: c.scope(token)}, nil
+}
+
+// Revoke refuses token from now on, sessions it opened go on. Only tokens of
+// projectID are revoked, an expired token needs no revocation.
+func (t *Tokens) Revoke(ctx context.Context, token string, projectID uint64) error {
+	c, err := t.parse(token)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if c.ProjectId != projectID {
+		return errors.New("session token was not minted for this project")
+	}
+	ttl := time.Until(c.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	return t.client().Set(ctx, revokedKey(c.ID), 1, ttl).Err()
+}
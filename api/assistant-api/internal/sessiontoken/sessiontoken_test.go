@@ -0,0 +1,114 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_sessiontoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/rapidaai/pkg/types"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTokens(t *testing.T) (*Tokens, redismock.ClientMock) {
+	t.Helper()
+	db, mock := redismock.NewClientMock()
+	return &Tokens{
+		secret: []byte("secret"),
+		ttl:    5 * time.Minute,
+		maxTTL: time.Hour,
+		client: func() redis.UniversalClient { return db },
+	}, mock
+}
+
+func projectScope() *types.ProjectScope {
+	projectID, organizationID := uint64(2), uint64(1)
+	return &types.ProjectScope{ProjectId: &projectID, OrganizationId: &organizationID}
+}
+
+func TestTokens_MintClaim(t *testing.T) {
+	tokens, mock := newTestTokens(t)
+	token, expiresAt, err := tokens.Mint(projectScope(), 3, "user-1", "https://example.com", 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), expiresAt, time.Second)
+
+	c, err := tokens.parse(token)
+	require.NoError(t, err)
+	mock.ExpectExists(revokedKey(c.ID)).SetVal(0)
+	auth, err := tokens.Claim(context.Background(), token)
+	require.NoError(t, err)
+	assert.True(t, auth.Info.IsAuthenticated())
+	assert.Equal(t, uint64(2), *auth.Info.GetCurrentProjectId())
+	assert.Equal(t, uint64(3), auth.Info.AssistantId)
+	assert.Equal(t, "user-1", auth.Info.UserId)
+	assert.Equal(t, "https://example.com", auth.Info.Origin)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokens_MintCapsTTL(t *testing.T) {
+	tokens, _ := newTestTokens(t)
+	_, expiresAt, err := tokens.Mint(projectScope(), 3, "", "", 24*time.Hour)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Second)
+}
+
+func TestTokens_RefusesForgedAndExpired(t *testing.T) {
+	tokens, _ := newTestTokens(t)
+	forger := &Tokens{secret: []byte("other"), ttl: time.Minute, maxTTL: time.Hour}
+	forged, _, err := forger.Mint(projectScope(), 3, "", "", 0)
+	require.NoError(t, err)
+	_, err = tokens.Claim(context.Background(), forged)
+	assert.Error(t, err)
+
+	tokens.ttl = -time.Minute
+	tokens.maxTTL = -time.Minute
+	expired, _, err := tokens.Mint(projectScope(), 3, "", "", 0)
+	require.NoError(t, err)
+	_, err = tokens.Claim(context.Background(), expired)
+	assert.Error(t, err)
+}
+
+func TestTokens_Revoke(t *testing.T) {
+	tokens, mock := newTestTokens(t)
+	token, _, err := tokens.Mint(projectScope(), 3, "user-1", "", 0)
+	require.NoError(t, err)
+	c, err := tokens.parse(token)
+	require.NoError(t, err)
+
+	assert.Error(t, tokens.Revoke(context.Background(), token, 9), "tokens of other projects are not revoked")
+
+	mock.CustomMatch(func(expected, actual []interface{}) error {
+		if actual[0] != "set" || actual[1] != revokedKey(c.ID) {
+			return errors.New("unexpected command")
+		}
+		// the revocation is kept until the token expires
+		if px, ok := actual[4].(int64); !ok || px <= 0 || px > (5*time.Minute).Milliseconds() {
+			return errors.New("unexpected ttl")
+		}
+		return nil
+	}).ExpectSet(revokedKey(c.ID), 1, 5*time.Minute).SetVal("OK")
+	require.NoError(t, tokens.Revoke(context.Background(), token, 2))
+
+	mock.ExpectExists(revokedKey(c.ID)).SetVal(1)
+	_, err = tokens.Claim(context.Background(), token)
+	assert.ErrorIs(t, err, ErrRevoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokens_Disabled(t *testing.T) {
+	tokens := NewTokens(nil, nil)
+	assert.False(t, tokens.Enabled())
+	_, _, err := tokens.Mint(projectScope(), 3, "", "", 0)
+	assert.ErrorIs(t, err, ErrDisabled)
+	_, err = tokens.Claim(context.Background(), "token")
+	assert.ErrorIs(t, err, ErrDisabled)
+}
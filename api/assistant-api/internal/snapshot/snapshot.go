@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_snapshot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/rapidaai/protos"
+)
+
+// Snapshots are configured on the speech to text options of a deployment.
+// A user turn transcribed below the threshold gets the stretch of the
+// recording it was heard in attached, widened by the padding in ms on both
+// ends:
+//
+//	listen.snapshot.threshold = 0.6
+//	listen.snapshot.padding   = 500
+const (
+	OptionsKeyThreshold = "listen.snapshot.threshold"
+	OptionsKeyPadding   = "listen.snapshot.padding"
+)
+
+// DefaultPadding is how much of the recording around the turn is kept when
+// the deployment does not say.
+const DefaultPadding = 300 * time.Millisecond
+
+// Policy decides which turns get a snapshot.
+type Policy struct {
+	Threshold float64
+	Padding   time.Duration
+}
+
+// FromOptions reads the policy configured in opts, nil when snapshots are
+// off.
+func FromOptions(opts utils.Option) *Policy {
+	threshold, err := opts.GetFloat64(OptionsKeyThreshold)
+	if err != nil || threshold <= 0 {
+		return nil
+	}
+	policy := &Policy{Threshold: threshold, Padding: DefaultPadding}
+	if padding, err := opts.GetFloat64(OptionsKeyPadding); err == nil && padding >= 0 {
+		policy.Padding = time.Duration(padding) * time.Millisecond
+	}
+	return policy
+}
+
+// Snapshot locates a misheard turn in the recording of the conversation.
+type Snapshot struct {
+	From       time.Duration
+	To         time.Duration
+	Confidence float64
+}
+
+// Metrics returns the snapshot as metrics of the message, offsets in ms.
+func (s Snapshot) Metrics() []*protos.Metric {
+	return []*protos.Metric{
+		{
+			Name:        type_enums.STT_CONFIDENCE.String(),
+			Value:       fmt.Sprintf("%.4f", s.Confidence),
+			Description: "Lowest confidence of the speech to text among the transcripts of the turn",
+		},
+		{
+			Name:        type_enums.AUDIO_SNAPSHOT_FROM.String(),
+			Value:       fmt.Sprintf("%d", s.From.Milliseconds()),
+			Description: "Offset in ms into the recording where the audio of the turn starts",
+		},
+		{
+			Name:        type_enums.AUDIO_SNAPSHOT_TO.String(),
+			Value:       fmt.Sprintf("%d", s.To.Milliseconds()),
+			Description: "Offset in ms into the recording where the audio of the turn ends",
+		},
+	}
+}
+
+// Turn follows one turn of the user: where it starts in the recording and
+// the lowest confidence any of its final transcripts came with. It is safe
+// for concurrent use.
+type Turn struct {
+	mu         sync.Mutex
+	started    bool
+	from       time.Duration
+	confidence float64
+	heard      bool
+}
+
+// Begin marks where the turn starts, later calls before End are ignored.
+func (t *Turn) Begin(offset time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.started {
+		t.started, t.from = true, offset
+	}
+}
+
+// Heard records the confidence of a final transcript of the turn.
+func (t *Turn) Heard(confidence float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.heard || confidence < t.confidence {
+		t.confidence = confidence
+	}
+	t.heard = true
+}
+
+// End closes the turn at offset and returns its snapshot when the policy
+// asks for one. The turn is reset either way.
+func (t *Turn) End(policy *Policy, offset time.Duration) (Snapshot, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	started, from, confidence, heard := t.started, t.from, t.confidence, t.heard
+	t.started, t.from, t.confidence, t.heard = false, 0, 0, false
+
+	if policy == nil || !heard || confidence >= policy.Threshold {
+		return Snapshot{}, false
+	}
+	if !started || from > offset {
+		from = offset
+	}
+	return Snapshot{
+		From:       max(from-policy.Padding, 0),
+		To:         offset + policy.Padding,
+		Confidence: confidence,
+	}, true
+}
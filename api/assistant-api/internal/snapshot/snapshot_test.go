@@ -0,0 +1,81 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_snapshot
+
+import (
+	"testing"
+	"time"
+
+	type_enums "github.com/rapidaai/pkg/types/enums"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromOptions(t *testing.T) {
+	assert.Nil(t, FromOptions(utils.Option{}))
+	assert.Nil(t, FromOptions(utils.Option{OptionsKeyThreshold: "0"}))
+	assert.Nil(t, FromOptions(utils.Option{OptionsKeyThreshold: "high"}))
+
+	policy := FromOptions(utils.Option{OptionsKeyThreshold: "0.6"})
+	require.NotNil(t, policy)
+	assert.Equal(t, 0.6, policy.Threshold)
+	assert.Equal(t, DefaultPadding, policy.Padding)
+
+	policy = FromOptions(utils.Option{OptionsKeyThreshold: 0.5, OptionsKeyPadding: "0"})
+	require.NotNil(t, policy)
+	assert.Equal(t, time.Duration(0), policy.Padding)
+}
+
+func TestTurn_LowConfidence(t *testing.T) {
+	policy := &Policy{Threshold: 0.6, Padding: 200 * time.Millisecond}
+	var turn Turn
+	turn.Begin(3 * time.Second)
+	turn.Begin(4 * time.Second) // still the same turn
+	turn.Heard(0.9)
+	turn.Heard(0.4)
+
+	snapshot, ok := turn.End(policy, 5*time.Second)
+	require.True(t, ok)
+	assert.Equal(t, 2800*time.Millisecond, snapshot.From)
+	assert.Equal(t, 5200*time.Millisecond, snapshot.To)
+	assert.Equal(t, 0.4, snapshot.Confidence)
+
+	// the next turn starts afresh
+	turn.Heard(0.9)
+	_, ok = turn.End(policy, 6*time.Second)
+	assert.False(t, ok)
+}
+
+func TestTurn_NoSnapshot(t *testing.T) {
+	var turn Turn
+	turn.Begin(time.Second)
+	turn.Heard(0.1)
+	_, ok := turn.End(nil, 2*time.Second)
+	assert.False(t, ok, "snapshots off")
+
+	turn.Begin(time.Second)
+	_, ok = turn.End(&Policy{Threshold: 0.6}, 2*time.Second)
+	assert.False(t, ok, "nothing transcribed")
+}
+
+func TestTurn_WithoutBegin(t *testing.T) {
+	var turn Turn
+	turn.Heard(0.3)
+	snapshot, ok := turn.End(&Policy{Threshold: 0.6, Padding: time.Second}, 500*time.Millisecond)
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), snapshot.From, "clamped to the start of the recording")
+	assert.Equal(t, 1500*time.Millisecond, snapshot.To)
+}
+
+func TestSnapshot_Metrics(t *testing.T) {
+	metrics := Snapshot{From: 1200 * time.Millisecond, To: 3400 * time.Millisecond, Confidence: 0.42}.Metrics()
+	require.Len(t, metrics, 3)
+	assert.Equal(t, type_enums.STT_CONFIDENCE.String(), metrics[0].GetName())
+	assert.Equal(t, "0.4200", metrics[0].GetValue())
+	assert.Equal(t, "1200", metrics[1].GetValue())
+	assert.Equal(t, "3400", metrics[2].GetValue())
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_spelling turns a spelled out transcript ("j as in juliet,
+// o h n at gmail dot com") into the value the user meant, validates it and
+// prepares a read back for confirmation.
+package internal_spelling
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kinds of values that can be captured.
+const (
+	KindEmail        = "email"
+	KindAlphanumeric = "alphanumeric"
+)
+
+var kindPatterns = map[string]*regexp.Regexp{
+	KindEmail:        regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9\-]+(\.[a-z0-9\-]+)*\.[a-z]{2,}$`),
+	KindAlphanumeric: regexp.MustCompile(`^[A-Z0-9]+$`),
+}
+
+// natoAlphabet maps the NATO phonetic alphabet and common variants to letters.
+var natoAlphabet = map[string]string{
+	"alpha": "a", "alfa": "a", "bravo": "b", "charlie": "c", "delta": "d",
+	"echo": "e", "foxtrot": "f", "golf": "g", "hotel": "h", "india": "i",
+	"juliet": "j", "juliett": "j", "kilo": "k", "lima": "l", "mike": "m",
+	"november": "n", "oscar": "o", "papa": "p", "quebec": "q", "romeo": "r",
+	"sierra": "s", "tango": "t", "uniform": "u", "victor": "v", "whiskey": "w",
+	"whisky": "w", "xray": "x", "x-ray": "x", "yankee": "y", "zulu": "z",
+}
+
+// letterNames are how recognizers transcribe single spoken letters.
+var letterNames = map[string]string{
+	"ay": "a", "bee": "b", "be": "b", "see": "c", "sea": "c", "cee": "c",
+	"dee": "d", "ee": "e", "ef": "f", "eff": "f", "gee": "g", "aitch": "h",
+	"eye": "i", "jay": "j", "kay": "k", "el": "l", "ell": "l", "em": "m",
+	"en": "n", "oh": "o", "pee": "p", "pea": "p", "cue": "q", "queue": "q",
+	"are": "r", "ar": "r", "ess": "s", "tee": "t", "tea": "t", "you": "u",
+	"vee": "v", "double-u": "w", "doubleu": "w", "double-you": "w", "ex": "x", "why": "y",
+	"zee": "z", "zed": "z",
+}
+
+// letterPairs are letter names recognizers transcribe as two words, matched
+// before "double" is taken as a repeat.
+var letterPairs = map[string]string{
+	"double you": "w",
+}
+
+var digitNames = map[string]string{
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+}
+
+var symbolNames = map[string]string{
+	"at": "@", "dot": ".", "period": ".", "point": ".", "underscore": "_",
+	"dash": "-", "hyphen": "-", "minus": "-", "plus": "+",
+}
+
+// readBackNames are the words symbols are read back with.
+var readBackNames = map[rune]string{
+	'@': "at", '.': "dot", '_': "underscore", '-': "dash", '+': "plus",
+}
+
+// fillers carry no character.
+var fillers = map[string]bool{
+	"and": true, "then": true, "uh": true, "um": true, "so": true,
+	"capital": true, "uppercase": true, "upper": true, "lowercase": true,
+	"lower": true, "small": true, "case": true, "letter": true, "sign": true,
+	"space": true, "it's": true, "its": true, "is": true,
+}
+
+var repeats = map[string]int{"double": 2, "triple": 3}
+
+// Capture decodes and validates one kind of spelled value.
+type Capture struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// NewCapture returns a capture for kind. A non empty pattern replaces the
+// validation of the kind, it is matched against the decoded value.
+func NewCapture(kind, pattern string) (*Capture, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = KindAlphanumeric
+	}
+	capture := &Capture{kind: kind, pattern: kindPatterns[kind]}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validation pattern: %w", err)
+		}
+		capture.pattern = re
+	}
+	if capture.pattern == nil {
+		return nil, fmt.Errorf("unsupported spelling kind %q", kind)
+	}
+	return capture, nil
+}
+
+// Kind returns the kind of value captured.
+func (c *Capture) Kind() string {
+	return c.kind
+}
+
+// Result is a decoded transcript.
+type Result struct {
+	// Value is what the user spelled.
+	Value string
+
+	// ReadBack spells the value character by character for confirmation.
+	ReadBack string
+
+	// Valid reports whether Value matches the validation of the capture.
+	Valid bool
+}
+
+// Parse decodes a spelled transcript.
+func (c *Capture) Parse(speech string) Result {
+	value := Decode(speech)
+	if c.kind == KindEmail {
+		value = strings.ToLower(value)
+	} else {
+		value = strings.ToUpper(value)
+	}
+	return Result{
+		Value:    value,
+		ReadBack: ReadBack(value),
+		Valid:    value != "" && c.pattern.MatchString(value),
+	}
+}
+
+// Decode converts spelled speech to the characters it spells: NATO words and
+// letter names become letters, number words digits, "at" and "dot" symbols,
+// "double"/"triple" repeat the next character unless "double you" is w, and
+// "b as in bravo" is b. Words that are neither are kept as they are, so
+// "john at gmail dot com" works too.
+func Decode(speech string) string {
+	tokens := tokenize(speech)
+	var sb strings.Builder
+	repeat := 1
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if i+1 < len(tokens) {
+			if c, ok := letterPairs[token+" "+tokens[i+1]]; ok {
+				token = c
+				i++
+			}
+		}
+		// "b as in bravo", "b for bravo", "b like bravo": skip the example word
+		if i+2 < len(tokens) && tokens[i+1] == "as" && tokens[i+2] == "in" {
+			i += 3
+		} else if i+1 < len(tokens) && (tokens[i+1] == "for" || tokens[i+1] == "like") {
+			i += 2
+		}
+		if n, ok := repeats[token]; ok {
+			repeat = n
+			continue
+		}
+		if fillers[token] {
+			continue
+		}
+		sb.WriteString(strings.Repeat(character(token), repeat))
+		repeat = 1
+	}
+	return sb.String()
+}
+
+func character(token string) string {
+	if len(token) == 1 {
+		return token
+	}
+	for _, names := range []map[string]string{natoAlphabet, letterNames, digitNames, symbolNames} {
+		if c, ok := names[token]; ok {
+			return c
+		}
+	}
+	return token
+}
+
+// tokenize lower cases the transcript and splits it into words, breaking up
+// "j-o-h-n" and "J. O. H. N." style output of recognizers.
+func tokenize(speech string) []string {
+	speech = strings.NewReplacer(",", " ", ";", " ", "?", " ", "!", " ").Replace(strings.ToLower(speech))
+	// "at the rate" is how "@" is said in some regions
+	speech = strings.ReplaceAll(speech, "at the rate", "at")
+	var tokens []string
+	for _, field := range strings.Fields(speech) {
+		if parts := strings.Split(field, "-"); len(parts) > 1 && singleCharacters(parts) {
+			tokens = append(tokens, parts...)
+			continue
+		}
+		if len(field) == 2 && field[1] == '.' {
+			field = field[:1]
+		} else if len(field) > 1 {
+			field = strings.TrimSuffix(field, ".")
+		}
+		if field != "" {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+func singleCharacters(parts []string) bool {
+	for _, p := range parts {
+		if len(p) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadBack spells value character by character, symbols by name.
+func ReadBack(value string) string {
+	words := make([]string, 0, len(value))
+	for _, r := range value {
+		if name, ok := readBackNames[r]; ok {
+			words = append(words, name)
+			continue
+		}
+		words = append(words, strings.ToUpper(string(r)))
+	}
+	return strings.Join(words, ", ")
+}
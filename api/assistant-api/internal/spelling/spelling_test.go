@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_spelling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		speech string
+		want   string
+	}{
+		{"J O H N", "john"},
+		{"j-o-h-n", "john"},
+		{"J. O. H. N.", "john"},
+		{"juliet oscar hotel november", "john"},
+		{"b as in bravo, e, double t", "bett"},
+		{"d for delta, triple seven", "d777"},
+		{"double you, double you, double you dot example", "www.example"},
+		{"double you as in whiskey, double oh", "woo"},
+		{"john at gmail dot com", "john@gmail.com"},
+		{"john underscore doe at the rate example dot org", "john_doe@example.org"},
+		{"capital A, um, bee see", "abc"},
+		{"x-ray zulu one two", "xz12"},
+		{"john.smith@gmail.com", "john.smith@gmail.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.speech, func(t *testing.T) {
+			assert.Equal(t, tt.want, Decode(tt.speech))
+		})
+	}
+}
+
+func TestCapture_Email(t *testing.T) {
+	c, err := NewCapture(KindEmail, "")
+	require.NoError(t, err)
+
+	r := c.Parse("J O H N at Gmail dot com")
+	assert.Equal(t, "john@gmail.com", r.Value)
+	assert.True(t, r.Valid)
+	assert.Equal(t, "J, O, H, N, at, G, M, A, I, L, dot, C, O, M", r.ReadBack)
+
+	assert.False(t, c.Parse("john gmail com").Valid)
+}
+
+func TestCapture_Alphanumeric(t *testing.T) {
+	c, err := NewCapture("", "")
+	require.NoError(t, err)
+	assert.Equal(t, KindAlphanumeric, c.Kind())
+
+	r := c.Parse("a b c one two three")
+	assert.Equal(t, "ABC123", r.Value)
+	assert.True(t, r.Valid)
+	assert.False(t, c.Parse("").Valid)
+}
+
+func TestCapture_Pattern(t *testing.T) {
+	c, err := NewCapture(KindAlphanumeric, `^[A-Z]{2}[0-9]{4}$`)
+	require.NoError(t, err)
+	assert.True(t, c.Parse("alpha bravo one two three four").Valid)
+	assert.False(t, c.Parse("alpha one two three four").Valid)
+
+	_, err = NewCapture(KindAlphanumeric, "[")
+	assert.Error(t, err)
+	_, err = NewCapture("postcode", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_startup profiles the cold start of a conversation, from
+// the channel accepting the caller to the session being ready to talk. The
+// phases after the initialization overlap, each is timed on its own and the
+// slowest of them gates the session.
+package internal_startup
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+// Phase is a step of the startup of a conversation.
+type Phase string
+
+const (
+	// Transport runs from the channel accepting the caller to the
+	// initialization of the conversation, the channel setting up its
+	// transport.
+	Transport Phase = "transport"
+	// Assistant is loading the assistant, or claiming its standby.
+	Assistant Phase = "assistant"
+	// Credentials is fetching the credentials of the providers from the
+	// vault, it runs alongside Conversation.
+	Credentials Phase = "credentials"
+	// Conversation is creating or resuming the conversation.
+	Conversation Phase = "conversation"
+	// LLM is initializing the assistant executor.
+	LLM Phase = "llm"
+	// TextToSpeech is connecting text to speech.
+	TextToSpeech Phase = "text_to_speech"
+	// SpeechToText is connecting speech to text, VAD and the denoiser. The
+	// session is ready before it ends, the caller's first words wait for it.
+	SpeechToText Phase = "speech_to_text"
+	// EndOfSpeech is initializing end of speech detection.
+	EndOfSpeech Phase = "end_of_speech"
+	// Ready runs from the initialization to the session being ready.
+	Ready Phase = "ready"
+	// Total runs from the channel accepting the caller to the session
+	// being ready.
+	Total Phase = "total"
+)
+
+// Phases in the order of the startup.
+var Phases = []Phase{Transport, Assistant, Credentials, Conversation, LLM, TextToSpeech, SpeechToText, EndOfSpeech, Ready, Total}
+
+// Profile times the startup phases of one conversation. A nil Profile
+// times nothing.
+type Profile struct {
+	mu      sync.Mutex
+	started time.Time
+	phases  map[Phase]time.Duration
+	pending sync.WaitGroup
+}
+
+// NewProfile starts a profile at started, when the channel accepted the
+// caller.
+func NewProfile(started time.Time) *Profile {
+	return &Profile{started: started, phases: make(map[Phase]time.Duration, len(Phases))}
+}
+
+// Elapsed returns the time since the profile started.
+func (p *Profile) Elapsed() time.Duration {
+	if p == nil {
+		return 0
+	}
+	return time.Since(p.started)
+}
+
+// Observe records how long phase took, a phase observed again keeps the
+// first.
+func (p *Profile) Observe(phase Phase, took time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.phases[phase]; !ok {
+		p.phases[phase] = took
+	}
+}
+
+// Track starts phase and returns the function ending it. Wait waits for
+// the phases tracked.
+func (p *Profile) Track(phase Phase) func() {
+	if p == nil {
+		return func() {}
+	}
+	start := time.Now()
+	p.pending.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.Observe(phase, time.Since(start))
+			p.pending.Done()
+		})
+	}
+}
+
+// Wait waits for the phases tracked to end.
+func (p *Profile) Wait() {
+	if p != nil {
+		p.pending.Wait()
+	}
+}
+
+// Breakdown returns the phases observed so far.
+func (p *Profile) Breakdown() map[Phase]time.Duration {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	breakdown := make(map[Phase]time.Duration, len(p.phases))
+	for phase, took := range p.phases {
+		breakdown[phase] = took
+	}
+	return breakdown
+}
+
+// Metrics returns the phases observed as conversation metrics
+// (startup_text_to_speech_ms).
+func (p *Profile) Metrics() []*protos.Metric {
+	breakdown := p.Breakdown()
+	var metrics []*protos.Metric
+	for _, phase := range Phases {
+		took, ok := breakdown[phase]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, &protos.Metric{
+			Name:        "startup_" + string(phase) + "_ms",
+			Value:       strconv.FormatInt(took.Milliseconds(), 10),
+			Description: "Startup phase " + string(phase) + " of the conversation",
+		})
+	}
+	return metrics
+}
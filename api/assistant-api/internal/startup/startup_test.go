@@ -0,0 +1,73 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_startup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_Breakdown(t *testing.T) {
+	profile := NewProfile(time.Now().Add(-time.Second))
+	profile.Observe(Transport, 300*time.Millisecond)
+	profile.Observe(Transport, 500*time.Millisecond)
+	end := profile.Track(TextToSpeech)
+	end()
+	end()
+
+	breakdown := profile.Breakdown()
+	assert.Equal(t, 300*time.Millisecond, breakdown[Transport], "the first observation is kept")
+	assert.Contains(t, breakdown, TextToSpeech)
+	assert.GreaterOrEqual(t, profile.Elapsed(), time.Second)
+}
+
+func TestProfile_WaitForTrackedPhases(t *testing.T) {
+	profile := NewProfile(time.Now())
+	end := profile.Track(SpeechToText)
+
+	waited := make(chan struct{})
+	go func() {
+		profile.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("Wait returned before speech to text ended")
+	case <-time.After(20 * time.Millisecond):
+	}
+	end()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return")
+	}
+}
+
+func TestProfile_Metrics(t *testing.T) {
+	profile := NewProfile(time.Now())
+	profile.Observe(Total, 1500*time.Millisecond)
+	profile.Observe(Assistant, 40*time.Millisecond)
+
+	metrics := profile.Metrics()
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "startup_assistant_ms", metrics[0].GetName())
+	assert.Equal(t, "40", metrics[0].GetValue())
+	assert.Equal(t, "startup_total_ms", metrics[1].GetName())
+	assert.Equal(t, "1500", metrics[1].GetValue())
+}
+
+func TestProfile_Nil(t *testing.T) {
+	var profile *Profile
+	profile.Observe(LLM, time.Second)
+	profile.Track(LLM)()
+	profile.Wait()
+	assert.Nil(t, profile.Metrics())
+	assert.Zero(t, profile.Elapsed())
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_supervision
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// Serve runs the WebSocket protocol of a supervisor attached to session as
+// observer until either side ends, the observer is detached then.
+//
+// Protocol:
+//   - binary frames from the server carry audio, linear16 16 kHz mono PCM
+//     behind one byte naming the source: 1 the caller, 2 the assistant,
+//     3 both mixed
+//   - text frames from the server are JSON events, the first is
+//     {"type":"attached","role":"supervisor","mode":"listen"}, then
+//     {"type":"transcript","role":"user","text":"…","final":true},
+//     {"type":"barge","barging":true}, {"type":"error","error":"…"} and
+//     {"type":"end"} once the conversation ended
+//   - text frames from the supervisor are JSON commands:
+//     {"type":"mode","mode":"barge"}, {"type":"whisper","text":"…"} in the
+//     whisper or barge mode and {"type":"say","text":"…"} while barging
+//   - binary frames from a barging supervisor are their voice for the
+//     caller, linear16 16 kHz mono PCM
+func Serve(ctx context.Context, logger commons.Logger, conn *websocket.Conn, session Session, observer *Observer) {
+	defer observer.Detach()
+	s := &socket{logger: logger, conn: conn}
+	s.write(websocket.TextMessage, map[string]interface{}{"type": "attached", "mode": observer.Mode(), "role": "supervisor"})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.commands(ctx, session, observer)
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case e, ok := <-observer.Events():
+			if !ok {
+				s.write(websocket.TextMessage, map[string]interface{}{"type": "end"})
+				s.close()
+				return
+			}
+			s.event(e)
+		}
+	}
+}
+
+// command is a message of the supervisor.
+type command struct {
+	Type string `json:"type"`
+	Mode string `json:"mode"`
+	Text string `json:"text"`
+}
+
+type socket struct {
+	logger    commons.Logger
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+}
+
+// commands reads the supervisor's commands until the connection closes.
+func (s *socket) commands(ctx context.Context, session Session, observer *Observer) {
+	for {
+		messageType, message, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				s.logger.Errorf("supervision: unexpected websocket close error %v", err)
+			}
+			return
+		}
+		if messageType == websocket.BinaryMessage {
+			if len(message) == 0 || observer.Mode() != ModeBarge {
+				continue
+			}
+			if err := session.Play(ctx, message); err != nil {
+				s.fail(err)
+			}
+			continue
+		}
+		var c command
+		if err := json.Unmarshal(message, &c); err != nil {
+			s.fail(errors.New("invalid command"))
+			continue
+		}
+		if err := s.apply(ctx, session, observer, c); err != nil {
+			s.fail(err)
+		}
+	}
+}
+
+// apply carries out a command of the supervisor.
+func (s *socket) apply(ctx context.Context, session Session, observer *Observer, c command) error {
+	switch c.Type {
+	case "mode":
+		mode, err := ParseMode(c.Mode)
+		if err != nil {
+			return err
+		}
+		return observer.SetMode(mode)
+	case "whisper":
+		if !observer.Mode().Whispers() {
+			return errors.New("whispering needs the whisper or barge mode")
+		}
+		if strings.TrimSpace(c.Text) == "" {
+			return errors.New("nothing to whisper")
+		}
+		return session.Whisper(ctx, c.Text)
+	case "say":
+		if observer.Mode() != ModeBarge {
+			return errors.New("speaking to the caller needs the barge mode")
+		}
+		if strings.TrimSpace(c.Text) == "" {
+			return errors.New("nothing to say")
+		}
+		return session.Say(ctx, c.Text)
+	}
+	return errors.New("unknown command " + c.Type)
+}
+
+// event sends e to the supervisor.
+func (s *socket) event(e Event) {
+	switch e.Type {
+	case EventAudio:
+		frame := make([]byte, 1+len(e.Audio))
+		frame[0] = byte(e.Source)
+		copy(frame[1:], e.Audio)
+		s.write(websocket.BinaryMessage, frame)
+	case EventTranscript:
+		s.write(websocket.TextMessage, map[string]interface{}{"type": e.Type, "role": e.Role, "text": e.Text, "final": e.Final, "time": e.Time})
+	case EventBarge:
+		s.write(websocket.TextMessage, map[string]interface{}{"type": e.Type, "barging": e.Barging, "time": e.Time})
+	}
+}
+
+func (s *socket) fail(err error) {
+	s.write(websocket.TextMessage, map[string]interface{}{"type": "error", "error": err.Error()})
+}
+
+// write sends a binary frame or a JSON text frame.
+func (s *socket) write(messageType int, v interface{}) {
+	payload, ok := v.([]byte)
+	if !ok {
+		var err error
+		if payload, err = json.Marshal(v); err != nil {
+			s.logger.Errorf("supervision: unable to encode event: %v", err)
+			return
+		}
+	}
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	if err := s.conn.WriteMessage(messageType, payload); err != nil {
+		s.logger.Debugf("supervision: unable to write to the supervisor: %v", err)
+	}
+}
+
+// close ends the connection with a close frame.
+func (s *socket) close() {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "conversation ended"), time.Now().Add(time.Second))
+}
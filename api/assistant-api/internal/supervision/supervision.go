@@ -0,0 +1,399 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_supervision lets supervisors follow a live conversation.
+// A supervisor listens to the caller and the assistant and reads the
+// transcript, whispers guidance only the assistant hears, or barges in and
+// talks to the caller while the assistant is muted.
+package internal_supervision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+)
+
+// Mode is what an attached supervisor may do.
+type Mode string
+
+const (
+	// ModeListen only follows the conversation.
+	ModeListen Mode = "listen"
+	// ModeWhisper follows it and gives the assistant guidance.
+	ModeWhisper Mode = "whisper"
+	// ModeBarge takes the conversation over, the assistant is muted and the
+	// supervisor talks to the caller. One supervisor barges at a time.
+	ModeBarge Mode = "barge"
+)
+
+// ParseMode returns the mode named s, listen when s is empty.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "":
+		return ModeListen, nil
+	case ModeListen, ModeWhisper, ModeBarge:
+		return Mode(s), nil
+	}
+	return "", fmt.Errorf("unknown supervision mode %q", s)
+}
+
+// Whispers reports whether the mode may give the assistant guidance.
+func (m Mode) Whispers() bool {
+	return m == ModeWhisper || m == ModeBarge
+}
+
+// Layout is how a supervisor receives the audio of the conversation.
+type Layout string
+
+const (
+	// LayoutSplit sends the caller's and the assistant's audio as they come,
+	// each tagged with its source.
+	LayoutSplit Layout = "split"
+	// LayoutMixed sends both mixed into one stream.
+	LayoutMixed Layout = "mixed"
+)
+
+// ParseLayout returns the layout named s, split when s is empty.
+func ParseLayout(s string) (Layout, error) {
+	switch Layout(s) {
+	case "":
+		return LayoutSplit, nil
+	case LayoutSplit, LayoutMixed:
+		return Layout(s), nil
+	}
+	return "", fmt.Errorf("unknown audio layout %q", s)
+}
+
+// Source is whose audio an audio event carries.
+type Source byte
+
+const (
+	SourceCaller    Source = 1
+	SourceAssistant Source = 2
+	SourceMixed     Source = 3
+)
+
+// EventType is the kind of an Event.
+type EventType string
+
+const (
+	// EventAudio carries linear16 16 kHz mono audio of Source.
+	EventAudio EventType = "audio"
+	// EventTranscript carries a transcript segment of Role.
+	EventTranscript EventType = "transcript"
+	// EventBarge tells a supervisor barged in or handed the conversation
+	// back to the assistant.
+	EventBarge EventType = "barge"
+)
+
+// Event is what an observer receives.
+type Event struct {
+	Type EventType
+	Time time.Time
+
+	// audio
+	Source Source
+	Audio  []byte
+
+	// transcript
+	Role  string
+	Text  string
+	Final bool
+
+	// barge
+	Barging bool
+}
+
+// Session is a live conversation supervisors can attach to.
+type Session interface {
+	// Supervision is the tap of the conversation, nil once it ended.
+	Supervision() *Tap
+	// Whisper gives the assistant guidance the caller does not hear.
+	Whisper(ctx context.Context, guidance string) error
+	// Say speaks text to the caller in the assistant's voice.
+	Say(ctx context.Context, text string) error
+	// Play plays linear16 16 kHz mono audio to the caller.
+	Play(ctx context.Context, audio []byte) error
+}
+
+var (
+	// ErrClosed is returned once the conversation ended.
+	ErrClosed = errors.New("conversation has ended")
+	// ErrBarged is returned when another supervisor already barged in.
+	ErrBarged = errors.New("another supervisor has barged in")
+)
+
+const (
+	// eventBuffer is how many events an observer falls behind before events
+	// are dropped, about two seconds of split audio.
+	eventBuffer = 256
+	// frameBytes is 20 ms of linear16 16 kHz mono audio, the mixing unit.
+	frameBytes = 640
+	// maxMixBacklog bounds the assistant audio waiting to be mixed. Speech
+	// synthesis runs ahead of playback, the caller's audio is the clock.
+	maxMixBacklog = 500 * frameBytes
+)
+
+// Tap is where a conversation hands its audio and transcript to the
+// supervisors attached to it. Publishing without observers is a single
+// atomic load.
+type Tap struct {
+	mu        sync.Mutex
+	observers map[*Observer]struct{}
+	attached  atomic.Int32
+	barging   *Observer
+	closed    bool
+
+	// onBarge is told when the conversation is taken over and handed back
+	onBarge func(barging bool)
+}
+
+// NewTap returns the tap of a conversation, onBarge is called outside the
+// tap when a supervisor barges in or hands the conversation back.
+func NewTap(onBarge func(barging bool)) *Tap {
+	return &Tap{observers: make(map[*Observer]struct{}), onBarge: onBarge}
+}
+
+// Attach attaches a supervisor in mode receiving audio in layout.
+func (t *Tap) Attach(mode Mode, layout Layout) (*Observer, error) {
+	o := &Observer{tap: t, mode: mode, layout: layout, events: make(chan Event, eventBuffer)}
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if mode == ModeBarge && t.barging != nil {
+		t.mu.Unlock()
+		return nil, ErrBarged
+	}
+	t.observers[o] = struct{}{}
+	t.attached.Add(1)
+	barged := mode == ModeBarge
+	if barged {
+		t.barging = o
+		t.broadcast(Event{Type: EventBarge, Time: time.Now(), Barging: true})
+	}
+	t.mu.Unlock()
+	if barged {
+		t.onBarge(true)
+	}
+	return o, nil
+}
+
+// Attached reports whether any supervisor is attached.
+func (t *Tap) Attached() bool {
+	return t.attached.Load() > 0
+}
+
+// Barging reports whether a supervisor has taken the conversation over.
+func (t *Tap) Barging() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.barging != nil
+}
+
+// Caller hands audio of the caller to the observers.
+func (t *Tap) Caller(audio []byte) {
+	t.audio(SourceCaller, audio)
+}
+
+// Assistant hands audio the caller hears from the assistant to the
+// observers.
+func (t *Tap) Assistant(audio []byte) {
+	t.audio(SourceAssistant, audio)
+}
+
+func (t *Tap) audio(source Source, audio []byte) {
+	if !t.Attached() || len(audio) == 0 {
+		return
+	}
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for o := range t.observers {
+		if o.layout == LayoutMixed {
+			o.mix(source, audio, now)
+			continue
+		}
+		o.send(Event{Type: EventAudio, Time: now, Source: source, Audio: append([]byte(nil), audio...)})
+	}
+}
+
+// Interrupt drops the assistant audio waiting to be mixed, the caller no
+// longer hears it.
+func (t *Tap) Interrupt() {
+	if !t.Attached() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for o := range t.observers {
+		o.assistant = o.assistant[:0]
+	}
+}
+
+// Transcript hands a transcript segment to the observers.
+func (t *Tap) Transcript(role, text string, final bool) {
+	if !t.Attached() || text == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.broadcast(Event{Type: EventTranscript, Time: time.Now(), Role: role, Text: text, Final: final})
+}
+
+// Close detaches every observer, their events end.
+func (t *Tap) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for o := range t.observers {
+		close(o.events)
+		delete(t.observers, o)
+	}
+	t.attached.Store(0)
+	t.barging = nil
+}
+
+// broadcast sends e to every observer, t.mu is held.
+func (t *Tap) broadcast(e Event) {
+	for o := range t.observers {
+		o.send(e)
+	}
+}
+
+// setMode switches the mode of o.
+func (t *Tap) setMode(o *Observer, mode Mode) error {
+	t.mu.Lock()
+	if _, ok := t.observers[o]; !ok {
+		t.mu.Unlock()
+		return ErrClosed
+	}
+	if mode == ModeBarge && t.barging != nil && t.barging != o {
+		t.mu.Unlock()
+		return ErrBarged
+	}
+	o.mode = mode
+	changed, barging := false, false
+	switch {
+	case mode == ModeBarge && t.barging == nil:
+		t.barging, changed, barging = o, true, true
+	case mode != ModeBarge && t.barging == o:
+		t.barging, changed = nil, true
+	}
+	if changed {
+		t.broadcast(Event{Type: EventBarge, Time: time.Now(), Barging: barging})
+	}
+	t.mu.Unlock()
+	if changed {
+		t.onBarge(barging)
+	}
+	return nil
+}
+
+// detach removes o, a barging observer hands the conversation back.
+func (t *Tap) detach(o *Observer) {
+	t.mu.Lock()
+	if _, ok := t.observers[o]; !ok {
+		t.mu.Unlock()
+		return
+	}
+	close(o.events)
+	delete(t.observers, o)
+	t.attached.Add(-1)
+	released := t.barging == o
+	if released {
+		t.barging = nil
+		t.broadcast(Event{Type: EventBarge, Time: time.Now(), Barging: false})
+	}
+	t.mu.Unlock()
+	if released {
+		t.onBarge(false)
+	}
+}
+
+// Observer is a supervisor attached to a conversation.
+type Observer struct {
+	tap    *Tap
+	layout Layout
+	events chan Event
+
+	// guarded by tap.mu
+	mode      Mode
+	caller    []byte // caller audio short of a frame, mixed layout only
+	assistant []byte // assistant audio waiting for caller audio, mixed layout only
+	dropped   uint64
+}
+
+// Events are the events of the conversation, closed when the observer is
+// detached or the conversation ended.
+func (o *Observer) Events() <-chan Event {
+	return o.events
+}
+
+// Mode returns the mode of the observer.
+func (o *Observer) Mode() Mode {
+	o.tap.mu.Lock()
+	defer o.tap.mu.Unlock()
+	return o.mode
+}
+
+// SetMode switches the mode of the observer, ErrBarged when it asks to
+// barge in while another supervisor does.
+func (o *Observer) SetMode(mode Mode) error {
+	return o.tap.setMode(o, mode)
+}
+
+// Dropped returns how many events the observer was too slow for.
+func (o *Observer) Dropped() uint64 {
+	o.tap.mu.Lock()
+	defer o.tap.mu.Unlock()
+	return o.dropped
+}
+
+// Detach detaches the observer.
+func (o *Observer) Detach() {
+	o.tap.detach(o)
+}
+
+// send queues e without waiting, tap.mu is held.
+func (o *Observer) send(e Event) {
+	select {
+	case o.events <- e:
+	default:
+		o.dropped++
+	}
+}
+
+// mix queues audio of source for mixing and sends the frames the caller's
+// audio completes, tap.mu is held.
+func (o *Observer) mix(source Source, audio []byte, now time.Time) {
+	if source == SourceAssistant {
+		o.assistant = append(o.assistant, audio...)
+		if over := len(o.assistant) - maxMixBacklog; over > 0 {
+			o.assistant = o.assistant[over:]
+		}
+		return
+	}
+	o.caller = append(o.caller, audio...)
+	for len(o.caller) >= frameBytes {
+		frame := make([]byte, frameBytes)
+		copy(frame, o.caller[:frameBytes])
+		o.caller = o.caller[frameBytes:]
+		n := min(len(o.assistant), frameBytes)
+		internal_audio.MixLinear16(frame, o.assistant[:n])
+		o.assistant = o.assistant[n:]
+		o.send(Event{Type: EventAudio, Time: now, Source: SourceMixed, Audio: frame})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_supervision
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samples returns n linear16 samples of value v.
+func samples(n int, v int16) []byte {
+	b := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint16(b[2*i:], uint16(v))
+	}
+	return b
+}
+
+func sample(b []byte, i int) int16 {
+	return int16(binary.LittleEndian.Uint16(b[2*i:]))
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ModeListen, mode)
+	mode, err = ParseMode("barge")
+	require.NoError(t, err)
+	assert.True(t, mode.Whispers())
+	assert.False(t, ModeListen.Whispers())
+	_, err = ParseMode("coach")
+	assert.Error(t, err)
+}
+
+func TestTap_SplitAudioAndTranscript(t *testing.T) {
+	tap := NewTap(func(bool) {})
+	tap.Caller([]byte{1, 2})
+	assert.False(t, tap.Attached(), "nothing is sent without observers")
+
+	o, err := tap.Attach(ModeListen, LayoutSplit)
+	require.NoError(t, err)
+	caller := []byte{1, 2}
+	tap.Caller(caller)
+	caller[0] = 9
+	tap.Assistant([]byte{3, 4})
+	tap.Transcript("user", "hello", true)
+
+	e := <-o.Events()
+	assert.Equal(t, EventAudio, e.Type)
+	assert.Equal(t, SourceCaller, e.Source)
+	assert.Equal(t, []byte{1, 2}, e.Audio, "the audio is copied")
+	e = <-o.Events()
+	assert.Equal(t, SourceAssistant, e.Source)
+	e = <-o.Events()
+	assert.Equal(t, EventTranscript, e.Type)
+	assert.Equal(t, "user", e.Role)
+	assert.Equal(t, "hello", e.Text)
+	assert.True(t, e.Final)
+}
+
+func TestTap_MixedAudioFollowsTheCaller(t *testing.T) {
+	tap := NewTap(func(bool) {})
+	o, err := tap.Attach(ModeListen, LayoutMixed)
+	require.NoError(t, err)
+
+	// the assistant runs ahead, it waits for the caller's audio
+	tap.Assistant(samples(frameBytes/2+10, 30000))
+	assert.Len(t, o.Events(), 0)
+
+	tap.Caller(samples(frameBytes/2, 10000))
+	e := <-o.Events()
+	assert.Equal(t, SourceMixed, e.Source)
+	require.Len(t, e.Audio, frameBytes)
+	assert.Equal(t, int16(32767), sample(e.Audio, 0), "the mix clips at full scale")
+
+	// the rest of the assistant's audio fills part of the next frame
+	tap.Caller(samples(frameBytes/2, -100))
+	e = <-o.Events()
+	assert.Equal(t, int16(29900), sample(e.Audio, 9))
+	assert.Equal(t, int16(-100), sample(e.Audio, 10))
+
+	// an interruption drops what the caller will not hear
+	tap.Assistant(samples(10, 500))
+	tap.Interrupt()
+	tap.Caller(samples(frameBytes/2, 1))
+	e = <-o.Events()
+	assert.Equal(t, int16(1), sample(e.Audio, 0))
+}
+
+func TestTap_OneSupervisorBarges(t *testing.T) {
+	var barges []bool
+	tap := NewTap(func(barging bool) { barges = append(barges, barging) })
+
+	first, err := tap.Attach(ModeBarge, LayoutSplit)
+	require.NoError(t, err)
+	assert.True(t, tap.Barging())
+	_, err = tap.Attach(ModeBarge, LayoutSplit)
+	assert.ErrorIs(t, err, ErrBarged)
+
+	second, err := tap.Attach(ModeWhisper, LayoutSplit)
+	require.NoError(t, err)
+	assert.ErrorIs(t, second.SetMode(ModeBarge), ErrBarged)
+
+	require.NoError(t, first.SetMode(ModeListen))
+	assert.False(t, tap.Barging())
+	require.NoError(t, second.SetMode(ModeBarge))
+
+	e := <-second.Events()
+	assert.Equal(t, EventBarge, e.Type)
+	assert.False(t, e.Barging)
+	e = <-second.Events()
+	assert.True(t, e.Barging)
+
+	// a barging supervisor leaving hands the conversation back
+	second.Detach()
+	assert.False(t, tap.Barging())
+	_, open := <-second.Events()
+	assert.False(t, open)
+	assert.Equal(t, []bool{true, false, true, false}, barges)
+}
+
+func TestTap_Close(t *testing.T) {
+	tap := NewTap(func(bool) {})
+	o, err := tap.Attach(ModeListen, LayoutSplit)
+	require.NoError(t, err)
+
+	tap.Close()
+	_, open := <-o.Events()
+	assert.False(t, open)
+	assert.False(t, tap.Attached())
+	o.Detach()
+
+	_, err = tap.Attach(ModeListen, LayoutSplit)
+	assert.ErrorIs(t, err, ErrClosed)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_telemetry_batch
+
+import (
+	"context"
+
+	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/types"
+)
+
+// The helpers below queue on the installed writer. Without one they write
+// through the conversation service right away, as before batching existed,
+// and return its error.
+
+// ConversationMetrics records metrics of a conversation.
+func ConversationMetrics(ctx context.Context, svc internal_services.AssistantConversationService, auth types.SimplePrinciple, assistantId, assistantConversationId uint64, metrics ...*types.Metric) error {
+	w := Active()
+	if w == nil {
+		_, err := svc.ApplyConversationMetrics(ctx, auth, assistantId, assistantConversationId, metrics)
+		return err
+	}
+	records := make([]*internal_services.TelemetryRecord, 0, len(metrics))
+	for _, m := range metrics {
+		records = append(records, &internal_services.TelemetryRecord{
+			AssistantId:             assistantId,
+			AssistantConversationId: assistantConversationId,
+			UserId:                  auth.GetUserId(),
+			Metric:                  m,
+		})
+	}
+	w.Add(records...)
+	return nil
+}
+
+// MessageMetrics records metrics of a message of a conversation.
+func MessageMetrics(ctx context.Context, svc internal_services.AssistantConversationService, auth types.SimplePrinciple, assistantConversationId uint64, messageId string, metrics ...*types.Metric) error {
+	w := Active()
+	if w == nil {
+		_, err := svc.ApplyMessageMetrics(ctx, auth, assistantConversationId, messageId, metrics)
+		return err
+	}
+	records := make([]*internal_services.TelemetryRecord, 0, len(metrics))
+	for _, m := range metrics {
+		records = append(records, &internal_services.TelemetryRecord{
+			AssistantConversationId: assistantConversationId,
+			UserId:                  auth.GetUserId(),
+			Metric:                  m,
+			MessageId:               messageId,
+		})
+	}
+	w.Add(records...)
+	return nil
+}
+
+// TelephonyEvents records events the telephony provider reported for a
+// conversation.
+func TelephonyEvents(ctx context.Context, svc internal_services.AssistantConversationService, auth types.SimplePrinciple, provider string, assistantId, assistantConversationId uint64, events ...*types.Event) error {
+	w := Active()
+	if w == nil {
+		_, err := svc.ApplyConversationTelephonyEvent(ctx, auth, provider, assistantId, assistantConversationId, events)
+		return err
+	}
+	records := make([]*internal_services.TelemetryRecord, 0, len(events))
+	for _, e := range events {
+		records = append(records, &internal_services.TelemetryRecord{
+			AssistantId:             assistantId,
+			AssistantConversationId: assistantConversationId,
+			UserId:                  auth.GetUserId(),
+			Event:                   e,
+			Provider:                provider,
+		})
+	}
+	w.Add(records...)
+	return nil
+}
+
+// ConversationEvents appends entries to the event log of a conversation.
+func ConversationEvents(ctx context.Context, svc internal_services.AssistantConversationService, events ...*internal_conversation_entity.AssistantConversationEvent) error {
+	w := Active()
+	if w == nil {
+		return svc.ApplyConversationEvents(ctx, events)
+	}
+	records := make([]*internal_services.TelemetryRecord, 0, len(events))
+	for _, e := range events {
+		records = append(records, &internal_services.TelemetryRecord{
+			AssistantId:             e.AssistantId,
+			AssistantConversationId: e.AssistantConversationId,
+			LogEvent:                e,
+		})
+	}
+	w.Add(records...)
+	return nil
+}
+
+// Flush writes what the installed writer holds, called when a call ends so
+// its telemetry is stored by the time the conversation is. The queue holds
+// other calls' records too, so the write gets the background timeout rather
+// than a deadline of the caller.
+func Flush(ctx context.Context) error {
+	w := Active()
+	if w == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	return w.Flush(ctx)
+}
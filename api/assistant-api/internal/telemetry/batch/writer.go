@@ -0,0 +1,184 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_telemetry_batch queues the metrics and telephony events
+// that calls record and writes them in batches. During a burst of calls every
+// status callback and talk loop metric used to be its own insert; the writer
+// turns them into one transaction per batch.
+package internal_telemetry_batch
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/commons"
+)
+
+const (
+	defaultBatchSize     = 200
+	defaultFlushInterval = 500 * time.Millisecond
+	defaultMaxBatches    = 20
+
+	// writeTimeout bounds a batch written in the background.
+	writeTimeout = 5 * time.Second
+)
+
+// Sink stores a batch of records.
+type Sink interface {
+	ApplyTelemetry(ctx context.Context, records []*internal_services.TelemetryRecord) error
+}
+
+// Writer buffers telemetry records and hands them to its sink in batches of
+// at most batchSize. The queue is written when it fills a batch, every
+// interval, and when Flush is called at the end of a call.
+type Writer struct {
+	logger     commons.Logger
+	sink       Sink
+	batchSize  int
+	interval   time.Duration
+	maxPending int
+
+	mu      sync.Mutex
+	pending []*internal_services.TelemetryRecord
+	dropped int
+
+	writeMu sync.Mutex // one batch reaches the sink at a time
+	full    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewWriter creates a writer, zero values take the defaults. Records queue up
+// right away but are only written in the background once Start ran.
+func NewWriter(logger commons.Logger, sink Sink, batchSize int, interval time.Duration, maxPending int) *Writer {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	if maxPending < batchSize {
+		maxPending = batchSize * defaultMaxBatches
+	}
+	return &Writer{
+		logger:     logger,
+		sink:       sink,
+		batchSize:  batchSize,
+		interval:   interval,
+		maxPending: maxPending,
+		full:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start writes the queue in the background until Stop.
+func (w *Writer) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *Writer) run(ctx context.Context) {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.full:
+		}
+		flushCtx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		w.Flush(flushCtx)
+		cancel()
+	}
+}
+
+// Stop ends the background writes and writes what is still queued.
+func (w *Writer) Stop(ctx context.Context) error {
+	close(w.stop)
+	<-w.done
+	return w.Flush(ctx)
+}
+
+// Add queues records. When the sink has been failing long enough for the
+// queue to reach its limit, the oldest records make room.
+func (w *Writer) Add(records ...*internal_services.TelemetryRecord) {
+	if len(records) == 0 {
+		return
+	}
+	w.mu.Lock()
+	w.pending = append(w.pending, records...)
+	if over := len(w.pending) - w.maxPending; over > 0 {
+		w.pending = w.pending[over:]
+		w.dropped += over
+	}
+	filled := len(w.pending) >= w.batchSize
+	w.mu.Unlock()
+
+	if filled {
+		select {
+		case w.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Pending returns the number of queued records.
+func (w *Writer) Pending() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.pending)
+}
+
+// Flush writes everything queued so far. Records of a batch the sink
+// rejects are dropped, telemetry is not worth holding up later calls for.
+func (w *Writer) Flush(ctx context.Context) error {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+
+	w.mu.Lock()
+	records := w.pending
+	w.pending = nil
+	dropped := w.dropped
+	w.dropped = 0
+	w.mu.Unlock()
+
+	if dropped > 0 {
+		w.logger.Warnf("telemetry queue was full, dropped the oldest %d records", dropped)
+	}
+
+	var firstErr error
+	for len(records) > 0 {
+		n := min(len(records), w.batchSize)
+		if err := w.sink.ApplyTelemetry(ctx, records[:n]); err != nil {
+			w.logger.Errorf("unable to write batch of %d telemetry records: %v", n, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		records = records[n:]
+	}
+	return firstErr
+}
+
+var active atomic.Pointer[Writer]
+
+// Install makes w the writer telemetry is recorded with, nil turns batching
+// off again.
+func Install(w *Writer) {
+	active.Store(w)
+}
+
+// Active returns the installed writer, nil when there is none.
+func Active() *Writer {
+	return active.Load()
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_telemetry_batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	mu      sync.Mutex
+	batches [][]*internal_services.TelemetryRecord
+	err     error
+	written chan struct{}
+}
+
+func newRecordingSink() *recordingSink {
+	return &recordingSink{written: make(chan struct{}, 16)}
+}
+
+func (s *recordingSink) ApplyTelemetry(ctx context.Context, records []*internal_services.TelemetryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]*internal_services.TelemetryRecord(nil), records...))
+	s.written <- struct{}{}
+	return s.err
+}
+
+func (s *recordingSink) sizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sizes := make([]int, 0, len(s.batches))
+	for _, b := range s.batches {
+		sizes = append(sizes, len(b))
+	}
+	return sizes
+}
+
+func metricRecord(conversationId uint64) *internal_services.TelemetryRecord {
+	return &internal_services.TelemetryRecord{AssistantConversationId: conversationId, Metric: types.NewMetric("STATUS", "COMPLETE", nil)}
+}
+
+func newTestWriter(sink Sink, batchSize int, interval time.Duration) *Writer {
+	logger, _ := commons.NewApplicationLogger()
+	return NewWriter(logger, sink, batchSize, interval, 0)
+}
+
+func TestWriter_FlushesFullBatch(t *testing.T) {
+	sink := newRecordingSink()
+	w := newTestWriter(sink, 3, time.Hour)
+	w.Start(context.Background())
+	defer w.Stop(context.Background())
+
+	w.Add(metricRecord(1), metricRecord(2))
+	w.Add(metricRecord(3))
+
+	select {
+	case <-sink.written:
+	case <-time.After(time.Second):
+		t.Fatal("full batch was not written")
+	}
+	assert.Equal(t, []int{3}, sink.sizes())
+	assert.Zero(t, w.Pending())
+}
+
+func TestWriter_FlushesOnInterval(t *testing.T) {
+	sink := newRecordingSink()
+	w := newTestWriter(sink, 100, 20*time.Millisecond)
+	w.Start(context.Background())
+	defer w.Stop(context.Background())
+
+	w.Add(metricRecord(1))
+	select {
+	case <-sink.written:
+	case <-time.After(time.Second):
+		t.Fatal("queued record was not written on the interval")
+	}
+	assert.Equal(t, []int{1}, sink.sizes())
+}
+
+func TestWriter_FlushSplitsBatches(t *testing.T) {
+	sink := newRecordingSink()
+	w := newTestWriter(sink, 2, time.Hour)
+	for i := uint64(0); i < 5; i++ {
+		w.Add(metricRecord(i))
+	}
+	require.NoError(t, w.Flush(context.Background()))
+	assert.Equal(t, []int{2, 2, 1}, sink.sizes())
+	assert.Zero(t, w.Pending())
+}
+
+func TestWriter_StopWritesQueue(t *testing.T) {
+	sink := newRecordingSink()
+	w := newTestWriter(sink, 100, time.Hour)
+	w.Start(context.Background())
+	w.Add(metricRecord(1), metricRecord(2))
+
+	require.NoError(t, w.Stop(context.Background()))
+	assert.Equal(t, []int{2}, sink.sizes())
+}
+
+func TestWriter_DropsOldestWhenFull(t *testing.T) {
+	sink := newRecordingSink()
+	logger, _ := commons.NewApplicationLogger()
+	w := NewWriter(logger, sink, 2, time.Hour, 3)
+	for i := uint64(1); i <= 5; i++ {
+		w.Add(metricRecord(i))
+	}
+	assert.Equal(t, 3, w.Pending())
+
+	require.NoError(t, w.Flush(context.Background()))
+	var conversations []uint64
+	for _, b := range sink.batches {
+		for _, r := range b {
+			conversations = append(conversations, r.AssistantConversationId)
+		}
+	}
+	assert.Equal(t, []uint64{3, 4, 5}, conversations)
+}
+
+func TestWriter_FailedBatchIsDropped(t *testing.T) {
+	sink := newRecordingSink()
+	sink.err = errors.New("connection refused")
+	w := newTestWriter(sink, 10, time.Hour)
+	w.Add(metricRecord(1))
+
+	assert.ErrorContains(t, w.Flush(context.Background()), "connection refused")
+	assert.Zero(t, w.Pending())
+}
+
+func TestConversationMetrics_QueuesOnActiveWriter(t *testing.T) {
+	sink := newRecordingSink()
+	w := newTestWriter(sink, 10, time.Hour)
+	Install(w)
+	t.Cleanup(func() { Install(nil) })
+
+	auth := &types.ServiceScope{ProjectId: utils.Ptr(uint64(7))}
+	require.NoError(t, ConversationMetrics(context.Background(), nil, auth, 1, 2, types.NewMetric("STATUS", "SUCCESS", nil)))
+	require.NoError(t, TelephonyEvents(context.Background(), nil, auth, "twilio", 1, 2, types.NewEvent("ringing", nil)))
+	require.NoError(t, MessageMetrics(context.Background(), nil, auth, 2, "msg-1", types.NewMetric("TIME_TAKEN", "12", nil)))
+	assert.Empty(t, sink.sizes())
+	assert.Equal(t, 3, w.Pending())
+
+	require.NoError(t, Flush(context.Background()))
+	require.Len(t, sink.batches, 1)
+	batch := sink.batches[0]
+	assert.Equal(t, uint64(1), batch[0].AssistantId)
+	assert.Equal(t, "twilio", batch[1].Provider)
+	assert.Equal(t, "ringing", batch[1].Event.EventType)
+	assert.Equal(t, "msg-1", batch[2].MessageId)
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_transcript
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// BatchInterval is the longest a segment waits before it is sent,
+	// MaxBatch the most segments sent in one request.
+	BatchInterval = 250 * time.Millisecond
+	MaxBatch      = 20
+
+	// MaxBackoff caps the wait between two attempts of a batch.
+	MaxBackoff = 5 * time.Second
+
+	// SignatureHeader carries the HMAC-SHA256 of a batch when the webhook has
+	// a signing secret, as t=<unix seconds>,v1=<hex digest of "<t>.<body>">.
+	SignatureHeader = "X-Rapida-Signature"
+
+	// queueSize bounds the segments waiting for a slow webhook, newer ones
+	// are dropped beyond it rather than holding up the call.
+	queueSize      = 512
+	defaultTimeout = 5 * time.Second
+	initialBackoff = 250 * time.Millisecond
+	maxResponse    = 64 << 10
+)
+
+// Segment is one update of the transcript. Interim segments of the user are
+// the speech recognized so far, those of the assistant the text it generated
+// since the previous one. Final segments are the complete message as
+// persisted, those of the user with the speaker who said it when speakers are
+// told apart.
+type Segment struct {
+	ID      string    `json:"id"`
+	Role    string    `json:"role"`
+	Speaker string    `json:"speaker,omitempty"`
+	Text    string    `json:"text"`
+	Final   bool      `json:"final"`
+	Time    time.Time `json:"time"`
+}
+
+// Batch is the body posted to the webhook. Sequence counts the batches of a
+// conversation from 1, a gap means a batch was lost.
+type Batch struct {
+	Event          string    `json:"event"`
+	AssistantID    string    `json:"assistantId"`
+	ConversationID string    `json:"conversationId"`
+	Sequence       uint64    `json:"sequence"`
+	Segments       []Segment `json:"segments"`
+}
+
+// Target is the webhook transcripts are streamed to.
+type Target struct {
+	URL              string
+	Method           string
+	Headers          map[string]string
+	Secret           string
+	Timeout          time.Duration
+	MaxRetries       uint32
+	RetryStatusCodes []string
+
+	// which segments the webhook subscribed to
+	Interim bool
+	Final   bool
+}
+
+// Result is the outcome of delivering a batch.
+type Result struct {
+	Status   int
+	Attempts uint32
+	Took     time.Duration
+	Request  []byte
+	Response []byte
+	Err      error
+}
+
+// Failed reports whether the batch did not reach the webhook.
+func (r Result) Failed() bool {
+	return r.Err != nil || r.Status < 200 || r.Status >= 300
+}
+
+// Sign returns the SignatureHeader value of body sent at t.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Publisher streams the transcript of a conversation to one webhook. Batches
+// are sent one at a time in order, a batch that fails is retried with
+// exponential backoff and handed to onFailure once it is given up on.
+type Publisher struct {
+	target         Target
+	assistantID    string
+	conversationID string
+	client         *http.Client
+	backoff        time.Duration
+	onFailure      func(Result)
+
+	mu       sync.RWMutex
+	closed   bool
+	in       chan Segment
+	done     chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+	sequence uint64
+}
+
+// NewPublisher starts streaming to target, onFailure may be nil.
+func NewPublisher(target Target, assistantID, conversationID string, onFailure func(Result)) *Publisher {
+	return newPublisher(target, assistantID, conversationID, onFailure, initialBackoff)
+}
+
+func newPublisher(target Target, assistantID, conversationID string, onFailure func(Result), backoff time.Duration) *Publisher {
+	if target.Timeout <= 0 {
+		target.Timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Publisher{
+		target:         target,
+		assistantID:    assistantID,
+		conversationID: conversationID,
+		client:         &http.Client{Timeout: target.Timeout},
+		backoff:        backoff,
+		onFailure:      onFailure,
+		in:             make(chan Segment, queueSize),
+		done:           make(chan struct{}),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	go p.run()
+	return p
+}
+
+// Publish queues a segment the webhook subscribed to. It never blocks and
+// returns false when the segment was dropped because the queue is full or
+// the publisher closed.
+func (p *Publisher) Publish(s Segment) bool {
+	if (s.Final && !p.target.Final) || (!s.Final && !p.target.Interim) {
+		return true
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
+	}
+	select {
+	case p.in <- s:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close sends what is still queued and stops. Delivery is abandoned when ctx
+// ends first.
+func (p *Publisher) Close(ctx context.Context) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.in)
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		p.cancel()
+		<-p.done
+	}
+	p.cancel()
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(BatchInterval)
+	defer ticker.Stop()
+
+	pending := make([]Segment, 0, MaxBatch)
+	flush := func() {
+		if len(pending) > 0 {
+			p.deliver(pending)
+			pending = make([]Segment, 0, MaxBatch)
+		}
+	}
+	for {
+		select {
+		case s, ok := <-p.in:
+			if !ok {
+				flush()
+				return
+			}
+			pending = append(pending, s)
+			if len(pending) >= MaxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (p *Publisher) deliver(segments []Segment) {
+	p.sequence++
+	body, err := json.Marshal(Batch{
+		Event:          "transcript",
+		AssistantID:    p.assistantID,
+		ConversationID: p.conversationID,
+		Sequence:       p.sequence,
+		Segments:       segments,
+	})
+	if err != nil {
+		p.fail(Result{Err: err})
+		return
+	}
+
+	started := time.Now()
+	result := Result{Request: body}
+	delay := p.backoff
+	for {
+		result.Attempts++
+		result.Status, result.Response, result.Err = p.post(body)
+		if !p.retryable(result) || result.Attempts > p.target.MaxRetries {
+			break
+		}
+		select {
+		case <-p.ctx.Done():
+			result.Err = p.ctx.Err()
+		case <-time.After(delay):
+			delay = min(delay*2, MaxBackoff)
+			continue
+		}
+		break
+	}
+	result.Took = time.Since(started)
+	if result.Failed() {
+		p.fail(result)
+	}
+}
+
+func (p *Publisher) fail(result Result) {
+	if p.onFailure != nil {
+		p.onFailure(result)
+	}
+}
+
+func (p *Publisher) post(body []byte) (int, []byte, error) {
+	method := p.target.Method
+	if method != http.MethodPut && method != http.MethodPatch {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(p.ctx, method, p.target.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	for k, v := range p.target.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.target.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(p.target.Secret, time.Now(), body))
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+	response, err := io.ReadAll(io.LimitReader(res.Body, maxResponse))
+	if err != nil {
+		return res.StatusCode, nil, fmt.Errorf("reading response: %w", err)
+	}
+	return res.StatusCode, response, nil
+}
+
+// retryable reports whether a failed attempt is worth repeating: transport
+// errors always are, statuses when the webhook lists them or, without such a
+// list, on 429 and server errors.
+func (p *Publisher) retryable(r Result) bool {
+	if r.Err != nil {
+		return p.ctx.Err() == nil
+	}
+	if len(p.target.RetryStatusCodes) > 0 {
+		return slices.Contains(p.target.RetryStatusCodes, strconv.Itoa(r.Status))
+	}
+	return r.Status == http.StatusTooManyRequests || r.Status >= 500
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_transcript
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhook struct {
+	mu       sync.Mutex
+	batches  []Batch
+	headers  []http.Header
+	bodies   [][]byte
+	statuses []int // answered in order, 200 once used up
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	status := http.StatusOK
+	if len(w.statuses) > 0 {
+		status, w.statuses = w.statuses[0], w.statuses[1:]
+	}
+	if status == http.StatusOK {
+		var batch Batch
+		json.Unmarshal(body, &batch)
+		w.batches = append(w.batches, batch)
+		w.headers = append(w.headers, r.Header.Clone())
+		w.bodies = append(w.bodies, body)
+	}
+	rw.WriteHeader(status)
+}
+
+func (w *webhook) received() []Batch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]Batch(nil), w.batches...)
+}
+
+func segment(role, text string, final bool) Segment {
+	return Segment{ID: "m1", Role: role, Text: text, Final: final, Time: time.Now()}
+}
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	sig := Sign("s3cret", at, []byte(`{"a":1}`))
+	assert.True(t, strings.HasPrefix(sig, "t=1700000000,v1="))
+	assert.Equal(t, sig, Sign("s3cret", at, []byte(`{"a":1}`)))
+	assert.NotEqual(t, sig, Sign("other", at, []byte(`{"a":1}`)))
+	assert.NotEqual(t, sig, Sign("s3cret", at, []byte(`{"a":2}`)))
+}
+
+func TestPublisher_BatchesInOrderAndSigns(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	p := newPublisher(Target{URL: server.URL, Secret: "s3cret", Headers: map[string]string{"X-Api-Key": "k"}, Interim: true, Final: true}, "1", "2", nil, time.Millisecond)
+	assert.True(t, p.Publish(segment("user", "hel", false)))
+	assert.True(t, p.Publish(segment("user", "hello", true)))
+	p.Close(context.Background())
+
+	batches := hook.received()
+	require.Len(t, batches, 1)
+	assert.Equal(t, uint64(1), batches[0].Sequence)
+	assert.Equal(t, "2", batches[0].ConversationID)
+	require.Len(t, batches[0].Segments, 2)
+	assert.Equal(t, "hel", batches[0].Segments[0].Text)
+	assert.True(t, batches[0].Segments[1].Final)
+
+	header := hook.headers[0]
+	assert.Equal(t, "k", header.Get("X-Api-Key"))
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	ts := strings.TrimPrefix(strings.Split(header.Get(SignatureHeader), ",")[0], "t=")
+	at, err := strconv.ParseInt(ts, 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("s3cret", time.Unix(at, 0), hook.bodies[0]), header.Get(SignatureHeader))
+}
+
+func TestPublisher_SplitsLargeBatches(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	p := newPublisher(Target{URL: server.URL, Final: true}, "1", "2", nil, time.Millisecond)
+	for i := 0; i < MaxBatch+1; i++ {
+		p.Publish(segment("assistant", "x", true))
+	}
+	p.Close(context.Background())
+
+	batches := hook.received()
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0].Segments, MaxBatch)
+	assert.Equal(t, uint64(2), batches[1].Sequence)
+}
+
+func TestPublisher_SubscribedSegmentsOnly(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	p := newPublisher(Target{URL: server.URL, Final: true}, "1", "2", nil, time.Millisecond)
+	p.Publish(segment("user", "hel", false))
+	p.Publish(segment("user", "hello", true))
+	p.Close(context.Background())
+
+	batches := hook.received()
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0].Segments, 1)
+	assert.Equal(t, "hello", batches[0].Segments[0].Text)
+	assert.False(t, p.Publish(segment("user", "late", true)), "closed")
+}
+
+func TestPublisher_RetriesWithBackoff(t *testing.T) {
+	hook := &webhook{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	var failures []Result
+	p := newPublisher(Target{URL: server.URL, Final: true, MaxRetries: 2}, "1", "2", func(r Result) { failures = append(failures, r) }, time.Millisecond)
+	p.Publish(segment("user", "hello", true))
+	p.Close(context.Background())
+
+	assert.Len(t, hook.received(), 1)
+	assert.Empty(t, failures)
+}
+
+func TestPublisher_GivesUp(t *testing.T) {
+	hook := &webhook{statuses: []int{500, 500, 500, 500}}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	var failures []Result
+	p := newPublisher(Target{URL: server.URL, Final: true, MaxRetries: 1}, "1", "2", func(r Result) { failures = append(failures, r) }, time.Millisecond)
+	p.Publish(segment("user", "hello", true))
+	p.Close(context.Background())
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, 500, failures[0].Status)
+	assert.Equal(t, uint32(2), failures[0].Attempts)
+	assert.NotEmpty(t, failures[0].Request)
+}
+
+func TestPublisher_RetryStatusCodes(t *testing.T) {
+	hook := &webhook{statuses: []int{500}}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	var failures []Result
+	// only 409 is retried, the 500 is given up on right away
+	p := newPublisher(Target{URL: server.URL, Final: true, MaxRetries: 3, RetryStatusCodes: []string{"409"}}, "1", "2", func(r Result) { failures = append(failures, r) }, time.Millisecond)
+	p.Publish(segment("user", "hello", true))
+	p.Close(context.Background())
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, uint32(1), failures[0].Attempts)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_transcript
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultAINotice closes every exported transcript whose assistant has not
+// configured a notice of its own, so a reader can always tell the replies
+// were generated.
+const DefaultAINotice = "This conversation was held with an AI assistant. Assistant replies are AI-generated and may contain mistakes."
+
+// footerSeparator sets the appended notices apart from the conversation.
+const footerSeparator = "---"
+
+// ContentType is the content type of a rendered transcript.
+const ContentType = "text/plain; charset=utf-8"
+
+// Line is one message of the conversation.
+type Line struct {
+	Role string
+	Body string
+	At   time.Time
+}
+
+// Footer is what the export pipeline appends below the conversation. Both
+// parts come from the assistant's transcript setting.
+type Footer struct {
+	AINotice    string
+	LegalFooter string
+}
+
+// Render writes the conversation as plain text, one message per line in
+// order, followed by the AI notice and the legal footer. It is the single
+// place transcripts leave the service, downloads and emails both go through
+// it so none of them can skip the footer.
+func Render(lines []Line, footer Footer) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		body := strings.TrimSpace(line.Body)
+		if body == "" {
+			continue
+		}
+		sb.WriteString("[")
+		sb.WriteString(line.At.UTC().Format(time.DateTime))
+		sb.WriteString("] ")
+		sb.WriteString(speaker(line.Role))
+		sb.WriteString(": ")
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	}
+
+	notice := strings.TrimSpace(footer.AINotice)
+	if notice == "" {
+		notice = DefaultAINotice
+	}
+	sb.WriteString("\n")
+	sb.WriteString(footerSeparator)
+	sb.WriteString("\n")
+	sb.WriteString(notice)
+	sb.WriteString("\n")
+	if legal := strings.TrimSpace(footer.LegalFooter); legal != "" {
+		sb.WriteString("\n")
+		sb.WriteString(legal)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// speaker is the label of a message role, "user" and "assistant" as they are
+// stored read User and Assistant.
+func speaker(role string) string {
+	role = strings.TrimSpace(role)
+	if role == "" {
+		return "Unknown"
+	}
+	return strings.ToUpper(role[:1]) + role[1:]
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_transcript
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_Conversation(t *testing.T) {
+	at := time.Date(2025, 3, 4, 10, 15, 0, 0, time.UTC)
+	text := Render([]Line{
+		{Role: "user", Body: "Hi, I want to book a table.", At: at},
+		{Role: "assistant", Body: " Sure, for how many people? ", At: at.Add(2 * time.Second)},
+		{Role: "assistant", Body: "   ", At: at.Add(3 * time.Second)},
+	}, Footer{AINotice: "Replies are AI-generated.", LegalFooter: "Acme Inc. Confidential."})
+
+	assert.Equal(t, "[2025-03-04 10:15:00] User: Hi, I want to book a table.\n"+
+		"[2025-03-04 10:15:02] Assistant: Sure, for how many people?\n"+
+		"\n---\nReplies are AI-generated.\n"+
+		"\nAcme Inc. Confidential.\n", text)
+}
+
+func TestRender_DefaultNotice(t *testing.T) {
+	text := Render(nil, Footer{})
+	assert.Equal(t, "\n---\n"+DefaultAINotice+"\n", text, "the notice is appended even without a setting")
+	assert.False(t, strings.Contains(Render(nil, Footer{AINotice: "custom"}), DefaultAINotice))
+}
+
+func TestRender_TimesInUTC(t *testing.T) {
+	at := time.Date(2025, 3, 4, 15, 45, 0, 0, time.FixedZone("IST", 5*3600+1800))
+	assert.True(t, strings.HasPrefix(Render([]Line{{Role: "user", Body: "hello", At: at}}, Footer{}), "[2025-03-04 10:15:00] User: hello\n"))
+}
+
+func TestSpeaker(t *testing.T) {
+	assert.Equal(t, "User", speaker("user"))
+	assert.Equal(t, "Assistant", speaker("assistant"))
+	assert.Equal(t, "Unknown", speaker(""))
+}
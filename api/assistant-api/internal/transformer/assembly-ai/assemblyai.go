@@ -7,14 +7,23 @@
 package internal_transformer_assemblyai
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 )
 
+// SpeechToTextOptions are the listen options assemblyai reads.
+var SpeechToTextOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("listen.language"),
+	transformer_internal.String("listen.model"),
+	transformer_internal.Number("listen.threshold", 0, 1),
+}.With(transformer_internal.VocabularyOptions...)
+
 func (opts *assemblyaiOption) GetEncoding() string {
 	return "pcm_s16le"
 }
@@ -62,5 +71,12 @@ func (co *assemblyaiOption) GetSpeechToTextConnectionString() string {
 		params.Add("model", model)
 	}
 
+	// custom vocabulary is sent as a JSON array of key terms
+	if vocabulary := transformer_internal.Vocabulary(co.mdlOpts); len(vocabulary) > 0 {
+		if keyterms, err := json.Marshal(transformer_internal.Texts(vocabulary)); err == nil {
+			params.Add("keyterms_prompt", string(keyterms))
+		}
+	}
+
 	return fmt.Sprintf("%s?%s", baseURL, params.Encode())
 }
@@ -1,6 +1,7 @@
 package internal_transformer_assemblyai
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/rapidaai/pkg/commons"
@@ -109,3 +110,14 @@ func TestGetSpeechToTextConnectionString_AllOptions(t *testing.T) {
 	assert.Contains(t, connStr, "encoding=pcm_s16le")
 	assert.Contains(t, connStr, "format_turns=true")
 }
+
+func TestGetSpeechToTextConnectionString_WithVocabulary(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k"})
+	opts := utils.Option{
+		"listen.vocabulary": "Rapida, SKU-1042:5",
+	}
+	opt, _ := NewAssemblyaiOption(newTestLogger(), cred, opts)
+	connStr := opt.GetSpeechToTextConnectionString()
+
+	assert.Contains(t, connStr, "keyterms_prompt="+url.QueryEscape(`["Rapida","SKU-1042"]`))
+}
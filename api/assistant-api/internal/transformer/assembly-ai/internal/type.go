@@ -23,3 +23,9 @@ type Word struct {
 	Confidence  float64 `json:"confidence"`
 	WordIsFinal bool    `json:"word_is_final"`
 }
+
+// UpdateConfigurationMessage changes the configuration of a live session.
+type UpdateConfigurationMessage struct {
+	Type           string   `json:"type"`
+	KeytermsPrompt []string `json:"keyterms_prompt"`
+}
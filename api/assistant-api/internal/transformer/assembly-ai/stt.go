@@ -16,6 +16,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	assemblyai_internal "github.com/rapidaai/api/assistant-api/internal/transformer/assembly-ai/internal"
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
@@ -166,6 +167,30 @@ func (aai *assemblyaiSTT) Transform(ctx context.Context, in internal_type.UserAu
 	return nil
 }
 
+// Bias updates the key terms of the live session, AssemblyAI applies them
+// from the next turn.
+func (aai *assemblyaiSTT) Bias(_ context.Context, hint internal_type.SpeechHintPacket) error {
+	phrases := transformer_internal.HintPhrases(transformer_internal.Vocabulary(aai.mdlOpts), hint)
+	message, err := json.Marshal(assemblyai_internal.UpdateConfigurationMessage{
+		Type:           "UpdateConfiguration",
+		KeytermsPrompt: transformer_internal.Texts(phrases),
+	})
+	if err != nil {
+		return err
+	}
+
+	aai.mu.Lock()
+	defer aai.mu.Unlock()
+	if aai.connection == nil {
+		return fmt.Errorf("assembly-ai-stt: websocket connection is not initialized")
+	}
+	if err := aai.connection.WriteMessage(websocket.TextMessage, message); err != nil {
+		aai.logger.Errorf("assembly-ai-stt: error sending configuration update: %v", err)
+		return fmt.Errorf("error sending configuration update: %w", err)
+	}
+	return nil
+}
+
 func (aai *assemblyaiSTT) Close(ctx context.Context) error {
 	aai.ctxCancel()
 
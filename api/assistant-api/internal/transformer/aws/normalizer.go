@@ -29,7 +29,7 @@ type awsNormalizer struct {
 	config internal_type.NormalizerConfig
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 
 	// conjunction handling
 	conjunctionPattern *regexp.Regexp
@@ -59,10 +59,10 @@ func NewAWSNormalizer(logger commons.Logger, opts utils.Option) internal_type.Te
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
 	return &awsNormalizer{
@@ -75,7 +75,8 @@ func NewAWSNormalizer(logger commons.Logger, opts utils.Option) internal_type.Te
 
 // buildNormalizerPipeline creates normalizers based on the provided names.
 // Supported normalizer names: url, currency, date, time, number, symbol,
-// general-abbreviation, role-abbreviation, tech-abbreviation, address
+// general-abbreviation, role-abbreviation, tech-abbreviation, address,
+// phone-number, email
 
 // Normalize applies AWS Polly-specific text transformations.
 func (n *awsNormalizer) Normalize(ctx context.Context, text string) string {
@@ -87,9 +88,7 @@ func (n *awsNormalizer) Normalize(ctx context.Context, text string) string {
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline (only if configured)
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// Escape XML special characters for SSML safety
 	text = n.escapeXML(text)
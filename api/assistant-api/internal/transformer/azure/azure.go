@@ -13,11 +13,24 @@ import (
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/common"
 	cmmn "github.com/Microsoft/cognitive-services-speech-sdk-go/common"
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/speech"
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 )
 
+// SpeechToTextOptions are the listen options azure reads.
+var SpeechToTextOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("listen.language"),
+	transformer_internal.Number("listen.threshold", 0, 1),
+}.With(transformer_internal.VocabularyOptions...)
+
+// TextToSpeechOptions are the speak options azure reads.
+var TextToSpeechOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("speak.voice.id"),
+	transformer_internal.String("speak.language"),
+}
+
 type azureOption struct {
 	logger          commons.Logger
 	mdlOpts         utils.Option
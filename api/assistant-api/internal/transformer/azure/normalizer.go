@@ -31,7 +31,7 @@ type azureNormalizer struct {
 	language  string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 
 	// conjunction handling
 	conjunctionPattern *regexp.Regexp
@@ -39,6 +39,16 @@ type azureNormalizer struct {
 
 // NewAzureNormalizer creates an Azure-specific text normalizer.
 func NewAzureNormalizer(logger commons.Logger, opts utils.Option) internal_type.TextNormalizer {
+	return internal_type.NewSSMLNormalizer(newAzureNormalizer(logger, opts), azureSSML, opts)
+}
+
+// azureSSML is the SSML Azure speaks, verbatim is spelled with characters.
+var azureSSML = internal_normalizers.SSMLDialect{
+	Tags:        internal_normalizers.W3CSSML.Tags,
+	InterpretAs: map[string]string{"verbatim": "characters"},
+}
+
+func newAzureNormalizer(logger commons.Logger, opts utils.Option) *azureNormalizer {
 	cfg := internal_type.DefaultNormalizerConfig()
 
 	// Get voice name and language
@@ -67,10 +77,10 @@ func NewAzureNormalizer(logger commons.Logger, opts utils.Option) internal_type.
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
 	return &azureNormalizer{
@@ -93,9 +103,7 @@ func (n *azureNormalizer) Normalize(ctx context.Context, text string) string {
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// Escape XML special characters for SSML safety (Azure uses SSML)
 	text = n.escapeXML(text)
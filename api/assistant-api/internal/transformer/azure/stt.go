@@ -16,6 +16,7 @@ import (
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/common"
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/speech"
 	azure_internal "github.com/rapidaai/api/assistant-api/internal/transformer/azure/internal"
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
@@ -36,6 +37,7 @@ type azureSpeechToText struct {
 	client           *speech.SpeechRecognizer
 	azureAudioConfig *audio.AudioConfig
 	inputstream      *audio.PushAudioInputStream
+	phraseList       *speech.PhraseListGrammar
 	onPacket         func(pkt ...internal_type.Packet) error
 }
 
@@ -89,10 +91,13 @@ func (s *azureSpeechToText) Initialize() error {
 		return fmt.Errorf("failed to create speech recognizer from config: %w", err)
 	}
 
+	phraseList := s.phraseListGrammar(client)
+
 	s.mu.Lock()
 	s.client = client
 	s.azureAudioConfig = audioConfig
 	s.inputstream = inputStream
+	s.phraseList = phraseList
 	s.mu.Unlock()
 
 	s.registerEventHandlers()
@@ -101,6 +106,52 @@ func (s *azureSpeechToText) Initialize() error {
 	return nil
 }
 
+// phraseListGrammar attaches the custom vocabulary to the recognizer as a
+// phrase list. Azure phrase lists carry no weight, so boosts are ignored.
+func (s *azureSpeechToText) phraseListGrammar(client *speech.SpeechRecognizer) *speech.PhraseListGrammar {
+	vocabulary := transformer_internal.Vocabulary(s.mdlOpts)
+	if len(vocabulary) == 0 {
+		return nil
+	}
+	grammar, err := speech.NewPhraseListGrammarFromRecognizer(client)
+	if err != nil {
+		s.logger.Warnf("azure-stt: unable to create phrase list, continuing without vocabulary: %v", err)
+		return nil
+	}
+	s.addPhrases(grammar, vocabulary)
+	return grammar
+}
+
+func (s *azureSpeechToText) addPhrases(grammar *speech.PhraseListGrammar, phrases []transformer_internal.Phrase) {
+	for _, phrase := range phrases {
+		if err := grammar.AddPhrase(phrase.Text); err != nil {
+			s.logger.Warnf("azure-stt: unable to add phrase %q: %v", phrase.Text, err)
+		}
+	}
+}
+
+// Bias replaces the phrase list of the running recognizer with the configured
+// vocabulary plus the phrases of the hint; it applies from the next utterance.
+func (s *azureSpeechToText) Bias(_ context.Context, hint internal_type.SpeechHintPacket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return fmt.Errorf("azure-stt: bias called before initialize")
+	}
+	if s.phraseList == nil {
+		grammar, err := speech.NewPhraseListGrammarFromRecognizer(s.client)
+		if err != nil {
+			return fmt.Errorf("failed to create phrase list: %w", err)
+		}
+		s.phraseList = grammar
+	}
+	if err := s.phraseList.Clear(); err != nil {
+		return fmt.Errorf("failed to clear phrase list: %w", err)
+	}
+	s.addPhrases(s.phraseList, transformer_internal.HintPhrases(transformer_internal.Vocabulary(s.mdlOpts), hint))
+	return nil
+}
+
 // registerEventHandlers sets up all the speech recognition event callbacks.
 func (s *azureSpeechToText) registerEventHandlers() {
 	s.client.SessionStarted(s.OnSessionStarted)
@@ -226,6 +277,9 @@ func (s *azureSpeechToText) Close(_ context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.phraseList != nil {
+		s.phraseList.Close()
+	}
 	if s.client != nil {
 		s.client.StopContinuousRecognitionAsync()
 		s.client.Close()
@@ -14,6 +14,7 @@ import (
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/audio"
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/common"
 	"github.com/Microsoft/cognitive-services-speech-sdk-go/speech"
+	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
@@ -33,6 +34,12 @@ type azureTextToSpeech struct {
 	audioConfig *audio.AudioConfig
 	client      *speech.SpeechSynthesizer
 	onPacket    func(pkt ...internal_type.Packet) error
+
+	// normalizer renders the SSML the assistant writes, nil to send the
+	// text as it is; voice wraps it for the synthesizer, nil to speak it as
+	// plain text
+	normalizer internal_type.TextNormalizer
+	voice      *azureNormalizer
 }
 
 func NewAzureTextToSpeech(ctx context.Context, logger commons.Logger, credential *protos.VaultCredential,
@@ -44,6 +51,16 @@ func NewAzureTextToSpeech(ctx context.Context, logger commons.Logger, credential
 		logger.Errorf("azure-tts: Unable to initilize azure option", err)
 		return nil, err
 	}
+	var normalizer internal_type.TextNormalizer
+	var voice *azureNormalizer
+	if internal_normalizers.SSMLPassthrough(opts) {
+		if n := newAzureNormalizer(logger, opts); n.voiceName != "" {
+			normalizer, voice = internal_type.NewSSMLNormalizer(n, azureSSML, opts), n
+		} else {
+			// without a voice to wrap the SSML in, its markup is removed
+			normalizer = internal_type.NewSSMLNormalizer(nil, internal_normalizers.PlainText, opts)
+		}
+	}
 	ct, ctxCancel := context.WithCancel(ctx)
 	return &azureTextToSpeech{
 		ctx:       ct,
@@ -52,6 +69,8 @@ func NewAzureTextToSpeech(ctx context.Context, logger commons.Logger, credential
 		azureOption: azureOption,
 		logger:      logger,
 		onPacket:    onPacket,
+		normalizer:  normalizer,
+		voice:       voice,
 	}, nil
 }
 
@@ -146,7 +165,15 @@ func (azure *azureTextToSpeech) Transform(ctx context.Context, in internal_type.
 		}
 		return nil
 	case internal_type.LLMResponseDeltaPacket:
-		res := <-cl.StartSpeakingTextAsync(input.Text)
+		if azure.voice != nil {
+			res := <-cl.StartSpeakingSsmlAsync(azure.voice.WrapWithSSML(azure.normalizer.Normalize(ctx, input.Text)))
+			return res.Error
+		}
+		text := input.Text
+		if azure.normalizer != nil {
+			text = azure.normalizer.Normalize(ctx, text)
+		}
+		res := <-cl.StartSpeakingTextAsync(text)
 		if res.Error != nil {
 			return res.Error
 		}
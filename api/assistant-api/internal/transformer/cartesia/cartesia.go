@@ -9,15 +9,32 @@ package internal_transformer_cartesia
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	cartesia_internal "github.com/rapidaai/api/assistant-api/internal/transformer/cartesia/internal"
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 )
 
+// SpeechToTextOptions are the listen options cartesia reads.
+var SpeechToTextOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("listen.language"),
+	transformer_internal.String("listen.model"),
+}
+
+// TextToSpeechOptions are the speak options cartesia reads.
+var TextToSpeechOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("speak.voice.id"),
+	transformer_internal.String("speak.language"),
+	transformer_internal.String("speak.model"),
+	transformer_internal.String("speak.__experimental_controls.speed"),
+	transformer_internal.String("speak.__experimental_controls.emotion"),
+}
+
 const (
 	URL                  = "wss://api.cartesia.ai/stt/websocket"
 	CARTESIA_API_VERSION = "2024-06-10"
@@ -70,6 +87,10 @@ func (co *cartesiaOption) GetTextToSpeechInput(
 
 	if speed, err := co.mdlOpts.GetString("speak.__experimental_controls.speed"); err == nil {
 		opts.ExperimentalControls.Speed = speed
+	} else if rate, err := co.mdlOpts.GetFloat64("speak.rate"); err == nil {
+		// a speaking profile's rate, 1 is normal, sonic takes -1 (slowest)
+		// to 1 (fastest) around 0
+		opts.ExperimentalControls.Speed = strconv.FormatFloat(max(-1, min(1, rate-1)), 'f', 2, 64)
 	}
 
 	if emotion, err := co.mdlOpts.GetString("speak.__experimental_controls.emotion"); err == nil {
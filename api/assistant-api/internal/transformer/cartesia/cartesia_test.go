@@ -104,6 +104,16 @@ func TestGetTextToSpeechInput_WithExperimentalControls(t *testing.T) {
 	assert.Equal(t, []string{"happy", "excited"}, input.ExperimentalControls.Emotion)
 }
 
+func TestGetTextToSpeechInput_SpeakingRate(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k"})
+	opt, _ := NewCartesiaOption(newTestLogger(), cred, utils.Option{"speak.rate": "0.85"})
+	assert.Equal(t, "-0.15", opt.GetTextToSpeechInput("test", map[string]interface{}{}).ExperimentalControls.Speed)
+
+	// the explicit speed control wins
+	opt, _ = NewCartesiaOption(newTestLogger(), cred, utils.Option{"speak.rate": "0.85", "speak.__experimental_controls.speed": "slow"})
+	assert.Equal(t, "slow", opt.GetTextToSpeechInput("test", map[string]interface{}{}).ExperimentalControls.Speed)
+}
+
 func TestGetSpeechToTextConnectionString_Default(t *testing.T) {
 	cred := newVaultCredential(map[string]interface{}{"key": "my-key"})
 	opt, _ := NewCartesiaOption(newTestLogger(), cred, utils.Option{})
@@ -29,7 +29,7 @@ type cartesiaNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 }
 
 // NewCartesiaNormalizer creates a Cartesia-specific text normalizer.
@@ -42,18 +42,18 @@ func NewCartesiaNormalizer(logger commons.Logger, opts utils.Option) internal_ty
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
-	return &cartesiaNormalizer{
+	return internal_type.NewSSMLNormalizer(&cartesiaNormalizer{
 		logger:      logger,
 		config:      cfg,
 		language:    language,
 		normalizers: normalizers,
-	}
+	}, internal_normalizers.PlainText, opts)
 }
 
 // Normalize applies Cartesia-specific text transformations.
@@ -67,9 +67,7 @@ func (n *cartesiaNormalizer) Normalize(ctx context.Context, text string) string
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// NO XML escaping - Cartesia uses plain text only
 	// NO SSML breaks - Cartesia doesn't support SSML
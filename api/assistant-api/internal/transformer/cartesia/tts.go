@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/gorilla/websocket"
+	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
 	cartesia_internal "github.com/rapidaai/api/assistant-api/internal/transformer/cartesia/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
@@ -33,6 +34,10 @@ type cartesiaTTS struct {
 	logger     commons.Logger
 	connection *websocket.Conn
 	onPacket   func(pkt ...internal_type.Packet) error
+
+	// normalizer removes the SSML the assistant writes, nil to send the text
+	// as it is
+	normalizer internal_type.TextNormalizer
 }
 
 func NewCartesiaTextToSpeech(ctx context.Context, logger commons.Logger, credential *protos.VaultCredential,
@@ -43,6 +48,10 @@ func NewCartesiaTextToSpeech(ctx context.Context, logger commons.Logger, credent
 		logger.Errorf("intializing cartesia failed %+v", err)
 		return nil, err
 	}
+	var normalizer internal_type.TextNormalizer
+	if internal_normalizers.SSMLPassthrough(opts) {
+		normalizer = NewCartesiaNormalizer(logger, opts)
+	}
 
 	ct, ctxCancel := context.WithCancel(ctx)
 	return &cartesiaTTS{
@@ -51,6 +60,7 @@ func NewCartesiaTextToSpeech(ctx context.Context, logger commons.Logger, credent
 		ctx:            ct,
 		ctxCancel:      ctxCancel,
 		onPacket:       onPacket,
+		normalizer:     normalizer,
 	}, nil
 }
 
@@ -135,7 +145,12 @@ func (ct *cartesiaTTS) Transform(ctx context.Context, in internal_type.LLMPacket
 		}
 		return nil
 	case internal_type.LLMResponseDeltaPacket:
-		message := ct.GetTextToSpeechInput(input.Text, map[string]interface{}{"continue": true, "context_id": ct.contextId, "max_buffer_delay_ms": "0ms"})
+		text := input.Text
+		if ct.normalizer != nil {
+			// chunks end with a space for the next one to start a word
+			text = ct.normalizer.Normalize(ctx, text) + " "
+		}
+		message := ct.GetTextToSpeechInput(text, map[string]interface{}{"continue": true, "context_id": ct.contextId, "max_buffer_delay_ms": "0ms"})
 		if err := conn.WriteJSON(message); err != nil {
 			return err
 		}
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	commons "github.com/rapidaai/pkg/commons"
 	utils "github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
@@ -18,6 +20,23 @@ import (
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
 )
 
+// SpeechToTextOptions are the listen options deepgram reads.
+var SpeechToTextOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("listen.language"),
+	transformer_internal.String("listen.model"),
+	transformer_internal.Bool("listen.smart_format"),
+	transformer_internal.Bool("listen.filler_words"),
+	transformer_internal.Bool("listen.vad_events"),
+	transformer_internal.String("listen.endpointing"),
+	transformer_internal.Bool("listen.multichannel"),
+	transformer_internal.Number("listen.threshold", 0, 1),
+}.With(transformer_internal.VocabularyOptions...)
+
+// TextToSpeechOptions are the speak options deepgram reads.
+var TextToSpeechOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("speak.voice.id"),
+}
+
 func (dg *deepgramOption) GetEncoding() string {
 	return "linear16"
 }
@@ -26,6 +45,9 @@ type deepgramOption struct {
 	key     string
 	logger  commons.Logger
 	mdlOpts utils.Option
+	// opus is set when the channel streams the caller as Opus, which goes
+	// to deepgram in an Ogg container
+	opus bool
 }
 
 func NewDeepgramOption(
@@ -36,10 +58,12 @@ func NewDeepgramOption(
 	if !ok {
 		return nil, fmt.Errorf("illegal vault config")
 	}
+	codec, _ := opts.GetString(internal_type.OptionsKeyInputCodec)
 	return &deepgramOption{
 		key:     cx.(string),
 		logger:  logger,
 		mdlOpts: opts,
+		opus:    codec == "opus",
 	}, nil
 }
 
@@ -65,6 +89,12 @@ func (dgOpt *deepgramOption) SpeechToTextOptions() *interfaces.LiveTranscription
 		Multichannel:   false,
 	}
 
+	// deepgram reads encoding and rate from the Ogg headers of a container
+	if dgOpt.opus {
+		opts.Encoding = ""
+		opts.SampleRate = 0
+	}
+
 	if language, err := dgOpt.mdlOpts.GetString("listen.language"); err == nil {
 		opts.Language = language
 	}
@@ -85,37 +115,46 @@ func (dgOpt *deepgramOption) SpeechToTextOptions() *interfaces.LiveTranscription
 	if multichannel, err := dgOpt.mdlOpts.GetBool("listen.multichannel"); err == nil {
 		opts.Multichannel = multichannel
 	}
+	if diarize, err := dgOpt.mdlOpts.GetBool("listen.diarize"); err == nil {
+		opts.Diarize = diarize
+	}
 	if model, err := dgOpt.mdlOpts.GetString("listen.model"); err == nil {
 		opts.Model = model
 	}
 
-	if keywordsRaw, exists := dgOpt.mdlOpts["listen.keyword"]; exists {
-		var keywords []string
-		switch v := keywordsRaw.(type) {
-		case string:
-			trimmed := strings.Trim(v, "[]")
-			keywords = strings.Fields(trimmed)
-		case []interface{}:
-			keywords = make([]string, len(v))
-			for i, keyword := range v {
-				if str, ok := keyword.(string); ok {
-					keywords[i] = strings.TrimSpace(str)
+	// nova-3 takes key terms, the nova-2, nova, enhanced and base families
+	// take keywords with an optional intensifier ("word:boost"). Other
+	// models, e.g. whisper, have no vocabulary.
+	if vocabulary := transformer_internal.Vocabulary(dgOpt.mdlOpts); len(vocabulary) > 0 {
+		if strings.HasPrefix(opts.Model, "nova-3") {
+			opts.Keyterm = transformer_internal.Texts(vocabulary)
+		} else if takesKeywords(opts.Model) {
+			keywords := make([]string, 0, len(vocabulary))
+			for _, phrase := range vocabulary {
+				if phrase.Boost != 0 {
+					keywords = append(keywords, fmt.Sprintf("%s:%g", phrase.Text, phrase.Boost))
+					continue
 				}
+				keywords = append(keywords, phrase.Text)
 			}
-		default:
-			dgOpt.logger.Warnf("Unexpected type for keywords: %T", keywordsRaw)
+			opts.Keywords = keywords
 		}
-		if len(keywords) > 0 {
-			if opts.Model == "nova-2" {
-				opts.Keywords = keywords
-			}
-			if opts.Model == "nova-3" {
-				opts.Keyterm = keywords
-			}
+	}
+	return opts
+}
+
+// keywordModels are the model families accepting the keywords parameter.
+var keywordModels = []string{"nova-2", "nova", "enhanced", "base"}
 
+// takesKeywords reports whether model, e.g. nova-2-phonecall, belongs to a
+// family accepting keywords.
+func takesKeywords(model string) bool {
+	for _, family := range keywordModels {
+		if model == family || strings.HasPrefix(model, family+"-") {
+			return true
 		}
 	}
-	return opts
+	return false
 }
 
 func (dgOpt *deepgramOption) GetTextToSpeechConnectionString() string {
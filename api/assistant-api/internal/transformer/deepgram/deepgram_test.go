@@ -3,6 +3,7 @@ package internal_transformer_deepgram
 import (
 	"testing"
 
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +71,26 @@ func TestSpeechToTextOptions_Defaults(t *testing.T) {
 	assert.False(t, sttOpts.Multichannel)
 }
 
+func TestSpeechToTextOptions_OpusInput(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k"})
+	opt, _ := NewDeepgramOption(newTestLogger(t), cred, utils.Option{internal_type.OptionsKeyInputCodec: "opus"})
+	sttOpts := opt.SpeechToTextOptions()
+
+	// the Ogg container carries encoding and rate
+	assert.Empty(t, sttOpts.Encoding)
+	assert.Zero(t, sttOpts.SampleRate)
+
+	stt, err := NewDeepgramSpeechToText(t.Context(), newTestLogger(t), cred, nil, utils.Option{internal_type.OptionsKeyInputCodec: "opus"})
+	assert.NoError(t, err)
+	receiver, ok := stt.(internal_type.SpeechToTextOpusReceiver)
+	assert.True(t, ok)
+	assert.True(t, receiver.AcceptsOpus())
+	assert.Error(t, receiver.TransformOpus(t.Context(), internal_type.UserOpusPacket{Payload: []byte{0xfc}}))
+
+	stt, _ = NewDeepgramSpeechToText(t.Context(), newTestLogger(t), cred, nil, utils.Option{})
+	assert.False(t, stt.(internal_type.SpeechToTextOpusReceiver).AcceptsOpus())
+}
+
 func TestSpeechToTextOptions_WithOverrides(t *testing.T) {
 	cred := newVaultCredential(map[string]interface{}{"key": "k"})
 	opts := utils.Option{
@@ -80,6 +101,7 @@ func TestSpeechToTextOptions_WithOverrides(t *testing.T) {
 		"listen.endpointing":  "10",
 		"listen.multichannel": true,
 		"listen.model":        "nova-2",
+		"listen.diarize":      true,
 	}
 	opt, _ := NewDeepgramOption(newTestLogger(t), cred, opts)
 	sttOpts := opt.SpeechToTextOptions()
@@ -91,6 +113,7 @@ func TestSpeechToTextOptions_WithOverrides(t *testing.T) {
 	assert.Equal(t, "10", sttOpts.Endpointing)
 	assert.True(t, sttOpts.Multichannel)
 	assert.Equal(t, "nova-2", sttOpts.Model)
+	assert.True(t, sttOpts.Diarize)
 	// Encoding and sample rate remain hardcoded
 	assert.Equal(t, "linear16", sttOpts.Encoding)
 	assert.Equal(t, 16000, sttOpts.SampleRate)
@@ -134,6 +157,47 @@ func TestSpeechToTextOptions_KeywordsAsString(t *testing.T) {
 	assert.Equal(t, []string{"hello", "world"}, sttOpts.Keywords)
 }
 
+func TestSpeechToTextOptions_VocabularyWithBoost(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k"})
+	opts := utils.Option{
+		"listen.model":      "nova-2-phonecall",
+		"listen.vocabulary": "Rapida:5, invoice",
+	}
+	opt, _ := NewDeepgramOption(newTestLogger(t), cred, opts)
+	sttOpts := opt.SpeechToTextOptions()
+
+	assert.Equal(t, []string{"Rapida:5", "invoice"}, sttOpts.Keywords)
+	assert.Empty(t, sttOpts.Keyterm)
+}
+
+func TestSpeechToTextOptions_VocabularyNova3Variant(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k"})
+	opts := utils.Option{
+		"listen.model":      "nova-3-medical",
+		"listen.vocabulary": "Rapida:5, invoice",
+	}
+	opt, _ := NewDeepgramOption(newTestLogger(t), cred, opts)
+	sttOpts := opt.SpeechToTextOptions()
+
+	assert.Equal(t, []string{"Rapida", "invoice"}, sttOpts.Keyterm)
+	assert.Empty(t, sttOpts.Keywords)
+}
+
+func TestSpeechToTextOptions_VocabularyUnsupportedModel(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k"})
+	for _, model := range []string{"whisper-large", "nova-3-general"} {
+		opts := utils.Option{
+			"listen.model":      model,
+			"listen.vocabulary": "Rapida, invoice",
+		}
+		opt, _ := NewDeepgramOption(newTestLogger(t), cred, opts)
+		assert.Empty(t, opt.SpeechToTextOptions().Keywords, model)
+	}
+	assert.True(t, takesKeywords("enhanced-phonecall"))
+	assert.True(t, takesKeywords("base"))
+	assert.False(t, takesKeywords("novice"))
+}
+
 // --- TextToSpeech Connection String Tests ---
 
 func TestGetTextToSpeechConnectionString_Default(t *testing.T) {
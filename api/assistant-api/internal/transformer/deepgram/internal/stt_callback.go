@@ -8,6 +8,7 @@ package deepgram_internal
 
 import (
 	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+	internal_diarization "github.com/rapidaai/api/assistant-api/internal/diarization"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
@@ -61,6 +62,7 @@ func (d *deepgramSttCallback) Message(mr *msginterfaces.MessageResponse) error {
 					Script:     alternative.Transcript,
 					Confidence: alternative.Confidence,
 					Language:   d.GetMostUsedLanguage(alternative.Languages),
+					Speaker:    d.GetMostWordsSpeaker(alternative.Words),
 					Interim:    !mr.IsFinal,
 				},
 			)
@@ -123,3 +125,23 @@ func (d *deepgramSttCallback) GetMostUsedLanguage(languages []string) string {
 	}
 	return mostUsedLang
 }
+
+// GetMostWordsSpeaker returns the speaker who said most of the words, ""
+// when deepgram is not diarizing.
+func (d *deepgramSttCallback) GetMostWordsSpeaker(words []msginterfaces.Word) string {
+	wordCount := make(map[int]int)
+	speaker, maxCount := 0, 0
+	for _, word := range words {
+		if word.Speaker == nil {
+			continue
+		}
+		wordCount[*word.Speaker]++
+		if count := wordCount[*word.Speaker]; count > maxCount || (count == maxCount && *word.Speaker < speaker) {
+			speaker, maxCount = *word.Speaker, count
+		}
+	}
+	if maxCount == 0 {
+		return ""
+	}
+	return internal_diarization.Label(speaker)
+}
@@ -429,6 +429,43 @@ func TestMessageLanguageDetection(t *testing.T) {
 	})
 }
 
+func TestMessageSpeakerDiarization(t *testing.T) {
+	speaker := func(n int) *int { return &n }
+
+	t.Run("labels the speaker of most words", func(t *testing.T) {
+		collector, _, callback := createTestCallback(utils.Option{})
+
+		mr := createMessageResponse("yes he is here", 0.95, true, []string{"en"})
+		mr.Channel.Alternatives[0].Words = []msginterfaces.Word{
+			{Word: "yes", Speaker: speaker(0)},
+			{Word: "he", Speaker: speaker(1)},
+			{Word: "is", Speaker: speaker(1)},
+			{Word: "here", Speaker: speaker(1)},
+		}
+		require.NoError(t, callback.Message(mr))
+
+		stt := collector.GetPackets()[1].(internal_type.SpeechToTextPacket)
+		assert.Equal(t, "speaker_1", stt.Speaker)
+	})
+
+	t.Run("no label without diarization", func(t *testing.T) {
+		collector, _, callback := createTestCallback(utils.Option{})
+
+		mr := createMessageResponse("hello there", 0.95, true, []string{"en"})
+		mr.Channel.Alternatives[0].Words = []msginterfaces.Word{{Word: "hello"}, {Word: "there"}}
+		require.NoError(t, callback.Message(mr))
+
+		stt := collector.GetPackets()[1].(internal_type.SpeechToTextPacket)
+		assert.Empty(t, stt.Speaker)
+	})
+
+	t.Run("ties go to the lowest speaker", func(t *testing.T) {
+		callback := &deepgramSttCallback{}
+		words := []msginterfaces.Word{{Speaker: speaker(2)}, {Speaker: speaker(1)}}
+		assert.Equal(t, "speaker_1", callback.GetMostWordsSpeaker(words))
+	})
+}
+
 // =============================================================================
 // UtteranceEnd Handler Tests
 // =============================================================================
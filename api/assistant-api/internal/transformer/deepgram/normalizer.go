@@ -29,7 +29,7 @@ type deepgramNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 }
 
 // NewDeepgramNormalizer creates a Deepgram-specific text normalizer.
@@ -41,18 +41,18 @@ func NewDeepgramNormalizer(logger commons.Logger, opts utils.Option) internal_ty
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
-	return &deepgramNormalizer{
+	return internal_type.NewSSMLNormalizer(&deepgramNormalizer{
 		logger:      logger,
 		config:      cfg,
 		language:    language,
 		normalizers: normalizers,
-	}
+	}, internal_normalizers.PlainText, opts)
 }
 
 // Normalize applies Deepgram-specific text transformations.
@@ -66,9 +66,7 @@ func (n *deepgramNormalizer) Normalize(ctx context.Context, text string) string
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// NO XML escaping - Deepgram uses plain text only
 	// NO SSML breaks - Deepgram doesn't support SSML
@@ -8,8 +8,11 @@ package internal_transformer_deepgram
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/stretchr/testify/assert"
@@ -141,7 +144,7 @@ func TestNewDeepgramNormalizer_NormalizerPipelineOrder(t *testing.T) {
 	require.True(t, ok)
 
 	// Should have 3 normalizers in order
-	assert.Len(t, dn.normalizers, 3)
+	assert.Equal(t, 3, dn.normalizers.Len())
 }
 
 // =============================================================================
@@ -846,6 +849,27 @@ func TestNormalize_NoSSMLOutput(t *testing.T) {
 	}
 }
 
+func TestNormalize_SSMLPassthrough(t *testing.T) {
+	opts := utils.Option{internal_normalizers.OptionsKeySSMLPassthrough: true}
+	input := `Your code is <say-as interpret-as="characters">AB1</say-as><break time="500ms"/> <emphasis level="strong">please</emphasis> <prosody rate="slow">note it</prosody>.`
+
+	// Deepgram doesn't support SSML, the assistant's markup must never be
+	// read aloud
+	normalizers := map[string]internal_type.TextNormalizer{
+		"deepgram":   NewDeepgramNormalizer(newTestLogger(t), opts),
+		"plain text": internal_type.NewSSMLNormalizer(nil, internal_normalizers.PlainText, opts),
+	}
+	for name, normalizer := range normalizers {
+		t.Run(name, func(t *testing.T) {
+			result := normalizer.Normalize(context.Background(), input)
+			assert.False(t, strings.ContainsAny(result, "<>"), "markup reached the provider: %q", result)
+			assert.Equal(t, "Your code is AB1 ... please note it.", result)
+		})
+	}
+
+	assert.Nil(t, internal_type.NewSSMLNormalizer(nil, internal_normalizers.PlainText, utils.Option{}), "without passthrough the text is sent as it is")
+}
+
 // =============================================================================
 // Context Handling Tests
 // =============================================================================
@@ -17,6 +17,8 @@ import (
 
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
 	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/pkg/media/oggwriter"
 	deepgram_internal "github.com/rapidaai/api/assistant-api/internal/transformer/deepgram/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
@@ -33,6 +35,11 @@ type deepgramSTT struct {
 	logger    commons.Logger
 	client    *client.WSCallback
 	onPacket  func(pkt ...internal_type.Packet) error
+
+	// ogg pages the caller's Opus packets when the stream was opened for
+	// Opus, pages collects the bytes written for the next Stream call.
+	ogg   *oggwriter.OggWriter
+	pages bytes.Buffer
 }
 
 func (*deepgramSTT) Name() string {
@@ -108,6 +115,38 @@ func (dg *deepgramSTT) Transform(ctx context.Context, in internal_type.UserAudio
 	return err
 }
 
+// AcceptsOpus reports whether the stream was opened for the caller's Opus.
+func (dg *deepgramSTT) AcceptsOpus() bool {
+	return dg.opus
+}
+
+// TransformOpus pages an Opus packet of the caller into the Ogg stream sent
+// to deepgram. The headers go out with the first packet.
+func (dg *deepgramSTT) TransformOpus(ctx context.Context, in internal_type.UserOpusPacket) error {
+	dg.mu.Lock()
+	defer dg.mu.Unlock()
+
+	if dg.client == nil {
+		return fmt.Errorf("deepgram-stt: connection is not initialized")
+	}
+	if dg.ogg == nil {
+		ogg, err := oggwriter.NewWith(&dg.pages, 48000, 1)
+		if err != nil {
+			return fmt.Errorf("deepgram-stt: unable to start ogg stream: %w", err)
+		}
+		dg.ogg = ogg
+	}
+	if err := dg.ogg.WriteRTP(&rtp.Packet{Header: rtp.Header{Timestamp: in.Timestamp}, Payload: in.Payload}); err != nil {
+		return fmt.Errorf("deepgram-stt: unable to page opus packet: %w", err)
+	}
+	defer dg.pages.Reset()
+	if err := dg.client.Stream(bufio.NewReader(bytes.NewReader(dg.pages.Bytes()))); err != nil && !errors.Is(err, io.EOF) {
+		dg.logger.Errorf("deepgram-stt: error while calling deepgram: %v", err)
+		return fmt.Errorf("deepgram stream error: %w", err)
+	}
+	return nil
+}
+
 func (dg *deepgramSTT) Close(ctx context.Context) error {
 	dg.ctxCancel()
 
@@ -10,11 +10,19 @@ import (
 	"fmt"
 	"net/url"
 
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 )
 
+// TextToSpeechOptions are the speak options elevenlabs reads.
+var TextToSpeechOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("speak.voice.id"),
+	transformer_internal.String("speak.language"),
+	transformer_internal.String("speak.model"),
+}
+
 const (
 	ELEVENLABS_VOICE_ID = "TWUKKXAylkYxxlPe4gx0"
 )
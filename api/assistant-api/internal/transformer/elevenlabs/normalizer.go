@@ -31,7 +31,7 @@ type elevenlabsNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 
 	// conjunction handling
 	conjunctionPattern *regexp.Regexp
@@ -65,19 +65,26 @@ func NewElevenLabsNormalizer(logger commons.Logger, opts utils.Option) internal_
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
-	return &elevenlabsNormalizer{
+	return internal_type.NewSSMLNormalizer(&elevenlabsNormalizer{
 		logger:             logger,
 		config:             cfg,
 		language:           language,
 		normalizers:        normalizers,
 		conjunctionPattern: conjunctionPattern,
-	}
+	}, elevenlabsSSML, opts)
+}
+
+// elevenlabsSSML is the SSML ElevenLabs speaks, breaks only and in
+// seconds.
+var elevenlabsSSML = internal_normalizers.SSMLDialect{
+	Tags:         []string{internal_normalizers.SSMLBreak},
+	BreakSeconds: true,
 }
 
 // Normalize applies ElevenLabs-specific text transformations.
@@ -91,9 +98,7 @@ func (n *elevenlabsNormalizer) Normalize(ctx context.Context, text string) strin
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// ElevenLabs supports limited SSML, so we escape XML characters
 	// except where we insert our own SSML tags
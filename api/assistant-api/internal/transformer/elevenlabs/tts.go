@@ -18,6 +18,7 @@ import (
 
 	"github.com/gorilla/websocket"
 
+	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
 	elevenlabs_internal "github.com/rapidaai/api/assistant-api/internal/transformer/elevenlabs/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
@@ -37,6 +38,10 @@ type elevenlabsTTS struct {
 	logger     commons.Logger
 	connection *websocket.Conn
 	onPacket   func(pkt ...internal_type.Packet) error
+
+	// normalizer renders the SSML the assistant writes, nil to send the
+	// text as it is
+	normalizer internal_type.TextNormalizer
 }
 
 func NewElevenlabsTextToSpeech(ctx context.Context, logger commons.Logger, credential *protos.VaultCredential,
@@ -47,6 +52,10 @@ func NewElevenlabsTextToSpeech(ctx context.Context, logger commons.Logger, crede
 		logger.Errorf("elevenlabs-tts: intializing elevenlabs failed %+v", err)
 		return nil, err
 	}
+	var normalizer internal_type.TextNormalizer
+	if internal_normalizers.SSMLPassthrough(opts) {
+		normalizer = NewElevenLabsNormalizer(logger, opts)
+	}
 	ctx2, contextCancel := context.WithCancel(ctx)
 	return &elevenlabsTTS{
 		ctx:              ctx2,
@@ -54,6 +63,7 @@ func NewElevenlabsTextToSpeech(ctx context.Context, logger commons.Logger, crede
 		onPacket:         onPacket,
 		logger:           logger,
 		elevenLabsOption: eleOpts,
+		normalizer:       normalizer,
 	}, nil
 }
 
@@ -137,8 +147,13 @@ func (t *elevenlabsTTS) Transform(ctx context.Context, in internal_type.LLMPacke
 	case internal_type.InterruptionPacket:
 		return nil
 	case internal_type.LLMResponseDeltaPacket:
+		text := input.Text
+		if t.normalizer != nil {
+			// chunks end with a space for the next one to start a word
+			text = t.normalizer.Normalize(ctx, text) + " "
+		}
 		if err := cnn.WriteJSON(map[string]interface{}{
-			"text":       input.Text,
+			"text":       text,
 			"context_id": currentCtx,
 			"flush":      true,
 		}); err != nil {
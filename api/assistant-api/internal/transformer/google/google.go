@@ -12,12 +12,26 @@ import (
 
 	"cloud.google.com/go/speech/apiv2/speechpb"
 	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 	"google.golang.org/api/option"
 )
 
+// SpeechToTextOptions are the listen options google reads.
+var SpeechToTextOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("listen.language"),
+	transformer_internal.String("listen.model"),
+	transformer_internal.String("listen.region"),
+	transformer_internal.Number("listen.threshold", 0, 1),
+}.With(transformer_internal.VocabularyOptions...)
+
+// TextToSpeechOptions are the speak options google reads.
+var TextToSpeechOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("speak.voice.id"),
+}
+
 // Introduced constants for default values
 const (
 	DefaultLanguageCode = "en-US"            // Default language code for Speech-to-Text
@@ -124,6 +138,20 @@ func (gog *googleOption) SpeechToTextOptions() *speechpb.StreamingRecognitionCon
 		gog.logger.Warn("Model not specified, defaulting to " + DefaultModel)
 	}
 
+	if vocabulary := transformer_internal.Vocabulary(gog.mdlOpts); len(vocabulary) > 0 {
+		phrases := make([]*speechpb.PhraseSet_Phrase, 0, len(vocabulary))
+		for _, phrase := range vocabulary {
+			phrases = append(phrases, &speechpb.PhraseSet_Phrase{Value: phrase.Text, Boost: float32(phrase.Boost)})
+		}
+		opts.Config.Adaptation = &speechpb.SpeechAdaptation{
+			PhraseSets: []*speechpb.SpeechAdaptation_AdaptationPhraseSet{{
+				Value: &speechpb.SpeechAdaptation_AdaptationPhraseSet_InlinePhraseSet{
+					InlinePhraseSet: &speechpb.PhraseSet{Phrases: phrases},
+				},
+			}},
+		}
+	}
+
 	return opts
 }
 
@@ -133,6 +133,31 @@ func TestSpeechToTextOptions_WithModelOverride(t *testing.T) {
 	assert.Equal(t, "chirp", sttOpts.Config.Model)
 }
 
+func TestSpeechToTextOptions_WithVocabulary(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k", "project_id": "p"})
+	opts := utils.Option{
+		"listen.vocabulary": "Rapida:10, SKU-1042",
+	}
+	opt, _ := NewGoogleOption(newTestLogger(), cred, opts)
+	sttOpts := opt.SpeechToTextOptions()
+
+	assert.NotNil(t, sttOpts.Config.Adaptation)
+	assert.Len(t, sttOpts.Config.Adaptation.PhraseSets, 1)
+	phrases := sttOpts.Config.Adaptation.PhraseSets[0].GetInlinePhraseSet().GetPhrases()
+	assert.Len(t, phrases, 2)
+	assert.Equal(t, "Rapida", phrases[0].Value)
+	assert.Equal(t, float32(10), phrases[0].Boost)
+	assert.Equal(t, "SKU-1042", phrases[1].Value)
+	assert.Equal(t, float32(0), phrases[1].Boost)
+}
+
+func TestSpeechToTextOptions_WithoutVocabulary(t *testing.T) {
+	cred := newVaultCredential(map[string]interface{}{"key": "k", "project_id": "p"})
+	opt, _ := NewGoogleOption(newTestLogger(), cred, utils.Option{})
+
+	assert.Nil(t, opt.SpeechToTextOptions().Config.Adaptation)
+}
+
 // --- TextToSpeechOptions Tests ---
 
 func TestTextToSpeechOptions_Defaults(t *testing.T) {
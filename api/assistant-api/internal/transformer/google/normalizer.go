@@ -30,7 +30,7 @@ type googleNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 
 	// conjunction handling
 	conjunctionPattern *regexp.Regexp
@@ -64,10 +64,10 @@ func NewGoogleNormalizer(logger commons.Logger, opts utils.Option) internal_type
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
 	return &googleNormalizer{
@@ -89,9 +89,7 @@ func (n *googleNormalizer) Normalize(ctx context.Context, text string) string {
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// Escape XML special characters for SSML safety (Google uses SSML)
 	text = n.escapeXML(text)
@@ -14,6 +14,7 @@ import (
 
 	texttospeech "cloud.google.com/go/texttospeech/apiv1"
 	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
+	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
@@ -33,6 +34,10 @@ type googleTextToSpeech struct {
 	client       *texttospeech.Client                                  // Google TTS client.
 	streamClient texttospeechpb.TextToSpeech_StreamingSynthesizeClient // Streaming client for real-time TTS.
 	onPacket     func(pkt ...internal_type.Packet) error               // Callback for handling audio packets.
+
+	// normalizer removes the SSML the assistant writes, streaming synthesis
+	// only takes plain text; nil to send the text as it is
+	normalizer internal_type.TextNormalizer
 }
 
 // Name returns the name of this transformer implementation.
@@ -70,6 +75,7 @@ func NewGoogleTextToSpeech(ctx context.Context, logger commons.Logger, credentia
 		onPacket:     onPacket,
 		client:       client,
 		googleOption: googleOption,
+		normalizer:   internal_type.NewSSMLNormalizer(nil, internal_normalizers.PlainText, opts),
 	}, nil
 }
 
@@ -135,11 +141,15 @@ func (google *googleTextToSpeech) Transform(ctx context.Context, in internal_typ
 		}
 		return nil
 	case internal_type.LLMResponseDeltaPacket:
-		google.logger.Debugf("google-tts: sending text for synthesis: %s", input.Text)
+		text := input.Text
+		if google.normalizer != nil {
+			text = google.normalizer.Normalize(ctx, text)
+		}
+		google.logger.Debugf("google-tts: sending text for synthesis: %s", text)
 		if err := sCli.Send(&texttospeechpb.StreamingSynthesizeRequest{
 			StreamingRequest: &texttospeechpb.StreamingSynthesizeRequest_Input{
 				Input: &texttospeechpb.StreamingSynthesisInput{
-					InputSource: &texttospeechpb.StreamingSynthesisInput_Text{Text: input.Text},
+					InputSource: &texttospeechpb.StreamingSynthesisInput_Text{Text: text},
 				},
 			},
 		}); err != nil {
@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package transformer_internal
+
+import (
+	"strings"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+)
+
+// entityPhrases are the words callers use when saying an entity of the given
+// type, they are what recognizers most often get wrong without biasing.
+var entityPhrases = map[string][]string{
+	internal_type.SpeechEntitySpelling: {
+		"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M",
+		"N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
+		"double", "as in", "capital",
+	},
+	internal_type.SpeechEntityDigits: {
+		"zero", "oh", "one", "two", "three", "four", "five", "six", "seven",
+		"eight", "nine", "double", "triple", "hash", "star",
+	},
+	internal_type.SpeechEntityEmail: {
+		"at", "dot", "dot com", "underscore", "dash", "hyphen",
+		"gmail", "outlook", "yahoo", "hotmail",
+	},
+	internal_type.SpeechEntityAddress: {
+		"street", "avenue", "road", "boulevard", "lane", "drive", "court",
+		"suite", "apartment", "unit", "floor", "zip code", "postcode",
+	},
+}
+
+// HintPhrases returns the phrases to bias towards for a turn: the configured
+// vocabulary first, then the phrases of the expected entity and finally the
+// explicit phrases of the hint.
+func HintPhrases(vocabulary []Phrase, hint internal_type.SpeechHintPacket) []Phrase {
+	phrases := make([]Phrase, 0, len(vocabulary)+len(hint.Phrases))
+	seen := map[string]bool{}
+	add := func(p Phrase) {
+		key := strings.ToLower(p.Text)
+		if p.Text == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		phrases = append(phrases, p)
+	}
+	for _, p := range vocabulary {
+		add(p)
+	}
+	for _, text := range entityPhrases[hint.Entity] {
+		add(Phrase{Text: text})
+	}
+	for _, text := range hint.Phrases {
+		add(parsePhrase(strings.TrimSpace(text)))
+	}
+	return phrases
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package transformer_internal
+
+import (
+	"testing"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHintPhrases_EmptyHintKeepsVocabulary(t *testing.T) {
+	vocabulary := []Phrase{{Text: "Rapida", Boost: 5}}
+	assert.Equal(t, vocabulary, HintPhrases(vocabulary, internal_type.SpeechHintPacket{}))
+}
+
+func TestHintPhrases_EntityAfterVocabulary(t *testing.T) {
+	phrases := HintPhrases([]Phrase{{Text: "Rapida"}}, internal_type.SpeechHintPacket{Entity: internal_type.SpeechEntityEmail})
+	assert.Equal(t, "Rapida", phrases[0].Text)
+	assert.Contains(t, Texts(phrases), "dot com")
+	assert.Contains(t, Texts(phrases), "underscore")
+}
+
+func TestHintPhrases_ExplicitPhrasesWithBoost(t *testing.T) {
+	phrases := HintPhrases(nil, internal_type.SpeechHintPacket{Phrases: []string{"Acme Corp:8", "acme corp", " "}})
+	assert.Equal(t, []Phrase{{Text: "Acme Corp", Boost: 8}}, phrases)
+}
+
+func TestHintPhrases_UnknownEntity(t *testing.T) {
+	assert.Empty(t, HintPhrases(nil, internal_type.SpeechHintPacket{Entity: "unknown"}))
+}
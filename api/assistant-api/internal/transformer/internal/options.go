@@ -0,0 +1,208 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package transformer_internal
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Namespaces of the deployment audio options, listen. options configure
+// speech to text on the input audio and speak. options text to speech on the
+// output audio.
+const (
+	NamespaceListen = "listen."
+	NamespaceSpeak  = "speak."
+)
+
+// OptionKind is the type of the value of an option.
+type OptionKind string
+
+const (
+	KindString OptionKind = "string"
+	KindBool   OptionKind = "bool"
+	KindNumber OptionKind = "number"
+)
+
+// OptionSpec declares an option a transformer reads.
+type OptionSpec struct {
+	Key  string
+	Kind OptionKind
+	// Enum lists the values a string option takes, any when empty.
+	Enum []string
+	// Min and Max bound a number option.
+	Min, Max float64
+	// Prefix declares every key starting with Key, e.g. speak.profile.
+	Prefix bool
+}
+
+// String declares a string option, limited to enum when given.
+func String(key string, enum ...string) OptionSpec {
+	return OptionSpec{Key: key, Kind: KindString, Enum: enum}
+}
+
+// Bool declares a true or false option.
+func Bool(key string) OptionSpec {
+	return OptionSpec{Key: key, Kind: KindBool}
+}
+
+// Number declares a number option between min and max.
+func Number(key string, min, max float64) OptionSpec {
+	return OptionSpec{Key: key, Kind: KindNumber, Min: min, Max: max}
+}
+
+// Prefixed declares every key under prefix as a string.
+func Prefixed(prefix string) OptionSpec {
+	return OptionSpec{Key: prefix, Kind: KindString, Prefix: true}
+}
+
+// check returns why value does not fit the option, "" when it does. Values
+// are checked the way utils.Option reads them, a value failing here is
+// ignored by the transformer.
+func (s OptionSpec) check(value string) string {
+	switch s.Kind {
+	case KindBool:
+		if _, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(value))); err != nil {
+			return fmt.Sprintf("%q is not true or false", value)
+		}
+	case KindNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not a number", value)
+		}
+		if n < s.Min || n > s.Max {
+			return fmt.Sprintf("%s is out of range, expected %s", value, s.bounds())
+		}
+	case KindString:
+		if len(s.Enum) == 0 {
+			return ""
+		}
+		for _, allowed := range s.Enum {
+			if strings.EqualFold(strings.TrimSpace(value), allowed) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of %s", value, strings.Join(s.Enum, ", "))
+	}
+	return ""
+}
+
+func (s OptionSpec) bounds() string {
+	if math.IsInf(s.Max, 1) {
+		return fmt.Sprintf("at least %g", s.Min)
+	}
+	return fmt.Sprintf("%g to %g", s.Min, s.Max)
+}
+
+// Severity of an OptionIssue. Errors are values the transformer would
+// ignore, warnings keys no transformer reads.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// OptionIssue is a problem with a configured option.
+type OptionIssue struct {
+	Key      string
+	Severity string
+	Message  string
+}
+
+// OptionSchema is the options a transformer reads.
+type OptionSchema []OptionSpec
+
+// With returns the schema extended by more.
+func (s OptionSchema) With(more ...OptionSpec) OptionSchema {
+	return append(append(OptionSchema{}, s...), more...)
+}
+
+func (s OptionSchema) lookup(key string) (OptionSpec, bool) {
+	for _, spec := range s {
+		if spec.Key == key || (spec.Prefix && strings.HasPrefix(key, spec.Key)) {
+			return spec, true
+		}
+	}
+	return OptionSpec{}, false
+}
+
+// Validate checks the options of namespace in opts against the schema. Keys
+// of other namespaces are left alone, except those of the other audio
+// namespace, which are read from the other side of the deployment. Issues
+// are ordered by key.
+func (s OptionSchema) Validate(namespace string, opts map[string]string) []OptionIssue {
+	var issues []OptionIssue
+	for key, value := range opts {
+		if !strings.HasPrefix(key, NamespaceListen) && !strings.HasPrefix(key, NamespaceSpeak) {
+			continue
+		}
+		if !strings.HasPrefix(key, namespace) {
+			issues = append(issues, OptionIssue{Key: key, Severity: SeverityWarning,
+				Message: fmt.Sprintf("%s is ignored, it is read from the %s audio", key, audioOf(key))})
+			continue
+		}
+		spec, ok := s.lookup(key)
+		if !ok {
+			message := fmt.Sprintf("unknown option %s is ignored", key)
+			if suggestion := s.closest(key); suggestion != "" {
+				message += fmt.Sprintf(", did you mean %s?", suggestion)
+			}
+			issues = append(issues, OptionIssue{Key: key, Severity: SeverityWarning, Message: message})
+			continue
+		}
+		if problem := spec.check(value); problem != "" {
+			issues = append(issues, OptionIssue{Key: key, Severity: SeverityError, Message: fmt.Sprintf("%s: %s", key, problem)})
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+	return issues
+}
+
+func audioOf(key string) string {
+	if strings.HasPrefix(key, NamespaceListen) {
+		return "input"
+	}
+	return "output"
+}
+
+// closest returns the declared key a misspelled key most likely meant, ""
+// when none is close.
+func (s OptionSchema) closest(key string) string {
+	best, bestDistance := "", 3
+	for _, spec := range s {
+		if spec.Prefix {
+			continue
+		}
+		if d := distance(key, spec.Key); d < bestDistance {
+			best, bestDistance = spec.Key, d
+		}
+	}
+	return best
+}
+
+// distance is the Levenshtein distance of a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
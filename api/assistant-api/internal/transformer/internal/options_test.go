@@ -0,0 +1,57 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package transformer_internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionSchema_Validate(t *testing.T) {
+	schema := OptionSchema{
+		String("listen.model"),
+		String("listen.mode", "fast", "accurate"),
+		Bool("listen.smart_format"),
+		Number("listen.threshold", 0, 1),
+		Number("listen.padding", 0, math.Inf(1)),
+		Prefixed("listen.profile."),
+	}
+
+	assert.Empty(t, schema.Validate(NamespaceListen, map[string]string{
+		"listen.model":          "nova-3",
+		"listen.mode":           "Fast",
+		"listen.smart_format":   "TRUE",
+		"listen.threshold":      "0.6",
+		"listen.padding":        "500",
+		"listen.profile.a.rate": "0.9",
+		"speaker.language":      "en",
+	}))
+
+	issues := schema.Validate(NamespaceListen, map[string]string{
+		"listen.mode":         "slow",
+		"listen.smart_fromat": "true",
+		"listen.smart_format": "yes",
+		"listen.threshold":    "1.5",
+		"listen.padding":      "-1",
+		"speak.voice.id":      "aura",
+	})
+	require.Len(t, issues, 6)
+	assert.Equal(t, OptionIssue{Key: "listen.mode", Severity: SeverityError,
+		Message: `listen.mode: "slow" is not one of fast, accurate`}, issues[0])
+	assert.Equal(t, OptionIssue{Key: "listen.padding", Severity: SeverityError,
+		Message: "listen.padding: -1 is out of range, expected at least 0"}, issues[1])
+	assert.Equal(t, SeverityError, issues[2].Severity)
+	assert.Equal(t, OptionIssue{Key: "listen.smart_fromat", Severity: SeverityWarning,
+		Message: "unknown option listen.smart_fromat is ignored, did you mean listen.smart_format?"}, issues[3])
+	assert.Equal(t, OptionIssue{Key: "listen.threshold", Severity: SeverityError,
+		Message: "listen.threshold: 1.5 is out of range, expected 0 to 1"}, issues[4])
+	assert.Equal(t, OptionIssue{Key: "speak.voice.id", Severity: SeverityWarning,
+		Message: "speak.voice.id is ignored, it is read from the output audio"}, issues[5])
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package transformer_internal holds helpers shared by the speech provider
+// transformers. Provider specific code lives in each provider package.
+package transformer_internal
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+)
+
+const (
+	// OptionVocabulary is the provider agnostic custom vocabulary of an
+	// assistant: product names, SKUs and other terms the recognizer should
+	// prefer. Phrases are separated by commas, new lines or commons.SEPARATOR
+	// and may carry a boost as "phrase:boost" (e.g. "Rapida:5").
+	OptionVocabulary = "listen.vocabulary"
+
+	// legacy deepgram keys, space separated single words
+	optionKeywords = "listen.keywords"
+	optionKeyword  = "listen.keyword"
+)
+
+// VocabularyOptions are the options Vocabulary reads, declared by the
+// providers taking a custom vocabulary.
+var VocabularyOptions = OptionSchema{
+	String(OptionVocabulary),
+	String(optionKeywords),
+	String(optionKeyword),
+}
+
+// Phrase is a single vocabulary entry. Boost is zero when the provider's
+// default weighting should be used.
+type Phrase struct {
+	Text  string
+	Boost float64
+}
+
+// Vocabulary returns the de-duplicated custom vocabulary configured in the
+// listen options, including the legacy deepgram keyword keys.
+func Vocabulary(opts utils.Option) []Phrase {
+	var phrases []Phrase
+	seen := map[string]bool{}
+	add := func(p Phrase) {
+		key := strings.ToLower(p.Text)
+		if p.Text == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		phrases = append(phrases, p)
+	}
+	if raw, ok := opts[OptionVocabulary]; ok {
+		for _, entry := range splitEntries(raw, splitPhrases) {
+			add(parsePhrase(entry))
+		}
+	}
+	for _, key := range []string{optionKeywords, optionKeyword} {
+		if raw, ok := opts[key]; ok {
+			for _, entry := range splitEntries(raw, splitWords) {
+				add(Phrase{Text: entry})
+			}
+		}
+	}
+	return phrases
+}
+
+// Texts returns the phrase texts without boosts.
+func Texts(phrases []Phrase) []string {
+	out := make([]string, 0, len(phrases))
+	for _, p := range phrases {
+		out = append(out, p.Text)
+	}
+	return out
+}
+
+func splitEntries(raw interface{}, split func(string) []string) []string {
+	var out []string
+	switch v := raw.(type) {
+	case string:
+		out = split(v)
+	case []string:
+		for _, s := range v {
+			out = append(out, strings.TrimSpace(s))
+		}
+	case []interface{}:
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				out = append(out, strings.TrimSpace(str))
+			}
+		}
+	}
+	return out
+}
+
+func splitPhrases(v string) []string {
+	v = strings.ReplaceAll(v, commons.SEPARATOR, ",")
+	v = strings.ReplaceAll(v, "\n", ",")
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func splitWords(v string) []string {
+	return strings.Fields(strings.Trim(v, "[]"))
+}
+
+// parsePhrase splits an optional trailing ":boost" from the phrase.
+func parsePhrase(entry string) Phrase {
+	if i := strings.LastIndex(entry, ":"); i > 0 {
+		if boost, err := strconv.ParseFloat(strings.TrimSpace(entry[i+1:]), 64); err == nil {
+			return Phrase{Text: strings.TrimSpace(entry[:i]), Boost: boost}
+		}
+	}
+	return Phrase{Text: entry}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package transformer_internal
+
+import (
+	"testing"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVocabulary_Empty(t *testing.T) {
+	assert.Empty(t, Vocabulary(utils.Option{}))
+}
+
+func TestVocabulary_CommaSeparatedWithBoost(t *testing.T) {
+	phrases := Vocabulary(utils.Option{OptionVocabulary: "Rapida:10, order number ,SKU-1042:2.5"})
+	assert.Equal(t, []Phrase{
+		{Text: "Rapida", Boost: 10},
+		{Text: "order number"},
+		{Text: "SKU-1042", Boost: 2.5},
+	}, phrases)
+}
+
+func TestVocabulary_SeparatorAndNewLines(t *testing.T) {
+	phrases := Vocabulary(utils.Option{OptionVocabulary: "alpha" + commons.SEPARATOR + "beta\ngamma"})
+	assert.Equal(t, []string{"alpha", "beta", "gamma"}, Texts(phrases))
+}
+
+func TestVocabulary_NonNumericSuffixIsText(t *testing.T) {
+	phrases := Vocabulary(utils.Option{OptionVocabulary: "ratio 3:two"})
+	assert.Equal(t, []Phrase{{Text: "ratio 3:two"}}, phrases)
+}
+
+func TestVocabulary_List(t *testing.T) {
+	phrases := Vocabulary(utils.Option{OptionVocabulary: []interface{}{"alpha:3", "beta", 42}})
+	assert.Equal(t, []Phrase{{Text: "alpha", Boost: 3}, {Text: "beta"}}, phrases)
+}
+
+func TestVocabulary_LegacyKeywords(t *testing.T) {
+	assert.Equal(t, []string{"hello", "world"}, Texts(Vocabulary(utils.Option{"listen.keyword": "[hello world]"})))
+	assert.Equal(t, []string{"hello", "world"}, Texts(Vocabulary(utils.Option{"listen.keywords": "hello world"})))
+}
+
+func TestVocabulary_DeduplicatesCaseInsensitive(t *testing.T) {
+	phrases := Vocabulary(utils.Option{
+		OptionVocabulary:  "Rapida:4",
+		"listen.keywords": "rapida voice",
+	})
+	assert.Equal(t, []Phrase{{Text: "Rapida", Boost: 4}, {Text: "voice"}}, phrases)
+}
@@ -29,7 +29,7 @@ type openaiNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 }
 
 // NewOpenAINormalizer creates an OpenAI-specific text normalizer.
@@ -42,10 +42,10 @@ func NewOpenAINormalizer(logger commons.Logger, opts utils.Option) internal_type
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
 	return &openaiNormalizer{
@@ -67,9 +67,7 @@ func (n *openaiNormalizer) Normalize(ctx context.Context, text string) string {
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// NO XML escaping - OpenAI uses plain text only
 	// NO SSML breaks - OpenAI doesn't support SSML
@@ -0,0 +1,114 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_transformer
+
+import (
+	"fmt"
+	"math"
+
+	internal_diarization "github.com/rapidaai/api/assistant-api/internal/diarization"
+	internal_fallback "github.com/rapidaai/api/assistant-api/internal/fallback"
+	internal_language "github.com/rapidaai/api/assistant-api/internal/language"
+	internal_pacing "github.com/rapidaai/api/assistant-api/internal/pacing"
+	internal_snapshot "github.com/rapidaai/api/assistant-api/internal/snapshot"
+	internal_transformer_assemblyai "github.com/rapidaai/api/assistant-api/internal/transformer/assembly-ai"
+	internal_transformer_azure "github.com/rapidaai/api/assistant-api/internal/transformer/azure"
+	internal_transformer_cartesia "github.com/rapidaai/api/assistant-api/internal/transformer/cartesia"
+	internal_transformer_deepgram "github.com/rapidaai/api/assistant-api/internal/transformer/deepgram"
+	internal_transformer_elevenlabs "github.com/rapidaai/api/assistant-api/internal/transformer/elevenlabs"
+	internal_transformer_google "github.com/rapidaai/api/assistant-api/internal/transformer/google"
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
+	internal_transformer_sarvam "github.com/rapidaai/api/assistant-api/internal/transformer/sarvam"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// OptionIssue is a problem with a listen. or speak. option of a deployment.
+type OptionIssue = transformer_internal.OptionIssue
+
+// Severities of an OptionIssue, a deployment with an error is not saved.
+const (
+	SeverityError   = transformer_internal.SeverityError
+	SeverityWarning = transformer_internal.SeverityWarning
+)
+
+// listenOptions are read from the input audio whatever its provider.
+var listenOptions = transformer_internal.OptionSchema{
+	transformer_internal.Number(internal_snapshot.OptionsKeyThreshold, 0, 1),
+	transformer_internal.Number(internal_snapshot.OptionsKeyPadding, 0, math.Inf(1)),
+	transformer_internal.Bool(internal_diarization.OptionsKeyDiarize),
+	transformer_internal.Number(internal_diarization.OptionsKeyMaxSpeakers, 1, 10),
+	transformer_internal.String(internal_language.OptionsKeyLanguages),
+	transformer_internal.Number(internal_language.OptionsKeyConfirm, 1, 10),
+}
+
+// speakOptions are read from the output audio whatever its provider.
+var speakOptions = transformer_internal.OptionSchema{
+	transformer_internal.Number(internal_pacing.RateOption, internal_pacing.MinRate, internal_pacing.MaxRate),
+	transformer_internal.String(internal_pacing.OptionsKeyProfiles),
+	transformer_internal.Prefixed("speak.profile."),
+	transformer_internal.Number(internal_pacing.OptionsKeyMonologueMax, 0, math.Inf(1)),
+	transformer_internal.String(internal_pacing.OptionsKeyMonologueAction, internal_pacing.MonologueCheckIn, internal_pacing.MonologuePause),
+	transformer_internal.String(internal_pacing.OptionsKeyMonologueCheckIn),
+	transformer_internal.String(internal_fallback.OptionsKeyApology),
+	transformer_internal.String(internal_fallback.OptionsKeyCallback),
+	transformer_internal.String(internal_fallback.OptionsKeyTransfer),
+	transformer_internal.String(internal_fallback.OptionsKeyTransferTo),
+	transformer_internal.Prefixed(internal_language.SpeakOptionsPrefix),
+}
+
+var speechToTextOptions = map[AudioTransformer]transformer_internal.OptionSchema{
+	DEEPGRAM:              internal_transformer_deepgram.SpeechToTextOptions,
+	AZURE_SPEECH_SERVICE:  internal_transformer_azure.SpeechToTextOptions,
+	GOOGLE_SPEECH_SERVICE: internal_transformer_google.SpeechToTextOptions,
+	ASSEMBLYAI:            internal_transformer_assemblyai.SpeechToTextOptions,
+	REVAI:                 nil,
+	SARVAM:                internal_transformer_sarvam.SpeechToTextOptions,
+	CARTESIA:              internal_transformer_cartesia.SpeechToTextOptions,
+}
+
+var textToSpeechOptions = map[AudioTransformer]transformer_internal.OptionSchema{
+	DEEPGRAM:              internal_transformer_deepgram.TextToSpeechOptions,
+	AZURE_SPEECH_SERVICE:  internal_transformer_azure.TextToSpeechOptions,
+	CARTESIA:              internal_transformer_cartesia.TextToSpeechOptions,
+	GOOGLE_SPEECH_SERVICE: internal_transformer_google.TextToSpeechOptions,
+	REVAI:                 nil,
+	SARVAM:                internal_transformer_sarvam.TextToSpeechOptions,
+	ELEVENLABS:            internal_transformer_elevenlabs.TextToSpeechOptions,
+}
+
+// ValidateSpeechToTextOptions checks the listen. options of an input audio
+// against the schema of its provider. Misspelled and unknown keys are
+// warnings, values of the wrong type or out of range errors.
+func ValidateSpeechToTextOptions(provider string, opts map[string]string) []OptionIssue {
+	schema, ok := speechToTextOptions[AudioTransformer(provider)]
+	if !ok {
+		return unknownProvider("speech to text", provider)
+	}
+	return listenOptions.With(schema...).Validate(transformer_internal.NamespaceListen, opts)
+}
+
+// ValidateTextToSpeechOptions checks the speak. options of an output audio
+// against the schema of its provider, and the speaking profiles they set up.
+func ValidateTextToSpeechOptions(provider string, opts map[string]string) []OptionIssue {
+	schema, ok := textToSpeechOptions[AudioTransformer(provider)]
+	if !ok {
+		return unknownProvider("text to speech", provider)
+	}
+	issues := speakOptions.With(schema...).Validate(transformer_internal.NamespaceSpeak, opts)
+	option := make(utils.Option, len(opts))
+	for key, value := range opts {
+		option[key] = value
+	}
+	if _, err := internal_pacing.FromOptions(option); err != nil {
+		issues = append(issues, OptionIssue{Key: internal_pacing.OptionsKeyProfiles, Severity: SeverityError, Message: err.Error()})
+	}
+	return issues
+}
+
+func unknownProvider(kind, provider string) []OptionIssue {
+	return []OptionIssue{{Severity: SeverityError, Message: fmt.Sprintf("%q is not a %s provider", provider, kind)}}
+}
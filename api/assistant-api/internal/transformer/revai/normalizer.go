@@ -30,7 +30,7 @@ type revaiNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 }
 
 // NewRevAINormalizer creates a Rev AI-specific text normalizer.
@@ -43,10 +43,10 @@ func NewRevAINormalizer(logger commons.Logger, opts utils.Option) internal_type.
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
 	return &revaiNormalizer{
@@ -68,9 +68,7 @@ func (n *revaiNormalizer) Normalize(ctx context.Context, text string) string {
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// NO XML escaping - Rev AI uses plain text only
 	// NO SSML breaks - Rev AI doesn't support SSML
@@ -30,7 +30,7 @@ type sarvamNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 }
 
 // NewSarvamNormalizer creates a Sarvam-specific text normalizer.
@@ -43,18 +43,18 @@ func NewSarvamNormalizer(logger commons.Logger, opts utils.Option) internal_type
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
-	return &sarvamNormalizer{
+	return internal_type.NewSSMLNormalizer(&sarvamNormalizer{
 		logger:      logger,
 		config:      cfg,
 		language:    language,
 		normalizers: normalizers,
-	}
+	}, internal_normalizers.PlainText, opts)
 }
 
 // Normalize applies Sarvam-specific text transformations.
@@ -68,9 +68,7 @@ func (n *sarvamNormalizer) Normalize(ctx context.Context, text string) string {
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// NO XML escaping - Sarvam uses plain text only
 	// NO SSML breaks - Sarvam doesn't support SSML
@@ -12,11 +12,25 @@ import (
 	"fmt"
 	"net/url"
 
+	transformer_internal "github.com/rapidaai/api/assistant-api/internal/transformer/internal"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/utils"
 	"github.com/rapidaai/protos"
 )
 
+// SpeechToTextOptions are the listen options sarvam reads.
+var SpeechToTextOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("listen.language"),
+	transformer_internal.String("listen.model"),
+}
+
+// TextToSpeechOptions are the speak options sarvam reads.
+var TextToSpeechOptions = transformer_internal.OptionSchema{
+	transformer_internal.String("speak.voice.id"),
+	transformer_internal.String("speak.language"),
+	transformer_internal.String("speak.model"),
+}
+
 const (
 	TEXT_TO_SPEECH_URL = "wss://api.sarvam.ai/text-to-speech/ws"
 	SPEECH_TO_TEXT_URL = "wss://api.sarvam.ai/speech-to-text/ws"
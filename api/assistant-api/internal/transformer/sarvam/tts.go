@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/gorilla/websocket"
+	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
 	sarvam_internal "github.com/rapidaai/api/assistant-api/internal/transformer/sarvam/internal"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
@@ -33,6 +34,10 @@ type sarvamTextToSpeech struct {
 
 	logger   commons.Logger
 	onPacket func(pkt ...internal_type.Packet) error
+
+	// normalizer removes the SSML the assistant writes, nil to send the text
+	// as it is
+	normalizer internal_type.TextNormalizer
 }
 
 func NewSarvamTextToSpeech(ctx context.Context, logger commons.Logger, credential *protos.VaultCredential,
@@ -43,6 +48,10 @@ func NewSarvamTextToSpeech(ctx context.Context, logger commons.Logger, credentia
 		logger.Errorf("sarvam-tts: initializing sarvam failed %+v", err)
 		return nil, err
 	}
+	var normalizer internal_type.TextNormalizer
+	if internal_normalizers.SSMLPassthrough(opts) {
+		normalizer = NewSarvamNormalizer(logger, opts)
+	}
 	ct, ctxCancel := context.WithCancel(ctx)
 	return &sarvamTextToSpeech{
 		ctx:          ct,
@@ -50,6 +59,7 @@ func NewSarvamTextToSpeech(ctx context.Context, logger commons.Logger, credentia
 		logger:       logger,
 		sarvamOption: sarvamOpts,
 		onPacket:     onPacket,
+		normalizer:   normalizer,
 	}, nil
 }
 
@@ -164,10 +174,15 @@ func (rt *sarvamTextToSpeech) Transform(ctx context.Context, in internal_type.LL
 		// no way to cancel ongoing synthesis in sarvam tts
 		return nil
 	case internal_type.LLMResponseDeltaPacket:
+		text := input.Text
+		if rt.normalizer != nil {
+			// chunks end with a space for the next one to start a word
+			text = rt.normalizer.Normalize(ctx, text) + " "
+		}
 		if err := connection.WriteJSON(map[string]interface{}{
 			"type": "text",
 			"data": map[string]interface{}{
-				"text": input.Text,
+				"text": text,
 			},
 		}); err != nil {
 			rt.logger.Errorf("sarvam-tts: error writing text message to websocket: %v", err)
@@ -30,7 +30,7 @@ type speechmaticsNormalizer struct {
 	language string
 
 	// normalizer pipeline
-	normalizers []internal_normalizers.Normalizer
+	normalizers *internal_normalizers.Chain
 }
 
 // NewSpeechmaticsNormalizer creates a Speechmatics-specific text normalizer.
@@ -43,10 +43,10 @@ func NewSpeechmaticsNormalizer(logger commons.Logger, opts utils.Option) interna
 	}
 
 	// Build normalizer pipeline based on speaker.pronunciation.dictionaries
-	var normalizers []internal_normalizers.Normalizer
+	var normalizers *internal_normalizers.Chain
 	if dictionaries, err := opts.GetString("speaker.pronunciation.dictionaries"); err == nil && dictionaries != "" {
 		normalizerNames := strings.Split(dictionaries, commons.SEPARATOR)
-		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames)
+		normalizers = internal_type.BuildNormalizerPipeline(logger, normalizerNames, opts)
 	}
 
 	return &speechmaticsNormalizer{
@@ -68,9 +68,7 @@ func (n *speechmaticsNormalizer) Normalize(ctx context.Context, text string) str
 	text = n.removeMarkdown(text)
 
 	// Apply normalizer pipeline
-	for _, normalizer := range n.normalizers {
-		text = normalizer.Normalize(text)
-	}
+	text = n.normalizers.Normalize(text)
 
 	// NO XML escaping - Speechmatics uses plain text only
 	// NO SSML breaks - Speechmatics doesn't support SSML
@@ -337,3 +337,29 @@ func BenchmarkGetSpeechToTextTransformer(b *testing.B) {
 		_, _ = GetSpeechToTextTransformer(ctx, mockLogger, DEEPGRAM.String(), credential, func(pkt ...internal_type.Packet) error { return nil }, utils.Option{})
 	}
 }
+
+func TestValidateOptions(t *testing.T) {
+	assert.Empty(t, ValidateSpeechToTextOptions("deepgram", map[string]string{
+		"listen.model":              "nova-3",
+		"listen.vocabulary":         "Rapida:5",
+		"listen.snapshot.threshold": "0.6",
+	}))
+
+	issues := ValidateSpeechToTextOptions("deepgram", map[string]string{"listen.modle": "nova-3"})
+	assert.Len(t, issues, 1)
+	assert.Equal(t, SeverityWarning, issues[0].Severity)
+
+	issues = ValidateTextToSpeechOptions("cartesia", map[string]string{
+		"speak.rate":                    "3",
+		"speak.profiles":                "night",
+		"speak.profile.night.verbosity": "chatty",
+	})
+	assert.Len(t, issues, 2)
+	for _, issue := range issues {
+		assert.Equal(t, SeverityError, issue.Severity)
+	}
+
+	issues = ValidateTextToSpeechOptions("unknown", nil)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+}
@@ -0,0 +1,340 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_ttscache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+)
+
+const (
+	// maxPhraseBytes is the longest audio kept for a context, about a minute
+	// of 16kHz linear16.
+	maxPhraseBytes = 2 << 20
+
+	// maxPhrases is the most phrases a context is kept with.
+	maxPhrases = 32
+
+	// chunkBytes is the size of the audio packets a cached phrase is played
+	// in, 100ms of 16kHz linear16.
+	chunkBytes = 3200
+
+	// readTimeout bounds a read of the cache, a slow cache must not hold
+	// the answer back longer than synthesizing it.
+	readTimeout = 200 * time.Millisecond
+
+	// writeTimeout bounds keeping a phrase, which happens after it was spoken.
+	writeTimeout = 5 * time.Second
+)
+
+// textToSpeech plays the contexts found in the cache and sends the others
+// to the text to speech provider it wraps. Text reaches it as the aggregator
+// chunked it, and a context is kept under the key of its first phrase along
+// with the keys of the phrases it goes on with:
+//
+//   - a phrase kept on its own is played as soon as it arrives;
+//   - the first phrase of a context kept with more is held until the next
+//     ones arrived and matched, then the context is played; a phrase that
+//     does not match, or the end of the answer, sends the held ones to the
+//     provider;
+//   - from the first phrase missing on, the provider speaks the rest of the
+//     context to keep it in order.
+//
+// A context the provider spoke all of is recorded and kept once the provider
+// finished it. Providers end a context once, or once per phrase like Azure;
+// it is kept when the provider ended every phrase, or else when the next
+// context starts.
+type textToSpeech struct {
+	internal_type.TextToSpeechTransformer
+
+	logger   commons.Logger
+	cache    Cache
+	key      func(text string) string
+	onPacket func(pkt ...internal_type.Packet) error
+
+	mu        sync.Mutex
+	contextID string
+	played    bool // audio of the context was played from the cache
+	forwarded int  // phrases of the context sent to the provider
+	done      bool // the end of the answer was sent to the provider
+
+	// held are the phrases matching a kept context, expected the keys of
+	// the phrases it goes on with and pending its audio
+	held     []internal_type.LLMResponseDeltaPacket
+	expected []string
+	pending  []byte
+
+	recording bool
+	phrases   []string // keys of the phrases recorded
+	audio     []byte
+	ends      int  // times the provider ended the context
+	endsEarly bool // the provider ended a phrase before the answer was done
+}
+
+// NewTextToSpeech builds the text to speech of build behind cache, key is
+// the key of a phrase. build is handed the onPacket the provider reports its
+// audio to, so that it can be recorded.
+func NewTextToSpeech(
+	logger commons.Logger,
+	cache Cache,
+	key func(text string) string,
+	onPacket func(pkt ...internal_type.Packet) error,
+	build func(onPacket func(pkt ...internal_type.Packet) error) (internal_type.TextToSpeechTransformer, error),
+) (internal_type.TextToSpeechTransformer, error) {
+	t := &textToSpeech{logger: logger, cache: cache, key: key, onPacket: onPacket}
+	transformer, err := build(t.onProviderPacket)
+	if err != nil {
+		return nil, err
+	}
+	t.TextToSpeechTransformer = transformer
+	return t, nil
+}
+
+func (t *textToSpeech) Transform(ctx context.Context, in internal_type.LLMPacket) error {
+	t.mu.Lock()
+	if in.ContextId() != t.contextID {
+		t.keepLocked(true)
+		t.contextID, t.played, t.forwarded, t.done = in.ContextId(), false, 0, false
+		t.held, t.expected, t.pending = nil, nil, nil
+		t.recording, t.phrases, t.audio = false, nil, nil
+	}
+	t.mu.Unlock()
+
+	switch input := in.(type) {
+	case internal_type.InterruptionPacket:
+		// what the provider still sends of the context is cut
+		t.mu.Lock()
+		t.held, t.expected, t.pending = nil, nil, nil
+		t.recording, t.audio = false, nil
+		t.mu.Unlock()
+	case internal_type.LLMResponseDeltaPacket:
+		return t.speak(ctx, input)
+	case internal_type.LLMResponseDonePacket:
+		return t.finish(ctx, input)
+	}
+	return t.TextToSpeechTransformer.Transform(ctx, in)
+}
+
+// speak plays a phrase from the cache, holds it while it matches a kept
+// context or sends it to the provider.
+func (t *textToSpeech) speak(ctx context.Context, phrase internal_type.LLMResponseDeltaPacket) error {
+	t.mu.Lock()
+	contextID, forwarded, holding := t.contextID, t.forwarded, len(t.expected) > 0
+	t.mu.Unlock()
+	if forwarded > 0 {
+		return t.forward(ctx, phrase)
+	}
+
+	key := t.key(phrase.Text)
+	if holding {
+		t.mu.Lock()
+		held := append(t.held, phrase)
+		if key != t.expected[0] {
+			t.held, t.expected, t.pending = nil, nil, nil
+			t.mu.Unlock()
+			return t.forward(ctx, held...)
+		}
+		t.held, t.expected = held, t.expected[1:]
+		if len(t.expected) > 0 {
+			t.mu.Unlock()
+			return nil
+		}
+		audio := t.pending
+		t.held, t.pending, t.played = nil, nil, true
+		t.mu.Unlock()
+		return t.onPacket(audioPackets(contextID, audio)...)
+	}
+
+	next, audio := t.get(ctx, key)
+	if len(audio) == 0 {
+		return t.forward(ctx, phrase)
+	}
+	t.mu.Lock()
+	if len(next) > 0 {
+		t.held, t.expected, t.pending = []internal_type.LLMResponseDeltaPacket{phrase}, next, audio
+		t.mu.Unlock()
+		return nil
+	}
+	t.played = true
+	t.mu.Unlock()
+	return t.onPacket(audioPackets(contextID, audio)...)
+}
+
+// forward sends phrases to the provider, recording them when nothing of
+// the context was played from the cache.
+func (t *textToSpeech) forward(ctx context.Context, phrases ...internal_type.LLMResponseDeltaPacket) error {
+	t.mu.Lock()
+	if t.forwarded == 0 && !t.played {
+		t.recording, t.phrases, t.audio, t.ends, t.endsEarly = true, nil, nil, 0, false
+	}
+	for _, phrase := range phrases {
+		if t.recording {
+			t.phrases = append(t.phrases, t.key(phrase.Text))
+		}
+	}
+	if len(t.phrases) > maxPhrases {
+		t.recording, t.audio = false, nil
+	}
+	t.forwarded += len(phrases)
+	t.mu.Unlock()
+
+	for _, phrase := range phrases {
+		if err := t.TextToSpeechTransformer.Transform(ctx, phrase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish ends the context, on the provider unless all of it was played
+// from the cache.
+func (t *textToSpeech) finish(ctx context.Context, in internal_type.LLMResponseDonePacket) error {
+	t.mu.Lock()
+	held := t.held
+	t.held, t.expected, t.pending = nil, nil, nil
+	t.mu.Unlock()
+	if len(held) > 0 {
+		// the answer ended before the kept context did
+		if err := t.forward(ctx, held...); err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	if t.forwarded == 0 && t.played {
+		contextID := t.contextID
+		t.mu.Unlock()
+		// nothing of the context reached the provider, it would not end it
+		return t.onPacket(internal_type.TextToSpeechEndPacket{ContextID: contextID})
+	}
+	t.done = true
+	t.keepLocked(false)
+	t.mu.Unlock()
+	return t.TextToSpeechTransformer.Transform(ctx, in)
+}
+
+// onProviderPacket records the audio the provider speaks of the context
+// and keeps it once the provider finished it.
+func (t *textToSpeech) onProviderPacket(pkts ...internal_type.Packet) error {
+	t.mu.Lock()
+	for _, pkt := range pkts {
+		if pkt.ContextId() != t.contextID || !t.recording {
+			continue
+		}
+		switch p := pkt.(type) {
+		case internal_type.TextToSpeechAudioPacket:
+			if len(t.audio)+len(p.AudioChunk) > maxPhraseBytes {
+				t.recording, t.audio = false, nil
+				continue
+			}
+			t.audio = append(t.audio, p.AudioChunk...)
+		case internal_type.TextToSpeechEndPacket:
+			t.ends++
+			t.endsEarly = t.endsEarly || !t.done
+			t.keepLocked(false)
+		}
+	}
+	t.mu.Unlock()
+	return t.onPacket(pkts...)
+}
+
+// Close keeps the context the provider finished last.
+func (t *textToSpeech) Close(ctx context.Context) error {
+	t.mu.Lock()
+	t.keepLocked(true)
+	t.mu.Unlock()
+	return t.TextToSpeechTransformer.Close(ctx)
+}
+
+// keepLocked keeps the recorded context once the provider spoke all of it:
+// when it ended every phrase or, for a provider ending the context once
+// after the answer, when the context is over.
+func (t *textToSpeech) keepLocked(over bool) {
+	if !t.recording || !t.done || len(t.audio) == 0 {
+		return
+	}
+	if t.ends != t.forwarded && (!over || t.ends == 0 || t.endsEarly) {
+		return
+	}
+	go t.set(t.phrases[0], encodeEntry(t.phrases[1:], t.audio))
+	t.recording, t.audio = false, nil
+}
+
+// get returns the keys of the phrases the context kept under key goes on
+// with and its audio, no audio when there is none.
+func (t *textToSpeech) get(ctx context.Context, key string) ([]string, []byte) {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	entry, err := t.cache.Get(ctx, key)
+	if err != nil {
+		t.logger.Warnf("tts-cache: unable to read phrase %s: %v", key, err)
+		return nil, nil
+	}
+	if len(entry) == 0 {
+		return nil, nil
+	}
+	next, audio, ok := decodeEntry(entry)
+	if !ok {
+		t.logger.Warnf("tts-cache: unable to decode phrase %s", key)
+		return nil, nil
+	}
+	return next, audio
+}
+
+func (t *textToSpeech) set(key string, audio []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+	if err := t.cache.Set(ctx, key, audio); err != nil {
+		t.logger.Warnf("tts-cache: unable to keep phrase %s: %v", key, err)
+	}
+}
+
+// encodeEntry writes a context kept in the cache: the number of phrases
+// after the first, the length and key of each, then the audio.
+func encodeEntry(next []string, audio []byte) []byte {
+	size := 1 + len(audio)
+	for _, key := range next {
+		size += 1 + len(key)
+	}
+	entry := make([]byte, 0, size)
+	entry = append(entry, byte(len(next)))
+	for _, key := range next {
+		entry = append(entry, byte(len(key)))
+		entry = append(entry, key...)
+	}
+	return append(entry, audio...)
+}
+
+// decodeEntry reads a context written by encodeEntry.
+func decodeEntry(entry []byte) ([]string, []byte, bool) {
+	if len(entry) == 0 {
+		return nil, nil, false
+	}
+	n, rest := int(entry[0]), entry[1:]
+	next := make([]string, 0, n)
+	for range n {
+		if len(rest) == 0 || len(rest) < 1+int(rest[0]) {
+			return nil, nil, false
+		}
+		next = append(next, string(rest[1:1+int(rest[0])]))
+		rest = rest[1+int(rest[0]):]
+	}
+	return next, rest, true
+}
+
+// audioPackets splits the audio of a cached phrase into packets.
+func audioPackets(contextID string, audio []byte) []internal_type.Packet {
+	pkts := make([]internal_type.Packet, 0, len(audio)/chunkBytes+1)
+	for start := 0; start < len(audio); start += chunkBytes {
+		end := min(start+chunkBytes, len(audio))
+		pkts = append(pkts, internal_type.TextToSpeechAudioPacket{ContextID: contextID, AudioChunk: audio[start:end]})
+	}
+	return pkts
+}
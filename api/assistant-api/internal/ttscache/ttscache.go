@@ -0,0 +1,140 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_ttscache keeps the audio of the phrases an assistant
+// speaks again and again, greetings, disclaimers and prompts, so they are
+// played from the cache instead of being synthesized on every call.
+//
+// Phrases are content addressed: the key hashes the assistant, the text to
+// speech provider, its options (voice, model, language, normalizers, ...)
+// and the text, so a phrase is only ever played in the voice and format it
+// was synthesized in.
+package internal_ttscache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rapidaai/pkg/storages"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// OptionsKeyEnabled is the text to speech option an assistant enables the
+// cache of its phrases with.
+const OptionsKeyEnabled = "speaker.cache.enabled"
+
+// Enabled reports whether the text to speech options enable the cache.
+func Enabled(opts utils.Option) bool {
+	enabled, err := opts.GetBool(OptionsKeyEnabled)
+	return err == nil && enabled
+}
+
+// Cache keeps the audio of phrases under their key.
+type Cache interface {
+	// Get returns the audio kept under key, nil when there is none or it
+	// expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set keeps audio under key.
+	Set(ctx context.Context, key string, audio []byte) error
+}
+
+// Key is the key of text spoken by the text to speech provider of the
+// assistant with opts. Options of the platform, such as the credential, do
+// not change the audio and are left out, whitespace is collapsed.
+func Key(assistantId uint64, provider string, opts utils.Option, text string) string {
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		if !strings.HasPrefix(k, "rapida.") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00", assistantId, provider)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%v\x00", k, opts[k])
+	}
+	h.Write([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ============================================================================
+// Redis
+// ============================================================================
+
+// redisKeyPrefix namespaces the phrases kept in redis.
+const redisKeyPrefix = "rapida:tts:cache:"
+
+type redisCache struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisCache keeps phrases in client, each for ttl.
+func NewRedisCache(client redis.UniversalClient, ttl time.Duration) Cache {
+	return &redisCache{client: client, ttl: ttl}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	audio, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return audio, err
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, audio []byte) error {
+	return c.client.Set(ctx, redisKeyPrefix+key, audio, c.ttl).Err()
+}
+
+// ============================================================================
+// Asset store
+// ============================================================================
+
+// storageKeyPrefix is the folder of the phrases in the asset store.
+const storageKeyPrefix = "tts-cache/"
+
+type storageCache struct {
+	storage storages.Storage
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewStorageCache keeps phrases in the asset store, S3 or the local disk.
+// The store cannot expire objects itself, a phrase is written after the time
+// it expires at and read as missing after it; a lifecycle rule on the
+// tts-cache/ prefix removes old objects from a bucket.
+func NewStorageCache(storage storages.Storage, ttl time.Duration) Cache {
+	return &storageCache{storage: storage, ttl: ttl, now: time.Now}
+}
+
+func (c *storageCache) Get(ctx context.Context, key string) ([]byte, error) {
+	object := c.storage.Get(ctx, storageKeyPrefix+key)
+	if object.Error != nil || len(object.Data) < 8 {
+		// a phrase never kept is not an error
+		return nil, nil
+	}
+	if expiresAt := int64(binary.BigEndian.Uint64(object.Data)); c.now().Unix() >= expiresAt {
+		return nil, nil
+	}
+	return object.Data[8:], nil
+}
+
+func (c *storageCache) Set(ctx context.Context, key string, audio []byte) error {
+	object := make([]byte, 8, 8+len(audio))
+	binary.BigEndian.PutUint64(object, uint64(c.now().Add(c.ttl).Unix()))
+	return c.storage.Store(ctx, storageKeyPrefix+key, append(object, audio...)).Error
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_ttscache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/storages"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	opts := utils.Option{"speak.voice.id": "aura", "rapida.credential_id": 7}
+	key := Key(1, "deepgram", opts, "Hello,  how can I help?")
+
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, Key(1, "deepgram", utils.Option{"speak.voice.id": "aura", "rapida.credential_id": 9}, "Hello, how can I help?"))
+	assert.NotEqual(t, key, Key(2, "deepgram", opts, "Hello, how can I help?"))
+	assert.NotEqual(t, key, Key(1, "cartesia", opts, "Hello, how can I help?"))
+	assert.NotEqual(t, key, Key(1, "deepgram", utils.Option{"speak.voice.id": "luna"}, "Hello, how can I help?"))
+	assert.NotEqual(t, key, Key(1, "deepgram", opts, "Hello, how can I help you?"))
+}
+
+func TestEnabled(t *testing.T) {
+	assert.True(t, Enabled(utils.Option{OptionsKeyEnabled: "true"}))
+	assert.False(t, Enabled(utils.Option{OptionsKeyEnabled: "false"}))
+	assert.False(t, Enabled(utils.Option{}))
+}
+
+func TestRedisCache(t *testing.T) {
+	ctx := context.Background()
+	db, mock := redismock.NewClientMock()
+	cache := NewRedisCache(db, time.Hour)
+
+	mock.ExpectSet(redisKeyPrefix+"k", []byte("audio"), time.Hour).SetVal("OK")
+	require.NoError(t, cache.Set(ctx, "k", []byte("audio")))
+
+	mock.ExpectGet(redisKeyPrefix + "k").SetVal("audio")
+	audio, err := cache.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("audio"), audio)
+
+	mock.ExpectGet(redisKeyPrefix + "missing").RedisNil()
+	audio, err = cache.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, audio)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type memoryStorage struct {
+	storages.Storage
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) Store(_ context.Context, key string, content []byte) storages.StorageOutput {
+	s.objects[key] = content
+	return storages.StorageOutput{CompletePath: key}
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) storages.GetStorageOutput {
+	if object, ok := s.objects[key]; ok {
+		return storages.GetStorageOutput{Data: object}
+	}
+	return storages.GetStorageOutput{Error: errors.New("not found")}
+}
+
+func TestStorageCache(t *testing.T) {
+	ctx := context.Background()
+	storage := &memoryStorage{objects: map[string][]byte{}}
+	now := time.Unix(1_700_000_000, 0)
+	cache := &storageCache{storage: storage, ttl: time.Hour, now: func() time.Time { return now }}
+
+	require.NoError(t, cache.Set(ctx, "k", []byte("audio")))
+	assert.Contains(t, storage.objects, storageKeyPrefix+"k")
+
+	audio, err := cache.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("audio"), audio)
+
+	audio, err = cache.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, audio)
+
+	now = now.Add(time.Hour)
+	audio, err = cache.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Nil(t, audio, "an expired phrase is missing")
+}
+
+// =============================================================================
+// Text to speech
+// =============================================================================
+
+type memoryCache struct {
+	mu      sync.Mutex
+	phrases map[string][]byte
+	kept    chan string
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.phrases[key], nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, audio []byte) error {
+	c.mu.Lock()
+	c.phrases[key] = audio
+	c.mu.Unlock()
+	c.kept <- key
+	return nil
+}
+
+// fakeProvider speaks each phrase as its text and ends the context when it
+// is done, like a streaming provider, or each phrase like Azure.
+type fakeProvider struct {
+	internal_type.TextToSpeechTransformer
+	onPacket      func(pkt ...internal_type.Packet) error
+	spoken        []string
+	endEachPhrase bool
+}
+
+func (p *fakeProvider) Transform(_ context.Context, in internal_type.LLMPacket) error {
+	switch input := in.(type) {
+	case internal_type.LLMResponseDeltaPacket:
+		p.spoken = append(p.spoken, input.Text)
+		pkts := []internal_type.Packet{internal_type.TextToSpeechAudioPacket{ContextID: input.ContextID, AudioChunk: []byte(input.Text)}}
+		if p.endEachPhrase {
+			pkts = append(pkts, internal_type.TextToSpeechEndPacket{ContextID: input.ContextID})
+		}
+		return p.onPacket(pkts...)
+	case internal_type.LLMResponseDonePacket:
+		if p.endEachPhrase {
+			return nil
+		}
+		return p.onPacket(internal_type.TextToSpeechEndPacket{ContextID: input.ContextID})
+	}
+	return nil
+}
+
+func (p *fakeProvider) Close(context.Context) error { return nil }
+
+type packets struct {
+	mu   sync.Mutex
+	list []internal_type.Packet
+}
+
+func (p *packets) onPacket(pkts ...internal_type.Packet) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.list = append(p.list, pkts...)
+	return nil
+}
+
+// audio is the audio played for contextID and whether it was ended.
+func (p *packets) audio(contextID string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var audio string
+	ended := false
+	for _, pkt := range p.list {
+		switch pkt := pkt.(type) {
+		case internal_type.TextToSpeechAudioPacket:
+			if pkt.ContextID == contextID {
+				audio += string(pkt.AudioChunk)
+			}
+		case internal_type.TextToSpeechEndPacket:
+			ended = ended || pkt.ContextID == contextID
+		}
+	}
+	return audio, ended
+}
+
+func newTestTextToSpeech(t *testing.T, cache Cache) (internal_type.TextToSpeechTransformer, *fakeProvider, *packets) {
+	logger, _ := commons.NewApplicationLogger()
+	out := &packets{}
+	provider := &fakeProvider{}
+	tts, err := NewTextToSpeech(logger, cache, func(text string) string { return text }, out.onPacket,
+		func(onPacket func(pkt ...internal_type.Packet) error) (internal_type.TextToSpeechTransformer, error) {
+			provider.onPacket = onPacket
+			return provider, nil
+		})
+	require.NoError(t, err)
+	return tts, provider, out
+}
+
+func speak(t *testing.T, tts internal_type.TextToSpeechTransformer, contextID string, phrases ...string) {
+	ctx := context.Background()
+	for _, phrase := range phrases {
+		require.NoError(t, tts.Transform(ctx, internal_type.LLMResponseDeltaPacket{ContextID: contextID, Text: phrase}))
+	}
+	require.NoError(t, tts.Transform(ctx, internal_type.LLMResponseDonePacket{ContextID: contextID}))
+}
+
+func waitKept(t *testing.T, cache *memoryCache, want string) {
+	t.Helper()
+	select {
+	case key := <-cache.kept:
+		assert.Equal(t, want, key)
+	case <-time.After(time.Second):
+		t.Fatalf("%q was not kept", want)
+	}
+}
+
+func TestTextToSpeech_KeepsAndPlaysPhrase(t *testing.T) {
+	cache := &memoryCache{phrases: map[string][]byte{}, kept: make(chan string, 1)}
+	tts, provider, out := newTestTextToSpeech(t, cache)
+
+	speak(t, tts, "greeting-1", "Hello, how can I help?")
+	waitKept(t, cache, "Hello, how can I help?")
+
+	speak(t, tts, "greeting-2", "Hello, how can I help?")
+	assert.Equal(t, []string{"Hello, how can I help?"}, provider.spoken, "the second greeting is played from the cache")
+	audio, ended := out.audio("greeting-2")
+	assert.Equal(t, "Hello, how can I help?", audio)
+	assert.True(t, ended)
+}
+
+func TestTextToSpeech_ProviderSpeaksFromFirstMiss(t *testing.T) {
+	cache := &memoryCache{phrases: map[string][]byte{"Welcome.": encodeEntry(nil, []byte("Welcome."))}, kept: make(chan string, 1)}
+	tts, provider, out := newTestTextToSpeech(t, cache)
+
+	speak(t, tts, "answer", "Welcome.", "Your balance is ten.", "Welcome.")
+	assert.Equal(t, []string{"Your balance is ten.", "Welcome."}, provider.spoken, "phrases after a miss keep their order")
+	audio, ended := out.audio("answer")
+	assert.Equal(t, "Welcome.Your balance is ten.Welcome.", audio)
+	assert.True(t, ended)
+
+	select {
+	case key := <-cache.kept:
+		t.Fatalf("a context partly played from the cache was kept as %q", key)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestTextToSpeech_KeepsAndPlaysContextOfManyPhrases(t *testing.T) {
+	greeting := []string{"Hi,", "thanks for calling Acme.", "How can I help?"}
+	for _, endEachPhrase := range []bool{false, true} {
+		cache := &memoryCache{phrases: map[string][]byte{}, kept: make(chan string, 1)}
+		tts, provider, out := newTestTextToSpeech(t, cache)
+		provider.endEachPhrase = endEachPhrase
+
+		speak(t, tts, "greeting-1", greeting...)
+		if !endEachPhrase {
+			// the provider ended the context once, it is kept when it is over
+			require.NoError(t, tts.Close(context.Background()))
+		}
+		waitKept(t, cache, "Hi,")
+
+		speak(t, tts, "greeting-2", greeting...)
+		assert.Equal(t, greeting, provider.spoken, "the second greeting is played from the cache")
+		audio, ended := out.audio("greeting-2")
+		assert.Equal(t, "Hi,thanks for calling Acme.How can I help?", audio)
+		assert.True(t, ended)
+	}
+}
+
+func TestTextToSpeech_ProviderSpeaksContextThatGoesOnDifferently(t *testing.T) {
+	cache := &memoryCache{phrases: map[string][]byte{
+		"Hi,": encodeEntry([]string{"thanks for calling Acme.", "How can I help?"}, []byte("kept")),
+	}, kept: make(chan string, 1)}
+	tts, provider, out := newTestTextToSpeech(t, cache)
+
+	speak(t, tts, "answer", "Hi,", "thanks for calling Acme.", "Goodbye.")
+	assert.Equal(t, []string{"Hi,", "thanks for calling Acme.", "Goodbye."}, provider.spoken, "held phrases keep their order")
+	audio, ended := out.audio("answer")
+	assert.Equal(t, "Hi,thanks for calling Acme.Goodbye.", audio)
+	assert.True(t, ended)
+
+	speak(t, tts, "short", "Hi,", "thanks for calling Acme.")
+	assert.Equal(t, []string{"Hi,", "thanks for calling Acme."}, provider.spoken[3:], "an answer ending early is spoken")
+}
+
+func TestEntry(t *testing.T) {
+	next, audio, ok := decodeEntry(encodeEntry([]string{"b", "cd"}, []byte("audio")))
+	require.True(t, ok)
+	assert.Equal(t, []string{"b", "cd"}, next)
+	assert.Equal(t, []byte("audio"), audio)
+
+	_, _, ok = decodeEntry([]byte{2, 1, 'b'})
+	assert.False(t, ok)
+}
+
+func TestTextToSpeech_InterruptedPhraseIsNotKept(t *testing.T) {
+	cache := &memoryCache{phrases: map[string][]byte{}, kept: make(chan string, 1)}
+	tts, _, _ := newTestTextToSpeech(t, cache)
+	ctx := context.Background()
+
+	require.NoError(t, tts.Transform(ctx, internal_type.LLMResponseDeltaPacket{ContextID: "c", Text: "Let me explain."}))
+	require.NoError(t, tts.Transform(ctx, internal_type.InterruptionPacket{ContextID: "c"}))
+	require.NoError(t, tts.Transform(ctx, internal_type.LLMResponseDonePacket{ContextID: "c"}))
+
+	select {
+	case key := <-cache.kept:
+		t.Fatalf("an interrupted phrase was kept as %q", key)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
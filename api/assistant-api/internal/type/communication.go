@@ -11,6 +11,7 @@ import (
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
 	internal_conversation_entity "github.com/rapidaai/api/assistant-api/internal/entity/conversations"
 	internal_knowledge_gorm "github.com/rapidaai/api/assistant-api/internal/entity/knowledges"
+	internal_pacing "github.com/rapidaai/api/assistant-api/internal/pacing"
 	internal_adapter_tracing "github.com/rapidaai/api/assistant-api/internal/telemetry"
 
 	endpoint_client "github.com/rapidaai/pkg/clients/endpoint"
@@ -76,6 +77,24 @@ type Communication interface {
 	GetArgs() map[string]interface{}
 	GetOptions() utils.Option
 
+	// conversation scoped state shared between tools and the llm
+	Scratchpad() Scratchpad
+
+	// metadata tools and clients attach to the conversation record
+	CustomMetadata() CustomMetadata
+
+	// speaking profiles of the deployment and the one the assistant speaks
+	// in, nil when it speaks as configured
+	SpeakingProfiles() internal_pacing.Profiles
+	SpeakingProfile() *internal_pacing.Profile
+
+	// guidance supervisors whispered to the assistant, oldest first
+	Whispers() []string
+
+	// language the call switched to following the caller, "" while it is in
+	// the one it started in
+	Language() string
+
 	//
 	GetKnowledge(ctx context.Context, knowledgeId uint64) (*internal_knowledge_gorm.Knowledge, error)
 
@@ -0,0 +1,24 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_type
+
+import "context"
+
+// CustomMetadata is the metadata tools and clients append to the active
+// conversation, such as an order ID or a resolution code. It is stored with
+// the conversation record under its own namespace and included in webhook
+// payloads.
+type CustomMetadata interface {
+	// Get returns the value stored under key.
+	Get(key string) (interface{}, bool)
+
+	// Set validates key and value against the namespace and size limits and
+	// stores them on the conversation.
+	Set(ctx context.Context, key string, value interface{}) error
+
+	// All returns a copy of every entry, keyed without the namespace.
+	All() map[string]interface{}
+}
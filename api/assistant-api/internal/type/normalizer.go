@@ -12,6 +12,7 @@ import (
 
 	internal_normalizers "github.com/rapidaai/api/assistant-api/internal/normalizers"
 	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/utils"
 )
 
 // =============================================================================
@@ -26,6 +27,37 @@ type TextNormalizer interface {
 	Normalize(ctx context.Context, text string) string
 }
 
+// =============================================================================
+// SSML Passthrough
+// =============================================================================
+
+// ssmlNormalizer speaks the SSML the assistant writes in the dialect of a
+// text to speech provider.
+type ssmlNormalizer struct {
+	normalizer TextNormalizer
+	dialect    internal_normalizers.SSMLDialect
+}
+
+// NewSSMLNormalizer lets the SSML the assistant writes through normalizer
+// when opts enable internal_normalizers.OptionsKeySSMLPassthrough: its tags
+// are rendered in dialect and normalizer runs over the text between them, a
+// nil normalizer keeps that text as it is. Without passthrough it returns
+// normalizer.
+func NewSSMLNormalizer(normalizer TextNormalizer, dialect internal_normalizers.SSMLDialect, opts utils.Option) TextNormalizer {
+	if !internal_normalizers.SSMLPassthrough(opts) {
+		return normalizer
+	}
+	return &ssmlNormalizer{normalizer: normalizer, dialect: dialect}
+}
+
+func (n *ssmlNormalizer) Normalize(ctx context.Context, text string) string {
+	normalize := func(text string) string { return text }
+	if n.normalizer != nil {
+		normalize = func(text string) string { return n.normalizer.Normalize(ctx, text) }
+	}
+	return strings.Join(strings.Fields(internal_normalizers.NormalizeSSML(text, n.dialect, normalize)), " ")
+}
+
 // =============================================================================
 // SSML Format Types
 // =============================================================================
@@ -61,8 +93,14 @@ func DefaultNormalizerConfig() NormalizerConfig {
 	}
 }
 
-func BuildNormalizerPipeline(logger commons.Logger, names []string) []internal_normalizers.Normalizer {
+// BuildNormalizerPipeline chains the named normalizers, see
+// internal_normalizers.Chain. Numbers, dates, times and amounts are spoken in
+// speaker.language of opts, the language of the text to speech, see
+// internal_normalizers.LocaleFor. It returns nil when none is known.
+func BuildNormalizerPipeline(logger commons.Logger, names []string, opts utils.Option) *internal_normalizers.Chain {
 	normalizers := make([]internal_normalizers.Normalizer, 0, len(names))
+	language, _ := opts.GetString("speaker.language")
+	locale := internal_normalizers.LocaleFor(language)
 
 	for _, name := range names {
 		name = strings.TrimSpace(strings.ToLower(name))
@@ -72,13 +110,13 @@ func BuildNormalizerPipeline(logger commons.Logger, names []string) []internal_n
 		case "url":
 			normalizer = internal_normalizers.NewUrlNormalizer(logger)
 		case "currency":
-			normalizer = internal_normalizers.NewCurrencyNormalizer(logger)
+			normalizer = internal_normalizers.NewCurrencyNormalizerFor(logger, locale)
 		case "date":
-			normalizer = internal_normalizers.NewDateNormalizer(logger)
+			normalizer = internal_normalizers.NewDateNormalizerFor(logger, locale)
 		case "time":
-			normalizer = internal_normalizers.NewTimeNormalizer(logger)
+			normalizer = internal_normalizers.NewTimeNormalizerFor(logger, locale)
 		case "number", "number-to-word":
-			normalizer = internal_normalizers.NewNumberToWordNormalizer(logger)
+			normalizer = internal_normalizers.NewNumberToWordNormalizerFor(logger, locale, internal_normalizers.NumberToWordOptions(opts)...)
 		case "symbol":
 			normalizer = internal_normalizers.NewSymbolNormalizer(logger)
 		case "general-abbreviation", "general":
@@ -89,11 +127,15 @@ func BuildNormalizerPipeline(logger commons.Logger, names []string) []internal_n
 			normalizer = internal_normalizers.NewTechAbbreviationNormalizer(logger)
 		case "address":
 			normalizer = internal_normalizers.NewAddressNormalizer(logger)
+		case "phone", "phone-number":
+			normalizer = internal_normalizers.NewPhoneNumberNormalizerFor(logger, locale)
+		case "email":
+			normalizer = internal_normalizers.NewEmailNormalizer(logger)
 		default:
 			logger.Warnf("normalizer: unknown normalizer '%s', skipping", name)
 			continue
 		}
 		normalizers = append(normalizers, normalizer)
 	}
-	return normalizers
+	return internal_normalizers.NewChain(normalizers...)
 }
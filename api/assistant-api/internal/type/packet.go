@@ -7,6 +7,7 @@ package internal_type
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/rapidaai/protos"
 )
@@ -56,6 +57,15 @@ type InterruptionPacket struct {
 
 	// end of interruption
 	EndAt float64
+
+	// CapturedAt is when the channel received the audio the VAD found the
+	// caller's voice in, zero for other sources.
+	CapturedAt time.Time
+
+	// Explicit marks interruptions not caused by the caller's voice, typed
+	// text, key presses or the agent itself. Barge in tuning does not hold
+	// them back.
+	Explicit bool
 }
 
 // ContextId returns the identifier of the context associated with this interruption request.
@@ -323,6 +333,9 @@ type UserTextPacket struct {
 
 	// text
 	Text string
+
+	// speaker who said most of the turn when speakers are told apart
+	Speaker string
 }
 
 func (f UserTextPacket) ContextId() string {
@@ -344,6 +357,9 @@ type UserAudioPacket struct {
 	Audio []byte
 
 	NoiseReduced bool
+
+	// CapturedAt is when the channel received the audio.
+	CapturedAt time.Time
 }
 
 func (f UserAudioPacket) ContextId() string {
@@ -358,6 +374,271 @@ func (f UserAudioPacket) Role() string {
 	return "user"
 }
 
+// UserOpusPacket is one Opus packet of the caller as it arrived on a WebRTC
+// track, 48kHz and not decoded. It goes straight to speech to text
+// transformers that take Opus, see SpeechToTextOpusReceiver.
+type UserOpusPacket struct {
+	ContextID string
+
+	Payload []byte
+
+	// Timestamp is the RTP timestamp of the packet, in 48kHz samples.
+	Timestamp uint32
+}
+
+func (f UserOpusPacket) ContextId() string {
+	return f.ContextID
+}
+
+// Conversation metadata keys the talk loop acts on besides storing them.
+const (
+	// MetadataKeyDTMF delivers keypad input (RFC 4733 events, provider DTMF
+	// webhooks) from the channel to the talk loop.
+	MetadataKeyDTMF = "dtmf"
+
+	// MetadataKeySpeechEntity lets the client announce the entity the user is
+	// expected to say next (e.g. the current step of its flow), see
+	// SpeechHintPacket.
+	MetadataKeySpeechEntity = "listen.entity"
+
+	// MetadataKeyTransferFailed tells the talk loop that a transfer requested
+	// by the assistant did not go through and the caller is back with it. The
+	// value carries the reason.
+	MetadataKeyTransferFailed = "transfer.failed"
+
+	// MetadataKeyCallHold tells the talk loop the remote party put the call
+	// on hold ("true") or took it off hold ("false"), see CallHoldPacket.
+	MetadataKeyCallHold = "call.hold"
+
+	// MetadataKeyChannelUUID reports the provider's id of the call once the
+	// channel learns it mid call. The talk loop keeps it on the call context
+	// so call control can reach the call.
+	MetadataKeyChannelUUID = "telephony.uuid"
+
+	// MetadataKeyAnsweredBy carries who picked up an outbound call, one of the
+	// AnsweredBy values. Channels detecting answering machines set it on the
+	// ConversationInitialization so the talk loop knows before it greets.
+	MetadataKeyAnsweredBy = "telephony.answered_by"
+
+	// MetadataKeyPickupCode reports the code a human agent dials after the
+	// pickup feature code (e.g. *81042) to take the call over, see
+	// CallPickupPacket.
+	MetadataKeyPickupCode = "telephony.pickup_code"
+
+	// MetadataKeyUserTyping tells the talk loop of a text chat that the user
+	// started ("true") or stopped ("false") typing, see UserTypingPacket.
+	MetadataKeyUserTyping = "chat.user_typing"
+
+	// MetadataKeyAssistantTyping tells the client of a text chat that the
+	// assistant is writing an answer ("true") or done with it ("false").
+	MetadataKeyAssistantTyping = "chat.assistant_typing"
+
+	// MetadataKeySupervisorBarge tells the client a supervisor took the
+	// conversation over from the assistant ("true") or handed it back
+	// ("false"), see SupervisorBargePacket.
+	MetadataKeySupervisorBarge = "supervisor.barge"
+
+	// MetadataKeyConferenceSpeaker tells the client of a call human agents
+	// joined who speaks: caller, assistant, agent, or empty when nobody does.
+	MetadataKeyConferenceSpeaker = "conference.active_speaker"
+
+	// MetadataKeyLanguage tells the client the call switched to another of
+	// the languages the assistant allows, e.g. es-ES.
+	MetadataKeyLanguage = "conversation.language"
+)
+
+// UserDTMFPacket is a single keypad press of the user.
+type UserDTMFPacket struct {
+	// contextID identifies the context to be flushed.
+	ContextID string
+
+	// Digit is one of 0-9, *, # or A-D
+	Digit string
+}
+
+func (f UserDTMFPacket) ContextId() string {
+	return f.ContextID
+}
+
+func (f UserDTMFPacket) Role() string {
+	return "user"
+}
+
+// UserTypingPacket is the user of a text chat starting or stopping to type.
+type UserTypingPacket struct {
+	// contextID identifies the context to be flushed.
+	ContextID string
+
+	// Typing is true while the user types.
+	Typing bool
+}
+
+func (f UserTypingPacket) ContextId() string {
+	return f.ContextID
+}
+
+// SupervisorBargePacket tells the talk loop a supervisor barged in, the
+// assistant is muted and the caller's turns are left to the supervisor, or
+// handed the conversation back to the assistant.
+type SupervisorBargePacket struct {
+	// contextID identifies the turn that was active when the barge changed.
+	ContextID string
+
+	// Barging is true while the supervisor has the conversation.
+	Barging bool
+}
+
+func (f SupervisorBargePacket) ContextId() string {
+	return f.ContextID
+}
+
+// SupervisorSpeechPacket is text a barging supervisor says to the caller in
+// the assistant's voice.
+type SupervisorSpeechPacket struct {
+	// contextID identifies the turn the supervisor speaks in.
+	ContextID string
+
+	Text string
+}
+
+func (f SupervisorSpeechPacket) ContextId() string {
+	return f.ContextID
+}
+
+func (f SupervisorSpeechPacket) Role() string {
+	return "supervisor"
+}
+
+func (f SupervisorSpeechPacket) Content() string {
+	return f.Text
+}
+
+// SupervisorAudioPacket is the voice of a barging supervisor for the caller,
+// linear16 16 kHz mono.
+type SupervisorAudioPacket struct {
+	// contextID identifies the turn the supervisor speaks in.
+	ContextID string
+
+	Audio []byte
+}
+
+func (f SupervisorAudioPacket) ContextId() string {
+	return f.ContextID
+}
+
+// SpeechHintPacket tells the speech to text layer what the user is expected to
+// say next, so providers that support it bias recognition for the next turn.
+type SpeechHintPacket struct {
+	// contextID identifies the turn the hint was derived from.
+	ContextID string
+
+	// Entity is the expected entity type, one of the SpeechEntity* values or
+	// empty when nothing specific is expected.
+	Entity string
+
+	// Phrases are additional phrases to bias towards for this turn.
+	Phrases []string
+}
+
+func (f SpeechHintPacket) ContextId() string {
+	return f.ContextID
+}
+
+// Expected entity types of a SpeechHintPacket.
+const (
+	SpeechEntitySpelling = "spelling"
+	SpeechEntityDigits   = "digits"
+	SpeechEntityEmail    = "email"
+	SpeechEntityAddress  = "address"
+)
+
+// SpellingModePacket switches capturing of character by character input on
+// or off. While on, user turns are decoded (NATO alphabet, "at", "dot", ...),
+// validated and handed to the model with a read back to confirm.
+type SpellingModePacket struct {
+	// contextID identifies the turn that requested the mode.
+	ContextID string
+
+	// Enabled turns the mode on, it turns off again once a valid value is
+	// captured.
+	Enabled bool
+
+	// Kind is the kind of value expected, "email" or "alphanumeric".
+	Kind string
+
+	// Pattern optionally replaces the validation of the kind.
+	Pattern string
+}
+
+func (f SpellingModePacket) ContextId() string {
+	return f.ContextID
+}
+
+// DictationModePacket switches dictation on or off. While on, user turns are
+// collected with their spoken punctuation applied instead of going to the
+// model, the assistant stays quiet until the caller ends the dictation or
+// MaxDuration passes. The dictation is then attached to the conversation and
+// handed to the model as a single turn.
+type DictationModePacket struct {
+	// contextID identifies the turn that requested the mode.
+	ContextID string
+
+	// Enabled starts a dictation, false ends the running one.
+	Enabled bool
+
+	// MaxDuration caps the dictation, zero for the default.
+	MaxDuration time.Duration
+}
+
+func (f DictationModePacket) ContextId() string {
+	return f.ContextID
+}
+
+// SpeakingProfilePacket switches the speaking profile of the conversation,
+// the speaking rate and how long and careful the assistant's answers are.
+type SpeakingProfilePacket struct {
+	// contextID identifies the turn that requested the switch.
+	ContextID string
+
+	// Profile is the name of a profile of the deployment, empty returns to
+	// the profile the call started with.
+	Profile string
+}
+
+func (f SpeakingProfilePacket) ContextId() string {
+	return f.ContextID
+}
+
+// CallHoldPacket pauses the conversation while the remote party has the call
+// on hold and resumes it when they return. Nobody is listening during hold, so
+// speech to text and text to speech are not fed.
+type CallHoldPacket struct {
+	// contextID identifies the turn that was active when hold changed.
+	ContextID string
+
+	// Held is true while the call is on hold.
+	Held bool
+}
+
+func (f CallHoldPacket) ContextId() string {
+	return f.ContextID
+}
+
+// CallPickupPacket tells the talk loop a human agent picked the call up, the
+// caller is bridged to them and the assistant is out of the call for good.
+type CallPickupPacket struct {
+	// contextID identifies the turn that was active when the call was picked up.
+	ContextID string
+
+	// Agent is the SIP URI of the agent's phone, Code the pickup code dialed.
+	Agent string
+	Code  string
+}
+
+func (f CallPickupPacket) ContextId() string {
+	return f.ContextID
+}
+
 // =============================================================================
 // End of speech Packet
 // =============================================================================
@@ -396,6 +677,10 @@ type SpeechToTextPacket struct {
 	// language
 	Language string
 
+	// speaker who said it when speech to text tells speakers apart, e.g.
+	// speaker_0
+	Speaker string
+
 	// interim
 	Interim bool
 }
@@ -5,7 +5,10 @@
 // See LICENSE.md or contact sales@rapida.ai for commercial usage.
 package internal_type
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Recorder interface {
 	// Start begins the recording timeline. All subsequent Record calls are
@@ -13,6 +16,14 @@ type Recorder interface {
 	Start()
 	// recording is done by calling Record with audio data. The implementation is
 	Record(context.Context, Packet) error
+	// Offset returns where the wall-clock time t falls on the recording
+	// timeline, zero before Start.
+	Offset(t time.Time) time.Duration
 	// Persist saves the recorded audio and returns user and system audio data.
 	Persist() ([]byte, []byte, error)
 }
+
+// RecordingConsentOption is the conversation option telling whether the
+// caller agreed to be recorded. Conversations with it set to false are never
+// recorded, assistants requiring consent record only those with it true.
+const RecordingConsentOption = "recording.consent"
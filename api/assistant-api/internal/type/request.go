@@ -15,3 +15,10 @@ type Talking interface {
 	Communication
 	Talk(ctx context.Context, auth types.SimplePrinciple) error
 }
+
+// Listening is a standalone speech to text session. It reuses the assistant's
+// listen configuration to transcribe audio received on the streamer without
+// running the rest of the conversation pipeline.
+type Listening interface {
+	Listen(ctx context.Context, auth types.SimplePrinciple) error
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package internal_type
+
+import "context"
+
+// Scratchpad is structured key/value state attached to a conversation. Tools
+// read and write it and it is serialized into the prompt, so state no longer
+// has to be threaded through prompt strings between tool calls.
+type Scratchpad interface {
+	// Get returns the value stored under key.
+	Get(key string) (interface{}, bool)
+
+	// Set stores value under key and persists the scratchpad.
+	Set(ctx context.Context, key string, value interface{}) error
+
+	// Delete removes key and persists the scratchpad.
+	Delete(ctx context.Context, key string) error
+
+	// All returns a copy of every entry.
+	All() map[string]interface{}
+
+	// String serializes the scratchpad for the prompt, empty when there is
+	// nothing stored.
+	String() string
+}
@@ -6,34 +6,54 @@
 package internal_type
 
 import (
-	"context"
+	"github.com/rapidaai/pkg/streamers"
 )
 
-// TalkInput defines the interface for incoming conversation messages from clients.
-// It represents messages that can be sent to the assistant during a conversation stream,
-// including initialization parameters, configuration updates, user messages, and metadata.
-type Stream interface {
-	// GetInitialization returns the conversation initialization message if present.
-	// Contains initial setup parameters for the conversation stream.
-	ProtoMessage()
+// Stream is a conversation message exchanged between a channel and the talk
+// loop. It is defined in pkg/streamers so channel plugins share it.
+type Stream = streamers.Stream
+
+// Streamer is the bidirectional conversation stream of a channel, see
+// streamers.Streamer.
+type Streamer = streamers.Streamer
+
+// OpusChannel is implemented by streamers receiving the caller as Opus. Once
+// passthrough is enabled, every packet is handed to sink as it arrives, before
+// and besides the PCM decoded for the talk loop.
+type OpusChannel interface {
+	EnableOpusPassthrough(sink func(UserOpusPacket))
+}
+
+// DTMFChannel is implemented by streamers playing the SEND_DTMF directive to
+// the remote party. Other streamers drop the directive, so the tool sending
+// key presses checks SendsDTMF first.
+type DTMFChannel interface {
+	SendsDTMF() bool
+}
+
+// HoldChannel is implemented by streamers putting the remote party on hold
+// for the HOLD_CONVERSATION directive. Other streamers drop the directive,
+// so the tool holding the call checks HoldsCall first.
+type HoldChannel interface {
+	HoldsCall() bool
+}
+
+// DroppingChannel is implemented by streamers counting the messages their
+// backpressure policies dropped, see streamers.Backpressure.
+type DroppingChannel interface {
+	Dropped() streamers.Drops
+}
+
+// ResumableChannel is implemented by streamers whose client can reconnect to
+// a dropped session with the resume token it was handed, see
+// internal_sessionstate.Resumes.
+type ResumableChannel interface {
+	Resumable() bool
 }
 
-// Streamer defines a bidirectional streaming interface for real-time conversation with the assistant.
-// It manages the lifecycle of a conversation stream, allowing clients to send input messages
-// and receive output responses asynchronously. The stream persists until explicitly closed
-// or an error occurs.
-type Streamer interface {
-	// Context returns the context associated with this stream.
-	// The context can be used to manage cancellation, timeouts, and deadlines.
-	Context() context.Context
-
-	// Recv receives the next output message from the stream.
-	// It blocks until a message is available, the stream is closed, or an error occurs.
-	// Returns the received message and any error encountered. If the stream is closed,
-	// it should return (nil, io.EOF).
-	Recv() (Stream, error)
-
-	// Send sends an input message to the stream.
-	// It returns an error if the send operation fails (e.g., stream closed, network error).
-	Send(Stream) error
+// HandoverChannel is implemented by streamers whose call can be taken over
+// by another instance, see internal_sessionstate.Migrations. A call handed
+// over goes on there, the session here ends without ending the conversation.
+type HandoverChannel interface {
+	HandedOver() bool
 }
@@ -6,6 +6,8 @@
 
 package internal_type
 
+import "context"
+
 // SpeechToTextTransformer is an interface for transforming input audio data.
 // It extends the Transformers interface, specifying that it transforms
 // from []byte (raw audio data) to string (processed audio representation).
@@ -19,3 +21,24 @@ type SpeechToTextTransformer interface {
 	//
 	Transformers[UserAudioPacket]
 }
+
+// SpeechToTextBiaser is implemented by speech to text transformers able to
+// change phrase biasing on a live stream. The hint replaces the previous one;
+// an empty hint restores the configured vocabulary.
+type SpeechToTextBiaser interface {
+	Bias(ctx context.Context, hint SpeechHintPacket) error
+}
+
+// OptionsKeyInputCodec is set in the options of a speech to text transformer
+// when the channel can hand it the caller's audio undecoded. "opus" asks for
+// an Opus stream; transformers that cannot take it ignore the key.
+const OptionsKeyInputCodec = "rapida.input_codec"
+
+// SpeechToTextOpusReceiver is implemented by speech to text transformers able
+// to take the caller's Opus packets in place of linear16. AcceptsOpus reports
+// whether the stream was opened for Opus, TransformOpus is then fed every
+// packet in the order received and Transform is not used.
+type SpeechToTextOpusReceiver interface {
+	AcceptsOpus() bool
+	TransformOpus(ctx context.Context, in UserOpusPacket) error
+}
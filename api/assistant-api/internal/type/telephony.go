@@ -7,6 +7,7 @@
 package internal_type
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
@@ -56,8 +57,61 @@ type CallInfo struct {
 	// Examples: vonage "conversation_uuid", sip "telephony.status".
 	// If a field is used by multiple providers, promote it to a top-level field.
 	Extra map[string]string
+
+	// Media is stored on the call context for streamers that pull the call
+	// audio from the provider themselves (ReceiveCall only).
+	Media map[string]string
 }
 
+// VoicemailMessageOption is the phone deployment option holding the message
+// an outbound call leaves when an answering machine picks up. Providers that
+// detect answering machines only do so when it is set.
+const VoicemailMessageOption = "voicemail.message"
+
+// Caller identity options of outbound calls. The phone deployment sets them
+// for all its calls, the options of an outbound call request replace them for
+// that call, e.g. to present every call of a campaign under its own name.
+const (
+	// CallerNameOption is the name presented to the recipient, the display
+	// name carriers deliver as CNAM.
+	CallerNameOption = "caller.name"
+	// CallerReasonOption is the reason for the call shown by carriers with
+	// branded calling.
+	CallerReasonOption = "caller.reason"
+)
+
+// CallerIdentityOptions are the options a call request may set over the
+// phone deployment's.
+var CallerIdentityOptions = []string{CallerNameOption, CallerReasonOption}
+
+// CallTypeOption is the category of an outbound call, one of the CallType
+// values. Carriers in India scrub promotional calls against the national DND
+// register and TRAI allows them only in the daytime; transactional calls
+// (OTPs, reminders, service updates) are neither scrubbed nor restricted.
+const CallTypeOption = "call.type"
+
+const (
+	CallTypePromotional   = "promotional"
+	CallTypeTransactional = "transactional"
+)
+
+// CallRequestOptions are the options of an outbound call request that reach
+// the provider over the phone deployment's.
+var CallRequestOptions = append([]string{CallTypeOption}, CallerIdentityOptions...)
+
+// Who picked up an outbound call, see MetadataKeyAnsweredBy.
+const (
+	AnsweredByHuman   = "human"
+	AnsweredByMachine = "machine"
+	AnsweredByFax     = "fax"
+	AnsweredByUnknown = "unknown"
+)
+
+// ErrNotACall is returned by ReceiveCall for webhooks it answered itself that
+// bring no call, e.g. the endpoint validation of Zoom. No conversation is
+// created for them.
+var ErrNotACall = errors.New("webhook carries no call")
+
 // Telephony defines the interface that all telephony providers must implement.
 // Providers return structured data — they never construct telemetry.
 // The dispatcher is responsible for converting CallInfo/StatusInfo into telemetry.
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sync"
+	"time"
 
 	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
 	internal_audio_resampler "github.com/rapidaai/api/assistant-api/internal/audio/resampler"
@@ -147,7 +148,7 @@ func (s *SileroVAD) Process(ctx context.Context, pkt internal_type.UserAudioPack
 
 	// Notify callback if speech detected
 	if len(segments) > 0 {
-		s.notifyActivity(ctx, segments)
+		s.notifyActivity(ctx, segments, pkt.CapturedAt)
 	}
 
 	return nil
@@ -295,7 +296,7 @@ func (s *SileroVAD) detectSafely(samples []float32) ([]speech.Segment, error) {
 }
 
 // notifyActivity calculates speech boundaries and invokes the callback.
-func (s *SileroVAD) notifyActivity(ctx context.Context, segments []speech.Segment) {
+func (s *SileroVAD) notifyActivity(ctx context.Context, segments []speech.Segment, capturedAt time.Time) {
 	minStart := math.MaxFloat64
 	maxEnd := -math.MaxFloat64
 
@@ -312,8 +313,9 @@ func (s *SileroVAD) notifyActivity(ctx context.Context, segments []speech.Segmen
 	}
 
 	s.onPacket(ctx, internal_type.InterruptionPacket{
-		Source:  internal_type.InterruptionSourceVad,
-		StartAt: minStart,
-		EndAt:   maxEnd,
+		Source:     internal_type.InterruptionSourceVad,
+		StartAt:    minStart,
+		EndAt:      maxEnd,
+		CapturedAt: capturedAt,
 	})
 }
@@ -0,0 +1,390 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+// Package internal_warmpool keeps pipelines of busy assistants built ahead of
+// their inbound calls. A standby holds the assistant definition and speech to
+// text and text to speech connections that are already open, so a call that
+// claims one skips the database, vault and provider round trips before its
+// greeting.
+//
+// Inbound calls authenticate with the API key of their project, the pool has
+// no principal of its own. It starts filling for an assistant when its first
+// call arrives and builds the standbys with the principal of the latest call.
+package internal_warmpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+)
+
+// DefaultMaxIdle is how long a standby waits for a call before it is
+// rebuilt. Providers close streams that carry no audio after 10 to 20
+// seconds, and the assistant definition should not be older than that either.
+const DefaultMaxIdle = 15 * time.Second
+
+// statsInterval is how often the pool logs its statistics.
+const statsInterval = time.Minute
+
+// Build prepares standby for a call of the assistant on behalf of auth. The
+// transformers it opens send their packets to standby.Deliver. A failed build
+// is closed by the pool, including what it opened before failing.
+type Build func(ctx context.Context, auth types.SimplePrinciple, assistantId uint64, standby *Standby) error
+
+// Standby is a pipeline of an assistant waiting for a call.
+type Standby struct {
+	Assistant    *internal_assistant_entity.Assistant
+	SpeechToText internal_type.SpeechToTextTransformer
+	TextToSpeech internal_type.TextToSpeechTransformer
+
+	projectId uint64
+	builtAt   time.Time
+	cancel    context.CancelFunc
+
+	mu   sync.Mutex
+	sink func(...internal_type.Packet) error
+}
+
+// Deliver passes packets of the transformers to the session that claimed the
+// standby. Until then there is no input, packets are dropped.
+func (s *Standby) Deliver(pkt ...internal_type.Packet) error {
+	s.mu.Lock()
+	sink := s.sink
+	s.mu.Unlock()
+	if sink == nil {
+		return nil
+	}
+	return sink(pkt...)
+}
+
+// Bind routes the packets of the transformers to the claiming session.
+func (s *Standby) Bind(sink func(...internal_type.Packet) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sink = sink
+}
+
+// TakeSpeechToText hands the open speech to text connection over, it is
+// returned once.
+func (s *Standby) TakeSpeechToText() internal_type.SpeechToTextTransformer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	transformer := s.SpeechToText
+	s.SpeechToText = nil
+	return transformer
+}
+
+// TakeTextToSpeech hands the open text to speech connection over, it is
+// returned once.
+func (s *Standby) TakeTextToSpeech() internal_type.TextToSpeechTransformer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	transformer := s.TextToSpeech
+	s.TextToSpeech = nil
+	return transformer
+}
+
+// Close closes the connections nobody took and ends the context the
+// transformers were opened with. It is safe to call more than once.
+func (s *Standby) Close(ctx context.Context) {
+	if transformer := s.TakeSpeechToText(); transformer != nil {
+		transformer.Close(ctx)
+	}
+	if transformer := s.TakeTextToSpeech(); transformer != nil {
+		transformer.Close(ctx)
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Stats are the counters of the standbys of one assistant.
+type Stats struct {
+	Size     int    `json:"size"`
+	Ready    int    `json:"ready"`
+	Building int    `json:"building"`
+	Claimed  uint64 `json:"claimed"` // calls that got a standby
+	Missed   uint64 `json:"missed"`  // calls that found none ready
+	Expired  uint64 `json:"expired"` // standbys rebuilt after waiting too long
+	Failed   uint64 `json:"failed"`  // builds that failed
+}
+
+type entry struct {
+	size  int
+	auth  types.SimplePrinciple
+	ready []*Standby // oldest first
+	stats Stats
+}
+
+// Pool keeps a configured number of standbys per assistant.
+type Pool struct {
+	logger  commons.Logger
+	build   Build
+	maxIdle time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	ctx     context.Context
+	stop    context.CancelFunc
+	entries map[uint64]*entry
+	builds  sync.WaitGroup
+	done    chan struct{}
+}
+
+// NewPool returns a pool keeping sizes[assistantId] standbys of each
+// assistant. Nothing is built before Start.
+func NewPool(logger commons.Logger, sizes map[uint64]int, maxIdle time.Duration, build Build) *Pool {
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdle
+	}
+	entries := make(map[uint64]*entry, len(sizes))
+	for assistantId, size := range sizes {
+		if size > 0 {
+			entries[assistantId] = &entry{size: size, stats: Stats{Size: size}}
+		}
+	}
+	return &Pool{
+		logger:  logger,
+		build:   build,
+		maxIdle: maxIdle,
+		now:     time.Now,
+		entries: entries,
+	}
+}
+
+// Start lets the pool build standbys until Stop or until ctx is done.
+func (p *Pool) Start(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx != nil {
+		return
+	}
+	p.ctx, p.stop = context.WithCancel(ctx)
+	p.done = make(chan struct{})
+	go p.run(p.ctx, p.done)
+}
+
+// Stop ends the pool, waits for builds in progress and closes every standby
+// that was not claimed.
+func (p *Pool) Stop(ctx context.Context) {
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	p.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	stop()
+	<-done
+	p.builds.Wait()
+
+	p.mu.Lock()
+	var idle []*Standby
+	for _, e := range p.entries {
+		idle = append(idle, e.ready...)
+		e.ready = nil
+	}
+	p.mu.Unlock()
+	for _, standby := range idle {
+		standby.Close(ctx)
+	}
+}
+
+func (p *Pool) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	refresh := time.NewTicker(p.maxIdle / 2)
+	defer refresh.Stop()
+	stats := time.NewTicker(statsInterval)
+	defer stats.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refresh.C:
+			p.Refresh()
+		case <-stats.C:
+			for assistantId, s := range p.Stats() {
+				p.logger.Infow("Warm pool", "assistant_id", assistantId,
+					"size", s.Size, "ready", s.Ready, "building", s.Building,
+					"claimed", s.Claimed, "missed", s.Missed, "expired", s.Expired, "failed", s.Failed)
+			}
+		}
+	}
+}
+
+// Pools reports whether standbys of the assistant are kept.
+func (p *Pool) Pools(assistantId uint64) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.entries[assistantId]
+	return ok
+}
+
+// Claim hands out a ready standby of the assistant built for the project of
+// auth, or nil when there is none. The standby's connections are closed with
+// ctx at the latest, the caller closes the ones it does not take earlier.
+// Every claim tops the assistant's standbys up again.
+func (p *Pool) Claim(ctx context.Context, auth types.SimplePrinciple, assistantId uint64) *Standby {
+	if p == nil {
+		return nil
+	}
+	projectId := projectOf(auth)
+
+	p.mu.Lock()
+	e, ok := p.entries[assistantId]
+	if !ok || p.ctx == nil || p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return nil
+	}
+	expired := p.expireLocked(e)
+	var claimed *Standby
+	for i, standby := range e.ready {
+		if standby.projectId == projectId {
+			claimed = standby
+			e.ready = append(e.ready[:i], e.ready[i+1:]...)
+			break
+		}
+	}
+	if claimed != nil {
+		e.stats.Claimed++
+	} else {
+		e.stats.Missed++
+	}
+	if projectId != 0 {
+		e.auth = auth
+	}
+	p.refillLocked(assistantId, e)
+	p.mu.Unlock()
+
+	for _, standby := range expired {
+		standby.Close(context.Background())
+	}
+	if claimed != nil {
+		context.AfterFunc(ctx, claimed.cancel)
+	}
+	return claimed
+}
+
+// Refresh rebuilds the standbys that waited too long and replaces the ones
+// that failed to build.
+func (p *Pool) Refresh() {
+	p.mu.Lock()
+	if p.ctx == nil || p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return
+	}
+	var expired []*Standby
+	for assistantId, e := range p.entries {
+		expired = append(expired, p.expireLocked(e)...)
+		p.refillLocked(assistantId, e)
+	}
+	p.mu.Unlock()
+
+	for _, standby := range expired {
+		standby.Close(context.Background())
+	}
+}
+
+// Stats returns the counters of every pooled assistant.
+func (p *Pool) Stats() map[uint64]Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make(map[uint64]Stats, len(p.entries))
+	for assistantId, e := range p.entries {
+		s := e.stats
+		s.Ready = len(e.ready)
+		stats[assistantId] = s
+	}
+	return stats
+}
+
+// expireLocked removes the standbys older than maxIdle and returns them to be
+// closed outside the lock.
+func (p *Pool) expireLocked(e *entry) []*Standby {
+	var expired []*Standby
+	now := p.now()
+	kept := e.ready[:0]
+	for _, standby := range e.ready {
+		if now.Sub(standby.builtAt) > p.maxIdle {
+			expired = append(expired, standby)
+			continue
+		}
+		kept = append(kept, standby)
+	}
+	e.ready = kept
+	e.stats.Expired += uint64(len(expired))
+	return expired
+}
+
+// refillLocked starts the builds missing to the assistant's size. Nothing is
+// built before a call brought a principal.
+func (p *Pool) refillLocked(assistantId uint64, e *entry) {
+	if e.auth == nil {
+		return
+	}
+	for missing := e.size - len(e.ready) - e.stats.Building; missing > 0; missing-- {
+		e.stats.Building++
+		p.builds.Add(1)
+		go p.fill(p.ctx, assistantId, e.auth)
+	}
+}
+
+func (p *Pool) fill(ctx context.Context, assistantId uint64, auth types.SimplePrinciple) {
+	defer p.builds.Done()
+	start := time.Now()
+	// not derived from the pool's context, stopping the pool must not cut
+	// the calls that claimed a standby
+	buildCtx, cancel := context.WithCancel(context.Background())
+	standby := &Standby{projectId: projectOf(auth), cancel: cancel}
+	err := p.build(buildCtx, auth, assistantId, standby)
+	standby.builtAt = p.now()
+
+	p.mu.Lock()
+	e := p.entries[assistantId]
+	e.stats.Building--
+	switch {
+	case err != nil:
+		e.stats.Failed++
+	case ctx.Err() == nil:
+		e.ready = append(e.ready, standby)
+		p.mu.Unlock()
+		p.logger.Benchmark("warmpool.build", time.Since(start))
+		return
+	}
+	p.mu.Unlock()
+
+	if err != nil {
+		p.logger.Warnw("Unable to build warm standby", "assistant_id", assistantId, "error", err)
+	}
+	standby.Close(context.Background())
+}
+
+func projectOf(auth types.SimplePrinciple) uint64 {
+	if auth == nil || auth.GetCurrentProjectId() == nil {
+		return 0
+	}
+	return *auth.GetCurrentProjectId()
+}
+
+var active atomic.Pointer[Pool]
+
+// Install makes p the pool sessions claim standbys from, nil removes it.
+func Install(p *Pool) {
+	active.Store(p)
+}
+
+// Active returns the installed pool, nil when there is none.
+func Active() *Pool {
+	return active.Load()
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package internal_warmpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type speechToText struct{ closed atomic.Bool }
+
+func (s *speechToText) Name() string      { return "test" }
+func (s *speechToText) Initialize() error { return nil }
+func (s *speechToText) Transform(context.Context, internal_type.UserAudioPacket) error {
+	return nil
+}
+func (s *speechToText) Close(context.Context) error {
+	s.closed.Store(true)
+	return nil
+}
+
+func project(id uint64) types.SimplePrinciple {
+	return &types.ServiceScope{ProjectId: utils.Ptr(id)}
+}
+
+// testPool returns a started pool keeping two standbys of assistant 1 and
+// the transformers its builds opened.
+func testPool(t *testing.T, build Build) (*Pool, *[]*speechToText) {
+	t.Helper()
+	var mu sync.Mutex
+	var opened []*speechToText
+	if build == nil {
+		build = func(ctx context.Context, auth types.SimplePrinciple, assistantId uint64, standby *Standby) error {
+			transformer := &speechToText{}
+			mu.Lock()
+			opened = append(opened, transformer)
+			mu.Unlock()
+			standby.Assistant = &internal_assistant_entity.Assistant{}
+			standby.SpeechToText = transformer
+			return nil
+		}
+	}
+	logger, _ := commons.NewApplicationLogger()
+	pool := NewPool(logger, map[uint64]int{1: 2, 2: 0}, time.Minute, build)
+	pool.Start(context.Background())
+	t.Cleanup(func() { pool.Stop(context.Background()) })
+	return pool, &opened
+}
+
+func waitReady(t *testing.T, pool *Pool, assistantId uint64, ready int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		s := pool.Stats()[assistantId]
+		return s.Ready == ready && s.Building == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestPool_ClaimAfterFirstCall(t *testing.T) {
+	pool, _ := testPool(t, nil)
+	assert.True(t, pool.Pools(1))
+	assert.False(t, pool.Pools(2), "assistants sized 0 are not pooled")
+
+	// nothing is built before a call brought a principal
+	assert.Nil(t, pool.Claim(context.Background(), project(7), 1))
+	waitReady(t, pool, 1, 2)
+
+	standby := pool.Claim(context.Background(), project(7), 1)
+	require.NotNil(t, standby)
+	assert.NotNil(t, standby.TakeSpeechToText())
+	assert.Nil(t, standby.TakeSpeechToText(), "a connection is handed over once")
+	waitReady(t, pool, 1, 2)
+
+	assert.Nil(t, pool.Claim(context.Background(), project(8), 1), "standbys are not shared between projects")
+	assert.Nil(t, pool.Claim(context.Background(), project(7), 3))
+
+	stats := pool.Stats()[1]
+	assert.Equal(t, uint64(1), stats.Claimed)
+	assert.Equal(t, uint64(2), stats.Missed)
+}
+
+func TestPool_ExpiredStandbysAreRebuilt(t *testing.T) {
+	pool, opened := testPool(t, nil)
+	pool.Claim(context.Background(), project(7), 1)
+	waitReady(t, pool, 1, 2)
+
+	pool.mu.Lock()
+	pool.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	pool.mu.Unlock()
+	pool.Refresh()
+	waitReady(t, pool, 1, 2)
+
+	assert.Equal(t, uint64(2), pool.Stats()[1].Expired)
+	require.Len(t, *opened, 4)
+	assert.True(t, (*opened)[0].closed.Load())
+	assert.True(t, (*opened)[1].closed.Load())
+}
+
+func TestPool_FailedBuildIsClosed(t *testing.T) {
+	transformer := &speechToText{}
+	pool, _ := testPool(t, func(ctx context.Context, auth types.SimplePrinciple, assistantId uint64, standby *Standby) error {
+		standby.SpeechToText = transformer
+		return errors.New("provider unavailable")
+	})
+	pool.Claim(context.Background(), project(7), 1)
+	require.Eventually(t, func() bool { return pool.Stats()[1].Failed == 2 }, time.Second, 5*time.Millisecond)
+	assert.Zero(t, pool.Stats()[1].Ready)
+	assert.True(t, transformer.closed.Load())
+}
+
+func TestPool_StopClosesIdleStandbys(t *testing.T) {
+	pool, opened := testPool(t, nil)
+	pool.Claim(context.Background(), project(7), 1)
+	waitReady(t, pool, 1, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	standby := pool.Claim(ctx, project(7), 1)
+	require.NotNil(t, standby)
+	waitReady(t, pool, 1, 2)
+
+	pool.Stop(context.Background())
+	closed := 0
+	for _, transformer := range *opened {
+		if transformer.closed.Load() {
+			closed++
+		}
+	}
+	assert.Equal(t, 2, closed, "the claimed standby belongs to its call")
+	assert.Nil(t, pool.Claim(context.Background(), project(7), 1))
+
+	cancel()
+}
+
+func TestStandby_Deliver(t *testing.T) {
+	standby := &Standby{}
+	assert.NoError(t, standby.Deliver(internal_type.InterruptionPacket{}), "packets before a claim are dropped")
+
+	var received []internal_type.Packet
+	standby.Bind(func(pkt ...internal_type.Packet) error {
+		received = append(received, pkt...)
+		return nil
+	})
+	require.NoError(t, standby.Deliver(internal_type.InterruptionPacket{}))
+	assert.Len(t, received, 1)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_livetranscript
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_livetranscript "github.com/rapidaai/api/assistant-api/internal/livetranscript"
+	"github.com/rapidaai/pkg/connectors"
+)
+
+// Install keeps the transcript of every live conversation in redis, as
+// configured, for the subscribers of the server-sent events endpoint.
+func Install(cfg *config.LiveTranscriptConfig, redis connectors.RedisConnector) {
+	internal_livetranscript.Install(internal_livetranscript.NewFeed(redis.GetConnection(), cfg.Retained(), cfg.TTL()))
+}
@@ -0,0 +1,2 @@
+ALTER TABLE public.call_contexts
+    ADD COLUMN scratchpad jsonb NOT NULL DEFAULT '{}';
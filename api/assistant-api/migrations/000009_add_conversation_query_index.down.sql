@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.idx_assistant_conversations_project_created_date;
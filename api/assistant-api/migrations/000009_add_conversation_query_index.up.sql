@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_assistant_conversations_project_created_date ON public.assistant_conversations USING btree (project_id, created_date DESC);
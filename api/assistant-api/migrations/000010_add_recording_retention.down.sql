@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS public.assistant_recording_retention_policies;
+
+DROP INDEX IF EXISTS public.idx_assistant_conversation_recordings_project_created_date;
+
+ALTER TABLE public.assistant_conversation_recordings
+    DROP COLUMN IF EXISTS archived_date,
+    DROP COLUMN IF EXISTS storage_tier;
@@ -0,0 +1,20 @@
+ALTER TABLE public.assistant_conversation_recordings
+    ADD COLUMN storage_tier character varying(20) DEFAULT 'standard' NOT NULL,
+    ADD COLUMN archived_date timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS idx_assistant_conversation_recordings_project_created_date ON public.assistant_conversation_recordings USING btree (project_id, created_date);
+
+CREATE TABLE public.assistant_recording_retention_policies (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    status character varying(50) DEFAULT 'ACTIVE'::character varying NOT NULL,
+    created_by bigint,
+    updated_by bigint,
+    project_id bigint NOT NULL,
+    organization_id bigint NOT NULL,
+    archive_after_days integer DEFAULT 0 NOT NULL,
+    delete_after_days integer DEFAULT 0 NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_assistant_recording_retention_policies_project_id ON public.assistant_recording_retention_policies USING btree (project_id);
@@ -0,0 +1,15 @@
+CREATE TABLE public.assistant_transcript_settings (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    status character varying(50) DEFAULT 'ACTIVE'::character varying NOT NULL,
+    created_by bigint,
+    updated_by bigint,
+    project_id bigint NOT NULL,
+    organization_id bigint NOT NULL,
+    assistant_id bigint NOT NULL,
+    ai_notice text DEFAULT '' NOT NULL,
+    legal_footer text DEFAULT '' NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_assistant_transcript_settings_assistant_id ON public.assistant_transcript_settings USING btree (assistant_id);
@@ -0,0 +1,2 @@
+ALTER TABLE public.call_contexts
+    DROP COLUMN media;
@@ -0,0 +1,2 @@
+ALTER TABLE public.call_contexts
+    ADD COLUMN media jsonb NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+ALTER TABLE public.assistant_conversation_recordings
+    DROP COLUMN IF EXISTS encrypted;
+
+DROP TABLE IF EXISTS public.assistant_conversation_keys;
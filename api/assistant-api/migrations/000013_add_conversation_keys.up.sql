@@ -0,0 +1,21 @@
+CREATE TABLE public.assistant_conversation_keys (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    status character varying(50) DEFAULT 'ACTIVE'::character varying NOT NULL,
+    created_by bigint,
+    updated_by bigint,
+    project_id bigint NOT NULL,
+    organization_id bigint NOT NULL,
+    assistant_conversation_id bigint NOT NULL,
+    algorithm character varying(50) NOT NULL,
+    master_key_id character varying(100) NOT NULL,
+    wrapped_key bytea NOT NULL,
+    rewrapped_date timestamp with time zone
+);
+
+CREATE UNIQUE INDEX idx_assistant_conversation_keys_conversation_id ON public.assistant_conversation_keys USING btree (assistant_conversation_id);
+CREATE INDEX idx_assistant_conversation_keys_master_key_id ON public.assistant_conversation_keys USING btree (master_key_id);
+
+ALTER TABLE public.assistant_conversation_recordings
+    ADD COLUMN encrypted boolean DEFAULT false NOT NULL;
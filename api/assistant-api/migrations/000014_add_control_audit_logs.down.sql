@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.control_audit_logs;
+DROP FUNCTION IF EXISTS public.control_audit_logs_append_only();
@@ -0,0 +1,36 @@
+CREATE TABLE public.control_audit_logs (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    project_id bigint NOT NULL,
+    organization_id bigint NOT NULL,
+    actor_id bigint DEFAULT 0 NOT NULL,
+    actor_type character varying(50) NOT NULL,
+    action character varying(50) NOT NULL,
+    resource_type character varying(100) NOT NULL,
+    resource_id bigint DEFAULT 0 NOT NULL,
+    reason text DEFAULT '' NOT NULL,
+    before jsonb,
+    after jsonb,
+    changes jsonb
+);
+
+CREATE INDEX idx_control_audit_logs_project_created ON public.control_audit_logs USING btree (organization_id, project_id, created_date DESC);
+CREATE INDEX idx_control_audit_logs_resource ON public.control_audit_logs USING btree (resource_type, resource_id);
+CREATE INDEX idx_control_audit_logs_actor ON public.control_audit_logs USING btree (actor_id);
+
+-- the audit log is append-only, even for the application's own role
+CREATE FUNCTION public.control_audit_logs_append_only() RETURNS trigger
+    LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'control_audit_logs is append-only';
+END;
+$$;
+
+CREATE TRIGGER control_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON public.control_audit_logs
+    FOR EACH ROW EXECUTE FUNCTION public.control_audit_logs_append_only();
+
+CREATE TRIGGER control_audit_logs_no_truncate
+    BEFORE TRUNCATE ON public.control_audit_logs
+    FOR EACH STATEMENT EXECUTE FUNCTION public.control_audit_logs_append_only();
@@ -0,0 +1,2 @@
+ALTER TABLE public.call_contexts
+    ADD COLUMN stream_mode varchar(20) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE public.call_contexts
+    ADD COLUMN outcome varchar(30) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.assistant_campaign_contacts;
+DROP TABLE IF EXISTS public.assistant_campaigns;
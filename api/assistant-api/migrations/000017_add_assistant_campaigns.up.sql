@@ -0,0 +1,44 @@
+CREATE TABLE public.assistant_campaigns (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    project_id bigint NOT NULL,
+    organization_id bigint NOT NULL,
+    created_by bigint DEFAULT 0 NOT NULL,
+    assistant_id bigint NOT NULL,
+    assistant_version character varying(50) DEFAULT '' NOT NULL,
+    name character varying(200) NOT NULL,
+    status character varying(20) DEFAULT 'draft' NOT NULL,
+    from_number character varying(50) DEFAULT '' NOT NULL,
+    timezone character varying(64) DEFAULT 'UTC' NOT NULL,
+    calling_hours_start character varying(5) DEFAULT '' NOT NULL,
+    calling_hours_end character varying(5) DEFAULT '' NOT NULL,
+    max_concurrent_calls integer DEFAULT 1 NOT NULL,
+    max_attempts integer DEFAULT 1 NOT NULL,
+    retry_delay_seconds integer DEFAULT 900 NOT NULL,
+    args jsonb DEFAULT '{}' NOT NULL,
+    options jsonb DEFAULT '{}' NOT NULL,
+    metadata jsonb DEFAULT '{}' NOT NULL
+);
+
+CREATE INDEX idx_assistant_campaigns_project_created ON public.assistant_campaigns USING btree (organization_id, project_id, created_date DESC);
+-- the scheduler only ever reads the running and paused campaigns
+CREATE INDEX idx_assistant_campaigns_active ON public.assistant_campaigns USING btree (status) WHERE status IN ('running', 'paused');
+
+CREATE TABLE public.assistant_campaign_contacts (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    assistant_campaign_id bigint NOT NULL REFERENCES public.assistant_campaigns (id) ON DELETE CASCADE,
+    to_number character varying(50) NOT NULL,
+    args jsonb DEFAULT '{}' NOT NULL,
+    status character varying(20) DEFAULT 'pending' NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    assistant_conversation_id bigint DEFAULT 0 NOT NULL,
+    outcome character varying(30) DEFAULT '' NOT NULL,
+    last_error text DEFAULT '' NOT NULL,
+    dialed_date timestamp with time zone,
+    next_attempt_date timestamp with time zone
+);
+
+CREATE INDEX idx_assistant_campaign_contacts_campaign_status ON public.assistant_campaign_contacts USING btree (assistant_campaign_id, status, next_attempt_date);
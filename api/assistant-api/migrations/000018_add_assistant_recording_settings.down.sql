@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.assistant_recording_settings;
+
+ALTER TABLE public.assistant_conversation_recordings
+    DROP COLUMN IF EXISTS channels;
@@ -0,0 +1,20 @@
+ALTER TABLE public.assistant_conversation_recordings
+    ADD COLUMN channels integer DEFAULT 1 NOT NULL;
+
+CREATE TABLE public.assistant_recording_settings (
+    id bigint PRIMARY KEY,
+    created_date timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_date timestamp with time zone,
+    status character varying(50) DEFAULT 'ACTIVE'::character varying NOT NULL,
+    created_by bigint,
+    updated_by bigint,
+    project_id bigint NOT NULL,
+    organization_id bigint NOT NULL,
+    assistant_id bigint NOT NULL,
+    enabled boolean DEFAULT true NOT NULL,
+    dual_channel boolean DEFAULT false NOT NULL,
+    require_consent boolean DEFAULT false NOT NULL,
+    redact_spelling boolean DEFAULT false NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_assistant_recording_settings_assistant_id ON public.assistant_recording_settings USING btree (assistant_id);
@@ -0,0 +1,2 @@
+ALTER TABLE public.assistant_webhooks
+    DROP COLUMN IF EXISTS signing_secret;
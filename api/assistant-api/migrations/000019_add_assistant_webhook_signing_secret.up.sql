@@ -0,0 +1,2 @@
+ALTER TABLE public.assistant_webhooks
+    ADD COLUMN signing_secret text;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.assistant_conversation_events;
+DROP FUNCTION IF EXISTS public.assistant_conversation_events_append_only();
@@ -0,0 +1,27 @@
+CREATE TABLE public.assistant_conversation_events (
+    id bigint PRIMARY KEY,
+    assistant_id bigint NOT NULL,
+    assistant_conversation_id bigint NOT NULL,
+    sequence bigint NOT NULL,
+    event_type character varying(50) NOT NULL,
+    context_id character varying(200) DEFAULT '' NOT NULL,
+    payload jsonb,
+    created_date timestamp without time zone DEFAULT now() NOT NULL,
+    updated_date timestamp without time zone
+);
+
+-- the sequence orders the log of a conversation, a batch written twice is
+-- only stored once
+CREATE UNIQUE INDEX idx_assistant_conversation_events_sequence ON public.assistant_conversation_events USING btree (assistant_conversation_id, sequence);
+
+-- entries are never changed, they go when their conversation does
+CREATE FUNCTION public.assistant_conversation_events_append_only() RETURNS trigger
+    LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'assistant_conversation_events is append-only';
+END;
+$$;
+
+CREATE TRIGGER assistant_conversation_events_append_only
+    BEFORE UPDATE ON public.assistant_conversation_events
+    FOR EACH ROW EXECUTE FUNCTION public.assistant_conversation_events_append_only();
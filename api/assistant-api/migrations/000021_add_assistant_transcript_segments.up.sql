@@ -0,0 +1,15 @@
+CREATE TABLE public.assistant_transcript_segments (
+    id bigint PRIMARY KEY,
+    assistant_id bigint NOT NULL,
+    assistant_conversation_id bigint NOT NULL,
+    context_id character varying(200) DEFAULT '' NOT NULL,
+    provider character varying(100) NOT NULL,
+    language character varying(50) DEFAULT '' NOT NULL,
+    confidence double precision NOT NULL,
+    words integer NOT NULL,
+    created_date timestamp without time zone DEFAULT now() NOT NULL,
+    updated_date timestamp without time zone
+);
+
+CREATE INDEX idx_assistant_transcript_segments_conversation ON public.assistant_transcript_segments USING btree (assistant_conversation_id);
+CREATE INDEX idx_assistant_transcript_segments_assistant ON public.assistant_transcript_segments USING btree (assistant_id, created_date);
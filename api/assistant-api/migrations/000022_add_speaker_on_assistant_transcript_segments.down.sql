@@ -0,0 +1 @@
+ALTER TABLE public.assistant_transcript_segments DROP COLUMN speaker;
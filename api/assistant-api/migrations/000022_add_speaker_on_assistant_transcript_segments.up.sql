@@ -0,0 +1 @@
+ALTER TABLE public.assistant_transcript_segments ADD COLUMN speaker character varying(50) DEFAULT '' NOT NULL;
@@ -0,0 +1,18 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_pacing
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	channel_webrtc "github.com/rapidaai/api/assistant-api/internal/channel/webrtc"
+)
+
+// Install sets the configured output pacing of new WebRTC, LiveKit and
+// WhatsApp calls.
+func Install(cfg *config.OutputPacingConfig) {
+	channel_webrtc.InstallPacing(channel_webrtc.NewPacing(cfg.MaxQueue(), cfg.InterruptWatermark(), cfg.CatchUpFactor))
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_relay
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	channel_webrtc "github.com/rapidaai/api/assistant-api/internal/channel/webrtc"
+	"github.com/rapidaai/pkg/commons"
+)
+
+// relayEngine sends web callers to the nearest TURN region answering its
+// health checks. Every replica probes the regions from where it runs.
+type relayEngine struct {
+	logger commons.Logger
+	cfg    *config.AssistantConfig
+
+	mu     sync.Mutex
+	relays *channel_webrtc.Relays
+}
+
+func NewRelayEngine(config *config.AssistantConfig, logger commons.Logger) *relayEngine {
+	return &relayEngine{logger: logger, cfg: config}
+}
+
+// Connect starts the health checks and installs the relays new WebRTC
+// sessions select their region from.
+func (e *relayEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.relays != nil {
+		return nil
+	}
+	configured, err := e.cfg.WebRTC.Regions()
+	if err != nil {
+		return err
+	}
+	regions := make([]channel_webrtc.TURNRegion, len(configured))
+	for i, region := range configured {
+		regions[i] = channel_webrtc.TURNRegion{
+			Name:      region.Name,
+			URLs:      region.URLs,
+			Latitude:  region.Latitude,
+			Longitude: region.Longitude,
+			Located:   region.Located,
+		}
+	}
+	e.relays = channel_webrtc.NewRelays(e.logger, regions, channel_webrtc.TURNCredentials{
+		Secret:     e.cfg.WebRTC.TURNSecret,
+		Username:   e.cfg.WebRTC.TURNUsername,
+		Credential: e.cfg.WebRTC.TURNCredential,
+	})
+	e.relays.Start(ctx, e.cfg.WebRTC.HealthCheckInterval())
+	channel_webrtc.InstallRelays(e.relays)
+	e.logger.Infow("TURN relays started", "regions", len(regions))
+	return nil
+}
+
+// Disconnect stops the health checks, new sessions use the default STUN
+// servers again.
+func (e *relayEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	relays := e.relays
+	e.relays = nil
+	e.mu.Unlock()
+	if relays == nil {
+		return nil
+	}
+	channel_webrtc.InstallRelays(nil)
+	relays.Stop()
+	return nil
+}
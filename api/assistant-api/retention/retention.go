@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_retention
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+)
+
+const (
+	defaultRetentionInterval = time.Hour
+
+	// retentionLockKey makes one replica apply the policies per interval.
+	retentionLockKey = "assistant:recording-retention:lock"
+)
+
+// recordingRetentionEngine periodically moves call recordings to archive
+// storage and deletes them by the retention policy of their project.
+type recordingRetentionEngine struct {
+	logger   commons.Logger
+	cfg      *config.AssistantConfig
+	redis    connectors.RedisConnector
+	interval time.Duration
+
+	recordingService internal_services.AssistantRecordingService
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewRecordingRetentionEngine(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+	redis connectors.RedisConnector,
+) *recordingRetentionEngine {
+	interval := defaultRetentionInterval
+	if config.RecordingRetention != nil && config.RecordingRetention.IntervalMinutes > 0 {
+		interval = time.Duration(config.RecordingRetention.IntervalMinutes) * time.Minute
+	}
+	return &recordingRetentionEngine{
+		logger:           logger,
+		cfg:              config,
+		redis:            redis,
+		interval:         interval,
+		recordingService: internal_assistant_service.NewAssistantRecordingService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+	}
+}
+
+// Connect starts applying the retention policies, right away and then once
+// per interval.
+func (e *recordingRetentionEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stop != nil {
+		return nil
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+
+	go e.run(ctx, e.stop, e.done)
+	e.logger.Infow("Recording retention started", "interval", e.interval.String())
+	return nil
+}
+
+// Disconnect stops the job and waits for a run in progress to finish.
+func (e *recordingRetentionEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.mu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	close(stop)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (e *recordingRetentionEngine) run(ctx context.Context, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.apply(ctx)
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// apply runs the policies unless another replica already did in this
+// interval. The lock expires on its own, half an interval leaves room for
+// clock skew between the replicas.
+func (e *recordingRetentionEngine) apply(ctx context.Context) {
+	acquired, err := e.redis.GetConnection().SetNX(ctx, retentionLockKey, time.Now().Unix(), e.interval/2).Result()
+	if err != nil {
+		e.logger.Warnf("unable to take the recording retention lock %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+	archived, deleted, err := e.recordingService.ApplyRetentionPolicies(ctx, time.Now())
+	if err != nil {
+		e.logger.Errorf("recording retention failed after archiving %d and deleting %d recordings: %v", archived, deleted, err)
+		return
+	}
+	if archived > 0 || deleted > 0 {
+		e.logger.Infow("Recording retention applied", "archived", archived, "deleted", deleted)
+	}
+}
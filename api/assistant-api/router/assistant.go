@@ -10,11 +10,22 @@ import (
 	"github.com/gin-gonic/gin"
 	assistantApi "github.com/rapidaai/api/assistant-api/api/assistant"
 	assistantDeploymentApi "github.com/rapidaai/api/assistant-api/api/assistant-deployment"
+	assistantAuditApi "github.com/rapidaai/api/assistant-api/api/audit"
+	assistantCampaignApi "github.com/rapidaai/api/assistant-api/api/campaign"
+	assistantConversationApi "github.com/rapidaai/api/assistant-api/api/conversation"
+	assistantConversationControlApi "github.com/rapidaai/api/assistant-api/api/conversation-control"
+	assistantConversationDebugApi "github.com/rapidaai/api/assistant-api/api/conversation-debug"
+	assistantConversationEventApi "github.com/rapidaai/api/assistant-api/api/conversation-event"
+	assistantRecordingApi "github.com/rapidaai/api/assistant-api/api/recording"
 	assistantTalkApi "github.com/rapidaai/api/assistant-api/api/talk"
+	assistantTranscriptApi "github.com/rapidaai/api/assistant-api/api/transcript"
+	assistantWebTalkSessionApi "github.com/rapidaai/api/assistant-api/api/webtalk-session"
 	"github.com/rapidaai/api/assistant-api/config"
+	internal_sessiontoken "github.com/rapidaai/api/assistant-api/internal/sessiontoken"
 	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/middlewares"
 	workflow_api "github.com/rapidaai/protos"
 	"google.golang.org/grpc"
 )
@@ -35,6 +46,57 @@ func AssistantApiRoute(
 			Opensearch,
 			Opensearch,
 		))
+	workflow_api.RegisterConversationServiceServer(S,
+		assistantConversationApi.NewConversationGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterRecognitionQualityServiceServer(S,
+		assistantConversationApi.NewRecognitionQualityGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterConversationDebugServiceServer(S,
+		assistantConversationDebugApi.NewConversationDebugGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterConversationEventServiceServer(S,
+		assistantConversationEventApi.NewConversationEventGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterRecordingServiceServer(S,
+		assistantRecordingApi.NewRecordingGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterTranscriptServiceServer(S,
+		assistantTranscriptApi.NewTranscriptGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterControlAuditServiceServer(S,
+		assistantAuditApi.NewControlAuditGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterConversationControlServiceServer(S,
+		assistantConversationControlApi.NewConversationControlGRPCApi(Cfg,
+			Logger,
+		))
+	workflow_api.RegisterCampaignServiceServer(S,
+		assistantCampaignApi.NewCampaignGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
+	workflow_api.RegisterWebTalkSessionServiceServer(S,
+		assistantWebTalkSessionApi.NewWebTalkSessionGRPCApi(Cfg,
+			Logger,
+			Postgres,
+			Redis,
+			Opensearch,
+		))
 }
 
 func AssistantDeploymentApiRoute(Cfg *config.AssistantConfig,
@@ -46,6 +108,11 @@ func AssistantDeploymentApiRoute(Cfg *config.AssistantConfig,
 			Logger,
 			Postgres,
 		))
+	workflow_api.RegisterAssistantDeploymentDryRunServiceServer(S,
+		assistantDeploymentApi.NewAssistantDeploymentDryRunGRPCApi(Cfg,
+			Logger,
+			Postgres,
+		))
 }
 
 func AssistantConversationApiRoute(
@@ -93,11 +160,54 @@ func TalkCallbackApiRoute(
 
 		// inbound call receiver — webhook from telephony provider, saves call context to Postgres
 		apiv1.GET("/:telephony/call/:assistantId", talkRpcApi.CallReciever)
+		apiv1.POST("/:telephony/call/:assistantId", talkRpcApi.CallReciever)
 
 		// contextId-based routes — all auth, assistant, conversation resolved from Postgres call context
-		// Used by all telephony providers (Twilio, Exotel, Vonage, Asterisk, SIP)
+		// Used by all telephony providers (Twilio, Exotel, Vonage, Asterisk, FreeSWITCH, SIP)
 		apiv1.GET("/:telephony/ctx/:contextId", talkRpcApi.CallTalkerByContext)
 		apiv1.GET("/:telephony/ctx/:contextId/event", talkRpcApi.CallbackByContext)
 		apiv1.POST("/:telephony/ctx/:contextId/event", talkRpcApi.CallbackByContext)
 	}
+
+	// standalone streaming speech to text using the assistant's listen configuration
+	listenv1 := engine.Group("v1/listen")
+	{
+		listenv1.GET("/:assistantId", talkRpcApi.Listen)
+	}
+
+	// supervisors listen to, whisper to or barge into a live conversation
+	supervisev1 := engine.Group("v1/supervise")
+	{
+		supervisev1.GET("/:conversationId", talkRpcApi.Supervise)
+	}
+
+	// human agents join a live call, making it a conference
+	conferencev1 := engine.Group("v1/conference")
+	{
+		conferencev1.GET("/:conversationId", talkRpcApi.JoinConference)
+	}
+
+	// send the assistant into a LiveKit room as a participant
+	livekitv1 := engine.Group("v1/livekit")
+	{
+		livekitv1.POST("/:assistantId", talkRpcApi.JoinLiveKitRoom)
+	}
+
+	// session tokens are accepted on the routes browsers open themselves
+	sessionAuthenticator := middlewares.NewSessionAuthenticatorMiddleware(
+		internal_sessiontoken.NewTokens(cfg.WebTalkSession, redis),
+		logger,
+	)
+
+	// WebTalk over a plain WebSocket, session tokens open it like the gRPC stream
+	webtalkv1 := engine.Group("v1/webtalk", sessionAuthenticator)
+	{
+		webtalkv1.GET("", talkRpcApi.WebTalkSocket)
+	}
+
+	// the transcript of a live conversation as server-sent events
+	transcriptv1 := engine.Group("v1/transcript", sessionAuthenticator)
+	{
+		transcriptv1.GET("/:assistantId/:conversationId", talkRpcApi.StreamTranscript)
+	}
 }
@@ -9,8 +9,10 @@ import (
 	"github.com/gin-gonic/gin"
 	healthCheckApi "github.com/rapidaai/api/assistant-api/api/health"
 	"github.com/rapidaai/api/assistant-api/config"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/streamers"
 )
 
 func HealthCheckRoutes(cfg *config.AssistantConfig, engine *gin.Engine, logger commons.Logger, postgres connectors.PostgresConnector) {
@@ -20,5 +22,10 @@ func HealthCheckRoutes(cfg *config.AssistantConfig, engine *gin.Engine, logger c
 	{
 		apiv1.GET("/readiness/", hcApi.Readiness)
 		apiv1.GET("/healthz/", hcApi.Healthz)
+		apiv1.GET("/metrics", hcApi.Metrics)
+		apiv1.GET("/drain", hcApi.Drain)
+		apiv1.GET("/capacity", hcApi.Capacity)
 	}
+	// streamers are counted from here on, sessions only start after routing
+	streamers.SetObserver(internal_runtimemetrics.Streamers)
 }
@@ -0,0 +1,21 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_sessiontoken
+
+import (
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_sessiontoken "github.com/rapidaai/api/assistant-api/internal/sessiontoken"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/types"
+)
+
+// NewSessionAuthenticator checks the WebTalk session tokens browsers open
+// their sessions with, for the session middleware. Without a configuration
+// every token is refused.
+func NewSessionAuthenticator(cfg *config.WebTalkSessionConfig, redis connectors.RedisConnector) types.ClaimAuthenticator[*types.SessionScope] {
+	return internal_sessiontoken.NewTokens(cfg, redis)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/zaf/g711"
+)
+
+// Bridge connects two RTP legs so that both parties hear each other directly,
+// without the audio passing through an assistant. Audio is transcoded when the
+// legs negotiated different G.711 laws, and DTMF received on one leg is
+// replayed on the other.
+//
+// The bridge consumes AudioIn and DTMFIn of both legs, nothing else may read
+// them while it runs.
+type Bridge struct {
+	logger commons.Logger
+	a, b   *RTPHandler
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewBridge creates a bridge between legs a and b. Call Start to begin relaying.
+func NewBridge(ctx context.Context, logger commons.Logger, a, b *RTPHandler) *Bridge {
+	bridgeCtx, cancel := context.WithCancel(ctx)
+	return &Bridge{
+		logger: logger,
+		a:      a,
+		b:      b,
+		ctx:    bridgeCtx,
+		cancel: cancel,
+	}
+}
+
+// Start relays media in both directions until Stop is called or either leg
+// stops.
+func (br *Bridge) Start() {
+	br.once.Do(func() {
+		br.wg.Add(2)
+		go br.relay(br.a, br.b)
+		go br.relay(br.b, br.a)
+	})
+}
+
+// Stop ends the relay and waits for it to finish. The legs themselves are not
+// stopped.
+func (br *Bridge) Stop() {
+	br.cancel()
+	br.wg.Wait()
+}
+
+// Done is closed once the bridge stops relaying.
+func (br *Bridge) Done() <-chan struct{} {
+	return br.ctx.Done()
+}
+
+func (br *Bridge) relay(from, to *RTPHandler) {
+	defer br.wg.Done()
+	// one leg going away ends the bridge for both directions
+	defer br.cancel()
+
+	for {
+		select {
+		case <-br.ctx.Done():
+			return
+		case audio, ok := <-from.AudioIn():
+			if !ok {
+				return
+			}
+			if !to.IsRunning() {
+				return
+			}
+			select {
+			case to.AudioOut() <- transcode(audio, from.GetCodec(), to.GetCodec()):
+			case <-br.ctx.Done():
+				return
+			default:
+				// the far leg is behind, drop the frame rather than add latency
+			}
+		case event, ok := <-from.DTMFIn():
+			if !ok {
+				return
+			}
+			if err := to.SendDTMF(event.Digit); err != nil && br.logger != nil {
+				br.logger.Warnw("Failed to relay DTMF across bridge", "digit", event.Digit, "error", err)
+			}
+		}
+	}
+}
+
+// transcode converts a G.711 frame between the laws of two legs. A-law is
+// converted through linear PCM, g711.Alaw2Ulaw/Ulaw2Alaw are not symmetric.
+// Legs of a transcoded codec carry µ-law, see channelCodec.
+func transcode(audio []byte, from, to *Codec) []byte {
+	from, to = channelCodec(from), channelCodec(to)
+	if from == nil || to == nil || from.Name == to.Name {
+		return audio
+	}
+	switch {
+	case from.Name == CodecPCMA.Name && to.Name == CodecPCMU.Name:
+		return g711.EncodeUlaw(g711.DecodeAlaw(audio))
+	case from.Name == CodecPCMU.Name && to.Name == CodecPCMA.Name:
+		return g711.EncodeAlaw(g711.DecodeUlaw(audio))
+	}
+	return audio
+}
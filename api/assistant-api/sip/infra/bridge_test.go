@@ -0,0 +1,89 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zaf/g711"
+)
+
+func bridgeLeg(t *testing.T, codec Codec) *RTPHandler {
+	t.Helper()
+	h, err := NewRTPHandler(context.Background(), &RTPConfig{
+		LocalIP:     "127.0.0.1",
+		PayloadType: codec.PayloadType,
+		ClockRate:   codec.ClockRate,
+	})
+	require.NoError(t, err)
+	// the relay only needs the channels, no packets go out
+	h.running.Store(true)
+	t.Cleanup(func() { h.Stop() })
+	return h
+}
+
+func TestTranscode(t *testing.T) {
+	ulaw := g711.EncodeUlaw(g711.DecodeAlaw([]byte{0xD5, 0x55, 0x2A, 0xAA}))
+
+	assert.Equal(t, []byte{1, 2, 3}, transcode([]byte{1, 2, 3}, &CodecPCMU, &CodecPCMU))
+	assert.Equal(t, []byte{1, 2, 3}, transcode([]byte{1, 2, 3}, nil, &CodecPCMA))
+	assert.Equal(t, ulaw, transcode([]byte{0xD5, 0x55, 0x2A, 0xAA}, &CodecPCMA, &CodecPCMU))
+	assert.Equal(t, g711.EncodeAlaw(g711.DecodeUlaw(ulaw)), transcode(ulaw, &CodecPCMU, &CodecPCMA))
+}
+
+func TestBridge_RelaysBothDirections(t *testing.T) {
+	caller := bridgeLeg(t, CodecPCMA)
+	agent := bridgeLeg(t, CodecPCMU)
+
+	bridge := NewBridge(context.Background(), nil, caller, agent)
+	bridge.Start()
+	defer bridge.Stop()
+
+	caller.audioInChan <- []byte{0xD5, 0xD5}
+	select {
+	case frame := <-agent.audioOutChan:
+		assert.Equal(t, transcode([]byte{0xD5, 0xD5}, &CodecPCMA, &CodecPCMU), frame)
+	case <-time.After(time.Second):
+		t.Fatal("caller audio was not relayed to the agent")
+	}
+
+	agent.audioInChan <- []byte{0xFF, 0xFF}
+	select {
+	case frame := <-caller.audioOutChan:
+		assert.Equal(t, transcode([]byte{0xFF, 0xFF}, &CodecPCMU, &CodecPCMA), frame)
+	case <-time.After(time.Second):
+		t.Fatal("agent audio was not relayed to the caller")
+	}
+
+	agent.dtmfInChan <- DTMFEvent{Digit: "5"}
+	select {
+	case codes := <-caller.dtmfOutChan:
+		assert.Equal(t, []byte{5}, codes)
+	case <-time.After(time.Second):
+		t.Fatal("agent DTMF was not relayed to the caller")
+	}
+}
+
+func TestBridge_EndsWhenLegStops(t *testing.T) {
+	caller := bridgeLeg(t, CodecPCMU)
+	agent := bridgeLeg(t, CodecPCMU)
+
+	bridge := NewBridge(context.Background(), nil, caller, agent)
+	bridge.Start()
+
+	agent.Stop()
+	select {
+	case <-bridge.Done():
+	case <-time.After(time.Second):
+		t.Fatal("bridge kept running after a leg stopped")
+	}
+	bridge.Stop()
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+)
+
+// EnableComfortNoise sends low-level noise at levelDBov where the sendLoop
+// would send digital silence, and puts it under frames of AudioOut quieter
+// than that. Zero levels take internal_audio.DefaultComfortNoiseLevel.
+func (h *RTPHandler) EnableComfortNoise(levelDBov int) {
+	if levelDBov == 0 {
+		levelDBov = internal_audio.DefaultComfortNoiseLevel
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.comfortNoise = internal_audio.NewComfortNoise(levelDBov)
+	if h.logger != nil {
+		h.logger.Infow("RTP comfort noise enabled", "level_dbov", levelDBov)
+	}
+}
+
+// withComfortNoise returns the frame with comfort noise under it when it is
+// quiet enough. frame is one packet of AudioOut in the channel codec, the
+// silence chunk included, and is not modified. Owned by sendLoop.
+func (h *RTPHandler) withComfortNoise(frame []byte, codec *Codec) []byte {
+	h.mu.RLock()
+	noise := h.comfortNoise
+	h.mu.RUnlock()
+	if noise == nil {
+		return frame
+	}
+	codec = channelCodec(codec)
+	pcm := decodeG711(frame, codec)
+	if !noise.Fill(pcm) {
+		return frame
+	}
+	return encodeG711(pcm, codec)
+}
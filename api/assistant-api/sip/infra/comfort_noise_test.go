@@ -0,0 +1,60 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRTPHandler_ComfortNoiseReplacesSilence(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMU)
+	silence := h.createSilenceChunk(160)
+	assert.Equal(t, silence, h.withComfortNoise(silence, &CodecPCMU), "off by default")
+
+	h.EnableComfortNoise(0)
+	noisy := h.withComfortNoise(silence, &CodecPCMU)
+	assert.NotEqual(t, silence, noisy)
+	assert.Equal(t, bytes.Repeat([]byte{0xFF}, 160), silence, "the silence chunk is reused, it must stay untouched")
+
+	level := 20 * math.Log10(internal_audio.RMS(decodeG711(noisy, &CodecPCMU))/math.MaxInt16)
+	assert.InDelta(t, internal_audio.DefaultComfortNoiseLevel, level, 6)
+}
+
+func TestRTPHandler_ComfortNoiseLeavesSpeech(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMA)
+	h.EnableComfortNoise(-60)
+
+	pcm := make([]int16, 160)
+	for i := range pcm {
+		pcm[i] = int16(4000 * math.Sin(2*math.Pi*400*float64(i)/8000))
+	}
+	speech := encodeG711(pcm, &CodecPCMA)
+	assert.Equal(t, speech, h.withComfortNoise(speech, &CodecPCMA))
+
+	// transcoded codecs carry µ-law frames
+	silence := bytes.Repeat([]byte{0xFF}, 160)
+	assert.NotEqual(t, silence, h.withComfortNoise(silence, &CodecG722))
+}
+
+func TestParseComfortNoise(t *testing.T) {
+	assert.Equal(t, internal_audio.DefaultComfortNoiseLevel, ParseComfortNoise(true))
+	assert.Equal(t, internal_audio.DefaultComfortNoiseLevel, ParseComfortNoise(" On"))
+	assert.Equal(t, internal_audio.DefaultComfortNoiseLevel, ParseComfortNoise("true"))
+	assert.Equal(t, -55, ParseComfortNoise("-55"))
+	assert.Equal(t, -55, ParseComfortNoise("55"))
+	assert.Equal(t, -50, ParseComfortNoise(-50.0))
+	assert.Equal(t, internal_audio.MaxComfortNoiseLevel, ParseComfortNoise("-10"))
+	assert.Equal(t, internal_audio.MinComfortNoiseLevel, ParseComfortNoise(-120))
+	assert.Zero(t, ParseComfortNoise("false"))
+	assert.Zero(t, ParseComfortNoise("loud"))
+	assert.Zero(t, ParseComfortNoise(nil))
+}
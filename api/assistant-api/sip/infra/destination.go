@@ -0,0 +1,159 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/emiago/sipgo/sip"
+)
+
+// SecurityPolicy decides how an outbound call is protected. A credential's
+// policy applies to every call placed with it, a sips: destination always
+// gets the strictest one.
+type SecurityPolicy string
+
+const (
+	// SecurityPolicyNone keeps the configured transport and plain RTP
+	SecurityPolicyNone SecurityPolicy = "none"
+	// SecurityPolicyTLS sends signaling over TLS, media stays plain RTP
+	SecurityPolicyTLS SecurityPolicy = "tls"
+	// SecurityPolicySRTP sends signaling over TLS and media as SRTP with
+	// SDES keys (RFC 4568), which are only safe inside TLS
+	SecurityPolicySRTP SecurityPolicy = "srtp"
+)
+
+// ParseSecurityPolicy maps a configured value to a SecurityPolicy, unknown
+// or empty values fall back to SecurityPolicyNone.
+func ParseSecurityPolicy(value string) SecurityPolicy {
+	switch SecurityPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case SecurityPolicyTLS:
+		return SecurityPolicyTLS
+	case SecurityPolicySRTP:
+		return SecurityPolicySRTP
+	}
+	return SecurityPolicyNone
+}
+
+// Destination is where an outbound INVITE goes and how it is protected.
+type Destination struct {
+	// URI is the request URI of the INVITE, also used for the To header
+	URI sip.Uri
+	// Direct is set for SIP URI destinations, which are dialled as given
+	// instead of through the credential's trunk
+	Direct bool
+	// TLS and SRTP follow the credential's policy and the URI scheme
+	TLS  bool
+	SRTP bool
+}
+
+// sipUser matches the user part of a SIP URI (RFC 3261 25.1): unreserved
+// characters, escapes and the user-unreserved set.
+var sipUser = regexp.MustCompile(`^([A-Za-z0-9\-_.!~*'()&=+$,;?/]|%[0-9A-Fa-f]{2})+$`)
+
+// sipHostname matches a DNS hostname.
+var sipHostname = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9\-]{0,61}[A-Za-z0-9])?\.)*[A-Za-z0-9]([A-Za-z0-9\-]{0,61}[A-Za-z0-9])?$`)
+
+// IsSIPURI reports whether a call destination is a SIP URI rather than a
+// phone number.
+func IsSIPURI(to string) bool {
+	lower := strings.ToLower(strings.TrimSpace(to))
+	return strings.HasPrefix(lower, "sip:") || strings.HasPrefix(lower, "sips:")
+}
+
+// ParseSIPURI parses and validates a SIP URI destination such as
+// sip:support@pbx.example.com or sips:1001@10.0.0.5:5061;transport=tls.
+// Headers and passwords are rejected, they have no place in a dialled URI.
+func ParseSIPURI(to string) (sip.Uri, error) {
+	to = strings.TrimSpace(to)
+	if !IsSIPURI(to) {
+		return sip.Uri{}, fmt.Errorf("%w: %q is not a sip: or sips: URI", ErrInvalidConfig, to)
+	}
+	var uri sip.Uri
+	if err := sip.ParseUri(to, &uri); err != nil {
+		return sip.Uri{}, fmt.Errorf("%w: invalid SIP URI %q: %v", ErrInvalidConfig, to, err)
+	}
+	uri.Scheme = strings.ToLower(uri.Scheme)
+
+	if uri.Password != "" {
+		return sip.Uri{}, fmt.Errorf("%w: SIP URI %q must not carry a password", ErrInvalidConfig, to)
+	}
+	if len(uri.Headers) > 0 {
+		return sip.Uri{}, fmt.Errorf("%w: SIP URI %q must not carry headers", ErrInvalidConfig, to)
+	}
+	if uri.User == "" || !sipUser.MatchString(uri.User) {
+		return sip.Uri{}, fmt.Errorf("%w: SIP URI %q has no valid user part", ErrInvalidConfig, to)
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(uri.Host, "["), "]")
+	if net.ParseIP(host) == nil && !sipHostname.MatchString(host) {
+		return sip.Uri{}, fmt.Errorf("%w: SIP URI %q has an invalid host", ErrInvalidConfig, to)
+	}
+	if uri.Port < 0 || uri.Port > 65535 {
+		return sip.Uri{}, fmt.Errorf("%w: SIP URI %q has an invalid port", ErrInvalidConfig, to)
+	}
+	if uri.UriParams != nil {
+		if transport, ok := uri.UriParams.Get("transport"); ok && !Transport(strings.ToLower(transport)).IsValid() {
+			return sip.Uri{}, fmt.Errorf("%w: SIP URI %q has unsupported transport %q", ErrInvalidConfig, to, transport)
+		}
+	}
+	return uri, nil
+}
+
+// ResolveDestination builds the destination of an outbound call to a phone
+// number or a SIP URI. Phone numbers go to the user of that name on the
+// credential's server. SIP URIs are dialled as given, sips: or a
+// transport=tls parameter ask for TLS, and sips: for SRTP as well.
+func ResolveDestination(cfg *Config, to string) (*Destination, error) {
+	policy := cfg.SecurityPolicy
+	dest := &Destination{
+		TLS:  cfg.Transport == TransportTLS || policy == SecurityPolicyTLS || policy == SecurityPolicySRTP,
+		SRTP: policy == SecurityPolicySRTP,
+	}
+
+	if IsSIPURI(to) {
+		uri, err := ParseSIPURI(to)
+		if err != nil {
+			return nil, err
+		}
+		dest.Direct = true
+		if uri.Scheme == "sips" {
+			dest.TLS, dest.SRTP = true, true
+		}
+		if transport, ok := uri.UriParams.Get("transport"); ok {
+			switch Transport(strings.ToLower(transport)) {
+			case TransportTLS:
+				dest.TLS = true
+			case TransportTCP, TransportUDP:
+				// the URI asks for a plain transport the policy does not allow
+				if dest.TLS {
+					return nil, fmt.Errorf("%w: calls require TLS but SIP URI %q asks for %s", ErrInvalidConfig, to, transport)
+				}
+			}
+		}
+		dest.URI = uri
+	} else {
+		if strings.TrimSpace(to) == "" {
+			return nil, fmt.Errorf("%w: empty destination", ErrInvalidConfig)
+		}
+		dest.URI = sip.Uri{Scheme: "sip", User: to, Host: cfg.Server, Port: cfg.Port}
+		if cfg.Transport == TransportTCP && !dest.TLS {
+			dest.URI.UriParams = sip.NewParams().Add("transport", string(TransportTCP))
+		}
+	}
+
+	if dest.TLS {
+		dest.URI.Scheme = "sips"
+		if dest.URI.UriParams == nil {
+			dest.URI.UriParams = sip.NewParams()
+		}
+		dest.URI.UriParams.Add("transport", string(TransportTLS))
+	}
+	return dest, nil
+}
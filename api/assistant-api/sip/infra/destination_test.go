@@ -0,0 +1,107 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSIPURI(t *testing.T) {
+	uri, err := ParseSIPURI(" sip:support@pbx.example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "support", uri.User)
+	assert.Equal(t, "pbx.example.com", uri.Host)
+
+	uri, err = ParseSIPURI("SIPS:1001@10.0.0.5:5061;transport=tls")
+	require.NoError(t, err)
+	assert.Equal(t, "sips", uri.Scheme)
+	assert.Equal(t, 5061, uri.Port)
+
+	for _, to := range []string{
+		"+15551234567",
+		"tel:+15551234567",
+		"sip:pbx.example.com",
+		"sip:alice:secret@pbx.example.com",
+		"sip:alice@pbx.example.com?Subject=hi",
+		"sip:alice@bad_host",
+		"sip:alice@pbx.example.com;transport=sctp",
+		"sip:al ice@pbx.example.com",
+	} {
+		_, err := ParseSIPURI(to)
+		assert.ErrorIs(t, err, ErrInvalidConfig, to)
+	}
+}
+
+func TestParseSecurityPolicy(t *testing.T) {
+	assert.Equal(t, SecurityPolicyNone, ParseSecurityPolicy(""))
+	assert.Equal(t, SecurityPolicyNone, ParseSecurityPolicy("zrtp"))
+	assert.Equal(t, SecurityPolicyTLS, ParseSecurityPolicy(" TLS "))
+	assert.Equal(t, SecurityPolicySRTP, ParseSecurityPolicy("srtp"))
+}
+
+func TestResolveDestination_PhoneNumber(t *testing.T) {
+	cfg := &Config{Server: "trunk.example.com", Port: 5060, Transport: TransportUDP}
+	dest, err := ResolveDestination(cfg, "+15551234567")
+	require.NoError(t, err)
+	assert.False(t, dest.Direct)
+	assert.False(t, dest.TLS)
+	assert.Equal(t, "sip:+15551234567@trunk.example.com:5060", dest.URI.String())
+
+	cfg.Transport = TransportTCP
+	dest, err = ResolveDestination(cfg, "+15551234567")
+	require.NoError(t, err)
+	assert.Equal(t, "sip:+15551234567@trunk.example.com:5060;transport=tcp", dest.URI.String())
+
+	cfg.SecurityPolicy = SecurityPolicySRTP
+	dest, err = ResolveDestination(cfg, "+15551234567")
+	require.NoError(t, err)
+	assert.True(t, dest.TLS)
+	assert.True(t, dest.SRTP)
+	assert.Equal(t, "sips:+15551234567@trunk.example.com:5060;transport=tls", dest.URI.String())
+
+	_, err = ResolveDestination(cfg, " ")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestResolveDestination_SIPURI(t *testing.T) {
+	cfg := &Config{Server: "trunk.example.com", Port: 5060, Transport: TransportUDP}
+
+	dest, err := ResolveDestination(cfg, "sip:support@pbx.example.com")
+	require.NoError(t, err)
+	assert.True(t, dest.Direct)
+	assert.False(t, dest.TLS)
+	assert.False(t, dest.SRTP)
+	assert.Equal(t, "pbx.example.com", dest.URI.Host, "dialled as given, not through the trunk")
+
+	dest, err = ResolveDestination(cfg, "sip:support@pbx.example.com;transport=tls")
+	require.NoError(t, err)
+	assert.True(t, dest.TLS)
+	assert.False(t, dest.SRTP)
+	assert.Equal(t, "sips", dest.URI.Scheme)
+
+	dest, err = ResolveDestination(cfg, "sips:support@pbx.example.com")
+	require.NoError(t, err)
+	assert.True(t, dest.TLS)
+	assert.True(t, dest.SRTP, "sips always protects the media")
+	transport, _ := dest.URI.UriParams.Get("transport")
+	assert.Equal(t, "tls", transport)
+
+	cfg.SecurityPolicy = SecurityPolicyTLS
+	dest, err = ResolveDestination(cfg, "sip:support@pbx.example.com")
+	require.NoError(t, err)
+	assert.True(t, dest.TLS)
+	assert.False(t, dest.SRTP)
+
+	_, err = ResolveDestination(cfg, "sip:support@pbx.example.com;transport=udp")
+	assert.ErrorContains(t, err, "require TLS")
+
+	_, err = ResolveDestination(cfg, "sip:support:pw@pbx.example.com")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// RFC 4733 telephone-event payload size: event(8) E|R|volume(8) duration(16)
+const dtmfPayloadSize = 4
+
+// dtmfDigits maps RFC 4733 event codes 0-15 to keypad digits.
+const dtmfDigits = "0123456789*#ABCD"
+
+// dtmfDecoder turns the RFC 4733 packet stream of a call into DTMFEvents.
+//
+// A single key press is carried by many packets sharing the same RTP
+// timestamp: updates while the key is held and, once released, the end
+// packet which is usually retransmitted three times. The decoder reports each
+// press exactly once, when its first end packet arrives. If every end packet
+// of a press is lost, the press is reported as soon as the next one starts.
+type dtmfDecoder struct {
+	clockRate uint32
+
+	// press in progress
+	active    bool
+	timestamp uint32
+	event     DTMFEvent
+
+	// timestamp of the last reported press, to drop end retransmissions
+	reported   bool
+	reportedTS uint32
+}
+
+func newDTMFDecoder(clockRate uint32) *dtmfDecoder {
+	if clockRate == 0 {
+		clockRate = CodecTelephoneEvent.ClockRate
+	}
+	return &dtmfDecoder{clockRate: clockRate}
+}
+
+// Decode consumes one telephone-event packet and returns the presses it
+// completes, oldest first.
+func (d *dtmfDecoder) Decode(packet *RTPPacket) ([]DTMFEvent, error) {
+	if len(packet.Payload) < dtmfPayloadSize {
+		return nil, fmt.Errorf("telephone-event payload too small: %d", len(packet.Payload))
+	}
+	code := packet.Payload[0]
+	if int(code) >= len(dtmfDigits) {
+		// flash (16) and the tone events are not keypad input
+		return nil, nil
+	}
+	end := packet.Payload[1]&0x80 != 0
+	event := DTMFEvent{
+		Digit:    string(dtmfDigits[code]),
+		Duration: int(uint32(binary.BigEndian.Uint16(packet.Payload[2:4])) * 1000 / d.clockRate),
+	}
+
+	if d.reported && packet.Timestamp == d.reportedTS {
+		return nil, nil
+	}
+
+	var events []DTMFEvent
+	if d.active && packet.Timestamp != d.timestamp {
+		// the previous press never delivered its end packet
+		events = append(events, d.event)
+		d.report(d.timestamp)
+	}
+
+	if end {
+		events = append(events, event)
+		d.report(packet.Timestamp)
+		return events, nil
+	}
+	d.active = true
+	d.timestamp = packet.Timestamp
+	d.event = event
+	return events, nil
+}
+
+func (d *dtmfDecoder) report(timestamp uint32) {
+	d.active = false
+	d.reported = true
+	d.reportedTS = timestamp
+}
+
+// Outgoing key press timing, in 20ms packets.
+const (
+	dtmfToneFrames = 5 // 100ms tone
+	dtmfEndFrames  = 3 // the end packet is sent three times
+	dtmfGapFrames  = 3 // 60ms pause before the next key
+	dtmfVolume     = 10
+)
+
+// dtmfEventCodes converts keypad digits to RFC 4733 event codes.
+func dtmfEventCodes(digits string) ([]byte, error) {
+	codes := make([]byte, 0, len(digits))
+	for _, d := range strings.ToUpper(digits) {
+		code := strings.IndexRune(dtmfDigits, d)
+		if code < 0 {
+			return nil, fmt.Errorf("invalid DTMF digit %q", d)
+		}
+		codes = append(codes, byte(code))
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("no DTMF digits to send")
+	}
+	return codes, nil
+}
+
+// dtmfEncoder produces the RFC 4733 packet stream for queued key presses,
+// one payload per 20ms send cycle. It is owned by the sendLoop.
+type dtmfEncoder struct {
+	frameSamples uint16
+	pending      []byte
+	frame        int
+}
+
+func newDTMFEncoder(frameSamples uint16) *dtmfEncoder {
+	return &dtmfEncoder{frameSamples: frameSamples}
+}
+
+// Enqueue adds event codes to send after the ones already pending.
+func (e *dtmfEncoder) Enqueue(codes []byte) {
+	e.pending = append(e.pending, codes...)
+}
+
+// Active reports whether key presses are still being sent.
+func (e *dtmfEncoder) Active() bool {
+	return len(e.pending) > 0
+}
+
+// Next returns the telephone-event payload of the next send cycle and whether
+// it starts a new event. A nil payload means the cycle falls in the pause
+// between two keys and regular audio should be sent instead.
+func (e *dtmfEncoder) Next() (payload []byte, start bool) {
+	if len(e.pending) == 0 {
+		return nil, false
+	}
+	code, frame := e.pending[0], e.frame
+	e.frame++
+	if e.frame == dtmfToneFrames+dtmfEndFrames+dtmfGapFrames {
+		e.pending = e.pending[1:]
+		e.frame = 0
+	}
+
+	switch {
+	case frame < dtmfToneFrames:
+		// key held, the duration grows with every update
+		payload = make([]byte, dtmfPayloadSize)
+		payload[0] = code
+		payload[1] = dtmfVolume
+		binary.BigEndian.PutUint16(payload[2:4], uint16(frame+1)*e.frameSamples)
+		return payload, frame == 0
+	case frame < dtmfToneFrames+dtmfEndFrames:
+		payload = make([]byte, dtmfPayloadSize)
+		payload[0] = code
+		payload[1] = 0x80 | dtmfVolume
+		binary.BigEndian.PutUint16(payload[2:4], dtmfToneFrames*e.frameSamples)
+		return payload, false
+	default:
+		return nil, false
+	}
+}
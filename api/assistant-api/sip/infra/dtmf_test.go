@@ -0,0 +1,172 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func telephoneEvent(timestamp uint32, code uint8, end bool, duration uint16) *RTPPacket {
+	flags := uint8(10) // volume
+	if end {
+		flags |= 0x80
+	}
+	return &RTPPacket{
+		PayloadType: CodecTelephoneEvent.PayloadType,
+		Timestamp:   timestamp,
+		Payload:     []byte{code, flags, byte(duration >> 8), byte(duration)},
+	}
+}
+
+func TestDTMFDecoder_ReportsOncePerPress(t *testing.T) {
+	d := newDTMFDecoder(8000)
+
+	for _, duration := range []uint16{160, 320, 480} {
+		events, err := d.Decode(telephoneEvent(1000, 5, false, duration))
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	}
+
+	events, err := d.Decode(telephoneEvent(1000, 5, true, 800))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "5", events[0].Digit)
+	assert.Equal(t, 100, events[0].Duration)
+
+	// end packet retransmissions are dropped
+	for i := 0; i < 2; i++ {
+		events, err = d.Decode(telephoneEvent(1000, 5, true, 800))
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	}
+}
+
+func TestDTMFDecoder_DigitMapping(t *testing.T) {
+	d := newDTMFDecoder(8000)
+	var digits string
+	for code := uint8(0); code < 16; code++ {
+		events, err := d.Decode(telephoneEvent(uint32(code)*1000, code, true, 800))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		digits += events[0].Digit
+	}
+	assert.Equal(t, "0123456789*#ABCD", digits)
+}
+
+func TestDTMFDecoder_LostEndPacket(t *testing.T) {
+	d := newDTMFDecoder(8000)
+
+	events, err := d.Decode(telephoneEvent(1000, 1, false, 160))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// next press starts without the previous one ending
+	events, err = d.Decode(telephoneEvent(2000, 2, false, 160))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1", events[0].Digit)
+
+	events, err = d.Decode(telephoneEvent(2000, 2, true, 800))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "2", events[0].Digit)
+}
+
+func TestDTMFDecoder_IgnoresNonKeypadEvents(t *testing.T) {
+	d := newDTMFDecoder(8000)
+	events, err := d.Decode(telephoneEvent(1000, 16, true, 800)) // flash
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestDTMFDecoder_ShortPayload(t *testing.T) {
+	d := newDTMFDecoder(8000)
+	_, err := d.Decode(&RTPPacket{Payload: []byte{1, 2}})
+	assert.Error(t, err)
+}
+
+func TestDTMFEventCodes(t *testing.T) {
+	codes, err := dtmfEventCodes("19*#a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 9, 10, 11, 12}, codes)
+
+	_, err = dtmfEventCodes("12x")
+	assert.Error(t, err)
+	_, err = dtmfEventCodes("")
+	assert.Error(t, err)
+}
+
+func TestDTMFEncoder_RoundTrip(t *testing.T) {
+	e := newDTMFEncoder(160)
+	e.Enqueue([]byte{4, 11})
+	d := newDTMFDecoder(8000)
+
+	var digits []string
+	var timestamp uint32
+	for cycle := 0; e.Active(); cycle++ {
+		payload, start := e.Next()
+		if payload == nil {
+			continue
+		}
+		if start {
+			timestamp = uint32(cycle * 160)
+		}
+		events, err := d.Decode(&RTPPacket{Timestamp: timestamp, Payload: payload})
+		require.NoError(t, err)
+		for _, ev := range events {
+			digits = append(digits, ev.Digit)
+			assert.Equal(t, dtmfToneFrames*20, ev.Duration)
+		}
+	}
+	assert.Equal(t, []string{"4", "#"}, digits)
+}
+
+func TestDTMFEncoder_PausesBetweenKeys(t *testing.T) {
+	e := newDTMFEncoder(160)
+	e.Enqueue([]byte{1})
+
+	var tone, end, gap int
+	for e.Active() {
+		payload, _ := e.Next()
+		switch {
+		case payload == nil:
+			gap++
+		case payload[1]&0x80 != 0:
+			end++
+		default:
+			tone++
+		}
+	}
+	assert.Equal(t, dtmfToneFrames, tone)
+	assert.Equal(t, dtmfEndFrames, end)
+	assert.Equal(t, dtmfGapFrames, gap)
+}
+
+func TestSDP_TelephoneEventPayloadType(t *testing.T) {
+	s := &Server{}
+	offer := "v=0\r\nc=IN IP4 10.0.0.2\r\nm=audio 20000 RTP/AVP 96 0\r\n" +
+		"a=rtpmap:96 telephone-event/8000\r\na=rtpmap:0 PCMU/8000\r\n"
+	info, err := s.ParseSDP([]byte(offer))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(96), info.TelephoneEventPayloadType)
+	assert.Equal(t, CodecPCMU.Name, info.PreferredCodec.Name, "telephone-event is not an audio codec")
+
+	cfg := s.NegotiatedSDPConfig("10.0.0.1", 10000, info.PreferredCodec)
+	cfg.TelephoneEventPayloadType = info.TelephoneEventPayloadType
+	answer := s.GenerateSDP(cfg)
+	assert.Contains(t, answer, "m=audio 10000 RTP/AVP 0 96\r\n")
+	assert.Contains(t, answer, "a=rtpmap:96 telephone-event/8000\r\n")
+	assert.NotContains(t, answer, "101")
+
+	info, err = s.ParseSDP([]byte("v=0\r\nm=audio 20000 RTP/AVP 0\r\n"))
+	require.NoError(t, err)
+	assert.Zero(t, info.TelephoneEventPayloadType)
+	assert.Equal(t, CodecPCMU.Name, info.PreferredCodec.Name)
+}
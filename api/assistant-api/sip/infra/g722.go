@@ -0,0 +1,328 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+// ITU-T G.722 sub-band ADPCM at 64 kbit/s. The signal is split by a QMF into
+// a lower and a higher band of 8 kHz each, coded with 6 and 2 bits per
+// sample. The codec runs either on 16 kHz linear PCM, or on 8 kHz PCM which
+// is coded as the lower band alone with a silent higher band. RTP streams of
+// G.722 use an 8 kHz clock regardless (RFC 3551), one byte per 8 kHz sample.
+
+var g722QMFCoeffs = [12]int{3, -11, 12, 32, -210, 951, 3876, -805, 362, -156, 53, -11}
+
+var (
+	g722Q6   = [32]int{0, 35, 72, 110, 150, 190, 233, 276, 323, 370, 422, 473, 530, 587, 650, 714, 786, 858, 940, 1023, 1121, 1219, 1339, 1458, 1612, 1765, 1980, 2195, 2557, 2919, 0, 0}
+	g722ILN  = [32]int{0, 63, 62, 31, 30, 29, 28, 27, 26, 25, 24, 23, 22, 21, 20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 0}
+	g722ILP  = [32]int{0, 61, 60, 59, 58, 57, 56, 55, 54, 53, 52, 51, 50, 49, 48, 47, 46, 45, 44, 43, 42, 41, 40, 39, 38, 37, 36, 35, 34, 33, 32, 0}
+	g722WL   = [8]int{-60, -30, 58, 172, 334, 538, 1198, 3042}
+	g722RL42 = [16]int{0, 7, 6, 5, 4, 3, 2, 1, 7, 6, 5, 4, 3, 2, 1, 0}
+	g722ILB  = [32]int{2048, 2093, 2139, 2186, 2233, 2282, 2332, 2383, 2435, 2489, 2543, 2599, 2656, 2714, 2774, 2834, 2896, 2960, 3025, 3091, 3158, 3228, 3298, 3371, 3444, 3520, 3597, 3676, 3756, 3838, 3922, 4008}
+	g722QM4  = [16]int{0, -20456, -12896, -8968, -6288, -4240, -2584, -1200, 20456, 12896, 8968, 6288, 4240, 2584, 1200, 0}
+	g722QM6  = [64]int{
+		-136, -136, -136, -136, -24808, -21904, -19008, -16704,
+		-14984, -13512, -12280, -11192, -10232, -9360, -8576, -7856,
+		-7192, -6576, -6000, -5456, -4944, -4464, -4008, -3576,
+		-3168, -2776, -2400, -2032, -1688, -1360, -1040, -728,
+		24808, 21904, 19008, 16704, 14984, 13512, 12280, 11192,
+		10232, 9360, 8576, 7856, 7192, 6576, 6000, 5456,
+		4944, 4464, 4008, 3576, 3168, 2776, 2400, 2032,
+		1688, 1360, 1040, 728, 432, 136, -432, -136,
+	}
+	g722IHN = [3]int{0, 1, 0}
+	g722IHP = [3]int{0, 3, 2}
+	g722WH  = [3]int{0, -214, 798}
+	g722RH2 = [4]int{2, 1, 2, 1}
+	g722QM2 = [4]int{-7408, -1616, 7408, 1616}
+)
+
+// g722Band is the adaptive predictor and quantizer state of one sub-band.
+type g722Band struct {
+	s, sp, sz int
+	r, a, p   [3]int
+	d, b, bp  [7]int
+	nb, det   int
+}
+
+func saturate16(v int) int {
+	if v > 32767 {
+		return 32767
+	}
+	if v < -32768 {
+		return -32768
+	}
+	return v
+}
+
+// update adapts the predictor of the band to the quantized difference dx
+// (blocks 4L and 4H of the recommendation).
+func (b *g722Band) update(dx int) {
+	var sg [7]int
+	var ap [3]int
+
+	// RECONS, PARREC
+	b.d[0] = dx
+	b.r[0] = saturate16(b.s + dx)
+	b.p[0] = saturate16(b.sz + dx)
+
+	// UPPOL2
+	for i := 0; i < 3; i++ {
+		sg[i] = b.p[i] >> 15
+	}
+	wd1 := saturate16(b.a[1] << 2)
+	wd2 := wd1
+	if sg[0] == sg[1] {
+		wd2 = -wd1
+	}
+	if wd2 > 32767 {
+		wd2 = 32767
+	}
+	wd3 := -128
+	if sg[0] == sg[2] {
+		wd3 = 128
+	}
+	wd3 += wd2 >> 7
+	wd3 += (b.a[2] * 32512) >> 15
+	ap[2] = min(max(wd3, -12288), 12288)
+
+	// UPPOL1
+	sg[0] = b.p[0] >> 15
+	sg[1] = b.p[1] >> 15
+	wd1 = -192
+	if sg[0] == sg[1] {
+		wd1 = 192
+	}
+	wd2 = (b.a[1] * 32640) >> 15
+	ap[1] = saturate16(wd1 + wd2)
+	wd3 = saturate16(15360 - ap[2])
+	ap[1] = min(max(ap[1], -wd3), wd3)
+
+	// UPZERO
+	wd1 = 128
+	if dx == 0 {
+		wd1 = 0
+	}
+	sg[0] = dx >> 15
+	for i := 1; i < 7; i++ {
+		sg[i] = b.d[i] >> 15
+		wd2 = -wd1
+		if sg[i] == sg[0] {
+			wd2 = wd1
+		}
+		wd3 = (b.b[i] * 32640) >> 15
+		b.bp[i] = saturate16(wd2 + wd3)
+	}
+
+	// DELAYA
+	for i := 6; i > 0; i-- {
+		b.d[i] = b.d[i-1]
+		b.b[i] = b.bp[i]
+	}
+	for i := 2; i > 0; i-- {
+		b.r[i] = b.r[i-1]
+		b.p[i] = b.p[i-1]
+		b.a[i] = ap[i]
+	}
+
+	// FILTEP
+	wd1 = saturate16(b.r[1] + b.r[1])
+	wd1 = (b.a[1] * wd1) >> 15
+	wd2 = saturate16(b.r[2] + b.r[2])
+	wd2 = (b.a[2] * wd2) >> 15
+	b.sp = saturate16(wd1 + wd2)
+
+	// FILTEZ
+	b.sz = 0
+	for i := 6; i > 0; i-- {
+		wd1 = saturate16(b.d[i] + b.d[i])
+		b.sz += (b.b[i] * wd1) >> 15
+	}
+	b.sz = saturate16(b.sz)
+
+	// PREDIC
+	b.s = saturate16(b.sp + b.sz)
+}
+
+// scaleLow adapts the step size of the lower band to the quantizer output
+// (LOGSCL and SCALEL).
+func (b *g722Band) scaleLow(il4 int) {
+	b.nb = min(max((b.nb*127)>>7+g722WL[il4], 0), 18432)
+	b.det = g722ScaleFactor(b.nb, 8)
+}
+
+// scaleHigh is scaleLow for the higher band (LOGSCH and SCALEH).
+func (b *g722Band) scaleHigh(ih2 int) {
+	b.nb = min(max((b.nb*127)>>7+g722WH[ih2], 0), 22528)
+	b.det = g722ScaleFactor(b.nb, 10)
+}
+
+func g722ScaleFactor(nb, shift int) int {
+	wd1 := (nb >> 6) & 31
+	wd2 := shift - (nb >> 11)
+	if wd2 < 0 {
+		return (g722ILB[wd1] << -wd2) << 2
+	}
+	return (g722ILB[wd1] >> wd2) << 2
+}
+
+func newG722Bands() [2]g722Band {
+	var bands [2]g722Band
+	bands[0].det = 32
+	bands[1].det = 8
+	return bands
+}
+
+// G722Encoder codes linear PCM as G.722 at 64 kbit/s.
+type G722Encoder struct {
+	eightK bool
+	band   [2]g722Band
+	x      [24]int
+}
+
+// NewG722Encoder returns an encoder of 16 kHz PCM, or of 8 kHz PCM when
+// eightK is set.
+func NewG722Encoder(eightK bool) *G722Encoder {
+	return &G722Encoder{eightK: eightK, band: newG722Bands()}
+}
+
+// Encode codes the samples, two per byte at 16 kHz and one per byte at 8 kHz.
+// An odd trailing sample at 16 kHz is dropped.
+func (e *G722Encoder) Encode(pcm []int16) []byte {
+	out := make([]byte, 0, len(pcm))
+	for j := 0; j < len(pcm); {
+		var xlow, xhigh int
+		if e.eightK {
+			// the algorithm works on 15 bit input
+			xlow = int(pcm[j]) >> 1
+			j++
+		} else {
+			if j+1 >= len(pcm) {
+				break
+			}
+			// transmit QMF, every other output is discarded
+			copy(e.x[:22], e.x[2:])
+			e.x[22] = int(pcm[j])
+			e.x[23] = int(pcm[j+1])
+			j += 2
+			sumOdd, sumEven := 0, 0
+			for i := 0; i < 12; i++ {
+				sumOdd += e.x[2*i] * g722QMFCoeffs[i]
+				sumEven += e.x[2*i+1] * g722QMFCoeffs[11-i]
+			}
+			xlow = (sumEven + sumOdd) >> 14
+			xhigh = (sumEven - sumOdd) >> 14
+		}
+
+		// lower band: SUBTRA, QUANTL, INVQAL
+		low := &e.band[0]
+		el := saturate16(xlow - low.s)
+		wd := el
+		if el < 0 {
+			wd = -(el + 1)
+		}
+		i := 1
+		for ; i < 30; i++ {
+			if wd < (g722Q6[i]*low.det)>>12 {
+				break
+			}
+		}
+		ilow := g722ILP[i]
+		if el < 0 {
+			ilow = g722ILN[i]
+		}
+		ril := ilow >> 2
+		dlow := (low.det * g722QM4[ril]) >> 15
+		low.scaleLow(g722RL42[ril])
+		low.update(dlow)
+
+		if e.eightK {
+			out = append(out, byte(0xC0|ilow))
+			continue
+		}
+
+		// higher band: SUBTRA, QUANTH, INVQAH
+		high := &e.band[1]
+		eh := saturate16(xhigh - high.s)
+		wd = eh
+		if eh < 0 {
+			wd = -(eh + 1)
+		}
+		mih := 1
+		if wd >= (564*high.det)>>12 {
+			mih = 2
+		}
+		ihigh := g722IHP[mih]
+		if eh < 0 {
+			ihigh = g722IHN[mih]
+		}
+		dhigh := (high.det * g722QM2[ihigh]) >> 15
+		high.scaleHigh(g722RH2[ihigh])
+		high.update(dhigh)
+
+		out = append(out, byte(ihigh<<6|ilow))
+	}
+	return out
+}
+
+// G722Decoder decodes G.722 at 64 kbit/s to linear PCM.
+type G722Decoder struct {
+	eightK bool
+	band   [2]g722Band
+	x      [24]int
+}
+
+// NewG722Decoder returns a decoder to 16 kHz PCM, or to 8 kHz PCM of the
+// lower band alone when eightK is set.
+func NewG722Decoder(eightK bool) *G722Decoder {
+	return &G722Decoder{eightK: eightK, band: newG722Bands()}
+}
+
+// Decode returns the samples of the coded bytes, two per byte at 16 kHz and
+// one per byte at 8 kHz.
+func (d *G722Decoder) Decode(data []byte) []int16 {
+	samples := 2
+	if d.eightK {
+		samples = 1
+	}
+	out := make([]int16, 0, len(data)*samples)
+	for _, code := range data {
+		// lower band: INVQBL, RECONS, LIMIT
+		low := &d.band[0]
+		wd1 := int(code) & 0x3F
+		ihigh := int(code>>6) & 0x03
+		rlow := min(max(low.s+(low.det*g722QM6[wd1])>>15, -16384), 16383)
+
+		// INVQAL, LOGSCL, SCALEL
+		ril := wd1 >> 2
+		dlow := (low.det * g722QM4[ril]) >> 15
+		low.scaleLow(g722RL42[ril])
+		low.update(dlow)
+
+		if d.eightK {
+			out = append(out, int16(rlow<<1))
+			continue
+		}
+
+		// higher band: INVQAH, RECONS, LIMIT, LOGSCH, SCALEH
+		high := &d.band[1]
+		dhigh := (high.det * g722QM2[ihigh]) >> 15
+		rhigh := min(max(dhigh+high.s, -16384), 16383)
+		high.scaleHigh(g722RH2[ihigh])
+		high.update(dhigh)
+
+		// receive QMF
+		copy(d.x[:22], d.x[2:])
+		d.x[22] = rlow + rhigh
+		d.x[23] = rlow - rhigh
+		xout1, xout2 := 0, 0
+		for i := 0; i < 12; i++ {
+			xout2 += d.x[2*i] * g722QMFCoeffs[i]
+			xout1 += d.x[2*i+1] * g722QMFCoeffs[11-i]
+		}
+		out = append(out, int16(saturate16(xout1>>11)), int16(saturate16(xout2>>11)))
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sine(n, rate int, freq, amplitude float64) []int16 {
+	pcm := make([]int16, n)
+	for i := range pcm {
+		pcm[i] = int16(amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(rate)))
+	}
+	return pcm
+}
+
+// bestSNR compares decoded to the original at the codec delay that matches
+// best, in dB.
+func bestSNR(original, decoded []int16, maxDelay int) float64 {
+	best := math.Inf(-1)
+	for delay := 0; delay <= maxDelay; delay++ {
+		var signal, noise float64
+		for i := len(original) / 2; i < len(original)-delay; i++ {
+			s := float64(original[i])
+			e := float64(decoded[i+delay]) - s
+			signal += s * s
+			noise += e * e
+		}
+		best = math.Max(best, 10*math.Log10(signal/math.Max(noise, 1)))
+	}
+	return best
+}
+
+func TestG722_WidebandRoundTrip(t *testing.T) {
+	pcm := sine(3200, 16000, 1000, 8000)
+	coded := NewG722Encoder(false).Encode(pcm)
+	assert.Len(t, coded, len(pcm)/2, "one byte per pair of 16 kHz samples")
+
+	decoded := NewG722Decoder(false).Decode(coded)
+	require.Len(t, decoded, len(pcm))
+	assert.Greater(t, bestSNR(pcm, decoded, 40), 20.0)
+}
+
+func TestG722_HigherBandRoundTrip(t *testing.T) {
+	pcm := sine(3200, 16000, 6000, 6000)
+	decoded := NewG722Decoder(false).Decode(NewG722Encoder(false).Encode(pcm))
+	assert.Greater(t, bestSNR(pcm, decoded, 40), 10.0, "6 kHz is carried by the higher band")
+}
+
+func TestG722_NarrowbandRoundTrip(t *testing.T) {
+	pcm := sine(1600, 8000, 440, 8000)
+	coded := NewG722Encoder(true).Encode(pcm)
+	assert.Len(t, coded, len(pcm))
+
+	decoded := NewG722Decoder(true).Decode(coded)
+	require.Len(t, decoded, len(pcm))
+	assert.Greater(t, bestSNR(pcm, decoded, 4), 20.0)
+
+	wideband := NewG722Decoder(false).Decode(coded)
+	assert.Len(t, wideband, 2*len(pcm), "a wideband decoder plays the narrowband stream")
+}
+
+func TestG722_Silence(t *testing.T) {
+	decoded := NewG722Decoder(false).Decode(NewG722Encoder(false).Encode(make([]int16, 640)))
+	for _, s := range decoded {
+		assert.InDelta(t, 0, s, 16)
+	}
+}
+
+func TestTranscoder_G722Frames(t *testing.T) {
+	ulaw := encodeG711(sine(160, 8000, 440, 8000), &CodecPCMU)
+
+	encoder, err := newFrameEncoder(&CodecG722)
+	require.NoError(t, err)
+	decoder, err := newFrameDecoder(&CodecG722)
+	require.NoError(t, err)
+
+	payload := encoder.Encode(ulaw)
+	assert.Len(t, payload, 160, "20ms of G.722 is 160 bytes")
+	frame, err := decoder.Decode(payload)
+	require.NoError(t, err)
+	assert.Len(t, frame, 160)
+
+	for _, codec := range []*Codec{&CodecPCMU, &CodecPCMA} {
+		encoder, err := newFrameEncoder(codec)
+		assert.NoError(t, err)
+		assert.Nil(t, encoder, "G.711 is carried as is")
+	}
+}
+
+func TestTranscode_TranscodedLegsCarryUlaw(t *testing.T) {
+	ulaw := []byte{0xFF, 0x7F, 0x10}
+	assert.Equal(t, ulaw, transcode(ulaw, &CodecG722, &CodecPCMU))
+	assert.Equal(t, transcode(ulaw, &CodecPCMU, &CodecPCMA), transcode(ulaw, &CodecG722, &CodecPCMA))
+	assert.Equal(t, ulaw, transcode(ulaw, &CodecG729, &CodecG722))
+}
+
+func TestRTPHandler_G722(t *testing.T) {
+	h := bridgeLeg(t, CodecG722)
+	assert.Equal(t, "G722", h.GetCodec().Name)
+
+	ulaw := encodeG711(sine(160, 8000, 440, 8000), &CodecPCMU)
+	first := h.createRTPPacket(ulaw)
+	second := h.createRTPPacket(ulaw)
+	assert.Equal(t, CodecG722.PayloadType, first.PayloadType)
+	assert.Len(t, first.Payload, 160)
+	assert.Equal(t, first.Timestamp+160, second.Timestamp)
+
+	frame, err := h.decodePayload(CodecG722.PayloadType, first.Payload, h.GetCodec())
+	require.NoError(t, err)
+	assert.Len(t, frame, 160, "AudioIn carries µ-law")
+
+	// a G.711 packet arriving around a codec switch is passed on as µ-law
+	frame, err = h.decodePayload(CodecPCMA.PayloadType, []byte{0xD5, 0xD5}, h.GetCodec())
+	require.NoError(t, err)
+	assert.Equal(t, transcode([]byte{0xD5, 0xD5}, &CodecPCMA, &CodecPCMU), frame)
+}
+
+func TestRTPHandler_G722ToPCMU(t *testing.T) {
+	h := bridgeLeg(t, CodecG722)
+	h.SetCodec(&CodecPCMU)
+
+	packet := h.createRTPPacket([]byte{1, 2, 3})
+	assert.Equal(t, CodecPCMU.PayloadType, packet.PayloadType)
+	assert.Equal(t, []byte{1, 2, 3}, packet.Payload, "no encoder after switching to G.711")
+}
+
+func TestSDP_OffersG722(t *testing.T) {
+	s := &Server{}
+	sdp := s.GenerateSDP(DefaultSDPConfig("10.0.0.1", 10000))
+	assert.Contains(t, sdp, "a=rtpmap:9 G722/8000\r\n")
+	if g729Available {
+		assert.Contains(t, sdp, "a=rtpmap:18 G729/8000\r\na=fmtp:18 annexb=no\r\n")
+	} else {
+		assert.False(t, strings.Contains(sdp, "G729"), "G.729 is only offered when compiled in")
+	}
+
+	info, err := s.ParseSDP([]byte("v=0\r\nc=IN IP4 10.0.0.2\r\nm=audio 20000 RTP/AVP 9 101\r\na=rtpmap:9 G722/8000\r\n"))
+	require.NoError(t, err)
+	require.NotNil(t, info.PreferredCodec)
+	assert.Equal(t, "G722", info.PreferredCodec.Name, "a trunk offering only G.722 is accepted")
+}
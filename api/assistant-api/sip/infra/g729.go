@@ -0,0 +1,108 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+//go:build g729
+
+package sip_infra
+
+// G.729 is patent and license encumbered in some jurisdictions, so it is only
+// compiled in with the g729 build tag. It binds libbcg729, which has to be
+// installed on the build host:
+//
+//	go build -tags g729 ./...
+
+/*
+#cgo LDFLAGS: -lbcg729
+#include <stdint.h>
+#include <bcg729/encoder.h>
+#include <bcg729/decoder.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+const g729Available = true
+
+// g729Encoder codes 8 kHz linear PCM as G.729, without Annex B silence
+// suppression.
+type g729Encoder struct {
+	ctx *C.bcg729EncoderChannelContextStruct
+}
+
+func newG729Encoder() (*g729Encoder, error) {
+	ctx := C.initBcg729EncoderChannel(0)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to create G.729 encoder")
+	}
+	return &g729Encoder{ctx: ctx}, nil
+}
+
+// Encode codes whole 10ms frames of pcm, trailing samples are dropped.
+func (e *g729Encoder) Encode(pcm []int16) []byte {
+	out := make([]byte, 0, len(pcm)/g729FrameSamples*g729FrameBytes)
+	var frame [g729FrameBytes]C.uint8_t
+	for i := 0; i+g729FrameSamples <= len(pcm); i += g729FrameSamples {
+		var n C.uint8_t
+		C.bcg729Encoder(e.ctx, (*C.int16_t)(unsafe.Pointer(&pcm[i])), &frame[0], &n)
+		for _, b := range frame[:n] {
+			out = append(out, byte(b))
+		}
+	}
+	return out
+}
+
+func (e *g729Encoder) Close() {
+	if e.ctx != nil {
+		C.closeBcg729EncoderChannel(e.ctx)
+		e.ctx = nil
+	}
+}
+
+// g729Decoder decodes G.729 and Annex B comfort noise frames to 8 kHz
+// linear PCM.
+type g729Decoder struct {
+	ctx *C.bcg729DecoderChannelContextStruct
+}
+
+func newG729Decoder() (*g729Decoder, error) {
+	ctx := C.initBcg729DecoderChannel()
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to create G.729 decoder")
+	}
+	return &g729Decoder{ctx: ctx}, nil
+}
+
+// Decode decodes an RTP payload of G.729 frames, optionally followed by a
+// comfort noise frame (RFC 3551 section 4.5.6).
+func (d *g729Decoder) Decode(payload []byte) ([]int16, error) {
+	if len(payload)%g729FrameBytes != 0 && len(payload)%g729FrameBytes != g729SIDBytes {
+		return nil, fmt.Errorf("G.729 payload of %d bytes is not a whole number of frames", len(payload))
+	}
+	out := make([]int16, 0, (len(payload)/g729FrameBytes+1)*g729FrameSamples)
+	var pcm [g729FrameSamples]C.int16_t
+	for len(payload) > 0 {
+		size, sid := g729FrameBytes, C.uint8_t(0)
+		if len(payload) == g729SIDBytes {
+			size, sid = g729SIDBytes, 1
+		}
+		C.bcg729Decoder(d.ctx, (*C.uint8_t)(unsafe.Pointer(&payload[0])), C.uint8_t(size), 0, sid, 0, &pcm[0])
+		for _, s := range pcm {
+			out = append(out, int16(s))
+		}
+		payload = payload[size:]
+	}
+	return out, nil
+}
+
+func (d *g729Decoder) Close() {
+	if d.ctx != nil {
+		C.closeBcg729DecoderChannel(d.ctx)
+		d.ctx = nil
+	}
+}
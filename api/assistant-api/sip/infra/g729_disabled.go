@@ -0,0 +1,33 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+//go:build !g729
+
+package sip_infra
+
+import "errors"
+
+// Without the g729 build tag G.729 is neither offered nor accepted, see
+// g729.go.
+const g729Available = false
+
+var errG729Unavailable = errors.New("G.729 support is not compiled in, build with -tags g729")
+
+type g729Encoder struct{}
+
+func newG729Encoder() (*g729Encoder, error) { return nil, errG729Unavailable }
+
+func (e *g729Encoder) Encode(pcm []int16) []byte { return nil }
+
+func (e *g729Encoder) Close() {}
+
+type g729Decoder struct{}
+
+func newG729Decoder() (*g729Decoder, error) { return nil, errG729Unavailable }
+
+func (d *g729Decoder) Decode(payload []byte) ([]int16, error) { return nil, errG729Unavailable }
+
+func (d *g729Decoder) Close() {}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/emiago/sipgo/sip"
+)
+
+// holdTimeout bounds the re-INVITE putting a call on hold or resuming it.
+const holdTimeout = 10 * time.Second
+
+// HoldCall puts the remote party on hold from this side, or resumes the
+// call, with a re-INVITE offering the media sendonly or sendrecv (RFC 3264
+// §8.4). Session refreshes and re-INVITE answers keep the direction while
+// the call is held. Providers play nothing on their side, the hold music is
+// up to the caller of HoldCall.
+func (s *Server) HoldCall(session *Session, held bool) error {
+	callID := session.GetCallID()
+	if state := session.GetState(); state != CallStateConnected && state != CallStateOnHold {
+		return fmt.Errorf("call %s is %s", callID, state)
+	}
+	dialog, ok := s.dialogOf(session)
+	if !ok {
+		return fmt.Errorf("call %s has no dialog", callID)
+	}
+	if session.localHold.Swap(held) == held {
+		return nil
+	}
+
+	req := sip.NewRequest(sip.INVITE, *dialog.target.Clone())
+	req.AppendHeader(sip.NewHeader("Content-Type", "application/sdp"))
+	req.SetBody([]byte(s.GenerateSDP(s.currentSDPConfig(session))))
+
+	ctx, cancel := context.WithTimeout(session.Context(), holdTimeout)
+	defer cancel()
+	res, err := dialog.do(ctx, req)
+	if err == nil && !res.IsSuccess() {
+		err = fmt.Errorf("re-INVITE answered %d %s", res.StatusCode, res.Reason)
+	}
+	if err != nil {
+		session.localHold.Store(!held)
+		return fmt.Errorf("hold of call %s: %w", callID, err)
+	}
+	dialog.ack(s, session)
+
+	s.logger.Infow("SIP call hold changed by this side", "call_id", callID, "held", held)
+	return nil
+}
+
+// LocalHold reports whether this side holds the call, see HoldCall.
+func (s *Session) LocalHold() bool {
+	return s.localHold.Load()
+}
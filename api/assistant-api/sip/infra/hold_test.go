@@ -0,0 +1,49 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentSDPConfig_LocalHold(t *testing.T) {
+	s := &Server{}
+	session := testSession(t)
+	session.SetLocalRTP("10.0.0.1", 10000)
+
+	info, err := s.ParseSDP([]byte(s.GenerateSDP(s.currentSDPConfig(session))))
+	require.NoError(t, err)
+	assert.Equal(t, SDPDirectionSendRecv, info.Direction)
+
+	session.localHold.Store(true)
+	info, err = s.ParseSDP([]byte(s.GenerateSDP(s.currentSDPConfig(session))))
+	require.NoError(t, err)
+	assert.Equal(t, SDPDirectionSendOnly, info.Direction)
+	assert.True(t, info.IsHold())
+}
+
+func TestHoldCall_WithoutDialog(t *testing.T) {
+	s := &Server{}
+	session := testSession(t)
+	session.SetState(CallStateConnected)
+
+	assert.Error(t, s.HoldCall(session, true))
+	assert.False(t, session.LocalHold(), "the hold is only kept once the remote party accepted it")
+}
+
+func TestCaptureDialog_LocalHold(t *testing.T) {
+	s := &Server{}
+	session := testSession(t)
+	session.SetState(CallStateConnected)
+	session.localHold.Store(true)
+
+	_, err := s.CaptureDialog(session)
+	assert.ErrorIs(t, err, ErrNotMigratable)
+}
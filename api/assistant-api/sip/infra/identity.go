@@ -0,0 +1,71 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/emiago/sipgo/sip"
+)
+
+// IdentityHeaders selects the headers asserting the caller identity of
+// outbound INVITEs. Trunks trusting rapida take the presented name and number
+// from them rather than from the From header.
+type IdentityHeaders string
+
+const (
+	// IdentityHeadersNone sends the identity in the From header only
+	IdentityHeadersNone IdentityHeaders = "none"
+	// IdentityHeadersPAI adds P-Asserted-Identity (RFC 3325)
+	IdentityHeadersPAI IdentityHeaders = "pai"
+	// IdentityHeadersRPID adds Remote-Party-ID, still the only one some
+	// older carriers and PBXs read
+	IdentityHeadersRPID IdentityHeaders = "rpid"
+	// IdentityHeadersBoth adds P-Asserted-Identity and Remote-Party-ID
+	IdentityHeadersBoth IdentityHeaders = "both"
+)
+
+// ParseIdentityHeaders maps a configured value to IdentityHeaders, unknown or
+// empty values fall back to IdentityHeadersNone.
+func ParseIdentityHeaders(value string) IdentityHeaders {
+	switch IdentityHeaders(strings.ToLower(strings.TrimSpace(value))) {
+	case IdentityHeadersPAI:
+		return IdentityHeadersPAI
+	case IdentityHeadersRPID:
+		return IdentityHeadersRPID
+	case IdentityHeadersBoth:
+		return IdentityHeadersBoth
+	}
+	return IdentityHeadersNone
+}
+
+// CallerName cleans a configured caller name for use as a display name.
+// sipgo writes display names quoted as given, so quotes, backslashes and
+// control characters are dropped.
+func CallerName(name string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == '"' || r == '\\' || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+}
+
+// identityHeaders returns the headers asserting the identity of from, the
+// From header of an outbound INVITE, as selected by mode.
+func identityHeaders(mode IdentityHeaders, from *sip.FromHeader) []sip.Header {
+	identity := (&sip.FromHeader{DisplayName: from.DisplayName, Address: from.Address}).Value()
+	var headers []sip.Header
+	if mode == IdentityHeadersPAI || mode == IdentityHeadersBoth {
+		headers = append(headers, sip.NewHeader("P-Asserted-Identity", identity))
+	}
+	if mode == IdentityHeadersRPID || mode == IdentityHeadersBoth {
+		headers = append(headers, sip.NewHeader("Remote-Party-ID", identity+";party=calling;screen=yes;privacy=off"))
+	}
+	return headers
+}
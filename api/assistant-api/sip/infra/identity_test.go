@@ -0,0 +1,52 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+
+	"github.com/emiago/sipgo/sip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIdentityHeaders(t *testing.T) {
+	assert.Equal(t, IdentityHeadersPAI, ParseIdentityHeaders(" PAI"))
+	assert.Equal(t, IdentityHeadersRPID, ParseIdentityHeaders("rpid"))
+	assert.Equal(t, IdentityHeadersBoth, ParseIdentityHeaders("both"))
+	assert.Equal(t, IdentityHeadersNone, ParseIdentityHeaders(""))
+	assert.Equal(t, IdentityHeadersNone, ParseIdentityHeaders("x-asserted"))
+}
+
+func TestCallerName(t *testing.T) {
+	assert.Equal(t, "Rapida Clinic", CallerName(" Rapida Clinic "))
+	assert.Equal(t, "Acme Inc", CallerName("\"Acme\\ Inc\"\r\n"))
+	assert.Empty(t, CallerName("\"\""))
+}
+
+func TestIdentityHeaders(t *testing.T) {
+	from := &sip.FromHeader{
+		DisplayName: "Rapida Clinic",
+		Address:     sip.Uri{Scheme: "sip", User: "+14155550100", Host: "trunk.example.com"},
+		Params:      sip.NewParams(),
+	}
+	from.Params.Add("tag", "abc")
+
+	assert.Empty(t, identityHeaders(IdentityHeadersNone, from))
+
+	headers := identityHeaders(IdentityHeadersBoth, from)
+	if assert.Len(t, headers, 2) {
+		assert.Equal(t, "P-Asserted-Identity", headers[0].Name())
+		assert.Equal(t, `"Rapida Clinic" <sip:+14155550100@trunk.example.com>`, headers[0].Value())
+		assert.Equal(t, "Remote-Party-ID", headers[1].Name())
+		assert.Equal(t, `"Rapida Clinic" <sip:+14155550100@trunk.example.com>;party=calling;screen=yes;privacy=off`, headers[1].Value())
+	}
+
+	headers = identityHeaders(IdentityHeadersPAI, from)
+	if assert.Len(t, headers, 1) {
+		assert.Equal(t, "P-Asserted-Identity", headers[0].Name())
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/emiago/sipgo"
+	"github.com/emiago/sipgo/sip"
+)
+
+// migrationTimeout bounds the re-INVITE moving the media of a call to this
+// instance.
+const migrationTimeout = 10 * time.Second
+
+// ErrNotMigratable is returned for calls whose dialog or media can not be
+// continued by another instance.
+var ErrNotMigratable = errors.New("SIP call can not be migrated")
+
+// RTPContinuity is where the RTP stream we send stands. The instance taking
+// a call over carries on with it, so the remote party sees one stream
+// instead of a new source.
+type RTPContinuity struct {
+	SSRC      uint32    `json:"ssrc"`
+	Sequence  uint16    `json:"sequence"`
+	Timestamp uint32    `json:"timestamp"`
+	At        time.Time `json:"at"`
+}
+
+// Continuity captures the RTP stream sent so far.
+func (h *RTPHandler) Continuity() RTPContinuity {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return RTPContinuity{SSRC: h.ssrc, Sequence: h.sequenceNumber, Timestamp: h.timestamp, At: time.Now()}
+}
+
+// Continue carries on with a stream captured by Continuity. The timestamp
+// advances by the time passed since the capture, the remote jitter buffer
+// then plays the first packet after the gap instead of before it.
+func (h *RTPHandler) Continue(c RTPContinuity) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ssrc = c.SSRC
+	h.sequenceNumber = c.Sequence
+	h.timestamp = c.Timestamp
+	if elapsed := time.Since(c.At); !c.At.IsZero() && elapsed > 0 {
+		h.timestamp += uint32(elapsed.Seconds() * float64(h.codec.ClockRate))
+	}
+}
+
+// DialogState is what another instance needs to take a call over: the
+// dialog it continues, the codec and remote media it answers and the RTP
+// stream it carries on.
+type DialogState struct {
+	CallID    string        `json:"callId"`
+	Direction CallDirection `json:"direction"`
+
+	// Invite and Answer are the INVITE and the 2xx that set the dialog up,
+	// as they went over the wire. CSeq is the last sequence number we sent.
+	Invite string `json:"invite"`
+	Answer string `json:"answer"`
+	CSeq   uint32 `json:"cseq"`
+
+	Codec                     string        `json:"codec"`
+	TelephoneEventPayloadType uint8         `json:"telephoneEventPayloadType"`
+	RemoteRTPAddress          string        `json:"remoteRtpAddress"`
+	RemoteRTPPort             int           `json:"remoteRtpPort"`
+	RTP                       RTPContinuity `json:"rtp"`
+
+	SessionExpires time.Duration `json:"sessionExpires,omitempty"`
+	ConnectedAt    *time.Time    `json:"connectedAt,omitempty"`
+}
+
+// CaptureDialog captures the dialog and media of a connected call for
+// another instance to take over with AdoptDialog. Calls on hold on either
+// side, calls with SRTP and calls without a dialog can not be captured.
+func (s *Server) CaptureDialog(session *Session) (*DialogState, error) {
+	callID := session.GetCallID()
+	if session.GetState() != CallStateConnected {
+		return nil, fmt.Errorf("%w: call %s is %s", ErrNotMigratable, callID, session.GetState())
+	}
+	if session.LocalHold() {
+		return nil, fmt.Errorf("%w: call %s is held by the assistant", ErrNotMigratable, callID)
+	}
+	rtpHandler := session.GetRTPHandler()
+	if rtpHandler == nil {
+		return nil, fmt.Errorf("%w: call %s has no media", ErrNotMigratable, callID)
+	}
+	rtpHandler.mu.RLock()
+	secure := rtpHandler.srtp != nil
+	rtpHandler.mu.RUnlock()
+	if secure {
+		// the keys and rollover counter of the stream stay on this instance
+		return nil, fmt.Errorf("%w: call %s is encrypted with SRTP", ErrNotMigratable, callID)
+	}
+
+	var (
+		invite *sip.Request
+		answer *sip.Response
+		cseq   uint32
+	)
+	switch {
+	case session.GetDialogClientSession() != nil:
+		ds := session.GetDialogClientSession()
+		invite, answer, cseq = ds.InviteRequest, ds.InviteResponse, ds.CSEQ()
+	case session.GetDialogServerSession() != nil:
+		ds := session.GetDialogServerSession()
+		invite, answer, cseq = ds.InviteRequest, ds.InviteResponse, ds.CSEQ()
+	case session.getMigratedDialog() != nil:
+		d := session.getMigratedDialog()
+		invite, answer, cseq = d.invite, d.answer, d.cseq.Load()
+	}
+	if invite == nil || answer == nil || !answer.IsSuccess() {
+		return nil, fmt.Errorf("%w: call %s has no confirmed dialog", ErrNotMigratable, callID)
+	}
+
+	info := session.GetInfo()
+	state := &DialogState{
+		CallID:                    callID,
+		Direction:                 info.Direction,
+		Invite:                    invite.String(),
+		Answer:                    answer.String(),
+		CSeq:                      cseq,
+		Codec:                     codecName(session.GetNegotiatedCodec()),
+		TelephoneEventPayloadType: rtpHandler.TelephoneEventPayloadType(),
+		RTP:                       rtpHandler.Continuity(),
+		ConnectedAt:               info.ConnectedTime,
+	}
+	if remote := rtpHandler.GetRemoteAddr(); remote != nil {
+		state.RemoteRTPAddress, state.RemoteRTPPort = remote.IP.String(), remote.Port
+	}
+	state.SessionExpires, _ = session.GetSessionTimer()
+	return state, nil
+}
+
+// HandOver lets go of a call another instance took over. No BYE is sent,
+// the dialog goes on over there; the media stops here right away so the
+// remote party only hears the new instance.
+func (s *Server) HandOver(session *Session) {
+	session.markHandedOver()
+	if rtpHandler := session.GetRTPHandler(); rtpHandler != nil {
+		if err := rtpHandler.Stop(); err != nil {
+			s.logger.Warnw("Error stopping RTP handler of handed over call", "error", err, "call_id", session.GetCallID())
+		}
+	}
+	s.removeSession(session.GetCallID())
+	s.logger.Infow("SIP call handed over to another instance", "call_id", session.GetCallID())
+}
+
+// AdoptDialog takes over a call captured by CaptureDialog on another
+// instance. Media is set up here and the remote party is sent a re-INVITE
+// pointing it and the dialog at this instance. The session returned is
+// connected; no onInvite is fired, the caller resumes the conversation.
+func (s *Server) AdoptDialog(ctx context.Context, state *DialogState, cfg *SessionConfig) (*Session, error) {
+	callID := state.CallID
+	dialog, err := newMigratedDialog(state, s.contact())
+	if err != nil {
+		return nil, err
+	}
+
+	codec := GetCodecByName(state.Codec)
+	if codec == nil {
+		codec = &CodecPCMU
+	}
+	sessionCfg := *cfg
+	sessionCfg.CallID = callID
+	sessionCfg.Direction = state.Direction
+	sessionCfg.Codec = codec
+	if sessionCfg.Logger == nil {
+		sessionCfg.Logger = s.logger
+	}
+	session, err := NewSession(s.ctx, &sessionCfg)
+	if err != nil {
+		return nil, err
+	}
+	session.setMigratedDialog(dialog)
+	session.SetRemoteEndpoint(dialog.invite)
+
+	rtpPort, err := s.rtpAllocator.Allocate()
+	if err != nil {
+		return nil, fmt.Errorf("no RTP port for migrated call %s: %w", callID, err)
+	}
+	rtpHandler, err := NewRTPHandler(s.ctx, &RTPConfig{
+		LocalIP:     s.listenConfig.GetBindAddress(),
+		LocalPort:   rtpPort,
+		PayloadType: codec.PayloadType,
+		ClockRate:   codec.ClockRate,
+		Logger:      s.logger,
+	})
+	if err != nil {
+		s.rtpAllocator.Release(rtpPort)
+		return nil, err
+	}
+	if state.RemoteRTPAddress != "" && state.RemoteRTPPort > 0 {
+		rtpHandler.SetRemoteAddr(state.RemoteRTPAddress, state.RemoteRTPPort)
+		session.SetRemoteRTP(state.RemoteRTPAddress, state.RemoteRTPPort)
+	}
+	if state.TelephoneEventPayloadType != 0 {
+		rtpHandler.SetTelephoneEventPayloadType(state.TelephoneEventPayloadType)
+	}
+	if tenant := sessionCfg.Config; tenant != nil {
+		if tenant.Concealment {
+			rtpHandler.EnableConcealment()
+		}
+		if tenant.ComfortNoise != 0 {
+			rtpHandler.EnableComfortNoise(tenant.ComfortNoise)
+		}
+	}
+
+	_, localPort := rtpHandler.LocalAddr()
+	externalIP := s.listenConfig.GetExternalIP()
+	session.SetLocalRTP(externalIP, localPort)
+	session.SetNegotiatedCodec(codec.Name, int(codec.ClockRate))
+	session.SetRTPHandler(rtpHandler)
+
+	// Registered before the re-INVITE, a BYE may follow its answer closely
+	s.mu.Lock()
+	s.sessions[callID] = session
+	s.sessionCount.Add(1)
+	s.mu.Unlock()
+
+	answer, err := s.reinviteMigrated(ctx, session, dialog)
+	if err != nil {
+		s.removeSession(callID)
+		session.End()
+		return nil, err
+	}
+	if sdpInfo, err := s.ParseSDP(answer.Body()); err == nil {
+		s.applyRemoteSDP(session, sdpInfo, "migration")
+	}
+
+	// the stream the remote party heard goes on from here
+	rtpHandler.Continue(state.RTP)
+	rtpHandler.Start()
+	session.SetState(CallStateConnected)
+	if state.SessionExpires > 0 {
+		session.SetSessionTimer(state.SessionExpires, true)
+		s.startSessionTimer(session)
+	}
+	session.SetOnDisconnect(func(sess *Session) {
+		if err := s.EndCall(sess); err != nil {
+			s.logger.Warnw("onDisconnect: EndCall failed", "error", err, "call_id", callID)
+		}
+	})
+
+	s.logger.Infow("SIP call migrated to this instance",
+		"call_id", callID,
+		"direction", state.Direction,
+		"local_rtp", fmt.Sprintf("%s:%d", externalIP, localPort),
+		"codec", codec.Name)
+	return session, nil
+}
+
+// reinviteMigrated sends the re-INVITE moving the dialog and the media of a
+// migrated call here and acknowledges its answer.
+func (s *Server) reinviteMigrated(ctx context.Context, session *Session, dialog *migratedDialog) (*sip.Response, error) {
+	localIP, localPort := session.GetLocalRTP()
+	sdpBody := s.GenerateSDP(withRedundancyOffer(s.NegotiatedSDPConfig(localIP, localPort, session.GetNegotiatedCodec()), session.GetRTPHandler()))
+
+	req := dialog.newRequest(sip.INVITE)
+	req.AppendHeader(sip.NewHeader("Content-Type", "application/sdp"))
+	req.SetBody([]byte(sdpBody))
+
+	ctx, cancel := context.WithTimeout(ctx, migrationTimeout)
+	defer cancel()
+	res, err := s.client.Do(ctx, req, withVia)
+	if err != nil {
+		return nil, fmt.Errorf("re-INVITE of migrated call %s: %w", session.GetCallID(), err)
+	}
+	if !res.IsSuccess() {
+		return nil, fmt.Errorf("re-INVITE of migrated call %s answered %d %s", session.GetCallID(), res.StatusCode, res.Reason)
+	}
+	if err := s.client.WriteRequest(dialog.newRequest(sip.ACK), withVia); err != nil {
+		s.logger.Warnw("Failed to ACK re-INVITE of migrated call", "call_id", session.GetCallID(), "error", err)
+	}
+	return res, nil
+}
+
+// doMigrated sends an in-dialog request of a migrated call and returns its
+// final response.
+func (s *Server) doMigrated(ctx context.Context, dialog *migratedDialog, req *sip.Request) (*sip.Response, error) {
+	return s.client.Do(ctx, dialog.build(req), withVia)
+}
+
+// contact is the Contact this instance is reached at for in-dialog requests.
+func (s *Server) contact() sip.ContactHeader {
+	return sip.ContactHeader{
+		Address: sip.Uri{
+			Scheme: "sip",
+			Host:   s.listenConfig.GetExternalIP(),
+			Port:   s.listenConfig.Port,
+		},
+	}
+}
+
+// withVia keeps the dialog headers of a request and only adds what the
+// transport needs, like sipgo does for its own dialogs.
+func withVia(c *sipgo.Client, req *sip.Request) error {
+	if req.Via() == nil {
+		if err := sipgo.ClientRequestAddVia(c, req); err != nil {
+			return err
+		}
+	}
+	if req.Body() == nil {
+		req.SetBody(nil)
+	}
+	return nil
+}
+
+// migratedDialog is a dialog another instance set up and this one took
+// over. Requests are built from its INVITE and 2xx the way sipgo builds
+// them for the dialogs it set up itself (RFC 3261 §12.2.1.1).
+type migratedDialog struct {
+	invite  *sip.Request
+	answer  *sip.Response
+	uac     bool // we sent the INVITE
+	contact sip.ContactHeader
+	cseq    atomic.Uint32
+}
+
+func newMigratedDialog(state *DialogState, contact sip.ContactHeader) (*migratedDialog, error) {
+	inviteMsg, err := sip.ParseMessage([]byte(state.Invite))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid INVITE of call %s: %v", ErrNotMigratable, state.CallID, err)
+	}
+	answerMsg, err := sip.ParseMessage([]byte(state.Answer))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid answer of call %s: %v", ErrNotMigratable, state.CallID, err)
+	}
+	invite, ok := inviteMsg.(*sip.Request)
+	if !ok {
+		return nil, fmt.Errorf("%w: INVITE of call %s is not a request", ErrNotMigratable, state.CallID)
+	}
+	answer, ok := answerMsg.(*sip.Response)
+	if !ok {
+		return nil, fmt.Errorf("%w: answer of call %s is not a response", ErrNotMigratable, state.CallID)
+	}
+	d := &migratedDialog{
+		invite:  invite,
+		answer:  answer,
+		uac:     state.Direction == CallDirectionOutbound,
+		contact: contact,
+	}
+	d.cseq.Store(state.CSeq)
+	return d, nil
+}
+
+// target is where in-dialog requests go, the Contact of the remote party.
+func (d *migratedDialog) target() sip.Uri {
+	if d.uac {
+		if contact := d.answer.Contact(); contact != nil {
+			return *contact.Address.Clone()
+		}
+		return *d.invite.Recipient.Clone()
+	}
+	if contact := d.invite.Contact(); contact != nil {
+		return *contact.Address.Clone()
+	}
+	return *d.invite.From().Address.Clone()
+}
+
+// newRequest starts an in-dialog request to the remote party.
+func (d *migratedDialog) newRequest(method sip.RequestMethod) *sip.Request {
+	return d.build(sip.NewRequest(method, d.target()))
+}
+
+// build adds the dialog headers to req. ACK reuses the sequence number of
+// the INVITE it acknowledges, every other request takes the next one.
+func (d *migratedDialog) build(req *sip.Request) *sip.Request {
+	if d.uac {
+		req.AppendHeader(sip.HeaderClone(d.invite.From()))
+		req.AppendHeader(sip.HeaderClone(d.answer.To()))
+	} else {
+		from := d.answer.To().AsFrom()
+		to := d.invite.From().AsTo()
+		req.AppendHeader(&from)
+		req.AppendHeader(&to)
+	}
+	req.AppendHeader(sip.HeaderClone(d.invite.CallID()))
+	maxForwards := sip.MaxForwardsHeader(70)
+	req.AppendHeader(&maxForwards)
+
+	seq := d.cseq.Load()
+	if !req.IsAck() {
+		seq = d.cseq.Add(1)
+	}
+	req.AppendHeader(&sip.CSeqHeader{SeqNo: seq, MethodName: req.Method})
+	if req.Method == sip.INVITE || req.Method == sip.UPDATE {
+		req.AppendHeader(d.contact.Clone())
+	}
+
+	for _, route := range d.routeSet() {
+		req.AppendHeader(sip.NewHeader("Route", route.Value()))
+	}
+	if route := req.Route(); route != nil {
+		req.SetDestination(route.Address.HostPort())
+	}
+	req.SetTransport(d.invite.Transport())
+	return req
+}
+
+// routeSet is the Record-Route of the dialog in the order requests take,
+// reversed for the side that sent the INVITE.
+func (d *migratedDialog) routeSet() []sip.Header {
+	if !d.uac {
+		return d.invite.GetHeaders("Record-Route")
+	}
+	recorded := d.answer.GetHeaders("Record-Route")
+	routes := make([]sip.Header, len(recorded))
+	for i, route := range recorded {
+		routes[len(recorded)-1-i] = route
+	}
+	return routes
+}
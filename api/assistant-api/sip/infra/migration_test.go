@@ -0,0 +1,117 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiago/sipgo/sip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sipMessage(lines ...string) string {
+	return strings.Join(lines, "\r\n") + "\r\n\r\n"
+}
+
+var (
+	migratedInvite = sipMessage(
+		"INVITE sip:assistant@10.0.0.1:5060 SIP/2.0",
+		"Via: SIP/2.0/UDP 192.0.2.10:5060;branch=z9hG4bK776asdhds",
+		"Record-Route: <sip:proxy1.example.com;lr>",
+		"Record-Route: <sip:proxy2.example.com;lr>",
+		"From: <sip:caller@example.com>;tag=caller-tag",
+		"To: <sip:assistant@10.0.0.1>",
+		"Call-ID: migrated-call",
+		"CSeq: 1 INVITE",
+		"Contact: <sip:caller@192.0.2.10:5060>",
+		"Content-Length: 0",
+	)
+	migratedAnswer = sipMessage(
+		"SIP/2.0 200 OK",
+		"Via: SIP/2.0/UDP 192.0.2.10:5060;branch=z9hG4bK776asdhds",
+		"Record-Route: <sip:proxy1.example.com;lr>",
+		"Record-Route: <sip:proxy2.example.com;lr>",
+		"From: <sip:caller@example.com>;tag=caller-tag",
+		"To: <sip:assistant@10.0.0.1>;tag=assistant-tag",
+		"Call-ID: migrated-call",
+		"CSeq: 1 INVITE",
+		"Contact: <sip:assistant@10.0.0.1:5060>",
+		"Content-Length: 0",
+	)
+)
+
+func testMigratedDialog(t *testing.T, direction CallDirection) *migratedDialog {
+	t.Helper()
+	d, err := newMigratedDialog(&DialogState{
+		CallID:    "migrated-call",
+		Direction: direction,
+		Invite:    migratedInvite,
+		Answer:    migratedAnswer,
+		CSeq:      3,
+	}, sip.ContactHeader{Address: sip.Uri{Scheme: "sip", Host: "10.0.0.2", Port: 5060}})
+	require.NoError(t, err)
+	return d
+}
+
+func TestMigratedDialog_InboundRequests(t *testing.T) {
+	d := testMigratedDialog(t, CallDirectionInbound)
+
+	invite := d.newRequest(sip.INVITE)
+	assert.Equal(t, "caller", invite.Recipient.User)
+	assert.Equal(t, "192.0.2.10", invite.Recipient.Host)
+	tag, _ := invite.From().Params.Get("tag")
+	assert.Equal(t, "assistant-tag", tag)
+	tag, _ = invite.To().Params.Get("tag")
+	assert.Equal(t, "caller-tag", tag)
+	assert.Equal(t, "migrated-call", invite.CallID().Value())
+	assert.Equal(t, uint32(4), invite.CSeq().SeqNo)
+	assert.Equal(t, "10.0.0.2", invite.Contact().Address.Host)
+
+	routes := invite.GetHeaders("Route")
+	require.Len(t, routes, 2)
+	assert.Contains(t, routes[0].Value(), "proxy1.example.com")
+
+	ack := d.newRequest(sip.ACK)
+	assert.Equal(t, uint32(4), ack.CSeq().SeqNo, "ACK takes the sequence number of its INVITE")
+	assert.Nil(t, ack.Contact())
+
+	assert.Equal(t, uint32(5), d.newRequest(sip.BYE).CSeq().SeqNo)
+}
+
+func TestMigratedDialog_OutboundRequests(t *testing.T) {
+	d := testMigratedDialog(t, CallDirectionOutbound)
+
+	bye := d.newRequest(sip.BYE)
+	assert.Equal(t, "10.0.0.1", bye.Recipient.Host, "requests go to the Contact of the answer")
+	tag, _ := bye.From().Params.Get("tag")
+	assert.Equal(t, "caller-tag", tag)
+	tag, _ = bye.To().Params.Get("tag")
+	assert.Equal(t, "assistant-tag", tag)
+
+	routes := bye.GetHeaders("Route")
+	require.Len(t, routes, 2)
+	assert.Contains(t, routes[0].Value(), "proxy2.example.com", "the caller reverses the recorded route")
+}
+
+func TestNewMigratedDialog_Invalid(t *testing.T) {
+	_, err := newMigratedDialog(&DialogState{CallID: "x", Invite: migratedAnswer, Answer: migratedAnswer}, sip.ContactHeader{})
+	assert.ErrorIs(t, err, ErrNotMigratable)
+}
+
+func TestRTPHandler_Continue(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMU)
+	h.Continue(RTPContinuity{SSRC: 42, Sequence: 1000, Timestamp: 8000, At: time.Now().Add(-time.Second)})
+
+	c := h.Continuity()
+	assert.Equal(t, uint32(42), c.SSRC)
+	assert.Equal(t, uint16(1000), c.Sequence)
+	// a second passed since the capture, 8000 samples at 8kHz
+	assert.InDelta(t, 16000, c.Timestamp, 400)
+}
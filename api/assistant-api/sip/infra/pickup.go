@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rapidaai/protos"
+)
+
+const (
+	// DefaultPickupPrefix is the feature code dialed before a pickup code,
+	// *8 is directed call pickup on most PBXes and desk phones.
+	DefaultPickupPrefix = "*8"
+
+	// pickup codes are four digits so they fit a desk phone's speed dial
+	minPickupCode = 1000
+	maxPickupCode = 9999
+)
+
+// PickupHandler takes a parked call over for the agent who dialed its code.
+// agent is the answered session of the agent's phone, the handler bridges it
+// to the caller. An error ends the agent's call.
+type PickupHandler func(agent *Session) error
+
+type parkedCall struct {
+	session *Session
+	handler PickupHandler
+}
+
+// Pickup keeps the calls waiting to be picked up by a human agent. Each is
+// given a short numeric code, an agent on a registered phone dials the
+// prefix and the code (e.g. *81042) to take the call over.
+type Pickup struct {
+	mu     sync.Mutex
+	prefix string
+	next   int
+	calls  map[string]*parkedCall
+}
+
+// NewPickup creates an empty pickup dialed with prefix, DefaultPickupPrefix
+// when empty.
+func NewPickup(prefix string) *Pickup {
+	if prefix == "" {
+		prefix = DefaultPickupPrefix
+	}
+	return &Pickup{
+		prefix: prefix,
+		next:   minPickupCode,
+		calls:  make(map[string]*parkedCall),
+	}
+}
+
+// Park makes the session's call available for pickup and returns its code.
+// handler runs once when an agent picks the call up.
+func (p *Pickup) Park(session *Session, handler PickupHandler) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for range maxPickupCode - minPickupCode + 1 {
+		code := strconv.Itoa(p.next)
+		if p.next++; p.next > maxPickupCode {
+			p.next = minPickupCode
+		}
+		if _, taken := p.calls[code]; !taken {
+			p.calls[code] = &parkedCall{session: session, handler: handler}
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("no pickup code available")
+}
+
+// Unpark withdraws the call parked under code, a no-op when it was picked
+// up already.
+func (p *Pickup) Unpark(code string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.calls, code)
+}
+
+// Code returns the pickup code a dialed user part asks for.
+func (p *Pickup) Code(user string) (string, bool) {
+	code, ok := strings.CutPrefix(user, p.prefix)
+	if !ok || code == "" {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(code, 10, 32); err != nil {
+		return "", false
+	}
+	return code, true
+}
+
+// lookup returns the call parked under code without claiming it.
+func (p *Pickup) lookup(code string) (*parkedCall, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call, ok := p.calls[code]
+	return call, ok
+}
+
+// claim removes the call parked under code, only one agent gets it.
+func (p *Pickup) claim(code string) (*parkedCall, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call, ok := p.calls[code]
+	if ok {
+		delete(p.calls, code)
+	}
+	return call, ok
+}
+
+// samePickupTenant reports whether a phone registered through binding may
+// pick up call: both must have been resolved to the same vault credential,
+// which is the SIP trunk of one project.
+func samePickupTenant(binding *Binding, call *parkedCall) bool {
+	if binding == nil || binding.Route == nil || call.session == nil {
+		return false
+	}
+	registered, _ := binding.Route.Extra["vault_credential"].(*protos.VaultCredential)
+	parked := call.session.GetVaultCredential()
+	if registered == nil || parked == nil {
+		return false
+	}
+	return registered.GetId() == parked.GetId()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickup_Code(t *testing.T) {
+	p := NewPickup("")
+	code, ok := p.Code("*81042")
+	assert.True(t, ok)
+	assert.Equal(t, "1042", code)
+
+	for _, user := range []string{"*8", "1042", "*81042a", "+14155550100"} {
+		_, ok := p.Code(user)
+		assert.False(t, ok, user)
+	}
+
+	code, ok = NewPickup("pickup-").Code("pickup-1042")
+	assert.True(t, ok)
+	assert.Equal(t, "1042", code)
+}
+
+func TestPickup_ParkAndClaim(t *testing.T) {
+	p := NewPickup("")
+	session := testSession(t)
+
+	picked := 0
+	first, err := p.Park(session, func(agent *Session) error { picked++; return nil })
+	require.NoError(t, err)
+	second, err := p.Park(session, func(agent *Session) error { return nil })
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, first, 4)
+
+	call, ok := p.claim(first)
+	require.True(t, ok)
+	require.NoError(t, call.handler(testSession(t)))
+	assert.Equal(t, 1, picked)
+
+	// a call is only picked up once
+	_, ok = p.claim(first)
+	assert.False(t, ok)
+
+	p.Unpark(second)
+	_, ok = p.lookup(second)
+	assert.False(t, ok)
+}
+
+func TestPickup_CodesWrap(t *testing.T) {
+	p := NewPickup("")
+	p.next = maxPickupCode
+	last, err := p.Park(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "9999", last)
+
+	// 1000 is still parked, the next free code is handed out
+	p.calls["1000"] = &parkedCall{}
+	code, err := p.Park(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1001", code)
+}
+
+func TestSamePickupTenant(t *testing.T) {
+	parkedSession, err := NewSession(context.Background(), &SessionConfig{
+		Config:          testSession(t).config,
+		Direction:       CallDirectionInbound,
+		VaultCredential: &protos.VaultCredential{Id: 7},
+	})
+	require.NoError(t, err)
+	call := &parkedCall{session: parkedSession}
+
+	binding := func(id uint64) *Binding {
+		return &Binding{Route: AllowWithExtra(&Config{}, map[string]interface{}{
+			"vault_credential": &protos.VaultCredential{Id: id},
+		})}
+	}
+	assert.True(t, samePickupTenant(binding(7), call))
+	assert.False(t, samePickupTenant(binding(8), call))
+	assert.False(t, samePickupTenant(&Binding{Route: Allow(&Config{})}, call))
+	assert.False(t, samePickupTenant(nil, call))
+}
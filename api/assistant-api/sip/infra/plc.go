@@ -0,0 +1,250 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"math"
+
+	"github.com/zaf/g711"
+)
+
+// Packet loss concealment after ITU-T G.711 Appendix I: a lost frame is
+// synthesized by repeating the last pitch period of the received speech,
+// attenuated the longer the loss lasts, and the first frame after the loss is
+// faded in over the synthesized signal.
+const (
+	plcMinPitch       = 40  // 200 Hz at 8 kHz
+	plcMaxPitch       = 120 // 66 Hz
+	plcCorrelation    = 160 // samples compared for the pitch estimate
+	plcHistorySamples = plcMaxPitch + plcCorrelation
+
+	plcFullGainSamples = 80  // the first 10ms are repeated at full level
+	plcMuteSamples     = 480 // and the signal is silent after 60ms
+	plcFadeInSamples   = 32  // overlap with the first received frame
+
+	// plcMaxGapPackets bounds the losses that are repaired. Longer gaps are
+	// outages or a paused stream and are passed on as they are.
+	plcMaxGapPackets = 10
+)
+
+// lossRepair fills the gaps in the audio received from the remote party
+// before it reaches AudioIn. A lost frame is recovered from the RED blocks of
+// the packet that follows it when the remote party sends redundancy, and
+// otherwise synthesized by the concealer when concealment is enabled. It is
+// only used by receiveLoop.
+type lossRepair struct {
+	initialized bool
+	ssrc        uint32
+	lastSeq     uint16
+
+	concealer concealer
+}
+
+// advance records the sequence number of a received packet. It returns the
+// number of packets lost right before it, and false for a late or duplicate
+// packet whose slot has already been played out.
+func (r *lossRepair) advance(ssrc uint32, seq uint16) (int, bool) {
+	if !r.initialized || ssrc != r.ssrc {
+		r.initialized = true
+		r.ssrc = ssrc
+		r.lastSeq = seq
+		r.concealer.reset()
+		return 0, true
+	}
+	delta := seq - r.lastSeq
+	if delta == 0 || delta >= 1<<15 {
+		return 0, false
+	}
+	r.lastSeq = seq
+	if int(delta)-1 > plcMaxGapPackets {
+		return 0, true
+	}
+	return int(delta) - 1, true
+}
+
+// repair returns the frames to deliver for a received audio frame that
+// follows lost packets: the recovered or concealed lost frames, oldest
+// first, then the frame itself. Redundant blocks are matched to the lost
+// frames by their timestamp offset, one frame apart each.
+func (r *lossRepair) repair(lost int, frame []byte, redundant []redBlock, codec *Codec, conceal bool) [][]byte {
+	frames := make([][]byte, 0, lost+1)
+	for distance := lost; distance > 0; distance-- {
+		if recovered := recoverFrame(redundant, uint32(distance*len(frame)), codec); recovered != nil {
+			frames = append(frames, recovered)
+			r.concealer.received(decodeG711(recovered, codec))
+			continue
+		}
+		if conceal {
+			frames = append(frames, encodeG711(r.concealer.conceal(len(frame)), codec))
+		}
+	}
+	pcm := decodeG711(frame, codec)
+	if r.concealer.received(pcm) {
+		frame = encodeG711(pcm, codec)
+	}
+	return append(frames, frame)
+}
+
+// recoverFrame returns the redundant copy of the frame offset samples before
+// the primary, in the codec of the stream.
+func recoverFrame(redundant []redBlock, offset uint32, codec *Codec) []byte {
+	for _, block := range redundant {
+		if block.TimestampOffset != offset {
+			continue
+		}
+		// redundant copies of stateful codecs cannot be decoded out of order
+		blockCodec := GetCodecByPayloadType(block.PayloadType)
+		if blockCodec == nil || isTranscoded(blockCodec) {
+			return nil
+		}
+		return transcode(block.Payload, blockCodec, codec)
+	}
+	return nil
+}
+
+// concealer synthesizes lost speech from the history of the received
+// signal.
+type concealer struct {
+	history []int16
+
+	// state of the loss in progress
+	lost   int // samples synthesized so far
+	pitch  int
+	period []int16
+	phase  int
+}
+
+func (c *concealer) reset() {
+	*c = concealer{}
+}
+
+// received adds a received frame to the history. When it ends a loss its
+// start is faded in over the continued synthetic signal, in place, and true
+// is returned.
+func (c *concealer) received(pcm []int16) bool {
+	faded := false
+	if c.lost > 0 {
+		n := min(plcFadeInSamples, len(pcm))
+		synthetic := c.synthesize(n)
+		for i := 0; i < n; i++ {
+			w := float64(i+1) / float64(n+1)
+			pcm[i] = clampSample(w*float64(pcm[i]) + (1-w)*float64(synthetic[i]))
+		}
+		faded = true
+	}
+	c.lost = 0
+	c.period = nil
+
+	c.history = append(c.history, pcm...)
+	if excess := len(c.history) - plcHistorySamples; excess > 0 {
+		c.history = append(c.history[:0], c.history[excess:]...)
+	}
+	return faded
+}
+
+// conceal returns n samples standing in for a lost frame. Without enough
+// history to estimate a pitch it returns silence.
+func (c *concealer) conceal(n int) []int16 {
+	if len(c.history) < plcHistorySamples {
+		return make([]int16, n)
+	}
+	if c.period == nil {
+		c.pitch = estimatePitch(c.history)
+		c.period = c.history[len(c.history)-c.pitch:]
+		c.phase = 0
+	}
+	return c.synthesize(n)
+}
+
+// synthesize continues the repeated pitch period by n samples.
+func (c *concealer) synthesize(n int) []int16 {
+	out := make([]int16, n)
+	if c.period == nil {
+		return out
+	}
+	for i := range out {
+		out[i] = clampSample(float64(c.period[c.phase]) * concealmentGain(c.lost))
+		c.phase = (c.phase + 1) % c.pitch
+		c.lost++
+	}
+	return out
+}
+
+// concealmentGain attenuates the synthetic signal linearly from full level
+// after the first 10ms of a loss to silence at 60ms.
+func concealmentGain(lost int) float64 {
+	switch {
+	case lost < plcFullGainSamples:
+		return 1
+	case lost >= plcMuteSamples:
+		return 0
+	}
+	return 1 - float64(lost-plcFullGainSamples)/float64(plcMuteSamples-plcFullGainSamples)
+}
+
+// estimatePitch returns the period, in samples, that best aligns the end of
+// the history with itself by normalized cross-correlation.
+func estimatePitch(history []int16) int {
+	end := len(history)
+	best, bestScore := plcMinPitch, math.Inf(-1)
+	for pitch := plcMinPitch; pitch <= plcMaxPitch; pitch++ {
+		var corr, energy float64
+		for i := end - plcCorrelation; i < end; i++ {
+			lagged := float64(history[i-pitch])
+			corr += float64(history[i]) * lagged
+			energy += lagged * lagged
+		}
+		if energy == 0 {
+			continue
+		}
+		if score := corr / math.Sqrt(energy); score > bestScore {
+			best, bestScore = pitch, score
+		}
+	}
+	return best
+}
+
+func clampSample(v float64) int16 {
+	return int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(v))))
+}
+
+// decodeG711 expands a G.711 frame to linear PCM samples.
+func decodeG711(frame []byte, codec *Codec) []int16 {
+	pcm := make([]int16, len(frame))
+	for i, b := range frame {
+		if codec.Name == CodecPCMA.Name {
+			pcm[i] = g711.DecodeAlawFrame(b)
+		} else {
+			pcm[i] = g711.DecodeUlawFrame(b)
+		}
+	}
+	return pcm
+}
+
+// encodeG711 compresses linear PCM samples to a G.711 frame.
+func encodeG711(pcm []int16, codec *Codec) []byte {
+	frame := make([]byte, len(pcm))
+	for i, s := range pcm {
+		if codec.Name == CodecPCMA.Name {
+			frame[i] = g711.EncodeAlawFrame(s)
+		} else {
+			frame[i] = g711.EncodeUlawFrame(s)
+		}
+	}
+	return frame
+}
+
+// EnableConcealment synthesizes the frames lost in transit from the received
+// speech instead of leaving gaps in the audio passed to AudioIn.
+func (h *RTPHandler) EnableConcealment() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.concealment = true
+	if h.logger != nil {
+		h.logger.Infow("RTP packet loss concealment enabled")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toneFrame returns 20ms of a 100 Hz tone (a period of 80 samples) starting
+// at sample start.
+func toneFrame(start int) []int16 {
+	pcm := make([]int16, 160)
+	for i := range pcm {
+		pcm[i] = int16(8000 * math.Sin(2*math.Pi*float64(start+i)/80))
+	}
+	return pcm
+}
+
+func TestLossRepair_Advance(t *testing.T) {
+	var r lossRepair
+	lost, ok := r.advance(1, 65534)
+	assert.True(t, ok)
+	assert.Zero(t, lost)
+
+	lost, ok = r.advance(1, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, lost, "65535 and 0 are missing across the wrap")
+
+	_, ok = r.advance(1, 0)
+	assert.False(t, ok, "late packet")
+	_, ok = r.advance(1, 1)
+	assert.False(t, ok, "duplicate")
+
+	lost, ok = r.advance(1, 500)
+	assert.True(t, ok)
+	assert.Zero(t, lost, "outages are not repaired")
+
+	lost, ok = r.advance(2, 7)
+	assert.True(t, ok)
+	assert.Zero(t, lost, "a new source starts over")
+}
+
+func TestEstimatePitch(t *testing.T) {
+	history := append(toneFrame(0), toneFrame(160)...)
+	assert.Equal(t, 80, estimatePitch(history[len(history)-plcHistorySamples:]))
+}
+
+func TestConcealer_ContinuesAndFades(t *testing.T) {
+	var c concealer
+	assert.Equal(t, make([]int16, 160), c.conceal(160), "no history yet")
+	c.reset()
+
+	c.received(toneFrame(0))
+	c.received(toneFrame(160))
+
+	// the synthetic signal continues the tone
+	concealed := c.conceal(160)
+	expected := toneFrame(320)
+	for i := 0; i < plcFullGainSamples; i++ {
+		assert.InDelta(t, expected[i], concealed[i], 1)
+	}
+	assert.Less(t, math.Abs(float64(concealed[159])), math.Abs(float64(expected[159]))+1, "attenuated after 10ms")
+
+	// and dies out after 60ms
+	c.conceal(160)
+	c.conceal(160)
+	assert.Equal(t, make([]int16, 160), c.conceal(160))
+
+	// the next received frame is faded in
+	frame := toneFrame(800)
+	original := append([]int16(nil), frame...)
+	assert.True(t, c.received(frame))
+	assert.NotEqual(t, original[:plcFadeInSamples], frame[:plcFadeInSamples])
+	assert.Equal(t, original[plcFadeInSamples:], frame[plcFadeInSamples:])
+	assert.False(t, c.received(toneFrame(960)))
+}
+
+func TestLossRepair_RecoversFromRedundancy(t *testing.T) {
+	var r lossRepair
+	r.advance(1, 10)
+	r.repair(0, encodeG711(toneFrame(0), &CodecPCMU), nil, &CodecPCMU, false)
+
+	lostFrame := encodeG711(toneFrame(160), &CodecPCMU)
+	frame := encodeG711(toneFrame(320), &CodecPCMU)
+	lost, ok := r.advance(1, 12)
+	require.True(t, ok)
+
+	frames := r.repair(lost, frame, []redBlock{{PayloadType: CodecPCMA.PayloadType, TimestampOffset: 160, Payload: transcode(lostFrame, &CodecPCMU, &CodecPCMA)}}, &CodecPCMU, false)
+	require.Len(t, frames, 2)
+	assert.Equal(t, transcode(transcode(lostFrame, &CodecPCMU, &CodecPCMA), &CodecPCMA, &CodecPCMU), frames[0])
+	assert.Equal(t, frame, frames[1])
+}
+
+func TestLossRepair_ConcealsWithoutRedundancy(t *testing.T) {
+	var r lossRepair
+	for i := 0; i < 2; i++ {
+		r.advance(1, uint16(i))
+		r.repair(0, encodeG711(toneFrame(i*160), &CodecPCMU), nil, &CodecPCMU, true)
+	}
+
+	lost, ok := r.advance(1, 4)
+	require.True(t, ok)
+	frames := r.repair(lost, encodeG711(toneFrame(640), &CodecPCMU), nil, &CodecPCMU, true)
+	require.Len(t, frames, 3, "two concealed frames and the received one")
+	for _, frame := range frames {
+		assert.Len(t, frame, 160)
+	}
+
+	frames = r.repair(2, encodeG711(toneFrame(800), &CodecPCMU), nil, &CodecPCMU, false)
+	assert.Len(t, frames, 1, "gaps are left as they are without concealment")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// CodecRED is RFC 2198 redundant audio. Its payload type is dynamic: 121 is
+// what we offer, an answer or offer from the remote party may map red/8000
+// to any other.
+var CodecRED = Codec{Name: "red", PayloadType: 121, ClockRate: 8000, Channels: 1}
+
+// RFC 2198 block header limits, the timestamp offset has 14 bits and the
+// block length 10.
+const (
+	redMaxTimestampOffset = 1<<14 - 1
+	redMaxBlockLength     = 1<<10 - 1
+)
+
+// redBlock is one encoding carried by a RED packet. The primary block has no
+// timestamp offset, redundant blocks are earlier frames.
+type redBlock struct {
+	PayloadType     uint8
+	TimestampOffset uint32
+	Payload         []byte
+}
+
+// encodeRED builds an RFC 2198 payload of the redundant blocks, oldest first,
+// followed by the primary block.
+//
+//	redundant header: F=1 | block PT (7) | timestamp offset (14) | length (10)
+//	primary header:   F=0 | block PT (7)
+func encodeRED(redundant []redBlock, primary redBlock) []byte {
+	size := 1 + len(primary.Payload)
+	for _, block := range redundant {
+		size += 4 + len(block.Payload)
+	}
+	data := make([]byte, 0, size)
+	for _, block := range redundant {
+		header := 1<<31 | uint32(block.PayloadType&0x7F)<<24 | block.TimestampOffset<<10 | uint32(len(block.Payload))
+		data = binary.BigEndian.AppendUint32(data, header)
+	}
+	data = append(data, primary.PayloadType&0x7F)
+	for _, block := range redundant {
+		data = append(data, block.Payload...)
+	}
+	return append(data, primary.Payload...)
+}
+
+// decodeRED splits an RFC 2198 payload into its redundant blocks, oldest
+// first, and the primary block.
+func decodeRED(payload []byte) ([]redBlock, redBlock, error) {
+	var redundant []redBlock
+	offset := 0
+	for {
+		if offset >= len(payload) {
+			return nil, redBlock{}, fmt.Errorf("RED payload truncated in block headers")
+		}
+		if payload[offset]&0x80 == 0 {
+			break
+		}
+		if offset+4 > len(payload) {
+			return nil, redBlock{}, fmt.Errorf("RED payload truncated in block headers")
+		}
+		header := binary.BigEndian.Uint32(payload[offset:])
+		redundant = append(redundant, redBlock{
+			PayloadType:     uint8(header>>24) & 0x7F,
+			TimestampOffset: header >> 10 & redMaxTimestampOffset,
+			Payload:         make([]byte, header&redMaxBlockLength),
+		})
+		offset += 4
+	}
+	primary := redBlock{PayloadType: payload[offset] & 0x7F}
+	offset++
+
+	for i := range redundant {
+		n := len(redundant[i].Payload)
+		if offset+n > len(payload) {
+			return nil, redBlock{}, fmt.Errorf("RED block of %d bytes exceeds payload", n)
+		}
+		copy(redundant[i].Payload, payload[offset:offset+n])
+		offset += n
+	}
+	primary.Payload = make([]byte, len(payload)-offset)
+	copy(primary.Payload, payload[offset:])
+	return redundant, primary, nil
+}
+
+// EnableRedundancy sends each audio frame together with a copy of the
+// previous one as RFC 2198 packets of payload type pt, and recovers lost
+// frames from the redundancy the remote party sends back. pt is the payload
+// type both sides mapped red/8000 to, zero stops redundancy.
+func (h *RTPHandler) EnableRedundancy(pt uint8) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.redPayloadType = pt
+	h.redPrevious = nil
+	if h.logger != nil {
+		h.logger.Infow("RTP redundancy updated", "payload_type", pt)
+	}
+}
+
+// RedundancyPayloadType returns the RED payload type in use, zero when audio
+// is sent without redundancy.
+func (h *RTPHandler) RedundancyPayloadType() uint8 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.redPayloadType
+}
+
+// withRedundancy wraps an outgoing audio frame into a RED packet carrying the
+// previous frame as well. Called with h.mu held, before the stream clock
+// advances past the frame.
+func (h *RTPHandler) withRedundancy(packet *RTPPacket) {
+	if h.redPayloadType == 0 {
+		return
+	}
+	primary := redBlock{PayloadType: packet.PayloadType, Payload: packet.Payload}
+
+	var redundant []redBlock
+	if previous := h.redPrevious; previous != nil {
+		offset := packet.Timestamp - h.redPreviousTimestamp
+		if offset > 0 && offset <= redMaxTimestampOffset && len(previous.Payload) <= redMaxBlockLength {
+			redundant = []redBlock{{PayloadType: previous.PayloadType, TimestampOffset: offset, Payload: previous.Payload}}
+		}
+	}
+	h.redPrevious = &primary
+	h.redPreviousTimestamp = packet.Timestamp
+
+	packet.PayloadType = h.redPayloadType
+	packet.Payload = encodeRED(redundant, primary)
+}
+
+// withRedundancyOffer advertises RED in cfg when the RTP handler of the
+// session sends it, so answers to re-INVITEs and refreshes keep it. The
+// telephone-event payload type is kept the same way.
+func withRedundancyOffer(cfg *SDPConfig, handler *RTPHandler) *SDPConfig {
+	if handler != nil {
+		cfg.REDPayloadType = handler.RedundancyPayloadType()
+		cfg.TelephoneEventPayloadType = handler.TelephoneEventPayloadType()
+	}
+	return cfg
+}
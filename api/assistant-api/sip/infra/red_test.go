@@ -0,0 +1,104 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRED_RoundTrip(t *testing.T) {
+	redundant := []redBlock{
+		{PayloadType: 0, TimestampOffset: 320, Payload: []byte{1, 2, 3}},
+		{PayloadType: 8, TimestampOffset: 160, Payload: []byte{4, 5}},
+	}
+	primary := redBlock{PayloadType: 0, Payload: []byte{6, 7, 8, 9}}
+
+	data := encodeRED(redundant, primary)
+	assert.Len(t, data, 2*4+1+3+2+4)
+
+	gotRedundant, gotPrimary, err := decodeRED(data)
+	require.NoError(t, err)
+	assert.Equal(t, redundant, gotRedundant)
+	assert.Equal(t, primary, gotPrimary)
+}
+
+func TestRED_PrimaryOnly(t *testing.T) {
+	redundant, primary, err := decodeRED(encodeRED(nil, redBlock{PayloadType: 8, Payload: []byte{1}}))
+	require.NoError(t, err)
+	assert.Empty(t, redundant)
+	assert.Equal(t, redBlock{PayloadType: 8, Payload: []byte{1}}, primary)
+}
+
+func TestRED_DecodeRejectsTruncated(t *testing.T) {
+	data := encodeRED([]redBlock{{TimestampOffset: 160, Payload: []byte{1, 2, 3}}}, redBlock{Payload: []byte{4}})
+
+	_, _, err := decodeRED(data[:3])
+	assert.Error(t, err, "header cut short")
+	_, _, err = decodeRED(data[:6])
+	assert.Error(t, err, "redundant block longer than the payload")
+	_, _, err = decodeRED(nil)
+	assert.Error(t, err)
+}
+
+func TestRTPHandler_RedundancyCarriesPreviousFrame(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMU)
+	h.EnableRedundancy(121)
+
+	first := h.createRTPPacket([]byte{1, 1})
+	second := h.createRTPPacket([]byte{2, 2})
+
+	assert.Equal(t, uint8(121), first.PayloadType)
+	redundant, primary, err := decodeRED(first.Payload)
+	require.NoError(t, err)
+	assert.Empty(t, redundant, "nothing to repeat yet")
+	assert.Equal(t, []byte{1, 1}, primary.Payload)
+
+	assert.Equal(t, first.Timestamp+2, second.Timestamp, "the clock advances by the primary frame only")
+	redundant, primary, err = decodeRED(second.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, []redBlock{{PayloadType: CodecPCMU.PayloadType, TimestampOffset: 2, Payload: []byte{1, 1}}}, redundant)
+	assert.Equal(t, redBlock{PayloadType: CodecPCMU.PayloadType, Payload: []byte{2, 2}}, primary)
+
+	h.EnableRedundancy(0)
+	plain := h.createRTPPacket([]byte{3})
+	assert.Equal(t, CodecPCMU.PayloadType, plain.PayloadType)
+	assert.Equal(t, []byte{3}, plain.Payload)
+}
+
+func TestSDP_RedundancyOfferAndParse(t *testing.T) {
+	s := &Server{}
+	cfg := DefaultSDPConfig("10.0.0.1", 10000)
+	cfg.Codecs = []Codec{CodecPCMU, CodecPCMA}
+	cfg.REDPayloadType = CodecRED.PayloadType
+	sdp := s.GenerateSDP(cfg)
+
+	assert.Contains(t, sdp, "m=audio 10000 RTP/AVP 121 0 8 101\r\n")
+	assert.Contains(t, sdp, "a=rtpmap:121 red/8000\r\n")
+	assert.Contains(t, sdp, "a=fmtp:121 0/0\r\n")
+
+	info, err := s.ParseSDP([]byte(strings.ReplaceAll(sdp, "121", "99")))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(99), info.REDPayloadType)
+	assert.Equal(t, CodecPCMU.Name, info.PreferredCodec.Name, "RED is not an audio codec")
+
+	info, err = s.ParseSDP([]byte(s.GenerateSDP(DefaultSDPConfig("10.0.0.1", 10000))))
+	require.NoError(t, err)
+	assert.Zero(t, info.REDPayloadType)
+}
+
+func TestParseFlag(t *testing.T) {
+	assert.True(t, ParseFlag(true))
+	assert.True(t, ParseFlag(" true"))
+	assert.True(t, ParseFlag("1"))
+	assert.False(t, ParseFlag("no"))
+	assert.False(t, ParseFlag(1.0))
+	assert.False(t, ParseFlag(nil))
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emiago/sipgo/sip"
+	"github.com/icholy/digest"
+)
+
+const (
+	// DefaultRegisterExpires is the binding interval when a REGISTER asks for none.
+	DefaultRegisterExpires = time.Hour
+
+	// MinRegisterExpires is the shortest binding accepted, shorter requests
+	// are answered with 423 Interval Too Brief (RFC 3261 §10.3).
+	MinRegisterExpires = time.Minute
+
+	// MaxRegisterExpires caps a binding so a PBX that went away is forgotten.
+	MaxRegisterExpires = 2 * time.Hour
+
+	// nonceLifetime is how long a digest challenge can be answered.
+	nonceLifetime = 5 * time.Minute
+)
+
+// Binding is a contact a PBX registered to us as a trunk.
+type Binding struct {
+	AOR      string    // address of record, the To URI of the REGISTER
+	Username string    // digest username the PBX authenticated with
+	Contact  string    // URI the PBX takes requests on
+	Source   string    // host:port the REGISTER came from
+	Expires  time.Time // when the binding lapses unless refreshed
+
+	// Route is what the REGISTER resolved to. INVITEs of the PBX carry no
+	// credentials in their URI and are routed with it.
+	Route *InviteResult
+}
+
+// Registrar lets PBXes register to Rapida as a trunk (RFC 3261 §10).
+//
+// A REGISTER goes through the server's middleware chain like an INVITE, so its
+// To URI names the assistant and API key. The PBX then digest-authenticates
+// with the sip_username and sip_password of the vault credential the chain
+// resolved. Bindings live in memory until they expire or are removed.
+type Registrar struct {
+	mu       sync.Mutex
+	realm    string
+	bindings map[string]map[string]*Binding // AOR → contact → binding
+	nonces   map[string]time.Time           // issued nonce → expiry
+	now      func() time.Time
+}
+
+// NewRegistrar creates a registrar challenging in the given digest realm.
+func NewRegistrar(realm string) *Registrar {
+	return &Registrar{
+		realm:    realm,
+		bindings: make(map[string]map[string]*Binding),
+		nonces:   make(map[string]time.Time),
+		now:      time.Now,
+	}
+}
+
+// Register handles an out-of-dialog REGISTER whose route was resolved and
+// allowed by the middleware chain. It returns the response to send: a digest
+// challenge, a rejection, or 200 OK listing the AOR's current bindings.
+func (r *Registrar) Register(req *sip.Request, route *InviteResult) *sip.Response {
+	cfg := route.Config
+	if cfg == nil || cfg.Username == "" || cfg.Password == "" {
+		return sip.NewResponseFromRequest(req, 403, "No registration credentials provisioned", nil)
+	}
+
+	h := req.GetHeader("Authorization")
+	if h == nil {
+		return r.challenge(req, false)
+	}
+	cred, err := digest.ParseCredentials(h.Value())
+	if err != nil {
+		return sip.NewResponseFromRequest(req, 400, "Malformed Authorization", nil)
+	}
+	if !r.validNonce(cred.Nonce) {
+		return r.challenge(req, true)
+	}
+	if cred.Username != cfg.Username || !r.verify(cred, req.Method.String(), cfg.Password) {
+		return sip.NewResponseFromRequest(req, 403, "Forbidden", nil)
+	}
+
+	aor := req.To().Address.String()
+	requested, wildcard, err := registerExpires(req)
+	if err != nil {
+		return sip.NewResponseFromRequest(req, 400, err.Error(), nil)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if wildcard {
+		delete(r.bindings, aor)
+		return r.okLocked(req, aor, now)
+	}
+	for _, contact := range requested {
+		if contact.expires > 0 && contact.expires < MinRegisterExpires {
+			res := sip.NewResponseFromRequest(req, 423, "Interval Too Brief", nil)
+			res.AppendHeader(sip.NewHeader("Min-Expires", strconv.Itoa(int(MinRegisterExpires.Seconds()))))
+			return res
+		}
+	}
+	for _, contact := range requested {
+		if contact.expires == 0 {
+			r.removeLocked(aor, contact.uri)
+			continue
+		}
+		if r.bindings[aor] == nil {
+			r.bindings[aor] = make(map[string]*Binding)
+		}
+		r.bindings[aor][contact.uri] = &Binding{
+			AOR:      aor,
+			Username: cred.Username,
+			Contact:  contact.uri,
+			Source:   req.Source(),
+			Expires:  now.Add(min(contact.expires, MaxRegisterExpires)),
+			Route:    route,
+		}
+	}
+	return r.okLocked(req, aor, now)
+}
+
+// Match returns the binding of the registered PBX an INVITE comes from. The
+// INVITE must come from the host a binding registered from. When that host
+// registered several accounts, the one whose username is the INVITE's To
+// user is picked, an ambiguous INVITE matches none.
+func (r *Registrar) Match(req *sip.Request) (*Binding, bool) {
+	host := sourceHost(req.Source())
+	toUser := req.To().Address.User
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	var candidates []*Binding
+	for _, contacts := range r.bindings {
+		for _, binding := range contacts {
+			if binding.Expires.After(now) && sourceHost(binding.Source) == host {
+				candidates = append(candidates, binding)
+			}
+		}
+	}
+	for _, binding := range candidates {
+		if binding.Username == toUser {
+			return binding, true
+		}
+	}
+	if len(candidates) > 0 && sameAccount(candidates) {
+		return candidates[0], true
+	}
+	return nil, false
+}
+
+// Bindings returns the unexpired bindings of an address of record.
+func (r *Registrar) Bindings(aor string) []Binding {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.activeLocked(aor, r.now())
+}
+
+func (r *Registrar) challenge(req *sip.Request, stale bool) *sip.Response {
+	chal := digest.Challenge{
+		Realm:     r.realm,
+		Nonce:     r.issueNonce(),
+		Algorithm: "MD5",
+		QOP:       []string{"auth"},
+		Stale:     stale,
+	}
+	res := sip.NewResponseFromRequest(req, 401, "Unauthorized", nil)
+	res.AppendHeader(sip.NewHeader("WWW-Authenticate", chal.String()))
+	return res
+}
+
+func (r *Registrar) issueNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	nonce := hex.EncodeToString(b)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	for n, expiry := range r.nonces {
+		if !expiry.After(now) {
+			delete(r.nonces, n)
+		}
+	}
+	r.nonces[nonce] = now.Add(nonceLifetime)
+	return nonce
+}
+
+func (r *Registrar) validNonce(nonce string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	expiry, ok := r.nonces[nonce]
+	return ok && expiry.After(r.now())
+}
+
+// verify recomputes the digest response the PBX should have sent.
+func (r *Registrar) verify(cred *digest.Credentials, method, password string) bool {
+	chal := &digest.Challenge{Realm: r.realm, Nonce: cred.Nonce, Algorithm: cred.Algorithm}
+	if cred.QOP != "" {
+		chal.QOP = []string{cred.QOP}
+	}
+	expected, err := digest.Digest(chal, digest.Options{
+		Method:   method,
+		URI:      cred.URI,
+		Username: cred.Username,
+		Password: password,
+		Cnonce:   cred.Cnonce,
+		Count:    cred.Nc,
+	})
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected.Response), []byte(cred.Response)) == 1
+}
+
+func (r *Registrar) okLocked(req *sip.Request, aor string, now time.Time) *sip.Response {
+	res := sip.NewResponseFromRequest(req, 200, "OK", nil)
+	for _, binding := range r.activeLocked(aor, now) {
+		remaining := int(binding.Expires.Sub(now).Seconds())
+		res.AppendHeader(sip.NewHeader("Contact", "<"+binding.Contact+">;expires="+strconv.Itoa(remaining)))
+	}
+	res.AppendHeader(sip.NewHeader("Date", now.UTC().Format(time.RFC1123)))
+	return res
+}
+
+func (r *Registrar) activeLocked(aor string, now time.Time) []Binding {
+	var active []Binding
+	for contact, binding := range r.bindings[aor] {
+		if !binding.Expires.After(now) {
+			delete(r.bindings[aor], contact)
+			continue
+		}
+		active = append(active, *binding)
+	}
+	if len(r.bindings[aor]) == 0 {
+		delete(r.bindings, aor)
+	}
+	return active
+}
+
+func (r *Registrar) removeLocked(aor, contact string) {
+	delete(r.bindings[aor], contact)
+	if len(r.bindings[aor]) == 0 {
+		delete(r.bindings, aor)
+	}
+}
+
+// requestedContact is one Contact of a REGISTER with the interval asked for it.
+type requestedContact struct {
+	uri     string
+	expires time.Duration
+}
+
+// registerExpires reads the contacts of a REGISTER. A contact's expires
+// parameter wins over the Expires header, which wins over the default.
+// wildcard is set for "Contact: *", which is only valid with Expires: 0.
+func registerExpires(req *sip.Request) (contacts []requestedContact, wildcard bool, err error) {
+	fallback := DefaultRegisterExpires
+	if h := req.GetHeader("Expires"); h != nil {
+		seconds, err := strconv.Atoi(strings.TrimSpace(h.Value()))
+		if err != nil || seconds < 0 {
+			return nil, false, ErrInvalidExpires
+		}
+		fallback = time.Duration(seconds) * time.Second
+	}
+
+	for _, h := range req.GetHeaders("Contact") {
+		contact, ok := h.(*sip.ContactHeader)
+		if !ok {
+			continue
+		}
+		if contact.Address.Wildcard {
+			if fallback != 0 {
+				return nil, false, ErrInvalidExpires
+			}
+			return nil, true, nil
+		}
+		expires := fallback
+		if value, ok := contact.Params.Get("expires"); ok {
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return nil, false, ErrInvalidExpires
+			}
+			expires = time.Duration(seconds) * time.Second
+		}
+		contacts = append(contacts, requestedContact{uri: contact.Address.String(), expires: expires})
+	}
+	return contacts, false, nil
+}
+
+func sourceHost(source string) string {
+	host, _, err := net.SplitHostPort(source)
+	if err != nil {
+		return source
+	}
+	return host
+}
+
+func sameAccount(bindings []*Binding) bool {
+	for _, binding := range bindings[1:] {
+		if binding.AOR != bindings[0].AOR {
+			return false
+		}
+	}
+	return true
+}
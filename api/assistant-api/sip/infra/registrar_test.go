@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiago/sipgo/sip"
+	"github.com/icholy/digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAOR = "sip:asst-1:key@rapida.ai"
+
+func testRoute() *InviteResult {
+	return Allow(&Config{Username: "pbx", Password: "secret"})
+}
+
+func parseRequest(t *testing.T, method, to, source string, headers ...string) *sip.Request {
+	t.Helper()
+	raw := method + " sip:rapida.ai SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP 10.0.0.5:5060;branch=z9hG4bK-1\r\n" +
+		"From: <sip:pbx@10.0.0.5>;tag=abc\r\n" +
+		"To: <" + to + ">\r\n" +
+		"Call-ID: reg-1\r\n" +
+		"CSeq: 1 " + method + "\r\n" +
+		strings.Join(headers, "") +
+		"Content-Length: 0\r\n\r\n"
+	msg, err := sip.ParseMessage([]byte(raw))
+	require.NoError(t, err)
+	req := msg.(*sip.Request)
+	req.SetSource(source)
+	return req
+}
+
+// register answers the registrar's challenge like a PBX would.
+func register(t *testing.T, r *Registrar, password string, headers ...string) *sip.Response {
+	t.Helper()
+	res := r.Register(parseRequest(t, "REGISTER", testAOR, "10.0.0.5:5060", headers...), testRoute())
+	require.Equal(t, 401, res.StatusCode)
+
+	chal, err := digest.ParseChallenge(res.GetHeader("WWW-Authenticate").Value())
+	require.NoError(t, err)
+	cred, err := digest.Digest(chal, digest.Options{
+		Method:   "REGISTER",
+		URI:      "sip:rapida.ai",
+		Username: "pbx",
+		Password: password,
+		Cnonce:   "0a4f113b",
+		Count:    1,
+	})
+	require.NoError(t, err)
+
+	authorized := append(headers, "Authorization: "+cred.String()+"\r\n")
+	return r.Register(parseRequest(t, "REGISTER", testAOR, "10.0.0.5:5060", authorized...), testRoute())
+}
+
+func TestRegistrar_Register(t *testing.T) {
+	r := NewRegistrar("rapida.ai")
+
+	res := register(t, r, "secret", "Contact: <sip:pbx@10.0.0.5:5060>\r\n", "Expires: 600\r\n")
+	require.Equal(t, 200, res.StatusCode)
+	assert.Contains(t, res.GetHeader("Contact").Value(), "expires=600")
+
+	bindings := r.Bindings(testAOR)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "pbx", bindings[0].Username)
+	assert.Equal(t, "sip:pbx@10.0.0.5:5060", bindings[0].Contact)
+}
+
+func TestRegistrar_RejectsWrongPassword(t *testing.T) {
+	r := NewRegistrar("rapida.ai")
+
+	res := register(t, r, "guess", "Contact: <sip:pbx@10.0.0.5:5060>\r\n")
+	assert.Equal(t, 403, res.StatusCode)
+	assert.Empty(t, r.Bindings(testAOR))
+}
+
+func TestRegistrar_NoCredentialsProvisioned(t *testing.T) {
+	r := NewRegistrar("rapida.ai")
+
+	req := parseRequest(t, "REGISTER", testAOR, "10.0.0.5:5060", "Contact: <sip:pbx@10.0.0.5:5060>\r\n")
+	res := r.Register(req, Allow(&Config{}))
+	assert.Equal(t, 403, res.StatusCode)
+}
+
+func TestRegistrar_IntervalTooBrief(t *testing.T) {
+	r := NewRegistrar("rapida.ai")
+
+	res := register(t, r, "secret", "Contact: <sip:pbx@10.0.0.5:5060>;expires=10\r\n")
+	require.Equal(t, 423, res.StatusCode)
+	assert.Equal(t, "60", res.GetHeader("Min-Expires").Value())
+}
+
+func TestRegistrar_ExpiryAndRemoval(t *testing.T) {
+	r := NewRegistrar("rapida.ai")
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	register(t, r, "secret", "Contact: <sip:pbx@10.0.0.5:5060>\r\n", "Expires: 120\r\n")
+	require.Len(t, r.Bindings(testAOR), 1)
+
+	now = now.Add(121 * time.Second)
+	assert.Empty(t, r.Bindings(testAOR), "binding lapses without a refresh")
+
+	register(t, r, "secret", "Contact: <sip:pbx@10.0.0.5:5060>\r\n")
+	require.Len(t, r.Bindings(testAOR), 1)
+	res := register(t, r, "secret", "Contact: *\r\n", "Expires: 0\r\n")
+	require.Equal(t, 200, res.StatusCode)
+	assert.Empty(t, r.Bindings(testAOR))
+}
+
+func TestRegistrar_Match(t *testing.T) {
+	r := NewRegistrar("rapida.ai")
+	register(t, r, "secret", "Contact: <sip:pbx@10.0.0.5:5060>\r\n")
+
+	binding, ok := r.Match(parseRequest(t, "INVITE", "sip:+15550100@rapida.ai", "10.0.0.5:40000"))
+	require.True(t, ok)
+	assert.Equal(t, testAOR, binding.AOR)
+	assert.Equal(t, "pbx", binding.Route.Config.Username)
+
+	_, ok = r.Match(parseRequest(t, "INVITE", "sip:+15550100@rapida.ai", "10.0.0.9:5060"))
+	assert.False(t, ok, "INVITE from an unregistered host")
+}
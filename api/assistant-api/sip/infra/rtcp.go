@@ -0,0 +1,498 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// RTCP constants (RFC 3550 section 6)
+const (
+	rtcpTypeSR   = 200
+	rtcpTypeRR   = 201
+	rtcpTypeSDES = 202
+	rtcpTypeBYE  = 203
+
+	rtcpHeaderSize      = 4
+	rtcpSenderInfoSize  = 20
+	rtcpReportBlockSize = 24
+	rtcpSDESCNAME       = 1
+
+	// rtcpInterval is the report interval. With a single audio stream per
+	// socket the 5 second minimum of RFC 3550 6.2 is always the one that applies.
+	rtcpInterval = 5 * time.Second
+
+	// rtcpStatsBufferSize keeps the latest reports for a slow reader, older
+	// ones are dropped as every report carries the full picture.
+	rtcpStatsBufferSize = 4
+
+	// seconds between the NTP epoch (1900) and the Unix epoch (1970)
+	ntpEpochOffset = 2208988800
+)
+
+// rtcpReportBlock is a reception report about one synchronization source.
+type rtcpReportBlock struct {
+	SSRC         uint32
+	FractionLost uint8
+	PacketsLost  int32 // 24 bit signed on the wire
+	HighestSeq   uint32
+	Jitter       uint32 // in RTP timestamp units
+	LastSR       uint32 // middle 32 bits of the NTP time of the last SR
+	DelaySinceSR uint32 // in units of 1/65536 seconds
+}
+
+// rtcpSenderInfo is the sender information section of an SR.
+type rtcpSenderInfo struct {
+	NTPTime     uint64
+	RTPTime     uint32
+	PacketCount uint32
+	OctetCount  uint32
+}
+
+// rtcpPacket is one SR or RR taken from a compound packet.
+type rtcpPacket struct {
+	Type    uint8
+	SSRC    uint32
+	Sender  *rtcpSenderInfo // set for SRs only
+	Reports []rtcpReportBlock
+}
+
+// ntpTime converts t to a 64 bit NTP timestamp.
+func ntpTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// ntpMiddle returns the middle 32 bits of an NTP timestamp, the compact form
+// LSR and the round trip calculation use.
+func ntpMiddle(ntp uint64) uint32 {
+	return uint32(ntp >> 16)
+}
+
+// rtpSourceStats tracks the stream received from the remote party, following
+// RFC 3550 appendix A.1 (sequence numbers), A.3 (loss) and A.8 (jitter). Only
+// the receiveLoop updates it, reads go through the handler's mutex.
+type rtpSourceStats struct {
+	initialized bool
+	ssrc        uint32
+
+	baseSeq  uint16
+	maxSeq   uint16
+	cycles   uint32
+	received uint32
+
+	// expected and received at the previous report, for the fraction lost
+	expectedPrior uint32
+	receivedPrior uint32
+
+	transit int64
+	jitter  float64 // in RTP timestamp units
+
+	// last SR from the remote party and when it arrived
+	lastSR   uint32
+	lastSRAt time.Time
+}
+
+// update accounts one packet. arrival is the arrival time in RTP timestamp
+// units of the stream's clock.
+func (s *rtpSourceStats) update(seq uint16, timestamp, arrival uint32, ssrc uint32) {
+	if !s.initialized || s.ssrc != ssrc {
+		// a new source restarts the statistics, an SR of the old one no
+		// longer describes it
+		*s = rtpSourceStats{initialized: true, ssrc: ssrc, baseSeq: seq, maxSeq: seq, transit: int64(int32(arrival - timestamp))}
+		s.received = 1
+		return
+	}
+
+	s.received++
+	if delta := seq - s.maxSeq; delta != 0 && delta < 0x8000 {
+		if seq < s.maxSeq {
+			// sequence number wrapped
+			s.cycles += 1 << 16
+		}
+		s.maxSeq = seq
+	}
+
+	transit := int64(int32(arrival - timestamp))
+	d := transit - s.transit
+	s.transit = transit
+	if d < 0 {
+		d = -d
+	}
+	s.jitter += (float64(d) - s.jitter) / 16
+}
+
+// expected returns the number of packets the source sent so far.
+func (s *rtpSourceStats) expected() uint32 {
+	return s.cycles + uint32(s.maxSeq) - uint32(s.baseSeq) + 1
+}
+
+// lost returns the cumulative number of lost packets. Duplicates can make it
+// negative.
+func (s *rtpSourceStats) lost() int64 {
+	return int64(s.expected()) - int64(s.received)
+}
+
+// report builds the reception report for the source and starts a new
+// interval for the fraction lost.
+func (s *rtpSourceStats) report(now time.Time) rtcpReportBlock {
+	expected := s.expected()
+	expectedInterval := expected - s.expectedPrior
+	receivedInterval := s.received - s.receivedPrior
+	s.expectedPrior = expected
+	s.receivedPrior = s.received
+
+	block := rtcpReportBlock{
+		SSRC:        s.ssrc,
+		PacketsLost: int32(max(min(s.lost(), 0x7FFFFF), -0x800000)),
+		HighestSeq:  s.cycles + uint32(s.maxSeq),
+		Jitter:      uint32(s.jitter),
+		LastSR:      s.lastSR,
+	}
+	if expectedInterval > 0 && expectedInterval > receivedInterval {
+		block.FractionLost = uint8((expectedInterval - receivedInterval) << 8 / expectedInterval)
+	}
+	if s.lastSR != 0 {
+		block.DelaySinceSR = uint32(now.Sub(s.lastSRAt) * 65536 / time.Second)
+	}
+	return block
+}
+
+// marshalRTCPReport builds the compound packet sent every rtcpInterval: an
+// SR, or an RR before anything was sent, followed by the SDES CNAME every
+// compound packet must carry. bye appends a BYE for the end of the call.
+func marshalRTCPReport(ssrc uint32, sender *rtcpSenderInfo, reports []rtcpReportBlock, cname string, bye bool) []byte {
+	packetType := uint8(rtcpTypeRR)
+	length := rtcpHeaderSize + 4 + len(reports)*rtcpReportBlockSize
+	if sender != nil {
+		packetType = rtcpTypeSR
+		length += rtcpSenderInfoSize
+	}
+
+	data := make([]byte, length)
+	putRTCPHeader(data, uint8(len(reports)), packetType, length)
+	binary.BigEndian.PutUint32(data[4:8], ssrc)
+	offset := 8
+	if sender != nil {
+		binary.BigEndian.PutUint64(data[8:16], sender.NTPTime)
+		binary.BigEndian.PutUint32(data[16:20], sender.RTPTime)
+		binary.BigEndian.PutUint32(data[20:24], sender.PacketCount)
+		binary.BigEndian.PutUint32(data[24:28], sender.OctetCount)
+		offset += rtcpSenderInfoSize
+	}
+	for _, report := range reports {
+		block := data[offset : offset+rtcpReportBlockSize]
+		binary.BigEndian.PutUint32(block[0:4], report.SSRC)
+		binary.BigEndian.PutUint32(block[4:8], uint32(report.FractionLost)<<24|uint32(report.PacketsLost)&0xFFFFFF)
+		binary.BigEndian.PutUint32(block[8:12], report.HighestSeq)
+		binary.BigEndian.PutUint32(block[12:16], report.Jitter)
+		binary.BigEndian.PutUint32(block[16:20], report.LastSR)
+		binary.BigEndian.PutUint32(block[20:24], report.DelaySinceSR)
+		offset += rtcpReportBlockSize
+	}
+
+	// SDES: one chunk with the CNAME item, null terminated and padded to a
+	// 32 bit boundary
+	cname = cname[:min(len(cname), 255)]
+	sdesLength := rtcpHeaderSize + (4+2+len(cname)+1+3)/4*4
+	sdes := make([]byte, sdesLength)
+	putRTCPHeader(sdes, 1, rtcpTypeSDES, sdesLength)
+	binary.BigEndian.PutUint32(sdes[4:8], ssrc)
+	sdes[8] = rtcpSDESCNAME
+	sdes[9] = uint8(len(cname))
+	copy(sdes[10:], cname)
+	data = append(data, sdes...)
+
+	if bye {
+		packet := make([]byte, rtcpHeaderSize+4)
+		putRTCPHeader(packet, 1, rtcpTypeBYE, len(packet))
+		binary.BigEndian.PutUint32(packet[4:8], ssrc)
+		data = append(data, packet...)
+	}
+	return data
+}
+
+func putRTCPHeader(data []byte, count, packetType uint8, length int) {
+	data[0] = rtpVersion<<6 | count&0x1F
+	data[1] = packetType
+	binary.BigEndian.PutUint16(data[2:4], uint16(length/4-1))
+}
+
+// parseRTCP returns the SRs and RRs of a compound RTCP packet. Other packet
+// types are skipped.
+func parseRTCP(data []byte) ([]rtcpPacket, error) {
+	if len(data) < rtcpHeaderSize {
+		return nil, errors.New("RTCP packet too small")
+	}
+
+	var packets []rtcpPacket
+	for len(data) >= rtcpHeaderSize {
+		if version := data[0] >> 6; version != rtpVersion {
+			return nil, fmt.Errorf("unsupported RTCP version: %d", version)
+		}
+		count := int(data[0] & 0x1F)
+		packetType := data[1]
+		length := (int(binary.BigEndian.Uint16(data[2:4])) + 1) * 4
+		if length > len(data) {
+			return nil, fmt.Errorf("invalid RTCP length %d, %d bytes left", length, len(data))
+		}
+		body := data[rtcpHeaderSize:length]
+		data = data[length:]
+
+		if packetType != rtcpTypeSR && packetType != rtcpTypeRR {
+			continue
+		}
+		if len(body) < 4 {
+			return nil, errors.New("RTCP report without SSRC")
+		}
+		packet := rtcpPacket{Type: packetType, SSRC: binary.BigEndian.Uint32(body[0:4])}
+		body = body[4:]
+		if packetType == rtcpTypeSR {
+			if len(body) < rtcpSenderInfoSize {
+				return nil, errors.New("RTCP SR without sender info")
+			}
+			packet.Sender = &rtcpSenderInfo{
+				NTPTime:     binary.BigEndian.Uint64(body[0:8]),
+				RTPTime:     binary.BigEndian.Uint32(body[8:12]),
+				PacketCount: binary.BigEndian.Uint32(body[12:16]),
+				OctetCount:  binary.BigEndian.Uint32(body[16:20]),
+			}
+			body = body[rtcpSenderInfoSize:]
+		}
+		if len(body) < count*rtcpReportBlockSize {
+			return nil, fmt.Errorf("RTCP report with %d blocks has %d bytes", count, len(body))
+		}
+		for i := 0; i < count; i++ {
+			block := body[i*rtcpReportBlockSize:]
+			lost := binary.BigEndian.Uint32(block[4:8])
+			packet.Reports = append(packet.Reports, rtcpReportBlock{
+				SSRC:         binary.BigEndian.Uint32(block[0:4]),
+				FractionLost: uint8(lost >> 24),
+				PacketsLost:  int32(lost<<8) >> 8,
+				HighestSeq:   binary.BigEndian.Uint32(block[8:12]),
+				Jitter:       binary.BigEndian.Uint32(block[12:16]),
+				LastSR:       binary.BigEndian.Uint32(block[16:20]),
+				DelaySinceSR: binary.BigEndian.Uint32(block[20:24]),
+			})
+		}
+		packets = append(packets, packet)
+	}
+	return packets, nil
+}
+
+// roundTripTime computes the round trip time from a report block received
+// at now (RFC 3550 6.4.1). It is false when the remote party has not seen
+// one of our SRs yet.
+func roundTripTime(block rtcpReportBlock, now time.Time) (time.Duration, bool) {
+	if block.LastSR == 0 {
+		return 0, false
+	}
+	rtt := int32(ntpMiddle(ntpTime(now)) - block.LastSR - block.DelaySinceSR)
+	if rtt < 0 {
+		return 0, false
+	}
+	return time.Duration(rtt) * time.Second / 65536, true
+}
+
+// startRTCP opens the RTCP socket on the port after the RTP port, which the
+// port allocator keeps free, and starts the report loop. Calls go on without
+// RTCP when the port can't be bound.
+func (h *RTPHandler) startRTCP() {
+	if h.localPort == 0 || h.localPort == 65535 {
+		return
+	}
+	network := "udp4"
+	if ip := net.ParseIP(h.localIP); ip != nil && ip.To4() == nil {
+		network = "udp6"
+	}
+	conn, err := net.ListenUDP(network, &net.UDPAddr{IP: net.ParseIP(h.localIP), Port: h.localPort + 1})
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Warnw("RTCP: failed to bind, call continues without RTCP", "error", err, "port", h.localPort+1)
+		}
+		return
+	}
+
+	h.mu.Lock()
+	h.rtcpConn = conn
+	h.mu.Unlock()
+	go h.rtcpLoop(conn)
+}
+
+// stopRTCP sends the final report with a BYE and closes the RTCP socket.
+func (h *RTPHandler) stopRTCP() {
+	h.mu.Lock()
+	conn := h.rtcpConn
+	h.rtcpConn = nil
+	h.mu.Unlock()
+	if conn == nil {
+		return
+	}
+	h.sendRTCPReport(conn, true)
+	conn.Close()
+}
+
+// rtcpLoop reads RTCP from the remote party and sends our report every
+// rtcpInterval.
+func (h *RTPHandler) rtcpLoop(conn *net.UDPConn) {
+	buf := make([]byte, rtpPacketMaxSize)
+	nextReport := time.Now().Add(rtcpInterval)
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		default:
+		}
+
+		if !time.Now().Before(nextReport) {
+			h.sendRTCPReport(conn, false)
+			nextReport = nextReport.Add(rtcpInterval)
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(rtpReadTimeout)); err != nil {
+			return
+		}
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				continue
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		data := buf[:n]
+		if session := h.currentSRTP(); session != nil {
+			if data, err = session.unprotectRTCP(data); err != nil {
+				continue
+			}
+		}
+		packets, err := parseRTCP(data)
+		if err != nil {
+			if h.logger != nil {
+				h.logger.Warnw("Failed to parse RTCP packet", "error", err, "from", from.String())
+			}
+			continue
+		}
+		h.mu.Lock()
+		// symmetric RTCP: reply to where the reports come from
+		h.rtcpRemoteAddr = from
+		h.mu.Unlock()
+		h.handleRTCP(packets, time.Now())
+	}
+}
+
+// handleRTCP records the remote party's SRs for our reception reports and
+// turns their reports about our stream into RTCPStats.
+func (h *RTPHandler) handleRTCP(packets []rtcpPacket, now time.Time) {
+	h.mu.Lock()
+	clockRate := h.codec.ClockRate
+	var stats *RTCPStats
+	for _, packet := range packets {
+		if packet.Sender != nil && packet.SSRC == h.source.ssrc {
+			h.source.lastSR = ntpMiddle(packet.Sender.NTPTime)
+			h.source.lastSRAt = now
+		}
+		for _, block := range packet.Reports {
+			if block.SSRC != h.ssrc {
+				continue
+			}
+			h.rtcpStats.ReportsReceived++
+			if rtt, ok := roundTripTime(block, now); ok {
+				h.rtcpStats.RoundTripTime = rtt
+			}
+			if clockRate > 0 {
+				h.rtcpStats.Jitter = time.Duration(float64(block.Jitter) / float64(clockRate) * float64(time.Second))
+			}
+			h.rtcpStats.FractionLost = float64(block.FractionLost) / 256
+			h.rtcpStats.PacketsLost = int64(block.PacketsLost)
+			snapshot := h.rtcpStats
+			stats = &snapshot
+		}
+	}
+	h.mu.Unlock()
+
+	if stats == nil || !h.running.Load() {
+		return
+	}
+	select {
+	case h.rtcpStatsChan <- *stats:
+	default:
+		if h.logger != nil {
+			h.logger.Debugw("RTCP: stats channel full, dropping report")
+		}
+	}
+}
+
+// sendRTCPReport sends our SR with a reception report about the remote
+// stream once it has been received.
+func (h *RTPHandler) sendRTCPReport(conn *net.UDPConn, bye bool) {
+	now := time.Now()
+	h.mu.Lock()
+	remoteAddr := h.rtcpRemoteAddr
+	if remoteAddr == nil && h.remoteAddr != nil {
+		remoteAddr = &net.UDPAddr{IP: h.remoteAddr.IP, Port: h.remoteAddr.Port + 1}
+	}
+	var reports []rtcpReportBlock
+	if h.source.initialized {
+		reports = append(reports, h.source.report(now))
+	}
+	ssrc, timestamp := h.ssrc, h.timestamp
+	h.mu.Unlock()
+	if remoteAddr == nil {
+		return
+	}
+
+	var sender *rtcpSenderInfo
+	if sent := h.packetsSent.Load(); sent > 0 {
+		sender = &rtcpSenderInfo{
+			NTPTime:     ntpTime(now),
+			RTPTime:     timestamp,
+			PacketCount: uint32(sent),
+			OctetCount:  uint32(h.bytesSent.Load()),
+		}
+	}
+	data := marshalRTCPReport(ssrc, sender, reports, fmt.Sprintf("rapida@%s", h.localIP), bye)
+	if session := h.currentSRTP(); session != nil {
+		protected, err := session.protectRTCP(data)
+		if err != nil {
+			return
+		}
+		data = protected
+	}
+	if _, err := conn.WriteToUDP(data, remoteAddr); err != nil && h.running.Load() && h.logger != nil {
+		h.logger.Debugw("RTCP: send failed", "error", err, "dest", remoteAddr.String())
+	}
+}
+
+// updateSourceStats accounts a received RTP packet for our reception reports.
+func (h *RTPHandler) updateSourceStats(packet *RTPPacket, arrival time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	units := uint32(arrival.Sub(h.startedAt) * time.Duration(h.codec.ClockRate) / time.Second)
+	h.source.update(packet.SequenceNumber, packet.Timestamp, units, packet.SSRC)
+}
+
+// RTCPIn returns the channel of call quality reports, one per RTCP report
+// the remote party sends about our stream. It is not closed on Stop.
+func (h *RTPHandler) RTCPIn() <-chan RTCPStats {
+	return h.rtcpStatsChan
+}
+
+// GetRTCPStats returns the latest call quality reported by the remote party.
+func (h *RTPHandler) GetRTCPStats() RTCPStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.rtcpStats
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRTCP_SenderReportRoundTrip(t *testing.T) {
+	sender := &rtcpSenderInfo{NTPTime: ntpTime(time.Unix(1700000000, 500000000)), RTPTime: 16000, PacketCount: 100, OctetCount: 16000}
+	block := rtcpReportBlock{SSRC: 0xCAFE, FractionLost: 25, PacketsLost: -2, HighestSeq: 70000, Jitter: 80, LastSR: 0x12345678, DelaySinceSR: 65536}
+
+	data := marshalRTCPReport(0xBEEF, sender, []rtcpReportBlock{block}, "rapida@10.0.0.1", true)
+	require.Zero(t, len(data)%4, "RTCP packets are 32 bit aligned")
+
+	packets, err := parseRTCP(data)
+	require.NoError(t, err)
+	require.Len(t, packets, 1, "SDES and BYE are skipped")
+	assert.Equal(t, uint8(rtcpTypeSR), packets[0].Type)
+	assert.Equal(t, uint32(0xBEEF), packets[0].SSRC)
+	assert.Equal(t, sender, packets[0].Sender)
+	assert.Equal(t, []rtcpReportBlock{block}, packets[0].Reports)
+}
+
+func TestRTCP_ReceiverReportWithoutSender(t *testing.T) {
+	packets, err := parseRTCP(marshalRTCPReport(7, nil, nil, "rapida", false))
+	require.NoError(t, err)
+	require.Len(t, packets, 1)
+	assert.Equal(t, uint8(rtcpTypeRR), packets[0].Type)
+	assert.Nil(t, packets[0].Sender)
+	assert.Empty(t, packets[0].Reports)
+}
+
+func TestRTCP_ParseRejectsTruncated(t *testing.T) {
+	data := marshalRTCPReport(7, &rtcpSenderInfo{}, []rtcpReportBlock{{SSRC: 1}}, "rapida", false)
+
+	_, err := parseRTCP(data[:20])
+	assert.Error(t, err)
+	_, err = parseRTCP([]byte{0x40, 200, 0, 1, 0, 0, 0, 0})
+	assert.Error(t, err, "version 1")
+}
+
+func TestRTPSourceStats_LossAndWrap(t *testing.T) {
+	var s rtpSourceStats
+	seq := uint16(65530)
+	for i := 0; i < 20; i++ {
+		if i == 5 || i == 12 {
+			seq++
+			continue // lost
+		}
+		s.update(seq, uint32(i*160), uint32(i*160), 42)
+		seq++
+	}
+
+	assert.Equal(t, uint32(20), s.expected())
+	assert.Equal(t, int64(2), s.lost())
+	assert.Equal(t, 0.0, s.jitter, "packets arrived exactly on time")
+
+	block := s.report(time.Now())
+	assert.Equal(t, uint32(42), block.SSRC)
+	assert.Equal(t, int32(2), block.PacketsLost)
+	assert.Equal(t, uint32(1<<16+13), block.HighestSeq)
+	assert.Equal(t, uint8(2*256/20), block.FractionLost)
+
+	// the next interval has no loss
+	s.update(seq, 20*160, 20*160, 42)
+	assert.Equal(t, uint8(0), s.report(time.Now()).FractionLost)
+}
+
+func TestRTPSourceStats_Jitter(t *testing.T) {
+	var s rtpSourceStats
+	for i := 0; i < 200; i++ {
+		// every other packet arrives 80 units (10ms at 8kHz) late
+		arrival := uint32(i * 160)
+		if i%2 == 1 {
+			arrival += 80
+		}
+		s.update(uint16(i), uint32(i*160), arrival, 1)
+	}
+	assert.InDelta(t, 80, s.jitter, 1)
+}
+
+func TestRTPSourceStats_NewSourceResets(t *testing.T) {
+	var s rtpSourceStats
+	s.update(10, 0, 0, 1)
+	s.update(20, 160, 160, 1)
+	s.update(500, 0, 0, 2)
+
+	assert.Equal(t, uint32(2), s.ssrc)
+	assert.Equal(t, uint32(1), s.expected())
+	assert.Equal(t, int64(0), s.lost())
+}
+
+func TestRoundTripTime(t *testing.T) {
+	now := time.Now()
+	sentAt := now.Add(-300 * time.Millisecond)
+	block := rtcpReportBlock{
+		LastSR:       ntpMiddle(ntpTime(sentAt)),
+		DelaySinceSR: uint32(200 * time.Millisecond * 65536 / time.Second),
+	}
+
+	rtt, ok := roundTripTime(block, now)
+	require.True(t, ok)
+	assert.InDelta(t, float64(100*time.Millisecond), float64(rtt), float64(time.Millisecond))
+
+	_, ok = roundTripTime(rtcpReportBlock{}, now)
+	assert.False(t, ok, "no SR seen by the remote party yet")
+}
+
+func TestRTPHandler_HandleRTCP(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMU)
+	h.updateSourceStats(&RTPPacket{SequenceNumber: 1, SSRC: 9}, time.Now())
+
+	now := time.Now()
+	h.handleRTCP([]rtcpPacket{{
+		Type:   rtcpTypeSR,
+		SSRC:   9,
+		Sender: &rtcpSenderInfo{NTPTime: ntpTime(now)},
+		Reports: []rtcpReportBlock{
+			{SSRC: h.ssrc, FractionLost: 64, PacketsLost: 12, Jitter: 160},
+			{SSRC: h.ssrc + 1, PacketsLost: 99},
+		},
+	}}, now)
+
+	stats := <-h.RTCPIn()
+	assert.Equal(t, 0.25, stats.FractionLost)
+	assert.Equal(t, int64(12), stats.PacketsLost)
+	assert.Equal(t, 20*time.Millisecond, stats.Jitter)
+	assert.Equal(t, uint64(1), stats.ReportsReceived)
+	assert.Equal(t, stats, h.GetRTCPStats())
+	assert.Equal(t, ntpMiddle(ntpTime(now)), h.source.lastSR, "the SR is echoed in our next report")
+}
@@ -18,6 +18,7 @@ import (
 	"syscall"
 	"time"
 
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
 	"github.com/rapidaai/pkg/commons"
 	"golang.org/x/sys/unix"
 )
@@ -35,6 +36,10 @@ const (
 	// Audio channel buffer sizes
 	rtpAudioInBufferSize  = 100
 	rtpAudioOutBufferSize = 100
+
+	// DTMF channel buffer size, a burst of fast key presses fits easily
+	rtpDTMFInBufferSize  = 32
+	rtpDTMFOutBufferSize = 8
 )
 
 // RTPPacket represents an RTP packet
@@ -76,6 +81,19 @@ type RTPHandler struct {
 	audioInChan  chan []byte
 	audioOutChan chan []byte
 
+	// dtmfInChan carries key presses decoded from RFC 4733 telephone-event
+	// packets; those packets are never forwarded as audio.
+	dtmfInChan  chan DTMFEvent
+	dtmfDecoder *dtmfDecoder
+
+	// dtmfOutChan carries event codes queued by SendDTMF; the sendLoop
+	// plays them as RFC 4733 packets in place of audio.
+	dtmfOutChan chan []byte
+
+	// telephoneEventPayloadType is the payload type of RFC 4733 packets in
+	// both directions, the one negotiated in the SDP.
+	telephoneEventPayloadType uint8
+
 	// flushAudioCh signals the sendLoop to discard all pending audio
 	// (used on user interruption to silence stale frames immediately).
 	flushAudioCh chan struct{}
@@ -84,9 +102,46 @@ type RTPHandler struct {
 	// codec changes and regenerate its pre-computed silence chunk.
 	codecVersion uint32
 
+	// RFC 2198 redundancy, see red.go. redPayloadType is zero unless RED was
+	// negotiated; redPrevious is the last audio frame sent, repeated in the
+	// next packet.
+	redPayloadType       uint8
+	redPrevious          *redBlock
+	redPreviousTimestamp uint32
+
+	// concealment enables the PLC stage of repair, see plc.go. repair is
+	// owned by receiveLoop.
+	concealment bool
+	repair      lossRepair
+
+	// comfortNoise replaces the digital silence sent in the gaps of AudioOut,
+	// see comfort_noise.go. nil sends plain silence.
+	comfortNoise *internal_audio.ComfortNoise
+
+	// G.722 and G.729 are transcoded to and from the µ-law of AudioIn and
+	// AudioOut, see transcoder.go. encoder is guarded by mu; decoder is owned
+	// by receiveLoop and follows the payload type of the received packets.
+	encoder            frameEncoder
+	decoder            frameDecoder
+	decoderPayloadType uint8
+
+	// srtp protects RTP and RTCP once SDES keys were negotiated, see
+	// srtp.go. nil sends and receives plain RTP.
+	srtp *srtpSession
+
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	// RTCP state, see rtcp.go. rtcpConn listens on the port after localPort,
+	// source describes the stream received from the remote party and
+	// rtcpStats the quality the remote party reports about ours.
+	rtcpConn       *net.UDPConn
+	rtcpRemoteAddr *net.UDPAddr
+	source         rtpSourceStats
+	rtcpStats      RTCPStats
+	rtcpStatsChan  chan RTCPStats
+	startedAt      time.Time
+
 	// Statistics
 	packetsSent     atomic.Uint64
 	packetsReceived atomic.Uint64
@@ -184,18 +239,26 @@ func NewRTPHandler(ctx context.Context, config *RTPConfig) (*RTPHandler, error)
 	}
 
 	handler := &RTPHandler{
-		logger:       config.Logger,
-		conn:         conn,
-		localIP:      localAddr.IP.String(),
-		localPort:    localAddr.Port,
-		ssrc:         rand.Uint32(),
-		codec:        codec,
-		audioInChan:  make(chan []byte, rtpAudioInBufferSize),
-		audioOutChan: make(chan []byte, rtpAudioOutBufferSize),
-		flushAudioCh: make(chan struct{}, 1),
-		ctx:          handlerCtx,
-		cancel:       cancel,
+		logger:        config.Logger,
+		conn:          conn,
+		localIP:       localAddr.IP.String(),
+		localPort:     localAddr.Port,
+		ssrc:          rand.Uint32(),
+		codec:         codec,
+		audioInChan:   make(chan []byte, rtpAudioInBufferSize),
+		audioOutChan:  make(chan []byte, rtpAudioOutBufferSize),
+		dtmfInChan:    make(chan DTMFEvent, rtpDTMFInBufferSize),
+		dtmfDecoder:   newDTMFDecoder(CodecTelephoneEvent.ClockRate),
+		dtmfOutChan:   make(chan []byte, rtpDTMFOutBufferSize),
+		flushAudioCh:  make(chan struct{}, 1),
+		rtcpStatsChan: make(chan RTCPStats, rtcpStatsBufferSize),
+		startedAt:     time.Now(),
+		ctx:           handlerCtx,
+		cancel:        cancel,
+
+		telephoneEventPayloadType: CodecTelephoneEvent.PayloadType,
 	}
+	handler.resetEncoder()
 
 	return handler, nil
 }
@@ -223,6 +286,7 @@ func (h *RTPHandler) Start() {
 
 	go h.receiveLoop()
 	go h.sendLoop()
+	h.startRTCP()
 
 	h.logger.Infow("RTP handler started — sendLoop and receiveLoop launched",
 		"local_addr", fmt.Sprintf("%s:%d", h.localIP, h.localPort),
@@ -291,6 +355,7 @@ func (h *RTPHandler) Stop() error {
 	}
 
 	h.cancel()
+	h.stopRTCP()
 
 	// Close channels safely
 	h.closeChannels()
@@ -301,6 +366,10 @@ func (h *RTPHandler) Stop() error {
 		h.sendConn.Close()
 		h.sendConn = nil
 	}
+	if h.encoder != nil {
+		h.encoder.Close()
+		h.encoder = nil
+	}
 	h.mu.Unlock()
 
 	var err error
@@ -325,6 +394,7 @@ func (h *RTPHandler) closeChannels() {
 	}()
 	close(h.audioInChan)
 	close(h.audioOutChan)
+	close(h.dtmfInChan)
 }
 
 // IsRunning returns whether the RTP handler is running
@@ -451,6 +521,28 @@ func (h *RTPHandler) AudioIn() <-chan []byte {
 	return h.audioInChan
 }
 
+// DTMFIn returns the channel of key presses received as RFC 4733 events
+func (h *RTPHandler) DTMFIn() <-chan DTMFEvent {
+	return h.dtmfInChan
+}
+
+// SendDTMF queues keypad digits (0-9, *, #, A-D) to be sent to the remote
+// party as RFC 4733 telephone events. Audio is held back while they play.
+func (h *RTPHandler) SendDTMF(digits string) error {
+	codes, err := dtmfEventCodes(digits)
+	if err != nil {
+		return err
+	}
+	select {
+	case h.dtmfOutChan <- codes:
+		return nil
+	case <-h.ctx.Done():
+		return h.ctx.Err()
+	default:
+		return fmt.Errorf("DTMF output queue full")
+	}
+}
+
 // AudioOut returns the channel for sending audio
 func (h *RTPHandler) AudioOut() chan<- []byte {
 	return h.audioOutChan
@@ -478,7 +570,9 @@ func (h *RTPHandler) GetCodec() *Codec {
 // and clock rate of outgoing packets are updated immediately; the silence
 // pattern is also adjusted (0xFF for PCMU, 0xD5 for PCMA).
 // The codecVersion counter is bumped so the sendLoop regenerates its
-// pre-computed silence chunk on the next iteration.
+// pre-computed silence chunk and re-encodes queued audio on the next
+// iteration; inbound packets still carrying the old payload type are
+// converted on receipt. A mid-call switch therefore loses no audio.
 func (h *RTPHandler) SetCodec(codec *Codec) {
 	if codec == nil {
 		return
@@ -488,6 +582,8 @@ func (h *RTPHandler) SetCodec(codec *Codec) {
 	old := h.codec
 	h.codec = codec
 	h.codecVersion++
+	h.redPrevious = nil
+	h.resetEncoder()
 	if h.logger != nil {
 		h.logger.Infow("RTP codec updated",
 			"old_codec", old.Name,
@@ -498,7 +594,28 @@ func (h *RTPHandler) SetCodec(codec *Codec) {
 	}
 }
 
+// SetTelephoneEventPayloadType sets the payload type RFC 4733 key presses
+// are received and sent with, the one mapped to telephone-event/8000 in the
+// SDP. Zero restores CodecTelephoneEvent's.
+func (h *RTPHandler) SetTelephoneEventPayloadType(pt uint8) {
+	if pt == 0 {
+		pt = CodecTelephoneEvent.PayloadType
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.telephoneEventPayloadType = pt
+}
+
+// TelephoneEventPayloadType returns the payload type of RFC 4733 packets.
+func (h *RTPHandler) TelephoneEventPayloadType() uint8 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.telephoneEventPayloadType
+}
+
 func (h *RTPHandler) receiveLoop() {
+	defer h.closeDecoder()
+
 	// Safety net: recover from "send on closed channel" panic that can occur
 	// if Stop() closes audioInChan while this goroutine is mid-send.
 	defer func() {
@@ -599,7 +716,17 @@ func (h *RTPHandler) receiveLoop() {
 			continue
 		}
 
-		packet, err := h.parseRTPPacket(buf[:n])
+		data := buf[:n]
+		if session := h.currentSRTP(); session != nil {
+			if data, err = session.unprotectRTP(data); err != nil {
+				if h.logger != nil {
+					h.logger.Debugw("Dropping SRTP packet that failed authentication", "error", err)
+				}
+				continue
+			}
+		}
+
+		packet, err := h.parseRTPPacket(data)
 		if err != nil {
 			if h.logger != nil {
 				h.logger.Warnw("Failed to parse RTP packet", "error", err)
@@ -622,18 +749,167 @@ func (h *RTPHandler) receiveLoop() {
 		// Update statistics
 		h.packetsReceived.Add(1)
 		h.bytesReceived.Add(uint64(len(packet.Payload)))
-		// running state and context together with the send.
+		h.updateSourceStats(packet, time.Now())
+
+		h.mu.RLock()
+		codec := h.codec
+		redPayloadType := h.redPayloadType
+		telEventPayloadType := h.telephoneEventPayloadType
+		conceal := h.concealment
+		h.mu.RUnlock()
+
+		// RED packets carry the frame and copies of the frames before it
+		var redundant []redBlock
+		if redPayloadType != 0 && packet.PayloadType == redPayloadType {
+			blocks, primary, err := decodeRED(packet.Payload)
+			if err != nil {
+				if h.logger != nil {
+					h.logger.Warnw("RTP: Failed to decode RED packet", "error", err, "seq", packet.SequenceNumber)
+				}
+				continue
+			}
+			packet.PayloadType = primary.PayloadType
+			packet.Payload = primary.Payload
+			redundant = blocks
+		}
+
+		// Telephone events share the sequence space of the audio, the gaps
+		// are tracked across both.
+		repairing := redPayloadType != 0 || conceal
+		lost, inOrder := 0, true
+		if repairing {
+			lost, inOrder = h.repair.advance(packet.SSRC, packet.SequenceNumber)
+		}
+
+		// telephone-event packets carry key presses, not audio
+		if packet.PayloadType == telEventPayloadType {
+			h.handleTelephoneEvent(packet)
+			continue
+		}
+
+		// A late packet's slot was already played out, possibly concealed.
+		if !inOrder {
+			continue
+		}
+
+		// Around a re-INVITE the peer may still send a few packets with the
+		// previous payload type. Convert them so AudioIn is always in the
+		// negotiated codec and readers never mix laws.
+		payload, err := h.decodePayload(packet.PayloadType, packet.Payload, codec)
+		if err != nil {
+			if h.logger != nil {
+				h.logger.Warnw("RTP: Failed to decode audio", "error", err, "payload_type", packet.PayloadType, "seq", packet.SequenceNumber)
+			}
+			continue
+		}
+
+		frames := [][]byte{payload}
+		if repairing {
+			frames = h.repair.repair(lost, payload, redundant, codec, conceal)
+		}
+		for _, frame := range frames {
+			if !h.deliverAudio(frame, packet.SequenceNumber) {
+				return
+			}
+		}
+	}
+}
+
+// decodePayload converts the audio of a received packet to the format of
+// AudioIn for the negotiated codec. Only called from receiveLoop.
+func (h *RTPHandler) decodePayload(pt uint8, payload []byte, codec *Codec) ([]byte, error) {
+	packetCodec := GetCodecByPayloadType(pt)
+	if !isTranscoded(packetCodec) {
+		if pt == codec.PayloadType {
+			return payload, nil
+		}
+		return transcode(payload, packetCodec, codec), nil
+	}
+
+	if h.decoder == nil || h.decoderPayloadType != pt {
+		h.closeDecoder()
+		decoder, err := newFrameDecoder(packetCodec)
+		if err != nil {
+			return nil, err
+		}
+		h.decoder, h.decoderPayloadType = decoder, pt
+	}
+	ulaw, err := h.decoder.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+	return transcode(ulaw, &CodecPCMU, codec), nil
+}
+
+func (h *RTPHandler) closeDecoder() {
+	if h.decoder != nil {
+		h.decoder.Close()
+		h.decoder = nil
+	}
+}
+
+// resetEncoder replaces the encoder by one for the current codec, a codec
+// switch starts a new stream. Called with h.mu held.
+func (h *RTPHandler) resetEncoder() {
+	if h.encoder != nil {
+		h.encoder.Close()
+		h.encoder = nil
+	}
+	encoder, err := newFrameEncoder(h.codec)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Errorw("RTP: Failed to create encoder", "codec", h.codec.Name, "error", err)
+		}
+		return
+	}
+	h.encoder = encoder
+}
+
+// deliverAudio passes a received frame to AudioIn, dropping it when the
+// reader falls behind. It returns false once the handler stops.
+func (h *RTPHandler) deliverAudio(frame []byte, seq uint16) bool {
+	// running state and context together with the send.
+	if !h.running.Load() {
+		return false
+	}
+	select {
+	case <-h.ctx.Done():
+		return false
+	case h.audioInChan <- frame:
+		// Successfully sent to channel
+	default:
+		if h.logger != nil {
+			h.logger.Warnw("RTP: Audio input channel full, dropping packet", "seq", seq)
+		}
+	}
+	return true
+}
+
+// handleTelephoneEvent decodes an RFC 4733 packet and forwards completed key
+// presses to dtmfInChan. Only called from receiveLoop, so the decoder needs no
+// locking.
+func (h *RTPHandler) handleTelephoneEvent(packet *RTPPacket) {
+	events, err := h.dtmfDecoder.Decode(packet)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Warnw("RTP: Failed to decode telephone-event", "error", err, "seq", packet.SequenceNumber)
+		}
+		return
+	}
+	for _, event := range events {
 		if !h.running.Load() {
 			return
 		}
+		if h.logger != nil {
+			h.logger.Infow("RTP: DTMF received", "digit", event.Digit, "duration_ms", event.Duration)
+		}
 		select {
 		case <-h.ctx.Done():
 			return
-		case h.audioInChan <- packet.Payload:
-			// Successfully sent to channel
+		case h.dtmfInChan <- event:
 		default:
 			if h.logger != nil {
-				h.logger.Warnw("RTP: Audio input channel full, dropping packet", "seq", packet.SequenceNumber)
+				h.logger.Warnw("RTP: DTMF input channel full, dropping digit", "digit", event.Digit)
 			}
 		}
 	}
@@ -642,7 +918,8 @@ func (h *RTPHandler) receiveLoop() {
 func (h *RTPHandler) sendLoop() {
 	// Calculate samples per packet based on codec (20ms packets)
 	h.mu.RLock()
-	samplesPerPacket := int(h.codec.ClockRate * 20 / 1000) // e.g., 160 bytes for PCMU at 8kHz
+	codec := h.codec
+	samplesPerPacket := int(codec.ClockRate * 20 / 1000) // e.g., 160 bytes for PCMU at 8kHz
 	lastCodecVersion := h.codecVersion
 	h.mu.RUnlock()
 
@@ -650,6 +927,8 @@ func (h *RTPHandler) sendLoop() {
 	silenceChunk := h.createSilenceChunk(samplesPerPacket)
 
 	var pendingAudio []byte
+	dtmf := newDTMFEncoder(uint16(CodecTelephoneEvent.ClockRate * 20 / 1000))
+	var dtmfTimestamp uint32
 	// First sendLoop packet should go out immediately (sendInitialSilence
 	// already sent packet #1, this will send packet #2 without delay).
 	nextSendTime := time.Now()
@@ -663,16 +942,20 @@ func (h *RTPHandler) sendLoop() {
 		}
 
 		// If the codec changed (e.g., via re-INVITE), regenerate the
-		// silence chunk so it uses the correct silence byte pattern.
+		// silence chunk so it uses the correct silence byte pattern, and
+		// re-encode audio queued under the old codec so it still plays.
 		h.mu.RLock()
 		cv := h.codecVersion
 		h.mu.RUnlock()
 		if cv != lastCodecVersion {
 			lastCodecVersion = cv
 			h.mu.RLock()
-			samplesPerPacket = int(h.codec.ClockRate * 20 / 1000)
+			previous := codec
+			codec = h.codec
+			samplesPerPacket = int(codec.ClockRate * 20 / 1000)
 			h.mu.RUnlock()
 			silenceChunk = h.createSilenceChunk(samplesPerPacket)
+			pendingAudio = h.reencodeQueuedAudio(pendingAudio, previous, codec)
 		}
 
 		// Collect pending audio (non-blocking) or handle flush signal
@@ -696,6 +979,12 @@ func (h *RTPHandler) sendLoop() {
 		}
 	collectDone:
 
+		select {
+		case codes := <-h.dtmfOutChan:
+			dtmf.Enqueue(codes)
+		default:
+		}
+
 		// Wait until next send time with precision
 		now := time.Now()
 		if sleepDuration := nextSendTime.Sub(now); sleepDuration > 0 {
@@ -718,10 +1007,21 @@ func (h *RTPHandler) sendLoop() {
 			continue
 		}
 
-		// Get exactly ONE chunk: audio if available, otherwise silence
-		chunk := h.getAudioChunk(&pendingAudio, samplesPerPacket, silenceChunk)
-
-		packet := h.createRTPPacket(chunk)
+		var packet *RTPPacket
+		var chunk []byte
+		if payload, start := dtmf.Next(); payload != nil {
+			// All packets of a key press carry the timestamp of its start
+			if start {
+				dtmfTimestamp = h.currentTimestamp()
+			}
+			chunk = payload
+			packet = h.createTelephoneEventPacket(payload, start, dtmfTimestamp, samplesPerPacket)
+		} else {
+			// Get exactly ONE chunk: audio if available, otherwise silence
+			chunk = h.getAudioChunk(&pendingAudio, samplesPerPacket, silenceChunk)
+			chunk = h.withComfortNoise(chunk, codec)
+			packet = h.createRTPPacket(chunk)
+		}
 		data := h.serializeRTPPacket(packet)
 
 		_, err := h.sendPacket(data, remoteAddr)
@@ -742,6 +1042,24 @@ func (h *RTPHandler) sendLoop() {
 	}
 }
 
+// reencodeQueuedAudio converts audio still queued for sending from the codec
+// it was written in to the newly negotiated one. Writers pace frames at 20ms,
+// so whatever sits in audioOutChan at the switch was encoded before it.
+func (h *RTPHandler) reencodeQueuedAudio(pendingAudio []byte, from, to *Codec) []byte {
+	pendingAudio = transcode(pendingAudio, from, to)
+	for {
+		select {
+		case audio, ok := <-h.audioOutChan:
+			if !ok {
+				return pendingAudio
+			}
+			pendingAudio = append(pendingAudio, transcode(audio, from, to)...)
+		default:
+			return pendingAudio
+		}
+	}
+}
+
 // createSilenceChunk creates a silence chunk for the codec
 func (h *RTPHandler) createSilenceChunk(size int) []byte {
 	chunk := make([]byte, size)
@@ -830,8 +1148,17 @@ func (h *RTPHandler) parseRTPPacket(data []byte) (*RTPPacket, error) {
 func (h *RTPHandler) sendPacket(data []byte, remoteAddr *net.UDPAddr) (int, error) {
 	h.mu.RLock()
 	sendConn := h.sendConn
+	session := h.srtp
 	h.mu.RUnlock()
 
+	if session != nil {
+		protected, err := session.protectRTP(data)
+		if err != nil {
+			return 0, err
+		}
+		data = protected
+	}
+
 	if sendConn != nil {
 		// Connected socket — Write() uses the pre-connected remote address.
 		// The kernel computes the correct UDP checksum because the source IP
@@ -847,6 +1174,12 @@ func (h *RTPHandler) createRTPPacket(payload []byte) *RTPPacket {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	// payload is a frame of AudioOut, one byte per sample
+	samples := len(payload)
+	if h.encoder != nil {
+		payload = h.encoder.Encode(payload)
+	}
+
 	packet := &RTPPacket{
 		Version:        rtpVersion,
 		PayloadType:    h.codec.PayloadType,
@@ -856,12 +1189,42 @@ func (h *RTPHandler) createRTPPacket(payload []byte) *RTPPacket {
 		Payload:        payload,
 	}
 
+	h.withRedundancy(packet)
+
+	h.sequenceNumber++
+	h.timestamp += uint32(samples)
+
+	return packet
+}
+
+// createTelephoneEventPacket builds an RFC 4733 packet stamped with the start
+// of its event, while the stream clock still advances by one audio frame.
+func (h *RTPHandler) createTelephoneEventPacket(payload []byte, marker bool, timestamp uint32, samples int) *RTPPacket {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	packet := &RTPPacket{
+		Version:        rtpVersion,
+		Marker:         marker,
+		PayloadType:    h.telephoneEventPayloadType,
+		SequenceNumber: h.sequenceNumber,
+		Timestamp:      timestamp,
+		SSRC:           h.ssrc,
+		Payload:        payload,
+	}
+
 	h.sequenceNumber++
-	h.timestamp += uint32(len(payload))
+	h.timestamp += uint32(samples)
 
 	return packet
 }
 
+func (h *RTPHandler) currentTimestamp() uint32 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.timestamp
+}
+
 func (h *RTPHandler) serializeRTPPacket(packet *RTPPacket) []byte {
 	headerLen := 12 + len(packet.CSRC)*4
 	data := make([]byte, headerLen+len(packet.Payload))
@@ -898,12 +1261,22 @@ func (h *RTPHandler) GetStats() (sent, received uint64) {
 	return h.packetsSent.Load(), h.packetsReceived.Load()
 }
 
-// GetDetailedStats returns detailed RTP statistics
+// GetDetailedStats returns detailed RTP statistics. Loss and jitter are
+// those of the stream received from the remote party.
 func (h *RTPHandler) GetDetailedStats() RTPStats {
-	return RTPStats{
+	stats := RTPStats{
 		PacketsSent:     h.packetsSent.Load(),
 		PacketsReceived: h.packetsReceived.Load(),
 		BytesSent:       h.bytesSent.Load(),
 		BytesReceived:   h.bytesReceived.Load(),
 	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.source.initialized {
+		stats.PacketsLost = uint64(max(h.source.lost(), 0))
+		if h.codec.ClockRate > 0 {
+			stats.Jitter = time.Duration(h.source.jitter / float64(h.codec.ClockRate) * float64(time.Second))
+		}
+	}
+	return stats
 }
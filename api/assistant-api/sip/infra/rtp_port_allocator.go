@@ -8,9 +8,11 @@ package sip_infra
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -29,13 +31,30 @@ const (
 
 	// TTL for per-instance allocated port tracking (crash recovery)
 	rtpAllocatedTTL = 10 * time.Minute
+
+	// Backoff between attempts while Redis fails over, bounded by the
+	// context of the operation
+	rtpRetryBackoff    = 50 * time.Millisecond
+	rtpMaxRetryBackoff = time.Second
 )
 
+// RTPPortStore is the part of the Redis client the allocator runs on. The
+// standalone, cluster and sentinel failover clients of go-redis all satisfy
+// it, as does redis.UniversalClient. The scripts touch keys of one hash tag
+// only, so they run on a cluster as they do on a single node.
+type RTPPortStore interface {
+	redis.Scripter
+	SCard(ctx context.Context, key string) *redis.IntCmd
+	SMembers(ctx context.Context, key string) *redis.StringSliceCmd
+	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
 // RTPPortAllocator manages distributed allocation of RTP ports via Redis.
 // RTP ports are even-numbered per RFC 3550 (RTCP uses the next odd port).
 // Thread-safe across multiple server instances via Redis atomic operations.
 type RTPPortAllocator struct {
-	client     *redis.Client
+	client     RTPPortStore
 	logger     commons.Logger
 	portStart  int
 	portEnd    int
@@ -45,7 +64,7 @@ type RTPPortAllocator struct {
 // NewRTPPortAllocator creates a Redis-backed distributed port allocator for the given range [start, end).
 // Ports are allocated as even numbers per RTP convention.
 // The allocator initializes the Redis available-ports set on first use.
-func NewRTPPortAllocator(client *redis.Client, logger commons.Logger, portStart, portEnd int) *RTPPortAllocator {
+func NewRTPPortAllocator(client RTPPortStore, logger commons.Logger, portStart, portEnd int) *RTPPortAllocator {
 	hostname, _ := os.Hostname()
 	instanceID := fmt.Sprintf("%s:%d", hostname, os.Getpid())
 
@@ -95,7 +114,7 @@ func (a *RTPPortAllocator) Init(ctx context.Context) error {
 	}
 
 	// Atomically initialize only if the set doesn't exist
-	result, err := initLuaScript.Run(ctx, a.client, []string{rtpAvailableKey}, ports...).Int()
+	result, err := a.runScript(ctx, initLuaScript, []string{rtpAvailableKey}, ports...).Int()
 	if err != nil {
 		return fmt.Errorf("failed to initialize RTP port pool in Redis: %w", err)
 	}
@@ -138,7 +157,7 @@ func (a *RTPPortAllocator) Allocate() (int, error) {
 	instanceKey := rtpAllocatedPrefix + a.instanceID
 
 	// Atomically pop from available and track in instance set
-	result, err := allocateLuaScript.Run(ctx, a.client, []string{rtpAvailableKey, instanceKey}).Int()
+	result, err := a.runScript(ctx, allocateLuaScript, []string{rtpAvailableKey, instanceKey}).Int()
 	if err != nil {
 		return 0, fmt.Errorf("failed to allocate RTP port from Redis: %w", err)
 	}
@@ -175,7 +194,7 @@ func (a *RTPPortAllocator) Release(port int) {
 
 	instanceKey := rtpAllocatedPrefix + a.instanceID
 
-	_, err := releaseLuaScript.Run(ctx, a.client, []string{rtpAvailableKey, instanceKey}, port).Result()
+	_, err := a.runScript(ctx, releaseLuaScript, []string{rtpAvailableKey, instanceKey}, port).Result()
 	if err != nil {
 		a.logger.Error("Failed to release RTP port to Redis", "port", port, "error", err)
 		return
@@ -194,18 +213,55 @@ func (a *RTPPortAllocator) InUse() (int, error) {
 	}
 
 	// Total ports minus available = in use
+	available, err := a.client.SCard(ctx, rtpAvailableKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get available port count: %w", err)
+	}
+
+	return a.Size() - int(available), nil
+}
+
+// Size returns the number of RTP ports in the configured range.
+func (a *RTPPortAllocator) Size() int {
 	start := a.portStart
 	if start%2 != 0 {
 		start++
 	}
-	totalPorts := (a.portEnd - start) / 2
+	return (a.portEnd - start) / 2
+}
 
-	available, err := a.client.SCard(ctx, rtpAvailableKey).Result()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get available port count: %w", err)
+// runScript runs script, retrying while Redis fails over. A new master has
+// an empty script cache, Script.Run loads the script again on NOSCRIPT.
+func (a *RTPPortAllocator) runScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) *redis.Cmd {
+	backoff := rtpRetryBackoff
+	for {
+		cmd := script.Run(ctx, a.client, keys, args...)
+		if !isFailoverError(cmd.Err()) {
+			return cmd
+		}
+		a.logger.Warnw("Redis unavailable for RTP port allocation, retrying", "error", cmd.Err(), "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return cmd
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, rtpMaxRetryBackoff)
 	}
+}
 
-	return totalPorts - int(available), nil
+// isFailoverError reports whether err was returned while a master is being
+// replaced, before the command ran. Lost replies are not retried, the script
+// may have run and popped a port already.
+func isFailoverError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, prefix := range []string{"READONLY", "LOADING", "MASTERDOWN", "CLUSTERDOWN", "TRYAGAIN"} {
+		if redis.HasErrorPrefix(err, prefix) {
+			return true
+		}
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
 }
 
 // reclaimCrashedPorts moves any ports tracked under this instance's key back to the available pool.
@@ -238,7 +294,7 @@ func (a *RTPPortAllocator) reclaimCrashedPorts(ctx context.Context) {
 		if err != nil {
 			continue
 		}
-		_, err = releaseLuaScript.Run(ctx, a.client, []string{rtpAvailableKey, instanceKey}, port).Result()
+		_, err = a.runScript(ctx, releaseLuaScript, []string{rtpAvailableKey, instanceKey}, port).Result()
 		if err != nil {
 			a.logger.Warn("Failed to reclaim port", "port", port, "error", err)
 		}
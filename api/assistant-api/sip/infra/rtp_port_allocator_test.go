@@ -0,0 +1,336 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rapidaai/pkg/commons"
+)
+
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+func (redisError) RedisError()     {}
+
+// fakeClusterNode runs the allocator's scripts in memory the way a cluster
+// node would: scripts touching keys of different slots fail with CROSSSLOT,
+// EVALSHA needs the script cached, and queued failures are returned first.
+type fakeClusterNode struct {
+	mu       sync.Mutex
+	sets     map[string]map[string]bool
+	scripts  map[string]bool
+	failures []error
+	calls    int
+}
+
+func newFakeClusterNode() *fakeClusterNode {
+	return &fakeClusterNode{sets: map[string]map[string]bool{}, scripts: map[string]bool{}}
+}
+
+// failover makes the next scripts fail with errs, and replaces the master:
+// its script cache is empty.
+func (f *fakeClusterNode) failover(errs ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, errs...)
+	f.scripts = map[string]bool{}
+}
+
+func (f *fakeClusterNode) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	sum := sha1.Sum([]byte(script))
+	sha := hex.EncodeToString(sum[:])
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failLocked(); err != nil {
+		return redis.NewCmdResult(nil, err)
+	}
+	f.scripts[sha] = true
+	return f.runLocked(sha, keys, args)
+}
+
+func (f *fakeClusterNode) EvalSha(ctx context.Context, sha string, keys []string, args ...interface{}) *redis.Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.failLocked(); err != nil {
+		return redis.NewCmdResult(nil, err)
+	}
+	if !f.scripts[sha] {
+		return redis.NewCmdResult(nil, redisError("NOSCRIPT No matching script. Please use EVAL."))
+	}
+	return f.runLocked(sha, keys, args)
+}
+
+func (f *fakeClusterNode) EvalRO(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	return f.Eval(ctx, script, keys, args...)
+}
+
+func (f *fakeClusterNode) EvalShaRO(ctx context.Context, sha string, keys []string, args ...interface{}) *redis.Cmd {
+	return f.EvalSha(ctx, sha, keys, args...)
+}
+
+func (f *fakeClusterNode) ScriptExists(ctx context.Context, hashes ...string) *redis.BoolSliceCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	exists := make([]bool, len(hashes))
+	for i, sha := range hashes {
+		exists[i] = f.scripts[sha]
+	}
+	return redis.NewBoolSliceResult(exists, nil)
+}
+
+func (f *fakeClusterNode) ScriptLoad(ctx context.Context, script string) *redis.StringCmd {
+	sum := sha1.Sum([]byte(script))
+	sha := hex.EncodeToString(sum[:])
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scripts[sha] = true
+	return redis.NewStringResult(sha, nil)
+}
+
+func (f *fakeClusterNode) SCard(ctx context.Context, key string) *redis.IntCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return redis.NewIntResult(int64(len(f.sets[key])), nil)
+}
+
+func (f *fakeClusterNode) SMembers(ctx context.Context, key string) *redis.StringSliceCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	members := make([]string, 0, len(f.sets[key]))
+	for member := range f.sets[key] {
+		members = append(members, member)
+	}
+	return redis.NewStringSliceResult(members, nil)
+}
+
+func (f *fakeClusterNode) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	return redis.NewBoolResult(true, nil)
+}
+
+func (f *fakeClusterNode) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.sets, key)
+	}
+	return redis.NewIntResult(int64(len(keys)), nil)
+}
+
+func (f *fakeClusterNode) failLocked() error {
+	f.calls++
+	if len(f.failures) == 0 {
+		return nil
+	}
+	err := f.failures[0]
+	f.failures = f.failures[1:]
+	return err
+}
+
+func (f *fakeClusterNode) runLocked(sha string, keys []string, args []interface{}) *redis.Cmd {
+	for _, key := range keys[1:] {
+		if keySlot(key) != keySlot(keys[0]) {
+			return redis.NewCmdResult(nil, redisError("CROSSSLOT Keys in request don't hash to the same slot"))
+		}
+	}
+	set := func(key string) map[string]bool {
+		if f.sets[key] == nil {
+			f.sets[key] = map[string]bool{}
+		}
+		return f.sets[key]
+	}
+	switch sha {
+	case initLuaScript.Hash():
+		if len(f.sets[keys[0]]) > 0 {
+			return redis.NewCmdResult(int64(0), nil)
+		}
+		for _, arg := range args {
+			set(keys[0])[fmt.Sprint(arg)] = true
+		}
+		return redis.NewCmdResult(int64(len(args)), nil)
+	case allocateLuaScript.Hash():
+		for port := range f.sets[keys[0]] {
+			delete(f.sets[keys[0]], port)
+			set(keys[1])[port] = true
+			return redis.NewCmdResult(port, nil)
+		}
+		return redis.NewCmdResult(int64(-1), nil)
+	case releaseLuaScript.Hash():
+		port := fmt.Sprint(args[0])
+		delete(set(keys[1]), port)
+		set(keys[0])[port] = true
+		return redis.NewCmdResult(int64(1), nil)
+	}
+	return redis.NewCmdResult(nil, redisError("ERR unknown script"))
+}
+
+// keySlot is the cluster slot of key, CRC16 of its hash tag modulo 16384.
+func keySlot(key string) uint16 {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc % 16384
+}
+
+func newTestAllocator(t *testing.T, store RTPPortStore) *RTPPortAllocator {
+	t.Helper()
+	logger, _ := commons.NewApplicationLogger()
+	allocator := NewRTPPortAllocator(store, logger, 10001, 10010)
+	require.NoError(t, allocator.Init(context.Background()))
+	return allocator
+}
+
+func TestKeySlot(t *testing.T) {
+	// slots documented in the Redis cluster specification
+	assert.Equal(t, uint16(12739), keySlot("123456789"))
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.following"))
+}
+
+func TestRTPPortAllocator_KeysShareClusterSlot(t *testing.T) {
+	slot := keySlot(rtpAvailableKey)
+	for _, instance := range []string{"sip-0:1", "sip-1.rapida.internal:4242", "host:65535"} {
+		assert.Equal(t, slot, keySlot(rtpAllocatedPrefix+instance), instance)
+	}
+}
+
+func TestRTPPortAllocator_AllocateAndRelease(t *testing.T) {
+	node := newFakeClusterNode()
+	allocator := newTestAllocator(t, node)
+
+	allocated := map[int]bool{}
+	for i := 0; i < 4; i++ {
+		port, err := allocator.Allocate()
+		require.NoError(t, err)
+		assert.Zero(t, port%2, "RTP ports are even")
+		assert.False(t, allocated[port])
+		allocated[port] = true
+	}
+	_, err := allocator.Allocate()
+	assert.ErrorContains(t, err, "no RTP ports available")
+
+	inUse, err := allocator.InUse()
+	require.NoError(t, err)
+	assert.Equal(t, 4, inUse)
+
+	for port := range allocated {
+		allocator.Release(port)
+		break
+	}
+	_, err = allocator.Allocate()
+	require.NoError(t, err)
+
+	allocator.ReleaseAll(context.Background())
+	inUse, err = allocator.InUse()
+	require.NoError(t, err)
+	assert.Zero(t, inUse)
+	assert.NotContains(t, node.sets, rtpAllocatedPrefix+allocator.instanceID)
+}
+
+func TestRTPPortAllocator_InitKeepsPoolOfOtherInstances(t *testing.T) {
+	node := newFakeClusterNode()
+	first := newTestAllocator(t, node)
+	_, err := first.Allocate()
+	require.NoError(t, err)
+
+	// instances on the same host and pid reclaim each other's ports
+	logger, _ := commons.NewApplicationLogger()
+	second := NewRTPPortAllocator(node, logger, 10001, 10010)
+	second.instanceID = "other:1"
+	require.NoError(t, second.Init(context.Background()))
+	inUse, err := second.InUse()
+	require.NoError(t, err)
+	assert.Equal(t, 1, inUse)
+}
+
+func TestRTPPortAllocator_RetriesDuringFailover(t *testing.T) {
+	node := newFakeClusterNode()
+	allocator := newTestAllocator(t, node)
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	node.failover(
+		redisError("READONLY You can't write against a read only replica."),
+		refused,
+		redisError("LOADING Redis is loading the dataset in memory"),
+	)
+	node.calls = 0
+
+	port, err := allocator.Allocate()
+	require.NoError(t, err)
+	assert.NotZero(t, port)
+	// three failures, EVALSHA on the new master, then EVAL loading the script
+	assert.Equal(t, 5, node.calls)
+
+	allocator.Release(port)
+	inUse, err := allocator.InUse()
+	require.NoError(t, err)
+	assert.Zero(t, inUse)
+}
+
+func TestRTPPortAllocator_FailoverBoundedByContext(t *testing.T) {
+	node := newFakeClusterNode()
+	allocator := newTestAllocator(t, node)
+
+	failures := make([]error, 100)
+	for i := range failures {
+		failures[i] = redisError("MASTERDOWN Link with MASTER is down and replica-serve-stale-data is set to 'no'.")
+	}
+	node.failover(failures...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := allocator.Init(ctx)
+	assert.ErrorContains(t, err, "MASTERDOWN")
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestRTPPortAllocator_OtherErrorsAreNotRetried(t *testing.T) {
+	node := newFakeClusterNode()
+	allocator := newTestAllocator(t, node)
+	node.failover(redisError("ERR something else"))
+	node.calls = 0
+
+	_, err := allocator.Allocate()
+	assert.ErrorContains(t, err, "ERR something else")
+	assert.Equal(t, 1, node.calls)
+}
+
+func TestIsFailoverError(t *testing.T) {
+	assert.True(t, isFailoverError(redisError("TRYAGAIN Multiple keys request during rehashing of slot")))
+	assert.True(t, isFailoverError(redisError("CLUSTERDOWN The cluster is down")))
+	assert.False(t, isFailoverError(nil))
+	assert.False(t, isFailoverError(redisError("NOSCRIPT No matching script.")))
+	assert.False(t, isFailoverError(fmt.Errorf("port %s", strconv.Itoa(1))))
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zaf/g711"
+)
+
+func TestRTPHandler_ReencodeQueuedAudioOnCodecSwitch(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMU)
+
+	pending := []byte{0x00, 0x10}
+	queued := []byte{0x20, 0x30, 0x40}
+	h.AudioOut() <- queued
+
+	out := h.reencodeQueuedAudio(pending, &CodecPCMU, &CodecPCMA)
+
+	assert.Equal(t, g711.EncodeAlaw(g711.DecodeUlaw(append(pending, queued...))), out)
+	assert.Empty(t, h.audioOutChan, "queued audio is moved into the pending buffer")
+}
+
+func TestRTPHandler_ReencodeQueuedAudioSameCodec(t *testing.T) {
+	h := bridgeLeg(t, CodecPCMA)
+	h.AudioOut() <- []byte{3}
+
+	assert.Equal(t, []byte{1, 2, 3}, h.reencodeQueuedAudio([]byte{1, 2}, &CodecPCMA, &CodecPCMA))
+}
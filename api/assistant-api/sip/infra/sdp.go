@@ -24,7 +24,11 @@ type Codec struct {
 var (
 	CodecPCMU = Codec{Name: "PCMU", PayloadType: 0, ClockRate: 8000, Channels: 1}
 	CodecPCMA = Codec{Name: "PCMA", PayloadType: 8, ClockRate: 8000, Channels: 1}
+	// G.722 samples at 16 kHz, its RTP clock is 8 kHz for historic reasons
+	// (RFC 3551). G.722 and G.729 are transcoded by the RTP handler, see
+	// transcoder.go.
 	CodecG722 = Codec{Name: "G722", PayloadType: 9, ClockRate: 8000, Channels: 1}
+	CodecG729 = Codec{Name: "G729", PayloadType: 18, ClockRate: 8000, Channels: 1}
 
 	// CodecTelephoneEvent is RFC 4733 DTMF telephone-event.
 	// Nearly all SIP endpoints (Asterisk, FreeSWITCH, Twilio, Zoiper) require
@@ -33,8 +37,17 @@ var (
 	CodecTelephoneEvent = Codec{Name: "telephone-event", PayloadType: 101, ClockRate: 8000, Channels: 1}
 )
 
-// SupportedCodecs lists audio codecs in order of preference (excludes telephone-event)
-var SupportedCodecs = []Codec{CodecPCMU, CodecPCMA}
+// SupportedCodecs lists audio codecs in order of preference (excludes telephone-event).
+// G.711 comes first as it needs no transcoding, G.729 only when compiled in.
+var SupportedCodecs = supportedCodecs()
+
+func supportedCodecs() []Codec {
+	codecs := []Codec{CodecPCMU, CodecPCMA, CodecG722}
+	if g729Available {
+		codecs = append(codecs, CodecG729)
+	}
+	return codecs
+}
 
 // SDPDirection represents the media direction attribute in SDP
 type SDPDirection string
@@ -53,6 +66,20 @@ type SDPMediaInfo struct {
 	PayloadTypes   []uint8
 	PreferredCodec *Codec
 	Direction      SDPDirection // sendrecv, sendonly, recvonly, inactive
+
+	// REDPayloadType is the payload type mapped to red/8000 (RFC 2198),
+	// zero when the remote party does not offer redundancy.
+	REDPayloadType uint8
+
+	// TelephoneEventPayloadType is the payload type mapped to
+	// telephone-event/8000 (RFC 4733), zero when the remote party does not
+	// map it.
+	TelephoneEventPayloadType uint8
+
+	// Secure is set when the audio is offered as RTP/SAVP. Crypto holds
+	// the SDES keys of the a=crypto lines in a suite we support.
+	Secure bool
+	Crypto []*SRTPKeys
 }
 
 // IsHold returns true if the SDP indicates a hold condition.
@@ -75,6 +102,22 @@ type SDPConfig struct {
 	RTPPort     int
 	Codecs      []Codec
 	PTime       int // Packetization time in milliseconds
+
+	// REDPayloadType advertises RFC 2198 redundancy of the first codec
+	// under this payload type, zero leaves it out.
+	REDPayloadType uint8
+
+	// TelephoneEventPayloadType advertises telephone-event under this
+	// payload type, zero uses CodecTelephoneEvent's.
+	TelephoneEventPayloadType uint8
+
+	// Crypto offers the audio as SRTP (RTP/SAVP) with these keys, nil
+	// offers plain RTP.
+	Crypto *SRTPKeys
+
+	// Direction of the audio, empty is sendrecv. sendonly puts the remote
+	// party on hold.
+	Direction SDPDirection
 }
 
 // DefaultSDPConfig returns a default SDP configuration
@@ -109,7 +152,7 @@ func (s *Server) NegotiatedSDPConfig(localIP string, rtpPort int, codec *Codec)
 }
 
 // GenerateSDP creates an SDP body for SIP responses.
-// Always includes telephone-event (PT 101) per RFC 4733. Nearly all SIP
+// Always includes telephone-event (PT 101 unless cfg maps it elsewhere) per RFC 4733. Nearly all SIP
 // endpoints (Asterisk, FreeSWITCH, Zoiper, Twilio) require telephone-event
 // in the m= line — without it, they report "remote codecs: None" and refuse
 // to bridge/accept media even when audio codecs match.
@@ -131,42 +174,75 @@ func (s *Server) GenerateSDP(cfg *SDPConfig) string {
 	// Time (0 0 = session is permanent)
 	sb.WriteString("t=0 0\r\n")
 
-	// Media Description — build payload type list: audio codecs + telephone-event (101)
-	payloadTypes := make([]string, 0, len(cfg.Codecs)+1)
+	// Media Description — build payload type list: RED first when offered,
+	// audio codecs + telephone-event (101)
+	payloadTypes := make([]string, 0, len(cfg.Codecs)+2)
+	if cfg.REDPayloadType != 0 && len(cfg.Codecs) > 0 {
+		payloadTypes = append(payloadTypes, strconv.Itoa(int(cfg.REDPayloadType)))
+	}
 	for _, codec := range cfg.Codecs {
 		payloadTypes = append(payloadTypes, strconv.Itoa(int(codec.PayloadType)))
 	}
-	// Always include telephone-event PT 101 in the m= line (RFC 4733 DTMF).
+	// Always include telephone-event in the m= line (RFC 4733 DTMF), on
+	// the payload type the remote party mapped it to when answering.
 	// Check it's not already in the codec list to avoid duplicates.
+	telEventPT := CodecTelephoneEvent.PayloadType
+	if cfg.TelephoneEventPayloadType != 0 {
+		telEventPT = cfg.TelephoneEventPayloadType
+	}
 	hasTelEvent := false
 	for _, codec := range cfg.Codecs {
-		if codec.PayloadType == CodecTelephoneEvent.PayloadType {
+		if codec.PayloadType == telEventPT {
 			hasTelEvent = true
 			break
 		}
 	}
 	if !hasTelEvent {
-		payloadTypes = append(payloadTypes, strconv.Itoa(int(CodecTelephoneEvent.PayloadType)))
+		payloadTypes = append(payloadTypes, strconv.Itoa(int(telEventPT)))
+	}
+	proto := sdpRTPProto
+	if cfg.Crypto != nil {
+		proto = sdpSRTPProto
+	}
+	sb.WriteString(fmt.Sprintf("m=audio %d %s %s\r\n", cfg.RTPPort, proto, strings.Join(payloadTypes, " ")))
+
+	// RED rtpmap + fmtp: one redundant copy of the primary codec
+	if cfg.REDPayloadType != 0 && len(cfg.Codecs) > 0 {
+		primary := cfg.Codecs[0].PayloadType
+		sb.WriteString(fmt.Sprintf("a=rtpmap:%d %s/%d\r\n", cfg.REDPayloadType, CodecRED.Name, CodecRED.ClockRate))
+		sb.WriteString(fmt.Sprintf("a=fmtp:%d %d/%d\r\n", cfg.REDPayloadType, primary, primary))
 	}
-	sb.WriteString(fmt.Sprintf("m=audio %d RTP/AVP %s\r\n", cfg.RTPPort, strings.Join(payloadTypes, " ")))
 
 	// Codec attributes (rtpmap for each audio codec)
 	for _, codec := range cfg.Codecs {
 		sb.WriteString(fmt.Sprintf("a=rtpmap:%d %s/%d\r\n", codec.PayloadType, codec.Name, codec.ClockRate))
+		// we never send Annex B comfort noise
+		if codec.Name == CodecG729.Name {
+			sb.WriteString(fmt.Sprintf("a=fmtp:%d annexb=no\r\n", codec.PayloadType))
+		}
 	}
 
 	// telephone-event rtpmap + fmtp (required by Asterisk, Zoiper, etc.)
 	if !hasTelEvent {
 		sb.WriteString(fmt.Sprintf("a=rtpmap:%d %s/%d\r\n",
-			CodecTelephoneEvent.PayloadType, CodecTelephoneEvent.Name, CodecTelephoneEvent.ClockRate))
-		sb.WriteString(fmt.Sprintf("a=fmtp:%d 0-16\r\n", CodecTelephoneEvent.PayloadType))
+			telEventPT, CodecTelephoneEvent.Name, CodecTelephoneEvent.ClockRate))
+		sb.WriteString(fmt.Sprintf("a=fmtp:%d 0-16\r\n", telEventPT))
+	}
+
+	// SDES key of the stream we send (RFC 4568)
+	if cfg.Crypto != nil {
+		sb.WriteString(fmt.Sprintf("a=crypto:%s\r\n", cfg.Crypto.CryptoAttribute()))
 	}
 
 	// Packetization time
 	sb.WriteString(fmt.Sprintf("a=ptime:%d\r\n", cfg.PTime))
 
-	// Direction (send and receive)
-	sb.WriteString("a=sendrecv\r\n")
+	// Direction, send and receive unless the call is held from this side
+	direction := cfg.Direction
+	if direction == "" {
+		direction = SDPDirectionSendRecv
+	}
+	sb.WriteString(fmt.Sprintf("a=%s\r\n", direction))
 
 	return sb.String()
 }
@@ -202,6 +278,7 @@ func (s *Server) ParseSDP(sdpBody []byte) (*SDPMediaInfo, error) {
 				if err == nil {
 					info.AudioPort = port
 				}
+				info.Secure = parts[2] == sdpSRTPProto
 				// Parse payload types
 				for i := 3; i < len(parts); i++ {
 					pt, err := strconv.Atoi(parts[i])
@@ -213,7 +290,27 @@ func (s *Server) ParseSDP(sdpBody []byte) (*SDPMediaInfo, error) {
 
 		case strings.HasPrefix(line, "a=rtpmap:"):
 			// RTP map: a=rtpmap:0 PCMU/8000
-			// We use this to confirm codec selection
+			// Static audio codecs are identified by payload type, only the
+			// dynamic RED and telephone-event mappings are read:
+			// a=rtpmap:121 red/8000, a=rtpmap:96 telephone-event/8000
+			fields := strings.Fields(strings.TrimPrefix(line, "a=rtpmap:"))
+			if len(fields) == 2 {
+				pt, err := strconv.Atoi(fields[0])
+				dynamic := err == nil && pt >= 96 && pt <= 127
+				switch {
+				case dynamic && strings.EqualFold(fields[1], fmt.Sprintf("%s/%d", CodecRED.Name, CodecRED.ClockRate)):
+					info.REDPayloadType = uint8(pt)
+				case dynamic && strings.EqualFold(fields[1], fmt.Sprintf("%s/%d", CodecTelephoneEvent.Name, CodecTelephoneEvent.ClockRate)):
+					info.TelephoneEventPayloadType = uint8(pt)
+				}
+			}
+
+		case strings.HasPrefix(line, "a=crypto:"):
+			// a=crypto:1 AES_CM_128_HMAC_SHA1_80 inline:<key||salt>
+			// Lines of suites we do not support are skipped.
+			if keys, err := ParseCryptoAttribute(strings.TrimPrefix(line, "a=crypto:")); err == nil {
+				info.Crypto = append(info.Crypto, keys)
+			}
 
 		// SDP direction attributes (RFC 3264)
 		// Used by all providers for hold/resume:
@@ -232,9 +329,9 @@ func (s *Server) ParseSDP(sdpBody []byte) (*SDPMediaInfo, error) {
 	}
 
 	// Determine preferred codec based on first matching payload type.
-	// Skip telephone-event (PT 101) — it is not an audio codec.
+	// Skip telephone-event — it is not an audio codec.
 	for _, pt := range info.PayloadTypes {
-		if pt == CodecTelephoneEvent.PayloadType {
+		if pt == CodecTelephoneEvent.PayloadType || (info.TelephoneEventPayloadType != 0 && pt == info.TelephoneEventPayloadType) {
 			continue // telephone-event is not an audio codec
 		}
 		for _, codec := range SupportedCodecs {
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/emiago/sipgo"
 	"github.com/emiago/sipgo/sip"
+	internal_drain "github.com/rapidaai/api/assistant-api/internal/drain"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/protos"
 	"github.com/redis/go-redis/v9"
@@ -31,6 +33,10 @@ const (
 	ServerStateStopped
 )
 
+// MetadataBridgeLeg marks outbound sessions placed by Dial. The invite handler
+// is not run for them when they are answered.
+const MetadataBridgeLeg = "bridge_leg"
+
 // SIPRequestContext contains information about an incoming SIP request.
 // Used by the middleware chain to authenticate and resolve config for every
 // SIP request (INVITE, REGISTER, BYE, etc.), not just INVITE.
@@ -176,6 +182,12 @@ type Server struct {
 	// Multi-tenant config resolver - called for each incoming INVITE
 	configResolver ConfigResolver
 
+	// Registrar for PBXes registering to us as a trunk, nil when disabled
+	registrar *Registrar
+
+	// Calls waiting for a human agent on a registered phone to pick them up
+	pickup *Pickup
+
 	// Event callbacks
 	onInvite func(session *Session, fromURI, toURI string) error
 	onBye    func(session *Session) error
@@ -224,9 +236,11 @@ type ServerConfig struct {
 	ListenConfig      *ListenConfig  // Shared server listen configuration
 	ConfigResolver    ConfigResolver // Resolves tenant-specific config per-call
 	Logger            commons.Logger
-	RedisClient       *redis.Client // Redis client for distributed RTP port allocation
-	RTPPortRangeStart int           // Start of RTP port range (even, >= 1024)
-	RTPPortRangeEnd   int           // End of RTP port range (exclusive)
+	RedisClient       redis.UniversalClient // Redis for distributed RTP port allocation, standalone, cluster or sentinel
+	RTPPortRangeStart int                   // Start of RTP port range (even, >= 1024)
+	RTPPortRangeEnd   int                   // End of RTP port range (exclusive)
+	RegistrarRealm    string                // Digest realm for inbound REGISTER, empty disables the registrar
+	PickupPrefix      string                // Feature code agents dial before a pickup code, DefaultPickupPrefix when empty
 }
 
 // Validate validates the server configuration
@@ -352,10 +366,15 @@ func NewServer(ctx context.Context, cfg *ServerConfig) (*Server, error) {
 		dialogServerCache: dialogServerCache,
 		configResolver:    cfg.ConfigResolver,
 		sessions:          make(map[string]*Session),
+		pickup:            NewPickup(cfg.PickupPrefix),
 		ctx:               serverCtx,
 		cancel:            cancel,
 	}
 
+	if cfg.RegistrarRealm != "" {
+		s.registrar = NewRegistrar(cfg.RegistrarRealm)
+	}
+
 	s.state.Store(int32(ServerStateCreated))
 	s.registerHandlers()
 
@@ -495,6 +514,11 @@ func (s *Server) ReleaseRTPPort(port int) {
 	s.rtpAllocator.Release(port)
 }
 
+// RTPPorts returns the shared RTP port pool, e.g. to report its utilization.
+func (s *Server) RTPPorts() *RTPPortAllocator {
+	return s.rtpAllocator
+}
+
 // SessionCount returns the number of active sessions
 func (s *Server) SessionCount() int {
 	s.mu.RLock()
@@ -553,6 +577,14 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 		return
 	}
 
+	// A draining replica takes no new calls, the provider retries them on
+	// another one.
+	if internal_drain.Default.Draining() {
+		s.logger.Infow("Draining, rejecting INVITE with 503", "call_id", callID)
+		s.sendResponse(tx, req, 503, sip.NewHeader("Retry-After", "0"))
+		return
+	}
+
 	// Parse SDP from incoming INVITE to get remote RTP address and codec preferences
 	sdpInfo, err := s.ParseSDP(req.Body())
 	if err != nil {
@@ -560,6 +592,15 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 		sdpInfo = &SDPMediaInfo{PreferredCodec: &CodecPCMU}
 	}
 
+	// Session timer (RFC 4028) — reject intervals below our Min-SE before doing
+	// any work, the caller retries with a larger Session-Expires.
+	sessionTimer, hasSessionTimer, tooSmall := negotiateSessionTimer(req)
+	if tooSmall {
+		s.logger.Warnw("Session-Expires below Min-SE, rejecting with 422", "call_id", callID)
+		s.sendResponse(tx, req, 422, minSEHeaders()...)
+		return
+	}
+
 	// Authenticate and resolve tenant-specific config via middleware chain.
 	// The chain: CredentialMiddleware → AuthMiddleware → AssistantMiddleware → VaultConfigMiddleware
 	// Each middleware enriches the SIPRequestContext; the final handler returns the InviteResult.
@@ -570,7 +611,36 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 	var tenantConfig *Config
 	var resolvedExtra map[string]interface{}
 
-	if resolver != nil {
+	// An agent dialing the pickup feature code takes a parked call over. Only
+	// phones registered with the trunk of the parked call may do so.
+	binding, registered := s.matchRegistration(req)
+	pickupCode, isPickup := s.pickup.Code(req.Recipient.User)
+	if isPickup {
+		call, parked := s.pickup.lookup(pickupCode)
+		if !parked {
+			s.logger.Warnw("Pickup of a call that is not parked", "call_id", callID, "code", pickupCode)
+			s.sendResponse(tx, req, 404)
+			return
+		}
+		if !registered || !samePickupTenant(binding, call) {
+			s.logger.Warnw("Pickup rejected, phone not registered with the call's trunk",
+				"call_id", callID, "code", pickupCode, "source", req.Source())
+			s.sendResponse(tx, req, 403)
+			return
+		}
+	}
+
+	// INVITEs from a registered PBX carry no credentials in their URI, they
+	// are routed with what the PBX's REGISTER resolved to.
+	if registered {
+		routed := *binding.Route.Config
+		tenantConfig = &routed
+		resolvedExtra = binding.Route.Extra
+		s.logger.Infow("SIP INVITE routed from registered PBX",
+			"call_id", callID,
+			"aor", binding.AOR,
+			"source", req.Source())
+	} else if resolver != nil {
 		reqCtx := &SIPRequestContext{
 			Method:  "INVITE",
 			CallID:  callID,
@@ -645,6 +715,11 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 		return
 	}
 
+	session.SetRemoteEndpoint(req)
+	if hasSessionTimer {
+		applyAnsweredSessionTimer(session, sessionTimer)
+	}
+
 	// Also propagate all middleware-resolved state to metadata for backward compatibility
 	// so the onInvite handler can access it via session.GetMetadata() if needed.
 	for k, v := range resolvedExtra {
@@ -729,29 +804,50 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 	// Store the RTP handler in the session
 	session.SetRTPHandler(rtpHandler)
 
+	// Redundancy is only sent when the caller offered RED, concealment
+	// needs nothing from the remote party.
+	if tenantConfig.Redundancy && sdpInfo.REDPayloadType != 0 {
+		rtpHandler.EnableRedundancy(sdpInfo.REDPayloadType)
+	}
+	// Key presses come on the payload type the caller mapped telephone-event to
+	rtpHandler.SetTelephoneEventPayloadType(sdpInfo.TelephoneEventPayloadType)
+	if tenantConfig.Concealment {
+		rtpHandler.EnableConcealment()
+	}
+	if tenantConfig.ComfortNoise != 0 {
+		rtpHandler.EnableComfortNoise(tenantConfig.ComfortNoise)
+	}
+
 	// Start RTP processing
 	rtpHandler.Start()
 
 	// Generate SDP for response — advertise the negotiated codec only.
 	// Using NegotiatedSDPConfig ensures we confirm the codec we agreed upon,
 	// rather than re-offering all codecs which can confuse some PBXes.
-	sdpConfig := s.NegotiatedSDPConfig(externalIP, localPort, negotiatedCodec)
+	sdpConfig := withRedundancyOffer(s.NegotiatedSDPConfig(externalIP, localPort, negotiatedCodec), rtpHandler)
 	sdpBody := s.GenerateSDP(sdpConfig)
 
+	var timerHeaders []sip.Header
+	if hasSessionTimer {
+		timerHeaders = sessionTimerHeaders(req, sessionTimer)
+	}
+
 	// Send 200 OK with SDP.
-	// When a dialog session exists, use RespondSDP which blocks until ACK is
+	// When a dialog session exists, respond through it, which blocks until ACK is
 	// received (or timeout). This establishes the dialog in Confirmed state,
 	// enabling us to send BYE later. Falls back to manual response if no dialog.
 	if ds := session.GetDialogServerSession(); ds != nil {
-		if err := ds.RespondSDP([]byte(sdpBody)); err != nil {
-			s.logger.Warnw("Dialog RespondSDP failed — falling back to manual response",
+		headers := append([]sip.Header{sip.NewHeader("Content-Type", "application/sdp")}, timerHeaders...)
+		if err := ds.Respond(200, "OK", []byte(sdpBody), headers...); err != nil {
+			s.logger.Warnw("Dialog Respond failed — falling back to manual response",
 				"error", err, "call_id", callID)
-			s.sendResponseWithSDPBody(tx, req, sdpBody)
+			s.sendResponseWithSDPBody(tx, req, sdpBody, timerHeaders...)
 		}
 	} else {
-		s.sendResponseWithSDPBody(tx, req, sdpBody)
+		s.sendResponseWithSDPBody(tx, req, sdpBody, timerHeaders...)
 	}
 	session.SetState(CallStateConnected)
+	s.startSessionTimer(session)
 
 	// Register the onDisconnect callback so that closing the session sends a SIP BYE.
 	// Captures the server reference in the closure — the session itself doesn't need
@@ -768,6 +864,12 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 		"remote_rtp", fmt.Sprintf("%s:%d", sdpInfo.ConnectionIP, sdpInfo.AudioPort),
 		"codec", negotiatedCodec.Name)
 
+	// A pickup bridges the agent to the parked call, no conversation starts
+	if isPickup {
+		s.completePickup(session, pickupCode)
+		return
+	}
+
 	// Call the invite handler (which will start the conversation)
 	s.mu.RLock()
 	onInvite := s.onInvite
@@ -781,6 +883,36 @@ func (s *Server) handleInvite(req *sip.Request, tx sip.ServerTransaction) {
 	}
 }
 
+// completePickup hands the answered session of an agent to the call parked
+// under code. The agent's call is ended when another agent got there first or
+// the parked call could not be taken over.
+func (s *Server) completePickup(agent *Session, code string) {
+	agent.SetMetadata("pickup_code", code)
+	err := fmt.Errorf("call %s was picked up already", code)
+	if call, ok := s.pickup.claim(code); ok {
+		err = call.handler(agent)
+	}
+	if err == nil {
+		s.logger.Infow("Parked call picked up", "call_id", agent.GetCallID(), "code", code)
+		return
+	}
+	s.logger.Warnw("Pickup failed, ending the agent's call", "call_id", agent.GetCallID(), "code", code, "error", err)
+	if err := s.EndCall(agent); err != nil {
+		s.logger.Warnw("Failed to end agent call", "call_id", agent.GetCallID(), "error", err)
+	}
+}
+
+// Park makes the call of session available to agents, see Pickup. It
+// returns the code agents dial after the pickup prefix.
+func (s *Server) Park(session *Session, handler PickupHandler) (string, error) {
+	return s.pickup.Park(session, handler)
+}
+
+// Unpark withdraws a call parked with Park.
+func (s *Server) Unpark(code string) {
+	s.pickup.Unpark(code)
+}
+
 // removeSession removes a session from the sessions map and releases its RTP port.
 func (s *Server) removeSession(callID string) {
 	s.mu.Lock()
@@ -839,10 +971,16 @@ func (s *Server) handleReInvite(req *sip.Request, tx sip.ServerTransaction, sess
 		}
 	}
 
+	// Any re-INVITE refreshes the session timer (RFC 4028 §10).
+	timerHeaders, ok := s.acceptSessionRefresh(tx, req, session)
+	if !ok {
+		return
+	}
+
 	// If no SDP body, this is a session refresh (RFC 4028) — just respond with our SDP
 	if len(req.Body()) == 0 {
 		s.logger.Debugw("re-INVITE with no SDP body (session refresh)", "call_id", callID)
-		s.respondWithCurrentSDP(tx, req, session)
+		s.respondWithCurrentSDP(tx, req, session, timerHeaders...)
 		return
 	}
 
@@ -865,53 +1003,97 @@ func (s *Server) handleReInvite(req *sip.Request, tx sip.ServerTransaction, sess
 		"sdp_port", sdpInfo.AudioPort,
 		"is_hold", sdpInfo.IsHold())
 
-	// Only update remote RTP when SDP indicates active media (not hold).
-	// Hold signals:
-	//   - 0.0.0.0 connection IP (RFC 3264 §8.4) — used by Asterisk, FreeSWITCH
-	//   - sendonly / inactive direction — used by Twilio, Telnyx, Vonage
-	// During hold we keep the previous remote RTP address so audio resumes correctly.
-	if !sdpInfo.IsHold() {
-		rtpHandler := session.GetRTPHandler()
-		if rtpHandler != nil && sdpInfo.ConnectionIP != "" && sdpInfo.AudioPort > 0 {
+	s.applyRemoteSDP(session, sdpInfo, "re-INVITE")
+
+	// Always respond with our SDP (sendrecv) to signal we're ready for media.
+	// respondWithCurrentSDP uses the session's negotiated codec, so after any
+	// codec switch above, the response will advertise only the correct codec.
+	s.respondWithCurrentSDP(tx, req, session, timerHeaders...)
+	s.logger.Infow("re-INVITE handled", "call_id", callID)
+}
+
+// applyRemoteSDP applies a mid-call offer (re-INVITE or UPDATE) to the
+// session's media. The RTP target and codec are switched in place on the
+// running RTP handler, which re-encodes audio already queued, so the
+// conversation continues without dropping audio.
+//
+// Only active media redirects RTP. Hold signals:
+//   - 0.0.0.0 connection IP (RFC 3264 §8.4) — used by Asterisk, FreeSWITCH
+//   - sendonly / inactive direction — used by Twilio, Telnyx, Vonage
+//
+// During hold we keep the previous remote RTP address so audio resumes correctly.
+// Hold and resume move the session between CallStateConnected and
+// CallStateOnHold, which emits EventTypeHold / EventTypeResume.
+func (s *Server) applyRemoteSDP(session *Session, sdpInfo *SDPMediaInfo, method string) {
+	callID := session.GetCallID()
+	if sdpInfo.IsHold() {
+		s.logger.Infow(method+" indicates hold — keeping current RTP target",
+			"call_id", callID,
+			"sdp_direction", string(sdpInfo.Direction),
+			"sdp_ip", sdpInfo.ConnectionIP)
+		if session.GetState() == CallStateConnected {
+			session.SetState(CallStateOnHold)
+		}
+		return
+	}
+	if session.GetState() == CallStateOnHold {
+		s.logger.Infow(method+" resumes the call from hold", "call_id", callID)
+		session.SetState(CallStateConnected)
+	}
+
+	rtpHandler := session.GetRTPHandler()
+	if sdpInfo.ConnectionIP != "" && sdpInfo.AudioPort > 0 {
+		// Re-creating the send socket for an unchanged target would only
+		// disturb the receive loop, most refreshes repeat the same address.
+		if rtpHandler != nil && !sameRTPAddr(rtpHandler.GetRemoteAddr(), sdpInfo.ConnectionIP, sdpInfo.AudioPort) {
 			rtpHandler.SetRemoteAddr(sdpInfo.ConnectionIP, sdpInfo.AudioPort)
-			session.SetRemoteRTP(sdpInfo.ConnectionIP, sdpInfo.AudioPort)
-			s.logger.Debugw("Updated remote RTP from re-INVITE",
+			s.logger.Infow("Remote RTP updated from "+method,
 				"call_id", callID,
 				"remote_rtp_ip", sdpInfo.ConnectionIP,
 				"remote_rtp_port", sdpInfo.AudioPort)
 		}
+		session.SetRemoteRTP(sdpInfo.ConnectionIP, sdpInfo.AudioPort)
+	}
 
-		// Update codec if the re-INVITE proposes a different one.
-		// Asterisk commonly sends re-INVITE after bridging to switch codecs
-		// (e.g., direct_media or codec transcoding changes). If we ignore this
-		// and keep sending the old payload type, Asterisk sees a PT mismatch
-		// and tears down the call immediately.
-		if sdpInfo.PreferredCodec != nil {
-			currentCodec := session.GetNegotiatedCodec()
-			if currentCodec == nil || currentCodec.PayloadType != sdpInfo.PreferredCodec.PayloadType {
-				rtpHandler := session.GetRTPHandler()
-				if rtpHandler != nil {
-					rtpHandler.SetCodec(sdpInfo.PreferredCodec)
-				}
-				session.SetNegotiatedCodec(sdpInfo.PreferredCodec.Name, int(sdpInfo.PreferredCodec.ClockRate))
-				s.logger.Infow("Codec updated from re-INVITE",
-					"call_id", callID,
-					"new_codec", sdpInfo.PreferredCodec.Name,
-					"payload_type", sdpInfo.PreferredCodec.PayloadType)
+	// Update codec if the offer proposes a different one.
+	// Asterisk commonly sends re-INVITE after bridging to switch codecs
+	// (e.g., direct_media or codec transcoding changes). If we ignore this
+	// and keep sending the old payload type, Asterisk sees a PT mismatch
+	// and tears down the call immediately.
+	if codec := sdpInfo.PreferredCodec; codec != nil {
+		current := session.GetNegotiatedCodec()
+		if current == nil || current.PayloadType != codec.PayloadType {
+			if rtpHandler != nil {
+				rtpHandler.SetCodec(codec)
 			}
+			session.SetNegotiatedCodec(codec.Name, int(codec.ClockRate))
+			s.logger.Infow("Codec updated from "+method,
+				"call_id", callID,
+				"old_codec", codecName(current),
+				"new_codec", codec.Name,
+				"payload_type", codec.PayloadType)
 		}
-	} else {
-		s.logger.Infow("re-INVITE indicates hold — keeping current RTP target",
-			"call_id", callID,
-			"sdp_direction", string(sdpInfo.Direction),
-			"sdp_ip", sdpInfo.ConnectionIP)
 	}
 
-	// Always respond with our SDP (sendrecv) to signal we're ready for media.
-	// respondWithCurrentSDP uses the session's negotiated codec, so after any
-	// codec switch above, the response will advertise only the correct codec.
-	s.respondWithCurrentSDP(tx, req, session)
-	s.logger.Infow("re-INVITE handled", "call_id", callID)
+	// A new offer may drop RED or map it to another payload type
+	if rtpHandler != nil && session.config.Redundancy && rtpHandler.RedundancyPayloadType() != sdpInfo.REDPayloadType {
+		rtpHandler.EnableRedundancy(sdpInfo.REDPayloadType)
+	}
+	if rtpHandler != nil && sdpInfo.TelephoneEventPayloadType != 0 {
+		rtpHandler.SetTelephoneEventPayloadType(sdpInfo.TelephoneEventPayloadType)
+	}
+}
+
+// sameRTPAddr reports whether addr already points at ip:port.
+func sameRTPAddr(addr *net.UDPAddr, ip string, port int) bool {
+	return addr != nil && addr.Port == port && addr.IP.Equal(net.ParseIP(ip))
+}
+
+func codecName(codec *Codec) string {
+	if codec == nil {
+		return ""
+	}
+	return codec.Name
 }
 
 // respondWithCurrentSDP builds a 200 OK response with the session's current local SDP.
@@ -920,15 +1102,9 @@ func (s *Server) handleReInvite(req *sip.Request, tx sip.ServerTransaction, sess
 // remote side sees a confirmation of the agreed codec, not a new offer. Advertising
 // multiple codecs in a re-INVITE answer confuses Asterisk/FreeSWITCH and can cause
 // immediate call teardown ("remote codecs: None" in the peer's logs).
-func (s *Server) respondWithCurrentSDP(tx sip.ServerTransaction, req *sip.Request, session *Session) {
-	localIP, localPort := session.GetLocalRTP()
-	if localIP == "" {
-		localIP = s.listenConfig.GetExternalIP()
-	}
-	codec := session.GetNegotiatedCodec()
-	sdpConfig := s.NegotiatedSDPConfig(localIP, localPort, codec)
-	sdpBody := s.GenerateSDP(sdpConfig)
-	s.sendResponseWithSDPBody(tx, req, sdpBody)
+func (s *Server) respondWithCurrentSDP(tx sip.ServerTransaction, req *sip.Request, session *Session, headers ...sip.Header) {
+	sdpBody := s.GenerateSDP(s.currentSDPConfig(session))
+	s.sendResponseWithSDPBody(tx, req, sdpBody, headers...)
 }
 
 func (s *Server) handleAck(req *sip.Request, tx sip.ServerTransaction) {
@@ -1006,7 +1182,7 @@ func (s *Server) handleBye(req *sip.Request, tx sip.ServerTransaction) {
 	// onInvite/startCall is still setting up, causing "Session already ended before
 	// startCall". Instead, handleOutboundDialog will call session.End() after
 	// onInvite returns and the select{} fires.
-	if info.Direction == CallDirectionOutbound {
+	if info.Direction == CallDirectionOutbound && !session.Migrated() {
 		// Notify the session that BYE was received BEFORE processing the dialog.
 		// This allows startCall (which may still be initializing) to detect the BYE
 		// via session.ByeReceived() and shut down gracefully — without relying on
@@ -1095,8 +1271,63 @@ func (s *Server) handleCancel(req *sip.Request, tx sip.ServerTransaction) {
 }
 
 func (s *Server) handleRegister(req *sip.Request, tx sip.ServerTransaction) {
-	s.logger.Debugw("REGISTER received")
-	s.sendResponse(tx, req, 200) // OK
+	s.logger.Debugw("REGISTER received", "source", req.Source(), "to", req.To().Address.String())
+	if s.registrar == nil {
+		s.sendResponse(tx, req, 200) // OK
+		return
+	}
+
+	// The REGISTER's To URI carries the assistant and API key like an INVITE,
+	// the chain resolves the vault credential the PBX authenticates against.
+	s.mu.RLock()
+	resolver := s.configResolver
+	s.mu.RUnlock()
+	if resolver == nil {
+		s.sendResponse(tx, req, 503)
+		return
+	}
+	result, err := resolver(&SIPRequestContext{
+		Method:  "REGISTER",
+		CallID:  req.CallID().Value(),
+		FromURI: req.From().Address.String(),
+		ToURI:   req.To().Address.String(),
+	})
+	if err != nil {
+		s.logger.Error("SIP REGISTER config resolution failed", "error", err, "source", req.Source())
+		s.sendResponse(tx, req, 500)
+		return
+	}
+	if !result.ShouldAllow {
+		s.logger.Warnw("REGISTER rejected by authentication chain",
+			"source", req.Source(),
+			"code", result.RejectCode,
+			"reason", result.RejectMsg)
+		s.sendResponse(tx, req, result.RejectCode)
+		return
+	}
+
+	resp := s.registrar.Register(req, result)
+	s.logger.Infow("REGISTER handled", "source", req.Source(), "status", resp.StatusCode)
+	if err := tx.Respond(resp); err != nil {
+		s.logger.Error("Failed to send REGISTER response", "error", err, "source", req.Source())
+	}
+}
+
+// matchRegistration finds the registered PBX an initial INVITE comes from.
+// Credentials in the To URI win, so a registered PBX can still dial any
+// assistant explicitly.
+func (s *Server) matchRegistration(req *sip.Request) (*Binding, bool) {
+	if s.registrar == nil {
+		return nil, false
+	}
+	if _, err := ParseCredentialsFromURI(req.To().Address.String()); err == nil {
+		return nil, false
+	}
+	binding, ok := s.registrar.Match(req)
+	if !ok || binding.Route == nil || binding.Route.Config == nil {
+		return nil, false
+	}
+	return binding, true
 }
 
 func (s *Server) handleOptions(req *sip.Request, tx sip.ServerTransaction) {
@@ -1133,12 +1364,18 @@ func (s *Server) handleUpdate(req *sip.Request, tx sip.ServerTransaction) {
 		return
 	}
 
+	// UPDATE without SDP is the usual session timer refresh (RFC 4028 §10).
+	timerHeaders, ok := s.acceptSessionRefresh(tx, req, session)
+	if !ok {
+		return
+	}
+
 	// If SDP body present, handle media renegotiation with hold detection
 	if body := req.Body(); len(body) > 0 {
 		sdpInfo, err := s.ParseSDP(body)
 		if err != nil {
 			s.logger.Warnw("Failed to parse UPDATE SDP", "error", err, "call_id", callID)
-			s.sendResponse(tx, req, 200) // Accept anyway to keep dialog alive
+			s.sendResponse(tx, req, 200, timerHeaders...) // Accept anyway to keep dialog alive
 			return
 		}
 
@@ -1149,43 +1386,11 @@ func (s *Server) handleUpdate(req *sip.Request, tx sip.ServerTransaction) {
 			"sdp_port", sdpInfo.AudioPort,
 			"is_hold", sdpInfo.IsHold())
 
-		// Only update remote RTP for active media (not hold)
-		if !sdpInfo.IsHold() {
-			rtpHandler := session.GetRTPHandler()
-			if rtpHandler != nil && sdpInfo.ConnectionIP != "" && sdpInfo.AudioPort > 0 {
-				rtpHandler.SetRemoteAddr(sdpInfo.ConnectionIP, sdpInfo.AudioPort)
-				session.SetRemoteRTP(sdpInfo.ConnectionIP, sdpInfo.AudioPort)
-				s.logger.Debugw("Updated remote RTP from UPDATE",
-					"call_id", callID,
-					"remote_rtp_ip", sdpInfo.ConnectionIP,
-					"remote_rtp_port", sdpInfo.AudioPort)
-			}
-
-			// Update codec if UPDATE proposes a different one
-			if sdpInfo.PreferredCodec != nil {
-				currentCodec := session.GetNegotiatedCodec()
-				if currentCodec == nil || currentCodec.PayloadType != sdpInfo.PreferredCodec.PayloadType {
-					rtpHandler := session.GetRTPHandler()
-					if rtpHandler != nil {
-						rtpHandler.SetCodec(sdpInfo.PreferredCodec)
-					}
-					session.SetNegotiatedCodec(sdpInfo.PreferredCodec.Name, int(sdpInfo.PreferredCodec.ClockRate))
-					s.logger.Infow("Codec updated from UPDATE",
-						"call_id", callID,
-						"new_codec", sdpInfo.PreferredCodec.Name,
-						"payload_type", sdpInfo.PreferredCodec.PayloadType)
-				}
-			}
-		} else {
-			s.logger.Infow("UPDATE indicates hold — keeping current RTP target",
-				"call_id", callID,
-				"sdp_direction", string(sdpInfo.Direction),
-				"sdp_ip", sdpInfo.ConnectionIP)
-		}
+		s.applyRemoteSDP(session, sdpInfo, "UPDATE")
 
-		s.respondWithCurrentSDP(tx, req, session)
+		s.respondWithCurrentSDP(tx, req, session, timerHeaders...)
 	} else {
-		s.sendResponse(tx, req, 200)
+		s.sendResponse(tx, req, 200, timerHeaders...)
 	}
 
 	s.logger.Debugw("UPDATE handled", "call_id", callID)
@@ -1320,8 +1525,11 @@ func (s *Server) handleUnknownRequest(req *sip.Request, tx sip.ServerTransaction
 	}
 }
 
-func (s *Server) sendResponse(tx sip.ServerTransaction, req *sip.Request, statusCode int) {
+func (s *Server) sendResponse(tx sip.ServerTransaction, req *sip.Request, statusCode int, headers ...sip.Header) {
 	resp := sip.NewResponseFromRequest(req, statusCode, "", nil)
+	for _, h := range headers {
+		resp.AppendHeader(h)
+	}
 	if err := tx.Respond(resp); err != nil {
 		s.logger.Error("Failed to send SIP response",
 			"error", err,
@@ -1333,12 +1541,15 @@ func (s *Server) sendResponse(tx sip.ServerTransaction, req *sip.Request, status
 // sendResponseWithSDPBody sends a SIP 200 OK response with the given SDP body.
 // Adds a Contact header (required by RFC 3261 §13.3.1.1 for INVITE/re-INVITE responses)
 // so that Asterisk, Twilio, and other providers know where to send subsequent requests.
-func (s *Server) sendResponseWithSDPBody(tx sip.ServerTransaction, req *sip.Request, sdpBody string) {
+func (s *Server) sendResponseWithSDPBody(tx sip.ServerTransaction, req *sip.Request, sdpBody string, headers ...sip.Header) {
 	s.logger.Debugw("Sending SIP response with SDP",
 		"call_id", req.CallID().Value(),
 		"method", req.Method,
 		"sdp_body", sdpBody)
 	resp := sip.NewSDPResponseFromRequest(req, []byte(sdpBody))
+	for _, h := range headers {
+		resp.AppendHeader(h)
+	}
 
 	// Add Contact header if not already present — mandatory for INVITE/re-INVITE 200 OK.
 	// Without this, Asterisk and other providers cannot route subsequent in-dialog requests
@@ -1418,6 +1629,22 @@ func (s *Server) EndCall(session *Session) error {
 		}
 	}
 
+	// For calls migrated from another instance, send BYE through the dialog
+	// taken over.
+	if d := session.getMigratedDialog(); d != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if res, err := s.doMigrated(ctx, d, sip.NewRequest(sip.BYE, d.target())); err != nil {
+			s.logger.Warnw("Failed to send BYE for migrated call",
+				"call_id", callID,
+				"error", err)
+		} else {
+			s.logger.Infow("Sent BYE for migrated call",
+				"call_id", callID,
+				"status", res.StatusCode)
+		}
+	}
+
 	// Remove session from active sessions (releases RTP port)
 	s.removeSession(callID)
 
@@ -1433,6 +1660,13 @@ func (s *Server) MakeCall(ctx context.Context, cfg *Config, toURI, fromURI strin
 		return nil, fmt.Errorf("SIP server is not running")
 	}
 
+	// Resolve where the INVITE goes before any media resources are taken,
+	// an invalid SIP URI fails the call right away.
+	dest, err := ResolveDestination(cfg, toURI)
+	if err != nil {
+		return nil, err
+	}
+
 	// Allocate an RTP port from the shared pool
 	rtpPort, err := s.rtpAllocator.Allocate()
 	if err != nil {
@@ -1470,33 +1704,39 @@ func (s *Server) MakeCall(ctx context.Context, cfg *Config, toURI, fromURI strin
 		"listen_config_external_ip", s.listenConfig.ExternalIP,
 		"listen_config_address", s.listenConfig.Address)
 
-	// Build SDP offer — advertise external IP so remote peer can reach us
-	sdpBody := s.GenerateSDP(DefaultSDPConfig(externalIP, localPort))
+	if cfg.Concealment {
+		rtpHandler.EnableConcealment()
+	}
+	if cfg.ComfortNoise != 0 {
+		rtpHandler.EnableComfortNoise(cfg.ComfortNoise)
+	}
+
+	// Build SDP offer — advertise external IP so remote peer can reach us.
+	// RED is offered here and only used once the answer accepts it.
+	sdpConfig := DefaultSDPConfig(externalIP, localPort)
+	if cfg.Redundancy {
+		sdpConfig.REDPayloadType = CodecRED.PayloadType
+	}
+	var srtpKeys *SRTPKeys
+	if dest.SRTP {
+		if srtpKeys, err = NewSRTPKeys(); err != nil {
+			rtpHandler.Stop()
+			s.rtpAllocator.Release(rtpPort)
+			return nil, err
+		}
+		sdpConfig.Crypto = srtpKeys
+	}
+	sdpBody := s.GenerateSDP(sdpConfig)
 
 	s.logger.Debugw("Outbound INVITE SDP offer",
 		"external_ip", externalIP,
 		"rtp_port", localPort,
 		"sdp_body", sdpBody)
 
-	scheme := "sip"
-	if cfg.Transport == TransportTLS {
-		scheme = "sips"
-	}
-
-	// Build recipient URI — target the SIP server/proxy (works for all providers)
-	recipient := sip.Uri{
-		Scheme: scheme,
-		Host:   cfg.Server,
-		Port:   cfg.Port,
-		User:   toURI,
-	}
-	// Add transport parameter for TCP/TLS so the proxy routes correctly
-	if cfg.Transport == TransportTLS || cfg.Transport == TransportTCP {
-		if recipient.UriParams == nil {
-			recipient.UriParams = sip.NewParams()
-		}
-		recipient.UriParams.Add("transport", string(cfg.Transport))
-	}
+	// Phone numbers go through the configured server, SIP URIs are dialled
+	// as given. The From header follows the scheme of the request URI.
+	recipient := dest.URI
+	scheme := recipient.Scheme
 
 	// Build From header:
 	//   - User: determined by CallerID > fromURI > cfg.Username (auth identity).
@@ -1505,7 +1745,7 @@ func (s *Server) MakeCall(ctx context.Context, cfg *Config, toURI, fromURI strin
 	//     the From user defaults to cfg.Username — this is critical because Asterisk
 	//     PJSIP resolves the endpoint from the From URI, and a mismatch between
 	//     From user and auth username causes "Failed to authenticate" errors.
-	//   - DisplayName: fromURI (shown as caller name / presentation number)
+	//   - DisplayName: cfg.CallerName if set, else fromURI (shown as caller name / presentation number)
 	//   - Domain: cfg.Domain if set (cloud providers use their domain), else cfg.Server
 	fromDomain := cfg.Domain
 	if fromDomain == "" {
@@ -1521,8 +1761,13 @@ func (s *Server) MakeCall(ctx context.Context, cfg *Config, toURI, fromURI strin
 		fromUser = cfg.CallerID
 	}
 
+	displayName := fromURI
+	if name := CallerName(cfg.CallerName); name != "" {
+		displayName = name
+	}
+
 	fromHDR := &sip.FromHeader{
-		DisplayName: fromURI,
+		DisplayName: displayName,
 		Address: sip.Uri{
 			Scheme: scheme,
 			User:   fromUser,
@@ -1535,7 +1780,11 @@ func (s *Server) MakeCall(ctx context.Context, cfg *Config, toURI, fromURI strin
 	// Send INVITE via DialogClientCache — the cache stores the dialog once established
 	// so that incoming BYE/re-INVITE can be matched to it via dialogClientCache.ReadBye
 	// and dialogClientCache.MatchRequestDialog.
-	dialogSession, err := s.dialogClientCache.Invite(ctx, recipient, []byte(sdpBody), fromHDR)
+	// Ask for a session timer (RFC 4028) so stateful proxies keep the dialog
+	// for calls longer than their default session lifetime.
+	inviteHeaders := append([]sip.Header{fromHDR}, sessionTimerOfferHeaders(SessionTimer{Interval: DefaultSessionExpires})...)
+	inviteHeaders = append(inviteHeaders, identityHeaders(cfg.IdentityHeaders, fromHDR)...)
+	dialogSession, err := s.dialogClientCache.Invite(ctx, recipient, []byte(sdpBody), inviteHeaders...)
 	if err != nil {
 		rtpHandler.Stop()
 		s.rtpAllocator.Release(rtpPort)
@@ -1606,13 +1855,48 @@ func (s *Server) MakeCall(ctx context.Context, cfg *Config, toURI, fromURI strin
 	s.mu.Unlock()
 
 	// Handle the call lifecycle in background
-	go s.handleOutboundDialog(session, rtpHandler, dialogSession)
+	go s.handleOutboundDialog(session, rtpHandler, dialogSession, srtpKeys)
 
 	return session, nil
 }
 
-// handleOutboundDialog processes the outbound dialog lifecycle
-func (s *Server) handleOutboundDialog(session *Session, rtpHandler *RTPHandler, dialogSession *sipgo.DialogClientSession) {
+// Dial places an outbound call leg that is not attached to an assistant, such
+// as the human agent leg of a warm transfer. It blocks until the callee
+// answers, the call fails or ringTimeout elapses; an unanswered leg is
+// cancelled. The returned session is owned by the caller, who ends it with
+// EndCall.
+func (s *Server) Dial(ctx context.Context, cfg *Config, toURI, fromURI string, ringTimeout time.Duration) (*Session, error) {
+	session, err := s.MakeCall(ctx, cfg, toURI, fromURI, map[string]interface{}{MetadataBridgeLeg: true})
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(ringTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case event, ok := <-session.Events():
+			if !ok {
+				return nil, fmt.Errorf("call to %s ended before it was answered", toURI)
+			}
+			if event.Type == EventTypeConnected {
+				return session, nil
+			}
+		case <-session.Context().Done():
+			return nil, fmt.Errorf("call to %s was not answered", toURI)
+		case <-timer.C:
+			session.End()
+			return nil, fmt.Errorf("call to %s was not answered within %s", toURI, ringTimeout)
+		case <-ctx.Done():
+			session.End()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// handleOutboundDialog processes the outbound dialog lifecycle. srtpKeys are
+// the keys offered in the INVITE, nil when the call uses plain RTP.
+func (s *Server) handleOutboundDialog(session *Session, rtpHandler *RTPHandler, dialogSession *sipgo.DialogClientSession, srtpKeys *SRTPKeys) {
 	callID := session.GetCallID()
 
 	// Ensure dialog resources are cleaned up when the goroutine exits.
@@ -1748,7 +2032,14 @@ func (s *Server) handleOutboundDialog(session *Session, rtpHandler *RTPHandler,
 	// so subsequent re-INVITE responses advertise only the negotiated codec.
 	var remoteRTPIP string
 	var remoteRTPPort int
+	// srtpErr is set when SRTP was offered but the answer does not use it.
+	// Plain RTP is never sent in that case, the call is hung up after the ACK.
+	var srtpErr error
+	if srtpKeys != nil {
+		srtpErr = fmt.Errorf("answer does not accept SRTP")
+	}
 	if dialogSession.InviteResponse != nil {
+		session.SetRemoteEndpoint(dialogSession.InviteResponse)
 		if body := dialogSession.InviteResponse.Body(); len(body) > 0 {
 			s.logger.Debugw("Outbound call 200 OK SDP answer (raw)",
 				"call_id", callID,
@@ -1777,6 +2068,13 @@ func (s *Server) handleOutboundDialog(session *Session, rtpHandler *RTPHandler,
 						"call_id", callID,
 						"remote_payload_types", sdpInfo.PayloadTypes)
 				}
+				if session.config.Redundancy && sdpInfo.REDPayloadType != 0 {
+					rtpHandler.EnableRedundancy(sdpInfo.REDPayloadType)
+				}
+				rtpHandler.SetTelephoneEventPayloadType(sdpInfo.TelephoneEventPayloadType)
+				if srtpKeys != nil && sdpInfo.Secure && len(sdpInfo.Crypto) > 0 {
+					srtpErr = rtpHandler.EnableSRTP(srtpKeys, sdpInfo.Crypto[0])
+				}
 			} else if parseErr != nil {
 				s.logger.Warnw("Failed to parse remote SDP from 200 OK",
 					"call_id", callID,
@@ -1791,6 +2089,19 @@ func (s *Server) handleOutboundDialog(session *Session, rtpHandler *RTPHandler,
 
 	// Step 2: Start RTP — sends the first silence packet synchronously, then
 	// launches sendLoop. This fires BEFORE ACK so Asterisk sees media immediately.
+	if srtpErr != nil {
+		// The 200 OK still has to be acknowledged before the BYE.
+		s.logger.Error("Outbound call answered without usable SRTP, hanging up",
+			"call_id", callID,
+			"error", srtpErr)
+		if err := dialogSession.Ack(session.ctx); err != nil {
+			s.logger.Warnw("Failed to send ACK", "error", err, "call_id", callID)
+		}
+		session.SetState(CallStateFailed)
+		rtpHandler.Stop()
+		s.EndCall(session)
+		return
+	}
 	rtpHandler.Start()
 
 	localIP, localPort := rtpHandler.LocalAddr()
@@ -1818,11 +2129,22 @@ func (s *Server) handleOutboundDialog(session *Session, rtpHandler *RTPHandler,
 
 	session.SetState(CallStateConnected)
 
+	// Start the session timer granted in the 200 OK. As UAC of the INVITE we
+	// refresh unless the answer made the remote side the refresher.
+	if granted, ok := sessionTimerFromMessage(dialogSession.InviteResponse); ok {
+		session.SetSessionTimer(granted.Interval, granted.Refresher != RefresherUAS)
+		s.startSessionTimer(session)
+	}
+
 	// Notify invite handler (which starts the conversation — may do DB lookups).
 	// RTP silence is already flowing, so Asterisk won't time out during this.
+	// Bridge legs placed by Dial carry no assistant, nothing to start for them.
 	s.mu.RLock()
 	onInvite := s.onInvite
 	s.mu.RUnlock()
+	if _, bridgeLeg := session.GetMetadata(MetadataBridgeLeg); bridgeLeg {
+		onInvite = nil
+	}
 	if onInvite != nil {
 		info := session.GetInfo()
 		s.logger.Infow("Starting onInvite handler for outbound call",
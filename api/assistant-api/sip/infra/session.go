@@ -10,11 +10,13 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/emiago/sipgo"
+	"github.com/emiago/sipgo/sip"
 	"github.com/google/uuid"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/protos"
@@ -85,9 +87,26 @@ type Session struct {
 	// nil for outbound calls.
 	dialogServerSession *sipgo.DialogServerSession
 
+	// Dialog set up by another instance this one took the call over from,
+	// see migration.go. nil unless the call was migrated here.
+	migratedDialog *migratedDialog
+
+	// handedOver is set once another instance took the call over; the
+	// dialog goes on there, so ending the session sends no BYE.
+	handedOver atomic.Bool
+
+	// localHold is set while this side holds the call, see hold.go.
+	localHold atomic.Bool
+
 	// onDisconnect is called during Close/End to perform transport-level call teardown
 	// (e.g., sending SIP BYE). Set by the server that owns this session.
 	onDisconnect func(session *Session)
+
+	// RFC 4028 session timer. sessionExpires is zero when no timer was
+	// negotiated; refreshLocal is true when we send the refreshes.
+	sessionExpires time.Duration
+	refreshLocal   bool
+	refreshed      chan struct{}
 }
 
 // NewSession creates a new SIP session
@@ -140,6 +159,7 @@ func NewSession(ctx context.Context, cfg *SessionConfig) (*Session, error) {
 		assistant:       cfg.Assistant,
 		vaultCredential: cfg.VaultCredential,
 		byeReceived:     make(chan struct{}),
+		refreshed:       make(chan struct{}, 1),
 	}
 
 	return session, nil
@@ -183,9 +203,16 @@ func (s *Session) SetState(state CallState) {
 
 	switch state {
 	case CallStateConnected:
+		if previousState == CallStateOnHold {
+			// the call was connected all along, keep its connect time
+			s.emitEvent(EventTypeResume, nil)
+			break
+		}
 		now := time.Now()
 		s.info.ConnectedTime = &now
 		s.emitEvent(EventTypeConnected, nil)
+	case CallStateOnHold:
+		s.emitEvent(EventTypeHold, nil)
 	case CallStateEnded:
 		now := time.Now()
 		s.info.EndTime = &now
@@ -264,6 +291,28 @@ func (s *Session) SetRemoteRTP(addr string, port int) {
 	s.info.RemoteRTPAddress = fmt.Sprintf("%s:%d", addr, port)
 }
 
+// SetRemoteEndpoint records the far end of the call from its INVITE or its
+// answer.
+func (s *Session) SetRemoteEndpoint(msg sip.Message) {
+	userAgent := headerValue(msg, "User-Agent")
+	if userAgent == "" {
+		// UAS identify themselves with Server in responses
+		userAgent = headerValue(msg, "Server")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.info.RemoteUserAgent = userAgent
+	s.info.SignalingTransport = strings.ToLower(msg.Transport())
+	s.info.SignalingAddress = msg.Source()
+}
+
+func headerValue(msg sip.Message, name string) string {
+	if hs := msg.GetHeaders(name); len(hs) > 0 {
+		return hs[0].Value()
+	}
+	return ""
+}
+
 // SetLocalRTP sets the local RTP address
 func (s *Session) SetLocalRTP(addr string, port int) {
 	s.mu.Lock()
@@ -397,6 +446,37 @@ func (s *Session) GetDialogServerSession() *sipgo.DialogServerSession {
 	return s.dialogServerSession
 }
 
+func (s *Session) setMigratedDialog(d *migratedDialog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.migratedDialog = d
+}
+
+func (s *Session) getMigratedDialog() *migratedDialog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.migratedDialog
+}
+
+// Migrated reports whether the call was taken over from another instance.
+func (s *Session) Migrated() bool {
+	return s.getMigratedDialog() != nil
+}
+
+// markHandedOver drops the teardown of the call, the instance that took it
+// over ends it.
+func (s *Session) markHandedOver() {
+	s.handedOver.Store(true)
+	s.mu.Lock()
+	s.onDisconnect = nil
+	s.mu.Unlock()
+}
+
+// HandedOver reports whether another instance took the call over.
+func (s *Session) HandedOver() bool {
+	return s.handedOver.Load()
+}
+
 // SetOnDisconnect registers a callback that is invoked when the session is disconnected.
 // This allows the SIP server to inject transport-level call teardown (e.g., sending BYE)
 // without the session needing to know about SIP signaling internals.
@@ -556,6 +636,36 @@ func (s *Session) ByeReceived() <-chan struct{} {
 	return s.byeReceived
 }
 
+// SetSessionTimer records the negotiated session interval and whether this
+// side is the refresher.
+func (s *Session) SetSessionTimer(interval time.Duration, refreshLocal bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessionExpires = interval
+	s.refreshLocal = refreshLocal
+}
+
+// GetSessionTimer returns the negotiated session interval (zero when none)
+// and whether this side is the refresher.
+func (s *Session) GetSessionTimer() (time.Duration, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sessionExpires, s.refreshLocal
+}
+
+// MarkRefreshed records that the remote side refreshed the session with a
+// re-INVITE or UPDATE, restarting the expiry countdown.
+func (s *Session) MarkRefreshed() {
+	select {
+	case s.refreshed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Session) sessionRefreshed() <-chan struct{} {
+	return s.refreshed
+}
+
 // GetState returns the current session state
 func (s *Session) GetState() CallState {
 	s.mu.RLock()
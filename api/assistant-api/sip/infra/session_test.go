@@ -0,0 +1,84 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/emiago/sipgo/sip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSession(t *testing.T) *Session {
+	t.Helper()
+	session, err := NewSession(context.Background(), &SessionConfig{
+		Config: &Config{
+			Server:            "127.0.0.1",
+			Port:              5060,
+			RTPPortRangeStart: 10000,
+			RTPPortRangeEnd:   10100,
+		},
+		Direction: CallDirectionInbound,
+	})
+	require.NoError(t, err)
+	return session
+}
+
+func nextEvent(t *testing.T, session *Session) EventType {
+	t.Helper()
+	select {
+	case event := <-session.Events():
+		return event.Type
+	default:
+		t.Fatal("no event emitted")
+		return ""
+	}
+}
+
+func TestSession_HoldAndResume(t *testing.T) {
+	session := testSession(t)
+	session.SetState(CallStateConnected)
+	assert.Equal(t, EventTypeConnected, nextEvent(t, session))
+	connectedAt := session.GetInfo().ConnectedTime
+
+	session.SetState(CallStateOnHold)
+	assert.Equal(t, EventTypeHold, nextEvent(t, session))
+	assert.True(t, session.IsActive())
+
+	session.SetState(CallStateConnected)
+	assert.Equal(t, EventTypeResume, nextEvent(t, session))
+	assert.Equal(t, connectedAt, session.GetInfo().ConnectedTime, "resume keeps the connect time")
+}
+
+func TestParseHoldAudio(t *testing.T) {
+	assert.Equal(t, HoldAudioSilence, ParseHoldAudio("silence"))
+	assert.Equal(t, HoldAudioTone, ParseHoldAudio(" Tone "))
+	assert.Equal(t, HoldAudioNone, ParseHoldAudio(""))
+	assert.Equal(t, HoldAudioNone, ParseHoldAudio("music"))
+}
+
+func TestSession_SetRemoteEndpoint(t *testing.T) {
+	session := testSession(t)
+	req := sip.NewRequest(sip.INVITE, sip.Uri{User: "assistant", Host: "127.0.0.1"})
+	req.AppendHeader(sip.NewHeader("User-Agent", "Zoiper rv2.10"))
+	req.SetTransport("UDP")
+	req.SetSource("203.0.113.7:5060")
+	session.SetRemoteEndpoint(req)
+
+	info := session.GetInfo()
+	assert.Equal(t, "Zoiper rv2.10", info.RemoteUserAgent)
+	assert.Equal(t, "udp", info.SignalingTransport)
+	assert.Equal(t, "203.0.113.7:5060", info.SignalingAddress)
+
+	// a carrier answering an outbound call names itself with Server
+	res := sip.NewResponseFromRequest(sip.NewRequest(sip.INVITE, sip.Uri{Host: "127.0.0.1"}), sip.StatusOK, "OK", nil)
+	res.AppendHeader(sip.NewHeader("Server", "Asterisk PBX 20.5.0"))
+	session.SetRemoteEndpoint(res)
+	assert.Equal(t, "Asterisk PBX 20.5.0", session.GetInfo().RemoteUserAgent)
+}
@@ -0,0 +1,424 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emiago/sipgo/sip"
+)
+
+// Session timer defaults (RFC 4028).
+const (
+	// DefaultSessionExpires is the interval we ask for on outbound calls and
+	// grant when an inbound caller supports timers but does not propose one.
+	DefaultSessionExpires = 1800 * time.Second
+
+	// MinSessionExpires is the smallest interval we accept (RFC 4028 §4).
+	// Shorter intervals are rejected with 422 Session Interval Too Small.
+	MinSessionExpires = 90 * time.Second
+
+	// sessionRefreshTimeout bounds a single refresh transaction.
+	sessionRefreshTimeout = 32 * time.Second
+)
+
+// Refresher names which side of a transaction keeps the session alive.
+type Refresher string
+
+const (
+	RefresherUAC Refresher = "uac"
+	RefresherUAS Refresher = "uas"
+)
+
+// SessionTimer is a Session-Expires value as carried in a request or response.
+type SessionTimer struct {
+	Interval  time.Duration
+	Refresher Refresher
+}
+
+// String renders the timer as a Session-Expires header value.
+func (t SessionTimer) String() string {
+	value := strconv.Itoa(int(t.Interval / time.Second))
+	if t.Refresher != "" {
+		value += ";refresher=" + string(t.Refresher)
+	}
+	return value
+}
+
+// ParseSessionExpires parses a Session-Expires header value such as
+// "1800;refresher=uac".
+func ParseSessionExpires(value string) (SessionTimer, error) {
+	parts := strings.Split(value, ";")
+	seconds, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || seconds <= 0 {
+		return SessionTimer{}, fmt.Errorf("invalid Session-Expires %q", value)
+	}
+	timer := SessionTimer{Interval: time.Duration(seconds) * time.Second}
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(key, "refresher") {
+			continue
+		}
+		switch Refresher(strings.ToLower(strings.TrimSpace(val))) {
+		case RefresherUAC:
+			timer.Refresher = RefresherUAC
+		case RefresherUAS:
+			timer.Refresher = RefresherUAS
+		default:
+			return SessionTimer{}, fmt.Errorf("invalid Session-Expires refresher %q", val)
+		}
+	}
+	return timer, nil
+}
+
+// sessionTimerFromMessage reads Session-Expires (or its compact form "x").
+func sessionTimerFromMessage(msg sip.Message) (SessionTimer, bool) {
+	hdr := firstHeader(msg, "Session-Expires", "x")
+	if hdr == nil {
+		return SessionTimer{}, false
+	}
+	timer, err := ParseSessionExpires(hdr.Value())
+	if err != nil {
+		return SessionTimer{}, false
+	}
+	return timer, true
+}
+
+// minSEFromMessage reads the Min-SE header, zero when absent or malformed.
+func minSEFromMessage(msg sip.Message) time.Duration {
+	hdr := firstHeader(msg, "Min-SE")
+	if hdr == nil {
+		return 0
+	}
+	value, _, _ := strings.Cut(hdr.Value(), ";")
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// hasOptionTag reports whether any of the given list headers carries tag,
+// e.g. "timer" in Supported or "UPDATE" in Allow.
+func hasOptionTag(msg sip.Message, tag string, names ...string) bool {
+	for _, name := range names {
+		for _, hdr := range msg.GetHeaders(name) {
+			for _, item := range strings.Split(hdr.Value(), ",") {
+				if strings.EqualFold(strings.TrimSpace(item), tag) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func firstHeader(msg sip.Message, names ...string) sip.Header {
+	for _, name := range names {
+		if hdrs := msg.GetHeaders(name); len(hdrs) > 0 {
+			return hdrs[0]
+		}
+	}
+	return nil
+}
+
+// negotiateSessionTimer decides the session timer we answer a session
+// refresh or initial INVITE with, acting as UAS (RFC 4028 §9). ok is false
+// when no timer applies. A proposed interval below MinSessionExpires must
+// be rejected with 422 by the caller, tooSmall reports that case.
+func negotiateSessionTimer(req *sip.Request) (timer SessionTimer, ok bool, tooSmall bool) {
+	supported := hasOptionTag(req, "timer", "Supported", "k", "Require")
+	proposed, present := sessionTimerFromMessage(req)
+	if !present {
+		if !supported {
+			return SessionTimer{}, false, false
+		}
+		return SessionTimer{Interval: DefaultSessionExpires, Refresher: RefresherUAS}, true, false
+	}
+	if proposed.Interval < MinSessionExpires {
+		return SessionTimer{}, false, true
+	}
+	// A caller without timer support can not refresh, and one that left the
+	// choice to us gets us as refresher: we know we will actually do it.
+	if !supported || proposed.Refresher == "" {
+		proposed.Refresher = RefresherUAS
+	}
+	return proposed, true, false
+}
+
+// sessionTimerHeaders are the headers a 2xx carries for a negotiated timer.
+// Require: timer is only added when the request supports it (RFC 4028 §9).
+func sessionTimerHeaders(req *sip.Request, timer SessionTimer) []sip.Header {
+	headers := []sip.Header{sip.NewHeader("Session-Expires", timer.String())}
+	if hasOptionTag(req, "timer", "Supported", "k", "Require") {
+		headers = append(headers, sip.NewHeader("Require", "timer"))
+	}
+	return headers
+}
+
+// minSEHeaders are the headers of a 422 Session Interval Too Small.
+func minSEHeaders() []sip.Header {
+	return []sip.Header{sip.NewHeader("Min-SE", strconv.Itoa(int(MinSessionExpires/time.Second)))}
+}
+
+// sessionTimerOfferHeaders are the headers of a request asking for a timer,
+// an initial INVITE or one of our refreshes.
+func sessionTimerOfferHeaders(timer SessionTimer) []sip.Header {
+	return append([]sip.Header{
+		sip.NewHeader("Supported", "timer"),
+		sip.NewHeader("Session-Expires", timer.String()),
+	}, minSEHeaders()...)
+}
+
+// acceptSessionRefresh handles the session timer part of an incoming
+// re-INVITE or UPDATE. It returns the headers for the 2xx, or false when the
+// request was already rejected with 422.
+func (s *Server) acceptSessionRefresh(tx sip.ServerTransaction, req *sip.Request, session *Session) ([]sip.Header, bool) {
+	timer, ok, tooSmall := negotiateSessionTimer(req)
+	if tooSmall {
+		s.logger.Warnw("Session refresh below Min-SE, rejecting with 422", "call_id", session.GetCallID())
+		s.sendResponse(tx, req, 422, minSEHeaders()...)
+		return nil, false
+	}
+	session.MarkRefreshed()
+	if !ok {
+		return nil, true
+	}
+	applyAnsweredSessionTimer(session, timer)
+	return sessionTimerHeaders(req, timer), true
+}
+
+// applyAnsweredSessionTimer records the timer granted to an incoming request
+// on the session. As UAS of that request we refresh when the refresher is uas.
+func applyAnsweredSessionTimer(session *Session, timer SessionTimer) {
+	session.SetSessionTimer(timer.Interval, timer.Refresher == RefresherUAS)
+}
+
+// startSessionTimer keeps the dialog alive once it is confirmed. Without a
+// negotiated timer it does nothing.
+func (s *Server) startSessionTimer(session *Session) {
+	if interval, _ := session.GetSessionTimer(); interval <= 0 {
+		return
+	}
+	go s.runSessionTimer(session)
+}
+
+// runSessionTimer refreshes the session at half the interval when we are the
+// refresher. Otherwise it waits for the remote refresh and tears the call
+// down if none arrives before expiry (RFC 4028 §10).
+func (s *Server) runSessionTimer(session *Session) {
+	callID := session.GetCallID()
+	for {
+		interval, local := session.GetSessionTimer()
+		if interval <= 0 {
+			return
+		}
+		wait := interval / 2
+		if !local {
+			wait = interval - min(sessionRefreshTimeout, interval/3)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-session.Context().Done():
+			timer.Stop()
+			return
+		case <-session.sessionRefreshed():
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+
+		if !local {
+			s.logger.Warnw("Session timer expired without refresh — ending call",
+				"call_id", callID,
+				"session_expires", interval)
+			s.expireSession(session)
+			return
+		}
+
+		if err := s.refreshSession(session); err != nil {
+			if errors.Is(err, ErrSessionExpired) {
+				s.logger.Warnw("Session refresh rejected — ending call",
+					"call_id", callID,
+					"error", err)
+				s.expireSession(session)
+				return
+			}
+			s.logger.Warnw("Session refresh failed, retrying next interval",
+				"call_id", callID,
+				"error", err)
+		}
+	}
+}
+
+// refreshSession sends a session refresh as UAC of a new in-dialog
+// transaction. UPDATE is preferred since it needs no offer/answer, re-INVITE
+// with the current SDP is used for peers that do not allow UPDATE.
+func (s *Server) refreshSession(session *Session) error {
+	callID := session.GetCallID()
+	interval, _ := session.GetSessionTimer()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		res, err := s.sendSessionRefresh(session, interval)
+		if err != nil {
+			return err
+		}
+		switch {
+		case res.IsSuccess():
+			// The answer may shorten the interval or hand the refresh over.
+			if granted, ok := sessionTimerFromMessage(res); ok {
+				session.SetSessionTimer(granted.Interval, granted.Refresher != RefresherUAS)
+			}
+			s.logger.Debugw("Session refreshed",
+				"call_id", callID,
+				"status", res.StatusCode)
+			return nil
+		case res.StatusCode == 422:
+			// Session Interval Too Small — retry once with the peer's Min-SE.
+			minSE := minSEFromMessage(res)
+			if minSE <= interval {
+				return fmt.Errorf("422 without usable Min-SE")
+			}
+			interval = minSE
+			session.SetSessionTimer(interval, true)
+		case res.StatusCode == 408 || res.StatusCode == 481:
+			return fmt.Errorf("%w: refresh answered %d %s", ErrSessionExpired, res.StatusCode, res.Reason)
+		default:
+			return fmt.Errorf("refresh answered %d %s", res.StatusCode, res.Reason)
+		}
+	}
+	return fmt.Errorf("session refresh not accepted")
+}
+
+// sendSessionRefresh sends one UPDATE or re-INVITE through whichever dialog
+// the session holds and returns the final response.
+func (s *Server) sendSessionRefresh(session *Session, interval time.Duration) (*sip.Response, error) {
+	dialog, ok := s.dialogOf(session)
+	if !ok {
+		return nil, fmt.Errorf("%w: no dialog to refresh", ErrSessionExpired)
+	}
+
+	method := sip.UPDATE
+	if !hasOptionTag(dialog.peer, "UPDATE", "Allow") {
+		method = sip.INVITE
+	}
+
+	req := sip.NewRequest(method, *dialog.target.Clone())
+	for _, h := range sessionTimerOfferHeaders(SessionTimer{Interval: interval, Refresher: RefresherUAC}) {
+		req.AppendHeader(h)
+	}
+	if method == sip.INVITE {
+		req.AppendHeader(sip.NewHeader("Content-Type", "application/sdp"))
+		req.SetBody([]byte(s.GenerateSDP(s.currentSDPConfig(session))))
+	}
+
+	ctx, cancel := context.WithTimeout(session.Context(), sessionRefreshTimeout)
+	defer cancel()
+	res, err := dialog.do(ctx, req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: no answer to %s refresh", ErrSessionExpired, method)
+		}
+		return nil, err
+	}
+
+	// A 2xx to re-INVITE must be acknowledged within the dialog.
+	if method == sip.INVITE && res.IsSuccess() {
+		dialog.ack(s, session)
+	}
+	return res, nil
+}
+
+// inDialog sends requests within the dialog of a session, whichever side
+// set it up.
+type inDialog struct {
+	target   sip.Uri
+	peer     sip.Message // the last message of the remote party setting up the dialog
+	do       func(ctx context.Context, req *sip.Request) (*sip.Response, error)
+	writeAck func(req *sip.Request) error
+}
+
+// dialogOf returns the dialog of a session, false when it has none yet.
+func (s *Server) dialogOf(session *Session) (*inDialog, bool) {
+	if ds := session.GetDialogClientSession(); ds != nil && ds.InviteResponse != nil {
+		target := ds.InviteRequest.Recipient
+		if contact := ds.InviteResponse.Contact(); contact != nil {
+			target = contact.Address
+		}
+		return &inDialog{target: target, peer: ds.InviteResponse, do: ds.Do, writeAck: ds.WriteRequest}, true
+	}
+	if ds := session.GetDialogServerSession(); ds != nil {
+		if contact := ds.InviteRequest.Contact(); contact != nil {
+			return &inDialog{target: contact.Address, peer: ds.InviteRequest, do: ds.Do, writeAck: ds.WriteRequest}, true
+		}
+		return nil, false
+	}
+	if d := session.getMigratedDialog(); d != nil {
+		peer := sip.Message(d.invite)
+		if d.uac {
+			peer = d.answer
+		}
+		return &inDialog{
+			target: d.target(),
+			peer:   peer,
+			do: func(ctx context.Context, req *sip.Request) (*sip.Response, error) {
+				return s.doMigrated(ctx, d, req)
+			},
+			writeAck: func(req *sip.Request) error {
+				return s.client.WriteRequest(d.build(req), withVia)
+			},
+		}, true
+	}
+	return nil, false
+}
+
+// ack acknowledges a 2xx to a re-INVITE sent within the dialog.
+func (d *inDialog) ack(s *Server, session *Session) {
+	if err := d.writeAck(sip.NewRequest(sip.ACK, *d.target.Clone())); err != nil {
+		s.logger.Warnw("Failed to ACK re-INVITE", "call_id", session.GetCallID(), "error", err)
+	}
+}
+
+// currentSDPConfig is the SDP the session offers or answers with mid-call:
+// the negotiated codec on the current local RTP address, sendonly while the
+// call is held from this side.
+func (s *Server) currentSDPConfig(session *Session) *SDPConfig {
+	localIP, localPort := session.GetLocalRTP()
+	if localIP == "" {
+		localIP = s.listenConfig.GetExternalIP()
+	}
+	cfg := withRedundancyOffer(s.NegotiatedSDPConfig(localIP, localPort, session.GetNegotiatedCodec()), session.GetRTPHandler())
+	if session.LocalHold() {
+		cfg.Direction = SDPDirectionSendOnly
+	}
+	return cfg
+}
+
+// expireSession ends a call whose session timer ran out. The application is
+// told as if the remote side hung up, then BYE is sent as RFC 4028 §10 asks.
+func (s *Server) expireSession(session *Session) {
+	session.NotifyBye()
+
+	s.mu.RLock()
+	onBye := s.onBye
+	s.mu.RUnlock()
+	if onBye != nil {
+		if err := onBye(session); err != nil {
+			s.logger.Warnw("BYE handler returned error", "error", err, "call_id", session.GetCallID())
+		}
+	}
+
+	if err := s.EndCall(session); err != nil {
+		s.logger.Warnw("Failed to end expired session", "error", err, "call_id", session.GetCallID())
+	}
+}
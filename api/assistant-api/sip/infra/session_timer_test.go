@@ -0,0 +1,95 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emiago/sipgo/sip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inviteWith(headers ...sip.Header) *sip.Request {
+	req := sip.NewRequest(sip.INVITE, sip.Uri{Scheme: "sip", User: "bob", Host: "example.com"})
+	for _, h := range headers {
+		req.AppendHeader(h)
+	}
+	return req
+}
+
+func TestParseSessionExpires(t *testing.T) {
+	timer, err := ParseSessionExpires("1800;refresher=uac")
+	require.NoError(t, err)
+	assert.Equal(t, 1800*time.Second, timer.Interval)
+	assert.Equal(t, RefresherUAC, timer.Refresher)
+	assert.Equal(t, "1800;refresher=uac", timer.String())
+
+	timer, err = ParseSessionExpires(" 90 ")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, timer.Interval)
+	assert.Empty(t, timer.Refresher)
+
+	_, err = ParseSessionExpires("soon")
+	assert.Error(t, err)
+	_, err = ParseSessionExpires("1800;refresher=proxy")
+	assert.Error(t, err)
+}
+
+func TestNegotiateSessionTimer_NoTimer(t *testing.T) {
+	_, ok, tooSmall := negotiateSessionTimer(inviteWith())
+	assert.False(t, ok)
+	assert.False(t, tooSmall)
+}
+
+func TestNegotiateSessionTimer_SupportedWithoutInterval(t *testing.T) {
+	timer, ok, _ := negotiateSessionTimer(inviteWith(sip.NewHeader("Supported", "replaces, timer")))
+	require.True(t, ok)
+	assert.Equal(t, DefaultSessionExpires, timer.Interval)
+	assert.Equal(t, RefresherUAS, timer.Refresher)
+}
+
+func TestNegotiateSessionTimer_KeepsCallerRefresher(t *testing.T) {
+	req := inviteWith(
+		sip.NewHeader("Supported", "timer"),
+		sip.NewHeader("Session-Expires", "600;refresher=uac"),
+	)
+	timer, ok, _ := negotiateSessionTimer(req)
+	require.True(t, ok)
+	assert.Equal(t, 600*time.Second, timer.Interval)
+	assert.Equal(t, RefresherUAC, timer.Refresher)
+
+	headers := sessionTimerHeaders(req, timer)
+	require.Len(t, headers, 2)
+	assert.Equal(t, "600;refresher=uac", headers[0].Value())
+	assert.Equal(t, "timer", headers[1].Value())
+}
+
+func TestNegotiateSessionTimer_CallerWithoutTimerSupport(t *testing.T) {
+	// a proxy inserted the interval, the caller can not refresh itself
+	req := inviteWith(sip.NewHeader("x", "900;refresher=uac"))
+	timer, ok, _ := negotiateSessionTimer(req)
+	require.True(t, ok)
+	assert.Equal(t, RefresherUAS, timer.Refresher)
+	assert.Len(t, sessionTimerHeaders(req, timer), 1)
+}
+
+func TestNegotiateSessionTimer_TooSmall(t *testing.T) {
+	_, ok, tooSmall := negotiateSessionTimer(inviteWith(
+		sip.NewHeader("Supported", "timer"),
+		sip.NewHeader("Session-Expires", "30"),
+	))
+	assert.False(t, ok)
+	assert.True(t, tooSmall)
+
+	res := sip.NewResponse(422, "Session Interval Too Small")
+	for _, h := range minSEHeaders() {
+		res.AppendHeader(h)
+	}
+	assert.Equal(t, MinSessionExpires, minSEFromMessage(res))
+}
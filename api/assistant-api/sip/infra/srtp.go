@@ -0,0 +1,158 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pion/srtp/v3"
+)
+
+// SRTP keys are exchanged in the SDP with SDES (RFC 4568). Only
+// AES_CM_128_HMAC_SHA1_80 is offered and accepted, it is the suite every
+// SIP endpoint supporting SRTP implements.
+const (
+	srtpSuite    = "AES_CM_128_HMAC_SHA1_80"
+	srtpKeyLen   = 16
+	srtpSaltLen  = 14
+	srtpProfile  = srtp.ProtectionProfileAes128CmHmacSha1_80
+	sdpSRTPProto = "RTP/SAVP"
+	sdpRTPProto  = "RTP/AVP"
+)
+
+// SRTPKeys is the master key and salt of one direction of an SRTP stream,
+// as carried by an a=crypto attribute.
+type SRTPKeys struct {
+	Tag  int
+	Key  []byte
+	Salt []byte
+}
+
+// NewSRTPKeys generates random keys for the stream we send.
+func NewSRTPKeys() (*SRTPKeys, error) {
+	material := make([]byte, srtpKeyLen+srtpSaltLen)
+	if _, err := rand.Read(material); err != nil {
+		return nil, fmt.Errorf("failed to generate SRTP keys: %w", err)
+	}
+	return &SRTPKeys{Tag: 1, Key: material[:srtpKeyLen], Salt: material[srtpKeyLen:]}, nil
+}
+
+// CryptoAttribute is the value of the a=crypto line offering the keys.
+func (k *SRTPKeys) CryptoAttribute() string {
+	material := append(append([]byte{}, k.Key...), k.Salt...)
+	return fmt.Sprintf("%d %s inline:%s", k.Tag, srtpSuite, base64.StdEncoding.EncodeToString(material))
+}
+
+// ParseCryptoAttribute reads the value of an a=crypto line:
+//
+//	1 AES_CM_128_HMAC_SHA1_80 inline:<base64 key||salt>[|lifetime][|MKI:length]
+//
+// Lifetimes are ignored, MKIs are not supported.
+func ParseCryptoAttribute(value string) (*SRTPKeys, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("malformed crypto attribute %q", value)
+	}
+	tag, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed crypto tag %q", fields[0])
+	}
+	if fields[1] != srtpSuite {
+		return nil, fmt.Errorf("unsupported crypto suite %s", fields[1])
+	}
+	inline, ok := strings.CutPrefix(fields[2], "inline:")
+	if !ok {
+		return nil, fmt.Errorf("unsupported key method in %q", fields[2])
+	}
+	parts := strings.Split(inline, "|")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("MKI is not supported")
+	}
+	material, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		// some endpoints leave out the padding
+		if material, err = base64.RawStdEncoding.DecodeString(parts[0]); err != nil {
+			return nil, fmt.Errorf("malformed key material: %w", err)
+		}
+	}
+	if len(material) != srtpKeyLen+srtpSaltLen {
+		return nil, fmt.Errorf("key material is %d bytes, expected %d", len(material), srtpKeyLen+srtpSaltLen)
+	}
+	return &SRTPKeys{Tag: tag, Key: material[:srtpKeyLen], Salt: material[srtpKeyLen:]}, nil
+}
+
+// srtpSession protects what the RTP handler sends with our keys and opens
+// what it receives with the remote party's. The contexts keep per-SSRC
+// state, each is used under its own lock.
+type srtpSession struct {
+	sendMu sync.Mutex
+	send   *srtp.Context
+	recvMu sync.Mutex
+	recv   *srtp.Context
+}
+
+func newSRTPSession(local, remote *SRTPKeys) (*srtpSession, error) {
+	send, err := srtp.CreateContext(local.Key, local.Salt, srtpProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SRTP send context: %w", err)
+	}
+	recv, err := srtp.CreateContext(remote.Key, remote.Salt, srtpProfile,
+		srtp.SRTPReplayProtection(64), srtp.SRTCPReplayProtection(64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SRTP receive context: %w", err)
+	}
+	return &srtpSession{send: send, recv: recv}, nil
+}
+
+func (s *srtpSession) protectRTP(packet []byte) ([]byte, error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.send.EncryptRTP(nil, packet, nil)
+}
+
+func (s *srtpSession) unprotectRTP(packet []byte) ([]byte, error) {
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
+	return s.recv.DecryptRTP(nil, packet, nil)
+}
+
+func (s *srtpSession) protectRTCP(packet []byte) ([]byte, error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.send.EncryptRTCP(nil, packet, nil)
+}
+
+func (s *srtpSession) unprotectRTCP(packet []byte) ([]byte, error) {
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
+	return s.recv.DecryptRTCP(nil, packet, nil)
+}
+
+// EnableSRTP switches the handler to SRTP, sending with local and
+// receiving with remote keys. Call it before Start.
+func (h *RTPHandler) EnableSRTP(local, remote *SRTPKeys) error {
+	session, err := newSRTPSession(local, remote)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	h.srtp = session
+	h.mu.Unlock()
+	return nil
+}
+
+// currentSRTP returns the SRTP session, nil for plain RTP.
+func (h *RTPHandler) currentSRTP() *srtpSession {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.srtp
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRTPKeys_CryptoAttributeRoundTrip(t *testing.T) {
+	keys, err := NewSRTPKeys()
+	require.NoError(t, err)
+	assert.Len(t, keys.Key, srtpKeyLen)
+	assert.Len(t, keys.Salt, srtpSaltLen)
+
+	attr := keys.CryptoAttribute()
+	assert.True(t, strings.HasPrefix(attr, "1 AES_CM_128_HMAC_SHA1_80 inline:"))
+
+	parsed, err := ParseCryptoAttribute(attr)
+	require.NoError(t, err)
+	assert.Equal(t, keys, parsed)
+
+	parsed, err = ParseCryptoAttribute(attr + "|2^31")
+	require.NoError(t, err, "lifetimes are ignored")
+	assert.Equal(t, keys.Key, parsed.Key)
+
+	unpadded := strings.TrimRight(attr, "=")
+	parsed, err = ParseCryptoAttribute(unpadded)
+	require.NoError(t, err)
+	assert.Equal(t, keys.Salt, parsed.Salt)
+}
+
+func TestParseCryptoAttribute_Rejects(t *testing.T) {
+	material := base64.StdEncoding.EncodeToString(make([]byte, srtpKeyLen+srtpSaltLen))
+	for name, value := range map[string]string{
+		"missing key": "1 AES_CM_128_HMAC_SHA1_80",
+		"bad tag":     "x AES_CM_128_HMAC_SHA1_80 inline:" + material,
+		"other suite": "1 AES_CM_128_HMAC_SHA1_32 inline:" + material,
+		"key method":  "1 AES_CM_128_HMAC_SHA1_80 uri:" + material,
+		"with MKI":    "1 AES_CM_128_HMAC_SHA1_80 inline:" + material + "|2^20|1:4",
+		"short key":   "1 AES_CM_128_HMAC_SHA1_80 inline:" + base64.StdEncoding.EncodeToString(make([]byte, 16)),
+		"not base64":  "1 AES_CM_128_HMAC_SHA1_80 inline:!!!!",
+	} {
+		_, err := ParseCryptoAttribute(value)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRTPHandler_SRTPRoundTrip(t *testing.T) {
+	localKeys, err := NewSRTPKeys()
+	require.NoError(t, err)
+	remoteKeys, err := NewSRTPKeys()
+	require.NoError(t, err)
+
+	local := bridgeLeg(t, CodecPCMU)
+	remote := bridgeLeg(t, CodecPCMU)
+	assert.Nil(t, local.currentSRTP(), "plain RTP until enabled")
+	require.NoError(t, local.EnableSRTP(localKeys, remoteKeys))
+	require.NoError(t, remote.EnableSRTP(remoteKeys, localKeys))
+
+	packet := local.serializeRTPPacket(local.createRTPPacket([]byte{1, 2, 3, 4}))
+	protected, err := local.currentSRTP().protectRTP(packet)
+	require.NoError(t, err)
+	assert.Len(t, protected, len(packet)+10, "80 bit authentication tag")
+	assert.NotEqual(t, packet[12:], protected[12:len(packet)])
+
+	opened, err := remote.currentSRTP().unprotectRTP(protected)
+	require.NoError(t, err)
+	assert.Equal(t, packet, opened)
+
+	_, err = remote.currentSRTP().unprotectRTP(protected)
+	assert.Error(t, err, "replayed packet")
+
+	tampered := local.serializeRTPPacket(local.createRTPPacket([]byte{5, 6, 7, 8}))
+	tampered, err = local.currentSRTP().protectRTP(tampered)
+	require.NoError(t, err)
+	tampered[len(tampered)-1] ^= 0xFF
+	_, err = remote.currentSRTP().unprotectRTP(tampered)
+	assert.Error(t, err, "authentication tag mismatch")
+
+	_, err = local.currentSRTP().unprotectRTP(protected)
+	assert.Error(t, err, "own packets do not open with the remote keys")
+}
+
+func TestSDP_CryptoOfferAndParse(t *testing.T) {
+	s := &Server{}
+	keys, err := NewSRTPKeys()
+	require.NoError(t, err)
+
+	cfg := DefaultSDPConfig("10.0.0.1", 10000)
+	cfg.Crypto = keys
+	sdp := s.GenerateSDP(cfg)
+	assert.Contains(t, sdp, "m=audio 10000 RTP/SAVP ")
+	assert.Contains(t, sdp, "a=crypto:"+keys.CryptoAttribute()+"\r\n")
+
+	info, err := s.ParseSDP([]byte(sdp))
+	require.NoError(t, err)
+	assert.True(t, info.Secure)
+	require.Len(t, info.Crypto, 1)
+	assert.Equal(t, keys, info.Crypto[0])
+
+	info, err = s.ParseSDP([]byte(s.GenerateSDP(DefaultSDPConfig("10.0.0.1", 10000))))
+	require.NoError(t, err)
+	assert.False(t, info.Secure)
+	assert.Empty(t, info.Crypto)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package sip_infra
+
+import "fmt"
+
+// G.729 frame sizes, the codec itself is in g729.go.
+const (
+	g729FrameSamples = 80 // 10ms at 8 kHz
+	g729FrameBytes   = 10
+	g729SIDBytes     = 2 // Annex B comfort noise update
+)
+
+// Codecs other than G.711 are transcoded where RTP enters and leaves the
+// handler: AudioIn and AudioOut carry µ-law at 8 kHz for them, the format the
+// telephony pipeline resamples from. G.722 is coded on its lower band, which
+// is the 8 kHz signal, so no resampling is needed on the wire side either.
+
+// frameEncoder codes µ-law frames of AudioOut into the RTP payload of a
+// transcoded codec. Encoders keep state across frames.
+type frameEncoder interface {
+	Encode(ulaw []byte) []byte
+	Close()
+}
+
+// frameDecoder decodes the RTP payload of a transcoded codec into µ-law.
+type frameDecoder interface {
+	Decode(payload []byte) ([]byte, error)
+	Close()
+}
+
+// isTranscoded reports whether the audio of the codec is converted to µ-law
+// for AudioIn and AudioOut.
+func isTranscoded(codec *Codec) bool {
+	return codec != nil && (codec.Name == CodecG722.Name || codec.Name == CodecG729.Name)
+}
+
+// channelCodec returns the codec of the audio AudioIn and AudioOut carry for
+// a negotiated codec.
+func channelCodec(codec *Codec) *Codec {
+	if isTranscoded(codec) {
+		return &CodecPCMU
+	}
+	return codec
+}
+
+// newFrameEncoder returns the encoder of a transcoded codec, nil for G.711.
+func newFrameEncoder(codec *Codec) (frameEncoder, error) {
+	switch {
+	case codec == nil || !isTranscoded(codec):
+		return nil, nil
+	case codec.Name == CodecG722.Name:
+		return &g722FrameEncoder{encoder: NewG722Encoder(true)}, nil
+	}
+	encoder, err := newG729Encoder()
+	if err != nil {
+		return nil, err
+	}
+	return &g729FrameEncoder{encoder: encoder}, nil
+}
+
+// newFrameDecoder returns the decoder of a transcoded codec, nil for G.711.
+func newFrameDecoder(codec *Codec) (frameDecoder, error) {
+	switch {
+	case codec == nil || !isTranscoded(codec):
+		return nil, nil
+	case codec.Name == CodecG722.Name:
+		return &g722FrameDecoder{decoder: NewG722Decoder(true)}, nil
+	}
+	decoder, err := newG729Decoder()
+	if err != nil {
+		return nil, err
+	}
+	return &g729FrameDecoder{decoder: decoder}, nil
+}
+
+type g722FrameEncoder struct{ encoder *G722Encoder }
+
+func (e *g722FrameEncoder) Encode(ulaw []byte) []byte {
+	return e.encoder.Encode(decodeG711(ulaw, &CodecPCMU))
+}
+
+func (e *g722FrameEncoder) Close() {}
+
+type g722FrameDecoder struct{ decoder *G722Decoder }
+
+func (d *g722FrameDecoder) Decode(payload []byte) ([]byte, error) {
+	return encodeG711(d.decoder.Decode(payload), &CodecPCMU), nil
+}
+
+func (d *g722FrameDecoder) Close() {}
+
+// g729FrameEncoder codes 20ms of µ-law as two G.729 frames. Frames are a
+// multiple of 10ms, anything shorter is padded with silence.
+type g729FrameEncoder struct{ encoder *g729Encoder }
+
+func (e *g729FrameEncoder) Encode(ulaw []byte) []byte {
+	pcm := decodeG711(ulaw, &CodecPCMU)
+	if rest := len(pcm) % g729FrameSamples; rest != 0 {
+		pcm = append(pcm, make([]int16, g729FrameSamples-rest)...)
+	}
+	return e.encoder.Encode(pcm)
+}
+
+func (e *g729FrameEncoder) Close() { e.encoder.Close() }
+
+type g729FrameDecoder struct{ decoder *g729Decoder }
+
+func (d *g729FrameDecoder) Decode(payload []byte) ([]byte, error) {
+	pcm, err := d.decoder.Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("G.729: %w", err)
+	}
+	return encodeG711(pcm, &CodecPCMU), nil
+}
+
+func (d *g729FrameDecoder) Close() { d.decoder.Close() }
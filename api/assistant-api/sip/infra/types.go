@@ -10,8 +10,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
 	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/types"
 )
@@ -25,6 +30,8 @@ var (
 	ErrSDPParseFailed    = errors.New("failed to parse SDP")
 	ErrCodecNotSupported = errors.New("codec not supported")
 	ErrConnectionFailed  = errors.New("SIP connection failed")
+	ErrSessionExpired    = errors.New("SIP session expired")
+	ErrInvalidExpires    = errors.New("invalid registration expiry")
 )
 
 // SIPError wraps SIP-specific errors with context
@@ -93,6 +100,12 @@ type Config struct {
 	// so the From URI matches the auth endpoint (required for PJSIP endpoint resolution).
 	CallerID string `json:"sip_caller_id,omitempty" mapstructure:"sip_caller_id"`
 
+	// CallerName is the display name of outbound calls, carriers deliver it as
+	// CNAM. IdentityHeaders asserts it with the From number in the headers
+	// trusting trunks read instead.
+	CallerName      string          `json:"sip_caller_name,omitempty" mapstructure:"sip_caller_name"`
+	IdentityHeaders IdentityHeaders `json:"sip_identity_headers,omitempty" mapstructure:"sip_identity_headers"`
+
 	// Platform operational settings — from app config (not from vault)
 	Port              int       `json:"sip_port" mapstructure:"sip_port"`
 	Transport         Transport `json:"sip_transport" mapstructure:"sip_transport"`
@@ -105,6 +118,108 @@ type Config struct {
 	InviteTimeout    time.Duration `json:"invite_timeout,omitempty" mapstructure:"invite_timeout"`
 	SessionTimeout   time.Duration `json:"session_timeout,omitempty" mapstructure:"session_timeout"`
 	KeepAliveEnabled bool          `json:"keepalive_enabled,omitempty" mapstructure:"keepalive_enabled"`
+
+	// HoldAudio is what the assistant hears while the remote party has the
+	// call on hold. The remote hold music itself is never passed on.
+	HoldAudio HoldAudio `json:"sip_hold_audio,omitempty" mapstructure:"sip_hold_audio"`
+
+	// HoldMusic is what the remote party hears while the assistant has the
+	// call on hold, a tone melody when empty.
+	HoldMusic HoldAudio `json:"sip_hold_music,omitempty" mapstructure:"sip_hold_music"`
+
+	// Redundancy offers RFC 2198 redundant audio (RED), used when the
+	// provider accepts it. Concealment synthesizes received frames lost in
+	// transit so speech recognition never sees the gaps.
+	Redundancy  bool `json:"sip_red,omitempty" mapstructure:"sip_red"`
+	Concealment bool `json:"sip_plc,omitempty" mapstructure:"sip_plc"`
+
+	// ComfortNoise is the level in dBov of the noise sent instead of digital
+	// silence between the assistant's utterances, zero sends silence. See
+	// ParseComfortNoise.
+	ComfortNoise int `json:"sip_comfort_noise,omitempty" mapstructure:"sip_comfort_noise"`
+
+	// Pickup parks every call of the trunk for human agents, an agent on a
+	// phone registered with the trunk dials the call's pickup code to take it
+	// over. See Pickup.
+	Pickup bool `json:"sip_pickup,omitempty" mapstructure:"sip_pickup"`
+
+	// SecurityPolicy is the least protection outbound calls get, see
+	// ResolveDestination.
+	SecurityPolicy SecurityPolicy `json:"sip_security,omitempty" mapstructure:"sip_security"`
+}
+
+// HoldAudio selects the comfort audio fed to the assistant during hold
+type HoldAudio string
+
+const (
+	// HoldAudioNone feeds nothing, the assistant pipeline idles
+	HoldAudioNone HoldAudio = "none"
+	// HoldAudioSilence feeds µ-law silence at the packet rate
+	HoldAudioSilence HoldAudio = "silence"
+	// HoldAudioTone feeds a quiet comfort tone at the packet rate
+	HoldAudioTone HoldAudio = "tone"
+)
+
+// ParseHoldAudio maps a configured value to a HoldAudio, unknown or empty
+// values fall back to HoldAudioNone.
+func ParseHoldAudio(value string) HoldAudio {
+	switch HoldAudio(strings.ToLower(strings.TrimSpace(value))) {
+	case HoldAudioSilence:
+		return HoldAudioSilence
+	case HoldAudioTone:
+		return HoldAudioTone
+	}
+	return HoldAudioNone
+}
+
+// ParseFlag reads an on/off setting of a credential, stored either as a
+// boolean or as its string form. Anything else is off.
+func ParseFlag(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		on, err := strconv.ParseBool(strings.TrimSpace(v))
+		return err == nil && on
+	}
+	return false
+}
+
+// ParseComfortNoise maps a configured comfort noise value to a level in
+// dBov: true or on take the default level, a number is the level itself
+// (e.g. -55). Anything else, false and empty turn it off and return zero.
+func ParseComfortNoise(value interface{}) int {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return internal_audio.DefaultComfortNoiseLevel
+		}
+	case float64:
+		return comfortNoiseLevel(v)
+	case int:
+		return comfortNoiseLevel(float64(v))
+	case string:
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "on" {
+			return internal_audio.DefaultComfortNoiseLevel
+		}
+		if on, err := strconv.ParseBool(v); err == nil {
+			return ParseComfortNoise(on)
+		}
+		if level, err := strconv.ParseFloat(v, 64); err == nil {
+			return comfortNoiseLevel(level)
+		}
+	}
+	return 0
+}
+
+// comfortNoiseLevel clamps a configured level, positive values are taken as
+// dB below overload as people tend to write them.
+func comfortNoiseLevel(level float64) int {
+	if level == 0 {
+		return 0
+	}
+	return min(max(-int(math.Abs(math.Round(level))), internal_audio.MinComfortNoiseLevel), internal_audio.MaxComfortNoiseLevel)
 }
 
 // Validate validates the full SIP configuration (for outbound calls / registration)
@@ -236,6 +351,12 @@ type SessionInfo struct {
 	Codec            string        `json:"codec"`
 	SampleRate       int           `json:"sample_rate"`
 	Duration         time.Duration `json:"duration,omitempty"`
+
+	// Remote endpoint as seen in signalling: the User-Agent (or Server) header
+	// of the far end, and the transport and address its messages came from.
+	RemoteUserAgent    string `json:"remote_user_agent,omitempty"`
+	SignalingTransport string `json:"signaling_transport,omitempty"`
+	SignalingAddress   string `json:"signaling_address,omitempty"`
 }
 
 // GetDuration calculates the call duration
@@ -262,6 +383,8 @@ const (
 	EventTypeError      EventType = "error"
 	EventTypeRTPStarted EventType = "rtp_started"
 	EventTypeRTPStopped EventType = "rtp_stopped"
+	EventTypeHold       EventType = "hold"
+	EventTypeResume     EventType = "resume"
 )
 
 // Event represents events from SIP stack
@@ -298,6 +421,16 @@ type RTPStats struct {
 	Jitter          time.Duration `json:"jitter"`
 }
 
+// RTCPStats contains the call quality the remote party reports about the
+// audio we send, taken from the RTCP receiver reports
+type RTCPStats struct {
+	RoundTripTime   time.Duration `json:"round_trip_time"`
+	Jitter          time.Duration `json:"jitter"`
+	FractionLost    float64       `json:"fraction_lost"`
+	PacketsLost     int64         `json:"packets_lost"`
+	ReportsReceived uint64        `json:"reports_received"`
+}
+
 // SIPSession represents an active SIP call session (used by SIP manager)
 type SIPSession struct {
 	CallID      string
@@ -307,4 +440,8 @@ type SIPSession struct {
 	Config      *Config
 	Streamer    internal_type.Streamer
 	Cancel      context.CancelFunc
+
+	// Call is the context the call was started with, kept to hand the call
+	// over to another instance
+	Call *callcontext.CallContext
 }
@@ -0,0 +1,205 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_sip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
+	internal_drain "github.com/rapidaai/api/assistant-api/internal/drain"
+	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
+	internal_sessionstate "github.com/rapidaai/api/assistant-api/internal/sessionstate"
+	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
+	"github.com/rapidaai/pkg/utils"
+)
+
+// migrationPoll is how long the instance waits for a call to take over
+// before it checks whether it still takes calls.
+const migrationPoll = 5 * time.Second
+
+// migratedCall is what the SIP channel hands to the instance taking a call
+// over: the dialog and media of the call and the context it was started
+// with. The auth token of the context is not part of its JSON.
+type migratedCall struct {
+	Dialog    *sip_infra.DialogState   `json:"dialog"`
+	Call      *callcontext.CallContext `json:"call"`
+	AuthToken string                   `json:"authToken"`
+}
+
+// migrations coordinates the calls moving between instances, nil unless
+// call migration is configured.
+func (m *SIPEngine) migrations() *internal_sessionstate.Migrations {
+	if m.cfg.CallMigration == nil || m.redis == nil {
+		return nil
+	}
+	return internal_sessionstate.NewMigrations(m.redis.GetConnection(), m.cfg.CallMigration.Timeout())
+}
+
+// MigrateCall hands the active call callID over to the instance to, to any
+// instance taking calls when to is empty. The call goes on there with the
+// same conversation, here it ends without a BYE once it was taken over.
+func (m *SIPEngine) MigrateCall(ctx context.Context, callID, to string) error {
+	migrations := m.migrations()
+	if migrations == nil {
+		return fmt.Errorf("call migration is not configured")
+	}
+	m.mu.RLock()
+	sipSession, ok := m.sessions[callID]
+	server := m.server
+	m.mu.RUnlock()
+	if !ok || sipSession.Call == nil || server == nil {
+		return fmt.Errorf("call not found: %s", callID)
+	}
+	session, ok := server.GetSession(callID)
+	if !ok {
+		return fmt.Errorf("call not found: %s", callID)
+	}
+
+	cc := sipSession.Call
+	var state *internal_sessionstate.State
+	if source, ok := internal_sessionstate.Lookup(cc.ConversationID); ok {
+		state = source.SessionState()
+		// the dialogue of an encrypted conversation is not kept outside it
+		if data, err := m.assistantConversationService.GetConversationCipher(ctx, cc.ConversationID); err != nil || data != nil {
+			state.Histories, state.ModelContext, state.PendingTools = nil, nil, nil
+		}
+	}
+	dialog, err := server.CaptureDialog(session)
+	if err != nil {
+		return err
+	}
+	channel, err := json.Marshal(&migratedCall{Dialog: dialog, Call: cc, AuthToken: cc.AuthToken})
+	if err != nil {
+		return err
+	}
+
+	from := m.cfg.CallMigration.Name()
+	if err := migrations.Offer(ctx, to, &internal_sessionstate.Migration{
+		CallID:    callID,
+		From:      from,
+		Channel:   channel,
+		State:     state,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to offer call %s: %w", callID, err)
+	}
+	if err := migrations.Await(ctx, callID, m.cfg.CallMigration.Timeout()); err != nil {
+		return fmt.Errorf("call %s was not taken over: %w", callID, err)
+	}
+
+	server.HandOver(session)
+	m.mu.Lock()
+	delete(m.sessions, callID)
+	m.mu.Unlock()
+	if sipSession.Cancel != nil {
+		sipSession.Cancel()
+	}
+	m.logger.Infow("SIP call migrated", "call_id", callID, "conversation_id", cc.ConversationID, "to", to)
+	return nil
+}
+
+// MigrateCalls hands every active call over to the instance to, e.g. while
+// draining, and returns how many of them were taken over.
+func (m *SIPEngine) MigrateCalls(ctx context.Context, to string) int {
+	m.mu.RLock()
+	callIDs := make([]string, 0, len(m.sessions))
+	for callID := range m.sessions {
+		callIDs = append(callIDs, callID)
+	}
+	m.mu.RUnlock()
+
+	migrated := 0
+	for _, callID := range callIDs {
+		if err := m.MigrateCall(ctx, callID, to); err != nil {
+			m.logger.Warnw("SIP call stays on this instance", "call_id", callID, "error", err)
+			continue
+		}
+		migrated++
+	}
+	return migrated
+}
+
+// takeOverCalls takes over the calls other instances hand to this one until
+// ctx is done. No calls are taken while draining.
+func (m *SIPEngine) takeOverCalls(ctx context.Context) {
+	migrations := m.migrations()
+	instance := m.cfg.CallMigration.Name()
+	for ctx.Err() == nil {
+		if internal_drain.Default.Draining() {
+			select {
+			case <-ctx.Done():
+			case <-time.After(migrationPoll):
+			}
+			continue
+		}
+		migration, err := migrations.Next(ctx, instance, migrationPoll)
+		if err != nil {
+			if ctx.Err() == nil {
+				m.logger.Warnw("Failed to read calls to take over", "error", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		if migration == nil {
+			continue
+		}
+		failure := m.takeOverCall(ctx, migration)
+		if failure != nil {
+			m.logger.Warnw("Failed to take SIP call over", "call_id", migration.CallID, "from", migration.From, "error", failure)
+		}
+		if err := migrations.Ack(ctx, migration.CallID, failure); err != nil {
+			m.logger.Warnw("Failed to answer call migration", "call_id", migration.CallID, "error", err)
+		}
+	}
+}
+
+// takeOverCall adopts the dialog of a migrated call and resumes its
+// conversation with the state it was handed over with.
+func (m *SIPEngine) takeOverCall(ctx context.Context, migration *internal_sessionstate.Migration) error {
+	var call migratedCall
+	if err := json.Unmarshal(migration.Channel, &call); err != nil {
+		return fmt.Errorf("invalid migrated call: %w", err)
+	}
+	if call.Dialog == nil || call.Call == nil {
+		return fmt.Errorf("invalid migrated call: missing dialog or context")
+	}
+	cc := call.Call
+	cc.AuthToken = call.AuthToken
+	auth := cc.ToAuth()
+
+	assistant, err := m.assistantService.Get(ctx, auth, cc.AssistantID, utils.GetVersionDefinition("latest"),
+		&internal_services.GetAssistantOption{InjectPhoneDeployment: true})
+	if err != nil {
+		return fmt.Errorf("failed to get assistant: %w", err)
+	}
+	sipConfig, vaultCred, err := m.fetchSIPConfigAndVaultCredential(auth, assistant)
+	if err != nil {
+		return err
+	}
+
+	session, err := m.server.AdoptDialog(ctx, call.Dialog, &sip_infra.SessionConfig{
+		Config:          sipConfig,
+		Auth:            auth,
+		Assistant:       assistant,
+		VaultCredential: vaultCred,
+	})
+	if err != nil {
+		return err
+	}
+	internal_sessionstate.Adopt(cc.ConversationID, migration.State)
+
+	source := utils.SIP
+	if call.Dialog.Direction == sip_infra.CallDirectionOutbound {
+		source = utils.PhoneCall
+	}
+	m.logger.Infow("SIP call taken over", "call_id", migration.CallID, "conversation_id", cc.ConversationID, "from", migration.From)
+	go m.startCall(m.ctx, session, cc, vaultCred, sipConfig, source)
+	return nil
+}
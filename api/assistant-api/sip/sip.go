@@ -20,6 +20,7 @@ import (
 	callcontext "github.com/rapidaai/api/assistant-api/internal/callcontext"
 	internal_telephony "github.com/rapidaai/api/assistant-api/internal/channel/telephony"
 	internal_assistant_entity "github.com/rapidaai/api/assistant-api/internal/entity/assistants"
+	internal_runtimemetrics "github.com/rapidaai/api/assistant-api/internal/runtimemetrics"
 	internal_services "github.com/rapidaai/api/assistant-api/internal/services"
 	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
 	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
@@ -71,7 +72,7 @@ func NewSIPEngine(config *config.AssistantConfig, logger commons.Logger,
 		postgres:                     postgres,
 		redis:                        redis,
 		opensearch:                   opensearch,
-		assistantConversationService: internal_assistant_service.NewAssistantConversationService(logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+		assistantConversationService: internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
 		assistantService:             internal_assistant_service.NewAssistantService(config, logger, postgres, opensearch),
 		storage:                      storage_files.NewStorage(config.AssetStoreConfig, logger),
 		vaultClient:                  web_client.NewVaultClientGRPC(&config.AppConfig, logger, redis),
@@ -121,6 +122,8 @@ func (m *SIPEngine) Connect(ctx context.Context) error {
 		RedisClient:       m.redis.GetConnection(),
 		RTPPortRangeStart: m.cfg.SIPConfig.RTPPortRangeStart,
 		RTPPortRangeEnd:   m.cfg.SIPConfig.RTPPortRangeEnd,
+		RegistrarRealm:    m.cfg.SIPConfig.RegistrarRealm,
+		PickupPrefix:      m.cfg.SIPConfig.PickupPrefix,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create SIP server: %w", err)
@@ -147,7 +150,13 @@ func (m *SIPEngine) Connect(ctx context.Context) error {
 	if err := server.Start(); err != nil {
 		return fmt.Errorf("failed to start SIP server: %w", err)
 	}
+	internal_runtimemetrics.SetRTPPortPool(server.RTPPorts())
 	m.server = server
+
+	// Take over the calls other instances hand to this one
+	if m.cfg.CallMigration != nil {
+		go m.takeOverCalls(m.ctx)
+	}
 	return nil
 }
 
@@ -528,6 +537,7 @@ func (m *SIPEngine) startCall(ctx context.Context, session *sip_infra.Session, c
 		Auth:        auth,
 		Config:      sipConfig,
 		Cancel:      cancel,
+		Call:        cc,
 	}
 	m.mu.Unlock()
 
@@ -562,6 +572,7 @@ func (m *SIPEngine) startCall(ctx context.Context, session *sip_infra.Session, c
 			Ctx:        callCtx,
 			SIPSession: session,
 			SIPConfig:  sipConfig,
+			SIPServer:  m.server,
 		})
 	if err != nil {
 		m.logger.Error("Failed to create SIP streamer", "error", err, "call_id", callID)
@@ -837,6 +848,7 @@ func (m *SIPEngine) Stop() {
 	m.mu.Lock()
 	// Stop the shared server
 	if m.server != nil {
+		internal_runtimemetrics.SetRTPPortPool(nil)
 		m.server.Stop()
 		m.server = nil
 	}
@@ -944,6 +956,14 @@ func (m *SIPEngine) fetchSIPConfigAndVaultCredential(auth types.SimplePrinciple,
 //	sip_server   - (optional) explicit server address, overrides sip_uri
 //	sip_realm    - (optional) SIP realm for auth
 //	sip_domain   - (optional) SIP domain
+//	sip_hold_audio - (optional) none, silence or tone fed to the assistant while on hold
+//	sip_hold_music - (optional) none, silence or tone played to the caller while the assistant holds the call
+//	sip_red      - (optional) true to send and receive RFC 2198 redundant audio
+//	sip_plc      - (optional) true to conceal lost inbound audio frames
+//	sip_comfort_noise - (optional) true or a level in dBov (e.g. -55) to send noise instead of silence
+//	sip_caller_name - (optional) display name of outbound calls, delivered as CNAM
+//	sip_identity_headers - (optional) pai, rpid or both to assert the caller identity of outbound calls
+//	sip_pickup   - (optional) true to let agents on registered phones pick calls up with their pickup code
 //
 // Does NOT set operational fields (port, transport, RTP range) — those come from app config.
 func GetSIPConfigFromVault(vaultCredential *protos.VaultCredential) (*sip_infra.Config, error) {
@@ -983,6 +1003,22 @@ func GetSIPConfigFromVault(vaultCredential *protos.VaultCredential) (*sip_infra.
 	if domain, ok := credMap["sip_domain"].(string); ok {
 		cfg.Domain = domain
 	}
+	if holdAudio, ok := credMap["sip_hold_audio"].(string); ok {
+		cfg.HoldAudio = sip_infra.ParseHoldAudio(holdAudio)
+	}
+	if holdMusic, ok := credMap["sip_hold_music"].(string); ok && holdMusic != "" {
+		cfg.HoldMusic = sip_infra.ParseHoldAudio(holdMusic)
+	}
+	cfg.Redundancy = sip_infra.ParseFlag(credMap["sip_red"])
+	cfg.Concealment = sip_infra.ParseFlag(credMap["sip_plc"])
+	cfg.ComfortNoise = sip_infra.ParseComfortNoise(credMap["sip_comfort_noise"])
+	cfg.Pickup = sip_infra.ParseFlag(credMap["sip_pickup"])
+	if callerName, ok := credMap["sip_caller_name"].(string); ok {
+		cfg.CallerName = callerName
+	}
+	if identity, ok := credMap["sip_identity_headers"].(string); ok {
+		cfg.IdentityHeaders = sip_infra.ParseIdentityHeaders(identity)
+	}
 
 	return cfg, nil
 }
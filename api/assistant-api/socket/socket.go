@@ -9,8 +9,6 @@ package assistant_socket
 import (
 	"bufio"
 	"context"
-	"encoding/binary"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -59,7 +57,7 @@ func NewAudioSocketEngine(config *config.AssistantConfig, logger commons.Logger,
 	vaultClient := web_client.NewVaultClientGRPC(&config.AppConfig, logger, redis)
 	fileStorage := storage_files.NewStorage(config.AssetStoreConfig, logger)
 	assistantService := internal_assistant_service.NewAssistantService(config, logger, postgres, opensearch)
-	conversationService := internal_assistant_service.NewAssistantConversationService(logger, postgres, fileStorage)
+	conversationService := internal_assistant_service.NewAssistantConversationService(config, logger, postgres, fileStorage)
 
 	dispatcher := internal_telephony.NewInboundDispatcher(internal_telephony.TelephonyDispatcherDeps{
 		Cfg:                 config,
@@ -132,7 +130,7 @@ func (m *audioSocketEngine) handleConnection(ctx context.Context, conn net.Conn)
 
 	// Asterisk sends a FrameTypeUUID (0x01) as the first frame with a 16-byte UUID payload.
 	// This UUID is the contextId that was passed via the dialplan (e.g. AudioSocket(contextId, host:port)).
-	contextID, err := m.readContextID(reader)
+	contextID, err := internal_telephony.ReadAudioSocketContextID(reader)
 	if err != nil {
 		// EOF is expected for health checks, port probes, or clients that disconnect before
 		// sending data. Log at debug level to reduce noise.
@@ -155,7 +153,7 @@ func (m *audioSocketEngine) handleConnection(ctx context.Context, conn net.Conn)
 
 	// Step 3: Create AudioSocket streamer and start talking.
 	// Pass the contextID as the initial UUID so the streamer sends ConversationInitialization
-	// on the first Recv() call — the UUID frame was already consumed by readContextID above.
+	// on the first Recv() call — the UUID frame was already consumed above.
 	streamer, err := internal_telephony.Telephony(internal_telephony.Asterisk).NewStreamer(
 		m.logger, cc, vaultCred, internal_telephony.StreamerOption{
 			AudioSocketConn:   conn,
@@ -191,46 +189,3 @@ func (m *audioSocketEngine) handleConnection(ctx context.Context, conn net.Conn)
 	// Mark call context as completed now that the call has ended
 	m.inboundDispatcher.CompleteCallSession(connCtx, contextID)
 }
-
-// readContextID reads the initial UUID frame from the AudioSocket connection.
-// Asterisk sends a FrameTypeUUID (0x01) frame with 16-byte UUID payload.
-// Frame format: 1-byte type + 2-byte big-endian length + payload.
-// We parse the UUID and return it as a string (the contextId).
-func (m *audioSocketEngine) readContextID(reader *bufio.Reader) (string, error) {
-	const frameTypeUUID byte = 0x01
-
-	// Read frame type
-	frameType, err := reader.ReadByte()
-	if err != nil {
-		return "", fmt.Errorf("failed to read frame type: %w", err)
-	}
-
-	// Read 2-byte big-endian length
-	lenBuf := make([]byte, 2)
-	if _, err := io.ReadFull(reader, lenBuf); err != nil {
-		return "", fmt.Errorf("failed to read frame length: %w", err)
-	}
-	payloadLen := int(binary.BigEndian.Uint16(lenBuf))
-
-	// Read payload
-	payload := make([]byte, payloadLen)
-	if payloadLen > 0 {
-		if _, err := io.ReadFull(reader, payload); err != nil {
-			return "", fmt.Errorf("failed to read frame payload: %w", err)
-		}
-	}
-
-	if frameType != frameTypeUUID {
-		return "", fmt.Errorf("expected UUID frame (0x01), got frame type 0x%02x", frameType)
-	}
-
-	if len(payload) != 16 {
-		return "", fmt.Errorf("invalid UUID payload length: %d (expected 16)", len(payload))
-	}
-
-	// Convert 16-byte UUID to standard string format (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)
-	h := hex.EncodeToString(payload)
-	uuid := h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
-
-	return uuid, nil
-}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_telemetry
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_assistant_service "github.com/rapidaai/api/assistant-api/internal/services/assistant"
+	internal_telemetry_batch "github.com/rapidaai/api/assistant-api/internal/telemetry/batch"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	storage_files "github.com/rapidaai/pkg/storages/file-storage"
+)
+
+// telemetryBatchEngine owns the writer the telephony handlers and talk loops
+// of this replica queue their metrics and telephony events on.
+type telemetryBatchEngine struct {
+	logger commons.Logger
+	cfg    *config.AssistantConfig
+	sink   internal_telemetry_batch.Sink
+
+	mu     sync.Mutex
+	writer *internal_telemetry_batch.Writer
+}
+
+func NewTelemetryBatchEngine(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+) *telemetryBatchEngine {
+	return &telemetryBatchEngine{
+		logger: logger,
+		cfg:    config,
+		sink:   internal_assistant_service.NewAssistantConversationService(config, logger, postgres, storage_files.NewStorage(config.AssetStoreConfig, logger)),
+	}
+}
+
+// Connect installs the writer, from then on telemetry is queued instead of
+// written per event.
+func (e *telemetryBatchEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writer != nil {
+		return nil
+	}
+	batch := e.cfg.TelemetryBatch
+	e.writer = internal_telemetry_batch.NewWriter(e.logger, e.sink, batch.BatchSize, batch.FlushInterval(), batch.MaxPending)
+	e.writer.Start(ctx)
+	internal_telemetry_batch.Install(e.writer)
+	e.logger.Infow("Telemetry batching started", "batch_size", batch.BatchSize, "flush_interval", batch.FlushInterval())
+	return nil
+}
+
+// Disconnect goes back to writing per event and writes what is still
+// queued. It has to run before the postgres connector closes.
+func (e *telemetryBatchEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	writer := e.writer
+	e.writer = nil
+	e.mu.Unlock()
+	if writer == nil {
+		return nil
+	}
+	internal_telemetry_batch.Install(nil)
+	return writer.Stop(ctx)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package assistant_warmpool
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rapidaai/api/assistant-api/config"
+	internal_adapter "github.com/rapidaai/api/assistant-api/internal/adapters"
+	internal_warmpool "github.com/rapidaai/api/assistant-api/internal/warmpool"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+)
+
+// warmPoolEngine keeps standbys of the configured assistants for the inbound
+// calls of this replica. Standbys hold live provider connections and are not
+// shared, every replica keeps its own.
+type warmPoolEngine struct {
+	logger commons.Logger
+	cfg    *config.AssistantConfig
+	build  internal_warmpool.Build
+
+	mu   sync.Mutex
+	pool *internal_warmpool.Pool
+}
+
+func NewWarmPoolEngine(config *config.AssistantConfig, logger commons.Logger,
+	postgres connectors.PostgresConnector,
+	opensearch connectors.OpenSearchConnector,
+	redis connectors.RedisConnector,
+) *warmPoolEngine {
+	return &warmPoolEngine{
+		logger: logger,
+		cfg:    config,
+		build:  internal_adapter.GetStandbyBuilder(config, logger, postgres, opensearch, redis),
+	}
+}
+
+// Connect installs the pool sessions claim their standbys from. Standbys of
+// an assistant are built once its first call arrived.
+func (e *warmPoolEngine) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.pool != nil {
+		return nil
+	}
+	sizes, err := e.cfg.WarmPool.Sizes()
+	if err != nil {
+		return err
+	}
+	e.pool = internal_warmpool.NewPool(e.logger, sizes, e.cfg.WarmPool.MaxIdle(), e.build)
+	e.pool.Start(ctx)
+	internal_warmpool.Install(e.pool)
+	e.logger.Infow("Warm pool started", "assistants", len(sizes))
+	return nil
+}
+
+// Disconnect stops handing out standbys and closes the ones still waiting.
+func (e *warmPoolEngine) Disconnect(ctx context.Context) error {
+	e.mu.Lock()
+	pool := e.pool
+	e.pool = nil
+	e.mu.Unlock()
+	if pool == nil {
+		return nil
+	}
+	internal_warmpool.Install(nil)
+	pool.Stop(ctx)
+	return nil
+}
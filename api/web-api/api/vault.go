@@ -5,9 +5,11 @@ import (
 
 	"github.com/rapidaai/api/web-api/config"
 	internal_connects "github.com/rapidaai/api/web-api/internal/connect"
+	internal_entity "github.com/rapidaai/api/web-api/internal/entity"
 	internal_service "github.com/rapidaai/api/web-api/internal/service"
 	internal_vault_service "github.com/rapidaai/api/web-api/internal/service/vault"
 	integration_client "github.com/rapidaai/pkg/clients/integration"
+	workflow_client "github.com/rapidaai/pkg/clients/workflow"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
 	"github.com/rapidaai/pkg/types"
@@ -22,6 +24,7 @@ type webVaultApi struct {
 	redis             connectors.RedisConnector
 	vaultService      internal_service.VaultService
 	integrationClient integration_client.IntegrationServiceClient
+	auditClient       workflow_client.ControlAuditServiceClient
 	hubspotConnect    internal_connects.HubspotConnect
 }
 
@@ -41,6 +44,7 @@ func NewVaultRPC(config *config.WebAppConfig, oauthCfg *config.OAuth2Config, log
 			postgres:          postgres,
 			vaultService:      internal_vault_service.NewVaultService(logger, postgres),
 			integrationClient: integration_client.NewIntegrationServiceClientGRPC(&config.AppConfig, logger, redis),
+			auditClient:       workflow_client.NewControlAuditServiceClientGRPC(&config.AppConfig, logger, redis),
 			hubspotConnect:    internal_connects.NewHubspotConnect(config, oauthCfg, logger, postgres),
 		},
 	}
@@ -55,6 +59,7 @@ func NewVaultGRPC(config *config.WebAppConfig, oauthCfg *config.OAuth2Config, lo
 			redis:             redis,
 			vaultService:      internal_vault_service.NewVaultService(logger, postgres),
 			integrationClient: integration_client.NewIntegrationServiceClientGRPC(&config.AppConfig, logger, redis),
+			auditClient:       workflow_client.NewControlAuditServiceClientGRPC(&config.AppConfig, logger, redis),
 			hubspotConnect:    internal_connects.NewHubspotConnect(config, oauthCfg, logger, postgres),
 		},
 	}
@@ -79,6 +84,8 @@ func (wVault *webVaultGRPCApi) CreateProviderCredential(ctx context.Context, irR
 			"Unable to create provider credential, please try again")
 	}
 
+	wVault.auditClient.CreateControlAuditLog(ctx, iAuth, "create", "credential", vlt.Id, nil, credentialAudit(vlt))
+
 	out := &protos.VaultCredential{}
 	err = utils.Cast(vlt, out)
 	if err != nil {
@@ -87,6 +94,17 @@ func (wVault *webVaultGRPCApi) CreateProviderCredential(ctx context.Context, irR
 	return utils.Success[protos.GetCredentialResponse](out)
 }
 
+// credentialAudit is what the control audit log keeps of a credential, the
+// secret itself is never sent.
+func credentialAudit(vlt *internal_entity.Vault) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       vlt.Id,
+		"provider": vlt.Provider,
+		"name":     vlt.Name,
+		"status":   vlt.Status,
+	}
+}
+
 func (wVault *webVaultGRPCApi) DeleteCredential(c context.Context, irRequest *protos.DeleteCredentialRequest) (*protos.GetCredentialResponse, error) {
 	iAuth, isAuthenticated := types.GetAuthPrincipleGPRC(c)
 	if !isAuthenticated && !iAuth.HasProject() {
@@ -106,6 +124,7 @@ func (wVault *webVaultGRPCApi) DeleteCredential(c context.Context, irRequest *pr
 				HumanMessage: "Unable to delete provider credential, please try again",
 			}}, nil
 	}
+	wVault.auditClient.CreateControlAuditLog(c, iAuth, "delete", "credential", vlt.Id, credentialAudit(vlt), nil)
 	out := &protos.VaultCredential{}
 	err = utils.Cast(vlt, out)
 	if err != nil {
@@ -23,16 +23,29 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	assistant_campaign "github.com/rapidaai/api/assistant-api/campaign"
+	assistant_capacity "github.com/rapidaai/api/assistant-api/capacity"
+	assistant_cluster "github.com/rapidaai/api/assistant-api/cluster"
 	"github.com/rapidaai/api/assistant-api/config"
+	assistant_drain "github.com/rapidaai/api/assistant-api/drain"
+	assistant_encryption "github.com/rapidaai/api/assistant-api/encryption"
+	assistant_livetranscript "github.com/rapidaai/api/assistant-api/livetranscript"
+	assistant_pacing "github.com/rapidaai/api/assistant-api/pacing"
+	assistant_relay "github.com/rapidaai/api/assistant-api/relay"
+	assistant_retention "github.com/rapidaai/api/assistant-api/retention"
 	router "github.com/rapidaai/api/assistant-api/router"
+	assistant_sessiontoken "github.com/rapidaai/api/assistant-api/sessiontoken"
 	assistant_sip "github.com/rapidaai/api/assistant-api/sip"
 	sip_infra "github.com/rapidaai/api/assistant-api/sip/infra"
 	assistant_socket "github.com/rapidaai/api/assistant-api/socket"
+	assistant_telemetry "github.com/rapidaai/api/assistant-api/telemetry"
+	assistant_warmpool "github.com/rapidaai/api/assistant-api/warmpool"
 	"github.com/rapidaai/pkg/authenticators"
 	web_client "github.com/rapidaai/pkg/clients/web"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/pkg/connectors"
 	"github.com/rapidaai/pkg/middlewares"
+	"github.com/rapidaai/protos"
 	"github.com/soheilhy/cmux"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -43,6 +56,7 @@ type AppRunner struct {
 	E          *gin.Engine
 	S          *grpc.Server
 	SIP        *sip_infra.Server
+	SIPEngine  *assistant_sip.SIPEngine
 	Cfg        *config.AssistantConfig
 	Logger     commons.Logger
 	Postgres   connectors.PostgresConnector
@@ -78,6 +92,7 @@ func main() {
 	authClient := web_client.NewAuthenticator(&appRunner.Cfg.AppConfig, appRunner.Logger, appRunner.Redis)
 	appRunner.S = grpc.NewServer(
 		grpc.ChainStreamInterceptor(
+			assistant_drain.StreamServerInterceptor(),
 			middlewares.NewRequestLoggerStreamServerMiddleware(appRunner.Cfg.Name, appRunner.Logger),
 			middlewares.NewRecoveryStreamServerMiddleware(appRunner.Logger),
 			middlewares.NewServiceAuthenticatorStreamServerMiddleware(
@@ -95,6 +110,12 @@ func main() {
 					authClient),
 				appRunner.Logger,
 			),
+			// session tokens only open WebTalk sessions
+			middlewares.NewSessionAuthenticatorStreamServerMiddleware(
+				assistant_sessiontoken.NewSessionAuthenticator(appRunner.Cfg.WebTalkSession, appRunner.Redis),
+				appRunner.Logger,
+				protos.WebRTC_WebTalk_FullMethodName,
+			),
 			middlewares.NewClientInformationStreamServerMiddleware(
 				appRunner.Logger,
 			),
@@ -195,6 +216,18 @@ func main() {
 
 	})
 
+	// on SIGTERM drain the active sessions, then close the engines; SIP
+	// releases its RTP ports as it stops
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		appRunner.Drain(context.Background())
+		appRunner.Close(context.Background())
+		appRunner.S.Stop()
+		os.Exit(0)
+	}()
+
 	//serve now
 	err = cmuxListener.Serve()
 	if err != nil {
@@ -203,11 +236,6 @@ func main() {
 	}
 
 	err = group.Wait()
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-	// done with ctx
-	ctx.Done()
-	<-quit
 }
 
 func (app *AppRunner) Logging() error {
@@ -297,6 +325,29 @@ func (app *AppRunner) Close(ctx context.Context) {
 	}
 }
 
+// Drain refuses new sessions and waits for the active ones to finish, up to
+// the configured deadline. With call migration, SIP calls are handed over to
+// other instances first.
+func (app *AppRunner) Drain(ctx context.Context) {
+	deadline := app.Cfg.Drain.Deadline()
+	if !assistant_drain.Start(deadline) {
+		return
+	}
+	if app.SIPEngine != nil && app.Cfg.CallMigration != nil {
+		migrated := app.SIPEngine.MigrateCalls(ctx, app.Cfg.CallMigration.DrainTo)
+		app.Logger.Infof("draining, %d SIP calls migrated to other instances", migrated)
+	}
+	app.Logger.Infof("draining, waiting up to %s for active sessions to finish", deadline)
+	left := assistant_drain.Wait(ctx, func(active int64) {
+		app.Logger.Infof("draining, %d sessions active", active)
+	})
+	if left > 0 {
+		app.Logger.Warnf("drain deadline passed with %d sessions active", left)
+		return
+	}
+	app.Logger.Infof("drained, no sessions active")
+}
+
 // all router initialize
 func (g *AppRunner) AllRouters(ctx context.Context) error {
 	router.AssistantApiRoute(g.Cfg, g.S, g.Logger, g.Postgres, g.Redis, g.Opensearch)
@@ -322,6 +373,7 @@ func (app *AppRunner) AllEngine(ctx context.Context) error {
 			return err
 		}
 		app.SIP = sipManager.GetServer()
+		app.SIPEngine = sipManager
 		app.Closeable = append(app.Closeable, sipManager.Disconnect)
 	}
 	// AudioSocket is optional and only started if configured. It listens for TCP connections from telephony providers for audio streaming in calls.
@@ -332,6 +384,78 @@ func (app *AppRunner) AllEngine(ctx context.Context) error {
 		}
 		app.Closeable = append(app.Closeable, socketEngine.Disconnect)
 	}
+	// Recording retention is optional and only started if configured. It archives and deletes call recordings by the retention policy of their project.
+	if app.Cfg.RecordingRetention != nil {
+		retentionEngine := assistant_retention.NewRecordingRetentionEngine(app.Cfg, app.Logger, app.Postgres, app.Redis)
+		if err := retentionEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, retentionEngine.Disconnect)
+	}
+	// Conversation encryption is optional. Its job moves the data keys of encrypted conversations to the active master key after a rotation.
+	if app.Cfg.ConversationEncryption != nil {
+		rewrapEngine := assistant_encryption.NewConversationKeyRewrapEngine(app.Cfg, app.Logger, app.Postgres, app.Redis)
+		if err := rewrapEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, rewrapEngine.Disconnect)
+	}
+	// The session registry is optional. It records which instance hosts every live session so callbacks and hangup/transfer requests reaching another instance are routed to it.
+	if app.Cfg.SessionRegistry != nil {
+		sessionRegistryEngine := assistant_cluster.NewSessionRegistryEngine(app.Cfg, app.Logger, app.Redis)
+		if err := sessionRegistryEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, sessionRegistryEngine.Disconnect)
+	}
+	// Capacity reporting is optional. It exports the utilization of this replica, the busiest of its sessions, RTP ports, CPU and provider streams, for HPA/KEDA.
+	if app.Cfg.Capacity != nil {
+		capacityEngine := assistant_capacity.NewCapacityEngine(app.Cfg, app.Logger)
+		if err := capacityEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, capacityEngine.Disconnect)
+	}
+	// The warm pool is optional. It keeps pipelines of busy assistants built ahead of their inbound calls so they answer without connecting providers first.
+	if app.Cfg.WarmPool != nil {
+		warmPoolEngine := assistant_warmpool.NewWarmPoolEngine(app.Cfg, app.Logger, app.Postgres, app.Opensearch, app.Redis)
+		if err := warmPoolEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, warmPoolEngine.Disconnect)
+	}
+	// Output pacing is optional. Web calls bound the audio queued ahead of playback, catch up after missed ticks and keep a little of it playing on interruptions.
+	if pacing := app.Cfg.OutputPacing; pacing != nil {
+		assistant_pacing.Install(pacing)
+	}
+	// Live transcripts are optional. The transcript of every conversation is kept in redis for subscribers following it as server-sent events from any instance.
+	if live := app.Cfg.LiveTranscript; live != nil {
+		assistant_livetranscript.Install(live, app.Redis)
+	}
+	// TURN regions are optional. Web callers relay their media through the healthy region nearest to them instead of the default STUN servers.
+	if app.Cfg.WebRTC != nil {
+		relayEngine := assistant_relay.NewRelayEngine(app.Cfg, app.Logger)
+		if err := relayEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, relayEngine.Disconnect)
+	}
+	// Campaigns are optional. The scheduler places the calls of running outbound campaigns, over the SIP server started above when there is one.
+	if app.Cfg.Campaign != nil {
+		campaignEngine := assistant_campaign.NewCampaignEngine(app.Cfg, app.Logger, app.Postgres, app.Redis, app.Opensearch, app.SIP)
+		if err := campaignEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append(app.Closeable, campaignEngine.Disconnect)
+	}
+	// Telemetry batching is optional. It writes call metrics and telephony events in batches; its queue is written out ahead of the connectors closing.
+	if app.Cfg.TelemetryBatch != nil {
+		telemetryEngine := assistant_telemetry.NewTelemetryBatchEngine(app.Cfg, app.Logger, app.Postgres)
+		if err := telemetryEngine.Connect(ctx); err != nil {
+			return err
+		}
+		app.Closeable = append([]func(context.Context) error{telemetryEngine.Disconnect}, app.Closeable...)
+	}
 
 	return nil
 }
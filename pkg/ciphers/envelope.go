@@ -0,0 +1,212 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package ciphers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EnvelopeAlgorithm encrypts both the data keys and the data under them.
+const EnvelopeAlgorithm = "AES-256-GCM"
+
+// sealedPrefix marks strings sealed by a DataCipher so they can be told
+// apart from values written before encryption was enabled.
+const sealedPrefix = "enc:v1:"
+
+const envelopeKeySize = 32
+
+// Keyring holds the master keys data keys are wrapped with. New data keys
+// are wrapped with the active one, the others are kept to unwrap keys that
+// were wrapped before a rotation.
+type Keyring struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+// WrappedKey is a data key encrypted with the master key KeyID.
+type WrappedKey struct {
+	KeyID string
+	Key   []byte
+}
+
+// ParseKeyring builds a keyring from "id:base64" entries, each key being 32
+// bytes long.
+func ParseKeyring(active string, entries []string) (*Keyring, error) {
+	keys := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, errors.New("master key must be given as id:base64")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s is not base64: %w", id, err)
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("master key %s is given twice", id)
+		}
+		keys[id] = key
+	}
+	return NewKeyring(active, keys)
+}
+
+func NewKeyring(active string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("active master key %q is not in the keyring", active)
+	}
+	ring := &Keyring{active: active, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s: %w", id, err)
+		}
+		ring.keys[id] = aead
+	}
+	return ring, nil
+}
+
+// ActiveKeyID is the id of the master key new data keys are wrapped with.
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+// GenerateDataKey returns a new random data key along with its wrapped form.
+// aad binds the wrapped key to its owner, the same aad has to be given to
+// unwrap it.
+func (k *Keyring) GenerateDataKey(aad []byte) (*DataCipher, *WrappedKey, error) {
+	key := make([]byte, envelopeKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	data, err := NewDataCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := seal(k.keys[k.active], key, aad)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, &WrappedKey{KeyID: k.active, Key: wrapped}, nil
+}
+
+// Unwrap decrypts a wrapped data key.
+func (k *Keyring) Unwrap(wrapped *WrappedKey, aad []byte) (*DataCipher, error) {
+	key, err := k.unwrap(wrapped, aad)
+	if err != nil {
+		return nil, err
+	}
+	return NewDataCipher(key)
+}
+
+// Rewrap wraps a data key again with the active master key. The data key and
+// everything encrypted with it stay the same.
+func (k *Keyring) Rewrap(wrapped *WrappedKey, aad []byte) (*WrappedKey, error) {
+	key, err := k.unwrap(wrapped, aad)
+	if err != nil {
+		return nil, err
+	}
+	rewrapped, err := seal(k.keys[k.active], key, aad)
+	if err != nil {
+		return nil, err
+	}
+	return &WrappedKey{KeyID: k.active, Key: rewrapped}, nil
+}
+
+func (k *Keyring) unwrap(wrapped *WrappedKey, aad []byte) ([]byte, error) {
+	master, ok := k.keys[wrapped.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %q is not in the keyring", wrapped.KeyID)
+	}
+	return open(master, wrapped.Key, aad)
+}
+
+// DataCipher encrypts the data of one owner, e.g. a conversation, with its
+// data key.
+type DataCipher struct {
+	aead cipher.AEAD
+}
+
+func NewDataCipher(key []byte) (*DataCipher, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &DataCipher{aead: aead}, nil
+}
+
+// Seal encrypts plaintext, the random nonce is prepended to the result.
+func (c *DataCipher) Seal(plaintext, aad []byte) ([]byte, error) {
+	return seal(c.aead, plaintext, aad)
+}
+
+// Open decrypts what Seal returned for the same aad.
+func (c *DataCipher) Open(sealed, aad []byte) ([]byte, error) {
+	return open(c.aead, sealed, aad)
+}
+
+// SealString encrypts s into printable text.
+func (c *DataCipher) SealString(s string, aad []byte) (string, error) {
+	sealed, err := c.Seal([]byte(s), aad)
+	if err != nil {
+		return "", err
+	}
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenString decrypts what SealString returned. Strings that were not
+// sealed are returned as they are.
+func (c *DataCipher) OpenString(s string, aad []byte) (string, error) {
+	if !IsSealed(s) {
+		return s, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, sealedPrefix))
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := c.Open(sealed, aad)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsSealed reports whether s was returned by SealString.
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, sealedPrefix)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != envelopeKeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", envelopeKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed data is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
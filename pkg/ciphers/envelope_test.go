@@ -0,0 +1,89 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package ciphers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func masterKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestParseKeyring(t *testing.T) {
+	ring, err := ParseKeyring("k2", []string{"k1:" + masterKey(1), " k2:" + masterKey(2)})
+	require.NoError(t, err)
+	assert.Equal(t, "k2", ring.ActiveKeyID())
+
+	_, err = ParseKeyring("k3", []string{"k1:" + masterKey(1)})
+	assert.ErrorContains(t, err, "not in the keyring")
+	_, err = ParseKeyring("k1", []string{masterKey(1)})
+	assert.Error(t, err)
+	_, err = ParseKeyring("k1", []string{"k1:" + base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.ErrorContains(t, err, "32 bytes")
+	_, err = ParseKeyring("k1", []string{"k1:" + masterKey(1), "k1:" + masterKey(2)})
+	assert.ErrorContains(t, err, "twice")
+}
+
+func TestKeyring_WrapRewrapUnwrap(t *testing.T) {
+	old, err := ParseKeyring("k1", []string{"k1:" + masterKey(1)})
+	require.NoError(t, err)
+	data, wrapped, err := old.GenerateDataKey([]byte("conversation-7"))
+	require.NoError(t, err)
+	assert.Equal(t, "k1", wrapped.KeyID)
+
+	sealed, err := data.SealString("my card ends in 4242", []byte("m1"))
+	require.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, sealed, "4242")
+
+	_, err = old.Unwrap(wrapped, []byte("conversation-8"))
+	assert.Error(t, err, "wrapped key is bound to its owner")
+
+	rotated, err := ParseKeyring("k2", []string{"k1:" + masterKey(1), "k2:" + masterKey(2)})
+	require.NoError(t, err)
+	rewrapped, err := rotated.Rewrap(wrapped, []byte("conversation-7"))
+	require.NoError(t, err)
+	assert.Equal(t, "k2", rewrapped.KeyID)
+
+	retired, err := ParseKeyring("k2", []string{"k2:" + masterKey(2)})
+	require.NoError(t, err)
+	_, err = retired.Unwrap(wrapped, []byte("conversation-7"))
+	assert.ErrorContains(t, err, "not in the keyring")
+	data, err = retired.Unwrap(rewrapped, []byte("conversation-7"))
+	require.NoError(t, err)
+	plaintext, err := data.OpenString(sealed, []byte("m1"))
+	require.NoError(t, err)
+	assert.Equal(t, "my card ends in 4242", plaintext)
+}
+
+func TestDataCipher(t *testing.T) {
+	data, err := NewDataCipher(bytes.Repeat([]byte{9}, 32))
+	require.NoError(t, err)
+
+	sealed, err := data.Seal([]byte{1, 2, 3}, []byte("user.wav"))
+	require.NoError(t, err)
+	plaintext, err := data.Open(sealed, []byte("user.wav"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, plaintext)
+
+	_, err = data.Open(sealed, []byte("assistant.wav"))
+	assert.Error(t, err)
+	_, err = data.Open(sealed[:4], nil)
+	assert.Error(t, err)
+
+	plain, err := data.OpenString("written before encryption", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "written before encryption", plain)
+
+	_, err = NewDataCipher([]byte("short"))
+	assert.Error(t, err)
+}
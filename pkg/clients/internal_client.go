@@ -76,9 +76,18 @@ func (ic *internalClient) WithAuth(c context.Context, auth types.SimplePrinciple
 		return c
 	}
 	md := metadata.New(map[string]string{types.SERVICE_SCOPE_KEY: token})
+	withAuditReason(c, md)
 	return metadata.NewOutgoingContext(c, md)
 }
 
+// withAuditReason carries the reason the caller gave for an action on to the
+// service that audits it.
+func withAuditReason(c context.Context, md metadata.MD) {
+	if reason := metadata.ValueFromIncomingContext(c, types.AUDIT_REASON_KEY); len(reason) > 0 {
+		md.Set(types.AUDIT_REASON_KEY, reason[0])
+	}
+}
+
 func (ic *internalClient) WithPlatform(c context.Context, auth types.SimplePrinciple) context.Context {
 	token, err := types.CreateServiceScopeToken(auth, ic.cfg.Secret)
 	if err != nil {
@@ -104,7 +113,9 @@ func (ic *internalClient) WithPlatform(c context.Context, auth types.SimplePrinc
 		_platform[utils.HEADER_REGION_KEY] = region.Get()
 	}
 
-	return metadata.NewOutgoingContext(c, metadata.New(_platform))
+	md := metadata.New(_platform)
+	withAuditReason(c, md)
+	return metadata.NewOutgoingContext(c, md)
 }
 
 func (ic *internalClient) WithHttpAuth(c context.Context, auth types.SimplePrinciple, req *http.Request) *http.Request {
@@ -0,0 +1,78 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package workflow_client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/rapidaai/config"
+	clients "github.com/rapidaai/pkg/clients"
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/connectors"
+	"github.com/rapidaai/pkg/types"
+	"github.com/rapidaai/pkg/utils"
+	assistant_api "github.com/rapidaai/protos"
+)
+
+// ControlAuditServiceClient records administrative actions taken outside the
+// assistant service in its control audit log.
+type ControlAuditServiceClient interface {
+	CreateControlAuditLog(c context.Context, auth types.SimplePrinciple, action, resourceType string, resourceId uint64, before, after map[string]interface{}) (*assistant_api.ControlAuditLog, error)
+}
+
+type controlAuditServiceClient struct {
+	clients.InternalClient
+	cfg         *config.AppConfig
+	logger      commons.Logger
+	auditClient assistant_api.ControlAuditServiceClient
+}
+
+func NewControlAuditServiceClientGRPC(config *config.AppConfig, logger commons.Logger, redis connectors.RedisConnector) ControlAuditServiceClient {
+	logger.Debugf("conntecting to control audit client with %s", config.AssistantHost)
+	conn, err := grpc.NewClient(config.AssistantHost, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		logger.Errorf("Unable to create connection %v", err)
+	}
+	return &controlAuditServiceClient{
+		InternalClient: clients.NewInternalClient(config, logger, redis),
+		cfg:            config,
+		logger:         logger,
+		auditClient:    assistant_api.NewControlAuditServiceClient(conn),
+	}
+}
+
+// CreateControlAuditLog records the action with auth as actor, the reason is
+// forwarded from the incoming request.
+func (client *controlAuditServiceClient) CreateControlAuditLog(c context.Context, auth types.SimplePrinciple, action, resourceType string, resourceId uint64, before, after map[string]interface{}) (*assistant_api.ControlAuditLog, error) {
+	start := time.Now()
+	request := &assistant_api.CreateControlAuditLogRequest{
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+	}
+	if before != nil {
+		request.Before = utils.MapToStruct(before)
+	}
+	if after != nil {
+		request.After = utils.MapToStruct(after)
+	}
+	res, err := client.auditClient.CreateControlAuditLog(client.WithAuth(c, auth), request)
+	client.logger.Benchmark("Benchmarking: controlAuditServiceClient.CreateControlAuditLog", time.Since(start))
+	if err != nil {
+		client.logger.Errorf("error while calling to CreateControlAuditLog %v", err)
+		return nil, err
+	}
+	if !res.GetSuccess() {
+		client.logger.Errorf("unable to record %s of %s %d: %s", action, resourceType, resourceId, res.GetError().GetErrorMessage())
+		return nil, errors.New(res.GetError().GetHumanMessage())
+	}
+	return res.GetData(), nil
+}
@@ -20,6 +20,15 @@ type AssetStoreConfig struct {
 	StoragePathPrefix string     `mapstructure:"storage_path_prefix"`
 	PublicUrlPrefix   *string    `mapstructure:"public_url_prefix"`
 	Auth              *AwsConfig `mapstructure:"auth"`
+
+	// ArchiveStorageClass is the S3 storage class objects are archived to,
+	// GLACIER when empty (DEEP_ARCHIVE for the cheapest, slowest tier).
+	ArchiveStorageClass string `mapstructure:"archive_storage_class"`
+
+	// Endpoint points the s3 storage at another S3 compatible service, e.g.
+	// https://storage.googleapis.com with HMAC keys for Google Cloud Storage
+	// or a MinIO server. Buckets are then addressed by path.
+	Endpoint string `mapstructure:"endpoint"`
 }
 
 func (cfg *AssetStoreConfig) Type() StorageType {
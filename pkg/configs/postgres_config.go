@@ -5,6 +5,8 @@
 // See LICENSE.md or contact sales@rapida.ai for commercial usage.
 package configs
 
+import "time"
+
 type PostgresConfig struct {
 	Host               string    `mapstructure:"host" validate:"required"`
 	Port               int       `mapstructure:"port"`
@@ -15,4 +17,15 @@ type PostgresConfig struct {
 	SslMode            string    `mapstructure:"ssl_mode" validate:"required"`
 	// currently we only support redis caching // later you know me i will add multiple
 	SLCache *RedisConfig `mapstructure:"slc_cache"`
+
+	// ConnMaxLifetime and ConnMaxIdleTime bound how long pooled connections
+	// are reused; zero keeps the defaults of one hour and unlimited.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+	// PrepareStatement caches prepared statements per connection.
+	PrepareStatement bool `mapstructure:"prepare_statement"`
+	// Replicas are host:port addresses of read replicas serving reads that
+	// tolerate replication lag. They share the database, credentials and ssl
+	// mode of the primary.
+	Replicas []string `mapstructure:"replicas"`
 }
@@ -7,6 +7,7 @@ package configs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
@@ -118,3 +119,29 @@ func TestPostgresConfig_Defaults(t *testing.T) {
 		t.Errorf("SLCache should be nil")
 	}
 }
+
+func TestPostgresConfig_PoolAndReplicas(t *testing.T) {
+	v := viper.New()
+	v.Set("host", "db.example.com")
+	v.Set("conn_max_lifetime", "30m")
+	v.Set("conn_max_idle_time", "90s")
+	v.Set("prepare_statement", true)
+	v.Set("replicas", "replica-0:5432,replica-1:5433")
+
+	var cfg PostgresConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if cfg.ConnMaxLifetime != 30*time.Minute {
+		t.Errorf("ConnMaxLifetime = %v, want 30m", cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime != 90*time.Second {
+		t.Errorf("ConnMaxIdleTime = %v, want 90s", cfg.ConnMaxIdleTime)
+	}
+	if !cfg.PrepareStatement {
+		t.Errorf("PrepareStatement = false, want true")
+	}
+	if len(cfg.Replicas) != 2 || cfg.Replicas[0] != "replica-0:5432" || cfg.Replicas[1] != "replica-1:5433" {
+		t.Errorf("Replicas = %v, want [replica-0:5432 replica-1:5433]", cfg.Replicas)
+	}
+}
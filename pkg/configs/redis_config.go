@@ -5,6 +5,15 @@
 // See LICENSE.md or contact sales@rapida.ai for commercial usage.
 package configs
 
+import "fmt"
+
+// Redis deployment modes.
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeCluster    = "cluster"
+	RedisModeSentinel   = "sentinel"
+)
+
 type RedisConfig struct {
 	Host               string    `mapstructure:"host" validate:"required"`
 	Port               int       `mapstructure:"port" validate:"required"`
@@ -12,4 +21,26 @@ type RedisConfig struct {
 	MaxConnection      int       `mapstructure:"max_connection" validate:"required"`
 	Auth               BasicAuth `mapstructure:"auth"`
 	InsecureSkipVerify bool      `mapstructure:"insecure_skip_verify"`
+
+	// Mode is standalone when empty. In cluster mode Host:Port and Addrs are
+	// the seed nodes, in sentinel mode they are the sentinels watching
+	// MasterName.
+	Mode       string   `mapstructure:"mode" validate:"omitempty,oneof=standalone cluster sentinel"`
+	Addrs      []string `mapstructure:"addrs"`
+	MasterName string   `mapstructure:"master_name" validate:"required_if=Mode sentinel"`
+	// SentinelAuth authenticates against the sentinels, Auth against the
+	// master they point to.
+	SentinelAuth BasicAuth `mapstructure:"sentinel_auth"`
+}
+
+// Addresses returns Host:Port followed by the further addresses of Addrs.
+func (cfg *RedisConfig) Addresses() []string {
+	first := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	addrs := []string{first}
+	for _, addr := range cfg.Addrs {
+		if addr != "" && addr != first {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
 }
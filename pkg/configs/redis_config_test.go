@@ -103,3 +103,48 @@ func TestRedisConfig_Defaults(t *testing.T) {
 		t.Errorf("InsecureSkipVerify = %v, want false", cfg.InsecureSkipVerify)
 	}
 }
+
+func TestRedisConfig_Modes(t *testing.T) {
+	validate := validator.New()
+	tests := []struct {
+		name    string
+		cfg     RedisConfig
+		wantErr bool
+	}{
+		{"cluster", RedisConfig{Host: "node-1", Port: 6379, MaxConnection: 10, Mode: RedisModeCluster, Addrs: []string{"node-2:6379"}}, false},
+		{"sentinel", RedisConfig{Host: "sentinel-1", Port: 26379, MaxConnection: 10, Mode: RedisModeSentinel, MasterName: "mymaster"}, false},
+		{"sentinel without master", RedisConfig{Host: "sentinel-1", Port: 26379, MaxConnection: 10, Mode: RedisModeSentinel}, true},
+		{"unknown mode", RedisConfig{Host: "localhost", Port: 6379, MaxConnection: 10, Mode: "replicated"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedisConfig_Addresses(t *testing.T) {
+	v := viper.New()
+	v.Set("host", "node-1")
+	v.Set("port", 6379)
+	v.Set("mode", "cluster")
+	v.Set("addrs", "node-2:6379,node-1:6379,node-3:6379")
+
+	var cfg RedisConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	want := []string{"node-1:6379", "node-2:6379", "node-3:6379"}
+	got := cfg.Addresses()
+	if len(got) != len(want) {
+		t.Fatalf("Addresses() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Addresses()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -8,6 +8,9 @@ package connectors
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-gorm/caches/v4"
@@ -23,12 +26,17 @@ type PostgresConnector interface {
 	Connector
 	Query(ctx context.Context, qry string, dest interface{}) error
 	DB(ctx context.Context) *gorm.DB
+	// ReadDB is a session on one of the read replicas, or on the primary when
+	// none are configured. Rows written moments ago may not be there yet.
+	ReadDB(ctx context.Context) *gorm.DB
 }
 
 type postgresConnector struct {
-	logger commons.Logger
-	cfg    *configs.PostgresConfig
-	db     *gorm.DB
+	logger   commons.Logger
+	cfg      *configs.PostgresConfig
+	db       *gorm.DB
+	replicas []*gorm.DB
+	next     atomic.Uint64
 }
 
 func NewPostgresConnector(config *configs.PostgresConfig, logger commons.Logger) PostgresConnector {
@@ -39,24 +47,51 @@ func (psql *postgresConnector) DB(ctx context.Context) *gorm.DB {
 	return psql.db.WithContext(ctx)
 }
 
+// ReadDB round-robins over the replicas.
+func (psql *postgresConnector) ReadDB(ctx context.Context) *gorm.DB {
+	if len(psql.replicas) == 0 {
+		return psql.DB(ctx)
+	}
+	n := psql.next.Add(1)
+	return psql.replicas[n%uint64(len(psql.replicas))].WithContext(ctx)
+}
+
 // generating connection string from configuration
 func (psql *postgresConnector) connectionString() string {
-	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s", psql.cfg.Host, psql.cfg.Auth.User, psql.cfg.Auth.Password, psql.cfg.DBName, psql.cfg.Port, psql.cfg.SslMode)
+	return psql.connectionStringFor(psql.cfg.Host, psql.cfg.Port)
 }
 
-func (psql *postgresConnector) Connect(ctx context.Context) error {
+func (psql *postgresConnector) connectionStringFor(host string, port int) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s", host, psql.cfg.Auth.User, psql.cfg.Auth.Password, psql.cfg.DBName, port, psql.cfg.SslMode)
+}
+
+// replicaConnectionString builds the connection string of a host:port
+// replica, falling back to the primary's port when addr has none.
+func (psql *postgresConnector) replicaConnectionString(addr string) (string, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return psql.connectionStringFor(addr, psql.cfg.Port), nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid port in postgres replica %q: %w", addr, err)
+	}
+	return psql.connectionStringFor(host, port), nil
+}
+
+// open opens a pooled connection tuned by the configuration.
+func (psql *postgresConnector) open(dsn string) (*gorm.DB, error) {
 	lgr := logger.Discard.LogMode(logger.Silent)
-	db, err := gorm.Open(postgres.Open(psql.connectionString()), &gorm.Config{
-		Logger: lgr,
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger:      lgr,
+		PrepareStmt: psql.cfg.PrepareStatement,
 	})
 	if err != nil {
-		psql.logger.Errorf("Failed to open postgres connection %s.", err)
-		return err
+		return nil, err
 	}
 	sqlDB, err := db.DB()
 	if err != nil {
-		psql.logger.Errorf("Failed to create postgres client connection pool %s.", err)
-		return err
+		return nil, err
 	}
 	// SetMaxIdleConns sets the maximum number of connections in the idle connection pool.
 	sqlDB.SetMaxIdleConns(psql.cfg.MaxIdealConnection)
@@ -64,6 +99,21 @@ func (psql *postgresConnector) Connect(ctx context.Context) error {
 	sqlDB.SetMaxOpenConns(psql.cfg.MaxOpenConnection)
 	// SetConnMaxLifetime sets the maximum amount of time a connection may be reused.
 	sqlDB.SetConnMaxLifetime(time.Hour)
+	if psql.cfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(psql.cfg.ConnMaxLifetime)
+	}
+	if psql.cfg.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(psql.cfg.ConnMaxIdleTime)
+	}
+	return db, nil
+}
+
+func (psql *postgresConnector) Connect(ctx context.Context) error {
+	db, err := psql.open(psql.connectionString())
+	if err != nil {
+		psql.logger.Errorf("Failed to open postgres connection %s.", err)
+		return err
+	}
 
 	if psql.cfg.SLCache != nil {
 		psql.logger.Debugf("Second level caching is enabled for gorm")
@@ -78,7 +128,21 @@ func (psql *postgresConnector) Connect(ctx context.Context) error {
 			_ = db.Use(cachesPlugin)
 		}
 	}
+	replicas := make([]*gorm.DB, 0, len(psql.cfg.Replicas))
+	for _, addr := range psql.cfg.Replicas {
+		dsn, err := psql.replicaConnectionString(addr)
+		if err == nil {
+			var replica *gorm.DB
+			if replica, err = psql.open(dsn); err == nil {
+				replicas = append(replicas, replica)
+				continue
+			}
+		}
+		// reads fall back to the primary rather than failing the service
+		psql.logger.Errorf("Failed to open postgres replica %s, reads skip it: %s.", addr, err)
+	}
 	psql.db = db
+	psql.replicas = replicas
 	return nil
 }
 
@@ -104,6 +168,12 @@ func (psql *postgresConnector) IsConnected(ctx context.Context) bool {
 }
 func (psql *postgresConnector) Disconnect(ctx context.Context) error {
 	psql.logger.Debug("Disconnecting with postgres client.")
+	for _, replica := range psql.replicas {
+		if sqlDB, err := replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+	psql.replicas = nil
 	db, err := psql.db.DB()
 	psql.db = nil
 	if err != nil {
@@ -187,3 +187,44 @@ func TestPostgresConnector_EdgeCases(t *testing.T) {
 		assert.Equal(t, "PSQL psql://db.example.com:9999", result)
 	})
 }
+
+func TestPostgresConnector_ReadDB(t *testing.T) {
+	open := func() *gorm.DB {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+		assert.NoError(t, err)
+		return gormDB
+	}
+	primary := open()
+	connector := &postgresConnector{cfg: &configs.PostgresConfig{}, db: primary}
+	ctx := context.Background()
+
+	// without replicas reads go to the primary
+	assert.Same(t, primary.ConnPool, connector.ReadDB(ctx).ConnPool)
+
+	connector.replicas = []*gorm.DB{open(), open()}
+	first := connector.ReadDB(ctx).ConnPool
+	second := connector.ReadDB(ctx).ConnPool
+	assert.NotSame(t, primary.ConnPool, first)
+	assert.NotSame(t, first, second)
+	assert.Same(t, first, connector.ReadDB(ctx).ConnPool)
+}
+
+func TestPostgresConnector_ReplicaConnectionString(t *testing.T) {
+	connector := &postgresConnector{cfg: &configs.PostgresConfig{
+		Host: "primary", Port: 5432, DBName: "assistant", SslMode: "disable",
+		Auth: configs.BasicAuth{User: "rapida", Password: "secret"},
+	}}
+
+	dsn, err := connector.replicaConnectionString("replica-0:6432")
+	assert.NoError(t, err)
+	assert.Equal(t, "host=replica-0 user=rapida password=secret dbname=assistant port=6432 sslmode=disable", dsn)
+
+	dsn, err = connector.replicaConnectionString("replica-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "host=replica-1 user=rapida password=secret dbname=assistant port=5432 sslmode=disable", dsn)
+
+	_, err = connector.replicaConnectionString("replica-2:pg")
+	assert.Error(t, err)
+}
@@ -29,7 +29,8 @@ type RedisConnector interface {
 	// multi key commands
 	Cmds(ctx context.Context, cmd string, args *[]string) *RedisResponse
 	// GetConnection returns the underlying redis client for advanced operations (Lua scripts, sets, etc.)
-	GetConnection() *redis.Client
+	// It is a cluster or sentinel failover client when the config asks for one.
+	GetConnection() redis.UniversalClient
 }
 
 type RedisPostgresCacheConnector interface {
@@ -39,7 +40,7 @@ type RedisPostgresCacheConnector interface {
 
 type redisConnector struct {
 	cfg        *configs.RedisConfig
-	Connection *redis.Client
+	Connection redis.UniversalClient
 	logger     commons.Logger
 }
 
@@ -69,27 +70,41 @@ func (redisC *redisConnector) connectionString() string {
 
 // provide a debug name for connector
 func (redisC *redisConnector) Name() string {
+	if redisC.cfg.Mode != "" && redisC.cfg.Mode != configs.RedisModeStandalone {
+		return fmt.Sprintf("REDIS %s %s:%d", redisC.cfg.Mode, redisC.cfg.Host, redisC.cfg.Port)
+	}
 	return fmt.Sprintf("REDIS %s:%d", redisC.cfg.Host, redisC.cfg.Port)
 }
 
 // only connect the call usually made by main.go to create a connection with given configuration
 // anyway can be called anywhere as config is will always be in socpe of connect
 func (redisC *redisConnector) Connect(ctx context.Context) error {
-	opt := &redis.Options{
-		Addr:     redisC.connectionString(),
-		PoolSize: redisC.cfg.MaxConnection,
-		Username: redisC.cfg.Auth.User,
-		Password: redisC.cfg.Auth.Password,
-		DB:       redisC.cfg.Db,
+	opt := &redis.UniversalOptions{
+		Addrs:            []string{redisC.connectionString()},
+		PoolSize:         redisC.cfg.MaxConnection,
+		Username:         redisC.cfg.Auth.User,
+		Password:         redisC.cfg.Auth.Password,
+		DB:               redisC.cfg.Db,
+		MasterName:       redisC.cfg.MasterName,
+		SentinelUsername: redisC.cfg.SentinelAuth.User,
+		SentinelPassword: redisC.cfg.SentinelAuth.Password,
 	}
 	if redisC.cfg.InsecureSkipVerify {
 		opt.TLSConfig = &tls.Config{
 			InsecureSkipVerify: redisC.cfg.InsecureSkipVerify,
 		}
 	}
-	client := redis.NewClient(opt)
-
-	redisC.Connection = client
+	// the mode is explicit, a cluster may well be reached through one seed node
+	switch redisC.cfg.Mode {
+	case configs.RedisModeCluster:
+		opt.Addrs = redisC.cfg.Addresses()
+		redisC.Connection = redis.NewClusterClient(opt.Cluster())
+	case configs.RedisModeSentinel:
+		opt.Addrs = redisC.cfg.Addresses()
+		redisC.Connection = redis.NewFailoverClient(opt.Failover())
+	default:
+		redisC.Connection = redis.NewClient(opt.Simple())
+	}
 	redisC.logger.Debugf("Created new client for redis with name: %s", redisC.Name())
 
 	if ok := redisC.IsConnected(ctx); !ok {
@@ -99,7 +114,7 @@ func (redisC *redisConnector) Connect(ctx context.Context) error {
 }
 
 // getting connection to use if anyone wants to use the connection
-func (redisC *redisConnector) GetConnection() *redis.Client {
+func (redisC *redisConnector) GetConnection() redis.UniversalClient {
 	return redisC.Connection
 }
 
@@ -377,11 +392,22 @@ func (redisC *redisPostgresCacheConnector) Store(ctx context.Context, key string
 }
 
 func (redisC *redisPostgresCacheConnector) Invalidate(ctx context.Context) error {
+	// SCAN only walks the keys of the node it reaches, every master of a
+	// cluster holds a share of the cache
+	if cluster, ok := redisC.Connection.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return redisC.invalidate(ctx, node)
+		})
+	}
+	return redisC.invalidate(ctx, redisC.Connection)
+}
+
+func (redisC *redisPostgresCacheConnector) invalidate(ctx context.Context, client redis.UniversalClient) error {
 	const batchSize = 1000
 	var cursor uint64
 
 	for {
-		keys, nextCursor, err := redisC.Connection.Scan(ctx, cursor, fmt.Sprintf("%s*", redisC.Prefix), batchSize).Result()
+		keys, nextCursor, err := client.Scan(ctx, cursor, fmt.Sprintf("%s*", redisC.Prefix), batchSize).Result()
 		if err != nil {
 			return fmt.Errorf("scan error: %w", err)
 		}
@@ -396,11 +422,11 @@ func (redisC *redisPostgresCacheConnector) Invalidate(ctx context.Context) error
 				batch := keys[i:end]
 
 				// Use UNLINK instead of DEL for better performance
-				err := redisC.Connection.Unlink(ctx, batch...).Err()
+				err := client.Unlink(ctx, batch...).Err()
 				if err != nil {
 					if strings.Contains(err.Error(), "CROSSSLOT") {
 						for _, key := range batch {
-							if err := redisC.Connection.Unlink(ctx, key).Err(); err != nil {
+							if err := client.Unlink(ctx, key).Err(); err != nil {
 								return fmt.Errorf("individual unlink error: %w", err)
 							}
 						}
@@ -60,6 +60,16 @@ func TestRedisConnector_Name(t *testing.T) {
 	}
 }
 
+func TestRedisConnector_NameWithMode(t *testing.T) {
+	connector := &redisConnector{
+		cfg: &configs.RedisConfig{Host: "sentinel-1", Port: 26379, Mode: configs.RedisModeSentinel, MasterName: "mymaster"},
+	}
+	assert.Equal(t, "REDIS sentinel sentinel-1:26379", connector.Name())
+
+	connector.cfg.Mode = configs.RedisModeStandalone
+	assert.Equal(t, "REDIS sentinel-1:26379", connector.Name())
+}
+
 func TestRedisConnector_Connect(t *testing.T) {
 	t.Skip("Connect requires real Redis server - integration test")
 }
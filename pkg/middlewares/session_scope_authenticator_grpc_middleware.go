@@ -0,0 +1,47 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package middlewares
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/metadata"
+	"google.golang.org/grpc"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+)
+
+// NewSessionAuthenticatorStreamServerMiddleware authenticates session tokens
+// on the given streaming methods only, a session token never grants access
+// to the rest of the API. Tokens bound to an origin are refused from pages
+// of other origins.
+func NewSessionAuthenticatorStreamServerMiddleware(resolver types.ClaimAuthenticator[*types.SessionScope], logger commons.Logger, methods ...string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		token := metadata.ExtractIncoming(ctx).Get(types.SESSION_SCOPE_KEY)
+		if strings.TrimSpace(token) == "" || !slices.Contains(methods, info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		auth, err := resolver.Claim(ctx, token)
+		if err != nil {
+			logger.Errorf("unable to resolve the session token: %v", err)
+			return handler(srv, stream)
+		}
+		if origin := metadata.ExtractIncoming(ctx).Get("origin"); !auth.Info.AllowsOrigin(origin) {
+			logger.Errorf("session token used from origin %s, it was minted for %s", origin, auth.Info.Origin)
+			return handler(srv, stream)
+		}
+
+		wrapped := middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = context.WithValue(ctx, types.CTX_, auth)
+		return handler(srv, wrapped)
+	}
+}
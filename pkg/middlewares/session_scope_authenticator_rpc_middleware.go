@@ -0,0 +1,46 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/pkg/types"
+)
+
+// NewSessionAuthenticatorMiddleware authenticates session tokens on the
+// routes it is added to, a session token never grants access to the rest of
+// the API. Browsers cannot set headers on a WebSocket, the token is taken
+// from the query as well. Tokens bound to an origin are refused from pages
+// of other origins.
+func NewSessionAuthenticatorMiddleware(resolver types.ClaimAuthenticator[*types.SessionScope], logger commons.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(types.SESSION_SCOPE_KEY)
+		if token == "" {
+			token = c.Query(types.SESSION_SCOPE_KEY)
+		}
+		if strings.TrimSpace(token) == "" {
+			c.Next()
+			return
+		}
+		auth, err := resolver.Claim(c, token)
+		if err != nil {
+			logger.Errorf("unable to resolve the session token: %v", err)
+			c.Next()
+			return
+		}
+		if origin := c.GetHeader("Origin"); !auth.Info.AllowsOrigin(origin) {
+			logger.Errorf("session token used from origin %s, it was minted for %s", origin, auth.Info.Origin)
+			c.Next()
+			return
+		}
+		c.Set(string(types.CTX_), auth)
+		c.Next()
+	}
+}
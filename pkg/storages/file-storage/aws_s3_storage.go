@@ -10,11 +10,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	aws_session "github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -31,10 +33,14 @@ type awsFileStorage struct {
 	options aws_session.Options
 }
 
-func NewAwsFileStorage(cfg configs.AssetStoreConfig, logger commons.Logger) storages.Storage {
+func NewAwsFileStorage(cfg configs.AssetStoreConfig, logger commons.Logger) storages.LifecycleStorage {
 	config := aws.Config{
 		Region: aws.String(cfg.Auth.Region),
 	}
+	if cfg.Endpoint != "" {
+		config.Endpoint = aws.String(cfg.Endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
 	if cfg.Auth.AccessKeyId != "" && cfg.Auth.SecretKey != "" {
 		config.Credentials = credentials.NewStaticCredentials(
 			cfg.Auth.AccessKeyId,
@@ -157,3 +163,108 @@ func (aws *awsFileStorage) GetUrl(ctx context.Context, key string) storages.Stor
 		StorageType:  configs.S3,
 	}
 }
+
+// archiveStorageClass is the storage class objects are archived to.
+func (storage *awsFileStorage) archiveStorageClass() string {
+	switch strings.ToUpper(strings.TrimSpace(storage.config.ArchiveStorageClass)) {
+	case s3.StorageClassDeepArchive:
+		return s3.StorageClassDeepArchive
+	case s3.StorageClassGlacierIr:
+		return s3.StorageClassGlacierIr
+	default:
+		return s3.StorageClassGlacier
+	}
+}
+
+// Archive implements storages.LifecycleStorage. S3 changes the storage class
+// of an object by copying it onto itself.
+func (storage *awsFileStorage) Archive(ctx context.Context, key string) error {
+	storage.logger.Debugf("s3.archive with file path name %s", key)
+	aws_session, err := aws_session.NewSessionWithOptions(storage.options)
+	if err != nil {
+		storage.logger.Errorf("unable to create aws s3 session to archive the object %v", err)
+		return err
+	}
+	s3Client := s3.New(aws_session)
+	_, err = s3Client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(storage.config.StoragePathPrefix),
+		Key:               aws.String(key),
+		CopySource:        aws.String(url.PathEscape(storage.config.StoragePathPrefix + "/" + key)),
+		StorageClass:      aws.String(storage.archiveStorageClass()),
+		MetadataDirective: aws.String(s3.MetadataDirectiveCopy),
+	})
+	if err != nil {
+		storage.logger.Errorf("Error archiving object %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+// Restore implements storages.LifecycleStorage.
+func (storage *awsFileStorage) Restore(ctx context.Context, key string, days int) (bool, error) {
+	aws_session, err := aws_session.NewSessionWithOptions(storage.options)
+	if err != nil {
+		storage.logger.Errorf("unable to create aws s3 session to restore the object %v", err)
+		return false, err
+	}
+	s3Client := s3.New(aws_session)
+	head, err := s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(storage.config.StoragePathPrefix),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		storage.logger.Errorf("Error reading object %s: %v", key, err)
+		return false, err
+	}
+	if !requiresRestore(aws.StringValue(head.StorageClass)) {
+		return true, nil
+	}
+	if head.Restore != nil {
+		// x-amz-restore: ongoing-request="false", expiry-date="..." once done
+		return strings.Contains(*head.Restore, `ongoing-request="false"`), nil
+	}
+
+	storage.logger.Debugf("s3.restore with file path name %s for %d days", key, days)
+	_, err = s3Client.RestoreObjectWithContext(ctx, &s3.RestoreObjectInput{
+		Bucket: aws.String(storage.config.StoragePathPrefix),
+		Key:    aws.String(key),
+		RestoreRequest: &s3.RestoreRequest{
+			Days:                 aws.Int64(int64(days)),
+			GlacierJobParameters: &s3.GlacierJobParameters{Tier: aws.String(s3.TierStandard)},
+		},
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "RestoreAlreadyInProgress" {
+		return false, nil
+	}
+	if err != nil {
+		storage.logger.Errorf("Error restoring object %s: %v", key, err)
+		return false, err
+	}
+	return false, nil
+}
+
+// requiresRestore reports whether objects of a storage class must be
+// restored before they can be read. Glacier Instant Retrieval reads directly.
+func requiresRestore(storageClass string) bool {
+	return storageClass == s3.StorageClassGlacier || storageClass == s3.StorageClassDeepArchive
+}
+
+// Delete implements storages.LifecycleStorage.
+func (storage *awsFileStorage) Delete(ctx context.Context, key string) error {
+	storage.logger.Debugf("s3.delete with file path name %s", key)
+	aws_session, err := aws_session.NewSessionWithOptions(storage.options)
+	if err != nil {
+		storage.logger.Errorf("unable to create aws s3 session to delete the object %v", err)
+		return err
+	}
+	s3Client := s3.New(aws_session)
+	_, err = s3Client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(storage.config.StoragePathPrefix),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		storage.logger.Errorf("Error deleting object %s: %v", key, err)
+		return err
+	}
+	return nil
+}
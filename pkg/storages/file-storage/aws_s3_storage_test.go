@@ -142,3 +142,32 @@ func TestAwsFileStorage_GetUrl_SessionCreationFailure(t *testing.T) {
 		assert.Contains(t, result.CompletePath, "test/file.txt")
 	}
 }
+
+func TestAwsFileStorage_archiveStorageClass(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	tests := map[string]string{
+		"":             "GLACIER",
+		"glacier":      "GLACIER",
+		"DEEP_ARCHIVE": "DEEP_ARCHIVE",
+		"glacier_ir":   "GLACIER_IR",
+		"STANDARD":     "GLACIER",
+	}
+	for configured, expected := range tests {
+		cfg := configs.AssetStoreConfig{
+			StorageType:         "s3",
+			StoragePathPrefix:   "test-bucket",
+			ArchiveStorageClass: configured,
+			Auth:                &configs.AwsConfig{Region: "us-east-1"},
+		}
+		storage := NewAwsFileStorage(cfg, logger).(*awsFileStorage)
+		assert.Equal(t, expected, storage.archiveStorageClass(), configured)
+	}
+}
+
+func TestRequiresRestore(t *testing.T) {
+	assert.True(t, requiresRestore("GLACIER"))
+	assert.True(t, requiresRestore("DEEP_ARCHIVE"))
+	assert.False(t, requiresRestore("GLACIER_IR"))
+	assert.False(t, requiresRestore("STANDARD"))
+	assert.False(t, requiresRestore(""), "HEAD omits the class of STANDARD objects")
+}
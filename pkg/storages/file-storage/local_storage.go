@@ -7,6 +7,7 @@ package storage_files
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path"
 	"path/filepath"
@@ -44,7 +45,7 @@ func (lfs *localFileStorage) Get(ctx context.Context, key string) storages.GetSt
 	return storages.GetStorageOutput{Data: content}
 }
 
-func NewLocalFileStorage(cfg configs.AssetStoreConfig, logger commons.Logger) storages.Storage {
+func NewLocalFileStorage(cfg configs.AssetStoreConfig, logger commons.Logger) storages.LifecycleStorage {
 	return &localFileStorage{
 		config: cfg,
 		logger: logger,
@@ -93,3 +94,31 @@ func (lfs *localFileStorage) GetUrl(ctx context.Context, key string) storages.St
 		StorageType:  configs.LOCAL,
 	}
 }
+
+// Archive implements storages.LifecycleStorage. A local disk has a single
+// tier, archived files stay where they are.
+func (lfs *localFileStorage) Archive(ctx context.Context, key string) error {
+	lfs.logger.Debugf("localstorage.archive with file path name %s", key)
+	_, err := os.Stat(path.Join(lfs.config.StoragePathPrefix, key))
+	return err
+}
+
+// Restore implements storages.LifecycleStorage, local files are always
+// readable.
+func (lfs *localFileStorage) Restore(ctx context.Context, key string, days int) (bool, error) {
+	if _, err := os.Stat(path.Join(lfs.config.StoragePathPrefix, key)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete implements storages.LifecycleStorage.
+func (lfs *localFileStorage) Delete(ctx context.Context, key string) error {
+	lfs.logger.Debugf("localstorage.delete with file path name %s", key)
+	err := os.Remove(path.Join(lfs.config.StoragePathPrefix, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		lfs.logger.Errorf("unable to delete file from local path, err %v", err)
+		return err
+	}
+	return nil
+}
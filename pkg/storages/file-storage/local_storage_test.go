@@ -168,3 +168,30 @@ func TestLocalFileStorage_GetUrl(t *testing.T) {
 	expectedPath := "file://" + filepath.Join("/", tempDir, key)
 	assert.Equal(t, expectedPath, result.CompletePath)
 }
+
+func TestLocalFileStorage_Lifecycle(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "local_storage_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logger, _ := commons.NewApplicationLogger()
+	storage := NewLocalFileStorage(configs.AssetStoreConfig{StorageType: "local", StoragePathPrefix: tempDir}, logger)
+
+	ctx := context.Background()
+	key := "recording/user.wav"
+	require.NoError(t, storage.Store(ctx, key, []byte("audio")).Error)
+
+	assert.NoError(t, storage.Archive(ctx, key))
+	readable, err := storage.Restore(ctx, key, 7)
+	assert.NoError(t, err)
+	assert.True(t, readable, "local files are never in a cold tier")
+	assert.Equal(t, []byte("audio"), storage.Get(ctx, key).Data)
+
+	assert.NoError(t, storage.Delete(ctx, key))
+	assert.NoFileExists(t, filepath.Join(tempDir, key))
+	assert.NoError(t, storage.Delete(ctx, key), "deleting twice is fine")
+
+	assert.Error(t, storage.Archive(ctx, key))
+	_, err = storage.Restore(ctx, key, 7)
+	assert.Error(t, err)
+}
@@ -81,3 +81,22 @@ type Storage interface {
 	//   - StorageOutput containing the URL/path and any error.
 	GetUrl(ctx context.Context, key string) StorageOutput
 }
+
+// LifecycleStorage is implemented by backends that can move objects between
+// storage tiers and remove them, as retention policies require.
+type LifecycleStorage interface {
+	Storage
+
+	// Archive moves the object to the cold tier of the backend. Archived
+	// objects keep their key but cannot be read until they are restored.
+	Archive(ctx context.Context, key string) error
+
+	// Restore makes an archived object readable for the given number of
+	// days. It returns true when the object can be read now and false while
+	// a restore started by this or an earlier call is still in progress.
+	// Objects that were never archived are always readable.
+	Restore(ctx context.Context, key string, days int) (bool, error)
+
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
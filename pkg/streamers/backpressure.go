@@ -0,0 +1,133 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rapidaai/protos"
+)
+
+// BackpressurePolicy is what a push does when its channel is full.
+type BackpressurePolicy string
+
+const (
+	// DropNewest drops the message being pushed, the default and the
+	// behaviour before policies existed.
+	DropNewest BackpressurePolicy = "drop_newest"
+	// DropOldest drops the oldest queued message to make room, the talk
+	// loop or the caller falls behind by one frame instead of losing the
+	// latest. A queued disconnection is never dropped for it.
+	DropOldest BackpressurePolicy = "drop_oldest"
+	// BlockWithTimeout waits up to the timeout for room, then drops the
+	// message being pushed. The pushing goroutine, e.g. text to speech,
+	// slows down to the pace of the reader.
+	BlockWithTimeout BackpressurePolicy = "block_with_timeout"
+)
+
+// DefaultBlockTimeout is how long a BlockWithTimeout push waits when no
+// timeout is given.
+const DefaultBlockTimeout = time.Second
+
+// dropLogEvery is how many drops of a direction are logged once, the first
+// drop is always logged.
+const dropLogEvery = 100
+
+// Backpressure selects the policy of the pushes of one direction.
+type Backpressure struct {
+	Policy BackpressurePolicy
+	// Timeout bounds BlockWithTimeout pushes, DefaultBlockTimeout when zero.
+	Timeout time.Duration
+}
+
+// WithInputBackpressure sets what pushes into InputCh do when it is full.
+// Default: DropNewest.
+func WithInputBackpressure(b Backpressure) Option {
+	return func(c *streamerConfig) { c.inputBackpressure = b }
+}
+
+// WithOutputBackpressure sets what pushes into OutputCh do when it is full.
+// Default: DropNewest.
+func WithOutputBackpressure(b Backpressure) Option {
+	return func(c *streamerConfig) { c.outputBackpressure = b }
+}
+
+// Drops counts the messages a streamer dropped per direction.
+type Drops struct {
+	Input  uint64
+	Output uint64
+}
+
+// Dropped returns how many messages the streamer dropped so far.
+func (s *BaseStreamer) Dropped() Drops {
+	return Drops{Input: s.inputDrops.Load(), Output: s.outputDrops.Load()}
+}
+
+// push enqueues msg on ch following the policy of b and reports whether it
+// was enqueued.
+func (s *BaseStreamer) push(ch chan Stream, direction Direction, b Backpressure, msg Stream) bool {
+	select {
+	case ch <- msg:
+		return true
+	default:
+	}
+
+	switch b.Policy {
+	case BlockWithTimeout:
+		timeout := b.Timeout
+		if timeout <= 0 {
+			timeout = DefaultBlockTimeout
+		}
+		start := time.Now()
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case ch <- msg:
+			observeBlocked(direction, time.Since(start))
+			return true
+		case <-timer.C:
+		case <-s.Ctx.Done():
+		}
+	case DropOldest:
+		select {
+		case oldest := <-ch:
+			if _, ok := oldest.(*protos.ConversationDisconnection); ok {
+				// the disconnection ends the queue anyway, keep it last
+				select {
+				case ch <- oldest:
+				default:
+				}
+				break
+			}
+			s.dropped(direction, b.Policy, oldest)
+		default:
+		}
+		select {
+		case ch <- msg:
+			return true
+		default:
+		}
+	}
+	s.dropped(direction, b.Policy, msg)
+	return false
+}
+
+// dropped counts and reports a message dropped from direction. Only every
+// dropLogEvery-th drop is logged so a saturated channel does not flood the
+// log.
+func (s *BaseStreamer) dropped(direction Direction, policy BackpressurePolicy, msg Stream) {
+	counter := &s.inputDrops
+	if direction == Output {
+		counter = &s.outputDrops
+	}
+	if n := counter.Add(1); n%dropLogEvery == 1 {
+		s.Logger.Warnw(fmt.Sprintf("%s channel full, dropping message", direction),
+			"type", fmt.Sprintf("%T", msg), "policy", policy, "dropped", n)
+	}
+	observeDrop(direction, policy)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func audioMessage(b byte) *protos.ConversationUserMessage {
+	return &protos.ConversationUserMessage{Message: &protos.ConversationUserMessage_Audio{Audio: []byte{b}}}
+}
+
+func newBackpressureStreamer(t *testing.T, opts ...Option) *BaseStreamer {
+	t.Helper()
+	logger, _ := commons.NewApplicationLogger()
+	bs := NewBaseStreamer(logger, append([]Option{WithInputChannelSize(2), WithOutputChannelSize(2)}, opts...)...)
+	return &bs
+}
+
+func TestBackpressure_DropNewestByDefault(t *testing.T) {
+	bs := newBackpressureStreamer(t)
+	bs.PushInput(audioMessage(1))
+	bs.PushInput(audioMessage(2))
+	bs.PushInput(audioMessage(3))
+
+	assert.Equal(t, audioMessage(1), <-bs.InputCh)
+	assert.Equal(t, audioMessage(2), <-bs.InputCh)
+	assert.Equal(t, Drops{Input: 1}, bs.Dropped())
+}
+
+func TestBackpressure_DropOldest(t *testing.T) {
+	bs := newBackpressureStreamer(t, WithInputBackpressure(Backpressure{Policy: DropOldest}))
+	bs.PushInput(audioMessage(1))
+	bs.PushInput(audioMessage(2))
+	bs.PushInput(audioMessage(3))
+
+	assert.Equal(t, audioMessage(2), <-bs.InputCh)
+	assert.Equal(t, audioMessage(3), <-bs.InputCh)
+	assert.Equal(t, uint64(1), bs.Dropped().Input)
+}
+
+func TestBackpressure_DropOldestKeepsDisconnection(t *testing.T) {
+	bs := newBackpressureStreamer(t, WithInputBackpressure(Backpressure{Policy: DropOldest}), WithInputChannelSize(1))
+	bs.PushDisconnection(protos.ConversationDisconnection_DISCONNECTION_TYPE_USER)
+	bs.PushInput(audioMessage(1))
+
+	_, ok := (<-bs.InputCh).(*protos.ConversationDisconnection)
+	assert.True(t, ok, "the disconnection is never dropped to make room")
+	assert.Equal(t, uint64(1), bs.Dropped().Input)
+}
+
+func TestBackpressure_BlockWithTimeout(t *testing.T) {
+	bs := newBackpressureStreamer(t, WithOutputBackpressure(Backpressure{Policy: BlockWithTimeout, Timeout: time.Second}))
+	bs.PushOutput(audioMessage(1))
+	bs.PushOutput(audioMessage(2))
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		<-bs.OutputCh
+	}()
+	start := time.Now()
+	bs.PushOutput(audioMessage(3))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "the push waits for room")
+	assert.Equal(t, Drops{}, bs.Dropped())
+}
+
+func TestBackpressure_BlockWithTimeoutDropsAfterTimeout(t *testing.T) {
+	bs := newBackpressureStreamer(t, WithOutputBackpressure(Backpressure{Policy: BlockWithTimeout, Timeout: 10 * time.Millisecond}))
+	bs.PushOutput(audioMessage(1))
+	bs.PushOutput(audioMessage(2))
+	bs.PushOutput(audioMessage(3))
+
+	assert.Equal(t, uint64(1), bs.Dropped().Output)
+	require.Len(t, bs.OutputCh, 2)
+}
+
+type backpressureObserver struct {
+	countingObserver
+	policies []BackpressurePolicy
+	blocked  int
+}
+
+func (o *backpressureObserver) DroppedBy(direction Direction, policy BackpressurePolicy) {
+	o.policies = append(o.policies, policy)
+}
+
+func (o *backpressureObserver) Blocked(direction Direction, wait time.Duration) {
+	o.blocked++
+}
+
+func TestBackpressure_Observer(t *testing.T) {
+	o := &backpressureObserver{countingObserver: countingObserver{frames: map[Direction]int{}, dropped: map[Direction]int{}}}
+	SetObserver(o)
+	defer SetObserver(nil)
+
+	bs := newBackpressureStreamer(t, WithInputBackpressure(Backpressure{Policy: DropOldest}))
+	bs.PushInput(audioMessage(1))
+	bs.PushInput(audioMessage(2))
+	bs.PushInput(audioMessage(3))
+	bs.PushOutput(audioMessage(1))
+	bs.PushOutput(audioMessage(2))
+	bs.PushOutput(audioMessage(3))
+
+	assert.Equal(t, []BackpressurePolicy{DropOldest, DropNewest}, o.policies)
+	assert.Empty(t, o.dropped, "Dropped is not called for a BackpressureObserver")
+}
@@ -4,35 +4,75 @@
 // Licensed under GPL-2.0 with Rapida Additional Terms.
 // See LICENSE.md or contact sales@rapida.ai for commercial usage.
 
-// Package channel_base provides the foundational BaseStreamer that every
-// concrete streamer (WebRTC, telephony WebSocket, SIP, Asterisk, …) embeds.
+// Package streamers provides BaseStreamer, the buffering and channel
+// plumbing every channel of the assistant (WebRTC, telephony WebSocket, SIP,
+// Asterisk, …) embeds, and the Streamer interface the talk loop drives. It
+// lives outside the assistant so that channel plugins built elsewhere can
+// embed the same implementation instead of rewriting it.
+//
+// # Stability
+//
+// The exported API of this package follows semantic versioning: within a
+// major version no exported identifier is removed or renamed, no signature
+// changes, and the documented behaviour below (thresholds, frame sizes,
+// non-blocking pushes by default, disconnect semantics) is kept. New options, methods
+// and helpers may be added. Unexported fields and functions carry no such
+// promise, plugins must not depend on them through reflection or unsafe.
 //
 // # Core API
 //
 // BaseStreamer owns transport-agnostic channel and buffer management:
 //
 //   - InputCh / OutputCh — ordered, typed message channels (sized via options)
-//   - inputAudioBuffer / outputAudioBuffer — PCM accumulation with configurable thresholds
+//   - inputAudioBuffer / outputAudioBuffer — PCM accumulation in fixed-size rings with configurable thresholds
 //   - FlushAudioCh — interrupt signalling for the output writer
-//   - PushInput / PushOutput — non-blocking sends into InputCh / OutputCh
+//   - PushInput / PushOutput — sends into InputCh / OutputCh, non-blocking unless a Backpressure policy blocks
 //   - BufferAndSendInput — accumulate input PCM, flush at threshold into InputCh
+//   - SetInputFilter / FilterInput — an InputFilter (the assistant's audio filters) on input PCM
 //   - BufferAndSendOutput — accumulate output PCM, flush fixed-size 20 ms frames into OutputCh
 //   - ClearInputBuffer / ClearOutputBuffer — drain buffers and channels (interruption)
 //   - WithInputBuffer / WithOutputBuffer — synchronous buffer access under lock
 //   - ResetInputBuffer / ResetOutputBuffer — quick buffer reset under lock
 //   - PushDisconnection — idempotent disconnect signal
+//   - PushTransportMetadata — describe the connection for the conversation
+//   - SetObserver — count the frames and drops of all streamers
+//   - Dropped — the messages this streamer dropped per direction
 //   - Context / Recv — Streamer interface helpers consumed by the Talk loop
 //
+// Output frames come from a sync.Pool; a writer done with a frame's bytes
+// may hand them back with ReleaseFrame, frames it keeps are simply garbage
+// collected.
+//
+// The audio of BufferAndSendInput / BufferAndSendOutput accumulates in a
+// ring allocated once per streamer and sized from the audio config (twice
+// the input threshold, the output threshold plus one frame), writing into
+// it never allocates. WithInputBuffer / WithOutputBuffer still hand out a
+// bytes.Buffer, allocated on first use, holding the same bytes.
+//
+// # Disconnect semantics
+//
+// PushDisconnection queues a single ConversationDisconnection behind any
+// message already in InputCh and marks the streamer Closed; later calls are
+// no-ops. It waits up to DefaultBlockTimeout for room whatever the policy. The talk loop ends the conversation when it reads it. Recv returns
+// io.EOF once the streamer's context is cancelled.
+//
 // # Configuration
 //
 // Use functional options (Option) to override defaults:
 //
-//	bs := channel_base.NewBaseStreamer(logger,
-//	    channel_base.WithInputChannelSize(500),
-//	    channel_base.WithOutputChannelSize(1500),
-//	    channel_base.WithOutputAudioConfig(audioConfig48kHz),
+//	bs := streamers.NewBaseStreamer(logger,
+//	    streamers.WithInputChannelSize(500),
+//	    streamers.WithOutputChannelSize(1500),
+//	    streamers.WithOutputAudioConfig(audioConfig48kHz),
 //	)
 //
+// A full channel drops the message being pushed unless another Backpressure
+// policy is set per direction, see WithInputBackpressure and
+// WithOutputBackpressure:
+//
+//	streamers.WithInputBackpressure(streamers.Backpressure{Policy: streamers.DropOldest}),
+//	streamers.WithOutputBackpressure(streamers.Backpressure{Policy: streamers.BlockWithTimeout, Timeout: time.Second}),
+//
 // Default output frame duration is 20 ms. Frame size and buffer thresholds
 // are automatically derived from the audio config (bytes_per_ms × duration).
 // See individual With* functions for details.
@@ -48,17 +88,15 @@
 //   - Recv() reads from the WebSocket inline
 //   - Send() writes audio using WithOutputBuffer for direct buffer access
 //   - ClearOutputBuffer is used on interruption
-package channel_base
+package streamers
 
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 
-	internal_audio "github.com/rapidaai/api/assistant-api/internal/audio"
-	internal_type "github.com/rapidaai/api/assistant-api/internal/type"
 	"github.com/rapidaai/pkg/commons"
 	"github.com/rapidaai/protos"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -70,8 +108,8 @@ import (
 
 // framePool recycles fixed-size byte slices used by BufferAndSendOutput.
 // The pool is sized to the output frame size (typically 160–1920 bytes
-// depending on codec/sample-rate). Callers must return slices via putFrame
-// after the downstream consumer has finished with the data.
+// depending on codec/sample-rate). Output writers may return slices via
+// ReleaseFrame after the downstream consumer has finished with the data.
 //
 // sync.Pool is safe for concurrent use and its per-P caching avoids
 // cross-goroutine contention on the hot path.
@@ -92,8 +130,10 @@ func getFrame(n int) []byte {
 	return make([]byte, n)
 }
 
-// putFrame returns a frame slice to the pool for reuse.
-func putFrame(b []byte) {
+// ReleaseFrame returns an audio frame of OutputCh to the pool for reuse. The
+// caller must not touch the frame afterwards; frames never released are
+// garbage collected as usual.
+func ReleaseFrame(b []byte) {
 	framePool.Put(b) //nolint:staticcheck // slice is intentionally pooled
 }
 
@@ -149,6 +189,10 @@ type streamerConfig struct {
 	inputThresholdSet  bool
 	outputThresholdSet bool
 	outputFrameSet     bool
+
+	// What pushes do when InputCh / OutputCh are full, see Backpressure.
+	inputBackpressure  Backpressure
+	outputBackpressure Backpressure
 }
 
 // Option configures a BaseStreamer. Pass one or more options to NewBaseStreamer.
@@ -211,11 +255,19 @@ func WithOutputAudioConfig(cfg *protos.AudioConfig) Option {
 
 // BytesPerMs computes the byte rate per millisecond for the given audio config.
 // Formula: sampleRate × bytesPerSample × channels / 1000.
-// Returns 0 if cfg is nil.
-//
-// Delegates to internal_audio.BytesPerMs for the shared implementation.
+// Returns 0 if cfg is nil or the format is neither linear16 nor µ-law.
 func BytesPerMs(cfg *protos.AudioConfig) int {
-	return internal_audio.BytesPerMs(cfg)
+	if cfg == nil {
+		return 0
+	}
+	bytesPerSample := 0
+	switch cfg.GetAudioFormat() {
+	case protos.AudioConfig_LINEAR16:
+		bytesPerSample = 2
+	case protos.AudioConfig_MuLaw8:
+		bytesPerSample = 1
+	}
+	return int(cfg.GetSampleRate()) * bytesPerSample * int(cfg.GetChannels()) / 1000
 }
 
 // resolveConfig applies all options, then derives any unset thresholds from
@@ -228,6 +280,12 @@ func resolveConfig(opts []Option) streamerConfig {
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.inputBackpressure.Policy == "" {
+		cfg.inputBackpressure.Policy = DropNewest
+	}
+	if cfg.outputBackpressure.Policy == "" {
+		cfg.outputBackpressure.Policy = DropNewest
+	}
 
 	// Derive input threshold from audio config if not explicitly set.
 	if !cfg.inputThresholdSet && cfg.inputAudioConfig != nil {
@@ -262,9 +320,9 @@ func resolveConfig(opts []Option) streamerConfig {
 // concrete streamer (WebRTC, telephony, SIP, …) needs. It handles:
 //
 //   - InputCh / OutputCh: unified, ordered message channels
-//   - inputAudioBuffer / outputAudioBuffer: PCM accumulation with thresholds
+//   - inputAudioBuffer / outputAudioBuffer: PCM accumulation rings with thresholds
 //   - FlushAudioCh: interrupt signalling for the output writer
-//   - PushInput / PushOutput: non-blocking channel sends
+//   - PushInput / PushOutput: channel sends following the Backpressure policy
 //   - ClearInputBuffer / ClearOutputBuffer: buffer + channel draining
 //   - PushDisconnection: idempotent disconnect signalling
 //   - Recv / Context: Streamer interface helpers
@@ -289,19 +347,28 @@ type BaseStreamer struct {
 
 	// InputCh: all downstream-bound messages (gRPC + decoded audio) funnelled here.
 	// recv (non-blocking) -> InputCh -> loop (Recv) -> downstream service
-	InputCh              chan internal_type.Stream
-	inputAudioBuffer     *bytes.Buffer
+	InputCh              chan Stream
+	inputAudioBuffer     audioBuffer
 	inputAudioBufferLock sync.Mutex
 
 	// OutputCh: all upstream-bound messages funnelled here to preserve ordering.
 	// send (non-blocking) -> OutputCh -> loop (runOutputWriter) -> upstream service
-	OutputCh              chan internal_type.Stream
-	outputAudioBuffer     *bytes.Buffer
+	OutputCh              chan Stream
+	outputAudioBuffer     audioBuffer
 	outputAudioBufferLock sync.Mutex
 
 	// FlushAudioCh signals the output writer to discard its pending audio queue
 	// (used on interruption to silence stale frames immediately).
 	FlushAudioCh chan struct{}
+
+	// inputFilter filters the caller audio, nil unless one was set (see
+	// SetInputFilter).
+	inputFilter atomic.Pointer[inputFilter]
+
+	// inputDrops / outputDrops count the messages dropped per direction,
+	// see Dropped.
+	inputDrops  atomic.Uint64
+	outputDrops atomic.Uint64
 }
 
 // NewBaseStreamer initialises a BaseStreamer with channels and buffers sized
@@ -320,9 +387,9 @@ func NewBaseStreamer(logger commons.Logger, opts ...Option) BaseStreamer {
 	cfg := resolveConfig(opts)
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Pre-allocate buffer capacity to avoid internal grow() allocations
-	// during streaming. Input buffer holds up to 2× threshold before flush;
-	// output buffer holds up to threshold + one extra frame of incoming data.
+	// Size the rings so streaming never allocates. Input buffer holds up to
+	// 2× threshold before flush; output buffer holds up to threshold + one
+	// extra frame of incoming data. Larger writes go through in pieces.
 	inputBufCap := cfg.inputBufferThreshold * 2
 	if inputBufCap == 0 {
 		inputBufCap = 4096 // safe fallback
@@ -337,10 +404,10 @@ func NewBaseStreamer(logger commons.Logger, opts ...Option) BaseStreamer {
 		Ctx:               ctx,
 		Cancel:            cancel,
 		config:            cfg,
-		InputCh:           make(chan internal_type.Stream, cfg.inputChannelSize),
-		OutputCh:          make(chan internal_type.Stream, cfg.outputChannelSize),
-		inputAudioBuffer:  bytes.NewBuffer(make([]byte, 0, inputBufCap)),
-		outputAudioBuffer: bytes.NewBuffer(make([]byte, 0, outputBufCap)),
+		InputCh:           make(chan Stream, cfg.inputChannelSize),
+		OutputCh:          make(chan Stream, cfg.outputChannelSize),
+		inputAudioBuffer:  newAudioBuffer(inputBufCap),
+		outputAudioBuffer: newAudioBuffer(outputBufCap),
 		FlushAudioCh:      make(chan struct{}, 1),
 	}
 }
@@ -352,30 +419,41 @@ func NewBaseStreamer(logger commons.Logger, opts ...Option) BaseStreamer {
 // BufferAndSendInput accumulates resampled audio and sends it to InputCh
 // when the buffer reaches the configured input threshold.
 //
-// Hot-path optimisation: instead of make([]byte)+copy on every flush, we
-// swap the filled buffer with a pre-allocated empty one. The old buffer's
-// backing array is consumed by the channel reader and eventually GC'd —
-// but the swap avoids an explicit copy (the buffer already owns the data).
+// Hot-path optimisation: the audio is copied into the streamer's ring, a
+// flush copies the waiting bytes out into one slice of exactly their length
+// that the channel reader owns. Audio larger than the ring free space is
+// flushed in pieces of at most the ring size.
 func (s *BaseStreamer) BufferAndSendInput(audio []byte) {
-	s.inputAudioBufferLock.Lock()
-	s.inputAudioBuffer.Write(audio)
+	audio = s.FilterInput(audio)
+	var batch [2][]byte
+	chunks := batch[:0]
 
-	if s.inputAudioBuffer.Len() < s.config.inputBufferThreshold {
-		s.inputAudioBufferLock.Unlock()
-		return
+	s.inputAudioBufferLock.Lock()
+	buf := &s.inputAudioBuffer
+	for {
+		audio = buf.fill(audio)
+		if n := buf.ring.Len(); n > 0 && n >= s.config.inputBufferThreshold {
+			chunk := make([]byte, n)
+			buf.ring.Read(chunk)
+			chunks = append(chunks, chunk)
+		}
+		if !buf.pending(audio) {
+			break
+		}
 	}
-
-	// Snapshot the accumulated bytes without an extra copy — Bytes() returns
-	// a slice backed by the buffer's internal array. We then swap in a fresh
-	// buffer so the old backing array is exclusively owned by audioData.
-	audioData := s.inputAudioBuffer.Bytes()
-	s.inputAudioBuffer = bytes.NewBuffer(make([]byte, 0, s.config.inputBufferThreshold*2))
 	s.inputAudioBufferLock.Unlock()
 
-	s.PushInput(&protos.ConversationUserMessage{
-		Message: &protos.ConversationUserMessage_Audio{Audio: audioData},
-		Time:    timestamppb.Now(),
-	})
+	now := timestamppb.Now()
+	sent := 0
+	for _, chunk := range chunks {
+		if s.pushInput(&protos.ConversationUserMessage{
+			Message: &protos.ConversationUserMessage_Audio{Audio: chunk},
+			Time:    now,
+		}) {
+			sent++
+		}
+	}
+	observeFrames(Input, sent)
 }
 
 // ClearInputBuffer resets the input PCM buffer and drains the input channel.
@@ -405,39 +483,52 @@ func (s *BaseStreamer) ClearInputBuffer() {
 //   - Single lock acquisition: all frames are extracted under one lock, then
 //     pushed to the channel outside the lock. This reduces lock contention
 //     from N acquires to 1 per call.
+//   - Fixed-size ring: the audio is written into the streamer's ring, which
+//     never grows; audio larger than its free space is framed in pieces.
 //   - sync.Pool frames: frame slices come from a pool and are recycled after
-//     the downstream consumer is done (see FrameRelease).
-//   - No intermediate copy: bytes.Buffer.Read fills the pooled slice directly.
+//     the downstream consumer is done (see ReleaseFrame).
+//   - No intermediate copy: the ring reads straight into the pooled slice.
 //
 // audio received -> outputAudioBuffer -> check threshold -> flush frames -> OutputCh
 func (s *BaseStreamer) BufferAndSendOutput(audio []byte) {
-	s.outputAudioBufferLock.Lock()
-	s.outputAudioBuffer.Write(audio)
-
-	if s.outputAudioBuffer.Len() < s.config.outputBufferThreshold {
-		s.outputAudioBufferLock.Unlock()
-		return
-	}
-
 	frameSize := s.config.outputFrameSize
+	var batch [8][]byte
+	frames := batch[:0]
 
-	// Collect all complete frames under a single lock acquisition.
-	var frames [][]byte
-	for s.outputAudioBuffer.Len() >= frameSize {
-		frame := getFrame(frameSize)
-		s.outputAudioBuffer.Read(frame)
-		frames = append(frames, frame)
+	s.outputAudioBufferLock.Lock()
+	buf := &s.outputAudioBuffer
+	for {
+		audio = buf.fill(audio)
+		if n := buf.ring.Len(); n > 0 && n >= s.config.outputBufferThreshold {
+			size := frameSize
+			if size <= 0 {
+				size = n
+			}
+			// Collect all complete frames under a single lock acquisition.
+			for buf.ring.Len() >= size {
+				frame := getFrame(size)
+				buf.ring.Read(frame)
+				frames = append(frames, frame)
+			}
+		}
+		if !buf.pending(audio) {
+			break
+		}
 	}
 	s.outputAudioBufferLock.Unlock()
 
 	// Push frames outside the lock — no contention with concurrent writers.
 	now := timestamppb.Now()
+	sent := 0
 	for _, frame := range frames {
-		s.PushOutput(&protos.ConversationAssistantMessage{
+		if s.pushOutput(&protos.ConversationAssistantMessage{
 			Message: &protos.ConversationAssistantMessage_Audio{Audio: frame},
 			Time:    now,
-		})
+		}) {
+			sent++
+		}
 	}
+	observeFrames(Output, sent)
 }
 
 // ClearOutputBuffer resets the output audio buffer, signals the output writer
@@ -478,7 +569,7 @@ func (s *BaseStreamer) ClearOutputBuffer() {
 func (s *BaseStreamer) WithInputBuffer(fn func(buf *bytes.Buffer)) {
 	s.inputAudioBufferLock.Lock()
 	defer s.inputAudioBufferLock.Unlock()
-	fn(s.inputAudioBuffer)
+	fn(s.inputAudioBuffer.Buffer())
 }
 
 // WithOutputBuffer executes fn while holding the output buffer lock.
@@ -488,7 +579,7 @@ func (s *BaseStreamer) WithInputBuffer(fn func(buf *bytes.Buffer)) {
 func (s *BaseStreamer) WithOutputBuffer(fn func(buf *bytes.Buffer)) {
 	s.outputAudioBufferLock.Lock()
 	defer s.outputAudioBufferLock.Unlock()
-	fn(s.outputAudioBuffer)
+	fn(s.outputAudioBuffer.Buffer())
 }
 
 // ResetOutputBuffer resets the output audio buffer under lock.
@@ -510,23 +601,27 @@ func (s *BaseStreamer) ResetInputBuffer() {
 // Channel push helpers
 // ============================================================================
 
-// PushInput sends a message to the unified input channel (non-blocking).
+// PushInput sends a message to the unified input channel, following the
+// input Backpressure policy when it is full (non-blocking by default).
 // Safe to call after Close — the send is guarded by the Closed flag.
-func (s *BaseStreamer) PushInput(msg internal_type.Stream) {
-	select {
-	case s.InputCh <- msg:
-	default:
-		s.Logger.Warnw("Input channel full, dropping message", "type", fmt.Sprintf("%T", msg))
-	}
+func (s *BaseStreamer) PushInput(msg Stream) {
+	s.pushInput(msg)
 }
 
-// PushOutput sends a message to the unified output channel (non-blocking).
-func (s *BaseStreamer) PushOutput(msg internal_type.Stream) {
-	select {
-	case s.OutputCh <- msg:
-	default:
-		s.Logger.Warnw("Output channel full, dropping message", "type", fmt.Sprintf("%T", msg))
-	}
+// PushOutput sends a message to the unified output channel, following the
+// output Backpressure policy when it is full (non-blocking by default).
+func (s *BaseStreamer) PushOutput(msg Stream) {
+	s.pushOutput(msg)
+}
+
+// pushInput is PushInput reporting whether msg was enqueued.
+func (s *BaseStreamer) pushInput(msg Stream) bool {
+	return s.push(s.InputCh, Input, s.config.inputBackpressure, msg)
+}
+
+// pushOutput is PushOutput reporting whether msg was enqueued.
+func (s *BaseStreamer) pushOutput(msg Stream) bool {
+	return s.push(s.OutputCh, Output, s.config.outputBackpressure, msg)
 }
 
 // ============================================================================
@@ -546,7 +641,9 @@ func (s *BaseStreamer) PushDisconnection(reason protos.ConversationDisconnection
 		return
 	}
 
-	s.PushInput(&protos.ConversationDisconnection{
+	// the talk loop only ends the conversation once it reads this, it is
+	// worth waiting for room
+	s.push(s.InputCh, Input, Backpressure{Policy: BlockWithTimeout}, &protos.ConversationDisconnection{
 		Type: reason,
 		Time: timestamppb.Now(),
 	})
@@ -584,7 +681,7 @@ func (s *BaseStreamer) Context() context.Context {
 // Both transport messages and decoded audio are fed into the same channel by
 // background goroutines. Shutdown is signalled by a ConversationDisconnection
 // message through InputCh, which the Talk loop handles to trigger Disconnect().
-func (s *BaseStreamer) Recv() (internal_type.Stream, error) {
+func (s *BaseStreamer) Recv() (Stream, error) {
 	select {
 	case msg, ok := <-s.InputCh:
 		if !ok {
@@ -4,7 +4,7 @@
 // Licensed under GPL-2.0 with Rapida Additional Terms.
 // See LICENSE.md or contact sales@rapida.ai for commercial usage.
 
-package channel_base
+package streamers
 
 import (
 	"bytes"
@@ -1070,7 +1070,7 @@ func TestGetFrame_ReturnsCorrectSize(t *testing.T) {
 	f := getFrame(160)
 	assert.Equal(t, 160, len(f))
 	assert.GreaterOrEqual(t, cap(f), 160)
-	putFrame(f)
+	ReleaseFrame(f)
 }
 
 func TestGetFrame_PoolReuse(t *testing.T) {
@@ -1078,25 +1078,25 @@ func TestGetFrame_PoolReuse(t *testing.T) {
 	f1 := getFrame(160)
 	f1[0] = 0xAA // mark it
 	ptr1 := &f1[0]
-	putFrame(f1)
+	ReleaseFrame(f1)
 
 	f2 := getFrame(160)
 	// Pool reuse is best-effort; we can't guarantee same pointer,
 	// but the slice should be correctly sized.
 	assert.Equal(t, 160, len(f2))
 	_ = ptr1 // used for debugging; pool reuse is non-deterministic
-	putFrame(f2)
+	ReleaseFrame(f2)
 }
 
 func TestGetFrame_UndersizedPooledSlice(t *testing.T) {
 	// Put a small slice, then request a larger one.
 	small := make([]byte, 10)
-	putFrame(small)
+	ReleaseFrame(small)
 
 	big := getFrame(160)
 	assert.Equal(t, 160, len(big))
 	assert.GreaterOrEqual(t, cap(big), 160)
-	putFrame(big)
+	ReleaseFrame(big)
 }
 
 // ============================================================================
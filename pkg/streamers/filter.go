@@ -0,0 +1,45 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+// InputFilter processes caller audio before it is buffered for the talk
+// loop, e.g. the assistant's audio pipeline. Frames come in order but not in
+// any fixed size.
+type InputFilter interface {
+	Process(pcm []byte) ([]byte, error)
+}
+
+// inputFilter boxes an InputFilter for the atomic pointer of BaseStreamer.
+type inputFilter struct {
+	InputFilter
+}
+
+// SetInputFilter runs f on the caller audio from then on, see FilterInput.
+// nil stops filtering.
+func (s *BaseStreamer) SetInputFilter(f InputFilter) {
+	if f == nil {
+		s.inputFilter.Store(nil)
+		return
+	}
+	s.inputFilter.Store(&inputFilter{f})
+}
+
+// FilterInput runs caller audio, already resampled to linear16 16kHz, through
+// the input filter. Audio passes unchanged without a filter, and when the
+// filter fails on it rather than being lost.
+func (s *BaseStreamer) FilterInput(audio []byte) []byte {
+	f := s.inputFilter.Load()
+	if f == nil {
+		return audio
+	}
+	filtered, err := f.Process(audio)
+	if err != nil {
+		s.Logger.Warnw("Input audio filter failed, forwarding unfiltered audio", "error", err.Error())
+		return audio
+	}
+	return filtered
+}
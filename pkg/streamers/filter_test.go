@@ -0,0 +1,55 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invert flips every byte, or fails when err is set.
+type invert struct{ err error }
+
+func (f invert) Process(pcm []byte) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]byte, len(pcm))
+	for i, b := range pcm {
+		out[i] = ^b
+	}
+	return out, nil
+}
+
+func TestFilterInput(t *testing.T) {
+	bs, _ := newTestStreamer()
+	assert.Equal(t, []byte{1, 2}, bs.FilterInput([]byte{1, 2}), "no filter")
+
+	bs.SetInputFilter(invert{})
+	assert.Equal(t, []byte{0xfe, 0xfd}, bs.FilterInput([]byte{1, 2}))
+
+	bs.SetInputFilter(invert{err: errors.New("boom")})
+	assert.Equal(t, []byte{1, 2}, bs.FilterInput([]byte{1, 2}), "failed audio passes unfiltered")
+
+	bs.SetInputFilter(nil)
+	assert.Equal(t, []byte{1, 2}, bs.FilterInput([]byte{1, 2}))
+}
+
+func TestBufferAndSendInput_Filtered(t *testing.T) {
+	bs, _ := newTestStreamer()
+	bs.SetInputFilter(invert{})
+
+	bs.BufferAndSendInput(make([]byte, 480))
+	msg := <-bs.InputCh
+	audio := msg.(*protos.ConversationUserMessage).GetAudio()
+	require.Len(t, audio, 480)
+	assert.Equal(t, byte(0xff), audio[0])
+}
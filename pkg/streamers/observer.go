@@ -0,0 +1,83 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Direction is the way a message goes through a streamer.
+type Direction string
+
+const (
+	// Input is caller audio and events on their way to the talk loop.
+	Input Direction = "input"
+	// Output is what the talk loop sends to the caller.
+	Output Direction = "output"
+)
+
+// Observer is told what every BaseStreamer of the process moves, e.g. to
+// export it as metrics. It is called on the audio path and must not block.
+type Observer interface {
+	// AudioFrames counts the audio frames buffered for a direction.
+	AudioFrames(direction Direction, n int)
+	// Dropped counts a message dropped because the channel was full.
+	Dropped(direction Direction)
+}
+
+// BackpressureObserver is an Observer also told the policy a message was
+// dropped under and how long BlockWithTimeout pushes waited for room. It is
+// called instead of Dropped when the observer implements it.
+type BackpressureObserver interface {
+	Observer
+	// DroppedBy counts a message dropped under policy.
+	DroppedBy(direction Direction, policy BackpressurePolicy)
+	// Blocked records how long a push waited before it was enqueued.
+	Blocked(direction Direction, wait time.Duration)
+}
+
+type observerBox struct {
+	Observer
+}
+
+var observer atomic.Pointer[observerBox]
+
+// SetObserver makes o the observer of all streamers, nil removes it.
+func SetObserver(o Observer) {
+	if o == nil {
+		observer.Store(nil)
+		return
+	}
+	observer.Store(&observerBox{o})
+}
+
+func observeFrames(direction Direction, n int) {
+	if o := observer.Load(); o != nil {
+		o.AudioFrames(direction, n)
+	}
+}
+
+func observeDrop(direction Direction, policy BackpressurePolicy) {
+	o := observer.Load()
+	if o == nil {
+		return
+	}
+	if bo, ok := o.Observer.(BackpressureObserver); ok {
+		bo.DroppedBy(direction, policy)
+		return
+	}
+	o.Dropped(direction)
+}
+
+func observeBlocked(direction Direction, wait time.Duration) {
+	if o := observer.Load(); o != nil {
+		if bo, ok := o.Observer.(BackpressureObserver); ok {
+			bo.Blocked(direction, wait)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingObserver struct {
+	mu      sync.Mutex
+	frames  map[Direction]int
+	dropped map[Direction]int
+}
+
+func (o *countingObserver) AudioFrames(direction Direction, n int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.frames[direction] += n
+}
+
+func (o *countingObserver) Dropped(direction Direction) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.dropped[direction]++
+}
+
+func TestSetObserver(t *testing.T) {
+	o := &countingObserver{frames: map[Direction]int{}, dropped: map[Direction]int{}}
+	SetObserver(o)
+	defer SetObserver(nil)
+
+	logger, _ := commons.NewApplicationLogger()
+	bs := NewBaseStreamer(logger, append(defaultTestOpts(), WithInputChannelSize(1), WithOutputChannelSize(2))...)
+
+	bs.BufferAndSendInput(make([]byte, 480))
+	bs.BufferAndSendInput(make([]byte, 480)) // channel full
+	bs.BufferAndSendOutput(make([]byte, 480))
+
+	assert.Equal(t, map[Direction]int{Input: 1, Output: 2}, o.frames, "dropped frames are not counted")
+	assert.Equal(t, map[Direction]int{Input: 1, Output: 1}, o.dropped)
+
+	SetObserver(nil)
+	bs.PushOutput(&protos.ConversationAssistantMessage{})
+	assert.Equal(t, 1, o.dropped[Output], "no observer once removed")
+}
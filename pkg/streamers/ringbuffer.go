@@ -0,0 +1,149 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"bytes"
+	"math/bits"
+	"sync/atomic"
+)
+
+// ringBuffer is a fixed-size single-producer single-consumer byte ring. The
+// read and write cursors are atomics that only ever grow, the writer owns
+// tail and the reader owns head, so Len can be read from any goroutine
+// without a lock. The capacity is a power of two, a cursor maps to its
+// slot with a mask.
+//
+// BaseStreamer still serialises writers with its buffer lock, several
+// goroutines may call BufferAndSendInput / BufferAndSendOutput.
+type ringBuffer struct {
+	buf  []byte
+	mask uint64
+	head atomic.Uint64
+	tail atomic.Uint64
+}
+
+// newRingBuffer returns a ring holding at least n bytes.
+func newRingBuffer(n int) *ringBuffer {
+	if n < 1 {
+		n = 1
+	}
+	size := 1 << bits.Len(uint(n-1))
+	return &ringBuffer{buf: make([]byte, size), mask: uint64(size - 1)}
+}
+
+// Cap returns how many bytes the ring holds.
+func (r *ringBuffer) Cap() int {
+	return len(r.buf)
+}
+
+// Len returns how many bytes are waiting to be read.
+func (r *ringBuffer) Len() int {
+	return int(r.tail.Load() - r.head.Load())
+}
+
+// Write copies as much of p as there is room for and returns how many bytes
+// it copied, it never grows the ring.
+func (r *ringBuffer) Write(p []byte) int {
+	tail := r.tail.Load()
+	n := min(len(p), len(r.buf)-int(tail-r.head.Load()))
+	if n == 0 {
+		return 0
+	}
+	at := int(tail & r.mask)
+	copied := copy(r.buf[at:], p[:n])
+	copy(r.buf, p[copied:n])
+	r.tail.Store(tail + uint64(n))
+	return n
+}
+
+// Read copies up to len(p) waiting bytes into p and returns how many it
+// copied.
+func (r *ringBuffer) Read(p []byte) int {
+	head := r.head.Load()
+	n := min(len(p), int(r.tail.Load()-head))
+	if n == 0 {
+		return 0
+	}
+	at := int(head & r.mask)
+	copied := copy(p[:n], r.buf[at:])
+	copy(p[copied:n], r.buf)
+	r.head.Store(head + uint64(n))
+	return n
+}
+
+// Reset discards the waiting bytes, it is a read of everything.
+func (r *ringBuffer) Reset() {
+	r.head.Store(r.tail.Load())
+}
+
+// audioBuffer is the PCM accumulation of one direction: the ring the
+// BufferAndSend hot path writes into, and a bytes.Buffer only allocated once
+// a synchronous transport asks for one through WithInputBuffer /
+// WithOutputBuffer. Callers hold the streamer's buffer lock.
+type audioBuffer struct {
+	ring *ringBuffer
+
+	// spill holds what the synchronous helpers see, the waiting bytes of
+	// the ring are moved into it first so both paths share one stream.
+	spill    *bytes.Buffer
+	spillCap int
+}
+
+func newAudioBuffer(size int) audioBuffer {
+	return audioBuffer{ring: newRingBuffer(size), spillCap: size}
+}
+
+// Buffer moves the waiting bytes of the ring into the bytes.Buffer of the
+// synchronous helpers and returns it.
+func (b *audioBuffer) Buffer() *bytes.Buffer {
+	if b.spill == nil {
+		b.spill = bytes.NewBuffer(make([]byte, 0, b.spillCap))
+	}
+	for b.ring.Len() > 0 {
+		n := b.ring.Len()
+		b.spill.Grow(n)
+		tail := b.spill.AvailableBuffer()[:n]
+		b.ring.Read(tail)
+		b.spill.Write(tail)
+	}
+	return b.spill
+}
+
+// Len returns how many bytes are buffered in total.
+func (b *audioBuffer) Len() int {
+	n := b.ring.Len()
+	if b.spill != nil {
+		n += b.spill.Len()
+	}
+	return n
+}
+
+// fill moves bytes into the ring, first what the synchronous helpers left
+// behind then from audio, and returns the part of audio that did not fit.
+func (b *audioBuffer) fill(audio []byte) []byte {
+	if b.spill != nil && b.spill.Len() > 0 {
+		b.spill.Next(b.ring.Write(b.spill.Bytes()))
+		if b.spill.Len() > 0 {
+			return audio
+		}
+	}
+	return audio[b.ring.Write(audio):]
+}
+
+// pending reports whether fill has bytes left to move.
+func (b *audioBuffer) pending(audio []byte) bool {
+	return len(audio) > 0 || (b.spill != nil && b.spill.Len() > 0)
+}
+
+// Reset discards everything buffered.
+func (b *audioBuffer) Reset() {
+	b.ring.Reset()
+	if b.spill != nil {
+		b.spill.Reset()
+	}
+}
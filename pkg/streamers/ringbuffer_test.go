@@ -0,0 +1,256 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBuffer_CapacityIsPowerOfTwo(t *testing.T) {
+	assert.Equal(t, 1024, newRingBuffer(960).Cap())
+	assert.Equal(t, 4096, newRingBuffer(4096).Cap())
+	assert.Equal(t, 1, newRingBuffer(0).Cap())
+}
+
+func TestRingBuffer_WrapsAround(t *testing.T) {
+	r := newRingBuffer(8)
+	require.Equal(t, 6, r.Write([]byte("abcdef")))
+	out := make([]byte, 4)
+	require.Equal(t, 4, r.Read(out))
+	assert.Equal(t, "abcd", string(out))
+
+	// "ef" sits at the end, "ghijkl" wraps to the start
+	require.Equal(t, 6, r.Write([]byte("ghijkl")))
+	out = make([]byte, 8)
+	require.Equal(t, 8, r.Read(out))
+	assert.Equal(t, "efghijkl", string(out))
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestRingBuffer_WriteStopsWhenFull(t *testing.T) {
+	r := newRingBuffer(4)
+	assert.Equal(t, 4, r.Write([]byte("abcdef")))
+	assert.Equal(t, 0, r.Write([]byte("g")))
+	assert.Equal(t, 4, r.Len())
+
+	r.Reset()
+	assert.Equal(t, 0, r.Len())
+	assert.Equal(t, 0, r.Read(make([]byte, 4)))
+}
+
+func TestRingBuffer_SingleProducerSingleConsumer(t *testing.T) {
+	r := newRingBuffer(64)
+	const total = 10000
+
+	go func() {
+		chunk := make([]byte, 7)
+		for written := 0; written < total; {
+			n := min(len(chunk), total-written)
+			for i := range n {
+				chunk[i] = byte(written + i)
+			}
+			for sent := 0; sent < n; {
+				if w := r.Write(chunk[sent:n]); w > 0 {
+					sent += w
+				} else {
+					runtime.Gosched()
+				}
+			}
+			written += n
+		}
+	}()
+
+	out := make([]byte, 13)
+	for read := 0; read < total; {
+		n := r.Read(out)
+		if n == 0 {
+			runtime.Gosched()
+			continue
+		}
+		for i := range n {
+			if out[i] != byte(read+i) {
+				t.Fatalf("byte %d out of order", read+i)
+			}
+		}
+		read += n
+	}
+}
+
+func TestBufferAndSendOutput_LargerThanRing(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	bs := NewBaseStreamer(logger,
+		WithOutputChannelSize(1000),
+		WithOutputBufferThreshold(160),
+		WithOutputFrameSize(160),
+	)
+	require.Equal(t, 512, bs.outputAudioBuffer.ring.Cap())
+
+	bs.BufferAndSendOutput(make([]byte, 4800+100))
+
+	require.Len(t, bs.OutputCh, 30)
+	for range 30 {
+		msg := (<-bs.OutputCh).(*protos.ConversationAssistantMessage)
+		assert.Len(t, msg.GetAudio(), 160)
+	}
+	assert.Equal(t, 100, bs.outputAudioBuffer.Len())
+}
+
+func TestBufferAndSendInput_LargerThanRing(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	bs := NewBaseStreamer(logger,
+		WithInputChannelSize(100),
+		WithInputBufferThreshold(100),
+	)
+	audio := make([]byte, 1000)
+	for i := range audio {
+		audio[i] = byte(i)
+	}
+
+	bs.BufferAndSendInput(audio)
+
+	var got []byte
+	for len(bs.InputCh) > 0 {
+		chunk := (<-bs.InputCh).(*protos.ConversationUserMessage).GetAudio()
+		assert.LessOrEqual(t, len(chunk), bs.inputAudioBuffer.ring.Cap())
+		got = append(got, chunk...)
+	}
+	bs.WithInputBuffer(func(buf *bytes.Buffer) { got = append(got, buf.Bytes()...) })
+	assert.Equal(t, audio, got)
+}
+
+func TestBufferAndSendOutput_KeepsSynchronousBytes(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	bs := NewBaseStreamer(logger,
+		WithOutputChannelSize(10),
+		WithOutputBufferThreshold(4),
+		WithOutputFrameSize(4),
+	)
+
+	bs.BufferAndSendOutput([]byte{1, 2})
+	bs.WithOutputBuffer(func(buf *bytes.Buffer) {
+		assert.Equal(t, []byte{1, 2}, buf.Bytes(), "the helpers see the ring bytes")
+		buf.WriteByte(3)
+	})
+	bs.BufferAndSendOutput([]byte{4, 5})
+
+	require.Len(t, bs.OutputCh, 1)
+	assert.Equal(t, []byte{1, 2, 3, 4}, (<-bs.OutputCh).(*protos.ConversationAssistantMessage).GetAudio())
+	assert.Equal(t, 1, bs.outputAudioBuffer.Len())
+}
+
+// ============================================================================
+// Benchmarks — 500 concurrent calls
+// ============================================================================
+
+const benchmarkCalls = 500
+
+// reportGC reports the garbage collections per op since before.
+func reportGC(b *testing.B, before *runtime.MemStats) {
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.NumGC-before.NumGC)/float64(b.N), "gc/op")
+}
+
+// BenchmarkBaseStreamer_500Calls moves 20 ms of caller audio (µ-law 8kHz)
+// and 20 ms of assistant audio (linear16 48kHz) through each of 500
+// streamers per op, the output writer releasing its frames.
+func BenchmarkBaseStreamer_500Calls(b *testing.B) {
+	logger, _ := commons.NewApplicationLogger()
+	calls := make([]BaseStreamer, benchmarkCalls)
+	for i := range calls {
+		calls[i] = NewBaseStreamer(logger,
+			WithInputBufferThreshold(480),
+			WithOutputFrameSize(1920),
+			WithOutputBufferThreshold(1920),
+		)
+	}
+	input, output := make([]byte, 160), make([]byte, 1920)
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for c := range calls {
+			bs := &calls[c]
+			bs.BufferAndSendInput(input)
+			bs.BufferAndSendOutput(output)
+			for len(bs.InputCh) > 0 {
+				<-bs.InputCh
+			}
+			for len(bs.OutputCh) > 0 {
+				ReleaseFrame((<-bs.OutputCh).(*protos.ConversationAssistantMessage).GetAudio())
+			}
+		}
+	}
+	b.StopTimer()
+	reportGC(b, &before)
+}
+
+// BenchmarkInputAccumulation_500Calls compares the ring against the
+// bytes.Buffer it replaced, which swapped in a fresh 2× threshold buffer
+// on every flush.
+func BenchmarkInputAccumulation_500Calls(b *testing.B) {
+	const threshold = 480
+	chunk := make([]byte, 160)
+	var sink []byte
+
+	b.Run("ring", func(b *testing.B) {
+		rings := make([]*ringBuffer, benchmarkCalls)
+		for i := range rings {
+			rings[i] = newRingBuffer(threshold * 2)
+		}
+		var before runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, r := range rings {
+				r.Write(chunk)
+				if r.Len() >= threshold {
+					sink = make([]byte, r.Len())
+					r.Read(sink)
+				}
+			}
+		}
+		b.StopTimer()
+		reportGC(b, &before)
+	})
+
+	b.Run("bytes.Buffer", func(b *testing.B) {
+		buffers := make([]*bytes.Buffer, benchmarkCalls)
+		for i := range buffers {
+			buffers[i] = bytes.NewBuffer(make([]byte, 0, threshold*2))
+		}
+		var before runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for c, buf := range buffers {
+				buf.Write(chunk)
+				if buf.Len() >= threshold {
+					sink = buf.Bytes()
+					buffers[c] = bytes.NewBuffer(make([]byte, 0, threshold*2))
+				}
+			}
+		}
+		b.StopTimer()
+		reportGC(b, &before)
+	})
+	_ = sink
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"context"
+)
+
+// Stream is a message exchanged with the talk loop, one of the talk-api
+// protos (ConversationInitialization, ConversationUserMessage,
+// ConversationMetadata, ConversationAssistantMessage, …).
+type Stream interface {
+	ProtoMessage()
+}
+
+// Streamer is a channel as the talk loop sees it: a bidirectional stream of
+// conversation messages that persists until closed or an error occurs.
+// Channel plugins implement it, usually by embedding BaseStreamer for
+// Context and Recv and writing Send themselves.
+type Streamer interface {
+	// Context returns the context associated with this stream.
+	// The context can be used to manage cancellation, timeouts, and deadlines.
+	Context() context.Context
+
+	// Recv receives the next message for the talk loop.
+	// It blocks until a message is available, the stream is closed, or an error occurs.
+	// If the stream is closed, it returns (nil, io.EOF).
+	Recv() (Stream, error)
+
+	// Send delivers a message of the talk loop to the channel.
+	// It returns an error if the send operation fails (e.g., stream closed, network error).
+	Send(Stream) error
+}
+
+// BaseStreamer provides Context and Recv, embedding it and adding Send makes
+// a Streamer.
+var _ interface {
+	Context() context.Context
+	Recv() (Stream, error)
+} = (*BaseStreamer)(nil)
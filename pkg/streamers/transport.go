@@ -0,0 +1,61 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rapidaai/protos"
+)
+
+// TransportMetadataPrefix namespaces the conversation metadata that
+// describes the connection a session runs over, e.g.
+// transport.sip.user_agent. It is kept to debug audio issues of particular
+// devices and carriers.
+const TransportMetadataPrefix = "transport."
+
+// maxTransportValue caps values that come from the remote side, user agents
+// and headers are not trusted to be short.
+const maxTransportValue = 256
+
+// TransportMetadata returns the metadata describing a session's connection
+// over channel: transport.channel, and every non-empty field as
+// transport.<channel>.<field>. It returns nil when no field is known.
+func TransportMetadata(channel string, fields map[string]string) *protos.ConversationMetadata {
+	keys := make([]string, 0, len(fields))
+	for key, value := range fields {
+		if strings.TrimSpace(value) != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+
+	metadata := []*protos.Metadata{{Key: TransportMetadataPrefix + "channel", Value: channel}}
+	for _, key := range keys {
+		value := strings.TrimSpace(fields[key])
+		if len(value) > maxTransportValue {
+			value = strings.ToValidUTF8(value[:maxTransportValue], "")
+		}
+		metadata = append(metadata, &protos.Metadata{
+			Key:   TransportMetadataPrefix + channel + "." + key,
+			Value: value,
+		})
+	}
+	return &protos.ConversationMetadata{Metadata: metadata}
+}
+
+// PushTransportMetadata queues the connection metadata of the session for
+// Recv. Nothing is queued when no field is known.
+func (s *BaseStreamer) PushTransportMetadata(channel string, fields map[string]string) {
+	if metadata := TransportMetadata(channel, fields); metadata != nil {
+		s.PushInput(metadata)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+
+package streamers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rapidaai/pkg/commons"
+	"github.com/rapidaai/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportMetadata(t *testing.T) {
+	metadata := TransportMetadata("sip", map[string]string{
+		"user_agent": " Zoiper rv2.10 ",
+		"transport":  "udp",
+		"codec":      "",
+	})
+	require.NotNil(t, metadata)
+	assert.Equal(t, []*protos.Metadata{
+		{Key: "transport.channel", Value: "sip"},
+		{Key: "transport.sip.transport", Value: "udp"},
+		{Key: "transport.sip.user_agent", Value: "Zoiper rv2.10"},
+	}, metadata.GetMetadata())
+
+	assert.Nil(t, TransportMetadata("sip", map[string]string{"codec": " "}))
+	assert.Nil(t, TransportMetadata("sip", nil))
+}
+
+func TestTransportMetadata_TruncatesLongValues(t *testing.T) {
+	// the cut lands inside the two byte é and must not leave half of it
+	long := strings.Repeat("a", maxTransportValue-1) + "é"
+	metadata := TransportMetadata("webrtc", map[string]string{"user_agent": long})
+	require.NotNil(t, metadata)
+	value := metadata.GetMetadata()[1].GetValue()
+	assert.Equal(t, strings.Repeat("a", maxTransportValue-1), value)
+}
+
+func TestPushTransportMetadata(t *testing.T) {
+	logger, _ := commons.NewApplicationLogger()
+	s := NewBaseStreamer(logger, defaultTestOpts()...)
+
+	s.PushTransportMetadata("twilio", map[string]string{})
+	s.PushTransportMetadata("twilio", map[string]string{"call_sid": "CA123"})
+
+	msg, err := s.Recv()
+	require.NoError(t, err)
+	metadata, ok := msg.(*protos.ConversationMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "transport.twilio.call_sid", metadata.GetMetadata()[1].GetKey())
+	assert.Empty(t, s.InputCh)
+}
@@ -23,6 +23,13 @@ var (
 	TIME_TO_FIRST_TOKEN    MetricName = "TIME_TO_FIRST_TOKEN"
 	PROVIDER_TOTAL_TIME    MetricName = "PROVIDER_TOTAL_TIME"
 	PROVIDER_GENERATE_TIME MetricName = "PROVIDER_GENERATE_TIME"
+	//
+	STT_CONFIDENCE      MetricName = "STT_CONFIDENCE"
+	AUDIO_SNAPSHOT_FROM MetricName = "AUDIO_SNAPSHOT_FROM"
+	AUDIO_SNAPSHOT_TO   MetricName = "AUDIO_SNAPSHOT_TO"
+	STT_LANGUAGE        MetricName = "STT_LANGUAGE"
+	STT_SEGMENTS        MetricName = "STT_SEGMENTS"
+	STT_SPEAKER         MetricName = "STT_SPEAKER"
 )
 
 func (m *MetricName) String() string {
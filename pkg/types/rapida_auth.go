@@ -19,9 +19,13 @@ var (
 	AUTH_KEY                  = "x-auth-id"
 	PROJECT_KEY               = "x-project-id"
 	SERVICE_SCOPE_KEY         = "x-internal-service-key"
+	// reason given for an administrative action, kept in the control audit log
+	AUDIT_REASON_KEY = "x-audit-reason"
 
 	//
 	PROJECT_SCOPE_KEY = "x-api-key"
+	// short-lived WebTalk session token, see SessionScope
+	SESSION_SCOPE_KEY = "x-session-token"
 	// later we will check the prefix and drop the request, this will not overload the server with random request
 	// another way to find length and pattern of our generated key, validate first if the given key in the same format
 	// only needed for scale
@@ -114,6 +118,8 @@ func GetSimplePrincipleGRPC(ctx context.Context) (SimplePrinciple, bool) {
 		return md.Info, md.Info.IsAuthenticated()
 	case *PlainClaimPrinciple[*OrganizationScope]:
 		return md.Info, md.Info.IsAuthenticated()
+	case *PlainClaimPrinciple[*SessionScope]:
+		return md.Info, md.Info.IsAuthenticated()
 	case Principle:
 		return md, md.IsAuthenticated()
 	default:
@@ -131,6 +137,8 @@ func GetAuthPrinciple(ctx *gin.Context) (SimplePrinciple, bool) {
 		return md.Info, md.Info.IsAuthenticated()
 	case *PlainClaimPrinciple[*OrganizationScope]:
 		return md.Info, md.Info.IsAuthenticated()
+	case *PlainClaimPrinciple[*SessionScope]:
+		return md.Info, md.Info.IsAuthenticated()
 	case Principle:
 		return md, md.IsAuthenticated()
 
@@ -0,0 +1,70 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package types
+
+// SessionScope is the principle of a WebTalk session token, a short-lived
+// token minted with a project key for one assistant, end user and origin so
+// browsers never hold the key itself.
+type SessionScope struct {
+	ProjectId      *uint64 `json:"projectId"`
+	OrganizationId *uint64 `json:"organizationId"`
+	// the only assistant the token talks to
+	AssistantId uint64 `json:"assistantId"`
+	// end user the token was minted for, any user when empty
+	UserId string `json:"userId"`
+	// origin of the page the token was minted for, any origin when empty
+	Origin       string `json:"origin"`
+	TokenId      string `json:"tokenId"`
+	CurrentToken string `json:"currentToken"`
+}
+
+func (ss *SessionScope) GetUserId() *uint64 {
+	return nil
+}
+func (ss *SessionScope) GetCurrentProjectId() *uint64 {
+	return ss.ProjectId
+}
+func (ss *SessionScope) GetCurrentOrganizationId() *uint64 {
+	return ss.OrganizationId
+}
+
+func (ss *SessionScope) HasOrganization() bool {
+	return ss.GetCurrentOrganizationId() != nil
+}
+
+func (ss *SessionScope) HasUser() bool {
+	return ss.GetUserId() != nil
+}
+
+func (ss *SessionScope) HasProject() bool {
+	return ss.GetCurrentProjectId() != nil
+}
+
+func (ss *SessionScope) IsAuthenticated() bool {
+	return ss.HasProject() && ss.HasOrganization() && ss.AssistantId > 0
+}
+
+func (ss *SessionScope) GetCurrentToken() string {
+	return ss.CurrentToken
+}
+
+func (ss *SessionScope) Type() string {
+	return "session"
+}
+
+// Permits reports whether the token allows talking to assistantId as the
+// end user userId.
+func (ss *SessionScope) Permits(assistantId uint64, userId string) bool {
+	if assistantId != ss.AssistantId {
+		return false
+	}
+	return ss.UserId == "" || userId == ss.UserId
+}
+
+// AllowsOrigin reports whether the token can be used from a page of origin.
+func (ss *SessionScope) AllowsOrigin(origin string) bool {
+	return ss.Origin == "" || origin == ss.Origin
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2023-2025 RapidaAI
+// Author: Prashant Srivastav <prashant@rapida.ai>
+//
+// Licensed under GPL-2.0 with Rapida Additional Terms.
+// See LICENSE.md or contact sales@rapida.ai for commercial usage.
+package types
+
+import "testing"
+
+func TestSessionScope_IsAuthenticated(t *testing.T) {
+	projectId, orgId := uint64(2), uint64(1)
+	tests := []struct {
+		name string
+		ss   *SessionScope
+		want bool
+	}{
+		{"bound to an assistant", &SessionScope{ProjectId: &projectId, OrganizationId: &orgId, AssistantId: 3}, true},
+		{"no assistant", &SessionScope{ProjectId: &projectId, OrganizationId: &orgId}, false},
+		{"no project", &SessionScope{OrganizationId: &orgId, AssistantId: 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ss.IsAuthenticated(); got != tt.want {
+				t.Errorf("IsAuthenticated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionScope_Permits(t *testing.T) {
+	ss := &SessionScope{AssistantId: 3, UserId: "user-1"}
+	tests := []struct {
+		name        string
+		assistantId uint64
+		userId      string
+		want        bool
+	}{
+		{"bound assistant and user", 3, "user-1", true},
+		{"other assistant", 4, "user-1", false},
+		{"other user", 3, "user-2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ss.Permits(tt.assistantId, tt.userId); got != tt.want {
+				t.Errorf("Permits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	unbound := &SessionScope{AssistantId: 3}
+	if !unbound.Permits(3, "anyone") {
+		t.Errorf("Permits() = false, want true for a token bound to no user")
+	}
+}
+
+func TestSessionScope_AllowsOrigin(t *testing.T) {
+	ss := &SessionScope{Origin: "https://example.com"}
+	if !ss.AllowsOrigin("https://example.com") {
+		t.Errorf("AllowsOrigin() = false, want true for the bound origin")
+	}
+	if ss.AllowsOrigin("https://evil.example") {
+		t.Errorf("AllowsOrigin() = true, want false for another origin")
+	}
+	if !(&SessionScope{}).AllowsOrigin("https://anywhere.example") {
+		t.Errorf("AllowsOrigin() = false, want true for a token bound to no origin")
+	}
+}
+
+func TestSessionScope_Type(t *testing.T) {
+	if got := (&SessionScope{}).Type(); got != "session" {
+		t.Errorf("Type() = %v, want session", got)
+	}
+}
@@ -60,6 +60,19 @@ const (
 	ConversationFailed AssistantWebhookEvent = "conversation.failed"
 	// Triggered when a conversation encounters an error.
 
+	ConversationDegraded AssistantWebhookEvent = "conversation.degraded"
+	// Triggered when text to speech is down and the conversation falls back
+	// to recorded prompts.
+
+	ConversationTransferring AssistantWebhookEvent = "conversation.transferring"
+	// Triggered when the assistant transfers the call to a human, before the
+	// agent answers, so their desktop can screen-pop the caller.
+
+	TranscriptInterim AssistantWebhookEvent = "transcript.interim"
+	TranscriptFinal   AssistantWebhookEvent = "transcript.final"
+	// Streamed while the conversation runs, interim as the user is heard and
+	// the assistant generates, final once a message is complete.
+
 )
 
 func (r AssistantWebhookEvent) Get() string {
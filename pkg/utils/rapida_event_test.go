@@ -51,6 +51,10 @@ func TestAssistantWebhookEvent_Get(t *testing.T) {
 		{ConversationResume, "conversation.resume"},
 		{ConversationCompleted, "conversation.completed"},
 		{ConversationFailed, "conversation.failed"},
+		{ConversationDegraded, "conversation.degraded"},
+		{ConversationTransferring, "conversation.transferring"},
+		{TranscriptInterim, "transcript.interim"},
+		{TranscriptFinal, "transcript.final"},
 	}
 
 	for _, tt := range tests {
@@ -24,6 +24,8 @@ const (
 	AssistantAgentTextGenerationStage RapidaStage = "talk.assistant.agent.text-generation"
 	AssistantSpeakingStage            RapidaStage = "talk.assistant.speak.speaking"
 	AssistantNotifyStage              RapidaStage = "talk.assistant.notify"
+	AssistantHandoffStage             RapidaStage = "talk.assistant.handoff"
+	AssistantTurnStage                RapidaStage = "talk.assistant.turn"
 	AssistantDisconnectStage          RapidaStage = "talk.assistant.disconnect"
 )
 
@@ -26,6 +26,8 @@ func TestRapidaStage_Get(t *testing.T) {
 		{AssistantAgentTextGenerationStage, "talk.assistant.agent.text-generation"},
 		{AssistantSpeakingStage, "talk.assistant.speak.speaking"},
 		{AssistantNotifyStage, "talk.assistant.notify"},
+		{AssistantHandoffStage, "talk.assistant.handoff"},
+		{AssistantTurnStage, "talk.assistant.turn"},
 		{AssistantDisconnectStage, "talk.assistant.disconnect"},
 	}
 
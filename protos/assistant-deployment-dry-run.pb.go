@@ -0,0 +1,387 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.20.3
+// source: assistant-deployment-dry-run.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DeploymentOptionIssue is a problem with a listen. or speak. option of the
+// audio of a deployment.
+type DeploymentOptionIssue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// input or output
+	AudioType string `protobuf:"bytes,1,opt,name=audioType,proto3" json:"audioType,omitempty"`
+	Key       string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// error, the deployment is not saved, or warning, e.g. an unknown key
+	Severity string `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message  string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeploymentOptionIssue) Reset() {
+	*x = DeploymentOptionIssue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_assistant_deployment_dry_run_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeploymentOptionIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentOptionIssue) ProtoMessage() {}
+
+func (x *DeploymentOptionIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_assistant_deployment_dry_run_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentOptionIssue.ProtoReflect.Descriptor instead.
+func (*DeploymentOptionIssue) Descriptor() ([]byte, []int) {
+	return file_assistant_deployment_dry_run_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeploymentOptionIssue) GetAudioType() string {
+	if x != nil {
+		return x.AudioType
+	}
+	return ""
+}
+
+func (x *DeploymentOptionIssue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeploymentOptionIssue) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *DeploymentOptionIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DryRunAssistantDeploymentAudioRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InputAudio  *DeploymentAudioProvider `protobuf:"bytes,1,opt,name=inputAudio,proto3" json:"inputAudio,omitempty"`
+	OutputAudio *DeploymentAudioProvider `protobuf:"bytes,2,opt,name=outputAudio,proto3" json:"outputAudio,omitempty"`
+}
+
+func (x *DryRunAssistantDeploymentAudioRequest) Reset() {
+	*x = DryRunAssistantDeploymentAudioRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_assistant_deployment_dry_run_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunAssistantDeploymentAudioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunAssistantDeploymentAudioRequest) ProtoMessage() {}
+
+func (x *DryRunAssistantDeploymentAudioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_assistant_deployment_dry_run_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunAssistantDeploymentAudioRequest.ProtoReflect.Descriptor instead.
+func (*DryRunAssistantDeploymentAudioRequest) Descriptor() ([]byte, []int) {
+	return file_assistant_deployment_dry_run_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DryRunAssistantDeploymentAudioRequest) GetInputAudio() *DeploymentAudioProvider {
+	if x != nil {
+		return x.InputAudio
+	}
+	return nil
+}
+
+func (x *DryRunAssistantDeploymentAudioRequest) GetOutputAudio() *DeploymentAudioProvider {
+	if x != nil {
+		return x.OutputAudio
+	}
+	return nil
+}
+
+type DryRunAssistantDeploymentAudioResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    int32                    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Success bool                     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Data    []*DeploymentOptionIssue `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+	Error   *Error                   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// no issue is an error, a deployment with this audio can be saved
+	Valid bool `protobuf:"varint,5,opt,name=valid,proto3" json:"valid,omitempty"`
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) Reset() {
+	*x = DryRunAssistantDeploymentAudioResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_assistant_deployment_dry_run_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunAssistantDeploymentAudioResponse) ProtoMessage() {}
+
+func (x *DryRunAssistantDeploymentAudioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_assistant_deployment_dry_run_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunAssistantDeploymentAudioResponse.ProtoReflect.Descriptor instead.
+func (*DryRunAssistantDeploymentAudioResponse) Descriptor() ([]byte, []int) {
+	return file_assistant_deployment_dry_run_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) GetData() []*DeploymentOptionIssue {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *DryRunAssistantDeploymentAudioResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+var File_assistant_deployment_dry_run_proto protoreflect.FileDescriptor
+
+var file_assistant_deployment_dry_run_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x61, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x2d, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2d, 0x64, 0x72, 0x79, 0x2d, 0x72, 0x75, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x61, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x5f,
+	0x61, 0x70, 0x69, 0x1a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1a, 0x61, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x2d, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7d, 0x0a,
+	0x15, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xb9, 0x01, 0x0a,
+	0x25, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x41, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74,
+	0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41,
+	0x75, 0x64, 0x69, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x73, 0x73,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x52, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x48,
+	0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x75,
+	0x64, 0x69, 0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x22, 0xc4, 0x01, 0x0a, 0x26, 0x44, 0x72, 0x79,
+	0x52, 0x75, 0x6e, 0x41, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x38, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x61, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x32,
+	0xb2, 0x01, 0x0a, 0x20, 0x41, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x1e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x41,
+	0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x34, 0x2e, 0x61, 0x73, 0x73, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x41, 0x73,
+	0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e,
+	0x61, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x41, 0x73, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1c, 0x5a, 0x1a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x70, 0x69, 0x64, 0x61, 0x61, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_assistant_deployment_dry_run_proto_rawDescOnce sync.Once
+	file_assistant_deployment_dry_run_proto_rawDescData = file_assistant_deployment_dry_run_proto_rawDesc
+)
+
+func file_assistant_deployment_dry_run_proto_rawDescGZIP() []byte {
+	file_assistant_deployment_dry_run_proto_rawDescOnce.Do(func() {
+		file_assistant_deployment_dry_run_proto_rawDescData = protoimpl.X.CompressGZIP(file_assistant_deployment_dry_run_proto_rawDescData)
+	})
+	return file_assistant_deployment_dry_run_proto_rawDescData
+}
+
+var file_assistant_deployment_dry_run_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_assistant_deployment_dry_run_proto_goTypes = []any{
+	(*DeploymentOptionIssue)(nil),                  // 0: assistant_api.DeploymentOptionIssue
+	(*DryRunAssistantDeploymentAudioRequest)(nil),  // 1: assistant_api.DryRunAssistantDeploymentAudioRequest
+	(*DryRunAssistantDeploymentAudioResponse)(nil), // 2: assistant_api.DryRunAssistantDeploymentAudioResponse
+	(*DeploymentAudioProvider)(nil),                // 3: assistant_api.DeploymentAudioProvider
+	(*Error)(nil),                                  // 4: Error
+}
+var file_assistant_deployment_dry_run_proto_depIdxs = []int32{
+	3, // 0: assistant_api.DryRunAssistantDeploymentAudioRequest.inputAudio:type_name -> assistant_api.DeploymentAudioProvider
+	3, // 1: assistant_api.DryRunAssistantDeploymentAudioRequest.outputAudio:type_name -> assistant_api.DeploymentAudioProvider
+	0, // 2: assistant_api.DryRunAssistantDeploymentAudioResponse.data:type_name -> assistant_api.DeploymentOptionIssue
+	4, // 3: assistant_api.DryRunAssistantDeploymentAudioResponse.error:type_name -> Error
+	1, // 4: assistant_api.AssistantDeploymentDryRunService.DryRunAssistantDeploymentAudio:input_type -> assistant_api.DryRunAssistantDeploymentAudioRequest
+	2, // 5: assistant_api.AssistantDeploymentDryRunService.DryRunAssistantDeploymentAudio:output_type -> assistant_api.DryRunAssistantDeploymentAudioResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_assistant_deployment_dry_run_proto_init() }
+func file_assistant_deployment_dry_run_proto_init() {
+	if File_assistant_deployment_dry_run_proto != nil {
+		return
+	}
+	file_common_proto_init()
+	file_assistant_deployment_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_assistant_deployment_dry_run_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*DeploymentOptionIssue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_assistant_deployment_dry_run_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*DryRunAssistantDeploymentAudioRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_assistant_deployment_dry_run_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*DryRunAssistantDeploymentAudioResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_assistant_deployment_dry_run_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_assistant_deployment_dry_run_proto_goTypes,
+		DependencyIndexes: file_assistant_deployment_dry_run_proto_depIdxs,
+		MessageInfos:      file_assistant_deployment_dry_run_proto_msgTypes,
+	}.Build()
+	File_assistant_deployment_dry_run_proto = out.File
+	file_assistant_deployment_dry_run_proto_rawDesc = nil
+	file_assistant_deployment_dry_run_proto_goTypes = nil
+	file_assistant_deployment_dry_run_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.3
+// source: control-audit-api.proto
+
+package protos
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ControlAuditService_GetAllControlAuditLog_FullMethodName = "/assistant_api.ControlAuditService/GetAllControlAuditLog"
+	ControlAuditService_CreateControlAuditLog_FullMethodName = "/assistant_api.ControlAuditService/CreateControlAuditLog"
+)
+
+// ControlAuditServiceClient is the client API for ControlAuditService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ControlAuditService exposes the append-only audit log of administrative
+// actions. Entries can be added and queried, never changed.
+type ControlAuditServiceClient interface {
+	GetAllControlAuditLog(ctx context.Context, in *GetAllControlAuditLogRequest, opts ...grpc.CallOption) (*GetAllControlAuditLogResponse, error)
+	CreateControlAuditLog(ctx context.Context, in *CreateControlAuditLogRequest, opts ...grpc.CallOption) (*CreateControlAuditLogResponse, error)
+}
+
+type controlAuditServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewControlAuditServiceClient(cc grpc.ClientConnInterface) ControlAuditServiceClient {
+	return &controlAuditServiceClient{cc}
+}
+
+func (c *controlAuditServiceClient) GetAllControlAuditLog(ctx context.Context, in *GetAllControlAuditLogRequest, opts ...grpc.CallOption) (*GetAllControlAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllControlAuditLogResponse)
+	err := c.cc.Invoke(ctx, ControlAuditService_GetAllControlAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlAuditServiceClient) CreateControlAuditLog(ctx context.Context, in *CreateControlAuditLogRequest, opts ...grpc.CallOption) (*CreateControlAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateControlAuditLogResponse)
+	err := c.cc.Invoke(ctx, ControlAuditService_CreateControlAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlAuditServiceServer is the server API for ControlAuditService service.
+// All implementations should embed UnimplementedControlAuditServiceServer
+// for forward compatibility.
+//
+// ControlAuditService exposes the append-only audit log of administrative
+// actions. Entries can be added and queried, never changed.
+type ControlAuditServiceServer interface {
+	GetAllControlAuditLog(context.Context, *GetAllControlAuditLogRequest) (*GetAllControlAuditLogResponse, error)
+	CreateControlAuditLog(context.Context, *CreateControlAuditLogRequest) (*CreateControlAuditLogResponse, error)
+}
+
+// UnimplementedControlAuditServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedControlAuditServiceServer struct{}
+
+func (UnimplementedControlAuditServiceServer) GetAllControlAuditLog(context.Context, *GetAllControlAuditLogRequest) (*GetAllControlAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllControlAuditLog not implemented")
+}
+func (UnimplementedControlAuditServiceServer) CreateControlAuditLog(context.Context, *CreateControlAuditLogRequest) (*CreateControlAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateControlAuditLog not implemented")
+}
+func (UnimplementedControlAuditServiceServer) testEmbeddedByValue() {}
+
+// UnsafeControlAuditServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControlAuditServiceServer will
+// result in compilation errors.
+type UnsafeControlAuditServiceServer interface {
+	mustEmbedUnimplementedControlAuditServiceServer()
+}
+
+func RegisterControlAuditServiceServer(s grpc.ServiceRegistrar, srv ControlAuditServiceServer) {
+	// If the following call pancis, it indicates UnimplementedControlAuditServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ControlAuditService_ServiceDesc, srv)
+}
+
+func _ControlAuditService_GetAllControlAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllControlAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlAuditServiceServer).GetAllControlAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlAuditService_GetAllControlAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlAuditServiceServer).GetAllControlAuditLog(ctx, req.(*GetAllControlAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlAuditService_CreateControlAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateControlAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlAuditServiceServer).CreateControlAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlAuditService_CreateControlAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlAuditServiceServer).CreateControlAuditLog(ctx, req.(*CreateControlAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ControlAuditService_ServiceDesc is the grpc.ServiceDesc for ControlAuditService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ControlAuditService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "assistant_api.ControlAuditService",
+	HandlerType: (*ControlAuditServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAllControlAuditLog",
+			Handler:    _ControlAuditService_GetAllControlAuditLog_Handler,
+		},
+		{
+			MethodName: "CreateControlAuditLog",
+			Handler:    _ControlAuditService_CreateControlAuditLog_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "control-audit-api.proto",
+}
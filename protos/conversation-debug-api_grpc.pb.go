@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.3
+// source: conversation-debug-api.proto
+
+package protos
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ConversationDebugService_SnapshotConversation_FullMethodName = "/assistant_api.ConversationDebugService/SnapshotConversation"
+	ConversationDebugService_RestoreConversation_FullMethodName  = "/assistant_api.ConversationDebugService/RestoreConversation"
+	ConversationDebugService_DiffConversations_FullMethodName    = "/assistant_api.ConversationDebugService/DiffConversations"
+)
+
+// ConversationDebugServiceClient is the client API for ConversationDebugService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ConversationDebugService captures live conversations so incidents can be
+// reproduced offline. A conversation is only found on the instance hosting
+// its call.
+type ConversationDebugServiceClient interface {
+	SnapshotConversation(ctx context.Context, in *SnapshotConversationRequest, opts ...grpc.CallOption) (*SnapshotConversationResponse, error)
+	RestoreConversation(ctx context.Context, in *RestoreConversationRequest, opts ...grpc.CallOption) (*RestoreConversationResponse, error)
+	DiffConversations(ctx context.Context, in *DiffConversationsRequest, opts ...grpc.CallOption) (*DiffConversationsResponse, error)
+}
+
+type conversationDebugServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConversationDebugServiceClient(cc grpc.ClientConnInterface) ConversationDebugServiceClient {
+	return &conversationDebugServiceClient{cc}
+}
+
+func (c *conversationDebugServiceClient) SnapshotConversation(ctx context.Context, in *SnapshotConversationRequest, opts ...grpc.CallOption) (*SnapshotConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnapshotConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationDebugService_SnapshotConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationDebugServiceClient) RestoreConversation(ctx context.Context, in *RestoreConversationRequest, opts ...grpc.CallOption) (*RestoreConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationDebugService_RestoreConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationDebugServiceClient) DiffConversations(ctx context.Context, in *DiffConversationsRequest, opts ...grpc.CallOption) (*DiffConversationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffConversationsResponse)
+	err := c.cc.Invoke(ctx, ConversationDebugService_DiffConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConversationDebugServiceServer is the server API for ConversationDebugService service.
+// All implementations should embed UnimplementedConversationDebugServiceServer
+// for forward compatibility.
+//
+// ConversationDebugService captures live conversations so incidents can be
+// reproduced offline. A conversation is only found on the instance hosting
+// its call.
+type ConversationDebugServiceServer interface {
+	SnapshotConversation(context.Context, *SnapshotConversationRequest) (*SnapshotConversationResponse, error)
+	RestoreConversation(context.Context, *RestoreConversationRequest) (*RestoreConversationResponse, error)
+	DiffConversations(context.Context, *DiffConversationsRequest) (*DiffConversationsResponse, error)
+}
+
+// UnimplementedConversationDebugServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConversationDebugServiceServer struct{}
+
+func (UnimplementedConversationDebugServiceServer) SnapshotConversation(context.Context, *SnapshotConversationRequest) (*SnapshotConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotConversation not implemented")
+}
+func (UnimplementedConversationDebugServiceServer) RestoreConversation(context.Context, *RestoreConversationRequest) (*RestoreConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreConversation not implemented")
+}
+func (UnimplementedConversationDebugServiceServer) DiffConversations(context.Context, *DiffConversationsRequest) (*DiffConversationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffConversations not implemented")
+}
+func (UnimplementedConversationDebugServiceServer) testEmbeddedByValue() {}
+
+// UnsafeConversationDebugServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConversationDebugServiceServer will
+// result in compilation errors.
+type UnsafeConversationDebugServiceServer interface {
+	mustEmbedUnimplementedConversationDebugServiceServer()
+}
+
+func RegisterConversationDebugServiceServer(s grpc.ServiceRegistrar, srv ConversationDebugServiceServer) {
+	// If the following call pancis, it indicates UnimplementedConversationDebugServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ConversationDebugService_ServiceDesc, srv)
+}
+
+func _ConversationDebugService_SnapshotConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationDebugServiceServer).SnapshotConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationDebugService_SnapshotConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationDebugServiceServer).SnapshotConversation(ctx, req.(*SnapshotConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationDebugService_RestoreConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationDebugServiceServer).RestoreConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationDebugService_RestoreConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationDebugServiceServer).RestoreConversation(ctx, req.(*RestoreConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationDebugService_DiffConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffConversationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationDebugServiceServer).DiffConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationDebugService_DiffConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationDebugServiceServer).DiffConversations(ctx, req.(*DiffConversationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ConversationDebugService_ServiceDesc is the grpc.ServiceDesc for ConversationDebugService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConversationDebugService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "assistant_api.ConversationDebugService",
+	HandlerType: (*ConversationDebugServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SnapshotConversation",
+			Handler:    _ConversationDebugService_SnapshotConversation_Handler,
+		},
+		{
+			MethodName: "RestoreConversation",
+			Handler:    _ConversationDebugService_RestoreConversation_Handler,
+		},
+		{
+			MethodName: "DiffConversations",
+			Handler:    _ConversationDebugService_DiffConversations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "conversation-debug-api.proto",
+}
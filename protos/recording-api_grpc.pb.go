@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.3
+// source: recording-api.proto
+
+package protos
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RecordingService_GetRecordingRetentionPolicy_FullMethodName     = "/assistant_api.RecordingService/GetRecordingRetentionPolicy"
+	RecordingService_UpdateRecordingRetentionPolicy_FullMethodName  = "/assistant_api.RecordingService/UpdateRecordingRetentionPolicy"
+	RecordingService_GetConversationRecording_FullMethodName        = "/assistant_api.RecordingService/GetConversationRecording"
+	RecordingService_GetAssistantRecordingSetting_FullMethodName    = "/assistant_api.RecordingService/GetAssistantRecordingSetting"
+	RecordingService_UpdateAssistantRecordingSetting_FullMethodName = "/assistant_api.RecordingService/UpdateAssistantRecordingSetting"
+)
+
+// RecordingServiceClient is the client API for RecordingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RecordingService manages how call recordings are made, where they are kept
+// and for how long, and retrieves them from whichever tier they are in.
+type RecordingServiceClient interface {
+	GetRecordingRetentionPolicy(ctx context.Context, in *GetRecordingRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRecordingRetentionPolicyResponse, error)
+	UpdateRecordingRetentionPolicy(ctx context.Context, in *UpdateRecordingRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRecordingRetentionPolicyResponse, error)
+	GetConversationRecording(ctx context.Context, in *GetConversationRecordingRequest, opts ...grpc.CallOption) (*GetConversationRecordingResponse, error)
+	GetAssistantRecordingSetting(ctx context.Context, in *GetAssistantRecordingSettingRequest, opts ...grpc.CallOption) (*GetAssistantRecordingSettingResponse, error)
+	UpdateAssistantRecordingSetting(ctx context.Context, in *UpdateAssistantRecordingSettingRequest, opts ...grpc.CallOption) (*GetAssistantRecordingSettingResponse, error)
+}
+
+type recordingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecordingServiceClient(cc grpc.ClientConnInterface) RecordingServiceClient {
+	return &recordingServiceClient{cc}
+}
+
+func (c *recordingServiceClient) GetRecordingRetentionPolicy(ctx context.Context, in *GetRecordingRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRecordingRetentionPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecordingRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, RecordingService_GetRecordingRetentionPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordingServiceClient) UpdateRecordingRetentionPolicy(ctx context.Context, in *UpdateRecordingRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRecordingRetentionPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecordingRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, RecordingService_UpdateRecordingRetentionPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordingServiceClient) GetConversationRecording(ctx context.Context, in *GetConversationRecordingRequest, opts ...grpc.CallOption) (*GetConversationRecordingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConversationRecordingResponse)
+	err := c.cc.Invoke(ctx, RecordingService_GetConversationRecording_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordingServiceClient) GetAssistantRecordingSetting(ctx context.Context, in *GetAssistantRecordingSettingRequest, opts ...grpc.CallOption) (*GetAssistantRecordingSettingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAssistantRecordingSettingResponse)
+	err := c.cc.Invoke(ctx, RecordingService_GetAssistantRecordingSetting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordingServiceClient) UpdateAssistantRecordingSetting(ctx context.Context, in *UpdateAssistantRecordingSettingRequest, opts ...grpc.CallOption) (*GetAssistantRecordingSettingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAssistantRecordingSettingResponse)
+	err := c.cc.Invoke(ctx, RecordingService_UpdateAssistantRecordingSetting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecordingServiceServer is the server API for RecordingService service.
+// All implementations should embed UnimplementedRecordingServiceServer
+// for forward compatibility.
+//
+// RecordingService manages how call recordings are made, where they are kept
+// and for how long, and retrieves them from whichever tier they are in.
+type RecordingServiceServer interface {
+	GetRecordingRetentionPolicy(context.Context, *GetRecordingRetentionPolicyRequest) (*GetRecordingRetentionPolicyResponse, error)
+	UpdateRecordingRetentionPolicy(context.Context, *UpdateRecordingRetentionPolicyRequest) (*GetRecordingRetentionPolicyResponse, error)
+	GetConversationRecording(context.Context, *GetConversationRecordingRequest) (*GetConversationRecordingResponse, error)
+	GetAssistantRecordingSetting(context.Context, *GetAssistantRecordingSettingRequest) (*GetAssistantRecordingSettingResponse, error)
+	UpdateAssistantRecordingSetting(context.Context, *UpdateAssistantRecordingSettingRequest) (*GetAssistantRecordingSettingResponse, error)
+}
+
+// UnimplementedRecordingServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecordingServiceServer struct{}
+
+func (UnimplementedRecordingServiceServer) GetRecordingRetentionPolicy(context.Context, *GetRecordingRetentionPolicyRequest) (*GetRecordingRetentionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecordingRetentionPolicy not implemented")
+}
+func (UnimplementedRecordingServiceServer) UpdateRecordingRetentionPolicy(context.Context, *UpdateRecordingRetentionPolicyRequest) (*GetRecordingRetentionPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecordingRetentionPolicy not implemented")
+}
+func (UnimplementedRecordingServiceServer) GetConversationRecording(context.Context, *GetConversationRecordingRequest) (*GetConversationRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConversationRecording not implemented")
+}
+func (UnimplementedRecordingServiceServer) GetAssistantRecordingSetting(context.Context, *GetAssistantRecordingSettingRequest) (*GetAssistantRecordingSettingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssistantRecordingSetting not implemented")
+}
+func (UnimplementedRecordingServiceServer) UpdateAssistantRecordingSetting(context.Context, *UpdateAssistantRecordingSettingRequest) (*GetAssistantRecordingSettingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAssistantRecordingSetting not implemented")
+}
+func (UnimplementedRecordingServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRecordingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecordingServiceServer will
+// result in compilation errors.
+type UnsafeRecordingServiceServer interface {
+	mustEmbedUnimplementedRecordingServiceServer()
+}
+
+func RegisterRecordingServiceServer(s grpc.ServiceRegistrar, srv RecordingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecordingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecordingService_ServiceDesc, srv)
+}
+
+func _RecordingService_GetRecordingRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordingRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordingServiceServer).GetRecordingRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordingService_GetRecordingRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordingServiceServer).GetRecordingRetentionPolicy(ctx, req.(*GetRecordingRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordingService_UpdateRecordingRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecordingRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordingServiceServer).UpdateRecordingRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordingService_UpdateRecordingRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordingServiceServer).UpdateRecordingRetentionPolicy(ctx, req.(*UpdateRecordingRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordingService_GetConversationRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConversationRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordingServiceServer).GetConversationRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordingService_GetConversationRecording_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordingServiceServer).GetConversationRecording(ctx, req.(*GetConversationRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordingService_GetAssistantRecordingSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssistantRecordingSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordingServiceServer).GetAssistantRecordingSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordingService_GetAssistantRecordingSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordingServiceServer).GetAssistantRecordingSetting(ctx, req.(*GetAssistantRecordingSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordingService_UpdateAssistantRecordingSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssistantRecordingSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordingServiceServer).UpdateAssistantRecordingSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordingService_UpdateAssistantRecordingSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordingServiceServer).UpdateAssistantRecordingSetting(ctx, req.(*UpdateAssistantRecordingSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecordingService_ServiceDesc is the grpc.ServiceDesc for RecordingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecordingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "assistant_api.RecordingService",
+	HandlerType: (*RecordingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRecordingRetentionPolicy",
+			Handler:    _RecordingService_GetRecordingRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateRecordingRetentionPolicy",
+			Handler:    _RecordingService_UpdateRecordingRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "GetConversationRecording",
+			Handler:    _RecordingService_GetConversationRecording_Handler,
+		},
+		{
+			MethodName: "GetAssistantRecordingSetting",
+			Handler:    _RecordingService_GetAssistantRecordingSetting_Handler,
+		},
+		{
+			MethodName: "UpdateAssistantRecordingSetting",
+			Handler:    _RecordingService_UpdateAssistantRecordingSetting_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "recording-api.proto",
+}
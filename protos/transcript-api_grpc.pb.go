@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.3
+// source: transcript-api.proto
+
+package protos
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TranscriptService_GetTranscriptSetting_FullMethodName         = "/assistant_api.TranscriptService/GetTranscriptSetting"
+	TranscriptService_UpdateTranscriptSetting_FullMethodName      = "/assistant_api.TranscriptService/UpdateTranscriptSetting"
+	TranscriptService_ExportConversationTranscript_FullMethodName = "/assistant_api.TranscriptService/ExportConversationTranscript"
+)
+
+// TranscriptServiceClient is the client API for TranscriptService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TranscriptService exports conversation transcripts and manages the
+// notices appended to them.
+type TranscriptServiceClient interface {
+	GetTranscriptSetting(ctx context.Context, in *GetTranscriptSettingRequest, opts ...grpc.CallOption) (*GetTranscriptSettingResponse, error)
+	UpdateTranscriptSetting(ctx context.Context, in *UpdateTranscriptSettingRequest, opts ...grpc.CallOption) (*GetTranscriptSettingResponse, error)
+	ExportConversationTranscript(ctx context.Context, in *ExportConversationTranscriptRequest, opts ...grpc.CallOption) (*ExportConversationTranscriptResponse, error)
+}
+
+type transcriptServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTranscriptServiceClient(cc grpc.ClientConnInterface) TranscriptServiceClient {
+	return &transcriptServiceClient{cc}
+}
+
+func (c *transcriptServiceClient) GetTranscriptSetting(ctx context.Context, in *GetTranscriptSettingRequest, opts ...grpc.CallOption) (*GetTranscriptSettingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTranscriptSettingResponse)
+	err := c.cc.Invoke(ctx, TranscriptService_GetTranscriptSetting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcriptServiceClient) UpdateTranscriptSetting(ctx context.Context, in *UpdateTranscriptSettingRequest, opts ...grpc.CallOption) (*GetTranscriptSettingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTranscriptSettingResponse)
+	err := c.cc.Invoke(ctx, TranscriptService_UpdateTranscriptSetting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcriptServiceClient) ExportConversationTranscript(ctx context.Context, in *ExportConversationTranscriptRequest, opts ...grpc.CallOption) (*ExportConversationTranscriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportConversationTranscriptResponse)
+	err := c.cc.Invoke(ctx, TranscriptService_ExportConversationTranscript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TranscriptServiceServer is the server API for TranscriptService service.
+// All implementations should embed UnimplementedTranscriptServiceServer
+// for forward compatibility.
+//
+// TranscriptService exports conversation transcripts and manages the
+// notices appended to them.
+type TranscriptServiceServer interface {
+	GetTranscriptSetting(context.Context, *GetTranscriptSettingRequest) (*GetTranscriptSettingResponse, error)
+	UpdateTranscriptSetting(context.Context, *UpdateTranscriptSettingRequest) (*GetTranscriptSettingResponse, error)
+	ExportConversationTranscript(context.Context, *ExportConversationTranscriptRequest) (*ExportConversationTranscriptResponse, error)
+}
+
+// UnimplementedTranscriptServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTranscriptServiceServer struct{}
+
+func (UnimplementedTranscriptServiceServer) GetTranscriptSetting(context.Context, *GetTranscriptSettingRequest) (*GetTranscriptSettingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTranscriptSetting not implemented")
+}
+func (UnimplementedTranscriptServiceServer) UpdateTranscriptSetting(context.Context, *UpdateTranscriptSettingRequest) (*GetTranscriptSettingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTranscriptSetting not implemented")
+}
+func (UnimplementedTranscriptServiceServer) ExportConversationTranscript(context.Context, *ExportConversationTranscriptRequest) (*ExportConversationTranscriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportConversationTranscript not implemented")
+}
+func (UnimplementedTranscriptServiceServer) testEmbeddedByValue() {}
+
+// UnsafeTranscriptServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TranscriptServiceServer will
+// result in compilation errors.
+type UnsafeTranscriptServiceServer interface {
+	mustEmbedUnimplementedTranscriptServiceServer()
+}
+
+func RegisterTranscriptServiceServer(s grpc.ServiceRegistrar, srv TranscriptServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTranscriptServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TranscriptService_ServiceDesc, srv)
+}
+
+func _TranscriptService_GetTranscriptSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranscriptSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscriptServiceServer).GetTranscriptSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscriptService_GetTranscriptSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscriptServiceServer).GetTranscriptSetting(ctx, req.(*GetTranscriptSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscriptService_UpdateTranscriptSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTranscriptSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscriptServiceServer).UpdateTranscriptSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscriptService_UpdateTranscriptSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscriptServiceServer).UpdateTranscriptSetting(ctx, req.(*UpdateTranscriptSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscriptService_ExportConversationTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportConversationTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscriptServiceServer).ExportConversationTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscriptService_ExportConversationTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscriptServiceServer).ExportConversationTranscript(ctx, req.(*ExportConversationTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TranscriptService_ServiceDesc is the grpc.ServiceDesc for TranscriptService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TranscriptService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "assistant_api.TranscriptService",
+	HandlerType: (*TranscriptServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTranscriptSetting",
+			Handler:    _TranscriptService_GetTranscriptSetting_Handler,
+		},
+		{
+			MethodName: "UpdateTranscriptSetting",
+			Handler:    _TranscriptService_UpdateTranscriptSetting_Handler,
+		},
+		{
+			MethodName: "ExportConversationTranscript",
+			Handler:    _TranscriptService_ExportConversationTranscript_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "transcript-api.proto",
+}
@@ -14,6 +14,10 @@ interface EndOfSpeechProviderProps extends HTMLAttributes<HTMLDivElement> {
   onChangeEndOfSpeechProvider: (string) => void;
   endOfSepeechTimeout: string;
   onChangeEndOfSepeechTimeout: (n: string) => void;
+  speechFinalDelay?: string;
+  onChangeSpeechFinalDelay?: (n: string) => void;
+  maxTurnSilence?: string;
+  onChangeMaxTurnSilence?: (n: string) => void;
 }
 
 export const EndOfSpeechProvider: React.FC<EndOfSpeechProviderProps> = ({
@@ -21,8 +25,13 @@ export const EndOfSpeechProvider: React.FC<EndOfSpeechProviderProps> = ({
   onChangeEndOfSpeechProvider,
   endOfSepeechTimeout,
   onChangeEndOfSepeechTimeout,
+  speechFinalDelay,
+  onChangeSpeechFinalDelay,
+  maxTurnSilence,
+  onChangeMaxTurnSilence,
   className,
 }) => {
+  const endpointing = endOfSpeechProvider === 'endpointing_eos';
   return (
     <InputGroup
       initiallyExpanded={false}
@@ -83,6 +92,60 @@ export const EndOfSpeechProvider: React.FC<EndOfSpeechProviderProps> = ({
             EOS: Based on silence and max time (500-4000ms).
           </InputHelper>
         </FieldSet>
+        {endpointing && (
+          <>
+            <FieldSet className="col-span-1">
+              <FormLabel>Speech Final Delay</FormLabel>
+              <div className="flex space-x-2 justify-center items-center">
+                <Slider
+                  min={0}
+                  max={2000}
+                  step={50}
+                  value={parseInt(speechFinalDelay || '300')}
+                  onSlide={v => {
+                    onChangeSpeechFinalDelay?.(v.toString());
+                  }}
+                />
+                <Input
+                  min={0}
+                  max={2000}
+                  className="bg-light-background w-16"
+                  value={speechFinalDelay || '300'}
+                  onChange={e => onChangeSpeechFinalDelay?.(e.target.value)}
+                />
+              </div>
+              <InputHelper>
+                Silence after which a message that reads like a finished
+                sentence is finalized (0-2000ms).
+              </InputHelper>
+            </FieldSet>
+            <FieldSet className="col-span-1">
+              <FormLabel>Max Turn Silence</FormLabel>
+              <div className="flex space-x-2 justify-center items-center">
+                <Slider
+                  min={500}
+                  max={6000}
+                  step={100}
+                  value={parseInt(maxTurnSilence || '2000')}
+                  onSlide={v => {
+                    onChangeMaxTurnSilence?.(v.toString());
+                  }}
+                />
+                <Input
+                  min={500}
+                  max={6000}
+                  className="bg-light-background w-16"
+                  value={maxTurnSilence || '2000'}
+                  onChange={e => onChangeMaxTurnSilence?.(e.target.value)}
+                />
+              </div>
+              <InputHelper>
+                Longest silence Rapida waits when the user trails off mid
+                sentence, e.g. after "and" or "my number is" (500-6000ms).
+              </InputHelper>
+            </FieldSet>
+          </>
+        )}
       </div>
     </InputGroup>
   );
@@ -0,0 +1,35 @@
+import { Metadata } from '@rapidaai/react';
+import { FieldSet } from '@/app/components/form/fieldset';
+import { InputHelper } from '@/app/components/input-helper';
+
+export const ValidateAmazonConnectTelephonyOptions = (
+  options: Metadata[],
+): boolean => {
+  const credentialID = options.find(
+    opt => opt.getKey() === 'rapida.credential_id',
+  );
+  if (
+    !credentialID ||
+    !credentialID.getValue() ||
+    credentialID.getValue().length === 0
+  ) {
+    return false;
+  }
+  return true;
+};
+
+export const ConfigureAmazonConnectTelephony: React.FC<{
+  onParameterChange: (parameters: Metadata[]) => void;
+  parameters: Metadata[] | null;
+}> = () => {
+  return (
+    <FieldSet className="col-span-3">
+      <InputHelper>
+        Calls are handed over by a contact flow: run Start media streaming,
+        then invoke a Lambda function that posts the contact flow event to
+        /v1/talk/amazon-connect/call/&lt;assistantId&gt;. Media streaming is
+        receive only, the caller does not hear the assistant.
+      </InputHelper>
+    </FieldSet>
+  );
+};
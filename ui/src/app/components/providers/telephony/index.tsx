@@ -19,6 +19,22 @@ import {
   ConfigureAsteriskTelephony,
   ValidateAsteriskTelephonyOptions,
 } from '@/app/components/providers/telephony/asterisk';
+import {
+  ConfigureFreeSWITCHTelephony,
+  ValidateFreeSWITCHTelephonyOptions,
+} from '@/app/components/providers/telephony/freeswitch';
+import {
+  ConfigureAmazonConnectTelephony,
+  ValidateAmazonConnectTelephonyOptions,
+} from '@/app/components/providers/telephony/amazon-connect';
+import {
+  ConfigureZoomTelephony,
+  ValidateZoomTelephonyOptions,
+} from '@/app/components/providers/telephony/zoom';
+import {
+  ConfigureWhatsAppTelephony,
+  ValidateWhatsAppTelephonyOptions,
+} from '@/app/components/providers/telephony/whatsapp';
 import { Dropdown } from '@/app/components/dropdown';
 import { FormLabel } from '@/app/components/form-label';
 import { FieldSet } from '@/app/components/form/fieldset';
@@ -44,6 +60,14 @@ export const ValidateTelephonyOptions = (
       return ValidateSIPTelephonyOptions(parameters);
     case 'asterisk':
       return ValidateAsteriskTelephonyOptions(parameters);
+    case 'freeswitch':
+      return ValidateFreeSWITCHTelephonyOptions(parameters);
+    case 'amazon-connect':
+      return ValidateAmazonConnectTelephonyOptions(parameters);
+    case 'zoom':
+      return ValidateZoomTelephonyOptions(parameters);
+    case 'whatsapp':
+      return ValidateWhatsAppTelephonyOptions(parameters);
     default:
       return false;
   }
@@ -96,6 +120,34 @@ export const ConfigureTelephonyComponent: React.FC<ProviderComponentProps> = ({
           onParameterChange={onChangeParameter}
         />
       );
+    case 'freeswitch':
+      return (
+        <ConfigureFreeSWITCHTelephony
+          parameters={parameters || []}
+          onParameterChange={onChangeParameter}
+        />
+      );
+    case 'amazon-connect':
+      return (
+        <ConfigureAmazonConnectTelephony
+          parameters={parameters || []}
+          onParameterChange={onChangeParameter}
+        />
+      );
+    case 'zoom':
+      return (
+        <ConfigureZoomTelephony
+          parameters={parameters || []}
+          onParameterChange={onChangeParameter}
+        />
+      );
+    case 'whatsapp':
+      return (
+        <ConfigureWhatsAppTelephony
+          parameters={parameters || []}
+          onParameterChange={onChangeParameter}
+        />
+      );
     default:
       return null;
   }
@@ -2,6 +2,7 @@ import { Metadata } from '@rapidaai/react';
 import { FormLabel } from '@/app/components/form-label';
 import { FieldSet } from '@/app/components/form/fieldset';
 import { Input } from '@/app/components/form/input';
+import { Select } from '@/app/components/form/select';
 import { InputHelper } from '@/app/components/input-helper';
 
 export const ValidateTwilioTelephonyOptions = (
@@ -65,6 +66,76 @@ export const ConfigureTwilioTelephony: React.FC<{
           Phone to recieve inbound or make outbound call.
         </InputHelper>
       </FieldSet>
+      <FieldSet className="col-span-2">
+        <FormLabel>Mode</FormLabel>
+        <Select
+          className="bg-light-background"
+          value={getParamValue('mode') || 'media_stream'}
+          onChange={v => {
+            updateParameter('mode', v.target.value);
+          }}
+          options={[
+            { name: 'Media Streams', value: 'media_stream' },
+            { name: 'ConversationRelay', value: 'conversation_relay' },
+          ]}
+        />
+        <InputHelper>
+          With ConversationRelay Twilio transcribes the caller and speaks the
+          assistant's replies, the assistant's speech to text and text to
+          speech are not used.
+        </InputHelper>
+      </FieldSet>
+      {getParamValue('mode') === 'conversation_relay' && (
+        <>
+          <FieldSet>
+            <FormLabel>Language</FormLabel>
+            <Input
+              className="bg-light-background"
+              value={getParamValue('relay.language')}
+              onChange={v => {
+                updateParameter('relay.language', v.target.value);
+              }}
+              placeholder="en-US"
+            />
+          </FieldSet>
+          <FieldSet>
+            <FormLabel>Voice</FormLabel>
+            <Input
+              className="bg-light-background"
+              value={getParamValue('relay.voice')}
+              onChange={v => {
+                updateParameter('relay.voice', v.target.value);
+              }}
+              placeholder="Twilio default voice"
+            />
+          </FieldSet>
+          <FieldSet>
+            <FormLabel>TTS Provider</FormLabel>
+            <Input
+              className="bg-light-background"
+              value={getParamValue('relay.tts_provider')}
+              onChange={v => {
+                updateParameter('relay.tts_provider', v.target.value);
+              }}
+              placeholder="ElevenLabs, Google or Amazon"
+            />
+          </FieldSet>
+          <FieldSet>
+            <FormLabel>Transcription Provider</FormLabel>
+            <Input
+              className="bg-light-background"
+              value={getParamValue('relay.transcription_provider')}
+              onChange={v => {
+                updateParameter(
+                  'relay.transcription_provider',
+                  v.target.value,
+                );
+              }}
+              placeholder="Deepgram or Google"
+            />
+          </FieldSet>
+        </>
+      )}
     </>
   );
 };
@@ -0,0 +1,36 @@
+import { Metadata } from '@rapidaai/react';
+import { FieldSet } from '@/app/components/form/fieldset';
+import { InputHelper } from '@/app/components/input-helper';
+
+export const ValidateWhatsAppTelephonyOptions = (
+  options: Metadata[],
+): boolean => {
+  const credentialID = options.find(
+    opt => opt.getKey() === 'rapida.credential_id',
+  );
+  if (
+    !credentialID ||
+    !credentialID.getValue() ||
+    credentialID.getValue().length === 0
+  ) {
+    return false;
+  }
+  return true;
+};
+
+export const ConfigureWhatsAppTelephony: React.FC<{
+  onParameterChange: (parameters: Metadata[]) => void;
+  parameters: Metadata[] | null;
+}> = () => {
+  return (
+    <FieldSet className="col-span-3">
+      <InputHelper>
+        Enable calling on the business phone number and subscribe the webhook
+        of your Meta app to the calls field with the callback URL
+        /v1/talk/whatsapp/call/&lt;assistantId&gt; and the verify token of the
+        credential. Calls are placed by WhatsApp users, the assistant answers
+        them.
+      </InputHelper>
+    </FieldSet>
+  );
+};
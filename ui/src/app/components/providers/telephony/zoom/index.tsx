@@ -0,0 +1,33 @@
+import { Metadata } from '@rapidaai/react';
+import { FieldSet } from '@/app/components/form/fieldset';
+import { InputHelper } from '@/app/components/input-helper';
+
+export const ValidateZoomTelephonyOptions = (options: Metadata[]): boolean => {
+  const credentialID = options.find(
+    opt => opt.getKey() === 'rapida.credential_id',
+  );
+  if (
+    !credentialID ||
+    !credentialID.getValue() ||
+    credentialID.getValue().length === 0
+  ) {
+    return false;
+  }
+  return true;
+};
+
+export const ConfigureZoomTelephony: React.FC<{
+  onParameterChange: (parameters: Metadata[]) => void;
+  parameters: Metadata[] | null;
+}> = () => {
+  return (
+    <FieldSet className="col-span-3">
+      <InputHelper>
+        Add an event subscription for the RTMS started events to your Zoom app
+        with the endpoint /v1/talk/zoom/call/&lt;assistantId&gt; and a custom
+        x-api-key header. Realtime Media Streams are receive only, the caller
+        does not hear the assistant.
+      </InputHelper>
+    </FieldSet>
+  );
+};
@@ -29,6 +29,7 @@ export type ParameterType =
   | 'argument'
   | 'metadata'
   | 'option'
+  | 'scratchpad'
   | 'custom';
 
 export interface KeyValueParameter {
@@ -50,6 +51,7 @@ export const PARAMETER_TYPE_OPTIONS: Array<{
   { name: 'Argument', value: 'argument' },
   { name: 'Metadata', value: 'metadata' },
   { name: 'Option', value: 'option' },
+  { name: 'Scratchpad', value: 'scratchpad' },
   { name: 'Custom', value: 'custom' },
 ];
 
@@ -0,0 +1,13 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetConversationMetadataDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  return [];
+};
+
+export const ValidateConversationMetadataDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
@@ -0,0 +1,24 @@
+import { FC } from 'react';
+import { ConfigureToolProps, ToolDefinitionForm } from '../common';
+
+// ============================================================================
+// Main Component
+// ============================================================================
+
+export const ConfigureConversationMetadata: FC<ConfigureToolProps> = ({
+  inputClass,
+  toolDefinition,
+  onChangeToolDefinition,
+}) => (
+  <>
+    {toolDefinition && onChangeToolDefinition && (
+      <ToolDefinitionForm
+        toolDefinition={toolDefinition}
+        onChangeToolDefinition={onChangeToolDefinition}
+        inputClass={inputClass}
+        documentationUrl="https://doc.rapida.ai/assistants/tools/add-conversation-metadata-tool"
+        documentationTitle="Know more about attaching metadata to the conversation record"
+      />
+    )}
+  </>
+);
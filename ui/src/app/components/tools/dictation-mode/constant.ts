@@ -0,0 +1,13 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetDictationModeDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  return [];
+};
+
+export const ValidateDictationModeDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
@@ -35,6 +35,7 @@ const ENDPOINT_PARAMETER_TYPE_OPTIONS: Array<{ name: string; value: string }> =
     { name: 'Argument', value: 'argument' },
     { name: 'Metadata', value: 'metadata' },
     { name: 'Option', value: 'option' },
+    { name: 'Scratchpad', value: 'scratchpad' },
   ];
 
 // ============================================================================
@@ -0,0 +1,52 @@
+import { Metadata } from '@rapidaai/react';
+import { SetMetadata } from '@/utils/metadata';
+
+// ============================================================================
+// Constants
+// ============================================================================
+
+const KEYS = ['tool.assistant_id', 'tool.assistant_version'];
+
+// ============================================================================
+// Default Options
+// ============================================================================
+
+export const GetHandoffAssistantDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  const metadata: Metadata[] = [];
+
+  const addMetadata = (key: string, defaultValue?: string) => {
+    const meta = SetMetadata(current, key, defaultValue);
+    if (meta) metadata.push(meta);
+  };
+
+  addMetadata('tool.assistant_id');
+  addMetadata('tool.assistant_version', 'latest');
+
+  return metadata.filter(m => KEYS.includes(m.getKey()));
+};
+
+// ============================================================================
+// Validation
+// ============================================================================
+
+export const ValidateHandoffAssistantDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  const assistantId = options
+    .find(opt => opt.getKey() === 'tool.assistant_id')
+    ?.getValue();
+  if (!assistantId || !/^\d+$/.test(assistantId.trim())) {
+    return 'Please provide the id of the assistant to hand the conversation to in tool.assistant_id.';
+  }
+
+  const version = options
+    .find(opt => opt.getKey() === 'tool.assistant_version')
+    ?.getValue()
+    ?.trim();
+  if (version && version !== 'latest' && !/^vrsn_\d+$/.test(version)) {
+    return 'Please provide tool.assistant_version as latest or a version such as vrsn_123.';
+  }
+  return undefined;
+};
@@ -0,0 +1,75 @@
+import { FC } from 'react';
+import { cn } from '@/utils';
+import { FieldSet } from '@/app/components/form/fieldset';
+import { FormLabel } from '@/app/components/form-label';
+import { Input } from '@/app/components/form/input';
+import { InputGroup } from '@/app/components/input-group';
+import { InputHelper } from '@/app/components/input-helper';
+import {
+  ConfigureToolProps,
+  ToolDefinitionForm,
+  useParameterManager,
+} from '../common';
+
+// ============================================================================
+// Main Component
+// ============================================================================
+
+export const ConfigureHandoffAssistant: FC<ConfigureToolProps> = ({
+  toolDefinition,
+  onChangeToolDefinition,
+  onParameterChange,
+  parameters,
+  inputClass,
+}) => {
+  const { getParamValue, updateParameter } = useParameterManager(
+    parameters,
+    onParameterChange,
+  );
+
+  return (
+    <>
+      <InputGroup title="Action Definition">
+        <div className="flex flex-col gap-8 max-w-6xl">
+          <div className="flex space-x-2">
+            <FieldSet className="relative w-full">
+              <FormLabel>Assistant ID</FormLabel>
+              <Input
+                value={getParamValue('tool.assistant_id')}
+                onChange={e =>
+                  updateParameter('tool.assistant_id', e.target.value)
+                }
+                placeholder="2214873389302812672"
+                className={cn('bg-light-background', inputClass)}
+              />
+              <InputHelper>
+                The assistant that takes over the conversation. The call and
+                the transcript stay, its prompt, tools and voice are used from
+                then on.
+              </InputHelper>
+            </FieldSet>
+            <FieldSet className="relative w-60">
+              <FormLabel>Version</FormLabel>
+              <Input
+                value={getParamValue('tool.assistant_version')}
+                onChange={e =>
+                  updateParameter('tool.assistant_version', e.target.value)
+                }
+                placeholder="latest"
+                className={cn('bg-light-background', inputClass)}
+              />
+            </FieldSet>
+          </div>
+        </div>
+      </InputGroup>
+
+      {toolDefinition && onChangeToolDefinition && (
+        <ToolDefinitionForm
+          toolDefinition={toolDefinition}
+          onChangeToolDefinition={onChangeToolDefinition}
+          inputClass={inputClass}
+        />
+      )}
+    </>
+  );
+};
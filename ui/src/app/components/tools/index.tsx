@@ -30,12 +30,66 @@ import {
   GetMCPDefaultOptions,
   ValidateMCPDefaultOptions,
 } from '@/app/components/tools/mcp/constant';
+import { ConfigureSendDTMF } from '@/app/components/tools/send-dtmf';
+import {
+  GetSendDTMFDefaultOptions,
+  ValidateSendDTMFDefaultOptions,
+} from '@/app/components/tools/send-dtmf/constant';
+import { ConfigurePutOnHold } from '@/app/components/tools/put-on-hold';
+import {
+  GetPutOnHoldDefaultOptions,
+  ValidatePutOnHoldDefaultOptions,
+} from '@/app/components/tools/put-on-hold/constant';
+import { ConfigureScratchpad } from '@/app/components/tools/scratchpad';
+import {
+  GetScratchpadDefaultOptions,
+  ValidateScratchpadDefaultOptions,
+} from '@/app/components/tools/scratchpad/constant';
+import { ConfigureConversationMetadata } from '@/app/components/tools/conversation-metadata';
+import {
+  GetConversationMetadataDefaultOptions,
+  ValidateConversationMetadataDefaultOptions,
+} from '@/app/components/tools/conversation-metadata/constant';
+import { ConfigureDictationMode } from '@/app/components/tools/dictation-mode';
+import {
+  GetDictationModeDefaultOptions,
+  ValidateDictationModeDefaultOptions,
+} from '@/app/components/tools/dictation-mode/constant';
+import { ConfigureSpeakingProfile } from '@/app/components/tools/speaking-profile';
+import {
+  GetSpeakingProfileDefaultOptions,
+  ValidateSpeakingProfileDefaultOptions,
+} from '@/app/components/tools/speaking-profile/constant';
+import { ConfigureSpellingMode } from '@/app/components/tools/spelling-mode';
+import {
+  GetSpellingModeDefaultOptions,
+  ValidateSpellingModeDefaultOptions,
+} from '@/app/components/tools/spelling-mode/constant';
+import { ConfigureTransferCall } from '@/app/components/tools/transfer-call';
+import {
+  GetTransferCallDefaultOptions,
+  ValidateTransferCallDefaultOptions,
+} from '@/app/components/tools/transfer-call/constant';
+import { ConfigureHandoffAssistant } from '@/app/components/tools/handoff-assistant';
+import {
+  GetHandoffAssistantDefaultOptions,
+  ValidateHandoffAssistantDefaultOptions,
+} from '@/app/components/tools/handoff-assistant/constant';
 import {
   APIRequestToolDefintion,
   BUILDIN_TOOLS,
+  ConversationMetadataToolDefinition,
+  DictationModeToolDefinition,
   EndOfConverstaionToolDefintion,
   EndpointToolDefintion,
+  HandoffAssistantToolDefinition,
   KnowledgeRetrievalToolDefintion,
+  PutOnHoldToolDefinition,
+  ScratchpadToolDefinition,
+  SendDTMFToolDefinition,
+  SpeakingProfileToolDefinition,
+  SpellingModeToolDefinition,
+  TransferCallToolDefinition,
 } from '@/llm-tools';
 import { ConfigureToolProps } from './common';
 
@@ -48,6 +102,15 @@ export type ToolCode =
   | 'api_request'
   | 'endpoint'
   | 'end_of_conversation'
+  | 'send_dtmf'
+  | 'put_on_hold'
+  | 'spelling_mode'
+  | 'dictation_mode'
+  | 'speaking_profile'
+  | 'scratchpad'
+  | 'conversation_metadata'
+  | 'transfer_call'
+  | 'handoff_assistant'
   | 'mcp';
 
 export interface ToolDefinition {
@@ -104,6 +167,60 @@ const TOOL_REGISTRY: Record<ToolCode, ToolConfig> = {
     validateOptions: ValidateEndOfConversationDefaultOptions,
     Component: ConfigureEndOfConversation,
   },
+  send_dtmf: {
+    definition: SendDTMFToolDefinition,
+    getDefaultOptions: GetSendDTMFDefaultOptions,
+    validateOptions: ValidateSendDTMFDefaultOptions,
+    Component: ConfigureSendDTMF,
+  },
+  put_on_hold: {
+    definition: PutOnHoldToolDefinition,
+    getDefaultOptions: GetPutOnHoldDefaultOptions,
+    validateOptions: ValidatePutOnHoldDefaultOptions,
+    Component: ConfigurePutOnHold,
+  },
+  spelling_mode: {
+    definition: SpellingModeToolDefinition,
+    getDefaultOptions: GetSpellingModeDefaultOptions,
+    validateOptions: ValidateSpellingModeDefaultOptions,
+    Component: ConfigureSpellingMode,
+  },
+  dictation_mode: {
+    definition: DictationModeToolDefinition,
+    getDefaultOptions: GetDictationModeDefaultOptions,
+    validateOptions: ValidateDictationModeDefaultOptions,
+    Component: ConfigureDictationMode,
+  },
+  speaking_profile: {
+    definition: SpeakingProfileToolDefinition,
+    getDefaultOptions: GetSpeakingProfileDefaultOptions,
+    validateOptions: ValidateSpeakingProfileDefaultOptions,
+    Component: ConfigureSpeakingProfile,
+  },
+  scratchpad: {
+    definition: ScratchpadToolDefinition,
+    getDefaultOptions: GetScratchpadDefaultOptions,
+    validateOptions: ValidateScratchpadDefaultOptions,
+    Component: ConfigureScratchpad,
+  },
+  conversation_metadata: {
+    definition: ConversationMetadataToolDefinition,
+    getDefaultOptions: GetConversationMetadataDefaultOptions,
+    validateOptions: ValidateConversationMetadataDefaultOptions,
+    Component: ConfigureConversationMetadata,
+  },
+  transfer_call: {
+    definition: TransferCallToolDefinition,
+    getDefaultOptions: GetTransferCallDefaultOptions,
+    validateOptions: ValidateTransferCallDefaultOptions,
+    Component: ConfigureTransferCall,
+  },
+  handoff_assistant: {
+    definition: HandoffAssistantToolDefinition,
+    getDefaultOptions: GetHandoffAssistantDefaultOptions,
+    validateOptions: ValidateHandoffAssistantDefaultOptions,
+    Component: ConfigureHandoffAssistant,
+  },
   mcp: {
     // MCP tools don't have a static definition - resolved dynamically at runtime
     definition: undefined,
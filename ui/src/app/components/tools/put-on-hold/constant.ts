@@ -0,0 +1,11 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetPutOnHoldDefaultOptions = (current: Metadata[]): Metadata[] => {
+  return [];
+};
+
+export const ValidatePutOnHoldDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
@@ -0,0 +1,24 @@
+import { FC } from 'react';
+import { ConfigureToolProps, ToolDefinitionForm } from '../common';
+
+// ============================================================================
+// Main Component
+// ============================================================================
+
+export const ConfigurePutOnHold: FC<ConfigureToolProps> = ({
+  inputClass,
+  toolDefinition,
+  onChangeToolDefinition,
+}) => (
+  <>
+    {toolDefinition && onChangeToolDefinition && (
+      <ToolDefinitionForm
+        toolDefinition={toolDefinition}
+        onChangeToolDefinition={onChangeToolDefinition}
+        inputClass={inputClass}
+        documentationUrl="https://doc.rapida.ai/assistants/tools/add-put-on-hold-tool"
+        documentationTitle="Know more about putting callers on hold that can be supported by rapida"
+      />
+    )}
+  </>
+);
@@ -0,0 +1,13 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetScratchpadDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  return [];
+};
+
+export const ValidateScratchpadDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
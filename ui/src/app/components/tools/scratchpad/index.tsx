@@ -0,0 +1,24 @@
+import { FC } from 'react';
+import { ConfigureToolProps, ToolDefinitionForm } from '../common';
+
+// ============================================================================
+// Main Component
+// ============================================================================
+
+export const ConfigureScratchpad: FC<ConfigureToolProps> = ({
+  inputClass,
+  toolDefinition,
+  onChangeToolDefinition,
+}) => (
+  <>
+    {toolDefinition && onChangeToolDefinition && (
+      <ToolDefinitionForm
+        toolDefinition={toolDefinition}
+        onChangeToolDefinition={onChangeToolDefinition}
+        inputClass={inputClass}
+        documentationUrl="https://doc.rapida.ai/assistants/tools/add-scratchpad-tool"
+        documentationTitle="Know more about the scratchpad that can be supported by rapida"
+      />
+    )}
+  </>
+);
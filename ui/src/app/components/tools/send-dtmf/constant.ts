@@ -0,0 +1,11 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetSendDTMFDefaultOptions = (current: Metadata[]): Metadata[] => {
+  return [];
+};
+
+export const ValidateSendDTMFDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
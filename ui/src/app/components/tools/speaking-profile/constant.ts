@@ -0,0 +1,13 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetSpeakingProfileDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  return [];
+};
+
+export const ValidateSpeakingProfileDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
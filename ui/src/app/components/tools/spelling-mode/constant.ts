@@ -0,0 +1,13 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetSpellingModeDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  return [];
+};
+
+export const ValidateSpellingModeDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
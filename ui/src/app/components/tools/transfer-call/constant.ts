@@ -0,0 +1,13 @@
+import { Metadata } from '@rapidaai/react';
+
+export const GetTransferCallDefaultOptions = (
+  current: Metadata[],
+): Metadata[] => {
+  return [];
+};
+
+export const ValidateTransferCallDefaultOptions = (
+  options: Metadata[],
+): string | undefined => {
+  return undefined;
+};
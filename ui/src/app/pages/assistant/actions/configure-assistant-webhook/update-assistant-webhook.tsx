@@ -46,6 +46,33 @@ const webhookEvents = [
     description: 'Triggered when a conversation fails.',
     category: 'Conversation',
   },
+  {
+    id: 'conversation.degraded',
+    name: 'conversation.degraded',
+    description:
+      'Triggered when text to speech is down and recorded prompts are played instead.',
+    category: 'Conversation',
+  },
+  {
+    id: 'conversation.transferring',
+    name: 'conversation.transferring',
+    description:
+      'Triggered when the call is transferred to a human, with the caller, intent and collected slots for a screen-pop.',
+    category: 'Conversation',
+  },
+  {
+    id: 'transcript.interim',
+    name: 'transcript.interim',
+    description:
+      'Streams what the user and the assistant are saying while the conversation runs.',
+    category: 'Transcript',
+  },
+  {
+    id: 'transcript.final',
+    name: 'transcript.final',
+    description: 'Streams every completed message while the conversation runs.',
+    category: 'Transcript',
+  },
 ];
 
 export const UpdateAssistantWebhook: FC<{ assistantId: string }> = ({
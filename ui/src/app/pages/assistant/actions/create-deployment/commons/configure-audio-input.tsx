@@ -136,6 +136,20 @@ export const ConfigureAudioInputProvider: React.FC<
             onChangeEndOfSepeechTimeout={(timeout: string) => {
               updateParameter('microphone.eos.timeout', timeout);
             }}
+            speechFinalDelay={getParamValue(
+              'microphone.eos.speech_final_delay_ms',
+              '300',
+            )}
+            onChangeSpeechFinalDelay={(delay: string) => {
+              updateParameter('microphone.eos.speech_final_delay_ms', delay);
+            }}
+            maxTurnSilence={getParamValue(
+              'microphone.eos.max_turn_silence_ms',
+              '2000',
+            )}
+            onChangeMaxTurnSilence={(silence: string) => {
+              updateParameter('microphone.eos.max_turn_silence_ms', silence);
+            }}
           />
         </>
       )}
@@ -24,6 +24,46 @@ export const BUILDIN_TOOLS = [
     code: 'end_of_conversation',
     name: 'End of conversation',
   },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'send_dtmf',
+    name: 'Send DTMF',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'spelling_mode',
+    name: 'Spelling mode',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'dictation_mode',
+    name: 'Dictation mode',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'speaking_profile',
+    name: 'Speaking profile',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'scratchpad',
+    name: 'Scratchpad',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'conversation_metadata',
+    name: 'Conversation metadata',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'transfer_call',
+    name: 'Transfer call',
+  },
+  {
+    icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
+    code: 'handoff_assistant',
+    name: 'Hand off to assistant',
+  },
   {
     icon: 'https://cdn-01.rapida.ai/partners/tools/api_call.png',
     code: 'mcp',
@@ -125,6 +165,215 @@ export const EndOfConverstaionToolDefintion = {
   ),
 };
 
+export const SendDTMFToolDefinition = {
+  name: 'send_dtmf',
+  description:
+    'Call this function to press keys on the phone keypad, for example to navigate an automated phone menu.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        digits: {
+          description:
+            "Keys to press in order, using 0-9, '*', '#' and A-D, such as '2' or '1234#'.",
+          type: 'string',
+        },
+      },
+      required: ['digits'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const PutOnHoldToolDefinition = {
+  name: 'put_on_hold',
+  description:
+    'Call this function to put the caller on hold with hold music, for example before a lookup that takes a while, and again with action resume to take them off hold before speaking with them.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        action: {
+          description:
+            "'hold' to put the caller on hold, 'resume' to take them off hold.",
+          enum: ['hold', 'resume'],
+          type: 'string',
+        },
+      },
+      required: ['action'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const SpellingModeToolDefinition = {
+  name: 'capture_spelling',
+  description:
+    'Call this function before asking the user to spell an email address or an alphanumeric ID, such as a booking reference, so it is captured letter by letter.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        kind: {
+          description: 'Kind of value the user will spell.',
+          enum: ['email', 'alphanumeric'],
+          type: 'string',
+        },
+        pattern: {
+          description:
+            "Optional regular expression the value must match, such as '^[A-Z]{2}[0-9]{6}$' for a booking reference.",
+          type: 'string',
+        },
+      },
+      required: ['kind'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const ScratchpadToolDefinition = {
+  name: 'scratchpad',
+  description:
+    'Use this tool to remember facts collected during the conversation, such as a verified account or the chosen plan, and to read them back later. The current scratchpad is also included in the prompt.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        action: {
+          description: 'What to do with the key.',
+          enum: ['get', 'set', 'delete'],
+          type: 'string',
+        },
+        key: {
+          description: "Name of the entry, such as 'account_id'.",
+          type: 'string',
+        },
+        value: {
+          description: 'Value to store when the action is set.',
+          type: 'string',
+        },
+      },
+      required: ['action', 'key'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const ConversationMetadataToolDefinition = {
+  name: 'add_conversation_metadata',
+  description:
+    'Use this tool to record an outcome of the conversation, such as the order ID it was about or the resolution code, on the call record. Recorded values are sent to the webhooks when the conversation ends.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        key: {
+          description:
+            "Name of the entry, letters, digits, '_', '-' and '.' only, such as 'order_id'.",
+          type: 'string',
+        },
+        value: {
+          description: "Value to record, such as 'ORD-1042'.",
+          type: 'string',
+        },
+      },
+      required: ['key', 'value'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const DictationModeToolDefinition = {
+  name: 'start_dictation',
+  description:
+    'Call this function when the user wants to give a long statement, such as complaint details or a note, without being interrupted. Their speech is captured until they say "end dictation" and then handed to you in full.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        max_duration_seconds: {
+          description:
+            'Optional limit for the dictation in seconds, 300 by default and at most 1800.',
+          type: 'number',
+        },
+      },
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const SpeakingProfileToolDefinition = {
+  name: 'set_speaking_profile',
+  description:
+    'Call this function to change how you speak for the rest of the call, for example a slower profile with more confirmations when the caller has trouble following. Leave the profile empty to go back to how you spoke at the start of the call.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        profile: {
+          description:
+            'Name of the speaking profile configured on the voice of the assistant.',
+          type: 'string',
+        },
+      },
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const TransferCallToolDefinition = {
+  name: 'transfer_call',
+  description:
+    'Call this function to hand the caller over to a human agent. The agent hears a short summary of the conversation before the caller is connected. Tell the caller they are being transferred before calling it.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        to: {
+          description:
+            "Phone number or SIP URI of the human agent, such as '+14155550100'.",
+          type: 'string',
+        },
+        reason: {
+          description: 'Why the caller needs a human agent.',
+          type: 'string',
+        },
+      },
+      required: ['to'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
+export const HandoffAssistantToolDefinition = {
+  name: 'handoff_to_specialist',
+  description:
+    'Call this function to hand the conversation to a specialist assistant when the user needs help it handles. The specialist continues the same call with the conversation so far. Tell the user they are being connected before calling it.',
+  parameters: JSON.stringify(
+    {
+      properties: {
+        reason: {
+          description:
+            'Why the conversation is handed over and what the user needs, in one sentence.',
+          type: 'string',
+        },
+      },
+      required: ['reason'],
+      type: 'object',
+    },
+    null,
+    2,
+  ),
+};
+
 export const EndpointToolDefintion = {
   name: 'llm_call',
   description:
@@ -30,6 +30,8 @@ export const PRONUNCIATION_DICTIONARIES = [
   'time',
   'numeral',
   'address',
+  'phone-number',
+  'email',
   'url',
   'tech-abbreviation',
   'role-abbreviation',